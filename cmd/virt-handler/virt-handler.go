@@ -89,6 +89,10 @@ const (
 	maxDevices = 110
 
 	maxRequestsInFlight = 3
+
+	// Default number of containerDisks which are prepared at the same time on the node
+	maxParallelContainerDiskPreparations = 4
+
 	// Default port that virt-handler listens to console requests
 	defaultConsoleServerPort = 8186
 
@@ -107,16 +111,17 @@ const (
 
 type virtHandlerApp struct {
 	service.ServiceListen
-	HostOverride              string
-	PodIpAddress              string
-	VirtShareDir              string
-	VirtPrivateDir            string
-	VirtLibDir                string
-	KubeletPodsDir            string
-	WatchdogTimeoutDuration   time.Duration
-	MaxDevices                int
-	MaxRequestsInFlight       int
-	domainResyncPeriodSeconds int
+	HostOverride                         string
+	PodIpAddress                         string
+	VirtShareDir                         string
+	VirtPrivateDir                       string
+	VirtLibDir                           string
+	KubeletPodsDir                       string
+	WatchdogTimeoutDuration              time.Duration
+	MaxDevices                           int
+	MaxRequestsInFlight                  int
+	MaxParallelContainerDiskPreparations int
+	domainResyncPeriodSeconds            int
 
 	caConfigMapName    string
 	clientCertFilePath string
@@ -297,6 +302,7 @@ func (app *virtHandlerApp) Run() {
 	podIsolationDetector := isolation.NewSocketBasedIsolationDetector(app.VirtShareDir)
 	vmiInformer := factory.VMI()
 	app.clusterConfig = virtconfig.NewClusterConfig(factory.ConfigMap(), factory.CRD(), factory.KubeVirt(), app.namespace)
+	app.clusterConfig.SetConfigModifiedCallback(app.updateLogVerbosity)
 
 	vmController := virthandler.NewController(
 		recorder,
@@ -311,6 +317,7 @@ func (app *virtHandlerApp) Run() {
 		gracefulShutdownInformer,
 		int(app.WatchdogTimeoutDuration.Seconds()),
 		app.MaxDevices,
+		app.MaxParallelContainerDiskPreparations,
 		app.clusterConfig,
 		app.serverTLSConfig,
 		app.clientTLSConfig,
@@ -330,6 +337,13 @@ func (app *virtHandlerApp) Run() {
 		app.VirtShareDir,
 	)
 
+	profileHandler := rest.NewProfileHandler(
+		app.clusterConfig,
+		vmSourceSharedInformer,
+	)
+
+	networkDryRunHandler := rest.NewNetworkDryRunHandler(app.clusterConfig)
+
 	promvm.SetupCollector(app.virtCli, app.VirtShareDir, app.HostOverride, app.MaxRequestsInFlight)
 
 	go app.clientcertmanager.Start()
@@ -365,12 +379,20 @@ func (app *virtHandlerApp) Run() {
 	go vmController.Run(10, stop)
 
 	errCh := make(chan error)
-	go app.runServer(errCh, consoleHandler, lifecycleHandler)
+	go app.runServer(errCh, consoleHandler, lifecycleHandler, profileHandler, networkDryRunHandler)
 
 	// wait for one of the servers to exit
 	fmt.Println(<-errCh)
 }
 
+// updateLogVerbosity applies the virt-handler log verbosity of the cluster config
+func (app *virtHandlerApp) updateLogVerbosity() {
+	verbosity := int(app.clusterConfig.GetVirtHandlerVerbosity())
+	if err := log.Log.SetVerbosityLevel(verbosity); err != nil {
+		log.Log.Reason(err).Errorf("Failed to set the log verbosity of virt-handler to %d", verbosity)
+	}
+}
+
 func (app *virtHandlerApp) runPrometheusServer(errCh chan error) {
 	mux := restful.NewContainer()
 	webService := new(restful.WebService)
@@ -387,15 +409,23 @@ func (app *virtHandlerApp) runPrometheusServer(errCh chan error) {
 	errCh <- server.ListenAndServeTLS("", "")
 }
 
-func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.ConsoleHandler, lifecycleHandler *rest.LifecycleHandler) {
+func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.ConsoleHandler, lifecycleHandler *rest.LifecycleHandler, profileHandler *rest.ProfileHandler, networkDryRunHandler *rest.NetworkDryRunHandler) {
 	ws := new(restful.WebService)
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/console").To(consoleHandler.SerialHandler))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/vnc").To(consoleHandler.VNCHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/channel/{channel}").To(consoleHandler.ChannelHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/hypervisorlog").To(consoleHandler.HypervisorLogHandler))
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/pause").To(lifecycleHandler.PauseHandler))
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/unpause").To(lifecycleHandler.UnpauseHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/freeze").To(lifecycleHandler.FreezeHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/unfreeze").To(lifecycleHandler.UnfreezeHandler))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guestosinfo").To(lifecycleHandler.GetGuestInfo).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/userlist").To(lifecycleHandler.GetUsers).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestOSUserList{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/filesystemlist").To(lifecycleHandler.GetFilesystems).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/qmp").To(lifecycleHandler.QMPCommandHandler).Produces(restful.MIME_JSON))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/stats").To(lifecycleHandler.GetStats).Produces(restful.MIME_JSON))
+	ws.Route(ws.GET("/v1/debug/profile").To(profileHandler.BundleHandler).Produces("application/gzip"))
+	ws.Route(ws.POST("/v1/debug/network/plugdryrun").To(networkDryRunHandler.PlugHandler).Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON))
 	restful.DefaultContainer.Add(ws)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", app.ServiceListen.BindAddress, app.consoleServerPort),
@@ -462,6 +492,9 @@ func (app *virtHandlerApp) AddFlags() {
 	flag.IntVar(&app.MaxRequestsInFlight, "max-metric-requests", maxRequestsInFlight,
 		"Number of concurrent requests to the metrics endpoint")
 
+	flag.IntVar(&app.MaxParallelContainerDiskPreparations, "max-parallel-container-disk-preparations", maxParallelContainerDiskPreparations,
+		"Number of containerDisks of all VMIs on the node which are mounted and verified at the same time")
+
 	flag.IntVar(&app.consoleServerPort, "console-server-port", defaultConsoleServerPort,
 		"The port virt-handler listens on for console requests")
 
@@ -26,6 +26,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -46,6 +47,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	"kubevirt.io/kubevirt/pkg/profiler"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	virtlauncher "kubevirt.io/kubevirt/pkg/virt-launcher"
 	notifyclient "kubevirt.io/kubevirt/pkg/virt-launcher/notify-client"
@@ -54,6 +56,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	virtcli "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	cmdserver "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cmd-server"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
@@ -317,10 +320,13 @@ func main() {
 	noFork := pflag.Bool("no-fork", false, "Fork and let virt-launcher watch itself to react to crashes if set to false")
 	lessPVCSpaceToleration := pflag.Int("less-pvc-space-toleration", 0, "Toleration in percent when PVs' available space is smaller than requested")
 	ovmfPath := pflag.String("ovmf-path", "/usr/share/OVMF", "The directory that contains the EFI roms (like OVMF_CODE.fd)")
+	emulator := pflag.String("emulator", "", "Name of an alternative qemu build to use as emulator instead of the default one")
 	qemuAgentSysInterval := pflag.Duration("qemu-agent-sys-interval", 120, "Interval in seconds between consecutive qemu agent calls for sys commands")
 	qemuAgentFileInterval := pflag.Duration("qemu-agent-file-interval", 300, "Interval in seconds between consecutive qemu agent calls for file command")
 	qemuAgentUserInterval := pflag.Duration("qemu-agent-user-interval", 10, "Interval in seconds between consecutive qemu agent calls for user command")
 	qemuAgentVersionInterval := pflag.Duration("qemu-agent-version-interval", 300, "Interval in seconds between consecutive qemu agent calls for version command")
+	profiling := pflag.Bool("profiling", false, "Serve the pprof profiles and the trace of virt-launcher to virt-handler")
+	networkCacheVersioning := pflag.Bool("network-cache-versioning", false, "Write the network cache files in the versioned format")
 	// set new default verbosity, was set to 0 by glog
 	goflag.Set("v", "2")
 
@@ -329,6 +335,20 @@ func main() {
 
 	log.InitializeLogging("virt-launcher")
 
+	if verbosityStr, ok := os.LookupEnv("VIRT_LAUNCHER_LOG_VERBOSITY"); ok {
+		verbosity, err := strconv.Atoi(verbosityStr)
+		if err == nil {
+			err = log.Log.SetVerbosityLevel(verbosity)
+		}
+		if err != nil {
+			log.Log.Reason(err).Warningf("Ignoring invalid log verbosity %q", verbosityStr)
+		}
+	}
+
+	if *networkCacheVersioning {
+		network.EnableVersionedCacheFiles()
+	}
+
 	if !*noFork {
 		exitCode, err := ForkAndMonitor(*containerDiskDir)
 		if err != nil {
@@ -370,7 +390,7 @@ func main() {
 	notifier := notifyclient.NewNotifier(*virtShareDir)
 	defer notifier.Close()
 
-	domainManager, err := virtwrap.NewLibvirtDomainManager(domainConn, *virtShareDir, notifier, *lessPVCSpaceToleration, &agentStore, *ovmfPath)
+	domainManager, err := virtwrap.NewLibvirtDomainManager(domainConn, *virtShareDir, notifier, *lessPVCSpaceToleration, &agentStore, *ovmfPath, *emulator)
 	if err != nil {
 		panic(err)
 	}
@@ -382,6 +402,14 @@ func main() {
 	cmdclient.SetLegacyBaseDir(*virtShareDir)
 	cmdServerDone := startCmdServer(cmdclient.UninitializedSocketOnGuest(), domainManager, stopChan, options)
 
+	if *profiling {
+		go func() {
+			if err := profiler.Serve(cmdclient.ProfilerSocketOnGuest(), stopChan); err != nil {
+				log.Log.Reason(err).Error("Failed to serve the profiles of virt-launcher")
+			}
+		}()
+	}
+
 	gracefulShutdownCallback := func() {
 		err := wait.PollImmediate(time.Second, 15*time.Second, func() (bool, error) {
 			err := domainManager.MarkGracefulShutdownVMI(vm)
@@ -11,8 +11,10 @@ rm -rf ${KUBEVIRT_DIR}/staging/src/${CLIENT_GEN_BASE}
 
 # KubeVirt stuff
 swagger-doc -in ${KUBEVIRT_DIR}/staging/src/kubevirt.io/client-go/apis/snapshot/v1alpha1/types.go
+swagger-doc -in ${KUBEVIRT_DIR}/staging/src/kubevirt.io/client-go/apis/vmimport/v1alpha1/types.go
+swagger-doc -in ${KUBEVIRT_DIR}/staging/src/kubevirt.io/client-go/apis/template/v1alpha1/types.go
 
-deepcopy-gen --input-dirs kubevirt.io/client-go/apis/snapshot/v1alpha1 \
+deepcopy-gen --input-dirs kubevirt.io/client-go/apis/snapshot/v1alpha1,kubevirt.io/client-go/apis/vmimport/v1alpha1,kubevirt.io/client-go/apis/template/v1alpha1 \
     --bounding-dirs kubevirt.io/client-go/apis \
     --go-header-file ${KUBEVIRT_DIR}/hack/boilerplate/boilerplate.go.txt
 
@@ -30,9 +32,20 @@ else
     exit 2
 fi
 
+# core, meta and v1 rule violations are already checked above
+openapi-gen --input-dirs kubevirt.io/client-go/apis/vmimport/v1alpha1,k8s.io/api/core/v1,k8s.io/apimachinery/pkg/apis/meta/v1,kubevirt.io/client-go/api/v1 \
+    --output-base ${KUBEVIRT_DIR}/staging/src \
+    --output-package kubevirt.io/client-go/apis/vmimport/v1alpha1 \
+    --go-header-file ${KUBEVIRT_DIR}/hack/boilerplate/boilerplate.go.txt >/dev/null
+
+openapi-gen --input-dirs kubevirt.io/client-go/apis/template/v1alpha1,k8s.io/api/core/v1,k8s.io/apimachinery/pkg/apis/meta/v1,kubevirt.io/client-go/api/v1 \
+    --output-base ${KUBEVIRT_DIR}/staging/src \
+    --output-package kubevirt.io/client-go/apis/template/v1alpha1 \
+    --go-header-file ${KUBEVIRT_DIR}/hack/boilerplate/boilerplate.go.txt >/dev/null
+
 client-gen --clientset-name versioned \
     --input-base kubevirt.io/client-go/apis \
-    --input snapshot/v1alpha1 \
+    --input snapshot/v1alpha1,vmimport/v1alpha1,template/v1alpha1 \
     --output-base ${KUBEVIRT_DIR}/staging/src \
     --output-package ${CLIENT_GEN_BASE}/kubevirt/clientset \
     --go-header-file ${KUBEVIRT_DIR}/hack/boilerplate/boilerplate.go.txt
@@ -77,6 +90,8 @@ ${KUBEVIRT_DIR}/hack/build-go.sh generate ${WHAT}
         GOFLAGS= controller-gen crd:allowDangerousTypes=true paths=./api/v1/
     #include snapshot
     GOFLAGS= controller-gen crd paths=./apis/snapshot/v1alpha1/
+    GOFLAGS= controller-gen crd paths=./apis/vmimport/v1alpha1/
+    GOFLAGS= controller-gen crd paths=./apis/template/v1alpha1/
 
     #remove some weird stuff from controller-gen
     cd config/crd
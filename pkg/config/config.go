@@ -56,6 +56,10 @@ var (
 	SecretSourceDir = mountBaseDir + "/secret"
 	// DownwardAPISourceDir represents a location where downwardapi is attached to the pod
 	DownwardAPISourceDir = mountBaseDir + "/downwardapi"
+	// DiskEncryptionSourceDir represents a location where the Secrets holding disk encryption keys are attached to the pod
+	DiskEncryptionSourceDir = mountBaseDir + "/disk-encryption"
+	// HibernationSourceDir represents a location where the PersistentVolumeClaim holding the memory of a hibernated VMI is attached to the pod
+	HibernationSourceDir = mountBaseDir + "/hibernation"
 	// ServiceAccountSourceDir represents the location where the ServiceAccount token is attached to the pod
 	ServiceAccountSourceDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
 
@@ -26,11 +26,19 @@ import (
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 )
 
+// DiskEncryptionSecretKey is the key of the LUKS passphrase in the Secret referenced by an encrypted volume
+const DiskEncryptionSecretKey = "key"
+
 // GetSecretSourcePath returns a path to Secret mounted on a pod
 func GetSecretSourcePath(volumeName string) string {
 	return filepath.Join(SecretSourceDir, volumeName)
 }
 
+// GetDiskEncryptionKeyPath returns a path to the LUKS passphrase of an encrypted volume
+func GetDiskEncryptionKeyPath(volumeName string) string {
+	return filepath.Join(DiskEncryptionSourceDir, volumeName, DiskEncryptionSecretKey)
+}
+
 // GetSecretDiskPath returns a path to Secret iso image created based on volume name
 func GetSecretDiskPath(volumeName string) string {
 	return filepath.Join(SecretDisksDir, volumeName+".iso")
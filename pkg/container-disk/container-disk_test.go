@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
@@ -233,6 +234,38 @@ var _ = Describe("ContainerDisk", func() {
 					Expect(path2).To(Equal(fmt.Sprintf("%s/pods/%s/volumes/kubernetes.io~empty-dir/container-disks/disk_1.sock", tmpDir, "poduid")))
 				})
 			})
+
+			Context("which verifies checksums", func() {
+				const diskChecksum = "sha256:45e56191345e1c63b2ad5aeffc99513bd055b5f4ff2819fdaad7942060ad324a"
+
+				BeforeEach(func() {
+					Expect(ioutil.WriteFile(GetDiskTargetPathFromLauncherView(1), []byte("disk content"), 0644)).To(Succeed())
+				})
+
+				table.DescribeTable("should validate the checksum format", func(checksum string, valid bool) {
+					err := ValidateChecksum(checksum)
+					if valid {
+						Expect(err).ToNot(HaveOccurred())
+					} else {
+						Expect(err).To(HaveOccurred())
+					}
+				},
+					table.Entry("with a sha256 digest", diskChecksum, true),
+					table.Entry("without an algorithm", diskChecksum[len("sha256:"):], false),
+					table.Entry("with an unsupported algorithm", "md5:"+diskChecksum[len("sha256:"):], false),
+					table.Entry("with a truncated digest", diskChecksum[:20], false),
+					table.Entry("with upper case hex", strings.ToUpper(diskChecksum), false),
+				)
+
+				It("should succeed if the digest matches", func() {
+					Expect(VerifyChecksum(GetDiskTargetPathFromLauncherView(1), diskChecksum)).To(Succeed())
+				})
+
+				It("should fail if the digest differs", func() {
+					err := VerifyChecksum(GetDiskTargetPathFromLauncherView(1), "sha256:"+strings.Repeat("0", 64))
+					Expect(err).To(MatchError(ContainSubstring(ChecksumMismatch)))
+				})
+			})
 		})
 	})
 })
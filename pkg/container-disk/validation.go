@@ -1,13 +1,23 @@
 package containerdisk
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"regexp"
 )
 
 const (
 	DiskSourceFallbackPath = "/disk"
+
+	// ChecksumMismatch is part of the error reported for disks which differ from their expected checksum
+	ChecksumMismatch = "checksum mismatch"
 )
 
+var checksumPattern = regexp.MustCompile("^sha256:[0-9a-f]{64}$")
+
 type DiskInfo struct {
 	Format      string `json:"format"`
 	BackingFile string `json:"backing-filename"`
@@ -36,3 +46,35 @@ func VerifyImage(diskInfo *DiskInfo) error {
 		return fmt.Errorf("unsupported image format: %v", diskInfo.Format)
 	}
 }
+
+// ValidateChecksum checks that the checksum has the form sha256:<hex>
+func ValidateChecksum(checksum string) error {
+	if !checksumPattern.MatchString(checksum) {
+		return fmt.Errorf("checksum %q is not of the form sha256:<hex>", checksum)
+	}
+	return nil
+}
+
+// VerifyChecksum computes the digest of the file at path and compares it with the expected checksum
+func VerifyChecksum(path string, checksum string) error {
+	if err := ValidateChecksum(checksum); err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	digest := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	if digest != checksum {
+		return fmt.Errorf("%s: expected %s, got %s", ChecksumMismatch, checksum, digest)
+	}
+	return nil
+}
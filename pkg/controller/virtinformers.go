@@ -26,6 +26,7 @@ import (
 	"time"
 
 	promv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
 	secv1 "github.com/openshift/api/security/v1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
@@ -50,6 +51,7 @@ import (
 
 	kubev1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
@@ -76,6 +78,12 @@ type KubeInformerFactory interface {
 	// Watches for VirtualMachineInstancePreset objects
 	VirtualMachinePreset() cache.SharedIndexInformer
 
+	// Watches for VirtualMachineNotificationHook objects
+	VirtualMachineNotificationHook() cache.SharedIndexInformer
+
+	// Watches for FencingRequest objects in the kubevirt namespace
+	FencingRequest() cache.SharedIndexInformer
+
 	// Watches for pods related only to kubevirt
 	KubeVirtPod() cache.SharedIndexInformer
 
@@ -94,9 +102,21 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineSnapshot objects
 	VirtualMachineSnapshotContent() cache.SharedIndexInformer
 
+	// Watches VirtualMachineSnapshotSchedule objects
+	VirtualMachineSnapshotSchedule() cache.SharedIndexInformer
+
 	// Watches VirtualMachineRestore objects
 	VirtualMachineRestore() cache.SharedIndexInformer
 
+	// Watches VirtualMachineOVFImport objects
+	VirtualMachineOVFImport() cache.SharedIndexInformer
+
+	// Watches VirtualMachineV2VImport objects
+	VirtualMachineV2VImport() cache.SharedIndexInformer
+
+	// Watches VirtualMachineClusterImport objects
+	VirtualMachineClusterImport() cache.SharedIndexInformer
+
 	// Watches for k8s extensions api configmap
 	ApiAuthConfigMap() cache.SharedIndexInformer
 
@@ -121,6 +141,12 @@ type KubeInformerFactory interface {
 	// Fake CDI DataVolume informer used when feature gate is disabled
 	DummyDataVolume() cache.SharedIndexInformer
 
+	// Watches for multus NetworkAttachmentDefinition objects
+	NetworkAttachmentDefinition() cache.SharedIndexInformer
+
+	// Fake NetworkAttachmentDefinition informer used when the multus API is not installed
+	DummyNetworkAttachmentDefinition() cache.SharedIndexInformer
+
 	// CRD
 	CRD() cache.SharedIndexInformer
 
@@ -314,6 +340,20 @@ func (f *kubeInformerFactory) VirtualMachinePreset() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineNotificationHook() cache.SharedIndexInformer {
+	return f.getInformer("vmNotificationHookInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachinenotificationhooks", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachineNotificationHook{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) FencingRequest() cache.SharedIndexInformer {
+	return f.getInformer("fencingRequestInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "fencingrequests", f.kubevirtNamespace, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.FencingRequest{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) VirtualMachineInstanceMigration() cache.SharedIndexInformer {
 	return f.getInformer("vmimInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachineinstancemigrations", k8sv1.NamespaceAll, fields.Everything())
@@ -376,6 +416,13 @@ func (f *kubeInformerFactory) VirtualMachineSnapshot() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineSnapshotSchedule() cache.SharedIndexInformer {
+	return f.getInformer("vmSnapshotScheduleInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1alpha1().RESTClient(), "virtualmachinesnapshotschedules", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &snapshotv1.VirtualMachineSnapshotSchedule{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
 func (f *kubeInformerFactory) VirtualMachineSnapshotContent() cache.SharedIndexInformer {
 	return f.getInformer("vmSnapshotContentInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().SnapshotV1alpha1().RESTClient(), "virtualmachinesnapshotcontents", k8sv1.NamespaceAll, fields.Everything())
@@ -426,6 +473,27 @@ func (f *kubeInformerFactory) VirtualMachineRestore() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineOVFImport() cache.SharedIndexInformer {
+	return f.getInformer("vmOVFImportInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().VmimportV1alpha1().RESTClient(), "virtualmachineovfimports", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &vmimportv1.VirtualMachineOVFImport{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineV2VImport() cache.SharedIndexInformer {
+	return f.getInformer("vmV2VImportInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().VmimportV1alpha1().RESTClient(), "virtualmachinev2vimports", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &vmimportv1.VirtualMachineV2VImport{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineClusterImport() cache.SharedIndexInformer {
+	return f.getInformer("vmClusterImportInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().VmimportV1alpha1().RESTClient(), "virtualmachineclusterimports", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &vmimportv1.VirtualMachineClusterImport{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) DataVolume() cache.SharedIndexInformer {
 	return f.getInformer("dataVolumeInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.CdiClient().CdiV1alpha1().RESTClient(), "datavolumes", k8sv1.NamespaceAll, fields.Everything())
@@ -440,6 +508,20 @@ func (f *kubeInformerFactory) DummyDataVolume() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) NetworkAttachmentDefinition() cache.SharedIndexInformer {
+	return f.getInformer("networkAttachmentDefinitionInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.NetworkClient().K8sCniCncfIoV1().RESTClient(), "network-attachment-definitions", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &networkv1.NetworkAttachmentDefinition{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) DummyNetworkAttachmentDefinition() cache.SharedIndexInformer {
+	return f.getInformer("fakeNetworkAttachmentDefinitionInformer", func() cache.SharedIndexInformer {
+		informer, _ := testutils.NewFakeInformerFor(&networkv1.NetworkAttachmentDefinition{})
+		return informer
+	})
+}
+
 func (f *kubeInformerFactory) ApiAuthConfigMap() cache.SharedIndexInformer {
 	return f.getInformer("extensionsConfigMapInformer", func() cache.SharedIndexInformer {
 		restClient := f.clientSet.CoreV1().RESTClient()
@@ -66,9 +66,63 @@ func createVolumeDirectory(volumeName string) error {
 	return nil
 }
 
-func GetFilePath(volumeName string) string {
+// GetFilePath returns the path of the writable image of the volume
+func GetFilePath(volumeName string, image *v1.EphemeralImage) string {
 	volumeMountDir := generateVolumeMountDir(volumeName)
-	return filepath.Join(volumeMountDir, "disk.qcow2")
+	return filepath.Join(volumeMountDir, "disk."+GetImageFormat(image))
+}
+
+// GetImageFormat returns the format of the writable image, qcow2 if not configured
+func GetImageFormat(image *v1.EphemeralImage) string {
+	if image == nil || image.Format == "" {
+		return string(v1.EphemeralImageFormatQcow2)
+	}
+	return string(image.Format)
+}
+
+// IsOverlay returns whether the writable image is a copy-on-write overlay backed by
+// the source image, instead of a converted copy of it
+func IsOverlay(image *v1.EphemeralImage) bool {
+	return GetImageFormat(image) == string(v1.EphemeralImageFormatQcow2) && (image == nil || !image.Compressed)
+}
+
+// GetEphemeralImage returns the configuration of the writable image of a containerDisk or ephemeral volume
+func GetEphemeralImage(volume v1.Volume) *v1.EphemeralImage {
+	if volume.ContainerDisk != nil {
+		return volume.ContainerDisk.EphemeralImage
+	}
+	if volume.Ephemeral != nil {
+		return volume.Ephemeral.EphemeralImage
+	}
+	return nil
+}
+
+func qemuImgArgs(image *v1.EphemeralImage, backingFile string, imagePath string) []string {
+	var args []string
+
+	if IsOverlay(image) {
+		args = append(args, "create")
+		args = append(args, "-f")
+		args = append(args, "qcow2")
+		args = append(args, "-b")
+		args = append(args, backingFile)
+		args = append(args, imagePath)
+		return args
+	}
+
+	args = append(args, "convert")
+	args = append(args, "-O")
+	args = append(args, GetImageFormat(image))
+	if image.Compressed {
+		args = append(args, "-c")
+	}
+	if image.Preallocation != "" && image.Preallocation != v1.EphemeralImagePreallocationOff {
+		args = append(args, "-o")
+		args = append(args, "preallocation="+string(image.Preallocation))
+	}
+	args = append(args, backingFile)
+	args = append(args, imagePath)
+	return args
 }
 
 func CreateBackedImageForVolume(volume v1.Volume, backingFile string) error {
@@ -77,7 +131,8 @@ func CreateBackedImageForVolume(volume v1.Volume, backingFile string) error {
 		return err
 	}
 
-	imagePath := GetFilePath(volume.Name)
+	image := GetEphemeralImage(volume)
+	imagePath := GetFilePath(volume.Name, image)
 
 	if _, err := os.Stat(imagePath); err == nil {
 		return nil
@@ -85,14 +140,7 @@ func CreateBackedImageForVolume(volume v1.Volume, backingFile string) error {
 		return err
 	}
 
-	var args []string
-
-	args = append(args, "create")
-	args = append(args, "-f")
-	args = append(args, "qcow2")
-	args = append(args, "-b")
-	args = append(args, backingFile)
-	args = append(args, imagePath)
+	args := qemuImgArgs(image, backingFile, imagePath)
 
 	// #nosec No risk for attacket injection. Parameters are predefined strings
 	cmd := exec.Command("qemu-img", args...)
@@ -100,6 +148,10 @@ func CreateBackedImageForVolume(volume v1.Volume, backingFile string) error {
 
 	// Cleanup of previous images isn't really necessary as they're all on EmptyDir.
 	if err != nil {
+		// a partially converted image must not be picked up by the next attempt
+		if !IsOverlay(image) {
+			os.Remove(imagePath)
+		}
 		return fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
 	}
 
@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
 
@@ -149,4 +150,24 @@ var _ = Describe("ContainerDisk", func() {
 			})
 		})
 	})
+
+	table.DescribeTable("should build the qemu-img arguments of the writable image", func(image *v1.EphemeralImage, expectedArgs ...string) {
+		Expect(qemuImgArgs(image, "/backing/disk.img", "/images/disk")).To(Equal(expectedArgs))
+	},
+		table.Entry("with a qcow2 overlay by default", nil,
+			"create", "-f", "qcow2", "-b", "/backing/disk.img", "/images/disk"),
+		table.Entry("with an explicit qcow2 overlay", &v1.EphemeralImage{Format: v1.EphemeralImageFormatQcow2},
+			"create", "-f", "qcow2", "-b", "/backing/disk.img", "/images/disk"),
+		table.Entry("with a compressed qcow2 image", &v1.EphemeralImage{Compressed: true},
+			"convert", "-O", "qcow2", "-c", "/backing/disk.img", "/images/disk"),
+		table.Entry("with a sparse raw image", &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw, Preallocation: v1.EphemeralImagePreallocationOff},
+			"convert", "-O", "raw", "/backing/disk.img", "/images/disk"),
+		table.Entry("with a preallocated raw image", &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw, Preallocation: v1.EphemeralImagePreallocationFalloc},
+			"convert", "-O", "raw", "-o", "preallocation=falloc", "/backing/disk.img", "/images/disk"),
+	)
+
+	It("should name the writable image after its format", func() {
+		Expect(GetFilePath("fake-disk", nil)).To(Equal(filepath.Join(mountBaseDir, "fake-disk", "disk.qcow2")))
+		Expect(GetFilePath("fake-disk", &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw})).To(Equal(filepath.Join(mountBaseDir, "fake-disk", "disk.raw")))
+	})
 })
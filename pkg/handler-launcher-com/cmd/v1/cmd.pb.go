@@ -20,6 +20,11 @@ It has these top-level messages:
 	GuestInfoResponse
 	GuestUserListResponse
 	GuestFilesystemsResponse
+	NetworkStatusResponse
+	QMPCommandRequest
+	QMPCommandResponse
+	HostCapabilities
+	NUMANode
 */
 package v1
 
@@ -111,8 +116,9 @@ func (m *SMBios) GetFamily() string {
 }
 
 type VirtualMachineOptions struct {
-	VirtualMachineSMBios  *SMBios `protobuf:"bytes,1,opt,name=VirtualMachineSMBios" json:"VirtualMachineSMBios,omitempty"`
-	MemBalloonStatsPeriod uint32  `protobuf:"varint,2,opt,name=MemBalloonStatsPeriod" json:"MemBalloonStatsPeriod,omitempty"`
+	VirtualMachineSMBios  *SMBios           `protobuf:"bytes,1,opt,name=VirtualMachineSMBios" json:"VirtualMachineSMBios,omitempty"`
+	MemBalloonStatsPeriod uint32            `protobuf:"varint,2,opt,name=MemBalloonStatsPeriod" json:"MemBalloonStatsPeriod,omitempty"`
+	HostCapabilities      *HostCapabilities `protobuf:"bytes,3,opt,name=HostCapabilities" json:"HostCapabilities,omitempty"`
 }
 
 func (m *VirtualMachineOptions) Reset()                    { *m = VirtualMachineOptions{} }
@@ -134,6 +140,13 @@ func (m *VirtualMachineOptions) GetMemBalloonStatsPeriod() uint32 {
 	return 0
 }
 
+func (m *VirtualMachineOptions) GetHostCapabilities() *HostCapabilities {
+	if m != nil {
+		return m.HostCapabilities
+	}
+	return nil
+}
+
 type VMIRequest struct {
 	Vmi     *VMI                   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	Options *VirtualMachineOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
@@ -193,6 +206,7 @@ func (*EmptyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []in
 type Response struct {
 	Success bool   `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
 	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Reason  string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
 }
 
 func (m *Response) Reset()                    { *m = Response{} }
@@ -214,6 +228,13 @@ func (m *Response) GetMessage() string {
 	return ""
 }
 
+func (m *Response) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type DomainResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 	Domain   string    `protobuf:"bytes,2,opt,name=domain" json:"domain,omitempty"`
@@ -334,6 +355,118 @@ func (m *GuestFilesystemsResponse) GetGuestFilesystemsResponse() string {
 	return ""
 }
 
+type NetworkStatusResponse struct {
+	Response      *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	NetworkStatus string    `protobuf:"bytes,2,opt,name=networkStatus" json:"networkStatus,omitempty"`
+}
+
+func (m *NetworkStatusResponse) Reset()                    { *m = NetworkStatusResponse{} }
+func (m *NetworkStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*NetworkStatusResponse) ProtoMessage()               {}
+func (*NetworkStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *NetworkStatusResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *NetworkStatusResponse) GetNetworkStatus() string {
+	if m != nil {
+		return m.NetworkStatus
+	}
+	return ""
+}
+
+type QMPCommandRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Command string `protobuf:"bytes,2,opt,name=command" json:"command,omitempty"`
+}
+
+func (m *QMPCommandRequest) Reset()                    { *m = QMPCommandRequest{} }
+func (m *QMPCommandRequest) String() string            { return proto.CompactTextString(m) }
+func (*QMPCommandRequest) ProtoMessage()               {}
+func (*QMPCommandRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *QMPCommandRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *QMPCommandRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+type QMPCommandResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Result   string    `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *QMPCommandResponse) Reset()                    { *m = QMPCommandResponse{} }
+func (m *QMPCommandResponse) String() string            { return proto.CompactTextString(m) }
+func (*QMPCommandResponse) ProtoMessage()               {}
+func (*QMPCommandResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *QMPCommandResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *QMPCommandResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type HostCapabilities struct {
+	NumaNodes []*NUMANode `protobuf:"bytes,1,rep,name=numaNodes" json:"numaNodes,omitempty"`
+}
+
+func (m *HostCapabilities) Reset()                    { *m = HostCapabilities{} }
+func (m *HostCapabilities) String() string            { return proto.CompactTextString(m) }
+func (*HostCapabilities) ProtoMessage()               {}
+func (*HostCapabilities) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *HostCapabilities) GetNumaNodes() []*NUMANode {
+	if m != nil {
+		return m.NumaNodes
+	}
+	return nil
+}
+
+type NUMANode struct {
+	Id   uint32   `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Cpus []uint32 `protobuf:"varint,2,rep,packed,name=cpus" json:"cpus,omitempty"`
+}
+
+func (m *NUMANode) Reset()                    { *m = NUMANode{} }
+func (m *NUMANode) String() string            { return proto.CompactTextString(m) }
+func (*NUMANode) ProtoMessage()               {}
+func (*NUMANode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *NUMANode) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *NUMANode) GetCpus() []uint32 {
+	if m != nil {
+		return m.Cpus
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
 	proto.RegisterType((*SMBios)(nil), "kubevirt.cmd.v1.SMBios")
@@ -347,6 +480,11 @@ func init() {
 	proto.RegisterType((*GuestInfoResponse)(nil), "kubevirt.cmd.v1.GuestInfoResponse")
 	proto.RegisterType((*GuestUserListResponse)(nil), "kubevirt.cmd.v1.GuestUserListResponse")
 	proto.RegisterType((*GuestFilesystemsResponse)(nil), "kubevirt.cmd.v1.GuestFilesystemsResponse")
+	proto.RegisterType((*NetworkStatusResponse)(nil), "kubevirt.cmd.v1.NetworkStatusResponse")
+	proto.RegisterType((*QMPCommandRequest)(nil), "kubevirt.cmd.v1.QMPCommandRequest")
+	proto.RegisterType((*QMPCommandResponse)(nil), "kubevirt.cmd.v1.QMPCommandResponse")
+	proto.RegisterType((*HostCapabilities)(nil), "kubevirt.cmd.v1.HostCapabilities")
+	proto.RegisterType((*NUMANode)(nil), "kubevirt.cmd.v1.NUMANode")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -376,6 +514,13 @@ type CmdClient interface {
 	GetUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestUserListResponse, error)
 	GetFilesystems(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GuestFilesystemsResponse, error)
 	Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
+	GetNetworkStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*NetworkStatusResponse, error)
+	PlugNetworkInterfaces(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	AnnounceNetworkInterfaces(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	HibernateVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	ExecQMPCommand(ctx context.Context, in *QMPCommandRequest, opts ...grpc.CallOption) (*QMPCommandResponse, error)
+	FreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type cmdClient struct {
@@ -530,6 +675,69 @@ func (c *cmdClient) Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *cmdClient) GetNetworkStatus(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*NetworkStatusResponse, error) {
+	out := new(NetworkStatusResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetNetworkStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) PlugNetworkInterfaces(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/PlugNetworkInterfaces", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) AnnounceNetworkInterfaces(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/AnnounceNetworkInterfaces", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) HibernateVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/HibernateVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) ExecQMPCommand(ctx context.Context, in *QMPCommandRequest, opts ...grpc.CallOption) (*QMPCommandResponse, error) {
+	out := new(QMPCommandResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/ExecQMPCommand", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) FreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -549,6 +757,13 @@ type CmdServer interface {
 	GetUsers(context.Context, *EmptyRequest) (*GuestUserListResponse, error)
 	GetFilesystems(context.Context, *EmptyRequest) (*GuestFilesystemsResponse, error)
 	Ping(context.Context, *EmptyRequest) (*Response, error)
+	GetNetworkStatus(context.Context, *EmptyRequest) (*NetworkStatusResponse, error)
+	PlugNetworkInterfaces(context.Context, *VMIRequest) (*Response, error)
+	AnnounceNetworkInterfaces(context.Context, *VMIRequest) (*Response, error)
+	HibernateVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	ExecQMPCommand(context.Context, *QMPCommandRequest) (*QMPCommandResponse, error)
+	FreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	UnfreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -843,6 +1058,132 @@ func _Cmd_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_GetNetworkStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetNetworkStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetNetworkStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetNetworkStatus(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_PlugNetworkInterfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).PlugNetworkInterfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/PlugNetworkInterfaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).PlugNetworkInterfaces(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_AnnounceNetworkInterfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).AnnounceNetworkInterfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/AnnounceNetworkInterfaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).AnnounceNetworkInterfaces(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_HibernateVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).HibernateVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/HibernateVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).HibernateVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_ExecQMPCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QMPCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).ExecQMPCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/ExecQMPCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).ExecQMPCommand(ctx, req.(*QMPCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_FreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/FreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).FreezeVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_UnfreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/UnfreezeVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).UnfreezeVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -911,6 +1252,34 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _Cmd_Ping_Handler,
 		},
+		{
+			MethodName: "GetNetworkStatus",
+			Handler:    _Cmd_GetNetworkStatus_Handler,
+		},
+		{
+			MethodName: "PlugNetworkInterfaces",
+			Handler:    _Cmd_PlugNetworkInterfaces_Handler,
+		},
+		{
+			MethodName: "AnnounceNetworkInterfaces",
+			Handler:    _Cmd_AnnounceNetworkInterfaces_Handler,
+		},
+		{
+			MethodName: "HibernateVirtualMachine",
+			Handler:    _Cmd_HibernateVirtualMachine_Handler,
+		},
+		{
+			MethodName: "ExecQMPCommand",
+			Handler:    _Cmd_ExecQMPCommand_Handler,
+		},
+		{
+			MethodName: "FreezeVirtualMachine",
+			Handler:    _Cmd_FreezeVirtualMachine_Handler,
+		},
+		{
+			MethodName: "UnfreezeVirtualMachine",
+			Handler:    _Cmd_UnfreezeVirtualMachine_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -919,52 +1288,65 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 738 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0x61, 0x4f, 0xd3, 0x40,
-	0x18, 0xc7, 0x37, 0x86, 0x30, 0x1e, 0x26, 0xc2, 0xc1, 0xb0, 0x62, 0x08, 0x78, 0x31, 0x44, 0x12,
-	0x19, 0x01, 0xf1, 0x8d, 0x2f, 0x8c, 0x19, 0xe8, 0x82, 0x38, 0x98, 0x1d, 0xcc, 0x68, 0x4c, 0xcc,
-	0xd1, 0x1e, 0xdd, 0x85, 0xf6, 0x6e, 0xf6, 0xae, 0xd3, 0xbd, 0xf7, 0x95, 0x89, 0x5f, 0xc0, 0x4f,
-	0xe3, 0x47, 0x33, 0xbd, 0x76, 0x83, 0xad, 0x9d, 0x8b, 0xd9, 0x5e, 0xd1, 0xe7, 0x9e, 0xe7, 0x7e,
-	0xff, 0xe7, 0x9e, 0x6b, 0xff, 0x0c, 0xb6, 0x5b, 0xd7, 0xce, 0x6e, 0x93, 0x70, 0xdb, 0xa5, 0xfe,
-	0x8e, 0x4b, 0x02, 0x6e, 0x35, 0xa9, 0xbf, 0x63, 0x09, 0x6f, 0xd7, 0xf2, 0xec, 0xdd, 0xf6, 0x5e,
-	0xf8, 0xa7, 0xd4, 0xf2, 0x85, 0x12, 0xe8, 0xde, 0x75, 0x70, 0x49, 0xdb, 0xcc, 0x57, 0xa5, 0x70,
-	0xad, 0xbd, 0x87, 0x37, 0x20, 0xd7, 0xa8, 0x1e, 0x23, 0x03, 0x66, 0xdb, 0x1e, 0x7b, 0x2b, 0x05,
-	0x37, 0xb2, 0x9b, 0xd9, 0x27, 0x05, 0xb3, 0x1b, 0xe2, 0x9f, 0x59, 0x98, 0xa9, 0x57, 0xcb, 0x4c,
-	0x48, 0x84, 0xa1, 0xe0, 0x11, 0x1e, 0x5c, 0x11, 0x4b, 0x05, 0x3e, 0xf5, 0x75, 0xe5, 0x9c, 0xd9,
-	0xb7, 0x16, 0x82, 0x5a, 0xbe, 0xb0, 0x03, 0x4b, 0x19, 0x53, 0x3a, 0xdd, 0x0d, 0xb5, 0x04, 0xf5,
-	0x25, 0x13, 0xdc, 0xc8, 0x45, 0x99, 0x38, 0x44, 0x8b, 0x90, 0x93, 0xd7, 0x81, 0x31, 0xad, 0x57,
-	0xc3, 0x47, 0xb4, 0x0a, 0x33, 0x57, 0xc4, 0x63, 0x6e, 0xc7, 0xb8, 0xa3, 0x17, 0xe3, 0x08, 0xff,
-	0xce, 0x42, 0xb1, 0xc1, 0x7c, 0x15, 0x10, 0xb7, 0x4a, 0xac, 0x26, 0xe3, 0xf4, 0xac, 0xa5, 0x98,
-	0xe0, 0x12, 0x9d, 0xc0, 0x4a, 0x7f, 0x22, 0xea, 0x59, 0xf7, 0x38, 0xbf, 0x7f, 0xbf, 0x34, 0x70,
-	0xee, 0x52, 0x94, 0x36, 0x53, 0x37, 0xa1, 0x03, 0x28, 0x56, 0xa9, 0x57, 0x26, 0xae, 0x2b, 0x04,
-	0xaf, 0x2b, 0xa2, 0x64, 0x8d, 0xfa, 0x4c, 0xd8, 0xfa, 0x48, 0x77, 0xcd, 0xf4, 0x24, 0x6e, 0x03,
-	0x34, 0xaa, 0xc7, 0x26, 0xfd, 0x1a, 0x50, 0xa9, 0xd0, 0x16, 0xe4, 0xda, 0x1e, 0x8b, 0xf5, 0x57,
-	0x12, 0xfa, 0x61, 0x65, 0x58, 0x80, 0x5e, 0xc1, 0xac, 0x88, 0xce, 0xa0, 0xe9, 0xf3, 0xfb, 0x5b,
-	0xc9, 0xda, 0xb4, 0x13, 0x9b, 0xdd, 0x6d, 0xf8, 0x1c, 0x16, 0xab, 0xcc, 0xf1, 0x49, 0x18, 0xfd,
-	0xaf, 0xba, 0xd1, 0xaf, 0x5e, 0xb8, 0xa1, 0x2e, 0x40, 0xe1, 0xb5, 0xd7, 0x52, 0x9d, 0x98, 0x88,
-	0x5f, 0x42, 0xde, 0xa4, 0xb2, 0x25, 0xb8, 0xa4, 0xe1, 0x2e, 0x19, 0x58, 0x16, 0x95, 0xd1, 0x7c,
-	0xf3, 0x66, 0x37, 0x0c, 0x33, 0x1e, 0x95, 0x92, 0x38, 0xb4, 0x7b, 0xfd, 0x71, 0x88, 0xbf, 0xc0,
-	0xc2, 0x91, 0xf0, 0x08, 0xe3, 0x3d, 0xca, 0x73, 0xc8, 0xfb, 0xf1, 0x73, 0xdc, 0xe8, 0x83, 0x44,
-	0xa3, 0xdd, 0x62, 0xb3, 0x57, 0x1a, 0xbe, 0x1b, 0xb6, 0x06, 0xc5, 0x0a, 0x71, 0x84, 0x39, 0x2c,
-	0x47, 0x02, 0xfa, 0x4e, 0xc6, 0x55, 0xd9, 0x84, 0x79, 0xfb, 0x86, 0x16, 0x4b, 0xdd, 0x5e, 0xc2,
-	0xdf, 0x61, 0xa9, 0x12, 0x4e, 0xe6, 0x98, 0x5f, 0x89, 0x71, 0xd5, 0x9e, 0xc2, 0x92, 0x33, 0xc8,
-	0x8a, 0x35, 0x93, 0x09, 0xfc, 0x23, 0x0b, 0x45, 0x2d, 0x7d, 0x21, 0xa9, 0xff, 0x8e, 0x49, 0x35,
-	0xae, 0xfc, 0x01, 0x14, 0x9d, 0x34, 0x5e, 0xdc, 0x42, 0x7a, 0x12, 0xff, 0xca, 0x82, 0xa1, 0xdb,
-	0x78, 0xc3, 0x5c, 0x2a, 0x3b, 0x52, 0x51, 0x6f, 0xec, 0xb1, 0xbf, 0x00, 0xc3, 0x19, 0x82, 0x8c,
-	0x9b, 0x19, 0x9a, 0xdf, 0xff, 0x03, 0x90, 0x3b, 0xf4, 0x6c, 0x74, 0x0a, 0xa8, 0xde, 0xe1, 0x56,
-	0xff, 0x57, 0x83, 0x1e, 0xa6, 0x7e, 0x04, 0xd1, 0xcb, 0xbd, 0x36, 0xbc, 0x37, 0x9c, 0x41, 0x67,
-	0xb0, 0x5c, 0x23, 0x81, 0xa4, 0x13, 0x03, 0xbe, 0x87, 0xe2, 0x05, 0x6f, 0x4d, 0x14, 0x69, 0xc2,
-	0x6a, 0xbd, 0x19, 0x28, 0x5b, 0x7c, 0xe3, 0x13, 0x63, 0x9e, 0x02, 0x3a, 0x61, 0xae, 0x3b, 0x31,
-	0x5e, 0x0d, 0x56, 0x8e, 0xa8, 0x4b, 0xd5, 0xe4, 0x4e, 0xfd, 0x01, 0x8a, 0x91, 0xf3, 0x0d, 0x22,
-	0x1f, 0x25, 0x76, 0x0d, 0x3a, 0xe4, 0xc8, 0x2b, 0x0f, 0x5f, 0xa1, 0xde, 0xa6, 0x73, 0xe2, 0x3b,
-	0x54, 0x8d, 0xd1, 0xe9, 0x47, 0x58, 0x3f, 0x24, 0xdc, 0xa2, 0x03, 0xd3, 0xec, 0x09, 0x8c, 0x81,
-	0x6e, 0xc0, 0x5a, 0x9d, 0xaa, 0x7e, 0xae, 0xfe, 0x2c, 0xcf, 0x99, 0x37, 0xce, 0x70, 0xab, 0x30,
-	0x57, 0xa1, 0x2a, 0xb2, 0x54, 0xb4, 0x9e, 0xa8, 0xbc, 0xfd, 0xcf, 0x61, 0x6d, 0x23, 0x91, 0xee,
-	0xf7, 0x7a, 0x7d, 0x57, 0x0b, 0x3d, 0x9c, 0x36, 0xd0, 0x51, 0xcc, 0xc7, 0x43, 0x98, 0x7d, 0xf6,
-	0x8e, 0x33, 0xa8, 0x0e, 0x85, 0x0a, 0x55, 0x3d, 0x2b, 0x1e, 0x85, 0xc5, 0x89, 0x74, 0xc2, 0xc5,
-	0x35, 0x34, 0x5f, 0xa1, 0xda, 0xf2, 0x46, 0xf6, 0xb9, 0x95, 0x0e, 0x4c, 0xd8, 0x65, 0x06, 0x7d,
-	0xd6, 0x23, 0xb8, 0x65, 0x5d, 0xa3, 0xd0, 0xdb, 0xe9, 0xe8, 0x14, 0xf3, 0xc3, 0x19, 0x54, 0x86,
-	0xe9, 0x1a, 0xe3, 0xce, 0x28, 0xe6, 0xbf, 0xee, 0xbc, 0x3c, 0xfd, 0x69, 0xaa, 0xbd, 0x77, 0x39,
-	0xa3, 0x7f, 0x2b, 0x3e, 0xfb, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x5c, 0xa3, 0x1e, 0x96, 0x58, 0x0a,
-	0x00, 0x00,
+	// 957 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x57, 0xdf, 0x6f, 0xdb, 0x36,
+	0x10, 0x8e, 0xe3, 0x2c, 0x75, 0x2e, 0x8e, 0x97, 0xb0, 0x49, 0xeb, 0x66, 0x28, 0xda, 0x72, 0x45,
+	0xb0, 0x02, 0x9b, 0x83, 0xa4, 0x2d, 0x06, 0xec, 0x69, 0x4d, 0xda, 0xb5, 0x59, 0xa6, 0xd4, 0x95,
+	0xe3, 0x0c, 0x5b, 0x07, 0x0c, 0x8c, 0xc4, 0x28, 0x44, 0x24, 0xca, 0x15, 0x29, 0xb7, 0x79, 0xdf,
+	0xd3, 0x80, 0xfd, 0xa1, 0xfb, 0x13, 0xf6, 0x36, 0x8a, 0xa2, 0x1c, 0xcb, 0x52, 0x6a, 0xb4, 0xf2,
+	0x93, 0x78, 0xbc, 0xe3, 0xf7, 0xdd, 0x1d, 0x7f, 0x7c, 0x10, 0x3c, 0x1a, 0x5c, 0x78, 0xdb, 0xe7,
+	0x84, 0xbb, 0x3e, 0x8d, 0xbe, 0xf3, 0x49, 0xcc, 0x9d, 0x73, 0x35, 0x70, 0xc2, 0x60, 0xdb, 0x09,
+	0xdc, 0xed, 0xe1, 0x4e, 0xf2, 0xe9, 0x0c, 0xa2, 0x50, 0x86, 0xe8, 0xcb, 0x8b, 0xf8, 0x94, 0x0e,
+	0x59, 0x24, 0x3b, 0xc9, 0xdc, 0x70, 0x07, 0xdf, 0x83, 0xfa, 0x89, 0x75, 0x80, 0xda, 0x70, 0x63,
+	0x18, 0xb0, 0x9f, 0x45, 0xc8, 0xdb, 0xb5, 0xfb, 0xb5, 0x6f, 0x9a, 0x76, 0x66, 0xe2, 0xbf, 0x6b,
+	0xb0, 0xd8, 0xb3, 0xf6, 0x58, 0x28, 0x10, 0x86, 0x66, 0x40, 0x78, 0x7c, 0x46, 0x1c, 0x19, 0x47,
+	0x34, 0xd2, 0x91, 0x4b, 0x76, 0x6e, 0x2e, 0x01, 0x52, 0x4c, 0x6e, 0xec, 0xc8, 0xf6, 0xbc, 0x76,
+	0x67, 0xa6, 0xa6, 0xa0, 0x91, 0x60, 0x8a, 0xa2, 0x9e, 0x7a, 0x8c, 0x89, 0x56, 0xa1, 0x2e, 0x2e,
+	0xe2, 0xf6, 0x82, 0x9e, 0x4d, 0x86, 0xe8, 0x16, 0x2c, 0x9e, 0x91, 0x80, 0xf9, 0x97, 0xed, 0x2f,
+	0xf4, 0xa4, 0xb1, 0xf0, 0xbf, 0x35, 0xd8, 0x38, 0x51, 0xd9, 0xc7, 0xc4, 0xb7, 0x88, 0x73, 0xce,
+	0x38, 0x7d, 0x3d, 0x90, 0x0a, 0x42, 0xa0, 0x43, 0x58, 0xcf, 0x3b, 0xd2, 0x9c, 0x75, 0x8e, 0xcb,
+	0xbb, 0xb7, 0x3b, 0x13, 0x75, 0x77, 0x52, 0xb7, 0x5d, 0xba, 0x08, 0x3d, 0x81, 0x0d, 0x8b, 0x06,
+	0x7b, 0xc4, 0xf7, 0xc3, 0x90, 0xf7, 0x24, 0x91, 0xa2, 0x4b, 0x23, 0x16, 0xba, 0xba, 0xa4, 0x15,
+	0xbb, 0xdc, 0x89, 0x2c, 0x58, 0x7d, 0x15, 0x0a, 0xb9, 0x4f, 0x06, 0xe4, 0x94, 0xf9, 0x4c, 0x32,
+	0x2a, 0x74, 0xa5, 0xcb, 0xbb, 0x0f, 0x0a, 0xf4, 0x93, 0x81, 0x76, 0x61, 0x29, 0x1e, 0x02, 0xa8,
+	0x9d, 0xb1, 0xe9, 0xbb, 0x98, 0x0a, 0x89, 0xb6, 0xa0, 0xae, 0x76, 0xc4, 0x94, 0xb3, 0x5e, 0xc0,
+	0x4b, 0x22, 0x93, 0x00, 0xf4, 0x23, 0xdc, 0x08, 0xd3, 0x96, 0xe8, 0x64, 0x97, 0x77, 0xb7, 0x8a,
+	0xb1, 0x65, 0x0d, 0xb4, 0xb3, 0x65, 0xf8, 0x18, 0x56, 0x2d, 0xe6, 0x45, 0x24, 0xb1, 0x3e, 0x95,
+	0xbd, 0x9d, 0x67, 0x6f, 0x5e, 0xa1, 0xb6, 0xa0, 0xf9, 0x22, 0x18, 0xc8, 0x4b, 0x83, 0x88, 0x4f,
+	0xa0, 0x61, 0x53, 0x31, 0x50, 0x2e, 0x9a, 0xac, 0x12, 0xb1, 0xe3, 0x50, 0x91, 0x6e, 0x57, 0xc3,
+	0xce, 0xcc, 0xc4, 0x13, 0xa8, 0x2f, 0xf1, 0x68, 0x76, 0x9a, 0x8c, 0x99, 0x9c, 0x90, 0x88, 0x12,
+	0x31, 0x3a, 0x4c, 0xc6, 0xc2, 0x7f, 0x42, 0xeb, 0x79, 0x18, 0x10, 0xc6, 0x47, 0xe8, 0x4f, 0xa1,
+	0x11, 0x99, 0xb1, 0x29, 0xe0, 0x4e, 0xa1, 0x80, 0x2c, 0xd8, 0x1e, 0x85, 0x26, 0x04, 0xae, 0x06,
+	0x32, 0xcc, 0xc6, 0xc2, 0x1c, 0x6e, 0xa6, 0x04, 0x7a, 0xeb, 0xab, 0xb2, 0xdc, 0x87, 0x65, 0xf7,
+	0x0a, 0xcd, 0x50, 0x8d, 0x4f, 0xe1, 0x0f, 0xb0, 0xf6, 0x32, 0xe9, 0xd8, 0x01, 0x3f, 0x0b, 0xab,
+	0xb2, 0x7d, 0x0b, 0x6b, 0xde, 0x24, 0x96, 0xe1, 0x2c, 0x3a, 0xf0, 0x5f, 0xea, 0xb2, 0x69, 0xea,
+	0xbe, 0xa0, 0xd1, 0x2f, 0x4c, 0xc8, 0xaa, 0xf4, 0xea, 0x5a, 0x79, 0x65, 0x78, 0x26, 0x85, 0x72,
+	0x27, 0xfe, 0xa7, 0x06, 0x6d, 0x9d, 0xc6, 0x4f, 0xcc, 0xa7, 0xe2, 0x52, 0x48, 0x1a, 0x54, 0x6e,
+	0xfb, 0x0f, 0xd0, 0xf6, 0xae, 0x81, 0x34, 0xc9, 0x5c, 0xeb, 0xc7, 0x12, 0x36, 0x8e, 0xa8, 0x7c,
+	0x1f, 0x46, 0x17, 0xc9, 0x06, 0xc5, 0x95, 0x73, 0x79, 0x08, 0x2b, 0x7c, 0x1c, 0xcf, 0x24, 0x90,
+	0x9f, 0xc4, 0x7d, 0x58, 0x7b, 0x63, 0x75, 0xf7, 0xc3, 0x40, 0xbd, 0xb6, 0xee, 0x67, 0x5c, 0x4b,
+	0x27, 0x5d, 0x99, 0x5d, 0x23, 0x63, 0x62, 0x07, 0xd0, 0x38, 0x6c, 0xe5, 0x2b, 0xa3, 0xc6, 0xb1,
+	0x9f, 0x3d, 0xfd, 0xc6, 0xc2, 0x87, 0xc5, 0x87, 0x11, 0x7d, 0x0f, 0x4b, 0x3c, 0x0e, 0xc8, 0x51,
+	0xe8, 0xd2, 0xe4, 0xd6, 0xd7, 0x4b, 0x39, 0x8e, 0xfa, 0xd6, 0xb3, 0x24, 0xc2, 0xbe, 0x8a, 0xc5,
+	0x1d, 0x68, 0x64, 0xd3, 0xa8, 0x05, 0xf3, 0xcc, 0xd5, 0x19, 0xae, 0xd8, 0x6a, 0x84, 0x10, 0x2c,
+	0x38, 0x03, 0xdd, 0xc1, 0xba, 0x9a, 0xd1, 0xe3, 0xdd, 0xff, 0x56, 0xa0, 0xbe, 0x1f, 0xb8, 0xe8,
+	0x08, 0x50, 0xef, 0x92, 0x3b, 0xf9, 0xc7, 0x0f, 0x7d, 0x55, 0xda, 0xb4, 0xb4, 0xbd, 0x9b, 0xd7,
+	0x17, 0x8d, 0xe7, 0xd0, 0x6b, 0xb8, 0xd9, 0x25, 0xb1, 0xa0, 0x33, 0x03, 0x7c, 0x03, 0x1b, 0x7d,
+	0x3e, 0x98, 0x29, 0xa4, 0x0d, 0xb7, 0x7a, 0xe7, 0xb1, 0x74, 0xc3, 0xf7, 0x7c, 0x66, 0x98, 0xaa,
+	0x8f, 0x87, 0xcc, 0xf7, 0x67, 0x86, 0xd7, 0x85, 0xf5, 0xe7, 0xd4, 0xa7, 0x72, 0x76, 0x55, 0xff,
+	0xaa, 0xd4, 0x5b, 0x0b, 0xd8, 0x24, 0x64, 0x51, 0x86, 0x27, 0x85, 0x6e, 0xea, 0x96, 0x27, 0x47,
+	0x68, 0xb4, 0xe8, 0x98, 0x44, 0x1e, 0x95, 0x15, 0x32, 0xfd, 0x0d, 0xee, 0xee, 0x13, 0xee, 0xd0,
+	0x89, 0x6e, 0x8e, 0x08, 0x2a, 0x40, 0x9f, 0xc0, 0x66, 0x8f, 0xca, 0x3c, 0xae, 0x7e, 0x45, 0x8f,
+	0x59, 0x50, 0xa5, 0xb9, 0x16, 0x2c, 0xbd, 0xa4, 0x32, 0x55, 0x40, 0x74, 0xb7, 0x10, 0x39, 0xae,
+	0xf1, 0x9b, 0xf7, 0x0a, 0xee, 0xbc, 0x34, 0xeb, 0xbd, 0x6a, 0x8d, 0xe0, 0xb4, 0xde, 0x4d, 0xc3,
+	0x7c, 0x78, 0x0d, 0x66, 0x4e, 0x8d, 0x15, 0x70, 0x0f, 0x9a, 0x0a, 0x78, 0xa4, 0x9c, 0xd3, 0x60,
+	0x71, 0xc1, 0x5d, 0x10, 0x5d, 0x0d, 0xda, 0x50, 0xa0, 0x89, 0x42, 0x4d, 0xcd, 0x73, 0xab, 0x1c,
+	0xb0, 0xa0, 0x6e, 0x73, 0xe8, 0x0f, 0xdd, 0x82, 0x31, 0xa5, 0x99, 0x06, 0xfd, 0xa8, 0x1c, 0xba,
+	0x4c, 0xab, 0xe6, 0xd0, 0x1e, 0x2c, 0x74, 0x19, 0xf7, 0xa6, 0x61, 0x7e, 0x74, 0xcf, 0xdf, 0xc2,
+	0xaa, 0xca, 0x30, 0x27, 0x7a, 0x9f, 0x5e, 0x7e, 0xa9, 0x66, 0xa6, 0xcf, 0x5e, 0xd7, 0x8f, 0x3d,
+	0xe3, 0x3e, 0xe0, 0x92, 0x46, 0xea, 0x67, 0x42, 0x29, 0xc4, 0xe7, 0x9f, 0xd1, 0x3e, 0xdc, 0x79,
+	0xc6, 0x79, 0xa8, 0xfe, 0x83, 0xe8, 0x2c, 0x61, 0x7b, 0x70, 0xfb, 0x15, 0x3b, 0xa5, 0x11, 0x27,
+	0x33, 0x7c, 0xac, 0xde, 0x42, 0xeb, 0xc5, 0x07, 0xea, 0x5c, 0x89, 0x30, 0x2a, 0x1e, 0xc5, 0x82,
+	0xf0, 0x6f, 0x7e, 0xfd, 0xd1, 0x98, 0x0c, 0x7c, 0x6f, 0xe1, 0xf7, 0xf9, 0xe1, 0xce, 0xe9, 0xa2,
+	0xfe, 0xf5, 0x7b, 0xfc, 0x3f, 0xc4, 0x8c, 0x6f, 0xc0, 0x27, 0x0e, 0x00, 0x00,
 }
@@ -22,11 +22,14 @@ package hostdisk
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"syscall"
 
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/config"
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 
 	k8sv1 "k8s.io/api/core/v1"
@@ -42,6 +45,10 @@ var pvcBaseDir = "/var/run/kubevirt-private/vmi-disks"
 const (
 	EventReasonToleratedSmallPV = "ToleratedSmallPV"
 	EventTypeToleratedSmallPV   = k8sv1.EventTypeNormal
+
+	// luksHeaderSize is reserved for the LUKS header of encrypted disk images,
+	// so that the image including its header still fits into the volume
+	luksHeaderSize = 16 * 1024 * 1024
 )
 
 // Used by tests.
@@ -103,6 +110,21 @@ func createSparseRaw(fullPath string, size int64) (err error) {
 	return nil
 }
 
+func createLuksImage(fullPath string, size int64, keyPath string) error {
+	// qemu-img reads the passphrase from the mounted secret, so that it never shows up on the command line
+	args := []string{
+		"create", "-f", "luks",
+		"--object", fmt.Sprintf("secret,id=sec0,file=%s", keyPath),
+		"-o", "key-secret=sec0",
+		fullPath, strconv.FormatInt(size-luksHeaderSize, 10),
+	}
+	out, err := exec.Command("qemu-img", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create LUKS image %s: %v: %s", fullPath, err, string(out))
+	}
+	return nil
+}
+
 func getPVCDiskImgPath(volumeName string, diskName string) string {
 	return path.Join(pvcBaseDir, volumeName, diskName)
 }
@@ -117,6 +139,7 @@ func GetMountedHostDiskDir(volumeName string) string {
 
 type DiskImgCreator struct {
 	dirBytesAvailableFunc  func(path string) (uint64, error)
+	createLuksImageFunc    func(fullPath string, size int64, keyPath string) error
 	notifier               k8sNotifier
 	lessPVCSpaceToleration int
 }
@@ -128,6 +151,7 @@ type k8sNotifier interface {
 func NewHostDiskCreator(notifier k8sNotifier, lessPVCSpaceToleration int) DiskImgCreator {
 	return DiskImgCreator{
 		dirBytesAvailableFunc:  dirBytesAvailable,
+		createLuksImageFunc:    createLuksImage,
 		notifier:               notifier,
 		lessPVCSpaceToleration: lessPVCSpaceToleration,
 	}
@@ -168,10 +192,19 @@ func (hdc DiskImgCreator) Create(vmi *v1.VirtualMachineInstance) error {
 						log.Log.Reason(err).Warningf("Couldn't send k8s event for tolerated PV size: %v", err)
 					}
 				}
-				err = createSparseRaw(diskPath, int64(diskSize))
-				if err != nil {
-					log.Log.Reason(err).Errorf("Couldn't create a sparse raw file for disk path: %s, error: %v", diskPath, err)
-					return err
+				if volume.Encryption != nil {
+					// blank encrypted volumes are formatted with LUKS, so that qemu can open them
+					err = hdc.createLuksImageFunc(diskPath, diskSize, config.GetDiskEncryptionKeyPath(volume.Name))
+					if err != nil {
+						log.Log.Reason(err).Errorf("Couldn't create a LUKS image for disk path: %s, error: %v", diskPath, err)
+						return err
+					}
+				} else {
+					err = createSparseRaw(diskPath, int64(diskSize))
+					if err != nil {
+						log.Log.Reason(err).Errorf("Couldn't create a sparse raw file for disk path: %s, error: %v", diskPath, err)
+						return err
+					}
 				}
 			} else if err != nil {
 				return err
@@ -296,6 +296,33 @@ var _ = Describe("HostDisk", func() {
 				Expect(tmpDiskImg.Size()).To(Equal(int64(67108864)))
 			})
 		})
+		Context("With encrypted volumes", func() {
+			It("Should format a non existing disk.img with LUKS", func() {
+				By("Creating a new minimal vmi")
+				vmi := v1.NewMinimalVMI("fake-vmi")
+
+				By("Adding an encrypted HostDisk volume")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+				vmi.Spec.Volumes[0].Encryption = &v1.VolumeEncryption{
+					SecretRef: &k8sv1.LocalObjectReference{Name: "luks-secret"},
+				}
+
+				var luksPath, luksKeyPath string
+				var luksSize int64
+				luksCreator := NewHostDiskCreator(notifier, 0)
+				luksCreator.createLuksImageFunc = func(fullPath string, size int64, keyPath string) error {
+					luksPath, luksSize, luksKeyPath = fullPath, size, keyPath
+					return createSparseRaw(fullPath, size)
+				}
+
+				By("Executing CreateHostDisks which should format disk.img")
+				err := luksCreator.Create(vmi)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(luksPath).To(Equal(vmi.Spec.Volumes[0].HostDisk.Path))
+				Expect(luksSize).To(Equal(int64(67108864)))
+				Expect(luksKeyPath).To(Equal("/var/run/kubevirt-private/disk-encryption/volume1/key"))
+			})
+		})
 	})
 
 	Describe("HostDisk with unknown type", func() {
@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package hypervisorlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Follow writes the lines of the qemu log and of the event log of a domain as
+// JSON entries to out, and keeps following both files until stop is closed or
+// writing fails. Files which don't exist yet are waited for.
+func Follow(out io.Writer, qemuLogPath string, eventLogPath string, interval time.Duration, stop <-chan struct{}) error {
+	entries := make(chan Entry, 100)
+	quit := make(chan struct{})
+	defer close(quit)
+
+	go followFile(qemuLogPath, interval, newQemuLogParser(), entries, quit)
+	go followFile(eventLogPath, interval, parseEvent, entries, quit)
+
+	encoder := json.NewEncoder(out)
+	for {
+		select {
+		case <-stop:
+			return nil
+		case entry := <-entries:
+			if err := encoder.Encode(&entry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// followFile sends the entries of the lines of the file at path, and polls the
+// file for new lines every interval until quit is closed
+func followFile(path string, interval time.Duration, parse func(string) (Entry, bool), entries chan<- Entry, quit <-chan struct{}) {
+	var file *os.File
+	var reader *bufio.Reader
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	var partial string
+	for {
+		if file == nil {
+			// #nosec No risk for path injection. path has a static basedir
+			if f, err := os.Open(path); err == nil {
+				file = f
+				reader = bufio.NewReader(file)
+			}
+		}
+
+		for file != nil {
+			line, err := reader.ReadString('\n')
+			partial += line
+			if err != nil {
+				// the rest of the line is not written yet
+				break
+			}
+			entry, ok := parse(strings.TrimSuffix(partial, "\n"))
+			partial = ""
+			if !ok {
+				continue
+			}
+			select {
+			case entries <- entry:
+			case <-quit:
+				return
+			}
+		}
+
+		select {
+		case <-quit:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package hypervisorlog writes the libvirt lifecycle events of a domain next to
+// its qemu log in virt-launcher, and streams both to virt-handler clients.
+package hypervisorlog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	SourceQemu    = "qemu"
+	SourceLibvirt = "libvirt"
+
+	LevelInfo    = "info"
+	LevelWarning = "warning"
+	LevelError   = "error"
+)
+
+const qemuLogTimestampLayout = "2006-01-02 15:04:05.000-0700"
+
+var (
+	// libvirt prefixes the lines it writes to the qemu log with a timestamp
+	qemuLogTimestamp = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}[+-]\d{4}): (.*)$`)
+	// qemu reports warnings and errors prefixed with the name of its binary
+	qemuLogReport = regexp.MustCompile(`^qemu[\w-]*: (warning: )?`)
+)
+
+// Entry is a line of the hypervisor log stream of a VMI
+type Entry struct {
+	// Timestamp is the time of the line, unset for qemu lines without one
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// Source is qemu or libvirt
+	Source string `json:"source"`
+	// Level is info, warning or error
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// QemuLogPath returns the path of the qemu log of the domain in virt-launcher
+func QemuLogPath(domainName string) string {
+	return filepath.Join("/var/log/libvirt/qemu", domainName+".log")
+}
+
+// EventLogPath returns the path of the lifecycle events of the domain of the
+// VMI in virt-launcher
+func EventLogPath(vmiUID types.UID) string {
+	return filepath.Join("/var/run/kubevirt-private", string(vmiUID), "libvirt-events.log")
+}
+
+// WriteEvent appends a lifecycle event of the domain to the event log at path
+func WriteEvent(path string, level string, message string, timestamp time.Time) error {
+	timestamp = timestamp.UTC()
+	data, err := json.Marshal(&Entry{
+		Timestamp: &timestamp,
+		Source:    SourceLibvirt,
+		Level:     level,
+		Message:   message,
+	})
+	if err != nil {
+		return err
+	}
+
+	// #nosec No risk for path injection. path has a static basedir
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// newQemuLogParser returns a parser of the lines of the qemu log, which joins
+// the lines of the qemu command line continued with a backslash
+func newQemuLogParser() func(line string) (Entry, bool) {
+	var continued string
+	return func(line string) (Entry, bool) {
+		if strings.TrimSpace(line) == "" {
+			return Entry{}, false
+		}
+		if strings.HasSuffix(line, "\\") {
+			continued += line
+			return Entry{}, false
+		}
+		line, continued = continued+line, ""
+
+		entry := Entry{Source: SourceQemu, Level: LevelInfo, Message: line}
+		if match := qemuLogTimestamp.FindStringSubmatch(line); match != nil {
+			if timestamp, err := time.Parse(qemuLogTimestampLayout, match[1]); err == nil {
+				timestamp = timestamp.UTC()
+				entry.Timestamp = &timestamp
+				entry.Message = match[2]
+			}
+		}
+		if match := qemuLogReport.FindStringSubmatch(entry.Message); match != nil {
+			entry.Level = LevelError
+			if match[1] != "" {
+				entry.Level = LevelWarning
+			}
+		}
+		return entry, true
+	}
+}
+
+// parseEvent parses a line of the event log, skipping invalid lines
+func parseEvent(line string) (Entry, bool) {
+	var entry Entry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return Entry{}, false
+	}
+	return entry, true
+}
@@ -0,0 +1,136 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package hypervisorlog
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hypervisor log", func() {
+
+	table.DescribeTable("should parse the qemu log", func(line string, level string, message string, timestamp *time.Time) {
+		entry, ok := newQemuLogParser()(line)
+		Expect(ok).To(BeTrue())
+		Expect(entry.Source).To(Equal(SourceQemu))
+		Expect(entry.Level).To(Equal(level))
+		Expect(entry.Message).To(Equal(message))
+		Expect(entry.Timestamp).To(Equal(timestamp))
+	},
+		table.Entry("with libvirt timestamps",
+			"2021-06-01 12:00:00.123+0200: starting up libvirt version: 7.0.0",
+			LevelInfo, "starting up libvirt version: 7.0.0", timeOf(time.Date(2021, time.June, 1, 10, 0, 0, 123000000, time.UTC))),
+		table.Entry("with qemu warnings",
+			"qemu-kvm: warning: host doesn't support requested feature",
+			LevelWarning, "qemu-kvm: warning: host doesn't support requested feature", nil),
+		table.Entry("with qemu errors",
+			"qemu-kvm: -device virtio-blk-pci: Failed to get shared write lock",
+			LevelError, "qemu-kvm: -device virtio-blk-pci: Failed to get shared write lock", nil),
+		table.Entry("with other lines", "LC_ALL=C", LevelInfo, "LC_ALL=C", nil),
+	)
+
+	It("should join the continued lines of the qemu command line", func() {
+		parse := newQemuLogParser()
+		_, ok := parse("/usr/libexec/qemu-kvm \\")
+		Expect(ok).To(BeFalse())
+		entry, ok := parse("-name guest=default_testvmi")
+		Expect(ok).To(BeTrue())
+		Expect(entry.Message).To(Equal("/usr/libexec/qemu-kvm \\-name guest=default_testvmi"))
+	})
+
+	It("should skip empty lines and invalid events", func() {
+		_, ok := newQemuLogParser()("  ")
+		Expect(ok).To(BeFalse())
+		_, ok = parseEvent("not json")
+		Expect(ok).To(BeFalse())
+	})
+
+	Context("with log files", func() {
+		var tmpDir string
+		var qemuLogPath string
+		var eventLogPath string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "hypervisorlog")
+			Expect(err).ToNot(HaveOccurred())
+			qemuLogPath = filepath.Join(tmpDir, "default_testvmi.log")
+			eventLogPath = filepath.Join(tmpDir, "libvirt-events.log")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		It("should stream both logs and follow them", func() {
+			now := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+			Expect(ioutil.WriteFile(qemuLogPath, []byte("2021-06-01 12:00:00.000+0000: starting up\n"), 0644)).To(Succeed())
+
+			reader, writer := io.Pipe()
+			stop := make(chan struct{})
+			done := make(chan error)
+			go func() {
+				done <- Follow(writer, qemuLogPath, eventLogPath, 10*time.Millisecond, stop)
+			}()
+			decoder := json.NewDecoder(reader)
+			next := func() Entry {
+				var entry Entry
+				Expect(decoder.Decode(&entry)).To(Succeed())
+				return entry
+			}
+
+			Expect(next().Message).To(Equal("starting up"))
+
+			// the event log is created once the domain is defined
+			Expect(WriteEvent(eventLogPath, LevelError, `Domain event="stopped" detail="crashed"`, now)).To(Succeed())
+			event := next()
+			Expect(event.Source).To(Equal(SourceLibvirt))
+			Expect(event.Level).To(Equal(LevelError))
+			Expect(event.Message).To(Equal(`Domain event="stopped" detail="crashed"`))
+			Expect(event.Timestamp.Equal(now)).To(BeTrue())
+
+			file, err := os.OpenFile(qemuLogPath, os.O_APPEND|os.O_WRONLY, 0644)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = file.WriteString("qemu-kvm: terminating on ")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = file.WriteString("signal 15\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+			Expect(next().Message).To(Equal("qemu-kvm: terminating on signal 15"))
+
+			close(stop)
+			// unblock the write of a pending entry
+			go ioutil.ReadAll(reader)
+			Eventually(done).Should(Receive(BeNil()))
+		})
+	})
+})
+
+func timeOf(t time.Time) *time.Time {
+	return &t
+}
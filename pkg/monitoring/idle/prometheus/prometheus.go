@@ -0,0 +1,90 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package prometheus exposes the idle status of the VirtualMachines under the
+// idle policy as prometheus metrics.
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var (
+	idleDesc = prometheus.NewDesc(
+		"kubevirt_vm_idle",
+		"Whether the VM is idle under the idle policy, with the action which suspended it.",
+		[]string{"namespace", "name", "action"},
+		nil,
+	)
+
+	lastActivityTimestampDesc = prometheus.NewDesc(
+		"kubevirt_vm_last_activity_timestamp",
+		"Unix timestamp of the last activity of the guest or of a console of the VM under the idle policy.",
+		[]string{"namespace", "name"},
+		nil,
+	)
+)
+
+type idleCollector struct {
+	vmInformer cache.SharedIndexInformer
+}
+
+// SetupIdleCollector registers a collector reporting the idle status of the
+// VMs in the store of the informer
+func SetupIdleCollector(vmInformer cache.SharedIndexInformer) {
+	prometheus.MustRegister(&idleCollector{vmInformer: vmInformer})
+}
+
+func (c *idleCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- idleDesc
+	ch <- lastActivityTimestampDesc
+}
+
+func (c *idleCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, obj := range c.vmInformer.GetStore().List() {
+		vm, ok := obj.(*v1.VirtualMachine)
+		if !ok || vm.Status.IdleStatus == nil {
+			continue
+		}
+		status := vm.Status.IdleStatus
+
+		idle := 0.0
+		if status.Idle {
+			idle = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			idleDesc,
+			prometheus.GaugeValue,
+			idle,
+			vm.Namespace, vm.Name, string(status.Action),
+		)
+
+		if status.LastActivityTime != nil {
+			ch <- prometheus.MustNewConstMetric(
+				lastActivityTimestampDesc,
+				prometheus.GaugeValue,
+				float64(status.LastActivityTime.Unix()),
+				vm.Namespace, vm.Name,
+			)
+		}
+	}
+}
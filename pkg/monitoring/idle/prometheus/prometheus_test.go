@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Idle collector", func() {
+
+	collect := func(collector prometheus.Collector) []prometheus.Metric {
+		ch := make(chan prometheus.Metric, 10)
+		collector.Collect(ch)
+		close(ch)
+
+		var metrics []prometheus.Metric
+		for metric := range ch {
+			metrics = append(metrics, metric)
+		}
+		return metrics
+	}
+
+	write := func(metric prometheus.Metric) *io_prometheus_client.Metric {
+		dto := &io_prometheus_client.Metric{}
+		Expect(metric.Write(dto)).To(Succeed())
+		return dto
+	}
+
+	It("should report the idle status of the VMs under the idle policy", func() {
+		vmInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		lastActivity := metav1.NewTime(time.Unix(1600000000, 0))
+
+		paused := &v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "paused"}}
+		paused.Status.IdleStatus = &v1.VirtualMachineIdleStatus{
+			LastActivityTime: &lastActivity,
+			Idle:             true,
+			Action:           v1.IdleActionPause,
+		}
+		Expect(vmInformer.GetStore().Add(paused)).To(Succeed())
+		Expect(vmInformer.GetStore().Add(&v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unmanaged"}})).To(Succeed())
+
+		metrics := collect(&idleCollector{vmInformer: vmInformer})
+		Expect(metrics).To(HaveLen(2))
+
+		Expect(metrics[0].Desc()).To(Equal(idleDesc))
+		idle := write(metrics[0])
+		Expect(idle.GetGauge().GetValue()).To(BeEquivalentTo(1))
+		Expect(idle.GetLabel()).To(ContainElement(
+			&io_prometheus_client.LabelPair{Name: &[]string{"action"}[0], Value: &[]string{"Pause"}[0]},
+		))
+
+		Expect(metrics[1].Desc()).To(Equal(lastActivityTimestampDesc))
+		Expect(write(metrics[1]).GetGauge().GetValue()).To(BeEquivalentTo(1600000000))
+	})
+})
@@ -0,0 +1,62 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package prometheus exposes the deviations of the network datapath of
+// VirtualMachineInstances found by virt-handler as prometheus metrics.
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var (
+	datapathLabels = []string{"namespace", "name", "interface", "component"}
+
+	datapathDrifts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubevirt_vmi_network_datapath_drifts",
+		Help: "Deviations of the network datapath of the VMI found by the last verification which were not repaired.",
+	}, datapathLabels)
+
+	datapathRepairs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevirt_vmi_network_datapath_repairs_total",
+		Help: "Deviations of the network datapath of the VMI which were repaired.",
+	}, datapathLabels)
+)
+
+func init() {
+	prometheus.MustRegister(datapathDrifts, datapathRepairs)
+}
+
+// ResetDatapathDrifts drops the drifts of the previous verification. It is
+// meant to be called before the drifts of a new verification are observed.
+func ResetDatapathDrifts() {
+	datapathDrifts.Reset()
+}
+
+// ObserveDatapathDrift counts a repaired drift, or reports an unrepaired one
+// until the next verification.
+func ObserveDatapathDrift(vmi *v1.VirtualMachineInstance, iface string, component string, repaired bool) {
+	if repaired {
+		datapathRepairs.WithLabelValues(vmi.Namespace, vmi.Name, iface, component).Inc()
+		return
+	}
+	datapathDrifts.WithLabelValues(vmi.Namespace, vmi.Name, iface, component).Inc()
+}
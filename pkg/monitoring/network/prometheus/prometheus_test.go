@@ -0,0 +1,69 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Datapath drifts", func() {
+	var vmi *v1.VirtualMachineInstance
+
+	drifts := func(iface, component string) float64 {
+		dto := &io_prometheus_client.Metric{}
+		Expect(datapathDrifts.WithLabelValues(vmi.Namespace, vmi.Name, iface, component).Write(dto)).To(Succeed())
+		return dto.GetGauge().GetValue()
+	}
+
+	repairs := func(iface, component string) float64 {
+		dto := &io_prometheus_client.Metric{}
+		Expect(datapathRepairs.WithLabelValues(vmi.Namespace, vmi.Name, iface, component).Write(dto)).To(Succeed())
+		return dto.GetCounter().GetValue()
+	}
+
+	BeforeEach(func() {
+		datapathDrifts.Reset()
+		datapathRepairs.Reset()
+		vmi = v1.NewMinimalVMI("testvmi")
+	})
+
+	It("should report unrepaired drifts until they are reset", func() {
+		ObserveDatapathDrift(vmi, "default", "nat", false)
+		ObserveDatapathDrift(vmi, "default", "nat", false)
+		Expect(drifts("default", "nat")).To(BeEquivalentTo(2))
+		Expect(repairs("default", "nat")).To(BeZero())
+
+		ResetDatapathDrifts()
+		Expect(drifts("default", "nat")).To(BeZero())
+	})
+
+	It("should keep counting repaired drifts across verifications", func() {
+		ObserveDatapathDrift(vmi, "default", "tap", true)
+		ResetDatapathDrifts()
+		ObserveDatapathDrift(vmi, "default", "tap", true)
+
+		Expect(repairs("default", "tap")).To(BeEquivalentTo(2))
+		Expect(drifts("default", "tap")).To(BeZero())
+	})
+})
@@ -0,0 +1,66 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package prometheus exposes the time of the last snapshot recorded in the
+// VirtualMachine status as prometheus metric.
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var lastBackupTimestampDesc = prometheus.NewDesc(
+	"kubevirt_vm_last_backup_timestamp",
+	"Unix timestamp of the creation of the last VirtualMachineSnapshot of the VM which became ready to use.",
+	[]string{"namespace", "name"},
+	nil,
+)
+
+type lastBackupCollector struct {
+	vmInformer cache.SharedIndexInformer
+}
+
+// SetupLastBackupCollector registers a collector reporting the last snapshot
+// time of the VMs in the store of the informer
+func SetupLastBackupCollector(vmInformer cache.SharedIndexInformer) {
+	prometheus.MustRegister(&lastBackupCollector{vmInformer: vmInformer})
+}
+
+func (c *lastBackupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lastBackupTimestampDesc
+}
+
+func (c *lastBackupCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, obj := range c.vmInformer.GetStore().List() {
+		vm, ok := obj.(*v1.VirtualMachine)
+		if !ok || vm.Status.LastSnapshotTime == nil {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			lastBackupTimestampDesc,
+			prometheus.GaugeValue,
+			float64(vm.Status.LastSnapshotTime.Unix()),
+			vm.Namespace, vm.Name,
+		)
+	}
+}
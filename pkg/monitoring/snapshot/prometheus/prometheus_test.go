@@ -0,0 +1,68 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Last backup collector", func() {
+
+	collect := func(collector prometheus.Collector) []*io_prometheus_client.Metric {
+		ch := make(chan prometheus.Metric, 10)
+		collector.Collect(ch)
+		close(ch)
+
+		var metrics []*io_prometheus_client.Metric
+		for metric := range ch {
+			dto := &io_prometheus_client.Metric{}
+			Expect(metric.Write(dto)).To(Succeed())
+			metrics = append(metrics, dto)
+		}
+		return metrics
+	}
+
+	It("should report the last snapshot time of the VMs which have one", func() {
+		vmInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		lastSnapshot := metav1.NewTime(time.Unix(1600000000, 0))
+
+		snapshotted := &v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "snapshotted"}}
+		snapshotted.Status.LastSnapshotTime = &lastSnapshot
+		Expect(vmInformer.GetStore().Add(snapshotted)).To(Succeed())
+		Expect(vmInformer.GetStore().Add(&v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "never"}})).To(Succeed())
+
+		metrics := collect(&lastBackupCollector{vmInformer: vmInformer})
+		Expect(metrics).To(HaveLen(1))
+		Expect(metrics[0].GetGauge().GetValue()).To(BeEquivalentTo(1600000000))
+		Expect(metrics[0].GetLabel()).To(ConsistOf(
+			&io_prometheus_client.LabelPair{Name: &[]string{"name"}[0], Value: &[]string{"snapshotted"}[0]},
+			&io_prometheus_client.LabelPair{Name: &[]string{"namespace"}[0], Value: &[]string{"default"}[0]},
+		))
+	})
+})
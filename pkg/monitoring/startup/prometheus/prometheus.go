@@ -0,0 +1,77 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package prometheus exposes the startup milestones recorded in the
+// VirtualMachineInstance status as prometheus metrics.
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	MilestonePodScheduled        = "pod_scheduled"
+	MilestoneNetworkConfigured   = "network_configured"
+	MilestoneDomainDefined       = "domain_defined"
+	MilestoneDomainRunning       = "domain_running"
+	MilestoneGuestAgentConnected = "guest_agent_connected"
+)
+
+var startupMilestoneSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "kubevirt_vmi_startup_milestone_seconds",
+	Help:    "Time from the VMI creation until a startup milestone was reached.",
+	Buckets: []float64{1, 2.5, 5, 10, 20, 30, 45, 60, 90, 120, 180, 300, 600},
+}, []string{"milestone"})
+
+func init() {
+	prometheus.MustRegister(startupMilestoneSeconds)
+}
+
+// ObserveStartupTimestamps observes all milestones which are recorded in the
+// status of the vmi, but not in old. It is meant to be called after the new
+// status was persisted, to report every milestone only once.
+func ObserveStartupTimestamps(vmi *v1.VirtualMachineInstance, old *v1.VirtualMachineInstanceStartupTimestamps) {
+	current := vmi.Status.StartupTimestamps
+	if current == nil {
+		return
+	}
+	if old == nil {
+		old = &v1.VirtualMachineInstanceStartupTimestamps{}
+	}
+
+	observe := func(milestone string, oldTime, newTime *metav1.Time) {
+		if oldTime != nil || newTime == nil {
+			return
+		}
+		elapsed := newTime.Sub(vmi.CreationTimestamp.Time).Seconds()
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		startupMilestoneSeconds.WithLabelValues(milestone).Observe(elapsed)
+	}
+
+	observe(MilestonePodScheduled, old.PodScheduled, current.PodScheduled)
+	observe(MilestoneNetworkConfigured, old.NetworkConfigured, current.NetworkConfigured)
+	observe(MilestoneDomainDefined, old.DomainDefined, current.DomainDefined)
+	observe(MilestoneDomainRunning, old.DomainRunning, current.DomainRunning)
+	observe(MilestoneGuestAgentConnected, old.GuestAgentConnected, current.GuestAgentConnected)
+}
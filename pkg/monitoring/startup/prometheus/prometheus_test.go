@@ -0,0 +1,75 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Startup milestones", func() {
+	var vmi *v1.VirtualMachineInstance
+	var created time.Time
+
+	histogram := func(milestone string) *io_prometheus_client.Histogram {
+		dto := &io_prometheus_client.Metric{}
+		Expect(startupMilestoneSeconds.WithLabelValues(milestone).(interface {
+			Write(*io_prometheus_client.Metric) error
+		}).Write(dto)).To(Succeed())
+		return dto.Histogram
+	}
+
+	BeforeEach(func() {
+		startupMilestoneSeconds.Reset()
+		created = time.Now()
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.CreationTimestamp = metav1.NewTime(created)
+	})
+
+	It("should observe the time since creation for new milestones", func() {
+		scheduled := metav1.NewTime(created.Add(3 * time.Second))
+		vmi.Status.StartupTimestamps = &v1.VirtualMachineInstanceStartupTimestamps{PodScheduled: &scheduled}
+
+		ObserveStartupTimestamps(vmi, nil)
+
+		h := histogram(MilestonePodScheduled)
+		Expect(h.GetSampleCount()).To(BeEquivalentTo(1))
+		Expect(h.GetSampleSum()).To(BeNumerically("~", 3, 0.001))
+		Expect(histogram(MilestoneDomainRunning).GetSampleCount()).To(BeZero())
+	})
+
+	It("should not observe milestones which were already recorded", func() {
+		scheduled := metav1.NewTime(created.Add(3 * time.Second))
+		running := metav1.NewTime(created.Add(10 * time.Second))
+		old := &v1.VirtualMachineInstanceStartupTimestamps{PodScheduled: &scheduled}
+		vmi.Status.StartupTimestamps = &v1.VirtualMachineInstanceStartupTimestamps{PodScheduled: &scheduled, DomainRunning: &running}
+
+		ObserveStartupTimestamps(vmi, old)
+
+		Expect(histogram(MilestonePodScheduled).GetSampleCount()).To(BeZero())
+		Expect(histogram(MilestoneDomainRunning).GetSampleCount()).To(BeEquivalentTo(1))
+	})
+})
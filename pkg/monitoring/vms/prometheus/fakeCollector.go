@@ -47,6 +47,8 @@ func (fc fakeCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 	ps.Report("test", &vmi, &out)
 	updateVMIsPhase("test", []*k6tv1.VirtualMachineInstance{&vmi}, ch)
+	updateVMIsPaused("test", []*k6tv1.VirtualMachineInstance{&vmi}, ch)
+	updateVMIsEmulated("test", []*k6tv1.VirtualMachineInstance{&vmi}, ch)
 }
 
 type fakeIdentifier struct {
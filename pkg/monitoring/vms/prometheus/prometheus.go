@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	k8sv1 "k8s.io/api/core/v1"
 	libvirt "libvirt.org/libvirt-go"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -66,6 +67,24 @@ var (
 		},
 		nil,
 	)
+
+	vmiPausedCountDesc = prometheus.NewDesc(
+		"kubevirt_vmi_paused_count",
+		"Amount of VMIs paused by the user.",
+		[]string{
+			"node",
+		},
+		nil,
+	)
+
+	vmiEmulatedCountDesc = prometheus.NewDesc(
+		"kubevirt_vmi_emulated_count",
+		"Amount of VMIs running with software emulation instead of KVM.",
+		[]string{
+			"node",
+		},
+		nil,
+	)
 )
 
 func tryToPushMetric(desc *prometheus.Desc, mv prometheus.Metric, err error, ch chan<- prometheus.Metric) {
@@ -486,6 +505,54 @@ func updateVMIsPhase(nodeName string, vmis []*k6tv1.VirtualMachineInstance, ch c
 	}
 }
 
+func countPausedVMIs(vmis []*k6tv1.VirtualMachineInstance) uint64 {
+	paused := uint64(0)
+
+	for _, vmi := range vmis {
+		for _, cond := range vmi.Status.Conditions {
+			if cond.Type == k6tv1.VirtualMachineInstancePaused && cond.Status == k8sv1.ConditionTrue {
+				paused += 1
+				break
+			}
+		}
+	}
+
+	return paused
+}
+
+func updateVMIsPaused(nodeName string, vmis []*k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+	mv, err := prometheus.NewConstMetric(
+		vmiPausedCountDesc, prometheus.GaugeValue,
+		float64(countPausedVMIs(vmis)),
+		nodeName,
+	)
+	tryToPushMetric(vmiPausedCountDesc, mv, err, ch)
+}
+
+func countEmulatedVMIs(vmis []*k6tv1.VirtualMachineInstance) uint64 {
+	emulated := uint64(0)
+
+	for _, vmi := range vmis {
+		for _, cond := range vmi.Status.Conditions {
+			if cond.Type == k6tv1.VirtualMachineInstanceEmulated && cond.Status == k8sv1.ConditionTrue {
+				emulated += 1
+				break
+			}
+		}
+	}
+
+	return emulated
+}
+
+func updateVMIsEmulated(nodeName string, vmis []*k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+	mv, err := prometheus.NewConstMetric(
+		vmiEmulatedCountDesc, prometheus.GaugeValue,
+		float64(countEmulatedVMIs(vmis)),
+		nodeName,
+	)
+	tryToPushMetric(vmiEmulatedCountDesc, mv, err, ch)
+}
+
 func updateVersion(ch chan<- prometheus.Metric) {
 	verinfo := version.Get()
 	ch <- prometheus.MustNewConstMetric(
@@ -557,6 +624,8 @@ func (co *Collector) Collect(ch chan<- prometheus.Metric) {
 	co.concCollector.Collect(socketToVMIs, scraper, collectionTimeout)
 
 	updateVMIsPhase(co.nodeName, vmis, ch)
+	updateVMIsPaused(co.nodeName, vmis, ch)
+	updateVMIsEmulated(co.nodeName, vmis, ch)
 	return
 }
 
@@ -22,6 +22,7 @@ package prometheus
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	libvirt "libvirt.org/libvirt-go"
 
@@ -807,5 +808,58 @@ var _ = Describe("Utility functions", func() {
 			Expect(phasesMap["scheduling"]).To(Equal(uint64(1)))
 			Expect(phasesMap["bogus"]).To(Equal(uint64(0))) // intentionally bogus key
 		})
+
+		It("should count the paused VMIs", func() {
+			vmis := []*k6tv1.VirtualMachineInstance{
+				{
+					Status: k6tv1.VirtualMachineInstanceStatus{
+						Phase: "Running",
+						Conditions: []k6tv1.VirtualMachineInstanceCondition{
+							{Type: k6tv1.VirtualMachineInstancePaused, Status: k8sv1.ConditionTrue},
+						},
+					},
+				},
+				{
+					Status: k6tv1.VirtualMachineInstanceStatus{
+						Phase: "Running",
+						Conditions: []k6tv1.VirtualMachineInstanceCondition{
+							{Type: k6tv1.VirtualMachineInstanceReady, Status: k8sv1.ConditionTrue},
+						},
+					},
+				},
+				{
+					Status: k6tv1.VirtualMachineInstanceStatus{
+						Phase: "Pending",
+					},
+				},
+			}
+
+			Expect(countPausedVMIs(vmis)).To(Equal(uint64(1)))
+			Expect(countPausedVMIs(nil)).To(Equal(uint64(0)))
+		})
+
+		It("should count the emulated VMIs", func() {
+			vmis := []*k6tv1.VirtualMachineInstance{
+				{
+					Status: k6tv1.VirtualMachineInstanceStatus{
+						Phase: "Running",
+						Conditions: []k6tv1.VirtualMachineInstanceCondition{
+							{Type: k6tv1.VirtualMachineInstanceEmulated, Status: k8sv1.ConditionTrue},
+						},
+					},
+				},
+				{
+					Status: k6tv1.VirtualMachineInstanceStatus{
+						Phase: "Running",
+						Conditions: []k6tv1.VirtualMachineInstanceCondition{
+							{Type: k6tv1.VirtualMachineInstanceReady, Status: k8sv1.ConditionTrue},
+						},
+					},
+				},
+			}
+
+			Expect(countEmulatedVMIs(vmis)).To(Equal(uint64(1)))
+			Expect(countEmulatedVMIs(nil)).To(Equal(uint64(0)))
+		})
 	})
 })
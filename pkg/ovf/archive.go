@@ -0,0 +1,72 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package ovf
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Directories of the pod extracting the disks of an OVA archive
+const (
+	// ArchiveDir holds the files of the archive, which CDI extracted
+	ArchiveDir = "/var/run/kubevirt-ova/archive"
+	// DisksDir holds a directory with the volume of every disk
+	DisksDir = "/var/run/kubevirt-ova/disks"
+)
+
+// ExtractScript returns the shell script converting the disks of an OVA
+// archive to the raw images of their volumes, in the order of the disks
+func ExtractScript(disks []ImportedDisk) (string, error) {
+	script := "set -e\n"
+	for i, disk := range disks {
+		if disk.File == "" {
+			continue
+		}
+		file, err := ArchiveFile(disk.File)
+		if err != nil {
+			return "", fmt.Errorf("invalid file of disk %s: %v", disk.DiskID, err)
+		}
+		source := path.Join(ArchiveDir, file)
+		target := path.Join(DisksDir, strconv.Itoa(i), "disk.img")
+		script += shellJoin([]string{"qemu-img", "convert", "-O", "raw", source, target}) + "\n"
+	}
+	return script, nil
+}
+
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+func shellQuote(arg string) string {
+	if safeShellWord.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
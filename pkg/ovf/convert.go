@@ -0,0 +1,462 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package ovf
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+const (
+	defaultMemoryAllocationUnits   = "byte * 2^20"
+	defaultCapacityAllocationUnits = "byte"
+	maxNameLength                  = 63
+
+	// archiveFilesystemOverhead is reserved on the PersistentVolumeClaims of
+	// the disks extracted from an OVA archive, the same CDI reserves for
+	// disk images
+	archiveFilesystemOverhead = 0.055
+
+	busVirtio = "virtio"
+	busSATA   = "sata"
+	busSCSI   = "scsi"
+)
+
+// Options tune the VirtualMachine created from a descriptor
+type Options struct {
+	// Name of the VirtualMachine, defaults to the name of the virtual system
+	Name      string
+	Namespace string
+	// DescriptorURL is the location of the descriptor, the files it references
+	// are imported from locations relative to it
+	DescriptorURL string
+	// SecretRef and CertConfigMap are passed to the DataVolumes importing the disks
+	SecretRef     string
+	CertConfigMap string
+	// StorageClassName of the PersistentVolumeClaims the disks are imported to
+	StorageClassName *string
+	// Networks maps the networks of the descriptor to networks of the
+	// VirtualMachine. Interfaces on networks which are not mapped are
+	// attached to the pod network.
+	Networks map[string]v1.NetworkSource
+	Running  bool
+}
+
+// ImportedDisk ties a disk of the descriptor to the DataVolume it is imported to
+type ImportedDisk struct {
+	DiskID         string
+	DataVolumeName string
+	// File is the path of the disk in the OVA archive, which is extracted to
+	// the blank DataVolume. It is only set for descriptors read from an archive.
+	File string
+}
+
+// ToVirtualMachine maps the virtual system of the descriptor to a
+// VirtualMachine whose DataVolumeTemplates import the disks of the appliance.
+// The disks of an OVA archive can't be imported by CDI separately, their
+// DataVolumeTemplates are blank and the disks are extracted to them.
+func ToVirtualMachine(envelope *Envelope, options Options) (*v1.VirtualMachine, []ImportedDisk, error) {
+	system := &envelope.VirtualSystem
+
+	name := options.Name
+	if name == "" {
+		name = sanitizeName(system.Name)
+		if name == "" {
+			name = sanitizeName(system.ID)
+		}
+	}
+	if name == "" {
+		return nil, nil, fmt.Errorf("the virtual system has no name usable for the VirtualMachine")
+	}
+
+	spec := v1.VirtualMachineInstanceSpec{
+		Domain: v1.DomainSpec{
+			Resources: v1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{},
+			},
+		},
+	}
+
+	items := system.HardwareItems()
+	buses := map[string]string{}
+	for _, item := range items {
+		if bus, isController := controllerBus(item); isController {
+			buses[item.InstanceID] = bus
+		}
+	}
+
+	var dataVolumes []v1.DataVolumeTemplateSpec
+	var disks []ImportedDisk
+	podNetworkUsed := false
+	for _, item := range items {
+		switch item.ResourceType {
+		case ResourceTypeProcessor:
+			spec.Domain.CPU = cpuTopology(item)
+		case ResourceTypeMemory:
+			memory, err := memoryQuantity(item)
+			if err != nil {
+				return nil, nil, err
+			}
+			spec.Domain.Resources.Requests[k8sv1.ResourceMemory] = *memory
+		case ResourceTypeDiskDrive:
+			index := len(disks)
+			diskID, err := diskReference(item)
+			if err != nil {
+				return nil, nil, err
+			}
+			dataVolumeName := fmt.Sprintf("%s-disk%d", name, index)
+			template, file, err := dataVolumeTemplate(envelope, diskID, dataVolumeName, options)
+			if err != nil {
+				return nil, nil, err
+			}
+			bus, exists := buses[item.Parent]
+			if !exists {
+				bus = busVirtio
+			}
+			diskName := fmt.Sprintf("disk%d", index)
+			spec.Domain.Devices.Disks = append(spec.Domain.Devices.Disks, v1.Disk{
+				Name: diskName,
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: bus},
+				},
+			})
+			spec.Volumes = append(spec.Volumes, v1.Volume{
+				Name: diskName,
+				VolumeSource: v1.VolumeSource{
+					DataVolume: &v1.DataVolumeSource{Name: dataVolumeName},
+				},
+			})
+			dataVolumes = append(dataVolumes, *template)
+			disks = append(disks, ImportedDisk{DiskID: diskID, DataVolumeName: dataVolumeName, File: file})
+		case ResourceTypeEthernetAdapter:
+			ifaceName := fmt.Sprintf("nic%d", len(spec.Networks))
+			networkName := ""
+			if len(item.Connection) > 0 {
+				networkName = item.Connection[0]
+			}
+			source, mapped := options.Networks[networkName]
+			if !mapped {
+				source = v1.NetworkSource{Pod: &v1.PodNetwork{}}
+			}
+			if source.Pod != nil {
+				if podNetworkUsed {
+					return nil, nil, fmt.Errorf("interface %s on network %q can't be attached to the pod network, another interface already is", item.ElementName, networkName)
+				}
+				podNetworkUsed = true
+			}
+			iface := v1.Interface{
+				Name:  ifaceName,
+				Model: interfaceModel(item.ResourceSubType),
+			}
+			if source.Pod != nil {
+				iface.Masquerade = &v1.InterfaceMasquerade{}
+			} else {
+				iface.Bridge = &v1.InterfaceBridge{}
+			}
+			if mac, err := net.ParseMAC(item.Address); err == nil {
+				iface.MacAddress = mac.String()
+			}
+			spec.Domain.Devices.Interfaces = append(spec.Domain.Devices.Interfaces, iface)
+			spec.Networks = append(spec.Networks, v1.Network{Name: ifaceName, NetworkSource: source})
+		}
+	}
+
+	if _, exists := spec.Domain.Resources.Requests[k8sv1.ResourceMemory]; !exists {
+		return nil, nil, fmt.Errorf("the virtual system does not define its memory")
+	}
+	if len(spec.Networks) == 0 {
+		autoattach := false
+		spec.Domain.Devices.AutoattachPodInterface = &autoattach
+	}
+	setFirmware(system, &spec)
+
+	running := options.Running
+	vm := &v1.VirtualMachine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: options.Namespace,
+		},
+		Spec: v1.VirtualMachineSpec{
+			Running: &running,
+			Template: &v1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"kubevirt.io/vm": name},
+				},
+				Spec: spec,
+			},
+			DataVolumeTemplates: dataVolumes,
+		},
+	}
+	return vm, disks, nil
+}
+
+func controllerBus(item Item) (string, bool) {
+	subType := strings.ToLower(item.ResourceSubType)
+	switch item.ResourceType {
+	case ResourceTypeIDEController:
+		// IDE is not supported, SATA is understood by the same guests
+		return busSATA, true
+	case ResourceTypeSCSIController, ResourceTypeSASController:
+		return busSCSI, true
+	case ResourceTypeOtherStorage:
+		if strings.Contains(subType, "sata") || strings.Contains(subType, "ahci") {
+			return busSATA, true
+		}
+		return busVirtio, true
+	}
+	return "", false
+}
+
+func cpuTopology(item Item) *v1.CPU {
+	count := item.VirtualQuantity
+	if count < 1 {
+		count = 1
+	}
+	if item.CoresPerSocket > 0 && count%item.CoresPerSocket == 0 {
+		return &v1.CPU{
+			Sockets: uint32(count / item.CoresPerSocket),
+			Cores:   uint32(item.CoresPerSocket),
+		}
+	}
+	return &v1.CPU{Cores: uint32(count)}
+}
+
+func memoryQuantity(item Item) (*resource.Quantity, error) {
+	units := item.AllocationUnits
+	if units == "" {
+		units = defaultMemoryAllocationUnits
+	}
+	multiplier, err := ParseAllocationUnits(units)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory of the virtual system: %v", err)
+	}
+	if item.VirtualQuantity <= 0 {
+		return nil, fmt.Errorf("invalid memory of the virtual system: %d", item.VirtualQuantity)
+	}
+	return resource.NewQuantity(item.VirtualQuantity*multiplier, resource.BinarySI), nil
+}
+
+func diskReference(item Item) (string, error) {
+	for _, hostResource := range item.HostResource {
+		if idx := strings.LastIndex(hostResource, "/disk/"); idx >= 0 {
+			return hostResource[idx+len("/disk/"):], nil
+		}
+	}
+	return "", fmt.Errorf("disk drive %s does not reference a disk", item.ElementName)
+}
+
+// dataVolumeTemplate returns the template of the DataVolume importing the
+// disk, and the path of its file if it is extracted from an OVA archive
+func dataVolumeTemplate(envelope *Envelope, diskID string, dataVolumeName string, options Options) (*v1.DataVolumeTemplateSpec, string, error) {
+	var disk *Disk
+	for i := range envelope.Disks {
+		if envelope.Disks[i].DiskID == diskID {
+			disk = &envelope.Disks[i]
+			break
+		}
+	}
+	if disk == nil {
+		return nil, "", fmt.Errorf("disk %s is not defined in the disk section", diskID)
+	}
+
+	capacity, err := diskCapacity(disk)
+	if err != nil {
+		return nil, "", err
+	}
+
+	source := cdiv1.DataVolumeSource{Blank: &cdiv1.DataVolumeBlankImage{}}
+	file := ""
+	if disk.FileRef != "" {
+		href := ""
+		for _, reference := range envelope.References {
+			if reference.ID == disk.FileRef {
+				href = reference.Href
+				break
+			}
+		}
+		if href == "" {
+			return nil, "", fmt.Errorf("file %s of disk %s is not defined in the references", disk.FileRef, diskID)
+		}
+		if envelope.Archive {
+			file, err = ArchiveFile(href)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid file of disk %s: %v", diskID, err)
+			}
+			// the disk is converted to a raw image on the filesystem of the volume
+			capacity = resource.NewQuantity(int64(float64(capacity.Value())*(1+archiveFilesystemOverhead)), resource.BinarySI)
+		} else {
+			location, err := resolveFile(options.DescriptorURL, href)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid location of disk %s: %v", diskID, err)
+			}
+			source = cdiv1.DataVolumeSource{
+				HTTP: &cdiv1.DataVolumeSourceHTTP{
+					URL:           location,
+					SecretRef:     options.SecretRef,
+					CertConfigMap: options.CertConfigMap,
+				},
+			}
+		}
+	}
+
+	return &v1.DataVolumeTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: dataVolumeName,
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: source,
+			PVC: &k8sv1.PersistentVolumeClaimSpec{
+				AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+				Resources: k8sv1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{
+						k8sv1.ResourceStorage: *capacity,
+					},
+				},
+				StorageClassName: options.StorageClassName,
+			},
+		},
+	}, file, nil
+}
+
+// ArchiveFile returns the path of a file referenced by the descriptor of an
+// OVA archive, which has to be in the archive
+func ArchiveFile(href string) (string, error) {
+	reference, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	if reference.IsAbs() || reference.Host != "" {
+		return "", fmt.Errorf("file %q is not part of the OVA archive", href)
+	}
+	file := path.Clean(reference.Path)
+	if path.IsAbs(file) || file == ".." || strings.HasPrefix(file, "../") {
+		return "", fmt.Errorf("file %q is not part of the OVA archive", href)
+	}
+	return file, nil
+}
+
+// ArchiveSize returns the size of the files the descriptor of an OVA archive
+// references, which all have to declare their size
+func ArchiveSize(envelope *Envelope) (int64, error) {
+	size := int64(0)
+	for _, file := range envelope.References {
+		if file.Size <= 0 {
+			return 0, fmt.Errorf("the size of file %s of the OVA archive is not known", file.Href)
+		}
+		size += file.Size
+	}
+	return size, nil
+}
+
+func diskCapacity(disk *Disk) (*resource.Quantity, error) {
+	capacity, err := strconv.ParseInt(strings.TrimSpace(disk.Capacity), 10, 64)
+	if err != nil || capacity <= 0 {
+		return nil, fmt.Errorf("invalid capacity %q of disk %s", disk.Capacity, disk.DiskID)
+	}
+	units := disk.CapacityAllocationUnits
+	if units == "" {
+		units = defaultCapacityAllocationUnits
+	}
+	multiplier, err := ParseAllocationUnits(units)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity of disk %s: %v", disk.DiskID, err)
+	}
+	return resource.NewQuantity(capacity*multiplier, resource.BinarySI), nil
+}
+
+func resolveFile(descriptorURL string, href string) (string, error) {
+	reference, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	if reference.IsAbs() {
+		return reference.String(), nil
+	}
+	base, err := url.Parse(descriptorURL)
+	if err != nil {
+		return "", err
+	}
+	if !base.IsAbs() {
+		return "", fmt.Errorf("the descriptor location %q is not absolute", descriptorURL)
+	}
+	return base.ResolveReference(reference).String(), nil
+}
+
+func interfaceModel(subType string) string {
+	switch strings.ToLower(subType) {
+	case "e1000":
+		return "e1000"
+	case "e1000e":
+		return "e1000e"
+	case "pcnet32":
+		return "pcnet"
+	case "rtl8139":
+		return "rtl8139"
+	default:
+		// vmxnet adapters have no emulated counterpart, virtio is the paravirtualized choice
+		return "virtio"
+	}
+}
+
+func setFirmware(system *VirtualSystem, spec *v1.VirtualMachineInstanceSpec) {
+	firmware, _ := system.Config("firmware")
+	if strings.ToLower(firmware) != "efi" {
+		return
+	}
+	secureBootValue, _ := system.Config("uefi.secureBoot.enabled")
+	secureBoot := strings.ToLower(secureBootValue) == "true"
+	spec.Domain.Firmware = &v1.Firmware{
+		Bootloader: &v1.Bootloader{
+			EFI: &v1.EFI{SecureBoot: &secureBoot},
+		},
+	}
+	if secureBoot {
+		enabled := true
+		spec.Domain.Features = &v1.Features{
+			SMM: &v1.FeatureState{Enabled: &enabled},
+		}
+	}
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+func sanitizeName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-")
+}
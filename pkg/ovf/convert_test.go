@@ -0,0 +1,196 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package ovf
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("OVF conversion", func() {
+
+	var envelope *Envelope
+	var options Options
+
+	BeforeEach(func() {
+		var err error
+		envelope, err = ReadDescriptor(strings.NewReader(vsphereDescriptor))
+		Expect(err).ToNot(HaveOccurred())
+		options = Options{
+			Namespace:     "default",
+			DescriptorURL: "https://appliances.example.com/web/appliance.ovf",
+			SecretRef:     "appliance-credentials",
+			Networks: map[string]v1.NetworkSource{
+				"Storage": {Multus: &v1.MultusNetwork{NetworkName: "storage"}},
+			},
+		}
+	})
+
+	It("should map the virtual hardware to a VirtualMachine", func() {
+		vm, disks, err := ToVirtualMachine(envelope, options)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(vm.Name).To(Equal("web-appliance-01"))
+		Expect(vm.Namespace).To(Equal("default"))
+		Expect(*vm.Spec.Running).To(BeFalse())
+
+		spec := vm.Spec.Template.Spec
+		Expect(spec.Domain.CPU.Sockets).To(Equal(uint32(2)))
+		Expect(spec.Domain.CPU.Cores).To(Equal(uint32(2)))
+		memory := spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+		Expect(memory.String()).To(Equal("4Gi"))
+
+		Expect(spec.Domain.Devices.Disks).To(HaveLen(2))
+		Expect(spec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("scsi"))
+		Expect(spec.Domain.Devices.Disks[1].Disk.Bus).To(Equal("sata"))
+		Expect(spec.Volumes[0].DataVolume.Name).To(Equal("web-appliance-01-disk0"))
+		Expect(disks).To(ConsistOf(
+			ImportedDisk{DiskID: "vmdisk1", DataVolumeName: "web-appliance-01-disk0"},
+			ImportedDisk{DiskID: "vmdisk2", DataVolumeName: "web-appliance-01-disk1"},
+		))
+
+		Expect(spec.Domain.Firmware.Bootloader.EFI.SecureBoot).To(Equal(&[]bool{true}[0]))
+		Expect(*spec.Domain.Features.SMM.Enabled).To(BeTrue())
+	})
+
+	It("should import the disks relative to the descriptor", func() {
+		storageClass := "fast"
+		options.StorageClassName = &storageClass
+
+		vm, _, err := ToVirtualMachine(envelope, options)
+		Expect(err).ToNot(HaveOccurred())
+
+		templates := vm.Spec.DataVolumeTemplates
+		Expect(templates).To(HaveLen(2))
+		Expect(templates[0].Spec.Source.HTTP.URL).To(Equal("https://appliances.example.com/web/appliance-disk1.vmdk"))
+		Expect(templates[0].Spec.Source.HTTP.SecretRef).To(Equal("appliance-credentials"))
+		capacity := templates[0].Spec.PVC.Resources.Requests[k8sv1.ResourceStorage]
+		Expect(capacity.String()).To(Equal("20Gi"))
+		Expect(*templates[0].Spec.PVC.StorageClassName).To(Equal("fast"))
+		Expect(templates[1].Spec.Source.Blank).ToNot(BeNil())
+		capacity = templates[1].Spec.PVC.Resources.Requests[k8sv1.ResourceStorage]
+		Expect(capacity.String()).To(Equal("1Gi"))
+	})
+
+	It("should extract the disks of an OVA archive to blank DataVolumes", func() {
+		envelope.Archive = true
+		options.DescriptorURL = "https://appliances.example.com/web/appliance.ova"
+
+		vm, disks, err := ToVirtualMachine(envelope, options)
+		Expect(err).ToNot(HaveOccurred())
+
+		templates := vm.Spec.DataVolumeTemplates
+		Expect(templates).To(HaveLen(2))
+		Expect(templates[0].Spec.Source.Blank).ToNot(BeNil())
+		Expect(templates[0].Spec.Source.HTTP).To(BeNil())
+		capacity := templates[0].Spec.PVC.Resources.Requests[k8sv1.ResourceStorage]
+		Expect(capacity.Value()).To(BeNumerically(">", int64(20<<30)))
+		Expect(disks).To(ConsistOf(
+			ImportedDisk{DiskID: "vmdisk1", DataVolumeName: "web-appliance-01-disk0", File: "appliance-disk1.vmdk"},
+			ImportedDisk{DiskID: "vmdisk2", DataVolumeName: "web-appliance-01-disk1"},
+		))
+	})
+
+	It("should refuse to extract files outside of the OVA archive", func() {
+		envelope.Archive = true
+		envelope.References[0].Href = "../appliance-disk1.vmdk"
+
+		_, _, err := ToVirtualMachine(envelope, options)
+		Expect(err).To(MatchError(ContainSubstring("is not part of the OVA archive")))
+	})
+
+	It("should convert the disks of an OVA archive to raw images", func() {
+		script, err := ExtractScript([]ImportedDisk{
+			{DiskID: "vmdisk1", DataVolumeName: "appliance-disk0", File: "appliance disk1.vmdk"},
+			{DiskID: "vmdisk2", DataVolumeName: "appliance-disk1"},
+			{DiskID: "vmdisk3", DataVolumeName: "appliance-disk2", File: "appliance-disk3.vmdk"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(script).To(Equal("set -e\n" +
+			"qemu-img convert -O raw '/var/run/kubevirt-ova/archive/appliance disk1.vmdk' /var/run/kubevirt-ova/disks/0/disk.img\n" +
+			"qemu-img convert -O raw /var/run/kubevirt-ova/archive/appliance-disk3.vmdk /var/run/kubevirt-ova/disks/2/disk.img\n"))
+	})
+
+	It("should sum the sizes of the files of an OVA archive", func() {
+		size, err := ArchiveSize(envelope)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(Equal(int64(1073741824)))
+
+		envelope.References[0].Size = 0
+		_, err = ArchiveSize(envelope)
+		Expect(err).To(MatchError(ContainSubstring("is not known")))
+	})
+
+	It("should attach interfaces on mapped networks to the mapped network", func() {
+		vm, _, err := ToVirtualMachine(envelope, options)
+		Expect(err).ToNot(HaveOccurred())
+
+		spec := vm.Spec.Template.Spec
+		Expect(spec.Domain.Devices.Interfaces).To(HaveLen(2))
+		Expect(spec.Domain.Devices.Interfaces[0].Masquerade).ToNot(BeNil())
+		Expect(spec.Domain.Devices.Interfaces[0].Model).To(Equal("virtio"))
+		Expect(spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("00:50:56:aa:bb:cc"))
+		Expect(spec.Domain.Devices.Interfaces[1].Bridge).ToNot(BeNil())
+		Expect(spec.Domain.Devices.Interfaces[1].Model).To(Equal("e1000"))
+		Expect(spec.Networks[0].Pod).ToNot(BeNil())
+		Expect(spec.Networks[1].Multus.NetworkName).To(Equal("storage"))
+	})
+
+	It("should refuse to attach more than one interface to the pod network", func() {
+		options.Networks = nil
+		_, _, err := ToVirtualMachine(envelope, options)
+		Expect(err).To(MatchError(ContainSubstring("another interface already is")))
+	})
+
+	It("should use the requested name", func() {
+		options.Name = "imported"
+		vm, disks, err := ToVirtualMachine(envelope, options)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vm.Name).To(Equal("imported"))
+		Expect(disks[0].DataVolumeName).To(Equal("imported-disk0"))
+	})
+
+	It("should fail without memory", func() {
+		items := envelope.VirtualSystem.Items[:0]
+		for _, item := range envelope.VirtualSystem.Items {
+			if item.ResourceType != ResourceTypeMemory {
+				items = append(items, item)
+			}
+		}
+		envelope.VirtualSystem.Items = items
+
+		_, _, err := ToVirtualMachine(envelope, options)
+		Expect(err).To(MatchError(ContainSubstring("does not define its memory")))
+	})
+
+	table.DescribeTable("should sanitize names", func(name string, expected string) {
+		Expect(sanitizeName(name)).To(Equal(expected))
+	},
+		table.Entry("with spaces", "Web Appliance 01", "web-appliance-01"),
+		table.Entry("with leading invalid characters", "_appliance_", "appliance"),
+		table.Entry("exceeding the length limit", strings.Repeat("a", 70), strings.Repeat("a", 63)),
+	)
+})
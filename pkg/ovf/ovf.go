@@ -0,0 +1,234 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package ovf reads the OVF descriptors of virtual appliances, as exported by
+// vSphere and other hypervisors, and maps them to KubeVirt VirtualMachines.
+package ovf
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Resource types of the virtual hardware items, as defined by the
+// CIM_ResourceAllocationSettingData class of DMTF DSP1041
+const (
+	ResourceTypeProcessor       = 3
+	ResourceTypeMemory          = 4
+	ResourceTypeIDEController   = 5
+	ResourceTypeSCSIController  = 6
+	ResourceTypeEthernetAdapter = 10
+	ResourceTypeDiskDrive       = 17
+	// vSphere describes SATA and NVMe controllers as other storage devices
+	ResourceTypeOtherStorage  = 20
+	ResourceTypeSASController = 21
+)
+
+const (
+	maxDescriptorSize = 16 * 1024 * 1024
+	tarMagicOffset    = 257
+	tarMagic          = "ustar"
+)
+
+// Envelope is the part of an OVF descriptor needed to create a VirtualMachine
+type Envelope struct {
+	XMLName       xml.Name      `xml:"Envelope"`
+	References    []File        `xml:"References>File"`
+	Disks         []Disk        `xml:"DiskSection>Disk"`
+	Networks      []Network     `xml:"NetworkSection>Network"`
+	VirtualSystem VirtualSystem `xml:"VirtualSystem"`
+	// Archive is set if the descriptor was read from an OVA archive, whose
+	// files are extracted from it instead of being fetched separately
+	Archive bool `xml:"-"`
+}
+
+// File is an external file referenced by the descriptor
+type File struct {
+	ID   string `xml:"id,attr"`
+	Href string `xml:"href,attr"`
+	Size int64  `xml:"size,attr"`
+}
+
+// Disk is a virtual disk backed by a file of the References section
+type Disk struct {
+	DiskID                  string `xml:"diskId,attr"`
+	FileRef                 string `xml:"fileRef,attr"`
+	Capacity                string `xml:"capacity,attr"`
+	CapacityAllocationUnits string `xml:"capacityAllocationUnits,attr"`
+	Format                  string `xml:"format,attr"`
+}
+
+// Network is a logical network the virtual system connects to
+type Network struct {
+	Name string `xml:"name,attr"`
+}
+
+// VirtualSystem is the single virtual machine of the appliance
+type VirtualSystem struct {
+	ID      string   `xml:"id,attr"`
+	Name    string   `xml:"Name"`
+	Items   []Item   `xml:"VirtualHardwareSection>Item"`
+	Storage []Item   `xml:"VirtualHardwareSection>StorageItem"`
+	Ports   []Item   `xml:"VirtualHardwareSection>EthernetPortItem"`
+	Configs []Config `xml:"VirtualHardwareSection>Config"`
+}
+
+// Item is a virtual hardware item, the OVF 2 storage and ethernet port items
+// share the elements used here
+type Item struct {
+	InstanceID      string   `xml:"InstanceID"`
+	ElementName     string   `xml:"ElementName"`
+	ResourceType    int      `xml:"ResourceType"`
+	ResourceSubType string   `xml:"ResourceSubType"`
+	VirtualQuantity int64    `xml:"VirtualQuantity"`
+	AllocationUnits string   `xml:"AllocationUnits"`
+	Address         string   `xml:"Address"`
+	AddressOnParent string   `xml:"AddressOnParent"`
+	Parent          string   `xml:"Parent"`
+	HostResource    []string `xml:"HostResource"`
+	Connection      []string `xml:"Connection"`
+	CoresPerSocket  int64    `xml:"CoresPerSocket"`
+}
+
+// Config is a hypervisor specific setting of the virtual hardware, like the
+// vmw:Config elements written by vSphere
+type Config struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// HardwareItems returns all virtual hardware items, whatever element they
+// were described with
+func (s *VirtualSystem) HardwareItems() []Item {
+	items := make([]Item, 0, len(s.Items)+len(s.Storage)+len(s.Ports))
+	items = append(items, s.Items...)
+	items = append(items, s.Storage...)
+	return append(items, s.Ports...)
+}
+
+// Config returns the value of the hypervisor setting key
+func (s *VirtualSystem) Config(key string) (string, bool) {
+	for _, config := range s.Configs {
+		if config.Key == key {
+			return config.Value, true
+		}
+	}
+	return "", false
+}
+
+// ReadDescriptor parses the OVF descriptor from r, which is either the
+// descriptor itself or an OVA archive, whose first .ovf entry is used.
+func ReadDescriptor(r io.Reader) (*Envelope, error) {
+	reader := bufio.NewReader(r)
+	header, err := reader.Peek(tarMagicOffset + len(tarMagic))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read the descriptor: %v", err)
+	}
+	if len(header) == tarMagicOffset+len(tarMagic) && string(header[tarMagicOffset:]) == tarMagic {
+		return readArchiveDescriptor(reader)
+	}
+	return parseDescriptor(reader)
+}
+
+func readArchiveDescriptor(r io.Reader) (*Envelope, error) {
+	archive := tar.NewReader(r)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("the archive does not contain an OVF descriptor")
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read the archive: %v", err)
+		}
+		if header.Typeflag == tar.TypeReg && strings.EqualFold(path.Ext(header.Name), ".ovf") {
+			envelope, err := parseDescriptor(archive)
+			if err != nil {
+				return nil, err
+			}
+			envelope.Archive = true
+			return envelope, nil
+		}
+	}
+}
+
+func parseDescriptor(r io.Reader) (*Envelope, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxDescriptorSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the descriptor: %v", err)
+	}
+	if len(data) > maxDescriptorSize {
+		return nil, fmt.Errorf("the descriptor exceeds %d bytes", maxDescriptorSize)
+	}
+
+	envelope := &Envelope{}
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse the descriptor: %v", err)
+	}
+	if envelope.XMLName.Local != "Envelope" {
+		return nil, fmt.Errorf("the descriptor is not an OVF envelope")
+	}
+	return envelope, nil
+}
+
+var programmaticUnits = regexp.MustCompile(`^byte(?:\s*\*\s*(\d+)\s*\^\s*(\d+))?$`)
+
+// ParseAllocationUnits returns the number of bytes of one unit, for
+// programmatic units like "byte * 2^20" and the legacy unit names.
+func ParseAllocationUnits(units string) (int64, error) {
+	normalized := strings.ToLower(strings.TrimSpace(units))
+	switch normalized {
+	case "kilobytes", "kb":
+		return 1 << 10, nil
+	case "megabytes", "mb":
+		return 1 << 20, nil
+	case "gigabytes", "gb":
+		return 1 << 30, nil
+	case "terabytes", "tb":
+		return 1 << 40, nil
+	}
+
+	match := programmaticUnits.FindStringSubmatch(normalized)
+	if match == nil {
+		return 0, fmt.Errorf("unsupported allocation units %q", units)
+	}
+	if match[1] == "" {
+		return 1, nil
+	}
+	base, _ := strconv.ParseInt(match[1], 10, 64)
+	exponent, _ := strconv.ParseInt(match[2], 10, 64)
+	if base == 0 {
+		return 0, fmt.Errorf("unsupported allocation units %q", units)
+	}
+	multiplier := int64(1)
+	for i := int64(0); i < exponent; i++ {
+		if multiplier > (1<<62)/base {
+			return 0, fmt.Errorf("allocation units %q are too large", units)
+		}
+		multiplier *= base
+	}
+	return multiplier, nil
+}
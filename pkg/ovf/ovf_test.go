@@ -0,0 +1,210 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package ovf
+
+import (
+	"archive/tar"
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const vsphereDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope vmw:buildId="build-16324942" xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData" xmlns:vmw="http://www.vmware.com/schema/ovf">
+  <References>
+    <File ovf:href="appliance-disk1.vmdk" ovf:id="file1" ovf:size="1073741824"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:capacity="20" ovf:capacityAllocationUnits="byte * 2^30" ovf:diskId="vmdisk1" ovf:fileRef="file1" ovf:format="http://www.vmware.com/interfaces/specifications/vmdk.html#streamOptimized"/>
+    <Disk ovf:capacity="1073741824" ovf:diskId="vmdisk2"/>
+  </DiskSection>
+  <NetworkSection>
+    <Info>The list of logical networks</Info>
+    <Network ovf:name="VM Network"/>
+    <Network ovf:name="Storage"/>
+  </NetworkSection>
+  <VirtualSystem ovf:id="appliance">
+    <Info>A virtual machine</Info>
+    <Name>Web Appliance 01</Name>
+    <VirtualHardwareSection>
+      <Info>Virtual hardware requirements</Info>
+      <Item>
+        <rasd:AllocationUnits>hertz * 10^6</rasd:AllocationUnits>
+        <rasd:ElementName>4 virtual CPU(s)</rasd:ElementName>
+        <rasd:InstanceID>1</rasd:InstanceID>
+        <rasd:ResourceType>3</rasd:ResourceType>
+        <rasd:VirtualQuantity>4</rasd:VirtualQuantity>
+        <vmw:CoresPerSocket ovf:required="false">2</vmw:CoresPerSocket>
+      </Item>
+      <Item>
+        <rasd:AllocationUnits>byte * 2^20</rasd:AllocationUnits>
+        <rasd:ElementName>4096MB of memory</rasd:ElementName>
+        <rasd:InstanceID>2</rasd:InstanceID>
+        <rasd:ResourceType>4</rasd:ResourceType>
+        <rasd:VirtualQuantity>4096</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:Address>0</rasd:Address>
+        <rasd:ElementName>SCSI controller 0</rasd:ElementName>
+        <rasd:InstanceID>3</rasd:InstanceID>
+        <rasd:ResourceSubType>VirtualSCSI</rasd:ResourceSubType>
+        <rasd:ResourceType>6</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:Address>0</rasd:Address>
+        <rasd:ElementName>SATA controller 0</rasd:ElementName>
+        <rasd:InstanceID>4</rasd:InstanceID>
+        <rasd:ResourceSubType>vmware.sata.ahci</rasd:ResourceSubType>
+        <rasd:ResourceType>20</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:AddressOnParent>0</rasd:AddressOnParent>
+        <rasd:ElementName>Hard disk 1</rasd:ElementName>
+        <rasd:HostResource>ovf:/disk/vmdisk1</rasd:HostResource>
+        <rasd:InstanceID>5</rasd:InstanceID>
+        <rasd:Parent>3</rasd:Parent>
+        <rasd:ResourceType>17</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:AddressOnParent>0</rasd:AddressOnParent>
+        <rasd:ElementName>Hard disk 2</rasd:ElementName>
+        <rasd:HostResource>ovf:/disk/vmdisk2</rasd:HostResource>
+        <rasd:InstanceID>6</rasd:InstanceID>
+        <rasd:Parent>4</rasd:Parent>
+        <rasd:ResourceType>17</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:Address>00:50:56:aa:bb:cc</rasd:Address>
+        <rasd:AddressOnParent>7</rasd:AddressOnParent>
+        <rasd:AutomaticAllocation>true</rasd:AutomaticAllocation>
+        <rasd:Connection>VM Network</rasd:Connection>
+        <rasd:ElementName>Network adapter 1</rasd:ElementName>
+        <rasd:InstanceID>7</rasd:InstanceID>
+        <rasd:ResourceSubType>VmxNet3</rasd:ResourceSubType>
+        <rasd:ResourceType>10</rasd:ResourceType>
+      </Item>
+      <Item>
+        <rasd:AddressOnParent>8</rasd:AddressOnParent>
+        <rasd:Connection>Storage</rasd:Connection>
+        <rasd:ElementName>Network adapter 2</rasd:ElementName>
+        <rasd:InstanceID>8</rasd:InstanceID>
+        <rasd:ResourceSubType>E1000</rasd:ResourceSubType>
+        <rasd:ResourceType>10</rasd:ResourceType>
+      </Item>
+      <vmw:Config ovf:required="false" vmw:key="firmware" vmw:value="efi"/>
+      <vmw:Config ovf:required="false" vmw:key="uefi.secureBoot.enabled" vmw:value="true"/>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+func newArchive(files map[string]string, order ...string) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+	writer := tar.NewWriter(buffer)
+	for _, name := range order {
+		content := files[name]
+		Expect(writer.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})).To(Succeed())
+		_, err := writer.Write([]byte(content))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(writer.Close()).To(Succeed())
+	return buffer
+}
+
+var _ = Describe("OVF descriptor", func() {
+
+	It("should parse the descriptor written by vSphere", func() {
+		envelope, err := ReadDescriptor(strings.NewReader(vsphereDescriptor))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(envelope.References).To(ConsistOf(File{ID: "file1", Href: "appliance-disk1.vmdk", Size: 1073741824}))
+		Expect(envelope.Disks).To(HaveLen(2))
+		Expect(envelope.Disks[0].DiskID).To(Equal("vmdisk1"))
+		Expect(envelope.Disks[0].FileRef).To(Equal("file1"))
+		Expect(envelope.Disks[0].CapacityAllocationUnits).To(Equal("byte * 2^30"))
+		Expect(envelope.Networks).To(ConsistOf(Network{Name: "VM Network"}, Network{Name: "Storage"}))
+
+		system := envelope.VirtualSystem
+		Expect(system.ID).To(Equal("appliance"))
+		Expect(system.Name).To(Equal("Web Appliance 01"))
+		Expect(system.HardwareItems()).To(HaveLen(8))
+		Expect(system.Items[0].CoresPerSocket).To(Equal(int64(2)))
+		Expect(system.Items[6].Connection).To(ConsistOf("VM Network"))
+
+		firmware, exists := system.Config("firmware")
+		Expect(exists).To(BeTrue())
+		Expect(firmware).To(Equal("efi"))
+	})
+
+	It("should read the descriptor from an OVA archive", func() {
+		archive := newArchive(map[string]string{
+			"appliance.ovf":        vsphereDescriptor,
+			"appliance.mf":         "SHA256(appliance.ovf)= 00",
+			"appliance-disk1.vmdk": "disk",
+		}, "appliance.mf", "appliance.ovf", "appliance-disk1.vmdk")
+
+		envelope, err := ReadDescriptor(archive)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(envelope.VirtualSystem.Name).To(Equal("Web Appliance 01"))
+		Expect(envelope.Archive).To(BeTrue())
+	})
+
+	It("should fail on archives without descriptor", func() {
+		archive := newArchive(map[string]string{"appliance-disk1.vmdk": "disk"}, "appliance-disk1.vmdk")
+
+		_, err := ReadDescriptor(archive)
+		Expect(err).To(MatchError(ContainSubstring("does not contain an OVF descriptor")))
+	})
+
+	It("should reject documents which are not an OVF envelope", func() {
+		_, err := ReadDescriptor(strings.NewReader(`<domain type="kvm"></domain>`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	table.DescribeTable("should parse allocation units", func(units string, expected int64) {
+		multiplier, err := ParseAllocationUnits(units)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(multiplier).To(Equal(expected))
+	},
+		table.Entry("for bytes", "byte", int64(1)),
+		table.Entry("for programmatic units", "byte * 2^20", int64(1<<20)),
+		table.Entry("for programmatic units without spaces", "byte*2^30", int64(1<<30)),
+		table.Entry("for decimal programmatic units", "byte * 10^3", int64(1000)),
+		table.Entry("for legacy unit names", "MegaBytes", int64(1<<20)),
+	)
+
+	table.DescribeTable("should reject allocation units", func(units string) {
+		_, err := ParseAllocationUnits(units)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("for frequencies", "hertz * 10^6"),
+		table.Entry("overflowing int64", "byte * 2^64"),
+		table.Entry("with a zero base", "byte * 0^2"),
+	)
+})
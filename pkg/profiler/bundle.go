@@ -0,0 +1,157 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package profiler
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// errorsFileName lists the profiles which could not be collected
+	errorsFileName = "errors.txt"
+
+	// socketRequestTimeout leaves time to the profiled process to answer
+	// after the longest recording
+	socketRequestTimeout = MaxDuration + 30*time.Second
+)
+
+// Source collects the profiles of a process
+type Source struct {
+	// Name is the directory of the profiles in the bundle
+	Name    string
+	Collect func(w io.Writer, profile string, duration time.Duration) error
+}
+
+// LocalSource collects the profiles of the current process
+func LocalSource(name string) Source {
+	return Source{Name: name, Collect: WriteProfile}
+}
+
+// SocketSource collects the profiles of a process serving them on a unix socket
+func SocketSource(name string, socketPath string) Source {
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+		Timeout: socketRequestTimeout,
+	}
+
+	collect := func(w io.Writer, profile string, duration time.Duration) error {
+		resp, err := client.Get(fmt.Sprintf("http://profiler%s%s?seconds=%d", profilePath, profile, int(duration.Seconds())))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+			return fmt.Errorf("unexpected return code %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		}
+		_, err = io.Copy(w, resp.Body)
+		return err
+	}
+	return Source{Name: name, Collect: collect}
+}
+
+// Bundle collects the profiles of several processes at once
+type Bundle struct {
+	Sources []Source
+	// Errors are listed with the profiles which could not be collected
+	Errors []string
+}
+
+// Write collects all profiles of all sources concurrently and writes them to
+// a gzipped tar archive, with a directory per source. A profile which can't be
+// collected does not fail the bundle, its error is listed in errors.txt.
+func (b *Bundle) Write(w io.Writer, duration time.Duration) error {
+	profiles := make([][]*bytes.Buffer, len(b.Sources))
+	errs := make([][]error, len(b.Sources))
+
+	wg := sync.WaitGroup{}
+	for i, source := range b.Sources {
+		profiles[i] = make([]*bytes.Buffer, len(Profiles))
+		errs[i] = make([]error, len(Profiles))
+		for j, profile := range Profiles {
+			profiles[i][j] = &bytes.Buffer{}
+			wg.Add(1)
+			go func(i, j int, source Source, profile string) {
+				defer wg.Done()
+				errs[i][j] = source.Collect(profiles[i][j], profile, duration)
+			}(i, j, source, profile)
+		}
+	}
+	wg.Wait()
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	now := time.Now()
+
+	errorLines := append([]string{}, b.Errors...)
+	for i, source := range b.Sources {
+		for j, profile := range Profiles {
+			if errs[i][j] != nil {
+				errorLines = append(errorLines, fmt.Sprintf("%s: %s: %v", source.Name, profile, errs[i][j]))
+				continue
+			}
+			if err := writeFile(tarWriter, path.Join(source.Name, FileName(profile)), profiles[i][j].Bytes(), now); err != nil {
+				return err
+			}
+		}
+	}
+	if len(errorLines) > 0 {
+		if err := writeFile(tarWriter, errorsFileName, []byte(strings.Join(errorLines, "\n")+"\n"), now); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func writeFile(tarWriter *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tarWriter.Write(data)
+	return err
+}
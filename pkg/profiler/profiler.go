@@ -0,0 +1,161 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package profiler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ProfileCPU       = "cpu"
+	ProfileTrace     = "trace"
+	ProfileHeap      = "heap"
+	ProfileAllocs    = "allocs"
+	ProfileGoroutine = "goroutine"
+
+	// DefaultDuration is the time the CPU profile and the trace are recorded for
+	DefaultDuration = 20 * time.Second
+
+	// MaxDuration keeps the requests through the API server below its request timeout
+	MaxDuration = 50 * time.Second
+
+	profilePath = "/profile/"
+)
+
+// Profiles lists the profiles of a bundle
+var Profiles = []string{ProfileCPU, ProfileTrace, ProfileHeap, ProfileAllocs, ProfileGoroutine}
+
+// ParseDuration parses the seconds parameter of a request, an empty parameter
+// is the default duration
+func ParseDuration(seconds string) (time.Duration, error) {
+	if seconds == "" {
+		return DefaultDuration, nil
+	}
+	s, err := strconv.Atoi(seconds)
+	if err != nil {
+		return 0, fmt.Errorf("invalid seconds %q: %v", seconds, err)
+	}
+	duration := time.Duration(s) * time.Second
+	if duration <= 0 || duration > MaxDuration {
+		return 0, fmt.Errorf("seconds has to be between 1 and %d", int(MaxDuration.Seconds()))
+	}
+	return duration, nil
+}
+
+func isProfile(profile string) bool {
+	for _, p := range Profiles {
+		if p == profile {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteProfile writes a profile of the current process. The CPU profile and
+// the trace are recorded for the duration, the other profiles are snapshots.
+func WriteProfile(w io.Writer, profile string, duration time.Duration) error {
+	switch profile {
+	case ProfileCPU:
+		if err := pprof.StartCPUProfile(w); err != nil {
+			return err
+		}
+		time.Sleep(duration)
+		pprof.StopCPUProfile()
+		return nil
+	case ProfileTrace:
+		if err := trace.Start(w); err != nil {
+			return err
+		}
+		time.Sleep(duration)
+		trace.Stop()
+		return nil
+	case ProfileHeap, ProfileAllocs, ProfileGoroutine:
+		return pprof.Lookup(profile).WriteTo(w, 0)
+	}
+	return fmt.Errorf("unknown profile %s", profile)
+}
+
+// FileName returns the name of the file of a profile in a bundle
+func FileName(profile string) string {
+	if profile == ProfileTrace {
+		return "trace.out"
+	}
+	return profile + ".pprof"
+}
+
+// NewHandler returns a handler serving the profiles of the current process
+// at /profile/<profile>?seconds=<seconds>
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(profilePath, func(w http.ResponseWriter, r *http.Request) {
+		profile := strings.TrimPrefix(r.URL.Path, profilePath)
+		if !isProfile(profile) {
+			http.Error(w, fmt.Sprintf("unknown profile %s", profile), http.StatusNotFound)
+			return
+		}
+		duration, err := ParseDuration(r.URL.Query().Get("seconds"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the profile is buffered to report a failure with the status code
+		buf := &bytes.Buffer{}
+		if err := WriteProfile(buf, profile, duration); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(buf.Bytes())
+	})
+	return mux
+}
+
+// Serve serves the profiles of the current process on a unix socket until
+// stop is closed
+func Serve(socketPath string, stop <-chan struct{}) error {
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Handler: NewHandler()}
+	go func() {
+		<-stop
+		server.Close()
+	}()
+	if err := server.Serve(listener); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package profiler
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiler", func() {
+
+	table.DescribeTable("should parse the duration", func(seconds string, duration time.Duration, valid bool) {
+		d, err := ParseDuration(seconds)
+		if !valid {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(d).To(Equal(duration))
+	},
+		table.Entry("with the default for an empty parameter", "", DefaultDuration, true),
+		table.Entry("with seconds", "5", 5*time.Second, true),
+		table.Entry("with the maximum", "50", MaxDuration, true),
+		table.Entry("rejecting zero", "0", time.Duration(0), false),
+		table.Entry("rejecting more than the maximum", "51", time.Duration(0), false),
+		table.Entry("rejecting invalid numbers", "5s", time.Duration(0), false),
+	)
+
+	table.DescribeTable("should answer requests for", func(path string, code int) {
+		recorder := httptest.NewRecorder()
+		NewHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		Expect(recorder.Code).To(Equal(code))
+		if code == http.StatusOK {
+			Expect(recorder.Body.Len()).ToNot(BeZero())
+		}
+	},
+		table.Entry("snapshots", "/profile/goroutine", http.StatusOK),
+		table.Entry("recordings", "/profile/trace?seconds=1", http.StatusOK),
+		table.Entry("unknown profiles with not found", "/profile/madeup", http.StatusNotFound),
+		table.Entry("invalid durations with bad request", "/profile/cpu?seconds=500", http.StatusBadRequest),
+	)
+
+	Context("with a bundle", func() {
+
+		readBundle := func(data []byte) map[string]string {
+			gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+			Expect(err).ToNot(HaveOccurred())
+			tarReader := tar.NewReader(gzipReader)
+			files := map[string]string{}
+			for {
+				header, err := tarReader.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).ToNot(HaveOccurred())
+				content, err := ioutil.ReadAll(tarReader)
+				Expect(err).ToNot(HaveOccurred())
+				files[header.Name] = string(content)
+			}
+			return files
+		}
+
+		fakeSource := func(name string, failing string) Source {
+			return Source{Name: name, Collect: func(w io.Writer, profile string, duration time.Duration) error {
+				if profile == failing {
+					return fmt.Errorf("failed")
+				}
+				_, err := fmt.Fprintf(w, "%s %s %s", name, profile, duration)
+				return err
+			}}
+		}
+
+		It("should contain the profiles of all sources and their errors", func() {
+			bundle := &Bundle{
+				Sources: []Source{fakeSource("virt-handler", ""), fakeSource("virt-launcher/default/testvmi", ProfileTrace)},
+				Errors:  []string{"virt-launcher/default/othervmi: profiling is not enabled"},
+			}
+			buf := &bytes.Buffer{}
+			Expect(bundle.Write(buf, time.Second)).To(Succeed())
+
+			files := readBundle(buf.Bytes())
+			Expect(files).To(HaveLen(10))
+			Expect(files).To(HaveKeyWithValue("virt-handler/cpu.pprof", "virt-handler cpu 1s"))
+			Expect(files).To(HaveKeyWithValue("virt-handler/trace.out", "virt-handler trace 1s"))
+			Expect(files).To(HaveKeyWithValue("virt-launcher/default/testvmi/heap.pprof", "virt-launcher/default/testvmi heap 1s"))
+			Expect(files).ToNot(HaveKey("virt-launcher/default/testvmi/trace.out"))
+			Expect(files).To(HaveKeyWithValue("errors.txt",
+				"virt-launcher/default/othervmi: profiling is not enabled\n"+
+					"virt-launcher/default/testvmi: trace: failed\n"))
+		})
+
+		It("should collect the profiles of a process serving them on a unix socket", func() {
+			dir, err := ioutil.TempDir("", "profiler")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			socket := filepath.Join(dir, "profiler-sock")
+			stop := make(chan struct{})
+			served := make(chan error, 1)
+			go func() {
+				served <- Serve(socket, stop)
+			}()
+			Eventually(func() error {
+				_, err := os.Stat(socket)
+				return err
+			}).Should(Succeed())
+
+			buf := &bytes.Buffer{}
+			Expect(SocketSource("virt-launcher", socket).Collect(buf, ProfileGoroutine, time.Second)).To(Succeed())
+			Expect(buf.Len()).ToNot(BeZero())
+
+			err = SocketSource("virt-launcher", socket).Collect(buf, "madeup", time.Second)
+			Expect(err).To(MatchError(ContainSubstring("unknown profile madeup")))
+
+			close(stop)
+			Eventually(served).Should(Receive(BeNil()))
+		})
+	})
+})
@@ -0,0 +1,64 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// MountPoint is where the cgroup hierarchies are mounted, relative to the root of a mount namespace
+const MountPoint = "/sys/fs/cgroup"
+
+// IsUnified returns true if path is a cgroup of the cgroup v2 unified hierarchy.
+// Every cgroup of the unified hierarchy, including the root, has a cgroup.controllers
+// file, while cgroup v1 hierarchies have none.
+func IsUnified(path string) bool {
+	_, err := os.Stat(filepath.Join(path, "cgroup.controllers"))
+	return err == nil
+}
+
+// ControllerPath returns the root of the hierarchy of a controller in the mount
+// namespace at root. On hosts with the unified hierarchy all controllers share
+// the same hierarchy, otherwise every controller has its own one.
+func ControllerPath(root string, controller string) string {
+	mountPoint := filepath.Join(root, MountPoint)
+	if IsUnified(mountPoint) {
+		return mountPoint
+	}
+	return filepath.Join(mountPoint, controller)
+}
+
+const (
+	minBlkioWeight = 10
+	maxBlkioWeight = 1000
+)
+
+// BlkioToIOWeight converts a cgroup v1 blkio.weight, between 10 and 1000, into
+// a cgroup v2 io.weight, between 1 and 10000. Weights out of the blkio range
+// are clamped to it first.
+func BlkioToIOWeight(weight uint16) uint64 {
+	if weight < minBlkioWeight {
+		weight = minBlkioWeight
+	} else if weight > maxBlkioWeight {
+		weight = maxBlkioWeight
+	}
+	return 1 + (uint64(weight)-minBlkioWeight)*9999/(maxBlkioWeight-minBlkioWeight)
+}
@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cgroup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cgroup", func() {
+	var root string
+
+	BeforeEach(func() {
+		var err error
+		root, err = ioutil.TempDir("", "cgroup")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Join(root, MountPoint), 0755)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(root)
+	})
+
+	It("should use one hierarchy per controller on cgroup v1 hosts", func() {
+		Expect(IsUnified(filepath.Join(root, MountPoint))).To(BeFalse())
+		Expect(ControllerPath(root, "devices")).To(Equal(filepath.Join(root, MountPoint, "devices")))
+	})
+
+	It("should use the unified hierarchy for all controllers on cgroup v2 hosts", func() {
+		Expect(ioutil.WriteFile(filepath.Join(root, MountPoint, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644)).To(Succeed())
+		Expect(IsUnified(filepath.Join(root, MountPoint))).To(BeTrue())
+		Expect(ControllerPath(root, "devices")).To(Equal(filepath.Join(root, MountPoint)))
+	})
+
+	table.DescribeTable("should convert blkio weights into io weights", func(weight uint16, expected uint64) {
+		Expect(BlkioToIOWeight(weight)).To(Equal(expected))
+	},
+		table.Entry("minimum", uint16(10), uint64(1)),
+		table.Entry("default", uint16(500), uint64(4950)),
+		table.Entry("maximum", uint16(1000), uint64(10000)),
+		table.Entry("below the minimum", uint16(5), uint64(1)),
+		table.Entry("zero", uint16(0), uint64(1)),
+		table.Entry("above the maximum", uint16(2000), uint64(10000)),
+	)
+})
@@ -0,0 +1,241 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package cron parses the five field cron syntax used by the schedules of
+// KubeVirt and computes their next run. Times are evaluated in UTC, unless a
+// time zone is given.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds the search for the next run, a schedule without a run in
+// this time, like one for the 30th of February, never runs
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: monthNames}
+	// 7 is accepted for Sunday as well
+	dowField = field{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+// Schedule is a parsed cron schedule
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// if both day fields are restricted a day matching either of them runs
+	domRestricted bool
+	dowRestricted bool
+}
+
+// Parse parses a schedule in the five field cron syntax
+// "minute hour day-of-month month day-of-week" or one of the macros
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly.
+// Fields are lists of values, ranges and steps, like "1,15", "9-17" or "*/10".
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := macros[spec]
+		if !ok {
+			return nil, fmt.Errorf("unknown schedule macro %s", spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in schedule %q, found %d", spec, len(fields))
+	}
+
+	schedule := &Schedule{}
+	var err error
+	if schedule.minute, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		itemBits, err := parseItem(item, f)
+		if err != nil {
+			return 0, err
+		}
+		bits |= itemBits
+	}
+	return bits, nil
+}
+
+func parseItem(item string, f field) (uint64, error) {
+	rangePart, step := item, 1
+	if i := strings.Index(item, "/"); i >= 0 {
+		var err error
+		rangePart = item[:i]
+		step, err = strconv.Atoi(item[i+1:])
+		if err != nil || step < 1 {
+			return 0, fmt.Errorf("invalid step %q in %s field", item[i+1:], f.name)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangePart == "*":
+		start, end = f.min, f.max
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if start, err = parseValue(bounds[0], f); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(bounds[1], f); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+		}
+	default:
+		var err error
+		if start, err = parseValue(rangePart, f); err != nil {
+			return 0, err
+		}
+		end = start
+		// "5/15" runs from 5 to the end of the range
+		if step > 1 {
+			end = f.max
+		}
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d in %s field is out of range %d-%d", v, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time of the schedule after t, in UTC. The zero time
+// is returned if the schedule never runs.
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.NextIn(t, time.UTC)
+}
+
+// NextIn returns the first time of the schedule after t, with the fields
+// evaluated in the time zone loc. A time skipped by a daylight saving time
+// change doesn't run, a repeated one runs twice.
+func (s *Schedule) NextIn(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
@@ -0,0 +1,96 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+
+	// Friday
+	from := time.Date(2021, time.January, 1, 10, 30, 15, 0, time.UTC)
+
+	table.DescribeTable("should compute the next run of", func(spec string, expected time.Time) {
+		schedule, err := Parse(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(from)).To(Equal(expected))
+	},
+		table.Entry("every minute", "* * * * *", time.Date(2021, time.January, 1, 10, 31, 0, 0, time.UTC)),
+		table.Entry("a step of minutes", "*/15 * * * *", time.Date(2021, time.January, 1, 10, 45, 0, 0, time.UTC)),
+		table.Entry("a step from a start value", "5/20 * * * *", time.Date(2021, time.January, 1, 10, 45, 0, 0, time.UTC)),
+		table.Entry("a list of hours", "0 3,12 * * *", time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)),
+		table.Entry("a range of hours", "0 1-5 * * *", time.Date(2021, time.January, 2, 1, 0, 0, 0, time.UTC)),
+		table.Entry("a day of week", "0 2 * * mon", time.Date(2021, time.January, 4, 2, 0, 0, 0, time.UTC)),
+		table.Entry("Sunday as 7", "0 2 * * 7", time.Date(2021, time.January, 3, 2, 0, 0, 0, time.UTC)),
+		table.Entry("a month name", "0 0 1 mar *", time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("either day field if both are restricted", "0 0 15 * mon", time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)),
+		table.Entry("the 29th of February", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		table.Entry("the hourly macro", "@hourly", time.Date(2021, time.January, 1, 11, 0, 0, 0, time.UTC)),
+		table.Entry("the daily macro", "@daily", time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)),
+		table.Entry("the weekly macro", "@weekly", time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC)),
+		table.Entry("the monthly macro", "@monthly", time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("the yearly macro", "@yearly", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should return the zero time for a schedule which never runs", func() {
+		schedule, err := Parse("0 0 30 2 *")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(from).IsZero()).To(BeTrue())
+	})
+
+	It("should evaluate the schedule in UTC", func() {
+		schedule, err := Parse("0 12 * * *")
+		Expect(err).ToNot(HaveOccurred())
+		local := time.Date(2021, time.January, 1, 11, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+		Expect(schedule.Next(local)).To(Equal(time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)))
+	})
+
+	It("should evaluate the schedule in a time zone", func() {
+		schedule, err := Parse("0 8 * * *")
+		Expect(err).ToNot(HaveOccurred())
+		zone := time.FixedZone("UTC+2", 2*60*60)
+		Expect(schedule.NextIn(from, zone)).To(Equal(time.Date(2021, time.January, 2, 8, 0, 0, 0, zone)))
+		Expect(schedule.NextIn(from, zone).UTC()).To(Equal(time.Date(2021, time.January, 2, 6, 0, 0, 0, time.UTC)))
+	})
+
+	It("should advance hours in time zones with a partial hour offset", func() {
+		schedule, err := Parse("0 17 * * *")
+		Expect(err).ToNot(HaveOccurred())
+		zone := time.FixedZone("UTC+5:30", 5*60*60+30*60)
+		Expect(schedule.NextIn(from, zone)).To(Equal(time.Date(2021, time.January, 1, 17, 0, 0, 0, zone)))
+	})
+
+	table.DescribeTable("should reject", func(spec string, expectedErr string) {
+		_, err := Parse(spec)
+		Expect(err).To(MatchError(expectedErr))
+	},
+		table.Entry("too few fields", "* * * *", `expected 5 fields in schedule "* * * *", found 4`),
+		table.Entry("an unknown macro", "@often", "unknown schedule macro @often"),
+		table.Entry("a value out of range", "60 * * * *", "value 60 in minute field is out of range 0-59"),
+		table.Entry("an invalid value", "* x * * *", `invalid value "x" in hour field`),
+		table.Entry("an inverted range", "* * 10-5 * *", `invalid range "10-5" in day of month field`),
+		table.Entry("a zero step", "*/0 * * * *", `invalid step "0" in minute field`),
+	)
+})
@@ -28,6 +28,10 @@ import (
 
 const CPUSET_PATH = "/sys/fs/cgroup/cpuset/cpuset.cpus"
 
+// CPUSET_UNIFIED_PATH holds the CPUs of the cgroup of a container on hosts with
+// the cgroup v2 unified hierarchy
+const CPUSET_UNIFIED_PATH = "/sys/fs/cgroup/cpuset.cpus.effective"
+
 // Parse linux cpuset into an array of ints
 // See: http://man7.org/linux/man-pages/man7/cpuset.7.html#FORMATS
 func ParseCPUSetLine(cpusetLine string) (cpusList []int, err error) {
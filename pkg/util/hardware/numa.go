@@ -0,0 +1,168 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package hardware
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NUMANodesPath is where the kernel exposes the NUMA nodes of the host
+const NUMANodesPath = "/sys/devices/system/node"
+
+// NUMAHugepagesResourcePrefix prefixes the extended resources advertising
+// the hugepages of the host NUMA nodes
+const NUMAHugepagesResourcePrefix = "numa.kubevirt.io/"
+
+var (
+	nodeDirRegex      = regexp.MustCompile(`^node(\d+)$`)
+	hugepagesDirRegex = regexp.MustCompile(`^hugepages-(\d+)kB$`)
+)
+
+// NUMANode is a NUMA node of the host with its CPUs and hugepage pools
+type NUMANode struct {
+	ID        int
+	CPUs      []int
+	Hugepages []HugepagesPool
+}
+
+// HugepagesPool is the pool of hugepages of one size on a NUMA node
+type HugepagesPool struct {
+	// PageSize is the size of a page in bytes
+	PageSize int64
+	// Total is the number of pages in the pool
+	Total int64
+	// Free is the number of pages not allocated yet
+	Free int64
+}
+
+// GetNUMANodes reads the NUMA nodes of the host below basePath, sorted by
+// their ID. A host without NUMA support has no nodes.
+func GetNUMANodes(basePath string) ([]NUMANode, error) {
+	entries, err := ioutil.ReadDir(basePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var nodes []NUMANode
+	for _, entry := range entries {
+		match := nodeDirRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		id, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, err
+		}
+
+		nodePath := filepath.Join(basePath, entry.Name())
+		cpuList, err := readSysfsValue(filepath.Join(nodePath, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		node := NUMANode{ID: id}
+		// nodes with memory only have no CPUs
+		if cpuList != "" {
+			if node.CPUs, err = ParseCPUSetLine(cpuList); err != nil {
+				return nil, fmt.Errorf("failed to parse the cpulist of NUMA node %d: %v", id, err)
+			}
+		}
+		if node.Hugepages, err = getHugepagesPools(filepath.Join(nodePath, "hugepages")); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes, nil
+}
+
+func getHugepagesPools(hugepagesPath string) ([]HugepagesPool, error) {
+	entries, err := ioutil.ReadDir(hugepagesPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var pools []HugepagesPool
+	for _, entry := range entries {
+		match := hugepagesDirRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		sizeKiB, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		pool := HugepagesPool{PageSize: sizeKiB * 1024}
+		poolPath := filepath.Join(hugepagesPath, entry.Name())
+		if pool.Total, err = readSysfsInt(filepath.Join(poolPath, "nr_hugepages")); err != nil {
+			return nil, err
+		}
+		if pool.Free, err = readSysfsInt(filepath.Join(poolPath, "free_hugepages")); err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].PageSize < pools[j].PageSize
+	})
+	return pools, nil
+}
+
+// NUMAHugepagesResourceName returns the extended resource advertising the
+// hugepages of the given size on a host NUMA node, like
+// numa.kubevirt.io/node0-hugepages-2Mi
+func NUMAHugepagesResourceName(nodeID int, pageSize int64) k8sv1.ResourceName {
+	size := resource.NewQuantity(pageSize, resource.BinarySI)
+	return k8sv1.ResourceName(fmt.Sprintf("%snode%d-hugepages-%s", NUMAHugepagesResourcePrefix, nodeID, size.String()))
+}
+
+func readSysfsValue(path string) (string, error) {
+	// #nosec No risk for path injection. path is composed of sysfs entries below a static base path
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func readSysfsInt(path string) (int64, error) {
+	value, err := readSysfsValue(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
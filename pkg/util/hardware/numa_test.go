@@ -0,0 +1,112 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package hardware
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("NUMA nodes", func() {
+	var nodesPath string
+
+	writeFile := func(path string, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	writeHugepages := func(node string, size string, total string, free string) {
+		poolPath := filepath.Join(nodesPath, node, "hugepages", "hugepages-"+size)
+		writeFile(filepath.Join(poolPath, "nr_hugepages"), total)
+		writeFile(filepath.Join(poolPath, "free_hugepages"), free)
+	}
+
+	BeforeEach(func() {
+		var err error
+		nodesPath, err = ioutil.TempDir("", "numa")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(nodesPath)
+	})
+
+	It("should read the CPUs and hugepage pools of the nodes", func() {
+		writeFile(filepath.Join(nodesPath, "node1", "cpulist"), "4-7\n")
+		writeHugepages("node1", "2048kB", "512\n", "256\n")
+		writeFile(filepath.Join(nodesPath, "node0", "cpulist"), "0-3\n")
+		writeHugepages("node0", "1048576kB", "4\n", "4\n")
+		writeHugepages("node0", "2048kB", "0\n", "0\n")
+		writeFile(filepath.Join(nodesPath, "possible"), "0-1\n")
+
+		nodes, err := GetNUMANodes(nodesPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nodes).To(Equal([]NUMANode{
+			{
+				ID:   0,
+				CPUs: []int{0, 1, 2, 3},
+				Hugepages: []HugepagesPool{
+					{PageSize: 2 * 1024 * 1024, Total: 0, Free: 0},
+					{PageSize: 1024 * 1024 * 1024, Total: 4, Free: 4},
+				},
+			},
+			{
+				ID:   1,
+				CPUs: []int{4, 5, 6, 7},
+				Hugepages: []HugepagesPool{
+					{PageSize: 2 * 1024 * 1024, Total: 512, Free: 256},
+				},
+			},
+		}))
+	})
+
+	It("should read nodes without CPUs", func() {
+		writeFile(filepath.Join(nodesPath, "node0", "cpulist"), "\n")
+
+		nodes, err := GetNUMANodes(nodesPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nodes).To(Equal([]NUMANode{{ID: 0}}))
+	})
+
+	It("should not find nodes on a host without NUMA support", func() {
+		nodes, err := GetNUMANodes(filepath.Join(nodesPath, "missing"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nodes).To(BeEmpty())
+	})
+
+	It("should fail on an invalid hugepage pool", func() {
+		writeFile(filepath.Join(nodesPath, "node0", "cpulist"), "0\n")
+		writeHugepages("node0", "2048kB", "many\n", "0\n")
+
+		_, err := GetNUMANodes(nodesPath)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should name the hugepages resources of the nodes", func() {
+		Expect(NUMAHugepagesResourceName(0, 2*1024*1024)).To(Equal(k8sv1.ResourceName("numa.kubevirt.io/node0-hugepages-2Mi")))
+		Expect(NUMAHugepagesResourceName(1, 1024*1024*1024)).To(Equal(k8sv1.ResourceName("numa.kubevirt.io/node1-hugepages-1Gi")))
+	})
+})
@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package allowlist restricts the URLs KubeVirt components connect to on
+// behalf of users to the URL prefixes allowed by the cluster admin
+package allowlist
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// URLAllowed tells whether rawURL starts with one of the allowed URL
+// prefixes. The scheme and host have to match exactly and the path has to be
+// below the path of the prefix, so that "https://example.com/images" allows
+// "https://example.com/images/disk.img" but not
+// "https://example.com/images-private/disk.img".
+func URLAllowed(rawURL string, prefixes []string) bool {
+	target, err := url.Parse(rawURL)
+	if err != nil || !target.IsAbs() || target.Host == "" || target.User != nil {
+		return false
+	}
+	for _, prefix := range prefixes {
+		allowed, err := url.Parse(prefix)
+		if err != nil || !allowed.IsAbs() {
+			continue
+		}
+		if !strings.EqualFold(target.Scheme, allowed.Scheme) || !strings.EqualFold(target.Host, allowed.Host) {
+			continue
+		}
+		if pathBelow(target.Path, allowed.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckURL returns an error unless rawURL starts with one of the allowed URL
+// prefixes
+func CheckURL(rawURL string, prefixes []string) error {
+	if !URLAllowed(rawURL, prefixes) {
+		return fmt.Errorf("URL %q is not allowed by the cluster configuration", rawURL)
+	}
+	return nil
+}
+
+// CheckRedirect is an http.Client CheckRedirect function which refuses
+// redirects to URLs which are not allowed
+func CheckRedirect(prefixes []string) func(*http.Request, []*http.Request) error {
+	return func(request *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		return CheckURL(request.URL.String(), prefixes)
+	}
+}
+
+func pathBelow(target string, prefix string) bool {
+	prefix = path.Clean("/" + prefix)
+	target = path.Clean("/" + target)
+	if prefix == "/" || target == prefix {
+		return true
+	}
+	return strings.HasPrefix(target, prefix+"/")
+}
@@ -0,0 +1,160 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package egress keeps KubeVirt components from connecting to loopback,
+// link-local, private or cluster-internal destinations on behalf of users
+package egress
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// blockedNetworks are the networks of loopback, link-local (including the
+// cloud metadata services), private, shared, multicast and reserved addresses
+var blockedNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// internalDomains are the domains which only resolve inside of the cluster
+// or the host
+var internalDomains = []string{
+	"localhost",
+	"local",
+	"internal",
+	"svc",
+}
+
+// ErrRedirect is returned when a server responds with a redirect, which is
+// not followed since it could point to an internal destination
+var ErrRedirect = fmt.Errorf("redirects are not followed")
+
+// IPAllowed tells whether ip is a public unicast address
+func IPAllowed(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckHost returns an error if host is an IP address which is not public or
+// a name in a cluster-internal domain
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return fmt.Errorf("missing host")
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if !IPAllowed(ip) {
+			return fmt.Errorf("%s is not a public address", host)
+		}
+		return nil
+	}
+	for _, domain := range internalDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return fmt.Errorf("%s is a cluster-internal name", host)
+		}
+	}
+	if !strings.Contains(host, ".") {
+		return fmt.Errorf("%s is not a fully qualified name", host)
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control function which refuses to connect to
+// addresses which are not public. It runs after the name was resolved, so
+// that names resolving to internal addresses are refused too.
+func Control(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IPAllowed(ip) {
+		return fmt.Errorf("connecting to %s is not allowed, it is not a public address", host)
+	}
+	return nil
+}
+
+// CheckRedirect is an http.Client CheckRedirect function which refuses all
+// redirects
+func CheckRedirect(_ *http.Request, _ []*http.Request) error {
+	return ErrRedirect
+}
+
+// NewHTTPClient returns an http.Client which only connects directly to
+// public addresses and does not follow redirects
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   Control,
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// No proxy, the proxy would connect to the destination instead
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: CheckRedirect,
+	}
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package egress
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Egress", func() {
+
+	table.DescribeTable("should tell whether an IP is public", func(ip string, allowed bool) {
+		Expect(IPAllowed(net.ParseIP(ip))).To(Equal(allowed))
+	},
+		table.Entry("public IPv4", "93.184.216.34", true),
+		table.Entry("public IPv6", "2606:2800:220:1:248:1893:25c8:1946", true),
+		table.Entry("loopback", "127.0.0.1", false),
+		table.Entry("IPv6 loopback", "::1", false),
+		table.Entry("metadata service", "169.254.169.254", false),
+		table.Entry("private", "10.96.0.1", false),
+		table.Entry("private 172.16/12", "172.20.0.5", false),
+		table.Entry("private 192.168/16", "192.168.1.1", false),
+		table.Entry("shared", "100.64.0.1", false),
+		table.Entry("unspecified", "0.0.0.0", false),
+		table.Entry("IPv6 unique local", "fd00::1", false),
+		table.Entry("IPv6 link-local", "fe80::1", false),
+		table.Entry("IPv4-mapped loopback", "::ffff:127.0.0.1", false),
+		table.Entry("multicast", "239.1.1.1", false),
+	)
+
+	table.DescribeTable("should check hosts", func(host string, allowed bool) {
+		if allowed {
+			Expect(CheckHost(host)).To(Succeed())
+		} else {
+			Expect(CheckHost(host)).ToNot(Succeed())
+		}
+	},
+		table.Entry("a public name", "cmdb.example.com", true),
+		table.Entry("a public address", "93.184.216.34", true),
+		table.Entry("localhost", "localhost", false),
+		table.Entry("a name below localhost", "foo.localhost", false),
+		table.Entry("a service", "ticketing.tools.svc", false),
+		table.Entry("a fully qualified service", "ticketing.tools.svc.cluster.local.", false),
+		table.Entry("the GCE metadata service", "metadata.google.internal", false),
+		table.Entry("a short name", "ticketing", false),
+		table.Entry("a loopback address", "127.0.0.1", false),
+		table.Entry("an IPv6 loopback address", "::1", false),
+		table.Entry("an empty host", "", false),
+	)
+
+	It("should refuse to connect to internal addresses", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		_, err := NewHTTPClient(time.Second).Get(server.URL)
+		Expect(err).To(MatchError(ContainSubstring("not a public address")))
+	})
+
+	It("should refuse redirects", func() {
+		request := httptest.NewRequest(http.MethodGet, "http://cmdb.example.com/", nil)
+		Expect(CheckRedirect(request, []*http.Request{request})).To(MatchError(ErrRedirect))
+	})
+})
@@ -0,0 +1,147 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package nad
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	authv1 "k8s.io/api/authorization/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// UseVerb is the verb a ServiceAccount needs to be granted on a
+	// NetworkAttachmentDefinition of another namespace to attach to it
+	UseVerb = "use"
+
+	group    = "k8s.cni.cncf.io"
+	resource = "network-attachment-definitions"
+)
+
+type SubjectAccessReviewsProxy interface {
+	Create(*authv1.SubjectAccessReview) (*authv1.SubjectAccessReview, error)
+}
+
+// AuthFunc tells whether the ServiceAccount saName of saNamespace may use the
+// NetworkAttachmentDefinition nadName of nadNamespace, with a message if not
+type AuthFunc func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error)
+
+// GetFunc returns the NetworkAttachmentDefinition name of namespace, or nil if
+// it does not exist
+type GetFunc func(namespace, name string) (*networkv1.NetworkAttachmentDefinition, error)
+
+type cniConfig struct {
+	Type    string            `json:"type"`
+	Plugins []json.RawMessage `json:"plugins"`
+}
+
+// ValidateConfig checks that the CNI configuration of the
+// NetworkAttachmentDefinition can be used by multus. An empty configuration is
+// valid, multus then reads it from the CNI configuration directory of the node.
+func ValidateConfig(definition *networkv1.NetworkAttachmentDefinition) error {
+	if strings.TrimSpace(definition.Spec.Config) == "" {
+		return nil
+	}
+
+	config := cniConfig{}
+	if err := json.Unmarshal([]byte(definition.Spec.Config), &config); err != nil {
+		return fmt.Errorf("failed to parse the CNI configuration: %v", err)
+	}
+	if config.Plugins == nil {
+		if config.Type == "" {
+			return fmt.Errorf("the CNI configuration has neither a type nor a plugin list")
+		}
+		return nil
+	}
+	if len(config.Plugins) == 0 {
+		return fmt.Errorf("the plugin list of the CNI configuration is empty")
+	}
+	for idx, raw := range config.Plugins {
+		plugin := cniConfig{}
+		if err := json.Unmarshal(raw, &plugin); err != nil {
+			return fmt.Errorf("failed to parse plugin %d of the CNI configuration: %v", idx, err)
+		}
+		if plugin.Type == "" {
+			return fmt.Errorf("plugin %d of the CNI configuration has no type", idx)
+		}
+	}
+	return nil
+}
+
+// SplitNetworkName returns the namespace and the name of the
+// NetworkAttachmentDefinition referenced by a multus network, the namespace
+// of the VMI is assumed if the reference does not have one.
+func SplitNetworkName(vmiNamespace string, fullNetworkName string) (namespace string, networkName string) {
+	if strings.Contains(fullNetworkName, "/") {
+		res := strings.SplitN(fullNetworkName, "/", 2)
+		return res[0], res[1]
+	}
+	return vmiNamespace, fullNetworkName
+}
+
+// ServiceAccountName returns the ServiceAccount the VMI runs with
+func ServiceAccountName(spec *v1.VirtualMachineInstanceSpec) string {
+	for _, volume := range spec.Volumes {
+		if volume.ServiceAccount != nil {
+			return volume.ServiceAccount.ServiceAccountName
+		}
+	}
+	return "default"
+}
+
+// CanServiceAccountUseNetwork checks whether the ServiceAccount was granted
+// the use verb on the NetworkAttachmentDefinition. Definitions in the
+// namespace of the ServiceAccount need no grant.
+func CanServiceAccountUseNetwork(client SubjectAccessReviewsProxy, nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+	if nadNamespace == saNamespace {
+		return true, "", nil
+	}
+
+	user := fmt.Sprintf("system:serviceaccount:%s:%s", saNamespace, saName)
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User: user,
+			Groups: []string{
+				"system:serviceaccounts",
+				"system:serviceaccounts:" + saNamespace,
+				"system:authenticated",
+			},
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: nadNamespace,
+				Verb:      UseVerb,
+				Group:     group,
+				Resource:  resource,
+				Name:      nadName,
+			},
+		},
+	}
+
+	response, err := client.Create(sar)
+	if err != nil {
+		return false, "", err
+	}
+	if !response.Status.Allowed {
+		return false, fmt.Sprintf("%s is not allowed to use network attachment definition %s/%s", user, nadNamespace, nadName), nil
+	}
+	return true, "", nil
+}
@@ -0,0 +1,137 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package nad
+
+import (
+	"fmt"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	authv1 "k8s.io/api/authorization/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+type fakeSarProxy struct {
+	allowed bool
+	err     error
+	review  *authv1.SubjectAccessReview
+}
+
+func (p *fakeSarProxy) Create(sar *authv1.SubjectAccessReview) (*authv1.SubjectAccessReview, error) {
+	p.review = sar
+	if p.err != nil {
+		return nil, p.err
+	}
+	result := sar.DeepCopy()
+	result.Status.Allowed = p.allowed
+	return result, nil
+}
+
+var _ = Describe("Network attachment definition references", func() {
+
+	DescribeTable("should split the network name",
+		func(fullName, expectedNamespace, expectedName string) {
+			namespace, name := SplitNetworkName("vmi-ns", fullName)
+			Expect(namespace).To(Equal(expectedNamespace))
+			Expect(name).To(Equal(expectedName))
+		},
+		Entry("without a namespace", "net", "vmi-ns", "net"),
+		Entry("with a namespace", "shared/net", "shared", "net"),
+	)
+
+	It("should use the default ServiceAccount when none is attached", func() {
+		Expect(ServiceAccountName(&v1.VirtualMachineInstanceSpec{})).To(Equal("default"))
+	})
+
+	It("should use the attached ServiceAccount", func() {
+		spec := &v1.VirtualMachineInstanceSpec{
+			Volumes: []v1.Volume{{
+				Name: "sa",
+				VolumeSource: v1.VolumeSource{
+					ServiceAccount: &v1.ServiceAccountVolumeSource{ServiceAccountName: "tenant"},
+				},
+			}},
+		}
+		Expect(ServiceAccountName(spec)).To(Equal("tenant"))
+	})
+
+	Context("authorization", func() {
+		It("should not review definitions of the same namespace", func() {
+			proxy := &fakeSarProxy{}
+			allowed, _, err := CanServiceAccountUseNetwork(proxy, "ns", "net", "ns", "default")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+			Expect(proxy.review).To(BeNil())
+		})
+
+		It("should review the use of definitions of another namespace", func() {
+			proxy := &fakeSarProxy{allowed: true}
+			allowed, _, err := CanServiceAccountUseNetwork(proxy, "shared", "net", "ns", "tenant")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+			Expect(proxy.review.Spec.User).To(Equal("system:serviceaccount:ns:tenant"))
+			Expect(proxy.review.Spec.Groups).To(ContainElement("system:serviceaccounts:ns"))
+			Expect(*proxy.review.Spec.ResourceAttributes).To(Equal(authv1.ResourceAttributes{
+				Namespace: "shared",
+				Verb:      "use",
+				Group:     "k8s.cni.cncf.io",
+				Resource:  "network-attachment-definitions",
+				Name:      "net",
+			}))
+		})
+
+		It("should explain a denial", func() {
+			proxy := &fakeSarProxy{}
+			allowed, message, err := CanServiceAccountUseNetwork(proxy, "shared", "net", "ns", "tenant")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+			Expect(message).To(ContainSubstring("shared/net"))
+		})
+
+		It("should return review errors", func() {
+			proxy := &fakeSarProxy{err: fmt.Errorf("unavailable")}
+			_, _, err := CanServiceAccountUseNetwork(proxy, "shared", "net", "ns", "tenant")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	DescribeTable("should validate the CNI configuration",
+		func(config string, valid bool) {
+			definition := &networkv1.NetworkAttachmentDefinition{
+				Spec: networkv1.NetworkAttachmentDefinitionSpec{Config: config},
+			}
+			err := ValidateConfig(definition)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("when it is empty", "", true),
+		Entry("with a single plugin", `{"cniVersion": "0.3.1", "type": "bridge"}`, true),
+		Entry("with a plugin list", `{"cniVersion": "0.3.1", "plugins": [{"type": "bridge"}, {"type": "tuning"}]}`, true),
+		Entry("when it is not JSON", "bridge", false),
+		Entry("without type and plugins", `{"cniVersion": "0.3.1"}`, false),
+		Entry("with an empty plugin list", `{"cniVersion": "0.3.1", "plugins": []}`, false),
+		Entry("with a plugin without type", `{"cniVersion": "0.3.1", "plugins": [{"type": "bridge"}, {"mtu": 1400}]}`, false),
+	)
+})
@@ -18,6 +18,8 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	templatev1 "kubevirt.io/client-go/apis/template/v1alpha1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
 )
 
 type Validator struct {
@@ -102,10 +104,15 @@ func createConfig() *common.Config {
 		},
 		GetDefinitions: func(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 			m := v1.GetOpenAPIDefinitions(ref)
-			m2 := snapshotv1.GetOpenAPIDefinitions(ref)
-			for k, v := range m2 {
-				if _, ok := m[k]; !ok {
-					m[k] = v
+			for _, definitions := range []map[string]common.OpenAPIDefinition{
+				snapshotv1.GetOpenAPIDefinitions(ref),
+				vmimportv1.GetOpenAPIDefinitions(ref),
+				templatev1.GetOpenAPIDefinitions(ref),
+			} {
+				for k, v := range definitions {
+					if _, ok := m[k]; !ok {
+						m[k] = v
+					}
 				}
 			}
 			return m
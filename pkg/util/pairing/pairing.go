@@ -0,0 +1,70 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package pairing grants access to peer clusters with pairing Secrets, which
+// hold the keys of a ServiceAccount token Secret of the peer cluster and its
+// API server
+package pairing
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"kubevirt.io/client-go/kubecli"
+)
+
+// keys of a pairing Secret
+const (
+	KeyServer    = "server"
+	KeyToken     = "token"
+	KeyCA        = "ca.crt"
+	KeyNamespace = "namespace"
+)
+
+// NewPeerClient authenticates with the token of the pairing Secret, the
+// server certificate is verified with its CA if it has one
+func NewPeerClient(secret *corev1.Secret) (kubecli.KubevirtClient, error) {
+	server := string(secret.Data[KeyServer])
+	token := string(secret.Data[KeyToken])
+	if server == "" || token == "" {
+		return nil, fmt.Errorf("Secret %s needs the %s and %s keys", secret.Name, KeyServer, KeyToken)
+	}
+
+	return kubecli.GetKubevirtClientFromRESTConfig(&rest.Config{
+		Host:        server,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: secret.Data[KeyCA],
+		},
+	})
+}
+
+// Namespace returns the namespace in the peer cluster, the namespace key of
+// the pairing Secret is the default
+func Namespace(secret *corev1.Secret, namespace string) (string, error) {
+	if namespace == "" {
+		namespace = string(secret.Data[KeyNamespace])
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("the namespace in the peer cluster is neither set nor in the pairing Secret %s", secret.Name)
+	}
+	return namespace, nil
+}
@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package qos
+
+import (
+	"sync"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// Class holds the node level settings a VMI QoS class is mapped to.
+type Class struct {
+	// BlkioWeight is the relative blkio weight of the launcher cgroup, between 10 and 1000
+	BlkioWeight uint16
+	// NetworkRate is the guaranteed egress rate of the tap device in bytes per second
+	NetworkRate uint64
+	// NetworkCeil is the maximum egress rate of the tap device in bytes per second
+	NetworkCeil uint64
+	// NetworkPrio is the HTB priority of the tap device, lower values are served first
+	NetworkPrio uint32
+}
+
+var configured struct {
+	lock    sync.Mutex
+	classes map[v1.QoSClass]Class
+}
+
+// SetClasses sets the QoS classes of the cluster configuration. virt-handler
+// updates them before it applies the class of a VMI, VMIs whose class is not
+// configured get no QoS.
+func SetClasses(classes []v1.QoSClassConfiguration) {
+	converted := map[v1.QoSClass]Class{}
+	for _, class := range classes {
+		converted[class.Name] = Class{
+			BlkioWeight: uint16(class.BlkioWeight),
+			NetworkRate: uint64(class.NetworkRate.Value()),
+			NetworkCeil: uint64(class.NetworkCeil.Value()),
+			NetworkPrio: class.NetworkPriority,
+		}
+	}
+
+	configured.lock.Lock()
+	defer configured.lock.Unlock()
+	configured.classes = converted
+}
+
+// Lookup returns the settings of a QoS class. It returns false if the
+// class is empty or not configured.
+func Lookup(class v1.QoSClass) (Class, bool) {
+	configured.lock.Lock()
+	defer configured.lock.Unlock()
+	c, ok := configured.classes[class]
+	return c, ok
+}
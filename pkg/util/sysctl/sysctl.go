@@ -26,6 +26,10 @@ import (
 const (
 	sysctlBase        = "/proc/sys"
 	NetIPv6Forwarding = "net/ipv6/conf/all/forwarding"
+	// NetIPv4ArpIgnore is formatted with the name of the interface
+	NetIPv4ArpIgnore         = "net/ipv4/conf/%s/arp_ignore"
+	NetBridgeNfCallIptables  = "net/bridge/bridge-nf-call-iptables"
+	NetBridgeNfCallIp6tables = "net/bridge/bridge-nf-call-ip6tables"
 )
 
 // Interface is an injectable interface for running sysctl commands.
@@ -17,9 +17,26 @@ const KubeletPodsDir = "/var/lib/kubelet/pods"
 const HostRootMount = "/proc/1/root/"
 const CPUManagerOS3Path = HostRootMount + "var/lib/origin/openshift.local.volumes/cpu_manager_state"
 const CPUManagerPath = HostRootMount + "var/lib/kubelet/cpu_manager_state"
+const EmulatorsDir = "/usr/libexec/kubevirt/emulators"
+const SidecarEmulatorsDir = "/var/run/kubevirt-emulators"
+const VhostNetZeroCopyTXPath = "/sys/module/vhost_net/parameters/experimental_zcopytx"
+
+// KVMNestedPaths are the nested parameters of the Intel and AMD KVM modules,
+// only the one of the loaded module exists
+var KVMNestedPaths = []string{
+	"/sys/module/kvm_intel/parameters/nested",
+	"/sys/module/kvm_amd/parameters/nested",
+}
+
+// CPUVulnerabilitiesDir holds a file per CPU vulnerability known to the
+// kernel, describing whether the CPU is affected and how it is mitigated
+const CPUVulnerabilitiesDir = "/sys/devices/system/cpu/vulnerabilities"
+const SMTActivePath = "/sys/devices/system/cpu/smt/active"
+const L1DFlushPath = "/sys/module/kvm_intel/parameters/vmentry_l1d_flush"
 
 var VMIInterfaceDir = NetworkInfoDir + "/%s"
 var VMIInterfacepath = NetworkInfoDir + "/%s/%s"
+var VMISysctlsPath = NetworkInfoDir + "/%s/sysctls.json"
 
 func IsSRIOVVmi(vmi *v1.VirtualMachineInstance) bool {
 	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
@@ -50,6 +67,16 @@ func IsVMIVirtiofsEnabled(vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// HasVhostUserBlkVolumes checks if a VMI has vhost-user-blk volumes, which require shared memory
+func HasVhostUserBlkVolumes(vmi *v1.VirtualMachineInstance) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.VhostUserBlk != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Check if a VMI spec requests a HostDevice
 func IsHostDevVMI(vmi *v1.VirtualMachineInstance) bool {
 	if vmi.Spec.Domain.Devices.HostDevices != nil && len(vmi.Spec.Domain.Devices.HostDevices) != 0 {
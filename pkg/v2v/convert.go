@@ -0,0 +1,245 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v2v
+
+import (
+	"fmt"
+	"net"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	maxNameLength = 63
+
+	busVirtio = "virtio"
+	busSATA   = "sata"
+	busSCSI   = "scsi"
+)
+
+// Metadata is the part of the metadata written by virt-v2v -o json needed
+// to create a VirtualMachine
+type Metadata struct {
+	Name      string       `json:"name"`
+	Memory    int64        `json:"memory"`
+	VCPU      uint32       `json:"vcpu"`
+	CPU       *MetadataCPU `json:"cpu,omitempty"`
+	Firmware  Firmware     `json:"firmware"`
+	Disks     []Disk       `json:"disks"`
+	NICs      []NIC        `json:"nics"`
+	GuestCaps GuestCaps    `json:"guestcaps"`
+}
+
+// MetadataCPU is the CPU of the source VM
+type MetadataCPU struct {
+	Topology *Topology `json:"topology,omitempty"`
+}
+
+// Topology is the CPU topology of the source VM
+type Topology struct {
+	Sockets uint32 `json:"sockets"`
+	Cores   uint32 `json:"cores"`
+	Threads uint32 `json:"threads"`
+}
+
+// Firmware is the firmware the guest boots with, "bios" or "uefi"
+type Firmware struct {
+	Type string `json:"type"`
+}
+
+// Disk is a converted disk
+type Disk struct {
+	File string `json:"file"`
+}
+
+// NIC is a network interface of the source VM
+type NIC struct {
+	MAC  string `json:"mac"`
+	VNet string `json:"vnet"`
+}
+
+// GuestCaps are the buses the converted guest has drivers for
+type GuestCaps struct {
+	BlockBus string `json:"block-bus"`
+	NetBus   string `json:"net-bus"`
+}
+
+// Options tune the VirtualMachine created from the metadata
+type Options struct {
+	Name      string
+	Namespace string
+	// ClaimNames are the PersistentVolumeClaims holding the disks, in the
+	// order of the metadata
+	ClaimNames []string
+	// Networks maps the networks of the source VM to networks of the
+	// VirtualMachine. Interfaces on networks which are not mapped are
+	// attached to the pod network.
+	Networks map[string]v1.NetworkSource
+	Running  bool
+}
+
+// ToVirtualMachine maps the metadata of a converted VM to a VirtualMachine
+// booting from the PersistentVolumeClaims of its disks.
+func ToVirtualMachine(metadata *Metadata, options Options) (*v1.VirtualMachine, error) {
+	if len(options.ClaimNames) != len(metadata.Disks) {
+		return nil, fmt.Errorf("the VM has %d disks, %d PersistentVolumeClaims were given", len(metadata.Disks), len(options.ClaimNames))
+	}
+	if metadata.Memory <= 0 {
+		return nil, fmt.Errorf("invalid memory of the VM: %d", metadata.Memory)
+	}
+
+	spec := v1.VirtualMachineInstanceSpec{
+		Domain: v1.DomainSpec{
+			CPU: cpuTopology(metadata),
+			Resources: v1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceMemory: *resource.NewQuantity(metadata.Memory, resource.BinarySI),
+				},
+			},
+		},
+	}
+
+	bus := diskBus(metadata.GuestCaps.BlockBus)
+	for i, claimName := range options.ClaimNames {
+		diskName := fmt.Sprintf("disk%d", i)
+		spec.Domain.Devices.Disks = append(spec.Domain.Devices.Disks, v1.Disk{
+			Name: diskName,
+			DiskDevice: v1.DiskDevice{
+				Disk: &v1.DiskTarget{Bus: bus},
+			},
+		})
+		spec.Volumes = append(spec.Volumes, v1.Volume{
+			Name: diskName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		})
+	}
+
+	model := interfaceModel(metadata.GuestCaps.NetBus)
+	podNetworkUsed := false
+	for _, nic := range metadata.NICs {
+		ifaceName := fmt.Sprintf("nic%d", len(spec.Networks))
+		source, mapped := options.Networks[nic.VNet]
+		if !mapped {
+			source = v1.NetworkSource{Pod: &v1.PodNetwork{}}
+		}
+		if source.Pod != nil {
+			if podNetworkUsed {
+				return nil, fmt.Errorf("interface %s on network %q can't be attached to the pod network, another interface already is", nic.MAC, nic.VNet)
+			}
+			podNetworkUsed = true
+		}
+		iface := v1.Interface{
+			Name:  ifaceName,
+			Model: model,
+		}
+		if source.Pod != nil {
+			iface.Masquerade = &v1.InterfaceMasquerade{}
+		} else {
+			iface.Bridge = &v1.InterfaceBridge{}
+		}
+		if mac, err := net.ParseMAC(nic.MAC); err == nil {
+			iface.MacAddress = mac.String()
+		}
+		spec.Domain.Devices.Interfaces = append(spec.Domain.Devices.Interfaces, iface)
+		spec.Networks = append(spec.Networks, v1.Network{Name: ifaceName, NetworkSource: source})
+	}
+	if len(spec.Networks) == 0 {
+		autoattach := false
+		spec.Domain.Devices.AutoattachPodInterface = &autoattach
+	}
+
+	if metadata.Firmware.Type == "uefi" {
+		// virt-v2v does not report whether the source booted securely
+		secureBoot := false
+		spec.Domain.Firmware = &v1.Firmware{
+			Bootloader: &v1.Bootloader{
+				EFI: &v1.EFI{SecureBoot: &secureBoot},
+			},
+		}
+	}
+
+	running := options.Running
+	return &v1.VirtualMachine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       v1.VirtualMachineGroupVersionKind.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      options.Name,
+			Namespace: options.Namespace,
+		},
+		Spec: v1.VirtualMachineSpec{
+			Running: &running,
+			Template: &v1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"kubevirt.io/vm": options.Name},
+				},
+				Spec: spec,
+			},
+		},
+	}, nil
+}
+
+func cpuTopology(metadata *Metadata) *v1.CPU {
+	if metadata.CPU != nil && metadata.CPU.Topology != nil {
+		topology := metadata.CPU.Topology
+		if topology.Sockets > 0 && topology.Cores > 0 && topology.Threads > 0 {
+			return &v1.CPU{
+				Sockets: topology.Sockets,
+				Cores:   topology.Cores,
+				Threads: topology.Threads,
+			}
+		}
+	}
+	count := metadata.VCPU
+	if count < 1 {
+		count = 1
+	}
+	return &v1.CPU{Cores: count}
+}
+
+func diskBus(blockBus string) string {
+	switch blockBus {
+	case "virtio-scsi":
+		return busSCSI
+	case "ide":
+		// IDE is not supported, SATA is understood by the same guests
+		return busSATA
+	default:
+		return busVirtio
+	}
+}
+
+func interfaceModel(netBus string) string {
+	switch netBus {
+	case "e1000":
+		return "e1000"
+	case "rtl8139":
+		return "rtl8139"
+	default:
+		return "virtio"
+	}
+}
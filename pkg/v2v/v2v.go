@@ -0,0 +1,251 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package v2v drives virt-v2v, which converts VMs of other hypervisors and
+// installs the virtio drivers in their guests, and maps the converted VMs to
+// KubeVirt VirtualMachines.
+package v2v
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+)
+
+// Locations the volumes of the conversion pods are mounted at
+const (
+	SourceDir  = "/var/tmp/v2v/source"
+	SecretDir  = "/var/tmp/v2v/secret"
+	ScratchDir = "/var/tmp/v2v/scratch"
+	DisksDir   = "/var/tmp/v2v/disks"
+
+	// PasswordKey is the key of the password in the Secret of a VMware source
+	PasswordKey = "password"
+
+	terminationLog = "/dev/termination-log"
+	diskPattern    = "disk-%{DiskNo}/disk.img"
+
+	// the conversion pod prints the result between these lines to its log,
+	// the termination message is limited to 4096 bytes which the metadata of
+	// VMs with many disks and interfaces exceeds
+	conversionBegin = "--- begin of the conversion result ---"
+	conversionEnd   = "--- end of the conversion result ---"
+)
+
+// Estimate is the space virt-v2v needs to convert the disks of a VM, in bytes
+type Estimate struct {
+	Disks []int64 `json:"disks"`
+	Total int64   `json:"total"`
+}
+
+// OutputDisk is a disk written to the scratch volume
+type OutputDisk struct {
+	File string
+	Size int64
+}
+
+// Conversion is the outcome of virt-v2v, as reported by the conversion pod
+type Conversion struct {
+	Disks    []OutputDisk
+	Metadata Metadata
+}
+
+// InputArgs returns the virt-v2v arguments reading the VM from source
+func InputArgs(source vmimportv1.V2VSource) ([]string, error) {
+	switch {
+	case source.VMware != nil && source.OVA != nil:
+		return nil, fmt.Errorf("only one source can be set")
+	case source.VMware != nil:
+		vmware := source.VMware
+		if vmware.URL == "" || vmware.VMName == "" || vmware.SecretRef == "" {
+			return nil, fmt.Errorf("the VMware source needs a URL, a VM name and a Secret")
+		}
+		return []string{
+			"-i", "libvirt",
+			"-ic", vmware.URL,
+			"--password-file", path.Join(SecretDir, PasswordKey),
+			vmware.VMName,
+		}, nil
+	case source.OVA != nil:
+		ova := source.OVA
+		if ova.ClaimName == "" || ova.Path == "" {
+			return nil, fmt.Errorf("the OVA source needs a PersistentVolumeClaim and a path")
+		}
+		return []string{"-i", "ova", path.Join(SourceDir, path.Clean("/"+ova.Path))}, nil
+	}
+	return nil, fmt.Errorf("no source is set")
+}
+
+// EstimateScript returns the script estimating the space the converted disks
+// need, the estimate is written to the termination message of the pod.
+func EstimateScript(inputArgs []string) string {
+	args := append([]string{"virt-v2v"}, inputArgs...)
+	args = append(args, "-o", "json", "-os", ScratchDir, "--print-estimate", "--machine-readable")
+	return fmt.Sprintf("set -e\n%s > %s\n", shellJoin(args), terminationLog)
+}
+
+// ConvertScript returns the script converting the VM to raw disk images on
+// the scratch volume. virt-v2v writes the metadata of the VM to a file next to
+// them, it is printed with the sizes of the disks at the end of the log of
+// the pod.
+func ConvertScript(inputArgs []string, name string) string {
+	args := append([]string{"virt-v2v"}, inputArgs...)
+	args = append(args,
+		"-o", "json",
+		"-os", ScratchDir,
+		"-oo", "json-disks-pattern="+diskPattern,
+		"-of", "raw",
+		"-on", name,
+		"--machine-readable",
+	)
+	metadata := path.Join(ScratchDir, name+".json")
+	return fmt.Sprintf("set -e\n%s\necho %s\nstat -c '%%s %%n' %s/disk-*/disk.img\ncat %s\necho\necho %s\n",
+		shellJoin(args), shellQuote(conversionBegin), ScratchDir, shellQuote(metadata), shellQuote(conversionEnd))
+}
+
+// CopyScript returns the script copying the converted disks to the volumes
+// mounted at DisksDir/<index>, holes of the images are preserved.
+func CopyScript(disks []OutputDisk) string {
+	script := "set -e\n"
+	for i, disk := range disks {
+		target := path.Join(DisksDir, strconv.Itoa(i), "disk.img")
+		script += shellJoin([]string{"cp", "--sparse=always", disk.File, target}) + "\n"
+	}
+	return script
+}
+
+// ParseEstimate reads the estimate from the termination message of the
+// estimate pod, virt-v2v may log other lines before it.
+func ParseEstimate(message string) (*Estimate, error) {
+	idx := strings.Index(message, "{")
+	if idx < 0 {
+		return nil, fmt.Errorf("no estimate was reported")
+	}
+	estimate := &Estimate{}
+	if err := json.NewDecoder(strings.NewReader(message[idx:])).Decode(estimate); err != nil {
+		return nil, fmt.Errorf("failed to parse the estimate: %v", err)
+	}
+	if estimate.Total <= 0 {
+		return nil, fmt.Errorf("invalid estimate of %d bytes", estimate.Total)
+	}
+	return estimate, nil
+}
+
+// ParseConversion reads the sizes of the converted disks and the metadata of
+// the VM from the log of the conversion pod, the disks are returned in the
+// order of the metadata.
+func ParseConversion(logs string) (*Conversion, error) {
+	begin := strings.LastIndex(logs, conversionBegin+"\n")
+	if begin < 0 {
+		return nil, fmt.Errorf("no metadata was reported")
+	}
+	message := logs[begin+len(conversionBegin)+1:]
+	end := strings.Index(message, conversionEnd)
+	if end < 0 {
+		return nil, fmt.Errorf("the metadata was not reported completely")
+	}
+	message = message[:end]
+
+	sizes := map[string]int64{}
+	scanner := bufio.NewScanner(strings.NewReader(message))
+	consumed := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "{") {
+			break
+		}
+		consumed += len(line) + 1
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size of disk %s: %v", fields[1], err)
+		}
+		sizes[fields[1]] = size
+	}
+	if consumed >= len(message) {
+		return nil, fmt.Errorf("no metadata was reported")
+	}
+
+	conversion := &Conversion{}
+	if err := json.NewDecoder(strings.NewReader(message[consumed:])).Decode(&conversion.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse the metadata: %v", err)
+	}
+	for _, disk := range conversion.Metadata.Disks {
+		size, exists := sizes[disk.File]
+		if !exists {
+			return nil, fmt.Errorf("the size of disk %s was not reported", disk.File)
+		}
+		conversion.Disks = append(conversion.Disks, OutputDisk{File: disk.File, Size: size})
+	}
+	if len(conversion.Disks) == 0 {
+		return nil, fmt.Errorf("the VM has no disks")
+	}
+	return conversion, nil
+}
+
+// virt-v2v reports the progress of copying the disks like "(45.20/100%)"
+var progressPattern = regexp.MustCompile(`\((\d+\.\d+)/100%\)`)
+
+// ParseProgress returns the last progress found in the logs of the
+// conversion pod, formatted like DataVolume progress.
+func ParseProgress(logs string) (string, bool) {
+	matches := progressPattern.FindAllStringSubmatch(logs, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+	return matches[len(matches)-1][1] + "%", true
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// SanitizeName turns the name of a VM of another hypervisor into a valid
+// Kubernetes name, or returns "" if nothing usable is left.
+func SanitizeName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-")
+}
+
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+func shellQuote(arg string) string {
+	if safeShellWord.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
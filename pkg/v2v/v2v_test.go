@@ -0,0 +1,242 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v2v
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+)
+
+const conversionResult = `1073741824 /var/tmp/v2v/scratch/disk-1/disk.img
+536870912 /var/tmp/v2v/scratch/disk-2/disk.img
+{
+  "version": 1,
+  "name": "web01",
+  "memory": 4294967296,
+  "vcpu": 4,
+  "cpu": {
+    "topology": {
+      "sockets": 2,
+      "cores": 2,
+      "threads": 1
+    }
+  },
+  "firmware": {
+    "type": "uefi"
+  },
+  "disks": [
+    {
+      "file": "/var/tmp/v2v/scratch/disk-1/disk.img",
+      "format": "raw"
+    },
+    {
+      "file": "/var/tmp/v2v/scratch/disk-2/disk.img",
+      "format": "raw"
+    }
+  ],
+  "nics": [
+    {
+      "mac": "00:50:56:AA:BB:CC",
+      "model": "vmxnet3",
+      "vnet": "VM Network",
+      "vnet-type": "network"
+    },
+    {
+      "mac": "00:50:56:aa:bb:cd",
+      "model": "e1000",
+      "vnet": "Storage",
+      "vnet-type": "network"
+    }
+  ],
+  "guestcaps": {
+    "block-bus": "virtio-scsi",
+    "net-bus": "virtio-net"
+  }
+}
+`
+
+var conversionMessage = "[   0.0] Setting up the source\n[  95.1] Finishing off\n" +
+	conversionBegin + "\n" + conversionResult + "\n" + conversionEnd + "\n"
+
+var _ = Describe("virt-v2v", func() {
+
+	It("should read a VM from vCenter", func() {
+		args, err := InputArgs(vmimportv1.V2VSource{
+			VMware: &vmimportv1.VMwareSource{
+				URL:       "vpx://administrator@vcenter.example.com/Datacenter/esxi01?no_verify=1",
+				VMName:    "web01",
+				SecretRef: "vcenter-credentials",
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]string{
+			"-i", "libvirt",
+			"-ic", "vpx://administrator@vcenter.example.com/Datacenter/esxi01?no_verify=1",
+			"--password-file", "/var/tmp/v2v/secret/password",
+			"web01",
+		}))
+	})
+
+	It("should keep OVA archives on the source volume", func() {
+		args, err := InputArgs(vmimportv1.V2VSource{
+			OVA: &vmimportv1.OVASource{ClaimName: "exports", Path: "../../etc/web01.ova"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]string{"-i", "ova", "/var/tmp/v2v/source/etc/web01.ova"}))
+	})
+
+	table.DescribeTable("should reject sources", func(source vmimportv1.V2VSource) {
+		_, err := InputArgs(source)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("without source", vmimportv1.V2VSource{}),
+		table.Entry("with two sources", vmimportv1.V2VSource{
+			VMware: &vmimportv1.VMwareSource{URL: "vpx://vcenter", VMName: "web01", SecretRef: "credentials"},
+			OVA:    &vmimportv1.OVASource{ClaimName: "exports", Path: "web01.ova"},
+		}),
+		table.Entry("without VMware credentials", vmimportv1.V2VSource{
+			VMware: &vmimportv1.VMwareSource{URL: "vpx://vcenter", VMName: "web01"},
+		}),
+		table.Entry("without OVA path", vmimportv1.V2VSource{
+			OVA: &vmimportv1.OVASource{ClaimName: "exports"},
+		}),
+	)
+
+	It("should quote the arguments of the scripts", func() {
+		script := ConvertScript([]string{"-i", "ova", "/var/tmp/v2v/source/web 01.ova"}, "web01")
+		Expect(script).To(ContainSubstring("virt-v2v -i ova '/var/tmp/v2v/source/web 01.ova' -o json"))
+		Expect(script).To(ContainSubstring("'json-disks-pattern=disk-%{DiskNo}/disk.img'"))
+		Expect(script).To(ContainSubstring("cat /var/tmp/v2v/scratch/web01.json\n"))
+		Expect(script).ToNot(ContainSubstring("/dev/termination-log"))
+
+		script = CopyScript([]OutputDisk{{File: "/var/tmp/v2v/scratch/disk-1/disk.img"}})
+		Expect(script).To(ContainSubstring("cp --sparse=always /var/tmp/v2v/scratch/disk-1/disk.img /var/tmp/v2v/disks/0/disk.img"))
+	})
+
+	It("should parse the estimate after other output", func() {
+		estimate, err := ParseEstimate("virt-v2v: warning: no support for remote libvirt connections\n{ \"disks\": [ 1073741824, 536870912 ], \"total\": 1610612736 }\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(estimate.Disks).To(Equal([]int64{1073741824, 536870912}))
+		Expect(estimate.Total).To(Equal(int64(1610612736)))
+
+		_, err = ParseEstimate("virt-v2v: error: could not connect to libvirt")
+		Expect(err).To(MatchError("no estimate was reported"))
+	})
+
+	It("should parse the converted disks and the metadata", func() {
+		conversion, err := ParseConversion(conversionMessage)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conversion.Disks).To(Equal([]OutputDisk{
+			{File: "/var/tmp/v2v/scratch/disk-1/disk.img", Size: 1073741824},
+			{File: "/var/tmp/v2v/scratch/disk-2/disk.img", Size: 536870912},
+		}))
+		Expect(conversion.Metadata.Name).To(Equal("web01"))
+		Expect(conversion.Metadata.NICs).To(HaveLen(2))
+	})
+
+	It("should fail when the size of a disk is missing", func() {
+		_, err := ParseConversion(strings.Replace(conversionMessage, "1073741824 /var/tmp/v2v/scratch/disk-1/disk.img\n", "", 1))
+		Expect(err).To(MatchError(ContainSubstring("size of disk /var/tmp/v2v/scratch/disk-1/disk.img was not reported")))
+	})
+
+	It("should parse metadata larger than a termination message", func() {
+		nics := strings.Repeat(`{ "mac": "00:50:56:aa:bb:ff", "model": "e1000", "vnet": "Storage", "vnet-type": "network" },`, 100)
+		result := strings.Replace(conversionResult, `"nics": [`, `"nics": [`+nics, 1)
+		Expect(len(result)).To(BeNumerically(">", 4096))
+
+		conversion, err := ParseConversion(conversionBegin + "\n" + result + "\n" + conversionEnd + "\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conversion.Metadata.NICs).To(HaveLen(102))
+	})
+
+	It("should fail when the result was not reported completely", func() {
+		_, err := ParseConversion("[   0.0] Setting up the source\n")
+		Expect(err).To(MatchError("no metadata was reported"))
+
+		_, err = ParseConversion(conversionMessage[:len(conversionMessage)-len(conversionEnd)-10])
+		Expect(err).To(MatchError("the metadata was not reported completely"))
+	})
+
+	It("should report the last progress", func() {
+		progress, found := ParseProgress("    (10.00/100%)\r    (45.20/100%)\r")
+		Expect(found).To(BeTrue())
+		Expect(progress).To(Equal("45.20%"))
+
+		_, found = ParseProgress("[   0.0] Opening the source")
+		Expect(found).To(BeFalse())
+	})
+
+	It("should map the metadata to a VirtualMachine", func() {
+		conversion, err := ParseConversion(conversionMessage)
+		Expect(err).ToNot(HaveOccurred())
+
+		vm, err := ToVirtualMachine(&conversion.Metadata, Options{
+			Name:       "web01",
+			Namespace:  "default",
+			ClaimNames: []string{"web01-disk0", "web01-disk1"},
+			Networks: map[string]v1.NetworkSource{
+				"Storage": {Multus: &v1.MultusNetwork{NetworkName: "storage"}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vm.Name).To(Equal("web01"))
+		Expect(*vm.Spec.Running).To(BeFalse())
+
+		spec := vm.Spec.Template.Spec
+		Expect(spec.Domain.CPU).To(Equal(&v1.CPU{Sockets: 2, Cores: 2, Threads: 1}))
+		memory := spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+		Expect(memory.String()).To(Equal("4Gi"))
+		Expect(spec.Domain.Devices.Disks).To(HaveLen(2))
+		Expect(spec.Domain.Devices.Disks[1].Disk.Bus).To(Equal("scsi"))
+		Expect(spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("web01-disk1"))
+		Expect(*spec.Domain.Firmware.Bootloader.EFI.SecureBoot).To(BeFalse())
+
+		Expect(spec.Domain.Devices.Interfaces).To(HaveLen(2))
+		Expect(spec.Domain.Devices.Interfaces[0].Masquerade).ToNot(BeNil())
+		Expect(spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("00:50:56:aa:bb:cc"))
+		Expect(spec.Domain.Devices.Interfaces[0].Model).To(Equal("virtio"))
+		Expect(spec.Domain.Devices.Interfaces[1].Bridge).ToNot(BeNil())
+		Expect(spec.Networks[1].Multus.NetworkName).To(Equal("storage"))
+	})
+
+	It("should not attach two interfaces to the pod network", func() {
+		metadata := &Metadata{
+			Memory: 1 << 30,
+			Disks:  []Disk{{File: "disk-1/disk.img"}},
+			NICs:   []NIC{{VNet: "VM Network"}, {VNet: "Storage"}},
+		}
+		_, err := ToVirtualMachine(metadata, Options{Name: "web01", ClaimNames: []string{"web01-disk0"}})
+		Expect(err).To(MatchError(ContainSubstring("can't be attached to the pod network")))
+	})
+
+	table.DescribeTable("should sanitize names", func(name, expected string) {
+		Expect(SanitizeName(name)).To(Equal(expected))
+	},
+		table.Entry("with spaces and capitals", "Web Server 01", "web-server-01"),
+		table.Entry("with only invalid characters", "___", ""),
+	)
+})
@@ -31,13 +31,18 @@ import (
 	"github.com/go-openapi/spec"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8coresv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	certificate2 "k8s.io/client-go/util/certificate"
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
@@ -50,9 +55,11 @@ import (
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/openapi"
 	webhooksutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	configvalidation "kubevirt.io/kubevirt/pkg/virt-api/config-validation"
 	"kubevirt.io/kubevirt/pkg/virt-api/rest"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	mutating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook/mutators"
 	validating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/components"
@@ -89,6 +96,7 @@ type virtAPIApp struct {
 	virtCli          kubecli.KubevirtClient
 	aggregatorClient *aggregatorclient.Clientset
 	authorizor       rest.VirtApiAuthorizor
+	recorder         record.EventRecorder
 	certsDirectory   string
 	clusterConfig    *virtconfig.ClusterConfig
 
@@ -140,6 +148,7 @@ func (app *virtAPIApp) Execute() {
 	app.authorizor = authorizor
 
 	app.virtCli = virtCli
+	app.recorder = app.getNewRecorder(k8sv1.NamespaceAll, "virt-api")
 
 	app.certsDirectory, err = ioutil.TempDir("", "certsdir")
 	if err != nil {
@@ -177,6 +186,12 @@ func subresourceAPIGroup() metav1.APIGroup {
 	return apiGroup
 }
 
+func (app *virtAPIApp) getNewRecorder(namespace string, componentName string) record.EventRecorder {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&k8coresv1.EventSinkImpl{Interface: app.virtCli.CoreV1().Events(namespace)})
+	return eventBroadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: componentName})
+}
+
 func (app *virtAPIApp) composeSubresources() {
 
 	var subwss []*restful.WebService
@@ -184,12 +199,20 @@ func (app *virtAPIApp) composeSubresources() {
 	for _, version := range v1.SubresourceGroupVersions {
 		subresourcesvmGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachines"}
 		subresourcesvmiGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachineinstances"}
+		subresourcesvmtemplateGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachinetemplates"}
+		subresourceskvGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "kubevirts"}
+		subresourcesvmbulkGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachinebulkoperations"}
 
 		subws := new(restful.WebService)
 		subws.Doc(fmt.Sprintf("KubeVirt \"%s\" Subresource API.", version.Version))
 		subws.Path(rest.GroupVersionBasePath(version))
 
-		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig)
+		vmiMutator := &mutators.VMIsMutator{ClusterConfig: app.clusterConfig}
+		applyVMIDefaults := func(vmi *v1.VirtualMachineInstance) error {
+			return vmiMutator.ApplyDefaults(vmi, webhooks.GetInformers())
+		}
+		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig, app.authorizor, app.recorder, applyVMIDefaults)
+		configValidationApp := configvalidation.NewConfigValidationApp(app.virtCli, app.clusterConfig)
 
 		restartRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("restart")).
 			To(subresourceApp.RestartVMRequestHandler).
@@ -230,6 +253,51 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, "Not Found", "").
 			Returns(http.StatusBadRequest, "Bad Request", ""))
 
+		subws.Route(subws.PUT(rest.ResourceBasePath(subresourcesvmbulkGVR)+rest.SubResourcePath("start")).
+			To(subresourceApp.BulkStartVMRequestHandler).
+			Reads(v1.VirtualMachineBulkOperationOptions{}).
+			Param(rest.NamespaceParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"BulkStart").
+			Doc("Start all VirtualMachines of a namespace matching a label selector.").
+			Writes(v1.VirtualMachineBulkOperationResult{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineBulkOperationResult{}).
+			Returns(http.StatusBadRequest, "Bad Request", "").
+			Returns(http.StatusInternalServerError, "Internal Server Error", ""))
+
+		subws.Route(subws.PUT(rest.ResourceBasePath(subresourcesvmbulkGVR)+rest.SubResourcePath("stop")).
+			To(subresourceApp.BulkStopVMRequestHandler).
+			Reads(v1.VirtualMachineBulkOperationOptions{}).
+			Param(rest.NamespaceParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"BulkStop").
+			Doc("Stop all VirtualMachines of a namespace matching a label selector.").
+			Writes(v1.VirtualMachineBulkOperationResult{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineBulkOperationResult{}).
+			Returns(http.StatusBadRequest, "Bad Request", "").
+			Returns(http.StatusInternalServerError, "Internal Server Error", ""))
+
+		subws.Route(subws.PUT(rest.ResourceBasePath(subresourcesvmbulkGVR)+rest.SubResourcePath("migrate")).
+			To(subresourceApp.BulkMigrateVMRequestHandler).
+			Reads(v1.VirtualMachineBulkOperationOptions{}).
+			Param(rest.NamespaceParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"BulkMigrate").
+			Doc("Migrate all running VirtualMachines of a namespace matching a label selector to other nodes.").
+			Writes(v1.VirtualMachineBulkOperationResult{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineBulkOperationResult{}).
+			Returns(http.StatusBadRequest, "Bad Request", "").
+			Returns(http.StatusInternalServerError, "Internal Server Error", ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("hibernate")).
+			To(subresourceApp.HibernateVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Hibernate").
+			Doc("Hibernate a VirtualMachine object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("pause")).
 			To(subresourceApp.PauseVMIRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -248,6 +316,24 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, "Not Found", "").
 			Returns(http.StatusBadRequest, "Bad Request", ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("freeze")).
+			To(subresourceApp.FreezeVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Freeze").
+			Doc("Freeze the guest file systems of a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("unfreeze")).
+			To(subresourceApp.UnfreezeVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Unfreeze").
+			Doc("Thaw the guest file systems of a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", ""))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("console")).
 			To(subresourceApp.ConsoleRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -260,6 +346,19 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version + "VNC").
 			Doc("Open a websocket connection to connect to VNC on the specified VirtualMachineInstance."))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("channel") + "/{channel}").
+			To(subresourceApp.ChannelRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.PathParameter("channel", "Name of the virtio-serial channel").Required(true)).
+			Operation(version.Version + "Channel").
+			Doc("Open a websocket connection to a virtio-serial channel on the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("hypervisorlog")).
+			To(subresourceApp.HypervisorLogRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version + "HypervisorLog").
+			Doc("Open a websocket connection streaming the qemu log and the libvirt lifecycle events of the specified VirtualMachineInstance."))
+
 		// An empty handler function would respond with HTTP OK by default
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("test")).
 			To(func(request *restful.Request, response *restful.Response) {}).
@@ -289,6 +388,37 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.VirtualMachineInstanceGuestAgentInfo{}).
 			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("domainxml")).
+			To(subresourceApp.VMIDomainXMLRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_XML).
+			Operation(version.Version+"vmi-domainxml").
+			Doc("Render the libvirt domain XML of a VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", ""))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("domainxml")).
+			To(subresourceApp.VMDomainXMLRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_XML).
+			Operation(version.Version+"vm-domainxml").
+			Doc("Render the libvirt domain XML a VirtualMachine would be started with, without starting it.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", ""))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("validate-start")).
+			To(subresourceApp.ValidateStartVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"vm-validate-start").
+			Doc("Run the checks a start of a VirtualMachine has to pass and report what would block it, without starting it.").
+			Writes(v1.VirtualMachineStartValidation{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineStartValidation{}).
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusInternalServerError, "Internal Server Error", ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("rename")).
 			To(subresourceApp.RenameVMRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -299,6 +429,19 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, "Not Found", "").
 			Returns(http.StatusBadRequest, "Bad Request", ""))
 
+		processRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmtemplateGVR)+rest.SubResourcePath("process")).
+			To(subresourceApp.ProcessVMTemplateRequestHandler).
+			Reads(templatev1alpha1.ProcessOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"ProcessTemplate").
+			Doc("Substitute parameters into a VirtualMachineTemplate and return the resulting VirtualMachine without creating it.").
+			Writes(v1.VirtualMachine{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachine{}).
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", "")
+		processRouteBuilder.ParameterNamed("body").Required(false)
+		subws.Route(processRouteBuilder)
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("userlist")).
 			To(subresourceApp.UserList).
 			Consumes(restful.MIME_JSON).
@@ -317,6 +460,53 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.VirtualMachineInstanceFileSystemList{}).
 			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("qmp")).
+			To(subresourceApp.QMPCommand).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter("command", "The read-only QMP command to execute, like query-status.")).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"vmi-qmp").
+			Doc("Execute a read-only QMP command on the qemu monitor of a VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", ""))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("stats")).
+			To(subresourceApp.Stats).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"vmi-stats").
+			Doc("Get the CPU, memory, disk and network usage counters of a VirtualMachineInstance.").
+			Writes(v1.VirtualMachineInstanceStats{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceStats{}).
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusConflict, "Conflict", ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourceskvGVR)+rest.SubResourcePath("validate-configuration")).
+			To(configValidationApp.ValidateConfigurationRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.KubeVirt{}).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"kubevirt-validate-configuration").
+			Doc("Report the workloads depending on the settings a changed KubeVirt configuration removes, without applying it.").
+			Writes(v1.KubeVirtConfigurationValidation{}).
+			Returns(http.StatusOK, "OK", v1.KubeVirtConfigurationValidation{}).
+			Returns(http.StatusNotFound, "Not Found", "").
+			Returns(http.StatusBadRequest, "Bad Request", "").
+			Returns(http.StatusInternalServerError, "Internal Server Error", ""))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourceskvGVR)+rest.SubResourcePath("profile")).
+			To(subresourceApp.ProfileRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter("node", "The node to collect the profiles of virt-handler and of the virt-launchers on.")).
+			Param(subws.QueryParameter("seconds", "The duration of the CPU profiles and the traces in seconds, 20 by default and at most 50.")).
+			Produces("application/gzip").
+			Operation(version.Version+"kubevirt-profile").
+			Doc("Collect the pprof profiles and the traces of virt-handler and of the virt-launchers on a node, as a gzipped tar archive.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, "Bad Request", "").
+			Returns(http.StatusInternalServerError, "Internal Server Error", ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("addvolume")).
 			To(subresourceApp.VMIAddVolumeRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -371,6 +561,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/console",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/channel",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/hypervisorlog",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/pause",
 						Namespaced: true,
@@ -379,6 +577,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/unpause",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/freeze",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/unfreeze",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/start",
 						Namespaced: true,
@@ -387,6 +593,10 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/stop",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/hibernate",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/restart",
 						Namespaced: true,
@@ -395,14 +605,34 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/migrate",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachinebulkoperations",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/guestosinfo",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/domainxml",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/domainxml",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/validate-start",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/rename",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachinetemplates/process",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/userlist",
 						Namespaced: true,
@@ -411,6 +641,22 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/filesystemlist",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/qmp",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/stats",
+						Namespaced: true,
+					},
+					{
+						Name:       "kubevirts/validate-configuration",
+						Namespaced: true,
+					},
+					{
+						Name:       "kubevirts/profile",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/addvolume",
 						Namespaced: true,
@@ -599,7 +845,7 @@ func (app *virtAPIApp) prepareCertManager() {
 
 func (app *virtAPIApp) registerValidatingWebhooks() {
 	http.HandleFunc(components.VMICreateValidatePath, func(w http.ResponseWriter, r *http.Request) {
-		validating_webhook.ServeVMICreate(w, r, app.clusterConfig)
+		validating_webhook.ServeVMICreate(w, r, app.clusterConfig, app.virtCli)
 	})
 	http.HandleFunc(components.VMIUpdateValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMIUpdate(w, r, app.clusterConfig)
@@ -622,6 +868,12 @@ func (app *virtAPIApp) registerValidatingWebhooks() {
 	http.HandleFunc(components.VMSnapshotValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMSnapshots(w, r, app.clusterConfig, app.virtCli)
 	})
+	http.HandleFunc(components.VMSnapshotScheduleValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMSnapshotSchedules(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(components.VMNotificationHookValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMNotificationHooks(w, r, app.clusterConfig)
+	})
 	http.HandleFunc(components.VMRestoreValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMRestores(w, r, app.clusterConfig, app.virtCli)
 	})
@@ -726,6 +978,7 @@ func (app *virtAPIApp) Run() {
 	go webhookInformers.VMIPresetInformer.Run(stopChan)
 	go webhookInformers.NamespaceLimitsInformer.Run(stopChan)
 	go webhookInformers.VMRestoreInformer.Run(stopChan)
+	go webhookInformers.NamespaceInformer.Run(stopChan)
 	go kubeVirtInformer.Run(stopChan)
 	go configMapInformer.Run(stopChan)
 	go crdInformer.Run(stopChan)
@@ -739,6 +992,7 @@ func (app *virtAPIApp) Run() {
 		webhookInformers.VMIInformer.HasSynced,
 		webhookInformers.VMIPresetInformer.HasSynced,
 		webhookInformers.NamespaceLimitsInformer.HasSynced,
+		webhookInformers.NamespaceInformer.HasSynced,
 		configMapInformer.HasSynced)
 
 	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, app.namespace)
@@ -0,0 +1,281 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package configvalidation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook/admitters"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// ConfigValidationApp serves the subresource which reports how a change of
+// the KubeVirt configuration affects the workloads, before it is applied
+type ConfigValidationApp struct {
+	virtCli       kubecli.KubevirtClient
+	clusterConfig *virtconfig.ClusterConfig
+}
+
+func NewConfigValidationApp(virtCli kubecli.KubevirtClient, clusterConfig *virtconfig.ClusterConfig) *ConfigValidationApp {
+	return &ConfigValidationApp{
+		virtCli:       virtCli,
+		clusterConfig: clusterConfig,
+	}
+}
+
+// ValidateConfigurationRequestHandler handles the subresource comparing the
+// workloads against the configuration of the KubeVirt CR in the body
+func (app *ConfigValidationApp) ValidateConfigurationRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if _, err := app.virtCli.KubeVirt(namespace).Get(name, &k8smetav1.GetOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			response.WriteError(http.StatusNotFound, fmt.Errorf("KubeVirt %s/%s does not exist", namespace, name))
+			return
+		}
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("the body has to hold the changed KubeVirt"))
+		return
+	}
+	defer request.Request.Body.Close()
+	proposed := &v1.KubeVirt{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(proposed); err != nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("cannot decode the KubeVirt: %v", err))
+		return
+	}
+
+	proposedConfig, err := app.clusterConfig.WithConfiguration(&proposed.Spec.Configuration)
+	if err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	workloads, err := app.listWorkloads()
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to list the workloads")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	result := v1.KubeVirtConfigurationValidation{
+		Impacts: ConfigurationImpacts(app.clusterConfig, proposedConfig, workloads),
+	}
+	response.WriteHeaderAndJson(http.StatusOK, result, restful.MIME_JSON)
+}
+
+func (app *ConfigValidationApp) listWorkloads() (*Workloads, error) {
+	vmis, err := app.virtCli.VirtualMachineInstance(k8smetav1.NamespaceAll).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	vms, err := app.virtCli.VirtualMachine(k8smetav1.NamespaceAll).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := app.virtCli.VirtualMachineInstanceMigration(k8smetav1.NamespaceAll).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &Workloads{
+		VirtualMachineInstances: vmis.Items,
+		VirtualMachines:         vms.Items,
+		Migrations:              migrations.Items,
+	}, nil
+}
+
+// Workloads are the objects of the cluster which depend on the configuration
+type Workloads struct {
+	VirtualMachineInstances []v1.VirtualMachineInstance
+	VirtualMachines         []v1.VirtualMachine
+	Migrations              []v1.VirtualMachineInstanceMigration
+}
+
+// summary describes how a reason affects the workloads, with the count of
+// the workloads and the verb following it
+type summary struct {
+	format string
+	verb   string
+}
+
+var (
+	dependOnSetting = summary{format: "%s %s on the current setting", verb: "depend"}
+	getNewBinding   = summary{format: "%s %s the new binding on the next start", verb: "get"}
+	keepMigrating   = summary{format: "%s %s migrating with the previous settings", verb: "keep"}
+)
+
+const migrationsReason = "the migration configuration changes"
+
+// impacts collects the affected workloads per reason, in the order the
+// reasons were found
+type impacts struct {
+	reasons   []string
+	byReason  map[string]*v1.KubeVirtConfigurationImpact
+	summaries map[string]summary
+}
+
+func (i *impacts) add(reason string, summary summary, vmi string, vm string) {
+	impact, exists := i.byReason[reason]
+	if !exists {
+		impact = &v1.KubeVirtConfigurationImpact{}
+		i.byReason[reason] = impact
+		i.summaries[reason] = summary
+		i.reasons = append(i.reasons, reason)
+	}
+	if vmi != "" {
+		impact.VirtualMachineInstances = append(impact.VirtualMachineInstances, vmi)
+	}
+	if vm != "" {
+		impact.VirtualMachines = append(impact.VirtualMachines, vm)
+	}
+}
+
+func (i *impacts) list() []v1.KubeVirtConfigurationImpact {
+	var list []v1.KubeVirtConfigurationImpact
+	for _, reason := range i.reasons {
+		impact := i.byReason[reason]
+		summary := i.summaries[reason]
+		vmis, vms := len(impact.VirtualMachineInstances), len(impact.VirtualMachines)
+		verb := summary.verb
+		if vmis+vms == 1 {
+			verb += "s"
+		}
+		impact.Message = fmt.Sprintf("%s: %s", reason, fmt.Sprintf(summary.format, countWorkloads(vmis, vms), verb))
+		list = append(list, *impact)
+	}
+	return list
+}
+
+// ConfigurationImpacts compares the workloads against the current and the
+// proposed configuration. VMIs and VMs which only the proposed configuration
+// rejects depend on a setting the change removes. Besides, it reports the VMs
+// which get a different default interface on their next start and the
+// migrations in progress when the migration configuration changes.
+func ConfigurationImpacts(current *virtconfig.ClusterConfig, proposed *virtconfig.ClusterConfig, workloads *Workloads) []v1.KubeVirtConfigurationImpact {
+	result := &impacts{
+		byReason:  map[string]*v1.KubeVirtConfigurationImpact{},
+		summaries: map[string]summary{},
+	}
+
+	for _, vmi := range workloads.VirtualMachineInstances {
+		if vmi.IsFinal() {
+			continue
+		}
+		for _, reason := range newCauses(&vmi.ObjectMeta, &vmi.Spec, current, proposed) {
+			result.add(reason, dependOnSetting, key(&vmi.ObjectMeta), "")
+		}
+	}
+
+	defaultInterfaceReason := fmt.Sprintf("the default network interface changes from %s to %s",
+		current.GetDefaultNetworkInterface(), proposed.GetDefaultNetworkInterface())
+	for _, vm := range workloads.VirtualMachines {
+		if vm.Spec.Template == nil {
+			continue
+		}
+		for _, reason := range newCauses(&vm.Spec.Template.ObjectMeta, &vm.Spec.Template.Spec, current, proposed) {
+			result.add(reason, dependOnSetting, "", key(&vm.ObjectMeta))
+		}
+		if current.GetDefaultNetworkInterface() != proposed.GetDefaultNetworkInterface() && usesDefaultInterface(&vm.Spec.Template.Spec) {
+			result.add(defaultInterfaceReason, getNewBinding, "", key(&vm.ObjectMeta))
+		}
+	}
+
+	if !reflect.DeepEqual(current.GetMigrationConfiguration(), proposed.GetMigrationConfiguration()) {
+		for _, migration := range workloads.Migrations {
+			if migration.IsFinal() {
+				continue
+			}
+			vmi := migration.Namespace + "/" + migration.Spec.VMIName
+			result.add(migrationsReason, keepMigrating, vmi, "")
+		}
+	}
+
+	return result.list()
+}
+
+// newCauses returns the reasons why the proposed configuration rejects a VMI
+// spec which the current configuration accepts
+func newCauses(metadata *k8smetav1.ObjectMeta, spec *v1.VirtualMachineInstanceSpec, current *virtconfig.ClusterConfig, proposed *virtconfig.ClusterConfig) []string {
+	validate := func(config *virtconfig.ClusterConfig) []k8smetav1.StatusCause {
+		causes := admitters.ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), spec, config)
+		return append(causes, admitters.ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), metadata, config, "")...)
+	}
+
+	accepted := map[string]bool{}
+	for _, cause := range validate(current) {
+		accepted[cause.Message] = true
+	}
+	var reasons []string
+	for _, cause := range validate(proposed) {
+		if !accepted[cause.Message] {
+			accepted[cause.Message] = true
+			reasons = append(reasons, cause.Message)
+		}
+	}
+	return reasons
+}
+
+// usesDefaultInterface mirrors the mutating webhook, which adds an interface
+// with the default binding to VMIs without interfaces and networks
+func usesDefaultInterface(spec *v1.VirtualMachineInstanceSpec) bool {
+	autoattach := spec.Domain.Devices.AutoattachPodInterface
+	if autoattach != nil && !*autoattach {
+		return false
+	}
+	return len(spec.Networks) == 0 && len(spec.Domain.Devices.Interfaces) == 0
+}
+
+func key(meta *k8smetav1.ObjectMeta) string {
+	return meta.Namespace + "/" + meta.Name
+}
+
+func countWorkloads(vmis int, vms int) string {
+	var counts []string
+	if vmis > 0 {
+		counts = append(counts, plural(vmis, "VMI"))
+	}
+	if vms > 0 {
+		counts = append(counts, plural(vms, "VM"))
+	}
+	return strings.Join(counts, " and ")
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,267 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package configvalidation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Configuration validation", func() {
+	newKubeVirt := func(featureGates ...string) *v1.KubeVirt {
+		return &v1.KubeVirt{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: featureGates,
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		}
+	}
+
+	newGPUVMI := func(namespace, name string) v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMIWithNS(namespace, name)
+		vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu", DeviceName: "nvidia.com/GV100GL_Tesla_V100"}}
+		vmi.Status.Phase = v1.Running
+		return *vmi
+	}
+
+	newVM := func(vmi v1.VirtualMachineInstance) v1.VirtualMachine {
+		return v1.VirtualMachine{
+			ObjectMeta: vmi.ObjectMeta,
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: vmi.Spec},
+			},
+		}
+	}
+
+	var current *virtconfig.ClusterConfig
+
+	BeforeEach(func() {
+		current, _, _, _ = testutils.NewFakeClusterConfigUsingKV(newKubeVirt(virtconfig.GPUGate, virtconfig.LiveMigrationGate))
+	})
+
+	withConfiguration := func(kv *v1.KubeVirt) *virtconfig.ClusterConfig {
+		proposed, err := current.WithConfiguration(&kv.Spec.Configuration)
+		Expect(err).ToNot(HaveOccurred())
+		return proposed
+	}
+
+	Context("ConfigurationImpacts", func() {
+		It("should report the workloads depending on a removed feature gate", func() {
+			proposed := withConfiguration(newKubeVirt(virtconfig.LiveMigrationGate))
+			first := newGPUVMI("default", "first")
+			second := newGPUVMI("other", "second")
+			stopped := newGPUVMI("default", "stopped")
+			stopped.Status.Phase = v1.Succeeded
+			plain := v1.NewMinimalVMIWithNS("default", "plain")
+
+			impacts := ConfigurationImpacts(current, proposed, &Workloads{
+				VirtualMachineInstances: []v1.VirtualMachineInstance{first, second, stopped, *plain},
+				VirtualMachines:         []v1.VirtualMachine{newVM(first)},
+			})
+
+			Expect(impacts).To(HaveLen(1))
+			Expect(impacts[0].Message).To(Equal("GPU feature gate is not enabled in kubevirt-config: 2 VMIs and 1 VM depend on the current setting"))
+			Expect(impacts[0].VirtualMachineInstances).To(Equal([]string{"default/first", "other/second"}))
+			Expect(impacts[0].VirtualMachines).To(Equal([]string{"default/first"}))
+		})
+
+		It("should not report anything when the workloads don't depend on the change", func() {
+			proposed := withConfiguration(newKubeVirt(virtconfig.GPUGate, virtconfig.LiveMigrationGate, virtconfig.HostDiskGate))
+
+			impacts := ConfigurationImpacts(current, proposed, &Workloads{
+				VirtualMachineInstances: []v1.VirtualMachineInstance{newGPUVMI("default", "first")},
+			})
+
+			Expect(impacts).To(BeEmpty())
+		})
+
+		It("should report the VMs which get another default interface", func() {
+			kv := newKubeVirt(virtconfig.GPUGate, virtconfig.LiveMigrationGate)
+			kv.Spec.Configuration.NetworkConfiguration = &v1.NetworkConfiguration{NetworkInterface: string(v1.MasqueradeInterface)}
+			proposed := withConfiguration(kv)
+
+			withoutInterfaces := v1.NewMinimalVMIWithNS("default", "defaults")
+			withInterfaces := v1.NewMinimalVMIWithNS("default", "explicit")
+			withInterfaces.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			withInterfaces.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			impacts := ConfigurationImpacts(current, proposed, &Workloads{
+				VirtualMachines: []v1.VirtualMachine{newVM(*withoutInterfaces), newVM(*withInterfaces)},
+			})
+
+			Expect(impacts).To(HaveLen(1))
+			Expect(impacts[0].Message).To(Equal("the default network interface changes from bridge to masquerade: 1 VM gets the new binding on the next start"))
+			Expect(impacts[0].VirtualMachines).To(Equal([]string{"default/defaults"}))
+		})
+
+		It("should report the migrations in progress when the migration configuration changes", func() {
+			kv := newKubeVirt(virtconfig.GPUGate, virtconfig.LiveMigrationGate)
+			allowPostCopy := true
+			kv.Spec.Configuration.MigrationConfiguration = &v1.MigrationConfiguration{AllowPostCopy: &allowPostCopy}
+			proposed := withConfiguration(kv)
+
+			running := v1.VirtualMachineInstanceMigration{
+				ObjectMeta: k8smetav1.ObjectMeta{Namespace: "default", Name: "running"},
+				Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: "testvmi"},
+				Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationRunning},
+			}
+			done := v1.VirtualMachineInstanceMigration{
+				ObjectMeta: k8smetav1.ObjectMeta{Namespace: "default", Name: "done"},
+				Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: "othervmi"},
+				Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationSucceeded},
+			}
+
+			impacts := ConfigurationImpacts(current, proposed, &Workloads{
+				Migrations: []v1.VirtualMachineInstanceMigration{running, done},
+			})
+
+			Expect(impacts).To(HaveLen(1))
+			Expect(impacts[0].Message).To(Equal("the migration configuration changes: 1 VMI keeps migrating with the previous settings"))
+			Expect(impacts[0].VirtualMachineInstances).To(Equal([]string{"default/testvmi"}))
+		})
+	})
+
+	Context("ValidateConfigurationRequestHandler", func() {
+		var ctrl *gomock.Controller
+		var virtClient *kubecli.MockKubevirtClient
+		var kvInterface *kubecli.MockKubeVirtInterface
+		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		var vmInterface *kubecli.MockVirtualMachineInterface
+		var migrationInterface *kubecli.MockVirtualMachineInstanceMigrationInterface
+		var app *ConfigValidationApp
+		var recorder *httptest.ResponseRecorder
+		var response *restful.Response
+
+		newRequest := func(body []byte) *restful.Request {
+			request := restful.NewRequest(&http.Request{Body: nopCloser{bytes.NewReader(body)}})
+			request.PathParameters()["name"] = "kubevirt"
+			request.PathParameters()["namespace"] = "kubevirt"
+			return request
+		}
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient = kubecli.NewMockKubevirtClient(ctrl)
+			kvInterface = kubecli.NewMockKubeVirtInterface(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			migrationInterface = kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
+			virtClient.EXPECT().KubeVirt("kubevirt").Return(kvInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceAll).Return(vmiInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachine(k8smetav1.NamespaceAll).Return(vmInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstanceMigration(k8smetav1.NamespaceAll).Return(migrationInterface).AnyTimes()
+
+			app = NewConfigValidationApp(virtClient, current)
+			recorder = httptest.NewRecorder()
+			response = restful.NewResponse(recorder)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should report the impacts of the KubeVirt in the body", func() {
+			kvInterface.EXPECT().Get("kubevirt", gomock.Any()).Return(newKubeVirt(virtconfig.GPUGate, virtconfig.LiveMigrationGate), nil)
+			vmiInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceList{
+				Items: []v1.VirtualMachineInstance{newGPUVMI("default", "testvmi")},
+			}, nil)
+			vmInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineList{}, nil)
+			migrationInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceMigrationList{}, nil)
+
+			body, err := json.Marshal(newKubeVirt(virtconfig.LiveMigrationGate))
+			Expect(err).ToNot(HaveOccurred())
+			app.ValidateConfigurationRequestHandler(newRequest(body), response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			validation := &v1.KubeVirtConfigurationValidation{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), validation)).To(Succeed())
+			Expect(validation.Impacts).To(HaveLen(1))
+			Expect(validation.Impacts[0].VirtualMachineInstances).To(Equal([]string{"default/testvmi"}))
+		})
+
+		It("should accept the KubeVirt as YAML", func() {
+			kvInterface.EXPECT().Get("kubevirt", gomock.Any()).Return(newKubeVirt(), nil)
+			vmiInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceList{}, nil)
+			vmInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineList{}, nil)
+			migrationInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceMigrationList{}, nil)
+
+			body := []byte("apiVersion: kubevirt.io/v1alpha3\nkind: KubeVirt\nspec:\n  configuration:\n    developerConfiguration:\n      featureGates: []\n")
+			app.ValidateConfigurationRequestHandler(newRequest(body), response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should fail when the KubeVirt does not exist", func() {
+			kvInterface.EXPECT().Get("kubevirt", gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("kubevirt"), "kubevirt"))
+
+			app.ValidateConfigurationRequestHandler(newRequest([]byte("{}")), response)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should fail when the body is no KubeVirt", func() {
+			kvInterface.EXPECT().Get("kubevirt", gomock.Any()).Return(newKubeVirt(), nil)
+
+			app.ValidateConfigurationRequestHandler(newRequest([]byte("{")), response)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail when the workloads can't be listed", func() {
+			kvInterface.EXPECT().Get("kubevirt", gomock.Any()).Return(newKubeVirt(), nil)
+			vmiInterface.EXPECT().List(gomock.Any()).Return(nil, fmt.Errorf("connection refused"))
+
+			app.ValidateConfigurationRequestHandler(newRequest([]byte("{}")), response)
+
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
+
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,159 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const unknownUser = "unknown"
+
+// consoleActivityInterval is the time between two refreshes of the console
+// activity of a VMI while a session is open
+const consoleActivityInterval = time.Minute
+
+// consoleSession is a console, VNC or channel session recorded for auditing
+type consoleSession struct {
+	vmi        *v1.VirtualMachineInstance
+	streamType string
+	channel    string
+	user       string
+	reason     string
+	start      time.Time
+}
+
+// newConsoleSession collects the audit details of a session requested for the VMI
+func (app *SubresourceAPIApp) newConsoleSession(request *restful.Request, vmi *v1.VirtualMachineInstance, streamType string) *consoleSession {
+	return &consoleSession{
+		vmi:        vmi,
+		streamType: streamType,
+		channel:    request.PathParameter("channel"),
+		user:       app.requestUser(request),
+		reason:     vmi.Annotations[v1.ConsoleAccessReasonAnnotation],
+	}
+}
+
+// requestUser returns the user which was authenticated by the apiserver
+// aggregating virt-api
+func (app *SubresourceAPIApp) requestUser(request *restful.Request) string {
+	headers := []string{userHeader}
+	if app.authorizor != nil {
+		headers = app.authorizor.GetUserHeaders()
+	}
+
+	for _, header := range headers {
+		if user := request.Request.Header.Get(header); user != "" {
+			return user
+		}
+	}
+	return unknownUser
+}
+
+func (s *consoleSession) logger() *log.FilteredLogger {
+	logger := log.Log.Object(s.vmi).With("user", s.user, "type", s.streamType, "reason", s.reason)
+	if s.channel != "" {
+		logger = logger.With("channel", s.channel)
+	}
+	return logger
+}
+
+// kind names the session in messages, channel sessions name the channel since
+// every channel can carry a different protocol
+func (s *consoleSession) kind() string {
+	if s.channel != "" {
+		return fmt.Sprintf("%s %s", s.streamType, s.channel)
+	}
+	return s.streamType
+}
+
+// reject records a session refused for lacking a reason and returns the error for the client
+func (app *SubresourceAPIApp) reject(s *consoleSession) *errors.StatusError {
+	s.logger().Warningf("Rejecting %s connection, the VMI has no %s annotation", s.kind(), v1.ConsoleAccessReasonAnnotation)
+	app.recorder.Eventf(s.vmi, k8sv1.EventTypeWarning, v1.ConsoleSessionRejected.String(),
+		"Rejected %s session of user %s, no access reason is given", s.kind(), s.user)
+	return errors.NewForbidden(v1.Resource("virtualmachineinstance"), s.vmi.Name,
+		fmt.Errorf("the %s annotation is required to open a %s session", v1.ConsoleAccessReasonAnnotation, s.kind()))
+}
+
+func (app *SubresourceAPIApp) startSession(s *consoleSession) {
+	s.start = time.Now()
+	s.logger().With("start", s.start.UTC().Format(time.RFC3339)).Infof("%s session started", s.kind())
+	app.recorder.Eventf(s.vmi, k8sv1.EventTypeNormal, v1.ConsoleSessionStarted.String(),
+		"User %s started a %s session%s", s.user, s.kind(), s.reasonSuffix())
+}
+
+func (app *SubresourceAPIApp) endSession(s *consoleSession) {
+	end := time.Now()
+	duration := end.Sub(s.start).Round(time.Second)
+	s.logger().With("start", s.start.UTC().Format(time.RFC3339), "end", end.UTC().Format(time.RFC3339), "duration", duration.String()).
+		Infof("%s session ended", s.kind())
+	app.recorder.Eventf(s.vmi, k8sv1.EventTypeNormal, v1.ConsoleSessionEnded.String(),
+		"User %s ended a %s session after %s%s", s.user, s.kind(), duration, s.reasonSuffix())
+}
+
+func (s *consoleSession) reasonSuffix() string {
+	if s.reason == "" {
+		return ""
+	}
+	return fmt.Sprintf(", reason: %s", s.reason)
+}
+
+// trackConsoleActivity records the console activity of the VMI for the idle
+// policy until the returned function is called at the end of the session
+func (app *SubresourceAPIApp) trackConsoleActivity(vmi *v1.VirtualMachineInstance) (stop func()) {
+	if !app.clusterConfig.IdleSuspendEnabled() {
+		return func() {}
+	}
+
+	app.recordConsoleActivity(vmi)
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(consoleActivityInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				app.recordConsoleActivity(vmi)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		app.recordConsoleActivity(vmi)
+	}
+}
+
+func (app *SubresourceAPIApp) recordConsoleActivity(vmi *v1.VirtualMachineInstance) {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, v1.ConsoleActivityAnnotation, time.Now().UTC().Format(time.RFC3339))
+	if _, err := app.virtCli.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.MergePatchType, []byte(patch)); err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("Failed to record the console activity")
+	}
+}
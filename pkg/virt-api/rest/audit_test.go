@@ -0,0 +1,138 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Console session auditing", func() {
+
+	var eventRecorder *record.FakeRecorder
+	var app *SubresourceAPIApp
+	var vmi *v1.VirtualMachineInstance
+
+	newRequest := func(header http.Header) *restful.Request {
+		return restful.NewRequest(&http.Request{Header: header})
+	}
+
+	BeforeEach(func() {
+		eventRecorder = record.NewFakeRecorder(10)
+		app = &SubresourceAPIApp{recorder: eventRecorder}
+		vmi = v1.NewMinimalVMI("testvmi")
+	})
+
+	It("should take the user from the configured user headers", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		authorizor := NewMockVirtApiAuthorizor(ctrl)
+		authorizor.EXPECT().GetUserHeaders().Return([]string{userHeader, "X-Custom-User"}).AnyTimes()
+		app.authorizor = authorizor
+
+		Expect(app.requestUser(newRequest(http.Header{"X-Custom-User": []string{"bob"}}))).To(Equal("bob"))
+		Expect(app.requestUser(newRequest(http.Header{}))).To(Equal(unknownUser))
+	})
+
+	It("should record the start and the end of a session", func() {
+		vmi.Annotations = map[string]string{v1.ConsoleAccessReasonAnnotation: "incident 42"}
+		session := app.newConsoleSession(newRequest(http.Header{userHeader: []string{"alice"}}), vmi, "console")
+
+		app.startSession(session)
+		Expect(eventRecorder.Events).To(Receive(Equal(fmt.Sprintf("Normal %s User alice started a console session, reason: incident 42", v1.ConsoleSessionStarted))))
+
+		app.endSession(session)
+		Expect(eventRecorder.Events).To(Receive(Equal(fmt.Sprintf("Normal %s User alice ended a console session after 0s, reason: incident 42", v1.ConsoleSessionEnded))))
+	})
+
+	It("should not mention a reason if none is given", func() {
+		session := app.newConsoleSession(newRequest(http.Header{userHeader: []string{"alice"}}), vmi, "vnc")
+
+		app.startSession(session)
+		Expect(eventRecorder.Events).To(Receive(Equal(fmt.Sprintf("Normal %s User alice started a vnc session", v1.ConsoleSessionStarted))))
+	})
+
+	It("should name the channel of a channel session", func() {
+		request := newRequest(http.Header{userHeader: []string{"alice"}})
+		request.PathParameters()["channel"] = "org.example.agent.0"
+		session := app.newConsoleSession(request, vmi, "channel")
+
+		app.startSession(session)
+		Expect(eventRecorder.Events).To(Receive(Equal(fmt.Sprintf("Normal %s User alice started a channel org.example.agent.0 session", v1.ConsoleSessionStarted))))
+	})
+
+	Context("with the IdleSuspend feature gate", func() {
+
+		var ctrl *gomock.Controller
+		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+			virtClient.EXPECT().VirtualMachineInstance(vmi.Namespace).Return(vmiInterface).AnyTimes()
+			app.virtCli = virtClient
+			app.clusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{
+							FeatureGates: []string{virtconfig.IdleSuspendGate},
+						},
+					},
+				},
+				Status: v1.KubeVirtStatus{
+					Phase: v1.KubeVirtPhaseDeployed,
+				},
+			})
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should record the console activity at the start and the end of a session", func() {
+			vmiInterface.EXPECT().Patch(vmi.Name, types.MergePatchType, gomock.Any()).
+				DoAndReturn(func(name string, pt types.PatchType, data []byte, subresources ...string) (*v1.VirtualMachineInstance, error) {
+					Expect(string(data)).To(HavePrefix(fmt.Sprintf(`{"metadata":{"annotations":{"%s":"`, v1.ConsoleActivityAnnotation)))
+					return vmi, nil
+				}).Times(2)
+
+			stop := app.trackConsoleActivity(vmi)
+			stop()
+		})
+	})
+})
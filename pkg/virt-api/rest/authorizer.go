@@ -137,10 +137,19 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 		return nil, fmt.Errorf("no URL in http request")
 	}
 
-	// URL example
+	// URL examples
 	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console
+	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachinebulkoperations/start
 	pathSplit := strings.Split(url.Path, "/")
-	if len(pathSplit) != 9 {
+	var resourceName, subresource string
+	switch {
+	case len(pathSplit) == 9:
+		resourceName = pathSplit[7]
+		subresource = pathSplit[8]
+	case len(pathSplit) == 8 && pathSplit[6] == "virtualmachinebulkoperations":
+		// the bulk operations are reviewed by name, like the API server does
+		resourceName = pathSplit[7]
+	default:
 		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
 
@@ -148,11 +157,9 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	version := pathSplit[3]
 	namespace := pathSplit[5]
 	resource := pathSplit[6]
-	resourceName := pathSplit[7]
-	subresource := pathSplit[8]
 	userExtras := a.getUserExtras(headers)
 
-	if resource != "virtualmachineinstances" && resource != "virtualmachines" {
+	if resource != "virtualmachineinstances" && resource != "virtualmachines" && resource != "virtualmachinetemplates" && resource != "kubevirts" && resource != "virtualmachinebulkoperations" {
 		return nil, fmt.Errorf("unknown resource type %s", resource)
 	}
 
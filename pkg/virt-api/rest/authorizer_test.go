@@ -147,6 +147,31 @@ var _ = Describe("Authorizer", func() {
 				close(done)
 			}, 5)
 
+			It("should review the access to the subresources of KubeVirt", func() {
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1alpha3/namespaces/kubevirt/kubevirts/kubevirt/profile"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.ResourceAttributes.Namespace).To(Equal("kubevirt"))
+				Expect(result.Spec.ResourceAttributes.Verb).To(Equal("get"))
+				Expect(result.Spec.ResourceAttributes.Resource).To(Equal("kubevirts"))
+				Expect(result.Spec.ResourceAttributes.Name).To(Equal("kubevirt"))
+				Expect(result.Spec.ResourceAttributes.Subresource).To(Equal("profile"))
+			})
+
+			It("should review the access to the bulk operations on VirtualMachines by name", func() {
+				req.Request.Method = http.MethodPut
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachinebulkoperations/start"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.ResourceAttributes.Namespace).To(Equal("default"))
+				Expect(result.Spec.ResourceAttributes.Verb).To(Equal("update"))
+				Expect(result.Spec.ResourceAttributes.Resource).To(Equal("virtualmachinebulkoperations"))
+				Expect(result.Spec.ResourceAttributes.Name).To(Equal("start"))
+				Expect(result.Spec.ResourceAttributes.Subresource).To(BeEmpty())
+			})
+
 			table.DescribeTable("should allow all users for info endpoints", func(path string) {
 				req.Request.URL.Path = path
 				allowed, _, err := app.Authorize(req)
@@ -173,6 +198,7 @@ var _ = Describe("Authorizer", func() {
 				table.Entry("random2", "/1/2/3/4/5/6/7/8/9/0/1/2/3/4/5/6/7/8/9"),
 				table.Entry("no subresource provided", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
 				table.Entry("invalid resource type", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/madeupresource/testvmi/console"),
+				table.Entry("no name provided", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachines/start"),
 			)
 		})
 
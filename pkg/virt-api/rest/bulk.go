@@ -0,0 +1,162 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	defaultBulkBatchSize      = 50
+	maxBulkBatchSize          = 500
+	defaultBulkMaxConcurrency = 10
+	maxBulkMaxConcurrency     = 50
+)
+
+// vmOperation applies a lifecycle operation to a single VM
+type vmOperation func(name string, namespace string) *errors.StatusError
+
+// BulkStartVMRequestHandler starts all VMs of a namespace matching a label selector
+func (app *SubresourceAPIApp) BulkStartVMRequestHandler(request *restful.Request, response *restful.Response) {
+	app.bulkVMRequestHandler(request, response, v1.BulkStartOperation, app.startVM)
+}
+
+// BulkStopVMRequestHandler stops all VMs of a namespace matching a label selector
+func (app *SubresourceAPIApp) BulkStopVMRequestHandler(request *restful.Request, response *restful.Response) {
+	app.bulkVMRequestHandler(request, response, v1.BulkStopOperation, app.stopVM)
+}
+
+// BulkMigrateVMRequestHandler migrates all VMs of a namespace matching a label selector
+func (app *SubresourceAPIApp) BulkMigrateVMRequestHandler(request *restful.Request, response *restful.Response) {
+	app.bulkVMRequestHandler(request, response, v1.BulkMigrateOperation, app.migrateVM)
+}
+
+// bulkVMRequestHandler lists the matching VMs batch by batch and applies the operation to the VMs
+// of a batch in parallel, up to the requested concurrency. VMs the operation does not apply to in
+// their current state are skipped, they don't fail the request.
+func (app *SubresourceAPIApp) bulkVMRequestHandler(request *restful.Request, response *restful.Response, operation v1.VirtualMachineBulkOperation, apply vmOperation) {
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.VirtualMachineBulkOperationOptions{}
+	if request.Request.Body != nil {
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	}
+	if statusErr := setBulkOperationDefaults(opts); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	result := &v1.VirtualMachineBulkOperationResult{Operation: operation}
+	listOptions := &k8smetav1.ListOptions{
+		LabelSelector: opts.LabelSelector,
+		Limit:         opts.BatchSize,
+	}
+	for {
+		vms, err := app.virtCli.VirtualMachine(namespace).List(listOptions)
+		if err != nil {
+			writeError(errors.NewInternalError(fmt.Errorf("failed to list the VirtualMachines after processing %d of them: %v", result.Matched, err)), response)
+			return
+		}
+		applyBulkOperation(result, namespace, vms.Items, int(opts.MaxConcurrency), apply)
+		if vms.Continue == "" {
+			break
+		}
+		listOptions.Continue = vms.Continue
+	}
+
+	log.Log.Infof("Applied %s to the VirtualMachines of namespace %s matching %q: %d matched, %d succeeded, %d skipped, %d failed",
+		operation, namespace, opts.LabelSelector, result.Matched, len(result.Succeeded), len(result.Skipped), len(result.Failed))
+	response.WriteHeaderAndJson(http.StatusOK, result, restful.MIME_JSON)
+}
+
+func setBulkOperationDefaults(opts *v1.VirtualMachineBulkOperationOptions) *errors.StatusError {
+	if opts.LabelSelector == "" {
+		return errors.NewBadRequest("a label selector is required")
+	}
+	if _, err := labels.Parse(opts.LabelSelector); err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("invalid label selector: %v", err))
+	}
+
+	if opts.BatchSize == 0 {
+		opts.BatchSize = defaultBulkBatchSize
+	} else if opts.BatchSize < 0 || opts.BatchSize > maxBulkBatchSize {
+		return errors.NewBadRequest(fmt.Sprintf("batchSize has to be between 1 and %d", maxBulkBatchSize))
+	}
+
+	if opts.MaxConcurrency == 0 {
+		opts.MaxConcurrency = defaultBulkMaxConcurrency
+	} else if opts.MaxConcurrency < 0 || opts.MaxConcurrency > maxBulkMaxConcurrency {
+		return errors.NewBadRequest(fmt.Sprintf("maxConcurrency has to be between 1 and %d", maxBulkMaxConcurrency))
+	}
+	return nil
+}
+
+// applyBulkOperation applies the operation to a batch of VMs and adds the outcome to the result,
+// in the order of the batch
+func applyBulkOperation(result *v1.VirtualMachineBulkOperationResult, namespace string, vms []v1.VirtualMachine, maxConcurrency int, apply vmOperation) {
+	errs := make([]*errors.StatusError, len(vms))
+	workers := make(chan struct{}, maxConcurrency)
+
+	wg := sync.WaitGroup{}
+	for i := range vms {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			errs[i] = apply(vms[i].Name, namespace)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, vm := range vms {
+		result.Matched++
+		switch {
+		case errs[i] == nil:
+			result.Succeeded = append(result.Succeeded, vm.Name)
+		case errors.IsConflict(errs[i]) || errors.IsNotFound(errs[i]):
+			// the VM is already in the requested state, can't reach it, or is gone
+			result.Skipped = append(result.Skipped, v1.VirtualMachineBulkOperationMessage{Name: vm.Name, Message: errs[i].ErrStatus.Message})
+		default:
+			result.Failed = append(result.Failed, v1.VirtualMachineBulkOperationMessage{Name: vm.Name, Message: errs[i].ErrStatus.Message})
+		}
+	}
+}
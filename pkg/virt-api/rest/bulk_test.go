@@ -0,0 +1,191 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("Bulk operations", func() {
+	const (
+		vmsPath        = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines"
+		migrationsPath = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"
+	)
+
+	var server *ghttp.Server
+	var app *SubresourceAPIApp
+	var request *restful.Request
+	var recorder *httptest.ResponseRecorder
+	var response *restful.Response
+
+	setBody := func(body string) {
+		request.Request.Body = ioutil.NopCloser(strings.NewReader(body))
+	}
+
+	newReadyVM := func(name string, ready bool) v1.VirtualMachine {
+		vm := newMinimalVM(name)
+		vm.Namespace = "default"
+		vm.Status.Ready = ready
+		return *vm
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		virtCli, err := kubecli.GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+		app = &SubresourceAPIApp{virtCli: virtCli}
+
+		request = restful.NewRequest(&http.Request{})
+		request.PathParameters()["namespace"] = "default"
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	table.DescribeTable("should reject invalid options", func(body string, message string) {
+		setBody(body)
+
+		app.BulkMigrateVMRequestHandler(request, response)
+
+		status := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		Expect(status.Error()).To(ContainSubstring(message))
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	},
+		table.Entry("without body", "", "a label selector is required"),
+		table.Entry("without label selector", `{"batchSize": 10}`, "a label selector is required"),
+		table.Entry("with an invalid label selector", `{"labelSelector": "app in (web"}`, "invalid label selector"),
+		table.Entry("with a too large batch size", `{"labelSelector": "app=web", "batchSize": 501}`, "batchSize has to be between 1 and 500"),
+		table.Entry("with a negative concurrency", `{"labelSelector": "app=web", "maxConcurrency": -1}`, "maxConcurrency has to be between 1 and 50"),
+	)
+
+	It("should migrate the matching VMs batch by batch and summarize the outcome", func() {
+		setBody(`{"labelSelector": "app=web", "batchSize": 2}`)
+
+		vms := []v1.VirtualMachine{newReadyVM("web1", true), newReadyVM("web2", false), newReadyVM("web3", true)}
+		server.RouteToHandler("GET", vmsPath, func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("labelSelector")).To(Equal("app=web"))
+			Expect(r.URL.Query().Get("limit")).To(Equal("2"))
+			if r.URL.Query().Get("continue") == "" {
+				ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.VirtualMachineList{
+					ListMeta: k8smetav1.ListMeta{Continue: "next"},
+					Items:    vms[:2],
+				})(w, r)
+				return
+			}
+			Expect(r.URL.Query().Get("continue")).To(Equal("next"))
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.VirtualMachineList{Items: vms[2:]})(w, r)
+		})
+		for i := range vms {
+			server.RouteToHandler("GET", vmsPath+"/"+vms[i].Name, ghttp.RespondWithJSONEncoded(http.StatusOK, vms[i]))
+		}
+		server.RouteToHandler("POST", migrationsPath, func(w http.ResponseWriter, r *http.Request) {
+			migration := &v1.VirtualMachineInstanceMigration{}
+			Expect(json.NewDecoder(r.Body).Decode(migration)).To(Succeed())
+			if migration.Spec.VMIName == "web3" {
+				ghttp.RespondWithJSONEncoded(http.StatusInternalServerError, nil)(w, r)
+				return
+			}
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, migration)(w, r)
+		})
+
+		app.BulkMigrateVMRequestHandler(request, response)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		result := &v1.VirtualMachineBulkOperationResult{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+		Expect(result.Operation).To(Equal(v1.BulkMigrateOperation))
+		Expect(result.Matched).To(BeEquivalentTo(3))
+		Expect(result.Succeeded).To(Equal([]string{"web1"}))
+		Expect(result.Skipped).To(HaveLen(1))
+		Expect(result.Skipped[0].Name).To(Equal("web2"))
+		Expect(result.Skipped[0].Message).To(ContainSubstring("VM is not running"))
+		Expect(result.Failed).To(HaveLen(1))
+		Expect(result.Failed[0].Name).To(Equal("web3"))
+	})
+
+	It("should fail if the VMs can't be listed", func() {
+		setBody(`{"labelSelector": "app=web"}`)
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", vmsPath),
+				ghttp.RespondWithJSONEncoded(http.StatusInternalServerError, nil),
+			),
+		)
+
+		app.BulkStartVMRequestHandler(request, response)
+
+		ExpectStatusErrorWithCode(recorder, http.StatusInternalServerError)
+	})
+
+	It("should not apply the operation to more VMs than the concurrency at once", func() {
+		vms := make([]v1.VirtualMachine, 20)
+		for i := range vms {
+			vms[i] = newReadyVM(fmt.Sprintf("vm%d", i), true)
+		}
+
+		lock := sync.Mutex{}
+		running, maxRunning := 0, 0
+		apply := func(name string, namespace string) *errors.StatusError {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+			return nil
+		}
+
+		result := &v1.VirtualMachineBulkOperationResult{}
+		applyBulkOperation(result, "default", vms, 3, apply)
+
+		Expect(maxRunning).To(Equal(3))
+		Expect(result.Matched).To(BeEquivalentTo(20))
+		Expect(result.Succeeded).To(HaveLen(20))
+		Expect(result.Succeeded[0]).To(Equal("vm0"))
+		Expect(result.Succeeded[19]).To(Equal("vm19"))
+	})
+})
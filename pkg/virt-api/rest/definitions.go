@@ -32,6 +32,8 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	templatev1 "kubevirt.io/client-go/apis/template/v1alpha1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
 	mime "kubevirt.io/kubevirt/pkg/rest"
 )
 
@@ -42,11 +44,20 @@ func ComposeAPIDefinitions() []*restful.WebService {
 	vmipGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachineinstancepresets"}
 	vmGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachines"}
 	migrationGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachineinstancemigrations"}
+	vmnhGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachinenotificationhooks"}
+	frGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "fencingrequests"}
 	kubeVirtGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "kubevirt"}
 
 	vmsGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshots")
 	vmscGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshotcontents")
 	vmrGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinerestores")
+	vmssGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshotschedules")
+
+	vmovfiGVR := vmimportv1.SchemeGroupVersion.WithResource("virtualmachineovfimports")
+	vmv2viGVR := vmimportv1.SchemeGroupVersion.WithResource("virtualmachinev2vimports")
+	vmcliGVR := vmimportv1.SchemeGroupVersion.WithResource("virtualmachineclusterimports")
+
+	vmtGVR := templatev1.SchemeGroupVersion.WithResource("virtualmachinetemplates")
 
 	ws, err := GroupVersionProxyBase(v1.GroupVersion)
 	if err != nil {
@@ -82,6 +93,16 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
+	ws, err = GenericResourceProxy(ws, vmnhGVR, &v1.VirtualMachineNotificationHook{}, v1.VirtualMachineNotificationHookGroupVersionKind.Kind, &v1.VirtualMachineNotificationHookList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = GenericResourceProxy(ws, frGVR, &v1.FencingRequest{}, v1.FencingRequestGroupVersionKind.Kind, &v1.FencingRequestList{})
+	if err != nil {
+		panic(err)
+	}
+
 	ws1, err := ResourceProxyAutodiscovery(vmiGVR)
 	if err != nil {
 		panic(err)
@@ -107,12 +128,57 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
+	ws2, err = GenericResourceProxy(ws2, vmssGVR, &snapshotv1.VirtualMachineSnapshotSchedule{}, "VirtualMachineSnapshotSchedule", &snapshotv1.VirtualMachineSnapshotScheduleList{})
+	if err != nil {
+		panic(err)
+	}
+
 	ws3, err := ResourceProxyAutodiscovery(vmsGVR)
 	if err != nil {
 		panic(err)
 	}
 
-	return []*restful.WebService{ws, ws1, ws2, ws3}
+	ws4, err := GroupVersionProxyBase(vmimportv1.SchemeGroupVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericResourceProxy(ws4, vmovfiGVR, &vmimportv1.VirtualMachineOVFImport{}, "VirtualMachineOVFImport", &vmimportv1.VirtualMachineOVFImportList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericResourceProxy(ws4, vmv2viGVR, &vmimportv1.VirtualMachineV2VImport{}, "VirtualMachineV2VImport", &vmimportv1.VirtualMachineV2VImportList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericResourceProxy(ws4, vmcliGVR, &vmimportv1.VirtualMachineClusterImport{}, "VirtualMachineClusterImport", &vmimportv1.VirtualMachineClusterImportList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws5, err := ResourceProxyAutodiscovery(vmovfiGVR)
+	if err != nil {
+		panic(err)
+	}
+
+	ws6, err := GroupVersionProxyBase(templatev1.SchemeGroupVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	ws6, err = GenericResourceProxy(ws6, vmtGVR, &templatev1.VirtualMachineTemplate{}, "VirtualMachineTemplate", &templatev1.VirtualMachineTemplateList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws7, err := ResourceProxyAutodiscovery(vmtGVR)
+	if err != nil {
+		panic(err)
+	}
+
+	return []*restful.WebService{ws, ws1, ws2, ws3, ws4, ws5, ws6, ws7}
 }
 
 func GroupVersionProxyBase(gv schema.GroupVersion) (*restful.WebService, error) {
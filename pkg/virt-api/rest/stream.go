@@ -0,0 +1,205 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	streamDirectionToClient  = "to_client"
+	streamDirectionToHandler = "to_handler"
+)
+
+var (
+	activeStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubevirt_console_active_connections",
+		Help: "Number of console and VNC connections currently proxied by virt-api.",
+	}, []string{"type"})
+
+	rejectedStreams = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevirt_console_rejected_connections_total",
+		Help: "Number of console and VNC connections rejected because the maximum number of sessions was reached.",
+	}, []string{"type"})
+
+	streamedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubevirt_console_transmitted_bytes_total",
+		Help: "Number of bytes proxied by virt-api between console and VNC clients and virt-handler.",
+	}, []string{"type", "direction"})
+
+	// streamBuffers are shared by all proxied streams, so that a connection
+	// does not allocate buffers of its own
+	streamBuffers = sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, kubecli.WebsocketMessageBufferSize)
+			return &buf
+		},
+	}
+)
+
+func init() {
+	prometheus.MustRegister(activeStreams, rejectedStreams, streamedBytes)
+}
+
+// acquireStream reserves one of the maxSessions stream slots, 0 meaning
+// unlimited. It returns false if all slots are taken.
+func (app *SubresourceAPIApp) acquireStream(maxSessions uint32) bool {
+	for {
+		active := atomic.LoadInt32(&app.activeStreams)
+		if maxSessions > 0 && active >= int32(maxSessions) {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&app.activeStreams, active, active+1) {
+			return true
+		}
+	}
+}
+
+func (app *SubresourceAPIApp) releaseStream() {
+	atomic.AddInt32(&app.activeStreams, -1)
+}
+
+// proxyStream passes the messages between the client and virt-handler until
+// either side closes its connection or, if idleTimeout is set, no traffic
+// passed in either direction for that long. Messages are read through the
+// websocket connections, since they may already hold data read together with
+// the handshake.
+func proxyStream(vmi *v1.VirtualMachineInstance, streamType string, client *websocket.Conn, handler *websocket.Conn, idleTimeout time.Duration) error {
+	closeConns := func() {
+		client.UnderlyingConn().Close()
+		handler.UnderlyingConn().Close()
+	}
+
+	var idleTimer *time.Timer
+	idle := int32(0)
+	if idleTimeout > 0 {
+		idleTimer = time.AfterFunc(idleTimeout, func() {
+			atomic.StoreInt32(&idle, 1)
+			closeConns()
+		})
+		defer idleTimer.Stop()
+	}
+
+	activeStreams.WithLabelValues(streamType).Inc()
+	defer activeStreams.WithLabelValues(streamType).Dec()
+
+	type copyResult struct {
+		direction string
+		written   int64
+		err       error
+	}
+	results := make(chan copyResult, 2)
+	copyStream := func(direction string, dst *websocket.Conn, src *websocket.Conn) {
+		written, err := copyMessages(dst, src, idleTimer, idleTimeout)
+		streamedBytes.WithLabelValues(streamType, direction).Add(float64(written))
+		results <- copyResult{direction: direction, written: written, err: err}
+	}
+	start := time.Now()
+	go copyStream(streamDirectionToClient, client, handler)
+	go copyStream(streamDirectionToHandler, handler, client)
+
+	// the first direction to finish ends the session, closing the
+	// connections unblocks the other one
+	first := <-results
+	closeConns()
+	second := <-results
+
+	written := map[string]int64{first.direction: first.written, second.direction: second.written}
+	logger := log.Log.Object(vmi)
+	if atomic.LoadInt32(&idle) == 1 {
+		logger.Infof("closing idle %s connection after %s", streamType, idleTimeout)
+	}
+	logger.V(3).Infof("%s connection closed after %s, %d bytes sent to the client, %d bytes sent to virt-handler",
+		streamType, time.Since(start).Round(time.Second), written[streamDirectionToClient], written[streamDirectionToHandler])
+
+	if atomic.LoadInt32(&idle) == 1 {
+		return nil
+	}
+	return first.err
+}
+
+// copyMessages passes the messages of src on to dst until src is closed. A
+// close message of src is passed on as well.
+func copyMessages(dst *websocket.Conn, src *websocket.Conn, idleTimer *time.Timer, idleTimeout time.Duration) (int64, error) {
+	buf := streamBuffers.Get().(*[]byte)
+	defer streamBuffers.Put(buf)
+
+	var written int64
+	for {
+		msgType, reader, err := src.NextReader()
+		if err != nil {
+			return written, forwardClose(dst, err)
+		}
+		writer, err := dst.NextWriter(msgType)
+		if err != nil {
+			return written, err
+		}
+		n, err := io.CopyBuffer(writer, &activityReader{reader: reader, timer: idleTimer, timeout: idleTimeout}, *buf)
+		written += n
+		if err != nil {
+			writer.Close()
+			return written, err
+		}
+		if err := writer.Close(); err != nil {
+			return written, err
+		}
+	}
+}
+
+// forwardClose passes a close message on to dst. Closing a connection is
+// the regular end of a stream and not reported as an error.
+func forwardClose(dst *websocket.Conn, err error) error {
+	closeErr, ok := err.(*websocket.CloseError)
+	if !ok {
+		return err
+	}
+	switch closeErr.Code {
+	case websocket.CloseAbnormalClosure, websocket.CloseNoStatusReceived:
+		// the codes can't be sent, the peer went away without a close message
+	default:
+		dst.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeErr.Code, closeErr.Text), time.Now().Add(time.Second))
+	}
+	return nil
+}
+
+// activityReader postpones the idle timeout of a stream whenever data is read.
+type activityReader struct {
+	reader  io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (r *activityReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.timer != nil {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
@@ -0,0 +1,169 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("Stream proxy", func() {
+
+	var server *httptest.Server
+	var accepted chan *websocket.Conn
+
+	// connect returns both ends of a new websocket connection
+	connect := func() (*websocket.Conn, *websocket.Conn) {
+		dialed, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		Expect(err).ToNot(HaveOccurred())
+		return dialed, <-accepted
+	}
+
+	BeforeEach(func() {
+		accepted = make(chan *websocket.Conn, 1)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			conn, err := kubecli.NewUpgrader().Upgrade(w, r, nil)
+			Expect(err).ToNot(HaveOccurred())
+			accepted <- conn
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should pass messages in both directions until a side closes", func(done Done) {
+		client, proxyClient := connect()
+		proxyHandler, handler := connect()
+
+		result := make(chan error)
+		go func() {
+			result <- proxyStream(v1.NewMinimalVMI("testvmi"), "console", proxyClient, proxyHandler, 0)
+		}()
+
+		Expect(client.WriteMessage(websocket.BinaryMessage, []byte("ls\n"))).To(Succeed())
+		_, msg, err := handler.ReadMessage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(msg)).To(Equal("ls\n"))
+
+		Expect(handler.WriteMessage(websocket.BinaryMessage, []byte("bin boot"))).To(Succeed())
+		_, msg, err = client.ReadMessage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(msg)).To(Equal("bin boot"))
+
+		client.Close()
+		Expect(<-result).To(Succeed())
+		_, _, err = handler.ReadMessage()
+		Expect(err).To(HaveOccurred())
+		close(done)
+	}, 5)
+
+	It("should pass on messages read together with the handshake", func(done Done) {
+		// a handler writing the handshake response and its first message at once
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer listener.Close()
+		go func() {
+			defer GinkgoRecover()
+			conn, err := listener.Accept()
+			Expect(err).ToNot(HaveOccurred())
+			request, err := http.ReadRequest(bufio.NewReader(conn))
+			Expect(err).ToNot(HaveOccurred())
+			accept := sha1.Sum([]byte(request.Header.Get("Sec-WebSocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+			response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+				"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n\r\n"
+			_, err = conn.Write(append([]byte(response), 0x82, 6, 'l', 'o', 'g', 'i', 'n', ':'))
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		client, proxyClient := connect()
+		defer client.Close()
+		proxyHandler, _, err := websocket.DefaultDialer.Dial("ws://"+listener.Addr().String(), nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		go proxyStream(v1.NewMinimalVMI("testvmi"), "console", proxyClient, proxyHandler, 0)
+
+		_, msg, err := client.ReadMessage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(msg)).To(Equal("login:"))
+		close(done)
+	}, 5)
+
+	It("should pass on the close message of a side", func(done Done) {
+		client, proxyClient := connect()
+		proxyHandler, handler := connect()
+		defer handler.Close()
+
+		result := make(chan error)
+		go func() {
+			result <- proxyStream(v1.NewMinimalVMI("testvmi"), "console", proxyClient, proxyHandler, 0)
+		}()
+
+		Expect(handler.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "bye"))).To(Succeed())
+		_, _, err := client.ReadMessage()
+		Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+		Expect(<-result).To(Succeed())
+		close(done)
+	}, 5)
+
+	It("should close idle connections", func(done Done) {
+		client, proxyClient := connect()
+		proxyHandler, handler := connect()
+		defer client.Close()
+		defer handler.Close()
+
+		start := time.Now()
+		Expect(proxyStream(v1.NewMinimalVMI("testvmi"), "vnc", proxyClient, proxyHandler, 200*time.Millisecond)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+		_, _, err := client.ReadMessage()
+		Expect(err).To(HaveOccurred())
+		close(done)
+	}, 5)
+
+	It("should limit the number of concurrent streams", func() {
+		app := SubresourceAPIApp{}
+		Expect(app.acquireStream(2)).To(BeTrue())
+		Expect(app.acquireStream(2)).To(BeTrue())
+		Expect(app.acquireStream(2)).To(BeFalse())
+		app.releaseStream()
+		Expect(app.acquireStream(2)).To(BeTrue())
+	})
+
+	It("should not limit the number of streams if no maximum is set", func() {
+		app := SubresourceAPIApp{}
+		for i := 0; i < 100; i++ {
+			Expect(app.acquireStream(0)).To(BeTrue())
+		}
+	})
+})
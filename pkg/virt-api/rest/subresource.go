@@ -21,13 +21,17 @@ package rest
 
 import (
 	"crypto/tls"
+	"encoding/xml"
 	goerror "errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	v12 "k8s.io/api/core/v1"
@@ -38,26 +42,37 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/record"
 
 	"kubevirt.io/kubevirt/pkg/util/status"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/profiler"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
+	"kubevirt.io/kubevirt/pkg/vmtemplate"
 )
 
 type SubresourceAPIApp struct {
+	activeStreams           int32
 	virtCli                 kubecli.KubevirtClient
 	consoleServerPort       int
 	handlerTLSConfiguration *tls.Config
 	credentialsLock         *sync.Mutex
 	statusUpdater           *status.VMStatusUpdater
 	clusterConfig           *virtconfig.ClusterConfig
+	authorizor              VirtApiAuthorizor
+	recorder                record.EventRecorder
+	// applyVMIDefaults applies what the mutating webhook adds to a new VMI
+	applyVMIDefaults func(vmi *v1.VirtualMachineInstance) error
 }
 
-func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int, tlsConfiguration *tls.Config, clusterConfig *virtconfig.ClusterConfig) *SubresourceAPIApp {
+func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int, tlsConfiguration *tls.Config, clusterConfig *virtconfig.ClusterConfig, authorizor VirtApiAuthorizor, recorder record.EventRecorder, applyVMIDefaults func(vmi *v1.VirtualMachineInstance) error) *SubresourceAPIApp {
 	return &SubresourceAPIApp{
 		virtCli:                 virtCli,
 		consoleServerPort:       consoleServerPort,
@@ -65,6 +80,9 @@ func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int,
 		handlerTLSConfiguration: tlsConfiguration,
 		statusUpdater:           status.NewVMStatusUpdater(virtCli),
 		clusterConfig:           clusterConfig,
+		authorizor:              authorizor,
+		recorder:                recorder,
+		applyVMIDefaults:        applyVMIDefaults,
 	}
 }
 
@@ -101,7 +119,7 @@ func (app *SubresourceAPIApp) prepareConnection(request *restful.Request, valida
 	return
 }
 
-func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, response *restful.Response, validate validation, getVirtHandlerURL URLResolver) {
+func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, response *restful.Response, streamType string, validate validation, getVirtHandlerURL URLResolver) {
 
 	var err error
 	vmi, url, _, statusError := app.prepareConnection(request, validate, getVirtHandlerURL)
@@ -110,6 +128,21 @@ func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, res
 		return
 	}
 
+	consoleConfig := app.clusterConfig.GetConsoleConfiguration()
+	session := app.newConsoleSession(request, vmi, streamType)
+	if consoleConfig.RequireAccessReason && session.reason == "" {
+		writeError(app.reject(session), response)
+		return
+	}
+
+	if !app.acquireStream(*consoleConfig.MaxSessions) {
+		rejectedStreams.WithLabelValues(streamType).Inc()
+		log.Log.Object(vmi).Warningf("Rejecting %s connection, the maximum of %d sessions is reached", streamType, *consoleConfig.MaxSessions)
+		writeError(errors.NewTooManyRequests(fmt.Sprintf("the maximum of %d console, VNC and channel sessions is reached", *consoleConfig.MaxSessions), 10), response)
+		return
+	}
+	defer app.releaseStream()
+
 	upgrader := kubecli.NewUpgrader()
 	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
@@ -127,24 +160,13 @@ func (app *SubresourceAPIApp) streamRequestHandler(request *restful.Request, res
 	}
 	defer conn.Close()
 
-	copyErr := make(chan error)
-	go func() {
-		_, err := kubecli.Copy(clientSocket, conn)
-		log.Log.Object(vmi).Reason(err).Error("error encountered reading from virt-handler stream")
-		copyErr <- err
-	}()
-
-	go func() {
-		_, err := kubecli.Copy(conn, clientSocket)
-		log.Log.Object(vmi).Reason(err).Error("error encountered reading from client stream")
-		copyErr <- err
-	}()
-
-	// wait for copy to finish and check the result
-	if err = <-copyErr; err != nil && err != io.EOF {
+	app.startSession(session)
+	defer app.endSession(session)
+	defer app.trackConsoleActivity(vmi)()
+
+	idleTimeout := time.Duration(*consoleConfig.IdleTimeoutSeconds) * time.Second
+	if err = proxyStream(vmi, streamType, clientSocket, conn, idleTimeout); err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Error in websocket proxy")
-		writeError(errors.NewInternalError(err), response)
-		return
 	}
 }
 
@@ -180,7 +202,7 @@ func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, respon
 	getConsoleURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 		return conn.VNCURI(vmi)
 	}
-	app.streamRequestHandler(request, response, validate, getConsoleURL)
+	app.streamRequestHandler(request, response, "vnc", validate, getConsoleURL)
 }
 
 func (app *SubresourceAPIApp) getVirtHandlerConnForVMI(vmi *v1.VirtualMachineInstance) (kubecli.VirtHandlerConn, error) {
@@ -206,7 +228,64 @@ func (app *SubresourceAPIApp) ConsoleRequestHandler(request *restful.Request, re
 	getConsoleURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 		return conn.ConsoleURI(vmi)
 	}
-	app.streamRequestHandler(request, response, validate, getConsoleURL)
+	app.streamRequestHandler(request, response, "console", validate, getConsoleURL)
+}
+
+func (app *SubresourceAPIApp) ChannelRequestHandler(request *restful.Request, response *restful.Response) {
+	channel := request.PathParameter("channel")
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		for _, c := range vmi.Spec.Domain.Devices.Channels {
+			if c.Name == channel {
+				return nil
+			}
+		}
+		err := fmt.Errorf("channel %s is not present", channel)
+		log.Log.Object(vmi).Reason(err).Error("Can't establish a channel connection.")
+		return errors.NewBadRequest(err.Error())
+	}
+	getChannelURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.ChannelURI(vmi, channel)
+	}
+	app.streamRequestHandler(request, response, "channel", validate, getChannelURL)
+}
+
+// HypervisorLogRequestHandler streams the qemu log and the libvirt lifecycle
+// events of a VMI from virt-handler. The stream is read-only, it is not a
+// console session and is not limited by the console configuration.
+func (app *SubresourceAPIApp) HypervisorLogRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.HypervisorLogURI(vmi)
+	}
+
+	vmi, url, _, statusError := app.prepareConnection(request, validate, getURL)
+	if statusError != nil {
+		writeError(statusError, response)
+		return
+	}
+
+	upgrader := kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+	defer clientSocket.Close()
+
+	conn, _, err := kubecli.Dial(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to dial virt-handler for a hypervisor log connection")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	defer conn.Close()
+
+	if err = proxyStream(vmi, "hypervisorlog", clientSocket, conn, 0); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Error in websocket proxy")
+	}
 }
 
 func getChangeRequestJson(vm *v1.VirtualMachine, changes ...v1.VirtualMachineStateChangeRequest) (string, error) {
@@ -276,45 +355,42 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
-	vm, err := app.fetchVirtualMachine(name, namespace)
-	if err != nil {
+	if err := app.migrateVM(name, namespace); err != nil {
 		writeError(err, response)
 		return
 	}
 
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (app *SubresourceAPIApp) migrateVM(name string, namespace string) *errors.StatusError {
+	vm, err := app.fetchVirtualMachine(name, namespace)
+	if err != nil {
+		return err
+	}
+
 	if !vm.Status.Ready {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running")), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running"))
 	}
 
 	for _, c := range vm.Status.Conditions {
 		if c.Type == v1.VirtualMachinePaused && c.Status == v12.ConditionTrue {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is paused")), response)
-			return
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is paused"))
 		}
 	}
 
-	createMigrationJob := func() *errors.StatusError {
-		_, err := app.virtCli.VirtualMachineInstanceMigration(namespace).Create(&v1.VirtualMachineInstanceMigration{
-			ObjectMeta: k8smetav1.ObjectMeta{
-				GenerateName: "kubevirt-migrate-vm-",
-			},
-			Spec: v1.VirtualMachineInstanceMigrationSpec{
-				VMIName: name,
-			},
-		})
-		if err != nil {
-			return errors.NewInternalError(err)
-		}
-		return nil
-	}
-
-	if err = createMigrationJob(); err != nil {
-		writeError(err, response)
-		return
+	_, createErr := app.virtCli.VirtualMachineInstanceMigration(namespace).Create(&v1.VirtualMachineInstanceMigration{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			GenerateName: "kubevirt-migrate-vm-",
+		},
+		Spec: v1.VirtualMachineInstanceMigrationSpec{
+			VMIName: name,
+		},
+	})
+	if createErr != nil {
+		return errors.NewInternalError(createErr)
 	}
-
-	response.WriteHeader(http.StatusAccepted)
+	return nil
 }
 
 func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request, response *restful.Response) {
@@ -552,29 +628,34 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
+	if err := app.startVM(name, namespace); err != nil {
+		writeError(err, response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (app *SubresourceAPIApp) startVM(name string, namespace string) *errors.StatusError {
 	vm, statusErr := app.fetchVirtualMachine(name, namespace)
 	if statusErr != nil {
-		writeError(statusErr, response)
-		return
+		return statusErr
 	}
 
 	for _, req := range vm.Status.StateChangeRequests {
 		if req.Action == v1.RenameRequest {
-			writeError(errors.NewBadRequest("Starting a VM during a rename process is not allowed"), response)
-			return
+			return errors.NewBadRequest("Starting a VM during a rename process is not allowed")
 		}
 	}
 
 	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
 	if err != nil {
 		if !errors.IsNotFound(err) {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
 	}
 	if vmi != nil && !vmi.IsFinal() && vmi.Status.Phase != v1.Unknown && vmi.Status.Phase != v1.VmPhaseUnset {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is already running")), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is already running"))
 	}
 
 	patchType := types.MergePatchType
@@ -582,8 +663,7 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 	// RunStrategyHalted         -> spec.running = true
 	// RunStrategyManual         -> send start request
@@ -602,8 +682,7 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 			(runStrategy == v1.RunStrategyManual && vmi != nil && vmi.IsFinal()) {
 			needsRestart = true
 		} else if runStrategy == v1.RunStrategyRerunOnFailure && vmi != nil && vmi.Status.Phase == v1.Failed {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support starting VM from failed state", v1.RunStrategyRerunOnFailure)), response)
-			return
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support starting VM from failed state", v1.RunStrategyRerunOnFailure))
 		}
 
 		var bodyString string
@@ -616,69 +695,68 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 				v1.VirtualMachineStateChangeRequest{Action: v1.StartRequest})
 		}
 		if err != nil {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
 		log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
 		patchErr = app.statusUpdater.PatchStatus(vm, patchType, []byte(bodyString))
 	case v1.RunStrategyAlways:
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", v1.RunStrategyAlways)), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", v1.RunStrategyAlways))
 	}
 
 	if patchErr != nil {
 		if strings.Contains(patchErr.Error(), "jsonpatch test operation does not apply") {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr), response)
-		} else {
-			writeError(errors.NewInternalError(patchErr), response)
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr)
 		}
+		return errors.NewInternalError(patchErr)
+	}
+
+	return nil
+}
+
+func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if err := app.stopVM(name, namespace); err != nil {
+		writeError(err, response)
 		return
 	}
 
 	response.WriteHeader(http.StatusAccepted)
 }
 
-func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, response *restful.Response) {
+func (app *SubresourceAPIApp) stopVM(name string, namespace string) *errors.StatusError {
 	// RunStrategyHalted         -> doesn't make sense
 	// RunStrategyManual         -> send stop request
 	// RunStrategyAlways         -> spec.running = false
 	// RunStrategyRerunOnFailure -> spec.running = false
 
-	name := request.PathParameter("name")
-	namespace := request.PathParameter("namespace")
-
 	vm, statusErr := app.fetchVirtualMachine(name, namespace)
 	if statusErr != nil {
-		writeError(statusErr, response)
-		return
+		return statusErr
 	}
 
 	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
 	if err != nil {
 		if !errors.IsNotFound(err) {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		} else {
-			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running")), response)
-			return
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running"))
 		}
 	}
 	if vmi == nil || vmi.IsFinal() || vmi.Status.Phase == v1.Unknown || vmi.Status.Phase == v1.VmPhaseUnset {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running")), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running"))
 	}
 
 	patchType := types.MergePatchType
 	var patchErr error
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
-		writeError(errors.NewInternalError(err), response)
-		return
+		return errors.NewInternalError(err)
 	}
 	switch runStrategy {
 	case v1.RunStrategyHalted:
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual stop requests", v1.RunStrategyHalted)), response)
-		return
+		return errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual stop requests", v1.RunStrategyHalted))
 	case v1.RunStrategyManual:
 		// pass the buck and ask virt-controller to stop the VM. this way the
 		// VM will retain RunStrategy = manual
@@ -686,8 +764,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 		bodyString, err := getChangeRequestJson(vm,
 			v1.VirtualMachineStateChangeRequest{Action: v1.StopRequest, UID: &vmi.UID})
 		if err != nil {
-			writeError(errors.NewInternalError(err), response)
-			return
+			return errors.NewInternalError(err)
 		}
 		log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
 		patchErr = app.statusUpdater.PatchStatus(vm, patchType, []byte(bodyString))
@@ -697,6 +774,103 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 		_, patchErr = app.virtCli.VirtualMachine(namespace).Patch(vm.GetName(), patchType, []byte(bodyString))
 	}
 
+	if patchErr != nil {
+		if strings.Contains(patchErr.Error(), "jsonpatch test operation does not apply") {
+			return errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr)
+		}
+		return errors.NewInternalError(patchErr)
+	}
+
+	return nil
+}
+
+// clusterConfigForNamespace returns the view of the cluster config for the
+// workloads of a namespace, in which the scoped feature gates are only enabled
+// if they select the namespace
+func (app *SubresourceAPIApp) clusterConfigForNamespace(namespace string) *virtconfig.ClusterConfig {
+	if !app.clusterConfig.HasScopedFeatureGates() {
+		return app.clusterConfig
+	}
+	var labels map[string]string
+	ns, err := app.virtCli.CoreV1().Namespaces().Get(namespace, k8smetav1.GetOptions{})
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to look up the labels of namespace %s", namespace)
+	} else {
+		labels = ns.Labels
+	}
+	return app.clusterConfig.ForNamespace(namespace, labels)
+}
+
+func (app *SubresourceAPIApp) HibernateVMRequestHandler(request *restful.Request, response *restful.Response) {
+	// RunStrategyHalted         -> doesn't make sense
+	// RunStrategyManual         -> send hibernate request
+	// RunStrategyAlways         -> send hibernate request and spec.running = false
+	// RunStrategyRerunOnFailure -> send hibernate request and spec.running = false
+
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfigForNamespace(namespace).HibernationEnabled() {
+		writeError(errors.NewBadRequest("Unable to hibernate VM because Hibernation feature gate is not enabled."), response)
+		return
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	if vm.Spec.Hibernation == nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("VM %s has no hibernation configured", name)), response)
+		return
+	}
+
+	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			writeError(errors.NewInternalError(err), response)
+			return
+		} else {
+			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running")), response)
+			return
+		}
+	}
+	if vmi == nil || vmi.Status.Phase != v1.Running {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running")), response)
+		return
+	}
+	// a VMI started before hibernation was configured has no claim to save its memory to
+	if _, hasClaim := vmi.Annotations[v1.HibernationClaimAnnotation]; !hasClaim {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM has to be restarted before it can be hibernated")), response)
+		return
+	}
+
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	if runStrategy == v1.RunStrategyHalted {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support hibernate requests", v1.RunStrategyHalted)), response)
+		return
+	}
+
+	// the request is placed before spec.running is changed, so that
+	// virt-controller hibernates the VMI instead of just stopping it
+	bodyString, err := getChangeRequestJson(vm,
+		v1.VirtualMachineStateChangeRequest{Action: v1.HibernateRequest, UID: &vmi.UID})
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
+	patchErr := app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(bodyString))
+	if patchErr == nil && (runStrategy == v1.RunStrategyAlways || runStrategy == v1.RunStrategyRerunOnFailure) {
+		bodyString := getRunningJson(vm, false)
+		log.Log.Object(vm).V(4).Infof("Patching VM: %s", bodyString)
+		_, patchErr = app.virtCli.VirtualMachine(namespace).Patch(vm.GetName(), types.MergePatchType, []byte(bodyString))
+	}
+
 	if patchErr != nil {
 		if strings.Contains(patchErr.Error(), "jsonpatch test operation does not apply") {
 			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, patchErr), response)
@@ -751,6 +925,38 @@ func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request,
 
 }
 
+func (app *SubresourceAPIApp) FreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI has no connected guest agent"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.FreezeURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.UnfreezeURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
 func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, *errors.StatusError) {
 
 	vm, err := app.virtCli.VirtualMachine(namespace).Get(name, &k8smetav1.GetOptions{})
@@ -905,6 +1111,137 @@ func (app *SubresourceAPIApp) FilesystemList(request *restful.Request, response
 	response.WriteEntity(filesystemList)
 }
 
+// QMPCommand handles the subresource for executing read-only QMP commands on the qemu monitor of a VMI
+func (app *SubresourceAPIApp) QMPCommand(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfigForNamespace(request.PathParameter("namespace")).QMPPassthroughEnabled() {
+		writeError(errors.NewBadRequest("Unable to execute QMP command because QMPPassthrough feature gate is not enabled."), response)
+		return
+	}
+
+	command := request.QueryParameter("command")
+	if !api.IsReadOnlyQMPCommand(command) {
+		writeError(errors.NewBadRequest(fmt.Sprintf("QMP command %q is not allowed, allowed commands are: %s", command, strings.Join(api.ReadOnlyQMPCommands, ", "))), response)
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.QMPCommandURI(vmi, command)
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	result, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to execute QMP command %s", command)
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.AddHeader("Content-Type", restful.MIME_JSON)
+	response.WriteHeader(http.StatusOK)
+	response.Write([]byte(result))
+}
+
+// Stats handles the subresource for providing the resource usage counters of a VMI
+func (app *SubresourceAPIApp) Stats(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.StatsURI(vmi)
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	resp, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to get the VMI stats")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	stats := v1.VirtualMachineInstanceStats{}
+	if err := json.Unmarshal([]byte(resp), &stats); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling stats response")
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.WriteEntity(stats)
+}
+
+// ProfileRequestHandler handles the subresource for collecting the profiles of
+// virt-handler and of the virt-launchers on a node, the bundle is collected by
+// the virt-handler of the node
+func (app *SubresourceAPIApp) ProfileRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.ProfilingEnabled() {
+		writeError(errors.NewBadRequest("Unable to collect profiles because Profiling feature gate is not enabled."), response)
+		return
+	}
+
+	node := request.QueryParameter("node")
+	if node == "" {
+		writeError(errors.NewBadRequest("the node to profile is required"), response)
+		return
+	}
+	duration, err := profiler.ParseDuration(request.QueryParameter("seconds"))
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	conn := kubecli.NewVirtHandlerClient(app.virtCli).Port(app.consoleServerPort).ForNode(node)
+	url, err := conn.ProfileURI(int(duration.Seconds()))
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: app.handlerTLSConfiguration,
+		},
+		// the profiles are recorded for the duration before the bundle is written
+		Timeout: duration + time.Minute,
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to get the profiles of node %s", node)
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		writeError(errors.NewInternalError(fmt.Errorf("virt-handler on node %s failed to collect the profiles: %s", node, strings.TrimSpace(string(body)))), response)
+		return
+	}
+
+	response.AddHeader("Content-Type", "application/gzip")
+	response.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(response, resp.Body); err != nil {
+		log.Log.Reason(err).Errorf("Failed to stream the profiles of node %s", node)
+	}
+}
+
 func generateVMVolumeRequestPatch(vm *v1.VirtualMachine, volumeRequest *v1.VirtualMachineVolumeRequest) (string, error) {
 	verb := "add"
 	if len(vm.Status.VolumeRequests) > 0 {
@@ -1024,7 +1361,7 @@ func (app *SubresourceAPIApp) addVolumeRequestHandler(request *restful.Request,
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
-	if !app.clusterConfig.HotplugVolumesEnabled() {
+	if !app.clusterConfigForNamespace(namespace).HotplugVolumesEnabled() {
 		writeError(errors.NewBadRequest("Unable to Add Volume because HotplugVolumes feature gate is not enabled."), response)
 		return
 	}
@@ -1114,7 +1451,7 @@ func (app *SubresourceAPIApp) removeVolumeRequestHandler(request *restful.Reques
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
-	if !app.clusterConfig.HotplugVolumesEnabled() {
+	if !app.clusterConfigForNamespace(namespace).HotplugVolumesEnabled() {
 		writeError(errors.NewBadRequest("Unable to Remove Volume because HotplugVolumes feature gate is not enabled."), response)
 		return
 	}
@@ -1212,3 +1549,121 @@ func (app *SubresourceAPIApp) VMIAddVolumeRequestHandler(request *restful.Reques
 func (app *SubresourceAPIApp) VMIRemoveVolumeRequestHandler(request *restful.Request, response *restful.Response) {
 	app.removeVolumeRequestHandler(request, response, true)
 }
+
+// defaultOVMFPath mirrors the default --ovmf-path of virt-launcher
+const defaultOVMFPath = "/usr/share/OVMF"
+
+// VMIDomainXMLRequestHandler handles the subresource for rendering the libvirt domain of a VMI
+func (app *SubresourceAPIApp) VMIDomainXMLRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vmi, statusErr := app.fetchVirtualMachineInstance(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	app.writeDomainXML(vmi, response)
+}
+
+// VMDomainXMLRequestHandler handles the subresource for rendering the libvirt domain a VM would start with
+func (app *SubresourceAPIApp) VMDomainXMLRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	if vm.Spec.Template == nil {
+		writeError(errors.NewBadRequest("VM has no VMI template"), response)
+		return
+	}
+
+	app.writeDomainXML(vmiFromTemplate(vm), response)
+}
+
+// vmiFromTemplate returns the VMI a VM would be started with, defaulted but
+// without the changes of the mutating webhook
+func vmiFromTemplate(vm *v1.VirtualMachine) *v1.VirtualMachineInstance {
+	vmi := v1.NewVMIReferenceFromNameWithNS(vm.Namespace, vm.Name)
+	vmi.ObjectMeta.Labels = vm.Spec.Template.ObjectMeta.Labels
+	vmi.ObjectMeta.Annotations = vm.Spec.Template.ObjectMeta.Annotations
+	vmi.Spec = *vm.Spec.Template.Spec.DeepCopy()
+	v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+	return vmi
+}
+
+func (app *SubresourceAPIApp) writeDomainXML(vmi *v1.VirtualMachineInstance, response *restful.Response) {
+	domainXML, err := renderDomainXML(vmi, app.clusterConfig.IsEmulationAllowed(vmi))
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("failed to render the domain: %v", err)), response)
+		return
+	}
+
+	response.AddHeader("Content-Type", restful.MIME_XML)
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write(domainXML); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
+
+// renderDomainXML converts the VMI spec into the domain virt-launcher would define,
+// including the decorations the network bindings apply to the interfaces.
+func renderDomainXML(vmi *v1.VirtualMachineInstance, useEmulation bool) ([]byte, error) {
+	domain := &api.Domain{}
+	c := &api.ConverterContext{
+		Architecture:   runtime.GOARCH,
+		VirtualMachine: vmi,
+		UseEmulation:   useEmulation,
+		OVMFPath:       defaultOVMFPath,
+		RenderOnly:     true,
+	}
+	if err := api.Convert_v1_VirtualMachine_To_api_Domain(vmi, domain, c); err != nil {
+		return nil, err
+	}
+	if err := network.RenderDomainInterfaces(vmi, domain); err != nil {
+		return nil, err
+	}
+	return xml.MarshalIndent(domain.Spec, "", "  ")
+}
+
+// ProcessVMTemplateRequestHandler substitutes the given parameter values into a
+// VirtualMachineTemplate and returns the resulting VirtualMachine without creating it.
+func (app *SubresourceAPIApp) ProcessVMTemplateRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	opts := &templatev1alpha1.ProcessOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s",
+				err)), response)
+			return
+		}
+	}
+
+	template, err := app.virtCli.VirtualMachineTemplate(namespace).Get(name, &k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			writeError(errors.NewNotFound(templatev1alpha1.Resource("virtualmachinetemplate"), name), response)
+			return
+		}
+		writeError(errors.NewInternalError(fmt.Errorf("unable to retrieve vm template [%s]: %v", name, err)), response)
+		return
+	}
+
+	vm, err := vmtemplate.Process(template, opts.Parameters)
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("failed to process the template: %v", err)), response)
+		return
+	}
+
+	response.WriteHeaderAndJson(http.StatusOK, vm, restful.MIME_JSON)
+}
@@ -29,6 +29,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -44,9 +45,12 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -357,6 +361,106 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			close(done)
 		}, 5)
 
+		It("should fail to connect to a channel which is not present", func(done Done) {
+
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			request.PathParameters()["channel"] = "org.example.missing.0"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.ObjectMeta.SetUID(uuid.NewUUID())
+			vmi.Spec.Domain.Devices.Channels = []v1.Channel{{Name: "org.example.agent.0"}}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.ChannelRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			close(done)
+		}, 5)
+
+		It("should fail to stream the hypervisor log of a VMI which is not running", func(done Done) {
+
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Scheduled
+			vmi.ObjectMeta.SetUID(uuid.NewUUID())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.HypervisorLogRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			close(done)
+		}, 5)
+
+		Context("with an access reason required", func() {
+			var eventRecorder *record.FakeRecorder
+
+			BeforeEach(func() {
+				eventRecorder = record.NewFakeRecorder(10)
+				app.recorder = eventRecorder
+
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.ConsoleConfiguration = &v1.ConsoleConfiguration{RequireAccessReason: true}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			})
+
+			AfterEach(func() {
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kv)
+			})
+
+			expectRunningVMI := func(annotations map[string]string) {
+				request.PathParameters()["name"] = "testvmi"
+				request.PathParameters()["namespace"] = "default"
+
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Annotations = annotations
+				vmi.Status.Phase = v1.Running
+				vmi.Status.NodeName = "mynode"
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+				expectHandlerPod()
+			}
+
+			table.DescribeTable("should reject sessions to VMIs without a reason", func(handler func(*restful.Request, *restful.Response), streamType string) {
+				request.Request.Header = http.Header{userHeader: []string{"alice"}}
+				expectRunningVMI(nil)
+
+				handler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusForbidden)
+				Expect(eventRecorder.Events).To(Receive(Equal(fmt.Sprintf("Warning %s Rejected %s session of user alice, no access reason is given", v1.ConsoleSessionRejected, streamType))))
+			},
+				table.Entry("console", app.ConsoleRequestHandler, "console"),
+				table.Entry("VNC", app.VNCRequestHandler, "vnc"),
+			)
+
+			It("should accept sessions to VMIs with a reason", func() {
+				expectRunningVMI(map[string]string{v1.ConsoleAccessReasonAnnotation: "ticket 1234"})
+
+				app.ConsoleRequestHandler(request, response)
+				// the session only fails because the request is no websocket upgrade
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(eventRecorder.Events).To(BeEmpty())
+			})
+		})
+
 		It("should fail if VirtualMachine not exists", func(done Done) {
 			request.PathParameters()["name"] = "testvm"
 			request.PathParameters()["namespace"] = "default"
@@ -1451,6 +1555,135 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
+	Context("Subresource api - HibernateVMRequestHandler", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+			enableFeatureGate(virtconfig.HibernationGate)
+		})
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		hibernatingVirtualMachine := func(runStrategy v1.VirtualMachineRunStrategy) *v1.VirtualMachine {
+			vm := newVirtualMachineWithRunStrategy(runStrategy)
+			vm.Spec.Hibernation = &v1.VirtualMachineHibernation{}
+			return vm
+		}
+
+		hibernatableVirtualMachineInstance := func() *v1.VirtualMachineInstance {
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+			vmi.Annotations = map[string]string{v1.HibernationClaimAnnotation: "testvm-hibernation"}
+			return vmi
+		}
+
+		expectVM := func(vm *v1.VirtualMachine) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+		}
+
+		expectVMI := func(vmi *v1.VirtualMachineInstance) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		It("should fail without the feature gate", func() {
+			disableFeatureGates()
+
+			app.HibernateVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("Hibernation feature gate is not enabled"))
+		})
+
+		It("should fail on a VM without hibernation", func() {
+			expectVM(newVirtualMachineWithRunStrategy(v1.RunStrategyManual))
+
+			app.HibernateVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("no hibernation configured"))
+		})
+
+		It("should fail if the VMI has no hibernation claim", func() {
+			expectVM(hibernatingVirtualMachine(v1.RunStrategyManual))
+			expectVMI(newVirtualMachineInstanceInPhase(v1.Running))
+
+			app.HibernateVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("has to be restarted"))
+		})
+
+		table.DescribeTable("should fail if the VMI is not running", func(phase v1.VirtualMachineInstancePhase) {
+			vmi := hibernatableVirtualMachineInstance()
+			vmi.Status.Phase = phase
+			expectVM(hibernatingVirtualMachine(v1.RunStrategyManual))
+			expectVMI(vmi)
+
+			app.HibernateVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("VM is not running"))
+		},
+			table.Entry("Scheduling", v1.Scheduling),
+			table.Entry("Succeeded", v1.Succeeded),
+		)
+
+		It("should fail on VM with RunStrategyHalted", func() {
+			expectVM(hibernatingVirtualMachine(v1.RunStrategyHalted))
+			expectVMI(hibernatableVirtualMachineInstance())
+
+			app.HibernateVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("does not support hibernate requests"))
+		})
+
+		table.DescribeTable("should request the hibernation with RunStrategy", func(runStrategy v1.VirtualMachineRunStrategy) {
+			vm := hibernatingVirtualMachine(runStrategy)
+			expectVM(vm)
+			expectVMI(hibernatableVirtualMachineInstance())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(`"action":"Hibernate"`))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+			if runStrategy != v1.RunStrategyManual {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					),
+				)
+			}
+
+			app.HibernateVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		},
+			table.Entry("Always", v1.RunStrategyAlways),
+			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure),
+			table.Entry("Manual", v1.RunStrategyManual),
+		)
+	})
+
 	Context("Subresource api - MigrateVMRequestHandler", func() {
 		It("should fail if VirtualMachine not exists", func(done Done) {
 			request.PathParameters()["name"] = "testvm"
@@ -1632,6 +1865,352 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
+	Context("Subresource api - QMP", func() {
+		setCommand := func(command string) {
+			request.Request.URL = &url.URL{RawQuery: url.Values{"command": []string{command}}.Encode()}
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			enableFeatureGate(virtconfig.QMPPassthroughGate)
+		})
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("should fail without the feature gate", func() {
+			disableFeatureGates()
+			setCommand("query-status")
+
+			app.QMPCommand(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("QMPPassthrough feature gate is not enabled"))
+		})
+
+		It("should reject commands which are not read-only", func() {
+			setCommand("system_powerdown")
+
+			app.QMPCommand(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring(`QMP command "system_powerdown" is not allowed`))
+		})
+
+		It("should fail when the VMI is not running", func() {
+			setCommand("query-status")
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVirtualMachineInstanceInPhase(v1.Scheduled)),
+				),
+			)
+
+			app.QMPCommand(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("VMI is not running"))
+		})
+
+		It("should return the result of the command", func() {
+			setCommand("query-status")
+			result := `{"running":true,"singlestep":false,"status":"running"}`
+			expectVMI(running, false)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/qmp", "command=query-status"),
+					ghttp.RespondWith(http.StatusOK, result),
+				),
+			)
+
+			app.QMPCommand(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(Equal(result))
+		})
+	})
+
+	Context("Subresource api - stats", func() {
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail when the VMI is not running", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVirtualMachineInstanceInPhase(v1.Scheduled)),
+				),
+			)
+
+			app.Stats(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("VMI is not running"))
+		})
+
+		It("should return the stats of the VMI", func() {
+			stats := v1.VirtualMachineInstanceStats{
+				VCPUs:                 2,
+				CPUTimeNanoseconds:    1000000000,
+				MemoryWorkingSetBytes: 1024,
+				NetworkReceiveBytes:   512,
+			}
+			expectVMI(running, false)
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/stats"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, stats),
+				),
+			)
+			response.SetRequestAccepts(restful.MIME_JSON)
+
+			app.Stats(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			result := v1.VirtualMachineInstanceStats{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.VCPUs).To(Equal(int64(2)))
+			Expect(result.CPUTimeNanoseconds).To(Equal(int64(1000000000)))
+			Expect(result.MemoryWorkingSetBytes).To(Equal(int64(1024)))
+			Expect(result.NetworkReceiveBytes).To(Equal(int64(512)))
+		})
+	})
+
+	Context("Subresource api - profile", func() {
+		setQuery := func(query url.Values) {
+			request.Request.URL = &url.URL{RawQuery: query.Encode()}
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "kubevirt"
+			request.PathParameters()["namespace"] = "kubevirt"
+			enableFeatureGate(virtconfig.ProfilingGate)
+		})
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("should fail without the feature gate", func() {
+			disableFeatureGates()
+			setQuery(url.Values{"node": []string{"mynode"}})
+
+			app.ProfileRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("Profiling feature gate is not enabled"))
+		})
+
+		table.DescribeTable("should reject invalid parameters", func(query url.Values, message string) {
+			setQuery(query)
+
+			app.ProfileRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring(message))
+		},
+			table.Entry("without a node", url.Values{}, "the node to profile is required"),
+			table.Entry("with too long durations", url.Values{"node": []string{"mynode"}, "seconds": []string{"300"}}, "seconds has to be between 1 and 50"),
+		)
+
+		It("should stream the profile bundle of the node", func() {
+			setQuery(url.Values{"node": []string{"mynode"}, "seconds": []string{"5"}})
+			expectHandlerPod()
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/debug/profile", "seconds=5"),
+					ghttp.RespondWith(http.StatusOK, "bundle"),
+				),
+			)
+
+			app.ProfileRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/gzip"))
+			Expect(recorder.Body.String()).To(Equal("bundle"))
+		})
+
+		It("should report the failures of virt-handler", func() {
+			setQuery(url.Values{"node": []string{"mynode"}})
+			expectHandlerPod()
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/debug/profile", "seconds=20"),
+					ghttp.RespondWith(http.StatusForbidden, "the Profiling feature gate is not enabled"),
+				),
+			)
+
+			app.ProfileRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusInternalServerError)
+			Expect(statusErr.Error()).To(ContainSubstring("virt-handler on node mynode failed to collect the profiles: the Profiling feature gate is not enabled"))
+		})
+	})
+
+	Context("Subresource api - domain XML", func() {
+		newBridgeVMISpec := func() v1.VirtualMachineInstanceSpec {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			return vmi.Spec
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail if the VMI does not exist", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.VMIDomainXMLRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+		})
+
+		It("should render the domain of a VMI including the interface target", func() {
+			vmi := v1.NewMinimalVMIWithNS("default", "testvm")
+			vmi.Spec = newBridgeVMISpec()
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.VMIDomainXMLRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal(restful.MIME_XML))
+			Expect(recorder.Body.String()).To(ContainSubstring("<name>default_testvm</name>"))
+			Expect(recorder.Body.String()).To(ContainSubstring(`<target dev="tap0" managed="no"></target>`))
+		})
+
+		It("should render the domain of a stopped VM from its template", func() {
+			vm := newMinimalVM("testvm")
+			vm.Namespace = "default"
+			vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{Spec: newBridgeVMISpec()}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.VMDomainXMLRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(ContainSubstring("<name>default_testvm</name>"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("should fail if the VM has no template", func() {
+			vm := newMinimalVM("testvm")
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.VMDomainXMLRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+	})
+
+	Context("Subresource api - template processing", func() {
+		const templatePath = "/apis/template.kubevirt.io/v1alpha1/namespaces/default/virtualmachinetemplates/small"
+
+		newTemplate := func() *templatev1alpha1.VirtualMachineTemplate {
+			return &templatev1alpha1.VirtualMachineTemplate{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "small", Namespace: "default"},
+				Spec: templatev1alpha1.VirtualMachineTemplateSpec{
+					Parameters: []templatev1alpha1.TemplateParameter{
+						{Name: "NAME", Type: templatev1alpha1.ParameterName, Required: true},
+						{Name: "MEMORY", Type: templatev1alpha1.ParameterMemory, Value: "1Gi"},
+					},
+					VirtualMachine: runtime.RawExtension{Raw: []byte(`{
+						"apiVersion": "kubevirt.io/v1alpha3",
+						"kind": "VirtualMachine",
+						"metadata": {"name": "${NAME}"},
+						"spec": {"template": {"spec": {"domain": {
+							"devices": {},
+							"resources": {"requests": {"memory": "${MEMORY}"}}
+						}}}}
+					}`)},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "small"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail if the template does not exist", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", templatePath),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.ProcessVMTemplateRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+		})
+
+		It("should return the processed VirtualMachine without creating it", func() {
+			body, _ := json.Marshal(&templatev1alpha1.ProcessOptions{Parameters: map[string]string{"NAME": "web"}})
+			request.Request.Body = &readCloserWrapper{bytes.NewReader(body)}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", templatePath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newTemplate()),
+				),
+			)
+
+			app.ProcessVMTemplateRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			vm := &v1.VirtualMachine{}
+			Expect(json.NewDecoder(recorder.Body).Decode(vm)).To(Succeed())
+			Expect(vm.Name).To(Equal("web"))
+			Expect(vm.Namespace).To(Equal("default"))
+			memory := vm.Spec.Template.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+			Expect(memory.String()).To(Equal("1Gi"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("should fail if a required parameter is missing", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", templatePath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newTemplate()),
+				),
+			)
+
+			app.ProcessVMTemplateRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+	})
+
 	Context("StateChange JSON", func() {
 		It("should create a stop request if status exists", func() {
 			uid := uuid.NewUUID()
@@ -1849,6 +2428,80 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Freezing", func() {
+		expectAgentVMI := func(running, agentConnected bool) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+			vmi.Status.Phase = v1.Running
+			if !running {
+				vmi.Status.Phase = v1.Failed
+			}
+			if agentConnected {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceAgentConnected,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			expectHandlerPod()
+		}
+
+		It("Should freeze a running VMI with a connected guest agent", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/freeze"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectAgentVMI(true, true)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail freezing a VMI without a connected guest agent", func() {
+			expectAgentVMI(true, false)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail freezing a not running VMI", func() {
+			expectAgentVMI(false, true)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should unfreeze a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/unfreeze"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectAgentVMI(true, false)
+
+			app.UnfreezeVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+	})
+
 	AfterEach(func() {
 		server.Close()
 		backend.Close()
@@ -0,0 +1,371 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/util/net/nad"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+// startValidationLauncherImage stands in for the virt-launcher image when the
+// pod is rendered to find the resources it requests, the image is not used
+const startValidationLauncherImage = "virt-launcher"
+
+// startValidation collects the blockers found by the start checks
+type startValidation struct {
+	blockers []v1.VirtualMachineStartBlocker
+}
+
+func (s *startValidation) block(check v1.VirtualMachineStartCheck, format string, args ...interface{}) {
+	s.blockers = append(s.blockers, v1.VirtualMachineStartBlocker{
+		Check:   check,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateStartVMRequestHandler handles the subresource running the checks a start of the VM
+// has to pass, without starting it
+func (app *SubresourceAPIApp) ValidateStartVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	validation := &startValidation{}
+	if err := app.validateStart(vm, validation); err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	result := v1.VirtualMachineStartValidation{
+		Startable: len(validation.blockers) == 0,
+		Blockers:  validation.blockers,
+	}
+	response.WriteHeaderAndJson(http.StatusOK, result, restful.MIME_JSON)
+}
+
+func (app *SubresourceAPIApp) validateStart(vm *v1.VirtualMachine, validation *startValidation) error {
+	if err := app.validateStartState(vm, validation); err != nil {
+		return err
+	}
+	if vm.Spec.Template == nil {
+		validation.block(v1.StartCheckState, "VM has no VMI template")
+		return nil
+	}
+
+	vmi := vmiFromTemplate(vm)
+	if err := app.applyVMIDefaults(vmi); err != nil {
+		validation.block(v1.StartCheckState, "VMI can't be created: %v", err)
+		return nil
+	}
+	if err := app.validateStartStorage(vm, vmi, validation); err != nil {
+		return err
+	}
+	unusableNetworks, err := app.validateStartNetworks(vmi, validation)
+	if err != nil {
+		return err
+	}
+
+	deviceResources, err := app.launcherDeviceResources(vmi, unusableNetworks)
+	if err != nil {
+		return err
+	}
+	return app.validateStartNodes(vmi, deviceResources, validation)
+}
+
+// launcherDeviceResources renders the virt-launcher pod of the VMI the way
+// virt-controller does and returns the device plugin resources it requests.
+// Networks which can't be used are left out, they are reported already.
+func (app *SubresourceAPIApp) launcherDeviceResources(vmi *v1.VirtualMachineInstance, unusableNetworks map[string]bool) (k8sv1.ResourceList, error) {
+	vmi = vmi.DeepCopy()
+	if len(unusableNetworks) > 0 {
+		var networks []v1.Network
+		for _, network := range vmi.Spec.Networks {
+			if !unusableNetworks[network.Name] {
+				networks = append(networks, network)
+			}
+		}
+		var interfaces []v1.Interface
+		for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+			if !unusableNetworks[iface.Name] {
+				interfaces = append(interfaces, iface)
+			}
+		}
+		vmi.Spec.Networks = networks
+		vmi.Spec.Domain.Devices.Interfaces = interfaces
+	}
+
+	// the volume modes of the claims only change how the volumes are passed
+	// to the pod, claims which don't exist yet are reported already
+	claims := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, volume := range vmi.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claimName = volume.DataVolume.Name
+		default:
+			continue
+		}
+		if err := claims.Add(&k8sv1.PersistentVolumeClaim{ObjectMeta: k8smetav1.ObjectMeta{Name: claimName, Namespace: vmi.Namespace}}); err != nil {
+			return nil, err
+		}
+	}
+
+	templateService := services.NewTemplateService(startValidationLauncherImage, "", "", "", "", "", "", claims, app.virtCli, app.clusterConfig, 0)
+	pod, err := templateService.RenderLaunchManifest(vmi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the virt-launcher pod: %v", err)
+	}
+	return podDeviceResources(pod), nil
+}
+
+// podDeviceResources sums up the device plugin resources the containers of
+// the pod request. Device plugin resources are requested as limits and are
+// the only resources with a domain prefix the pod requests.
+func podDeviceResources(pod *k8sv1.Pod) k8sv1.ResourceList {
+	resources := k8sv1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for resourceName, quantity := range container.Resources.Limits {
+			if !strings.Contains(string(resourceName), "/") {
+				continue
+			}
+			sum := resources[resourceName]
+			sum.Add(quantity)
+			resources[resourceName] = sum
+		}
+	}
+	return resources
+}
+
+// validateStartState mirrors the checks of the start subresource
+func (app *SubresourceAPIApp) validateStartState(vm *v1.VirtualMachine, validation *startValidation) error {
+	for _, req := range vm.Status.StateChangeRequests {
+		if req.Action == v1.RenameRequest {
+			validation.block(v1.StartCheckState, "VM is being renamed")
+		}
+	}
+
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return err
+	}
+	if runStrategy == v1.RunStrategyAlways {
+		validation.block(v1.StartCheckState, "%v does not support manual start requests", v1.RunStrategyAlways)
+	}
+
+	vmi, err := app.virtCli.VirtualMachineInstance(vm.Namespace).Get(vm.Name, &k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !vmi.IsFinal() && vmi.Status.Phase != v1.Unknown && vmi.Status.Phase != v1.VmPhaseUnset {
+		validation.block(v1.StartCheckState, "VM is already running")
+	} else if runStrategy == v1.RunStrategyRerunOnFailure && vmi.Status.Phase == v1.Failed {
+		validation.block(v1.StartCheckState, "%v does not support starting VM from failed state", v1.RunStrategyRerunOnFailure)
+	}
+	return nil
+}
+
+// validateStartStorage checks that the claims, DataVolumes and ConfigMaps the
+// volumes refer to exist and are usable. DataVolumes created from the
+// templates of the VM don't have to exist yet.
+func (app *SubresourceAPIApp) validateStartStorage(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance, validation *startValidation) error {
+	templates := map[string]bool{}
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		templates[template.Name] = true
+	}
+
+	for _, volume := range vmi.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName := volume.PersistentVolumeClaim.ClaimName
+			pvc, err := app.virtCli.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(claimName, k8smetav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				validation.block(v1.StartCheckStorage, "PersistentVolumeClaim %s of volume %s does not exist", claimName, volume.Name)
+			} else if err != nil {
+				return err
+			} else if pvc.Status.Phase == k8sv1.ClaimLost {
+				validation.block(v1.StartCheckStorage, "PersistentVolumeClaim %s of volume %s lost its PersistentVolume", claimName, volume.Name)
+			}
+		case volume.DataVolume != nil:
+			if templates[volume.DataVolume.Name] {
+				continue
+			}
+			dataVolume, err := app.virtCli.CdiClient().CdiV1alpha1().DataVolumes(vmi.Namespace).Get(volume.DataVolume.Name, k8smetav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				validation.block(v1.StartCheckStorage, "DataVolume %s of volume %s does not exist", volume.DataVolume.Name, volume.Name)
+			} else if err != nil {
+				return err
+			} else if dataVolume.Status.Phase == cdiv1.Failed {
+				validation.block(v1.StartCheckStorage, "DataVolume %s of volume %s failed", volume.DataVolume.Name, volume.Name)
+			}
+		case volume.ConfigMap != nil:
+			if volume.ConfigMap.Optional != nil && *volume.ConfigMap.Optional {
+				continue
+			}
+			_, err := app.virtCli.CoreV1().ConfigMaps(vmi.Namespace).Get(volume.ConfigMap.Name, k8smetav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				validation.block(v1.StartCheckStorage, "ConfigMap %s of volume %s does not exist", volume.ConfigMap.Name, volume.Name)
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateStartNetworks checks that the network attachment definitions exist
+// and may be used, and that the cluster permits the bindings of the pod
+// network. It returns the multus networks which can't be used.
+func (app *SubresourceAPIApp) validateStartNetworks(vmi *v1.VirtualMachineInstance, validation *startValidation) (map[string]bool, error) {
+	unusable := map[string]bool{}
+	for _, network := range vmi.Spec.Networks {
+		if network.Pod != nil {
+			for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+				if iface.Name != network.Name {
+					continue
+				}
+				if iface.Bridge != nil && !app.clusterConfig.IsBridgeInterfaceOnPodNetworkEnabled() {
+					validation.block(v1.StartCheckNetwork, "bridge interface %s is not permitted on the pod network", iface.Name)
+				}
+				if iface.Slirp != nil && !app.clusterConfig.IsSlirpInterfaceEnabled() {
+					validation.block(v1.StartCheckNetwork, "slirp interface %s is not permitted", iface.Name)
+				}
+			}
+		}
+		if network.Multus == nil {
+			continue
+		}
+
+		nadNamespace, nadName := vmi.Namespace, network.Multus.NetworkName
+		if strings.Contains(nadName, "/") {
+			parts := strings.SplitN(nadName, "/", 2)
+			nadNamespace, nadName = parts[0], parts[1]
+		}
+
+		allowed, message, err := nad.CanServiceAccountUseNetwork(app.virtCli.AuthorizationV1().SubjectAccessReviews(), nadNamespace, nadName, vmi.Namespace, nad.ServiceAccountName(&vmi.Spec))
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			validation.block(v1.StartCheckNetwork, "%s", message)
+			unusable[network.Name] = true
+			continue
+		}
+
+		_, err = app.virtCli.NetworkClient().K8sCniCncfIoV1().NetworkAttachmentDefinitions(nadNamespace).Get(nadName, k8smetav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			validation.block(v1.StartCheckNetwork, "network attachment definition %s/%s of network %s does not exist", nadNamespace, nadName, network.Name)
+			unusable[network.Name] = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return unusable, nil
+}
+
+// validateStartNodes checks that a schedulable node matches the node selector
+// of the virt-launcher pod and provides the device plugin resources it needs
+func (app *SubresourceAPIApp) validateStartNodes(vmi *v1.VirtualMachineInstance, deviceResources k8sv1.ResourceList, validation *startValidation) error {
+	nodeSelector := services.RequiredNodeSelector(vmi, app.clusterConfig)
+	nodes, err := app.virtCli.CoreV1().Nodes().List(k8smetav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(nodeSelector).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var candidates []k8sv1.Node
+	for _, node := range nodes.Items {
+		if !node.Spec.Unschedulable {
+			candidates = append(candidates, node)
+		}
+	}
+	if len(candidates) == 0 {
+		validation.block(v1.StartCheckNode, "no schedulable node matches the node selector %s", labels.SelectorFromSet(nodeSelector).String())
+		return nil
+	}
+
+	var resourceNames []string
+	for resourceName := range deviceResources {
+		resourceNames = append(resourceNames, string(resourceName))
+	}
+	sort.Strings(resourceNames)
+
+	missing := false
+	for _, resourceName := range resourceNames {
+		requested := deviceResources[k8sv1.ResourceName(resourceName)]
+		provided := false
+		for _, node := range candidates {
+			allocatable := node.Status.Allocatable[k8sv1.ResourceName(resourceName)]
+			if allocatable.Cmp(requested) >= 0 {
+				provided = true
+				break
+			}
+		}
+		if !provided {
+			missing = true
+			validation.block(v1.StartCheckDevices, "no node matching the node selector provides %s of %s", requested.String(), resourceName)
+		}
+	}
+	if missing {
+		return nil
+	}
+
+	for _, node := range candidates {
+		if nodeProvides(node, deviceResources) {
+			return nil
+		}
+	}
+	validation.block(v1.StartCheckDevices, "no single node matching the node selector provides all of %s", strings.Join(resourceNames, ", "))
+	return nil
+}
+
+func nodeProvides(node k8sv1.Node, resources k8sv1.ResourceList) bool {
+	for resourceName, requested := range resources {
+		allocatable := node.Status.Allocatable[resourceName]
+		if allocatable.Cmp(requested) < 0 {
+			return false
+		}
+	}
+	return true
+}
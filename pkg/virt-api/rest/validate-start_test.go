@@ -0,0 +1,356 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+var _ = Describe("Start validation", func() {
+	const (
+		vmPath    = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"
+		vmiPath   = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"
+		pvcPath   = "/api/v1/namespaces/default/persistentvolumeclaims/rootdisk"
+		nadPath   = "/apis/k8s.cni.cncf.io/v1/namespaces/default/network-attachment-definitions/sriov"
+		nodesPath = "/api/v1/nodes"
+	)
+
+	var server *ghttp.Server
+	var app *SubresourceAPIApp
+	var vm *v1.VirtualMachine
+	var request *restful.Request
+	var recorder *httptest.ResponseRecorder
+	var response *restful.Response
+
+	kv := &v1.KubeVirt{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			Name:      "kubevirt",
+			Namespace: "kubevirt",
+		},
+		Spec: v1.KubeVirtSpec{
+			Configuration: v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{},
+			},
+		},
+		Status: v1.KubeVirtStatus{
+			Phase: v1.KubeVirtPhaseDeploying,
+		},
+	}
+	config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+
+	newNode := func(allocatable k8sv1.ResourceList) k8sv1.Node {
+		return k8sv1.Node{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "node01"},
+			Status:     k8sv1.NodeStatus{Allocatable: allocatable},
+		}
+	}
+
+	launcherResources := k8sv1.ResourceList{
+		services.KvmDevice: resource.MustParse("110"),
+		services.TunDevice: resource.MustParse("110"),
+	}
+
+	expectVM := func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", vmPath),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", vmiPath),
+				ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+			),
+		)
+	}
+
+	expectPVC := func(status int) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", pvcPath),
+				ghttp.RespondWithJSONEncoded(status, &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: k8smetav1.ObjectMeta{Name: "rootdisk", Namespace: "default"},
+					Status:     k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimBound},
+				}),
+			),
+		)
+	}
+
+	expectNodes := func(nodes ...k8sv1.Node) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", nodesPath),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, &k8sv1.NodeList{Items: nodes}),
+			),
+		)
+	}
+
+	validate := func() *v1.VirtualMachineStartValidation {
+		app.ValidateStartVMRequestHandler(request, response)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		validation := &v1.VirtualMachineStartValidation{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), validation)).To(Succeed())
+		return validation
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		virtCli, err := kubecli.GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+		app = &SubresourceAPIApp{
+			virtCli:          virtCli,
+			clusterConfig:    config,
+			applyVMIDefaults: func(vmi *v1.VirtualMachineInstance) error { return nil },
+		}
+
+		vmi := v1.NewMinimalVMI("testvm")
+		vmi.Spec.Volumes = []v1.Volume{{
+			Name: "rootdisk",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rootdisk"},
+			},
+		}}
+		vm = newMinimalVM("testvm")
+		vm.Namespace = "default"
+		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{Spec: vmi.Spec}
+
+		request = restful.NewRequest(&http.Request{})
+		request.PathParameters()["name"] = "testvm"
+		request.PathParameters()["namespace"] = "default"
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should report a VM without blockers as startable", func() {
+		expectVM()
+		expectPVC(http.StatusOK)
+		expectNodes(newNode(launcherResources))
+
+		validation := validate()
+		Expect(validation.Startable).To(BeTrue())
+		Expect(validation.Blockers).To(BeEmpty())
+		Expect(server.ReceivedRequests()).To(HaveLen(4))
+	})
+
+	It("should fail if the VM does not exist", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", vmPath),
+				ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+			),
+		)
+
+		app.ValidateStartVMRequestHandler(request, response)
+		ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+	})
+
+	It("should report a running VMI", func() {
+		vmi := v1.NewMinimalVMIWithNS("default", "testvm")
+		vmi.Status.Phase = v1.Running
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", vmPath),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", vmiPath),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+			),
+		)
+		expectPVC(http.StatusOK)
+		expectNodes(newNode(launcherResources))
+
+		validation := validate()
+		Expect(validation.Startable).To(BeFalse())
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{Check: v1.StartCheckState, Message: "VM is already running"}))
+	})
+
+	It("should report a missing PersistentVolumeClaim", func() {
+		expectVM()
+		expectPVC(http.StatusNotFound)
+		expectNodes(newNode(launcherResources))
+
+		validation := validate()
+		Expect(validation.Startable).To(BeFalse())
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{
+			Check:   v1.StartCheckStorage,
+			Message: "PersistentVolumeClaim rootdisk of volume rootdisk does not exist",
+		}))
+	})
+
+	It("should report a missing network attachment definition", func() {
+		vm.Spec.Template.Spec.Networks = []v1.Network{{
+			Name:          "sriov",
+			NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "sriov"}},
+		}}
+		expectVM()
+		expectPVC(http.StatusOK)
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", nadPath),
+				ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+			),
+		)
+		expectNodes(newNode(launcherResources))
+
+		validation := validate()
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{
+			Check:   v1.StartCheckNetwork,
+			Message: "network attachment definition default/sriov of network sriov does not exist",
+		}))
+	})
+
+	It("should report if no node matches the node selector", func() {
+		vm.Spec.Template.Spec.NodeSelector = map[string]string{"zone": "east"}
+		expectVM()
+		expectPVC(http.StatusOK)
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", nodesPath, "labelSelector=kubevirt.io%2Fschedulable%3Dtrue%2Czone%3Deast"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, &k8sv1.NodeList{}),
+			),
+		)
+
+		validation := validate()
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{
+			Check:   v1.StartCheckNode,
+			Message: "no schedulable node matches the node selector kubevirt.io/schedulable=true,zone=east",
+		}))
+	})
+
+	It("should report device plugin resources no node provides", func() {
+		vm.Spec.Template.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu1", DeviceName: "vendor.com/gpu"}}
+		nicResources := launcherResources.DeepCopy()
+		nicResources["vendor.com/nic"] = resource.MustParse("4")
+		sriov := &networkv1.NetworkAttachmentDefinition{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:        "sriov",
+				Namespace:   "default",
+				Annotations: map[string]string{services.MULTUS_RESOURCE_NAME_ANNOTATION: "vendor.com/nic"},
+			},
+		}
+		vm.Spec.Template.Spec.Networks = []v1.Network{{
+			Name:          "sriov",
+			NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "sriov"}},
+		}}
+		expectVM()
+		expectPVC(http.StatusOK)
+		// the definition is looked up by the check and by the rendering of the pod
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", nadPath),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, sriov),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", nadPath),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, sriov),
+			),
+		)
+		expectNodes(newNode(nicResources))
+
+		validation := validate()
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{
+			Check:   v1.StartCheckDevices,
+			Message: "no node matching the node selector provides 1 of vendor.com/gpu",
+		}))
+	})
+
+	It("should report if the resources are spread over several nodes", func() {
+		vm.Spec.Template.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu1", DeviceName: "vendor.com/gpu"}}
+		gpuResources := k8sv1.ResourceList{"vendor.com/gpu": resource.MustParse("1")}
+		expectVM()
+		expectPVC(http.StatusOK)
+		expectNodes(newNode(launcherResources), newNode(gpuResources))
+
+		validation := validate()
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{
+			Check:   v1.StartCheckDevices,
+			Message: "no single node matching the node selector provides all of devices.kubevirt.io/kvm, devices.kubevirt.io/tun, vendor.com/gpu",
+		}))
+	})
+	It("should check the VMI with the defaults of the mutating webhook", func() {
+		app.applyVMIDefaults = func(vmi *v1.VirtualMachineInstance) error {
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "preset", DeviceName: "vendor.com/gpu"}}
+			return nil
+		}
+		expectVM()
+		expectPVC(http.StatusOK)
+		expectNodes(newNode(launcherResources))
+
+		validation := validate()
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{
+			Check:   v1.StartCheckDevices,
+			Message: "no node matching the node selector provides 1 of vendor.com/gpu",
+		}))
+	})
+
+	It("should report if the VMI would be rejected by the mutating webhook", func() {
+		app.applyVMIDefaults = func(vmi *v1.VirtualMachineInstance) error {
+			return fmt.Errorf("presets conflict")
+		}
+		expectVM()
+
+		validation := validate()
+		Expect(validation.Blockers).To(ConsistOf(v1.VirtualMachineStartBlocker{
+			Check:   v1.StartCheckState,
+			Message: "VMI can't be created: presets conflict",
+		}))
+	})
+})
+
+var _ = Describe("podDeviceResources", func() {
+	It("should sum up the device plugin resources of the containers", func() {
+		pod := &k8sv1.Pod{Spec: k8sv1.PodSpec{Containers: []k8sv1.Container{
+			{Resources: k8sv1.ResourceRequirements{Limits: k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+				services.KvmDevice:   resource.MustParse("1"),
+				"vendor.com/gpu":     resource.MustParse("1"),
+			}}},
+			{Resources: k8sv1.ResourceRequirements{Limits: k8sv1.ResourceList{
+				"vendor.com/gpu": resource.MustParse("1"),
+			}}},
+		}}}
+
+		resources := podDeviceResources(pod)
+		Expect(resources).To(HaveLen(2))
+		gpus := resources["vendor.com/gpu"]
+		Expect(gpus.Value()).To(Equal(int64(2)))
+	})
+})
@@ -0,0 +1,242 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package webhooks
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/jsonpath"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// ValidateVMIAdmissionPolicies checks that the admission policies of the
+// KubeVirt configuration can be evaluated
+func ValidateVMIAdmissionPolicies(field *k8sfield.Path, policies []v1.VMIAdmissionPolicy) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	invalid := func(field *k8sfield.Path, format string, args ...interface{}) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s "+format, append([]interface{}{field.String()}, args...)...),
+			Field:   field.String(),
+		})
+	}
+
+	names := map[string]bool{}
+	for i, policy := range policies {
+		policyField := field.Index(i)
+		if policy.Name == "" {
+			invalid(policyField.Child("name"), "must not be empty")
+		} else if names[policy.Name] {
+			invalid(policyField.Child("name"), "must be unique, %s is used more than once", policy.Name)
+		}
+		names[policy.Name] = true
+
+		if len(policy.Rules) == 0 {
+			invalid(policyField.Child("rules"), "must contain at least one rule")
+		}
+		for j, rule := range policy.Rules {
+			ruleField := policyField.Child("rules").Index(j)
+			if err := newRulePath(rule.Path).Parse(rule.Path); err != nil {
+				invalid(ruleField.Child("path"), "is no valid JSONPath expression: %v", err)
+			}
+
+			switch rule.Operator {
+			case v1.VMIAdmissionRuleOpIn, v1.VMIAdmissionRuleOpNotIn:
+				if len(rule.Values) == 0 {
+					invalid(ruleField.Child("values"), "must not be empty for operator %s", rule.Operator)
+				}
+			case v1.VMIAdmissionRuleOpExists, v1.VMIAdmissionRuleOpDoesNotExist:
+				if len(rule.Values) != 0 {
+					invalid(ruleField.Child("values"), "must be empty for operator %s", rule.Operator)
+				}
+			case v1.VMIAdmissionRuleOpGt, v1.VMIAdmissionRuleOpLt:
+				if len(rule.Values) != 1 {
+					invalid(ruleField.Child("values"), "must contain a single value for operator %s", rule.Operator)
+				} else if _, err := resource.ParseQuantity(rule.Values[0]); err != nil {
+					invalid(ruleField.Child("values").Index(0), "is no valid quantity: %v", err)
+				}
+			default:
+				invalid(ruleField.Child("operator"), "must be one of In, NotIn, Exists, DoesNotExist, Gt and Lt")
+			}
+		}
+	}
+	return causes
+}
+
+// ValidateVirtualMachineInstanceAdmissionPolicies rejects VMIs which match
+// all rules of one of the admission policies applying to their namespace.
+// Policies which can't be evaluated reject the VMI as well, so that a broken
+// policy does not let VMIs pass which it is meant to reject.
+func ValidateVirtualMachineInstanceAdmissionPolicies(vmi *v1.VirtualMachineInstance, namespace string, policies []v1.VMIAdmissionPolicy) []metav1.StatusCause {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to convert the VMI for the evaluation of admission policies")
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("the admission policies can't be evaluated: %v", err),
+		}}
+	}
+
+	var causes []metav1.StatusCause
+	for _, policy := range policies {
+		if !policyAppliesTo(policy, namespace) {
+			continue
+		}
+
+		matches, err := policyMatches(policy, obj)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Warningf("Failed to evaluate admission policy %s", policy.Name)
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("the VMI is rejected, admission policy %s can't be evaluated: %v", policy.Name, err),
+			})
+			continue
+		}
+		if matches {
+			message := fmt.Sprintf("the VMI is rejected by admission policy %s", policy.Name)
+			if policy.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, policy.Message)
+			}
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: message,
+			})
+		}
+	}
+	return causes
+}
+
+func policyAppliesTo(policy v1.VMIAdmissionPolicy, namespace string) bool {
+	if len(policy.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range policy.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func policyMatches(policy v1.VMIAdmissionPolicy, obj interface{}) (bool, error) {
+	if len(policy.Rules) == 0 {
+		return false, nil
+	}
+	for _, rule := range policy.Rules {
+		matches, err := ruleMatches(rule, obj)
+		if err != nil {
+			return false, fmt.Errorf("rule %s %s: %v", rule.Path, rule.Operator, err)
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func ruleMatches(rule v1.VMIAdmissionRule, obj interface{}) (bool, error) {
+	values, err := selectValues(rule.Path, obj)
+	if err != nil {
+		return false, err
+	}
+
+	switch rule.Operator {
+	case v1.VMIAdmissionRuleOpExists:
+		return len(values) > 0, nil
+	case v1.VMIAdmissionRuleOpDoesNotExist:
+		return len(values) == 0, nil
+	case v1.VMIAdmissionRuleOpIn, v1.VMIAdmissionRuleOpNotIn:
+		for _, value := range values {
+			if contains(rule.Values, fmt.Sprint(value)) == (rule.Operator == v1.VMIAdmissionRuleOpIn) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case v1.VMIAdmissionRuleOpGt, v1.VMIAdmissionRuleOpLt:
+		if len(rule.Values) != 1 {
+			return false, fmt.Errorf("operator %s needs a single value", rule.Operator)
+		}
+		limit, err := resource.ParseQuantity(rule.Values[0])
+		if err != nil {
+			return false, err
+		}
+		for _, value := range values {
+			quantity, err := resource.ParseQuantity(fmt.Sprint(value))
+			if err != nil {
+				return false, fmt.Errorf("selected value %v is no quantity", value)
+			}
+			if cmp := quantity.Cmp(limit); (cmp > 0 && rule.Operator == v1.VMIAdmissionRuleOpGt) || (cmp < 0 && rule.Operator == v1.VMIAdmissionRuleOpLt) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("unknown operator %s", rule.Operator)
+}
+
+// selectValues returns the values the JSONPath expression selects, unset
+// fields do not select anything
+func selectValues(path string, obj interface{}) ([]interface{}, error) {
+	parser := newRulePath(path)
+	if err := parser.Parse(path); err != nil {
+		return nil, err
+	}
+	results, err := parser.FindResults(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []interface{}
+	for _, result := range results {
+		for _, value := range result {
+			if value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					continue
+				}
+			}
+			values = append(values, value.Interface())
+		}
+	}
+	return values, nil
+}
+
+func newRulePath(name string) *jsonpath.JSONPath {
+	return jsonpath.New(name).AllowMissingKeys(true)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package webhooks
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// ValidateScopedFeatureGates checks that the scoped feature gates of the
+// KubeVirt configuration select namespaces
+func ValidateScopedFeatureGates(field *k8sfield.Path, featureGates []v1.ScopedFeatureGate) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	invalid := func(field *k8sfield.Path, format string, args ...interface{}) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s "+format, append([]interface{}{field.String()}, args...)...),
+			Field:   field.String(),
+		})
+	}
+
+	for i, featureGate := range featureGates {
+		featureGateField := field.Index(i)
+		if featureGate.Name == "" {
+			invalid(featureGateField.Child("name"), "must not be empty")
+		}
+		if len(featureGate.Namespaces) == 0 && featureGate.NamespaceSelector == nil {
+			invalid(featureGateField, "must select namespaces by name or by a namespace selector")
+		}
+		if featureGate.NamespaceSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(featureGate.NamespaceSelector); err != nil {
+				invalid(featureGateField.Child("namespaceSelector"), "is no valid label selector: %v", err)
+			}
+		}
+	}
+	return causes
+}
+
+// ClusterConfigForNamespace returns the view of the cluster config for the
+// workloads of a namespace, in which the scoped feature gates are only enabled
+// if they select the namespace
+func ClusterConfigForNamespace(clusterConfig *virtconfig.ClusterConfig, namespace string) *virtconfig.ClusterConfig {
+	if !clusterConfig.HasScopedFeatureGates() {
+		return clusterConfig
+	}
+
+	var labels map[string]string
+	if informer := GetInformers().NamespaceInformer; informer != nil {
+		obj, exists, err := informer.GetStore().GetByKey(namespace)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to look up the labels of namespace %s", namespace)
+		} else if exists {
+			labels = obj.(*k8sv1.Namespace).Labels
+		}
+	}
+	return clusterConfig.ForNamespace(namespace, labels)
+}
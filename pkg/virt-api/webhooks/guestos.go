@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+/*
+ * guest OS preferences are in the webhooks package because they are used both
+ * by validation and mutation webhooks.
+ */
+package webhooks
+
+import (
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// GuestOSPreference holds the devices, the clock and the inputs which suit a
+// guest OS. They are only applied to what is neither set on the vmi nor by a
+// preset.
+type GuestOSPreference struct {
+	DiskBus        string
+	InterfaceModel string
+	InputBus       string
+	Clock          *v1.Clock
+}
+
+var _false bool = false
+
+var windowsPreference = GuestOSPreference{
+	// Windows comes without virtio drivers
+	DiskBus:        "sata",
+	InterfaceModel: "e1000e",
+	InputBus:       "usb",
+	Clock: &v1.Clock{
+		ClockOffset: v1.ClockOffset{UTC: &v1.ClockOffsetUTC{}},
+		Timer: &v1.Timer{
+			HPET: &v1.HPETTimer{Enabled: &_false},
+			PIT:  &v1.PITTimer{TickPolicy: v1.PITTickPolicyDelay},
+			RTC:  &v1.RTCTimer{TickPolicy: v1.RTCTickPolicyCatchup},
+		},
+	},
+}
+
+var linuxPreference = GuestOSPreference{
+	DiskBus:        "virtio",
+	InterfaceModel: "virtio",
+	InputBus:       "virtio",
+	Clock: &v1.Clock{
+		ClockOffset: v1.ClockOffset{UTC: &v1.ClockOffsetUTC{}},
+		Timer:       &v1.Timer{},
+	},
+}
+
+var guestOSPreferences = map[v1.GuestOS]GuestOSPreference{
+	v1.GuestOSWindows10:   windowsPreference,
+	v1.GuestOSWindows2k12: windowsPreference,
+	v1.GuestOSWindows2k16: windowsPreference,
+	v1.GuestOSWindows2k19: windowsPreference,
+	v1.GuestOSRHEL7:       linuxPreference,
+	v1.GuestOSRHEL8:       linuxPreference,
+	v1.GuestOSRHEL9:       linuxPreference,
+	v1.GuestOSCentOS7:     linuxPreference,
+	v1.GuestOSCentOS8:     linuxPreference,
+	v1.GuestOSFedora:      linuxPreference,
+	v1.GuestOSUbuntu:      linuxPreference,
+}
+
+// GetGuestOSPreference returns the preference of a guest OS, and false if
+// the guest OS is unknown
+func GetGuestOSPreference(guestOS v1.GuestOS) (GuestOSPreference, bool) {
+	preference, ok := guestOSPreferences[guestOS]
+	return preference, ok
+}
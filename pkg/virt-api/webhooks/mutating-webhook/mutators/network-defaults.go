@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package mutators
+
+import (
+	v1 "kubevirt.io/client-go/api/v1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// interfaceDefault fills in a single field of an interface, if it was not
+// specified. The defaults are applied in the order of interfaceDefaults, a
+// default can rely on the ones applied before it.
+type interfaceDefault struct {
+	field string
+	apply func(iface *v1.Interface, network *v1.Network, config *virtconfig.ClusterConfig)
+}
+
+var interfaceDefaults = []interfaceDefault{
+	{field: "binding", apply: setDefaultInterfaceBinding},
+	{field: "pod.vmNetworkCIDR", apply: setDefaultVMNetworkCIDRs},
+	{field: "model", apply: setDefaultInterfaceModel},
+	{field: "ports.protocol", apply: setDefaultPortProtocol},
+}
+
+// setDefaultInterfaceBinding binds interfaces on the pod network with the
+// default interface of the cluster and interfaces on multus networks with a
+// bridge.
+func setDefaultInterfaceBinding(iface *v1.Interface, network *v1.Network, config *virtconfig.ClusterConfig) {
+	if iface.InterfaceBindingMethod != (v1.InterfaceBindingMethod{}) {
+		return
+	}
+	if network.Pod == nil {
+		iface.Bridge = &v1.InterfaceBridge{}
+		return
+	}
+	switch v1.NetworkInterfaceType(config.GetDefaultNetworkInterface()) {
+	case v1.MasqueradeInterface:
+		iface.Masquerade = &v1.InterfaceMasquerade{}
+	case v1.SlirpInterface:
+		iface.Slirp = &v1.InterfaceSlirp{}
+	default:
+		iface.Bridge = &v1.InterfaceBridge{}
+	}
+}
+
+// setDefaultVMNetworkCIDRs sets the CIDRs of the cluster on the pod network of
+// masquerade interfaces. The IPv4 CIDR is left to virt-controller when a
+// masquerade subnet pool is configured.
+func setDefaultVMNetworkCIDRs(iface *v1.Interface, network *v1.Network, config *virtconfig.ClusterConfig) {
+	if iface.Masquerade == nil || network.Pod == nil {
+		return
+	}
+	if network.Pod.VMNetworkCIDR == "" && config.GetMasqueradeSubnetPool() == nil {
+		network.Pod.VMNetworkCIDR = config.GetDefaultVMNetworkCIDR()
+	}
+	if network.Pod.VMIPv6NetworkCIDR == "" {
+		network.Pod.VMIPv6NetworkCIDR = config.GetDefaultVMIPv6NetworkCIDR()
+	}
+}
+
+// setDefaultInterfaceModel picks virtio, except for slirp which qemu only
+// supports with e1000.
+func setDefaultInterfaceModel(iface *v1.Interface, _ *v1.Network, _ *virtconfig.ClusterConfig) {
+	if iface.Model != "" || iface.SRIOV != nil {
+		return
+	}
+	if iface.Slirp != nil {
+		iface.Model = "e1000"
+		return
+	}
+	iface.Model = "virtio"
+}
+
+func setDefaultPortProtocol(iface *v1.Interface, _ *v1.Network, _ *virtconfig.ClusterConfig) {
+	for i := range iface.Ports {
+		if iface.Ports[i].Protocol == "" {
+			iface.Ports[i].Protocol = "TCP"
+		}
+	}
+}
+
+// setDefaultInterfaceFields applies interfaceDefaults to every interface of
+// the VMI. Interfaces without a matching network are left as they are, the
+// validating webhook rejects them.
+func (mutator *VMIsMutator) setDefaultInterfaceFields(vmi *v1.VirtualMachineInstance) {
+	networks := map[string]*v1.Network{}
+	for i := range vmi.Spec.Networks {
+		networks[vmi.Spec.Networks[i].Name] = &vmi.Spec.Networks[i]
+	}
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		iface := &vmi.Spec.Domain.Devices.Interfaces[i]
+		network, exists := networks[iface.Name]
+		if !exists {
+			continue
+		}
+		for _, d := range interfaceDefaults {
+			d.apply(iface, network, mutator.ClusterConfig)
+		}
+	}
+}
@@ -74,34 +74,10 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 			}
 		}
 
-		// Apply namespace limits
-		applyNamespaceLimitRangeValues(newVMI, informers.NamespaceLimitsInformer)
-
-		// Set VMI defaults
-		log.Log.Object(newVMI).V(4).Info("Apply defaults")
-		mutator.setDefaultCPUModel(newVMI)
-		mutator.setDefaultMachineType(newVMI)
-		mutator.setDefaultResourceRequests(newVMI)
-		mutator.setDefaultGuestCPUTopology(newVMI)
-		mutator.setDefaultPullPoliciesOnContainerDisks(newVMI)
-		err = mutator.setDefaultNetworkInterface(newVMI)
+		err = mutator.setDefaults(newVMI, informers)
 		if err != nil {
 			return webhookutils.ToAdmissionResponseError(err)
 		}
-		v1.SetObjectDefaults_VirtualMachineInstance(newVMI)
-
-		// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
-		// Until that time, we need to handle the hyperv deps to avoid obscure rejections from QEMU later on
-		log.Log.V(4).Info("Set HyperV dependencies")
-		err = webhooks.SetVirtualMachineInstanceHypervFeatureDependencies(newVMI)
-		if err != nil {
-			// HyperV is a special case. If our best-effort attempt fails, we should leave
-			// rejection to be performed later on in the validating webhook, and continue here.
-			// Please note this means that partial changes may have been performed.
-			// This is OK since each dependency must be atomic and independent (in ACID sense),
-			// so the VMI configuration is still legal.
-			log.Log.V(2).Infof("Failed to set HyperV dependencies: %s", err)
-		}
 
 		// Add foreground finalizer
 		newVMI.Finalizers = append(newVMI.Finalizers, v1.VirtualMachineInstanceFinalizer)
@@ -150,6 +126,50 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 	}
 }
 
+// ApplyDefaults applies the presets, the namespace limits and the defaults to
+// a VMI, like it happens when the VMI is created
+func (mutator *VMIsMutator) ApplyDefaults(vmi *v1.VirtualMachineInstance, informers *webhooks.Informers) error {
+	if err := applyPresets(vmi, informers.VMIPresetInformer); err != nil {
+		return err
+	}
+	return mutator.setDefaults(vmi, informers)
+}
+
+func (mutator *VMIsMutator) setDefaults(newVMI *v1.VirtualMachineInstance, informers *webhooks.Informers) error {
+	// Apply namespace limits
+	applyNamespaceLimitRangeValues(newVMI, informers.NamespaceLimitsInformer)
+
+	// Set VMI defaults
+	log.Log.Object(newVMI).V(4).Info("Apply defaults")
+	mutator.setDefaultCPUModel(newVMI)
+	mutator.setCPUBaseline(newVMI)
+	mutator.setDefaultMachineType(newVMI)
+	mutator.setDefaultResourceRequests(newVMI)
+	mutator.setDefaultGuestCPUTopology(newVMI)
+	mutator.setVirtioWinDriverDisk(newVMI)
+	mutator.setDefaultPullPoliciesOnContainerDisks(newVMI)
+	if err := mutator.setDefaultNetworkInterface(newVMI); err != nil {
+		return err
+	}
+	setGuestOSPreferences(newVMI)
+	setDefaultInputDevice(newVMI)
+	mutator.setDefaultInterfaceFields(newVMI)
+	v1.SetObjectDefaults_VirtualMachineInstance(newVMI)
+
+	// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
+	// Until that time, we need to handle the hyperv deps to avoid obscure rejections from QEMU later on
+	log.Log.V(4).Info("Set HyperV dependencies")
+	if err := webhooks.SetVirtualMachineInstanceHypervFeatureDependencies(newVMI); err != nil {
+		// HyperV is a special case. If our best-effort attempt fails, we should leave
+		// rejection to be performed later on in the validating webhook, and continue here.
+		// Please note this means that partial changes may have been performed.
+		// This is OK since each dependency must be atomic and independent (in ACID sense),
+		// so the VMI configuration is still legal.
+		log.Log.V(2).Infof("Failed to set HyperV dependencies: %s", err)
+	}
+	return nil
+}
+
 func (mutator *VMIsMutator) setDefaultNetworkInterface(obj *v1.VirtualMachineInstance) error {
 	autoAttach := obj.Spec.Domain.Devices.AutoattachPodInterface
 	if autoAttach != nil && *autoAttach == false {
@@ -194,6 +214,44 @@ func (mutator *VMIsMutator) setDefaultCPUModel(vmi *v1.VirtualMachineInstance) {
 	}
 }
 
+// setCPUBaseline replaces a CPU baseline referenced as CPU model by the model virt-controller computed
+// for the node pool of the baseline, requires its features and schedules the vmi on the pool. Features
+// of the vmi take precedence over the features of the baseline.
+func (mutator *VMIsMutator) setCPUBaseline(vmi *v1.VirtualMachineInstance) {
+	if vmi.Spec.Domain.CPU == nil {
+		return
+	}
+	baseline := mutator.ClusterConfig.GetCPUBaseline(vmi.Spec.Domain.CPU.Model)
+	if baseline == nil {
+		return
+	}
+	status := mutator.ClusterConfig.GetCPUBaselineStatus(baseline.Name)
+	if status == nil || status.Model == "" {
+		// the validating webhook rejects the vmi
+		return
+	}
+
+	vmi.Spec.Domain.CPU.Model = status.Model
+	features := map[string]bool{}
+	for _, feature := range vmi.Spec.Domain.CPU.Features {
+		features[feature.Name] = true
+	}
+	for _, feature := range status.Features {
+		if !features[feature] {
+			vmi.Spec.Domain.CPU.Features = append(vmi.Spec.Domain.CPU.Features, v1.CPUFeature{Name: feature, Policy: "require"})
+		}
+	}
+
+	if vmi.Spec.NodeSelector == nil {
+		vmi.Spec.NodeSelector = map[string]string{}
+	}
+	for key, value := range baseline.NodeSelector {
+		if _, exists := vmi.Spec.NodeSelector[key]; !exists {
+			vmi.Spec.NodeSelector[key] = value
+		}
+	}
+}
+
 func (mutator *VMIsMutator) setDefaultGuestCPUTopology(vmi *v1.VirtualMachineInstance) {
 	cores := uint32(1)
 	threads := uint32(1)
@@ -225,6 +283,99 @@ func (mutator *VMIsMutator) setDefaultMachineType(vmi *v1.VirtualMachineInstance
 	}
 }
 
+// setVirtioWinDriverDisk attaches the container disk with the virtio-win
+// drivers as a CD-ROM if the VMI asks for it. Without an image in the config,
+// the VMI is left alone and gets rejected by the validating webhook.
+func (mutator *VMIsMutator) setVirtioWinDriverDisk(vmi *v1.VirtualMachineInstance) {
+	autoattach := vmi.Spec.Domain.Devices.AutoattachVirtioWinDriverDisk
+	image := mutator.ClusterConfig.GetVirtioWinImage()
+	if autoattach == nil || !*autoattach || image == "" {
+		return
+	}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == v1.VirtioWinDriverDiskName {
+			return
+		}
+	}
+
+	vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+		Name: v1.VirtioWinDriverDiskName,
+		DiskDevice: v1.DiskDevice{
+			// Windows installers come with drivers for SATA, not for virtio
+			CDRom: &v1.CDRomTarget{Bus: "sata"},
+		},
+	})
+	vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+		Name: v1.VirtioWinDriverDiskName,
+		VolumeSource: v1.VolumeSource{
+			ContainerDisk: &v1.ContainerDiskSource{Image: image},
+		},
+	})
+}
+
+// setGuestOSPreferences defaults the disk bus, the interface model and the
+// clock to what suits the guest OS. It runs after the presets were
+// applied, and before the cluster wide defaults, so that the vmi and its
+// presets override the preference, which itself overrides the cluster.
+func setGuestOSPreferences(vmi *v1.VirtualMachineInstance) {
+	if vmi.Spec.GuestOS == "" {
+		return
+	}
+	preference, ok := webhooks.GetGuestOSPreference(vmi.Spec.GuestOS)
+	if !ok {
+		// rejected by the validating webhook
+		return
+	}
+
+	devices := &vmi.Spec.Domain.Devices
+	for i := range devices.Disks {
+		disk := &devices.Disks[i].DiskDevice
+		// CD-ROMs and LUNs keep the default bus, guests boot from them
+		// without drivers
+		if disk.Disk == nil && disk.CDRom == nil && disk.Floppy == nil && disk.LUN == nil {
+			disk.Disk = &v1.DiskTarget{}
+		}
+		if disk.Disk != nil && disk.Disk.Bus == "" {
+			disk.Disk.Bus = preference.DiskBus
+		}
+	}
+
+	for i := range devices.Interfaces {
+		iface := &devices.Interfaces[i]
+		if iface.Model == "" && iface.SRIOV == nil {
+			iface.Model = preference.InterfaceModel
+		}
+	}
+
+	if vmi.Spec.Domain.Clock == nil {
+		vmi.Spec.Domain.Clock = preference.Clock.DeepCopy()
+	}
+}
+
+// setDefaultInputDevice attaches a tablet to vmis with a graphics device and
+// no inputs. Without it, VNC clients send relative mouse movements, and their
+// cursor drifts away from the one of the guest.
+func setDefaultInputDevice(vmi *v1.VirtualMachineInstance) {
+	devices := &vmi.Spec.Domain.Devices
+	autoattachGraphics := devices.AutoattachGraphicsDevice
+	autoattachInput := devices.AutoattachInputDevice
+	if len(devices.Inputs) > 0 ||
+		(autoattachGraphics != nil && !*autoattachGraphics) ||
+		(autoattachInput != nil && !*autoattachInput) {
+		return
+	}
+
+	bus := "usb"
+	if preference, ok := webhooks.GetGuestOSPreference(vmi.Spec.GuestOS); ok {
+		bus = preference.InputBus
+	}
+	devices.Inputs = []v1.Input{{
+		Name: "tablet",
+		Type: "tablet",
+		Bus:  bus,
+	}}
+}
+
 func (mutator *VMIsMutator) setDefaultPullPoliciesOnContainerDisks(vmi *v1.VirtualMachineInstance) {
 	for _, volume := range vmi.Spec.Volumes {
 		if volume.ContainerDisk != nil && volume.ContainerDisk.ImagePullPolicy == "" {
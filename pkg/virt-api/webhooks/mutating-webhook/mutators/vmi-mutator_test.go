@@ -34,11 +34,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook/admitters"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/rbac"
 )
@@ -238,6 +240,216 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(vmiSpec.Domain.Resources.Requests.Cpu().String()).To(Equal(cpuRequestFromConfig))
 	})
 
+	Context("with CPU baselines", func() {
+		setCPUBaselines := func(statuses ...v1.CPUBaselineStatus) {
+			mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						CPUBaselines: []v1.CPUBaseline{{
+							Name:         "rack1",
+							NodeSelector: map[string]string{"pool": "rack1"},
+						}},
+					},
+				},
+				Status: v1.KubeVirtStatus{
+					Phase:        v1.KubeVirtPhaseDeployed,
+					CPUBaselines: statuses,
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			vmi.Spec.Domain.CPU = &v1.CPU{Model: "rack1"}
+		})
+
+		It("should replace the baseline by its CPU model, features and node pool", func() {
+			setCPUBaselines(v1.CPUBaselineStatus{
+				Name:     "rack1",
+				Model:    "Haswell-noTSX",
+				Features: []string{"aes", "avx2"},
+				Nodes:    2,
+			})
+			vmi.Spec.Domain.CPU.Features = []v1.CPUFeature{{Name: "avx2", Policy: "disable"}}
+			vmi.Spec.NodeSelector = map[string]string{"disktype": "ssd"}
+
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.CPU.Model).To(Equal("Haswell-noTSX"))
+			Expect(vmiSpec.Domain.CPU.Features).To(ConsistOf(
+				v1.CPUFeature{Name: "avx2", Policy: "disable"},
+				v1.CPUFeature{Name: "aes", Policy: "require"},
+			))
+			Expect(vmiSpec.NodeSelector).To(Equal(map[string]string{"disktype": "ssd", "pool": "rack1"}))
+		})
+
+		It("should leave a baseline without computed CPU model to the validation", func() {
+			setCPUBaselines(v1.CPUBaselineStatus{
+				Name:    "rack1",
+				Message: "no node matches the node selector of the baseline",
+			})
+
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.CPU.Model).To(Equal("rack1"))
+			Expect(vmiSpec.NodeSelector).To(BeEmpty())
+		})
+
+		It("should leave other CPU models alone", func() {
+			setCPUBaselines(v1.CPUBaselineStatus{Name: "rack1", Model: "IvyBridge", Nodes: 1})
+			vmi.Spec.Domain.CPU.Model = "Westmere"
+
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.CPU.Model).To(Equal("Westmere"))
+			Expect(vmiSpec.NodeSelector).To(BeEmpty())
+		})
+	})
+
+	Context("with the virtio-win driver disk", func() {
+		image := "registry:5000/kubevirt/virtio-container-disk:devel"
+
+		setVirtioWinImage := func(image string) {
+			mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{VirtioWinImage: image},
+				},
+				Status: v1.KubeVirtStatus{
+					Phase: v1.KubeVirtPhaseDeployed,
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			vmi.Spec.Domain.Devices.AutoattachVirtioWinDriverDisk = &_true
+		})
+
+		It("should attach the drivers as a SATA CD-ROM", func() {
+			setVirtioWinImage(image)
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Disks).To(HaveLen(1))
+			Expect(vmiSpec.Domain.Devices.Disks[0].Name).To(Equal(v1.VirtioWinDriverDiskName))
+			Expect(vmiSpec.Domain.Devices.Disks[0].CDRom).ToNot(BeNil())
+			Expect(vmiSpec.Domain.Devices.Disks[0].CDRom.Bus).To(Equal("sata"))
+			Expect(vmiSpec.Volumes).To(ConsistOf(v1.Volume{
+				Name: v1.VirtioWinDriverDiskName,
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: image, ImagePullPolicy: k8sv1.PullIfNotPresent},
+				},
+			}))
+		})
+
+		It("should not attach the drivers twice", func() {
+			setVirtioWinImage(image)
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: v1.VirtioWinDriverDiskName,
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "custom/virtio-win:latest"},
+				},
+			}}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Volumes).To(HaveLen(1))
+			Expect(vmiSpec.Volumes[0].ContainerDisk.Image).To(Equal("custom/virtio-win:latest"))
+		})
+
+		It("should leave the VMI alone without a configured image", func() {
+			setVirtioWinImage("")
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Volumes).To(BeEmpty())
+		})
+
+		It("should not attach the drivers if the VMI doesn't ask for them", func() {
+			setVirtioWinImage(image)
+			vmi.Spec.Domain.Devices.AutoattachVirtioWinDriverDisk = &_false
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Volumes).To(BeEmpty())
+		})
+	})
+
+	Context("with a guest OS", func() {
+		BeforeEach(func() {
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "rootdisk"},
+				{Name: "cloudinit", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}},
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}},
+			}
+		})
+
+		It("should default the devices, the clock and the inputs of Windows", func() {
+			vmi.Spec.GuestOS = v1.GuestOSWindows2k19
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("sata"))
+			Expect(vmiSpec.Domain.Devices.Interfaces).To(HaveLen(1))
+			Expect(vmiSpec.Domain.Devices.Interfaces[0].Model).To(Equal("e1000e"))
+			Expect(vmiSpec.Domain.Devices.Inputs).To(ConsistOf(v1.Input{Name: "tablet", Type: "tablet", Bus: "usb"}))
+			Expect(vmiSpec.Domain.Clock).ToNot(BeNil())
+			Expect(vmiSpec.Domain.Clock.UTC).ToNot(BeNil())
+			Expect(vmiSpec.Domain.Clock.Timer.HPET.Enabled).To(Equal(&_false))
+			Expect(vmiSpec.Domain.Clock.Timer.RTC.TickPolicy).To(Equal(v1.RTCTickPolicyCatchup))
+		})
+
+		It("should default the devices and the inputs of Linux to virtio", func() {
+			vmi.Spec.GuestOS = v1.GuestOSRHEL9
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("virtio"))
+			Expect(vmiSpec.Domain.Devices.Interfaces[0].Model).To(Equal("virtio"))
+			Expect(vmiSpec.Domain.Devices.Inputs).To(ConsistOf(v1.Input{Name: "tablet", Type: "tablet", Bus: "virtio"}))
+			Expect(vmiSpec.Domain.Clock.Timer).To(Equal(&v1.Timer{}))
+		})
+
+		It("should keep what is set on the VMI", func() {
+			vmi.Spec.GuestOS = v1.GuestOSRHEL9
+			timezone := v1.ClockOffsetTimezone("Europe/Berlin")
+			vmi.Spec.Domain.Clock = &v1.Clock{ClockOffset: v1.ClockOffset{Timezone: &timezone}, Timer: &v1.Timer{}}
+			vmi.Spec.Domain.Devices.Inputs = []v1.Input{{Name: "keyboard", Type: "tablet", Bus: "usb"}}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Disks[1].Disk.Bus).To(Equal("scsi"))
+			Expect(vmiSpec.Domain.Devices.Disks[2].CDRom.Bus).To(Equal("sata"))
+			Expect(vmiSpec.Domain.Clock.UTC).To(BeNil())
+			Expect(vmiSpec.Domain.Devices.Inputs).To(ConsistOf(v1.Input{Name: "keyboard", Type: "tablet", Bus: "usb"}))
+		})
+
+		It("should keep what is set by a preset", func() {
+			preset.Spec.Domain.Clock = &v1.Clock{ClockOffset: v1.ClockOffset{UTC: &v1.ClockOffsetUTC{}}, Timer: &v1.Timer{}}
+			vmi.Spec.GuestOS = v1.GuestOSWindows10
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Clock.Timer.HPET).To(BeNil())
+			Expect(vmiSpec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("sata"))
+		})
+
+		It("should not add inputs without graphics", func() {
+			vmi.Spec.GuestOS = v1.GuestOSFedora
+			vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = &_false
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Inputs).To(BeEmpty())
+		})
+
+		It("should default the disk bus to sata without a guest OS", func() {
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("sata"))
+		})
+	})
+
+	table.DescribeTable("should attach a tablet", func(autoattachGraphics, autoattachInput *bool, inputs []v1.Input, expected []v1.Input) {
+		vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = autoattachGraphics
+		vmi.Spec.Domain.Devices.AutoattachInputDevice = autoattachInput
+		vmi.Spec.Domain.Devices.Inputs = inputs
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Inputs).To(Equal(expected))
+	},
+		table.Entry("by default", nil, nil, nil, []v1.Input{{Name: "tablet", Type: "tablet", Bus: "usb"}}),
+		table.Entry("when asked for", nil, &_true, nil, []v1.Input{{Name: "tablet", Type: "tablet", Bus: "usb"}}),
+		table.Entry("but not when asked not to", nil, &_false, nil, nil),
+		table.Entry("but not without graphics", &_false, nil, nil, nil),
+		table.Entry("but not next to other inputs", nil, nil,
+			[]v1.Input{{Name: "keyboard", Type: "keyboard", Bus: "virtio"}},
+			[]v1.Input{{Name: "keyboard", Type: "keyboard", Bus: "virtio"}}),
+	)
+
 	table.DescribeTable("it should", func(given []v1.Volume, expected []v1.Volume) {
 		vmi.Spec.Volumes = given
 		vmiSpec, _ := getVMISpecMetaFromResponse()
@@ -459,6 +671,101 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		table.Entry("networks is non-empty", []v1.Interface{}, []v1.Network{{Name: "b"}}),
 	)
 
+	Context("interface defaults", func() {
+		podNetwork := v1.Network{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}}
+		multusNetwork := v1.Network{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue"}}}
+
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.NetworkInterfaceKey:  "masquerade",
+					virtconfig.PermitSlirpInterface: "true",
+				},
+			})
+		})
+
+		table.DescribeTable("should default", func(iface v1.Interface, network v1.Network, expected v1.Interface) {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+			vmi.Spec.Networks = []v1.Network{network}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Interfaces).To(Equal([]v1.Interface{expected}))
+		},
+			table.Entry("the binding on the pod network to the cluster default",
+				v1.Interface{Name: "default"}, podNetwork,
+				v1.Interface{Name: "default", Model: "virtio", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}}),
+			table.Entry("the binding on a multus network to bridge",
+				v1.Interface{Name: "blue"}, multusNetwork,
+				v1.Interface{Name: "blue", Model: "virtio", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}),
+			table.Entry("the model of slirp interfaces to e1000",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}}, podNetwork,
+				v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}}),
+			table.Entry("no model on SR-IOV interfaces",
+				v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}}, multusNetwork,
+				v1.Interface{Name: "blue", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}}),
+			table.Entry("the protocol of ports to TCP",
+				v1.Interface{Name: "default", Ports: []v1.Port{{Name: "http", Port: 80}, {Name: "dns", Port: 53, Protocol: "UDP"}}}, podNetwork,
+				v1.Interface{Name: "default", Model: "virtio", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+					Ports: []v1.Port{{Name: "http", Port: 80, Protocol: "TCP"}, {Name: "dns", Port: 53, Protocol: "UDP"}}}),
+		)
+
+		It("should default the vm network CIDRs of the pod network of masquerade interfaces", func() {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default"}}
+			vmi.Spec.Networks = []v1.Network{podNetwork}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Networks[0].Pod.VMNetworkCIDR).To(Equal(virtconfig.DefaultVMNetworkCIDR))
+			Expect(vmiSpec.Networks[0].Pod.VMIPv6NetworkCIDR).To(Equal(virtconfig.DefaultVMIPv6NetworkCIDR))
+		})
+
+		It("should not default the vm network CIDRs of other interfaces or override specified ones", func() {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+				{Name: "other"},
+			}
+			vmi.Spec.Networks = []v1.Network{
+				podNetwork,
+				{Name: "other", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{VMNetworkCIDR: "10.1.0.0/24", VMIPv6NetworkCIDR: "fd20::/120"}}},
+			}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(*vmiSpec.Networks[0].Pod).To(Equal(v1.PodNetwork{}))
+			Expect(*vmiSpec.Networks[1].Pod).To(Equal(v1.PodNetwork{VMNetworkCIDR: "10.1.0.0/24", VMIPv6NetworkCIDR: "fd20::/120"}))
+		})
+
+		It("should not override specified fields", func() {
+			iface := v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				Ports: []v1.Port{{Name: "dns", Port: 53, Protocol: "UDP"}}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+			vmi.Spec.Networks = []v1.Network{podNetwork}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Interfaces).To(Equal([]v1.Interface{iface}))
+		})
+
+		It("should be idempotent", func() {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Ports: []v1.Port{{Port: 80}}}, {Name: "blue"}}
+			vmi.Spec.Networks = []v1.Network{podNetwork, multusNetwork}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+
+			vmi.Spec = *vmiSpec
+			defaultedSpec, _ := getVMISpecMetaFromResponse()
+			Expect(defaultedSpec.Domain.Devices.Interfaces).To(Equal(vmiSpec.Domain.Devices.Interfaces))
+		})
+
+		It("should produce a spec accepted by the validating webhook", func() {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", Ports: []v1.Port{{Name: "http", Port: 80}}}, {Name: "blue"}}
+			vmi.Spec.Networks = []v1.Network{podNetwork, multusNetwork}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+
+			causes := admitters.ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), vmiSpec, mutator.ClusterConfig)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should leave interfaces without a network untouched", func() {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default"}, {Name: "missing", Ports: []v1.Port{{Port: 80}}}}
+			vmi.Spec.Networks = []v1.Network{podNetwork}
+			vmiSpec, _ := getVMISpecMetaFromResponse()
+			Expect(vmiSpec.Domain.Devices.Interfaces[1]).To(Equal(v1.Interface{Name: "missing", Ports: []v1.Port{{Port: 80}}}))
+		})
+	})
+
 	It("should not override specified properties with defaults on VMI create", func() {
 		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
 			Data: map[string]string{
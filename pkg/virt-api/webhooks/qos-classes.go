@@ -0,0 +1,69 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package webhooks
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	minQoSBlkioWeight = 10
+	maxQoSBlkioWeight = 1000
+)
+
+// ValidateQoSClasses checks that the QoS classes of the KubeVirt
+// configuration can be applied to the VMIs on the nodes
+func ValidateQoSClasses(field *k8sfield.Path, classes []v1.QoSClassConfiguration) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	invalid := func(field *k8sfield.Path, format string, args ...interface{}) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s "+format, append([]interface{}{field.String()}, args...)...),
+			Field:   field.String(),
+		})
+	}
+
+	names := map[v1.QoSClass]bool{}
+	for i, class := range classes {
+		classField := field.Index(i)
+		if class.Name == "" {
+			invalid(classField.Child("name"), "must not be empty")
+		} else if names[class.Name] {
+			invalid(classField.Child("name"), "must be unique, %s is used more than once", class.Name)
+		}
+		names[class.Name] = true
+
+		if class.BlkioWeight < minQoSBlkioWeight || class.BlkioWeight > maxQoSBlkioWeight {
+			invalid(classField.Child("blkioWeight"), "must be between %d and %d", minQoSBlkioWeight, maxQoSBlkioWeight)
+		}
+		if class.NetworkRate.Sign() <= 0 {
+			invalid(classField.Child("networkRate"), "must be positive")
+		}
+		if class.NetworkCeil.Cmp(class.NetworkRate) < 0 {
+			invalid(classField.Child("networkCeil"), "must not be less than the networkRate")
+		}
+	}
+	return causes
+}
@@ -84,6 +84,7 @@ type Informers struct {
 	NamespaceLimitsInformer cache.SharedIndexInformer
 	VMIInformer             cache.SharedIndexInformer
 	VMRestoreInformer       cache.SharedIndexInformer
+	NamespaceInformer       cache.SharedIndexInformer
 }
 
 // XXX fix this, this is a huge mess. Move informers to Admitter and Mutator structs.
@@ -120,6 +121,7 @@ func newInformers() *Informers {
 		VMIPresetInformer:       kubeInformerFactory.VirtualMachinePreset(),
 		NamespaceLimitsInformer: kubeInformerFactory.LimitRanges(),
 		VMRestoreInformer:       kubeInformerFactory.VirtualMachineRestore(),
+		NamespaceInformer:       kubeInformerFactory.Namespace(),
 	}
 }
 
@@ -48,7 +48,7 @@ func (admitter *MigrationCreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1b
 		return resp
 	}
 
-	if !admitter.ClusterConfig.LiveMigrationEnabled() {
+	if !webhooks.ClusterConfigForNamespace(admitter.ClusterConfig, ar.Request.Namespace).LiveMigrationEnabled() {
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("LiveMigration feature gate is not enabled in kubevirt-config"))
 	}
 
@@ -23,20 +23,26 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net"
+	"path/filepath"
 	"regexp"
 	"strings"
 
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"k8s.io/api/admission/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/util/net/nad"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -57,9 +63,16 @@ const (
 	maxDNSNameservers     = 3
 	maxDNSSearchPaths     = 6
 	maxDNSSearchListChars = 256
+
+	// libvirt and QEMU accept boot menu timeouts of up to 65535 milliseconds
+	maxBootMenuTimeout = 65535
+
+	// the virtio-serial port of the guest agent channel, which is always added
+	guestAgentChannelName = "org.qemu.guest_agent.0"
 )
 
 var validInterfaceModels = map[string]*struct{}{"e1000": nil, "e1000e": nil, "ne2k_pci": nil, "pcnet": nil, "rtl8139": nil, "virtio": nil}
+var validInputModels = map[string]*struct{}{"virtio": nil, "virtio-transitional": nil, "virtio-non-transitional": nil}
 var validIOThreadsPolicies = []v1.IOThreadsPolicy{v1.IOThreadsPolicyShared, v1.IOThreadsPolicyAuto}
 var validCPUFeaturePolicies = map[string]*struct{}{"": nil, "force": nil, "require": nil, "optional": nil, "disable": nil, "forbid": nil}
 
@@ -73,7 +86,27 @@ var restriectedVmiLabels = map[string]bool{
 }
 
 type VMICreateAdmitter struct {
-	ClusterConfig *virtconfig.ClusterConfig
+	ClusterConfig   *virtconfig.ClusterConfig
+	networkAuthFunc nad.AuthFunc
+	networkGetFunc  nad.GetFunc
+}
+
+func NewVMICreateAdmitter(clusterConfig *virtconfig.ClusterConfig, client kubecli.KubevirtClient) *VMICreateAdmitter {
+	proxy := &sarProxy{client: client}
+
+	return &VMICreateAdmitter{
+		ClusterConfig: clusterConfig,
+		networkAuthFunc: func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+			return nad.CanServiceAccountUseNetwork(proxy, nadNamespace, nadName, saNamespace, saName)
+		},
+		networkGetFunc: func(namespace, name string) (*networkv1.NetworkAttachmentDefinition, error) {
+			definition, err := client.NetworkClient().K8sCniCncfIoV1().NetworkAttachmentDefinitions(namespace).Get(name, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return definition, err
+		},
+	}
 }
 
 func (admitter *VMICreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
@@ -87,21 +120,119 @@ func (admitter *VMICreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
-	causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ClusterConfig)
+	namespace := vmi.Namespace
+	if namespace == "" {
+		namespace = ar.Request.Namespace
+	}
+	config := webhooks.ClusterConfigForNamespace(admitter.ClusterConfig, namespace)
+
+	causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, config)
 	causes = append(causes, ValidateVirtualMachineInstanceMandatoryFields(k8sfield.NewPath("spec"), &vmi.Spec)...)
-	causes = append(causes, ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, admitter.ClusterConfig, accountName)...)
+	causes = append(causes, ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, config, accountName)...)
 	// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
 	causes = append(causes, webhooks.ValidateVirtualMachineInstanceHypervFeatureDependencies(k8sfield.NewPath("spec"), &vmi.Spec)...)
+	causes = append(causes, webhooks.ValidateVirtualMachineInstanceAdmissionPolicies(vmi, namespace, config.GetVMIAdmissionPolicies())...)
+	causes = append(causes, validateCPUBaselineExpanded(k8sfield.NewPath("spec", "domain", "cpu", "model"), &vmi.Spec, config)...)
 
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
+	causes, err = authorizeNetworks(k8sfield.NewPath("spec"), namespace, &vmi.Spec, admitter.networkAuthFunc)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	} else if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	causes, err = resolveNetworks(k8sfield.NewPath("spec"), namespace, &vmi.Spec, admitter.networkGetFunc)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	} else if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
 
 	reviewResponse := v1beta1.AdmissionResponse{}
 	reviewResponse.Allowed = true
 	return &reviewResponse
 }
 
+// validateCPUBaselineExpanded rejects VMIs still referencing a CPU baseline, the mutating webhook only
+// replaces baselines virt-controller computed a CPU model for. The templates of VMs may reference them.
+func validateCPUBaselineExpanded(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	if spec.Domain.CPU == nil || config.GetCPUBaseline(spec.Domain.CPU.Model) == nil {
+		return nil
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("%s references the CPU baseline %s, whose CPU model is not computed yet", field.String(), spec.Domain.CPU.Model),
+		Field:   field.String(),
+	}}
+}
+
+// authorizeNetworks makes sure that the ServiceAccount of the VMI was granted
+// the use of the network attachment definitions it references in other namespaces
+func authorizeNetworks(field *k8sfield.Path, namespace string, spec *v1.VirtualMachineInstanceSpec, authFunc nad.AuthFunc) ([]metav1.StatusCause, error) {
+	var causes []metav1.StatusCause
+
+	serviceAccount := nad.ServiceAccountName(spec)
+	for idx, network := range spec.Networks {
+		if network.Multus == nil {
+			continue
+		}
+		nadNamespace, nadName := nad.SplitNetworkName(namespace, network.Multus.NetworkName)
+		if nadNamespace == namespace {
+			continue
+		}
+
+		allowed, message, err := authFunc(nadNamespace, nadName, namespace, serviceAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "Authorization failed, message is: " + message,
+				Field:   field.Child("networks").Index(idx).Child("multus", "networkName").String(),
+			})
+		}
+	}
+
+	return causes, nil
+}
+
+// resolveNetworks rejects multus networks referencing a
+// NetworkAttachmentDefinition with an invalid CNI configuration. Definitions
+// which do not exist yet are tolerated, virt-controller reflects them on the
+// VMI and waits for them before creating the pod.
+func resolveNetworks(field *k8sfield.Path, namespace string, spec *v1.VirtualMachineInstanceSpec, getFunc nad.GetFunc) ([]metav1.StatusCause, error) {
+	var causes []metav1.StatusCause
+
+	for idx, network := range spec.Networks {
+		if network.Multus == nil {
+			continue
+		}
+		nadNamespace, nadName := nad.SplitNetworkName(namespace, network.Multus.NetworkName)
+		definition, err := getFunc(nadNamespace, nadName)
+		if err != nil {
+			return nil, err
+		}
+		if definition == nil {
+			continue
+		}
+
+		if err := nad.ValidateConfig(definition); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Network attachment definition %s/%s is invalid: %v", nadNamespace, nadName, err),
+				Field:   field.Child("networks").Index(idx).Child("multus", "networkName").String(),
+			})
+		}
+	}
+
+	return causes, nil
+}
+
 func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	volumeNameMap := make(map[string]*v1.Volume)
@@ -292,6 +423,18 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		}
 	}
 
+	if spec.Domain.Firmware != nil {
+		switch spec.Domain.Firmware.IdentityPolicy {
+		case "", v1.FirmwareIdentityPolicyKeep, v1.FirmwareIdentityPolicyRegenerateOnClone:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("domain", "firmware", "identityPolicy").String(), spec.Domain.Firmware.IdentityPolicy),
+				Field:   field.Child("domain", "firmware", "identityPolicy").String(),
+			})
+		}
+	}
+
 	// Validate cpu if values are not negative
 	if spec.Domain.Resources.Requests.Cpu().MilliValue() < 0 {
 		causes = append(causes, metav1.StatusCause{
@@ -420,6 +563,14 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 			Field:   field.Child("domain", "cpu", "isolateEmulatorThread").String(),
 		})
 	}
+	causes = append(causes, validateNUMA(field, spec, config)...)
+	if spec.Domain.AllowEmulation != nil && *spec.Domain.AllowEmulation && config.GetEmulationPolicy() == v1.EmulationPolicyNever {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not permitted by the emulation policy %s of the cluster", field.Child("domain", "allowEmulation").String(), v1.EmulationPolicyNever),
+			Field:   field.Child("domain", "allowEmulation").String(),
+		})
+	}
 	// Validate CPU Feature Policies
 	if spec.Domain.CPU != nil && spec.Domain.CPU.Features != nil {
 		for idx, feature := range spec.Domain.CPU.Features {
@@ -505,6 +656,8 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		}
 	}
 
+	causes = append(causes, validateVhostUserBlkDisks(field, spec)...)
+
 	multusDefaultCount := 0
 	podExists := false
 
@@ -517,6 +670,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		if network.Pod != nil {
 			cniTypesCount++
 			podExists = true
+			causes = append(causes, validatePodNetworkCIDRs(field.Child("networks").Index(idx).Child("pod"), network.Pod)...)
 		}
 
 		if network.NetworkSource.Multus != nil {
@@ -786,6 +940,41 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 			}
 		}
 
+		if iface.Bridge != nil && iface.Bridge.GuestAddress != nil {
+			causes = append(causes, validateBridgeGuestAddress(field.Child("domain", "devices", "interfaces").Index(idx), &iface)...)
+		}
+
+		if iface.Masquerade != nil {
+			switch iface.Masquerade.Hairpin {
+			case "", v1.MasqueradeHairpinLoopback, v1.MasqueradeHairpinNone, v1.MasqueradeHairpinFull:
+			default:
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("domain", "devices", "interfaces").Index(idx).Child("masquerade", "hairpin").String(), iface.Masquerade.Hairpin),
+					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("masquerade", "hairpin").String(),
+				})
+			}
+		}
+
+		causes = append(causes, validateInterfaceRoles(field.Child("domain", "devices", "interfaces").Index(idx).Child("roles"), iface.Roles)...)
+		causes = append(causes, validateInterfaceTuning(field.Child("domain", "devices", "interfaces").Index(idx), &iface)...)
+
+		if iface.RSS != nil {
+			if (iface.Model != "" && iface.Model != "virtio") || iface.SRIOV != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s is only supported on virtio interfaces", field.Child("domain", "devices", "interfaces").Index(idx).Child("rss").String()),
+					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("rss").String(),
+				})
+			} else if vifMQ == nil || !*vifMQ {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s requires %s", field.Child("domain", "devices", "interfaces").Index(idx).Child("rss").String(), field.Child("domain", "devices", "networkInterfaceMultiqueue").String()),
+					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("rss").String(),
+				})
+			}
+		}
+
 		if iface.Model == "virtio" || iface.Model == "" {
 			isVirtioNicRequested = true
 		}
@@ -800,6 +989,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 					})
 				}
 			}
+			causes = append(causes, validateDHCPBootOptions(field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions"), &iface)...)
 		}
 	}
 	// Network interface multiqueue can only be set for a virtio driver
@@ -812,8 +1002,27 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		})
 
 	}
+	if queues := spec.Domain.Devices.NetworkInterfaceQueues; queues != nil {
+		if vifMQ == nil || !*vifMQ {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires %s", field.Child("domain", "devices", "networkInterfaceQueues").String(), field.Child("domain", "devices", "networkInterfaceMultiqueue").String()),
+				Field:   field.Child("domain", "devices", "networkInterfaceQueues").String(),
+			})
+		} else if *queues < 1 || *queues > v1.MaxNetworkInterfaceQueues {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be between 1 and %d, the maximum number of queues of a tap device", field.Child("domain", "devices", "networkInterfaceQueues").String(), v1.MaxNetworkInterfaceQueues),
+				Field:   field.Child("domain", "devices", "networkInterfaceQueues").String(),
+			})
+		}
+	}
+
+	causes = append(causes, validateInterfaceMirrors(field, spec, networkNameMap, networkInterfaceMap)...)
 
-	// Validate that every network was assign to an interface
+	// Validate that every network was assign to an interface, or is the
+	// target of a mirrored interface
+	mirrorTargets := mirrorNetworks(spec)
 	networkDuplicates := map[string]struct{}{}
 	for i, network := range spec.Networks {
 		if _, exists := networkDuplicates[network.Name]; exists {
@@ -824,7 +1033,8 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 			})
 		}
 		networkDuplicates[network.Name] = struct{}{}
-		if _, exists := networkInterfaceMap[network.Name]; !exists {
+		_, isMirrorTarget := mirrorTargets[network.Name]
+		if _, exists := networkInterfaceMap[network.Name]; !exists && !isMirrorTarget {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueRequired,
 				Message: fmt.Sprintf("%s '%s' not found.", field.Child("networks").Index(i).Child("name").String(), network.Name),
@@ -842,13 +1052,39 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 			})
 		}
 
-		if input.Type != "tablet" {
+		if input.Type != "tablet" && input.Type != "keyboard" {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: "Input device can have only tablet type.",
+				Message: "Input device can have only tablet or keyboard type.",
 				Field:   field.Child("domain", "devices", "inputs").Index(idx).Child("type").String(),
 			})
 		}
+
+		if input.Model != "" {
+			if input.Bus != "virtio" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "Input device can have a model only on the virtio bus.",
+					Field:   field.Child("domain", "devices", "inputs").Index(idx).Child("model").String(),
+				})
+			} else if _, exists := validInputModels[input.Model]; !exists {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("Input device uses model %s that is not supported.", input.Model),
+					Field:   field.Child("domain", "devices", "inputs").Index(idx).Child("model").String(),
+				})
+			}
+		}
+	}
+
+	switch spec.Domain.Devices.USBControllerModel {
+	case "", "qemu-xhci", "nec-xhci":
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("domain", "devices", "usbControllerModel").String(), spec.Domain.Devices.USBControllerModel),
+			Field:   field.Child("domain", "devices", "usbControllerModel").String(),
+		})
 	}
 	if spec.Domain.IOThreadsPolicy != nil {
 		isValidPolicy := func(policy v1.IOThreadsPolicy) bool {
@@ -952,6 +1188,38 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 
 	}
 
+	if spec.QoSClass != "" && config.GetQoSClass(spec.QoSClass) == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("qosClass").String(), spec.QoSClass),
+			Field:   field.Child("qosClass").String(),
+		})
+	}
+
+	if _, ok := webhooks.GetGuestOSPreference(spec.GuestOS); spec.GuestOS != "" && !ok {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("guestOS").String(), spec.GuestOS),
+			Field:   field.Child("guestOS").String(),
+		})
+	}
+
+	if spec.MetadataService != nil && !hasMasqueradeInterface(spec) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires a masquerade interface on the pod network", field.Child("metadataService").String()),
+			Field:   field.Child("metadataService").String(),
+		})
+	}
+
+	if autoattach := spec.Domain.Devices.AutoattachVirtioWinDriverDisk; autoattach != nil && *autoattach && config.GetVirtioWinImage() == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires a virtio-win image in the KubeVirt configuration", field.Child("domain", "devices", "autoattachVirtioWinDriverDisk").String()),
+			Field:   field.Child("domain", "devices", "autoattachVirtioWinDriverDisk").String(),
+		})
+	}
+
 	if spec.Domain.Devices.GPUs != nil && !config.GPUPassthroughEnabled() {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
@@ -984,6 +1252,9 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		for _, dev := range hostDevs.MediatedDevices {
 			supportedHostDevicesMap[dev.ResourceName] = true
 		}
+		for _, dev := range hostDevs.NvmeNamespaces {
+			supportedHostDevicesMap[dev.ResourceName] = true
+		}
 		for _, hostDev := range spec.Domain.Devices.GPUs {
 			if _, exist := supportedHostDevicesMap[hostDev.DeviceName]; !exist {
 				causes = append(causes, metav1.StatusCause{
@@ -1006,6 +1277,35 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	return causes
 }
 
+func validateNUMA(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.CPU == nil || spec.Domain.CPU.NUMA == nil || spec.Domain.CPU.NUMA.GuestMappingPassthrough == nil {
+		return
+	}
+
+	if !config.NUMAEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("NUMA feature gate is not enabled in kubevirt-config"),
+			Field:   field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String(),
+		})
+	}
+	if !spec.Domain.CPU.DedicatedCPUPlacement {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires %s to be set", field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String(), field.Child("domain", "cpu", "dedicatedCpuPlacement").String()),
+			Field:   field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String(),
+		})
+	}
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s requires %s to be set", field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String(), field.Child("domain", "memory", "hugepages").String()),
+			Field:   field.Child("domain", "cpu", "numa", "guestMappingPassthrough").String(),
+		})
+	}
+	return
+}
+
 // ValidateVirtualMachineInstanceMandatoryFields should be invoked after all defaults and presets are applied.
 // It is only meant to be used for VMI reviews, not if they are templates on other objects
 func ValidateVirtualMachineInstanceMandatoryFields(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
@@ -1065,6 +1365,311 @@ func ValidateVirtualMachineInstanceMetadata(field *k8sfield.Path, metadata *meta
 		})
 	}
 
+	// Validate emulator selection feature gate if set when the corresponding annotation is found
+	if emulator, ok := annotations[v1.EmulatorAnnotation]; ok {
+		if !config.EmulatorSelectionEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("EmulatorSelection feature gate is not enabled in kubevirt-config, invalid entry %s",
+					field.Child("annotations", v1.EmulatorAnnotation).String()),
+				Field: field.Child("annotations").String(),
+			})
+		} else if errs := validation.IsDNS1123Subdomain(emulator); len(errs) != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not a valid emulator name: %s",
+					field.Child("annotations", v1.EmulatorAnnotation).String(), strings.Join(errs, ", ")),
+				Field: field.Child("annotations").String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+func validateInterfaceRoles(field *k8sfield.Path, roles []v1.InterfaceRole) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	seen := map[v1.InterfaceRole]bool{}
+	for idx, role := range roles {
+		switch role {
+		case v1.InterfaceRoleManagement, v1.InterfaceRoleStorage, v1.InterfaceRoleWorkload:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is set with an unrecognized role: %s", field.Index(idx).String(), role),
+				Field:   field.Index(idx).String(),
+			})
+			continue
+		}
+		if seen[role] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s role %s is listed more than once", field.Index(idx).String(), role),
+				Field:   field.Index(idx).String(),
+			})
+		}
+		seen[role] = true
+	}
+	return causes
+}
+
+// validateInterfaceTuning verifies the ring sizes, the offloads and the latency profile of the interface
+func validateInterfaceTuning(field *k8sfield.Path, iface *v1.Interface) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	isVirtio := iface.Model == "" || iface.Model == "virtio"
+	queueSizes := []struct {
+		name string
+		size *uint32
+	}{
+		{"rxQueueSize", iface.RxQueueSize},
+		{"txQueueSize", iface.TxQueueSize},
+	}
+	for _, queueSize := range queueSizes {
+		name, size := queueSize.name, queueSize.size
+		if size == nil {
+			continue
+		}
+		if !isVirtio {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only supported on virtio interfaces", field.Child(name).String()),
+				Field:   field.Child(name).String(),
+			})
+		} else if *size < 256 || *size > 1024 || *size&(*size-1) != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a power of 2 between 256 and 1024", field.Child(name).String()),
+				Field:   field.Child(name).String(),
+			})
+		}
+	}
+	if iface.PackedRing != nil && !isVirtio {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported on virtio interfaces", field.Child("packedRing").String()),
+			Field:   field.Child("packedRing").String(),
+		})
+	}
+	if iface.VhostZeroCopyTX != nil && (!isVirtio || iface.Slirp != nil) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported on virtio interfaces which are not bound with slirp", field.Child("vhostZeroCopyTX").String()),
+			Field:   field.Child("vhostZeroCopyTX").String(),
+		})
+	}
+	if iface.Offloads != nil && iface.Bridge == nil && iface.Masquerade == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported on bridge and masquerade interfaces", field.Child("offloads").String()),
+			Field:   field.Child("offloads").String(),
+		})
+	}
+	switch iface.LatencyProfile {
+	case "":
+	case v1.InterfaceLatencyProfileLowLatency:
+		if !isVirtio || (iface.Bridge == nil && iface.Masquerade == nil) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only supported on virtio bridge and masquerade interfaces", field.Child("latencyProfile").String()),
+				Field:   field.Child("latencyProfile").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be %s", field.Child("latencyProfile").String(), v1.InterfaceLatencyProfileLowLatency),
+			Field:   field.Child("latencyProfile").String(),
+		})
+	}
+	if iface.Mirror != nil {
+		if iface.Bridge == nil && iface.Masquerade == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only supported on bridge and masquerade interfaces", field.Child("mirror").String()),
+				Field:   field.Child("mirror").String(),
+			})
+		}
+		switch iface.Mirror.Direction {
+		case "", v1.MirrorDirectionRX, v1.MirrorDirectionTX, v1.MirrorDirectionBoth:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("mirror", "direction").String(), iface.Mirror.Direction),
+				Field:   field.Child("mirror", "direction").String(),
+			})
+		}
+	}
+	return causes
+}
+
+// validateInterfaceMirrors verifies that the interfaces are mirrored to
+// multus networks which are not used by any interface
+func validateInterfaceMirrors(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, networkNameMap map[string]*v1.Network, networkInterfaceMap map[string]struct{}) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Mirror == nil {
+			continue
+		}
+		mirrorField := field.Child("domain", "devices", "interfaces").Index(idx).Child("mirror", "network")
+		network, exists := networkNameMap[iface.Mirror.Network]
+		if !exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' not found.", mirrorField.String(), iface.Mirror.Network),
+				Field:   mirrorField.String(),
+			})
+			continue
+		}
+		if network.Multus == nil || network.Multus.Default {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must refer to a multus network which is not the default one", mirrorField.String()),
+				Field:   mirrorField.String(),
+			})
+		}
+		if _, used := networkInterfaceMap[iface.Mirror.Network]; used {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must refer to a network which is not used by an interface", mirrorField.String()),
+				Field:   mirrorField.String(),
+			})
+		}
+	}
+	return causes
+}
+
+// mirrorNetworks returns the networks which interfaces are mirrored to
+func mirrorNetworks(spec *v1.VirtualMachineInstanceSpec) map[string]struct{} {
+	networks := map[string]struct{}{}
+	for _, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Mirror != nil {
+			networks[iface.Mirror.Network] = struct{}{}
+		}
+	}
+	return networks
+}
+
+// validateDHCPBootOptions verifies that the boot server is an IPv4 address and
+// that the TFTP proxy has a masquerade gateway to listen on and a server to relay to
+func validateDHCPBootOptions(field *k8sfield.Path, iface *v1.Interface) (causes []metav1.StatusCause) {
+	dhcpOptions := iface.DHCPOptions
+	if dhcpOptions.NextServer != "" && net.ParseIP(dhcpOptions.NextServer).To4() == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be a valid IPv4 address", field.Child("nextServer").String()),
+			Field:   field.Child("nextServer").String(),
+		})
+	}
+	if !dhcpOptions.TFTPProxy {
+		return causes
+	}
+	if iface.Masquerade == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported on masquerade interfaces", field.Child("tftpProxy").String()),
+			Field:   field.Child("tftpProxy").String(),
+		})
+	}
+	if dhcpOptions.NextServer == "" && dhcpOptions.TFTPServerName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s requires a next server or a TFTP server to relay to", field.Child("tftpProxy").String()),
+			Field:   field.Child("tftpProxy").String(),
+		})
+	}
+	return causes
+}
+
+// hasMasqueradeInterface tells if the guest is connected to the pod network
+// through a masquerade interface, which is how it reaches the metadata service
+func hasMasqueradeInterface(spec *v1.VirtualMachineInstanceSpec) bool {
+	for _, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Masquerade != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePodNetworkCIDRs verifies that the vm network CIDRs are of the right
+// family and leave room for the gateway and the VM addresses
+func validatePodNetworkCIDRs(field *k8sfield.Path, pod *v1.PodNetwork) (causes []metav1.StatusCause) {
+	cidrs := []struct {
+		field     string
+		cidr      string
+		isIPv4    bool
+		maxPrefix int
+	}{
+		{field: "vmNetworkCIDR", cidr: pod.VMNetworkCIDR, isIPv4: true, maxPrefix: 30},
+		{field: "vmIPv6NetworkCIDR", cidr: pod.VMIPv6NetworkCIDR, isIPv4: false, maxPrefix: 126},
+	}
+	for _, c := range cidrs {
+		if c.cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(c.cidr)
+		if err != nil || (ipNet.IP.To4() != nil) != c.isIPv4 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not a valid CIDR: %s", field.Child(c.field).String(), c.cidr),
+				Field:   field.Child(c.field).String(),
+			})
+			continue
+		}
+		if prefix, _ := ipNet.Mask.Size(); prefix > c.maxPrefix {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must have a prefix length of at most %d", field.Child(c.field).String(), c.maxPrefix),
+				Field:   field.Child(c.field).String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateBridgeGuestAddress(field *k8sfield.Path, iface *v1.Interface) (causes []metav1.StatusCause) {
+	guestAddress := iface.Bridge.GuestAddress
+	field = field.Child("bridge", "guestAddress")
+
+	// the forwarding and neighbor entries need a MAC address which is known upfront
+	if iface.MacAddress == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s requires the interface to have a macAddress", field.String()),
+			Field:   field.String(),
+		})
+	}
+
+	ip, _, err := net.ParseCIDR(guestAddress.IP)
+	if err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be an IP address in CIDR notation: %s", field.Child("ip").String(), guestAddress.IP),
+			Field:   field.Child("ip").String(),
+		})
+	} else if guestAddress.ServeDHCP && ip.To4() == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can only be served over DHCP if it is an IPv4 address", field.Child("ip").String()),
+			Field:   field.Child("serveDHCP").String(),
+		})
+	}
+
+	if guestAddress.Gateway != "" {
+		if !guestAddress.ServeDHCP {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only offered to the guest with serveDHCP", field.Child("gateway").String()),
+				Field:   field.Child("gateway").String(),
+			})
+		} else if net.ParseIP(guestAddress.Gateway).To4() == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a valid IPv4 address: %s", field.Child("gateway").String(), guestAddress.Gateway),
+				Field:   field.Child("gateway").String(),
+			})
+		}
+	}
 	return causes
 }
 
@@ -1195,6 +1800,21 @@ func validateFirmware(field *k8sfield.Path, firmware *v1.Firmware) []metav1.Stat
 
 	if firmware != nil {
 		causes = append(causes, validateBootloader(field.Child("bootloader"), firmware.Bootloader)...)
+		causes = append(causes, validateBootMenu(field.Child("bootMenu"), firmware.BootMenu)...)
+	}
+
+	return causes
+}
+
+func validateBootMenu(field *k8sfield.Path, bootMenu *v1.BootMenu) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if bootMenu != nil && bootMenu.Timeout != nil && *bootMenu.Timeout > maxBootMenuTimeout {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be at most %d milliseconds.", field.Child("timeout").String(), maxBootMenuTimeout),
+			Field:   field.Child("timeout").String(),
+		})
 	}
 
 	return causes
@@ -1377,9 +1997,20 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			volumeSourceSetCount++
 		}
 		if volume.ContainerDisk != nil {
+			if volume.ContainerDisk.Checksum != "" {
+				if err := containerdisk.ValidateChecksum(volume.ContainerDisk.Checksum); err != nil {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("%s is invalid: %v", field.Index(idx).Child("containerDisk", "checksum").String(), err),
+						Field:   field.Index(idx).Child("containerDisk", "checksum").String(),
+					})
+				}
+			}
+			causes = append(causes, validateEphemeralImage(field.Index(idx).Child("containerDisk", "ephemeralImage"), volume.ContainerDisk.EphemeralImage)...)
 			volumeSourceSetCount++
 		}
 		if volume.Ephemeral != nil {
+			causes = append(causes, validateEphemeralImage(field.Index(idx).Child("ephemeral", "ephemeralImage"), volume.Ephemeral.EphemeralImage)...)
 			volumeSourceSetCount++
 		}
 		if volume.EmptyDisk != nil {
@@ -1404,6 +2035,15 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 					Field:   field.Index(idx).Child("name").String(),
 				})
 			}
+			if volume.DataVolume.Checksum != "" {
+				if err := containerdisk.ValidateChecksum(volume.DataVolume.Checksum); err != nil {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("%s is invalid: %v", field.Index(idx).Child("dataVolume", "checksum").String(), err),
+						Field:   field.Index(idx).Child("dataVolume", "checksum").String(),
+					})
+				}
+			}
 			volumeSourceSetCount++
 		}
 		if volume.ConfigMap != nil {
@@ -1419,6 +2059,9 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			volumeSourceSetCount++
 			serviceAccountVolumeCount++
 		}
+		if volume.VhostUserBlk != nil {
+			volumeSourceSetCount++
+		}
 
 		if volumeSourceSetCount != 1 {
 			causes = append(causes, metav1.StatusCause{
@@ -1428,6 +2071,23 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			})
 		}
 
+		if volume.Encryption != nil {
+			if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s is only supported for persistentVolumeClaim and dataVolume volumes", field.Index(idx).Child("encryption").String()),
+					Field:   field.Index(idx).Child("encryption").String(),
+				})
+			}
+			if volume.Encryption.SecretRef == nil || volume.Encryption.SecretRef.Name == "" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueRequired,
+					Message: fmt.Sprintf("%s must be set", field.Index(idx).Child("encryption", "secretRef", "name").String()),
+					Field:   field.Index(idx).Child("encryption", "secretRef", "name").String(),
+				})
+			}
+		}
+
 		// Verify cloud init data is within size limits
 		if volume.CloudInitNoCloud != nil || volume.CloudInitConfigDrive != nil {
 			var userDataSecretRef, networkDataSecretRef *k8sv1.LocalObjectReference
@@ -1600,6 +2260,36 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 				})
 			}
 		}
+
+		if vhostUserBlk := volume.VhostUserBlk; vhostUserBlk != nil {
+			if !config.VhostUserBlkEnabled() {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VhostUserBlkGate),
+					Field:   field.Index(idx).Child("vhostUserBlk").String(),
+				})
+			}
+			if vhostUserBlk.ClaimName == "" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueRequired,
+					Message: fmt.Sprintf("%s is a required field", field.Index(idx).Child("vhostUserBlk", "claimName").String()),
+					Field:   field.Index(idx).Child("vhostUserBlk", "claimName").String(),
+				})
+			}
+			if vhostUserBlk.Socket == "" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueRequired,
+					Message: fmt.Sprintf("%s is a required field", field.Index(idx).Child("vhostUserBlk", "socket").String()),
+					Field:   field.Index(idx).Child("vhostUserBlk", "socket").String(),
+				})
+			} else if filepath.IsAbs(vhostUserBlk.Socket) || strings.Contains(vhostUserBlk.Socket, "..") {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be a relative path in the volume without '..'", field.Index(idx).Child("vhostUserBlk", "socket").String()),
+					Field:   field.Index(idx).Child("vhostUserBlk", "socket").String(),
+				})
+			}
+		}
 	}
 
 	if serviceAccountVolumeCount > 1 {
@@ -1613,9 +2303,176 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 	return causes
 }
 
+// validateVhostUserBlkDisks verifies that the disks of vhost-user-blk volumes only use the options
+// qemu supports for vhost-user-blk devices
+func validateVhostUserBlkDisks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	vhostUserBlkVolumes := make(map[string]bool)
+	for _, volume := range spec.Volumes {
+		if volume.VhostUserBlk != nil {
+			vhostUserBlkVolumes[volume.Name] = true
+		}
+	}
+
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if !vhostUserBlkVolumes[disk.Name] {
+			continue
+		}
+		diskField := field.Child("domain", "devices", "disks").Index(idx)
+		if disk.LUN != nil || disk.CDRom != nil || disk.Floppy != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s of a vhostUserBlk volume has to be a disk", diskField.String()),
+				Field:   diskField.String(),
+			})
+		}
+		if disk.Disk != nil {
+			if disk.Disk.Bus != "" && disk.Disk.Bus != "virtio" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s of a vhostUserBlk volume has to be virtio", diskField.Child("disk", "bus").String()),
+					Field:   diskField.Child("disk", "bus").String(),
+				})
+			}
+			if disk.Disk.ReadOnly {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s is not supported for vhostUserBlk volumes", diskField.Child("disk", "readonly").String()),
+					Field:   diskField.Child("disk", "readonly").String(),
+				})
+			}
+		}
+
+		unsupported := map[string]bool{
+			"serial":            disk.Serial != "",
+			"wwn":               disk.WWN != "",
+			"cache":             disk.Cache != "",
+			"io":                disk.IO != "",
+			"dedicatedIOThread": disk.DedicatedIOThread != nil && *disk.DedicatedIOThread,
+		}
+		for _, option := range []string{"serial", "wwn", "cache", "io", "dedicatedIOThread"} {
+			if unsupported[option] {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s is not supported for vhostUserBlk volumes", diskField.Child(option).String()),
+					Field:   diskField.Child(option).String(),
+				})
+			}
+		}
+	}
+
+	return causes
+}
+
+// validateEphemeralImage verifies that the options of the writable image of a volume can be combined
+func validateEphemeralImage(field *k8sfield.Path, image *v1.EphemeralImage) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if image == nil {
+		return causes
+	}
+
+	format := image.Format
+	switch format {
+	case "":
+		format = v1.EphemeralImageFormatQcow2
+	case v1.EphemeralImageFormatQcow2, v1.EphemeralImageFormatRaw:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is set with an unrecognized format: %s", field.Child("format").String(), image.Format),
+			Field:   field.Child("format").String(),
+		})
+	}
+
+	switch image.Preallocation {
+	case "", v1.EphemeralImagePreallocationOff:
+	case v1.EphemeralImagePreallocationFalloc, v1.EphemeralImagePreallocationFull:
+		if format != v1.EphemeralImageFormatRaw {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only supported for the %s format", field.Child("preallocation").String(), v1.EphemeralImageFormatRaw),
+				Field:   field.Child("preallocation").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is set with an unrecognized preallocation mode: %s", field.Child("preallocation").String(), image.Preallocation),
+			Field:   field.Child("preallocation").String(),
+		})
+	}
+
+	if image.Compressed && format != v1.EphemeralImageFormatQcow2 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported for the %s format", field.Child("compressed").String(), v1.EphemeralImageFormatQcow2),
+			Field:   field.Child("compressed").String(),
+		})
+	}
+
+	return causes
+}
+
 func validateDevices(field *k8sfield.Path, devices *v1.Devices) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	causes = append(causes, validateDisks(field.Child("disks"), devices.Disks)...)
+	causes = append(causes, validateChannels(field.Child("channels"), devices.Channels)...)
+	return causes
+}
+
+func validateChannels(field *k8sfield.Path, channels []v1.Channel) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	nameMap := make(map[string]int)
+
+	if len(channels) > arrayLenMax {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s list exceeds the %d element limit in length", field.String(), arrayLenMax),
+			Field:   field.String(),
+		})
+		// We won't process anything over the limit
+		return causes
+	}
+
+	isValid := regexp.MustCompile(`^[A-Za-z0-9_.-]+$`).MatchString
+	for idx, channel := range channels {
+		if channel.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is required", field.Index(idx).Child("name").String()),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+			continue
+		}
+		if !isValid(channel.Name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must only contain alphanumeric characters, '.', '_' and '-'", field.Index(idx).Child("name").String()),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		}
+		if channel.Name == guestAgentChannelName {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is reserved for the guest agent", field.Index(idx).Child("name").String()),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		}
+
+		// verify name is unique
+		otherIdx, ok := nameMap[channel.Name]
+		if !ok {
+			nameMap[channel.Name] = idx
+		} else {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s and %s must not have the same Name.", field.Index(idx).String(), field.Index(otherIdx).String()),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+		}
+	}
+
 	return causes
 }
 
@@ -1786,6 +2643,25 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		// Verify the WWN is made up of 16 hexadecimal digits and is set on a bus libvirt supports it for
+		isValidWWN := regexp.MustCompile(`^(0x)?[0-9A-Fa-f]{16}$`).MatchString
+		if disk.WWN != "" {
+			if !isValidWWN(disk.WWN) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be made up of 16 hexadecimal digits, optionally prefixed with 0x", field.Index(idx).Child("wwn").String()),
+					Field:   field.Index(idx).Child("wwn").String(),
+				})
+			}
+			if disk.Disk == nil || (disk.Disk.Bus != "sata" && disk.Disk.Bus != "scsi") {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s is only supported for disks on the sata or scsi bus", field.Index(idx).Child("wwn").String()),
+					Field:   field.Index(idx).Child("wwn").String(),
+				})
+			}
+		}
+
 		// Verify if cache mode is valid
 		if disk.Cache != "" && disk.Cache != v1.CacheNone && disk.Cache != v1.CacheWriteThrough {
 			causes = append(causes, metav1.StatusCause{
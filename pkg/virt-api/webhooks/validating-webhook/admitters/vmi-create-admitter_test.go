@@ -28,6 +28,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/rbac"
 
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -136,6 +137,148 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		Expect(resp.Result.Message).To(ContainSubstring("no memory requested"))
 	})
 
+	Context("with admission policies", func() {
+		setAdmissionPolicies := func(policies ...v1.VMIAdmissionPolicy) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.AdmissionPolicies = policies
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+		}
+
+		admit := func(vmi *v1.VirtualMachineInstance, namespace string) *v1beta1.AdmissionResponse {
+			vmi = vmi.DeepCopy()
+			vmi.Namespace = namespace
+			vmiBytes, _ := json.Marshal(vmi)
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			return vmiCreateAdmitter.Admit(ar)
+		}
+
+		noBridge := v1.VMIAdmissionPolicy{
+			Name:       "no-bridge",
+			Namespaces: []string{"production"},
+			Rules: []v1.VMIAdmissionRule{
+				{Path: "{.spec.domain.devices.interfaces[?(@.bridge)].name}", Operator: v1.VMIAdmissionRuleOpExists},
+			},
+			Message: "bridge interfaces are not allowed",
+		}
+		smallVMs := v1.VMIAdmissionPolicy{
+			Name: "small-vms",
+			Rules: []v1.VMIAdmissionRule{
+				{Path: "{.spec.domain.resources.requests.memory}", Operator: v1.VMIAdmissionRuleOpGt, Values: []string{"4Gi"}},
+			},
+		}
+
+		newVMI := func(memory string, bridge bool) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse(memory),
+			}
+			if bridge {
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			}
+			return vmi
+		}
+
+		table.DescribeTable("should", func(vmi *v1.VirtualMachineInstance, namespace string, message string) {
+			setAdmissionPolicies(noBridge, smallVMs)
+			resp := admit(vmi, namespace)
+			if message == "" {
+				Expect(resp.Allowed).To(BeTrue())
+				return
+			}
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal(message))
+		},
+			table.Entry("accept a VMI matching no policy", newVMI("1Gi", false), "production", ""),
+			table.Entry("reject a VMI exceeding the memory limit", newVMI("8Gi", false), "production", "the VMI is rejected by admission policy small-vms"),
+			table.Entry("reject a VMI with a bridge interface", newVMI("1Gi", true), "production", "the VMI is rejected by admission policy no-bridge: bridge interfaces are not allowed"),
+			table.Entry("ignore policies of other namespaces", newVMI("1Gi", true), "development", ""),
+		)
+
+		It("should reject VMIs if a policy can't be evaluated", func() {
+			setAdmissionPolicies(v1.VMIAdmissionPolicy{
+				Name: "broken",
+				Rules: []v1.VMIAdmissionRule{
+					{Path: "{.spec.domain.resources.requests.memory}", Operator: v1.VMIAdmissionRuleOpGt, Values: []string{"a lot"}},
+				},
+			})
+			resp := admit(newVMI("8Gi", false), "default")
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Message).To(HavePrefix("the VMI is rejected, admission policy broken can't be evaluated: rule {.spec.domain.resources.requests.memory} Gt:"))
+		})
+
+		It("should reject VMIs if a rule selects values which are no quantities", func() {
+			setAdmissionPolicies(v1.VMIAdmissionPolicy{
+				Name: "machine",
+				Rules: []v1.VMIAdmissionRule{
+					{Path: "{.metadata.name}", Operator: v1.VMIAdmissionRuleOpGt, Values: []string{"4"}},
+				},
+			})
+			resp := admit(newVMI("1Gi", false), "default")
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("the VMI is rejected, admission policy machine can't be evaluated: rule {.metadata.name} Gt: selected value testvmi is no quantity"))
+		})
+	})
+
+	Context("with scoped feature gates", func() {
+		BeforeEach(func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.ScopedFeatureGates = []v1.ScopedFeatureGate{
+				{
+					Name:       virtconfig.GPUGate,
+					Namespaces: []string{"gpu-team"},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"gpu": "allowed"},
+					},
+				},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			namespaceInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Namespace{})
+			namespaceInformer.GetStore().Add(&k8sv1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "labeled", Labels: map[string]string{"gpu": "allowed"}},
+			})
+			informers := *webhooks.GetInformers()
+			informers.NamespaceInformer = namespaceInformer
+			webhooks.SetInformers(&informers)
+		})
+
+		table.DescribeTable("should", func(namespace string, allowed bool) {
+			vmi := v1.NewMinimalVMIWithNS(namespace, "testvmi")
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu", DeviceName: "nvidia.com/GV100GL_Tesla_V100"}}
+			vmiBytes, _ := json.Marshal(vmi)
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+
+			resp := vmiCreateAdmitter.Admit(ar)
+			Expect(resp.Allowed).To(Equal(allowed))
+			if !allowed {
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("GPU feature gate is not enabled"))
+			}
+		},
+			table.Entry("accept a VMI in a namespace the feature gate lists", "gpu-team", true),
+			table.Entry("accept a VMI in a namespace the feature gate selects", "labeled", true),
+			table.Entry("reject a VMI in another namespace", "default", false),
+			table.Entry("reject a VMI in an unknown namespace", "unknown", false),
+		)
+	})
+
 	Context("tolerations with eviction policies given", func() {
 		var vmi *v1.VirtualMachineInstance
 		var policy = v1.EvictionStrategyLiveMigrate
@@ -425,6 +568,10 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				map[string]string{hooks.HookSidecarListAnnotationName: "[{'image': 'fake-image'}]"},
 				fmt.Sprintf("invalid entry metadata.annotations.%s", hooks.HookSidecarListAnnotationName),
 			),
+			table.Entry("without EmulatorSelection feature gate enabled",
+				map[string]string{v1.EmulatorAnnotation: "qemu-6.0"},
+				fmt.Sprintf("invalid entry metadata.annotations.%s", v1.EmulatorAnnotation),
+			),
 		)
 
 		table.DescribeTable("should accept annotations which require feature gate enabled", func(annotations map[string]string, featureGate string) {
@@ -444,6 +591,25 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				map[string]string{hooks.HookSidecarListAnnotationName: "[{'image': 'fake-image'}]"},
 				virtconfig.SidecarGate,
 			),
+			table.Entry("with EmulatorSelection feature gate enabled",
+				map[string]string{v1.EmulatorAnnotation: "qemu-6.0"},
+				virtconfig.EmulatorSelectionGate,
+			),
+		)
+
+		table.DescribeTable("should reject invalid emulator names", func(emulator string) {
+			enableFeatureGate(virtconfig.EmulatorSelectionGate)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.ObjectMeta = metav1.ObjectMeta{
+				Annotations: map[string]string{v1.EmulatorAnnotation: emulator},
+			}
+			causes := ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, config, "fake-account")
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring("is not a valid emulator name"))
+		},
+			table.Entry("with a path", "../../../usr/bin/qemu"),
+			table.Entry("which is empty", ""),
+			table.Entry("with upper case letters", "Qemu"),
 		)
 	})
 
@@ -640,24 +806,66 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					Name: "tablet0",
 					Bus:  "ps2",
 				}, 1, []string{"fake.domain.devices.inputs[0].bus"}, "Expect bus error"),
-			table.Entry("and reject input with keyboard type and virtio bus",
+			table.Entry("and accept input with keyboard type and virtio bus",
 				v1.Input{
 					Type: "keyboard",
-					Name: "tablet0",
+					Name: "keyboard0",
 					Bus:  "virtio",
-				}, 1, []string{"fake.domain.devices.inputs[0].type"}, "Expect type error"),
-			table.Entry("and reject input with keyboard type and usb bus",
+				}, 0, []string{}, "Expect no errors"),
+			table.Entry("and accept input with keyboard type and usb bus",
 				v1.Input{
 					Type: "keyboard",
-					Name: "tablet0",
+					Name: "keyboard0",
+					Bus:  "usb",
+				}, 0, []string{}, "Expect no errors"),
+			table.Entry("and reject input with mouse type",
+				v1.Input{
+					Type: "mouse",
+					Name: "mouse0",
 					Bus:  "usb",
 				}, 1, []string{"fake.domain.devices.inputs[0].type"}, "Expect type error"),
 			table.Entry("and reject input with wrong type and wrong bus",
 				v1.Input{
-					Type: "keyboard",
-					Name: "tablet0",
+					Type: "mouse",
+					Name: "mouse0",
 					Bus:  "ps2",
 				}, 2, []string{"fake.domain.devices.inputs[0].bus", "fake.domain.devices.inputs[0].type"}, "Expect type error"),
+			table.Entry("and accept input with a virtio model",
+				v1.Input{
+					Type:  "tablet",
+					Name:  "tablet0",
+					Bus:   "virtio",
+					Model: "virtio-non-transitional",
+				}, 0, []string{}, "Expect no errors"),
+			table.Entry("and reject input with an unknown model",
+				v1.Input{
+					Type:  "tablet",
+					Name:  "tablet0",
+					Bus:   "virtio",
+					Model: "virtio-legacy",
+				}, 1, []string{"fake.domain.devices.inputs[0].model"}, "Expect model error"),
+			table.Entry("and reject input with a model on the usb bus",
+				v1.Input{
+					Type:  "tablet",
+					Name:  "tablet0",
+					Bus:   "usb",
+					Model: "virtio",
+				}, 1, []string{"fake.domain.devices.inputs[0].model"}, "Expect model error"),
+		)
+
+		table.DescribeTable("should verify the USB controller model", func(model string, expectedErrors int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.USBControllerModel = model
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedErrors))
+			if expectedErrors > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.usbControllerModel"))
+			}
+		},
+			table.Entry("and accept no model", "", 0),
+			table.Entry("and accept qemu-xhci", "qemu-xhci", 0),
+			table.Entry("and accept nec-xhci", "nec-xhci", 0),
+			table.Entry("and reject piix3-uhci", "piix3-uhci", 1),
 		)
 
 		It("should reject negative requests.cpu value", func() {
@@ -1768,6 +1976,271 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(2))
 		})
 
+		table.DescribeTable("should validate the DHCP boot options", func(iface *v1.Interface, dhcpOptions v1.DHCPOptions, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = &dhcpOptions
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf(expectedFields))
+		},
+			table.Entry("accept an IPv4 next server", v1.DefaultBridgeNetworkInterface(),
+				v1.DHCPOptions{NextServer: "192.168.1.10", BootFileName: "pxelinux.0"}),
+			table.Entry("reject a next server which is not an IPv4 address", v1.DefaultBridgeNetworkInterface(),
+				v1.DHCPOptions{NextServer: "tftp.example.com"},
+				"fake.domain.devices.interfaces[0].dhcpOptions.nextServer"),
+			table.Entry("accept the TFTP proxy on a masquerade interface", v1.DefaultMasqueradeNetworkInterface(),
+				v1.DHCPOptions{TFTPServerName: "tftp.example.com", TFTPProxy: true}),
+			table.Entry("reject the TFTP proxy on a bridge interface", v1.DefaultBridgeNetworkInterface(),
+				v1.DHCPOptions{NextServer: "192.168.1.10", TFTPProxy: true},
+				"fake.domain.devices.interfaces[0].dhcpOptions.tftpProxy"),
+			table.Entry("reject the TFTP proxy without a server to relay to", v1.DefaultMasqueradeNetworkInterface(),
+				v1.DHCPOptions{TFTPProxy: true},
+				"fake.domain.devices.interfaces[0].dhcpOptions.tftpProxy"),
+		)
+
+		table.DescribeTable("should validate the bridge guest address", func(macAddress string, guestAddress v1.InterfaceBridgeGuestAddress, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = macAddress
+			vmi.Spec.Domain.Devices.Interfaces[0].Bridge.GuestAddress = &guestAddress
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("accept an address served over DHCP", "de:ad:00:00:be:af",
+				v1.InterfaceBridgeGuestAddress{IP: "192.168.1.10/24", Gateway: "192.168.1.1", ServeDHCP: true}),
+			table.Entry("accept an IPv6 address without DHCP", "de:ad:00:00:be:af",
+				v1.InterfaceBridgeGuestAddress{IP: "fd10::10/64"}),
+			table.Entry("reject a missing MAC address", "",
+				v1.InterfaceBridgeGuestAddress{IP: "192.168.1.10/24"},
+				"fake.domain.devices.interfaces[0].bridge.guestAddress"),
+			table.Entry("reject an address without prefix length", "de:ad:00:00:be:af",
+				v1.InterfaceBridgeGuestAddress{IP: "192.168.1.10"},
+				"fake.domain.devices.interfaces[0].bridge.guestAddress.ip"),
+			table.Entry("reject an IPv6 address served over DHCP", "de:ad:00:00:be:af",
+				v1.InterfaceBridgeGuestAddress{IP: "fd10::10/64", ServeDHCP: true},
+				"fake.domain.devices.interfaces[0].bridge.guestAddress.serveDHCP"),
+			table.Entry("reject a gateway without DHCP", "de:ad:00:00:be:af",
+				v1.InterfaceBridgeGuestAddress{IP: "192.168.1.10/24", Gateway: "192.168.1.1"},
+				"fake.domain.devices.interfaces[0].bridge.guestAddress.gateway"),
+			table.Entry("reject an invalid gateway", "de:ad:00:00:be:af",
+				v1.InterfaceBridgeGuestAddress{IP: "192.168.1.10/24", Gateway: "gateway", ServeDHCP: true},
+				"fake.domain.devices.interfaces[0].bridge.guestAddress.gateway"),
+		)
+
+		table.DescribeTable("should validate the masquerade hairpin mode", func(hairpin v1.MasqueradeHairpinMode, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].Masquerade.Hairpin = hairpin
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].masquerade.hairpin"))
+			}
+		},
+			table.Entry("accept the default", v1.MasqueradeHairpinMode(""), 0),
+			table.Entry("accept loopback", v1.MasqueradeHairpinLoopback, 0),
+			table.Entry("accept none", v1.MasqueradeHairpinNone, 0),
+			table.Entry("accept full", v1.MasqueradeHairpinFull, 0),
+			table.Entry("reject an unknown mode", v1.MasqueradeHairpinMode("partial"), 1),
+		)
+
+		table.DescribeTable("should validate the interface roles", func(roles []v1.InterfaceRole, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].Roles = roles
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept no roles", nil, ""),
+			table.Entry("accept all known roles", []v1.InterfaceRole{v1.InterfaceRoleManagement, v1.InterfaceRoleStorage, v1.InterfaceRoleWorkload}, ""),
+			table.Entry("reject an unknown role", []v1.InterfaceRole{v1.InterfaceRoleStorage, "backup"}, "fake.domain.devices.interfaces[0].roles[1]"),
+			table.Entry("reject a duplicated role", []v1.InterfaceRole{v1.InterfaceRoleWorkload, v1.InterfaceRoleWorkload}, "fake.domain.devices.interfaces[0].roles[1]"),
+		)
+
+		uint32Ptr := func(v uint32) *uint32 { return &v }
+		table.DescribeTable("should validate the interface ring sizes and offloads", func(iface v1.Interface, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				var fields []string
+				for _, cause := range causes {
+					fields = append(fields, cause.Field)
+				}
+				Expect(fields).To(ContainElement(expectedField))
+			}
+		},
+			table.Entry("accept power of 2 ring sizes",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, RxQueueSize: uint32Ptr(1024), TxQueueSize: uint32Ptr(256)}, ""),
+			table.Entry("reject a ring size which is not a power of 2",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, RxQueueSize: uint32Ptr(768)}, "fake.domain.devices.interfaces[0].rxQueueSize"),
+			table.Entry("reject a ring size out of range",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, TxQueueSize: uint32Ptr(2048)}, "fake.domain.devices.interfaces[0].txQueueSize"),
+			table.Entry("reject ring sizes on non virtio interfaces",
+				v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, RxQueueSize: uint32Ptr(512)}, "fake.domain.devices.interfaces[0].rxQueueSize"),
+			table.Entry("accept offloads on masquerade interfaces",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, Offloads: &v1.InterfaceOffloads{TSO: pointer.BoolPtr(false), GRO: pointer.BoolPtr(false)}}, ""),
+			table.Entry("reject offloads on slirp interfaces",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}, Offloads: &v1.InterfaceOffloads{GSO: pointer.BoolPtr(false)}}, "fake.domain.devices.interfaces[0].offloads"),
+			table.Entry("accept the low-latency profile on bridge interfaces",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, LatencyProfile: v1.InterfaceLatencyProfileLowLatency}, ""),
+			table.Entry("reject the low-latency profile on slirp interfaces",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}, LatencyProfile: v1.InterfaceLatencyProfileLowLatency}, "fake.domain.devices.interfaces[0].latencyProfile"),
+			table.Entry("reject the low-latency profile on non virtio interfaces",
+				v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, LatencyProfile: v1.InterfaceLatencyProfileLowLatency}, "fake.domain.devices.interfaces[0].latencyProfile"),
+			table.Entry("reject an unknown latency profile",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, LatencyProfile: "realtime"}, "fake.domain.devices.interfaces[0].latencyProfile"),
+			table.Entry("accept packed virtqueues and zero copy transmission on virtio interfaces",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, PackedRing: pointer.BoolPtr(true), VhostZeroCopyTX: pointer.BoolPtr(true)}, ""),
+			table.Entry("reject packed virtqueues on non virtio interfaces",
+				v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, PackedRing: pointer.BoolPtr(true)}, "fake.domain.devices.interfaces[0].packedRing"),
+			table.Entry("reject zero copy transmission on non virtio interfaces",
+				v1.Interface{Name: "default", Model: "rtl8139", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, VhostZeroCopyTX: pointer.BoolPtr(true)}, "fake.domain.devices.interfaces[0].vhostZeroCopyTX"),
+			table.Entry("reject zero copy transmission on slirp interfaces",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}, VhostZeroCopyTX: pointer.BoolPtr(true)}, "fake.domain.devices.interfaces[0].vhostZeroCopyTX"),
+		)
+
+		table.DescribeTable("should validate the number of network interface queues", func(multiQueue *bool, queues *uint32, expectedMessage string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = multiQueue
+			vmi.Spec.Domain.Devices.NetworkInterfaceQueues = queues
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedMessage == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.networkInterfaceQueues"))
+				Expect(causes[0].Message).To(Equal(expectedMessage))
+			}
+		},
+			table.Entry("accept the default", pointer.BoolPtr(true), nil, ""),
+			table.Entry("accept the maximum", pointer.BoolPtr(true), uint32Ptr(256), ""),
+			table.Entry("reject queues without multiqueue", nil, uint32Ptr(4),
+				"fake.domain.devices.networkInterfaceQueues requires fake.domain.devices.networkInterfaceMultiqueue"),
+			table.Entry("reject zero queues", pointer.BoolPtr(true), uint32Ptr(0),
+				"fake.domain.devices.networkInterfaceQueues must be between 1 and 256, the maximum number of queues of a tap device"),
+			table.Entry("reject more queues than a tap device has", pointer.BoolPtr(true), uint32Ptr(257),
+				"fake.domain.devices.networkInterfaceQueues must be between 1 and 256, the maximum number of queues of a tap device"),
+		)
+
+		table.DescribeTable("should validate the receive side scaling of interfaces", func(iface v1.Interface, multiQueue *bool, expectedMessage string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = multiQueue
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedMessage == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].rss"))
+				Expect(causes[0].Message).To(ContainSubstring(expectedMessage))
+			}
+		},
+			table.Entry("accept rss on multiqueue virtio interfaces",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, RSS: &v1.InterfaceRSS{HashReport: pointer.BoolPtr(true)}}, pointer.BoolPtr(true), ""),
+			table.Entry("reject rss without multiqueue",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, RSS: &v1.InterfaceRSS{}}, nil, "requires fake.domain.devices.networkInterfaceMultiqueue"),
+			table.Entry("reject rss with disabled multiqueue",
+				v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, RSS: &v1.InterfaceRSS{}}, pointer.BoolPtr(false), "requires fake.domain.devices.networkInterfaceMultiqueue"),
+			table.Entry("reject rss on non virtio interfaces",
+				v1.Interface{Name: "default", Model: "e1000", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, RSS: &v1.InterfaceRSS{}}, nil, "is only supported on virtio interfaces"),
+		)
+
+		table.DescribeTable("should validate the interface mirror", func(binding v1.InterfaceBindingMethod, mirror *v1.InterfaceMirror, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", InterfaceBindingMethod: binding, Mirror: mirror}}
+			vmi.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "monitor", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "monitor-net"}}},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				var fields []string
+				for _, cause := range causes {
+					fields = append(fields, cause.Field)
+				}
+				Expect(fields).To(ContainElement(expectedField))
+			}
+		},
+			table.Entry("accept a mirror of a bridge interface to an unused multus network",
+				v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, &v1.InterfaceMirror{Network: "monitor", Direction: v1.MirrorDirectionRX}, ""),
+			table.Entry("accept a mirror of a masquerade interface in both directions",
+				v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, &v1.InterfaceMirror{Network: "monitor"}, ""),
+			table.Entry("reject a mirror of a slirp interface",
+				v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}, &v1.InterfaceMirror{Network: "monitor"}, "fake.domain.devices.interfaces[0].mirror"),
+			table.Entry("reject an unknown direction",
+				v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, &v1.InterfaceMirror{Network: "monitor", Direction: "ingress"}, "fake.domain.devices.interfaces[0].mirror.direction"),
+			table.Entry("reject a mirror to an unknown network",
+				v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, &v1.InterfaceMirror{Network: "unknown"}, "fake.domain.devices.interfaces[0].mirror.network"),
+			table.Entry("reject a mirror to a network which is used by an interface",
+				v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, &v1.InterfaceMirror{Network: "default"}, "fake.domain.devices.interfaces[0].mirror.network"),
+		)
+
+		table.DescribeTable("should validate the vm network CIDRs of the pod network", func(pod v1.PodNetwork, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{{Name: "default", NetworkSource: v1.NetworkSource{Pod: &pod}}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept IPv4 and IPv6 CIDRs", v1.PodNetwork{VMNetworkCIDR: "10.1.0.0/24", VMIPv6NetworkCIDR: "fd20::/120"}, ""),
+			table.Entry("reject an invalid IPv4 CIDR", v1.PodNetwork{VMNetworkCIDR: "10.1.0.0"}, "fake.networks[0].pod.vmNetworkCIDR"),
+			table.Entry("reject an IPv6 CIDR as IPv4 CIDR", v1.PodNetwork{VMNetworkCIDR: "fd20::/120"}, "fake.networks[0].pod.vmNetworkCIDR"),
+			table.Entry("reject an IPv4 CIDR without room for the VM", v1.PodNetwork{VMNetworkCIDR: "10.1.0.0/31"}, "fake.networks[0].pod.vmNetworkCIDR"),
+			table.Entry("reject an IPv4 CIDR as IPv6 CIDR", v1.PodNetwork{VMIPv6NetworkCIDR: "10.1.0.0/24"}, "fake.networks[0].pod.vmIPv6NetworkCIDR"),
+			table.Entry("reject an IPv6 CIDR without room for the VM", v1.PodNetwork{VMIPv6NetworkCIDR: "fd20::/127"}, "fake.networks[0].pod.vmIPv6NetworkCIDR"),
+		)
+
+		It("should reject a network without interface which is not a mirror target", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "monitor", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "monitor-net"}}},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.networks[1].name"))
+		})
+
 		It("should accept valid DHCPPrivateOptions", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -2067,6 +2540,47 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(0))
 		})
+		It("should accept permitted NVMe namespaces", func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.HostDevicesGate}
+			kvConfig.Spec.Configuration.PermittedHostDevices = &v1.PermittedHostDevices{
+				NvmeNamespaces: []v1.NvmeNamespaceHostDevice{
+					{
+						PCIVendorSelector: "144D:A824",
+						ResourceName:      "example.org/nvme",
+					},
+				},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{
+				v1.HostDevice{
+					Name:       "nvme1",
+					DeviceName: "example.org/nvme",
+				},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(0))
+		})
+		It("should reject VMIs referencing a CPU baseline without computed CPU model", func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.CPUBaselines = []v1.CPUBaseline{
+				{
+					Name:         "rack1",
+					NodeSelector: map[string]string{"pool": "rack1"},
+				},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.CPU = &v1.CPU{Model: "rack1"}
+
+			causes := validateCPUBaselineExpanded(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake"))
+
+			vmi.Spec.Domain.CPU.Model = "Haswell"
+			Expect(validateCPUBaselineExpanded(k8sfield.NewPath("fake"), &vmi.Spec, config)).To(BeEmpty())
+		})
 		table.DescribeTable("Should accept valid DNSPolicy and DNSConfig",
 			func(dnsPolicy k8sv1.DNSPolicy, dnsConfig *k8sv1.PodDNSConfig) {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -2133,6 +2647,180 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				[]string{fmt.Sprintf("must provide `dnsConfig` when `dnsPolicy` is %s", k8sv1.DNSNone)}),
 		)
 	})
+	Context("with network attachment definitions of other namespaces", func() {
+		newAdmissionReview := func(vmi *v1.VirtualMachineInstance) *v1beta1.AdmissionReview {
+			vmiBytes, _ := json.Marshal(vmi)
+			return &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Namespace: "tenant",
+					Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+		}
+		newVMIWithNetwork := func(networkName string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMIWithNS("tenant", "testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name: "provider",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Bridge: &v1.InterfaceBridge{},
+				},
+			}}
+			vmi.Spec.Networks = []v1.Network{{
+				Name: "provider",
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: networkName},
+				},
+			}}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			vmiCreateAdmitter.networkGetFunc = func(namespace, name string) (*networkv1.NetworkAttachmentDefinition, error) {
+				return nil, nil
+			}
+		})
+
+		AfterEach(func() {
+			vmiCreateAdmitter.networkAuthFunc = nil
+			vmiCreateAdmitter.networkGetFunc = nil
+		})
+
+		It("should allow granted definitions", func() {
+			vmiCreateAdmitter.networkAuthFunc = func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+				Expect(nadNamespace).To(Equal("shared"))
+				Expect(nadName).To(Equal("provider-net"))
+				Expect(saNamespace).To(Equal("tenant"))
+				Expect(saName).To(Equal("default"))
+				return true, "", nil
+			}
+			resp := vmiCreateAdmitter.Admit(newAdmissionReview(newVMIWithNetwork("shared/provider-net")))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject definitions which were not granted", func() {
+			vmiCreateAdmitter.networkAuthFunc = func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+				return false, "no permission", nil
+			}
+			resp := vmiCreateAdmitter.Admit(newAdmissionReview(newVMIWithNetwork("shared/provider-net")))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.networks[0].multus.networkName"))
+		})
+
+		It("should reject the VMI when the authorization can't be reviewed", func() {
+			vmiCreateAdmitter.networkAuthFunc = func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+				return false, "", fmt.Errorf("unavailable")
+			}
+			resp := vmiCreateAdmitter.Admit(newAdmissionReview(newVMIWithNetwork("shared/provider-net")))
+			Expect(resp.Allowed).To(BeFalse())
+		})
+	})
+
+	Context("with network attachment definitions", func() {
+		newAdmissionReview := func(vmi *v1.VirtualMachineInstance) *v1beta1.AdmissionReview {
+			vmiBytes, _ := json.Marshal(vmi)
+			return &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Namespace: "tenant",
+					Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+		}
+		newVMIWithNetwork := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMIWithNS("tenant", "testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name: "provider",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Bridge: &v1.InterfaceBridge{},
+				},
+			}}
+			vmi.Spec.Networks = []v1.Network{{
+				Name: "provider",
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: "provider-net"},
+				},
+			}}
+			return vmi
+		}
+		withConfig := func(config string) func(namespace, name string) (*networkv1.NetworkAttachmentDefinition, error) {
+			return func(namespace, name string) (*networkv1.NetworkAttachmentDefinition, error) {
+				Expect(namespace).To(Equal("tenant"))
+				Expect(name).To(Equal("provider-net"))
+				return &networkv1.NetworkAttachmentDefinition{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+					Spec:       networkv1.NetworkAttachmentDefinitionSpec{Config: config},
+				}, nil
+			}
+		}
+
+		AfterEach(func() {
+			vmiCreateAdmitter.networkGetFunc = nil
+		})
+
+		It("should allow valid definitions", func() {
+			vmiCreateAdmitter.networkGetFunc = withConfig(`{"cniVersion": "0.3.1", "type": "bridge"}`)
+			resp := vmiCreateAdmitter.Admit(newAdmissionReview(newVMIWithNetwork()))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should allow definitions which do not exist yet", func() {
+			vmiCreateAdmitter.networkGetFunc = func(namespace, name string) (*networkv1.NetworkAttachmentDefinition, error) {
+				return nil, nil
+			}
+			resp := vmiCreateAdmitter.Admit(newAdmissionReview(newVMIWithNetwork()))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject definitions with an invalid CNI configuration", func() {
+			vmiCreateAdmitter.networkGetFunc = withConfig(`{"cniVersion": "0.3.1"}`)
+			resp := vmiCreateAdmitter.Admit(newAdmissionReview(newVMIWithNetwork()))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.networks[0].multus.networkName"))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("tenant/provider-net"))
+		})
+
+		It("should reject the VMI when the definition can't be fetched", func() {
+			vmiCreateAdmitter.networkGetFunc = func(namespace, name string) (*networkv1.NetworkAttachmentDefinition, error) {
+				return nil, fmt.Errorf("unavailable")
+			}
+			resp := vmiCreateAdmitter.Admit(newAdmissionReview(newVMIWithNetwork()))
+			Expect(resp.Allowed).To(BeFalse())
+		})
+	})
+
+	Context("with allowEmulation", func() {
+		setEmulationPolicy := func(policy v1.EmulationPolicy) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.EmulationPolicy = policy
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+		}
+
+		table.DescribeTable("should validate the emulation policy", func(policy v1.EmulationPolicy, allowEmulation bool, valid bool) {
+			setEmulationPolicy(policy)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.AllowEmulation = pointer.BoolPtr(allowEmulation)
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if valid {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.allowEmulation"))
+			}
+		},
+			table.Entry("and reject opting in with policy Never", v1.EmulationPolicyNever, true, false),
+			table.Entry("and accept opting out with policy Never", v1.EmulationPolicyNever, false, true),
+			table.Entry("and accept opting in with policy OptIn", v1.EmulationPolicyOptIn, true, true),
+			table.Entry("and accept opting out with policy Always", v1.EmulationPolicyAlways, false, true),
+		)
+	})
+
 	Context("with cpu pinning", func() {
 		var vmi *v1.VirtualMachineInstance
 		BeforeEach(func() {
@@ -2154,6 +2842,41 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.cpu.isolateEmulatorThread"))
 		})
+		Context("with guest NUMA mapping passthrough", func() {
+			BeforeEach(func() {
+				vmi.Spec.Domain.CPU.Cores = 2
+				vmi.Spec.Domain.CPU.NUMA = &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}}
+				vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+					k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+				}
+				vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			})
+			It("should accept specs with dedicated cpus and hugepages", func() {
+				enableFeatureGate(virtconfig.NUMAFeatureGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+			It("should reject specs if the NUMA feature gate is disabled", func() {
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(ContainSubstring("NUMA feature gate"))
+				Expect(causes[0].Field).To(Equal("fake.domain.cpu.numa.guestMappingPassthrough"))
+			})
+			It("should reject specs without hugepages", func() {
+				enableFeatureGate(virtconfig.NUMAFeatureGate)
+				vmi.Spec.Domain.Memory = nil
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(ContainSubstring("fake.domain.memory.hugepages"))
+			})
+			It("should reject specs without DedicatedCPUPlacement", func() {
+				enableFeatureGate(virtconfig.NUMAFeatureGate)
+				vmi.Spec.Domain.CPU.DedicatedCPUPlacement = false
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(ContainSubstring("fake.domain.cpu.dedicatedCpuPlacement"))
+			})
+		})
 		It("should reject specs without inconsistent cpu reqirements", func() {
 			vmi.Spec.Domain.CPU.Cores = 4
 			vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
@@ -2814,6 +3537,47 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(0))
 		})
 
+		table.DescribeTable("should validate the WWN", func(wwn string, target *v1.DiskTarget, expectedFields ...string) {
+			disks := []v1.Disk{{
+				Name: "testdisk",
+				WWN:  wwn,
+				DiskDevice: v1.DiskDevice{
+					Disk: target,
+				},
+			}}
+
+			causes := validateDisks(k8sfield.NewPath("fake"), disks)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("and accept a WWN on the scsi bus", "5000c500a1b2c3d4", &v1.DiskTarget{Bus: "scsi"}),
+			table.Entry("and accept a WWN with the 0x prefix on the sata bus", "0x5000C500A1B2C3D4", &v1.DiskTarget{Bus: "sata"}),
+			table.Entry("and reject a WWN with invalid digits", "5000c500a1b2c3dx", &v1.DiskTarget{Bus: "scsi"}, "fake[0].wwn"),
+			table.Entry("and reject a WWN of the wrong length", "5000c500", &v1.DiskTarget{Bus: "scsi"}, "fake[0].wwn"),
+			table.Entry("and reject a WWN on the virtio bus", "5000c500a1b2c3d4", &v1.DiskTarget{Bus: "virtio"}, "fake[0].wwn"),
+		)
+
+	})
+
+	Context("with channels", func() {
+		It("should accept valid channels", func() {
+			channels := []v1.Channel{{Name: "org.example.agent.0"}, {Name: "org.example.metadata_1"}}
+			causes := validateChannels(k8sfield.NewPath("fake"), channels)
+			Expect(causes).To(BeEmpty())
+		})
+
+		table.DescribeTable("should reject invalid channels", func(channels []v1.Channel, field string) {
+			causes := validateChannels(k8sfield.NewPath("fake"), channels)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(field))
+		},
+			table.Entry("without a name", []v1.Channel{{Name: ""}}, "fake[0].name"),
+			table.Entry("with an invalid name", []v1.Channel{{Name: "org/example"}}, "fake[0].name"),
+			table.Entry("with the name of the guest agent channel", []v1.Channel{{Name: "org.qemu.guest_agent.0"}}, "fake[0].name"),
+			table.Entry("with the same name twice", []v1.Channel{{Name: "org.example.agent.0"}, {Name: "org.example.agent.0"}}, "fake[1].name"),
+		)
 	})
 
 	Context("with volume", func() {
@@ -2852,6 +3616,179 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(BeEmpty())
 		})
 
+		It("should reject vhostUserBlk volumes if the feature gate is not enabled", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "spdk",
+				VolumeSource: v1.VolumeSource{
+					VhostUserBlk: &v1.VhostUserBlkSource{ClaimName: "spdk", Socket: "vhost.0"},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake[0].vhostUserBlk"))
+		})
+
+		table.DescribeTable("should validate vhostUserBlk volumes", func(source *v1.VhostUserBlkSource, field string) {
+			enableFeatureGate(virtconfig.VhostUserBlkGate)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "spdk",
+				VolumeSource: v1.VolumeSource{VhostUserBlk: source},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			if field == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(field))
+			}
+		},
+			table.Entry("and accept a socket in the volume", &v1.VhostUserBlkSource{ClaimName: "spdk", Socket: "sockets/vhost.0"}, ""),
+			table.Entry("and reject a missing claim name", &v1.VhostUserBlkSource{Socket: "vhost.0"}, "fake[0].vhostUserBlk.claimName"),
+			table.Entry("and reject a missing socket", &v1.VhostUserBlkSource{ClaimName: "spdk"}, "fake[0].vhostUserBlk.socket"),
+			table.Entry("and reject an absolute socket path", &v1.VhostUserBlkSource{ClaimName: "spdk", Socket: "/var/tmp/vhost.0"}, "fake[0].vhostUserBlk.socket"),
+			table.Entry("and reject a socket outside of the volume", &v1.VhostUserBlkSource{ClaimName: "spdk", Socket: "../vhost.0"}, "fake[0].vhostUserBlk.socket"),
+		)
+
+		table.DescribeTable("should validate the disks of vhostUserBlk volumes", func(disk v1.Disk, field string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			disk.Name = "spdk"
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{disk}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "spdk",
+				VolumeSource: v1.VolumeSource{
+					VhostUserBlk: &v1.VhostUserBlkSource{ClaimName: "spdk", Socket: "vhost.0"},
+				},
+			}}
+
+			causes := validateVhostUserBlkDisks(k8sfield.NewPath("fake"), &vmi.Spec)
+			if field == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(field))
+			}
+		},
+			table.Entry("and accept a virtio disk", v1.Disk{DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}}, ""),
+			table.Entry("and reject a sata disk", v1.Disk{DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "sata"}}}, "fake.domain.devices.disks[0].disk.bus"),
+			table.Entry("and reject a read-only disk", v1.Disk{DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio", ReadOnly: true}}}, "fake.domain.devices.disks[0].disk.readonly"),
+			table.Entry("and reject a lun", v1.Disk{DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: "virtio"}}}, "fake.domain.devices.disks[0]"),
+			table.Entry("and reject a serial", v1.Disk{Serial: "spdk"}, "fake.domain.devices.disks[0].serial"),
+			table.Entry("and reject a cache mode", v1.Disk{Cache: v1.CacheNone}, "fake.domain.devices.disks[0].cache"),
+			table.Entry("and reject an io mode", v1.Disk{IO: v1.IONative}, "fake.domain.devices.disks[0].io"),
+		)
+
+		table.DescribeTable("should validate the checksum of containerDisks", func(checksum string, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "fake", Checksum: checksum},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake[0].containerDisk.checksum"))
+			}
+		},
+			table.Entry("without checksum", "", 0),
+			table.Entry("with a sha256 digest", "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", 0),
+			table.Entry("with a plain digest", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", 1),
+			table.Entry("with an unsupported algorithm", "md5:d41d8cd98f00b204e9800998ecf8427e", 1),
+		)
+
+		table.DescribeTable("should validate the checksum of DataVolumes", func(checksum string, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					DataVolume: &v1.DataVolumeSource{Name: "fake", Checksum: checksum},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake[0].dataVolume.checksum"))
+			}
+		},
+			table.Entry("without checksum", "", 0),
+			table.Entry("with a sha256 digest", "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", 0),
+			table.Entry("with a plain digest", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", 1),
+		)
+
+		table.DescribeTable("should validate the ephemeral image of volumes", func(volumeSource v1.VolumeSource, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "testdisk",
+				VolumeSource: volumeSource,
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("with a compressed qcow2 containerDisk",
+				v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake", EphemeralImage: &v1.EphemeralImage{Compressed: true}}},
+			),
+			table.Entry("with a preallocated raw containerDisk",
+				v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake", EphemeralImage: &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw, Preallocation: v1.EphemeralImagePreallocationFull}}},
+			),
+			table.Entry("with an unknown format",
+				v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake", EphemeralImage: &v1.EphemeralImage{Format: "vmdk"}}},
+				"fake[0].containerDisk.ephemeralImage.format",
+			),
+			table.Entry("with an unknown preallocation mode",
+				v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake", EphemeralImage: &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw, Preallocation: "metadata"}}},
+				"fake[0].containerDisk.ephemeralImage.preallocation",
+			),
+			table.Entry("with a preallocated qcow2 image",
+				v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{EphemeralImage: &v1.EphemeralImage{Preallocation: v1.EphemeralImagePreallocationFalloc}}},
+				"fake[0].ephemeral.ephemeralImage.preallocation",
+			),
+			table.Entry("with a compressed raw image",
+				v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{EphemeralImage: &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw, Compressed: true}}},
+				"fake[0].ephemeral.ephemeralImage.compressed",
+			),
+		)
+
+		table.DescribeTable("should validate the encryption of volumes", func(volumeSource v1.VolumeSource, encryption *v1.VolumeEncryption, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "testdisk",
+				VolumeSource: volumeSource,
+				Encryption:   encryption,
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("with an encrypted PVC",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"}},
+				&v1.VolumeEncryption{SecretRef: &k8sv1.LocalObjectReference{Name: "luks-secret"}},
+			),
+			table.Entry("with an encrypted PVC without secret",
+				v1.VolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"}},
+				&v1.VolumeEncryption{},
+				"fake[0].encryption.secretRef.name",
+			),
+			table.Entry("with an encrypted containerDisk",
+				v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake"}},
+				&v1.VolumeEncryption{SecretRef: &k8sv1.LocalObjectReference{Name: "luks-secret"}},
+				"fake[0].encryption",
+			),
+		)
+
 		It("should reject CloudInitNoCloud volume if either userData or networkData is missing", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -2918,6 +3855,138 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 
 	})
 
+	table.DescribeTable("should validate the QoS class", func(qosClass v1.QoSClass, expectedCauses int) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.QoSClass = qosClass
+
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+		Expect(causes).To(HaveLen(expectedCauses))
+		if expectedCauses > 0 {
+			Expect(causes[0].Field).To(Equal("fake.qosClass"))
+		}
+	},
+		table.Entry("accept no class", v1.QoSClass(""), 0),
+		table.Entry("accept gold", v1.QoSClassGold, 0),
+		table.Entry("accept silver", v1.QoSClassSilver, 0),
+		table.Entry("accept bronze", v1.QoSClassBronze, 0),
+		table.Entry("reject unknown classes", v1.QoSClass("platinum"), 1),
+	)
+
+	It("should accept the QoS classes configured in the KubeVirt CR", func() {
+		kvConfig := kv.DeepCopy()
+		kvConfig.Spec.Configuration.QoSClasses = []v1.QoSClassConfiguration{{
+			Name:        "platinum",
+			BlkioWeight: 1000,
+			NetworkRate: resource.MustParse("1G"),
+			NetworkCeil: resource.MustParse("10G"),
+		}}
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+		defer disableFeatureGates()
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.QoSClass = "platinum"
+		Expect(ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)).To(BeEmpty())
+
+		vmi.Spec.QoSClass = v1.QoSClassGold
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal("fake.qosClass"))
+	})
+
+	table.DescribeTable("should validate the firmware identity policy", func(policy v1.FirmwareIdentityPolicy, expectedCauses int) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Firmware = &v1.Firmware{IdentityPolicy: policy}
+
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+		Expect(causes).To(HaveLen(expectedCauses))
+		if expectedCauses > 0 {
+			Expect(causes[0].Field).To(Equal("fake.domain.firmware.identityPolicy"))
+		}
+	},
+		table.Entry("accept no policy", v1.FirmwareIdentityPolicy(""), 0),
+		table.Entry("accept Keep", v1.FirmwareIdentityPolicyKeep, 0),
+		table.Entry("accept RegenerateOnClone", v1.FirmwareIdentityPolicyRegenerateOnClone, 0),
+		table.Entry("reject unknown policies", v1.FirmwareIdentityPolicy("Random"), 1),
+	)
+
+	table.DescribeTable("should validate the guest OS", func(guestOS v1.GuestOS, expectedCauses int) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.GuestOS = guestOS
+
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+		Expect(causes).To(HaveLen(expectedCauses))
+		if expectedCauses > 0 {
+			Expect(causes[0].Field).To(Equal("fake.guestOS"))
+		}
+	},
+		table.Entry("accept no guest OS", v1.GuestOS(""), 0),
+		table.Entry("accept windows", v1.GuestOSWindows2k19, 0),
+		table.Entry("accept rhel", v1.GuestOSRHEL9, 0),
+		table.Entry("reject unknown guest OSes", v1.GuestOS("plan9"), 1),
+	)
+
+	table.DescribeTable("should validate the virtio-win driver disk", func(image string, expectedCauses int) {
+		kvConfig := kv.DeepCopy()
+		kvConfig.Spec.Configuration.VirtioWinImage = image
+		testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.AutoattachVirtioWinDriverDisk = pointer.BoolPtr(true)
+
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+		Expect(causes).To(HaveLen(expectedCauses))
+		if expectedCauses > 0 {
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.autoattachVirtioWinDriverDisk"))
+		}
+	},
+		table.Entry("accept it with a configured image", "registry:5000/kubevirt/virtio-container-disk:devel", 0),
+		table.Entry("reject it without a configured image", "", 1),
+	)
+
+	table.DescribeTable("should validate the metadata service", func(iface *v1.Interface, expectedCauses int) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.MetadataService = &v1.MetadataService{}
+		if iface != nil {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		}
+
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+		Expect(causes).To(HaveLen(expectedCauses))
+		if expectedCauses > 0 {
+			Expect(causes[0].Field).To(Equal("fake.metadataService"))
+		}
+	},
+		table.Entry("accept a masquerade interface", v1.DefaultMasqueradeNetworkInterface(), 0),
+		table.Entry("reject a bridge interface", v1.DefaultBridgeNetworkInterface(), 1),
+		table.Entry("reject no interface", nil, 1),
+	)
+
+	Context("with boot menu", func() {
+		It("should accept a timeout of up to 65535 milliseconds", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			timeout := uint32(65535)
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				BootMenu: &v1.BootMenu{Timeout: &timeout},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject a timeout above 65535 milliseconds", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			timeout := uint32(65536)
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				BootMenu: &v1.BootMenu{Timeout: &timeout},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.firmware.bootMenu.timeout"))
+		})
+	})
+
 	Context("with bootloader", func() {
 		It("should accept empty bootloader setting", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -3248,6 +4317,123 @@ var _ = Describe("Function getNumberOfPodInterfaces()", func() {
 		Expect(len(causes)).To(Equal(0))
 	})
 
+	table.DescribeTable("Should validate admission policies", func(policy v1.VMIAdmissionPolicy, field string) {
+		causes := webhooks.ValidateVMIAdmissionPolicies(k8sfield.NewPath("admissionPolicies"), []v1.VMIAdmissionPolicy{policy})
+		if field == "" {
+			Expect(causes).To(BeEmpty())
+			return
+		}
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal(field))
+	},
+		table.Entry("and accept a valid policy", v1.VMIAdmissionPolicy{
+			Name:  "valid",
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain.cpu.cores}", Operator: v1.VMIAdmissionRuleOpGt, Values: []string{"4"}}},
+		}, ""),
+		table.Entry("and reject a policy without name", v1.VMIAdmissionPolicy{
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain.cpu}", Operator: v1.VMIAdmissionRuleOpExists}},
+		}, "admissionPolicies[0].name"),
+		table.Entry("and reject a policy without rules", v1.VMIAdmissionPolicy{
+			Name: "empty",
+		}, "admissionPolicies[0].rules"),
+		table.Entry("and reject an invalid path", v1.VMIAdmissionPolicy{
+			Name:  "path",
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain[", Operator: v1.VMIAdmissionRuleOpExists}},
+		}, "admissionPolicies[0].rules[0].path"),
+		table.Entry("and reject an unknown operator", v1.VMIAdmissionPolicy{
+			Name:  "operator",
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain.cpu}", Operator: "Matches"}},
+		}, "admissionPolicies[0].rules[0].operator"),
+		table.Entry("and reject In without values", v1.VMIAdmissionPolicy{
+			Name:  "in",
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain.machine.type}", Operator: v1.VMIAdmissionRuleOpIn}},
+		}, "admissionPolicies[0].rules[0].values"),
+		table.Entry("and reject Exists with values", v1.VMIAdmissionPolicy{
+			Name:  "exists",
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain.cpu}", Operator: v1.VMIAdmissionRuleOpExists, Values: []string{"x"}}},
+		}, "admissionPolicies[0].rules[0].values"),
+		table.Entry("and reject Gt with an invalid quantity", v1.VMIAdmissionPolicy{
+			Name:  "gt",
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain.cpu.cores}", Operator: v1.VMIAdmissionRuleOpGt, Values: []string{"many"}}},
+		}, "admissionPolicies[0].rules[0].values[0]"),
+	)
+
+	table.DescribeTable("Should validate scoped feature gates", func(featureGate v1.ScopedFeatureGate, field string) {
+		causes := webhooks.ValidateScopedFeatureGates(k8sfield.NewPath("scopedFeatureGates"), []v1.ScopedFeatureGate{featureGate})
+		if field == "" {
+			Expect(causes).To(BeEmpty())
+			return
+		}
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal(field))
+	},
+		table.Entry("and accept a feature gate listing namespaces", v1.ScopedFeatureGate{
+			Name:       virtconfig.MacvtapGate,
+			Namespaces: []string{"network-team"},
+		}, ""),
+		table.Entry("and accept a feature gate selecting namespaces", v1.ScopedFeatureGate{
+			Name:              virtconfig.MacvtapGate,
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "network"}},
+		}, ""),
+		table.Entry("and reject a feature gate without name", v1.ScopedFeatureGate{
+			Namespaces: []string{"network-team"},
+		}, "scopedFeatureGates[0].name"),
+		table.Entry("and reject a feature gate without namespaces", v1.ScopedFeatureGate{
+			Name: virtconfig.MacvtapGate,
+		}, "scopedFeatureGates[0]"),
+		table.Entry("and reject an invalid namespace selector", v1.ScopedFeatureGate{
+			Name: virtconfig.MacvtapGate,
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: "Matches"},
+			}},
+		}, "scopedFeatureGates[0].namespaceSelector"),
+	)
+
+	table.DescribeTable("Should validate QoS classes", func(class v1.QoSClassConfiguration, field string) {
+		classes := []v1.QoSClassConfiguration{{
+			Name:        v1.QoSClassGold,
+			BlkioWeight: 1000,
+			NetworkRate: resource.MustParse("125M"),
+			NetworkCeil: resource.MustParse("1250M"),
+		}, class}
+		causes := webhooks.ValidateQoSClasses(k8sfield.NewPath("qosClasses"), classes)
+		if field == "" {
+			Expect(causes).To(BeEmpty())
+			return
+		}
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal(field))
+	},
+		table.Entry("and accept a valid class", v1.QoSClassConfiguration{
+			Name: "platinum", BlkioWeight: 1000, NetworkRate: resource.MustParse("1G"), NetworkCeil: resource.MustParse("10G"),
+		}, ""),
+		table.Entry("and reject a class without name", v1.QoSClassConfiguration{
+			BlkioWeight: 100, NetworkRate: resource.MustParse("1M"), NetworkCeil: resource.MustParse("1M"),
+		}, "qosClasses[1].name"),
+		table.Entry("and reject a duplicate name", v1.QoSClassConfiguration{
+			Name: v1.QoSClassGold, BlkioWeight: 100, NetworkRate: resource.MustParse("1M"), NetworkCeil: resource.MustParse("1M"),
+		}, "qosClasses[1].name"),
+		table.Entry("and reject a blkio weight below 10", v1.QoSClassConfiguration{
+			Name: "tin", BlkioWeight: 5, NetworkRate: resource.MustParse("1M"), NetworkCeil: resource.MustParse("1M"),
+		}, "qosClasses[1].blkioWeight"),
+		table.Entry("and reject a zero network rate", v1.QoSClassConfiguration{
+			Name: "tin", BlkioWeight: 100, NetworkCeil: resource.MustParse("1M"),
+		}, "qosClasses[1].networkRate"),
+		table.Entry("and reject a network ceil below the rate", v1.QoSClassConfiguration{
+			Name: "tin", BlkioWeight: 100, NetworkRate: resource.MustParse("10M"), NetworkCeil: resource.MustParse("1M"),
+		}, "qosClasses[1].networkCeil"),
+	)
+
+	It("Should reject admission policies with duplicate names", func() {
+		policy := v1.VMIAdmissionPolicy{
+			Name:  "dup",
+			Rules: []v1.VMIAdmissionRule{{Path: "{.spec.domain.cpu}", Operator: v1.VMIAdmissionRuleOpExists}},
+		}
+		causes := webhooks.ValidateVMIAdmissionPolicies(k8sfield.NewPath("admissionPolicies"), []v1.VMIAdmissionPolicy{policy, policy})
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal("admissionPolicies[1].name"))
+	})
+
 	It("Should validate VMIs without HyperV configuration", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		Expect(vmi.Spec.Domain.Features).To(BeNil())
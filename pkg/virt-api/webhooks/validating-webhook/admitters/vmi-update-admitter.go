@@ -53,7 +53,13 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	if !reflect.DeepEqual(newVMI.Spec, oldVMI.Spec) {
 		// Only allow the KubeVirt SA to modify the VMI spec, since that means it went through the sub resource.
 		allowed := webhooks.GetAllowedServiceAccounts()
-		if _, ok := allowed[ar.Request.UserInfo.Username]; ok {
+		if isPortForwardsUpdate(&newVMI.Spec, &oldVMI.Spec) {
+			// the forwarded ports of masquerade interfaces are applied by virt-handler on the fly
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &newVMI.Spec, admitter.ClusterConfig)
+			if len(causes) > 0 {
+				return webhookutils.ToAdmissionResponse(causes)
+			}
+		} else if _, ok := allowed[ar.Request.UserInfo.Username]; ok {
 			hotplugResponse := admitHotplug(newVMI.Spec.Volumes, oldVMI.Spec.Volumes, newVMI.Spec.Domain.Devices.Disks, oldVMI.Spec.Domain.Devices.Disks, oldVMI.Status.VolumeStatus, newVMI, admitter.ClusterConfig)
 			if hotplugResponse != nil {
 				return hotplugResponse
@@ -77,6 +83,23 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	return &reviewResponse
 }
 
+// isPortForwardsUpdate tells if the only difference between the old and the new spec are the
+// forwarded ports of masquerade interfaces.
+func isPortForwardsUpdate(newSpec, oldSpec *v1.VirtualMachineInstanceSpec) bool {
+	if len(newSpec.Domain.Devices.Interfaces) != len(oldSpec.Domain.Devices.Interfaces) {
+		return false
+	}
+
+	spec := oldSpec.DeepCopy()
+	for i, iface := range newSpec.Domain.Devices.Interfaces {
+		if spec.Domain.Devices.Interfaces[i].Masquerade == nil {
+			continue
+		}
+		spec.Domain.Devices.Interfaces[i].Ports = iface.Ports
+	}
+	return reflect.DeepEqual(newSpec, spec)
+}
+
 // admitHotplug compares the old and new volumes and disks, and ensures that they match and are valid.
 func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Disk, volumeStatuses []v1.VolumeStatus, newVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *v1beta1.AdmissionResponse {
 	if len(newVolumes) != len(newDisks) {
@@ -517,4 +517,44 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		table.Entry("Should admit internal sa", "system:serviceaccount:kubevirt:"+rbac.ApiServiceAccountName, BeTrue()),
 		table.Entry("Should reject regular user", "system:serviceaccount:someNamespace:someUser", BeFalse()),
 	)
+
+	table.DescribeTable("should admit changes of the forwarded ports of masquerade interfaces only", func(iface *v1.Interface, update func(vmi *v1.VirtualMachineInstance), allowed bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		updateVmi := vmi.DeepCopy()
+		update(updateVmi)
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:someNamespace:someUser"},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: v1beta1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(Equal(allowed))
+	},
+		table.Entry("add a port", v1.DefaultMasqueradeNetworkInterface(), func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80}}
+		}, true),
+		table.Entry("reject an invalid port", v1.DefaultMasqueradeNetworkInterface(), func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80, Protocol: "SCTP"}}
+		}, false),
+		table.Entry("reject ports on a bridge interface", v1.DefaultBridgeNetworkInterface(), func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80}}
+		}, false),
+		table.Entry("reject other changes along with the ports", v1.DefaultMasqueradeNetworkInterface(), func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80}}
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de:ad:00:00:be:af"
+		}, false),
+	)
 })
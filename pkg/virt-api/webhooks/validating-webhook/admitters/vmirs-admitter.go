@@ -56,7 +56,8 @@ func (admitter *VMIRSAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.Admis
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
-	causes := ValidateVMIRSSpec(k8sfield.NewPath("spec"), &vmirs.Spec, admitter.ClusterConfig)
+	config := webhooks.ClusterConfigForNamespace(admitter.ClusterConfig, ar.Request.Namespace)
+	causes := ValidateVMIRSSpec(k8sfield.NewPath("spec"), &vmirs.Spec, config)
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
@@ -77,6 +78,7 @@ func ValidateVMIRSSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceRepl
 		})
 	}
 	causes = append(causes, ValidateVirtualMachineInstanceSpec(field.Child("template", "spec"), &spec.Template.Spec, config)...)
+	causes = append(causes, validateDataVolumeTemplates(field, spec.DataVolumeTemplates, &spec.Template.Spec)...)
 
 	selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
 	if err != nil {
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 )
@@ -104,6 +105,27 @@ var _ = Describe("Validating VMIRS Admitter", func() {
 		}, []string{
 			"spec.selector",
 		}),
+		table.Entry("with unnamed and unreferenced DataVolume templates", &v1.VirtualMachineInstanceReplicaSet{
+			Spec: v1.VirtualMachineInstanceReplicaSetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "me"},
+				},
+				Template: newVirtualMachineBuilder().WithLabel("match", "me").BuildTemplate(),
+				DataVolumeTemplates: []v1.DataVolumeTemplateSpec{
+					{
+						Spec: cdiv1.DataVolumeSpec{
+							Source: cdiv1.DataVolumeSource{
+								PVC: &cdiv1.DataVolumeSourcePVC{Name: "golden"},
+							},
+							PVC: &k8sv1.PersistentVolumeClaimSpec{},
+						},
+					},
+				},
+			},
+		}, []string{
+			"spec.dataVolumeTemplate[0].name",
+			"spec.dataVolumeTemplate[0]",
+		}),
 	)
 	It("should accept valid vmi spec", func() {
 		vmirs := &v1.VirtualMachineInstanceReplicaSet{
@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2018 Red Hat, Inc.
+ *
+ */
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/net/egress"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const maxNotificationRetries = 20
+
+// VMNotificationHookAdmitter validates VirtualMachineNotificationHooks
+type VMNotificationHookAdmitter struct {
+	Config *virtconfig.ClusterConfig
+}
+
+// NewVMNotificationHookAdmitter creates a VMNotificationHookAdmitter
+func NewVMNotificationHookAdmitter(config *virtconfig.ClusterConfig) *VMNotificationHookAdmitter {
+	return &VMNotificationHookAdmitter{
+		Config: config,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMNotificationHookAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	if ar.Request.Resource.Group != v1.GroupName ||
+		ar.Request.Resource.Resource != "virtualmachinenotificationhooks" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == v1beta1.Create && !webhooks.ClusterConfigForNamespace(admitter.Config, ar.Request.Namespace).NotificationHooksEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("%s feature gate not enabled", virtconfig.NotificationHooksGate))
+	}
+
+	if ar.Request.Operation != v1beta1.Create && ar.Request.Operation != v1beta1.Update {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	hook := &v1.VirtualMachineNotificationHook{}
+	err := json.Unmarshal(ar.Request.Object.Raw, hook)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	causes := validateVMNotificationHookSpec(k8sfield.NewPath("spec"), &hook.Spec)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateVMNotificationHookSpec(field *k8sfield.Path, spec *v1.VirtualMachineNotificationHookSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if u, err := url.Parse(spec.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "url must be an absolute http or https URL",
+			Field:   field.Child("url").String(),
+		})
+	} else if u.User != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "url must not contain credentials, sign the events with signingSecretRef instead",
+			Field:   field.Child("url").String(),
+		})
+	} else if err := egress.CheckHost(u.Hostname()); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("url must point to a public destination: %v", err),
+			Field:   field.Child("url").String(),
+		})
+	}
+
+	for i, event := range spec.Events {
+		switch event {
+		case v1.NotificationEventStarted, v1.NotificationEventMigrated, v1.NotificationEventStopped, v1.NotificationEventCrashed:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("unsupported event %q", event),
+				Field:   field.Child("events").Index(i).String(),
+			})
+		}
+	}
+
+	if spec.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.Selector); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid selector: %v", err),
+				Field:   field.Child("selector").String(),
+			})
+		}
+	}
+
+	if spec.SigningSecretRef != nil && spec.SigningSecretRef.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "missing name",
+			Field:   field.Child("signingSecretRef", "name").String(),
+		})
+	}
+
+	if spec.MaxRetries != nil && (*spec.MaxRetries < 0 || *spec.MaxRetries > maxNotificationRetries) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("maxRetries must be between 0 and %d", maxNotificationRetries),
+			Field:   field.Child("maxRetries").String(),
+		})
+	}
+
+	return causes
+}
@@ -60,7 +60,7 @@ func (admitter *VMRestoreAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
 	}
 
-	if ar.Request.Operation == v1beta1.Create && !admitter.Config.SnapshotEnabled() {
+	if ar.Request.Operation == v1beta1.Create && !webhooks.ClusterConfigForNamespace(admitter.Config, ar.Request.Namespace).SnapshotEnabled() {
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("Snapshot/Restore feature gate not enabled"))
 	}
 
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"k8s.io/api/admission/v1beta1"
 	authv1 "k8s.io/api/authorization/v1"
@@ -37,6 +38,8 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/cron"
+	"kubevirt.io/kubevirt/pkg/util/net/nad"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -47,9 +50,10 @@ var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
 type VMsAdmitter struct {
-	ClusterConfig *virtconfig.ClusterConfig
-	cloneAuthFunc CloneAuthFunc
-	virtClient    kubecli.KubevirtClient
+	ClusterConfig   *virtconfig.ClusterConfig
+	cloneAuthFunc   CloneAuthFunc
+	networkAuthFunc nad.AuthFunc
+	virtClient      kubecli.KubevirtClient
 }
 
 type sarProxy struct {
@@ -69,6 +73,9 @@ func NewVMsAdmitter(clusterConfig *virtconfig.ClusterConfig, client kubecli.Kube
 		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
+		networkAuthFunc: func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+			return nad.CanServiceAccountUseNetwork(proxy, nadNamespace, nadName, saNamespace, saName)
+		},
 	}
 }
 
@@ -91,7 +98,8 @@ func (admitter *VMsAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
-	causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, admitter.ClusterConfig, accountName)
+	config := webhooks.ClusterConfigForNamespace(admitter.ClusterConfig, ar.Request.Namespace)
+	causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, config, accountName)
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
@@ -204,6 +212,18 @@ func (admitter *VMsAdmitter) authorizeVirtualMachineSpec(ar *v1beta1.AdmissionRe
 		}
 	}
 
+	if vm.Spec.Template != nil {
+		namespace := vm.Namespace
+		if namespace == "" {
+			namespace = ar.Namespace
+		}
+		networkCauses, err := authorizeNetworks(k8sfield.NewPath("spec", "template", "spec"), namespace, &vm.Spec.Template.Spec, admitter.networkAuthFunc)
+		if err != nil {
+			return nil, err
+		}
+		causes = append(causes, networkCauses...)
+	}
+
 	return causes, nil
 }
 
@@ -221,38 +241,7 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(field.Child("template", "metadata"), &spec.Template.ObjectMeta, config, accountName)...)
 	causes = append(causes, ValidateVirtualMachineInstanceSpec(field.Child("template", "spec"), &spec.Template.Spec, config)...)
 
-	if len(spec.DataVolumeTemplates) > 0 {
-
-		for idx, dataVolume := range spec.DataVolumeTemplates {
-			if dataVolume.Name == "" {
-				causes = append(causes, metav1.StatusCause{
-					Type:    metav1.CauseTypeFieldValueRequired,
-					Message: fmt.Sprintf("'name' field must not be empty for DataVolumeTemplate entry %s.", field.Child("dataVolumeTemplate").Index(idx).String()),
-					Field:   field.Child("dataVolumeTemplate").Index(idx).Child("name").String(),
-				})
-			}
-
-			dataVolumeRefFound := false
-			for _, volume := range spec.Template.Spec.Volumes {
-				// TODO: Assuming here that PVC name == DV name which might not be the case in the future
-				if volume.VolumeSource.PersistentVolumeClaim != nil && volume.VolumeSource.PersistentVolumeClaim.ClaimName == dataVolume.Name {
-					dataVolumeRefFound = true
-					break
-				} else if volume.VolumeSource.DataVolume != nil && volume.VolumeSource.DataVolume.Name == dataVolume.Name {
-					dataVolumeRefFound = true
-					break
-				}
-			}
-
-			if !dataVolumeRefFound {
-				causes = append(causes, metav1.StatusCause{
-					Type:    metav1.CauseTypeFieldValueRequired,
-					Message: fmt.Sprintf("DataVolumeTemplate entry %s must be referenced in the VMI template's 'volumes' list", field.Child("dataVolumeTemplate").Index(idx).String()),
-					Field:   field.Child("dataVolumeTemplate").Index(idx).String(),
-				})
-			}
-		}
-	}
+	causes = append(causes, validateDataVolumeTemplates(field, spec.DataVolumeTemplates, &spec.Template.Spec)...)
 
 	// Validate RunStrategy
 	if spec.Running != nil && spec.RunStrategy != nil {
@@ -288,6 +277,119 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 		}
 	}
 
+	if spec.Hibernation != nil && !config.HibernationEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Hibernation feature gate is not enabled",
+			Field:   field.Child("hibernation").String(),
+		})
+	}
+
+	if spec.Replication != nil {
+		causes = append(causes, validateReplication(field.Child("replication"), spec.Replication, config)...)
+	}
+
+	if spec.PowerSchedule != nil {
+		causes = append(causes, validatePowerSchedule(field.Child("powerSchedule"), spec.PowerSchedule)...)
+	}
+
+	return causes
+}
+
+// validateDataVolumeTemplates checks that the DataVolume templates are named
+// and referenced by the volumes of the VMI template
+func validateDataVolumeTemplates(field *k8sfield.Path, dataVolumeTemplates []v1.DataVolumeTemplateSpec, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	for idx, dataVolume := range dataVolumeTemplates {
+		if dataVolume.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("'name' field must not be empty for DataVolumeTemplate entry %s.", field.Child("dataVolumeTemplate").Index(idx).String()),
+				Field:   field.Child("dataVolumeTemplate").Index(idx).Child("name").String(),
+			})
+		}
+
+		dataVolumeRefFound := false
+		for _, volume := range spec.Volumes {
+			// TODO: Assuming here that PVC name == DV name which might not be the case in the future
+			if volume.VolumeSource.PersistentVolumeClaim != nil && volume.VolumeSource.PersistentVolumeClaim.ClaimName == dataVolume.Name {
+				dataVolumeRefFound = true
+				break
+			} else if volume.VolumeSource.DataVolume != nil && volume.VolumeSource.DataVolume.Name == dataVolume.Name {
+				dataVolumeRefFound = true
+				break
+			}
+		}
+
+		if !dataVolumeRefFound {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("DataVolumeTemplate entry %s must be referenced in the VMI template's 'volumes' list", field.Child("dataVolumeTemplate").Index(idx).String()),
+				Field:   field.Child("dataVolumeTemplate").Index(idx).String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+func validateReplication(field *k8sfield.Path, replication *v1.VirtualMachineReplication, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	if !config.DiskReplicationEnabled() {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "DiskReplication feature gate is not enabled",
+			Field:   field.String(),
+		}}
+	}
+
+	var causes []metav1.StatusCause
+	if replication.PairingSecretRef == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "replication needs a pairing Secret",
+			Field:   field.Child("pairingSecretRef").String(),
+		})
+	}
+	if replication.Interval != nil && replication.Interval.Duration <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "the replication interval must be positive",
+			Field:   field.Child("interval").String(),
+		})
+	}
+	return causes
+}
+
+func validatePowerSchedule(field *k8sfield.Path, schedule *v1.VirtualMachinePowerSchedule) []metav1.StatusCause {
+	if schedule.Start == "" && schedule.Stop == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "a power schedule needs a start or a stop schedule",
+			Field:   field.String(),
+		}}
+	}
+
+	var causes []metav1.StatusCause
+	for _, s := range []struct{ name, spec string }{{"start", schedule.Start}, {"stop", schedule.Stop}} {
+		if s.spec == "" {
+			continue
+		}
+		if _, err := cron.Parse(s.spec); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid schedule: %v", err),
+				Field:   field.Child(s.name).String(),
+			})
+		}
+	}
+	if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("invalid time zone: %v", err),
+			Field:   field.Child("timeZone").String(),
+		})
+	}
 	return causes
 }
 
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/rbac"
 
@@ -988,6 +989,105 @@ var _ = Describe("Validating VM Admitter", func() {
 		})
 	})
 
+	Context("with hibernation", func() {
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		table.DescribeTable("should validate the feature gate", func(featureGate string, expectedCauses int) {
+			enableFeatureGate(featureGate)
+			vm := &v1.VirtualMachine{
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: v1.NewMinimalVMI("testvmi").Spec,
+					},
+					Hibernation: &v1.VirtualMachineHibernation{},
+				},
+			}
+
+			causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, config, "fake-account")
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("spec.hibernation"))
+			}
+		},
+			table.Entry("and accept hibernation with the feature gate", virtconfig.HibernationGate, 0),
+			table.Entry("and reject hibernation without the feature gate", "", 1),
+		)
+	})
+
+	Context("with replication", func() {
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		table.DescribeTable("should validate", func(featureGate string, replication *v1.VirtualMachineReplication, expectedField string) {
+			enableFeatureGate(featureGate)
+			vm := &v1.VirtualMachine{
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: v1.NewMinimalVMI("testvmi").Spec,
+					},
+					Replication: replication,
+				},
+			}
+
+			causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, config, "fake-account")
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("and accept replication with the feature gate", virtconfig.DiskReplicationGate,
+				&v1.VirtualMachineReplication{PairingSecretRef: "dr", Interval: &metav1.Duration{Duration: time.Hour}}, ""),
+			table.Entry("and reject replication without the feature gate", "",
+				&v1.VirtualMachineReplication{PairingSecretRef: "dr"}, "spec.replication"),
+			table.Entry("and reject replication without a pairing Secret", virtconfig.DiskReplicationGate,
+				&v1.VirtualMachineReplication{}, "spec.replication.pairingSecretRef"),
+			table.Entry("and reject a negative interval", virtconfig.DiskReplicationGate,
+				&v1.VirtualMachineReplication{PairingSecretRef: "dr", Interval: &metav1.Duration{Duration: -time.Minute}}, "spec.replication.interval"),
+		)
+	})
+
+	table.DescribeTable("should validate the power schedule", func(schedule *v1.VirtualMachinePowerSchedule, expectedField string) {
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Running: &notRunning,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.NewMinimalVMI("testvmi").Spec,
+				},
+				PowerSchedule: schedule,
+			},
+		}
+
+		causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, config, "fake-account")
+		if expectedField == "" {
+			Expect(causes).To(BeEmpty())
+		} else {
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+		}
+	},
+		table.Entry("and accept a start and a stop schedule",
+			&v1.VirtualMachinePowerSchedule{Start: "0 8 * * 1-5", Stop: "0 20 * * *"}, ""),
+		table.Entry("and accept a time zone",
+			&v1.VirtualMachinePowerSchedule{Stop: "@midnight", TimeZone: "UTC"}, ""),
+		table.Entry("and reject a schedule without start and stop",
+			&v1.VirtualMachinePowerSchedule{TimeZone: "UTC"}, "spec.powerSchedule"),
+		table.Entry("and reject an invalid start schedule",
+			&v1.VirtualMachinePowerSchedule{Start: "0 25 * * *"}, "spec.powerSchedule.start"),
+		table.Entry("and reject an invalid stop schedule",
+			&v1.VirtualMachinePowerSchedule{Stop: "@often"}, "spec.powerSchedule.stop"),
+		table.Entry("and reject an unknown time zone",
+			&v1.VirtualMachinePowerSchedule{Stop: "0 20 * * *", TimeZone: "Mars/Olympus_Mons"}, "spec.powerSchedule.timeZone"),
+	)
+
 	Context("with Volume", func() {
 
 		BeforeEach(func() {
@@ -1471,6 +1571,62 @@ var _ = Describe("Validating VM Admitter", func() {
 			table.Entry("when user not authorized", "sourceNamespace", "sourceName", "no permission", nil, "Authorization failed, message is: no permission"),
 			table.Entry("error occurs", "sourceNamespace", "sourceName", "", fmt.Errorf("bad error"), ""),
 		)
+
+		Context("with network attachment definitions of other namespaces", func() {
+			newVMWithNetwork := func(networkName string) *v1.VirtualMachine {
+				return &v1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "tenant",
+					},
+					Spec: v1.VirtualMachineSpec{
+						Template: &v1.VirtualMachineInstanceTemplateSpec{
+							Spec: v1.VirtualMachineInstanceSpec{
+								Networks: []v1.Network{{
+									Name: "provider",
+									NetworkSource: v1.NetworkSource{
+										Multus: &v1.MultusNetwork{NetworkName: networkName},
+									},
+								}},
+							},
+						},
+					},
+				}
+			}
+
+			It("should not review definitions of the VM namespace", func() {
+				vmsAdmitter.networkAuthFunc = func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+					Fail("unexpected authorization request")
+					return false, "", nil
+				}
+				causes, err := vmsAdmitter.authorizeVirtualMachineSpec(&v1beta1.AdmissionRequest{}, newVMWithNetwork("tenant/provider-net"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(causes).To(BeEmpty())
+			})
+
+			It("should allow granted definitions", func() {
+				vmsAdmitter.networkAuthFunc = func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+					Expect(nadNamespace).To(Equal("shared"))
+					Expect(nadName).To(Equal("provider-net"))
+					Expect(saNamespace).To(Equal("tenant"))
+					Expect(saName).To(Equal("default"))
+					return true, "", nil
+				}
+				causes, err := vmsAdmitter.authorizeVirtualMachineSpec(&v1beta1.AdmissionRequest{}, newVMWithNetwork("shared/provider-net"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(causes).To(BeEmpty())
+			})
+
+			It("should deny definitions which were not granted", func() {
+				vmsAdmitter.networkAuthFunc = func(nadNamespace, nadName, saNamespace, saName string) (bool, string, error) {
+					return false, "no permission", nil
+				}
+				causes, err := vmsAdmitter.authorizeVirtualMachineSpec(&v1beta1.AdmissionRequest{}, newVMWithNetwork("shared/provider-net"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(Equal("Authorization failed, message is: no permission"))
+				Expect(causes[0].Field).To(Equal("spec.template.spec.networks[0].multus.networkName"))
+			})
+		})
 	})
 
 	table.DescribeTable("when snapshot is in progress, should", func(mutateFn func(*v1.VirtualMachine) bool) {
@@ -33,6 +33,7 @@ import (
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -57,7 +58,7 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
 	}
 
-	if ar.Request.Operation == v1beta1.Create && !admitter.Config.SnapshotEnabled() {
+	if ar.Request.Operation == v1beta1.Create && !webhooks.ClusterConfigForNamespace(admitter.Config, ar.Request.Namespace).SnapshotEnabled() {
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("snapshot feature gate not enabled"))
 	}
 
@@ -89,7 +90,7 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.
 		case v1.GroupName:
 			switch vmSnapshot.Spec.Source.Kind {
 			case "VirtualMachine":
-				causes, err = admitter.validateCreateVM(sourceField.Child("name"), ar.Request.Namespace, vmSnapshot.Spec.Source.Name)
+				causes, err = admitter.validateCreateVM(sourceField.Child("name"), ar.Request.Namespace, vmSnapshot.Spec.Source.Name, vmSnapshot.Spec.Quiesce)
 				if err != nil {
 					return webhookutils.ToAdmissionResponseError(err)
 				}
@@ -142,7 +143,7 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.
 	return &reviewResponse
 }
 
-func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, namespace, name string) ([]metav1.StatusCause, error) {
+func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, namespace, name string, quiesce bool) ([]metav1.StatusCause, error) {
 	vm, err := admitter.Client.VirtualMachine(namespace).Get(name, &metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		return []metav1.StatusCause{
@@ -160,6 +161,11 @@ func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, names
 
 	var causes []metav1.StatusCause
 
+	// running VMs are snapshotted with their guest file systems frozen
+	if quiesce {
+		return causes, nil
+	}
+
 	rs, err := vm.RunStrategy()
 	if err != nil {
 		return nil, err
@@ -210,6 +210,26 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source.name"))
 			})
 
+			It("should accept when VM is running and the snapshot quiesces it", func() {
+				snapshot := &snapshotv1.VirtualMachineSnapshot{
+					Spec: snapshotv1.VirtualMachineSnapshotSpec{
+						Source: corev1.TypedLocalObjectReference{
+							APIGroup: &apiGroup,
+							Kind:     "VirtualMachine",
+							Name:     vmName,
+						},
+						Quiesce: true,
+					},
+				}
+
+				t := true
+				vm.Spec.Running = &t
+
+				ar := createSnapshotAdmissionReview(snapshot)
+				resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
 			It("should reject invalid kind", func() {
 				snapshot := &snapshotv1.VirtualMachineSnapshot{
 					Spec: snapshotv1.VirtualMachineSnapshotSpec{
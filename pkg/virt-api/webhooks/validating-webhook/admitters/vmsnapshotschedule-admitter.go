@@ -0,0 +1,131 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2018 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/util/cron"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// VMSnapshotScheduleAdmitter validates VirtualMachineSnapshotSchedules
+type VMSnapshotScheduleAdmitter struct {
+	Config *virtconfig.ClusterConfig
+}
+
+// NewVMSnapshotScheduleAdmitter creates a VMSnapshotScheduleAdmitter
+func NewVMSnapshotScheduleAdmitter(config *virtconfig.ClusterConfig) *VMSnapshotScheduleAdmitter {
+	return &VMSnapshotScheduleAdmitter{
+		Config: config,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMSnapshotScheduleAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	if ar.Request.Resource.Group != snapshotv1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachinesnapshotschedules" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == v1beta1.Create && !webhooks.ClusterConfigForNamespace(admitter.Config, ar.Request.Namespace).SnapshotEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("snapshot feature gate not enabled"))
+	}
+
+	if ar.Request.Operation != v1beta1.Create && ar.Request.Operation != v1beta1.Update {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	schedule := &snapshotv1.VirtualMachineSnapshotSchedule{}
+	err := json.Unmarshal(ar.Request.Object.Raw, schedule)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	causes := validateVMSnapshotScheduleSpec(k8sfield.NewPath("spec"), &schedule.Spec)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateVMSnapshotScheduleSpec(field *k8sfield.Path, spec *snapshotv1.VirtualMachineSnapshotScheduleSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	sourceField := field.Child("source")
+	if spec.Source.APIGroup == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: "missing apiGroup",
+			Field:   sourceField.Child("apiGroup").String(),
+		})
+	} else if *spec.Source.APIGroup != v1.GroupName {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "invalid apiGroup",
+			Field:   sourceField.Child("apiGroup").String(),
+		})
+	} else if spec.Source.Kind != "VirtualMachine" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "invalid kind",
+			Field:   sourceField.Child("kind").String(),
+		})
+	}
+
+	if spec.Source.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "missing name",
+			Field:   sourceField.Child("name").String(),
+		})
+	}
+
+	if _, err := cron.Parse(spec.Schedule); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("invalid schedule: %v", err),
+			Field:   field.Child("schedule").String(),
+		})
+	}
+
+	if spec.Retention != nil && *spec.Retention < 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "retention must be at least 1",
+			Field:   field.Child("retention").String(),
+		})
+	}
+
+	return causes
+}
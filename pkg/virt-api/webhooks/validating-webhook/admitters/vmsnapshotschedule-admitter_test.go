@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2018 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineSnapshotSchedule Admitter", func() {
+	apiGroup := "kubevirt.io"
+
+	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
+
+	newSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+		return &snapshotv1.VirtualMachineSnapshotSchedule{
+			Spec: snapshotv1.VirtualMachineSnapshotScheduleSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "VirtualMachine",
+					Name:     "vm",
+				},
+				Schedule: "0 2 * * *",
+			},
+		}
+	}
+
+	Context("Without feature gate enabled", func() {
+		It("should reject anything", func() {
+			ar := createScheduleAdmissionReview(v1beta1.Create, newSchedule())
+			resp := NewVMSnapshotScheduleAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("snapshot feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: "Snapshot"},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMSnapshotScheduleAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		table.DescribeTable("should accept a valid schedule", func(operation v1beta1.Operation, spec string) {
+			schedule := newSchedule()
+			schedule.Spec.Schedule = spec
+			schedule.Spec.Retention = &[]int32{3}[0]
+
+			resp := NewVMSnapshotScheduleAdmitter(config).Admit(createScheduleAdmissionReview(operation, schedule))
+			Expect(resp.Allowed).To(BeTrue())
+		},
+			table.Entry("on create", v1beta1.Create, "*/30 1-5 * * mon-fri"),
+			table.Entry("on update", v1beta1.Update, "@weekly"),
+		)
+
+		table.DescribeTable("should reject", func(modify func(*snapshotv1.VirtualMachineSnapshotSchedule), field string) {
+			schedule := newSchedule()
+			modify(schedule)
+
+			resp := NewVMSnapshotScheduleAdmitter(config).Admit(createScheduleAdmissionReview(v1beta1.Create, schedule))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("a missing apiGroup", func(s *snapshotv1.VirtualMachineSnapshotSchedule) {
+				s.Spec.Source.APIGroup = nil
+			}, "spec.source.apiGroup"),
+			table.Entry("an invalid apiGroup", func(s *snapshotv1.VirtualMachineSnapshotSchedule) {
+				s.Spec.Source.APIGroup = &[]string{"foo.io"}[0]
+			}, "spec.source.apiGroup"),
+			table.Entry("an invalid kind", func(s *snapshotv1.VirtualMachineSnapshotSchedule) {
+				s.Spec.Source.Kind = "VirtualMachineInstance"
+			}, "spec.source.kind"),
+			table.Entry("a missing name", func(s *snapshotv1.VirtualMachineSnapshotSchedule) {
+				s.Spec.Source.Name = ""
+			}, "spec.source.name"),
+			table.Entry("an invalid schedule", func(s *snapshotv1.VirtualMachineSnapshotSchedule) {
+				s.Spec.Schedule = "0 25 * * *"
+			}, "spec.schedule"),
+			table.Entry("a retention of zero", func(s *snapshotv1.VirtualMachineSnapshotSchedule) {
+				s.Spec.Retention = &[]int32{0}[0]
+			}, "spec.retention"),
+		)
+	})
+})
+
+func createScheduleAdmissionReview(operation v1beta1.Operation, schedule *snapshotv1.VirtualMachineSnapshotSchedule) *v1beta1.AdmissionReview {
+	bytes, _ := json.Marshal(schedule)
+
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Operation: operation,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "snapshot.kubevirt.io",
+				Resource: "virtualmachinesnapshotschedules",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
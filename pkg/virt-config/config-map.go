@@ -29,6 +29,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
@@ -43,6 +44,7 @@ const (
 	EmulatedMachinesKey               = "emulated-machines"
 	MachineTypeKey                    = "machine-type"
 	UseEmulationKey                   = "debug.useEmulation"
+	EmulationPolicyKey                = "debug.emulationPolicy"
 	ImagePullPolicyKey                = "dev.imagePullPolicy"
 	MigrationsConfigKey               = "migrations"
 	CPUModelKey                       = "default-cpu-model"
@@ -111,8 +113,8 @@ func (c *ClusterConfig) configAddedDeleted(obj interface{}) {
 	go c.GetConfig()
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	if c.configModifiedCallback != nil {
-		go c.configModifiedCallback()
+	for _, cb := range c.configModifiedCallback {
+		go cb()
 	}
 }
 
@@ -120,8 +122,8 @@ func (c *ClusterConfig) configUpdated(old, cur interface{}) {
 	go c.GetConfig()
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	if c.configModifiedCallback != nil {
-		go c.configModifiedCallback()
+	for _, cb := range c.configModifiedCallback {
+		go cb()
 	}
 }
 
@@ -133,17 +135,21 @@ func isDataVolumeCrd(crd *extv1beta1.CustomResourceDefinition) bool {
 	return false
 }
 
+func isNetworkAttachmentDefinitionCrd(crd *extv1beta1.CustomResourceDefinition) bool {
+	return crd.Spec.Names.Kind == "NetworkAttachmentDefinition"
+}
+
 func (c *ClusterConfig) crdAddedDeleted(obj interface{}) {
 	go c.GetConfig()
 	crd := obj.(*extv1beta1.CustomResourceDefinition)
-	if !isDataVolumeCrd(crd) {
+	if !isDataVolumeCrd(crd) && !isNetworkAttachmentDefinitionCrd(crd) {
 		return
 	}
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	if c.configModifiedCallback != nil {
-		go c.configModifiedCallback()
+	for _, cb := range c.configModifiedCallback {
+		go cb()
 	}
 }
 
@@ -166,6 +172,13 @@ func defaultClusterConfig() *v1.KubeVirtConfiguration {
 	nodeSelectorsDefault, _ := parseNodeSelectors(DefaultNodeSelectors)
 	defaultNetworkInterface := DefaultNetworkInterface
 	defaultMemBalloonStatsPeriod := DefaultMemBalloonStatsPeriod
+	consoleMaxSessions := DefaultConsoleMaxSessions
+	consoleIdleTimeoutSeconds := DefaultConsoleIdleTimeoutSeconds
+	usageUpdateIntervalSeconds := DefaultUsageUpdateIntervalSeconds
+	idleWindowSeconds := DefaultIdleWindowSeconds
+	idleSampleIntervalSeconds := DefaultIdleSampleIntervalSeconds
+	idleCPUThreshold := resource.MustParse(DefaultIdleCPUThreshold)
+	idleNetworkThreshold := resource.MustParse(DefaultIdleNetworkThreshold)
 	SmbiosDefaultConfig := &v1.SMBiosConfiguration{
 		Family:       SmbiosConfigDefaultFamily,
 		Manufacturer: SmbiosConfigDefaultManufacturer,
@@ -181,6 +194,11 @@ func defaultClusterConfig() *v1.KubeVirtConfiguration {
 			LessPVCSpaceToleration: DefaultLessPVCSpaceToleration,
 			NodeSelectors:          nodeSelectorsDefault,
 			CPUAllocationRatio:     DefaultCPUAllocationRatio,
+			LogVerbosity: &v1.LogVerbosity{
+				VirtController: DefaultVirtControllerLogVerbosity,
+				VirtHandler:    DefaultVirtHandlerLogVerbosity,
+				VirtLauncher:   DefaultVirtLauncherLogVerbosity,
+			},
 		},
 		MigrationConfiguration: &v1.MigrationConfiguration{
 			ParallelMigrationsPerCluster:      &parallelMigrationsPerClusterDefault,
@@ -200,12 +218,31 @@ func defaultClusterConfig() *v1.KubeVirtConfiguration {
 			NetworkInterface:                  defaultNetworkInterface,
 			PermitSlirpInterface:              pointer.BoolPtr(DefaultPermitSlirpInterface),
 			PermitBridgeInterfaceOnPodNetwork: pointer.BoolPtr(DefaultPermitBridgeInterfaceOnPodNetwork),
+			DefaultVMNetworkCIDR:              DefaultVMNetworkCIDR,
+			DefaultVMIPv6NetworkCIDR:          DefaultVMIPv6NetworkCIDR,
 		},
 		SMBIOSConfig:                SmbiosDefaultConfig,
 		SELinuxLauncherType:         DefaultSELinuxLauncherType,
 		SupportedGuestAgentVersions: supportedQEMUGuestAgentVersions,
 		OVMFPath:                    DefaultOVMFPath,
 		MemBalloonStatsPeriod:       &defaultMemBalloonStatsPeriod,
+		ConsoleConfiguration: &v1.ConsoleConfiguration{
+			MaxSessions:        &consoleMaxSessions,
+			IdleTimeoutSeconds: &consoleIdleTimeoutSeconds,
+		},
+		UsageAccountingConfiguration: &v1.UsageAccountingConfiguration{
+			BillingPeriod:         DefaultUsageBillingPeriod,
+			UpdateIntervalSeconds: &usageUpdateIntervalSeconds,
+		},
+		IdlePolicy: &v1.IdlePolicyConfiguration{
+			Action:                DefaultIdleAction,
+			WindowSeconds:         &idleWindowSeconds,
+			SampleIntervalSeconds: &idleSampleIntervalSeconds,
+			CPUThreshold:          &idleCPUThreshold,
+			NetworkThreshold:      &idleNetworkThreshold,
+		},
+		DisruptionBudgetPolicy: DefaultDisruptionBudgetPolicy,
+		QoSClasses:             defaultQoSClasses(),
 	}
 }
 
@@ -219,14 +256,43 @@ type ClusterConfig struct {
 	defaultConfig                    *v1.KubeVirtConfiguration
 	lastInvalidConfigResourceVersion string
 	lastValidConfigResourceVersion   string
-	configModifiedCallback           ConfigModifiedFn
+	configModifiedCallback           []ConfigModifiedFn
+	scope                            *featureGateScope
 }
 
+// SetConfigModifiedCallback registers a callback which is called whenever the
+// config changes. Several components of a binary can register their callbacks.
 func (c *ClusterConfig) SetConfigModifiedCallback(cb ConfigModifiedFn) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	c.configModifiedCallback = cb
-	go c.configModifiedCallback()
+	c.configModifiedCallback = append(c.configModifiedCallback, cb)
+	go cb()
+}
+
+// WithConfiguration returns a ClusterConfig of the same cluster, which doesn't
+// follow the cluster but always returns the given configuration on top of the
+// defaults. It allows to check workloads against a configuration before it is
+// applied.
+func (c *ClusterConfig) WithConfiguration(configuration *v1.KubeVirtConfiguration) (*ClusterConfig, error) {
+	config := defaultClusterConfig()
+	if err := setConfigFromKubeVirt(config, &v1.KubeVirt{Spec: v1.KubeVirtSpec{Configuration: *configuration}}); err != nil {
+		return nil, err
+	}
+
+	// without a config map or a KubeVirt CR in the stores, GetConfig sticks
+	// to the last valid config
+	newStoreOnlyInformer := func(obj runtime.Object) cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(&cache.ListWatch{}, obj, 0, cache.Indexers{})
+	}
+	return &ClusterConfig{
+		configMapInformer: newStoreOnlyInformer(&k8sv1.ConfigMap{}),
+		crdInformer:       c.crdInformer,
+		kubeVirtInformer:  newStoreOnlyInformer(&v1.KubeVirt{}),
+		namespace:         c.namespace,
+		lock:              &sync.Mutex{},
+		lastValidConfig:   config,
+		defaultConfig:     c.defaultConfig,
+	}, nil
 }
 
 // This struct is for backward compatibility and is deprecated, no new fields should be added
@@ -307,6 +373,17 @@ func setConfigFromConfigMap(config *v1.KubeVirtConfiguration, configMap *k8sv1.C
 		return fmt.Errorf("invalid debug.useEmulation in config: %v", useEmulation)
 	}
 
+	// set which VMIs may be emulated
+	emulationPolicy := v1.EmulationPolicy(strings.TrimSpace(configMap.Data[EmulationPolicyKey]))
+	switch emulationPolicy {
+	case "":
+		// keep the default
+	case v1.EmulationPolicyNever, v1.EmulationPolicyOptIn, v1.EmulationPolicyAlways:
+		config.DeveloperConfiguration.EmulationPolicy = emulationPolicy
+	default:
+		return fmt.Errorf("invalid debug.emulationPolicy in config: %v", emulationPolicy)
+	}
+
 	// set machine type
 	if machineType := strings.TrimSpace(configMap.Data[MachineTypeKey]); machineType != "" {
 		config.MachineType = machineType
@@ -543,6 +620,21 @@ func (c *ClusterConfig) HasDataVolumeAPI() bool {
 	return false
 }
 
+func (c *ClusterConfig) HasNetworkAttachmentDefinitionAPI() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	objects := c.crdInformer.GetStore().List()
+	for _, obj := range objects {
+		if crd, ok := obj.(*extv1beta1.CustomResourceDefinition); ok && crd.DeletionTimestamp == nil {
+			if isNetworkAttachmentDefinitionCrd(crd) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func parseNodeSelectors(str string) (map[string]string, error) {
 	nodeSelectors := make(map[string]string)
 	for _, s := range strings.Split(strings.TrimSpace(str), "\n") {
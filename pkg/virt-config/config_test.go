@@ -8,6 +8,7 @@ import (
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/utils/pointer"
@@ -47,6 +48,37 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when invalid, IsUseEmulation should return the default", "invalid", false),
 	)
 
+	table.DescribeTable(" when emulationPolicy", func(useEmulation string, policy string, result v1.EmulationPolicy) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{
+				virtconfig.UseEmulationKey:    useEmulation,
+				virtconfig.EmulationPolicyKey: policy,
+			},
+		})
+		Expect(clusterConfig.GetEmulationPolicy()).To(Equal(result))
+	},
+		table.Entry("is set, GetEmulationPolicy should return it", "", "OptIn", v1.EmulationPolicyOptIn),
+		table.Entry("is set, GetEmulationPolicy should ignore useEmulation", "true", "Never", v1.EmulationPolicyNever),
+		table.Entry("when unset, GetEmulationPolicy should return Always with useEmulation", "true", "", v1.EmulationPolicyAlways),
+		table.Entry("when unset, GetEmulationPolicy should return Never without useEmulation", "", "", v1.EmulationPolicyNever),
+		table.Entry("when invalid, GetEmulationPolicy should return the default", "", "invalid", v1.EmulationPolicyNever),
+	)
+
+	table.DescribeTable("should allow emulation", func(policy string, allowEmulation *bool, result bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.EmulationPolicyKey: policy},
+		})
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.AllowEmulation = allowEmulation
+		Expect(clusterConfig.IsEmulationAllowed(vmi)).To(Equal(result))
+	},
+		table.Entry("never with policy Never", "Never", pointer.BoolPtr(true), false),
+		table.Entry("with policy OptIn if the VMI opts in", "OptIn", pointer.BoolPtr(true), true),
+		table.Entry("not with policy OptIn by default", "OptIn", nil, false),
+		table.Entry("with policy Always by default", "Always", nil, true),
+		table.Entry("not with policy Always if the VMI opts out", "Always", pointer.BoolPtr(false), false),
+	)
+
 	table.DescribeTable(" when permitSlirpInterface", func(value string, result bool) {
 		clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
 			Data: map[string]string{"permitSlirpInterface": value},
@@ -386,12 +418,29 @@ var _ = Describe("ConfigMap", func() {
 					NodeSelectors:          map[string]string{"test": "test"},
 					UseEmulation:           true,
 					CPUAllocationRatio:     25,
+					LogVerbosity: &v1.LogVerbosity{
+						VirtController: 3,
+						VirtHandler:    4,
+						VirtLauncher:   5,
+					},
 				},
 			},
 			func(c *v1.KubeVirtConfiguration) interface{} {
 				return c.DeveloperConfiguration
 			},
-			`{"featureGates":["test1","test2"],"pvcTolerateLessSpaceUpToPercent":5,"memoryOvercommit":150,"nodeSelectors":{"test":"test"},"useEmulation":true,"cpuAllocationRatio":25}`),
+			`{"featureGates":["test1","test2"],"pvcTolerateLessSpaceUpToPercent":5,"memoryOvercommit":150,"nodeSelectors":{"test":"test"},"useEmulation":true,"cpuAllocationRatio":25,"logVerbosity":{"virtController":3,"virtHandler":4,"virtLauncher":5}}`),
+		table.Entry("when logVerbosity is partially set, should keep the defaults of the other components",
+			v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{
+					LogVerbosity: &v1.LogVerbosity{
+						VirtLauncher: 4,
+					},
+				},
+			},
+			func(c *v1.KubeVirtConfiguration) interface{} {
+				return c.DeveloperConfiguration.LogVerbosity
+			},
+			`{"virtController":2,"virtHandler":2,"virtLauncher":4}`),
 		table.Entry("when networkConfiguration set, should equal to result",
 			v1.KubeVirtConfiguration{
 				NetworkConfiguration: &v1.NetworkConfiguration{
@@ -403,9 +452,106 @@ var _ = Describe("ConfigMap", func() {
 			func(c *v1.KubeVirtConfiguration) interface{} {
 				return c.NetworkConfiguration
 			},
-			`{"defaultNetworkInterface":"test","permitSlirpInterface":true,"permitBridgeInterfaceOnPodNetwork":false}`),
+			`{"defaultNetworkInterface":"test","permitSlirpInterface":true,"permitBridgeInterfaceOnPodNetwork":false,"defaultVMNetworkCIDR":"10.0.2.0/24","defaultVMIPv6NetworkCIDR":"fd10:0:2::/120"}`),
+		table.Entry("when default masquerade CIDRs set, should equal to result",
+			v1.KubeVirtConfiguration{
+				NetworkConfiguration: &v1.NetworkConfiguration{
+					DefaultVMNetworkCIDR:     "10.200.0.0/24",
+					DefaultVMIPv6NetworkCIDR: "fd20::/120",
+				},
+			},
+			func(c *v1.KubeVirtConfiguration) interface{} {
+				return []string{c.NetworkConfiguration.DefaultVMNetworkCIDR, c.NetworkConfiguration.DefaultVMIPv6NetworkCIDR}
+			},
+			`["10.200.0.0/24","fd20::/120"]`),
+		table.Entry("when only the billing period of usageAccounting is set, should keep the default interval",
+			v1.KubeVirtConfiguration{
+				UsageAccountingConfiguration: &v1.UsageAccountingConfiguration{
+					BillingPeriod: v1.BillingPeriodDaily,
+				},
+			},
+			func(c *v1.KubeVirtConfiguration) interface{} {
+				return c.UsageAccountingConfiguration
+			},
+			`{"billingPeriod":"Daily","updateIntervalSeconds":300}`),
+		table.Entry("when only the action of idlePolicy is set, should keep the default thresholds",
+			v1.KubeVirtConfiguration{
+				IdlePolicy: &v1.IdlePolicyConfiguration{
+					Action: v1.IdleActionHibernate,
+				},
+			},
+			func(c *v1.KubeVirtConfiguration) interface{} {
+				return c.IdlePolicy
+			},
+			`{"action":"Hibernate","windowSeconds":3600,"sampleIntervalSeconds":300,"cpuThreshold":"50m","networkThreshold":"1Ki"}`),
+		table.Entry("when disruptionBudgetPolicy is unset, should default to Always",
+			v1.KubeVirtConfiguration{},
+			func(c *v1.KubeVirtConfiguration) interface{} {
+				return c.DisruptionBudgetPolicy
+			},
+			`"Always"`),
+	)
+
+	table.DescribeTable("when disruptionBudgetPolicy", func(policy v1.DisruptionBudgetPolicy, result v1.DisruptionBudgetPolicy) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DisruptionBudgetPolicy: policy,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetDisruptionBudgetPolicy()).To(Equal(result))
+	},
+		table.Entry("is set, GetDisruptionBudgetPolicy should return it", v1.DisruptionBudgetPolicyDuringMigration, v1.DisruptionBudgetPolicyDuringMigration),
+		table.Entry("is unset, GetDisruptionBudgetPolicy should return Always", v1.DisruptionBudgetPolicy(""), v1.DisruptionBudgetPolicyAlways),
+		table.Entry("is invalid, GetDisruptionBudgetPolicy should return Always", v1.DisruptionBudgetPolicy("invalid"), v1.DisruptionBudgetPolicyAlways),
 	)
 
+	It("should replace the default QoS classes with the configured ones", func() {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		for _, name := range []v1.QoSClass{v1.QoSClassGold, v1.QoSClassSilver, v1.QoSClassBronze} {
+			Expect(clusterConfig.GetQoSClass(name)).ToNot(BeNil())
+		}
+
+		clusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					QoSClasses: []v1.QoSClassConfiguration{{
+						Name:        "platinum",
+						BlkioWeight: 1000,
+						NetworkRate: resource.MustParse("1G"),
+						NetworkCeil: resource.MustParse("10G"),
+					}},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetQoSClasses()).To(HaveLen(1))
+		Expect(clusterConfig.GetQoSClass("platinum").BlkioWeight).To(Equal(uint32(1000)))
+		Expect(clusterConfig.GetQoSClass(v1.QoSClassGold)).To(BeNil())
+	})
+
 	It("should use configmap value over kubevirt configuration", func() {
 		clusterConfig, cminformer, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
 			ObjectMeta: metav1.ObjectMeta{
@@ -440,4 +586,91 @@ var _ = Describe("ConfigMap", func() {
 		emulation = clusterConfig.IsUseEmulation()
 		Expect(emulation).To(BeFalse())
 	})
+	It("should return the given configuration with WithConfiguration", func() {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: []string{virtconfig.GPUGate},
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		Expect(clusterConfig.GPUPassthroughEnabled()).To(BeTrue())
+
+		proposed, err := clusterConfig.WithConfiguration(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: []string{virtconfig.HostDiskGate},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(proposed.GPUPassthroughEnabled()).To(BeFalse())
+		Expect(proposed.HostDiskEnabled()).To(BeTrue())
+		Expect(proposed.HasDataVolumeAPI()).To(BeTrue())
+		// values which the configuration doesn't set keep their default
+		Expect(proposed.GetMemBalloonStatsPeriod()).To(Equal(virtconfig.DefaultMemBalloonStatsPeriod))
+
+		Expect(clusterConfig.GPUPassthroughEnabled()).To(BeTrue())
+		Expect(clusterConfig.HostDiskEnabled()).To(BeFalse())
+	})
+
+	It("should enable scoped feature gates only in the namespaces they select", func() {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: []string{virtconfig.HostDiskGate},
+						ScopedFeatureGates: []v1.ScopedFeatureGate{
+							{
+								Name:       virtconfig.MacvtapGate,
+								Namespaces: []string{"network-team"},
+							},
+							{
+								Name: virtconfig.GPUGate,
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"gpu": "allowed"},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		Expect(clusterConfig.HasScopedFeatureGates()).To(BeTrue())
+
+		By("enabling the scoped feature gates without a namespace")
+		Expect(clusterConfig.MacvtapEnabled()).To(BeTrue())
+		Expect(clusterConfig.GPUPassthroughEnabled()).To(BeTrue())
+
+		By("enabling them in the namespaces they select")
+		networkTeam := clusterConfig.ForNamespace("network-team", nil)
+		Expect(networkTeam.MacvtapEnabled()).To(BeTrue())
+		Expect(networkTeam.GPUPassthroughEnabled()).To(BeFalse())
+		Expect(networkTeam.HostDiskEnabled()).To(BeTrue())
+
+		gpuTeam := clusterConfig.ForNamespace("gpu-team", map[string]string{"gpu": "allowed"})
+		Expect(gpuTeam.MacvtapEnabled()).To(BeFalse())
+		Expect(gpuTeam.GPUPassthroughEnabled()).To(BeTrue())
+
+		By("disabling them in other namespaces")
+		other := clusterConfig.ForNamespace("default", map[string]string{"gpu": "denied"})
+		Expect(other.MacvtapEnabled()).To(BeFalse())
+		Expect(other.GPUPassthroughEnabled()).To(BeFalse())
+		Expect(other.HostDiskEnabled()).To(BeTrue())
+	})
 })
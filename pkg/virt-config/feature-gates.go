@@ -19,35 +19,105 @@
 
 package virtconfig
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
 /*
  This module is intended for determining whether an optional feature is enabled or not at the cluster-level.
 */
 
 const (
-	CPUManager            = "CPUManager"
-	IgnitionGate          = "ExperimentalIgnitionSupport"
-	LiveMigrationGate     = "LiveMigration"
-	CPUNodeDiscoveryGate  = "CPUNodeDiscovery"
-	HypervStrictCheckGate = "HypervStrictCheck"
-	SidecarGate           = "Sidecar"
-	GPUGate               = "GPU"
-	HostDevicesGate       = "HostDevices"
-	SnapshotGate          = "Snapshot"
-	HotplugVolumesGate    = "HotplugVolumes"
-	HostDiskGate          = "HostDisk"
-	VirtIOFSGate          = "ExperimentalVirtiofsSupport"
-	MacvtapGate           = "Macvtap"
+	CPUManager                 = "CPUManager"
+	IgnitionGate               = "ExperimentalIgnitionSupport"
+	LiveMigrationGate          = "LiveMigration"
+	CPUNodeDiscoveryGate       = "CPUNodeDiscovery"
+	HypervStrictCheckGate      = "HypervStrictCheck"
+	SidecarGate                = "Sidecar"
+	GPUGate                    = "GPU"
+	HostDevicesGate            = "HostDevices"
+	SnapshotGate               = "Snapshot"
+	HotplugVolumesGate         = "HotplugVolumes"
+	HostDiskGate               = "HostDisk"
+	VirtIOFSGate               = "ExperimentalVirtiofsSupport"
+	MacvtapGate                = "Macvtap"
+	UsageAccountingGate        = "UsageAccounting"
+	NUMAFeatureGate            = "NUMA"
+	HibernationGate            = "Hibernation"
+	QMPPassthroughGate         = "QMPPassthrough"
+	NotificationHooksGate      = "NotificationHooks"
+	DiskReplicationGate        = "DiskReplication"
+	IdleSuspendGate            = "IdleSuspend"
+	ProfilingGate              = "Profiling"
+	VhostUserBlkGate           = "VhostUserBlk"
+	NodeFencingGate            = "NodeFencing"
+	EmulatorSelectionGate      = "EmulatorSelection"
+	NetworkDryRunGate          = "NetworkDryRun"
+	NetworkCacheVersioningGate = "NetworkCacheVersioning"
 )
 
 func (c *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
-	for _, fg := range c.GetConfig().DeveloperConfiguration.FeatureGates {
+	developerConfig := c.GetConfig().DeveloperConfiguration
+	for _, fg := range developerConfig.FeatureGates {
 		if fg == featureGate {
 			return true
 		}
 	}
+	// without a namespace, e.g. in the controllers and in virt-handler, scoped
+	// feature gates are enabled, the webhooks keep them to their namespaces
+	for _, scoped := range developerConfig.ScopedFeatureGates {
+		if scoped.Name == featureGate && (c.scope == nil || c.scope.selects(scoped)) {
+			return true
+		}
+	}
 	return false
 }
 
+// featureGateScope is the namespace a ClusterConfig returned by ForNamespace
+// evaluates the scoped feature gates for
+type featureGateScope struct {
+	namespace string
+	labels    map[string]string
+}
+
+func (s *featureGateScope) selects(featureGate v1.ScopedFeatureGate) bool {
+	for _, namespace := range featureGate.Namespaces {
+		if namespace == s.namespace {
+			return true
+		}
+	}
+	if featureGate.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(featureGate.NamespaceSelector)
+	if err != nil {
+		log.Log.Reason(err).Warningf("Ignoring the namespace selector of scoped feature gate %s", featureGate.Name)
+		return false
+	}
+	return selector.Matches(labels.Set(s.labels))
+}
+
+// HasScopedFeatureGates returns true if feature gates are enabled for the
+// workloads of some namespaces only
+func (c *ClusterConfig) HasScopedFeatureGates() bool {
+	return len(c.GetConfig().DeveloperConfiguration.ScopedFeatureGates) > 0
+}
+
+// ForNamespace returns a view of the ClusterConfig for the workloads of a
+// namespace with the given labels. In the view, scoped feature gates are only
+// enabled if they select the namespace.
+func (c *ClusterConfig) ForNamespace(namespace string, namespaceLabels map[string]string) *ClusterConfig {
+	scoped := *c
+	scoped.scope = &featureGateScope{namespace: namespace, labels: namespaceLabels}
+	// changes of the config are announced by the ClusterConfig the view was created from
+	scoped.configModifiedCallback = nil
+	return &scoped
+}
+
 func (config *ClusterConfig) CPUManagerEnabled() bool {
 	return config.isFeatureGateEnabled(CPUManager)
 }
@@ -99,3 +169,55 @@ func (config *ClusterConfig) MacvtapEnabled() bool {
 func (config *ClusterConfig) HostDevicesPassthroughEnabled() bool {
 	return config.isFeatureGateEnabled(HostDevicesGate)
 }
+
+func (config *ClusterConfig) UsageAccountingEnabled() bool {
+	return config.isFeatureGateEnabled(UsageAccountingGate)
+}
+
+func (config *ClusterConfig) NUMAEnabled() bool {
+	return config.isFeatureGateEnabled(NUMAFeatureGate)
+}
+
+func (config *ClusterConfig) HibernationEnabled() bool {
+	return config.isFeatureGateEnabled(HibernationGate)
+}
+
+func (config *ClusterConfig) QMPPassthroughEnabled() bool {
+	return config.isFeatureGateEnabled(QMPPassthroughGate)
+}
+
+func (config *ClusterConfig) NotificationHooksEnabled() bool {
+	return config.isFeatureGateEnabled(NotificationHooksGate)
+}
+
+func (config *ClusterConfig) DiskReplicationEnabled() bool {
+	return config.isFeatureGateEnabled(DiskReplicationGate)
+}
+
+func (config *ClusterConfig) IdleSuspendEnabled() bool {
+	return config.isFeatureGateEnabled(IdleSuspendGate)
+}
+
+func (config *ClusterConfig) ProfilingEnabled() bool {
+	return config.isFeatureGateEnabled(ProfilingGate)
+}
+
+func (config *ClusterConfig) VhostUserBlkEnabled() bool {
+	return config.isFeatureGateEnabled(VhostUserBlkGate)
+}
+
+func (config *ClusterConfig) NodeFencingEnabled() bool {
+	return config.isFeatureGateEnabled(NodeFencingGate)
+}
+
+func (config *ClusterConfig) EmulatorSelectionEnabled() bool {
+	return config.isFeatureGateEnabled(EmulatorSelectionGate)
+}
+
+func (config *ClusterConfig) NetworkDryRunEnabled() bool {
+	return config.isFeatureGateEnabled(NetworkDryRunGate)
+}
+
+func (config *ClusterConfig) NetworkCacheVersioningEnabled() bool {
+	return config.isFeatureGateEnabled(NetworkCacheVersioningGate)
+}
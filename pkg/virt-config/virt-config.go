@@ -25,6 +25,7 @@ package virtconfig
 
 import (
 	"runtime"
+	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -62,6 +63,22 @@ const (
 	DefaultOVMFPath                                 = "/usr/share/OVMF"
 	DefaultMemBalloonStatsPeriod             uint32 = 10
 	DefaultCPUAllocationRatio                       = 10
+	DefaultConsoleMaxSessions                uint32 = 0
+	DefaultConsoleIdleTimeoutSeconds         int64  = 0
+	DefaultMasqueradeSubnetPrefixLength             = 24
+	DefaultVMNetworkCIDR                            = "10.0.2.0/24"
+	DefaultVMIPv6NetworkCIDR                        = "fd10:0:2::/120"
+	DefaultUsageBillingPeriod                       = v1.BillingPeriodMonthly
+	DefaultUsageUpdateIntervalSeconds        int64  = 300
+	DefaultIdleAction                               = v1.IdleActionPause
+	DefaultIdleWindowSeconds                 int64  = 3600
+	DefaultIdleSampleIntervalSeconds         int64  = 300
+	DefaultIdleCPUThreshold                         = "50m"
+	DefaultIdleNetworkThreshold                     = "1Ki"
+	DefaultDisruptionBudgetPolicy                   = v1.DisruptionBudgetPolicyAlways
+	DefaultVirtControllerLogVerbosity               = 2
+	DefaultVirtHandlerLogVerbosity                  = 2
+	DefaultVirtLauncherLogVerbosity                 = 2
 )
 
 // Set default machine type and supported emulated machines based on architecture
@@ -74,6 +91,34 @@ func getDefaultMachinesForArch() (string, string) {
 
 var DefaultMachineType, DefaultEmulatedMachines = getDefaultMachinesForArch()
 
+// defaultQoSClasses are the QoS classes VMIs can select unless the configuration sets others,
+// the network rates are in bytes per second
+func defaultQoSClasses() []v1.QoSClassConfiguration {
+	return []v1.QoSClassConfiguration{
+		{
+			Name:            v1.QoSClassGold,
+			BlkioWeight:     1000,
+			NetworkRate:     resource.MustParse("125M"),
+			NetworkCeil:     resource.MustParse("1250M"),
+			NetworkPriority: 0,
+		},
+		{
+			Name:            v1.QoSClassSilver,
+			BlkioWeight:     500,
+			NetworkRate:     resource.MustParse("62500k"),
+			NetworkCeil:     resource.MustParse("125M"),
+			NetworkPriority: 1,
+		},
+		{
+			Name:            v1.QoSClassBronze,
+			BlkioWeight:     100,
+			NetworkRate:     resource.MustParse("12500k"),
+			NetworkCeil:     resource.MustParse("12500k"),
+			NetworkPriority: 2,
+		},
+	}
+}
+
 func (c *ClusterConfig) GetMemBalloonStatsPeriod() uint32 {
 	return *c.GetConfig().MemBalloonStatsPeriod
 }
@@ -82,10 +127,112 @@ func (c *ClusterConfig) IsUseEmulation() bool {
 	return c.GetConfig().DeveloperConfiguration.UseEmulation
 }
 
+// GetEmulationPolicy returns the configured emulation policy, falling back to
+// the useEmulation setting if no policy is configured
+func (c *ClusterConfig) GetEmulationPolicy() v1.EmulationPolicy {
+	developerConfig := c.GetConfig().DeveloperConfiguration
+	if developerConfig.EmulationPolicy != "" {
+		return developerConfig.EmulationPolicy
+	}
+	if developerConfig.UseEmulation {
+		return v1.EmulationPolicyAlways
+	}
+	return v1.EmulationPolicyNever
+}
+
+// IsEmulationAllowed returns whether the VMI may fall back to software emulation
+// on nodes without /dev/kvm
+func (c *ClusterConfig) IsEmulationAllowed(vmi *v1.VirtualMachineInstance) bool {
+	allowEmulation := vmi.Spec.Domain.AllowEmulation
+	switch c.GetEmulationPolicy() {
+	case v1.EmulationPolicyOptIn:
+		return allowEmulation != nil && *allowEmulation
+	case v1.EmulationPolicyAlways:
+		return allowEmulation == nil || *allowEmulation
+	default:
+		return false
+	}
+}
+
 func (c *ClusterConfig) GetMigrationConfiguration() *v1.MigrationConfiguration {
 	return c.GetConfig().MigrationConfiguration
 }
 
+func (c *ClusterConfig) GetConsoleConfiguration() *v1.ConsoleConfiguration {
+	return c.GetConfig().ConsoleConfiguration
+}
+
+func (c *ClusterConfig) GetUsageAccountingConfiguration() *v1.UsageAccountingConfiguration {
+	return c.GetConfig().UsageAccountingConfiguration
+}
+
+// GetUsageUpdateInterval returns the time between two usage accumulations, falling back
+// to the default for non-positive values, which would otherwise requeue in a hot loop.
+func (c *ClusterConfig) GetUsageUpdateInterval() time.Duration {
+	seconds := DefaultUsageUpdateIntervalSeconds
+	if interval := c.GetConfig().UsageAccountingConfiguration.UpdateIntervalSeconds; interval != nil && *interval > 0 {
+		seconds = *interval
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func (c *ClusterConfig) GetIdlePolicy() *v1.IdlePolicyConfiguration {
+	return c.GetConfig().IdlePolicy
+}
+
+// GetIdleWindow returns how long a VM has to be inactive to be idle, falling back
+// to the default for non-positive values
+func (c *ClusterConfig) GetIdleWindow() time.Duration {
+	seconds := DefaultIdleWindowSeconds
+	if window := c.GetConfig().IdlePolicy.WindowSeconds; window != nil && *window > 0 {
+		seconds = *window
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetIdleSampleInterval returns the time between two reads of the stats of a VM,
+// falling back to the default for non-positive values, which would otherwise
+// requeue in a hot loop.
+func (c *ClusterConfig) GetIdleSampleInterval() time.Duration {
+	seconds := DefaultIdleSampleIntervalSeconds
+	if interval := c.GetConfig().IdlePolicy.SampleIntervalSeconds; interval != nil && *interval > 0 {
+		seconds = *interval
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetDisruptionBudgetPolicy returns the configured disruption budget policy, falling
+// back to the default for unknown policies
+func (c *ClusterConfig) GetDisruptionBudgetPolicy() v1.DisruptionBudgetPolicy {
+	switch policy := c.GetConfig().DisruptionBudgetPolicy; policy {
+	case v1.DisruptionBudgetPolicyAlways, v1.DisruptionBudgetPolicyDuringMigration, v1.DisruptionBudgetPolicyNever:
+		return policy
+	default:
+		return DefaultDisruptionBudgetPolicy
+	}
+}
+
+// GetMaxVMIsPerNode returns the number of VMIs a node can run, 0 if it is not limited
+func (c *ClusterConfig) GetMaxVMIsPerNode() int64 {
+	if density := c.GetConfig().NodeDensity; density != nil && density.MaxVMIsPerNode > 0 {
+		return density.MaxVMIsPerNode
+	}
+	return 0
+}
+
+// GetMemoryOvercommitBudget returns the overcommitted guest memory a node can give to its VMIs,
+// nil if it is not limited
+func (c *ClusterConfig) GetMemoryOvercommitBudget() *resource.Quantity {
+	if density := c.GetConfig().NodeDensity; density != nil {
+		return density.MemoryOvercommitBudget
+	}
+	return nil
+}
+
+func (c *ClusterConfig) GetVMIAdmissionPolicies() []v1.VMIAdmissionPolicy {
+	return c.GetConfig().AdmissionPolicies
+}
+
 func (c *ClusterConfig) GetImagePullPolicy() (policy k8sv1.PullPolicy) {
 	return c.GetConfig().ImagePullPolicy
 }
@@ -104,6 +251,57 @@ func (c *ClusterConfig) GetCPUModel() string {
 	return c.GetConfig().CPUModel
 }
 
+func (c *ClusterConfig) GetCPUBaselines() []v1.CPUBaseline {
+	return c.GetConfig().CPUBaselines
+}
+
+// GetCPUBaseline returns the CPU baseline with the name, nil if none is configured
+func (c *ClusterConfig) GetCPUBaseline(name string) *v1.CPUBaseline {
+	if name == "" {
+		return nil
+	}
+	baselines := c.GetCPUBaselines()
+	for i := range baselines {
+		if baselines[i].Name == name {
+			return &baselines[i]
+		}
+	}
+	return nil
+}
+
+// GetCPUBaselineStatus returns the CPU model and features virt-controller computed for the
+// CPU baseline with the name, nil if they were not computed yet
+func (c *ClusterConfig) GetCPUBaselineStatus(name string) *v1.CPUBaselineStatus {
+	kv := c.getConfigFromKubeVirtCR()
+	if kv == nil {
+		return nil
+	}
+	for i := range kv.Status.CPUBaselines {
+		if kv.Status.CPUBaselines[i].Name == name {
+			return kv.Status.CPUBaselines[i].DeepCopy()
+		}
+	}
+	return nil
+}
+
+func (c *ClusterConfig) GetQoSClasses() []v1.QoSClassConfiguration {
+	return c.GetConfig().QoSClasses
+}
+
+// GetQoSClass returns the QoS class with the name, nil if none is configured
+func (c *ClusterConfig) GetQoSClass(name v1.QoSClass) *v1.QoSClassConfiguration {
+	if name == "" {
+		return nil
+	}
+	classes := c.GetQoSClasses()
+	for i := range classes {
+		if classes[i].Name == name {
+			return &classes[i]
+		}
+	}
+	return nil
+}
+
 func (c *ClusterConfig) GetCPURequest() *resource.Quantity {
 	return c.GetConfig().CPURequest
 }
@@ -140,6 +338,42 @@ func (c *ClusterConfig) IsBridgeInterfaceOnPodNetworkEnabled() bool {
 	return *c.GetConfig().NetworkConfiguration.PermitBridgeInterfaceOnPodNetwork
 }
 
+func (c *ClusterConfig) GetBridgeConfiguration() *v1.BridgeConfiguration {
+	return c.GetConfig().NetworkConfiguration.Bridge
+}
+
+func (c *ClusterConfig) GetMasqueradeSubnetPool() *v1.MasqueradeSubnetPool {
+	return c.GetConfig().NetworkConfiguration.MasqueradeSubnetPool
+}
+
+func (c *ClusterConfig) GetDefaultVMNetworkCIDR() string {
+	return c.GetConfig().NetworkConfiguration.DefaultVMNetworkCIDR
+}
+
+func (c *ClusterConfig) GetDefaultVMIPv6NetworkCIDR() string {
+	return c.GetConfig().NetworkConfiguration.DefaultVMIPv6NetworkCIDR
+}
+
+func (c *ClusterConfig) GetV2VConversionImage() string {
+	return c.GetConfig().V2VConversionImage
+}
+
+func (c *ClusterConfig) GetDiskTransferImage() string {
+	return c.GetConfig().DiskTransferImage
+}
+
+func (c *ClusterConfig) GetAllowedImportURLs() []string {
+	return c.GetConfig().AllowedImportURLs
+}
+
+func (c *ClusterConfig) GetReplicationImage() string {
+	return c.GetConfig().ReplicationImage
+}
+
+func (c *ClusterConfig) GetVirtioWinImage() string {
+	return c.GetConfig().VirtioWinImage
+}
+
 func (c *ClusterConfig) GetDefaultClusterConfig() *v1.KubeVirtConfiguration {
 	return c.defaultConfig
 }
@@ -163,3 +397,15 @@ func (c *ClusterConfig) GetCPUAllocationRatio() int {
 func (c *ClusterConfig) GetPermittedHostDevices() *v1.PermittedHostDevices {
 	return c.GetConfig().PermittedHostDevices
 }
+
+func (c *ClusterConfig) GetVirtControllerVerbosity() uint {
+	return c.GetConfig().DeveloperConfiguration.LogVerbosity.VirtController
+}
+
+func (c *ClusterConfig) GetVirtHandlerVerbosity() uint {
+	return c.GetConfig().DeveloperConfiguration.LogVerbosity.VirtHandler
+}
+
+func (c *ClusterConfig) GetVirtLauncherVerbosity() uint {
+	return c.GetConfig().DeveloperConfiguration.LogVerbosity.VirtLauncher
+}
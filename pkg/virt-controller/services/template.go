@@ -47,6 +47,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
+	"kubevirt.io/kubevirt/pkg/util/net/nad"
 	"kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -84,11 +85,13 @@ const ISTIO_KUBEVIRT_ANNOTATION = "traffic.sidecar.istio.io/kubevirtInterfaces"
 
 const ENV_VAR_LIBVIRT_DEBUG_LOGS = "LIBVIRT_DEBUG_LOGS"
 const ENV_VAR_VIRTIOFSD_DEBUG_LOGS = "VIRTIOFSD_DEBUG_LOGS"
+const ENV_VAR_VIRT_LAUNCHER_LOG_VERBOSITY = "VIRT_LAUNCHER_LOG_VERBOSITY"
 
 type TemplateService interface {
 	RenderLaunchManifest(*v1.VirtualMachineInstance) (*k8sv1.Pod, error)
 	RenderHotplugAttachmentPodTemplate(volume *v1.Volume, ownerPod *k8sv1.Pod, vmi *v1.VirtualMachineInstance, pvcName string, isBlock bool) (*k8sv1.Pod, error)
 	RenderLaunchManifestNoVm(*v1.VirtualMachineInstance) (*k8sv1.Pod, error)
+	RenderImageVerifierPodTemplate(name string, claimName string, isBlock bool) (*k8sv1.Pod, error)
 }
 
 type templateService struct {
@@ -318,7 +321,6 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	precond.MustNotBeNil(vmi)
 	domain := precond.MustNotBeEmpty(vmi.GetObjectMeta().GetName())
 	namespace := precond.MustNotBeEmpty(vmi.GetObjectMeta().GetNamespace())
-	nodeSelector := map[string]string{}
 
 	var volumes []k8sv1.Volume
 	var volumeDevices []k8sv1.VolumeDevice
@@ -445,6 +447,18 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 				},
 			})
 		}
+		if volume.VhostUserBlk != nil {
+			// the volume of the claim contains the vhost-user-blk socket of the storage backend
+			volumeMounts = append(volumeMounts, volumeMount)
+			volumes = append(volumes, k8sv1.Volume{
+				Name: volume.Name,
+				VolumeSource: k8sv1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: volume.VhostUserBlk.ClaimName,
+					},
+				},
+			})
+		}
 		if volume.DataVolume != nil {
 			logger := log.DefaultLogger()
 			claimName := volume.DataVolume.Name
@@ -532,6 +546,24 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 			serviceAccountName = volume.ServiceAccount.ServiceAccountName
 		}
 
+		if volume.Encryption != nil && volume.Encryption.SecretRef != nil {
+			// attach the secret holding the LUKS passphrase of the volume
+			volumeName := volume.Name + "-luks"
+			volumes = append(volumes, k8sv1.Volume{
+				Name: volumeName,
+				VolumeSource: k8sv1.VolumeSource{
+					Secret: &k8sv1.SecretVolumeSource{
+						SecretName: volume.Encryption.SecretRef.Name,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+				Name:      volumeName,
+				MountPath: filepath.Join(config.DiskEncryptionSourceDir, volume.Name),
+				ReadOnly:  true,
+			})
+		}
+
 		if volume.CloudInitNoCloud != nil {
 			if volume.CloudInitNoCloud.UserDataSecretRef != nil {
 				// attach a secret referenced by the user
@@ -638,6 +670,22 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		})
 	}
 
+	if claimName, ok := vmi.Annotations[v1.HibernationClaimAnnotation]; ok {
+		// attach the claim the memory of the guest is saved to on hibernation
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "hibernation",
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      "hibernation",
+			MountPath: config.HibernationSourceDir,
+		})
+	}
+
 	if t.imagePullSecret != "" {
 		imagePullSecrets = appendUniqueImagePullSecret(imagePullSecrets, k8sv1.LocalObjectReference{
 			Name: t.imagePullSecret,
@@ -777,9 +825,6 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 
 	// Handle CPU pinning
 	if vmi.IsCPUDedicated() {
-		// schedule only on nodes with a running cpu manager
-		nodeSelector[v1.CPUManager] = "true"
-
 		vcpus := hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU)
 
 		if vcpus != 0 {
@@ -832,7 +877,37 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		}
 	}
 
-	useEmulation := t.clusterConfig.IsUseEmulation()
+	if !tempPod && t.clusterConfig.ProfilingEnabled() {
+		command = append(command, "--profiling")
+	}
+
+	if !tempPod && t.clusterConfig.NetworkCacheVersioningEnabled() {
+		command = append(command, "--network-cache-versioning")
+	}
+
+	emulator := ""
+	if !tempPod && t.clusterConfig.EmulatorSelectionEnabled() {
+		emulator = vmi.Annotations[v1.EmulatorAnnotation]
+	}
+	if emulator != "" {
+		command = append(command, "--emulator", emulator)
+	}
+	// hook sidecars can provide the emulator build in a directory shared
+	// with the compute container
+	if emulator != "" && len(requestedHookSidecarList) != 0 {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "emulators",
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      "emulators",
+			MountPath: util.SidecarEmulatorsDir,
+		})
+	}
+
+	useEmulation := t.clusterConfig.IsEmulationAllowed(vmi)
 	imagePullPolicy := t.clusterConfig.GetImagePullPolicy()
 
 	if resources.Limits == nil {
@@ -880,6 +955,10 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		}
 	}
 
+	for key, val := range NodeDensityResources(vmi, t.clusterConfig) {
+		resources.Limits[key] = val
+	}
+
 	// VirtualMachineInstance target container
 	compute := k8sv1.Container{
 		Name:            "compute",
@@ -921,6 +1000,10 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	if _, ok := vmi.Labels[virtiofsDebugLogs]; ok {
 		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: ENV_VAR_VIRTIOFSD_DEBUG_LOGS, Value: "1"})
 	}
+	compute.Env = append(compute.Env, k8sv1.EnvVar{
+		Name:  ENV_VAR_VIRT_LAUNCHER_LOG_VERBOSITY,
+		Value: strconv.Itoa(int(launcherLogVerbosity(vmi, t.clusterConfig))),
+	})
 
 	// Make sure the compute container is always the first since the mutating webhook shipped with the sriov operator
 	// for adding the requested resources to the pod will add them to the first container of the list
@@ -963,33 +1046,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		})
 	}
 
-	for k, v := range vmi.Spec.NodeSelector {
-		nodeSelector[k] = v
-
-	}
-	if t.clusterConfig.CPUNodeDiscoveryEnabled() {
-		if cpuModelLabel, err := CPUModelLabelFromCPUModel(vmi); err == nil {
-			if vmi.Spec.Domain.CPU.Model != v1.CPUModeHostModel && vmi.Spec.Domain.CPU.Model != v1.CPUModeHostPassthrough {
-				nodeSelector[cpuModelLabel] = "true"
-			}
-		}
-		for _, cpuFeatureLable := range CPUFeatureLabelsFromCPUFeatures(vmi) {
-			nodeSelector[cpuFeatureLable] = "true"
-		}
-	}
-
-	if t.clusterConfig.HypervStrictCheckEnabled() {
-		hvNodeSelectors := getHypervNodeSelectors(vmi)
-		for k, v := range hvNodeSelectors {
-			nodeSelector[k] = v
-		}
-	}
-
-	nodeSelector[v1.NodeSchedulable] = "true"
-	nodeSelectors := t.clusterConfig.GetNodeSelectors()
-	for k, v := range nodeSelectors {
-		nodeSelector[k] = v
-	}
+	nodeSelector := RequiredNodeSelector(vmi, t.clusterConfig)
 
 	podLabels := map[string]string{}
 
@@ -1022,6 +1079,12 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 				},
 			},
 		}
+		if emulator != "" {
+			sidecar.VolumeMounts = append(sidecar.VolumeMounts, k8sv1.VolumeMount{
+				Name:      "emulators",
+				MountPath: util.SidecarEmulatorsDir,
+			})
+		}
 		containers = append(containers, sidecar)
 	}
 
@@ -1286,6 +1349,175 @@ func (t *templateService) RenderHotplugAttachmentPodTemplate(volume *v1.Volume,
 	return pod, nil
 }
 
+// RenderImageVerifierPodTemplate renders a pod hashing the disk on the claim,
+// the digest is written to the termination message in the form sha256:<hex>
+func (t *templateService) RenderImageVerifierPodTemplate(name string, claimName string, isBlock bool) (*k8sv1.Pod, error) {
+	diskPath := "/pvc/disk.img"
+	if isBlock {
+		diskPath = "/dev/image"
+	}
+	zero := int64(0)
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				v1.AppLabel: "image-verifier",
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			RestartPolicy: k8sv1.RestartPolicyNever,
+			Containers: []k8sv1.Container{
+				{
+					Name:    "image-verifier",
+					Image:   t.launcherImage,
+					Command: []string{"/bin/sh", "-c", fmt.Sprintf("set -e\ndigest=$(sha256sum %s)\necho -n \"sha256:${digest%%%% *}\" > /dev/termination-log\n", diskPath)},
+					Resources: k8sv1.ResourceRequirements{
+						Limits: map[k8sv1.ResourceName]resource.Quantity{
+							k8sv1.ResourceCPU:    resource.MustParse("1"),
+							k8sv1.ResourceMemory: resource.MustParse("40M"),
+						},
+						Requests: map[k8sv1.ResourceName]resource.Quantity{
+							k8sv1.ResourceCPU:    resource.MustParse("10m"),
+							k8sv1.ResourceMemory: resource.MustParse("1M"),
+						},
+					},
+				},
+			},
+			Volumes: []k8sv1.Volume{
+				{
+					Name: "image",
+					VolumeSource: k8sv1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			TerminationGracePeriodSeconds: &zero,
+		},
+	}
+
+	if isBlock {
+		pod.Spec.Containers[0].VolumeDevices = []k8sv1.VolumeDevice{
+			{
+				Name:       "image",
+				DevicePath: diskPath,
+			},
+		}
+		pod.Spec.SecurityContext = &k8sv1.PodSecurityContext{
+			RunAsUser: &[]int64{0}[0],
+		}
+	} else {
+		pod.Spec.Containers[0].VolumeMounts = []k8sv1.VolumeMount{
+			{
+				Name:      "image",
+				MountPath: "/pvc",
+				ReadOnly:  true,
+			},
+		}
+	}
+	return pod, nil
+}
+
+// launcherLogVerbosity returns the log verbosity of the virt-launcher of the
+// VMI. The LauncherLogVerbosityAnnotation can only raise the verbosity
+// configured for the cluster, invalid values are ignored.
+func launcherLogVerbosity(vmi *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) uint {
+	verbosity := config.GetVirtLauncherVerbosity()
+	if value, ok := vmi.Annotations[v1.LauncherLogVerbosityAnnotation]; ok {
+		requested, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Warningf("Ignoring invalid value %q of annotation %s", value, v1.LauncherLogVerbosityAnnotation)
+		} else if uint(requested) > verbosity {
+			verbosity = uint(requested)
+		}
+	}
+	return verbosity
+}
+
+// RequiredNodeSelector returns the node selector the virt-launcher pod of the VMI is scheduled with
+func RequiredNodeSelector(vmi *v1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig) map[string]string {
+	nodeSelector := map[string]string{}
+
+	for k, v := range vmi.Spec.NodeSelector {
+		nodeSelector[k] = v
+	}
+
+	if vmi.IsCPUDedicated() {
+		// schedule only on nodes with a running cpu manager
+		nodeSelector[v1.CPUManager] = "true"
+	}
+
+	if clusterConfig.CPUNodeDiscoveryEnabled() {
+		if cpuModelLabel, err := CPUModelLabelFromCPUModel(vmi); err == nil {
+			if vmi.Spec.Domain.CPU.Model != v1.CPUModeHostModel && vmi.Spec.Domain.CPU.Model != v1.CPUModeHostPassthrough {
+				nodeSelector[cpuModelLabel] = "true"
+			}
+		}
+		for _, cpuFeatureLable := range CPUFeatureLabelsFromCPUFeatures(vmi) {
+			nodeSelector[cpuFeatureLable] = "true"
+		}
+	}
+
+	if clusterConfig.HypervStrictCheckEnabled() {
+		hvNodeSelectors := getHypervNodeSelectors(vmi)
+		for k, v := range hvNodeSelectors {
+			nodeSelector[k] = v
+		}
+	}
+
+	if requiresVhostNetZeroCopyTX(vmi) {
+		// schedule only on nodes where vhost-net transmits without copying
+		nodeSelector[v1.VhostNetZeroCopyTX] = "true"
+	}
+
+	if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.NestedVirtualization {
+		// schedule only on nodes where KVM allows nested virtualization
+		nodeSelector[v1.NestedVirtualization] = "true"
+	}
+
+	if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.SideChannelIsolation {
+		// schedule only on nodes mitigating all CPU vulnerabilities
+		nodeSelector[v1.SideChannelIsolation] = "true"
+	}
+
+	nodeSelector[v1.NodeSchedulable] = "true"
+	nodeSelectors := clusterConfig.GetNodeSelectors()
+	for k, v := range nodeSelectors {
+		nodeSelector[k] = v
+	}
+	return nodeSelector
+}
+
+// NodeDensityResources returns the extended resources the virt-launcher pod of
+// the VMI requests to respect the node density configuration
+func NodeDensityResources(vmi *v1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig) k8sv1.ResourceList {
+	res := k8sv1.ResourceList{}
+	if clusterConfig.GetMaxVMIsPerNode() > 0 {
+		res[v1.VMIResource] = resource.MustParse("1")
+	}
+	if clusterConfig.GetMemoryOvercommitBudget() != nil {
+		if overcommit := MemoryOvercommit(vmi); overcommit.Sign() > 0 {
+			res[v1.MemoryOvercommitResource] = overcommit
+		}
+	}
+	return res
+}
+
+// MemoryOvercommit returns the guest memory of the VMI beyond its memory request
+func MemoryOvercommit(vmi *v1.VirtualMachineInstance) resource.Quantity {
+	if vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Guest == nil {
+		return *resource.NewQuantity(0, resource.BinarySI)
+	}
+	overcommit := resource.NewQuantity(vmi.Spec.Domain.Memory.Guest.Value(), resource.BinarySI)
+	overcommit.Sub(*vmi.Spec.Domain.Resources.Requests.Memory())
+	if overcommit.Sign() < 0 {
+		return *resource.NewQuantity(0, resource.BinarySI)
+	}
+	return *overcommit
+}
+
 func getRequiredCapabilities(vmi *v1.VirtualMachineInstance) []k8sv1.Capability {
 	res := []k8sv1.Capability{}
 	if (len(vmi.Spec.Domain.Devices.Interfaces) > 0) ||
@@ -1464,6 +1696,15 @@ func getNetworkToResourceMap(virtClient kubecli.KubevirtClient, vmi *v1.VirtualM
 	for _, network := range vmi.Spec.Networks {
 		if network.Multus != nil {
 			namespace, networkName := getNamespaceAndNetworkName(vmi, network.Multus.NetworkName)
+			if namespace != vmi.Namespace {
+				allowed, message, err := nad.CanServiceAccountUseNetwork(virtClient.AuthorizationV1().SubjectAccessReviews(), namespace, networkName, vmi.Namespace, nad.ServiceAccountName(&vmi.Spec))
+				if err != nil {
+					return map[string]string{}, fmt.Errorf("Failed to authorize the use of network attachment definition %s/%s: %v", namespace, networkName, err)
+				}
+				if !allowed {
+					return map[string]string{}, fmt.Errorf("%s", message)
+				}
+			}
 			crd, err := virtClient.NetworkClient().K8sCniCncfIoV1().NetworkAttachmentDefinitions(namespace).Get(networkName, metav1.GetOptions{})
 			if err != nil {
 				return map[string]string{}, fmt.Errorf("Failed to locate network attachment definition %s/%s", namespace, networkName)
@@ -1474,6 +1715,15 @@ func getNetworkToResourceMap(virtClient kubecli.KubevirtClient, vmi *v1.VirtualM
 	return
 }
 
+func requiresVhostNetZeroCopyTX(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.VhostZeroCopyTX != nil && *iface.VhostZeroCopyTX {
+			return true
+		}
+	}
+	return false
+}
+
 func getIfaceByName(vmi *v1.VirtualMachineInstance, name string) *v1.Interface {
 	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
 		if iface.Name == name {
@@ -29,12 +29,17 @@ import (
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	authv1 "k8s.io/api/authorization/v1"
 	kubev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	fakenetworkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned/fake"
@@ -42,6 +47,7 @@ import (
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -54,6 +60,7 @@ var _ = Describe("Template", func() {
 
 	pvcCache := cache.NewIndexer(cache.DeletionHandlingMetaNamespaceKeyFunc, nil)
 	var svc TemplateService
+	var networkUseAllowed bool
 
 	ctrl := gomock.NewController(GinkgoT())
 	virtClient := kubecli.NewMockKubevirtClient(ctrl)
@@ -85,6 +92,14 @@ var _ = Describe("Template", func() {
 		// Set up mock clients
 		networkClient := fakenetworkclient.NewSimpleClientset()
 		virtClient.EXPECT().NetworkClient().Return(networkClient).AnyTimes()
+		networkUseAllowed = true
+		kubeClient := k8sfake.NewSimpleClientset()
+		kubeClient.Fake.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			sar := action.(k8stesting.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+			sar.Status.Allowed = networkUseAllowed
+			return true, sar, nil
+		})
+		virtClient.EXPECT().AuthorizationV1().Return(kubeClient.AuthorizationV1()).AnyTimes()
 		// Sadly, we cannot pass desired attachment objects into
 		// Clientset constructor because UnsafeGuessKindToResource
 		// calculates incorrect object kind (without dashes). Instead
@@ -388,6 +403,37 @@ var _ = Describe("Template", func() {
 				Expect(debugLogsValue).To(Equal("1"))
 			})
 		})
+		Context("with launcher log verbosity", func() {
+			table.DescribeTable("should pass the verbosity to virt-launcher", func(annotations map[string]string, expectedVerbosity string) {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "testvmi",
+						Namespace:   "default",
+						UID:         "1234",
+						Annotations: annotations,
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Env).To(ContainElement(kubev1.EnvVar{
+					Name:  ENV_VAR_VIRT_LAUNCHER_LOG_VERBOSITY,
+					Value: expectedVerbosity,
+				}))
+			},
+				table.Entry("of the cluster without annotation", nil, "2"),
+				table.Entry("of the annotation if it is higher", map[string]string{v1.LauncherLogVerbosityAnnotation: "6"}, "6"),
+				table.Entry("of the cluster if the annotation is lower", map[string]string{v1.LauncherLogVerbosityAnnotation: "1"}, "2"),
+				table.Entry("of the cluster if the annotation is invalid", map[string]string{v1.LauncherLogVerbosityAnnotation: "verbose"}, "2"),
+			)
+		})
 
 		Context("with access credentials", func() {
 			It("should add volume with secret referenced by cloud-init user secret ref", func() {
@@ -728,6 +774,33 @@ var _ = Describe("Template", func() {
 					"]")
 				Expect(value).To(Equal(expectedIfaces))
 			})
+			It("should fail when the use of a multus network of another namespace was not granted", func() {
+				networkUseAllowed = false
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+						},
+						Networks: []v1.Network{
+							{Name: "other-test1",
+								NetworkSource: v1.NetworkSource{
+									Multus: &v1.MultusNetwork{NetworkName: "other-namespace/test1"},
+								}},
+						},
+					},
+				}
+
+				_, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("not allowed to use network attachment definition other-namespace/test1"))
+			})
 			It("should add default multus networks in the multus default-network annotation", func() {
 				vmi := v1.VirtualMachineInstance{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1151,6 +1224,120 @@ var _ = Describe("Template", func() {
 				Expect(found).To(BeTrue(), "Expected compute container to be granted SYS_NICE capability")
 				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(v1.CPUManager, "true"))
 			})
+			It("should schedule interfaces requesting zero copy transmission on capable nodes", func() {
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+				vmi.Spec.Domain.Devices.DisableHotplug = true
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+				vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).ToNot(HaveKey(v1.VhostNetZeroCopyTX))
+
+				vmi.Spec.Domain.Devices.Interfaces[0].VhostZeroCopyTX = &[]bool{true}[0]
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.VhostNetZeroCopyTX, "true"))
+			})
+			It("should schedule VMIs requesting nested virtualization on capable nodes", func() {
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).ToNot(HaveKey(v1.NestedVirtualization))
+
+				vmi.Spec.Domain.CPU = &v1.CPU{NestedVirtualization: true}
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.NestedVirtualization, "true"))
+			})
+			It("should schedule VMIs requesting side-channel isolation on isolating nodes", func() {
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).ToNot(HaveKey(v1.SideChannelIsolation))
+
+				vmi.Spec.Domain.CPU = &v1.CPU{SideChannelIsolation: true}
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.SideChannelIsolation, "true"))
+			})
+			It("should enable the profiler of virt-launcher with the Profiling feature gate", func() {
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Command).ToNot(ContainElement("--profiling"))
+
+				enableFeatureGate(virtconfig.ProfilingGate)
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Command).To(ContainElement("--profiling"))
+			})
+			It("should make virt-launcher write versioned network cache files with the NetworkCacheVersioning feature gate", func() {
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Command).ToNot(ContainElement("--network-cache-versioning"))
+
+				enableFeatureGate(virtconfig.NetworkCacheVersioningGate)
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Command).To(ContainElement("--network-cache-versioning"))
+			})
+			It("should select the emulator of the annotation with the EmulatorSelection feature gate", func() {
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+				vmi.Annotations = map[string]string{v1.EmulatorAnnotation: "qemu-6.0"}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Command).ToNot(ContainElement("--emulator"))
+
+				enableFeatureGate(virtconfig.EmulatorSelectionGate)
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Command).To(ContainElements("--emulator", "qemu-6.0"))
+				Expect(pod.Spec.Containers[0].VolumeMounts).ToNot(ContainElement(kubev1.VolumeMount{Name: "emulators", MountPath: util.SidecarEmulatorsDir}))
+			})
+			It("should share the emulators directory with the hook sidecars", func() {
+				enableFeatureGate(virtconfig.EmulatorSelectionGate)
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+				vmi.Annotations = map[string]string{
+					v1.EmulatorAnnotation:               "qemu-6.0",
+					hooks.HookSidecarListAnnotationName: `[{"image": "some-image:v1", "imagePullPolicy": "IfNotPresent"}]`,
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				mount := kubev1.VolumeMount{Name: "emulators", MountPath: util.SidecarEmulatorsDir}
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(mount))
+				Expect(pod.Spec.Containers[1].VolumeMounts).To(ContainElement(mount))
+			})
+			table.DescribeTable("should apply the emulation policy", func(policy string, allowEmulation *bool, emulated bool) {
+				testutils.UpdateFakeClusterConfig(configMapInformer, &kubev1.ConfigMap{
+					Data: map[string]string{virtconfig.EmulationPolicyKey: policy},
+				})
+				vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+				vmi.Spec.Domain.AllowEmulation = allowEmulation
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				if emulated {
+					Expect(pod.Spec.Containers[0].Command).To(ContainElement("--use-emulation"))
+					Expect(pod.Spec.Containers[0].Resources.Limits).ToNot(HaveKey(kubev1.ResourceName(KvmDevice)))
+				} else {
+					Expect(pod.Spec.Containers[0].Command).ToNot(ContainElement("--use-emulation"))
+					Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveKey(kubev1.ResourceName(KvmDevice)))
+				}
+			},
+				table.Entry("requiring KVM with policy Never", "Never", pointer.BoolPtr(true), false),
+				table.Entry("requiring KVM with policy OptIn by default", "OptIn", nil, false),
+				table.Entry("allowing emulation with policy OptIn if the VMI opts in", "OptIn", pointer.BoolPtr(true), true),
+				table.Entry("allowing emulation with policy Always by default", "Always", nil, true),
+				table.Entry("requiring KVM with policy Always if the VMI opts out", "Always", pointer.BoolPtr(false), false),
+			)
 			It("should allocate 1 more cpu when isolateEmulatorThread requested", func() {
 				vmi := v1.VirtualMachineInstance{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1747,6 +1934,140 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with encrypted pvc source", func() {
+			It("should add the secret holding the passphrase to template", func() {
+				namespace := "testns"
+				pvcName := "pvcEncrypted"
+				pvc := kubev1.PersistentVolumeClaim{
+					TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: pvcName},
+				}
+				err := pvcCache.Add(&pvc)
+				Expect(err).ToNot(HaveOccurred(), "Added PVC to cache successfully")
+
+				volumes := []v1.Volume{
+					{
+						Name: "pvc-volume",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+						},
+						Encryption: &v1.VolumeEncryption{
+							SecretRef: &kubev1.LocalObjectReference{Name: "luks-secret"},
+						},
+					},
+				}
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: namespace, UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{Volumes: volumes, Domain: v1.DomainSpec{
+						Devices: v1.Devices{
+							DisableHotplug: true,
+						},
+					}},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred(), "Render manifest successfully")
+
+				var secretVolume *kubev1.Volume
+				for i, volume := range pod.Spec.Volumes {
+					if volume.Name == "pvc-volume-luks" {
+						secretVolume = &pod.Spec.Volumes[i]
+					}
+				}
+				Expect(secretVolume).ToNot(BeNil(), "could not find the passphrase secret volume")
+				Expect(secretVolume.Secret.SecretName).To(Equal("luks-secret"))
+
+				var secretVolumeMount *kubev1.VolumeMount
+				for i, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+					if volumeMount.Name == "pvc-volume-luks" {
+						secretVolumeMount = &pod.Spec.Containers[0].VolumeMounts[i]
+					}
+				}
+				Expect(secretVolumeMount).ToNot(BeNil(), "could not find the passphrase secret volume mount")
+				Expect(secretVolumeMount.MountPath).To(Equal("/var/run/kubevirt-private/disk-encryption/pvc-volume"))
+				Expect(secretVolumeMount.ReadOnly).To(BeTrue())
+			})
+		})
+
+		Context("with hibernation claim", func() {
+			It("should attach the claim to the compute container", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "testns", UID: "1234",
+						Annotations: map[string]string{v1.HibernationClaimAnnotation: "testvmi-hibernation"},
+					},
+					Spec: v1.VirtualMachineInstanceSpec{Domain: v1.DomainSpec{
+						Devices: v1.Devices{
+							DisableHotplug: true,
+						},
+					}},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred(), "Render manifest successfully")
+
+				var claimVolume *kubev1.Volume
+				for i, volume := range pod.Spec.Volumes {
+					if volume.Name == "hibernation" {
+						claimVolume = &pod.Spec.Volumes[i]
+					}
+				}
+				Expect(claimVolume).ToNot(BeNil(), "could not find the hibernation volume")
+				Expect(claimVolume.PersistentVolumeClaim.ClaimName).To(Equal("testvmi-hibernation"))
+
+				var claimVolumeMount *kubev1.VolumeMount
+				for i, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+					if volumeMount.Name == "hibernation" {
+						claimVolumeMount = &pod.Spec.Containers[0].VolumeMounts[i]
+					}
+				}
+				Expect(claimVolumeMount).ToNot(BeNil(), "could not find the hibernation volume mount")
+				Expect(claimVolumeMount.MountPath).To(Equal("/var/run/kubevirt-private/hibernation"))
+			})
+		})
+
+		Context("with vhost-user-blk volume", func() {
+			It("should mount the claim holding the socket to the compute container", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: "testns", UID: "1234"},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{},
+						Volumes: []v1.Volume{
+							{
+								Name: "spdk",
+								VolumeSource: v1.VolumeSource{
+									VhostUserBlk: &v1.VhostUserBlkSource{ClaimName: "spdk-claim", Socket: "vhost.0"},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred(), "Render manifest successfully")
+
+				var claimVolume *kubev1.Volume
+				for i, volume := range pod.Spec.Volumes {
+					if volume.Name == "spdk" {
+						claimVolume = &pod.Spec.Volumes[i]
+					}
+				}
+				Expect(claimVolume).ToNot(BeNil(), "could not find the vhost-user-blk volume")
+				Expect(claimVolume.PersistentVolumeClaim.ClaimName).To(Equal("spdk-claim"))
+
+				var claimVolumeMount *kubev1.VolumeMount
+				for i, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+					if volumeMount.Name == "spdk" {
+						claimVolumeMount = &pod.Spec.Containers[0].VolumeMounts[i]
+					}
+				}
+				Expect(claimVolumeMount).ToNot(BeNil(), "could not find the vhost-user-blk volume mount")
+				Expect(claimVolumeMount.MountPath).To(Equal("/var/run/kubevirt-private/vmi-disks/spdk"))
+			})
+		})
+
 		Context("with blockdevice mode pvc source", func() {
 			It("should add device to template", func() {
 				namespace := "testns"
@@ -2675,6 +2996,66 @@ func False() *bool {
 	return &b
 }
 
+var _ = Describe("Node density resources", func() {
+	newClusterConfig := func(density *v1.NodeDensityConfiguration) *virtconfig.ClusterConfig {
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{},
+					NodeDensity:            density,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		return config
+	}
+
+	newVMI := func(request string, guest string) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Resources.Requests = kubev1.ResourceList{
+			kubev1.ResourceMemory: resource.MustParse(request),
+		}
+		if guest != "" {
+			guestMemory := resource.MustParse(guest)
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guestMemory}
+		}
+		return vmi
+	}
+
+	It("should not request any resource without a node density configuration", func() {
+		Expect(NodeDensityResources(newVMI("1Gi", "2Gi"), newClusterConfig(nil))).To(BeEmpty())
+	})
+
+	It("should request a VMI slot if the VMIs per node are limited", func() {
+		config := newClusterConfig(&v1.NodeDensityConfiguration{MaxVMIsPerNode: 50})
+		Expect(NodeDensityResources(newVMI("1Gi", "2Gi"), config)).To(Equal(kubev1.ResourceList{
+			v1.VMIResource: resource.MustParse("1"),
+		}))
+	})
+
+	table.DescribeTable("should request the overcommitted memory if the budget is limited", func(request string, guest string, expected kubev1.ResourceList) {
+		budget := resource.MustParse("64Gi")
+		config := newClusterConfig(&v1.NodeDensityConfiguration{MemoryOvercommitBudget: &budget})
+		resources := NodeDensityResources(newVMI(request, guest), config)
+		Expect(resources).To(HaveLen(len(expected)))
+		for key, value := range expected {
+			Expect(resources).To(HaveKey(key))
+			actual := resources[key]
+			Expect(actual.Cmp(value)).To(BeZero())
+		}
+	},
+		table.Entry("for an overcommitted VMI", "1Gi", "3Gi", kubev1.ResourceList{v1.MemoryOvercommitResource: resource.MustParse("2Gi")}),
+		table.Entry("not for a VMI without guest memory", "1Gi", "", kubev1.ResourceList{}),
+		table.Entry("not for a VMI which is not overcommitted", "2Gi", "2Gi", kubev1.ResourceList{}),
+	)
+})
+
 func TestTemplate(t *testing.T) {
 	log.Log.SetIOWriter(GinkgoWriter)
 	RegisterFailHandler(Fail)
@@ -44,21 +44,31 @@ import (
 	"kubevirt.io/kubevirt/pkg/healthz"
 
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
 	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
+	idlemetrics "kubevirt.io/kubevirt/pkg/monitoring/idle/prometheus"
+	snapshotmetrics "kubevirt.io/kubevirt/pkg/monitoring/snapshot/prometheus"
 	"kubevirt.io/kubevirt/pkg/service"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/leaderelectionconfig"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/cpubaseline"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/idle"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/notification"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/powerschedule"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/replication"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/snapshot"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/usage"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/vmimport"
 )
 
 const (
@@ -134,16 +144,34 @@ type VirtControllerApp struct {
 
 	dataVolumeInformer cache.SharedIndexInformer
 
+	networkAttachmentDefinitionInformer cache.SharedIndexInformer
+
 	migrationController *MigrationController
 	migrationInformer   cache.SharedIndexInformer
 
-	snapshotController        *snapshot.VMSnapshotController
-	restoreController         *snapshot.VMRestoreController
-	vmSnapshotInformer        cache.SharedIndexInformer
-	vmSnapshotContentInformer cache.SharedIndexInformer
-	vmRestoreInformer         cache.SharedIndexInformer
-	storageClassInformer      cache.SharedIndexInformer
-	allPodInformer            cache.SharedIndexInformer
+	snapshotController         *snapshot.VMSnapshotController
+	restoreController          *snapshot.VMRestoreController
+	vmSnapshotInformer         cache.SharedIndexInformer
+	vmSnapshotContentInformer  cache.SharedIndexInformer
+	vmSnapshotScheduleInformer cache.SharedIndexInformer
+	vmRestoreInformer          cache.SharedIndexInformer
+	ovfImportController        *vmimport.OVFImportController
+	vmOVFImportInformer        cache.SharedIndexInformer
+	v2vImportController        *vmimport.V2VImportController
+	vmV2VImportInformer        cache.SharedIndexInformer
+	clusterImportController    *vmimport.ClusterImportController
+	vmClusterImportInformer    cache.SharedIndexInformer
+	usageController            *usage.UsageController
+	notificationController     *notification.NotificationController
+	replicationController      *replication.ReplicationController
+	powerScheduleController    *powerschedule.PowerScheduleController
+	idleController             *idle.IdleController
+	cpuBaselineController      *cpubaseline.CPUBaselineController
+	vmNotificationHookInformer cache.SharedIndexInformer
+	fencingRequestInformer     cache.SharedIndexInformer
+	storageClassInformer       cache.SharedIndexInformer
+	allPodInformer             cache.SharedIndexInformer
+	namespaceInformer          cache.SharedIndexInformer
 
 	crdInformer cache.SharedIndexInformer
 
@@ -165,6 +193,8 @@ type VirtControllerApp struct {
 
 	// indicates if controllers were started with or without CDI/DataVolume support
 	hasCDI bool
+	// indicates if controllers were started with or without multus/NetworkAttachmentDefinition support
+	hasMultus bool
 	// the channel used to trigger re-initialization.
 	reInitChan chan string
 
@@ -179,6 +209,15 @@ type VirtControllerApp struct {
 	launcherSubGid                    int64
 	snapshotControllerThreads         int
 	restoreControllerThreads          int
+	ovfImportControllerThreads        int
+	v2vImportControllerThreads        int
+	clusterImportControllerThreads    int
+	usageControllerThreads            int
+	notificationControllerThreads     int
+	replicationControllerThreads      int
+	powerScheduleControllerThreads    int
+	idleControllerThreads             int
+	cpuBaselineControllerThreads      int
 	snapshotControllerResyncPeriod    time.Duration
 
 	caConfigMapName  string
@@ -191,6 +230,7 @@ var _ service.Service = &VirtControllerApp{}
 func init() {
 	vsv1beta1.AddToScheme(scheme.Scheme)
 	snapshotv1.AddToScheme(scheme.Scheme)
+	vmimportv1.AddToScheme(scheme.Scheme)
 
 	prometheus.MustRegister(leaderGauge)
 	prometheus.MustRegister(readyGauge)
@@ -238,6 +278,7 @@ func Execute() {
 
 	app.reInitChan = make(chan string, 10)
 	app.hasCDI = app.clusterConfig.HasDataVolumeAPI()
+	app.hasMultus = app.clusterConfig.HasNetworkAttachmentDefinitionAPI()
 	app.clusterConfig.SetConfigModifiedCallback(app.configModificationCallback)
 
 	webService := new(restful.WebService)
@@ -266,9 +307,16 @@ func Execute() {
 
 	app.vmSnapshotInformer = app.informerFactory.VirtualMachineSnapshot()
 	app.vmSnapshotContentInformer = app.informerFactory.VirtualMachineSnapshotContent()
+	app.vmSnapshotScheduleInformer = app.informerFactory.VirtualMachineSnapshotSchedule()
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
+	app.vmOVFImportInformer = app.informerFactory.VirtualMachineOVFImport()
+	app.vmV2VImportInformer = app.informerFactory.VirtualMachineV2VImport()
+	app.vmClusterImportInformer = app.informerFactory.VirtualMachineClusterImport()
+	app.vmNotificationHookInformer = app.informerFactory.VirtualMachineNotificationHook()
+	app.fencingRequestInformer = app.informerFactory.FencingRequest()
 	app.storageClassInformer = app.informerFactory.StorageClass()
 	app.allPodInformer = app.informerFactory.Pod()
+	app.namespaceInformer = app.informerFactory.Namespace()
 
 	if app.hasCDI {
 		app.dataVolumeInformer = app.informerFactory.DataVolume()
@@ -281,6 +329,16 @@ func Execute() {
 		log.Log.Infof("CDI not detected, DataVolume integration disabled")
 	}
 
+	if app.hasMultus {
+		app.networkAttachmentDefinitionInformer = app.informerFactory.NetworkAttachmentDefinition()
+		log.Log.Infof("Multus detected, NetworkAttachmentDefinition integration enabled")
+	} else {
+		// Without the multus API no definition can be found, the dummy informer
+		// lets the controller report the multus networks of VMIs as missing.
+		app.networkAttachmentDefinitionInformer = app.informerFactory.DummyNetworkAttachmentDefinition()
+		log.Log.Infof("Multus not detected, NetworkAttachmentDefinition integration disabled")
+	}
+
 	app.initCommon()
 	app.initReplicaSet()
 	app.initVirtualMachines()
@@ -288,6 +346,15 @@ func Execute() {
 	app.initEvacuationController()
 	app.initSnapshotController()
 	app.initRestoreController()
+	app.initOVFImportController()
+	app.initV2VImportController()
+	app.initClusterImportController()
+	app.initUsageController()
+	app.initNotificationController()
+	app.initReplicationController()
+	app.initPowerScheduleController()
+	app.initIdleController()
+	app.initCPUBaselineController()
 	go app.Run()
 
 	select {
@@ -299,6 +366,11 @@ func Execute() {
 // Detects if a config has been applied that requires
 // re-initializing virt-controller.
 func (vca *VirtControllerApp) configModificationCallback() {
+	verbosity := int(vca.clusterConfig.GetVirtControllerVerbosity())
+	if err := log.Log.SetVerbosityLevel(verbosity); err != nil {
+		log.Log.Reason(err).Errorf("Failed to set the log verbosity of virt-controller to %d", verbosity)
+	}
+
 	newHasCDI := vca.clusterConfig.HasDataVolumeAPI()
 	if newHasCDI != vca.hasCDI {
 		if newHasCDI {
@@ -307,6 +379,17 @@ func (vca *VirtControllerApp) configModificationCallback() {
 			log.Log.Infof("Reinitialize virt-controller, cdi api has been removed")
 		}
 		vca.reInitChan <- "reinit"
+		return
+	}
+
+	newHasMultus := vca.clusterConfig.HasNetworkAttachmentDefinitionAPI()
+	if newHasMultus != vca.hasMultus {
+		if newHasMultus {
+			log.Log.Infof("Reinitialize virt-controller, multus api has been introduced")
+		} else {
+			log.Log.Infof("Reinitialize virt-controller, multus api has been removed")
+		}
+		vca.reInitChan <- "reinit"
 	}
 }
 
@@ -394,6 +477,15 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		go vca.migrationController.Run(vca.migrationControllerThreads, stop)
 		go vca.snapshotController.Run(vca.snapshotControllerThreads, stop)
 		go vca.restoreController.Run(vca.restoreControllerThreads, stop)
+		go vca.ovfImportController.Run(vca.ovfImportControllerThreads, stop)
+		go vca.v2vImportController.Run(vca.v2vImportControllerThreads, stop)
+		go vca.clusterImportController.Run(vca.clusterImportControllerThreads, stop)
+		go vca.usageController.Run(vca.usageControllerThreads, stop)
+		go vca.notificationController.Run(vca.notificationControllerThreads, stop)
+		go vca.replicationController.Run(vca.replicationControllerThreads, stop)
+		go vca.powerScheduleController.Run(vca.powerScheduleControllerThreads, stop)
+		go vca.idleController.Run(vca.idleControllerThreads, stop)
+		go vca.cpuBaselineController.Run(vca.cpuBaselineControllerThreads, stop)
 		cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced)
 		close(vca.readyChan)
 		leaderGauge.Set(1)
@@ -428,15 +520,15 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.launcherSubGid,
 	)
 
-	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.persistentVolumeClaimInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer)
+	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.persistentVolumeClaimInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer, vca.networkAttachmentDefinitionInformer, vca.clusterConfig)
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "node-controller")
-	vca.nodeController = NewNodeController(vca.clientSet, vca.nodeInformer, vca.vmiInformer, recorder)
+	vca.nodeController = NewNodeController(vca.clientSet, vca.nodeInformer, vca.vmiInformer, vca.fencingRequestInformer, recorder, vca.clusterConfig, vca.kubevirtNamespace)
 	vca.migrationController = NewMigrationController(vca.templateService, vca.vmiInformer, vca.kvPodInformer, vca.migrationInformer, vca.vmiRecorder, vca.clientSet, vca.clusterConfig)
 }
 
 func (vca *VirtControllerApp) initReplicaSet() {
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "virtualmachinereplicaset-controller")
-	vca.rsController = NewVMIReplicaSet(vca.vmiInformer, vca.rsInformer, recorder, vca.clientSet, controller.BurstReplicas)
+	vca.rsController = NewVMIReplicaSet(vca.vmiInformer, vca.rsInformer, vca.dataVolumeInformer, vca.persistentVolumeClaimInformer, vca.storageClassInformer, recorder, vca.clientSet, controller.BurstReplicas)
 }
 
 func (vca *VirtControllerApp) initVirtualMachines() {
@@ -447,6 +539,7 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 		vca.vmInformer,
 		vca.dataVolumeInformer,
 		vca.persistentVolumeClaimInformer,
+		vca.storageClassInformer,
 		recorder,
 		vca.clientSet)
 }
@@ -456,8 +549,10 @@ func (vca *VirtControllerApp) initDisruptionBudgetController() {
 	vca.disruptionBudgetController = disruptionbudget.NewDisruptionBudgetController(
 		vca.vmiInformer,
 		vca.informerFactory.K8SInformerFactory().Policy().V1beta1().PodDisruptionBudgets().Informer(),
+		vca.migrationInformer,
 		recorder,
 		vca.clientSet,
+		vca.clusterConfig,
 	)
 
 }
@@ -477,20 +572,22 @@ func (vca *VirtControllerApp) initEvacuationController() {
 func (vca *VirtControllerApp) initSnapshotController() {
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "snapshot-controller")
 	vca.snapshotController = &snapshot.VMSnapshotController{
-		Client:                    vca.clientSet,
-		VMSnapshotInformer:        vca.vmSnapshotInformer,
-		VMSnapshotContentInformer: vca.vmSnapshotContentInformer,
-		VMInformer:                vca.vmInformer,
-		VMIInformer:               vca.vmiInformer,
-		StorageClassInformer:      vca.storageClassInformer,
-		PVCInformer:               vca.persistentVolumeClaimInformer,
-		CRDInformer:               vca.crdInformer,
-		PodInformer:               vca.allPodInformer,
-		DVInformer:                vca.dataVolumeInformer,
-		Recorder:                  recorder,
-		ResyncPeriod:              vca.snapshotControllerResyncPeriod,
+		Client:                     vca.clientSet,
+		VMSnapshotInformer:         vca.vmSnapshotInformer,
+		VMSnapshotContentInformer:  vca.vmSnapshotContentInformer,
+		VMSnapshotScheduleInformer: vca.vmSnapshotScheduleInformer,
+		VMInformer:                 vca.vmInformer,
+		VMIInformer:                vca.vmiInformer,
+		StorageClassInformer:       vca.storageClassInformer,
+		PVCInformer:                vca.persistentVolumeClaimInformer,
+		CRDInformer:                vca.crdInformer,
+		PodInformer:                vca.allPodInformer,
+		DVInformer:                 vca.dataVolumeInformer,
+		Recorder:                   recorder,
+		ResyncPeriod:               vca.snapshotControllerResyncPeriod,
 	}
 	vca.snapshotController.Init()
+	snapshotmetrics.SetupLastBackupCollector(vca.vmInformer)
 }
 
 func (vca *VirtControllerApp) initRestoreController() {
@@ -510,6 +607,119 @@ func (vca *VirtControllerApp) initRestoreController() {
 	vca.restoreController.Init()
 }
 
+func (vca *VirtControllerApp) initOVFImportController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "ovf-import-controller")
+	vca.ovfImportController = &vmimport.OVFImportController{
+		Client:              vca.clientSet,
+		VMOVFImportInformer: vca.vmOVFImportInformer,
+		VMInformer:          vca.vmInformer,
+		DataVolumeInformer:  vca.dataVolumeInformer,
+		PodInformer:         vca.kvPodInformer,
+		ClusterConfig:       vca.clusterConfig,
+		Recorder:            recorder,
+	}
+	vca.ovfImportController.Init()
+}
+
+func (vca *VirtControllerApp) initV2VImportController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "v2v-import-controller")
+	vca.v2vImportController = &vmimport.V2VImportController{
+		Client:              vca.clientSet,
+		VMV2VImportInformer: vca.vmV2VImportInformer,
+		VMInformer:          vca.vmInformer,
+		PodInformer:         vca.kvPodInformer,
+		PVCInformer:         vca.persistentVolumeClaimInformer,
+		ClusterConfig:       vca.clusterConfig,
+		Recorder:            recorder,
+	}
+	vca.v2vImportController.Init()
+}
+
+func (vca *VirtControllerApp) initClusterImportController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "cluster-import-controller")
+	vca.clusterImportController = &vmimport.ClusterImportController{
+		Client:                  vca.clientSet,
+		VMClusterImportInformer: vca.vmClusterImportInformer,
+		VMInformer:              vca.vmInformer,
+		DataVolumeInformer:      vca.dataVolumeInformer,
+		ClusterConfig:           vca.clusterConfig,
+		Recorder:                recorder,
+	}
+	vca.clusterImportController.Init()
+}
+
+func (vca *VirtControllerApp) initUsageController() {
+	vca.usageController = &usage.UsageController{
+		Client:        vca.clientSet,
+		VMInformer:    vca.vmInformer,
+		VMIInformer:   vca.vmiInformer,
+		PVCInformer:   vca.persistentVolumeClaimInformer,
+		ClusterConfig: vca.clusterConfig,
+	}
+	vca.usageController.Init()
+}
+
+func (vca *VirtControllerApp) initNotificationController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "notification-controller")
+	vca.notificationController = &notification.NotificationController{
+		Client:                   vca.clientSet,
+		VMIInformer:              vca.vmiInformer,
+		NotificationHookInformer: vca.vmNotificationHookInformer,
+		ClusterConfig:            vca.clusterConfig,
+		Recorder:                 recorder,
+	}
+	vca.notificationController.Init()
+}
+
+func (vca *VirtControllerApp) initReplicationController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "replication-controller")
+	vca.replicationController = &replication.ReplicationController{
+		Client:               vca.clientSet,
+		VMInformer:           vca.vmInformer,
+		VMIInformer:          vca.vmiInformer,
+		PVCInformer:          vca.persistentVolumeClaimInformer,
+		StorageClassInformer: vca.storageClassInformer,
+		ClusterConfig:        vca.clusterConfig,
+		Recorder:             recorder,
+	}
+	vca.replicationController.Init()
+}
+
+func (vca *VirtControllerApp) initPowerScheduleController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "power-schedule-controller")
+	vca.powerScheduleController = &powerschedule.PowerScheduleController{
+		Client:      vca.clientSet,
+		VMInformer:  vca.vmInformer,
+		VMIInformer: vca.vmiInformer,
+		Recorder:    recorder,
+	}
+	vca.powerScheduleController.Init()
+}
+
+func (vca *VirtControllerApp) initIdleController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "idle-controller")
+	vca.idleController = &idle.IdleController{
+		Client:            vca.clientSet,
+		VMInformer:        vca.vmInformer,
+		VMIInformer:       vca.vmiInformer,
+		NamespaceInformer: vca.namespaceInformer,
+		ClusterConfig:     vca.clusterConfig,
+		Recorder:          recorder,
+	}
+	vca.idleController.Init()
+	idlemetrics.SetupIdleCollector(vca.vmInformer)
+}
+
+func (vca *VirtControllerApp) initCPUBaselineController() {
+	vca.cpuBaselineController = &cpubaseline.CPUBaselineController{
+		Client:           vca.clientSet,
+		NodeInformer:     vca.nodeInformer,
+		KubeVirtInformer: vca.kubeVirtInformer,
+		ClusterConfig:    vca.clusterConfig,
+	}
+	vca.cpuBaselineController.Init()
+}
+
 func (vca *VirtControllerApp) leaderProbe(_ *restful.Request, response *restful.Response) {
 	res := map[string]interface{}{}
 
@@ -588,6 +798,33 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.restoreControllerThreads, "restore-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for restore controller")
 
+	flag.IntVar(&vca.ovfImportControllerThreads, "ovf-import-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for OVF import controller")
+
+	flag.IntVar(&vca.v2vImportControllerThreads, "v2v-import-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for V2V import controller")
+
+	flag.IntVar(&vca.clusterImportControllerThreads, "cluster-import-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for cluster import controller")
+
+	flag.IntVar(&vca.usageControllerThreads, "usage-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for usage controller")
+
+	flag.IntVar(&vca.notificationControllerThreads, "notification-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for notification controller")
+
+	flag.IntVar(&vca.replicationControllerThreads, "replication-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for replication controller")
+
+	flag.IntVar(&vca.powerScheduleControllerThreads, "power-schedule-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for power schedule controller")
+
+	flag.IntVar(&vca.idleControllerThreads, "idle-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for idle controller")
+
+	flag.IntVar(&vca.cpuBaselineControllerThreads, "cpu-baseline-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for CPU baseline controller")
+
 	flag.DurationVar(&vca.snapshotControllerResyncPeriod, "snapshot-controller-resync-period", defaultSnapshotControllerResyncPeriod,
 		"Number of goroutines to run for snapshot controller")
 
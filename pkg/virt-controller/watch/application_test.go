@@ -29,6 +29,7 @@ import (
 
 	restful "github.com/emicklei/go-restful"
 	"github.com/golang/mock/gomock"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -42,15 +43,23 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/rest"
 	testutils "kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/cpubaseline"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/idle"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/notification"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/powerschedule"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/replication"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/snapshot"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/usage"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/vmimport"
 
 	storagev1 "k8s.io/api/storage/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -75,26 +84,34 @@ var _ = Describe("Application", func() {
 		vmiInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		vmSnapshotInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
 		vmSnapshotContentInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
+		vmSnapshotScheduleInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotSchedule{})
 		migrationInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
 		nodeInformer, _ := testutils.NewFakeInformerFor(&kubev1.Node{})
 		recorder := record.NewFakeRecorder(100)
-		config, _, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{})
+		config, _, _, kubeVirtInformer := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{})
 		pdbInformer, _ := testutils.NewFakeInformerFor(&v1beta1.PodDisruptionBudget{})
 		podInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Pod{})
 		pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
 		dataVolumeInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		networkAttachmentInformer, _ := testutils.NewFakeInformerFor(&networkv1.NetworkAttachmentDefinition{})
 		rsInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceReplicaSet{})
 		storageClassInformer, _ := testutils.NewFakeInformerFor(&storagev1.StorageClass{})
 		crdInformer, _ := testutils.NewFakeInformerFor(&extv1beta1.CustomResourceDefinition{})
 		vmRestoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
+		vmOVFImportInformer, _ := testutils.NewFakeInformerFor(&vmimportv1.VirtualMachineOVFImport{})
+		vmV2VImportInformer, _ := testutils.NewFakeInformerFor(&vmimportv1.VirtualMachineV2VImport{})
+		vmClusterImportInformer, _ := testutils.NewFakeInformerFor(&vmimportv1.VirtualMachineClusterImport{})
 		dvInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		vmNotificationHookInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineNotificationHook{})
+		fencingRequestInformer, _ := testutils.NewFakeInformerFor(&v1.FencingRequest{})
+		namespaceInformer, _ := testutils.NewFakeInformerFor(&kubev1.Namespace{})
 
 		var qemuGid int64 = 107
 
 		app.informerFactory = controller.NewKubeInformerFactory(nil, nil, nil, "test")
 		app.evacuationController = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, recorder, virtClient, config)
-		app.disruptionBudgetController = disruptionbudget.NewDisruptionBudgetController(vmiInformer, pdbInformer, recorder, virtClient)
-		app.nodeController = NewNodeController(virtClient, nodeInformer, vmiInformer, recorder)
+		app.disruptionBudgetController = disruptionbudget.NewDisruptionBudgetController(vmiInformer, pdbInformer, migrationInformer, recorder, virtClient, config)
+		app.nodeController = NewNodeController(virtClient, nodeInformer, vmiInformer, fencingRequestInformer, recorder, config, "kubevirt")
 		app.vmiController = NewVMIController(services.NewTemplateService("a", "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
 			vmiInformer,
 			podInformer,
@@ -102,9 +119,11 @@ var _ = Describe("Application", func() {
 			recorder,
 			virtClient,
 			dataVolumeInformer,
+			networkAttachmentInformer,
+			config,
 		)
-		app.rsController = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
-		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, recorder, virtClient)
+		app.rsController = NewVMIReplicaSet(vmiInformer, rsInformer, dataVolumeInformer, pvcInformer, storageClassInformer, recorder, virtClient, uint(10))
+		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, storageClassInformer, recorder, virtClient)
 		app.migrationController = NewMigrationController(services.NewTemplateService("a", "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
 			vmiInformer,
 			podInformer,
@@ -114,18 +133,19 @@ var _ = Describe("Application", func() {
 			config,
 		)
 		app.snapshotController = &snapshot.VMSnapshotController{
-			Client:                    virtClient,
-			VMSnapshotInformer:        vmSnapshotInformer,
-			VMSnapshotContentInformer: vmSnapshotContentInformer,
-			VMInformer:                vmInformer,
-			VMIInformer:               vmiInformer,
-			PodInformer:               podInformer,
-			StorageClassInformer:      storageClassInformer,
-			PVCInformer:               pvcInformer,
-			CRDInformer:               crdInformer,
-			DVInformer:                dvInformer,
-			Recorder:                  recorder,
-			ResyncPeriod:              60 * time.Second,
+			Client:                     virtClient,
+			VMSnapshotInformer:         vmSnapshotInformer,
+			VMSnapshotContentInformer:  vmSnapshotContentInformer,
+			VMSnapshotScheduleInformer: vmSnapshotScheduleInformer,
+			VMInformer:                 vmInformer,
+			VMIInformer:                vmiInformer,
+			PodInformer:                podInformer,
+			StorageClassInformer:       storageClassInformer,
+			PVCInformer:                pvcInformer,
+			CRDInformer:                crdInformer,
+			DVInformer:                 dvInformer,
+			Recorder:                   recorder,
+			ResyncPeriod:               60 * time.Second,
 		}
 		app.snapshotController.Init()
 		app.restoreController = &snapshot.VMRestoreController{
@@ -141,6 +161,84 @@ var _ = Describe("Application", func() {
 			Recorder:                  recorder,
 		}
 		app.restoreController.Init()
+		app.ovfImportController = &vmimport.OVFImportController{
+			Client:              virtClient,
+			VMOVFImportInformer: vmOVFImportInformer,
+			VMInformer:          vmInformer,
+			DataVolumeInformer:  dataVolumeInformer,
+			PodInformer:         podInformer,
+			ClusterConfig:       config,
+			Recorder:            recorder,
+		}
+		app.ovfImportController.Init()
+		app.v2vImportController = &vmimport.V2VImportController{
+			Client:              virtClient,
+			VMV2VImportInformer: vmV2VImportInformer,
+			VMInformer:          vmInformer,
+			PodInformer:         podInformer,
+			PVCInformer:         pvcInformer,
+			ClusterConfig:       config,
+			Recorder:            recorder,
+		}
+		app.v2vImportController.Init()
+		app.clusterImportController = &vmimport.ClusterImportController{
+			Client:                  virtClient,
+			VMClusterImportInformer: vmClusterImportInformer,
+			VMInformer:              vmInformer,
+			DataVolumeInformer:      dataVolumeInformer,
+			ClusterConfig:           config,
+			Recorder:                recorder,
+		}
+		app.clusterImportController.Init()
+		app.usageController = &usage.UsageController{
+			Client:        virtClient,
+			VMInformer:    vmInformer,
+			VMIInformer:   vmiInformer,
+			PVCInformer:   pvcInformer,
+			ClusterConfig: config,
+		}
+		app.usageController.Init()
+		app.notificationController = &notification.NotificationController{
+			Client:                   virtClient,
+			VMIInformer:              vmiInformer,
+			NotificationHookInformer: vmNotificationHookInformer,
+			ClusterConfig:            config,
+			Recorder:                 recorder,
+		}
+		app.notificationController.Init()
+		app.replicationController = &replication.ReplicationController{
+			Client:               virtClient,
+			VMInformer:           vmInformer,
+			VMIInformer:          vmiInformer,
+			PVCInformer:          pvcInformer,
+			StorageClassInformer: storageClassInformer,
+			ClusterConfig:        config,
+			Recorder:             recorder,
+		}
+		app.replicationController.Init()
+		app.powerScheduleController = &powerschedule.PowerScheduleController{
+			Client:      virtClient,
+			VMInformer:  vmInformer,
+			VMIInformer: vmiInformer,
+			Recorder:    recorder,
+		}
+		app.powerScheduleController.Init()
+		app.idleController = &idle.IdleController{
+			Client:            virtClient,
+			VMInformer:        vmInformer,
+			VMIInformer:       vmiInformer,
+			NamespaceInformer: namespaceInformer,
+			ClusterConfig:     config,
+			Recorder:          recorder,
+		}
+		app.idleController.Init()
+		app.cpuBaselineController = &cpubaseline.CPUBaselineController{
+			Client:           virtClient,
+			NodeInformer:     nodeInformer,
+			KubeVirtInformer: kubeVirtInformer,
+			ClusterConfig:    config,
+		}
+		app.cpuBaselineController.Init()
 		app.persistentVolumeClaimInformer = pvcInformer
 
 		app.readyChan = make(chan bool)
@@ -0,0 +1,175 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cpubaseline
+
+import (
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+// cpuModelGenerations lists the CPU models of libvirt from the newest to the
+// oldest generation of each vendor. Variants like Haswell-noTSX belong to the
+// generation of their base model.
+var cpuModelGenerations = []string{
+	"Icelake-Server",
+	"Icelake-Client",
+	"Cascadelake-Server",
+	"Skylake-Server",
+	"Skylake-Client",
+	"Broadwell",
+	"Haswell",
+	"IvyBridge",
+	"SandyBridge",
+	"Westmere",
+	"Nehalem",
+	"Penryn",
+	"Conroe",
+	"EPYC-Rome",
+	"EPYC",
+	"Opteron_G5",
+	"Opteron_G4",
+	"Opteron_G3",
+	"Opteron_G2",
+	"Opteron_G1",
+}
+
+func (ctrl *CPUBaselineController) updateCPUBaselines(kv *kubevirtv1.KubeVirt) error {
+	// only the deployed KubeVirt resource holds the cluster config
+	if kv.DeletionTimestamp != nil || kv.Status.Phase == "" {
+		return nil
+	}
+
+	var nodes []*k8sv1.Node
+	for _, obj := range ctrl.NodeInformer.GetStore().List() {
+		if node, ok := obj.(*k8sv1.Node); ok && node.DeletionTimestamp == nil {
+			nodes = append(nodes, node)
+		}
+	}
+
+	var baselines []kubevirtv1.CPUBaselineStatus
+	for _, baseline := range ctrl.ClusterConfig.GetCPUBaselines() {
+		baselines = append(baselines, computeCPUBaseline(baseline, nodes))
+	}
+
+	if equality.Semantic.DeepEqual(kv.Status.CPUBaselines, baselines) {
+		return nil
+	}
+
+	kvCopy := kv.DeepCopy()
+	kvCopy.Status.CPUBaselines = baselines
+	log.Log.Object(kv).Infof("Updating the CPU baselines to %+v", baselines)
+	return ctrl.statusUpdater.UpdateStatus(kvCopy)
+}
+
+// computeCPUBaseline returns the newest CPU model and the CPU features all
+// nodes of the pool of the baseline support, according to the labels node
+// feature discovery put on the nodes
+func computeCPUBaseline(baseline kubevirtv1.CPUBaseline, nodes []*k8sv1.Node) kubevirtv1.CPUBaselineStatus {
+	status := kubevirtv1.CPUBaselineStatus{Name: baseline.Name}
+	if len(baseline.NodeSelector) == 0 {
+		status.Message = "the node selector of the baseline is empty"
+		return status
+	}
+
+	selector := labels.SelectorFromSet(baseline.NodeSelector)
+	var models, features map[string]bool
+	for _, node := range nodes {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		status.Nodes++
+		models = intersect(models, labelSuffixes(node.Labels, services.NFD_CPU_MODEL_PREFIX))
+		features = intersect(features, labelSuffixes(node.Labels, services.NFD_CPU_FEATURE_PREFIX))
+	}
+
+	if status.Nodes == 0 {
+		status.Message = "no node matches the node selector of the baseline"
+		return status
+	}
+	if len(models) == 0 {
+		status.Message = "the nodes of the pool support no common CPU model"
+		return status
+	}
+
+	status.Model = newestCPUModel(models)
+	for feature := range features {
+		status.Features = append(status.Features, feature)
+	}
+	sort.Strings(status.Features)
+	return status
+}
+
+// labelSuffixes returns the names following the prefix of the labels set to true
+func labelSuffixes(nodeLabels map[string]string, prefix string) map[string]bool {
+	suffixes := map[string]bool{}
+	for label, value := range nodeLabels {
+		if strings.HasPrefix(label, prefix) && value == "true" {
+			suffixes[strings.TrimPrefix(label, prefix)] = true
+		}
+	}
+	return suffixes
+}
+
+// intersect returns the names in both sets, a nil set stands for the first node
+func intersect(set, names map[string]bool) map[string]bool {
+	if set == nil {
+		return names
+	}
+	for name := range set {
+		if !names[name] {
+			delete(set, name)
+		}
+	}
+	return set
+}
+
+// newestCPUModel picks the model of the newest generation, models of unknown
+// generations are only picked if there is no other, in alphabetical order
+func newestCPUModel(models map[string]bool) string {
+	var sorted []string
+	for model := range models {
+		sorted = append(sorted, model)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		gi, gj := cpuModelGeneration(sorted[i]), cpuModelGeneration(sorted[j])
+		if gi != gj {
+			return gi < gj
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted[0]
+}
+
+func cpuModelGeneration(model string) int {
+	for i, generation := range cpuModelGenerations {
+		if model == generation || strings.HasPrefix(model, generation+"-") {
+			return i
+		}
+	}
+	return len(cpuModelGenerations)
+}
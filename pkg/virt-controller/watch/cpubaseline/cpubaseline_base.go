@@ -0,0 +1,173 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cpubaseline
+
+import (
+	"fmt"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/status"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// CPUBaselineController computes the CPU model and features all nodes of the
+// node pools of the CPU baselines support, and records them in the status of
+// the KubeVirt resource
+type CPUBaselineController struct {
+	Client kubecli.KubevirtClient
+
+	NodeInformer     cache.SharedIndexInformer
+	KubeVirtInformer cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+
+	kvQueue       workqueue.RateLimitingInterface
+	statusUpdater *status.KVStatusUpdater
+}
+
+// Init initializes the CPU baseline controller
+func (ctrl *CPUBaselineController) Init() {
+	ctrl.kvQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cpu-baseline-controller-kubevirt")
+	ctrl.statusUpdater = status.NewKubeVirtStatusUpdater(ctrl.Client)
+
+	ctrl.KubeVirtInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleKubeVirt,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleKubeVirt(newObj) },
+		},
+	)
+
+	ctrl.NodeInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleNode,
+			UpdateFunc: ctrl.updateNode,
+			DeleteFunc: ctrl.handleNode,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *CPUBaselineController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.kvQueue.ShutDown()
+
+	log.Log.Info("Starting CPU baseline controller.")
+	defer log.Log.Info("Shutting down CPU baseline controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.NodeInformer.HasSynced,
+		ctrl.KubeVirtInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.kvWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *CPUBaselineController) kvWorker() {
+	for ctrl.processKubeVirtWorkItem() {
+	}
+}
+
+func (ctrl *CPUBaselineController) processKubeVirtWorkItem() bool {
+	key, quit := ctrl.kvQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.kvQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing KubeVirt %v", key)
+		ctrl.kvQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed KubeVirt %v", key)
+		ctrl.kvQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *CPUBaselineController) execute(key string) error {
+	storeObj, exists, err := ctrl.KubeVirtInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	kv, ok := storeObj.(*kubevirtv1.KubeVirt)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateCPUBaselines(kv)
+}
+
+func (ctrl *CPUBaselineController) handleKubeVirt(obj interface{}) {
+	kv, ok := obj.(*kubevirtv1.KubeVirt)
+	if !ok {
+		return
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(kv)
+	if err != nil {
+		log.Log.Errorf("failed to get key from object: %v, %v", err, kv)
+		return
+	}
+
+	log.Log.V(3).Infof("enqueued %q for sync", key)
+	ctrl.kvQueue.Add(key)
+}
+
+// updateNode only enqueues the KubeVirt resources when the labels of a node
+// change, the heartbeats of the nodes don't affect the baselines
+func (ctrl *CPUBaselineController) updateNode(oldObj, newObj interface{}) {
+	oldNode, ok := oldObj.(*k8sv1.Node)
+	if !ok {
+		return
+	}
+	newNode, ok := newObj.(*k8sv1.Node)
+	if !ok || (equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) && oldNode.DeletionTimestamp.Equal(newNode.DeletionTimestamp)) {
+		return
+	}
+	ctrl.handleNode(newObj)
+}
+
+// handleNode enqueues the KubeVirt resources, whose baselines the node may
+// belong to
+func (ctrl *CPUBaselineController) handleNode(_ interface{}) {
+	for _, obj := range ctrl.KubeVirtInformer.GetStore().List() {
+		ctrl.handleKubeVirt(obj)
+	}
+}
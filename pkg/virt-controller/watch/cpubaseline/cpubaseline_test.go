@@ -0,0 +1,218 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cpubaseline
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+var _ = Describe("CPU Baseline", func() {
+
+	var ctrl *gomock.Controller
+	var kvInterface *kubecli.MockKubeVirtInterface
+	var nodeInformer cache.SharedIndexInformer
+	var kubeVirtInformer cache.SharedIndexInformer
+	var controller *CPUBaselineController
+	var updated *v1.KubeVirt
+
+	newKubeVirt := func(baselines ...v1.CPUBaseline) *v1.KubeVirt {
+		return &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					CPUBaselines: baselines,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		}
+	}
+
+	pool := func(name string) v1.CPUBaseline {
+		return v1.CPUBaseline{
+			Name:         name,
+			NodeSelector: map[string]string{"pool": name},
+		}
+	}
+
+	newNode := func(name string, pool string, models []string, features []string) *k8sv1.Node {
+		node := &k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"pool": pool},
+			},
+		}
+		for _, model := range models {
+			node.Labels[services.NFD_CPU_MODEL_PREFIX+model] = "true"
+		}
+		for _, feature := range features {
+			node.Labels[services.NFD_CPU_FEATURE_PREFIX+feature] = "true"
+		}
+		return node
+	}
+
+	process := func(kv *v1.KubeVirt, nodes ...*k8sv1.Node) error {
+		config, _, _, informer := testutils.NewFakeClusterConfigUsingKV(kv)
+		kubeVirtInformer = informer
+		controller.KubeVirtInformer = kubeVirtInformer
+		controller.ClusterConfig = config
+		for _, node := range nodes {
+			Expect(nodeInformer.GetStore().Add(node)).To(Succeed())
+		}
+		return controller.execute("kubevirt/kubevirt")
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		kvInterface = kubecli.NewMockKubeVirtInterface(ctrl)
+
+		nodeInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Node{})
+		kubeVirtInformer, _ = testutils.NewFakeInformerFor(&v1.KubeVirt{})
+
+		controller = &CPUBaselineController{
+			Client:           virtClient,
+			NodeInformer:     nodeInformer,
+			KubeVirtInformer: kubeVirtInformer,
+		}
+		controller.Init()
+
+		virtClient.EXPECT().KubeVirt("kubevirt").Return(kvInterface).AnyTimes()
+
+		updated = nil
+		kvInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(kv *v1.KubeVirt) (*v1.KubeVirt, error) {
+			updated = kv
+			return kv, nil
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should compute the newest common CPU model and the common features of the pool", func() {
+		err := process(newKubeVirt(pool("rack1")),
+			newNode("node01", "rack1", []string{"Haswell-noTSX", "Broadwell-noTSX", "IvyBridge"}, []string{"aes", "avx2", "pcid"}),
+			newNode("node02", "rack1", []string{"Haswell-noTSX", "IvyBridge"}, []string{"aes", "avx2"}),
+			newNode("node03", "rack2", []string{"IvyBridge"}, []string{"aes"}),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(updated.Status.CPUBaselines).To(Equal([]v1.CPUBaselineStatus{{
+			Name:     "rack1",
+			Model:    "Haswell-noTSX",
+			Features: []string{"aes", "avx2"},
+			Nodes:    2,
+		}}))
+	})
+
+	table.DescribeTable("should report why no CPU model could be computed", func(baseline v1.CPUBaseline, message string) {
+		err := process(newKubeVirt(baseline),
+			newNode("node01", "rack1", []string{"Haswell-noTSX"}, nil),
+			newNode("node02", "rack1", []string{"EPYC"}, nil),
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(updated.Status.CPUBaselines).To(HaveLen(1))
+		Expect(updated.Status.CPUBaselines[0].Model).To(BeEmpty())
+		Expect(updated.Status.CPUBaselines[0].Message).To(Equal(message))
+	},
+		table.Entry("without common CPU model", pool("rack1"), "the nodes of the pool support no common CPU model"),
+		table.Entry("without nodes", pool("rack2"), "no node matches the node selector of the baseline"),
+		table.Entry("without node selector", v1.CPUBaseline{Name: "all"}, "the node selector of the baseline is empty"),
+	)
+
+	It("should not update the status when the baselines did not change", func() {
+		kv := newKubeVirt(pool("rack1"))
+		kv.Status.CPUBaselines = []v1.CPUBaselineStatus{{
+			Name:     "rack1",
+			Model:    "IvyBridge",
+			Features: []string{"aes"},
+			Nodes:    1,
+		}}
+
+		Expect(process(kv, newNode("node01", "rack1", []string{"IvyBridge"}, []string{"aes"}))).To(Succeed())
+		Expect(updated).To(BeNil())
+	})
+
+	It("should remove the status of baselines which are not configured anymore", func() {
+		kv := newKubeVirt()
+		kv.Status.CPUBaselines = []v1.CPUBaselineStatus{{
+			Name:  "rack1",
+			Model: "IvyBridge",
+			Nodes: 1,
+		}}
+
+		Expect(process(kv)).To(Succeed())
+		Expect(updated).ToNot(BeNil())
+		Expect(updated.Status.CPUBaselines).To(BeEmpty())
+	})
+
+	It("should ignore KubeVirt resources which are not deployed", func() {
+		kv := newKubeVirt(pool("rack1"))
+		kv.Status.Phase = ""
+
+		Expect(process(kv, newNode("node01", "rack1", []string{"IvyBridge"}, nil))).To(Succeed())
+		Expect(updated).To(BeNil())
+	})
+
+	It("should enqueue the KubeVirt resources only when the labels of a node change", func() {
+		Expect(kubeVirtInformer.GetStore().Add(newKubeVirt(pool("rack1")))).To(Succeed())
+		node := newNode("node01", "rack1", []string{"IvyBridge"}, nil)
+
+		heartbeat := node.DeepCopy()
+		heartbeat.Status.Conditions = []k8sv1.NodeCondition{{Type: k8sv1.NodeReady, Status: k8sv1.ConditionTrue}}
+		controller.updateNode(node, heartbeat)
+		Expect(controller.kvQueue.Len()).To(Equal(0))
+
+		relabeled := node.DeepCopy()
+		relabeled.Labels[services.NFD_CPU_MODEL_PREFIX+"SandyBridge"] = "true"
+		controller.updateNode(node, relabeled)
+		Expect(controller.kvQueue.Len()).To(Equal(1))
+	})
+
+	table.DescribeTable("should pick the CPU model of the newest generation", func(models []string, expected string) {
+		set := map[string]bool{}
+		for _, model := range models {
+			set[model] = true
+		}
+		Expect(newestCPUModel(set)).To(Equal(expected))
+	},
+		table.Entry("of the same vendor", []string{"Nehalem", "Skylake-Client-IBRS", "Haswell"}, "Skylake-Client-IBRS"),
+		table.Entry("with a generation prefixing another one", []string{"EPYC", "EPYC-Rome", "Opteron_G5"}, "EPYC-Rome"),
+		table.Entry("over unknown models", []string{"Westmere", "Dhyana"}, "Westmere"),
+		table.Entry("of unknown models in alphabetical order", []string{"kvm64", "Dhyana"}, "Dhyana"),
+	)
+})
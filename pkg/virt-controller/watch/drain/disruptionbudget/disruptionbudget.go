@@ -3,11 +3,13 @@ package disruptionbudget
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	v12 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -18,6 +20,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -29,6 +32,10 @@ const (
 	FailedDeletePodDisruptionBudgetReason = "FailedDelete"
 	// SuccessfulDeletePodDisruptionBudgetReason is added in an event if deleting a PodDisruptionBudget succeeded.
 	SuccessfulDeletePodDisruptionBudgetReason = "SuccessfulDelete"
+	// FailedUpdatePodDisruptionBudgetReason is added in an event if updating a PodDisruptionBudget failed.
+	FailedUpdatePodDisruptionBudgetReason = "FailedUpdate"
+	// SuccessfulUpdatePodDisruptionBudgetReason is added in an event if updating a PodDisruptionBudget succeeded.
+	SuccessfulUpdatePodDisruptionBudgetReason = "SuccessfulUpdate"
 )
 
 type DisruptionBudgetController struct {
@@ -36,24 +43,33 @@ type DisruptionBudgetController struct {
 	Queue                           workqueue.RateLimitingInterface
 	vmiInformer                     cache.SharedIndexInformer
 	pdbInformer                     cache.SharedIndexInformer
+	migrationInformer               cache.SharedIndexInformer
 	recorder                        record.EventRecorder
 	podDisruptionBudgetExpectations *controller.UIDTrackingControllerExpectations
+	clusterConfig                   *virtconfig.ClusterConfig
+	policyLock                      sync.Mutex
+	policy                          virtv1.DisruptionBudgetPolicy
 }
 
 func NewDisruptionBudgetController(
 	vmiInformer cache.SharedIndexInformer,
 	pdbInformer cache.SharedIndexInformer,
+	migrationInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
+	clusterConfig *virtconfig.ClusterConfig,
 ) *DisruptionBudgetController {
 
 	c := &DisruptionBudgetController{
 		Queue:                           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
 		vmiInformer:                     vmiInformer,
 		pdbInformer:                     pdbInformer,
+		migrationInformer:               migrationInformer,
 		recorder:                        recorder,
 		clientset:                       clientset,
 		podDisruptionBudgetExpectations: controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		clusterConfig:                   clusterConfig,
+		policy:                          clusterConfig.GetDisruptionBudgetPolicy(),
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -68,9 +84,60 @@ func NewDisruptionBudgetController(
 		UpdateFunc: c.updatePodDisruptionBudget,
 	})
 
+	c.migrationInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueMigration,
+		DeleteFunc: c.enqueueMigration,
+		UpdateFunc: c.updateMigration,
+	})
+
+	c.clusterConfig.SetConfigModifiedCallback(c.policyModified)
+
 	return c
 }
 
+// policyModified resyncs the PodDisruptionBudgets of all VMIs if the disruption budget policy changed
+func (c *DisruptionBudgetController) policyModified() {
+	c.policyLock.Lock()
+	defer c.policyLock.Unlock()
+
+	policy := c.clusterConfig.GetDisruptionBudgetPolicy()
+	if policy == c.policy {
+		return
+	}
+	log.Log.Infof("Disruption budget policy changed from %s to %s, resyncing all PodDisruptionBudgets", c.policy, policy)
+	c.policy = policy
+	for _, key := range c.vmiInformer.GetStore().ListKeys() {
+		c.Queue.Add(key)
+	}
+}
+
+func (c *DisruptionBudgetController) updateMigration(old, curr interface{}) {
+	c.enqueueMigration(curr)
+}
+
+// enqueueMigration enqueues the vmi of a migration, its PodDisruptionBudget has to cover both
+// the source and the target pod while the migration is in progress
+func (c *DisruptionBudgetController) enqueueMigration(obj interface{}) {
+	migration, ok := obj.(*virtv1.VirtualMachineInstanceMigration)
+
+	// When a delete is dropped, the relist will notice a migration in the store not
+	// in the list, leading to the insertion of a tombstone object which contains
+	// the deleted key/value. Note that this value might be stale.
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		migration, ok = tombstone.Obj.(*virtv1.VirtualMachineInstanceMigration)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a migration %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	c.Queue.Add(migration.Namespace + "/" + migration.Spec.VMIName)
+}
+
 func (c *DisruptionBudgetController) addVirtualMachineInstance(obj interface{}) {
 	c.enqueueVMI(obj)
 }
@@ -252,7 +319,7 @@ func (c *DisruptionBudgetController) Run(threadiness int, stopCh <-chan struct{}
 	log.Log.Info("Starting disruption budget controller.")
 
 	// Wait for cache sync before we start the node controller
-	cache.WaitForCacheSync(stopCh, c.pdbInformer.HasSynced, c.vmiInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.pdbInformer.HasSynced, c.vmiInformer.HasSynced, c.migrationInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -327,25 +394,35 @@ func (c *DisruptionBudgetController) execute(key string) error {
 func (c *DisruptionBudgetController) sync(key string, vmi *virtv1.VirtualMachineInstance, pdb *v1beta1.PodDisruptionBudget) error {
 	delete := false
 	create := false
+	update := false
+	minAvailable := 0
 	// delete if there is no VMI
 	if vmi == nil && pdb != nil {
 		delete = true
 	} else if vmi != nil {
-		wantsToMigrate := wantsToMigrateOnDrain(vmi)
+		var err error
+		minAvailable, err = c.minAvailableForVMI(vmi)
+		if err != nil {
+			return err
+		}
+		wantsPDB := minAvailable > 0
 		if vmi.DeletionTimestamp != nil && pdb != nil {
 			// pdb can already be deleted, shutdown already in process
 			delete = true
-		} else if !wantsToMigrate && pdb != nil {
-			// We don't want migrations on evictions, if there is a pdb, remove it
+		} else if !wantsPDB && pdb != nil {
+			// We don't want a pdb, e.g. no migrations on evictions or no migration in progress, if there is a pdb, remove it
 			delete = true
-		} else if wantsToMigrate && vmi.DeletionTimestamp == nil && pdb == nil {
-			// No pdb and we want migrations on evictions
+		} else if wantsPDB && vmi.DeletionTimestamp == nil && pdb == nil {
+			// No pdb and we want one
 			create = true
-		} else if wantsToMigrate && pdb != nil {
+		} else if wantsPDB && pdb != nil {
 			if ownerRef := v1.GetControllerOf(pdb); ownerRef != nil && ownerRef.UID != vmi.UID {
 				// The pdb is from an old vmi with a different uid, delete and later create the correct one
 				// The VMI always has a minimum grace period, so normally this should not happen, therefore no optimizations
 				delete = true
+			} else if pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.IntValue() != minAvailable {
+				// A migration started or finished
+				update = true
 			}
 		}
 	}
@@ -365,7 +442,7 @@ func (c *DisruptionBudgetController) sync(key string, vmi *virtv1.VirtualMachine
 		c.recorder.Eventf(vmi, v12.EventTypeNormal, SuccessfulDeletePodDisruptionBudgetReason, "Deleted PodDisruptionBudget %s", pdb.Name)
 		return nil
 	} else if create {
-		two := intstr.FromInt(2)
+		minAvailable := intstr.FromInt(minAvailable)
 		c.podDisruptionBudgetExpectations.ExpectCreations(key, 1)
 		createdPDB, err := c.clientset.PolicyV1beta1().PodDisruptionBudgets(vmi.Namespace).Create(&v1beta1.PodDisruptionBudget{
 			ObjectMeta: v1.ObjectMeta{
@@ -375,7 +452,7 @@ func (c *DisruptionBudgetController) sync(key string, vmi *virtv1.VirtualMachine
 				GenerateName: "kubevirt-disruption-budget-",
 			},
 			Spec: v1beta1.PodDisruptionBudgetSpec{
-				MinAvailable: &two,
+				MinAvailable: &minAvailable,
 				Selector: &v1.LabelSelector{
 					MatchLabels: map[string]string{
 						virtv1.CreatedByLabel: string(vmi.UID),
@@ -389,10 +466,61 @@ func (c *DisruptionBudgetController) sync(key string, vmi *virtv1.VirtualMachine
 			return err
 		}
 		c.recorder.Eventf(vmi, v12.EventTypeNormal, SuccessfulCreatePodDisruptionBudgetReason, "Created PodDisruptionBudget %s", createdPDB.Name)
+	} else if update {
+		patch := fmt.Sprintf(`[{"op": "replace", "path": "/spec/minAvailable", "value": %d}]`, minAvailable)
+		_, err := c.clientset.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace).Patch(pdb.Name, types.JSONPatchType, []byte(patch))
+		if err != nil {
+			c.recorder.Eventf(vmi, v12.EventTypeWarning, FailedUpdatePodDisruptionBudgetReason, "Error updating the PodDisruptionBudget %s: %v", pdb.Name, err)
+			return err
+		}
+		c.recorder.Eventf(vmi, v12.EventTypeNormal, SuccessfulUpdatePodDisruptionBudgetReason, "Updated the minimum available pods of PodDisruptionBudget %s to %d", pdb.Name, minAvailable)
 	}
 	return nil
 }
 
+// minAvailableForVMI returns how many pods of the vmi its pdb has to keep, 0 if the vmi should
+// not have a pdb. While the vmi is migrated, the pdb has to cover the source and the target pod.
+func (c *DisruptionBudgetController) minAvailableForVMI(vmi *virtv1.VirtualMachineInstance) (int, error) {
+	if !wantsToMigrateOnDrain(vmi) {
+		return 0, nil
+	}
+
+	migrating, err := c.isMigrating(vmi)
+	if err != nil {
+		return 0, err
+	}
+
+	switch c.clusterConfig.GetDisruptionBudgetPolicy() {
+	case virtv1.DisruptionBudgetPolicyNever:
+		return 0, nil
+	case virtv1.DisruptionBudgetPolicyDuringMigration:
+		if migrating {
+			return 2, nil
+		}
+		return 0, nil
+	default:
+		if migrating {
+			return 2, nil
+		}
+		return 1, nil
+	}
+}
+
+// isMigrating returns whether an unfinished migration of the vmi exists
+func (c *DisruptionBudgetController) isMigrating(vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	objs, err := c.migrationInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		return false, err
+	}
+	for _, obj := range objs {
+		migration := obj.(*virtv1.VirtualMachineInstanceMigration)
+		if migration.Spec.VMIName == vmi.Name && !migration.IsFinal() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c *DisruptionBudgetController) pdbForVMI(namespace, name string) (*v1beta1.PodDisruptionBudget, error) {
 	pbds, err := c.pdbInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
 	if err != nil {
@@ -1,6 +1,8 @@
 package disruptionbudget_test
 
 import (
+	"fmt"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,6 +21,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/disruptionbudget"
 )
 
@@ -32,6 +35,10 @@ var _ = Describe("Disruptionbudget", func() {
 	var vmiInformer cache.SharedIndexInformer
 	var pdbInformer cache.SharedIndexInformer
 	var pdbSource *framework.FakeControllerSource
+	var migrationInformer cache.SharedIndexInformer
+	var migrationSource *framework.FakeControllerSource
+	var kubeVirtInformer cache.SharedIndexInformer
+	var config *virtconfig.ClusterConfig
 	var recorder *record.FakeRecorder
 	var mockQueue *testutils.MockWorkQueue
 	var kubeClient *fake.Clientset
@@ -43,13 +50,34 @@ var _ = Describe("Disruptionbudget", func() {
 	syncCaches := func(stop chan struct{}) {
 		go vmiInformer.Run(stop)
 		go pdbInformer.Run(stop)
+		go migrationInformer.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop,
 			vmiInformer.HasSynced,
 			pdbInformer.HasSynced,
+			migrationInformer.HasSynced,
 		)).To(BeTrue())
 	}
 
+	setPolicy := func(policy v1.DisruptionBudgetPolicy) {
+		testutils.UpdateFakeKubeVirtClusterConfig(kubeVirtInformer, &v1.KubeVirt{
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DisruptionBudgetPolicy: policy,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+	}
+
+	addMigration := func(migration *v1.VirtualMachineInstanceMigration) {
+		mockQueue.ExpectAdds(1)
+		migrationSource.Add(migration)
+		mockQueue.Wait()
+	}
+
 	addVirtualMachine := func(vmi *v1.VirtualMachineInstance) {
 		mockQueue.ExpectAdds(1)
 		vmiSource.Add(vmi)
@@ -67,18 +95,29 @@ var _ = Describe("Disruptionbudget", func() {
 		})
 	}
 
-	shouldExpectPDBCreation := func(uid types.UID) {
+	shouldExpectPDBCreation := func(uid types.UID, minAvailable string) {
 		// Expect pod creation
 		kubeClient.Fake.PrependReactor("create", "poddisruptionbudgets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
 			update, ok := action.(testing.CreateAction)
 			pdb := update.GetObject().(*v1beta1.PodDisruptionBudget)
 			Expect(ok).To(BeTrue())
-			Expect(pdb.Spec.MinAvailable.String()).To(Equal("2"))
+			Expect(pdb.Spec.MinAvailable.String()).To(Equal(minAvailable))
 			Expect(update.GetObject().(*v1beta1.PodDisruptionBudget).Spec.Selector.MatchLabels[v1.CreatedByLabel]).To(Equal(string(uid)))
 			return true, update.GetObject(), nil
 		})
 	}
 
+	shouldExpectPDBPatch := func(pdb *v1beta1.PodDisruptionBudget, minAvailable int) {
+		kubeClient.Fake.PrependReactor("patch", "poddisruptionbudgets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			patch, ok := action.(testing.PatchAction)
+			Expect(ok).To(BeTrue())
+			Expect(patch.GetNamespace()).To(Equal(pdb.Namespace))
+			Expect(patch.GetName()).To(Equal(pdb.Name))
+			Expect(string(patch.GetPatch())).To(Equal(fmt.Sprintf(`[{"op": "replace", "path": "/spec/minAvailable", "value": %d}]`, minAvailable)))
+			return true, pdb, nil
+		})
+	}
+
 	BeforeEach(func() {
 		stop = make(chan struct{})
 		ctrl = gomock.NewController(GinkgoT())
@@ -87,9 +126,19 @@ var _ = Describe("Disruptionbudget", func() {
 
 		vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		pdbInformer, pdbSource = testutils.NewFakeInformerFor(&v1beta1.PodDisruptionBudget{})
+		migrationInformer, migrationSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
 		recorder = record.NewFakeRecorder(100)
+		config, _, _, kubeVirtInformer = testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: v13.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
 
-		controller = disruptionbudget.NewDisruptionBudgetController(vmiInformer, pdbInformer, recorder, virtClient)
+		controller = disruptionbudget.NewDisruptionBudgetController(vmiInformer, pdbInformer, migrationInformer, recorder, virtClient, config)
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 		controller.Queue = mockQueue
 		pdbFeeder = testutils.NewPodDisruptionBudgetFeeder(mockQueue, pdbSource)
@@ -173,7 +222,7 @@ var _ = Describe("Disruptionbudget", func() {
 			pdbFeeder.Delete(pdb)
 			vmi.UID = "45356"
 			vmiFeeder.Add(vmi)
-			shouldExpectPDBCreation(vmi.UID)
+			shouldExpectPDBCreation(vmi.UID, "1")
 			controller.Execute()
 
 			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
@@ -227,7 +276,7 @@ var _ = Describe("Disruptionbudget", func() {
 			vmi.Spec.EvictionStrategy = newEvictionStrategy()
 			addVirtualMachine(vmi)
 
-			shouldExpectPDBCreation(vmi.UID)
+			shouldExpectPDBCreation(vmi.UID, "1")
 			controller.Execute()
 			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
 		})
@@ -240,7 +289,7 @@ var _ = Describe("Disruptionbudget", func() {
 			pdbFeeder.Add(pdb)
 			controller.Execute()
 
-			shouldExpectPDBCreation(vmi.UID)
+			shouldExpectPDBCreation(vmi.UID, "1")
 			pdbFeeder.Delete(pdb)
 			controller.Execute()
 			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
@@ -254,13 +303,120 @@ var _ = Describe("Disruptionbudget", func() {
 			pdbFeeder.Add(pdb)
 			controller.Execute()
 
-			shouldExpectPDBCreation(vmi.UID)
+			shouldExpectPDBCreation(vmi.UID, "1")
 			newPdb := pdb.DeepCopy()
 			newPdb.OwnerReferences = nil
 			pdbFeeder.Modify(newPdb)
 			controller.Execute()
 			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
 		})
+
+		It("should raise the minimum available pods of the pdb to two while the VMI is migrated", func() {
+			vmi := newVirtualMachine("testvm")
+			vmi.Spec.EvictionStrategy = newEvictionStrategy()
+			addVirtualMachine(vmi)
+			pdb := newPodDisruptionBudget(vmi)
+			pdbFeeder.Add(pdb)
+			controller.Execute()
+
+			addMigration(newMigration(vmi, v1.MigrationRunning))
+			shouldExpectPDBPatch(pdb, 2)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulUpdatePodDisruptionBudgetReason)
+		})
+
+		It("should lower the minimum available pods of the pdb to one once the migration finished", func() {
+			vmi := newVirtualMachine("testvm")
+			vmi.Spec.EvictionStrategy = newEvictionStrategy()
+			migration := newMigration(vmi, v1.MigrationSucceeded)
+			addMigration(migration)
+			pdb := newPodDisruptionBudget(vmi)
+			two := intstr.FromInt(2)
+			pdb.Spec.MinAvailable = &two
+			pdbFeeder.Add(pdb)
+			addVirtualMachine(vmi)
+
+			shouldExpectPDBPatch(pdb, 1)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulUpdatePodDisruptionBudgetReason)
+		})
+
+		It("should create the pdb with two minimum available pods if the VMI is migrated", func() {
+			vmi := newVirtualMachine("testvm")
+			vmi.Spec.EvictionStrategy = newEvictionStrategy()
+			addMigration(newMigration(vmi, v1.MigrationPending))
+			addVirtualMachine(vmi)
+
+			shouldExpectPDBCreation(vmi.UID, "2")
+			controller.Execute()
+			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
+		})
+
+		Context("with the DuringMigration policy", func() {
+
+			BeforeEach(func() {
+				setPolicy(v1.DisruptionBudgetPolicyDuringMigration)
+			})
+
+			It("should not create a pdb while the VMI is not migrated", func() {
+				vmi := newVirtualMachine("testvm")
+				vmi.Spec.EvictionStrategy = newEvictionStrategy()
+				addVirtualMachine(vmi)
+
+				controller.Execute()
+			})
+
+			It("should create a pdb while the VMI is migrated and remove it afterwards", func() {
+				vmi := newVirtualMachine("testvm")
+				vmi.Spec.EvictionStrategy = newEvictionStrategy()
+				addVirtualMachine(vmi)
+				controller.Execute()
+
+				migration := newMigration(vmi, v1.MigrationRunning)
+				addMigration(migration)
+				shouldExpectPDBCreation(vmi.UID, "2")
+				controller.Execute()
+				testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
+
+				pdb := newPodDisruptionBudget(vmi)
+				pdbFeeder.Add(pdb)
+				migration.Status.Phase = v1.MigrationSucceeded
+				mockQueue.ExpectAdds(1)
+				migrationSource.Modify(migration)
+				mockQueue.Wait()
+				shouldExpectPDBDeletion(pdb)
+				controller.Execute()
+				testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulDeletePodDisruptionBudgetReason)
+			})
+		})
+
+		Context("with the Never policy", func() {
+
+			BeforeEach(func() {
+				setPolicy(v1.DisruptionBudgetPolicyNever)
+			})
+
+			It("should remove the pdb", func() {
+				vmi := newVirtualMachine("testvm")
+				vmi.Spec.EvictionStrategy = newEvictionStrategy()
+				addVirtualMachine(vmi)
+				pdb := newPodDisruptionBudget(vmi)
+				pdbFeeder.Add(pdb)
+
+				shouldExpectPDBDeletion(pdb)
+				controller.Execute()
+				testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulDeletePodDisruptionBudgetReason)
+			})
+
+			It("should not create a pdb while the VMI is migrated", func() {
+				vmi := newVirtualMachine("testvm")
+				vmi.Spec.EvictionStrategy = newEvictionStrategy()
+				addMigration(newMigration(vmi, v1.MigrationRunning))
+				addVirtualMachine(vmi)
+
+				controller.Execute()
+			})
+		})
 	})
 
 	AfterEach(func() {
@@ -299,6 +455,21 @@ func newPodDisruptionBudget(vmi *v1.VirtualMachineInstance) *v1beta1.PodDisrupti
 	}
 }
 
+func newMigration(vmi *v1.VirtualMachineInstance, phase v1.VirtualMachineInstanceMigrationPhase) *v1.VirtualMachineInstanceMigration {
+	return &v1.VirtualMachineInstanceMigration{
+		ObjectMeta: v13.ObjectMeta{
+			Name:      "testmigration",
+			Namespace: vmi.Namespace,
+		},
+		Spec: v1.VirtualMachineInstanceMigrationSpec{
+			VMIName: vmi.Name,
+		},
+		Status: v1.VirtualMachineInstanceMigrationStatus{
+			Phase: phase,
+		},
+	}
+}
+
 func newEvictionStrategy() *v1.EvictionStrategy {
 	strategy := v1.EvictionStrategyLiveMigrate
 	return &strategy
@@ -0,0 +1,269 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package idle
+
+import (
+	"fmt"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	idleSuspendedEvent = "IdleSuspended"
+
+	idlePolicyEnabled = "enabled"
+
+	idlePolicyDisabled = "disabled"
+)
+
+// sample holds the counters of a VMI read at a time
+type sample struct {
+	vmiUID  types.UID
+	time    time.Time
+	cpuTime int64
+	network int64
+	// the stats of a VMI paused by the user are not read, the next sample
+	// starts a new measurement
+	paused bool
+}
+
+// updateIdleStatus samples the activity of the VMI of the VM, suspends the VM
+// once it was idle for the window of the idle policy, and returns the time
+// until the next sample, 0 if none is needed
+func (ctrl *IdleController) updateIdleStatus(key string, vm *kubevirtv1.VirtualMachine) (time.Duration, error) {
+	if !ctrl.policyApplies(vm) {
+		ctrl.forgetSample(key)
+		return 0, ctrl.updateStatus(vm, nil)
+	}
+
+	obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return 0, err
+	}
+
+	now := ctrl.now()
+	status := vm.Status.IdleStatus.DeepCopy()
+	var vmi *kubevirtv1.VirtualMachineInstance
+	if exists {
+		vmi = obj.(*kubevirtv1.VirtualMachineInstance)
+		// the window starts when a VMI is first seen, a VMI created after the
+		// last activity is a restart of the VM
+		if status == nil || status.LastActivityTime == nil || vmi.CreationTimestamp.After(status.LastActivityTime.Time) {
+			status = &kubevirtv1.VirtualMachineIdleStatus{LastActivityTime: &metav1.Time{Time: now}}
+		}
+	}
+
+	if vmi == nil || !vmi.IsRunning() {
+		ctrl.forgetSample(key)
+		// a VM hibernated for being idle reports it until it is started again
+		if status != nil && status.Action == kubevirtv1.IdleActionHibernate {
+			return 0, ctrl.updateStatus(vm, status)
+		}
+		return 0, ctrl.updateStatus(vm, nil)
+	}
+
+	log.Log.Object(vm).V(4).Infof("Updating the idle status")
+
+	paused := controller.NewVirtualMachineInstanceConditionManager().HasCondition(vmi, kubevirtv1.VirtualMachineInstancePaused)
+	switch {
+	case status.Action == kubevirtv1.IdleActionHibernate:
+		// the VMI is shutting down
+		return 0, ctrl.updateStatus(vm, status)
+	case status.Action == kubevirtv1.IdleActionPause && paused:
+		return 0, ctrl.updateStatus(vm, status)
+	case status.Action == kubevirtv1.IdleActionPause:
+		// unpausing the VM is activity
+		ctrl.forgetSample(key)
+		status = &kubevirtv1.VirtualMachineIdleStatus{LastActivityTime: &metav1.Time{Time: now}}
+	}
+
+	interval := ctrl.ClusterConfig.GetIdleSampleInterval()
+	prev := ctrl.getSample(key, vmi.UID)
+	if prev != nil && now.Sub(prev.time) < interval {
+		return interval - now.Sub(prev.time), ctrl.updateStatus(vm, status)
+	}
+
+	if paused {
+		// a VM paused by the user is not suspended again once unpaused
+		ctrl.setSample(key, &sample{vmiUID: vmi.UID, time: now, paused: true})
+		status.LastActivityTime = &metav1.Time{Time: now}
+		status.Idle = false
+		return interval, ctrl.updateStatus(vm, status)
+	}
+
+	stats, err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).Stats(vmi.Name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the stats of VirtualMachineInstance %s: %v", vmi.Name, err)
+	}
+	current := &sample{
+		vmiUID:  vmi.UID,
+		time:    now,
+		cpuTime: stats.CPUTimeNanoseconds,
+		network: stats.NetworkReceiveBytes + stats.NetworkTransmitBytes,
+	}
+	ctrl.setSample(key, current)
+
+	if prev != nil && !prev.paused && ctrl.isActive(prev, current) {
+		status.LastActivityTime = &metav1.Time{Time: now}
+	}
+	if consoleActivity := consoleActivityTime(vmi); consoleActivity.After(status.LastActivityTime.Time) {
+		status.LastActivityTime = &metav1.Time{Time: consoleActivity}
+	}
+
+	status.Idle = !now.Before(status.LastActivityTime.Add(ctrl.ClusterConfig.GetIdleWindow()))
+	if !status.Idle {
+		return interval, ctrl.updateStatus(vm, status)
+	}
+
+	action, err := ctrl.suspend(vm, vmi, status.LastActivityTime.Time)
+	if err != nil {
+		return 0, err
+	}
+	status.Action = action
+	ctrl.forgetSample(key)
+	return 0, ctrl.updateStatus(vm, status)
+}
+
+// policyApplies returns whether the namespace of the VM opted in to the idle
+// policy and the VM did not opt out
+func (ctrl *IdleController) policyApplies(vm *kubevirtv1.VirtualMachine) bool {
+	if vm.Labels[kubevirtv1.IdlePolicyLabel] == idlePolicyDisabled {
+		return false
+	}
+
+	obj, exists, err := ctrl.NamespaceInformer.GetStore().GetByKey(vm.Namespace)
+	if err != nil || !exists {
+		return false
+	}
+	return obj.(*k8sv1.Namespace).Labels[kubevirtv1.IdlePolicyLabel] == idlePolicyEnabled
+}
+
+// isActive returns whether the guest used more CPU or network than the
+// thresholds between the samples. Counters which went backwards count as
+// activity. A zero threshold ignores its counter.
+func (ctrl *IdleController) isActive(prev, current *sample) bool {
+	elapsed := current.time.Sub(prev.time).Seconds()
+	cpuTime := current.cpuTime - prev.cpuTime
+	network := current.network - prev.network
+	if elapsed <= 0 || cpuTime < 0 || network < 0 {
+		return true
+	}
+
+	policy := ctrl.ClusterConfig.GetIdlePolicy()
+	if threshold := policy.CPUThreshold; threshold != nil && !threshold.IsZero() {
+		// CPU time per second in millicores
+		if float64(cpuTime)/1e6/elapsed >= float64(threshold.MilliValue()) {
+			return true
+		}
+	}
+	if threshold := policy.NetworkThreshold; threshold != nil && !threshold.IsZero() {
+		if float64(network)/elapsed >= float64(threshold.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+// suspend hibernates the idle VM if the policy asks for it and the VM can be
+// hibernated, and pauses it otherwise
+func (ctrl *IdleController) suspend(vm *kubevirtv1.VirtualMachine, vmi *kubevirtv1.VirtualMachineInstance, lastActivity time.Time) (kubevirtv1.IdleAction, error) {
+	since := lastActivity.UTC().Format(time.RFC3339)
+	if ctrl.ClusterConfig.GetIdlePolicy().Action == kubevirtv1.IdleActionHibernate && ctrl.canHibernate(vm, vmi) {
+		if err := ctrl.Client.VirtualMachine(vm.Namespace).Hibernate(vm.Name); err != nil {
+			return "", fmt.Errorf("failed to hibernate idle VirtualMachine %s: %v", vm.Name, err)
+		}
+		ctrl.Recorder.Eventf(vm, k8sv1.EventTypeNormal, idleSuspendedEvent, "Hibernated the VirtualMachine, it was idle since %s", since)
+		return kubevirtv1.IdleActionHibernate, nil
+	}
+
+	if err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).Pause(vmi.Name); err != nil {
+		return "", fmt.Errorf("failed to pause idle VirtualMachineInstance %s: %v", vmi.Name, err)
+	}
+	ctrl.Recorder.Eventf(vm, k8sv1.EventTypeNormal, idleSuspendedEvent, "Paused the VirtualMachine, it was idle since %s", since)
+	return kubevirtv1.IdleActionPause, nil
+}
+
+// canHibernate returns whether the VM has a hibernation spec and its VMI
+// claimed the hibernation volume
+func (ctrl *IdleController) canHibernate(vm *kubevirtv1.VirtualMachine, vmi *kubevirtv1.VirtualMachineInstance) bool {
+	return ctrl.ClusterConfig.HibernationEnabled() &&
+		vm.Spec.Hibernation != nil &&
+		vmi.Annotations[kubevirtv1.HibernationClaimAnnotation] != ""
+}
+
+func (ctrl *IdleController) updateStatus(vm *kubevirtv1.VirtualMachine, status *kubevirtv1.VirtualMachineIdleStatus) error {
+	// the times read back from the API server are in the local time zone
+	if equality.Semantic.DeepEqual(vm.Status.IdleStatus, status) {
+		return nil
+	}
+	updated := vm.DeepCopy()
+	updated.Status.IdleStatus = status
+	_, err := ctrl.Client.VirtualMachine(vm.Namespace).UpdateStatus(updated)
+	return err
+}
+
+// getSample returns the last sample of the VMI, nil if there is none or it
+// belongs to a previous VMI of the VM
+func (ctrl *IdleController) getSample(key string, uid types.UID) *sample {
+	ctrl.samplesLock.Lock()
+	defer ctrl.samplesLock.Unlock()
+
+	if s, ok := ctrl.samples[key]; ok && s.vmiUID == uid {
+		return s
+	}
+	return nil
+}
+
+func (ctrl *IdleController) setSample(key string, s *sample) {
+	ctrl.samplesLock.Lock()
+	defer ctrl.samplesLock.Unlock()
+
+	ctrl.samples[key] = s
+}
+
+func (ctrl *IdleController) forgetSample(key string) {
+	ctrl.samplesLock.Lock()
+	defer ctrl.samplesLock.Unlock()
+
+	delete(ctrl.samples, key)
+}
+
+// consoleActivityTime returns the last console activity virt-api recorded on
+// the VMI, the zero time if there was none
+func consoleActivityTime(vmi *kubevirtv1.VirtualMachineInstance) time.Time {
+	value, ok := vmi.Annotations[kubevirtv1.ConsoleActivityAnnotation]
+	if !ok {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warningf("Ignoring invalid console activity %q", value)
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,212 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package idle
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// IdleController marks VMs idle whose guests stay below the activity
+// thresholds of the idle policy, and pauses or hibernates them
+type IdleController struct {
+	Client kubecli.KubevirtClient
+
+	VMInformer        cache.SharedIndexInformer
+	VMIInformer       cache.SharedIndexInformer
+	NamespaceInformer cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+	Recorder      record.EventRecorder
+
+	vmQueue workqueue.RateLimitingInterface
+	now     func() time.Time
+
+	samplesLock sync.Mutex
+	samples     map[string]*sample
+}
+
+// Init initializes the idle controller
+func (ctrl *IdleController) Init() {
+	ctrl.vmQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "idle-controller-vm")
+	ctrl.now = time.Now
+	ctrl.samples = map[string]*sample{}
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVM,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVM(newObj) },
+			DeleteFunc: ctrl.handleVM,
+		},
+	)
+
+	ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+
+	ctrl.NamespaceInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleNamespace,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleNamespace(newObj) },
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *IdleController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmQueue.ShutDown()
+
+	log.Log.Info("Starting idle controller.")
+	defer log.Log.Info("Shutting down idle controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.NamespaceInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *IdleController) vmWorker() {
+	for ctrl.processVMWorkItem() {
+	}
+}
+
+func (ctrl *IdleController) processVMWorkItem() bool {
+	key, quit := ctrl.vmQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.vmQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachine %v", key)
+		ctrl.vmQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachine %v", key)
+		ctrl.vmQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *IdleController) execute(key string) error {
+	if !ctrl.ClusterConfig.IdleSuspendEnabled() {
+		return nil
+	}
+
+	storeObj, exists, err := ctrl.VMInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		ctrl.forgetSample(key)
+		return nil
+	}
+
+	vm, ok := storeObj.(*kubevirtv1.VirtualMachine)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	next, err := ctrl.updateIdleStatus(key, vm.DeepCopy())
+	if err != nil {
+		return err
+	}
+	// the stats are not announced by an event of the VM
+	if next > 0 {
+		ctrl.vmQueue.AddAfter(key, next)
+	}
+	return nil
+}
+
+func (ctrl *IdleController) handleVM(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+		ctrl.enqueue(vm)
+	}
+}
+
+// handleVMI enqueues the VM of the VMI, which shares its name
+func (ctrl *IdleController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmi, ok := obj.(*kubevirtv1.VirtualMachineInstance); ok {
+		ctrl.enqueue(vmi)
+	}
+}
+
+// handleNamespace enqueues the VMs of a namespace, which may have opted in to
+// or out of the idle policy
+func (ctrl *IdleController) handleNamespace(obj interface{}) {
+	namespace, ok := obj.(*k8sv1.Namespace)
+	if !ok {
+		return
+	}
+
+	for _, obj := range ctrl.VMInformer.GetStore().List() {
+		if vm := obj.(*kubevirtv1.VirtualMachine); vm.Namespace == namespace.Name {
+			ctrl.enqueue(vm)
+		}
+	}
+}
+
+func (ctrl *IdleController) enqueue(obj interface{}) {
+	objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Log.Errorf("failed to get key from object: %v, %v", err, obj)
+		return
+	}
+
+	log.Log.V(4).Infof("enqueued %q for sync", objName)
+	ctrl.vmQueue.Add(objName)
+}
@@ -0,0 +1,396 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package idle
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	testNamespace = "default"
+
+	testKey = testNamespace + "/dev01"
+)
+
+var _ = Describe("Idle", func() {
+
+	var ctrl *gomock.Controller
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var namespaceInformer cache.SharedIndexInformer
+	var recorder *record.FakeRecorder
+	var controller *IdleController
+	var now time.Time
+	var updated *v1.VirtualMachine
+
+	start := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	newClusterConfig := func(action v1.IdleAction) *virtconfig.ClusterConfig {
+		cpuThreshold := resource.MustParse("50m")
+		networkThreshold := resource.MustParse("1Ki")
+		window := int64(3600)
+		interval := int64(300)
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{
+						FeatureGates: []string{virtconfig.IdleSuspendGate, virtconfig.HibernationGate},
+					},
+					IdlePolicy: &v1.IdlePolicyConfiguration{
+						Action:                action,
+						WindowSeconds:         &window,
+						SampleIntervalSeconds: &interval,
+						CPUThreshold:          &cpuThreshold,
+						NetworkThreshold:      &networkThreshold,
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		return config
+	}
+
+	newVM := func() *v1.VirtualMachine {
+		running := true
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dev01",
+				Namespace: testNamespace,
+			},
+			Spec: v1.VirtualMachineSpec{
+				Running: &running,
+			},
+		}
+	}
+
+	runningVMI := func() *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "dev01",
+				Namespace:         testNamespace,
+				UID:               "vmi-uid",
+				CreationTimestamp: metav1.NewTime(start.Add(-time.Minute)),
+				Annotations:       map[string]string{},
+			},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: v1.Running,
+			},
+		}
+	}
+
+	pause := func(vmi *v1.VirtualMachineInstance) {
+		vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+			Type:   v1.VirtualMachineInstancePaused,
+			Status: k8sv1.ConditionTrue,
+		}}
+	}
+
+	expectStats := func(cpuTime, network int64) {
+		vmiInterface.EXPECT().Stats("dev01").Return(&v1.VirtualMachineInstanceStats{
+			CPUTimeNanoseconds:  cpuTime,
+			NetworkReceiveBytes: network,
+		}, nil)
+	}
+
+	lastActivity := func(t time.Time) *v1.VirtualMachineIdleStatus {
+		return &v1.VirtualMachineIdleStatus{LastActivityTime: &metav1.Time{Time: t}}
+	}
+
+	process := func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) (time.Duration, error) {
+		Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+		if vmi != nil {
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+		}
+		updated = nil
+		return controller.updateIdleStatus(testKey, vm)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		namespaceInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Namespace{})
+		recorder = record.NewFakeRecorder(100)
+
+		Expect(namespaceInformer.GetStore().Add(&k8sv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   testNamespace,
+				Labels: map[string]string{v1.IdlePolicyLabel: "enabled"},
+			},
+		})).To(Succeed())
+
+		controller = &IdleController{
+			Client:            virtClient,
+			VMInformer:        vmInformer,
+			VMIInformer:       vmiInformer,
+			NamespaceInformer: namespaceInformer,
+			ClusterConfig:     newClusterConfig(v1.IdleActionPause),
+			Recorder:          recorder,
+		}
+		controller.Init()
+
+		now = start
+		controller.now = func() time.Time { return now }
+
+		virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
+
+		vmInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+			updated = vm
+			return vm, nil
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should start the window when the VMI is first seen", func() {
+		vm := newVM()
+		expectStats(0, 0)
+
+		next, err := process(vm, runningVMI())
+		Expect(err).ToNot(HaveOccurred())
+
+		status := updated.Status.IdleStatus
+		Expect(status.LastActivityTime.Time).To(Equal(now))
+		Expect(status.Idle).To(BeFalse())
+		Expect(next).To(Equal(5 * time.Minute))
+	})
+
+	It("should wait for the sample interval between two reads of the stats", func() {
+		vm := newVM()
+		vmi := runningVMI()
+		expectStats(0, 0)
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+
+		vm.Status.IdleStatus = updated.Status.IdleStatus
+		now = now.Add(time.Minute)
+		next, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated).To(BeNil())
+		Expect(next).To(Equal(4 * time.Minute))
+	})
+
+	It("should record the activity of a busy guest", func() {
+		vm := newVM()
+		vmi := runningVMI()
+		expectStats(0, 0)
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+
+		vm.Status.IdleStatus = updated.Status.IdleStatus
+		now = now.Add(5 * time.Minute)
+		// 100m of CPU
+		expectStats(int64(30*time.Second), 0)
+		_, err = process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Status.IdleStatus.LastActivityTime.Time).To(Equal(now))
+	})
+
+	It("should record the network activity of a guest", func() {
+		vm := newVM()
+		vmi := runningVMI()
+		expectStats(0, 0)
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+
+		vm.Status.IdleStatus = updated.Status.IdleStatus
+		now = now.Add(5 * time.Minute)
+		expectStats(0, 300*2048)
+		_, err = process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Status.IdleStatus.LastActivityTime.Time).To(Equal(now))
+	})
+
+	It("should pause a VM idle for the window", func() {
+		vm := newVM()
+		vm.Status.IdleStatus = lastActivity(start.Add(-55 * time.Minute))
+		vmi := runningVMI()
+		vmi.CreationTimestamp = metav1.NewTime(start.Add(-2 * time.Hour))
+		expectStats(0, 0)
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated).To(BeNil())
+
+		now = now.Add(5 * time.Minute)
+		// 10m of CPU
+		expectStats(int64(3*time.Second), 1024)
+		vmiInterface.EXPECT().Pause("dev01").Return(nil)
+		next, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		testutils.ExpectEvent(recorder, idleSuspendedEvent)
+
+		status := updated.Status.IdleStatus
+		Expect(status.Idle).To(BeTrue())
+		Expect(status.Action).To(Equal(v1.IdleActionPause))
+		Expect(status.LastActivityTime.Time).To(Equal(start.Add(-55 * time.Minute)))
+		Expect(next).To(BeZero())
+	})
+
+	It("should keep a VM with recent console activity running", func() {
+		vm := newVM()
+		vm.Status.IdleStatus = lastActivity(start.Add(-2 * time.Hour))
+		vmi := runningVMI()
+		vmi.CreationTimestamp = metav1.NewTime(start.Add(-3 * time.Hour))
+		vmi.Annotations[v1.ConsoleActivityAnnotation] = start.Add(-time.Minute).Format(time.RFC3339)
+		expectStats(0, 0)
+
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+
+		status := updated.Status.IdleStatus
+		Expect(status.Idle).To(BeFalse())
+		Expect(status.LastActivityTime.Time).To(Equal(start.Add(-time.Minute)))
+	})
+
+	It("should hibernate an idle VM which can be hibernated", func() {
+		controller.ClusterConfig = newClusterConfig(v1.IdleActionHibernate)
+		vm := newVM()
+		vm.Spec.Hibernation = &v1.VirtualMachineHibernation{}
+		vm.Status.IdleStatus = lastActivity(start.Add(-2 * time.Hour))
+		vmi := runningVMI()
+		vmi.CreationTimestamp = metav1.NewTime(start.Add(-3 * time.Hour))
+		vmi.Annotations[v1.HibernationClaimAnnotation] = "dev01-hibernation"
+		expectStats(0, 0)
+		vmInterface.EXPECT().Hibernate("dev01").Return(nil)
+
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		testutils.ExpectEvent(recorder, idleSuspendedEvent)
+		Expect(updated.Status.IdleStatus.Action).To(Equal(v1.IdleActionHibernate))
+
+		// the status is kept while the VM is hibernated
+		vm.Status.IdleStatus = updated.Status.IdleStatus
+		Expect(vmiInformer.GetStore().Delete(vmi)).To(Succeed())
+		_, err = process(vm, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated).To(BeNil())
+	})
+
+	It("should pause an idle VM without a hibernation claim", func() {
+		controller.ClusterConfig = newClusterConfig(v1.IdleActionHibernate)
+		vm := newVM()
+		vm.Status.IdleStatus = lastActivity(start.Add(-2 * time.Hour))
+		vmi := runningVMI()
+		vmi.CreationTimestamp = metav1.NewTime(start.Add(-3 * time.Hour))
+		expectStats(0, 0)
+		vmiInterface.EXPECT().Pause("dev01").Return(nil)
+
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Status.IdleStatus.Action).To(Equal(v1.IdleActionPause))
+	})
+
+	It("should restart the window once a paused VM is unpaused", func() {
+		vm := newVM()
+		vm.Status.IdleStatus = lastActivity(start.Add(-2 * time.Hour))
+		vm.Status.IdleStatus.Idle = true
+		vm.Status.IdleStatus.Action = v1.IdleActionPause
+		vmi := runningVMI()
+		vmi.CreationTimestamp = metav1.NewTime(start.Add(-3 * time.Hour))
+		pause(vmi)
+
+		next, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated).To(BeNil())
+		Expect(next).To(BeZero())
+
+		vmi.Status.Conditions = nil
+		expectStats(0, 0)
+		_, err = process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+
+		status := updated.Status.IdleStatus
+		Expect(status.Idle).To(BeFalse())
+		Expect(status.Action).To(BeEmpty())
+		Expect(status.LastActivityTime.Time).To(Equal(now))
+	})
+
+	It("should not read the stats of a VMI paused by the user", func() {
+		vm := newVM()
+		vm.Status.IdleStatus = lastActivity(start.Add(-2 * time.Hour))
+		vmi := runningVMI()
+		vmi.CreationTimestamp = metav1.NewTime(start.Add(-3 * time.Hour))
+		pause(vmi)
+
+		_, err := process(vm, vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Status.IdleStatus.LastActivityTime.Time).To(Equal(now))
+		Expect(updated.Status.IdleStatus.Idle).To(BeFalse())
+	})
+
+	table.DescribeTable("should clear the status of VMs outside of the policy", func(namespaceLabel, vmLabel string) {
+		Expect(namespaceInformer.GetStore().Update(&k8sv1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   testNamespace,
+				Labels: map[string]string{v1.IdlePolicyLabel: namespaceLabel},
+			},
+		})).To(Succeed())
+		vm := newVM()
+		vm.Labels = map[string]string{v1.IdlePolicyLabel: vmLabel}
+		vm.Status.IdleStatus = lastActivity(start)
+
+		next, err := process(vm, runningVMI())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Status.IdleStatus).To(BeNil())
+		Expect(next).To(BeZero())
+	},
+		table.Entry("namespace not opted in", "", ""),
+		table.Entry("VM opted out", "enabled", "disabled"),
+	)
+
+	It("should clear the status of a stopped VM", func() {
+		vm := newVM()
+		vm.Status.IdleStatus = lastActivity(start)
+
+		_, err := process(vm, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Status.IdleStatus).To(BeNil())
+	})
+})
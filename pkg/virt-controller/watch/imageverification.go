@@ -0,0 +1,201 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
+)
+
+const (
+	// imageChecksumAnnotation records the digest of the disk of a DataVolume,
+	// it is taken once after the import
+	imageChecksumAnnotation = "kubevirt.io/image-checksum"
+	// imageVerifierAnnotation names the DataVolume an image verifier pod hashes
+	imageVerifierAnnotation = "kubevirt.io/verified-data-volume"
+
+	// FailedVerifyImageReason is added in an event when the disk of a DataVolume
+	// could not be hashed
+	FailedVerifyImageReason = "FailedVerifyImage"
+)
+
+// verifyDataVolumes ensures that the imported disks of the DataVolumes of the
+// vmi which declare a checksum match it. Every disk is hashed once by an image
+// verifier pod, the digest is kept on the DataVolume since the guest changes
+// the disk afterwards. It returns whether all disks were verified.
+func (c *VMIController) verifyDataVolumes(vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) (bool, syncError) {
+	verified := true
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.DataVolume == nil || volume.DataVolume.Checksum == "" {
+			continue
+		}
+
+		var dataVolume *cdiv1.DataVolume
+		for _, dv := range dataVolumes {
+			if dv.Name == volume.DataVolume.Name {
+				dataVolume = dv
+				break
+			}
+		}
+		if dataVolume == nil || dataVolume.Status.Phase != cdiv1.Succeeded {
+			verified = false
+			continue
+		}
+
+		done, syncErr := c.verifyDataVolume(vmi, dataVolume, volume.DataVolume.Checksum)
+		if syncErr != nil {
+			return false, syncErr
+		}
+		verified = verified && done
+	}
+	return verified, nil
+}
+
+func (c *VMIController) verifyDataVolume(vmi *virtv1.VirtualMachineInstance, dataVolume *cdiv1.DataVolume, checksum string) (bool, syncError) {
+	podName := imageVerifierPodName(dataVolume)
+	obj, podExists, err := c.podInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", dataVolume.Namespace, podName))
+	if err != nil {
+		return false, &syncErrorImpl{err, FailedVerifyImageReason}
+	}
+
+	if digest, ok := dataVolume.Annotations[imageChecksumAnnotation]; ok {
+		if podExists {
+			// the pod has done its job, the volume is used once it is gone
+			if err := c.deleteImageVerifierPod(dataVolume.Namespace, podName); err != nil {
+				return false, &syncErrorImpl{err, FailedVerifyImageReason}
+			}
+			return false, nil
+		}
+		if digest != checksum {
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, virtv1.VirtualMachineInstanceReasonChecksumMismatch, "DataVolume %s does not match its checksum", dataVolume.Name)
+			return false, &syncErrorImpl{fmt.Errorf("DataVolume %s: %s: expected %s, got %s", dataVolume.Name, containerdisk.ChecksumMismatch, checksum, digest), virtv1.VirtualMachineInstanceReasonChecksumMismatch}
+		}
+		return true, nil
+	}
+
+	if !podExists {
+		return false, c.createImageVerifierPod(vmi, dataVolume)
+	}
+
+	pod := obj.(*k8sv1.Pod)
+	switch pod.Status.Phase {
+	case k8sv1.PodSucceeded:
+		digest := imageVerifierDigest(pod)
+		if err := containerdisk.ValidateChecksum(digest); err != nil {
+			return false, c.failImageVerification(vmi, dataVolume, fmt.Errorf("invalid digest reported: %v", err))
+		}
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, imageChecksumAnnotation, digest)
+		if _, err := c.clientset.CdiClient().CdiV1alpha1().DataVolumes(dataVolume.Namespace).Patch(dataVolume.Name, types.MergePatchType, []byte(patch)); err != nil {
+			return false, &syncErrorImpl{fmt.Errorf("failed to record the digest of DataVolume %s: %v", dataVolume.Name, err), FailedVerifyImageReason}
+		}
+		log.Log.Object(vmi).Infof("Hashed the disk of DataVolume %s: %s", dataVolume.Name, digest)
+	case k8sv1.PodFailed:
+		return false, c.failImageVerification(vmi, dataVolume, fmt.Errorf("pod %s failed: %s", pod.Name, strings.TrimSpace(imageVerifierDigest(pod))))
+	}
+	return false, nil
+}
+
+func (c *VMIController) createImageVerifierPod(vmi *virtv1.VirtualMachineInstance, dataVolume *cdiv1.DataVolume) syncError {
+	_, exists, isBlock, err := kubevirttypes.IsPVCBlockFromStore(c.pvcInformer.GetStore(), dataVolume.Namespace, dataVolume.Name)
+	if err != nil {
+		return &syncErrorImpl{err, FailedVerifyImageReason}
+	}
+	if !exists {
+		return &syncErrorImpl{fmt.Errorf("PVC %s of DataVolume %s does not exist", dataVolume.Name, dataVolume.Name), FailedVerifyImageReason}
+	}
+
+	pod, err := c.templateService.RenderImageVerifierPodTemplate(imageVerifierPodName(dataVolume), dataVolume.Name, isBlock)
+	if err != nil {
+		return &syncErrorImpl{fmt.Errorf("failed to render the image verifier pod: %v", err), FailedVerifyImageReason}
+	}
+	pod.Annotations = map[string]string{imageVerifierAnnotation: dataVolume.Name}
+	// the pod is not controlled by the DataVolume, CDI would adopt it otherwise
+	pod.OwnerReferences = []v1.OwnerReference{{
+		APIVersion: cdiv1.SchemeGroupVersion.String(),
+		Kind:       "DataVolume",
+		Name:       dataVolume.Name,
+		UID:        dataVolume.UID,
+	}}
+	if _, err := c.clientset.CoreV1().Pods(dataVolume.Namespace).Create(pod); err != nil && !k8serrors.IsAlreadyExists(err) {
+		c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedVerifyImageReason, "Error creating image verifier pod: %v", err)
+		return &syncErrorImpl{fmt.Errorf("failed to create the image verifier pod: %v", err), FailedVerifyImageReason}
+	}
+	c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, SuccessfulCreatePodReason, "Created image verifier pod %s for DataVolume %s", pod.Name, dataVolume.Name)
+	return nil
+}
+
+// failImageVerification removes the pod which failed to hash the disk, so that
+// the next attempt starts a new one
+func (c *VMIController) failImageVerification(vmi *virtv1.VirtualMachineInstance, dataVolume *cdiv1.DataVolume, err error) syncError {
+	c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedVerifyImageReason, "Failed to hash the disk of DataVolume %s: %v", dataVolume.Name, err)
+	if err := c.deleteImageVerifierPod(dataVolume.Namespace, imageVerifierPodName(dataVolume)); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to delete the image verifier pod")
+	}
+	return &syncErrorImpl{fmt.Errorf("failed to hash the disk of DataVolume %s: %v", dataVolume.Name, err), FailedVerifyImageReason}
+}
+
+func (c *VMIController) deleteImageVerifierPod(namespace, name string) error {
+	err := c.clientset.CoreV1().Pods(namespace).Delete(name, &v1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the image verifier pod: %v", err)
+	}
+	return nil
+}
+
+// enqueueImageVerifierPod wakes up the vmis using the DataVolume hashed by the
+// pod, false is returned if it is no image verifier pod
+func (c *VMIController) enqueueImageVerifierPod(pod *k8sv1.Pod) bool {
+	dataVolumeName, ok := pod.Annotations[imageVerifierAnnotation]
+	if !ok {
+		return false
+	}
+	vmis, err := c.listVMIsMatchingDataVolume(pod.Namespace, dataVolumeName)
+	if err != nil {
+		return true
+	}
+	for _, vmi := range vmis {
+		c.enqueueVirtualMachine(vmi)
+	}
+	return true
+}
+
+func imageVerifierPodName(dataVolume *cdiv1.DataVolume) string {
+	return dataVolume.Name + "-image-verifier"
+}
+
+func imageVerifierDigest(pod *k8sv1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			return status.State.Terminated.Message
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const maxMasqueradeSubnetPrefixLength = 30
+
+// masqueradeSubnetAllocator hands out the subnets of the masquerade subnet pool
+// of the cluster. The subnets in use are the ones recorded in the status of the
+// VMIs which are not final, plus the ones allocated by this controller which
+// did not reach the VMI cache yet.
+type masqueradeSubnetAllocator struct {
+	vmiStore      cache.Store
+	clusterConfig *virtconfig.ClusterConfig
+
+	lock    sync.Mutex
+	pending map[types.UID]string
+}
+
+func newMasqueradeSubnetAllocator(vmiStore cache.Store, clusterConfig *virtconfig.ClusterConfig) *masqueradeSubnetAllocator {
+	return &masqueradeSubnetAllocator{
+		vmiStore:      vmiStore,
+		clusterConfig: clusterConfig,
+		pending:       map[types.UID]string{},
+	}
+}
+
+// needsSubnet returns true if the pool is configured and the vmi has a
+// masquerade interface on a pod network without vmNetworkCIDR which was not
+// allocated a subnet yet
+func (a *masqueradeSubnetAllocator) needsSubnet(vmi *virtv1.VirtualMachineInstance) bool {
+	if a.clusterConfig.GetMasqueradeSubnetPool() == nil || vmi.Status.VMNetworkCIDR != "" {
+		return false
+	}
+	for _, network := range vmi.Spec.Networks {
+		if network.Pod == nil || network.Pod.VMNetworkCIDR != "" {
+			continue
+		}
+		for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+			if iface.Name == network.Name && iface.Masquerade != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allocate returns the first subnet of the pool which does not overlap with a
+// subnet in use. Until the vmi cache shows the subnet in the status of the vmi,
+// further calls for the same vmi return the same subnet.
+func (a *masqueradeSubnetAllocator) allocate(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	pool := a.clusterConfig.GetMasqueradeSubnetPool()
+	if pool == nil {
+		return "", fmt.Errorf("no masquerade subnet pool is configured")
+	}
+	_, poolNet, err := net.ParseCIDR(pool.CIDR)
+	if err != nil || poolNet.IP.To4() == nil {
+		return "", fmt.Errorf("invalid masquerade subnet pool CIDR %s", pool.CIDR)
+	}
+	poolPrefixLength, _ := poolNet.Mask.Size()
+	prefixLength := virtconfig.DefaultMasqueradeSubnetPrefixLength
+	if pool.PrefixLength != nil {
+		prefixLength = int(*pool.PrefixLength)
+	}
+	if prefixLength < poolPrefixLength || prefixLength > maxMasqueradeSubnetPrefixLength {
+		return "", fmt.Errorf("invalid masquerade subnet prefix length %d for pool %s", prefixLength, pool.CIDR)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	used := a.usedSubnets()
+	if subnet, exists := a.pending[vmi.UID]; exists {
+		return subnet, nil
+	}
+
+	base := binary.BigEndian.Uint32(poolNet.IP.To4())
+	size := uint32(1) << uint(32-prefixLength)
+	count := uint64(1) << uint(prefixLength-poolPrefixLength)
+	for i := uint64(0); i < count; i++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, base+uint32(i)*size)
+		candidate := &net.IPNet{IP: ip, Mask: net.CIDRMask(prefixLength, 32)}
+		if !overlapsAny(candidate, used) {
+			a.pending[vmi.UID] = candidate.String()
+			return candidate.String(), nil
+		}
+	}
+	return "", fmt.Errorf("masquerade subnet pool %s is exhausted", pool.CIDR)
+}
+
+// usedSubnets collects the subnets in use and forgets the pending allocations
+// which are visible in the vmi cache or belong to vmis which are gone or final.
+// The caller must hold the lock.
+func (a *masqueradeSubnetAllocator) usedSubnets() []*net.IPNet {
+	var used []*net.IPNet
+	vmis := map[types.UID]*virtv1.VirtualMachineInstance{}
+	for _, obj := range a.vmiStore.List() {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		vmis[vmi.UID] = vmi
+		if vmi.IsFinal() || vmi.Status.VMNetworkCIDR == "" {
+			continue
+		}
+		if _, subnet, err := net.ParseCIDR(vmi.Status.VMNetworkCIDR); err == nil {
+			used = append(used, subnet)
+		}
+	}
+
+	for uid, cidr := range a.pending {
+		vmi, exists := vmis[uid]
+		if !exists || vmi.IsFinal() || vmi.Status.VMNetworkCIDR != "" {
+			delete(a.pending, uid)
+			continue
+		}
+		if _, subnet, err := net.ParseCIDR(cidr); err == nil {
+			used = append(used, subnet)
+		}
+	}
+	return used
+}
+
+func overlapsAny(subnet *net.IPNet, subnets []*net.IPNet) bool {
+	for _, other := range subnets {
+		if subnet.Contains(other.IP) || other.Contains(subnet.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/net/nad"
+)
+
+// networkAttachmentsCondition resolves the NetworkAttachmentDefinitions of the
+// multus networks of the vmi and returns the NetworkAttachmentsReady condition
+// reflecting them, or nil if the vmi has no multus network.
+func (c *VMIController) networkAttachmentsCondition(vmi *virtv1.VirtualMachineInstance) *virtv1.VirtualMachineInstanceCondition {
+	hasMultusNetwork := false
+	for _, network := range vmi.Spec.Networks {
+		if network.Multus == nil {
+			continue
+		}
+		hasMultusNetwork = true
+
+		namespace, name := nad.SplitNetworkName(vmi.Namespace, network.Multus.NetworkName)
+		obj, exists, _ := c.networkAttachmentInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+		if !exists {
+			return &virtv1.VirtualMachineInstanceCondition{
+				Type:    virtv1.VirtualMachineInstanceNetworkAttachmentsReady,
+				Status:  k8sv1.ConditionFalse,
+				Reason:  virtv1.VirtualMachineInstanceReasonNetworkAttachmentNotFound,
+				Message: fmt.Sprintf("network attachment definition %s/%s of network %s does not exist", namespace, name, network.Name),
+			}
+		}
+		if err := nad.ValidateConfig(obj.(*networkv1.NetworkAttachmentDefinition)); err != nil {
+			return &virtv1.VirtualMachineInstanceCondition{
+				Type:    virtv1.VirtualMachineInstanceNetworkAttachmentsReady,
+				Status:  k8sv1.ConditionFalse,
+				Reason:  virtv1.VirtualMachineInstanceReasonNetworkAttachmentInvalid,
+				Message: fmt.Sprintf("network attachment definition %s/%s of network %s is invalid: %v", namespace, name, network.Name, err),
+			}
+		}
+	}
+
+	if !hasMultusNetwork {
+		return nil
+	}
+	return &virtv1.VirtualMachineInstanceCondition{
+		Type:   virtv1.VirtualMachineInstanceNetworkAttachmentsReady,
+		Status: k8sv1.ConditionTrue,
+	}
+}
+
+// syncNetworkAttachmentsCondition replaces the NetworkAttachmentsReady
+// condition of the vmi if it changed, or removes it if condition is nil.
+func syncNetworkAttachmentsCondition(vmi *virtv1.VirtualMachineInstance, condition *virtv1.VirtualMachineInstanceCondition) {
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	current := conditionManager.GetCondition(vmi, virtv1.VirtualMachineInstanceNetworkAttachmentsReady)
+	if condition == nil {
+		if current != nil {
+			conditionManager.RemoveCondition(vmi, virtv1.VirtualMachineInstanceNetworkAttachmentsReady)
+		}
+		return
+	}
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason && current.Message == condition.Message {
+		return
+	}
+
+	conditionManager.RemoveCondition(vmi, virtv1.VirtualMachineInstanceNetworkAttachmentsReady)
+	condition.LastTransitionTime = v1.Now()
+	vmi.Status.Conditions = append(vmi.Status.Conditions, *condition)
+}
+
+func (c *VMIController) addNetworkAttachmentDefinition(obj interface{}) {
+	c.enqueueVMIsReferencingNetworkAttachment(obj.(*networkv1.NetworkAttachmentDefinition))
+}
+
+func (c *VMIController) updateNetworkAttachmentDefinition(old, cur interface{}) {
+	curDefinition := cur.(*networkv1.NetworkAttachmentDefinition)
+	oldDefinition := old.(*networkv1.NetworkAttachmentDefinition)
+	if curDefinition.ResourceVersion == oldDefinition.ResourceVersion {
+		// Periodic resync will send update events for all known definitions.
+		return
+	}
+	c.enqueueVMIsReferencingNetworkAttachment(curDefinition)
+}
+
+func (c *VMIController) deleteNetworkAttachmentDefinition(obj interface{}) {
+	definition, ok := obj.(*networkv1.NetworkAttachmentDefinition)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		definition, ok = tombstone.Obj.(*networkv1.NetworkAttachmentDefinition)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a network attachment definition %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	c.enqueueVMIsReferencingNetworkAttachment(definition)
+}
+
+// enqueueVMIsReferencingNetworkAttachment wakes up the vmis which did not get
+// a pod yet and reference the definition, definitions may live in another
+// namespace than the vmi.
+func (c *VMIController) enqueueVMIsReferencingNetworkAttachment(definition *networkv1.NetworkAttachmentDefinition) {
+	for _, obj := range c.vmiInformer.GetStore().List() {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		if !vmi.IsUnprocessed() {
+			continue
+		}
+		for _, network := range vmi.Spec.Networks {
+			if network.Multus == nil {
+				continue
+			}
+			namespace, name := nad.SplitNetworkName(vmi.Namespace, network.Multus.NetworkName)
+			if namespace == definition.Namespace && name == definition.Name {
+				log.Log.V(4).Object(definition).Infof("Network attachment definition changed for vmi %s", vmi.Name)
+				c.enqueueVirtualMachine(vmi)
+				break
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
@@ -20,35 +21,45 @@ import (
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/util/lookup"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
 	// NodeUnresponsiveReason is in various places as reason to indicate that
 	// an action was taken because virt-handler became unresponsive.
 	NodeUnresponsiveReason = "NodeUnresponsive"
+	// FencingRequestedReason is the reason of the event recorded when a
+	// fencing agent is asked to fence an unresponsive node.
+	FencingRequestedReason = "FencingRequested"
 )
 
 // NodeController is the main NodeController struct.
 type NodeController struct {
-	clientset        kubecli.KubevirtClient
-	Queue            workqueue.RateLimitingInterface
-	nodeInformer     cache.SharedIndexInformer
-	vmiInformer      cache.SharedIndexInformer
-	recorder         record.EventRecorder
-	heartBeatTimeout time.Duration
-	recheckInterval  time.Duration
+	clientset              kubecli.KubevirtClient
+	Queue                  workqueue.RateLimitingInterface
+	nodeInformer           cache.SharedIndexInformer
+	vmiInformer            cache.SharedIndexInformer
+	fencingRequestInformer cache.SharedIndexInformer
+	recorder               record.EventRecorder
+	clusterConfig          *virtconfig.ClusterConfig
+	namespace              string
+	heartBeatTimeout       time.Duration
+	recheckInterval        time.Duration
 }
 
 // NewNodeController creates a new instance of the NodeController struct.
-func NewNodeController(clientset kubecli.KubevirtClient, nodeInformer cache.SharedIndexInformer, vmiInformer cache.SharedIndexInformer, recorder record.EventRecorder) *NodeController {
+func NewNodeController(clientset kubecli.KubevirtClient, nodeInformer cache.SharedIndexInformer, vmiInformer cache.SharedIndexInformer, fencingRequestInformer cache.SharedIndexInformer, recorder record.EventRecorder, clusterConfig *virtconfig.ClusterConfig, namespace string) *NodeController {
 	c := &NodeController{
-		clientset:        clientset,
-		Queue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
-		nodeInformer:     nodeInformer,
-		vmiInformer:      vmiInformer,
-		recorder:         recorder,
-		heartBeatTimeout: 5 * time.Minute,
-		recheckInterval:  1 * time.Minute,
+		clientset:              clientset,
+		Queue:                  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		nodeInformer:           nodeInformer,
+		vmiInformer:            vmiInformer,
+		fencingRequestInformer: fencingRequestInformer,
+		recorder:               recorder,
+		clusterConfig:          clusterConfig,
+		namespace:              namespace,
+		heartBeatTimeout:       5 * time.Minute,
+		recheckInterval:        1 * time.Minute,
 	}
 
 	c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -63,6 +74,12 @@ func NewNodeController(clientset kubecli.KubevirtClient, nodeInformer cache.Shar
 		UpdateFunc: c.updateVirtualMachine,
 	})
 
+	c.fencingRequestInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addFencingRequest,
+		DeleteFunc: func(_ interface{}) {}, // nothing to do
+		UpdateFunc: c.updateFencingRequest,
+	})
+
 	return c
 }
 
@@ -102,6 +119,17 @@ func (c *NodeController) updateVirtualMachine(old, curr interface{}) {
 	}
 }
 
+func (c *NodeController) addFencingRequest(obj interface{}) {
+	request := obj.(*virtv1.FencingRequest)
+	if request.Spec.NodeName != "" {
+		c.Queue.Add(request.Spec.NodeName)
+	}
+}
+
+func (c *NodeController) updateFencingRequest(old, curr interface{}) {
+	c.addFencingRequest(curr)
+}
+
 // Run runs the passed in NodeController.
 func (c *NodeController) Run(threadiness int, stopCh <-chan struct{}) {
 	defer controller.HandlePanic()
@@ -109,7 +137,7 @@ func (c *NodeController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting node controller.")
 
 	// Wait for cache sync before we start the node controller
-	cache.WaitForCacheSync(stopCh, c.nodeInformer.HasSynced, c.vmiInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.nodeInformer.HasSynced, c.vmiInformer.HasSynced, c.fencingRequestInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -195,6 +223,21 @@ func (c *NodeController) execute(key string) error {
 
 		vmis = filterStuckVirtualMachinesWithoutPods(vmis, pods)
 
+		// The VMIs may still run on the node, only restart them elsewhere
+		// once a fencing agent acknowledged that the node is fenced
+		if len(vmis) > 0 && c.clusterConfig.NodeFencingEnabled() {
+			if fenced, err := c.requestFencing(node, nodeName); err != nil {
+				logger.Reason(err).Error("Failed to request fencing of node")
+				return fmt.Errorf("failed to request fencing of node %s: %v", nodeName, err)
+			} else if !fenced {
+				logger.V(2).Infof("Waiting for node %s to be fenced", nodeName)
+				if nodeExists {
+					c.Queue.AddAfter(key, c.recheckInterval)
+				}
+				return nil
+			}
+		}
+
 		errs := []string{}
 		// Do sequential updates, we don't want to create update storms in situations where something might already be wrong
 		for _, vmi := range vmis {
@@ -216,6 +259,9 @@ func (c *NodeController) execute(key string) error {
 		if len(errs) > 0 {
 			return fmt.Errorf("%v", strings.Join(errs, "; "))
 		}
+	} else if err := c.withdrawFencingRequest(nodeName); err != nil {
+		logger.Reason(err).Error("Failed to delete the fencing request of node")
+		return fmt.Errorf("failed to delete the fencing request of node %s: %v", nodeName, err)
 	}
 	if nodeExists {
 		c.Queue.AddAfter(key, c.recheckInterval)
@@ -223,6 +269,51 @@ func (c *NodeController) execute(key string) error {
 	return nil
 }
 
+// requestFencing creates a FencingRequest for the node if there is none yet,
+// and returns true once a fencing agent set its phase to Fenced
+func (c *NodeController) requestFencing(node *v1.Node, nodeName string) (bool, error) {
+	obj, exists, err := c.fencingRequestInformer.GetStore().GetByKey(c.namespace + "/" + nodeName)
+	if err != nil {
+		return false, err
+	} else if exists {
+		return obj.(*virtv1.FencingRequest).Status.Phase == virtv1.FencingRequestFenced, nil
+	}
+
+	request := &virtv1.FencingRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeName,
+			Namespace: c.namespace,
+		},
+		Spec: virtv1.FencingRequestSpec{
+			NodeName: nodeName,
+		},
+	}
+	_, err = c.clientset.FencingRequest(c.namespace).Create(request)
+	if errors.IsAlreadyExists(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if node != nil {
+		c.recorder.Event(node, v1.EventTypeNormal, FencingRequestedReason, "virt-handler is not responsive, requesting the node to be fenced")
+	}
+	return false, nil
+}
+
+// withdrawFencingRequest deletes the FencingRequest of a node which is
+// responsive again, a later outage of the node needs to be fenced again
+func (c *NodeController) withdrawFencingRequest(nodeName string) error {
+	_, exists, err := c.fencingRequestInformer.GetStore().GetByKey(c.namespace + "/" + nodeName)
+	if err != nil || !exists {
+		return err
+	}
+	err = c.clientset.FencingRequest(c.namespace).Delete(nodeName, &metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (c *NodeController) alivePodsOnNode(nodeName string) ([]*v1.Pod, error) {
 	handlerNodeSelector := fields.ParseSelectorOrDie("spec.nodeName=" + nodeName)
 	list, err := c.clientset.CoreV1().Pods(v1.NamespaceAll).List(metav1.ListOptions{
@@ -25,6 +25,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var _ = Describe("Node controller with", func() {
@@ -36,6 +37,10 @@ var _ = Describe("Node controller with", func() {
 	var nodeInformer cache.SharedIndexInformer
 	var vmiSource *framework.FakeControllerSource
 	var vmiInformer cache.SharedIndexInformer
+	var fencingRequestSource *framework.FakeControllerSource
+	var fencingRequestInformer cache.SharedIndexInformer
+	var fencingRequestInterface *kubecli.MockFencingRequestInterface
+	var kubeVirtInformer cache.SharedIndexInformer
 	var stop chan struct{}
 	var controller *NodeController
 	var recorder *record.FakeRecorder
@@ -47,7 +52,8 @@ var _ = Describe("Node controller with", func() {
 	syncCaches := func(stop chan struct{}) {
 		go nodeInformer.Run(stop)
 		go vmiInformer.Run(stop)
-		Expect(cache.WaitForCacheSync(stop, nodeInformer.HasSynced, vmiInformer.HasSynced)).To(BeTrue())
+		go fencingRequestInformer.Run(stop)
+		Expect(cache.WaitForCacheSync(stop, nodeInformer.HasSynced, vmiInformer.HasSynced, fencingRequestInformer.HasSynced)).To(BeTrue())
 	}
 
 	BeforeEach(func() {
@@ -55,12 +61,25 @@ var _ = Describe("Node controller with", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		virtClient = kubecli.NewMockKubevirtClient(ctrl)
 		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		fencingRequestInterface = kubecli.NewMockFencingRequestInterface(ctrl)
 
 		nodeInformer, nodeSource = testutils.NewFakeInformerFor(&k8sv1.Node{})
 		vmiInformer, vmiSource = testutils.NewFakeInformerFor(&virtv1.VirtualMachineInstance{})
+		fencingRequestInformer, fencingRequestSource = testutils.NewFakeInformerFor(&virtv1.FencingRequest{})
 		recorder = record.NewFakeRecorder(100)
 
-		controller = NewNodeController(virtClient, nodeInformer, vmiInformer, recorder)
+		config, _, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(&virtv1.KubeVirt{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Status: virtv1.KubeVirtStatus{
+				Phase: virtv1.KubeVirtPhaseDeployed,
+			},
+		})
+		kubeVirtInformer = kvInformer
+
+		controller = NewNodeController(virtClient, nodeInformer, vmiInformer, fencingRequestInformer, recorder, config, "kubevirt")
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 		controller.Queue = mockQueue
@@ -69,6 +88,7 @@ var _ = Describe("Node controller with", func() {
 		// Set up mock client
 		virtClient.EXPECT().VirtualMachineInstance(v1.NamespaceAll).Return(vmiInterface).AnyTimes()
 		virtClient.EXPECT().VirtualMachineInstance(v1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+		virtClient.EXPECT().FencingRequest("kubevirt").Return(fencingRequestInterface).AnyTimes()
 		kubeClient = fake.NewSimpleClientset()
 		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
 
@@ -339,6 +359,102 @@ var _ = Describe("Node controller with", func() {
 		)
 	})
 
+	Context("unresponsive virt-handler and node fencing given", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kubeVirtInformer, &virtv1.KubeVirt{
+				Spec: virtv1.KubeVirtSpec{
+					Configuration: virtv1.KubeVirtConfiguration{
+						DeveloperConfiguration: &virtv1.DeveloperConfiguration{
+							FeatureGates: []string{virtconfig.NodeFencingGate},
+						},
+					},
+				},
+				Status: virtv1.KubeVirtStatus{
+					Phase: virtv1.KubeVirtPhaseDeployed,
+				},
+			})
+			kubeClient.Fake.PrependReactor("list", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				return true, &k8sv1.PodList{}, nil
+			})
+		})
+
+		newFencingRequest := func(node *k8sv1.Node, phase virtv1.FencingRequestPhase) *virtv1.FencingRequest {
+			return &virtv1.FencingRequest{
+				ObjectMeta: v1.ObjectMeta{Name: node.Name, Namespace: "kubevirt"},
+				Spec:       virtv1.FencingRequestSpec{NodeName: node.Name},
+				Status:     virtv1.FencingRequestStatus{Phase: phase},
+			}
+		}
+
+		It("should request fencing of the node instead of failing its vmis", func() {
+			node := NewUnhealthyNode("testnode")
+			vmi := NewRunningVirtualMachine("vmi1", node)
+
+			addNode(node)
+			vmiInterface.EXPECT().List(gomock.Any()).Return(&virtv1.VirtualMachineInstanceList{Items: []virtv1.VirtualMachineInstance{*vmi}}, nil)
+			fencingRequestInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(request *virtv1.FencingRequest) (*virtv1.FencingRequest, error) {
+				Expect(request.Name).To(Equal(node.Name))
+				Expect(request.Namespace).To(Equal("kubevirt"))
+				Expect(request.Spec.NodeName).To(Equal(node.Name))
+				return request, nil
+			})
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, FencingRequestedReason)
+		})
+
+		table.DescribeTable("should keep the vmis of the node while the fencing request is", func(phase virtv1.FencingRequestPhase) {
+			node := NewUnhealthyNode("testnode")
+			vmi := NewRunningVirtualMachine("vmi1", node)
+
+			fencingRequestInformer.GetStore().Add(newFencingRequest(node, phase))
+			addNode(node)
+			vmiInterface.EXPECT().List(gomock.Any()).Return(&virtv1.VirtualMachineInstanceList{Items: []virtv1.VirtualMachineInstance{*vmi}}, nil)
+
+			controller.Execute()
+		},
+			table.Entry("not handled yet", virtv1.FencingRequestPhase("")),
+			table.Entry("pending", virtv1.FencingRequestPending),
+			table.Entry("failed", virtv1.FencingRequestFailed),
+		)
+
+		It("should set the vmis of the node to failed state once the node is fenced", func() {
+			node := NewUnhealthyNode("testnode")
+			vmi := NewRunningVirtualMachine("vmi1", node)
+
+			fencingRequestInformer.GetStore().Add(newFencingRequest(node, virtv1.FencingRequestFenced))
+			addNode(node)
+			vmiInterface.EXPECT().List(gomock.Any()).Return(&virtv1.VirtualMachineInstanceList{Items: []virtv1.VirtualMachineInstance{*vmi}}, nil)
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any())
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, NodeUnresponsiveReason)
+		})
+
+		It("should enqueue the node of an updated fencing request", func() {
+			node := NewUnhealthyNode("testnode")
+
+			mockQueue.ExpectAdds(1)
+			fencingRequestSource.Add(newFencingRequest(node, virtv1.FencingRequestFenced))
+			mockQueue.Wait()
+
+			Expect(mockQueue.Len()).To(Equal(1))
+			key, _ := mockQueue.Get()
+			Expect(key).To(Equal(node.Name))
+			mockQueue.Done(key)
+		})
+
+		It("should delete the fencing request once the node is responsive again", func() {
+			node := NewHealthyNode("testnode")
+
+			fencingRequestInformer.GetStore().Add(newFencingRequest(node, virtv1.FencingRequestFenced))
+			addNode(node)
+			fencingRequestInterface.EXPECT().Delete(node.Name, gomock.Any())
+
+			controller.Execute()
+		})
+	})
+
 	AfterEach(func() {
 		close(stop)
 		// Ensure that we add checks for expected events to every test
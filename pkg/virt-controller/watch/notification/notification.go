@@ -0,0 +1,265 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package notification
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/cache"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// SigningKey is the entry of the signing secret which holds the HMAC key
+	SigningKey = "key"
+
+	// EventHeader names the event of a notification
+	EventHeader = "X-KubeVirt-Event"
+	// DeliveryHeader holds the ID of a notification, which stays the same
+	// when the delivery is retried
+	DeliveryHeader = "X-KubeVirt-Delivery"
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the body, prefixed
+	// with "sha256="
+	SignatureHeader = "X-KubeVirt-Signature"
+
+	// FailedDeliveryReason is the reason of the event recorded on a hook when
+	// a notification could not be delivered
+	FailedDeliveryReason = "FailedDelivery"
+
+	defaultMaxRetries = 5
+)
+
+// Notification is the body POSTed to the URL of a hook
+type Notification struct {
+	// ID of the notification
+	ID string `json:"id"`
+	// Event which happened to the VMI
+	Event kubevirtv1.VirtualMachineNotificationEvent `json:"event"`
+	// Time the event was observed
+	Time metav1.Time `json:"time"`
+	// Namespace, name, UID and labels of the VMI
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	UID       types.UID         `json:"uid"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// NodeName is the node the VMI runs or ran on
+	NodeName string `json:"nodeName,omitempty"`
+	// SourceNode is the node a migrated VMI was running on before
+	SourceNode string `json:"sourceNode,omitempty"`
+	// Reason is the reason of the VMI status, if any
+	Reason string `json:"reason,omitempty"`
+}
+
+// delivery is a notification which is sent to a hook
+type delivery struct {
+	hookKey      string
+	notification Notification
+}
+
+// lifecycleEvents returns the events which happened between two states of a
+// VMI
+func lifecycleEvents(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) []kubevirtv1.VirtualMachineNotificationEvent {
+	var events []kubevirtv1.VirtualMachineNotificationEvent
+
+	if oldVMI.Status.Phase != newVMI.Status.Phase {
+		switch newVMI.Status.Phase {
+		case kubevirtv1.Running:
+			events = append(events, kubevirtv1.NotificationEventStarted)
+		case kubevirtv1.Succeeded:
+			events = append(events, kubevirtv1.NotificationEventStopped)
+		case kubevirtv1.Failed:
+			events = append(events, kubevirtv1.NotificationEventCrashed)
+		}
+	}
+
+	if migrationCompleted(newVMI.Status.MigrationState) &&
+		(!migrationCompleted(oldVMI.Status.MigrationState) ||
+			oldVMI.Status.MigrationState.MigrationUID != newVMI.Status.MigrationState.MigrationUID) {
+		events = append(events, kubevirtv1.NotificationEventMigrated)
+	}
+
+	return events
+}
+
+func migrationCompleted(state *kubevirtv1.VirtualMachineInstanceMigrationState) bool {
+	return state != nil && state.Completed && !state.Failed
+}
+
+// enqueue queues a delivery of the event for every hook in the namespace of
+// the VMI which is interested in it
+func (ctrl *NotificationController) enqueue(vmi *kubevirtv1.VirtualMachineInstance, event kubevirtv1.VirtualMachineNotificationEvent) {
+	objs, err := ctrl.NotificationHookInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to list the notification hooks")
+		return
+	}
+	if len(objs) == 0 {
+		return
+	}
+
+	notification := newNotification(vmi, event, ctrl.now())
+	for _, obj := range objs {
+		hook := obj.(*kubevirtv1.VirtualMachineNotificationHook)
+		if !subscribed(hook, vmi, event) {
+			continue
+		}
+
+		key, err := cache.MetaNamespaceKeyFunc(hook)
+		if err != nil {
+			continue
+		}
+		log.Log.Object(vmi).V(4).Infof("queueing %s event %s for %s", event, notification.ID, key)
+		ctrl.deliveryQueue.Add(&delivery{hookKey: key, notification: notification})
+	}
+}
+
+func newNotification(vmi *kubevirtv1.VirtualMachineInstance, event kubevirtv1.VirtualMachineNotificationEvent, now time.Time) Notification {
+	notification := Notification{
+		ID:        string(uuid.NewUUID()),
+		Event:     event,
+		Time:      metav1.NewTime(now),
+		Namespace: vmi.Namespace,
+		Name:      vmi.Name,
+		UID:       vmi.UID,
+		Labels:    vmi.Labels,
+		NodeName:  vmi.Status.NodeName,
+		Reason:    vmi.Status.Reason,
+	}
+	if event == kubevirtv1.NotificationEventMigrated {
+		notification.NodeName = vmi.Status.MigrationState.TargetNode
+		notification.SourceNode = vmi.Status.MigrationState.SourceNode
+	}
+	return notification
+}
+
+// subscribed reports whether the hook wants to receive the event of the VMI
+func subscribed(hook *kubevirtv1.VirtualMachineNotificationHook, vmi *kubevirtv1.VirtualMachineInstance, event kubevirtv1.VirtualMachineNotificationEvent) bool {
+	if hook.DeletionTimestamp != nil {
+		return false
+	}
+
+	if len(hook.Spec.Events) > 0 {
+		found := false
+		for _, e := range hook.Spec.Events {
+			if e == event {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if hook.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(hook.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(vmi.Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver POSTs the notification to the URL of its hook. Deliveries to hooks
+// which were deleted in the meantime are dropped.
+func (ctrl *NotificationController) deliver(d *delivery) error {
+	if !ctrl.ClusterConfig.NotificationHooksEnabled() {
+		return nil
+	}
+
+	hook, err := ctrl.getHook(d.hookKey)
+	if err != nil || hook == nil {
+		return err
+	}
+
+	body, err := json.Marshal(d.notification)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, hook.Spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, string(d.notification.Event))
+	request.Header.Set(DeliveryHeader, d.notification.ID)
+
+	if ref := hook.Spec.SigningSecretRef; ref != nil {
+		key, err := ctrl.signingKey(hook.Namespace, ref.Name)
+		if err != nil {
+			return err
+		}
+		request.Header.Set(SignatureHeader, "sha256="+sign(key, body))
+	}
+
+	response, err := ctrl.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("%s responded with %s", hook.Spec.URL, response.Status)
+	}
+	return nil
+}
+
+func (ctrl *NotificationController) signingKey(namespace string, name string) ([]byte, error) {
+	secret, err := ctrl.Client.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the signing secret %s: %v", name, err)
+	}
+
+	key, ok := secret.Data[SigningKey]
+	if !ok || len(key) == 0 {
+		return nil, fmt.Errorf("the signing secret %s has no %q entry", name, SigningKey)
+	}
+	return key, nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the body
+func sign(key []byte, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func maxRetries(hook *kubevirtv1.VirtualMachineNotificationHook) int {
+	if hook.Spec.MaxRetries != nil {
+		return int(*hook.Spec.MaxRetries)
+	}
+	return defaultMaxRetries
+}
@@ -0,0 +1,175 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/net/egress"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	deliveryTimeout = 10 * time.Second
+	minRetryDelay   = time.Second
+	maxRetryDelay   = 5 * time.Minute
+)
+
+// NotificationController sends the lifecycle events of VMIs to the
+// endpoints registered with VirtualMachineNotificationHooks
+type NotificationController struct {
+	Client kubecli.KubevirtClient
+
+	VMIInformer              cache.SharedIndexInformer
+	NotificationHookInformer cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+	Recorder      record.EventRecorder
+
+	deliveryQueue workqueue.RateLimitingInterface
+	httpClient    *http.Client
+	now           func() time.Time
+
+	// leading is set once Run is called by the leader, events observed
+	// before are dropped instead of piling up in the queue
+	leading int32
+}
+
+// Init initializes the notification controller
+func (ctrl *NotificationController) Init() {
+	ctrl.deliveryQueue = workqueue.NewNamedRateLimitingQueue(
+		workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "notification-controller-delivery")
+	ctrl.httpClient = egress.NewHTTPClient(deliveryTimeout)
+	ctrl.now = time.Now
+
+	ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: ctrl.handleVMIUpdate,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *NotificationController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.deliveryQueue.ShutDown()
+
+	log.Log.Info("Starting notification controller.")
+	defer log.Log.Info("Shutting down notification controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.NotificationHookInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+	atomic.StoreInt32(&ctrl.leading, 1)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.deliveryWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *NotificationController) deliveryWorker() {
+	for ctrl.processDeliveryWorkItem() {
+	}
+}
+
+func (ctrl *NotificationController) processDeliveryWorkItem() bool {
+	item, quit := ctrl.deliveryQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.deliveryQueue.Done(item)
+
+	d := item.(*delivery)
+	if err := ctrl.deliver(d); err != nil {
+		ctrl.handleDeliveryFailure(d, err)
+	} else {
+		log.Log.V(4).Infof("delivered %s event %s of VMI %s/%s to %s", d.notification.Event, d.notification.ID,
+			d.notification.Namespace, d.notification.Name, d.hookKey)
+		ctrl.deliveryQueue.Forget(d)
+	}
+	return true
+}
+
+// handleDeliveryFailure retries the delivery with an exponential backoff
+// until the retries of the hook are exhausted
+func (ctrl *NotificationController) handleDeliveryFailure(d *delivery, err error) {
+	hook, err2 := ctrl.getHook(d.hookKey)
+	if err2 != nil || hook == nil {
+		ctrl.deliveryQueue.Forget(d)
+		return
+	}
+
+	if retries := ctrl.deliveryQueue.NumRequeues(d); retries < maxRetries(hook) {
+		log.Log.Object(hook).Reason(err).Infof("retrying the delivery of %s event %s", d.notification.Event, d.notification.ID)
+		ctrl.deliveryQueue.AddRateLimited(d)
+		return
+	}
+
+	log.Log.Object(hook).Reason(err).Errorf("giving up the delivery of %s event %s", d.notification.Event, d.notification.ID)
+	ctrl.Recorder.Eventf(hook, "Warning", FailedDeliveryReason, "Failed to deliver %s event of VMI %s: %v",
+		d.notification.Event, d.notification.Name, err)
+	ctrl.deliveryQueue.Forget(d)
+}
+
+func (ctrl *NotificationController) getHook(key string) (*kubevirtv1.VirtualMachineNotificationHook, error) {
+	obj, exists, err := ctrl.NotificationHookInformer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return obj.(*kubevirtv1.VirtualMachineNotificationHook), nil
+}
+
+func (ctrl *NotificationController) handleVMIUpdate(oldObj, newObj interface{}) {
+	oldVMI, ok := oldObj.(*kubevirtv1.VirtualMachineInstance)
+	if !ok {
+		return
+	}
+	newVMI, ok := newObj.(*kubevirtv1.VirtualMachineInstance)
+	if !ok {
+		return
+	}
+
+	if atomic.LoadInt32(&ctrl.leading) == 0 || !ctrl.ClusterConfig.NotificationHooksEnabled() {
+		return
+	}
+
+	for _, event := range lifecycleEvents(oldVMI, newVMI) {
+		ctrl.enqueue(newVMI, event)
+	}
+}
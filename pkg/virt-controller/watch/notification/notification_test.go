@@ -0,0 +1,346 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package notification
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/net/egress"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Notification controller", func() {
+	var ctrl *NotificationController
+	var recorder *record.FakeRecorder
+	var server *httptest.Server
+	var requests chan *http.Request
+	var bodies chan []byte
+	var status int
+	var location string
+
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	newVMI := func(phase kubevirtv1.VirtualMachineInstancePhase) *kubevirtv1.VirtualMachineInstance {
+		return &kubevirtv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testvmi",
+				Namespace: "default",
+				UID:       "1234",
+				Labels:    map[string]string{"app": "db"},
+			},
+			Status: kubevirtv1.VirtualMachineInstanceStatus{
+				Phase:    phase,
+				NodeName: "node01",
+			},
+		}
+	}
+
+	newHook := func(name string) *kubevirtv1.VirtualMachineNotificationHook {
+		return &kubevirtv1.VirtualMachineNotificationHook{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: kubevirtv1.VirtualMachineNotificationHookSpec{
+				URL: server.URL,
+			},
+		}
+	}
+
+	addHook := func(hook *kubevirtv1.VirtualMachineNotificationHook) {
+		Expect(ctrl.NotificationHookInformer.GetStore().Add(hook)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		requests = make(chan *http.Request, 10)
+		bodies = make(chan []byte, 10)
+		status = http.StatusOK
+		location = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			requests <- r
+			bodies <- body
+			if location != "" {
+				w.Header().Set("Location", location)
+			}
+			w.WriteHeader(status)
+		}))
+
+		mockCtrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(mockCtrl)
+		k8sClient := k8sfake.NewSimpleClientset(&k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "signing", Namespace: "default"},
+			Data:       map[string][]byte{SigningKey: []byte("s3cr3t")},
+		})
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+		vmiInformer, _ := testutils.NewFakeInformerFor(&kubevirtv1.VirtualMachineInstance{})
+		hookInformer, _ := testutils.NewFakeInformerFor(&kubevirtv1.VirtualMachineNotificationHook{})
+		config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+			Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.NotificationHooksGate},
+		})
+		recorder = record.NewFakeRecorder(10)
+
+		ctrl = &NotificationController{
+			Client:                   virtClient,
+			VMIInformer:              vmiInformer,
+			NotificationHookInformer: hookInformer,
+			ClusterConfig:            config,
+			Recorder:                 recorder,
+		}
+		ctrl.Init()
+		// the test server listens on loopback, which the default client refuses
+		ctrl.httpClient = server.Client()
+		ctrl.leading = 1
+		ctrl.deliveryQueue = workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Millisecond))
+		ctrl.now = func() time.Time { return now }
+	})
+
+	AfterEach(func() {
+		ctrl.deliveryQueue.ShutDown()
+		server.Close()
+	})
+
+	table.DescribeTable("should detect", func(modify func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance), expected []kubevirtv1.VirtualMachineNotificationEvent) {
+		oldVMI := newVMI(kubevirtv1.Scheduled)
+		newVMI := oldVMI.DeepCopy()
+		modify(oldVMI, newVMI)
+		Expect(lifecycleEvents(oldVMI, newVMI)).To(Equal(expected))
+	},
+		table.Entry("a started VMI", func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) {
+			newVMI.Status.Phase = kubevirtv1.Running
+		}, []kubevirtv1.VirtualMachineNotificationEvent{kubevirtv1.NotificationEventStarted}),
+		table.Entry("a stopped VMI", func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) {
+			oldVMI.Status.Phase = kubevirtv1.Running
+			newVMI.Status.Phase = kubevirtv1.Succeeded
+		}, []kubevirtv1.VirtualMachineNotificationEvent{kubevirtv1.NotificationEventStopped}),
+		table.Entry("a crashed VMI", func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) {
+			oldVMI.Status.Phase = kubevirtv1.Running
+			newVMI.Status.Phase = kubevirtv1.Failed
+		}, []kubevirtv1.VirtualMachineNotificationEvent{kubevirtv1.NotificationEventCrashed}),
+		table.Entry("a migrated VMI", func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) {
+			oldVMI.Status.Phase = kubevirtv1.Running
+			oldVMI.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{MigrationUID: "m1"}
+			newVMI.Status.Phase = kubevirtv1.Running
+			newVMI.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{MigrationUID: "m1", Completed: true}
+		}, []kubevirtv1.VirtualMachineNotificationEvent{kubevirtv1.NotificationEventMigrated}),
+		table.Entry("a VMI migrated again", func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) {
+			oldVMI.Status.Phase = kubevirtv1.Running
+			oldVMI.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{MigrationUID: "m1", Completed: true}
+			newVMI.Status.Phase = kubevirtv1.Running
+			newVMI.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{MigrationUID: "m2", Completed: true}
+		}, []kubevirtv1.VirtualMachineNotificationEvent{kubevirtv1.NotificationEventMigrated}),
+		table.Entry("nothing for a failed migration", func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) {
+			oldVMI.Status.Phase = kubevirtv1.Running
+			newVMI.Status.Phase = kubevirtv1.Running
+			newVMI.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{MigrationUID: "m1", Completed: true, Failed: true}
+		}, nil),
+		table.Entry("nothing for an unchanged VMI", func(oldVMI, newVMI *kubevirtv1.VirtualMachineInstance) {
+			oldVMI.Status.Phase = kubevirtv1.Running
+			oldVMI.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{MigrationUID: "m1", Completed: true}
+			newVMI.Status.Phase = kubevirtv1.Running
+			newVMI.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{MigrationUID: "m1", Completed: true}
+		}, nil),
+	)
+
+	It("should only queue the events hooks subscribed to", func() {
+		all := newHook("all")
+		started := newHook("started")
+		started.Spec.Events = []kubevirtv1.VirtualMachineNotificationEvent{kubevirtv1.NotificationEventStarted}
+		stopped := newHook("stopped")
+		stopped.Spec.Events = []kubevirtv1.VirtualMachineNotificationEvent{kubevirtv1.NotificationEventStopped}
+		web := newHook("web")
+		web.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+		other := newHook("other")
+		other.Namespace = "other"
+		for _, hook := range []*kubevirtv1.VirtualMachineNotificationHook{all, started, stopped, web, other} {
+			addHook(hook)
+		}
+
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Scheduled), newVMI(kubevirtv1.Running))
+
+		Expect(ctrl.deliveryQueue.Len()).To(Equal(2))
+		var keys []string
+		for i := 0; i < 2; i++ {
+			item, _ := ctrl.deliveryQueue.Get()
+			d := item.(*delivery)
+			Expect(d.notification.Event).To(Equal(kubevirtv1.NotificationEventStarted))
+			keys = append(keys, d.hookKey)
+		}
+		Expect(keys).To(ConsistOf("default/all", "default/started"))
+	})
+
+	It("should not queue events before leading", func() {
+		ctrl.leading = 0
+		addHook(newHook("all"))
+
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Scheduled), newVMI(kubevirtv1.Running))
+
+		Expect(ctrl.deliveryQueue.Len()).To(BeZero())
+	})
+
+	It("should not deliver to internal addresses by default", func() {
+		hook := newHook("cmdb")
+		hook.Spec.MaxRetries = &[]int32{0}[0]
+		addHook(hook)
+		ctrl.httpClient = egress.NewHTTPClient(deliveryTimeout)
+
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Scheduled), newVMI(kubevirtv1.Running))
+		Expect(ctrl.processDeliveryWorkItem()).To(BeTrue())
+
+		Expect(requests).ToNot(Receive())
+		Expect(recorder.Events).To(Receive(ContainSubstring("not a public address")))
+	})
+
+	It("should not follow redirects", func() {
+		status = http.StatusTemporaryRedirect
+		location = server.URL + "/internal"
+		hook := newHook("cmdb")
+		hook.Spec.MaxRetries = &[]int32{0}[0]
+		addHook(hook)
+		ctrl.httpClient.CheckRedirect = egress.CheckRedirect
+
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Scheduled), newVMI(kubevirtv1.Running))
+		Expect(ctrl.processDeliveryWorkItem()).To(BeTrue())
+
+		var request *http.Request
+		Eventually(requests).Should(Receive(&request))
+		Expect(request.URL.Path).ToNot(Equal("/internal"))
+		Expect(requests).ToNot(Receive())
+		Expect(recorder.Events).To(Receive(ContainSubstring(FailedDeliveryReason)))
+	})
+
+	It("should not queue events without the feature gate", func() {
+		ctrl.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+		addHook(newHook("all"))
+
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Scheduled), newVMI(kubevirtv1.Running))
+
+		Expect(ctrl.deliveryQueue.Len()).To(BeZero())
+	})
+
+	It("should POST a signed notification", func() {
+		hook := newHook("cmdb")
+		hook.Spec.SigningSecretRef = &k8sv1.LocalObjectReference{Name: "signing"}
+		addHook(hook)
+
+		oldVMI := newVMI(kubevirtv1.Running)
+		vmi := newVMI(kubevirtv1.Running)
+		vmi.Status.NodeName = "node02"
+		vmi.Status.MigrationState = &kubevirtv1.VirtualMachineInstanceMigrationState{
+			MigrationUID: "m1",
+			Completed:    true,
+			SourceNode:   "node01",
+			TargetNode:   "node02",
+		}
+		ctrl.handleVMIUpdate(oldVMI, vmi)
+		Expect(ctrl.processDeliveryWorkItem()).To(BeTrue())
+
+		var request *http.Request
+		Eventually(requests).Should(Receive(&request))
+		var body []byte
+		Eventually(bodies).Should(Receive(&body))
+
+		Expect(request.Method).To(Equal(http.MethodPost))
+		Expect(request.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(request.Header.Get(EventHeader)).To(Equal("Migrated"))
+		Expect(request.Header.Get(SignatureHeader)).To(Equal("sha256=" + sign([]byte("s3cr3t"), body)))
+
+		notification := Notification{}
+		Expect(json.Unmarshal(body, &notification)).To(Succeed())
+		Expect(request.Header.Get(DeliveryHeader)).To(Equal(notification.ID))
+		Expect(notification.Event).To(Equal(kubevirtv1.NotificationEventMigrated))
+		Expect(notification.Time.UTC()).To(Equal(now))
+		Expect(notification.Namespace).To(Equal("default"))
+		Expect(notification.Name).To(Equal("testvmi"))
+		Expect(string(notification.UID)).To(Equal("1234"))
+		Expect(notification.Labels).To(Equal(map[string]string{"app": "db"}))
+		Expect(notification.NodeName).To(Equal("node02"))
+		Expect(notification.SourceNode).To(Equal("node01"))
+		Expect(ctrl.deliveryQueue.Len()).To(BeZero())
+	})
+
+	It("should compute the HMAC-SHA256 of the body", func() {
+		// RFC 4231 test case 2
+		Expect(sign([]byte("Jefe"), []byte("what do ya want for nothing?"))).To(
+			Equal("5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"))
+	})
+
+	It("should retry failed deliveries and give up after the retries of the hook", func() {
+		status = http.StatusServiceUnavailable
+		hook := newHook("cmdb")
+		hook.Spec.MaxRetries = &[]int32{2}[0]
+		addHook(hook)
+
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Running), newVMI(kubevirtv1.Succeeded))
+
+		var ids []string
+		for i := 0; i < 3; i++ {
+			Expect(ctrl.processDeliveryWorkItem()).To(BeTrue())
+			var request *http.Request
+			Eventually(requests).Should(Receive(&request))
+			ids = append(ids, request.Header.Get(DeliveryHeader))
+		}
+		Expect(ids[1]).To(Equal(ids[0]))
+		Expect(ids[2]).To(Equal(ids[0]))
+
+		Expect(ctrl.deliveryQueue.Len()).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring(FailedDeliveryReason)))
+	})
+
+	It("should fail the delivery if the signing secret is missing", func() {
+		hook := newHook("cmdb")
+		hook.Spec.SigningSecretRef = &k8sv1.LocalObjectReference{Name: "missing"}
+		hook.Spec.MaxRetries = &[]int32{0}[0]
+		addHook(hook)
+
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Running), newVMI(kubevirtv1.Failed))
+		Expect(ctrl.processDeliveryWorkItem()).To(BeTrue())
+
+		Expect(requests).ToNot(Receive())
+		Expect(recorder.Events).To(Receive(ContainSubstring("signing secret missing")))
+	})
+
+	It("should drop deliveries to deleted hooks", func() {
+		hook := newHook("cmdb")
+		addHook(hook)
+		ctrl.handleVMIUpdate(newVMI(kubevirtv1.Scheduled), newVMI(kubevirtv1.Running))
+		Expect(ctrl.NotificationHookInformer.GetStore().Delete(hook)).To(Succeed())
+
+		Expect(ctrl.processDeliveryWorkItem()).To(BeTrue())
+
+		Expect(requests).ToNot(Receive())
+		Expect(ctrl.deliveryQueue.Len()).To(BeZero())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+})
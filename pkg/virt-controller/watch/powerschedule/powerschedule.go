@@ -0,0 +1,258 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package powerschedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/cron"
+)
+
+const (
+	scheduledStartEvent = "ScheduledStart"
+
+	scheduledStopEvent = "ScheduledStop"
+
+	scheduledStopSkippedEvent = "ScheduledStopSkipped"
+
+	skippedStopMessage = "Skipped scheduled stop: "
+)
+
+// updatePowerSchedule starts or stops the VM if a run of its schedule is due
+// and returns the time until the next run, 0 if none is scheduled
+func (ctrl *PowerScheduleController) updatePowerSchedule(vm *kubevirtv1.VirtualMachine) (time.Duration, error) {
+	schedule := vm.Spec.PowerSchedule
+	if schedule == nil {
+		return 0, ctrl.updateStatus(vm, nil)
+	}
+
+	log.Log.Object(vm).V(3).Infof("Updating the power schedule")
+
+	status := vm.Status.PowerScheduleStatus.DeepCopy()
+	if status == nil {
+		status = &kubevirtv1.VirtualMachinePowerScheduleStatus{}
+	}
+	// errors of a previous spec are cleared, a skipped stop is reported until the next run
+	if !strings.HasPrefix(status.Message, skippedStopMessage) {
+		status.Message = ""
+	}
+
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return 0, ctrl.updateStatusInvalid(vm, status, fmt.Sprintf("invalid time zone: %v", err))
+	}
+	start, err := parseSchedule(schedule.Start)
+	if err != nil {
+		return 0, ctrl.updateStatusInvalid(vm, status, fmt.Sprintf("invalid start schedule: %v", err))
+	}
+	stop, err := parseSchedule(schedule.Stop)
+	if err != nil {
+		return 0, ctrl.updateStatusInvalid(vm, status, fmt.Sprintf("invalid stop schedule: %v", err))
+	}
+
+	now := ctrl.now()
+	startDue := dueRun(start, status.LastStartTime, vm, now, loc)
+	stopDue := dueRun(stop, status.LastStopTime, vm, now, loc)
+
+	// missed runs are collapsed into a single one, the later of a missed
+	// start and stop wins
+	switch {
+	case !startDue.IsZero() && (stopDue.IsZero() || startDue.After(stopDue)):
+		if err := ctrl.start(vm); err != nil {
+			return 0, err
+		}
+		status.Message = ""
+	case !stopDue.IsZero():
+		reason, err := ctrl.stopBlocked(vm)
+		if err != nil {
+			return 0, err
+		}
+		if reason != "" {
+			ctrl.Recorder.Event(vm, corev1.EventTypeWarning, scheduledStopSkippedEvent, skippedStopMessage+reason)
+			status.Message = skippedStopMessage + reason
+		} else {
+			if err := ctrl.stop(vm); err != nil {
+				return 0, err
+			}
+			status.Message = ""
+		}
+	}
+	if !startDue.IsZero() {
+		status.LastStartTime = &metav1.Time{Time: now}
+	}
+	if !stopDue.IsZero() {
+		status.LastStopTime = &metav1.Time{Time: now}
+	}
+
+	status.NextStartTime = nextRun(start, now, loc)
+	status.NextStopTime = nextRun(stop, now, loc)
+	if status.NextStartTime == nil && status.NextStopTime == nil {
+		status.Message = "schedule never runs"
+	}
+	if err := ctrl.updateStatus(vm, status); err != nil {
+		return 0, err
+	}
+
+	var next time.Duration
+	for _, t := range []*metav1.Time{status.NextStartTime, status.NextStopTime} {
+		if t != nil && (next == 0 || t.Sub(now) < next) {
+			next = t.Sub(now)
+		}
+	}
+	return next, nil
+}
+
+// start starts the VM unless it is running
+func (ctrl *PowerScheduleController) start(vm *kubevirtv1.VirtualMachine) error {
+	if running, err := isRunning(vm); err != nil || running {
+		return err
+	}
+
+	if err := ctrl.Client.VirtualMachine(vm.Namespace).Start(vm.Name); err != nil {
+		return fmt.Errorf("failed to start VirtualMachine %s: %v", vm.Name, err)
+	}
+	ctrl.Recorder.Event(vm, corev1.EventTypeNormal, scheduledStartEvent, "Started the VirtualMachine as scheduled")
+	return nil
+}
+
+// stop stops the VM unless it is stopped
+func (ctrl *PowerScheduleController) stop(vm *kubevirtv1.VirtualMachine) error {
+	if running, err := isRunning(vm); err != nil || !running {
+		return err
+	}
+
+	if err := ctrl.Client.VirtualMachine(vm.Namespace).Stop(vm.Name); err != nil {
+		return fmt.Errorf("failed to stop VirtualMachine %s: %v", vm.Name, err)
+	}
+	ctrl.Recorder.Event(vm, corev1.EventTypeNormal, scheduledStopEvent, "Stopped the VirtualMachine as scheduled")
+	return nil
+}
+
+// stopBlocked returns why the VM is in use and not stopped, an empty reason
+// if it can be stopped
+func (ctrl *PowerScheduleController) stopBlocked(vm *kubevirtv1.VirtualMachine) (string, error) {
+	if !vm.Spec.PowerSchedule.SkipIfInUse {
+		return "", nil
+	}
+
+	obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name))
+	if err != nil || !exists {
+		return "", err
+	}
+	vmi := obj.(*kubevirtv1.VirtualMachineInstance)
+	if vmi.IsFinal() {
+		return "", nil
+	}
+
+	if vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed {
+		return "the VirtualMachineInstance is migrating", nil
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if vmi.Status.Phase != kubevirtv1.Running || !condManager.HasCondition(vmi, kubevirtv1.VirtualMachineInstanceAgentConnected) {
+		return "", nil
+	}
+	users, err := ctrl.Client.VirtualMachineInstance(vmi.Namespace).UserList(vmi.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the users of the guest: %v", err)
+	}
+	if len(users.Items) > 0 {
+		return fmt.Sprintf("%d users are logged in to the guest", len(users.Items)), nil
+	}
+	return "", nil
+}
+
+func (ctrl *PowerScheduleController) updateStatusInvalid(vm *kubevirtv1.VirtualMachine, status *kubevirtv1.VirtualMachinePowerScheduleStatus, message string) error {
+	status.NextStartTime = nil
+	status.NextStopTime = nil
+	status.Message = message
+	return ctrl.updateStatus(vm, status)
+}
+
+func (ctrl *PowerScheduleController) updateStatus(vm *kubevirtv1.VirtualMachine, status *kubevirtv1.VirtualMachinePowerScheduleStatus) error {
+	// the times read back from the API server are in the local time zone
+	if equality.Semantic.DeepEqual(vm.Status.PowerScheduleStatus, status) {
+		return nil
+	}
+	updated := vm.DeepCopy()
+	updated.Status.PowerScheduleStatus = status
+	_, err := ctrl.Client.VirtualMachine(vm.Namespace).UpdateStatus(updated)
+	return err
+}
+
+// isRunning returns whether the run strategy of the VM asks for a VMI, a VM
+// with the manual run strategy runs while it has one
+func isRunning(vm *kubevirtv1.VirtualMachine) (bool, error) {
+	rs, err := vm.RunStrategy()
+	if err != nil {
+		return false, err
+	}
+	switch rs {
+	case kubevirtv1.RunStrategyHalted:
+		return false, nil
+	case kubevirtv1.RunStrategyManual:
+		return vm.Status.Created, nil
+	}
+	return true, nil
+}
+
+func parseSchedule(spec string) (*cron.Schedule, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	return cron.Parse(spec)
+}
+
+// dueRun returns the scheduled time of the run due now, the zero time if no
+// run is due. The schedule of a new VM starts at its creation.
+func dueRun(schedule *cron.Schedule, last *metav1.Time, vm *kubevirtv1.VirtualMachine, now time.Time, loc *time.Location) time.Time {
+	if schedule == nil {
+		return time.Time{}
+	}
+	from := vm.CreationTimestamp.Time
+	if last != nil {
+		from = last.Time
+	}
+	due := schedule.NextIn(from, loc)
+	if due.IsZero() || now.Before(due) {
+		return time.Time{}
+	}
+	return due
+}
+
+func nextRun(schedule *cron.Schedule, now time.Time, loc *time.Location) *metav1.Time {
+	if schedule == nil {
+		return nil
+	}
+	next := schedule.NextIn(now, loc)
+	if next.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: next.UTC()}
+}
@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package powerschedule
+
+import (
+	"fmt"
+	"time"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+// PowerScheduleController starts and stops VMs at the times of their power
+// schedules
+type PowerScheduleController struct {
+	Client kubecli.KubevirtClient
+
+	VMInformer  cache.SharedIndexInformer
+	VMIInformer cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmQueue workqueue.RateLimitingInterface
+	now     func() time.Time
+}
+
+// Init initializes the power schedule controller
+func (ctrl *PowerScheduleController) Init() {
+	ctrl.vmQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "power-schedule-controller-vm")
+	ctrl.now = time.Now
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVM,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVM(newObj) },
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *PowerScheduleController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmQueue.ShutDown()
+
+	log.Log.Info("Starting power schedule controller.")
+	defer log.Log.Info("Shutting down power schedule controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *PowerScheduleController) vmWorker() {
+	for ctrl.processVMWorkItem() {
+	}
+}
+
+func (ctrl *PowerScheduleController) processVMWorkItem() bool {
+	key, quit := ctrl.vmQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.vmQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachine %v", key)
+		ctrl.vmQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachine %v", key)
+		ctrl.vmQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *PowerScheduleController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vm, ok := storeObj.(*kubevirtv1.VirtualMachine)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	next, err := ctrl.updatePowerSchedule(vm.DeepCopy())
+	if err != nil {
+		return err
+	}
+	// the next run is not triggered by an event of the VM
+	if next > 0 {
+		ctrl.vmQueue.AddAfter(key, next)
+	}
+	return nil
+}
+
+// handleVM enqueues the VMs with a power schedule, and those which still
+// report one to clear their status
+func (ctrl *PowerScheduleController) handleVM(obj interface{}) {
+	vm, ok := obj.(*kubevirtv1.VirtualMachine)
+	if !ok || (vm.Spec.PowerSchedule == nil && vm.Status.PowerScheduleStatus == nil) {
+		return
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(vm)
+	if err != nil {
+		log.Log.Errorf("failed to get key from object: %v, %v", err, vm)
+		return
+	}
+
+	log.Log.V(3).Infof("enqueued %q for sync", key)
+	ctrl.vmQueue.Add(key)
+}
@@ -0,0 +1,291 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package powerschedule
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+const testNamespace = "default"
+
+var _ = Describe("Power Schedule", func() {
+
+	var ctrl *gomock.Controller
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var recorder *record.FakeRecorder
+	var controller *PowerScheduleController
+	var now time.Time
+	var updated *v1.VirtualMachine
+
+	newVM := func(running bool, schedule *v1.VirtualMachinePowerSchedule) *v1.VirtualMachine {
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "dev01",
+				Namespace:         testNamespace,
+				CreationTimestamp: metav1.NewTime(time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			Spec: v1.VirtualMachineSpec{
+				Running:       &running,
+				PowerSchedule: schedule,
+			},
+		}
+	}
+
+	officeHours := func() *v1.VirtualMachinePowerSchedule {
+		return &v1.VirtualMachinePowerSchedule{
+			Start: "0 8 * * 1-5",
+			Stop:  "0 18 * * 1-5",
+		}
+	}
+
+	runningVMI := func(agentConnected bool) *v1.VirtualMachineInstance {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dev01",
+				Namespace: testNamespace,
+			},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: v1.Running,
+			},
+		}
+		if agentConnected {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:   v1.VirtualMachineInstanceAgentConnected,
+				Status: "True",
+			}}
+		}
+		return vmi
+	}
+
+	process := func(vm *v1.VirtualMachine) (time.Duration, error) {
+		Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+		return controller.updatePowerSchedule(vm)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &PowerScheduleController{
+			Client:      virtClient,
+			VMInformer:  vmInformer,
+			VMIInformer: vmiInformer,
+			Recorder:    recorder,
+		}
+		controller.Init()
+
+		// a Tuesday
+		now = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+		controller.now = func() time.Time { return now }
+
+		virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
+
+		updated = nil
+		vmInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+			updated = vm
+			return vm, nil
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should start a stopped VM when the start is due", func() {
+		vm := newVM(false, officeHours())
+		vmInterface.EXPECT().Start("dev01").Return(nil)
+
+		next, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+		testutils.ExpectEvent(recorder, scheduledStartEvent)
+
+		status := updated.Status.PowerScheduleStatus
+		Expect(status.LastStartTime.Time).To(Equal(now))
+		Expect(status.LastStopTime).To(BeNil())
+		Expect(status.NextStartTime.Time).To(Equal(time.Date(2021, time.June, 2, 8, 0, 0, 0, time.UTC)))
+		Expect(status.NextStopTime.Time).To(Equal(time.Date(2021, time.June, 1, 18, 0, 0, 0, time.UTC)))
+		Expect(next).To(Equal(6 * time.Hour))
+	})
+
+	It("should not start a running VM again", func() {
+		vm := newVM(true, officeHours())
+
+		_, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(BeEmpty())
+		Expect(updated.Status.PowerScheduleStatus.LastStartTime.Time).To(Equal(now))
+	})
+
+	It("should stop a running VM when the stop is due", func() {
+		vm := newVM(true, officeHours())
+		lastStart := metav1.NewTime(time.Date(2021, time.June, 1, 8, 0, 0, 0, time.UTC))
+		vm.Status.PowerScheduleStatus = &v1.VirtualMachinePowerScheduleStatus{LastStartTime: &lastStart}
+		now = time.Date(2021, time.June, 1, 18, 0, 30, 0, time.UTC)
+		vmInterface.EXPECT().Stop("dev01").Return(nil)
+
+		next, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+		testutils.ExpectEvent(recorder, scheduledStopEvent)
+		Expect(updated.Status.PowerScheduleStatus.LastStopTime.Time).To(Equal(now))
+		Expect(next).To(Equal(14*time.Hour - 30*time.Second))
+	})
+
+	It("should only run the later of a missed start and stop", func() {
+		vm := newVM(true, officeHours())
+		now = time.Date(2021, time.June, 1, 19, 0, 0, 0, time.UTC)
+		vmInterface.EXPECT().Stop("dev01").Return(nil)
+
+		_, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+		testutils.ExpectEvent(recorder, scheduledStopEvent)
+
+		status := updated.Status.PowerScheduleStatus
+		Expect(status.LastStartTime.Time).To(Equal(now))
+		Expect(status.LastStopTime.Time).To(Equal(now))
+	})
+
+	It("should evaluate the schedule in its time zone", func() {
+		schedule := officeHours()
+		schedule.TimeZone = "America/New_York"
+		vm := newVM(false, schedule)
+		now = time.Date(2021, time.June, 1, 11, 0, 0, 0, time.UTC)
+
+		next, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+
+		status := updated.Status.PowerScheduleStatus
+		Expect(status.LastStartTime).To(BeNil())
+		Expect(status.NextStartTime.Time).To(Equal(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)))
+		Expect(status.NextStopTime.Time).To(Equal(time.Date(2021, time.June, 1, 22, 0, 0, 0, time.UTC)))
+		Expect(next).To(Equal(time.Hour))
+	})
+
+	It("should report an invalid time zone", func() {
+		schedule := officeHours()
+		schedule.TimeZone = "Mars/Olympus_Mons"
+		vm := newVM(false, schedule)
+
+		next, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next).To(BeZero())
+
+		status := updated.Status.PowerScheduleStatus
+		Expect(status.Message).To(ContainSubstring("invalid time zone"))
+		Expect(status.NextStartTime).To(BeNil())
+	})
+
+	It("should not update an unchanged status", func() {
+		vm := newVM(true, officeHours())
+		lastStart := metav1.NewTime(now)
+		nextStart := metav1.NewTime(time.Date(2021, time.June, 2, 8, 0, 0, 0, time.UTC).Local())
+		nextStop := metav1.NewTime(time.Date(2021, time.June, 1, 18, 0, 0, 0, time.UTC).Local())
+		vm.Status.PowerScheduleStatus = &v1.VirtualMachinePowerScheduleStatus{
+			LastStartTime: &lastStart,
+			NextStartTime: &nextStart,
+			NextStopTime:  &nextStop,
+		}
+
+		_, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated).To(BeNil())
+	})
+
+	It("should clear the status of a removed schedule", func() {
+		vm := newVM(true, nil)
+		vm.Status.PowerScheduleStatus = &v1.VirtualMachinePowerScheduleStatus{}
+
+		next, err := process(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next).To(BeZero())
+		Expect(updated.Status.PowerScheduleStatus).To(BeNil())
+	})
+
+	Context("with skipIfInUse", func() {
+
+		var vm *v1.VirtualMachine
+
+		BeforeEach(func() {
+			schedule := officeHours()
+			schedule.SkipIfInUse = true
+			vm = newVM(true, schedule)
+			lastStart := metav1.NewTime(time.Date(2021, time.June, 1, 8, 0, 0, 0, time.UTC))
+			vm.Status.PowerScheduleStatus = &v1.VirtualMachinePowerScheduleStatus{LastStartTime: &lastStart}
+			now = time.Date(2021, time.June, 1, 18, 0, 0, 0, time.UTC)
+		})
+
+		It("should skip the stop while users are logged in", func() {
+			Expect(vmiInformer.GetStore().Add(runningVMI(true))).To(Succeed())
+			vmiInterface.EXPECT().UserList("dev01").Return(v1.VirtualMachineInstanceGuestOSUserList{
+				Items: []v1.VirtualMachineInstanceGuestOSUser{{UserName: "alice"}},
+			}, nil)
+
+			_, err := process(vm)
+			Expect(err).ToNot(HaveOccurred())
+			testutils.ExpectEvent(recorder, scheduledStopSkippedEvent)
+
+			status := updated.Status.PowerScheduleStatus
+			Expect(status.Message).To(Equal("Skipped scheduled stop: 1 users are logged in to the guest"))
+			Expect(status.LastStopTime.Time).To(Equal(now))
+		})
+
+		It("should skip the stop while the VMI is migrating", func() {
+			vmi := runningVMI(false)
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+			_, err := process(vm)
+			Expect(err).ToNot(HaveOccurred())
+			testutils.ExpectEvent(recorder, scheduledStopSkippedEvent)
+			Expect(updated.Status.PowerScheduleStatus.Message).To(ContainSubstring("migrating"))
+		})
+
+		It("should stop the VM if nobody is logged in", func() {
+			Expect(vmiInformer.GetStore().Add(runningVMI(true))).To(Succeed())
+			vmiInterface.EXPECT().UserList("dev01").Return(v1.VirtualMachineInstanceGuestOSUserList{}, nil)
+			vmInterface.EXPECT().Stop("dev01").Return(nil)
+
+			_, err := process(vm)
+			Expect(err).ToNot(HaveOccurred())
+			testutils.ExpectEvent(recorder, scheduledStopEvent)
+			Expect(updated.Status.PowerScheduleStatus.Message).To(BeEmpty())
+		})
+	})
+})
@@ -26,8 +26,10 @@ import (
 	"time"
 
 	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -38,6 +40,8 @@ import (
 	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
 )
 
@@ -65,17 +69,25 @@ const (
 	SuccessfulResumedReplicaSetReason = "SuccessfulResumed"
 )
 
-func NewVMIReplicaSet(vmiInformer cache.SharedIndexInformer, vmiRSInformer cache.SharedIndexInformer, recorder record.EventRecorder, clientset kubecli.KubevirtClient, burstReplicas uint) *VMIReplicaSet {
+func NewVMIReplicaSet(vmiInformer cache.SharedIndexInformer, vmiRSInformer cache.SharedIndexInformer, dataVolumeInformer cache.SharedIndexInformer, pvcInformer cache.SharedIndexInformer, storageClassInformer cache.SharedIndexInformer, recorder record.EventRecorder, clientset kubecli.KubevirtClient, burstReplicas uint) *VMIReplicaSet {
+
+	proxy := &sarProxy{client: clientset}
 
 	c := &VMIReplicaSet{
-		Queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
-		vmiInformer:   vmiInformer,
-		vmiRSInformer: vmiRSInformer,
-		recorder:      recorder,
-		clientset:     clientset,
-		expectations:  controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		burstReplicas: burstReplicas,
-		statusUpdater: status.NewVMIRSStatusUpdater(clientset),
+		Queue:                workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		vmiInformer:          vmiInformer,
+		vmiRSInformer:        vmiRSInformer,
+		dataVolumeInformer:   dataVolumeInformer,
+		pvcInformer:          pvcInformer,
+		storageClassInformer: storageClassInformer,
+		recorder:             recorder,
+		clientset:            clientset,
+		expectations:         controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		burstReplicas:        burstReplicas,
+		statusUpdater:        status.NewVMIRSStatusUpdater(clientset),
+		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
+			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
+		},
 	}
 
 	c.vmiRSInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -94,14 +106,18 @@ func NewVMIReplicaSet(vmiInformer cache.SharedIndexInformer, vmiRSInformer cache
 }
 
 type VMIReplicaSet struct {
-	clientset     kubecli.KubevirtClient
-	Queue         workqueue.RateLimitingInterface
-	vmiInformer   cache.SharedIndexInformer
-	vmiRSInformer cache.SharedIndexInformer
-	recorder      record.EventRecorder
-	expectations  *controller.UIDTrackingControllerExpectations
-	burstReplicas uint
-	statusUpdater *status.VMIRSStatusUpdater
+	clientset            kubecli.KubevirtClient
+	Queue                workqueue.RateLimitingInterface
+	vmiInformer          cache.SharedIndexInformer
+	vmiRSInformer        cache.SharedIndexInformer
+	dataVolumeInformer   cache.SharedIndexInformer
+	pvcInformer          cache.SharedIndexInformer
+	storageClassInformer cache.SharedIndexInformer
+	recorder             record.EventRecorder
+	expectations         *controller.UIDTrackingControllerExpectations
+	burstReplicas        uint
+	statusUpdater        *status.VMIRSStatusUpdater
+	cloneAuthFunc        CloneAuthFunc
 }
 
 func (c *VMIReplicaSet) Run(threadiness int, stopCh <-chan struct{}) {
@@ -110,7 +126,7 @@ func (c *VMIReplicaSet) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting VirtualMachineInstanceReplicaSet controller.")
 
 	// Wait for cache sync before we start the controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiRSInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiRSInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.pvcInformer.HasSynced, c.storageClassInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -222,6 +238,9 @@ func (c *VMIReplicaSet) execute(key string) error {
 		if len(finishedVmis) > 0 && scaleErr == nil {
 			scaleErr = c.cleanFinishedVmis(rs, finishedVmis)
 		}
+		if scaleErr == nil {
+			scaleErr = c.ensureReplicaVolumes(rs, activeVmis)
+		}
 	}
 	// If the controller is going to be deleted and the orphan finalizer is the next one, release the VMIs. Don't update the status
 	// TODO: Workaround for https://github.com/kubernetes/kubernetes/issues/56348, remove it once it is fixed
@@ -327,6 +346,13 @@ func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis
 				vmi.ObjectMeta.Name = ""
 				vmi.ObjectMeta.GenerateName = basename
 				vmi.Spec = rs.Spec.Template.Spec
+				if len(rs.Spec.DataVolumeTemplates) > 0 {
+					// the names of the volumes of the replica are derived from its name
+					vmi.ObjectMeta.GenerateName = ""
+					vmi.ObjectMeta.Name = basename + utilrand.String(5)
+					vmi.Spec = *rs.Spec.Template.Spec.DeepCopy()
+					useReplicaVolumes(rs, vmi)
+				}
 				// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 				vmi.ObjectMeta.Labels = rs.Spec.Template.ObjectMeta.Labels
 				vmi.ObjectMeta.OwnerReferences = []metav1.OwnerReference{OwnerRef(rs)}
@@ -338,6 +364,10 @@ func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis
 					return
 				}
 				c.recorder.Eventf(rs, k8score.EventTypeNormal, SuccessfulCreateVirtualMachineReason, "Started the virtual machine by creating the new virtual machine instance %v", vmi.ObjectMeta.Name)
+				// volumes which fail now are created on the next sync
+				if err := c.createReplicaVolumes(rs, vmi); err != nil {
+					errChan <- err
+				}
 			}()
 		}
 	}
@@ -352,6 +382,125 @@ func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis
 	return nil
 }
 
+// replicaVolumeName returns the name of the copy of a DataVolume template
+// created for a replica
+func replicaVolumeName(vmiName string, templateName string) string {
+	return fmt.Sprintf("%s-%s", vmiName, templateName)
+}
+
+// useReplicaVolumes points the volumes of a new replica which reference a
+// DataVolume template to the copy of the replica
+func useReplicaVolumes(rs *virtv1.VirtualMachineInstanceReplicaSet, vmi *virtv1.VirtualMachineInstance) {
+	for _, template := range rs.Spec.DataVolumeTemplates {
+		for i := range vmi.Spec.Volumes {
+			volume := &vmi.Spec.Volumes[i]
+			if volume.DataVolume != nil && volume.DataVolume.Name == template.Name {
+				volume.DataVolume.Name = replicaVolumeName(vmi.Name, template.Name)
+			} else if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == template.Name {
+				volume.PersistentVolumeClaim.ClaimName = replicaVolumeName(vmi.Name, template.Name)
+			}
+		}
+	}
+}
+
+// ensureReplicaVolumes creates the missing volumes of the replicas which did
+// not start yet
+func (c *VMIReplicaSet) ensureReplicaVolumes(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis []*virtv1.VirtualMachineInstance) error {
+	if len(rs.Spec.DataVolumeTemplates) == 0 {
+		return nil
+	}
+	for _, vmi := range vmis {
+		if !vmi.IsUnprocessed() {
+			continue
+		}
+		if err := c.createReplicaVolumes(rs, vmi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createReplicaVolumes creates the copies of the DataVolume templates the
+// replica references. They are owned by the replica, so that they are deleted
+// along with it. Templates cloning a PVC are cloned by the CSI driver if it
+// can, and by CDI otherwise.
+func (c *VMIReplicaSet) createReplicaVolumes(rs *virtv1.VirtualMachineInstanceReplicaSet, vmi *virtv1.VirtualMachineInstance) error {
+	for i := range rs.Spec.DataVolumeTemplates {
+		template := &rs.Spec.DataVolumeTemplates[i]
+		name := replicaVolumeName(vmi.Name, template.Name)
+		if !referencesVolume(vmi, name) || c.replicaVolumeExists(vmi.Namespace, name) {
+			continue
+		}
+
+		if err := authorizeDataVolumeClone(c.cloneAuthFunc, vmi.Namespace, &vmi.Spec, &template.Spec); err != nil {
+			c.recorder.Eventf(rs, k8score.EventTypeWarning, UnauthorizedDataVolumeCreateReason, "Not authorized to create DataVolume %s: %v", name, err)
+			return fmt.Errorf("Not authorized to create DataVolume: %v", err)
+		}
+
+		labels := map[string]string{}
+		for k, v := range template.Labels {
+			labels[k] = v
+		}
+		labels[virtv1.CreatedByLabel] = string(vmi.UID)
+		ownerRefs := []metav1.OwnerReference{
+			*metav1.NewControllerRef(vmi, virtv1.VirtualMachineInstanceGroupVersionKind),
+		}
+
+		if source := csiCloneSource(&template.Spec, vmi.Namespace, c.pvcInformer.GetStore(), c.storageClassInformer.GetStore()); source != nil {
+			pvc := newCSIClonePVC(name, vmi.Namespace, template, source)
+			pvc.Labels = labels
+			pvc.OwnerReferences = ownerRefs
+			_, err := c.clientset.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(pvc)
+			if errors.IsAlreadyExists(err) {
+				continue
+			} else if err != nil {
+				c.recorder.Eventf(rs, k8score.EventTypeWarning, FailedCSICloneReason, "Error creating PVC %s as CSI clone of %s: %v", name, source.Name, err)
+				return fmt.Errorf("Failed to create PVC as CSI clone: %v", err)
+			}
+			c.recorder.Eventf(rs, k8score.EventTypeNormal, SuccessfulCSICloneReason, "Created PVC %s as CSI clone of %s", name, source.Name)
+			continue
+		}
+
+		dataVolume := &cdiv1.DataVolume{}
+		dataVolume.ObjectMeta = *template.ObjectMeta.DeepCopy()
+		dataVolume.ObjectMeta.Name = name
+		dataVolume.ObjectMeta.Namespace = vmi.Namespace
+		dataVolume.ObjectMeta.Labels = labels
+		dataVolume.ObjectMeta.OwnerReferences = ownerRefs
+		dataVolume.Spec = *template.Spec.DeepCopy()
+		_, err := c.clientset.CdiClient().CdiV1alpha1().DataVolumes(vmi.Namespace).Create(dataVolume)
+		if errors.IsAlreadyExists(err) {
+			continue
+		} else if err != nil {
+			c.recorder.Eventf(rs, k8score.EventTypeWarning, FailedDataVolumeCreateReason, "Error creating DataVolume %s: %v", name, err)
+			return fmt.Errorf("Failed to create DataVolume: %v", err)
+		}
+		c.recorder.Eventf(rs, k8score.EventTypeNormal, SuccessfulDataVolumeCreateReason, "Created DataVolume %s", name)
+	}
+	return nil
+}
+
+func (c *VMIReplicaSet) replicaVolumeExists(namespace string, name string) bool {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	if _, exists, _ := c.dataVolumeInformer.GetStore().GetByKey(key); exists {
+		return true
+	}
+	_, exists, _ := c.pvcInformer.GetStore().GetByKey(key)
+	return exists
+}
+
+func referencesVolume(vmi *virtv1.VirtualMachineInstance, name string) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.DataVolume != nil && volume.DataVolume.Name == name {
+			return true
+		}
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == name {
+			return true
+		}
+	}
+	return false
+}
+
 // filterActiveVMIs takes a list of VMIs and returns all VMIs which are not in a final state, not terminating and not unknown
 func (c *VMIReplicaSet) filterActiveVMIs(vmis []*virtv1.VirtualMachineInstance) []*virtv1.VirtualMachineInstance {
 	return filter(vmis, func(vmi *virtv1.VirtualMachineInstance) bool {
@@ -2,19 +2,28 @@ package watch
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
 	"k8s.io/client-go/tools/record"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/testutils"
 )
@@ -40,6 +49,10 @@ var _ = Describe("Replicaset", func() {
 		var rsSource *framework.FakeControllerSource
 		var vmiInformer cache.SharedIndexInformer
 		var rsInformer cache.SharedIndexInformer
+		var dataVolumeInformer cache.SharedIndexInformer
+		var pvcInformer cache.SharedIndexInformer
+		var storageClassInformer cache.SharedIndexInformer
+		var virtClient *kubecli.MockKubevirtClient
 		var stop chan struct{}
 		var controller *VMIReplicaSet
 		var recorder *record.FakeRecorder
@@ -55,15 +68,18 @@ var _ = Describe("Replicaset", func() {
 		BeforeEach(func() {
 			stop = make(chan struct{})
 			ctrl = gomock.NewController(GinkgoT())
-			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			virtClient = kubecli.NewMockKubevirtClient(ctrl)
 			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 			rsInterface = kubecli.NewMockReplicaSetInterface(ctrl)
 
 			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 			rsInformer, rsSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceReplicaSet{})
+			dataVolumeInformer, _ = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+			pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+			storageClassInformer, _ = testutils.NewFakeInformerFor(&storagev1.StorageClass{})
 			recorder = record.NewFakeRecorder(100)
 
-			controller = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
+			controller = NewVMIReplicaSet(vmiInformer, rsInformer, dataVolumeInformer, pvcInformer, storageClassInformer, recorder, virtClient, uint(10))
 			// Wrap our workqueue to have a way to detect when we are done processing updates
 			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 			controller.Queue = mockQueue
@@ -97,6 +113,155 @@ var _ = Describe("Replicaset", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
+		Context("with DataVolume templates", func() {
+			var kubeClient *fake.Clientset
+			var cdiClient *cdifake.Clientset
+			storageClassName := "csi"
+
+			BeforeEach(func() {
+				kubeClient = fake.NewSimpleClientset()
+				virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+				cdiClient = cdifake.NewSimpleClientset()
+				virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+				controller.cloneAuthFunc = func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
+					return true, "", nil
+				}
+
+				Expect(pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "golden",
+						Namespace: metav1.NamespaceDefault,
+					},
+					Spec: k8sv1.PersistentVolumeClaimSpec{
+						StorageClassName: &storageClassName,
+					},
+					Status: k8sv1.PersistentVolumeClaimStatus{
+						Phase:    k8sv1.ClaimBound,
+						Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("1Gi")},
+					},
+				})).To(Succeed())
+			})
+
+			replicaSetWithTemplate := func(replicas int32) (*v1.VirtualMachineInstanceReplicaSet, *v1.VirtualMachineInstance) {
+				rs, vmi := DefaultReplicaSet(replicas)
+				rs.Spec.Template.Spec.Volumes = []v1.Volume{{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "rootdisk"},
+					},
+				}}
+				rs.Spec.DataVolumeTemplates = []v1.DataVolumeTemplateSpec{{
+					ObjectMeta: metav1.ObjectMeta{Name: "rootdisk"},
+					Spec: cdiv1.DataVolumeSpec{
+						Source: cdiv1.DataVolumeSource{
+							PVC: &cdiv1.DataVolumeSourcePVC{Name: "golden"},
+						},
+						PVC: &k8sv1.PersistentVolumeClaimSpec{
+							StorageClassName: &storageClassName,
+							Resources: k8sv1.ResourceRequirements{
+								Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				}}
+				return rs, vmi
+			}
+
+			table.DescribeTable("should clone the volumes of each new VMI", func(cloneStrategy string, csiClone bool) {
+				Expect(storageClassInformer.GetStore().Add(&storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        storageClassName,
+						Annotations: map[string]string{v1.CloneStrategyAnnotation: cloneStrategy},
+					},
+				})).To(Succeed())
+				rs, _ := replicaSetWithTemplate(2)
+				addReplicaSet(rs)
+
+				var vmiNames []string
+				vmiInterface.EXPECT().Create(gomock.Any()).Times(2).DoAndReturn(func(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstance, error) {
+					Expect(vmi.Name).To(HavePrefix("testvmi"))
+					Expect(vmi.GenerateName).To(BeEmpty())
+					Expect(vmi.Spec.Volumes[0].DataVolume.Name).To(Equal(vmi.Name + "-rootdisk"))
+					Expect(rs.Spec.Template.Spec.Volumes[0].DataVolume.Name).To(Equal("rootdisk"))
+					vmi = vmi.DeepCopy()
+					vmi.Namespace = metav1.NamespaceDefault
+					vmi.UID = "uid-" + types.UID(vmi.Name)
+					return vmi, nil
+				})
+
+				// the VMIs and their volumes are created in parallel
+				var lock sync.Mutex
+				var created []metav1.Object
+				createReactor := func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					lock.Lock()
+					defer lock.Unlock()
+					obj = action.(testing.CreateAction).GetObject()
+					created = append(created, obj.(metav1.Object))
+					return true, obj, nil
+				}
+				kubeClient.Fake.PrependReactor("create", "persistentvolumeclaims", createReactor)
+				cdiClient.Fake.PrependReactor("create", "datavolumes", createReactor)
+
+				controller.Execute()
+
+				Expect(created).To(HaveLen(2))
+				for _, obj := range created {
+					vmiName := obj.GetOwnerReferences()[0].Name
+					vmiNames = append(vmiNames, vmiName)
+					Expect(obj.GetName()).To(Equal(vmiName + "-rootdisk"))
+					Expect(obj.GetOwnerReferences()[0].Kind).To(Equal("VirtualMachineInstance"))
+					Expect(string(obj.GetOwnerReferences()[0].UID)).To(Equal("uid-" + vmiName))
+					Expect(obj.GetLabels()).To(HaveKeyWithValue(v1.CreatedByLabel, "uid-"+vmiName))
+					if csiClone {
+						Expect(obj.(*k8sv1.PersistentVolumeClaim).Spec.DataSource.Name).To(Equal("golden"))
+					} else {
+						Expect(obj.(*cdiv1.DataVolume).Spec.Source.PVC.Name).To(Equal("golden"))
+					}
+				}
+				Expect(vmiNames[0]).ToNot(Equal(vmiNames[1]))
+
+				volumeReason := SuccessfulDataVolumeCreateReason
+				if csiClone {
+					volumeReason = SuccessfulCSICloneReason
+				}
+				for x := 0; x < 2; x++ {
+					testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+					testutils.ExpectEvent(recorder, volumeReason)
+				}
+			},
+				table.Entry("with the CSI driver if the StorageClass supports it", v1.CSICloneStrategy, true),
+				table.Entry("with CDI if the StorageClass doesn't support CSI clones", "", false),
+			)
+
+			It("should create the missing volumes of VMIs which did not start yet", func() {
+				rs, vmi := replicaSetWithTemplate(1)
+				vmi.Name = "testvmiabcde"
+				vmi.UID = "uid-testvmiabcde"
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "testvmiabcde-rootdisk"},
+					},
+				}}
+				addReplicaSet(rs)
+				vmiFeeder.Add(vmi)
+
+				createCount := 0
+				cdiClient.Fake.PrependReactor("create", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					dataVolume := action.(testing.CreateAction).GetObject().(*cdiv1.DataVolume)
+					Expect(dataVolume.Name).To(Equal("testvmiabcde-rootdisk"))
+					createCount++
+					return true, dataVolume, nil
+				})
+				rsInterface.EXPECT().UpdateStatus(gomock.Any()).AnyTimes()
+
+				controller.Execute()
+
+				Expect(createCount).To(Equal(1))
+				testutils.ExpectEvent(recorder, SuccessfulDataVolumeCreateReason)
+			})
+		})
+
 		It("should create missing VMIs when it gets unpaused", func() {
 			rs, vmi := DefaultReplicaSet(3)
 			rs.Spec.Paused = false
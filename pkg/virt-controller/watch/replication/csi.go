@@ -0,0 +1,178 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package replication
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+const volumeReplicationAPIVersion = "replication.storage.openshift.io/v1alpha1"
+
+// volumeReplication is the subset of the VolumeReplication of the CSI
+// replication addons used by the controller, the API is not vendored
+type volumeReplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   volumeReplicationSpec   `json:"spec"`
+	Status volumeReplicationStatus `json:"status,omitempty"`
+}
+
+type volumeReplicationSpec struct {
+	VolumeReplicationClass string                           `json:"volumeReplicationClass"`
+	ReplicationState       string                           `json:"replicationState"`
+	DataSource             corev1.TypedLocalObjectReference `json:"dataSource"`
+}
+
+type volumeReplicationStatus struct {
+	Conditions   []volumeReplicationCondition `json:"conditions,omitempty"`
+	LastSyncTime *metav1.Time                 `json:"lastSyncTime,omitempty"`
+}
+
+type volumeReplicationCondition struct {
+	Type    string                 `json:"type"`
+	Status  corev1.ConditionStatus `json:"status"`
+	Message string                 `json:"message,omitempty"`
+}
+
+// csiReplicator leaves the replication to the storage, which replicates the
+// claims of the storage classes with a volume replication class
+type csiReplicator struct {
+	ctrl *ReplicationController
+}
+
+func (r *csiReplicator) replicate(vm *kubevirtv1.VirtualMachine, volumeName string, claim *corev1.PersistentVolumeClaim, _ *replicationTarget, _ *kubevirtv1.VolumeReplicationStatus) (*kubevirtv1.VolumeReplicationStatus, error) {
+	status := &kubevirtv1.VolumeReplicationStatus{
+		Name:      volumeName,
+		ClaimName: claim.Name,
+		Method:    kubevirtv1.VolumeReplicationCSI,
+		Phase:     kubevirtv1.ReplicationPending,
+	}
+
+	vr, err := r.get(vm.Namespace, claim.Name)
+	if err != nil {
+		return nil, err
+	}
+	if vr == nil {
+		class, err := r.ctrl.volumeReplicationClass(claim)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.create(vm, claim, class); err != nil {
+			return nil, err
+		}
+		return status, nil
+	}
+
+	status.Phase = kubevirtv1.ReplicationReplicating
+	status.LastSyncTime = vr.Status.LastSyncTime
+	for _, condition := range vr.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case "Degraded":
+			status.Phase = kubevirtv1.ReplicationFailed
+			status.Message = condition.Message
+			return status, nil
+		case "Completed":
+			status.Phase = kubevirtv1.ReplicationReplicated
+		}
+	}
+	return status, nil
+}
+
+func (r *csiReplicator) stop(vm *kubevirtv1.VirtualMachine, claimName string) error {
+	err := r.ctrl.Client.RestClient().Delete().AbsPath(volumeReplicationPath(vm.Namespace, claimName)).Do().Error()
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *csiReplicator) get(namespace, name string) (*volumeReplication, error) {
+	data, err := r.ctrl.Client.RestClient().Get().AbsPath(volumeReplicationPath(namespace, name)).DoRaw()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VolumeReplication %s: %v", name, err)
+	}
+
+	vr := &volumeReplication{}
+	if err := json.Unmarshal(data, vr); err != nil {
+		return nil, err
+	}
+	return vr, nil
+}
+
+func (r *csiReplicator) create(vm *kubevirtv1.VirtualMachine, claim *corev1.PersistentVolumeClaim, class string) error {
+	vr := &volumeReplication{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: volumeReplicationAPIVersion,
+			Kind:       "VolumeReplication",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: vm.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vm, kubevirtv1.VirtualMachineGroupVersionKind),
+			},
+		},
+		Spec: volumeReplicationSpec{
+			VolumeReplicationClass: class,
+			ReplicationState:       "primary",
+			DataSource: corev1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: claim.Name,
+			},
+		},
+	}
+	data, err := json.Marshal(vr)
+	if err != nil {
+		return err
+	}
+
+	err = r.ctrl.Client.RestClient().Post().
+		AbsPath(volumeReplicationPath(vm.Namespace, "")).
+		SetHeader("Content-Type", "application/json").
+		Body(data).
+		Do().
+		Error()
+	if err != nil {
+		return fmt.Errorf("failed to create VolumeReplication %s: %v", claim.Name, err)
+	}
+	return nil
+}
+
+func volumeReplicationPath(namespace, name string) string {
+	path := fmt.Sprintf("/apis/%s/namespaces/%s/volumereplications", volumeReplicationAPIVersion, namespace)
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
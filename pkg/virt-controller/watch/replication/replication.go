@@ -0,0 +1,368 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package replication
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/pairing"
+)
+
+const (
+	// ReplicaOfAnnotation marks the copy of a VM in the disaster recovery
+	// cluster with the namespace and name of the replicated VM
+	ReplicaOfAnnotation = "replication.kubevirt.io/replica-of"
+
+	// VolumeReplicationClassAnnotation of a storage class names the volume
+	// replication class of the CSI driver, its PersistentVolumeClaims are
+	// replicated by the storage instead of rsync
+	VolumeReplicationClassAnnotation = "replication.kubevirt.io/volume-replication-class"
+
+	replicationErrorEvent = "ReplicationError"
+
+	replicaCreatedEvent = "ReplicaCreated"
+
+	// the disaster recovery cluster is not watched, the replication is
+	// checked periodically
+	replicationCheckInterval = 30 * time.Second
+
+	defaultReplicationInterval = time.Hour
+)
+
+// volumeReplicator replicates the disk of a volume to the disaster recovery
+// cluster, where it is available in a PersistentVolumeClaim of the same name
+type volumeReplicator interface {
+	// replicate starts or checks the replication of a claim, previous is the
+	// status the volume had, if any
+	replicate(vm *kubevirtv1.VirtualMachine, volumeName string, claim *corev1.PersistentVolumeClaim, target *replicationTarget, previous *kubevirtv1.VolumeReplicationStatus) (*kubevirtv1.VolumeReplicationStatus, error)
+	// stop ends the replication of a claim
+	stop(vm *kubevirtv1.VirtualMachine, claimName string) error
+}
+
+// replicationTarget is the namespace in the disaster recovery cluster the
+// disks and the copy of a VM are replicated to
+type replicationTarget struct {
+	client    kubecli.KubevirtClient
+	namespace string
+	secret    *corev1.Secret
+}
+
+func (ctrl *ReplicationController) updateReplication(vm *kubevirtv1.VirtualMachine) error {
+	logger := log.Log.Object(vm)
+
+	if vm.Spec.Replication == nil {
+		return ctrl.stopReplication(vm)
+	}
+
+	logger.V(3).Infof("Updating the replication")
+
+	// the replication is checked periodically, an error retries it sooner
+	defer ctrl.vmQueue.AddAfter(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name), replicationCheckInterval)
+
+	target, err := ctrl.replicationTarget(vm)
+	if err != nil {
+		return ctrl.updateStatusError(vm, err)
+	}
+
+	replica, err := target.client.VirtualMachine(target.namespace).Get(vm.Name, &metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return ctrl.updateStatusError(vm, fmt.Errorf("failed to get the copy in the disaster recovery cluster: %v", err))
+	}
+	if err != nil {
+		replica = nil
+	} else if replica.Annotations[ReplicaOfAnnotation] != replicaOf(vm) {
+		return ctrl.updateStatusError(vm, fmt.Errorf("VirtualMachine %s/%s in the disaster recovery cluster is no copy of this VM", target.namespace, vm.Name))
+	} else if replicaStarted(replica) {
+		// replicating into the disks of the started copy would corrupt them
+		status := vm.Status.ReplicationStatus.DeepCopy()
+		if status == nil {
+			status = &kubevirtv1.VirtualMachineReplicationStatus{}
+		}
+		status.Phase = kubevirtv1.ReplicationFailedOver
+		status.Message = "the copy was started in the disaster recovery cluster, the replication is suspended"
+		return ctrl.updateStatus(vm, status)
+	}
+
+	status := &kubevirtv1.VirtualMachineReplicationStatus{}
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		claimName := volumeClaimName(&volume)
+		if claimName == "" {
+			// the other volumes are part of the spec of the copy
+			continue
+		}
+		volumeStatus, err := ctrl.replicateVolume(vm, volume.Name, claimName, target)
+		if err != nil {
+			return ctrl.updateStatusError(vm, err)
+		}
+		status.Volumes = append(status.Volumes, *volumeStatus)
+	}
+	summarize(status)
+
+	// the copy is only created once its disks were replicated, starting it
+	// earlier would boot from incomplete disks
+	if len(status.Volumes) == 0 || status.LastSyncTime != nil {
+		if err := ctrl.ensureReplica(vm, replica, target); err != nil {
+			return ctrl.updateStatusError(vm, err)
+		}
+	}
+
+	return ctrl.updateStatus(vm, status)
+}
+
+// replicateVolume hands a claim to the replicator of its storage class
+func (ctrl *ReplicationController) replicateVolume(vm *kubevirtv1.VirtualMachine, volumeName string, claimName string, target *replicationTarget) (*kubevirtv1.VolumeReplicationStatus, error) {
+	var previous *kubevirtv1.VolumeReplicationStatus
+	if vm.Status.ReplicationStatus != nil {
+		for i, volume := range vm.Status.ReplicationStatus.Volumes {
+			if volume.Name == volumeName && volume.ClaimName == claimName {
+				previous = &vm.Status.ReplicationStatus.Volumes[i]
+			}
+		}
+	}
+
+	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, claimName))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		status := &kubevirtv1.VolumeReplicationStatus{
+			Name:      volumeName,
+			ClaimName: claimName,
+			Method:    kubevirtv1.VolumeReplicationRsync,
+			Phase:     kubevirtv1.ReplicationPending,
+			Message:   fmt.Sprintf("PersistentVolumeClaim %s does not exist", claimName),
+		}
+		if previous != nil {
+			status.Method = previous.Method
+			status.LastSyncTime = previous.LastSyncTime
+		}
+		return status, nil
+	}
+	claim := obj.(*corev1.PersistentVolumeClaim)
+
+	method, err := ctrl.replicationMethod(claim)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.Method != method {
+		previous = nil
+	}
+	return ctrl.replicators[method].replicate(vm, volumeName, claim, target, previous)
+}
+
+// replicationMethod returns CSI for the claims of storage classes with a
+// volume replication class, rsync for the others
+func (ctrl *ReplicationController) replicationMethod(claim *corev1.PersistentVolumeClaim) (kubevirtv1.VolumeReplicationMethod, error) {
+	class, err := ctrl.volumeReplicationClass(claim)
+	if err != nil {
+		return "", err
+	}
+	if class != "" {
+		return kubevirtv1.VolumeReplicationCSI, nil
+	}
+	return kubevirtv1.VolumeReplicationRsync, nil
+}
+
+func (ctrl *ReplicationController) volumeReplicationClass(claim *corev1.PersistentVolumeClaim) (string, error) {
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName == "" {
+		return "", nil
+	}
+	obj, exists, err := ctrl.StorageClassInformer.GetStore().GetByKey(*claim.Spec.StorageClassName)
+	if err != nil || !exists {
+		return "", err
+	}
+	return obj.(*storagev1.StorageClass).Annotations[VolumeReplicationClassAnnotation], nil
+}
+
+// stopReplication ends the replication of the volumes once it is removed
+// from the spec, the copy in the disaster recovery cluster is kept
+func (ctrl *ReplicationController) stopReplication(vm *kubevirtv1.VirtualMachine) error {
+	if vm.Status.ReplicationStatus == nil {
+		return nil
+	}
+
+	for _, volume := range vm.Status.ReplicationStatus.Volumes {
+		if replicator, ok := ctrl.replicators[volume.Method]; ok {
+			if err := replicator.stop(vm, volume.ClaimName); err != nil {
+				return err
+			}
+		}
+	}
+	log.Log.Object(vm).Infof("Stopped the replication")
+	return ctrl.updateStatus(vm, nil)
+}
+
+func (ctrl *ReplicationController) replicationTarget(vm *kubevirtv1.VirtualMachine) (*replicationTarget, error) {
+	replication := vm.Spec.Replication
+	secret, err := ctrl.Client.CoreV1().Secrets(vm.Namespace).Get(replication.PairingSecretRef, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the pairing Secret %s: %v", replication.PairingSecretRef, err)
+	}
+
+	namespace, err := pairing.Namespace(secret, replication.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ctrl.PeerClient(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client of the disaster recovery cluster: %v", err)
+	}
+	return &replicationTarget{client: client, namespace: namespace, secret: secret}, nil
+}
+
+// ensureReplica creates or updates the stopped copy of the VM in the
+// disaster recovery cluster
+func (ctrl *ReplicationController) ensureReplica(vm *kubevirtv1.VirtualMachine, replica *kubevirtv1.VirtualMachine, target *replicationTarget) error {
+	desired := newReplica(vm, target.namespace)
+	if replica == nil {
+		if _, err := target.client.VirtualMachine(target.namespace).Create(desired); err != nil {
+			return fmt.Errorf("failed to create the copy in the disaster recovery cluster: %v", err)
+		}
+		ctrl.Recorder.Eventf(vm, corev1.EventTypeNormal, replicaCreatedEvent,
+			"Created the copy %s/%s in the disaster recovery cluster", target.namespace, vm.Name)
+		return nil
+	}
+
+	if reflect.DeepEqual(replica.Spec, desired.Spec) && reflect.DeepEqual(replica.Labels, desired.Labels) {
+		return nil
+	}
+	updated := replica.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Spec = desired.Spec
+	if _, err := target.client.VirtualMachine(target.namespace).Update(updated); err != nil {
+		return fmt.Errorf("failed to update the copy in the disaster recovery cluster: %v", err)
+	}
+	return nil
+}
+
+func (ctrl *ReplicationController) updateStatusError(vm *kubevirtv1.VirtualMachine, err error) error {
+	ctrl.Recorder.Eventf(vm, corev1.EventTypeWarning, replicationErrorEvent, "Replication encountered error %s", err.Error())
+
+	status := vm.Status.ReplicationStatus.DeepCopy()
+	if status == nil {
+		status = &kubevirtv1.VirtualMachineReplicationStatus{Phase: kubevirtv1.ReplicationPending}
+	}
+	status.Message = err.Error()
+	if err2 := ctrl.updateStatus(vm, status); err2 != nil {
+		return err2
+	}
+	return err
+}
+
+func (ctrl *ReplicationController) updateStatus(vm *kubevirtv1.VirtualMachine, status *kubevirtv1.VirtualMachineReplicationStatus) error {
+	if reflect.DeepEqual(vm.Status.ReplicationStatus, status) {
+		return nil
+	}
+	updated := vm.DeepCopy()
+	updated.Status.ReplicationStatus = status
+	_, err := ctrl.Client.VirtualMachine(vm.Namespace).UpdateStatus(updated)
+	return err
+}
+
+// newReplica copies the spec of the VM, its volumes backed by
+// PersistentVolumeClaims use the replicated claims
+func newReplica(vm *kubevirtv1.VirtualMachine, namespace string) *kubevirtv1.VirtualMachine {
+	replica := &kubevirtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        vm.Name,
+			Namespace:   namespace,
+			Labels:      vm.Labels,
+			Annotations: map[string]string{ReplicaOfAnnotation: replicaOf(vm)},
+		},
+		Spec: *vm.Spec.DeepCopy(),
+	}
+
+	running := false
+	replica.Spec.Running = &running
+	replica.Spec.RunStrategy = nil
+	// the disks are replicated, the copy doesn't import them again
+	replica.Spec.DataVolumeTemplates = nil
+	replica.Spec.Replication = nil
+
+	volumes := replica.Spec.Template.Spec.Volumes
+	for i := range volumes {
+		if claimName := volumeClaimName(&volumes[i]); claimName != "" {
+			volumes[i].VolumeSource = kubevirtv1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			}
+		}
+	}
+	return replica
+}
+
+// summarize sets the phase of the VM to the least advanced phase of its
+// volumes, and the last sync time to the oldest one
+func summarize(status *kubevirtv1.VirtualMachineReplicationStatus) {
+	rank := map[kubevirtv1.VirtualMachineReplicationPhase]int{
+		kubevirtv1.ReplicationFailed:      0,
+		kubevirtv1.ReplicationPending:     1,
+		kubevirtv1.ReplicationReplicating: 2,
+		kubevirtv1.ReplicationReplicated:  3,
+	}
+
+	status.Phase = kubevirtv1.ReplicationReplicated
+	synced := true
+	for _, volume := range status.Volumes {
+		if rank[volume.Phase] < rank[status.Phase] {
+			status.Phase = volume.Phase
+			status.Message = volume.Message
+		}
+		if volume.LastSyncTime == nil {
+			synced = false
+		} else if status.LastSyncTime == nil || volume.LastSyncTime.Before(status.LastSyncTime) {
+			status.LastSyncTime = volume.LastSyncTime.DeepCopy()
+		}
+	}
+	if !synced {
+		status.LastSyncTime = nil
+	}
+}
+
+func replicaStarted(replica *kubevirtv1.VirtualMachine) bool {
+	if replica.Spec.Running != nil {
+		return *replica.Spec.Running
+	}
+	return replica.Spec.RunStrategy != nil && *replica.Spec.RunStrategy != kubevirtv1.RunStrategyHalted
+}
+
+func replicaOf(vm *kubevirtv1.VirtualMachine) string {
+	return fmt.Sprintf("%s/%s", vm.Namespace, vm.Name)
+}
+
+func volumeClaimName(volume *kubevirtv1.Volume) string {
+	switch {
+	case volume.PersistentVolumeClaim != nil:
+		return volume.PersistentVolumeClaim.ClaimName
+	case volume.DataVolume != nil:
+		return volume.DataVolume.Name
+	}
+	return ""
+}
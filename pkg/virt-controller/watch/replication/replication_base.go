@@ -0,0 +1,166 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package replication
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/pairing"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// ReplicationController replicates the disks of VMs to a disaster recovery
+// cluster and keeps a stopped copy of the VMs there
+type ReplicationController struct {
+	Client kubecli.KubevirtClient
+
+	VMInformer           cache.SharedIndexInformer
+	VMIInformer          cache.SharedIndexInformer
+	PVCInformer          cache.SharedIndexInformer
+	StorageClassInformer cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+	Recorder      record.EventRecorder
+
+	// PeerClient returns a client of the disaster recovery cluster a pairing
+	// Secret grants access to, defaults to a client using its server and token
+	PeerClient func(secret *corev1.Secret) (kubecli.KubevirtClient, error)
+
+	replicators map[kubevirtv1.VolumeReplicationMethod]volumeReplicator
+	vmQueue     workqueue.RateLimitingInterface
+	now         func() time.Time
+}
+
+// Init initializes the replication controller
+func (ctrl *ReplicationController) Init() {
+	ctrl.vmQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "replication-controller-vm")
+	ctrl.now = time.Now
+
+	if ctrl.PeerClient == nil {
+		ctrl.PeerClient = pairing.NewPeerClient
+	}
+	ctrl.replicators = map[kubevirtv1.VolumeReplicationMethod]volumeReplicator{
+		kubevirtv1.VolumeReplicationCSI:   &csiReplicator{ctrl: ctrl},
+		kubevirtv1.VolumeReplicationRsync: &rsyncReplicator{ctrl: ctrl},
+	}
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVM,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVM(newObj) },
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *ReplicationController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmQueue.ShutDown()
+
+	log.Log.Info("Starting replication controller.")
+	defer log.Log.Info("Shutting down replication controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.PVCInformer.HasSynced,
+		ctrl.StorageClassInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *ReplicationController) vmWorker() {
+	for ctrl.processVMWorkItem() {
+	}
+}
+
+func (ctrl *ReplicationController) processVMWorkItem() bool {
+	key, quit := ctrl.vmQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.vmQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachine %v", key)
+		ctrl.vmQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachine %v", key)
+		ctrl.vmQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *ReplicationController) execute(key string) error {
+	if !ctrl.ClusterConfig.DiskReplicationEnabled() {
+		return nil
+	}
+
+	storeObj, exists, err := ctrl.VMInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vm, ok := storeObj.(*kubevirtv1.VirtualMachine)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateReplication(vm.DeepCopy())
+}
+
+// handleVM enqueues the VMs which are replicated, and those which still
+// report a replication to stop it
+func (ctrl *ReplicationController) handleVM(obj interface{}) {
+	vm, ok := obj.(*kubevirtv1.VirtualMachine)
+	if !ok || (vm.Spec.Replication == nil && vm.Status.ReplicationStatus == nil) {
+		return
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(vm)
+	if err != nil {
+		log.Log.Errorf("failed to get key from object: %v, %v", err, vm)
+		return
+	}
+
+	log.Log.V(3).Infof("enqueued %q for sync", key)
+	ctrl.vmQueue.Add(key)
+}
@@ -0,0 +1,482 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package replication
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/pairing"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	testNamespace = "default"
+	peerNamespace = "dr"
+)
+
+var _ = Describe("Replication", func() {
+
+	var ctrl *gomock.Controller
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var peerVMInterface *kubecli.MockVirtualMachineInterface
+	var k8sClient *fake.Clientset
+	var peerK8sClient *fake.Clientset
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var pvcInformer cache.SharedIndexInformer
+	var storageClassInformer cache.SharedIndexInformer
+	var server *ghttp.Server
+	var controller *ReplicationController
+	var now time.Time
+	var updated *v1.VirtualMachine
+
+	setFeatureGates := func(gates ...string) {
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: gates},
+					ReplicationImage:       "quay.io/kubevirt/replication:latest",
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		controller.ClusterConfig = config
+	}
+
+	newVM := func() *v1.VirtualMachine {
+		running := true
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web01",
+				Namespace: testNamespace,
+				UID:       "vm-uid",
+				Labels:    map[string]string{"app": "web"},
+			},
+			Spec: v1.VirtualMachineSpec{
+				Running: &running,
+				Replication: &v1.VirtualMachineReplication{
+					PairingSecretRef: "dr",
+				},
+				DataVolumeTemplates: []v1.DataVolumeTemplateSpec{{
+					ObjectMeta: metav1.ObjectMeta{Name: "web01-rootdisk"},
+				}},
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "rootdisk",
+								VolumeSource: v1.VolumeSource{
+									DataVolume: &v1.DataVolumeSource{Name: "web01-rootdisk"},
+								},
+							},
+							{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	replicatedVM := func(syncTime time.Time) *v1.VirtualMachine {
+		vm := newVM()
+		lastSyncTime := metav1.NewTime(syncTime)
+		vm.Status.ReplicationStatus = &v1.VirtualMachineReplicationStatus{
+			Phase:        v1.ReplicationReplicated,
+			LastSyncTime: &lastSyncTime,
+			Volumes: []v1.VolumeReplicationStatus{{
+				Name:         "rootdisk",
+				ClaimName:    "web01-rootdisk",
+				Method:       v1.VolumeReplicationRsync,
+				Phase:        v1.ReplicationReplicated,
+				LastSyncTime: &lastSyncTime,
+			}},
+		}
+		return vm
+	}
+
+	addClaim := func(storageClassName string, volumeMode corev1.PersistentVolumeMode) {
+		Expect(pvcInformer.GetStore().Add(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web01-rootdisk",
+				Namespace: testNamespace,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				StorageClassName: &storageClassName,
+				VolumeMode:       &volumeMode,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("20Gi"),
+					},
+				},
+			},
+		})).To(Succeed())
+	}
+
+	addReplicationPod := func(phase corev1.PodPhase, created time.Time) {
+		_, err := k8sClient.CoreV1().Pods(testNamespace).Create(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "web01-rootdisk-replication",
+				Namespace:         testNamespace,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: replicationContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message:    "rsync error: error in socket IO",
+							FinishedAt: metav1.NewTime(created.Add(time.Minute)),
+						},
+					},
+				}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	expectNoReplica := func() {
+		peerVMInterface.EXPECT().Get("web01", gomock.Any()).
+			Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), "web01"))
+	}
+
+	process := func(vm *v1.VirtualMachine) error {
+		Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+		return controller.execute(testNamespace + "/" + vm.Name)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		peerClient := kubecli.NewMockKubevirtClient(ctrl)
+		peerVMInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+
+		server = ghttp.NewServer()
+		serverClient, err := kubecli.GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+		virtClient.EXPECT().RestClient().Return(serverClient.RestClient()).AnyTimes()
+
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		pvcInformer, _ = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
+		storageClassInformer, _ = testutils.NewFakeInformerFor(&storagev1.StorageClass{})
+
+		controller = &ReplicationController{
+			Client:               virtClient,
+			VMInformer:           vmInformer,
+			VMIInformer:          vmiInformer,
+			PVCInformer:          pvcInformer,
+			StorageClassInformer: storageClassInformer,
+			Recorder:             record.NewFakeRecorder(100),
+			PeerClient: func(secret *corev1.Secret) (kubecli.KubevirtClient, error) {
+				Expect(secret.Name).To(Equal("dr"))
+				return peerClient, nil
+			},
+		}
+		controller.Init()
+		setFeatureGates(virtconfig.DiskReplicationGate)
+
+		now = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+		controller.now = func() time.Time { return now }
+
+		k8sClient = fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dr",
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				pairing.KeyServer:    []byte("https://api.dr.example.com:6443"),
+				pairing.KeyToken:     []byte("dr-token"),
+				pairing.KeyNamespace: []byte(peerNamespace),
+			},
+		})
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+
+		peerK8sClient = fake.NewSimpleClientset()
+		peerClient.EXPECT().CoreV1().Return(peerK8sClient.CoreV1()).AnyTimes()
+		peerClient.EXPECT().VirtualMachine(peerNamespace).Return(peerVMInterface).AnyTimes()
+
+		updated = nil
+		vmInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+			updated = vm
+			return vm, nil
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		server.Close()
+		ctrl.Finish()
+	})
+
+	It("should ignore VMs without the feature gate", func() {
+		setFeatureGates()
+
+		Expect(process(newVM())).To(Succeed())
+		Expect(updated).To(BeNil())
+	})
+
+	Context("with rsync", func() {
+
+		BeforeEach(func() {
+			addClaim("local", corev1.PersistentVolumeFilesystem)
+		})
+
+		It("should start the copy on the node of the VMI", func() {
+			Expect(vmiInformer.GetStore().Add(&v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web01",
+					Namespace: testNamespace,
+				},
+				Status: v1.VirtualMachineInstanceStatus{
+					Phase:    v1.Running,
+					NodeName: "node01",
+				},
+			})).To(Succeed())
+			expectNoReplica()
+
+			Expect(process(newVM())).To(Succeed())
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationReplicating))
+			Expect(updated.Status.ReplicationStatus.LastSyncTime).To(BeNil())
+			Expect(updated.Status.ReplicationStatus.Volumes).To(HaveLen(1))
+			Expect(updated.Status.ReplicationStatus.Volumes[0].Method).To(Equal(v1.VolumeReplicationRsync))
+
+			claim, err := peerK8sClient.CoreV1().PersistentVolumeClaims(peerNamespace).Get("web01-rootdisk", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claim.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("20Gi")))
+			Expect(claim.Annotations).To(HaveKeyWithValue(ReplicaOfAnnotation, "default/web01"))
+
+			receiver, err := peerK8sClient.CoreV1().Pods(peerNamespace).Get("web01-rootdisk-replication", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(receiver.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("web01-rootdisk"))
+
+			pod, err := k8sClient.CoreV1().Pods(testNamespace).Get("web01-rootdisk-replication", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.NodeName).To(Equal("node01"))
+			Expect(pod.Spec.Containers[0].Image).To(Equal("quay.io/kubevirt/replication:latest"))
+			Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+			Expect(pod.Spec.Volumes[1].Secret.SecretName).To(Equal("dr"))
+		})
+
+		It("should create the stopped copy once the disks were replicated", func() {
+			created := now.Add(-10 * time.Minute)
+			addReplicationPod(corev1.PodSucceeded, created)
+			expectNoReplica()
+
+			var replica *v1.VirtualMachine
+			peerVMInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				replica = vm
+				return vm, nil
+			})
+
+			Expect(process(newVM())).To(Succeed())
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationReplicated))
+			Expect(updated.Status.ReplicationStatus.LastSyncTime.Time).To(Equal(created))
+
+			_, err := k8sClient.CoreV1().Pods(testNamespace).Get("web01-rootdisk-replication", metav1.GetOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			Expect(replica.Namespace).To(Equal(peerNamespace))
+			Expect(replica.Annotations).To(HaveKeyWithValue(ReplicaOfAnnotation, "default/web01"))
+			Expect(replica.Labels).To(HaveKeyWithValue("app", "web"))
+			Expect(*replica.Spec.Running).To(BeFalse())
+			Expect(replica.Spec.Replication).To(BeNil())
+			Expect(replica.Spec.DataVolumeTemplates).To(BeEmpty())
+			volumes := replica.Spec.Template.Spec.Volumes
+			Expect(volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("web01-rootdisk"))
+			Expect(volumes[1].CloudInitNoCloud).ToNot(BeNil())
+		})
+
+		It("should wait for the interval before the next copy", func() {
+			vm := replicatedVM(now.Add(-10 * time.Minute))
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(newReplica(vm, peerNamespace), nil)
+
+			Expect(process(vm)).To(Succeed())
+			Expect(updated).To(BeNil())
+
+			pods, err := k8sClient.CoreV1().Pods(testNamespace).List(metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods.Items).To(BeEmpty())
+		})
+
+		It("should start the next copy after the interval", func() {
+			vm := replicatedVM(now.Add(-2 * time.Hour))
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(newReplica(vm, peerNamespace), nil)
+
+			Expect(process(vm)).To(Succeed())
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationReplicating))
+			Expect(updated.Status.ReplicationStatus.LastSyncTime.Time).To(Equal(now.Add(-2 * time.Hour)))
+
+			_, err := k8sClient.CoreV1().Pods(testNamespace).Get("web01-rootdisk-replication", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should report a failed copy", func() {
+			addReplicationPod(corev1.PodFailed, now.Add(-2*time.Minute))
+			expectNoReplica()
+
+			Expect(process(newVM())).To(Succeed())
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationFailed))
+			Expect(updated.Status.ReplicationStatus.Message).To(ContainSubstring("error in socket IO"))
+
+			_, err := k8sClient.CoreV1().Pods(testNamespace).Get("web01-rootdisk-replication", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail for block volumes", func() {
+			pvcInformer.GetStore().Replace(nil, "")
+			addClaim("local", corev1.PersistentVolumeBlock)
+			expectNoReplica()
+
+			Expect(process(newVM())).To(Succeed())
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationFailed))
+			Expect(updated.Status.ReplicationStatus.Message).To(ContainSubstring("block volumes"))
+		})
+
+		It("should stop the copy once the replication is removed", func() {
+			vm := replicatedVM(now)
+			vm.Spec.Replication = nil
+			addReplicationPod(corev1.PodRunning, now)
+
+			Expect(process(vm)).To(Succeed())
+			Expect(updated.Status.ReplicationStatus).To(BeNil())
+
+			_, err := k8sClient.CoreV1().Pods(testNamespace).Get("web01-rootdisk-replication", metav1.GetOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("with a volume replication class", func() {
+
+		BeforeEach(func() {
+			Expect(storageClassInformer.GetStore().Add(&storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ceph-rbd",
+					Annotations: map[string]string{VolumeReplicationClassAnnotation: "rbd-replication"},
+				},
+			})).To(Succeed())
+			addClaim("ceph-rbd", corev1.PersistentVolumeBlock)
+		})
+
+		It("should create the VolumeReplication", func() {
+			path := "/apis/replication.storage.openshift.io/v1alpha1/namespaces/default/volumereplications"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", path+"/web01-rootdisk"),
+					ghttp.RespondWith(http.StatusNotFound, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", path),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						vr := &volumeReplication{}
+						Expect(json.Unmarshal(body, vr)).To(Succeed())
+						Expect(vr.Spec.VolumeReplicationClass).To(Equal("rbd-replication"))
+						Expect(vr.Spec.DataSource.Name).To(Equal("web01-rootdisk"))
+						Expect(vr.OwnerReferences[0].Name).To(Equal("web01"))
+					},
+					ghttp.RespondWith(http.StatusCreated, "{}"),
+				),
+			)
+			expectNoReplica()
+
+			Expect(process(newVM())).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationPending))
+			Expect(updated.Status.ReplicationStatus.Volumes[0].Method).To(Equal(v1.VolumeReplicationCSI))
+		})
+
+		It("should report the sync time of the storage", func() {
+			syncTime := metav1.NewTime(now.Add(-time.Minute))
+			server.AppendHandlers(
+				ghttp.RespondWithJSONEncoded(http.StatusOK, &volumeReplication{
+					Status: volumeReplicationStatus{
+						Conditions:   []volumeReplicationCondition{{Type: "Completed", Status: corev1.ConditionTrue}},
+						LastSyncTime: &syncTime,
+					},
+				}),
+			)
+			expectNoReplica()
+			peerVMInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				return vm, nil
+			})
+
+			Expect(process(newVM())).To(Succeed())
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationReplicated))
+			Expect(updated.Status.ReplicationStatus.LastSyncTime.Time.Unix()).To(Equal(syncTime.Unix()))
+		})
+	})
+
+	Context("with a copy in the disaster recovery cluster", func() {
+
+		It("should suspend the replication once the copy was started", func() {
+			vm := replicatedVM(now)
+			replica := newReplica(vm, peerNamespace)
+			running := true
+			replica.Spec.Running = &running
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(replica, nil)
+
+			Expect(process(vm)).To(Succeed())
+			Expect(updated.Status.ReplicationStatus.Phase).To(Equal(v1.ReplicationFailedOver))
+		})
+
+		It("should not replicate into a VM which is no copy", func() {
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(&v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "web01", Namespace: peerNamespace},
+			}, nil)
+
+			Expect(process(newVM())).ToNot(Succeed())
+			Expect(updated.Status.ReplicationStatus.Message).To(ContainSubstring("no copy of this VM"))
+		})
+	})
+})
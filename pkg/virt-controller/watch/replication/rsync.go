@@ -0,0 +1,307 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package replication
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/pairing"
+)
+
+const (
+	replicationPodLabel = "replication"
+
+	replicationContainerName = "replication"
+
+	replicationDiskDir = "/disk"
+
+	replicationPairingDir = "/var/run/kubevirt-replication"
+
+	// a copy which takes longer is considered stuck, the receiver
+	// doesn't outlive it
+	replicationDeadlineSeconds = int64(6 * 60 * 60)
+
+	replicationRetryDelay = 5 * time.Minute
+)
+
+// rsyncReplicator copies the changed blocks of the disk images of filesystem
+// claims with rsync, through a receiver pod in the disaster recovery cluster
+type rsyncReplicator struct {
+	ctrl *ReplicationController
+}
+
+func (r *rsyncReplicator) replicate(vm *kubevirtv1.VirtualMachine, volumeName string, claim *corev1.PersistentVolumeClaim, target *replicationTarget, previous *kubevirtv1.VolumeReplicationStatus) (*kubevirtv1.VolumeReplicationStatus, error) {
+	status := &kubevirtv1.VolumeReplicationStatus{
+		Name:      volumeName,
+		ClaimName: claim.Name,
+		Method:    kubevirtv1.VolumeReplicationRsync,
+		Phase:     kubevirtv1.ReplicationPending,
+	}
+	if previous != nil {
+		status.LastSyncTime = previous.LastSyncTime
+	}
+
+	if claim.Spec.VolumeMode != nil && *claim.Spec.VolumeMode == corev1.PersistentVolumeBlock {
+		status.Phase = kubevirtv1.ReplicationFailed
+		status.Message = "block volumes are only replicated by storage classes with a volume replication class"
+		return status, nil
+	}
+
+	name := replicationPodName(claim.Name)
+	pod, err := r.ctrl.Client.CoreV1().Pods(vm.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get the replication pod %s: %v", name, err)
+	}
+	if err == nil {
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			// the copy holds the disk as of the start of the pod
+			syncTime := pod.CreationTimestamp
+			status.LastSyncTime = &syncTime
+			status.Phase = kubevirtv1.ReplicationReplicated
+			return status, r.cleanup(vm, claim.Name, target)
+		case corev1.PodFailed:
+			status.Phase = kubevirtv1.ReplicationFailed
+			status.Message = fmt.Sprintf("failed to replicate PersistentVolumeClaim %s: %s", claim.Name, replicationMessage(pod))
+			if r.ctrl.now().Sub(replicationFinished(pod)) >= replicationRetryDelay {
+				return status, r.cleanup(vm, claim.Name, target)
+			}
+			return status, nil
+		default:
+			status.Phase = kubevirtv1.ReplicationReplicating
+			return status, nil
+		}
+	}
+
+	if status.LastSyncTime != nil && r.ctrl.now().Before(status.LastSyncTime.Add(replicationInterval(vm))) {
+		status.Phase = kubevirtv1.ReplicationReplicated
+		return status, nil
+	}
+
+	if err := r.ensureTargetClaim(vm, claim, target); err != nil {
+		return nil, err
+	}
+
+	receiver := r.newReceiverPod(vm, claim.Name)
+	if _, err := target.client.CoreV1().Pods(target.namespace).Create(receiver); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create the receiver pod %s in the disaster recovery cluster: %v", name, err)
+	}
+
+	pod, err = r.newReplicationPod(vm, claim.Name, target)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.ctrl.Client.CoreV1().Pods(vm.Namespace).Create(pod); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create the replication pod %s: %v", name, err)
+	}
+	log.Log.Object(vm).Infof("Started the replication of PersistentVolumeClaim %s", claim.Name)
+
+	status.Phase = kubevirtv1.ReplicationReplicating
+	return status, nil
+}
+
+func (r *rsyncReplicator) stop(vm *kubevirtv1.VirtualMachine, claimName string) error {
+	err := r.ctrl.Client.CoreV1().Pods(vm.Namespace).Delete(replicationPodName(claimName), &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// cleanup removes the pods of a finished copy, the next one starts once
+// the interval passed
+func (r *rsyncReplicator) cleanup(vm *kubevirtv1.VirtualMachine, claimName string, target *replicationTarget) error {
+	name := replicationPodName(claimName)
+	err := target.client.CoreV1().Pods(target.namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the receiver pod %s in the disaster recovery cluster: %v", name, err)
+	}
+	return r.stop(vm, claimName)
+}
+
+// ensureTargetClaim creates the claim the disk is replicated to, with the
+// name and size of the replicated claim
+func (r *rsyncReplicator) ensureTargetClaim(vm *kubevirtv1.VirtualMachine, claim *corev1.PersistentVolumeClaim, target *replicationTarget) error {
+	_, err := target.client.CoreV1().PersistentVolumeClaims(target.namespace).Get(claim.Name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get PersistentVolumeClaim %s in the disaster recovery cluster: %v", claim.Name, err)
+	}
+
+	targetClaim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        claim.Name,
+			Annotations: map[string]string{ReplicaOfAnnotation: replicaOf(vm)},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      claim.Spec.AccessModes,
+			Resources:        claim.Spec.Resources,
+			VolumeMode:       claim.Spec.VolumeMode,
+			StorageClassName: vm.Spec.Replication.StorageClassName,
+		},
+	}
+	if _, err := target.client.CoreV1().PersistentVolumeClaims(target.namespace).Create(targetClaim); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create PersistentVolumeClaim %s in the disaster recovery cluster: %v", claim.Name, err)
+	}
+	return nil
+}
+
+// newReceiverPod mounts the replicated claim in the disaster recovery
+// cluster, rsync runs in it through kubectl exec
+func (r *rsyncReplicator) newReceiverPod(vm *kubevirtv1.VirtualMachine, claimName string) *corev1.Pod {
+	deadline := replicationDeadlineSeconds
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        replicationPodName(claimName),
+			Labels:      map[string]string{kubevirtv1.AppLabel: replicationPodLabel},
+			Annotations: map[string]string{ReplicaOfAnnotation: replicaOf(vm)},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &deadline,
+			Containers: []corev1.Container{{
+				Name:    replicationContainerName,
+				Image:   r.ctrl.ClusterConfig.GetReplicationImage(),
+				Command: []string{"sleep", "infinity"},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "disk", MountPath: replicationDiskDir},
+				},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "disk",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				},
+			}},
+		},
+	}
+}
+
+// newReplicationPod mounts the replicated claim read-only, on the node of
+// the VMI while it runs, and the pairing Secret to reach the receiver
+func (r *rsyncReplicator) newReplicationPod(vm *kubevirtv1.VirtualMachine, claimName string, target *replicationTarget) (*corev1.Pod, error) {
+	deadline := replicationDeadlineSeconds
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   replicationPodName(claimName),
+			Labels: map[string]string{kubevirtv1.AppLabel: replicationPodLabel},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vm, kubevirtv1.VirtualMachineGroupVersionKind),
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &deadline,
+			Containers: []corev1.Container{{
+				Name:    replicationContainerName,
+				Image:   r.ctrl.ClusterConfig.GetReplicationImage(),
+				Command: []string{"/bin/sh", "-c", replicationScript(target.namespace, replicationPodName(claimName))},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "disk", MountPath: replicationDiskDir, ReadOnly: true},
+					{Name: "pairing", MountPath: replicationPairingDir, ReadOnly: true},
+				},
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			}},
+			Volumes: []corev1.Volume{
+				{
+					Name: "disk",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName, ReadOnly: true},
+					},
+				},
+				{
+					Name:         "pairing",
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: target.secret.Name}},
+				},
+			},
+		},
+	}
+
+	obj, exists, err := r.ctrl.VMIInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name))
+	if err != nil {
+		return nil, err
+	}
+	// a ReadWriteOnce claim can only be mounted on the node it is attached to
+	if exists {
+		vmi := obj.(*kubevirtv1.VirtualMachineInstance)
+		if !vmi.IsFinal() && vmi.Status.NodeName != "" {
+			pod.Spec.NodeName = vmi.Status.NodeName
+		}
+	}
+	return pod, nil
+}
+
+// replicationScript syncs the disk directory into the receiver pod, rsync
+// uses kubectl exec with the token of the pairing Secret as remote shell
+func replicationScript(namespace, receiver string) string {
+	return fmt.Sprintf(`set -e
+kubectl="kubectl --server=$(cat %[1]s/%[2]s) --token=$(cat %[1]s/%[3]s) --namespace=%[5]s"
+if [ -s %[1]s/%[4]s ]; then
+  kubectl="$kubectl --certificate-authority=%[1]s/%[4]s"
+fi
+$kubectl wait --for=condition=Ready --timeout=10m pod/%[6]s
+cat > /tmp/rsh <<EOF
+#!/bin/sh
+shift
+exec $kubectl exec -i %[6]s -- "\$@"
+EOF
+chmod +x /tmp/rsh
+rsync --archive --inplace --no-whole-file --blocking-io --rsh=/tmp/rsh %[7]s/ rsync:%[7]s/
+`, replicationPairingDir, pairing.KeyServer, pairing.KeyToken, pairing.KeyCA, namespace, receiver, replicationDiskDir)
+}
+
+func replicationMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == replicationContainerName && status.State.Terminated != nil {
+			return strings.TrimSpace(status.State.Terminated.Message)
+		}
+	}
+	return pod.Status.Message
+}
+
+func replicationFinished(pod *corev1.Pod) time.Time {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == replicationContainerName && status.State.Terminated != nil {
+			return status.State.Terminated.FinishedAt.Time
+		}
+	}
+	return pod.CreationTimestamp.Time
+}
+
+func replicationInterval(vm *kubevirtv1.VirtualMachine) time.Duration {
+	if vm.Spec.Replication.Interval != nil {
+		return vm.Spec.Replication.Interval.Duration
+	}
+	return defaultReplicationInterval
+}
+
+func replicationPodName(claimName string) string {
+	return fmt.Sprintf("%s-replication", claimName)
+}
@@ -0,0 +1,294 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package snapshot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/cron"
+)
+
+const (
+	// ScheduleLabel is set on the VirtualMachineSnapshots created by a schedule
+	ScheduleLabel = "snapshot.kubevirt.io/schedule"
+
+	scheduledSnapshotCreateEvent = "SuccessfulScheduledSnapshotCreate"
+
+	scheduledSnapshotSkippedEvent = "ScheduledSnapshotSkipped"
+
+	scheduledSnapshotDeleteEvent = "SuccessfulScheduledSnapshotDelete"
+
+	skippedSnapshotMessage = "Skipped scheduled snapshot: "
+)
+
+func (ctrl *VMSnapshotController) updateVMSnapshotSchedule(schedule *snapshotv1.VirtualMachineSnapshotSchedule) (time.Duration, error) {
+	log.Log.V(3).Infof("Updating VirtualMachineSnapshotSchedule %s/%s", schedule.Namespace, schedule.Name)
+
+	if schedule.DeletionTimestamp != nil {
+		return 0, nil
+	}
+
+	scheduleCpy := schedule.DeepCopy()
+	if scheduleCpy.Status == nil {
+		scheduleCpy.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{}
+	}
+
+	parsed, err := cron.Parse(schedule.Spec.Schedule)
+	if err != nil {
+		scheduleCpy.Status.NextScheduleTime = nil
+		scheduleCpy.Status.Error = scheduleError(schedule, fmt.Sprintf("Invalid schedule: %v", err))
+		return 0, ctrl.updateSnapshotScheduleStatus(schedule, scheduleCpy)
+	}
+
+	// errors of a previous spec are cleared, a skipped run is reported until the next one
+	if e := scheduleCpy.Status.Error; e != nil && (e.Message == nil || !strings.HasPrefix(*e.Message, skippedSnapshotMessage)) {
+		scheduleCpy.Status.Error = nil
+	}
+
+	snapshots, err := ctrl.getScheduledSnapshots(schedule)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = ctrl.deleteExpiredSnapshots(schedule, snapshots); err != nil {
+		return 0, err
+	}
+
+	now := currentTime()
+	last := schedule.CreationTimestamp
+	if schedule.Status != nil && schedule.Status.LastScheduleTime != nil {
+		last = *schedule.Status.LastScheduleTime
+	}
+
+	next := parsed.Next(last.Time)
+	if !next.IsZero() && !now.Time.Before(next) {
+		// missed runs are collapsed into a single one
+		scheduleCpy.Status.LastScheduleTime = now
+		scheduleCpy.Status.Error = nil
+
+		reason, err := ctrl.scheduledSnapshotBlocked(schedule, snapshots)
+		if err != nil {
+			return 0, err
+		}
+
+		if reason != "" {
+			ctrl.Recorder.Event(
+				schedule,
+				corev1.EventTypeWarning,
+				scheduledSnapshotSkippedEvent,
+				skippedSnapshotMessage+reason,
+			)
+			scheduleCpy.Status.Error = newError(skippedSnapshotMessage + reason)
+		} else {
+			name, err := ctrl.createScheduledSnapshot(schedule, next)
+			if err != nil {
+				return 0, err
+			}
+			scheduleCpy.Status.LastSnapshotName = &name
+		}
+
+		next = parsed.Next(now.Time)
+	}
+
+	if next.IsZero() {
+		scheduleCpy.Status.NextScheduleTime = nil
+		scheduleCpy.Status.Error = scheduleError(schedule, "Schedule never runs")
+		return 0, ctrl.updateSnapshotScheduleStatus(schedule, scheduleCpy)
+	}
+
+	scheduleCpy.Status.NextScheduleTime = &metav1.Time{Time: next}
+	if err = ctrl.updateSnapshotScheduleStatus(schedule, scheduleCpy); err != nil {
+		return 0, err
+	}
+
+	return next.Sub(now.Time), nil
+}
+
+// getScheduledSnapshots returns the VirtualMachineSnapshots created by the schedule, newest first
+func (ctrl *VMSnapshotController) getScheduledSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule) ([]*snapshotv1.VirtualMachineSnapshot, error) {
+	objs, err := ctrl.VMSnapshotInformer.GetIndexer().ByIndex("vm", schedule.Spec.Source.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*snapshotv1.VirtualMachineSnapshot
+	for _, obj := range objs {
+		vmSnapshot := obj.(*snapshotv1.VirtualMachineSnapshot)
+		if vmSnapshot.Namespace == schedule.Namespace && vmSnapshot.Labels[ScheduleLabel] == schedule.Name {
+			snapshots = append(snapshots, vmSnapshot)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+	})
+
+	return snapshots, nil
+}
+
+// deleteExpiredSnapshots deletes the ready snapshots exceeding the retention of the schedule
+func (ctrl *VMSnapshotController) deleteExpiredSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule, snapshots []*snapshotv1.VirtualMachineSnapshot) error {
+	if schedule.Spec.Retention == nil {
+		return nil
+	}
+
+	retained := int32(0)
+	for _, vmSnapshot := range snapshots {
+		if vmSnapshot.DeletionTimestamp != nil || !vmSnapshotReady(vmSnapshot) {
+			continue
+		}
+
+		if retained < *schedule.Spec.Retention {
+			retained++
+			continue
+		}
+
+		err := ctrl.Client.VirtualMachineSnapshot(vmSnapshot.Namespace).Delete(vmSnapshot.Name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		ctrl.Recorder.Eventf(
+			schedule,
+			corev1.EventTypeNormal,
+			scheduledSnapshotDeleteEvent,
+			"Deleted VirtualMachineSnapshot %s exceeding the retention",
+			vmSnapshot.Name,
+		)
+	}
+
+	return nil
+}
+
+// scheduledSnapshotBlocked returns why a snapshot can't be taken now, an empty reason if it can
+func (ctrl *VMSnapshotController) scheduledSnapshotBlocked(schedule *snapshotv1.VirtualMachineSnapshotSchedule, snapshots []*snapshotv1.VirtualMachineSnapshot) (string, error) {
+	for _, vmSnapshot := range snapshots {
+		if vmSnapshot.DeletionTimestamp == nil && vmSnapshotProgressing(vmSnapshot) {
+			return fmt.Sprintf("snapshot %s is still in progress", vmSnapshot.Name), nil
+		}
+	}
+
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(cacheKeyFunc(schedule.Namespace, schedule.Spec.Source.Name))
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		return fmt.Sprintf("VirtualMachine %s does not exist", schedule.Spec.Source.Name), nil
+	}
+
+	vm := obj.(*kubevirtv1.VirtualMachine)
+
+	// running VMs are only snapshotted with their guest file systems frozen
+	if schedule.Spec.Quiesce {
+		obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(vm.Namespace, vm.Name))
+		if err != nil {
+			return "", err
+		}
+
+		if exists {
+			if reason := quiesceBlocked(obj.(*kubevirtv1.VirtualMachineInstance)); reason != "" {
+				return fmt.Sprintf("VirtualMachine %s can't be quiesced, %s", vm.Name, reason), nil
+			}
+			return "", nil
+		}
+	}
+
+	rs, err := vm.RunStrategy()
+	if err != nil {
+		return "", err
+	}
+
+	if rs != kubevirtv1.RunStrategyHalted {
+		return fmt.Sprintf("VirtualMachine %s has run strategy %s, snapshots of running VMs require quiesce", vm.Name, rs), nil
+	}
+
+	if vm.Status.Ready || vm.Status.Created {
+		return fmt.Sprintf("VirtualMachine %s is still running", vm.Name), nil
+	}
+
+	return "", nil
+}
+
+func (ctrl *VMSnapshotController) createScheduledSnapshot(schedule *snapshotv1.VirtualMachineSnapshotSchedule, scheduled time.Time) (string, error) {
+	// the name is derived from the scheduled time so a retry doesn't create a second snapshot
+	vmSnapshot := &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", schedule.Name, scheduled.Unix()),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				ScheduleLabel: schedule.Name,
+			},
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source:         schedule.Spec.Source,
+			DeletionPolicy: schedule.Spec.DeletionPolicy,
+			Quiesce:        schedule.Spec.Quiesce,
+		},
+	}
+
+	_, err := ctrl.Client.VirtualMachineSnapshot(vmSnapshot.Namespace).Create(vmSnapshot)
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			return vmSnapshot.Name, nil
+		}
+		return "", err
+	}
+
+	ctrl.Recorder.Eventf(
+		schedule,
+		corev1.EventTypeNormal,
+		scheduledSnapshotCreateEvent,
+		"Successfully created VirtualMachineSnapshot %s",
+		vmSnapshot.Name,
+	)
+
+	return vmSnapshot.Name, nil
+}
+
+// scheduleError keeps the time of an unchanged error, so it doesn't cause an update
+func scheduleError(schedule *snapshotv1.VirtualMachineSnapshotSchedule, message string) *snapshotv1.Error {
+	if schedule.Status != nil && schedule.Status.Error != nil &&
+		schedule.Status.Error.Message != nil && *schedule.Status.Error.Message == message {
+		return schedule.Status.Error.DeepCopy()
+	}
+
+	return newError(message)
+}
+
+func (ctrl *VMSnapshotController) updateSnapshotScheduleStatus(schedule, scheduleCpy *snapshotv1.VirtualMachineSnapshotSchedule) error {
+	if reflect.DeepEqual(schedule, scheduleCpy) {
+		return nil
+	}
+
+	_, err := ctrl.Client.VirtualMachineSnapshotSchedule(scheduleCpy.Namespace).Update(scheduleCpy)
+	return err
+}
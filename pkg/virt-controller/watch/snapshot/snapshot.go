@@ -64,6 +64,8 @@ type snapshotSource interface {
 	Locked() bool
 	Lock() (bool, error)
 	Unlock() (bool, error)
+	Freeze() error
+	Unfreeze() error
 	Spec() snapshotv1.SourceSpec
 	PersistentVolumeClaims() map[string]string
 }
@@ -165,8 +167,19 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 
 		// create content if does not exist
 		if content == nil {
+			if err := source.Freeze(); err != nil {
+				return 0, err
+			}
+
 			return 0, ctrl.createContent(vmSnapshot)
 		}
+
+		// the guest file systems only have to stay frozen until the volume snapshots are cut
+		if volumeSnapshotsCut(content) {
+			if err := source.Unfreeze(); err != nil {
+				return 0, err
+			}
+		}
 	}
 
 	if err = ctrl.updateSnapshotStatus(vmSnapshot, source); err != nil {
@@ -176,6 +189,28 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 	return 0, nil
 }
 
+func volumeSnapshotsCut(content *snapshotv1.VirtualMachineSnapshotContent) bool {
+	if content.Status == nil {
+		return false
+	}
+
+	if vmSnapshotContentReady(content) || content.Status.Error != nil {
+		return true
+	}
+
+	if len(content.Status.VolumeSnapshotStatus) < len(content.Spec.VolumeBackups) {
+		return false
+	}
+
+	for _, vss := range content.Status.VolumeSnapshotStatus {
+		if vss.CreationTime == nil && vss.Error == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.VirtualMachineSnapshotContent) (time.Duration, error) {
 	log.Log.V(3).Infof("Updating VirtualMachineSnapshotContent %s/%s", content.Namespace, content.Name)
 
@@ -813,40 +848,42 @@ func (s *vmSnapshotSource) Lock() (bool, error) {
 		return true, nil
 	}
 
-	rs, err := s.vm.RunStrategy()
+	vmi, err := s.vmi()
 	if err != nil {
 		return false, err
 	}
 
-	if rs != kubevirtv1.RunStrategyHalted {
-		log.Log.V(3).Infof("Snapshottting a running VM is not supported yet")
-		return false, nil
-	}
-
-	key, err := controller.KeyFunc(s.vm)
-	if err != nil {
-		return false, err
-	}
+	if vmi != nil && s.snapshot.Spec.Quiesce {
+		if reason := quiesceBlocked(vmi); reason != "" {
+			log.Log.V(3).Infof("Not quiescing VMI: %s", reason)
+			return false, nil
+		}
+	} else {
+		rs, err := s.vm.RunStrategy()
+		if err != nil {
+			return false, err
+		}
 
-	_, exists, err := s.controller.VMIInformer.GetStore().GetByKey(key)
-	if err != nil {
-		return false, err
-	}
+		if rs != kubevirtv1.RunStrategyHalted {
+			log.Log.V(3).Infof("Snapshottting a running VM requires quiesce")
+			return false, nil
+		}
 
-	if exists {
-		log.Log.V(3).Infof("VMI still running")
-		return false, nil
-	}
+		if vmi != nil {
+			log.Log.V(3).Infof("VMI still running")
+			return false, nil
+		}
 
-	pvcNames := s.pvcNames()
-	pods, err := podsUsingPVCs(s.controller.PodInformer, s.vm.Namespace, pvcNames)
-	if err != nil {
-		return false, err
-	}
+		pvcNames := s.pvcNames()
+		pods, err := podsUsingPVCs(s.controller.PodInformer, s.vm.Namespace, pvcNames)
+		if err != nil {
+			return false, err
+		}
 
-	if len(pods) > 0 {
-		log.Log.V(3).Infof("%d pods using PVCs %+v", len(pods), pvcNames)
-		return false, nil
+		if len(pods) > 0 {
+			log.Log.V(3).Infof("%d pods using PVCs %+v", len(pods), pvcNames)
+			return false, nil
+		}
 	}
 
 	if s.vm.Status.SnapshotInProgress != nil && *s.vm.Status.SnapshotInProgress != s.snapshot.Name {
@@ -881,7 +918,12 @@ func (s *vmSnapshotSource) Unlock() (bool, error) {
 		return false, nil
 	}
 
-	var err error
+	// never leave the guest frozen behind a failed or cancelled snapshot
+	err := s.Unfreeze()
+	if err != nil {
+		return false, err
+	}
+
 	vmCopy := s.vm.DeepCopy()
 
 	if controller.HasFinalizer(vmCopy, sourceFinalizer) {
@@ -893,6 +935,10 @@ func (s *vmSnapshotSource) Unlock() (bool, error) {
 	}
 
 	vmCopy.Status.SnapshotInProgress = nil
+	if vmSnapshotReady(s.snapshot) && s.snapshot.Status.CreationTime != nil &&
+		(vmCopy.Status.LastSnapshotTime == nil || vmCopy.Status.LastSnapshotTime.Before(s.snapshot.Status.CreationTime)) {
+		vmCopy.Status.LastSnapshotTime = s.snapshot.Status.CreationTime
+	}
 	err = s.controller.vmStatusUpdater.UpdateStatus(vmCopy)
 	if err != nil {
 		return true, err
@@ -901,6 +947,75 @@ func (s *vmSnapshotSource) Unlock() (bool, error) {
 	return true, nil
 }
 
+// Freeze freezes the guest file systems of the running VMI of a quiesced snapshot
+func (s *vmSnapshotSource) Freeze() error {
+	vmi, err := s.quiescedVMI()
+	if vmi == nil || err != nil {
+		return err
+	}
+
+	log.Log.Infof("Freezing guest file systems of VMI %s/%s", vmi.Namespace, vmi.Name)
+
+	return s.controller.Client.VirtualMachineInstance(vmi.Namespace).Freeze(vmi.Name)
+}
+
+// Unfreeze thaws the guest file systems again, thawing a VMI which is not frozen is a no-op
+func (s *vmSnapshotSource) Unfreeze() error {
+	vmi, err := s.quiescedVMI()
+	if vmi == nil || err != nil {
+		return err
+	}
+
+	log.Log.V(3).Infof("Thawing guest file systems of VMI %s/%s", vmi.Namespace, vmi.Name)
+
+	return s.controller.Client.VirtualMachineInstance(vmi.Namespace).Unfreeze(vmi.Name)
+}
+
+func (s *vmSnapshotSource) quiescedVMI() (*kubevirtv1.VirtualMachineInstance, error) {
+	if !s.snapshot.Spec.Quiesce {
+		return nil, nil
+	}
+
+	vmi, err := s.vmi()
+	if vmi == nil || err != nil {
+		return nil, err
+	}
+
+	if !vmi.IsRunning() {
+		return nil, nil
+	}
+
+	return vmi, nil
+}
+
+func (s *vmSnapshotSource) vmi() (*kubevirtv1.VirtualMachineInstance, error) {
+	key, err := controller.KeyFunc(s.vm)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, exists, err := s.controller.VMIInformer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return obj.(*kubevirtv1.VirtualMachineInstance), nil
+}
+
+// quiesceBlocked returns why the guest file systems of the VMI can't be frozen
+func quiesceBlocked(vmi *kubevirtv1.VirtualMachineInstance) string {
+	if !vmi.IsRunning() {
+		return "VMI is not running"
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, kubevirtv1.VirtualMachineInstanceAgentConnected) {
+		return "guest agent is not connected"
+	}
+
+	return ""
+}
+
 func (s *vmSnapshotSource) Spec() snapshotv1.SourceSpec {
 	vmCpy := s.vm.DeepCopy()
 	vmCpy.Status = kubevirtv1.VirtualMachineStatus{}
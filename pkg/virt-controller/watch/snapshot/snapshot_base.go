@@ -60,26 +60,28 @@ type dynamicInformer struct {
 type VMSnapshotController struct {
 	Client kubecli.KubevirtClient
 
-	VMSnapshotInformer        cache.SharedIndexInformer
-	VMSnapshotContentInformer cache.SharedIndexInformer
-	VMInformer                cache.SharedIndexInformer
-	VMIInformer               cache.SharedIndexInformer
-	StorageClassInformer      cache.SharedIndexInformer
-	PVCInformer               cache.SharedIndexInformer
-	CRDInformer               cache.SharedIndexInformer
-	PodInformer               cache.SharedIndexInformer
-	DVInformer                cache.SharedIndexInformer
+	VMSnapshotInformer         cache.SharedIndexInformer
+	VMSnapshotContentInformer  cache.SharedIndexInformer
+	VMSnapshotScheduleInformer cache.SharedIndexInformer
+	VMInformer                 cache.SharedIndexInformer
+	VMIInformer                cache.SharedIndexInformer
+	StorageClassInformer       cache.SharedIndexInformer
+	PVCInformer                cache.SharedIndexInformer
+	CRDInformer                cache.SharedIndexInformer
+	PodInformer                cache.SharedIndexInformer
+	DVInformer                 cache.SharedIndexInformer
 
 	Recorder record.EventRecorder
 
 	ResyncPeriod time.Duration
 
-	vmSnapshotQueue        workqueue.RateLimitingInterface
-	vmSnapshotContentQueue workqueue.RateLimitingInterface
-	crdQueue               workqueue.RateLimitingInterface
-	vmSnapshotStatusQueue  workqueue.RateLimitingInterface
-	vmQueue                workqueue.RateLimitingInterface
-	dvQueue                workqueue.RateLimitingInterface
+	vmSnapshotQueue         workqueue.RateLimitingInterface
+	vmSnapshotContentQueue  workqueue.RateLimitingInterface
+	vmSnapshotScheduleQueue workqueue.RateLimitingInterface
+	crdQueue                workqueue.RateLimitingInterface
+	vmSnapshotStatusQueue   workqueue.RateLimitingInterface
+	vmQueue                 workqueue.RateLimitingInterface
+	dvQueue                 workqueue.RateLimitingInterface
 
 	dynamicInformerMap map[string]*dynamicInformer
 	eventHandlerMap    map[string]cache.ResourceEventHandlerFuncs
@@ -93,6 +95,7 @@ var supportedCRDVersions = []string{"v1beta1"}
 func (ctrl *VMSnapshotController) Init() {
 	ctrl.vmSnapshotQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "snapshot-controller-vmsnapshot")
 	ctrl.vmSnapshotContentQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "snapshot-controller-vmsnapshotcontent")
+	ctrl.vmSnapshotScheduleQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "snapshot-controller-vmsnapshotschedule")
 	ctrl.crdQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "snapshot-controller-crd")
 	ctrl.vmSnapshotStatusQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "snapshot-controller-vmsnashotstatus")
 	ctrl.vmQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "snapshot-controller-vm")
@@ -132,6 +135,14 @@ func (ctrl *VMSnapshotController) Init() {
 		ctrl.ResyncPeriod,
 	)
 
+	ctrl.VMSnapshotScheduleInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMSnapshotSchedule,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMSnapshotSchedule(newObj) },
+		},
+		ctrl.ResyncPeriod,
+	)
+
 	ctrl.VMInformer.AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    ctrl.handleVM,
@@ -166,6 +177,7 @@ func (ctrl *VMSnapshotController) Run(threadiness int, stopCh <-chan struct{}) e
 	defer utilruntime.HandleCrash()
 	defer ctrl.vmSnapshotQueue.ShutDown()
 	defer ctrl.vmSnapshotContentQueue.ShutDown()
+	defer ctrl.vmSnapshotScheduleQueue.ShutDown()
 	defer ctrl.crdQueue.ShutDown()
 	defer ctrl.vmSnapshotStatusQueue.ShutDown()
 	defer ctrl.vmQueue.ShutDown()
@@ -177,6 +189,7 @@ func (ctrl *VMSnapshotController) Run(threadiness int, stopCh <-chan struct{}) e
 		stopCh,
 		ctrl.VMSnapshotInformer.HasSynced,
 		ctrl.VMSnapshotContentInformer.HasSynced,
+		ctrl.VMSnapshotScheduleInformer.HasSynced,
 		ctrl.VMInformer.HasSynced,
 		ctrl.VMIInformer.HasSynced,
 		ctrl.CRDInformer.HasSynced,
@@ -191,6 +204,7 @@ func (ctrl *VMSnapshotController) Run(threadiness int, stopCh <-chan struct{}) e
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(ctrl.vmSnapshotWorker, time.Second, stopCh)
 		go wait.Until(ctrl.vmSnapshotContentWorker, time.Second, stopCh)
+		go wait.Until(ctrl.vmSnapshotScheduleWorker, time.Second, stopCh)
 		go wait.Until(ctrl.crdWorker, time.Second, stopCh)
 		go wait.Until(ctrl.vmSnapshotStatusWorker, time.Second, stopCh)
 		go wait.Until(ctrl.vmWorker, time.Second, stopCh)
@@ -216,6 +230,11 @@ func (ctrl *VMSnapshotController) vmSnapshotContentWorker() {
 	}
 }
 
+func (ctrl *VMSnapshotController) vmSnapshotScheduleWorker() {
+	for ctrl.processVMSnapshotScheduleWorkItem() {
+	}
+}
+
 func (ctrl *VMSnapshotController) crdWorker() {
 	for ctrl.processCRDWorkItem() {
 	}
@@ -272,6 +291,24 @@ func (ctrl *VMSnapshotController) processVMSnapshotContentWorkItem() bool {
 	})
 }
 
+func (ctrl *VMSnapshotController) processVMSnapshotScheduleWorkItem() bool {
+	return processWorkItem(ctrl.vmSnapshotScheduleQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmSnapshotSchedule worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMSnapshotScheduleInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		schedule, ok := storeObj.(*snapshotv1.VirtualMachineSnapshotSchedule)
+		if !ok {
+			return 0, fmt.Errorf("unexpected resource %+v", storeObj)
+		}
+
+		return ctrl.updateVMSnapshotSchedule(schedule.DeepCopy())
+	})
+}
+
 func (ctrl *VMSnapshotController) processCRDWorkItem() bool {
 	return processWorkItem(ctrl.crdQueue, func(key string) (time.Duration, error) {
 		log.Log.V(3).Infof("CRD worker processing key [%s]", key)
@@ -384,6 +421,28 @@ func (ctrl *VMSnapshotController) handleVMSnapshot(obj interface{}) {
 		}
 		log.Log.V(3).Infof("enqueued %q for sync", objName)
 		ctrl.vmSnapshotQueue.Add(objName)
+
+		if scheduleName, ok := vmSnapshot.Labels[ScheduleLabel]; ok {
+			k := cacheKeyFunc(vmSnapshot.Namespace, scheduleName)
+			log.Log.V(5).Infof("enqueued vmsnapshotschedule %q for sync", k)
+			ctrl.vmSnapshotScheduleQueue.Add(k)
+		}
+	}
+}
+
+func (ctrl *VMSnapshotController) handleVMSnapshotSchedule(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if schedule, ok := obj.(*snapshotv1.VirtualMachineSnapshotSchedule); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(schedule)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, schedule)
+			return
+		}
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmSnapshotScheduleQueue.Add(objName)
 	}
 }
 
@@ -31,6 +31,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiv1alpha1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/cron"
 	"kubevirt.io/kubevirt/pkg/util/status"
 )
 
@@ -106,6 +107,20 @@ var _ = Describe("Snapshot controlleer", func() {
 		}
 	}
 
+	createRunningVMI := func(vm *v1.VirtualMachine, agentConnected bool) *v1.VirtualMachineInstance {
+		vmi := createVMI(vm)
+		vmi.Status.Phase = v1.Running
+		if agentConnected {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: corev1.ConditionTrue,
+				},
+			}
+		}
+		return vmi
+	}
+
 	createPersistentVolumeClaims := func() []corev1.PersistentVolumeClaim {
 		return createPVCsForVM(createLockedVM())
 	}
@@ -174,10 +189,13 @@ var _ = Describe("Snapshot controlleer", func() {
 
 		var ctrl *gomock.Controller
 		var vmInterface *kubecli.MockVirtualMachineInterface
+		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
 		var vmSnapshotSource *framework.FakeControllerSource
 		var vmSnapshotInformer cache.SharedIndexInformer
 		var vmSnapshotContentSource *framework.FakeControllerSource
 		var vmSnapshotContentInformer cache.SharedIndexInformer
+		var vmSnapshotScheduleSource *framework.FakeControllerSource
+		var vmSnapshotScheduleInformer cache.SharedIndexInformer
 		var vmInformer cache.SharedIndexInformer
 		var vmSource *framework.FakeControllerSource
 		var vmiInformer cache.SharedIndexInformer
@@ -201,6 +219,7 @@ var _ = Describe("Snapshot controlleer", func() {
 		var recorder *record.FakeRecorder
 		var mockVMSnapshotQueue *testutils.MockWorkQueue
 		var mockVMSnapshotContentQueue *testutils.MockWorkQueue
+		var mockVMSnapshotScheduleQueue *testutils.MockWorkQueue
 		var mockCRDQueue *testutils.MockWorkQueue
 		var mockVMQueue *testutils.MockWorkQueue
 
@@ -211,6 +230,7 @@ var _ = Describe("Snapshot controlleer", func() {
 		syncCaches := func(stop chan struct{}) {
 			go vmSnapshotInformer.Run(stop)
 			go vmSnapshotContentInformer.Run(stop)
+			go vmSnapshotScheduleInformer.Run(stop)
 			go vmInformer.Run(stop)
 			go storageClassInformer.Run(stop)
 			go pvcInformer.Run(stop)
@@ -222,6 +242,7 @@ var _ = Describe("Snapshot controlleer", func() {
 				stop,
 				vmSnapshotInformer.HasSynced,
 				vmSnapshotContentInformer.HasSynced,
+				vmSnapshotScheduleInformer.HasSynced,
 				vmInformer.HasSynced,
 				storageClassInformer.HasSynced,
 				pvcInformer.HasSynced,
@@ -237,6 +258,7 @@ var _ = Describe("Snapshot controlleer", func() {
 			ctrl = gomock.NewController(GinkgoT())
 			virtClient := kubecli.NewMockKubevirtClient(ctrl)
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 
 			vmSnapshotInformer, vmSnapshotSource = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, cache.Indexers{
 				"vm": func(obj interface{}) ([]string, error) {
@@ -261,6 +283,7 @@ var _ = Describe("Snapshot controlleer", func() {
 					return volumeSnapshots, nil
 				},
 			})
+			vmSnapshotScheduleInformer, vmSnapshotScheduleSource = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotSchedule{})
 			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
 			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 			podInformer, podSource = testutils.NewFakeInformerFor(&corev1.Pod{})
@@ -274,19 +297,20 @@ var _ = Describe("Snapshot controlleer", func() {
 			recorder = record.NewFakeRecorder(100)
 
 			controller = &VMSnapshotController{
-				Client:                    virtClient,
-				VMSnapshotInformer:        vmSnapshotInformer,
-				VMSnapshotContentInformer: vmSnapshotContentInformer,
-				VMInformer:                vmInformer,
-				VMIInformer:               vmiInformer,
-				PodInformer:               podInformer,
-				StorageClassInformer:      storageClassInformer,
-				PVCInformer:               pvcInformer,
-				CRDInformer:               crdInformer,
-				DVInformer:                dvInformer,
-				Recorder:                  recorder,
-				ResyncPeriod:              60 * time.Second,
-				vmStatusUpdater:           status.NewVMStatusUpdater(virtClient),
+				Client:                     virtClient,
+				VMSnapshotInformer:         vmSnapshotInformer,
+				VMSnapshotContentInformer:  vmSnapshotContentInformer,
+				VMSnapshotScheduleInformer: vmSnapshotScheduleInformer,
+				VMInformer:                 vmInformer,
+				VMIInformer:                vmiInformer,
+				PodInformer:                podInformer,
+				StorageClassInformer:       storageClassInformer,
+				PVCInformer:                pvcInformer,
+				CRDInformer:                crdInformer,
+				DVInformer:                 dvInformer,
+				Recorder:                   recorder,
+				ResyncPeriod:               60 * time.Second,
+				vmStatusUpdater:            status.NewVMStatusUpdater(virtClient),
 			}
 			controller.Init()
 
@@ -297,6 +321,9 @@ var _ = Describe("Snapshot controlleer", func() {
 			mockVMSnapshotContentQueue = testutils.NewMockWorkQueue(controller.vmSnapshotContentQueue)
 			controller.vmSnapshotContentQueue = mockVMSnapshotContentQueue
 
+			mockVMSnapshotScheduleQueue = testutils.NewMockWorkQueue(controller.vmSnapshotScheduleQueue)
+			controller.vmSnapshotScheduleQueue = mockVMSnapshotScheduleQueue
+
 			mockCRDQueue = testutils.NewMockWorkQueue(controller.crdQueue)
 			controller.crdQueue = mockCRDQueue
 
@@ -305,12 +332,15 @@ var _ = Describe("Snapshot controlleer", func() {
 
 			// Set up mock client
 			virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
 
 			vmSnapshotClient = kubevirtfake.NewSimpleClientset()
 			virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
 				Return(vmSnapshotClient.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
 			virtClient.EXPECT().VirtualMachineSnapshotContent(testNamespace).
 				Return(vmSnapshotClient.SnapshotV1alpha1().VirtualMachineSnapshotContents(testNamespace)).AnyTimes()
+			virtClient.EXPECT().VirtualMachineSnapshotSchedule(testNamespace).
+				Return(vmSnapshotClient.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(testNamespace)).AnyTimes()
 
 			k8sSnapshotClient = k8ssnapshotfake.NewSimpleClientset()
 			virtClient.EXPECT().KubernetesSnapshotClient().Return(k8sSnapshotClient).AnyTimes()
@@ -348,6 +378,13 @@ var _ = Describe("Snapshot controlleer", func() {
 			mockVMSnapshotContentQueue.Wait()
 		}
 
+		addVirtualMachineSnapshotSchedule := func(s *snapshotv1.VirtualMachineSnapshotSchedule) {
+			syncCaches(stop)
+			mockVMSnapshotScheduleQueue.ExpectAdds(1)
+			vmSnapshotScheduleSource.Add(s)
+			mockVMSnapshotScheduleQueue.Wait()
+		}
+
 		addVM := func(vm *v1.VirtualMachine) {
 			syncCaches(stop)
 			mockVMSnapshotQueue.ExpectAdds(1)
@@ -445,6 +482,7 @@ var _ = Describe("Snapshot controlleer", func() {
 				vmInterface.EXPECT().Update(updatedVM).Return(updatedVM, nil)
 				statusUpdate := updatedVM.DeepCopy()
 				statusUpdate.Status.SnapshotInProgress = nil
+				statusUpdate.Status.LastSnapshotTime = timeFunc()
 				vmInterface.EXPECT().UpdateStatus(statusUpdate).Return(statusUpdate, nil)
 				addVirtualMachineSnapshot(vmSnapshot)
 				controller.processVMSnapshotWorkItem()
@@ -457,6 +495,22 @@ var _ = Describe("Snapshot controlleer", func() {
 				statusUpdate := vm.DeepCopy()
 				statusUpdate.ResourceVersion = "1"
 				statusUpdate.Status.SnapshotInProgress = nil
+				statusUpdate.Status.LastSnapshotTime = timeFunc()
+				vmSource.Add(vm)
+				vmInterface.EXPECT().UpdateStatus(statusUpdate).Return(statusUpdate, nil)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
+			It("should keep a newer last snapshot time when unlocking source VirtualMachine", func() {
+				vmSnapshot := createVMSnapshotSuccess()
+				vm := createLockedVM()
+				vm.Finalizers = []string{}
+				newer := metav1.NewTime(timeStamp.Add(time.Hour))
+				vm.Status.LastSnapshotTime = &newer
+				statusUpdate := vm.DeepCopy()
+				statusUpdate.ResourceVersion = "1"
+				statusUpdate.Status.SnapshotInProgress = nil
 				vmSource.Add(vm)
 				vmInterface.EXPECT().UpdateStatus(statusUpdate).Return(statusUpdate, nil)
 				addVirtualMachineSnapshot(vmSnapshot)
@@ -611,6 +665,37 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			It("should lock running source with quiesce", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Spec.Quiesce = true
+				vm := createVM()
+				vm.Spec.Running = &t
+				vmStatusUpdate := vm.DeepCopy()
+				vmStatusUpdate.ResourceVersion = "1"
+				vmStatusUpdate.Status.SnapshotInProgress = &vmSnapshotName
+				vmUpdate := vmStatusUpdate.DeepCopy()
+				vmUpdate.Finalizers = []string{"snapshot.kubevirt.io/snapshot-source-protection"}
+
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmSource.Add(vm)
+				vmInterface.EXPECT().UpdateStatus(vmStatusUpdate).Return(vmStatusUpdate, nil)
+				vmInterface.EXPECT().Update(vmUpdate).Return(vmUpdate, nil)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
+			It("should not lock running source with quiesce if guest agent not connected", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Spec.Quiesce = true
+				vm := createVM()
+				vm.Spec.Running = &t
+
+				vmiSource.Add(createRunningVMI(vm, false))
+				vmSource.Add(vm)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should init VirtualMachineSnapshot", func() {
 				vmSnapshot := createVMSnapshotInProgress()
 				vmSnapshot.Finalizers = nil
@@ -673,6 +758,121 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			It("should freeze guest before creating VirtualMachineSnapshotContent", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Spec.Quiesce = true
+				vm := createLockedVM()
+				storageClass := createStorageClass()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmSource.Add(vm)
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				vmiInterface.EXPECT().Freeze(vmName).Return(nil)
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			})
+
+			It("should not create VirtualMachineSnapshotContent if guest freeze fails", func() {
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Spec.Quiesce = true
+				vm := createLockedVM()
+
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmSource.Add(vm)
+				vmiInterface.EXPECT().Freeze(vmName).Return(fmt.Errorf("freeze failed"))
+				vmSnapshotClient.Fake.PrependReactor("create", "virtualmachinesnapshotcontents", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					Expect(action).To(BeNil())
+					return true, nil, nil
+				})
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
+			It("should keep guest frozen until VolumeSnapshots are created", func() {
+				vmSnapshotContent := createVMSnapshotContent()
+				var statuses []snapshotv1.VolumeSnapshotStatus
+				for _, vb := range vmSnapshotContent.Spec.VolumeBackups {
+					statuses = append(statuses, snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: *vb.VolumeSnapshotName,
+					})
+				}
+				vmSnapshotContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse:           &f,
+					VolumeSnapshotStatus: statuses,
+				}
+				Expect(volumeSnapshotsCut(vmSnapshotContent)).To(BeFalse())
+
+				for i := range statuses {
+					statuses[i].CreationTime = timeFunc()
+				}
+				Expect(volumeSnapshotsCut(vmSnapshotContent)).To(BeTrue())
+			})
+
+			It("should thaw guest when VolumeSnapshots are created", func() {
+				vmSnapshotContent := createVMSnapshotContent()
+				var statuses []snapshotv1.VolumeSnapshotStatus
+				for _, vb := range vmSnapshotContent.Spec.VolumeBackups {
+					statuses = append(statuses, snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: *vb.VolumeSnapshotName,
+						CreationTime:       timeFunc(),
+						ReadyToUse:         &f,
+					})
+				}
+				vmSnapshotContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse:           &f,
+					VolumeSnapshotStatus: statuses,
+				}
+
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Spec.Quiesce = true
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status.SourceUID = &vmUID
+				updatedSnapshot.Status.VirtualMachineSnapshotContentName = &vmSnapshotContent.Name
+				updatedSnapshot.Status.Conditions = []snapshotv1.Condition{
+					newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"),
+					newReadyCondition(corev1.ConditionFalse, "Not ready"),
+				}
+
+				vm := createLockedVM()
+
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				vmiInterface.EXPECT().Unfreeze(vmName).Return(nil)
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
+			It("should thaw guest when unlocking source VirtualMachine", func() {
+				vmSnapshot := createVMSnapshotSuccess()
+				vmSnapshot.Spec.Quiesce = true
+				vm := createLockedVM()
+				updatedVM := vm.DeepCopy()
+				updatedVM.Finalizers = []string{}
+				updatedVM.ResourceVersion = "1"
+				vmiSource.Add(createRunningVMI(vm, true))
+				vmSource.Add(vm)
+				vmiInterface.EXPECT().Unfreeze(vmName).Return(nil)
+				vmInterface.EXPECT().Update(updatedVM).Return(updatedVM, nil)
+				statusUpdate := updatedVM.DeepCopy()
+				statusUpdate.Status.SnapshotInProgress = nil
+				statusUpdate.Status.LastSnapshotTime = timeFunc()
+				vmInterface.EXPECT().UpdateStatus(statusUpdate).Return(statusUpdate, nil)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should create VolumeSnapshot", func() {
 				storageClass := createStorageClass()
 				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
@@ -1361,6 +1561,247 @@ var _ = Describe("Snapshot controlleer", func() {
 			})
 		})
 
+		Context("with a VirtualMachineSnapshotSchedule", func() {
+			const scheduleName = "test-schedule"
+
+			var created time.Time
+
+			createSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+				return &snapshotv1.VirtualMachineSnapshotSchedule{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              scheduleName,
+						Namespace:         testNamespace,
+						CreationTimestamp: metav1.NewTime(created),
+					},
+					Spec: snapshotv1.VirtualMachineSnapshotScheduleSpec{
+						Source: corev1.TypedLocalObjectReference{
+							APIGroup: &vmAPIGroup,
+							Kind:     "VirtualMachine",
+							Name:     vmName,
+						},
+						Schedule: "0 * * * *",
+					},
+				}
+			}
+
+			createScheduledSnapshot := func(name string, creationTime time.Time, ready bool) *snapshotv1.VirtualMachineSnapshot {
+				vmSnapshot := createVirtualMachineSnapshot(testNamespace, name, vmName)
+				vmSnapshot.Labels = map[string]string{ScheduleLabel: scheduleName}
+				vmSnapshot.CreationTimestamp = metav1.NewTime(creationTime)
+				vmSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{
+					ReadyToUse: &ready,
+				}
+				return vmSnapshot
+			}
+
+			nextRun := func(spec string, from time.Time) time.Time {
+				parsed, err := cron.Parse(spec)
+				Expect(err).ToNot(HaveOccurred())
+				return parsed.Next(from)
+			}
+
+			BeforeEach(func() {
+				created = timeStamp.Add(-2 * time.Hour)
+			})
+
+			It("should create a VirtualMachineSnapshot when the schedule is due", func() {
+				schedule := createSchedule()
+				scheduled := nextRun(schedule.Spec.Schedule, created)
+				snapshotName := fmt.Sprintf("%s-%d", scheduleName, scheduled.Unix())
+				next := nextRun(schedule.Spec.Schedule, timeStamp.Time)
+
+				expectVMSnapshotCreate(vmSnapshotClient, &snapshotv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      snapshotName,
+						Namespace: testNamespace,
+						Labels:    map[string]string{ScheduleLabel: scheduleName},
+					},
+					Spec: snapshotv1.VirtualMachineSnapshotSpec{
+						Source: schedule.Spec.Source,
+					},
+				})
+				updatedSchedule := schedule.DeepCopy()
+				updatedSchedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					LastScheduleTime: &timeStamp,
+					NextScheduleTime: &metav1.Time{Time: next},
+					LastSnapshotName: &snapshotName,
+				}
+				expectVMSnapshotScheduleUpdate(vmSnapshotClient, updatedSchedule)
+
+				Expect(vmInformer.GetStore().Add(createVM())).To(Succeed())
+				requeue, err := controller.updateVMSnapshotSchedule(schedule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requeue).To(Equal(next.Sub(timeStamp.Time)))
+				testutils.ExpectEvent(recorder, scheduledSnapshotCreateEvent)
+			})
+
+			It("should create a quiesced VirtualMachineSnapshot of a running VM", func() {
+				schedule := createSchedule()
+				schedule.Spec.Quiesce = true
+				scheduled := nextRun(schedule.Spec.Schedule, created)
+				snapshotName := fmt.Sprintf("%s-%d", scheduleName, scheduled.Unix())
+				next := nextRun(schedule.Spec.Schedule, timeStamp.Time)
+
+				expectVMSnapshotCreate(vmSnapshotClient, &snapshotv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      snapshotName,
+						Namespace: testNamespace,
+						Labels:    map[string]string{ScheduleLabel: scheduleName},
+					},
+					Spec: snapshotv1.VirtualMachineSnapshotSpec{
+						Source:  schedule.Spec.Source,
+						Quiesce: true,
+					},
+				})
+				updatedSchedule := schedule.DeepCopy()
+				updatedSchedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					LastScheduleTime: &timeStamp,
+					NextScheduleTime: &metav1.Time{Time: next},
+					LastSnapshotName: &snapshotName,
+				}
+				expectVMSnapshotScheduleUpdate(vmSnapshotClient, updatedSchedule)
+
+				vm := createVM()
+				vm.Spec.Running = &t
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+				Expect(vmiInformer.GetStore().Add(createRunningVMI(vm, true))).To(Succeed())
+				_, err := controller.updateVMSnapshotSchedule(schedule)
+				Expect(err).ToNot(HaveOccurred())
+				testutils.ExpectEvent(recorder, scheduledSnapshotCreateEvent)
+			})
+
+			It("should skip the quiesced snapshot if the guest agent is not connected", func() {
+				schedule := createSchedule()
+				schedule.Spec.Quiesce = true
+
+				updatedSchedule := schedule.DeepCopy()
+				updatedSchedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					LastScheduleTime: &timeStamp,
+					NextScheduleTime: &metav1.Time{Time: nextRun(schedule.Spec.Schedule, timeStamp.Time)},
+					Error:            newError("Skipped scheduled snapshot: VirtualMachine testvm can't be quiesced, guest agent is not connected"),
+				}
+				expectVMSnapshotScheduleUpdate(vmSnapshotClient, updatedSchedule)
+
+				vm := createVM()
+				vm.Spec.Running = &t
+				Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+				Expect(vmiInformer.GetStore().Add(createRunningVMI(vm, false))).To(Succeed())
+				_, err := controller.updateVMSnapshotSchedule(schedule)
+				Expect(err).ToNot(HaveOccurred())
+				testutils.ExpectEvent(recorder, scheduledSnapshotSkippedEvent)
+			})
+
+			It("should only set the next run if the schedule is not due", func() {
+				created = timeStamp.Time
+				schedule := createSchedule()
+				next := nextRun(schedule.Spec.Schedule, created)
+
+				updatedSchedule := schedule.DeepCopy()
+				updatedSchedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					NextScheduleTime: &metav1.Time{Time: next},
+				}
+				expectVMSnapshotScheduleUpdate(vmSnapshotClient, updatedSchedule)
+
+				requeue, err := controller.updateVMSnapshotSchedule(schedule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requeue).To(Equal(next.Sub(timeStamp.Time)))
+			})
+
+			DescribeTable("should skip the snapshot", func(running bool, ready bool, inProgress bool, reason string) {
+				schedule := createSchedule()
+				vm := createVM()
+				vm.Spec.Running = &running
+				vm.Status.Created = ready
+				vm.Status.Ready = ready
+				Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+				if inProgress {
+					vmSnapshot := createScheduledSnapshot("in-progress", created, false)
+					Expect(vmSnapshotInformer.GetStore().Add(vmSnapshot)).To(Succeed())
+				}
+
+				updatedSchedule := schedule.DeepCopy()
+				updatedSchedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					LastScheduleTime: &timeStamp,
+					NextScheduleTime: &metav1.Time{Time: nextRun(schedule.Spec.Schedule, timeStamp.Time)},
+					Error:            newError("Skipped scheduled snapshot: " + reason),
+				}
+				expectVMSnapshotScheduleUpdate(vmSnapshotClient, updatedSchedule)
+
+				_, err := controller.updateVMSnapshotSchedule(schedule)
+				Expect(err).ToNot(HaveOccurred())
+				testutils.ExpectEvent(recorder, scheduledSnapshotSkippedEvent)
+			},
+				Entry("if the VM is running", true, true, false, "VirtualMachine testvm has run strategy Always, snapshots of running VMs require quiesce"),
+				Entry("if the VM is halted but its VMI still runs", false, true, false, "VirtualMachine testvm is still running"),
+				Entry("if a scheduled snapshot is in progress", false, false, true, "snapshot in-progress is still in progress"),
+			)
+
+			It("should report an invalid schedule", func() {
+				schedule := createSchedule()
+				schedule.Spec.Schedule = "0 * *"
+
+				updatedSchedule := schedule.DeepCopy()
+				updatedSchedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					Error: newError(`Invalid schedule: expected 5 fields in schedule "0 * *", found 3`),
+				}
+				expectVMSnapshotScheduleUpdate(vmSnapshotClient, updatedSchedule)
+
+				requeue, err := controller.updateVMSnapshotSchedule(schedule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requeue).To(BeZero())
+			})
+
+			It("should delete the ready snapshots exceeding the retention", func() {
+				created = timeStamp.Time
+				schedule := createSchedule()
+				schedule.Spec.Retention = &[]int32{2}[0]
+				schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					NextScheduleTime: &metav1.Time{Time: nextRun(schedule.Spec.Schedule, created)},
+				}
+
+				for i, ready := range []bool{false, true, true, true, true} {
+					vmSnapshot := createScheduledSnapshot(fmt.Sprintf("snapshot-%d", i), timeStamp.Add(-time.Duration(i)*time.Hour), ready)
+					Expect(vmSnapshotInformer.GetStore().Add(vmSnapshot)).To(Succeed())
+				}
+
+				var deleted []string
+				vmSnapshotClient.Fake.PrependReactor("delete", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					deleted = append(deleted, action.(testing.DeleteAction).GetName())
+					return true, nil, nil
+				})
+
+				_, err := controller.updateVMSnapshotSchedule(schedule)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deleted).To(ConsistOf("snapshot-3", "snapshot-4"))
+				testutils.ExpectEvent(recorder, scheduledSnapshotDeleteEvent)
+			})
+
+			It("should enqueue the schedule of a scheduled snapshot", func() {
+				mockVMSnapshotScheduleQueue.ExpectAdds(1)
+				addVirtualMachineSnapshot(createScheduledSnapshot("scheduled", created, false))
+				mockVMSnapshotScheduleQueue.Wait()
+				Expect(mockVMSnapshotScheduleQueue.Len()).To(Equal(1))
+			})
+
+			It("should process an added schedule", func() {
+				created = timeStamp.Time
+				schedule := createSchedule()
+
+				updatedSchedule := schedule.DeepCopy()
+				updatedSchedule.ResourceVersion = "1"
+				updatedSchedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+					NextScheduleTime: &metav1.Time{Time: nextRun(schedule.Spec.Schedule, created)},
+				}
+				expectVMSnapshotScheduleUpdate(vmSnapshotClient, updatedSchedule)
+
+				addVirtualMachineSnapshotSchedule(schedule)
+				controller.processVMSnapshotScheduleWorkItem()
+				Expect(mockVMSnapshotScheduleQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+			})
+		})
+
 		Context("without VolumeSnapshot and VolumeSnapshotClass informers", func() {
 			BeforeEach(func() {
 				controller.dynamicInformerMap[volumeSnapshotCRD].informerFunc = func(kubecli.KubevirtClient, time.Duration) cache.SharedIndexInformer {
@@ -1411,6 +1852,30 @@ func expectVMSnapshotUpdate(client *kubevirtfake.Clientset, vmSnapshot *snapshot
 	})
 }
 
+func expectVMSnapshotCreate(client *kubevirtfake.Clientset, vmSnapshot *snapshotv1.VirtualMachineSnapshot) {
+	client.Fake.PrependReactor("create", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+		create, ok := action.(testing.CreateAction)
+		Expect(ok).To(BeTrue())
+
+		createObj := create.GetObject().(*snapshotv1.VirtualMachineSnapshot)
+		Expect(createObj).To(Equal(vmSnapshot))
+
+		return true, create.GetObject(), nil
+	})
+}
+
+func expectVMSnapshotScheduleUpdate(client *kubevirtfake.Clientset, schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+	client.Fake.PrependReactor("update", "virtualmachinesnapshotschedules", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+		update, ok := action.(testing.UpdateAction)
+		Expect(ok).To(BeTrue())
+
+		updateObj := update.GetObject().(*snapshotv1.VirtualMachineSnapshotSchedule)
+		Expect(updateObj).To(Equal(schedule))
+
+		return true, update.GetObject(), nil
+	})
+}
+
 func expectVMSnapshotContentCreate(client *kubevirtfake.Clientset, content *snapshotv1.VirtualMachineSnapshotContent) {
 	client.Fake.PrependReactor("create", "virtualmachinesnapshotcontents", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
 		create, ok := action.(testing.CreateAction)
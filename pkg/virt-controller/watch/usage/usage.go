@@ -0,0 +1,320 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package usage
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+)
+
+const mebibyte = 1024 * 1024
+
+// run is a stretch of time a VMI was running with the given resources
+type run struct {
+	from            time.Time
+	to              time.Time
+	vcpus           int64
+	memoryMebibytes int64
+}
+
+func (ctrl *UsageController) updateUsage(vm *kubevirtv1.VirtualMachine) error {
+	logger := log.Log.Object(vm)
+
+	vmi, err := ctrl.getVMI(vm)
+	if err != nil {
+		return err
+	}
+
+	config := ctrl.ClusterConfig.GetUsageAccountingConfiguration()
+	interval := ctrl.ClusterConfig.GetUsageUpdateInterval()
+	// metav1.Time is serialized with second precision, accounting in whole
+	// seconds keeps the stored and the in-memory update time in sync
+	now := ctrl.now().UTC().Truncate(time.Second)
+	key := fmt.Sprintf("%s/%s", vm.Namespace, vm.Name)
+
+	if usage := vm.Status.Usage; usage != nil && !instanceChanged(usage.Instance, observeInstance(vmi, usage.Instance)) &&
+		now.Before(usage.Current.End.Time) && now.Sub(usage.LastUpdateTime.Time) < interval {
+		ctrl.vmQueue.AddAfter(key, nextUpdate(usage, interval, now))
+		return nil
+	}
+
+	vmCopy := vm.DeepCopy()
+	vmCopy.Status.Usage = accumulate(vm.Status.Usage, vmi, ctrl.storageBytes(vm), config.BillingPeriod, now)
+
+	logger.V(4).Infof("Updating the usage of the VirtualMachine")
+	if _, err := ctrl.Client.VirtualMachine(vm.Namespace).UpdateStatus(vmCopy); err != nil {
+		return err
+	}
+
+	ctrl.vmQueue.AddAfter(key, nextUpdate(vmCopy.Status.Usage, interval, now))
+	return nil
+}
+
+// getVMI returns the VMI of the VM or nil if it has none
+func (ctrl *UsageController) getVMI(vm *kubevirtv1.VirtualMachine) (*kubevirtv1.VirtualMachineInstance, error) {
+	obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	vmi := obj.(*kubevirtv1.VirtualMachineInstance)
+	if !metav1.IsControlledBy(vmi, vm) {
+		return nil, nil
+	}
+	return vmi, nil
+}
+
+// storageBytes sums up the capacity of the PVCs and DataVolumes of the VM and
+// of its hibernation claim, claims which are not bound yet do not count
+func (ctrl *UsageController) storageBytes(vm *kubevirtv1.VirtualMachine) int64 {
+	var claimNames []string
+	if vm.Spec.Template != nil {
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			switch {
+			case volume.PersistentVolumeClaim != nil:
+				claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+			case volume.DataVolume != nil:
+				claimNames = append(claimNames, volume.DataVolume.Name)
+			}
+		}
+	}
+	if vm.Status.Hibernation != nil && vm.Status.Hibernation.ClaimName != "" {
+		claimNames = append(claimNames, vm.Status.Hibernation.ClaimName)
+	}
+
+	var total int64
+	for _, claimName := range claimNames {
+		obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, claimName))
+		if err != nil || !exists {
+			continue
+		}
+		if capacity, ok := obj.(*corev1.PersistentVolumeClaim).Status.Capacity[corev1.ResourceStorage]; ok {
+			total += capacity.Value()
+		}
+	}
+	return total
+}
+
+// nextUpdate returns when the usage has to be accumulated again. Without a
+// running VMI only the end of the billing period changes the usage.
+func nextUpdate(usage *kubevirtv1.VirtualMachineUsage, interval time.Duration, now time.Time) time.Duration {
+	untilEnd := usage.Current.End.Sub(now)
+	if usage.Instance == nil || !usage.Instance.Running {
+		return untilEnd
+	}
+
+	untilInterval := usage.LastUpdateTime.Add(interval).Sub(now)
+	if untilEnd < untilInterval {
+		return untilEnd
+	}
+	return untilInterval
+}
+
+// instanceChanged reports VMIs which were started, stopped or replaced
+// since the last update
+func instanceChanged(stored, observed *kubevirtv1.VirtualMachineUsageInstance) bool {
+	if stored == nil || observed == nil {
+		return stored != observed
+	}
+	return stored.UID != observed.UID || stored.Running != observed.Running
+}
+
+// observeInstance returns the state of the VMI relevant for the usage. The
+// network counters of the stored instance are kept as long as virt-handler
+// did not report any, so that they are not counted twice once it does.
+func observeInstance(vmi *kubevirtv1.VirtualMachineInstance, stored *kubevirtv1.VirtualMachineUsageInstance) *kubevirtv1.VirtualMachineUsageInstance {
+	if vmi == nil {
+		return nil
+	}
+
+	instance := &kubevirtv1.VirtualMachineUsageInstance{
+		UID:             vmi.UID,
+		Running:         vmi.Status.Phase == kubevirtv1.Running,
+		VCPUs:           vcpus(vmi),
+		MemoryMebibytes: memoryMebibytes(vmi),
+	}
+	if vmi.Status.Usage != nil {
+		instance.NetworkReceiveBytes = vmi.Status.Usage.NetworkReceiveBytes
+		instance.NetworkTransmitBytes = vmi.Status.Usage.NetworkTransmitBytes
+	} else if stored != nil && stored.UID == vmi.UID {
+		instance.NetworkReceiveBytes = stored.NetworkReceiveBytes
+		instance.NetworkTransmitBytes = stored.NetworkTransmitBytes
+	}
+	return instance
+}
+
+// vcpus returns the vCPUs of the domain, which are derived from the CPU
+// resources like virt-launcher does if no topology is given
+func vcpus(vmi *kubevirtv1.VirtualMachineInstance) int64 {
+	if vmi.Spec.Domain.CPU != nil {
+		if n := hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU); n > 0 {
+			return n
+		}
+	}
+
+	resources := vmi.Spec.Domain.Resources
+	for _, list := range []corev1.ResourceList{resources.Limits, resources.Requests} {
+		if cpu, ok := list[corev1.ResourceCPU]; ok && !cpu.IsZero() {
+			return (cpu.MilliValue() + 999) / 1000
+		}
+	}
+	return 1
+}
+
+// memoryMebibytes returns the guest memory of the domain in MiB
+func memoryMebibytes(vmi *kubevirtv1.VirtualMachineInstance) int64 {
+	if memory := vmi.Spec.Domain.Memory; memory != nil && memory.Guest != nil {
+		return (memory.Guest.Value() + mebibyte - 1) / mebibyte
+	}
+
+	resources := vmi.Spec.Domain.Resources
+	for _, list := range []corev1.ResourceList{resources.Requests, resources.Limits} {
+		if memory, ok := list[corev1.ResourceMemory]; ok {
+			return (memory.Value() + mebibyte - 1) / mebibyte
+		}
+	}
+	return 0
+}
+
+// accumulate adds the usage since the last update and returns the new usage.
+// Time based usage is split at the end of the billing period, network
+// traffic counts for the period in which it was observed.
+func accumulate(usage *kubevirtv1.VirtualMachineUsage, vmi *kubevirtv1.VirtualMachineInstance, storageBytes int64, period kubevirtv1.BillingPeriod, now time.Time) *kubevirtv1.VirtualMachineUsage {
+	if usage == nil {
+		// nothing is known about the time before, start counting now
+		start, end := periodBounds(now, period)
+		usage = &kubevirtv1.VirtualMachineUsage{
+			Current: kubevirtv1.VirtualMachineUsagePeriod{
+				Start: metav1.NewTime(start),
+				End:   metav1.NewTime(end),
+			},
+			LastUpdateTime: metav1.NewTime(now),
+			Instance:       observeInstance(vmi, nil),
+		}
+	} else {
+		usage = usage.DeepCopy()
+	}
+
+	last := usage.LastUpdateTime.Time
+	stored := usage.Instance
+	observed := observeInstance(vmi, stored)
+	sameInstance := stored != nil && observed != nil && stored.UID == observed.UID
+
+	var started *time.Time
+	if vmi != nil && vmi.Status.StartupTimestamps != nil && vmi.Status.StartupTimestamps.DomainRunning != nil {
+		started = &vmi.Status.StartupTimestamps.DomainRunning.Time
+	}
+
+	var runs []*run
+	if stored != nil && stored.Running && !(sameInstance && observed.Running) {
+		// the instance stopped at some point since the last update, at the
+		// latest when its successor started
+		to := now
+		if observed != nil && observed.Running && !sameInstance && started != nil && started.After(last) && started.Before(now) {
+			to = *started
+		}
+		runs = append(runs, &run{from: last, to: to, vcpus: stored.VCPUs, memoryMebibytes: stored.MemoryMebibytes})
+	}
+	if observed != nil && observed.Running {
+		from := last
+		if !(sameInstance && stored.Running) && started != nil && started.After(last) {
+			from = *started
+		}
+		runs = append(runs, &run{from: from, to: now, vcpus: observed.VCPUs, memoryMebibytes: observed.MemoryMebibytes})
+	}
+
+	for !now.Before(usage.Current.End.Time) {
+		end := usage.Current.End.Time
+		charge(&usage.Current, runs, end)
+		closed := usage.Current
+		usage.Previous = &closed
+
+		_, next := periodBounds(end, period)
+		usage.Current = kubevirtv1.VirtualMachineUsagePeriod{
+			Start:        metav1.NewTime(end),
+			End:          metav1.NewTime(next),
+			StorageBytes: closed.StorageBytes,
+		}
+	}
+	charge(&usage.Current, runs, now)
+
+	if observed != nil {
+		rx, tx := observed.NetworkReceiveBytes, observed.NetworkTransmitBytes
+		if sameInstance {
+			// counters only go down if they were reset, e.g. by a migration
+			if rx >= stored.NetworkReceiveBytes {
+				rx -= stored.NetworkReceiveBytes
+			}
+			if tx >= stored.NetworkTransmitBytes {
+				tx -= stored.NetworkTransmitBytes
+			}
+		}
+		usage.Current.NetworkReceiveBytes += rx
+		usage.Current.NetworkTransmitBytes += tx
+	}
+
+	usage.Current.StorageBytes = storageBytes
+	usage.Instance = observed
+	usage.LastUpdateTime = metav1.NewTime(now)
+	return usage
+}
+
+// charge adds the runs up to the given time to the period and advances them
+func charge(period *kubevirtv1.VirtualMachineUsagePeriod, runs []*run, until time.Time) {
+	for _, r := range runs {
+		to := r.to
+		if until.Before(to) {
+			to = until
+		}
+		if !to.After(r.from) {
+			continue
+		}
+
+		seconds := int64(to.Sub(r.from) / time.Second)
+		period.VCPUSeconds += seconds * r.vcpus
+		period.MemoryMebibyteSeconds += seconds * r.memoryMebibytes
+		r.from = to
+	}
+}
+
+// periodBounds returns the billing period containing t, periods start at
+// midnight UTC and weeks on Monday
+func periodBounds(t time.Time, period kubevirtv1.BillingPeriod) (time.Time, time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case kubevirtv1.BillingPeriodDaily:
+		return day, day.AddDate(0, 0, 1)
+	case kubevirtv1.BillingPeriodWeekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	default:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package usage
+
+import (
+	"fmt"
+	"time"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// UsageController accumulates the resources consumed by VMs into their
+// status, for chargeback
+type UsageController struct {
+	Client kubecli.KubevirtClient
+
+	VMInformer  cache.SharedIndexInformer
+	VMIInformer cache.SharedIndexInformer
+	PVCInformer cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+
+	vmQueue workqueue.RateLimitingInterface
+	now     func() time.Time
+}
+
+// Init initializes the usage controller
+func (ctrl *UsageController) Init() {
+	ctrl.vmQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "usage-controller-vm")
+	ctrl.now = time.Now
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVM,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVM(newObj) },
+		},
+	)
+
+	ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *UsageController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmQueue.ShutDown()
+
+	log.Log.Info("Starting usage controller.")
+	defer log.Log.Info("Shutting down usage controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+		ctrl.PVCInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *UsageController) vmWorker() {
+	for ctrl.processVMWorkItem() {
+	}
+}
+
+func (ctrl *UsageController) processVMWorkItem() bool {
+	key, quit := ctrl.vmQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.vmQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachine %v", key)
+		ctrl.vmQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachine %v", key)
+		ctrl.vmQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *UsageController) execute(key string) error {
+	if !ctrl.ClusterConfig.UsageAccountingEnabled() {
+		return nil
+	}
+
+	storeObj, exists, err := ctrl.VMInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	vm, ok := storeObj.(*kubevirtv1.VirtualMachine)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateUsage(vm)
+}
+
+func (ctrl *UsageController) handleVM(obj interface{}) {
+	if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+		ctrl.enqueue(vm)
+	}
+}
+
+// handleVMI enqueues the VM of the VMI, which shares its name
+func (ctrl *UsageController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmi, ok := obj.(*kubevirtv1.VirtualMachineInstance); ok {
+		ctrl.enqueue(vmi)
+	}
+}
+
+func (ctrl *UsageController) enqueue(obj interface{}) {
+	objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Log.Errorf("failed to get key from object: %v, %v", err, obj)
+		return
+	}
+
+	log.Log.V(4).Infof("enqueued %q for sync", objName)
+	ctrl.vmQueue.Add(objName)
+}
@@ -0,0 +1,331 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package usage
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const testNamespace = "default"
+
+var _ = Describe("Usage accounting", func() {
+
+	var ctrl *gomock.Controller
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var pvcInformer cache.SharedIndexInformer
+	var controller *UsageController
+	var now time.Time
+	var updated *v1.VirtualMachine
+
+	setFeatureGates := func(gates ...string) {
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: gates},
+					UsageAccountingConfiguration: &v1.UsageAccountingConfiguration{
+						BillingPeriod: v1.BillingPeriodDaily,
+					},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		controller.ClusterConfig = config
+	}
+
+	newVM := func(usage *v1.VirtualMachineUsage) *v1.VirtualMachine {
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testvm",
+				Namespace: testNamespace,
+				UID:       "vm-uid",
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{},
+			},
+			Status: v1.VirtualMachineStatus{
+				Usage: usage,
+			},
+		}
+	}
+
+	newVMI := func(vm *v1.VirtualMachine, uid string, phase v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            vm.Name,
+				Namespace:       vm.Namespace,
+				UID:             types.UID("vmi-" + uid),
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)},
+			},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: phase,
+			},
+		}
+		vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+		guest := resource.MustParse("1Gi")
+		vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest}
+		return vmi
+	}
+
+	// usageAt returns a usage of the day of now, last updated at the given time
+	usageAt := func(last time.Time, instance *v1.VirtualMachineUsageInstance) *v1.VirtualMachineUsage {
+		start, end := periodBounds(last, v1.BillingPeriodDaily)
+		return &v1.VirtualMachineUsage{
+			Current: v1.VirtualMachineUsagePeriod{
+				Start: metav1.NewTime(start),
+				End:   metav1.NewTime(end),
+			},
+			LastUpdateTime: metav1.NewTime(last),
+			Instance:       instance,
+		}
+	}
+
+	runningInstance := func(uid string) *v1.VirtualMachineUsageInstance {
+		return &v1.VirtualMachineUsageInstance{
+			UID:             types.UID("vmi-" + uid),
+			Running:         true,
+			VCPUs:           2,
+			MemoryMebibytes: 1024,
+		}
+	}
+
+	process := func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) error {
+		Expect(vmInformer.GetStore().Add(vm)).To(Succeed())
+		if vmi != nil {
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+		}
+		return controller.execute(testNamespace + "/" + vm.Name)
+	}
+
+	expectUpdate := func() {
+		vmInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+			updated = vm
+			return vm, nil
+		})
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		pvcInformer, _ = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
+
+		controller = &UsageController{
+			Client:      virtClient,
+			VMInformer:  vmInformer,
+			VMIInformer: vmiInformer,
+			PVCInformer: pvcInformer,
+		}
+		controller.Init()
+		setFeatureGates(virtconfig.UsageAccountingGate)
+
+		now = time.Date(2021, time.March, 10, 12, 0, 0, 0, time.UTC)
+		controller.now = func() time.Time { return now }
+		updated = nil
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should not account anything without the feature gate", func() {
+		setFeatureGates()
+		Expect(process(newVM(nil), nil)).To(Succeed())
+	})
+
+	It("should start the accounting with an empty billing period", func() {
+		vm := newVM(nil)
+		expectUpdate()
+		Expect(process(vm, newVMI(vm, "a", v1.Running))).To(Succeed())
+
+		usage := updated.Status.Usage
+		Expect(usage).ToNot(BeNil())
+		Expect(usage.Current.Start.Time).To(Equal(time.Date(2021, time.March, 10, 0, 0, 0, 0, time.UTC)))
+		Expect(usage.Current.End.Time).To(Equal(time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)))
+		Expect(usage.Current.VCPUSeconds).To(BeZero())
+		Expect(usage.LastUpdateTime.Time).To(Equal(now))
+		Expect(usage.Instance).To(Equal(runningInstance("a")))
+	})
+
+	It("should account the resources of a running VMI", func() {
+		instance := runningInstance("a")
+		instance.NetworkReceiveBytes = 1000
+		instance.NetworkTransmitBytes = 500
+		vm := newVM(usageAt(now.Add(-10*time.Minute), instance))
+		vmi := newVMI(vm, "a", v1.Running)
+		vmi.Status.Usage = &v1.VirtualMachineInstanceUsage{NetworkReceiveBytes: 3000, NetworkTransmitBytes: 800}
+
+		expectUpdate()
+		Expect(process(vm, vmi)).To(Succeed())
+
+		current := updated.Status.Usage.Current
+		Expect(current.VCPUSeconds).To(Equal(int64(2 * 600)))
+		Expect(current.MemoryMebibyteSeconds).To(Equal(int64(1024 * 600)))
+		Expect(current.NetworkReceiveBytes).To(Equal(int64(2000)))
+		Expect(current.NetworkTransmitBytes).To(Equal(int64(300)))
+		Expect(updated.Status.Usage.Instance.NetworkReceiveBytes).To(Equal(int64(3000)))
+	})
+
+	It("should not update the usage before the interval passed", func() {
+		vm := newVM(usageAt(now.Add(-time.Minute), runningInstance("a")))
+		Expect(process(vm, newVMI(vm, "a", v1.Running))).To(Succeed())
+	})
+
+	It("should account a stopped VMI up to now", func() {
+		vm := newVM(usageAt(now.Add(-time.Minute), runningInstance("a")))
+
+		expectUpdate()
+		Expect(process(vm, newVMI(vm, "a", v1.Succeeded))).To(Succeed())
+
+		Expect(updated.Status.Usage.Current.VCPUSeconds).To(Equal(int64(2 * 60)))
+		Expect(updated.Status.Usage.Instance.Running).To(BeFalse())
+	})
+
+	It("should account a new VMI from the time its domain started running", func() {
+		vm := newVM(usageAt(now.Add(-time.Hour), nil))
+		vmi := newVMI(vm, "b", v1.Running)
+		vmi.Status.StartupTimestamps = &v1.VirtualMachineInstanceStartupTimestamps{
+			DomainRunning: &metav1.Time{Time: now.Add(-time.Minute)},
+		}
+
+		expectUpdate()
+		Expect(process(vm, vmi)).To(Succeed())
+
+		Expect(updated.Status.Usage.Current.VCPUSeconds).To(Equal(int64(2 * 60)))
+	})
+
+	It("should count reset network counters as they are", func() {
+		instance := runningInstance("a")
+		instance.NetworkReceiveBytes = 1000
+		vm := newVM(usageAt(now.Add(-10*time.Minute), instance))
+		vmi := newVMI(vm, "a", v1.Running)
+		vmi.Status.Usage = &v1.VirtualMachineInstanceUsage{NetworkReceiveBytes: 100}
+
+		expectUpdate()
+		Expect(process(vm, vmi)).To(Succeed())
+
+		Expect(updated.Status.Usage.Current.NetworkReceiveBytes).To(Equal(int64(100)))
+	})
+
+	It("should split the usage at the end of the billing period", func() {
+		now = time.Date(2021, time.March, 11, 0, 5, 0, 0, time.UTC)
+		vm := newVM(usageAt(now.Add(-10*time.Minute), runningInstance("a")))
+		vm.Status.Usage.Current.VCPUSeconds = 1000
+
+		expectUpdate()
+		Expect(process(vm, newVMI(vm, "a", v1.Running))).To(Succeed())
+
+		usage := updated.Status.Usage
+		Expect(usage.Previous).ToNot(BeNil())
+		Expect(usage.Previous.End.Time).To(Equal(time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)))
+		Expect(usage.Previous.VCPUSeconds).To(Equal(int64(1000 + 2*300)))
+		Expect(usage.Current.Start.Time).To(Equal(time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)))
+		Expect(usage.Current.VCPUSeconds).To(Equal(int64(2 * 300)))
+	})
+
+	It("should sum up the capacity of the claims of the VM", func() {
+		vm := newVM(usageAt(now.Add(-time.Hour), nil))
+		vm.Spec.Template.Spec.Volumes = []v1.Volume{
+			{Name: "pvc", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "disk0"}}},
+			{Name: "dv", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "disk1"}}},
+		}
+		for _, name := range []string{"disk0", "disk1"} {
+			Expect(pvcInformer.GetStore().Add(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+				Status: corev1.PersistentVolumeClaimStatus{
+					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			})).To(Succeed())
+		}
+
+		expectUpdate()
+		Expect(process(vm, nil)).To(Succeed())
+
+		Expect(updated.Status.Usage.Current.StorageBytes).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+	})
+
+	It("should count the hibernation claim of the VM", func() {
+		vm := newVM(usageAt(now.Add(-time.Hour), nil))
+		vm.Status.Hibernation = &v1.VirtualMachineHibernationStatus{ClaimName: "testvm-hibernation"}
+		Expect(pvcInformer.GetStore().Add(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "testvm-hibernation", Namespace: testNamespace},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")},
+			},
+		})).To(Succeed())
+
+		expectUpdate()
+		Expect(process(vm, nil)).To(Succeed())
+
+		Expect(updated.Status.Usage.Current.StorageBytes).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+	})
+
+	table.DescribeTable("should derive the vCPUs", func(cpu *v1.CPU, resources v1.ResourceRequirements, expected int64) {
+		vmi := &v1.VirtualMachineInstance{}
+		vmi.Spec.Domain.CPU = cpu
+		vmi.Spec.Domain.Resources = resources
+		Expect(vcpus(vmi)).To(Equal(expected))
+	},
+		table.Entry("from the topology", &v1.CPU{Sockets: 2, Cores: 2}, v1.ResourceRequirements{}, int64(4)),
+		table.Entry("from the CPU limits rounded up", nil, v1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+		}, int64(2)),
+		table.Entry("as one without any CPU settings", nil, v1.ResourceRequirements{}, int64(1)),
+	)
+
+	table.DescribeTable("should compute the billing period", func(period v1.BillingPeriod, start, end time.Time) {
+		s, e := periodBounds(time.Date(2021, time.March, 10, 12, 0, 0, 0, time.UTC), period)
+		Expect(s).To(Equal(start))
+		Expect(e).To(Equal(end))
+	},
+		table.Entry("for days", v1.BillingPeriodDaily,
+			time.Date(2021, time.March, 10, 0, 0, 0, 0, time.UTC), time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)),
+		table.Entry("for weeks starting on Monday", v1.BillingPeriodWeekly,
+			time.Date(2021, time.March, 8, 0, 0, 0, 0, time.UTC), time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC)),
+		table.Entry("for months", v1.BillingPeriodMonthly,
+			time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)),
+	)
+})
@@ -29,6 +29,7 @@ import (
 	authv1 "k8s.io/api/authorization/v1"
 	k8score "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -45,12 +46,32 @@ import (
 	"kubevirt.io/kubevirt/pkg/util/status"
 )
 
+const (
+	// SuccessfulCreateHibernationClaimReason is added in an event when the claim holding
+	// the memory of a hibernated virtual machine is created.
+	SuccessfulCreateHibernationClaimReason = "SuccessfulCreateHibernationClaim"
+	// FailedCreateHibernationClaimReason is added in an event when the claim holding
+	// the memory of a hibernated virtual machine could not be created.
+	FailedCreateHibernationClaimReason = "FailedCreateHibernationClaim"
+	// SuccessfulHibernateVirtualMachineReason is added in an event when the memory of the
+	// virtual machine instance was saved to the hibernation claim.
+	SuccessfulHibernateVirtualMachineReason = "SuccessfulHibernate"
+	// FailedHibernateVirtualMachineReason is added in an event when the memory of the
+	// virtual machine instance could not be saved. The virtual machine instance is stopped anyway.
+	FailedHibernateVirtualMachineReason = "FailedHibernate"
+)
+
+// hibernationClaimOverhead is added to the guest memory for the device state
+// which is saved along with it
+var hibernationClaimOverhead = resource.MustParse("128Mi")
+
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
 func NewVMController(vmiInformer cache.SharedIndexInformer,
 	vmiVMInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
 	pvcInformer cache.SharedIndexInformer,
+	storageClassInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient) *VMController {
 
@@ -62,6 +83,7 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		vmiVMInformer:          vmiVMInformer,
 		dataVolumeInformer:     dataVolumeInformer,
 		pvcInformer:            pvcInformer,
+		storageClassInformer:   storageClassInformer,
 		recorder:               recorder,
 		clientset:              clientset,
 		expectations:           controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
@@ -90,6 +112,11 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.pvcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addPVC,
+		DeleteFunc: c.deletePVC,
+	})
+
 	return c
 }
 
@@ -108,6 +135,7 @@ type VMController struct {
 	vmiVMInformer          cache.SharedIndexInformer
 	dataVolumeInformer     cache.SharedIndexInformer
 	pvcInformer            cache.SharedIndexInformer
+	storageClassInformer   cache.SharedIndexInformer
 	recorder               record.EventRecorder
 	expectations           *controller.UIDTrackingControllerExpectations
 	dataVolumeExpectations *controller.UIDTrackingControllerExpectations
@@ -121,7 +149,7 @@ func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting VirtualMachine controller.")
 
 	// Wait for cache sync before we start the controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiVMInformer.HasSynced, c.dataVolumeInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiVMInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.pvcInformer.HasSynced, c.storageClassInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -255,6 +283,8 @@ func (c *VMController) execute(key string) error {
 		dataVolumesReady, err := c.handleDataVolumes(vm, dataVolumes)
 		if err != nil {
 			createErr = err
+		} else if err = c.handleHibernationClaim(vm); err != nil {
+			createErr = err
 		} else if dataVolumesReady == true {
 			createErr = c.startStop(vm, vmi)
 		} else {
@@ -284,7 +314,7 @@ func (c *VMController) execute(key string) error {
 		logger.Reason(err).Error("Creating the VirtualMachine failed.")
 	}
 
-	err = c.updateStatus(vm, vmi, createErr)
+	err = c.updateStatus(vm, vmi, dataVolumes, createErr)
 	if err != nil {
 		logger.Reason(err).Error("Updating the VirtualMachine status failed.")
 		return err
@@ -456,33 +486,45 @@ func createDataVolumeManifest(dataVolumeTemplate *virtv1.DataVolumeTemplateSpec,
 }
 
 func (c *VMController) authorizeDataVolume(vm *virtv1.VirtualMachine, dataVolume *cdiv1.DataVolume) error {
-	if dataVolume.Spec.Source.PVC == nil {
+	return authorizeDataVolumeClone(c.cloneAuthFunc, vm.Namespace, &vm.Spec.Template.Spec, &dataVolume.Spec)
+}
+
+// csiClonedPVC returns the PVC the DataVolume template was provisioned with by
+// a CSI clone, if any
+func (c *VMController) csiClonedPVC(vm *virtv1.VirtualMachine, name string) *k8score.PersistentVolumeClaim {
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, name))
+	if err != nil || !exists {
 		return nil
 	}
-
-	pvcNamespace := dataVolume.Spec.Source.PVC.Namespace
-	if pvcNamespace == "" {
-		pvcNamespace = vm.Namespace
+	pvc := obj.(*k8score.PersistentVolumeClaim)
+	if !v1.IsControlledBy(pvc, vm) {
+		return nil
 	}
+	return pvc
+}
 
-	pvcName := dataVolume.Spec.Source.PVC.Name
-
-	serviceAccount := "default"
-	for _, vol := range vm.Spec.Template.Spec.Volumes {
-		if vol.ServiceAccount != nil {
-			serviceAccount = vol.ServiceAccount.ServiceAccountName
-		}
+// createCSIClone creates the PVC of a DataVolume template as CSI clone of the
+// source PVC, instead of a DataVolume
+func (c *VMController) createCSIClone(vm *virtv1.VirtualMachine, template *virtv1.DataVolumeTemplateSpec, source *k8score.PersistentVolumeClaim) error {
+	pvc := newCSIClonePVC(template.Name, vm.Namespace, template, source)
+	labels := map[string]string{}
+	for k, v := range template.Labels {
+		labels[k] = v
 	}
-
-	allowed, reason, err := c.cloneAuthFunc(pvcNamespace, pvcName, vm.Namespace, serviceAccount)
-	if err != nil {
-		return err
+	labels[virtv1.CreatedByLabel] = string(vm.UID)
+	pvc.Labels = labels
+	pvc.OwnerReferences = []v1.OwnerReference{
+		*v1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
 	}
 
-	if !allowed {
-		return fmt.Errorf(reason)
+	_, err := c.clientset.CoreV1().PersistentVolumeClaims(vm.Namespace).Create(pvc)
+	if errors.IsAlreadyExists(err) {
+		return nil
+	} else if err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCSICloneReason, "Error creating PVC %s as CSI clone of %s: %v", pvc.Name, source.Name, err)
+		return fmt.Errorf("Failed to create PVC as CSI clone: %v", err)
 	}
-
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulCSICloneReason, "Created PVC %s as CSI clone of %s", pvc.Name, source.Name)
 	return nil
 }
 
@@ -502,6 +544,10 @@ func (c *VMController) handleDataVolumes(vm *virtv1.VirtualMachine, dataVolumes
 			}
 		}
 		if !exists {
+			if c.csiClonedPVC(vm, template.Name) != nil {
+				// the CSI driver provisions the PVC, the pod waits until it is bound
+				continue
+			}
 			// ready = false because encountered DataVolume that is not created yet
 			ready = false
 			newDataVolume := createDataVolumeManifest(&template, vm)
@@ -511,6 +557,14 @@ func (c *VMController) handleDataVolumes(vm *virtv1.VirtualMachine, dataVolumes
 				return ready, fmt.Errorf("Not authorized to create DataVolume: %v", err)
 			}
 
+			// clone with the CSI driver if it can, CDI falls back to a smart or host-assisted clone
+			if source := csiCloneSource(&newDataVolume.Spec, vm.Namespace, c.pvcInformer.GetStore(), c.storageClassInformer.GetStore()); source != nil {
+				if err = c.createCSIClone(vm, &template, source); err != nil {
+					return ready, err
+				}
+				continue
+			}
+
 			c.dataVolumeExpectations.ExpectCreations(vmKey, 1)
 			curDataVolume, err = c.clientset.CdiClient().CdiV1alpha1().DataVolumes(vm.Namespace).Create(newDataVolume)
 			if err != nil {
@@ -585,6 +639,16 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 	}
 	log.Log.Object(vm).V(4).Infof("VirtualMachine RunStrategy: %s", runStrategy)
 
+	// A VMI which is hibernated is kept until its memory is saved, regardless of the RunStrategy
+	if vmi != nil && len(vm.Status.StateChangeRequests) != 0 {
+		stateChange := vm.Status.StateChangeRequests[0]
+		if stateChange.Action == virtv1.HibernateRequest &&
+			stateChange.UID != nil &&
+			*stateChange.UID == vmi.UID {
+			return c.hibernateVMI(vm, vmi)
+		}
+	}
+
 	switch runStrategy {
 	case virtv1.RunStrategyAlways:
 		// For this RunStrategy, a VMI should always be running. If a StateChangeRequest
@@ -718,6 +782,118 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 	}
 }
 
+// hibernateVMI asks virt-handler to save the memory of the VMI to the hibernation claim.
+// The VMI is stopped once it shut off or if saving its memory failed.
+func (c *VMController) hibernateVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi.IsFinal() {
+		log.Log.Object(vm).V(4).Info("VMI is hibernated, stopping it")
+		return c.stopVMI(vm, vmi)
+	}
+
+	cond := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, virtv1.VirtualMachineInstanceHibernated)
+	if cond != nil && cond.Status == k8score.ConditionFalse {
+		if vmi.DeletionTimestamp == nil {
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedHibernateVirtualMachineReason, "Failed to hibernate the virtual machine instance, stopping it: %s", cond.Message)
+		}
+		return c.stopVMI(vm, vmi)
+	}
+
+	if _, requested := vmi.Annotations[virtv1.HibernationRequestedAnnotation]; requested {
+		return nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"true"}}}`, virtv1.HibernationRequestedAnnotation)
+	_, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.MergePatchType, []byte(patch))
+	if err != nil {
+		return err
+	}
+	log.Log.Object(vm).Info("Requested the hibernation of the VMI")
+	return nil
+}
+
+// hibernationClaimName returns the name of the PersistentVolumeClaim the memory of a VM is saved to
+func hibernationClaimName(vm *virtv1.VirtualMachine) string {
+	return vm.Name + "-hibernation"
+}
+
+// hibernationClaimSize returns the size of the hibernation claim of a VM, which is
+// the guest memory plus some room for the device state
+func hibernationClaimSize(vm *virtv1.VirtualMachine) resource.Quantity {
+	var size resource.Quantity
+	domain := vm.Spec.Template.Spec.Domain
+	if domain.Memory != nil && domain.Memory.Guest != nil {
+		size = domain.Memory.Guest.DeepCopy()
+	} else if memory, ok := domain.Resources.Requests[k8score.ResourceMemory]; ok {
+		size = memory.DeepCopy()
+	} else if memory, ok := domain.Resources.Limits[k8score.ResourceMemory]; ok {
+		size = memory.DeepCopy()
+	}
+	size.Add(hibernationClaimOverhead)
+	return size
+}
+
+// handleHibernationClaim creates the PersistentVolumeClaim the memory of the VM is saved to
+// on hibernation. The claim and the memory saved on it are deleted once hibernation is no
+// longer configured.
+func (c *VMController) handleHibernationClaim(vm *virtv1.VirtualMachine) error {
+	claimName := hibernationClaimName(vm)
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, claimName))
+	if err != nil {
+		return err
+	}
+
+	if vm.Spec.Hibernation == nil {
+		if !exists {
+			return nil
+		}
+		claim := obj.(*k8score.PersistentVolumeClaim)
+		if !v1.IsControlledBy(claim, vm) || claim.DeletionTimestamp != nil {
+			return nil
+		}
+		err := c.clientset.CoreV1().PersistentVolumeClaims(vm.Namespace).Delete(claimName, &v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.Log.Object(vm).Infof("Deleted the hibernation claim %s", claimName)
+		return nil
+	}
+
+	if exists {
+		if !v1.IsControlledBy(obj.(*k8score.PersistentVolumeClaim), vm) {
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateHibernationClaimReason, "Hibernation claim %s already exists and is not owned by the VirtualMachine", claimName)
+			return fmt.Errorf("the hibernation claim %s is not owned by the VirtualMachine", claimName)
+		}
+		return nil
+	}
+
+	claim := &k8score.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      claimName,
+			Namespace: vm.Namespace,
+			Labels:    map[string]string{virtv1.VirtualMachineLabel: vm.Name},
+			OwnerReferences: []v1.OwnerReference{
+				*v1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
+			},
+		},
+		Spec: k8score.PersistentVolumeClaimSpec{
+			AccessModes: []k8score.PersistentVolumeAccessMode{k8score.ReadWriteOnce},
+			Resources: k8score.ResourceRequirements{
+				Requests: k8score.ResourceList{
+					k8score.ResourceStorage: hibernationClaimSize(vm),
+				},
+			},
+			StorageClassName: vm.Spec.Hibernation.StorageClassName,
+		},
+	}
+	// an AlreadyExists error is retried, the owner is checked once the
+	// cache caught up
+	if _, err := c.clientset.CoreV1().PersistentVolumeClaims(vm.Namespace).Create(claim); err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateHibernationClaimReason, "Error creating hibernation claim %s: %v", claimName, err)
+		return err
+	}
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulCreateHibernationClaimReason, "Created hibernation claim %s", claimName)
+	return nil
+}
+
 func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 	// TODO add check for existence
 	vmKey, err := controller.KeyFunc(vm)
@@ -782,7 +958,17 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 	vmi.ObjectMeta.Namespace = vm.ObjectMeta.Namespace
 	vmi.Spec = vm.Spec.Template.Spec
 
-	setupStableFirmwareUUID(vm, vmi)
+	setupStableFirmwareIdentity(vm, vmi)
+
+	if vm.Spec.Hibernation != nil {
+		// the annotations are shared with the template of the VM
+		annotations := map[string]string{}
+		for key, value := range vmi.ObjectMeta.Annotations {
+			annotations[key] = value
+		}
+		annotations[virtv1.HibernationClaimAnnotation] = hibernationClaimName(vm)
+		vmi.ObjectMeta.Annotations = annotations
+	}
 
 	// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 	vmi.ObjectMeta.Labels = vm.Spec.Template.ObjectMeta.Labels
@@ -799,23 +985,38 @@ const magicUUID = "6a1a24a1-4061-4607-8bf4-a3963d0c5895"
 
 var firmwareUUIDns = uuid.Parse(magicUUID)
 
-// setStableUUID makes sure the VirtualMachineInstance being started has a a 'stable' UUID.
-// The UUID is 'stable' if doesn't change across reboots.
-func setupStableFirmwareUUID(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+// setupStableFirmwareIdentity makes sure the VirtualMachineInstance being started has a 'stable' UUID.
+// The UUID is 'stable' if doesn't change across reboots. With the RegenerateOnClone identity policy,
+// the UUID and the serial are derived from the uid of the VirtualMachine instead of its name, which
+// a restore keeps, while every copy of the VirtualMachine gets a new one.
+func setupStableFirmwareIdentity(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
 
 	logger := log.Log.Object(vm)
 
 	if vmi.Spec.Domain.Firmware == nil {
 		vmi.Spec.Domain.Firmware = &virtv1.Firmware{}
+	} else {
+		// the firmware is shared with the template of the VM
+		vmi.Spec.Domain.Firmware = vmi.Spec.Domain.Firmware.DeepCopy()
+	}
+	firmware := vmi.Spec.Domain.Firmware
+	regenerateOnClone := firmware.IdentityPolicy == virtv1.FirmwareIdentityPolicyRegenerateOnClone
+
+	if regenerateOnClone && firmware.Serial == "" {
+		firmware.Serial = string(vm.UID)
 	}
 
-	existingUUID := vmi.Spec.Domain.Firmware.UUID
+	existingUUID := firmware.UUID
 	if existingUUID != "" {
 		logger.V(4).Infof("Using existing UUID '%s'", existingUUID)
 		return
 	}
 
-	vmi.Spec.Domain.Firmware.UUID = types.UID(uuid.NewSHA1(firmwareUUIDns, []byte(vmi.ObjectMeta.Name)).String())
+	if regenerateOnClone {
+		firmware.UUID = types.UID(uuid.NewSHA1(firmwareUUIDns, []byte(vm.UID)).String())
+		return
+	}
+	firmware.UUID = types.UID(uuid.NewSHA1(firmwareUUIDns, []byte(vmi.ObjectMeta.Name)).String())
 }
 
 // filterActiveVMIs takes a list of VMIs and returns all VMIs which are not in a final state
@@ -1133,6 +1334,32 @@ func (c *VMController) deleteDataVolume(obj interface{}) {
 	c.enqueueVm(vm)
 }
 
+func (c *VMController) addPVC(obj interface{}) {
+	c.enqueuePVCOwner(obj)
+}
+
+func (c *VMController) deletePVC(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	c.enqueuePVCOwner(obj)
+}
+
+// enqueuePVCOwner wakes up the VM which created a PVC as CSI clone
+func (c *VMController) enqueuePVCOwner(obj interface{}) {
+	pvc, ok := obj.(*k8score.PersistentVolumeClaim)
+	if !ok {
+		return
+	}
+	controllerRef := v1.GetControllerOf(pvc)
+	if controllerRef == nil {
+		return
+	}
+	if vm := c.resolveControllerRef(pvc.Namespace, controllerRef); vm != nil {
+		c.enqueueVm(vm)
+	}
+}
+
 func (c *VMController) addVm(obj interface{}) {
 	c.enqueueVm(obj)
 }
@@ -1155,7 +1382,7 @@ func (c *VMController) enqueueVm(obj interface{}) {
 	c.Queue.Add(key)
 }
 
-func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, createErr error) error {
+func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume, createErr error) error {
 	vm := vmOrig.DeepCopy()
 
 	created := vmi != nil
@@ -1167,6 +1394,12 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 	}
 	vm.Status.Ready = ready
 
+	fsFreezeStatus := ""
+	if created {
+		fsFreezeStatus = vmi.Status.FSFreezeStatus
+	}
+	vm.Status.FSFreezeStatus = fsFreezeStatus
+
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
 		log.Log.Object(vm).Errorf("Error getting RunStrategy: %v", err)
@@ -1178,7 +1411,7 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		// requests have not been acted upon by this controller yet!
 		stateChange := vm.Status.StateChangeRequests[0]
 		switch stateChange.Action {
-		case virtv1.StopRequest:
+		case virtv1.StopRequest, virtv1.HibernateRequest:
 			if vmi == nil {
 				// because either the VM or VMI informers can trigger processing here
 				// double check the state of the cluster before taking action
@@ -1284,7 +1517,11 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		vm.Status.StateChangeRequests = vm.Status.StateChangeRequests[1:]
 	}
 
-	c.syncReadyConditionFromVMI(vm, vmi)
+	for _, condType := range vmiConditionsForVM {
+		syncConditionFromVMI(vm, vmi, condType)
+	}
+	c.syncDataVolumesCondition(vm, dataVolumes)
+	c.updateHibernationStatus(vm, vmi)
 
 	// Add/Remove Failure condition if necessary
 	vmCondManager := controller.NewVirtualMachineConditionManager()
@@ -1313,6 +1550,8 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		vmCondManager.RemoveCondition(vm, virtv1.VirtualMachinePaused)
 	}
 
+	vm.Status.PrintableStatus = printableStatus(vm, vmi)
+
 	// only update if necessary
 	err = nil
 	if !reflect.DeepEqual(vm.Status, vmOrig.Status) {
@@ -1322,23 +1561,209 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 	return err
 }
 
-func (c *VMController) syncReadyConditionFromVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
-	vmReadyCond := controller.NewVirtualMachineConditionManager().
-		GetCondition(vm, virtv1.VirtualMachineReady)
-	vmiReadyCond := controller.NewVirtualMachineInstanceConditionManager().
-		GetCondition(vmi, virtv1.VirtualMachineInstanceConditionType(k8score.PodReady))
+// vmiConditionsForVM are the conditions of the vmi which are copied to the vm, so that the reason
+// why the vm is not running can be seen without looking at the vmi
+var vmiConditionsForVM = []virtv1.VirtualMachineInstanceConditionType{
+	virtv1.VirtualMachineInstanceConditionType(k8score.PodReady),
+	virtv1.VirtualMachineInstanceConditionType(k8score.PodScheduled),
+	virtv1.VirtualMachineInstanceSynchronized,
+	virtv1.VirtualMachineInstanceNetworkAttachmentsReady,
+}
 
-	if vmReadyCond == nil && vmiReadyCond != nil {
-		log.Log.Object(vm).V(4).Info("Adding ready condition")
-		newCond := virtv1.VirtualMachineCondition{Type: virtv1.VirtualMachineReady}
-		copyConditionDetails(vmiReadyCond, &newCond)
+func syncConditionFromVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, condType virtv1.VirtualMachineInstanceConditionType) {
+	vmCondType := virtv1.VirtualMachineConditionType(condType)
+	vmCond := controller.NewVirtualMachineConditionManager().GetCondition(vm, vmCondType)
+	vmiCond := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, condType)
+
+	if vmCond == nil && vmiCond != nil {
+		log.Log.Object(vm).V(4).Infof("Adding %s condition", condType)
+		newCond := virtv1.VirtualMachineCondition{Type: vmCondType}
+		copyConditionDetails(vmiCond, &newCond)
 		vm.Status.Conditions = append(vm.Status.Conditions, newCond)
-	} else if vmReadyCond != nil && vmiReadyCond != nil {
-		log.Log.Object(vm).V(4).Info("Updating ready condition")
-		copyConditionDetails(vmiReadyCond, vmReadyCond)
-	} else if vmReadyCond != nil && vmiReadyCond == nil {
-		log.Log.Object(vm).V(4).Info("Removing ready condition")
-		controller.NewVirtualMachineConditionManager().RemoveCondition(vm, virtv1.VirtualMachineReady)
+	} else if vmCond != nil && vmiCond != nil {
+		log.Log.Object(vm).V(4).Infof("Updating %s condition", condType)
+		copyConditionDetails(vmiCond, vmCond)
+	} else if vmCond != nil && vmiCond == nil {
+		log.Log.Object(vm).V(4).Infof("Removing %s condition", condType)
+		controller.NewVirtualMachineConditionManager().RemoveCondition(vm, vmCondType)
+	}
+}
+
+// syncDataVolumesCondition reports in the DataVolumesReady condition whether the DataVolumes of the
+// templates of the vm are populated, or which one is not
+func (c *VMController) syncDataVolumesCondition(vm *virtv1.VirtualMachine, dataVolumes []*cdiv1.DataVolume) {
+	vmCondManager := controller.NewVirtualMachineConditionManager()
+	if len(vm.Spec.DataVolumeTemplates) == 0 {
+		vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineDataVolumesReady)
+		return
+	}
+
+	newCond := virtv1.VirtualMachineCondition{
+		Type:   virtv1.VirtualMachineDataVolumesReady,
+		Status: k8score.ConditionTrue,
+	}
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		var dataVolume *cdiv1.DataVolume
+		for _, dv := range dataVolumes {
+			if dv.Name == template.Name {
+				dataVolume = dv
+				break
+			}
+		}
+
+		if dataVolume == nil {
+			if c.csiClonedPVC(vm, template.Name) != nil {
+				// provisioned by the CSI driver, the pod waits until it is bound
+				continue
+			}
+			if newCond.Status == k8score.ConditionTrue {
+				newCond.Status = k8score.ConditionFalse
+				newCond.Reason = virtv1.VirtualMachineReasonDataVolumeNotPopulated
+				newCond.Message = fmt.Sprintf("DataVolume %s is not created yet", template.Name)
+			}
+			continue
+		}
+
+		switch dataVolume.Status.Phase {
+		case cdiv1.Succeeded, cdiv1.WaitForFirstConsumer:
+		case cdiv1.Failed:
+			// A failed DataVolume is the more interesting reason, report it even if another one is not populated yet
+			newCond.Status = k8score.ConditionFalse
+			newCond.Reason = virtv1.VirtualMachineReasonDataVolumeFailed
+			newCond.Message = dataVolumeMessage(dataVolume)
+		default:
+			if newCond.Status == k8score.ConditionTrue {
+				newCond.Status = k8score.ConditionFalse
+				newCond.Reason = virtv1.VirtualMachineReasonDataVolumeNotPopulated
+				newCond.Message = dataVolumeMessage(dataVolume)
+			}
+		}
+		if newCond.Reason == virtv1.VirtualMachineReasonDataVolumeFailed {
+			break
+		}
+	}
+
+	cond := vmCondManager.GetCondition(vm, virtv1.VirtualMachineDataVolumesReady)
+	if cond != nil && cond.Status == newCond.Status && cond.Reason == newCond.Reason && cond.Message == newCond.Message {
+		return
+	}
+	vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineDataVolumesReady)
+	newCond.LastTransitionTime = v1.Now()
+	vm.Status.Conditions = append(vm.Status.Conditions, newCond)
+}
+
+// dataVolumeMessage describes the phase of the DataVolume, with the error of the pod populating it, if any
+func dataVolumeMessage(dataVolume *cdiv1.DataVolume) string {
+	phase := dataVolume.Status.Phase
+	if phase == "" {
+		phase = cdiv1.Pending
+	}
+	message := fmt.Sprintf("DataVolume %s is %s", dataVolume.Name, phase)
+	for _, cond := range dataVolume.Status.Conditions {
+		if cond.Type == cdiv1.DataVolumeRunning && cond.Status != k8score.ConditionTrue && cond.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, cond.Message)
+		}
+	}
+	return message
+}
+
+// printableStatus summarizes the state of the vm, preferring the reasons why its vmi can't be started
+// over the phase of the vmi
+func printableStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) virtv1.VirtualMachinePrintableStatus {
+	vmCondManager := controller.NewVirtualMachineConditionManager()
+	vmiCondManager := controller.NewVirtualMachineInstanceConditionManager()
+
+	if vm.DeletionTimestamp != nil {
+		return virtv1.VirtualMachineStatusTerminating
+	}
+	if vmi != nil && !vmi.IsFinal() && vmi.DeletionTimestamp != nil {
+		return virtv1.VirtualMachineStatusStopping
+	}
+
+	if cond := vmCondManager.GetCondition(vm, virtv1.VirtualMachineDataVolumesReady); cond != nil && cond.Status == k8score.ConditionFalse {
+		if cond.Reason == virtv1.VirtualMachineReasonDataVolumeFailed {
+			return virtv1.VirtualMachineStatusDataVolumeError
+		}
+		return virtv1.VirtualMachineStatusProvisioning
+	}
+
+	if vmi == nil || vmi.IsFinal() {
+		return virtv1.VirtualMachineStatusStopped
+	}
+
+	if vmi.IsUnprocessed() || vmi.IsScheduling() {
+		switch {
+		case vmiCondManager.HasConditionWithStatusAndReason(vmi, virtv1.VirtualMachineInstanceSynchronized, k8score.ConditionFalse, FailedPvcNotFoundReason):
+			return virtv1.VirtualMachineStatusPvcNotFound
+		case vmiCondManager.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceNetworkAttachmentsReady, k8score.ConditionFalse):
+			return virtv1.VirtualMachineStatusNetworkAttachmentError
+		case vmiCondManager.HasConditionWithStatusAndReason(vmi, virtv1.VirtualMachineInstanceConditionType(k8score.PodScheduled), k8score.ConditionFalse, k8score.PodReasonUnschedulable):
+			return virtv1.VirtualMachineStatusUnschedulable
+		case vmiCondManager.HasConditionWithStatusAndReason(vmi, virtv1.VirtualMachineInstanceSynchronized, k8score.ConditionFalse, ErrImagePullReason):
+			return virtv1.VirtualMachineStatusErrImagePull
+		case vmiCondManager.HasConditionWithStatusAndReason(vmi, virtv1.VirtualMachineInstanceSynchronized, k8score.ConditionFalse, ImagePullBackOffReason):
+			return virtv1.VirtualMachineStatusImagePullBackOff
+		}
+	}
+
+	switch {
+	case vmi.IsUnknown():
+		return virtv1.VirtualMachineStatusUnknown
+	case !vmi.IsRunning():
+		return virtv1.VirtualMachineStatusStarting
+	case vmi.Status.MigrationState != nil && !vmi.Status.MigrationState.Completed:
+		return virtv1.VirtualMachineStatusMigrating
+	case vmiCondManager.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstancePaused, k8score.ConditionTrue):
+		return virtv1.VirtualMachineStatusPaused
+	default:
+		return virtv1.VirtualMachineStatusRunning
+	}
+}
+
+// updateHibernationStatus reports the hibernation claim of the VM and whether the memory of the guest
+// is saved on it
+func (c *VMController) updateHibernationStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	if vm.Spec.Hibernation == nil {
+		vm.Status.Hibernation = nil
+		return
+	}
+	if vm.Status.Hibernation == nil {
+		vm.Status.Hibernation = &virtv1.VirtualMachineHibernationStatus{}
+	}
+	status := vm.Status.Hibernation
+	status.ClaimName = hibernationClaimName(vm)
+
+	size := hibernationClaimSize(vm)
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, status.ClaimName))
+	if err == nil && exists {
+		claim := obj.(*k8score.PersistentVolumeClaim)
+		if capacity, ok := claim.Status.Capacity[k8score.ResourceStorage]; ok {
+			size = capacity
+		} else if request, ok := claim.Spec.Resources.Requests[k8score.ResourceStorage]; ok {
+			size = request
+		}
+	}
+	if status.Size == nil || status.Size.Cmp(size) != 0 {
+		status.Size = &size
+	}
+
+	if vmi == nil {
+		return
+	}
+	cond := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, virtv1.VirtualMachineInstanceHibernated)
+	if cond != nil && cond.Status == k8score.ConditionTrue {
+		if !status.Hibernated {
+			log.Log.Object(vm).V(3).Info("VMI is hibernated")
+			c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulHibernateVirtualMachineReason, "Saved the memory of the virtual machine instance %v", vmi.ObjectMeta.UID)
+			hibernationTime := cond.LastTransitionTime
+			status.Hibernated = true
+			status.HibernationTime = &hibernationTime
+		}
+	} else if status.Hibernated && vmi.IsRunning() {
+		// virt-launcher restores the memory when it starts a VMI of a hibernated VM
+		log.Log.Object(vm).V(3).Info("VMI was resumed from hibernation")
+		status.Hibernated = false
+		status.HibernationTime = nil
 	}
 }
 
@@ -10,9 +10,12 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pborman/uuid"
 	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
@@ -41,6 +44,7 @@ var _ = Describe("VirtualMachine", func() {
 		var dataVolumeInformer cache.SharedIndexInformer
 		var dataVolumeSource *framework.FakeControllerSource
 		var pvcInformer cache.SharedIndexInformer
+		var storageClassInformer cache.SharedIndexInformer
 		var stop chan struct{}
 		var controller *VMController
 		var recorder *record.FakeRecorder
@@ -48,6 +52,7 @@ var _ = Describe("VirtualMachine", func() {
 		var vmiFeeder *testutils.VirtualMachineFeeder
 		var dataVolumeFeeder *testutils.DataVolumeFeeder
 		var cdiClient *cdifake.Clientset
+		var virtClient *kubecli.MockKubevirtClient
 
 		syncCaches := func(stop chan struct{}) {
 			go vmiInformer.Run(stop)
@@ -59,7 +64,7 @@ var _ = Describe("VirtualMachine", func() {
 		BeforeEach(func() {
 			stop = make(chan struct{})
 			ctrl = gomock.NewController(GinkgoT())
-			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			virtClient = kubecli.NewMockKubevirtClient(ctrl)
 			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
 
@@ -67,9 +72,10 @@ var _ = Describe("VirtualMachine", func() {
 			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
 			pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+			storageClassInformer, _ = testutils.NewFakeInformerFor(&storagev1.StorageClass{})
 			recorder = record.NewFakeRecorder(100)
 
-			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, recorder, virtClient)
+			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, storageClassInformer, recorder, virtClient)
 			// Wrap our workqueue to have a way to detect when we are done processing updates
 			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 			controller.Queue = mockQueue
@@ -158,6 +164,15 @@ var _ = Describe("VirtualMachine", func() {
 			dataVolumeFeeder.Add(existingDataVolume)
 			createCount := 0
 			shouldExpectDataVolumeCreation(vm.UID, map[string]string{"kubevirt.io/created-by": "", "my": "label"}, map[string]string{"my": "annotation"}, &createCount)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+				objVM := obj.(*v1.VirtualMachine)
+				Expect(objVM.Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusProvisioning))
+				cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(objVM, v1.VirtualMachineDataVolumesReady)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+				Expect(cond.Reason).To(Equal(v1.VirtualMachineReasonDataVolumeNotPopulated))
+				Expect(cond.Message).To(Equal("DataVolume dv1 is not created yet"))
+			}).Return(vm, nil)
 			controller.Execute()
 			Expect(createCount).To(Equal(1))
 			testutils.ExpectEvent(recorder, SuccessfulDataVolumeCreateReason)
@@ -619,6 +634,7 @@ var _ = Describe("VirtualMachine", func() {
 
 			createCount := 0
 			shouldExpectDataVolumeCreation(vm.UID, map[string]string{"kubevirt.io/created-by": ""}, map[string]string{}, &createCount)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
 			controller.Execute()
 			Expect(createCount).To(Equal(2))
 			testutils.ExpectEvent(recorder, SuccessfulDataVolumeCreateReason)
@@ -685,9 +701,7 @@ var _ = Describe("VirtualMachine", func() {
 				createCount := 0
 				shouldExpectDataVolumeCreation(vm.UID, map[string]string{"kubevirt.io/created-by": ""}, map[string]string{}, &createCount)
 
-				if fail {
-					vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
-				}
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
 
 				controller.cloneAuthFunc = func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 					if dv.Spec.Source.PVC.Namespace != "" {
@@ -727,6 +741,94 @@ var _ = Describe("VirtualMachine", func() {
 			)
 		})
 
+		table.DescribeTable("should clone the source PVC of a DataVolume template", func(cloneStrategy string, csiClone bool) {
+			storageClassName := "csi"
+			storageClass := &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        storageClassName,
+					Annotations: map[string]string{virtv1.CloneStrategyAnnotation: cloneStrategy},
+				},
+			}
+			Expect(storageClassInformer.GetStore().Add(storageClass)).To(Succeed())
+			source := &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "golden",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					StorageClassName: &storageClassName,
+				},
+				Status: k8sv1.PersistentVolumeClaimStatus{
+					Phase:    k8sv1.ClaimBound,
+					Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			}
+			Expect(pvcInformer.GetStore().Add(source)).To(Succeed())
+
+			vm, _ := DefaultVirtualMachine(true)
+			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+				Name: "test1",
+				VolumeSource: v1.VolumeSource{
+					DataVolume: &v1.DataVolumeSource{
+						Name: "dv1",
+					},
+				},
+			})
+			vm.Spec.DataVolumeTemplates = append(vm.Spec.DataVolumeTemplates, v1.DataVolumeTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "dv1",
+				},
+				Spec: cdiv1.DataVolumeSpec{
+					Source: cdiv1.DataVolumeSource{
+						PVC: &cdiv1.DataVolumeSourcePVC{
+							Name: "golden",
+						},
+					},
+					PVC: &k8sv1.PersistentVolumeClaimSpec{
+						StorageClassName: &storageClassName,
+						Resources: k8sv1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("2Gi")},
+						},
+					},
+				},
+			})
+			addVirtualMachine(vm)
+
+			kubeClient := fake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+			pvcCreateCount := 0
+			kubeClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				pvc := action.(testing.CreateAction).GetObject().(*k8sv1.PersistentVolumeClaim)
+				Expect(pvc.Name).To(Equal("dv1"))
+				Expect(pvc.Spec.DataSource.Kind).To(Equal("PersistentVolumeClaim"))
+				Expect(pvc.Spec.DataSource.Name).To(Equal("golden"))
+				Expect(pvc.OwnerReferences[0].UID).To(Equal(vm.UID))
+				pvcCreateCount++
+				return true, pvc, nil
+			})
+			dvCreateCount := 0
+			shouldExpectDataVolumeCreation(vm.UID, map[string]string{"kubevirt.io/created-by": ""}, map[string]string{}, &dvCreateCount)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+			controller.cloneAuthFunc = func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
+				return true, "", nil
+			}
+
+			controller.Execute()
+
+			if csiClone {
+				Expect(pvcCreateCount).To(Equal(1))
+				Expect(dvCreateCount).To(Equal(0))
+				testutils.ExpectEvent(recorder, SuccessfulCSICloneReason)
+			} else {
+				Expect(pvcCreateCount).To(Equal(0))
+				Expect(dvCreateCount).To(Equal(1))
+				testutils.ExpectEvent(recorder, SuccessfulDataVolumeCreateReason)
+			}
+		},
+			table.Entry("with the CSI driver if the StorageClass supports it", virtv1.CSICloneStrategy, true),
+			table.Entry("with CDI if the StorageClass doesn't support CSI clones", "", false),
+		)
+
 		It("should create missing VirtualMachineInstance", func() {
 			vm, vmi := DefaultVirtualMachine(true)
 
@@ -802,6 +904,34 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		It("should report the freeze state of the guest file systems of the vmi", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			markAsReady(vmi)
+			vmi.Status.FSFreezeStatus = v1.FSFrozen
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.FSFreezeStatus).To(Equal(v1.FSFrozen))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should clear the freeze state once the vmi is gone", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Status.FSFreezeStatus = v1.FSFrozen
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.FSFreezeStatus).To(BeEmpty())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
 		It("should have stable firmware UUIDs", func() {
 			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
 			vmi1 := controller.setupVMIFromVM(vm1)
@@ -817,6 +947,39 @@ var _ = Describe("VirtualMachine", func() {
 			Expect(vmi1.Spec.Domain.Firmware.UUID).NotTo(Equal(vmi3.Spec.Domain.Firmware.UUID))
 		})
 
+		It("should regenerate the firmware UUID and serial for clones", func() {
+			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
+			vm1.UID = "vm1-uid"
+			vm1.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{IdentityPolicy: virtv1.FirmwareIdentityPolicyRegenerateOnClone}
+			vmi1 := controller.setupVMIFromVM(vm1)
+			Expect(vmi1.Spec.Domain.Firmware.Serial).To(Equal("vm1-uid"))
+
+			// a restore keeps the VirtualMachine
+			vmi1Restored := controller.setupVMIFromVM(vm1.DeepCopy())
+			Expect(vmi1Restored.Spec.Domain.Firmware.UUID).To(Equal(vmi1.Spec.Domain.Firmware.UUID))
+			Expect(vmi1Restored.Spec.Domain.Firmware.Serial).To(Equal(vmi1.Spec.Domain.Firmware.Serial))
+
+			// a clone is a new VirtualMachine, even under the same name
+			vm2 := vm1.DeepCopy()
+			vm2.UID = "vm2-uid"
+			vmi2 := controller.setupVMIFromVM(vm2)
+			Expect(vmi2.Spec.Domain.Firmware.UUID).NotTo(Equal(vmi1.Spec.Domain.Firmware.UUID))
+			Expect(vmi2.Spec.Domain.Firmware.Serial).To(Equal("vm2-uid"))
+		})
+
+		It("should keep the firmware UUID for clones under the same name", func() {
+			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
+			vm1.UID = "vm1-uid"
+			vm1.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{IdentityPolicy: virtv1.FirmwareIdentityPolicyKeep}
+			vmi1 := controller.setupVMIFromVM(vm1)
+
+			vm2 := vm1.DeepCopy()
+			vm2.UID = "vm2-uid"
+			vmi2 := controller.setupVMIFromVM(vm2)
+			Expect(vmi2.Spec.Domain.Firmware.UUID).To(Equal(vmi1.Spec.Domain.Firmware.UUID))
+			Expect(vmi2.Spec.Domain.Firmware.Serial).To(BeEmpty())
+		})
+
 		It("should honour any firmware UUID present in the template", func() {
 			uid := uuid.NewRandom().String()
 			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
@@ -1059,6 +1222,7 @@ var _ = Describe("VirtualMachine", func() {
 			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(obj interface{}) {
 				Expect(obj.(*v1.VirtualMachineInstance).ObjectMeta.Annotations).To(Equal(annotations))
 			}).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
 
 			controller.Execute()
 		})
@@ -1073,6 +1237,7 @@ var _ = Describe("VirtualMachine", func() {
 			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(obj interface{}) {
 				Expect(obj.(*v1.VirtualMachineInstance).ObjectMeta.Annotations).To(Equal(annotations))
 			}).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
 
 			controller.Execute()
 		})
@@ -1087,10 +1252,381 @@ var _ = Describe("VirtualMachine", func() {
 			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(obj interface{}) {
 				Expect(obj.(*v1.VirtualMachineInstance).ObjectMeta.Annotations).To(Equal(annotations))
 			}).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
 
 			controller.Execute()
 		})
 
+		Context("printable status", func() {
+			withCondition := func(condType v1.VirtualMachineInstanceConditionType, reason string) func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+				return func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = v1.Scheduling
+					vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+						Type:   condType,
+						Status: k8sv1.ConditionFalse,
+						Reason: reason,
+					})
+				}
+			}
+
+			table.DescribeTable("should summarize the state of the VM", func(modify func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance), expected v1.VirtualMachinePrintableStatus) {
+				vm, vmi := DefaultVirtualMachine(true)
+				modify(vm, vmi)
+				if vmi.Status.Phase == "" {
+					vmi = nil
+				}
+				Expect(printableStatus(vm, vmi)).To(Equal(expected))
+			},
+				table.Entry("stopped without VMI", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = ""
+				}, v1.VirtualMachineStatusStopped),
+				table.Entry("stopped with a final VMI", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = v1.Succeeded
+				}, v1.VirtualMachineStatusStopped),
+				table.Entry("terminating", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					now := metav1.Now()
+					vm.DeletionTimestamp = &now
+				}, v1.VirtualMachineStatusTerminating),
+				table.Entry("stopping", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					now := metav1.Now()
+					vmi.DeletionTimestamp = &now
+				}, v1.VirtualMachineStatusStopping),
+				table.Entry("provisioning", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = ""
+					vm.Status.Conditions = []v1.VirtualMachineCondition{{
+						Type:   v1.VirtualMachineDataVolumesReady,
+						Status: k8sv1.ConditionFalse,
+						Reason: v1.VirtualMachineReasonDataVolumeNotPopulated,
+					}}
+				}, v1.VirtualMachineStatusProvisioning),
+				table.Entry("with a failed DataVolume", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = ""
+					vm.Status.Conditions = []v1.VirtualMachineCondition{{
+						Type:   v1.VirtualMachineDataVolumesReady,
+						Status: k8sv1.ConditionFalse,
+						Reason: v1.VirtualMachineReasonDataVolumeFailed,
+					}}
+				}, v1.VirtualMachineStatusDataVolumeError),
+				table.Entry("unschedulable", withCondition(v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled), k8sv1.PodReasonUnschedulable), v1.VirtualMachineStatusUnschedulable),
+				table.Entry("with a missing PVC", withCondition(v1.VirtualMachineInstanceSynchronized, FailedPvcNotFoundReason), v1.VirtualMachineStatusPvcNotFound),
+				table.Entry("with an image which can't be pulled", withCondition(v1.VirtualMachineInstanceSynchronized, ErrImagePullReason), v1.VirtualMachineStatusErrImagePull),
+				table.Entry("backing off pulling an image", withCondition(v1.VirtualMachineInstanceSynchronized, ImagePullBackOffReason), v1.VirtualMachineStatusImagePullBackOff),
+				table.Entry("with a missing NetworkAttachmentDefinition", withCondition(v1.VirtualMachineInstanceNetworkAttachmentsReady, v1.VirtualMachineInstanceReasonNetworkAttachmentNotFound), v1.VirtualMachineStatusNetworkAttachmentError),
+				table.Entry("starting", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = v1.Scheduled
+				}, v1.VirtualMachineStatusStarting),
+				table.Entry("migrating", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+				}, v1.VirtualMachineStatusMigrating),
+				table.Entry("paused", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+						Type:   v1.VirtualMachineInstancePaused,
+						Status: k8sv1.ConditionTrue,
+					}}
+				}, v1.VirtualMachineStatusPaused),
+				table.Entry("running", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {}, v1.VirtualMachineStatusRunning),
+				table.Entry("unknown", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmi.Status.Phase = v1.Unknown
+				}, v1.VirtualMachineStatusUnknown),
+			)
+
+			It("should copy the reason why the VMI can't be scheduled to the VM", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Status.Created = true
+				vmi.Status.Phase = v1.Scheduling
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+					Type:    v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
+					Status:  k8sv1.ConditionFalse,
+					Reason:  k8sv1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient memory.",
+				}}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusUnschedulable))
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(objVM, v1.VirtualMachineConditionType(k8sv1.PodScheduled))
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+					Expect(cond.Reason).To(Equal(k8sv1.PodReasonUnschedulable))
+					Expect(cond.Message).To(Equal("0/3 nodes are available: 3 Insufficient memory."))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should remove conditions the VMI does not have anymore", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Status.Created = true
+				vm.Status.Conditions = []v1.VirtualMachineCondition{{
+					Type:   v1.VirtualMachineConditionType(k8sv1.PodScheduled),
+					Status: k8sv1.ConditionFalse,
+					Reason: k8sv1.PodReasonUnschedulable,
+				}}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusRunning))
+					Expect(objVM.Status.Conditions).To(BeEmpty())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should report a failed DataVolume with the error of its importer", func() {
+				vm, _ := DefaultVirtualMachine(true)
+				vm.Spec.DataVolumeTemplates = []v1.DataVolumeTemplateSpec{
+					{ObjectMeta: metav1.ObjectMeta{Name: "dv1"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "dv2"}},
+				}
+				addVirtualMachine(vm)
+
+				pending := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[0], vm)
+				pending.Namespace = "default"
+				pending.Status.Phase = cdiv1.ImportInProgress
+				dataVolumeFeeder.Add(pending)
+				failed := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[1], vm)
+				failed.Namespace = "default"
+				failed.Status.Phase = cdiv1.Failed
+				failed.Status.Conditions = []cdiv1.DataVolumeCondition{{
+					Type:    cdiv1.DataVolumeRunning,
+					Status:  k8sv1.ConditionFalse,
+					Reason:  "Error",
+					Message: "Unable to connect to http data source",
+				}}
+				dataVolumeFeeder.Add(failed)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.PrintableStatus).To(Equal(v1.VirtualMachineStatusDataVolumeError))
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(objVM, v1.VirtualMachineDataVolumesReady)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+					Expect(cond.Reason).To(Equal(v1.VirtualMachineReasonDataVolumeFailed))
+					Expect(cond.Message).To(Equal("DataVolume dv2 is Failed: Unable to connect to http data source"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, FailedDataVolumeImportReason)
+			})
+		})
+
+		Context("VM hibernation", func() {
+			var kubeClient *fake.Clientset
+
+			hibernatingVirtualMachine := func() (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(false)
+				vm.UID = "vm-uid"
+				vmi.OwnerReferences[0].UID = vm.UID
+				vm.Spec.Hibernation = &v1.VirtualMachineHibernation{}
+				vm.Spec.Template.Spec.Domain.Memory = &v1.Memory{Guest: resource.NewQuantity(1024*1024*1024, resource.BinarySI)}
+				return vm, vmi
+			}
+
+			hibernationClaim := func(vm *v1.VirtualMachine) *k8sv1.PersistentVolumeClaim {
+				return &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            vm.Name + "-hibernation",
+						Namespace:       vm.Namespace,
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)},
+					},
+					Status: k8sv1.PersistentVolumeClaimStatus{
+						Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("2Gi")},
+					},
+				}
+			}
+
+			hibernateRequest := func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+				vmi.UID = "vmi-uid"
+				vm.Status.StateChangeRequests = []v1.VirtualMachineStateChangeRequest{{Action: v1.HibernateRequest, UID: &vmi.UID}}
+			}
+
+			BeforeEach(func() {
+				kubeClient = fake.NewSimpleClientset()
+				virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+				kubeClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					Expect(action).To(BeNil())
+					return true, nil, nil
+				})
+			})
+
+			It("should create the hibernation claim", func() {
+				vm, _ := hibernatingVirtualMachine()
+				storageClass := "local"
+				vm.Spec.Hibernation.StorageClassName = &storageClass
+
+				addVirtualMachine(vm)
+
+				created := false
+				kubeClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					created = true
+					claim := action.(testing.CreateAction).GetObject().(*k8sv1.PersistentVolumeClaim)
+					Expect(claim.Name).To(Equal("testvmi-hibernation"))
+					Expect(metav1.IsControlledBy(claim, vm)).To(BeTrue())
+					Expect(claim.Spec.AccessModes).To(ConsistOf(k8sv1.ReadWriteOnce))
+					Expect(claim.Spec.StorageClassName).To(Equal(&storageClass))
+					size := claim.Spec.Resources.Requests[k8sv1.ResourceStorage]
+					Expect(size.String()).To(Equal("1152Mi"))
+					return true, claim, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					status := obj.(*v1.VirtualMachine).Status.Hibernation
+					Expect(status).ToNot(BeNil())
+					Expect(status.ClaimName).To(Equal("testvmi-hibernation"))
+					Expect(status.Size.String()).To(Equal("1152Mi"))
+					Expect(status.Hibernated).To(BeFalse())
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				Expect(created).To(BeTrue())
+				testutils.ExpectEvent(recorder, SuccessfulCreateHibernationClaimReason)
+			})
+
+			It("should delete the hibernation claim once hibernation is disabled", func() {
+				vm, _ := hibernatingVirtualMachine()
+				Expect(pvcInformer.GetStore().Add(hibernationClaim(vm))).To(Succeed())
+				vm.Spec.Hibernation = nil
+
+				addVirtualMachine(vm)
+
+				deleted := false
+				kubeClient.Fake.PrependReactor("delete", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					deleted = true
+					Expect(action.(testing.DeleteAction).GetName()).To(Equal("testvmi-hibernation"))
+					return true, nil, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+
+				Expect(deleted).To(BeTrue())
+			})
+
+			It("should not use a hibernation claim it does not own", func() {
+				vm, _ := hibernatingVirtualMachine()
+				claim := hibernationClaim(vm)
+				claim.OwnerReferences = nil
+				Expect(pvcInformer.GetStore().Add(claim)).To(Succeed())
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedCreateHibernationClaimReason)
+			})
+
+			It("should point the VMI to the hibernation claim", func() {
+				vm, _ := hibernatingVirtualMachine()
+				vm.Spec.Template.ObjectMeta.Annotations = map[string]string{"test": "test"}
+
+				vmi := controller.setupVMIFromVM(vm)
+				Expect(vmi.Annotations).To(HaveKeyWithValue(v1.HibernationClaimAnnotation, "testvmi-hibernation"))
+				Expect(vm.Spec.Template.ObjectMeta.Annotations).ToNot(HaveKey(v1.HibernationClaimAnnotation))
+			})
+
+			It("should request the hibernation of the VMI instead of stopping it", func() {
+				vm, vmi := hibernatingVirtualMachine()
+				hibernateRequest(vm, vmi)
+				Expect(pvcInformer.GetStore().Add(hibernationClaim(vm))).To(Succeed())
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Patch(vmi.Name, types.MergePatchType, []byte(`{"metadata":{"annotations":{"kubevirt.io/hibernation-requested":"true"}}}`)).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					status := obj.(*v1.VirtualMachine).Status
+					Expect(status.StateChangeRequests).To(HaveLen(1))
+					Expect(status.Hibernation.Size.String()).To(Equal("2Gi"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should stop the VMI once it is hibernated", func() {
+				vm, vmi := hibernatingVirtualMachine()
+				hibernateRequest(vm, vmi)
+				Expect(pvcInformer.GetStore().Add(hibernationClaim(vm))).To(Succeed())
+				hibernationTime := metav1.Now()
+				vmi.Annotations[v1.HibernationRequestedAnnotation] = "true"
+				vmi.Status.Phase = v1.Succeeded
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+					Type:               v1.VirtualMachineInstanceHibernated,
+					Status:             k8sv1.ConditionTrue,
+					LastTransitionTime: hibernationTime,
+				}}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Delete(vmi.Name, gomock.Any()).Return(nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					status := obj.(*v1.VirtualMachine).Status.Hibernation
+					Expect(status.Hibernated).To(BeTrue())
+					Expect(status.HibernationTime).To(Equal(&hibernationTime))
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvents(recorder, SuccessfulDeleteVirtualMachineReason, SuccessfulHibernateVirtualMachineReason)
+			})
+
+			It("should stop the VMI if the hibernation failed", func() {
+				vm, vmi := hibernatingVirtualMachine()
+				hibernateRequest(vm, vmi)
+				Expect(pvcInformer.GetStore().Add(hibernationClaim(vm))).To(Succeed())
+				vmi.Annotations[v1.HibernationRequestedAnnotation] = "true"
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+					Type:    v1.VirtualMachineInstanceHibernated,
+					Status:  k8sv1.ConditionFalse,
+					Reason:  v1.VirtualMachineInstanceReasonHibernationFailed,
+					Message: "no space left on device",
+				}}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Delete(vmi.Name, gomock.Any()).Return(nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.Hibernation.Hibernated).To(BeFalse())
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvents(recorder, FailedHibernateVirtualMachineReason, SuccessfulDeleteVirtualMachineReason)
+			})
+
+			It("should clear the hibernated status once the VMI runs again", func() {
+				vm, vmi := hibernatingVirtualMachine()
+				vm.Spec.Running = &[]bool{true}[0]
+				Expect(pvcInformer.GetStore().Add(hibernationClaim(vm))).To(Succeed())
+				hibernationTime := metav1.Now()
+				vm.Status.Hibernation = &v1.VirtualMachineHibernationStatus{
+					ClaimName:       "testvmi-hibernation",
+					Hibernated:      true,
+					HibernationTime: &hibernationTime,
+				}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					status := obj.(*v1.VirtualMachine).Status.Hibernation
+					Expect(status.Hibernated).To(BeFalse())
+					Expect(status.HibernationTime).To(BeNil())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
 		Context("VM rename", func() {
 			Context("source VM", func() {
 				var vm *v1.VirtualMachine
@@ -1171,6 +1707,7 @@ var _ = Describe("VirtualMachine", func() {
 							Return(newVM, nil)
 
 						vmInterface.EXPECT().Delete(vm.Name, gomock.Any())
+						vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
 
 						addVirtualMachine(vm)
 						controller.Execute()
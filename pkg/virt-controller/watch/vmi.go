@@ -42,7 +42,9 @@ import (
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	startupmetrics "kubevirt.io/kubevirt/pkg/monitoring/startup/prometheus"
 	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -108,6 +110,15 @@ const (
 	PVCNotReadyReason = "PVCNotReady"
 	// FailedHotplugSyncReason is set when a hotplug specific failure occurs during sync
 	FailedHotplugSyncReason = "FailedHotplugSync"
+	// FailedAllocateMasqueradeSubnetReason is added in an event when no subnet of the masquerade subnet pool
+	// could be allocated to the vmi.
+	FailedAllocateMasqueradeSubnetReason = "FailedAllocateMasqueradeSubnet"
+	// ErrImagePullReason is set in the Synchronized condition of the vmi when an image of its pod
+	// could not be pulled.
+	ErrImagePullReason = "ErrImagePull"
+	// ImagePullBackOffReason is set in the Synchronized condition of the vmi when pulling an image
+	// of its pod failed repeatedly and the kubelet backs off.
+	ImagePullBackOffReason = "ImagePullBackOff"
 )
 
 func NewVMIController(templateService services.TemplateService,
@@ -116,18 +127,22 @@ func NewVMIController(templateService services.TemplateService,
 	pvcInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
-	dataVolumeInformer cache.SharedIndexInformer) *VMIController {
+	dataVolumeInformer cache.SharedIndexInformer,
+	networkAttachmentInformer cache.SharedIndexInformer,
+	clusterConfig *virtconfig.ClusterConfig) *VMIController {
 
 	c := &VMIController{
-		templateService:    templateService,
-		Queue:              workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
-		vmiInformer:        vmiInformer,
-		podInformer:        podInformer,
-		pvcInformer:        pvcInformer,
-		recorder:           recorder,
-		clientset:          clientset,
-		podExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		dataVolumeInformer: dataVolumeInformer,
+		templateService:           templateService,
+		Queue:                     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		vmiInformer:               vmiInformer,
+		podInformer:               podInformer,
+		pvcInformer:               pvcInformer,
+		recorder:                  recorder,
+		clientset:                 clientset,
+		podExpectations:           controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		dataVolumeInformer:        dataVolumeInformer,
+		networkAttachmentInformer: networkAttachmentInformer,
+		masqueradeSubnets:         newMasqueradeSubnetAllocator(vmiInformer.GetStore(), clusterConfig),
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -148,6 +163,12 @@ func NewVMIController(templateService services.TemplateService,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.networkAttachmentInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addNetworkAttachmentDefinition,
+		DeleteFunc: c.deleteNetworkAttachmentDefinition,
+		UpdateFunc: c.updateNetworkAttachmentDefinition,
+	})
+
 	return c
 }
 
@@ -170,15 +191,17 @@ func (e *syncErrorImpl) Reason() string {
 }
 
 type VMIController struct {
-	templateService    services.TemplateService
-	clientset          kubecli.KubevirtClient
-	Queue              workqueue.RateLimitingInterface
-	vmiInformer        cache.SharedIndexInformer
-	podInformer        cache.SharedIndexInformer
-	pvcInformer        cache.SharedIndexInformer
-	recorder           record.EventRecorder
-	podExpectations    *controller.UIDTrackingControllerExpectations
-	dataVolumeInformer cache.SharedIndexInformer
+	templateService           services.TemplateService
+	clientset                 kubecli.KubevirtClient
+	Queue                     workqueue.RateLimitingInterface
+	vmiInformer               cache.SharedIndexInformer
+	podInformer               cache.SharedIndexInformer
+	pvcInformer               cache.SharedIndexInformer
+	recorder                  record.EventRecorder
+	podExpectations           *controller.UIDTrackingControllerExpectations
+	dataVolumeInformer        cache.SharedIndexInformer
+	networkAttachmentInformer cache.SharedIndexInformer
+	masqueradeSubnets         *masqueradeSubnetAllocator
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -187,7 +210,7 @@ func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting vmi controller.")
 
 	// Wait for cache sync before we start the pod controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.networkAttachmentInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -256,6 +279,12 @@ func (c *VMIController) execute(key string) error {
 		return err
 	}
 
+	// The masquerade subnet has to be recorded on the vmi before its pod is
+	// created, virt-launcher picks it up when it configures the pod network.
+	if !podExists(pod) && vmi.IsUnprocessed() && vmi.DeletionTimestamp == nil && c.masqueradeSubnets.needsSubnet(vmi) {
+		return c.allocateMasqueradeSubnet(vmi)
+	}
+
 	// Get all dataVolumes associated with this vmi
 	dataVolumes, err := c.listMatchingDataVolumes(vmi)
 	if err != nil {
@@ -283,6 +312,23 @@ func (c *VMIController) execute(key string) error {
 
 }
 
+func (c *VMIController) allocateMasqueradeSubnet(vmi *virtv1.VirtualMachineInstance) error {
+	subnet, err := c.masqueradeSubnets.allocate(vmi)
+	if err != nil {
+		c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedAllocateMasqueradeSubnetReason, "Failed to allocate a masquerade subnet: %v", err)
+		return err
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Status.VMNetworkCIDR = subnet
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Update(vmiCopy)
+	if err != nil {
+		return err
+	}
+	log.Log.Object(vmi).Infof("Allocated masquerade subnet %s", subnet)
+	return nil
+}
+
 // verifies all conditions match even if they are not in the same order
 func conditionsEqual(a []virtv1.VirtualMachineInstanceCondition, b []virtv1.VirtualMachineInstanceCondition) bool {
 	if len(a) != len(b) {
@@ -346,6 +392,7 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			vmiCopy.Status.Phase = virtv1.Failed
 		} else {
 			vmiCopy.Status.Phase = virtv1.Pending
+			syncNetworkAttachmentsCondition(vmiCopy, c.networkAttachmentsCondition(vmi))
 			if hasWffcDataVolume {
 				condition := virtv1.VirtualMachineInstanceCondition{
 					Type:   virtv1.VirtualMachineInstanceProvisioning,
@@ -367,6 +414,16 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 					}
 				}
 			}
+			if syncErr != nil && syncErr.Reason() == virtv1.VirtualMachineInstanceReasonChecksumMismatch {
+				vmiCopy.Status.Phase = virtv1.Failed
+				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceImagesVerified)
+				vmiCopy.Status.Conditions = append(vmiCopy.Status.Conditions, virtv1.VirtualMachineInstanceCondition{
+					Type:    virtv1.VirtualMachineInstanceImagesVerified,
+					Status:  k8sv1.ConditionFalse,
+					Reason:  virtv1.VirtualMachineInstanceReasonChecksumMismatch,
+					Message: syncErr.Error(),
+				})
+			}
 			if syncErr != nil && syncErr.Reason() == FailedPvcNotFoundReason {
 				condition := virtv1.VirtualMachineInstanceCondition{
 					Type:    virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
@@ -402,6 +459,16 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 				// Remove PodScheduling condition from the VM
 				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled))
 			}
+			recordPodScheduledTimestamp(vmiCopy, pod)
+			if syncErr == nil {
+				if pullErr := imagePullFailure(pod); pullErr != nil {
+					// Replace the condition when the kubelet switches between ErrImagePull and ImagePullBackOff
+					if cond := conditionManager.GetCondition(vmiCopy, virtv1.VirtualMachineInstanceSynchronized); cond != nil && cond.Reason != pullErr.Reason() {
+						conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceSynchronized)
+					}
+					syncErr = pullErr
+				}
+			}
 			if isPodReady(pod) && vmi.DeletionTimestamp == nil {
 				// fail vmi creation if CPU pinning has been requested but the Pod QOS is not Guaranteed
 				podQosClass := pod.Status.QOSClass
@@ -458,6 +525,20 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 				})
 				c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, virtv1.PodTerminatingReason, "Pod %s is terminating, marking VMI as not ready.", pod.Name)
 			}
+		} else if conditionManager.HasConditionWithStatus(vmiCopy, virtv1.VirtualMachineInstancePaused, k8sv1.ConditionTrue) {
+			// The guest of a paused VMI can't serve requests, regardless of the readiness of the pod
+			cond := conditionManager.GetCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
+			if cond == nil || cond.Reason != virtv1.PausedReason {
+				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
+				conditionManager.AddPodCondition(vmiCopy, &k8sv1.PodCondition{
+					Type:               k8sv1.PodReady,
+					Status:             k8sv1.ConditionFalse,
+					LastProbeTime:      v1.Now(),
+					LastTransitionTime: v1.Now(),
+					Reason:             virtv1.PausedReason,
+					Message:            "The VMI is paused",
+				})
+			}
 		} else if cond := conditionManager.GetPodCondition(pod, k8sv1.PodReady); cond != nil {
 			conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
 			conditionManager.AddPodCondition(vmiCopy, cond)
@@ -565,11 +646,32 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 		if err != nil {
 			return err
 		}
+		startupmetrics.ObserveStartupTimestamps(vmiCopy, vmi.Status.StartupTimestamps)
 	}
 
 	return nil
 }
 
+// recordPodScheduledTimestamp records the first startup milestone of the vmi,
+// the time at which its virt-launcher pod got scheduled to a node.
+func recordPodScheduledTimestamp(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) {
+	if vmi.Status.StartupTimestamps != nil && vmi.Status.StartupTimestamps.PodScheduled != nil {
+		return
+	}
+	cond := controller.NewVirtualMachineInstanceConditionManager().GetPodCondition(pod, k8sv1.PodScheduled)
+	if cond == nil || cond.Status != k8sv1.ConditionTrue {
+		return
+	}
+	scheduled := cond.LastTransitionTime
+	if scheduled.IsZero() {
+		scheduled = v1.Now()
+	}
+	if vmi.Status.StartupTimestamps == nil {
+		vmi.Status.StartupTimestamps = &virtv1.VirtualMachineInstanceStartupTimestamps{}
+	}
+	vmi.Status.StartupTimestamps.PodScheduled = &scheduled
+}
+
 // isPodReady treats the pod as ready to be handed over to virt-handler, as soon as all pods except
 // the compute pod are ready.
 func isPodReady(pod *k8sv1.Pod) bool {
@@ -596,6 +698,19 @@ func isPodReady(pod *k8sv1.Pod) bool {
 	return pod.Status.Phase == k8sv1.PodRunning
 }
 
+// imagePullFailure returns a sync error if the kubelet can't pull the image of a container of the pod,
+// to report it in the Synchronized condition of the vmi.
+func imagePullFailure(pod *k8sv1.Pod) syncError {
+	statuses := append(append([]k8sv1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, containerStatus := range statuses {
+		waiting := containerStatus.State.Waiting
+		if waiting != nil && (waiting.Reason == ErrImagePullReason || waiting.Reason == ImagePullBackOffReason) {
+			return &syncErrorImpl{fmt.Errorf("failed to pull image %s of container %s: %s", containerStatus.Image, containerStatus.Name, waiting.Message), waiting.Reason}
+		}
+	}
+	return nil
+}
+
 func isPodDownOrGoingDown(pod *k8sv1.Pod) bool {
 	return podIsDown(pod) || isComputeContainerDown(pod) || pod.DeletionTimestamp != nil
 }
@@ -652,6 +767,26 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			log.Log.V(3).Object(vmi).Infof("Delaying pod creation while DataVolume populates")
 			return nil
 		}
+
+		// ensure that the imported disks match their checksums, DataVolumes waiting
+		// for their first consumer are verified once the temporary pod is gone
+		if !isWaitForFirstConsumer {
+			verified, syncErr := c.verifyDataVolumes(vmi, dataVolumes)
+			if syncErr != nil {
+				return syncErr
+			}
+			if !verified {
+				log.Log.V(3).Object(vmi).Infof("Delaying pod creation while DataVolumes are verified")
+				return nil
+			}
+		}
+
+		// ensure that the network attachment definitions of the VMI exist and are valid,
+		// definition changes wake the VMI up again
+		if condition := c.networkAttachmentsCondition(vmi); condition != nil && condition.Status != k8sv1.ConditionTrue {
+			log.Log.V(3).Object(vmi).Infof("Delaying pod creation while network attachment definitions are not ready: %s", condition.Message)
+			return nil
+		}
 		var templatePod *k8sv1.Pod
 		var err error
 		if isWaitForFirstConsumer {
@@ -867,6 +1002,10 @@ func (c *VMIController) addPod(obj interface{}) {
 		return
 	}
 
+	if c.enqueueImageVerifierPod(pod) {
+		return
+	}
+
 	controllerRef := controller.GetControllerOf(pod)
 	vmi := c.resolveControllerRef(pod.Namespace, controllerRef)
 	if vmi == nil {
@@ -893,6 +1032,10 @@ func (c *VMIController) updatePod(old, cur interface{}) {
 		return
 	}
 
+	if c.enqueueImageVerifierPod(curPod) {
+		return
+	}
+
 	if curPod.DeletionTimestamp != nil {
 		labelChanged := !reflect.DeepEqual(curPod.Labels, oldPod.Labels)
 		// having a pod marked for deletion is enough to count as a deletion expectation
@@ -945,6 +1088,10 @@ func (c *VMIController) deletePod(obj interface{}) {
 		}
 	}
 
+	if c.enqueueImageVerifierPod(pod) {
+		return
+	}
+
 	controllerRef := controller.GetControllerOf(pod)
 	vmi := c.resolveControllerRef(pod.Namespace, controllerRef)
 	if vmi == nil {
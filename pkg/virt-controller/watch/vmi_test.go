@@ -24,9 +24,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/golang/mock/gomock"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -46,6 +48,7 @@ import (
 	kvcontroller "kubevirt.io/kubevirt/pkg/controller"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
 	fakenetworkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
@@ -76,6 +79,9 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 	var dataVolumeSource *framework.FakeControllerSource
 	var dataVolumeInformer cache.SharedIndexInformer
 	var dataVolumeFeeder *testutils.DataVolumeFeeder
+	var networkAttachmentSource *framework.FakeControllerSource
+	var networkAttachmentInformer cache.SharedIndexInformer
+	var kubeVirtInformer cache.SharedIndexInformer
 	var qemuGid int64 = 107
 
 	shouldExpectMatchingPodCreation := func(uid types.UID, matchers ...gomegaTypes.GomegaMatcher) {
@@ -172,11 +178,13 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		go pvcInformer.Run(stop)
 
 		go dataVolumeInformer.Run(stop)
+		go networkAttachmentInformer.Run(stop)
 		Expect(cache.WaitForCacheSync(stop,
 			vmiInformer.HasSynced,
 			podInformer.HasSynced,
 			pvcInformer.HasSynced,
-			dataVolumeInformer.HasSynced)).To(BeTrue())
+			dataVolumeInformer.HasSynced,
+			networkAttachmentInformer.HasSynced)).To(BeTrue())
 	}
 
 	BeforeEach(func() {
@@ -188,9 +196,19 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		podInformer, podSource = testutils.NewFakeInformerFor(&k8sv1.Pod{})
 		dataVolumeInformer, dataVolumeSource = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		networkAttachmentInformer, networkAttachmentSource = testutils.NewFakeInformerFor(&networkv1.NetworkAttachmentDefinition{})
 		recorder = record.NewFakeRecorder(100)
 
-		config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+		config, _, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		kubeVirtInformer = kvInformer
 		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
 		controller = NewVMIController(
 			services.NewTemplateService("a", "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
@@ -200,6 +218,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			recorder,
 			virtClient,
 			dataVolumeInformer,
+			networkAttachmentInformer,
+			config,
 		)
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
@@ -264,6 +284,127 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
 		})
 
+		Context("with a checksum", func() {
+			const checksum = "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+			newVMIWithChecksum := func() *v1.VirtualMachineInstance {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "test1",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "test1", Checksum: checksum},
+					},
+				})
+				pvcInformer.GetIndexer().Add(NewPvc(vmi.Namespace, "test1"))
+				return vmi
+			}
+
+			newVerifierPod := func(phase k8sv1.PodPhase, message string) *k8sv1.Pod {
+				return &k8sv1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test1-image-verifier",
+						Namespace:   k8sv1.NamespaceDefault,
+						Labels:      map[string]string{v1.AppLabel: "image-verifier"},
+						Annotations: map[string]string{imageVerifierAnnotation: "test1"},
+					},
+					Status: k8sv1.PodStatus{
+						Phase: phase,
+						ContainerStatuses: []k8sv1.ContainerStatus{{
+							State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{Message: message}},
+						}},
+					},
+				}
+			}
+
+			It("should hash the imported disk before creating the pod", func() {
+				vmi := newVMIWithChecksum()
+				addVirtualMachine(vmi)
+				dataVolumeFeeder.Add(NewDv(vmi.Namespace, "test1", cdiv1.Succeeded))
+
+				kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+					Expect(pod.Name).To(Equal("test1-image-verifier"))
+					Expect(pod.Labels).ToNot(HaveKey(v1.CreatedByLabel))
+					Expect(pod.Annotations).To(HaveKeyWithValue(imageVerifierAnnotation, "test1"))
+					Expect(pod.OwnerReferences[0].Kind).To(Equal("DataVolume"))
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("test1"))
+					Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+					Expect(pod.Spec.Containers[0].Command[2]).To(ContainSubstring("sha256sum /pvc/disk.img"))
+					return true, pod, nil
+				})
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			})
+
+			It("should record the digest reported by the image verifier pod", func() {
+				vmi := newVMIWithChecksum()
+				addVirtualMachine(vmi)
+				dataVolumeFeeder.Add(NewDv(vmi.Namespace, "test1", cdiv1.Succeeded))
+				podFeeder.Add(newVerifierPod(k8sv1.PodSucceeded, checksum))
+
+				cdiClient := cdifake.NewSimpleClientset()
+				virtClient.EXPECT().CdiClient().Return(cdiClient)
+				patched := false
+				cdiClient.Fake.PrependReactor("patch", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patch := action.(testing.PatchAction)
+					Expect(patch.GetName()).To(Equal("test1"))
+					Expect(string(patch.GetPatch())).To(Equal(fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, imageChecksumAnnotation, checksum)))
+					patched = true
+					return true, nil, nil
+				})
+
+				controller.Execute()
+				Expect(patched).To(BeTrue())
+			})
+
+			It("should retry when the image verifier pod failed", func() {
+				vmi := newVMIWithChecksum()
+				addVirtualMachine(vmi)
+				dataVolumeFeeder.Add(NewDv(vmi.Namespace, "test1", cdiv1.Succeeded))
+				pod := newVerifierPod(k8sv1.PodFailed, "sha256sum: /pvc/disk.img: Input/output error")
+				podFeeder.Add(pod)
+				shouldExpectPodDeletion(pod)
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstance).Status.Phase).To(Equal(v1.Pending))
+				}).Return(vmi, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, FailedVerifyImageReason)
+			})
+
+			It("should create the pod once the digest matches and the image verifier pod is gone", func() {
+				vmi := newVMIWithChecksum()
+				dataVolume := NewDv(vmi.Namespace, "test1", cdiv1.Succeeded)
+				dataVolume.Annotations = map[string]string{imageChecksumAnnotation: checksum}
+				addVirtualMachine(vmi)
+				dataVolumeFeeder.Add(dataVolume)
+				shouldExpectPodCreation(vmi.UID)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			})
+
+			It("should fail the VMI if the digest does not match", func() {
+				vmi := newVMIWithChecksum()
+				dataVolume := NewDv(vmi.Namespace, "test1", cdiv1.Succeeded)
+				dataVolume.Annotations = map[string]string{imageChecksumAnnotation: "sha256:0000000000000000000000000000000000000000000000000000000000000000"}
+				addVirtualMachine(vmi)
+				dataVolumeFeeder.Add(dataVolume)
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					updated := arg.(*v1.VirtualMachineInstance)
+					Expect(updated.Status.Phase).To(Equal(v1.Failed))
+					condition := kvcontroller.NewVirtualMachineInstanceConditionManager().GetCondition(updated, v1.VirtualMachineInstanceImagesVerified)
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+					Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonChecksumMismatch))
+				}).Return(vmi, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, v1.VirtualMachineInstanceReasonChecksumMismatch)
+			})
+		})
+
 		It("should create a doppleganger Pod on VMI creation when DataVolume is in WaitForFirstConsumer state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 
@@ -896,6 +1037,85 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			})
 		})
 
+		Context("when an image of the pod can't be pulled", func() {
+			withWaitingContainer := func(pod *k8sv1.Pod, reason string) {
+				pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+					Name:  "volumerootdisk",
+					Image: "registry:5000/kubevirt/missing-container-disk",
+					State: k8sv1.ContainerState{Waiting: &k8sv1.ContainerStateWaiting{
+						Reason:  reason,
+						Message: "manifest unknown",
+					}},
+				}}
+			}
+
+			table.DescribeTable("should report it in the Synchronized condition", func(reason string) {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Status.Phase = v1.Scheduling
+				pod := NewPodForVirtualMachine(vmi, k8sv1.PodPending)
+				withWaitingContainer(pod, reason)
+
+				addVirtualMachine(vmi)
+				podFeeder.Add(pod)
+
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					cond := kvcontroller.NewVirtualMachineInstanceConditionManager().GetCondition(arg.(*v1.VirtualMachineInstance), v1.VirtualMachineInstanceSynchronized)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+					Expect(cond.Reason).To(Equal(reason))
+					Expect(cond.Message).To(Equal("failed to pull image registry:5000/kubevirt/missing-container-disk of container volumerootdisk: manifest unknown"))
+				}).Return(vmi, nil)
+
+				controller.Execute()
+			},
+				table.Entry("with ErrImagePull", ErrImagePullReason),
+				table.Entry("with ImagePullBackOff", ImagePullBackOffReason),
+			)
+
+			It("should replace the reason when the kubelet starts backing off", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Status.Phase = v1.Scheduling
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+					Type:   v1.VirtualMachineInstanceSynchronized,
+					Status: k8sv1.ConditionFalse,
+					Reason: ErrImagePullReason,
+				}}
+				pod := NewPodForVirtualMachine(vmi, k8sv1.PodPending)
+				withWaitingContainer(pod, ImagePullBackOffReason)
+
+				addVirtualMachine(vmi)
+				podFeeder.Add(pod)
+
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					conditions := arg.(*v1.VirtualMachineInstance).Status.Conditions
+					Expect(conditions).To(HaveLen(1))
+					Expect(conditions[0].Reason).To(Equal(ImagePullBackOffReason))
+				}).Return(vmi, nil)
+
+				controller.Execute()
+			})
+
+			It("should remove the condition once the image is pulled", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Status.Phase = v1.Scheduling
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+					Type:   v1.VirtualMachineInstanceSynchronized,
+					Status: k8sv1.ConditionFalse,
+					Reason: ImagePullBackOffReason,
+				}}
+				pod := NewPodForVirtualMachine(vmi, k8sv1.PodPending)
+
+				addVirtualMachine(vmi)
+				podFeeder.Add(pod)
+
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).To(BeEmpty())
+				}).Return(vmi, nil)
+
+				controller.Execute()
+			})
+		})
+
 		Context("when Pod recovers from scheduling issues", func() {
 			table.DescribeTable("it should remove scheduling pod condition from the VirtualMachineInstance if the pod", func(owner string, podPhase k8sv1.PodPhase) {
 				vmi := NewPendingVirtualMachine("testvmi")
@@ -1105,6 +1325,28 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			controller.Execute()
 		})
+		It("should record when the pod got scheduled", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Scheduling
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			scheduled := metav1.NewTime(time.Now().Add(-5 * time.Second).Truncate(time.Second))
+			pod.Status.Conditions = append(pod.Status.Conditions, k8sv1.PodCondition{
+				Type:               k8sv1.PodScheduled,
+				Status:             k8sv1.ConditionTrue,
+				LastTransitionTime: scheduled,
+			})
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				timestamps := arg.(*v1.VirtualMachineInstance).Status.StartupTimestamps
+				Expect(timestamps).ToNot(BeNil())
+				Expect(timestamps.PodScheduled).To(Equal(&scheduled))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
 		It("should update the virtual machine QOS class if the pod finally has a QOS class assigned", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Scheduling
@@ -1268,6 +1510,53 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			testutils.ExpectEvent(recorder, v1.PodTerminatingReason)
 		})
 
+		It("should indicate on the ready condition if the VMI is paused", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstancePaused, Status: k8sv1.ConditionTrue}}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(_ string, _ interface{}, patchBytes []byte) (*v1.VirtualMachineInstance, error) {
+				patch, err := jsonpatch.DecodePatch(patchBytes)
+				Expect(err).ToNot(HaveOccurred())
+				vmiBytes, err := json.Marshal(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				vmiBytes, err = patch.Apply(vmiBytes)
+				Expect(err).ToNot(HaveOccurred())
+				patchedVMI := &v1.VirtualMachineInstance{}
+				err = json.Unmarshal(vmiBytes, patchedVMI)
+				Expect(err).ToNot(HaveOccurred())
+				cond := kvcontroller.NewVirtualMachineInstanceConditionManager().GetCondition(patchedVMI, v1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Reason).To(Equal(v1.PausedReason))
+				Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+				return patchedVMI, nil
+			})
+			controller.Execute()
+		})
+
+		It("should keep the ready condition of a paused VMI", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstancePaused, Status: k8sv1.ConditionTrue},
+				{Type: v1.VirtualMachineInstanceConditionType(k8sv1.PodReady), Status: k8sv1.ConditionFalse, Reason: v1.PausedReason},
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			controller.Execute()
+		})
+
 		It("should add active pods to status if VMI is in running state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = v1.Running
@@ -1345,6 +1634,203 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		)
 	})
 
+	Context("with a masquerade subnet pool", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kubeVirtInformer, &v1.KubeVirt{
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						NetworkConfiguration: &v1.NetworkConfiguration{
+							MasqueradeSubnetPool: &v1.MasqueradeSubnetPool{CIDR: "10.10.0.0/16"},
+						},
+					},
+				},
+				Status: v1.KubeVirtStatus{
+					Phase: v1.KubeVirtPhaseDeployed,
+				},
+			})
+		})
+
+		newMasqueradeVirtualMachine := func(name string, uid types.UID) *v1.VirtualMachineInstance {
+			vmi := NewPendingVirtualMachine(name)
+			vmi.UID = uid
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			return vmi
+		}
+
+		shouldExpectMasqueradeSubnet := func(subnet string) {
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.VMNetworkCIDR).To(Equal(subnet))
+			}).Return(nil, nil)
+		}
+
+		It("should record a subnet on the VirtualMachineInstance before creating its Pod", func() {
+			vmi := newMasqueradeVirtualMachine("testvmi", "1234")
+			addVirtualMachine(vmi)
+
+			shouldExpectMasqueradeSubnet("10.10.0.0/24")
+
+			controller.Execute()
+		})
+
+		It("should skip the subnets used by other VirtualMachineInstances", func() {
+			running := newMasqueradeVirtualMachine("running", "5678")
+			running.Status.Phase = v1.Running
+			running.Status.VMNetworkCIDR = "10.10.0.0/24"
+			Expect(vmiInformer.GetStore().Add(running)).To(Succeed())
+			finished := newMasqueradeVirtualMachine("finished", "9012")
+			finished.Status.Phase = v1.Succeeded
+			finished.Status.VMNetworkCIDR = "10.10.1.0/24"
+			Expect(vmiInformer.GetStore().Add(finished)).To(Succeed())
+
+			vmi := newMasqueradeVirtualMachine("testvmi", "1234")
+			addVirtualMachine(vmi)
+
+			shouldExpectMasqueradeSubnet("10.10.1.0/24")
+
+			controller.Execute()
+		})
+
+		It("should create the Pod once the subnet is recorded", func() {
+			vmi := newMasqueradeVirtualMachine("testvmi", "1234")
+			vmi.Status.VMNetworkCIDR = "10.10.0.0/24"
+			addVirtualMachine(vmi)
+
+			shouldExpectPodCreation(vmi.UID)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should not allocate a subnet when the pod network sets its own CIDR", func() {
+			vmi := newMasqueradeVirtualMachine("testvmi", "1234")
+			vmi.Spec.Networks[0].Pod.VMNetworkCIDR = "192.168.0.0/24"
+			addVirtualMachine(vmi)
+
+			shouldExpectPodCreation(vmi.UID)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should fail when the pool is exhausted", func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kubeVirtInformer, &v1.KubeVirt{
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						NetworkConfiguration: &v1.NetworkConfiguration{
+							MasqueradeSubnetPool: &v1.MasqueradeSubnetPool{CIDR: "10.10.0.0/24"},
+						},
+					},
+				},
+				Status: v1.KubeVirtStatus{
+					Phase: v1.KubeVirtPhaseDeployed,
+				},
+			})
+			running := newMasqueradeVirtualMachine("running", "5678")
+			running.Status.Phase = v1.Running
+			running.Status.VMNetworkCIDR = "10.10.0.0/24"
+			Expect(vmiInformer.GetStore().Add(running)).To(Succeed())
+
+			vmi := newMasqueradeVirtualMachine("testvmi", "1234")
+			addVirtualMachine(vmi)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedAllocateMasqueradeSubnetReason)
+		})
+	})
+
+	Context("with multus networks", func() {
+		newMultusVirtualMachine := func(networkName string) *v1.VirtualMachineInstance {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Domain.Devices.Interfaces[0].Name = "provider"
+			vmi.Spec.Networks = []v1.Network{{
+				Name: "provider",
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: networkName},
+				},
+			}}
+			return vmi
+		}
+
+		newNetworkAttachmentDefinition := func(namespace, name, config string) *networkv1.NetworkAttachmentDefinition {
+			return &networkv1.NetworkAttachmentDefinition{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, ResourceVersion: "1"},
+				Spec:       networkv1.NetworkAttachmentDefinitionSpec{Config: config},
+			}
+		}
+
+		shouldExpectNetworkAttachmentsCondition := func(status k8sv1.ConditionStatus, reason string) {
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				vmi := arg.(*v1.VirtualMachineInstance)
+				Expect(vmi.Status.Phase).To(Equal(v1.Pending))
+				cond := kvcontroller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, v1.VirtualMachineInstanceNetworkAttachmentsReady)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(status))
+				Expect(cond.Reason).To(Equal(reason))
+			}).Return(nil, nil)
+		}
+
+		It("should not create the Pod while the network attachment definition is missing", func() {
+			vmi := newMultusVirtualMachine("provider-net")
+			addVirtualMachine(vmi)
+
+			shouldExpectNetworkAttachmentsCondition(k8sv1.ConditionFalse, v1.VirtualMachineInstanceReasonNetworkAttachmentNotFound)
+
+			controller.Execute()
+		})
+
+		It("should not create the Pod while the network attachment definition is invalid", func() {
+			Expect(networkAttachmentInformer.GetStore().Add(newNetworkAttachmentDefinition("shared", "provider-net", `{"cniVersion": "0.3.1"}`))).To(Succeed())
+			vmi := newMultusVirtualMachine("shared/provider-net")
+			addVirtualMachine(vmi)
+
+			shouldExpectNetworkAttachmentsCondition(k8sv1.ConditionFalse, v1.VirtualMachineInstanceReasonNetworkAttachmentInvalid)
+
+			controller.Execute()
+		})
+
+		It("should create the Pod once the network attachment definition is valid", func() {
+			definition := newNetworkAttachmentDefinition(k8sv1.NamespaceDefault, "provider-net", `{"cniVersion": "0.3.1", "type": "bridge"}`)
+			Expect(networkAttachmentInformer.GetStore().Add(definition)).To(Succeed())
+			_, err := networkClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(k8sv1.NamespaceDefault).Create(definition)
+			Expect(err).ToNot(HaveOccurred())
+			vmi := newMultusVirtualMachine("provider-net")
+			addVirtualMachine(vmi)
+
+			shouldExpectPodCreation(vmi.UID)
+			shouldExpectNetworkAttachmentsCondition(k8sv1.ConditionTrue, "")
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should enqueue the VirtualMachineInstance when its network attachment definition is created", func() {
+			vmi := newMultusVirtualMachine("provider-net")
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+			mockQueue.ExpectAdds(1)
+			networkAttachmentSource.Add(newNetworkAttachmentDefinition(k8sv1.NamespaceDefault, "provider-net", ""))
+			mockQueue.Wait()
+
+			Expect(mockQueue.Len()).To(Equal(1))
+		})
+
+		It("should ignore network attachment definitions referenced by running VirtualMachineInstances", func() {
+			vmi := newMultusVirtualMachine("provider-net")
+			vmi.Status.Phase = v1.Running
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+			controller.addNetworkAttachmentDefinition(newNetworkAttachmentDefinition(k8sv1.NamespaceDefault, "provider-net", ""))
+
+			Expect(mockQueue.Len()).To(Equal(0))
+		})
+	})
+
 	Context("hotplug volume", func() {
 		It("Should find vmi, from virt-launcher pod", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
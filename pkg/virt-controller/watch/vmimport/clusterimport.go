@@ -0,0 +1,641 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vmimport
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	uploadcdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/upload/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/util/pairing"
+)
+
+const (
+	// clusterImportAnnotation names the import which created a VM or
+	// DataVolume, and the transfer pods and Secrets in the peer cluster
+	clusterImportAnnotation = "vmimport.kubevirt.io/cluster-import"
+
+	clusterImportCompleteEvent = "VirtualMachineClusterImportComplete"
+
+	clusterImportErrorEvent = "VirtualMachineClusterImportError"
+
+	// transferPodLabel is the value of the kubevirt.io label of the
+	// transfer pods in the peer cluster
+	transferPodLabel = "cluster-import-transfer"
+
+	transferContainerName = "transfer"
+
+	// keys of the Secret mounted by the transfer pods
+	keyTransferToken = "token"
+	keyTransferCA    = "ca.pem"
+
+	transferSecretDir = "/var/run/kubevirt-transfer"
+	transferDiskDir   = "/var/run/kubevirt-transfer-disk"
+	transferDiskPath  = "/dev/kubevirt-transfer-disk"
+
+	uploadProxyURI = "/v1alpha1/upload"
+	cdiConfigName  = "config"
+
+	// the peer cluster is not watched, the transfers are checked periodically
+	transferCheckInterval = 10 * time.Second
+)
+
+func vmClusterImportFinished(clusterImport *vmimportv1.VirtualMachineClusterImport) bool {
+	return clusterImport.Status != nil &&
+		(clusterImport.Status.Phase == vmimportv1.ClusterImportSucceeded || clusterImport.Status.Phase == vmimportv1.ClusterImportFailed)
+}
+
+func (ctrl *ClusterImportController) updateVMClusterImport(clusterImport *vmimportv1.VirtualMachineClusterImport) error {
+	logger := log.Log.Object(clusterImport)
+
+	logger.V(1).Infof("Updating VirtualMachineClusterImport")
+
+	if vmClusterImportFinished(clusterImport) {
+		return nil
+	}
+
+	if clusterImport.Status == nil || clusterImport.Status.VirtualMachineName == nil {
+		return ctrl.startImport(clusterImport)
+	}
+
+	return ctrl.updateTransfer(clusterImport)
+}
+
+// startImport waits until the source VM is stopped, creates the VM with the
+// spec of the source VM and the DataVolumes its disks are uploaded to
+func (ctrl *ClusterImportController) startImport(clusterImport *vmimportv1.VirtualMachineClusterImport) error {
+	logger := log.Log.Object(clusterImport)
+
+	if ctrl.ClusterConfig.GetDiskTransferImage() == "" {
+		// the configuration may be fixed later, keep trying
+		return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportPending, fmt.Errorf("no disk transfer image is configured"))
+	}
+
+	peer, namespace, err := ctrl.peerClient(clusterImport)
+	if err != nil {
+		return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportPending, err)
+	}
+
+	source := clusterImport.Spec.Source
+	updated := clusterImport.DeepCopy()
+	if updated.Status == nil {
+		updated.Status = &vmimportv1.VirtualMachineClusterImportStatus{}
+	}
+	sourceVM, err := peer.VirtualMachine(namespace).Get(source.Name, &metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportFailed, fmt.Errorf("VirtualMachine %s/%s does not exist in the peer cluster", namespace, source.Name))
+		}
+		return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportPending, fmt.Errorf("failed to get the source VM: %v", err))
+	}
+
+	// the disks can only be copied while no VMI is writing to them, the
+	// memory of a running VM is not transferred
+	if stopped, err := ctrl.sourceStopped(updated, peer, sourceVM); err != nil {
+		return ctrl.doUpdateStatusError(clusterImport, updated, vmimportv1.ClusterImportPending, err)
+	} else if !stopped {
+		return ctrl.doUpdateStatusError(clusterImport, updated, vmimportv1.ClusterImportPending, fmt.Errorf("waiting for the source VM %s/%s to stop", namespace, source.Name))
+	}
+
+	name := source.Name
+	if clusterImport.Spec.VirtualMachineName != nil {
+		name = *clusterImport.Spec.VirtualMachineName
+	}
+	disks, dataVolumes, err := ctrl.transferredDisks(clusterImport, peer, namespace, name, sourceVM)
+	if err != nil {
+		return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportFailed, err)
+	}
+
+	for _, dataVolume := range dataVolumes {
+		if owned, err := ctrl.ensureDataVolume(clusterImport, dataVolume); err != nil {
+			return err
+		} else if !owned {
+			return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportFailed, fmt.Errorf("DataVolume %s already exists", dataVolume.Name))
+		}
+	}
+
+	vm := importedVirtualMachine(clusterImport, name, sourceVM, disks)
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name))
+	if err != nil {
+		return err
+	}
+	if exists {
+		// the status update may have failed after the VM was created
+		if obj.(*kubevirtv1.VirtualMachine).Annotations[clusterImportAnnotation] != clusterImport.Name {
+			return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportFailed, fmt.Errorf("VirtualMachine %s already exists", vm.Name))
+		}
+	} else {
+		if _, err := ctrl.Client.VirtualMachine(vm.Namespace).Create(vm); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportFailed, fmt.Errorf("VirtualMachine %s already exists", vm.Name))
+			}
+			return err
+		}
+		logger.Infof("Created VirtualMachine %s", vm.Name)
+	}
+
+	updated.Status = &vmimportv1.VirtualMachineClusterImportStatus{
+		Phase:              vmimportv1.ClusterImportTransferringDisks,
+		VirtualMachineName: &vm.Name,
+		Disks:              disks,
+		SourceStopped:      updated.Status.SourceStopped,
+	}
+	return ctrl.doUpdate(clusterImport, updated)
+}
+
+// sourceStopped returns whether the source VM has no active VMI, it stops a
+// running source VM if the import asks for it and records that in the status
+func (ctrl *ClusterImportController) sourceStopped(clusterImport *vmimportv1.VirtualMachineClusterImport, peer kubecli.KubevirtClient, sourceVM *kubevirtv1.VirtualMachine) (bool, error) {
+	vmi, err := peer.VirtualMachineInstance(sourceVM.Namespace).Get(sourceVM.Name, &metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the source VMI: %v", err)
+	}
+	if vmi.IsFinal() {
+		return true, nil
+	}
+
+	if runStrategy, err := sourceVM.RunStrategy(); err == nil && clusterImport.Spec.StopSource && runStrategy != kubevirtv1.RunStrategyHalted {
+		if err := peer.VirtualMachine(sourceVM.Namespace).Stop(sourceVM.Name); err != nil {
+			return false, fmt.Errorf("failed to stop the source VM: %v", err)
+		}
+		clusterImport.Status.SourceStopped = true
+		log.Log.Object(clusterImport).Infof("Stopped the source VM %s/%s", sourceVM.Namespace, sourceVM.Name)
+	}
+	return false, nil
+}
+
+// transferredDisks returns the disks of the volumes of the source VM backed
+// by PersistentVolumeClaims, with the DataVolumes they are uploaded to
+func (ctrl *ClusterImportController) transferredDisks(clusterImport *vmimportv1.VirtualMachineClusterImport, peer kubecli.KubevirtClient, namespace string, name string, sourceVM *kubevirtv1.VirtualMachine) ([]vmimportv1.TransferredDisk, []*cdiv1.DataVolume, error) {
+	if sourceVM.Spec.Template == nil {
+		return nil, nil, fmt.Errorf("the source VM has no template")
+	}
+
+	var disks []vmimportv1.TransferredDisk
+	var dataVolumes []*cdiv1.DataVolume
+	for _, volume := range sourceVM.Spec.Template.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claimName = volume.DataVolume.Name
+		default:
+			// the other volumes are part of the spec
+			continue
+		}
+
+		claim, err := peer.CoreV1().PersistentVolumeClaims(namespace).Get(claimName, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get PersistentVolumeClaim %s of the source VM: %v", claimName, err)
+		}
+		size, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !ok {
+			return nil, nil, fmt.Errorf("PersistentVolumeClaim %s of the source VM has no storage request", claimName)
+		}
+
+		disk := vmimportv1.TransferredDisk{
+			VolumeName:      volume.Name,
+			SourceClaimName: claimName,
+			DataVolumeName:  fmt.Sprintf("%s-%s", name, volume.Name),
+		}
+		disks = append(disks, disk)
+		dataVolumes = append(dataVolumes, &cdiv1.DataVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        disk.DataVolumeName,
+				Namespace:   clusterImport.Namespace,
+				Annotations: map[string]string{clusterImportAnnotation: clusterImport.Name},
+			},
+			Spec: cdiv1.DataVolumeSpec{
+				Source: cdiv1.DataVolumeSource{
+					Upload: &cdiv1.DataVolumeSourceUpload{},
+				},
+				PVC: &corev1.PersistentVolumeClaimSpec{
+					AccessModes: claim.Spec.AccessModes,
+					VolumeMode:  claim.Spec.VolumeMode,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: size,
+						},
+					},
+					StorageClassName: clusterImport.Spec.StorageClassName,
+				},
+			},
+		})
+	}
+	return disks, dataVolumes, nil
+}
+
+// updateTransfer starts the transfer pods once the DataVolumes are ready
+// for the upload and removes them once all disks were uploaded
+func (ctrl *ClusterImportController) updateTransfer(clusterImport *vmimportv1.VirtualMachineClusterImport) error {
+	peer, namespace, err := ctrl.peerClient(clusterImport)
+	if err != nil {
+		return ctrl.doUpdateError(clusterImport, vmimportv1.ClusterImportTransferringDisks, err)
+	}
+
+	updated := clusterImport.DeepCopy()
+	succeeded := 0
+	for i := range updated.Status.Disks {
+		disk := &updated.Status.Disks[i]
+		obj, exists, err := ctrl.DataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", clusterImport.Namespace, disk.DataVolumeName))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ctrl.doUpdateError(updated, vmimportv1.ClusterImportFailed, fmt.Errorf("DataVolume %s is gone", disk.DataVolumeName))
+		}
+
+		dataVolume := obj.(*cdiv1.DataVolume)
+		disk.Phase = string(dataVolume.Status.Phase)
+		switch dataVolume.Status.Phase {
+		case cdiv1.Succeeded:
+			succeeded++
+		case cdiv1.Failed:
+			return ctrl.doUpdateError(updated, vmimportv1.ClusterImportFailed, fmt.Errorf("failed to upload disk %s", disk.VolumeName))
+		case cdiv1.UploadReady:
+			if err := ctrl.ensureTransfer(clusterImport, peer, namespace, disk); err != nil {
+				return ctrl.doUpdateError(updated, vmimportv1.ClusterImportFailed, err)
+			}
+		}
+	}
+
+	if succeeded < len(updated.Status.Disks) {
+		ctrl.vmClusterImportQueue.AddAfter(fmt.Sprintf("%s/%s", clusterImport.Namespace, clusterImport.Name), transferCheckInterval)
+		return ctrl.doUpdate(clusterImport, updated)
+	}
+
+	for _, disk := range updated.Status.Disks {
+		name := transferName(clusterImport, disk.VolumeName)
+		err := peer.CoreV1().Pods(namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		err = peer.CoreV1().Secrets(namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if err := ctrl.startImportedVM(updated); err != nil {
+		return err
+	}
+
+	updated.Status.Phase = vmimportv1.ClusterImportSucceeded
+	updated.Status.Message = ""
+	ctrl.Recorder.Eventf(
+		updated,
+		corev1.EventTypeNormal,
+		clusterImportCompleteEvent,
+		"Successfully imported VirtualMachine %s",
+		*updated.Status.VirtualMachineName,
+	)
+	return ctrl.doUpdate(clusterImport, updated)
+}
+
+// startImportedVM starts the VM of an import which stopped the running source
+// VM once its disks were transferred, unless the import sets running
+func (ctrl *ClusterImportController) startImportedVM(clusterImport *vmimportv1.VirtualMachineClusterImport) error {
+	if clusterImport.Spec.Running != nil || !clusterImport.Status.SourceStopped {
+		return nil
+	}
+
+	name := *clusterImport.Status.VirtualMachineName
+	// the patch is idempotent, unlike the start subresource
+	patch := []byte(`{"spec":{"running":true}}`)
+	if _, err := ctrl.Client.VirtualMachine(clusterImport.Namespace).Patch(name, types.MergePatchType, patch); err != nil {
+		return fmt.Errorf("failed to start VirtualMachine %s: %v", name, err)
+	}
+	log.Log.Object(clusterImport).Infof("Started VirtualMachine %s", name)
+	return nil
+}
+
+// ensureTransfer creates the pod uploading a disk in the peer cluster, with
+// the Secret holding the upload token, and reports a failed upload
+func (ctrl *ClusterImportController) ensureTransfer(clusterImport *vmimportv1.VirtualMachineClusterImport, peer kubecli.KubevirtClient, namespace string, disk *vmimportv1.TransferredDisk) error {
+	name := transferName(clusterImport, disk.VolumeName)
+	pod, err := peer.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+	if err == nil {
+		if pod.Status.Phase == corev1.PodFailed {
+			return fmt.Errorf("failed to transfer disk %s: %s", disk.VolumeName, transferMessage(pod))
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		log.Log.Object(clusterImport).Reason(err).Warningf("Failed to get the transfer pod %s", name)
+		return nil
+	}
+
+	claim, err := peer.CoreV1().PersistentVolumeClaims(namespace).Get(disk.SourceClaimName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get PersistentVolumeClaim %s of the source VM: %v", disk.SourceClaimName, err)
+	}
+
+	uploadURL, err := ctrl.uploadURL(clusterImport)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: ctrl.transferObjectMeta(clusterImport, name),
+		Data:       map[string][]byte{},
+	}
+	if clusterImport.Spec.UploadProxyCertConfigMap != "" {
+		configMap, err := ctrl.Client.CoreV1().ConfigMaps(clusterImport.Namespace).Get(clusterImport.Spec.UploadProxyCertConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get ConfigMap %s: %v", clusterImport.Spec.UploadProxyCertConfigMap, err)
+		}
+		secret.Data[keyTransferCA] = []byte(configMap.Data[keyCertConfigMap])
+	}
+
+	// the token expires shortly, it is requested right before the pod
+	// is created
+	tokenRequest, err := ctrl.Client.CdiClient().UploadV1alpha1().UploadTokenRequests(clusterImport.Namespace).Create(&uploadcdiv1.UploadTokenRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: uploadcdiv1.UploadTokenRequestSpec{
+			PvcName: disk.DataVolumeName,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to request the upload token of DataVolume %s: %v", disk.DataVolumeName, err)
+	}
+	secret.Data[keyTransferToken] = []byte(tokenRequest.Status.Token)
+
+	if _, err := peer.CoreV1().Secrets(namespace).Create(secret); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create the transfer Secret %s: %v", name, err)
+		}
+		if _, err := peer.CoreV1().Secrets(namespace).Update(secret); err != nil {
+			return fmt.Errorf("failed to update the transfer Secret %s: %v", name, err)
+		}
+	}
+
+	pod = ctrl.newTransferPod(clusterImport, name, claim, uploadURL)
+	if _, err := peer.CoreV1().Pods(namespace).Create(pod); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create the transfer pod %s: %v", name, err)
+	}
+	log.Log.Object(clusterImport).Infof("Created the transfer pod %s/%s in the peer cluster", namespace, name)
+	return nil
+}
+
+func (ctrl *ClusterImportController) newTransferPod(clusterImport *vmimportv1.VirtualMachineClusterImport, name string, claim *corev1.PersistentVolumeClaim, uploadURL string) *corev1.Pod {
+	container := corev1.Container{
+		Name:  transferContainerName,
+		Image: ctrl.ClusterConfig.GetDiskTransferImage(),
+		Env: []corev1.EnvVar{
+			{Name: "UPLOAD_URL", Value: uploadURL},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "transfer", MountPath: transferSecretDir, ReadOnly: true},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if claim.Spec.VolumeMode != nil && *claim.Spec.VolumeMode == corev1.PersistentVolumeBlock {
+		container.VolumeDevices = []corev1.VolumeDevice{{Name: "disk", DevicePath: transferDiskPath}}
+		container.Command = []string{"/bin/sh", "-c", transferScript(transferDiskPath)}
+	} else {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "disk", MountPath: transferDiskDir, ReadOnly: true})
+		container.Command = []string{"/bin/sh", "-c", transferScript(path.Join(transferDiskDir, "disk.img"))}
+	}
+
+	disk := claimVolume("disk", claim.Name)
+	disk.PersistentVolumeClaim.ReadOnly = true
+	objectMeta := ctrl.transferObjectMeta(clusterImport, name)
+	objectMeta.Labels = map[string]string{kubevirtv1.AppLabel: transferPodLabel}
+	return &corev1.Pod{
+		ObjectMeta: objectMeta,
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers:    []corev1.Container{container},
+			Volumes: []corev1.Volume{
+				disk,
+				{
+					Name:         "transfer",
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: name}},
+				},
+			},
+		},
+	}
+}
+
+// transferObjectMeta is the metadata of the objects in the peer cluster,
+// which can't be owned by the import
+func (ctrl *ClusterImportController) transferObjectMeta(clusterImport *vmimportv1.VirtualMachineClusterImport, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name: name,
+		Annotations: map[string]string{
+			clusterImportAnnotation: fmt.Sprintf("%s/%s", clusterImport.Namespace, clusterImport.Name),
+		},
+	}
+}
+
+// uploadURL returns the URL of the upload proxy of this cluster the
+// transfer pods post the disks to
+func (ctrl *ClusterImportController) uploadURL(clusterImport *vmimportv1.VirtualMachineClusterImport) (string, error) {
+	proxyURL := clusterImport.Spec.UploadProxyURL
+	if proxyURL == "" {
+		cdiConfig, err := ctrl.Client.CdiClient().CdiV1alpha1().CDIConfigs().Get(cdiConfigName, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get the CDIConfig: %v", err)
+		}
+		if cdiConfig.Spec.UploadProxyURLOverride != nil {
+			proxyURL = *cdiConfig.Spec.UploadProxyURLOverride
+		} else if cdiConfig.Status.UploadProxyURL != nil {
+			proxyURL = *cdiConfig.Status.UploadProxyURL
+		}
+	}
+	if proxyURL == "" {
+		return "", fmt.Errorf("the upload proxy URL is neither set in the import nor in the CDIConfig")
+	}
+	if !strings.Contains(proxyURL, "://") {
+		proxyURL = "https://" + proxyURL
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid upload proxy URL: %v", err)
+	}
+	u.Path = path.Join(u.Path, uploadProxyURI)
+	return u.String(), nil
+}
+
+// peerClient returns the client of the peer cluster and the namespace of
+// the source VM
+func (ctrl *ClusterImportController) peerClient(clusterImport *vmimportv1.VirtualMachineClusterImport) (kubecli.KubevirtClient, string, error) {
+	source := clusterImport.Spec.Source
+	secret, err := ctrl.Client.CoreV1().Secrets(clusterImport.Namespace).Get(source.PairingSecretRef, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get the pairing Secret %s: %v", source.PairingSecretRef, err)
+	}
+
+	namespace, err := pairing.Namespace(secret, source.Namespace)
+	if err != nil {
+		return nil, "", err
+	}
+
+	peer, err := ctrl.PeerClient(secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create the client of the peer cluster: %v", err)
+	}
+	return peer, namespace, nil
+}
+
+// ensureDataVolume creates the DataVolume unless it exists already, false is
+// returned if it was not created by the import
+func (ctrl *ClusterImportController) ensureDataVolume(clusterImport *vmimportv1.VirtualMachineClusterImport, dataVolume *cdiv1.DataVolume) (bool, error) {
+	obj, exists, err := ctrl.DataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", dataVolume.Namespace, dataVolume.Name))
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return obj.(*cdiv1.DataVolume).Annotations[clusterImportAnnotation] == clusterImport.Name, nil
+	}
+
+	// an AlreadyExists error is retried, the owner is checked once the
+	// cache caught up
+	if _, err := ctrl.Client.CdiClient().CdiV1alpha1().DataVolumes(dataVolume.Namespace).Create(dataVolume); err != nil {
+		return false, err
+	}
+	log.Log.Object(clusterImport).Infof("Created DataVolume %s", dataVolume.Name)
+	return true, nil
+}
+
+// doUpdateError moves the import to phase and reports err, the error is only
+// returned to retry for phases which are not final
+func (ctrl *ClusterImportController) doUpdateError(clusterImport *vmimportv1.VirtualMachineClusterImport, phase vmimportv1.VirtualMachineClusterImportPhase, err error) error {
+	return ctrl.doUpdateStatusError(clusterImport, clusterImport.DeepCopy(), phase, err)
+}
+
+// doUpdateStatusError is doUpdateError for an import whose status was
+// changed already
+func (ctrl *ClusterImportController) doUpdateStatusError(clusterImport, updated *vmimportv1.VirtualMachineClusterImport, phase vmimportv1.VirtualMachineClusterImportPhase, err error) error {
+	ctrl.Recorder.Eventf(
+		clusterImport,
+		corev1.EventTypeWarning,
+		clusterImportErrorEvent,
+		"VirtualMachineClusterImport encountered error %s",
+		err.Error(),
+	)
+
+	if updated.Status == nil {
+		updated.Status = &vmimportv1.VirtualMachineClusterImportStatus{}
+	}
+	updated.Status.Phase = phase
+	updated.Status.Message = err.Error()
+	if err2 := ctrl.doUpdate(clusterImport, updated); err2 != nil {
+		return err2
+	}
+
+	if phase == vmimportv1.ClusterImportFailed {
+		return nil
+	}
+	return err
+}
+
+func (ctrl *ClusterImportController) doUpdate(original, updated *vmimportv1.VirtualMachineClusterImport) error {
+	if !reflect.DeepEqual(original, updated) {
+		if _, err := ctrl.Client.VirtualMachineClusterImport(updated.Namespace).Update(updated); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importedVirtualMachine copies the spec of the source VM, its volumes
+// backed by PersistentVolumeClaims use the DataVolumes the disks are
+// uploaded to
+func importedVirtualMachine(clusterImport *vmimportv1.VirtualMachineClusterImport, name string, sourceVM *kubevirtv1.VirtualMachine, disks []vmimportv1.TransferredDisk) *kubevirtv1.VirtualMachine {
+	vm := &kubevirtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   clusterImport.Namespace,
+			Labels:      sourceVM.Labels,
+			Annotations: map[string]string{clusterImportAnnotation: clusterImport.Name},
+		},
+		Spec: *sourceVM.Spec.DeepCopy(),
+	}
+
+	running := false
+	if clusterImport.Spec.Running != nil {
+		running = *clusterImport.Spec.Running
+	}
+	vm.Spec.Running = &running
+	vm.Spec.RunStrategy = nil
+	// the DataVolumes are created by the import
+	vm.Spec.DataVolumeTemplates = nil
+
+	dataVolumes := map[string]string{}
+	for _, disk := range disks {
+		dataVolumes[disk.VolumeName] = disk.DataVolumeName
+	}
+	volumes := vm.Spec.Template.Spec.Volumes
+	for i := range volumes {
+		if dataVolume, ok := dataVolumes[volumes[i].Name]; ok {
+			volumes[i].VolumeSource = kubevirtv1.VolumeSource{
+				DataVolume: &kubevirtv1.DataVolumeSource{Name: dataVolume},
+			}
+		}
+	}
+	return vm
+}
+
+// transferScript posts the disk to the upload proxy, with the CA of the
+// transfer Secret if it has one
+func transferScript(diskPath string) string {
+	return fmt.Sprintf(`set -e
+cacert=""
+if [ -s %[1]s/%[2]s ]; then
+  cacert="--cacert %[1]s/%[2]s"
+fi
+curl --fail --silent --show-error $cacert -X POST -T %[3]s \
+  -H "Authorization: Bearer $(cat %[1]s/%[4]s)" "$UPLOAD_URL"
+`, transferSecretDir, keyTransferCA, diskPath, keyTransferToken)
+}
+
+func transferMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == transferContainerName && status.State.Terminated != nil {
+			return strings.TrimSpace(status.State.Terminated.Message)
+		}
+	}
+	return ""
+}
+
+func transferName(clusterImport *vmimportv1.VirtualMachineClusterImport, volumeName string) string {
+	return fmt.Sprintf("%s-%s-transfer", clusterImport.Name, volumeName)
+}
@@ -0,0 +1,192 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vmimport
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/util/pairing"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// ClusterImportController migrates stopped VMs from peer clusters running
+// KubeVirt, pods in the peer cluster upload their disks to DataVolumes
+type ClusterImportController struct {
+	Client kubecli.KubevirtClient
+
+	VMClusterImportInformer cache.SharedIndexInformer
+	VMInformer              cache.SharedIndexInformer
+	DataVolumeInformer      cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+
+	Recorder record.EventRecorder
+
+	// PeerClient returns a client of the peer cluster a pairing Secret
+	// grants access to, defaults to a client using its server and token
+	PeerClient func(secret *corev1.Secret) (kubecli.KubevirtClient, error)
+
+	vmClusterImportQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the cluster import controller
+func (ctrl *ClusterImportController) Init() {
+	ctrl.vmClusterImportQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "vmimport-controller-vmclusterimport")
+
+	if ctrl.PeerClient == nil {
+		ctrl.PeerClient = pairing.NewPeerClient
+	}
+
+	ctrl.VMClusterImportInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMClusterImport,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMClusterImport(newObj) },
+		},
+	)
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleTransferred,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleTransferred(newObj) },
+			DeleteFunc: ctrl.handleTransferred,
+		},
+	)
+
+	ctrl.DataVolumeInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleTransferred,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleTransferred(newObj) },
+			DeleteFunc: ctrl.handleTransferred,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *ClusterImportController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmClusterImportQueue.ShutDown()
+
+	log.Log.Info("Starting cluster import controller.")
+	defer log.Log.Info("Shutting down cluster import controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMClusterImportInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.DataVolumeInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmClusterImportWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *ClusterImportController) vmClusterImportWorker() {
+	for ctrl.processVMClusterImportWorkItem() {
+	}
+}
+
+func (ctrl *ClusterImportController) processVMClusterImportWorkItem() bool {
+	key, quit := ctrl.vmClusterImportQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.vmClusterImportQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachineClusterImport %v", key)
+		ctrl.vmClusterImportQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachineClusterImport %v", key)
+		ctrl.vmClusterImportQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *ClusterImportController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMClusterImportInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	clusterImport, ok := storeObj.(*vmimportv1.VirtualMachineClusterImport)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMClusterImport(clusterImport.DeepCopy())
+}
+
+func (ctrl *ClusterImportController) handleVMClusterImport(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if clusterImport, ok := obj.(*vmimportv1.VirtualMachineClusterImport); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(clusterImport)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, clusterImport)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmClusterImportQueue.Add(objName)
+	}
+}
+
+// handleTransferred enqueues the import which created the VM or DataVolume
+func (ctrl *ClusterImportController) handleTransferred(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	var namespace string
+	var annotations map[string]string
+	switch transferred := obj.(type) {
+	case *kubevirtv1.VirtualMachine:
+		namespace, annotations = transferred.Namespace, transferred.Annotations
+	case *cdiv1.DataVolume:
+		namespace, annotations = transferred.Namespace, transferred.Annotations
+	default:
+		return
+	}
+
+	if importName, ok := annotations[clusterImportAnnotation]; ok {
+		ctrl.vmClusterImportQueue.Add(fmt.Sprintf("%s/%s", namespace, importName))
+	}
+}
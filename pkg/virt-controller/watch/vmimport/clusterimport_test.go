@@ -0,0 +1,440 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vmimport
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	uploadcdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/upload/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/pairing"
+)
+
+var _ = Describe("Cluster import", func() {
+
+	const peerNamespace = "source"
+
+	var ctrl *gomock.Controller
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var peerVMInterface *kubecli.MockVirtualMachineInterface
+	var peerVMIInterface *kubecli.MockVirtualMachineInstanceInterface
+	var k8sClient *fake.Clientset
+	var peerK8sClient *fake.Clientset
+	var cdiClient *cdifake.Clientset
+	var kubevirtClient *kubevirtfake.Clientset
+	var vmClusterImportInformer cache.SharedIndexInformer
+	var vmInformer cache.SharedIndexInformer
+	var dataVolumeInformer cache.SharedIndexInformer
+	var recorder *record.FakeRecorder
+	var controller *ClusterImportController
+	var updated *vmimportv1.VirtualMachineClusterImport
+
+	newClusterImport := func() *vmimportv1.VirtualMachineClusterImport {
+		return &vmimportv1.VirtualMachineClusterImport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "import",
+				Namespace: testNamespace,
+			},
+			Spec: vmimportv1.VirtualMachineClusterImportSpec{
+				Source: vmimportv1.ClusterImportSource{
+					PairingSecretRef: "peer",
+					Name:             "web01",
+				},
+				UploadProxyURL: "cdi-uploadproxy.example.com",
+			},
+		}
+	}
+
+	transferringImport := func() *vmimportv1.VirtualMachineClusterImport {
+		clusterImport := newClusterImport()
+		clusterImport.Status = &vmimportv1.VirtualMachineClusterImportStatus{
+			Phase:              vmimportv1.ClusterImportTransferringDisks,
+			VirtualMachineName: &[]string{"web01"}[0],
+			Disks: []vmimportv1.TransferredDisk{{
+				VolumeName:      "rootdisk",
+				SourceClaimName: "web01-rootdisk",
+				DataVolumeName:  "web01-rootdisk",
+			}},
+		}
+		return clusterImport
+	}
+
+	newSourceVM := func(running bool) *v1.VirtualMachine {
+		return &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web01",
+				Namespace: peerNamespace,
+				Labels:    map[string]string{"app": "web"},
+			},
+			Spec: v1.VirtualMachineSpec{
+				Running: &running,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "rootdisk",
+								VolumeSource: v1.VolumeSource{
+									DataVolume: &v1.DataVolumeSource{Name: "web01-rootdisk"},
+								},
+							},
+							{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	addSourceClaim := func() {
+		_, err := peerK8sClient.CoreV1().PersistentVolumeClaims(peerNamespace).Create(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web01-rootdisk",
+				Namespace: peerNamespace,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("20Gi"),
+					},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	addDataVolume := func(phase cdiv1.DataVolumePhase) {
+		Expect(dataVolumeInformer.GetStore().Add(&cdiv1.DataVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "web01-rootdisk",
+				Namespace:   testNamespace,
+				Annotations: map[string]string{clusterImportAnnotation: "import"},
+			},
+			Status: cdiv1.DataVolumeStatus{Phase: phase},
+		})).To(Succeed())
+	}
+
+	process := func(clusterImport *vmimportv1.VirtualMachineClusterImport) error {
+		Expect(vmClusterImportInformer.GetStore().Add(clusterImport)).To(Succeed())
+		return controller.execute(testNamespace + "/" + clusterImport.Name)
+	}
+
+	setTransferImage := func(image string) {
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{DiskTransferImage: image},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		controller.ClusterConfig = config
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		peerClient := kubecli.NewMockKubevirtClient(ctrl)
+		peerVMInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		peerVMIInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		vmClusterImportInformer, _ = testutils.NewFakeInformerFor(&vmimportv1.VirtualMachineClusterImport{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		dataVolumeInformer, _ = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &ClusterImportController{
+			Client:                  virtClient,
+			VMClusterImportInformer: vmClusterImportInformer,
+			VMInformer:              vmInformer,
+			DataVolumeInformer:      dataVolumeInformer,
+			Recorder:                recorder,
+			PeerClient: func(secret *corev1.Secret) (kubecli.KubevirtClient, error) {
+				Expect(secret.Name).To(Equal("peer"))
+				return peerClient, nil
+			},
+		}
+		controller.Init()
+		setTransferImage("quay.io/kubevirt/disk-transfer:latest")
+
+		virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+
+		k8sClient = fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "peer",
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				pairing.KeyServer:    []byte("https://api.peer.example.com:6443"),
+				pairing.KeyToken:     []byte("peer-token"),
+				pairing.KeyNamespace: []byte(peerNamespace),
+			},
+		})
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+		cdiClient = cdifake.NewSimpleClientset()
+		cdiClient.Fake.PrependReactor("create", "uploadtokenrequests", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			request := action.(testing.CreateAction).GetObject().(*uploadcdiv1.UploadTokenRequest)
+			request.Status.Token = "upload-token"
+			return true, request, nil
+		})
+		virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+
+		peerClient.EXPECT().VirtualMachine(peerNamespace).Return(peerVMInterface).AnyTimes()
+		peerClient.EXPECT().VirtualMachineInstance(peerNamespace).Return(peerVMIInterface).AnyTimes()
+		peerK8sClient = fake.NewSimpleClientset()
+		peerClient.EXPECT().CoreV1().Return(peerK8sClient.CoreV1()).AnyTimes()
+
+		updated = nil
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		kubevirtClient.Fake.PrependReactor("update", "virtualmachineclusterimports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			updated = action.(testing.UpdateAction).GetObject().(*vmimportv1.VirtualMachineClusterImport)
+			return true, updated, nil
+		})
+		virtClient.EXPECT().VirtualMachineClusterImport(testNamespace).
+			Return(kubevirtClient.VmimportV1alpha1().VirtualMachineClusterImports(testNamespace)).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("before the transfer", func() {
+
+		It("should stay pending without a disk transfer image", func() {
+			setTransferImage("")
+
+			Expect(process(newClusterImport())).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportPending))
+			Expect(updated.Status.Message).To(ContainSubstring("no disk transfer image"))
+		})
+
+		It("should fail if the source VM does not exist", func() {
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).
+				Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), "web01"))
+
+			Expect(process(newClusterImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportFailed))
+		})
+
+		It("should wait for a running source VM to stop", func() {
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(newSourceVM(true), nil)
+			peerVMIInterface.EXPECT().Get("web01", gomock.Any()).Return(&v1.VirtualMachineInstance{
+				Status: v1.VirtualMachineInstanceStatus{Phase: v1.Running},
+			}, nil)
+
+			Expect(process(newClusterImport())).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportPending))
+			Expect(updated.Status.Message).To(ContainSubstring("waiting for the source VM"))
+		})
+
+		It("should stop the source VM if asked for it", func() {
+			clusterImport := newClusterImport()
+			clusterImport.Spec.StopSource = true
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(newSourceVM(true), nil)
+			peerVMIInterface.EXPECT().Get("web01", gomock.Any()).Return(&v1.VirtualMachineInstance{
+				Status: v1.VirtualMachineInstanceStatus{Phase: v1.Running},
+			}, nil)
+			peerVMInterface.EXPECT().Stop("web01").Return(nil)
+
+			Expect(process(clusterImport)).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportPending))
+			Expect(updated.Status.SourceStopped).To(BeTrue())
+		})
+
+		It("should stop a source VM with a run strategy", func() {
+			clusterImport := newClusterImport()
+			clusterImport.Spec.StopSource = true
+			sourceVM := newSourceVM(false)
+			sourceVM.Spec.Running = nil
+			runStrategy := v1.RunStrategyAlways
+			sourceVM.Spec.RunStrategy = &runStrategy
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(sourceVM, nil)
+			peerVMIInterface.EXPECT().Get("web01", gomock.Any()).Return(&v1.VirtualMachineInstance{
+				Status: v1.VirtualMachineInstanceStatus{Phase: v1.Running},
+			}, nil)
+			peerVMInterface.EXPECT().Stop("web01").Return(nil)
+
+			Expect(process(clusterImport)).ToNot(Succeed())
+			Expect(updated.Status.SourceStopped).To(BeTrue())
+		})
+
+		It("should keep that it stopped the source VM when creating the VM", func() {
+			addSourceClaim()
+			clusterImport := newClusterImport()
+			clusterImport.Spec.StopSource = true
+			clusterImport.Status = &vmimportv1.VirtualMachineClusterImportStatus{
+				Phase:         vmimportv1.ClusterImportPending,
+				SourceStopped: true,
+			}
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(newSourceVM(false), nil)
+			peerVMIInterface.EXPECT().Get("web01", gomock.Any()).
+				Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), "web01"))
+			vmInterface.EXPECT().Create(gomock.Any()).Return(&v1.VirtualMachine{}, nil)
+
+			Expect(process(clusterImport)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportTransferringDisks))
+			Expect(updated.Status.SourceStopped).To(BeTrue())
+		})
+
+		It("should create the DataVolumes and the VM of a stopped source VM", func() {
+			addSourceClaim()
+			peerVMInterface.EXPECT().Get("web01", gomock.Any()).Return(newSourceVM(false), nil)
+			peerVMIInterface.EXPECT().Get("web01", gomock.Any()).
+				Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), "web01"))
+
+			var vm *v1.VirtualMachine
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(created *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				vm = created
+				return created, nil
+			})
+
+			Expect(process(newClusterImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportTransferringDisks))
+			Expect(*updated.Status.VirtualMachineName).To(Equal("web01"))
+			Expect(updated.Status.Disks).To(HaveLen(1))
+			Expect(updated.Status.Disks[0].SourceClaimName).To(Equal("web01-rootdisk"))
+
+			dataVolume, err := cdiClient.CdiV1alpha1().DataVolumes(testNamespace).Get("web01-rootdisk", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dataVolume.Spec.Source.Upload).ToNot(BeNil())
+			Expect(dataVolume.Spec.PVC.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("20Gi")))
+
+			Expect(vm.Annotations).To(HaveKeyWithValue(clusterImportAnnotation, "import"))
+			Expect(vm.Labels).To(HaveKeyWithValue("app", "web"))
+			Expect(*vm.Spec.Running).To(BeFalse())
+			volumes := vm.Spec.Template.Spec.Volumes
+			Expect(volumes[0].DataVolume.Name).To(Equal("web01-rootdisk"))
+			Expect(volumes[1].CloudInitNoCloud).ToNot(BeNil())
+		})
+	})
+
+	Context("while transferring the disks", func() {
+
+		BeforeEach(func() {
+			addSourceClaim()
+		})
+
+		It("should start the transfer once the DataVolume is ready for the upload", func() {
+			addDataVolume(cdiv1.UploadReady)
+
+			Expect(process(transferringImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportTransferringDisks))
+			Expect(updated.Status.Disks[0].Phase).To(Equal(string(cdiv1.UploadReady)))
+
+			secret, err := peerK8sClient.CoreV1().Secrets(peerNamespace).Get("import-rootdisk-transfer", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(secret.Data[keyTransferToken])).To(Equal("upload-token"))
+
+			pod, err := peerK8sClient.CoreV1().Pods(peerNamespace).Get("import-rootdisk-transfer", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Labels).To(HaveKeyWithValue(v1.AppLabel, transferPodLabel))
+			Expect(pod.Annotations).To(HaveKeyWithValue(clusterImportAnnotation, testNamespace+"/import"))
+			container := pod.Spec.Containers[0]
+			Expect(container.Image).To(Equal("quay.io/kubevirt/disk-transfer:latest"))
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "UPLOAD_URL", Value: "https://cdi-uploadproxy.example.com/v1alpha1/upload"}))
+			Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("web01-rootdisk"))
+		})
+
+		It("should fail if the transfer pod failed", func() {
+			addDataVolume(cdiv1.UploadReady)
+			_, err := peerK8sClient.CoreV1().Pods(peerNamespace).Create(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "import-rootdisk-transfer"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: transferContainerName,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Message: "connection refused"},
+						},
+					}},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(process(transferringImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportFailed))
+			Expect(updated.Status.Message).To(ContainSubstring("connection refused"))
+		})
+
+		It("should succeed and clean up the peer cluster once all disks were uploaded", func() {
+			addDataVolume(cdiv1.Succeeded)
+			_, err := peerK8sClient.CoreV1().Pods(peerNamespace).Create(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "import-rootdisk-transfer"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(process(transferringImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportSucceeded))
+			Expect(recorder.Events).To(Receive(ContainSubstring(clusterImportCompleteEvent)))
+
+			_, err = peerK8sClient.CoreV1().Pods(peerNamespace).Get("import-rootdisk-transfer", metav1.GetOptions{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should start the VM once all disks were uploaded if it stopped the source VM", func() {
+			addDataVolume(cdiv1.Succeeded)
+			clusterImport := transferringImport()
+			clusterImport.Status.SourceStopped = true
+			vmInterface.EXPECT().Patch("web01", types.MergePatchType, []byte(`{"spec":{"running":true}}`)).Return(&v1.VirtualMachine{}, nil)
+
+			Expect(process(clusterImport)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportSucceeded))
+		})
+
+		It("should not start the VM if the import sets running", func() {
+			addDataVolume(cdiv1.Succeeded)
+			clusterImport := transferringImport()
+			clusterImport.Status.SourceStopped = true
+			running := false
+			clusterImport.Spec.Running = &running
+
+			Expect(process(clusterImport)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ClusterImportSucceeded))
+		})
+	})
+})
@@ -0,0 +1,586 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmimport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/ovf"
+	"kubevirt.io/kubevirt/pkg/util/net/allowlist"
+)
+
+const (
+	// ovfImportAnnotation names the import which created a VM or DataVolume
+	ovfImportAnnotation = "vmimport.kubevirt.io/ovf-import"
+
+	ovfImportCompleteEvent = "VirtualMachineOVFImportComplete"
+
+	ovfImportErrorEvent = "VirtualMachineOVFImportError"
+
+	// keys of the Secret and ConfigMap of the source, the same CDI uses
+	keyAccess        = "accessKeyId"
+	keySecret        = "secretKey"
+	keyCertConfigMap = "ca.pem"
+
+	descriptorTimeout = time.Minute
+
+	// archivePodLabel is the value of the kubevirt.io label of the pods
+	// extracting the disks of OVA archives
+	archivePodLabel = "ovf-import"
+
+	archiveContainerName = "extract"
+)
+
+var ovfImportGroupVersionKind = vmimportv1.SchemeGroupVersion.WithKind("VirtualMachineOVFImport")
+
+func vmOVFImportFinished(ovfImport *vmimportv1.VirtualMachineOVFImport) bool {
+	return ovfImport.Status != nil &&
+		(ovfImport.Status.Phase == vmimportv1.ImportSucceeded || ovfImport.Status.Phase == vmimportv1.ImportFailed)
+}
+
+func (ctrl *OVFImportController) updateVMOVFImport(ovfImport *vmimportv1.VirtualMachineOVFImport) error {
+	logger := log.Log.Object(ovfImport)
+
+	logger.V(1).Infof("Updating VirtualMachineOVFImport")
+
+	if vmOVFImportFinished(ovfImport) {
+		return nil
+	}
+
+	if ovfImport.Status == nil || ovfImport.Status.VirtualMachineName == nil {
+		return ctrl.createVirtualMachine(ovfImport)
+	}
+
+	return ctrl.updateDiskStatus(ovfImport)
+}
+
+// createVirtualMachine maps the descriptor to a VM and creates it, the
+// DataVolumeTemplates of the VM import the disks
+func (ctrl *OVFImportController) createVirtualMachine(ovfImport *vmimportv1.VirtualMachineOVFImport) error {
+	logger := log.Log.Object(ovfImport)
+
+	envelope, err := ctrl.readDescriptor(ovfImport)
+	if err != nil {
+		// the server may be back later, keep trying
+		logger.Reason(err).Error("Error reading the OVF descriptor")
+		return ctrl.doUpdateError(ovfImport, vmimportv1.ImportPending, err)
+	}
+
+	vm, disks, err := ovf.ToVirtualMachine(envelope, ovfOptions(ovfImport))
+	if err != nil {
+		logger.Reason(err).Error("Error mapping the OVF descriptor")
+		return ctrl.doUpdateError(ovfImport, vmimportv1.ImportFailed, err)
+	}
+	// CDI passes the credentials of the source to the disk locations as well
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		if source := template.Spec.Source.HTTP; source != nil {
+			if err := allowlist.CheckURL(source.URL, ctrl.ClusterConfig.GetAllowedImportURLs()); err != nil {
+				return ctrl.doUpdateError(ovfImport, vmimportv1.ImportFailed, fmt.Errorf("invalid location of DataVolume %s: %v", template.Name, err))
+			}
+		}
+	}
+
+	if envelope.Archive {
+		if ctrl.ClusterConfig.GetV2VConversionImage() == "" {
+			// the configuration may be fixed later, keep trying
+			return ctrl.doUpdateError(ovfImport, vmimportv1.ImportPending, fmt.Errorf("no conversion image is configured to extract the OVA archive"))
+		}
+		size, err := ovf.ArchiveSize(envelope)
+		if err != nil {
+			return ctrl.doUpdateError(ovfImport, vmimportv1.ImportFailed, err)
+		}
+		if owned, err := ctrl.ensureDataVolume(ovfImport, ctrl.newArchiveDataVolume(ovfImport, size)); err != nil {
+			return err
+		} else if !owned {
+			return ctrl.doUpdateError(ovfImport, vmimportv1.ImportFailed, fmt.Errorf("DataVolume %s already exists", archiveDataVolumeName(ovfImport)))
+		}
+		// the VM is started once its disks were extracted
+		running := false
+		vm.Spec.Running = &running
+	}
+
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name))
+	if err != nil {
+		return err
+	}
+	if exists {
+		// the status update may have failed after the VM was created
+		if obj.(*kubevirtv1.VirtualMachine).Annotations[ovfImportAnnotation] != ovfImport.Name {
+			return ctrl.doUpdateError(ovfImport, vmimportv1.ImportFailed, fmt.Errorf("VirtualMachine %s already exists", vm.Name))
+		}
+	} else {
+		setImportAnnotation(&vm.ObjectMeta, ovfImport.Name)
+		for i := range vm.Spec.DataVolumeTemplates {
+			setImportAnnotation(&vm.Spec.DataVolumeTemplates[i].ObjectMeta, ovfImport.Name)
+		}
+		if _, err := ctrl.Client.VirtualMachine(vm.Namespace).Create(vm); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return ctrl.doUpdateError(ovfImport, vmimportv1.ImportFailed, fmt.Errorf("VirtualMachine %s already exists", vm.Name))
+			}
+			return err
+		}
+		logger.Infof("Created VirtualMachine %s", vm.Name)
+	}
+
+	updated := ovfImport.DeepCopy()
+	updated.Status = &vmimportv1.VirtualMachineOVFImportStatus{
+		Phase:              vmimportv1.ImportingDisks,
+		VirtualMachineName: &vm.Name,
+	}
+	for _, disk := range disks {
+		updated.Status.Disks = append(updated.Status.Disks, vmimportv1.DiskImportStatus{
+			DiskID:         disk.DiskID,
+			DataVolumeName: disk.DataVolumeName,
+			File:           disk.File,
+		})
+	}
+	return ctrl.doUpdate(ovfImport, updated)
+}
+
+// updateDiskStatus reflects the DataVolumes importing the disks in the status
+func (ctrl *OVFImportController) updateDiskStatus(ovfImport *vmimportv1.VirtualMachineOVFImport) error {
+	updated := ovfImport.DeepCopy()
+
+	var failed []string
+	succeeded := 0
+	progress := float64(0)
+	for i := range updated.Status.Disks {
+		disk := &updated.Status.Disks[i]
+		obj, exists, err := ctrl.DataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", ovfImport.Namespace, disk.DataVolumeName))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			disk.Phase, disk.Progress = "", ""
+			continue
+		}
+
+		dataVolume := obj.(*cdiv1.DataVolume)
+		disk.Phase = string(dataVolume.Status.Phase)
+		disk.Progress = string(dataVolume.Status.Progress)
+		switch dataVolume.Status.Phase {
+		case cdiv1.Succeeded:
+			succeeded++
+			progress += 100
+		case cdiv1.Failed:
+			failed = append(failed, disk.DiskID)
+		default:
+			progress += parseProgress(dataVolume.Status.Progress)
+		}
+	}
+
+	if len(updated.Status.Disks) > 0 {
+		progress /= float64(len(updated.Status.Disks))
+	} else {
+		progress = 100
+	}
+	updated.Status.Progress = fmt.Sprintf("%.2f%%", progress)
+
+	if len(failed) > 0 {
+		err := fmt.Errorf("failed to import disk(s) %s", strings.Join(failed, ", "))
+		return ctrl.doUpdateError(updated, vmimportv1.ImportFailed, err)
+	}
+
+	if archiveImport(updated) {
+		// the blank DataVolumes succeed right away, the disks are only
+		// imported once they were extracted from the archive
+		extracted, failure, err := ctrl.extractArchive(updated, succeeded == len(updated.Status.Disks))
+		if err != nil {
+			return err
+		}
+		if failure != nil {
+			return ctrl.doUpdateError(updated, vmimportv1.ImportFailed, failure)
+		}
+		if !extracted {
+			return ctrl.doUpdate(ovfImport, updated)
+		}
+	}
+
+	if succeeded == len(updated.Status.Disks) {
+		updated.Status.Phase = vmimportv1.ImportSucceeded
+		updated.Status.Message = ""
+		ctrl.Recorder.Eventf(
+			updated,
+			corev1.EventTypeNormal,
+			ovfImportCompleteEvent,
+			"Successfully imported VirtualMachine %s",
+			*updated.Status.VirtualMachineName,
+		)
+	}
+
+	return ctrl.doUpdate(ovfImport, updated)
+}
+
+// extractArchive reflects the import of the OVA archive in the status and
+// extracts its disks once CDI imported it and the blank DataVolumes of the
+// disks are ready. The VM is started once the disks were extracted, if it is
+// requested, and true is returned. A failure of the import is returned
+// separately from errors which are retried.
+func (ctrl *OVFImportController) extractArchive(ovfImport *vmimportv1.VirtualMachineOVFImport, disksReady bool) (bool, error, error) {
+	name := archiveDataVolumeName(ovfImport)
+	obj, archiveExists, err := ctrl.DataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", ovfImport.Namespace, name))
+	if err != nil {
+		return false, nil, err
+	}
+	if archiveExists {
+		archive := obj.(*cdiv1.DataVolume)
+		switch archive.Status.Phase {
+		case cdiv1.Failed:
+			return false, fmt.Errorf("failed to import the OVA archive"), nil
+		case cdiv1.Succeeded:
+		default:
+			ovfImport.Status.Progress = fmt.Sprintf("%.2f%%", parseProgress(archive.Status.Progress))
+			return false, nil, nil
+		}
+	}
+	ovfImport.Status.Progress = "100.00%"
+
+	pod, exists, err := ctrl.getPod(ovfImport.Namespace, extractPodName(ovfImport))
+	if err != nil {
+		return false, nil, err
+	}
+	if !exists {
+		if !archiveExists {
+			return false, fmt.Errorf("DataVolume %s importing the OVA archive is gone", name), nil
+		}
+		if !disksReady {
+			return false, nil, nil
+		}
+		return false, nil, ctrl.createExtractPod(ovfImport)
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodFailed:
+		return false, fmt.Errorf("failed to extract the disks of the OVA archive: %s", extractMessage(pod)), nil
+	case corev1.PodSucceeded:
+	default:
+		return false, nil, nil
+	}
+
+	if ovfImport.Spec.Running != nil && *ovfImport.Spec.Running {
+		patch := []byte(`{"spec":{"running":true}}`)
+		if _, err := ctrl.Client.VirtualMachine(ovfImport.Namespace).Patch(*ovfImport.Status.VirtualMachineName, types.MergePatchType, patch); err != nil {
+			return false, nil, err
+		}
+	}
+	// the completed pod is kept until the import is deleted, it tells the
+	// disks were extracted if the status update fails
+	if archiveExists {
+		err := ctrl.Client.CdiClient().CdiV1alpha1().DataVolumes(ovfImport.Namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return false, nil, err
+		}
+	}
+	return true, nil, nil
+}
+
+// createExtractPod creates the pod converting the disks of the OVA archive
+// to the volumes of the blank DataVolumes
+func (ctrl *OVFImportController) createExtractPod(ovfImport *vmimportv1.VirtualMachineOVFImport) error {
+	var disks []ovf.ImportedDisk
+	archive := claimVolume("archive", archiveDataVolumeName(ovfImport))
+	archive.PersistentVolumeClaim.ReadOnly = true
+	volumes := []corev1.Volume{archive}
+	mounts := []corev1.VolumeMount{{Name: "archive", MountPath: ovf.ArchiveDir, ReadOnly: true}}
+	for i, disk := range ovfImport.Status.Disks {
+		disks = append(disks, ovf.ImportedDisk{DiskID: disk.DiskID, DataVolumeName: disk.DataVolumeName, File: disk.File})
+		volumeName := fmt.Sprintf("disk%d", i)
+		volumes = append(volumes, claimVolume(volumeName, disk.DataVolumeName))
+		mounts = append(mounts, corev1.VolumeMount{Name: volumeName, MountPath: path.Join(ovf.DisksDir, strconv.Itoa(i))})
+	}
+	script, err := ovf.ExtractScript(disks)
+	if err != nil {
+		return err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            extractPodName(ovfImport),
+			Namespace:       ovfImport.Namespace,
+			Labels:          map[string]string{kubevirtv1.AppLabel: archivePodLabel},
+			Annotations:     map[string]string{ovfImportAnnotation: ovfImport.Name},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ovfImport, ovfImportGroupVersionKind)},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:                     archiveContainerName,
+					Image:                    ctrl.ClusterConfig.GetV2VConversionImage(),
+					Command:                  []string{"/bin/sh", "-c", script},
+					VolumeMounts:             mounts,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			Volumes: volumes,
+		},
+	}
+	if _, err := ctrl.Client.CoreV1().Pods(ovfImport.Namespace).Create(pod); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	log.Log.Object(ovfImport).Infof("Created pod %s", pod.Name)
+	return nil
+}
+
+// newArchiveDataVolume returns the DataVolume to which CDI imports and
+// extracts the OVA archive, size is the size of the files in the archive
+func (ctrl *OVFImportController) newArchiveDataVolume(ovfImport *vmimportv1.VirtualMachineOVFImport, size int64) *cdiv1.DataVolume {
+	source := ovfImport.Spec.Source
+	return &cdiv1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            archiveDataVolumeName(ovfImport),
+			Namespace:       ovfImport.Namespace,
+			Annotations:     map[string]string{ovfImportAnnotation: ovfImport.Name},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ovfImport, ovfImportGroupVersionKind)},
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: cdiv1.DataVolumeSource{
+				HTTP: &cdiv1.DataVolumeSourceHTTP{
+					URL:           source.URL,
+					SecretRef:     source.SecretRef,
+					CertConfigMap: source.CertConfigMap,
+				},
+			},
+			ContentType: cdiv1.DataVolumeArchive,
+			PVC: &corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: *resource.NewQuantity(int64(float64(size)*(1+filesystemOverhead)), resource.BinarySI),
+					},
+				},
+				StorageClassName: ovfImport.Spec.StorageClassName,
+			},
+		},
+	}
+}
+
+// ensureDataVolume creates the DataVolume unless it exists already, false is
+// returned if it was not created by the import
+func (ctrl *OVFImportController) ensureDataVolume(ovfImport *vmimportv1.VirtualMachineOVFImport, dataVolume *cdiv1.DataVolume) (bool, error) {
+	obj, exists, err := ctrl.DataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", dataVolume.Namespace, dataVolume.Name))
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return obj.(*cdiv1.DataVolume).Annotations[ovfImportAnnotation] == ovfImport.Name, nil
+	}
+
+	// an AlreadyExists error is retried, the owner is checked once the
+	// cache caught up
+	if _, err := ctrl.Client.CdiClient().CdiV1alpha1().DataVolumes(dataVolume.Namespace).Create(dataVolume); err != nil {
+		return false, err
+	}
+	log.Log.Object(ovfImport).Infof("Created DataVolume %s", dataVolume.Name)
+	return true, nil
+}
+
+func (ctrl *OVFImportController) getPod(namespace, name string) (*corev1.Pod, bool, error) {
+	obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if !exists || err != nil {
+		return nil, false, err
+	}
+	return obj.(*corev1.Pod), true, nil
+}
+
+// doUpdateError moves the import to phase and reports err, the error is only
+// returned to retry for phases which are not final
+func (ctrl *OVFImportController) doUpdateError(ovfImport *vmimportv1.VirtualMachineOVFImport, phase vmimportv1.VirtualMachineOVFImportPhase, err error) error {
+	ctrl.Recorder.Eventf(
+		ovfImport,
+		corev1.EventTypeWarning,
+		ovfImportErrorEvent,
+		"VirtualMachineOVFImport encountered error %s",
+		err.Error(),
+	)
+
+	updated := ovfImport.DeepCopy()
+	if updated.Status == nil {
+		updated.Status = &vmimportv1.VirtualMachineOVFImportStatus{}
+	}
+	updated.Status.Phase = phase
+	updated.Status.Message = err.Error()
+	if err2 := ctrl.doUpdate(ovfImport, updated); err2 != nil {
+		return err2
+	}
+
+	if phase == vmimportv1.ImportFailed {
+		return nil
+	}
+	return err
+}
+
+func (ctrl *OVFImportController) doUpdate(original, updated *vmimportv1.VirtualMachineOVFImport) error {
+	if !reflect.DeepEqual(original, updated) {
+		if _, err := ctrl.Client.VirtualMachineOVFImport(updated.Namespace).Update(updated); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readDescriptor fetches and parses the descriptor of the import source
+func (ctrl *OVFImportController) readDescriptor(ovfImport *vmimportv1.VirtualMachineOVFImport) (*ovf.Envelope, error) {
+	body, err := ctrl.fetchDescriptor(ovfImport)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return ovf.ReadDescriptor(body)
+}
+
+func (ctrl *OVFImportController) fetchDescriptor(ovfImport *vmimportv1.VirtualMachineOVFImport) (io.ReadCloser, error) {
+	source := ovfImport.Spec.Source
+
+	// the controller must not be used to reach arbitrary endpoints of the
+	// cluster network, nor to send the credentials to them
+	allowedURLs := ctrl.ClusterConfig.GetAllowedImportURLs()
+	if err := allowlist.CheckURL(source.URL, allowedURLs); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor URL: %v", err)
+	}
+
+	if source.SecretRef != "" {
+		secret, err := ctrl.Client.CoreV1().Secrets(ovfImport.Namespace).Get(source.SecretRef, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s: %v", source.SecretRef, err)
+		}
+		request.SetBasicAuth(string(secret.Data[keyAccess]), string(secret.Data[keySecret]))
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if source.CertConfigMap != "" {
+		configMap, err := ctrl.Client.CoreV1().ConfigMaps(ovfImport.Namespace).Get(source.CertConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %v", source.CertConfigMap, err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(configMap.Data[keyCertConfigMap])) {
+			return nil, fmt.Errorf("ConfigMap %s has no valid %s", source.CertConfigMap, keyCertConfigMap)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: certPool}
+	}
+
+	client := &http.Client{
+		Transport:     transport,
+		Timeout:       descriptorTimeout,
+		CheckRedirect: allowlist.CheckRedirect(allowedURLs),
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the descriptor: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("failed to fetch the descriptor: %s", response.Status)
+	}
+
+	return response.Body, nil
+}
+
+func ovfOptions(ovfImport *vmimportv1.VirtualMachineOVFImport) ovf.Options {
+	options := ovf.Options{
+		Namespace:        ovfImport.Namespace,
+		DescriptorURL:    ovfImport.Spec.Source.URL,
+		SecretRef:        ovfImport.Spec.Source.SecretRef,
+		CertConfigMap:    ovfImport.Spec.Source.CertConfigMap,
+		StorageClassName: ovfImport.Spec.StorageClassName,
+		Networks:         map[string]kubevirtv1.NetworkSource{},
+	}
+	if ovfImport.Spec.VirtualMachineName != nil {
+		options.Name = *ovfImport.Spec.VirtualMachineName
+	}
+	if ovfImport.Spec.Running != nil {
+		options.Running = *ovfImport.Spec.Running
+	}
+	for _, mapping := range ovfImport.Spec.NetworkMappings {
+		options.Networks[mapping.Name] = mapping.NetworkSource
+	}
+	return options
+}
+
+// archiveImport tells whether the disks are extracted from an OVA archive
+func archiveImport(ovfImport *vmimportv1.VirtualMachineOVFImport) bool {
+	for _, disk := range ovfImport.Status.Disks {
+		if disk.File != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func extractMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == archiveContainerName && status.State.Terminated != nil {
+			return status.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
+func archiveDataVolumeName(ovfImport *vmimportv1.VirtualMachineOVFImport) string {
+	return ovfImport.Name + "-ova"
+}
+
+func extractPodName(ovfImport *vmimportv1.VirtualMachineOVFImport) string {
+	return ovfImport.Name + "-ova-extract"
+}
+
+func setImportAnnotation(meta *metav1.ObjectMeta, importName string) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[ovfImportAnnotation] = importName
+}
+
+// parseProgress returns the percentage of a DataVolume progress like
+// "45.20%", or 0 if it is not known
+func parseProgress(progress cdiv1.DataVolumeProgress) float64 {
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(string(progress), "%"), 64)
+	if err != nil {
+		return 0
+	}
+	return percentage
+}
@@ -0,0 +1,196 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmimport
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// OVFImportController imports the virtual appliances described by OVF
+// descriptors as VMs
+type OVFImportController struct {
+	Client kubecli.KubevirtClient
+
+	VMOVFImportInformer cache.SharedIndexInformer
+	VMInformer          cache.SharedIndexInformer
+	DataVolumeInformer  cache.SharedIndexInformer
+	PodInformer         cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+
+	Recorder record.EventRecorder
+
+	vmOVFImportQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the OVF import controller
+func (ctrl *OVFImportController) Init() {
+	ctrl.vmOVFImportQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "vmimport-controller-vmovfimport")
+
+	ctrl.VMOVFImportInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMOVFImport,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMOVFImport(newObj) },
+		},
+	)
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleImported,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleImported(newObj) },
+			DeleteFunc: ctrl.handleImported,
+		},
+	)
+
+	ctrl.DataVolumeInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleImported,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleImported(newObj) },
+			DeleteFunc: ctrl.handleImported,
+		},
+	)
+
+	ctrl.PodInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleImported,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleImported(newObj) },
+			DeleteFunc: ctrl.handleImported,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *OVFImportController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmOVFImportQueue.ShutDown()
+
+	log.Log.Info("Starting OVF import controller.")
+	defer log.Log.Info("Shutting down OVF import controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMOVFImportInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.DataVolumeInformer.HasSynced,
+		ctrl.PodInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmOVFImportWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *OVFImportController) vmOVFImportWorker() {
+	for ctrl.processVMOVFImportWorkItem() {
+	}
+}
+
+func (ctrl *OVFImportController) processVMOVFImportWorkItem() bool {
+	key, quit := ctrl.vmOVFImportQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.vmOVFImportQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachineOVFImport %v", key)
+		ctrl.vmOVFImportQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachineOVFImport %v", key)
+		ctrl.vmOVFImportQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *OVFImportController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMOVFImportInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	ovfImport, ok := storeObj.(*vmimportv1.VirtualMachineOVFImport)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMOVFImport(ovfImport.DeepCopy())
+}
+
+func (ctrl *OVFImportController) handleVMOVFImport(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if ovfImport, ok := obj.(*vmimportv1.VirtualMachineOVFImport); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(ovfImport)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, ovfImport)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmOVFImportQueue.Add(objName)
+	}
+}
+
+// handleImported enqueues the import which created the VM, DataVolume or pod
+func (ctrl *OVFImportController) handleImported(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	var namespace string
+	var annotations map[string]string
+	switch imported := obj.(type) {
+	case *kubevirtv1.VirtualMachine:
+		namespace, annotations = imported.Namespace, imported.Annotations
+	case *cdiv1.DataVolume:
+		namespace, annotations = imported.Namespace, imported.Annotations
+	case *corev1.Pod:
+		namespace, annotations = imported.Namespace, imported.Annotations
+	default:
+		return
+	}
+
+	if importName, ok := annotations[ovfImportAnnotation]; ok {
+		ctrl.vmOVFImportQueue.Add(fmt.Sprintf("%s/%s", namespace, importName))
+	}
+}
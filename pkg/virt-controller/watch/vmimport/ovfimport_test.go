@@ -0,0 +1,520 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmimport
+
+import (
+	"archive/tar"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+const (
+	testNamespace = "default"
+
+	testDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData">
+  <References>
+    <File ovf:href="appliance-disk1.vmdk" ovf:id="file1" ovf:size="1073741824"/>
+  </References>
+  <DiskSection>
+    <Disk ovf:capacity="10" ovf:capacityAllocationUnits="byte * 2^30" ovf:diskId="vmdisk1" ovf:fileRef="file1"/>
+  </DiskSection>
+  <VirtualSystem ovf:id="appliance">
+    <Name>appliance</Name>
+    <VirtualHardwareSection>
+      <Item>
+        <rasd:InstanceID>1</rasd:InstanceID>
+        <rasd:ResourceType>3</rasd:ResourceType>
+        <rasd:VirtualQuantity>2</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:AllocationUnits>byte * 2^20</rasd:AllocationUnits>
+        <rasd:InstanceID>2</rasd:InstanceID>
+        <rasd:ResourceType>4</rasd:ResourceType>
+        <rasd:VirtualQuantity>2048</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:HostResource>ovf:/disk/vmdisk1</rasd:HostResource>
+        <rasd:InstanceID>3</rasd:InstanceID>
+        <rasd:ResourceType>17</rasd:ResourceType>
+      </Item>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>
+`
+)
+
+func newOVA(descriptor string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := tar.NewWriter(buffer)
+	for _, file := range []struct{ name, content string }{
+		{"appliance.ovf", descriptor},
+		{"appliance-disk1.vmdk", "disk"},
+	} {
+		Expect(writer.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Mode:     0644,
+			Size:     int64(len(file.content)),
+			Typeflag: tar.TypeReg,
+		})).To(Succeed())
+		_, err := writer.Write([]byte(file.content))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(writer.Close()).To(Succeed())
+	return buffer.Bytes()
+}
+
+var _ = Describe("OVF import", func() {
+
+	var ctrl *gomock.Controller
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var k8sClient *fake.Clientset
+	var kubevirtClient *kubevirtfake.Clientset
+	var cdiClient *cdifake.Clientset
+	var vmOVFImportInformer cache.SharedIndexInformer
+	var vmInformer cache.SharedIndexInformer
+	var dataVolumeInformer cache.SharedIndexInformer
+	var podInformer cache.SharedIndexInformer
+	var recorder *record.FakeRecorder
+	var controller *OVFImportController
+	var server *httptest.Server
+	var requests int
+	var updated *vmimportv1.VirtualMachineOVFImport
+
+	setConfig := func(configuration v1.KubeVirtConfiguration) {
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: configuration,
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		controller.ClusterConfig = config
+	}
+
+	newOVFImport := func() *vmimportv1.VirtualMachineOVFImport {
+		return &vmimportv1.VirtualMachineOVFImport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "import",
+				Namespace: testNamespace,
+			},
+			Spec: vmimportv1.VirtualMachineOVFImportSpec{
+				Source: vmimportv1.OVFSource{
+					URL: server.URL + "/appliance.ovf",
+				},
+			},
+		}
+	}
+
+	importingOVFImport := func() *vmimportv1.VirtualMachineOVFImport {
+		ovfImport := newOVFImport()
+		ovfImport.Status = &vmimportv1.VirtualMachineOVFImportStatus{
+			Phase:              vmimportv1.ImportingDisks,
+			VirtualMachineName: &[]string{"appliance"}[0],
+			Disks: []vmimportv1.DiskImportStatus{
+				{DiskID: "vmdisk1", DataVolumeName: "appliance-disk0"},
+				{DiskID: "vmdisk2", DataVolumeName: "appliance-disk1"},
+			},
+		}
+		return ovfImport
+	}
+
+	extractingOVFImport := func() *vmimportv1.VirtualMachineOVFImport {
+		ovfImport := newOVFImport()
+		ovfImport.Spec.Source.URL = server.URL + "/appliance.ova"
+		ovfImport.Spec.Running = &[]bool{true}[0]
+		ovfImport.Status = &vmimportv1.VirtualMachineOVFImportStatus{
+			Phase:              vmimportv1.ImportingDisks,
+			VirtualMachineName: &[]string{"appliance"}[0],
+			Disks: []vmimportv1.DiskImportStatus{
+				{DiskID: "vmdisk1", DataVolumeName: "appliance-disk0", File: "appliance-disk1.vmdk"},
+			},
+		}
+		return ovfImport
+	}
+
+	newExtractPod := func(phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "import-ova-extract",
+				Namespace:   testNamespace,
+				Annotations: map[string]string{ovfImportAnnotation: "import"},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: archiveContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: "qemu-img: Could not open"},
+					},
+				}},
+			},
+		}
+	}
+
+	newDataVolume := func(name string, phase cdiv1.DataVolumePhase, progress cdiv1.DataVolumeProgress) *cdiv1.DataVolume {
+		return &cdiv1.DataVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{ovfImportAnnotation: "import"},
+			},
+			Status: cdiv1.DataVolumeStatus{
+				Phase:    phase,
+				Progress: progress,
+			},
+		}
+	}
+
+	process := func(ovfImport *vmimportv1.VirtualMachineOVFImport) error {
+		Expect(vmOVFImportInformer.GetStore().Add(ovfImport)).To(Succeed())
+		return controller.execute(testNamespace + "/" + ovfImport.Name)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+
+		vmOVFImportInformer, _ = testutils.NewFakeInformerFor(&vmimportv1.VirtualMachineOVFImport{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		dataVolumeInformer, _ = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		podInformer, _ = testutils.NewFakeInformerFor(&corev1.Pod{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &OVFImportController{
+			Client:              virtClient,
+			VMOVFImportInformer: vmOVFImportInformer,
+			VMInformer:          vmInformer,
+			DataVolumeInformer:  dataVolumeInformer,
+			PodInformer:         podInformer,
+			Recorder:            recorder,
+		}
+		controller.Init()
+
+		virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+
+		k8sClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+		updated = nil
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		kubevirtClient.Fake.PrependReactor("update", "virtualmachineovfimports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			updated = action.(testing.UpdateAction).GetObject().(*vmimportv1.VirtualMachineOVFImport)
+			return true, updated, nil
+		})
+		virtClient.EXPECT().VirtualMachineOVFImport(testNamespace).
+			Return(kubevirtClient.VmimportV1alpha1().VirtualMachineOVFImports(testNamespace)).AnyTimes()
+
+		cdiClient = cdifake.NewSimpleClientset()
+		virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if user, password, ok := r.BasicAuth(); ok && (user != "admin" || password != "secret") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/appliance.ovf":
+				w.Write([]byte(testDescriptor))
+			case "/appliance.ova":
+				w.Write(newOVA(testDescriptor))
+			case "/redirect.ovf":
+				http.Redirect(w, r, "http://169.254.169.254/appliance.ovf", http.StatusFound)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		setConfig(v1.KubeVirtConfiguration{
+			AllowedImportURLs:  []string{server.URL},
+			V2VConversionImage: "quay.io/kubevirt/v2v-conversion:latest",
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+		ctrl.Finish()
+	})
+
+	Context("without VirtualMachine", func() {
+
+		It("should create the VirtualMachine importing the disks", func() {
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Name).To(Equal("appliance"))
+				Expect(vm.Annotations).To(HaveKeyWithValue(ovfImportAnnotation, "import"))
+				Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(1))
+				Expect(vm.Spec.DataVolumeTemplates[0].Annotations).To(HaveKeyWithValue(ovfImportAnnotation, "import"))
+				Expect(vm.Spec.DataVolumeTemplates[0].Spec.Source.HTTP.URL).To(Equal(server.URL + "/appliance-disk1.vmdk"))
+				return vm, nil
+			})
+
+			Expect(process(newOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportingDisks))
+			Expect(*updated.Status.VirtualMachineName).To(Equal("appliance"))
+			Expect(updated.Status.Disks).To(ConsistOf(vmimportv1.DiskImportStatus{DiskID: "vmdisk1", DataVolumeName: "appliance-disk0"}))
+		})
+
+		It("should authenticate with the credentials of the Secret", func() {
+			_, err := k8sClient.CoreV1().Secrets(testNamespace).Create(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: testNamespace},
+				Data: map[string][]byte{
+					keyAccess: []byte("admin"),
+					keySecret: []byte("wrong"),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ovfImport := newOVFImport()
+			ovfImport.Spec.Source.SecretRef = "credentials"
+
+			Expect(process(ovfImport)).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportPending))
+			Expect(updated.Status.Message).To(ContainSubstring("401 Unauthorized"))
+			testutils.ExpectEvent(recorder, ovfImportErrorEvent)
+		})
+
+		It("should keep retrying while the descriptor can't be fetched", func() {
+			ovfImport := newOVFImport()
+			ovfImport.Spec.Source.URL = server.URL + "/missing.ovf"
+
+			Expect(process(ovfImport)).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportPending))
+			Expect(updated.Status.Message).To(ContainSubstring("404 Not Found"))
+		})
+
+		It("should fail if the VirtualMachine was not created by the import", func() {
+			Expect(vmInformer.GetStore().Add(&v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "appliance", Namespace: testNamespace},
+			})).To(Succeed())
+
+			Expect(process(newOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportFailed))
+			Expect(updated.Status.Message).To(Equal("VirtualMachine appliance already exists"))
+			testutils.ExpectEvent(recorder, ovfImportErrorEvent)
+		})
+
+		It("should fail if the VirtualMachine is created concurrently", func() {
+			vmInterface.EXPECT().Create(gomock.Any()).Return(nil, errors.NewAlreadyExists(v1.Resource("virtualmachines"), "appliance"))
+
+			Expect(process(newOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportFailed))
+		})
+
+		It("should not fetch descriptors from URLs which are not allowed", func() {
+			setConfig(v1.KubeVirtConfiguration{AllowedImportURLs: []string{"https://appliances.example.com"}})
+
+			Expect(process(newOVFImport())).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportPending))
+			Expect(updated.Status.Message).To(ContainSubstring("is not allowed"))
+			Expect(requests).To(BeZero())
+		})
+
+		It("should not follow redirects to URLs which are not allowed", func() {
+			ovfImport := newOVFImport()
+			ovfImport.Spec.Source.URL = server.URL + "/redirect.ovf"
+
+			Expect(process(ovfImport)).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportPending))
+			Expect(updated.Status.Message).To(ContainSubstring("is not allowed"))
+		})
+
+		It("should refuse to import disks from URLs which are not allowed", func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(strings.Replace(testDescriptor, `ovf:href="appliance-disk1.vmdk"`, `ovf:href="http://10.0.0.1/disk.vmdk"`, 1)))
+			})
+
+			Expect(process(newOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportFailed))
+			Expect(updated.Status.Message).To(ContainSubstring("is not allowed"))
+		})
+
+		It("should import an OVA archive to extract its disks", func() {
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(*vm.Spec.Running).To(BeFalse())
+				Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(1))
+				Expect(vm.Spec.DataVolumeTemplates[0].Spec.Source.Blank).ToNot(BeNil())
+				return vm, nil
+			})
+			_, err := k8sClient.CoreV1().Secrets(testNamespace).Create(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: testNamespace},
+				Data: map[string][]byte{
+					keyAccess: []byte("admin"),
+					keySecret: []byte("secret"),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			ovfImport := newOVFImport()
+			ovfImport.Spec.Source.URL = server.URL + "/appliance.ova"
+			ovfImport.Spec.Source.SecretRef = "credentials"
+			ovfImport.Spec.Running = &[]bool{true}[0]
+
+			Expect(process(ovfImport)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportingDisks))
+			Expect(updated.Status.Disks).To(ConsistOf(vmimportv1.DiskImportStatus{
+				DiskID:         "vmdisk1",
+				DataVolumeName: "appliance-disk0",
+				File:           "appliance-disk1.vmdk",
+			}))
+
+			archive, err := cdiClient.CdiV1alpha1().DataVolumes(testNamespace).Get("import-ova", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(archive.Spec.ContentType).To(Equal(cdiv1.DataVolumeArchive))
+			Expect(archive.Spec.Source.HTTP.URL).To(Equal(ovfImport.Spec.Source.URL))
+			Expect(archive.Spec.Source.HTTP.SecretRef).To(Equal("credentials"))
+			Expect(archive.Annotations).To(HaveKeyWithValue(ovfImportAnnotation, "import"))
+		})
+
+		It("should wait for a conversion image to extract OVA archives", func() {
+			setConfig(v1.KubeVirtConfiguration{AllowedImportURLs: []string{server.URL}})
+			ovfImport := newOVFImport()
+			ovfImport.Spec.Source.URL = server.URL + "/appliance.ova"
+
+			Expect(process(ovfImport)).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportPending))
+			Expect(updated.Status.Message).To(ContainSubstring("no conversion image"))
+		})
+	})
+
+	Context("with OVA archive", func() {
+
+		It("should report the progress of the archive import", func() {
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("import-ova", cdiv1.ImportInProgress, "40.0%"))).To(Succeed())
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk0", cdiv1.Succeeded, "N/A"))).To(Succeed())
+
+			Expect(process(extractingOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportingDisks))
+			Expect(updated.Status.Progress).To(Equal("40.00%"))
+		})
+
+		It("should extract the disks once the archive was imported", func() {
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("import-ova", cdiv1.Succeeded, "100.0%"))).To(Succeed())
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk0", cdiv1.Succeeded, "N/A"))).To(Succeed())
+
+			Expect(process(extractingOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportingDisks))
+
+			pod, err := k8sClient.CoreV1().Pods(testNamespace).Get("import-ova-extract", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].Image).To(Equal("quay.io/kubevirt/v2v-conversion:latest"))
+			Expect(pod.Spec.Containers[0].Command[2]).To(ContainSubstring("qemu-img convert -O raw /var/run/kubevirt-ova/archive/appliance-disk1.vmdk /var/run/kubevirt-ova/disks/0/disk.img"))
+			Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("import-ova"))
+			Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("appliance-disk0"))
+		})
+
+		It("should start the VirtualMachine once the disks were extracted", func() {
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("import-ova", cdiv1.Succeeded, "100.0%"))).To(Succeed())
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk0", cdiv1.Succeeded, "N/A"))).To(Succeed())
+			Expect(podInformer.GetStore().Add(newExtractPod(corev1.PodSucceeded))).To(Succeed())
+			vmInterface.EXPECT().Patch("appliance", gomock.Any(), []byte(`{"spec":{"running":true}}`)).Return(&v1.VirtualMachine{}, nil)
+
+			Expect(process(extractingOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportSucceeded))
+			Expect(cdiClient.Actions()).To(ContainElement(WithTransform(func(action testing.Action) string {
+				return action.GetVerb() + "/" + action.GetResource().Resource
+			}, Equal("delete/datavolumes"))))
+		})
+
+		It("should fail if the disks can't be extracted", func() {
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk0", cdiv1.Succeeded, "N/A"))).To(Succeed())
+			Expect(podInformer.GetStore().Add(newExtractPod(corev1.PodFailed))).To(Succeed())
+
+			Expect(process(extractingOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportFailed))
+			Expect(updated.Status.Message).To(ContainSubstring("qemu-img: Could not open"))
+		})
+	})
+
+	Context("with VirtualMachine", func() {
+
+		It("should aggregate the progress of the DataVolumes", func() {
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk0", cdiv1.Succeeded, "100.0%"))).To(Succeed())
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk1", cdiv1.ImportInProgress, "50.0%"))).To(Succeed())
+
+			Expect(process(importingOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportingDisks))
+			Expect(updated.Status.Progress).To(Equal("75.00%"))
+			Expect(updated.Status.Disks[1].Phase).To(Equal(string(cdiv1.ImportInProgress)))
+			Expect(updated.Status.Disks[1].Progress).To(Equal("50.0%"))
+		})
+
+		It("should succeed once all DataVolumes succeeded", func() {
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk0", cdiv1.Succeeded, "100.0%"))).To(Succeed())
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk1", cdiv1.Succeeded, "N/A"))).To(Succeed())
+
+			Expect(process(importingOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportSucceeded))
+			Expect(updated.Status.Progress).To(Equal("100.00%"))
+			testutils.ExpectEvent(recorder, ovfImportCompleteEvent)
+		})
+
+		It("should fail if a DataVolume failed", func() {
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk0", cdiv1.Succeeded, "100.0%"))).To(Succeed())
+			Expect(dataVolumeInformer.GetStore().Add(newDataVolume("appliance-disk1", cdiv1.Failed, "10.0%"))).To(Succeed())
+
+			Expect(process(importingOVFImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.ImportFailed))
+			Expect(updated.Status.Message).To(Equal("failed to import disk(s) vmdisk2"))
+			testutils.ExpectEvent(recorder, ovfImportErrorEvent)
+		})
+
+		It("should not update finished imports", func() {
+			ovfImport := importingOVFImport()
+			ovfImport.Status.Phase = vmimportv1.ImportSucceeded
+
+			Expect(process(ovfImport)).To(Succeed())
+			Expect(updated).To(BeNil())
+		})
+	})
+
+	It("should enqueue the import which created a DataVolume", func() {
+		controller.handleImported(newDataVolume("appliance-disk0", cdiv1.ImportInProgress, "1.0%"))
+		Expect(controller.vmOVFImportQueue.Len()).To(Equal(1))
+		key, _ := controller.vmOVFImportQueue.Get()
+		Expect(key).To(Equal(testNamespace + "/import"))
+	})
+})
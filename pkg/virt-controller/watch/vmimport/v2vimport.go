@@ -0,0 +1,579 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vmimport
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/v2v"
+)
+
+const (
+	// v2vImportAnnotation names the import which created a VM, pod or
+	// PersistentVolumeClaim
+	v2vImportAnnotation = "vmimport.kubevirt.io/v2v-import"
+
+	v2vImportCompleteEvent = "VirtualMachineV2VImportComplete"
+
+	v2vImportErrorEvent = "VirtualMachineV2VImportError"
+
+	// v2vPodLabel is the value of the kubevirt.io label of the conversion pods
+	v2vPodLabel = "v2v-import"
+
+	v2vContainerName = "virt-v2v"
+
+	// filesystemOverhead is reserved on the PersistentVolumeClaims of the
+	// disks, the same CDI reserves for disk images
+	filesystemOverhead = 0.055
+
+	// virt-v2v writes temporary files and the metadata next to the disks
+	scratchOverhead    = 0.1
+	minScratchOverhead = 1 << 30
+
+	progressLogLines = 10
+)
+
+var v2vImportGroupVersionKind = vmimportv1.SchemeGroupVersion.WithKind("VirtualMachineV2VImport")
+
+func vmV2VImportFinished(v2vImport *vmimportv1.VirtualMachineV2VImport) bool {
+	return v2vImport.Status != nil &&
+		(v2vImport.Status.Phase == vmimportv1.V2VSucceeded || v2vImport.Status.Phase == vmimportv1.V2VFailed)
+}
+
+func (ctrl *V2VImportController) updateVMV2VImport(v2vImport *vmimportv1.VirtualMachineV2VImport) error {
+	logger := log.Log.Object(v2vImport)
+
+	logger.V(1).Infof("Updating VirtualMachineV2VImport")
+
+	if vmV2VImportFinished(v2vImport) {
+		return nil
+	}
+
+	if v2vImport.Status == nil || v2vImport.Status.VirtualMachineName == nil {
+		return ctrl.startEstimate(v2vImport)
+	}
+
+	switch v2vImport.Status.Phase {
+	case vmimportv1.V2VEstimating:
+		return ctrl.updateEstimate(v2vImport)
+	case vmimportv1.V2VConverting:
+		return ctrl.updateConversion(v2vImport)
+	case vmimportv1.V2VCopyingDisks:
+		return ctrl.updateCopy(v2vImport)
+	}
+	return nil
+}
+
+// startEstimate checks the import can be done and starts the pod estimating
+// the space the conversion needs
+func (ctrl *V2VImportController) startEstimate(v2vImport *vmimportv1.VirtualMachineV2VImport) error {
+	if ctrl.ClusterConfig.GetV2VConversionImage() == "" {
+		// the configuration may be fixed later, keep trying
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VPending, fmt.Errorf("no virt-v2v conversion image is configured"))
+	}
+
+	inputArgs, err := v2v.InputArgs(v2vImport.Spec.Source)
+	if err != nil {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, err)
+	}
+
+	name := v2vVirtualMachineName(v2vImport)
+	if name == "" {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("the source VM has no name usable for the VirtualMachine"))
+	}
+	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", v2vImport.Namespace, name))
+	if err != nil {
+		return err
+	}
+	if exists && obj.(*kubevirtv1.VirtualMachine).Annotations[v2vImportAnnotation] != v2vImport.Name {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("VirtualMachine %s already exists", name))
+	}
+
+	volumes, mounts := sourceVolumes(v2vImport.Spec.Source)
+	volumes = append(volumes, corev1.Volume{
+		Name:         "scratch",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	mounts = append(mounts, corev1.VolumeMount{Name: "scratch", MountPath: v2v.ScratchDir})
+	if err := ctrl.ensurePod(v2vImport, estimatePodName(v2vImport), v2v.EstimateScript(inputArgs), volumes, mounts); err != nil {
+		return err
+	}
+
+	updated := v2vImport.DeepCopy()
+	updated.Status = &vmimportv1.VirtualMachineV2VImportStatus{
+		Phase:              vmimportv1.V2VEstimating,
+		VirtualMachineName: &name,
+	}
+	return ctrl.doUpdate(v2vImport, updated)
+}
+
+// updateEstimate creates the scratch volume sized from the estimate and
+// starts the conversion once the estimate pod finished
+func (ctrl *V2VImportController) updateEstimate(v2vImport *vmimportv1.VirtualMachineV2VImport) error {
+	pod, exists, err := ctrl.getPod(v2vImport.Namespace, estimatePodName(v2vImport))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("pod %s estimating the conversion is gone", estimatePodName(v2vImport)))
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodFailed:
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("failed to estimate the conversion: %s", terminationMessage(pod)))
+	case corev1.PodSucceeded:
+	default:
+		return nil
+	}
+
+	estimate, err := v2v.ParseEstimate(terminationMessage(pod))
+	if err != nil {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, err)
+	}
+	overhead := int64(float64(estimate.Total) * scratchOverhead)
+	if overhead < minScratchOverhead {
+		overhead = minScratchOverhead
+	}
+	scratchSize := resource.NewQuantity(estimate.Total+overhead, resource.BinarySI)
+
+	scratchName := scratchClaimName(v2vImport)
+	scratch := ctrl.newClaim(v2vImport, scratchName, *scratchSize)
+	scratch.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(v2vImport, v2vImportGroupVersionKind)}
+	if owned, err := ctrl.ensureClaim(v2vImport, scratch); err != nil {
+		return err
+	} else if !owned {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("PersistentVolumeClaim %s already exists", scratchName))
+	}
+
+	inputArgs, err := v2v.InputArgs(v2vImport.Spec.Source)
+	if err != nil {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, err)
+	}
+	volumes, mounts := sourceVolumes(v2vImport.Spec.Source)
+	volumes, mounts = append(volumes, claimVolume("scratch", scratchName)), append(mounts, corev1.VolumeMount{Name: "scratch", MountPath: v2v.ScratchDir})
+	script := v2v.ConvertScript(inputArgs, *v2vImport.Status.VirtualMachineName)
+	if err := ctrl.ensurePod(v2vImport, convertPodName(v2vImport), script, volumes, mounts); err != nil {
+		return err
+	}
+
+	updated := v2vImport.DeepCopy()
+	updated.Status.Phase = vmimportv1.V2VConverting
+	updated.Status.ScratchClaimName = scratchName
+	updated.Status.ScratchSize = scratchSize
+	return ctrl.doUpdate(v2vImport, updated)
+}
+
+// updateConversion reports the progress of virt-v2v and creates the volumes
+// of the converted disks once it finished
+func (ctrl *V2VImportController) updateConversion(v2vImport *vmimportv1.VirtualMachineV2VImport) error {
+	pod, exists, err := ctrl.getPod(v2vImport.Namespace, convertPodName(v2vImport))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("pod %s converting the VM is gone", convertPodName(v2vImport)))
+	}
+
+	updated := v2vImport.DeepCopy()
+	switch pod.Status.Phase {
+	case corev1.PodFailed:
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("failed to convert the VM: %s", terminationMessage(pod)))
+	case corev1.PodRunning:
+		if progress, found := ctrl.conversionProgress(pod); found {
+			updated.Status.Progress = progress
+		}
+		return ctrl.doUpdate(v2vImport, updated)
+	case corev1.PodSucceeded:
+	default:
+		return nil
+	}
+
+	logs, err := ctrl.podLogs(pod)
+	if err != nil {
+		return err
+	}
+	conversion, err := v2v.ParseConversion(logs)
+	if err != nil {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, err)
+	}
+
+	updated.Status.Disks = nil
+	for i, disk := range conversion.Disks {
+		updated.Status.Disks = append(updated.Status.Disks, vmimportv1.ConvertedDisk{
+			File:      disk.File,
+			Size:      *resource.NewQuantity(disk.Size, resource.BinarySI),
+			ClaimName: fmt.Sprintf("%s-disk%d", *v2vImport.Status.VirtualMachineName, i),
+		})
+	}
+	// fail before copying the disks if the VM can't be mapped
+	if _, err := v2v.ToVirtualMachine(&conversion.Metadata, v2vOptions(updated)); err != nil {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, err)
+	}
+
+	volumes := []corev1.Volume{claimVolume("scratch", v2vImport.Status.ScratchClaimName)}
+	mounts := []corev1.VolumeMount{{Name: "scratch", MountPath: v2v.ScratchDir, ReadOnly: true}}
+	for i, disk := range updated.Status.Disks {
+		size := resource.NewQuantity(int64(float64(disk.Size.Value())*(1+filesystemOverhead)), resource.BinarySI)
+		if owned, err := ctrl.ensureClaim(v2vImport, ctrl.newClaim(v2vImport, disk.ClaimName, *size)); err != nil {
+			return err
+		} else if !owned {
+			return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("PersistentVolumeClaim %s already exists", disk.ClaimName))
+		}
+		volumeName := fmt.Sprintf("disk%d", i)
+		volumes = append(volumes, claimVolume(volumeName, disk.ClaimName))
+		mounts = append(mounts, corev1.VolumeMount{Name: volumeName, MountPath: path.Join(v2v.DisksDir, fmt.Sprint(i))})
+	}
+	if err := ctrl.ensurePod(v2vImport, copyPodName(v2vImport), v2v.CopyScript(conversion.Disks), volumes, mounts); err != nil {
+		return err
+	}
+
+	updated.Status.Phase = vmimportv1.V2VCopyingDisks
+	updated.Status.Progress = "100.00%"
+	return ctrl.doUpdate(v2vImport, updated)
+}
+
+// updateCopy creates the VM and removes the pods and the scratch volume
+// once the disks were copied to their volumes
+func (ctrl *V2VImportController) updateCopy(v2vImport *vmimportv1.VirtualMachineV2VImport) error {
+	logger := log.Log.Object(v2vImport)
+
+	pod, exists, err := ctrl.getPod(v2vImport.Namespace, copyPodName(v2vImport))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("pod %s copying the disks is gone", copyPodName(v2vImport)))
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodFailed:
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("failed to copy the disks: %s", terminationMessage(pod)))
+	case corev1.PodSucceeded:
+	default:
+		return nil
+	}
+
+	// the metadata of the VM is kept in the logs of the conversion pod
+	convertPod, exists, err := ctrl.getPod(v2vImport.Namespace, convertPodName(v2vImport))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("pod %s converting the VM is gone", convertPodName(v2vImport)))
+	}
+	logs, err := ctrl.podLogs(convertPod)
+	if err != nil {
+		return err
+	}
+	conversion, err := v2v.ParseConversion(logs)
+	if err != nil {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, err)
+	}
+	vm, err := v2v.ToVirtualMachine(&conversion.Metadata, v2vOptions(v2vImport))
+	if err != nil {
+		return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, err)
+	}
+
+	_, exists, err = ctrl.VMInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, vm.Name))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		vm.Annotations = map[string]string{v2vImportAnnotation: v2vImport.Name}
+		if _, err := ctrl.Client.VirtualMachine(vm.Namespace).Create(vm); err != nil {
+			if errors.IsAlreadyExists(err) {
+				return ctrl.doUpdateError(v2vImport, vmimportv1.V2VFailed, fmt.Errorf("VirtualMachine %s already exists", vm.Name))
+			}
+			return err
+		}
+		logger.Infof("Created VirtualMachine %s", vm.Name)
+	}
+
+	for _, podName := range []string{estimatePodName(v2vImport), convertPodName(v2vImport), copyPodName(v2vImport)} {
+		err := ctrl.Client.CoreV1().Pods(v2vImport.Namespace).Delete(podName, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	err = ctrl.Client.CoreV1().PersistentVolumeClaims(v2vImport.Namespace).Delete(v2vImport.Status.ScratchClaimName, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	updated := v2vImport.DeepCopy()
+	updated.Status.Phase = vmimportv1.V2VSucceeded
+	updated.Status.Message = ""
+	ctrl.Recorder.Eventf(
+		updated,
+		corev1.EventTypeNormal,
+		v2vImportCompleteEvent,
+		"Successfully converted VirtualMachine %s",
+		vm.Name,
+	)
+	return ctrl.doUpdate(v2vImport, updated)
+}
+
+// doUpdateError moves the import to phase and reports err, the error is only
+// returned to retry for phases which are not final
+func (ctrl *V2VImportController) doUpdateError(v2vImport *vmimportv1.VirtualMachineV2VImport, phase vmimportv1.VirtualMachineV2VImportPhase, err error) error {
+	ctrl.Recorder.Eventf(
+		v2vImport,
+		corev1.EventTypeWarning,
+		v2vImportErrorEvent,
+		"VirtualMachineV2VImport encountered error %s",
+		err.Error(),
+	)
+
+	updated := v2vImport.DeepCopy()
+	if updated.Status == nil {
+		updated.Status = &vmimportv1.VirtualMachineV2VImportStatus{}
+	}
+	updated.Status.Phase = phase
+	updated.Status.Message = err.Error()
+	if err2 := ctrl.doUpdate(v2vImport, updated); err2 != nil {
+		return err2
+	}
+
+	if phase == vmimportv1.V2VFailed {
+		return nil
+	}
+	return err
+}
+
+func (ctrl *V2VImportController) doUpdate(original, updated *vmimportv1.VirtualMachineV2VImport) error {
+	if !reflect.DeepEqual(original, updated) {
+		if _, err := ctrl.Client.VirtualMachineV2VImport(updated.Namespace).Update(updated); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ctrl *V2VImportController) getPod(namespace, name string) (*corev1.Pod, bool, error) {
+	obj, exists, err := ctrl.PodInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if !exists || err != nil {
+		return nil, false, err
+	}
+	return obj.(*corev1.Pod), true, nil
+}
+
+// ensurePod creates the pod running script with the conversion image,
+// unless it exists already
+func (ctrl *V2VImportController) ensurePod(v2vImport *vmimportv1.VirtualMachineV2VImport, name string, script string, volumes []corev1.Volume, mounts []corev1.VolumeMount) error {
+	if _, exists, err := ctrl.getPod(v2vImport.Namespace, name); exists || err != nil {
+		return err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       v2vImport.Namespace,
+			Labels:          map[string]string{kubevirtv1.AppLabel: v2vPodLabel},
+			Annotations:     map[string]string{v2vImportAnnotation: v2vImport.Name},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(v2vImport, v2vImportGroupVersionKind)},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:    v2vContainerName,
+					Image:   ctrl.ClusterConfig.GetV2VConversionImage(),
+					Command: []string{"/bin/sh", "-c", script},
+					Env: []corev1.EnvVar{
+						// libvirt is not running in the pod
+						{Name: "LIBGUESTFS_BACKEND", Value: "direct"},
+					},
+					VolumeMounts:             mounts,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			Volumes: volumes,
+		},
+	}
+	if _, err := ctrl.Client.CoreV1().Pods(v2vImport.Namespace).Create(pod); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	log.Log.Object(v2vImport).Infof("Created pod %s", name)
+	return nil
+}
+
+func (ctrl *V2VImportController) newClaim(v2vImport *vmimportv1.VirtualMachineV2VImport, name string, size resource.Quantity) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   v2vImport.Namespace,
+			Annotations: map[string]string{v2vImportAnnotation: v2vImport.Name},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+			StorageClassName: v2vImport.Spec.StorageClassName,
+		},
+	}
+}
+
+// ensureClaim creates the PersistentVolumeClaim unless it exists already,
+// false is returned if it was not created by the import
+func (ctrl *V2VImportController) ensureClaim(v2vImport *vmimportv1.VirtualMachineV2VImport, claim *corev1.PersistentVolumeClaim) (bool, error) {
+	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", claim.Namespace, claim.Name))
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return obj.(*corev1.PersistentVolumeClaim).Annotations[v2vImportAnnotation] == v2vImport.Name, nil
+	}
+
+	// an AlreadyExists error is retried, the owner is checked once the
+	// cache caught up
+	if _, err := ctrl.Client.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(claim); err != nil {
+		return false, err
+	}
+	log.Log.Object(v2vImport).Infof("Created PersistentVolumeClaim %s", claim.Name)
+	return true, nil
+}
+
+// conversionProgress reads the progress of virt-v2v from the logs of pod
+func (ctrl *V2VImportController) conversionProgress(pod *corev1.Pod) (string, bool) {
+	tailLines := int64(progressLogLines)
+	logs, err := ctrl.Client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: v2vContainerName,
+		TailLines: &tailLines,
+	}).DoRaw()
+	if err != nil {
+		log.Log.Object(pod).Reason(err).Warning("Failed to read the progress of the conversion")
+		return "", false
+	}
+	return v2v.ParseProgress(string(logs))
+}
+
+// conversionLogs reads the complete logs of pod, which end with the result
+// of the conversion
+func (ctrl *V2VImportController) conversionLogs(pod *corev1.Pod) (string, error) {
+	logs, err := ctrl.Client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: v2vContainerName,
+	}).DoRaw()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the logs of pod %s: %v", pod.Name, err)
+	}
+	return string(logs), nil
+}
+
+func sourceVolumes(source vmimportv1.V2VSource) ([]corev1.Volume, []corev1.VolumeMount) {
+	switch {
+	case source.VMware != nil:
+		return []corev1.Volume{{
+				Name:         "source",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: source.VMware.SecretRef}},
+			}},
+			[]corev1.VolumeMount{{Name: "source", MountPath: v2v.SecretDir, ReadOnly: true}}
+	case source.OVA != nil:
+		volume := claimVolume("source", source.OVA.ClaimName)
+		volume.PersistentVolumeClaim.ReadOnly = true
+		return []corev1.Volume{volume}, []corev1.VolumeMount{{Name: "source", MountPath: v2v.SourceDir, ReadOnly: true}}
+	}
+	return nil, nil
+}
+
+func claimVolume(name, claimName string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+		},
+	}
+}
+
+func terminationMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == v2vContainerName && status.State.Terminated != nil {
+			return status.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
+// v2vVirtualMachineName returns the name of the VM to create, which is
+// needed before virt-v2v inspected the source
+func v2vVirtualMachineName(v2vImport *vmimportv1.VirtualMachineV2VImport) string {
+	if v2vImport.Spec.VirtualMachineName != nil {
+		return *v2vImport.Spec.VirtualMachineName
+	}
+	source := v2vImport.Spec.Source
+	if source.VMware != nil {
+		return v2v.SanitizeName(source.VMware.VMName)
+	}
+	if source.OVA != nil {
+		base := path.Base(source.OVA.Path)
+		return v2v.SanitizeName(strings.TrimSuffix(base, path.Ext(base)))
+	}
+	return ""
+}
+
+func v2vOptions(v2vImport *vmimportv1.VirtualMachineV2VImport) v2v.Options {
+	options := v2v.Options{
+		Name:      *v2vImport.Status.VirtualMachineName,
+		Namespace: v2vImport.Namespace,
+		Networks:  map[string]kubevirtv1.NetworkSource{},
+	}
+	for _, disk := range v2vImport.Status.Disks {
+		options.ClaimNames = append(options.ClaimNames, disk.ClaimName)
+	}
+	if v2vImport.Spec.Running != nil {
+		options.Running = *v2vImport.Spec.Running
+	}
+	for _, mapping := range v2vImport.Spec.NetworkMappings {
+		options.Networks[mapping.Name] = mapping.NetworkSource
+	}
+	return options
+}
+
+func estimatePodName(v2vImport *vmimportv1.VirtualMachineV2VImport) string {
+	return v2vImport.Name + "-v2v-estimate"
+}
+
+func convertPodName(v2vImport *vmimportv1.VirtualMachineV2VImport) string {
+	return v2vImport.Name + "-v2v-convert"
+}
+
+func copyPodName(v2vImport *vmimportv1.VirtualMachineV2VImport) string {
+	return v2vImport.Name + "-v2v-copy"
+}
+
+func scratchClaimName(v2vImport *vmimportv1.VirtualMachineV2VImport) string {
+	return v2vImport.Name + "-v2v-scratch"
+}
@@ -0,0 +1,199 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vmimport
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// V2VImportController migrates VMs of other hypervisors, whose disks are
+// converted by virt-v2v running in pods
+type V2VImportController struct {
+	Client kubecli.KubevirtClient
+
+	VMV2VImportInformer cache.SharedIndexInformer
+	VMInformer          cache.SharedIndexInformer
+	PodInformer         cache.SharedIndexInformer
+	PVCInformer         cache.SharedIndexInformer
+
+	ClusterConfig *virtconfig.ClusterConfig
+
+	Recorder record.EventRecorder
+
+	vmV2VImportQueue workqueue.RateLimitingInterface
+
+	// podLogs reads the logs of the conversion pod, it is replaced in tests
+	podLogs func(pod *corev1.Pod) (string, error)
+}
+
+// Init initializes the V2V import controller
+func (ctrl *V2VImportController) Init() {
+	ctrl.vmV2VImportQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "vmimport-controller-vmv2vimport")
+	ctrl.podLogs = ctrl.conversionLogs
+
+	ctrl.VMV2VImportInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMV2VImport,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMV2VImport(newObj) },
+		},
+	)
+
+	ctrl.VMInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleConverted,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleConverted(newObj) },
+			DeleteFunc: ctrl.handleConverted,
+		},
+	)
+
+	ctrl.PodInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleConverted,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleConverted(newObj) },
+			DeleteFunc: ctrl.handleConverted,
+		},
+	)
+
+	ctrl.PVCInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleConverted,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleConverted(newObj) },
+			DeleteFunc: ctrl.handleConverted,
+		},
+	)
+}
+
+// Run the controller
+func (ctrl *V2VImportController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmV2VImportQueue.ShutDown()
+
+	log.Log.Info("Starting V2V import controller.")
+	defer log.Log.Info("Shutting down V2V import controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMV2VImportInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.PodInformer.HasSynced,
+		ctrl.PVCInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmV2VImportWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *V2VImportController) vmV2VImportWorker() {
+	for ctrl.processVMV2VImportWorkItem() {
+	}
+}
+
+func (ctrl *V2VImportController) processVMV2VImportWorkItem() bool {
+	key, quit := ctrl.vmV2VImportQueue.Get()
+	if quit {
+		return false
+	}
+	defer ctrl.vmV2VImportQueue.Done(key)
+
+	if err := ctrl.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("reenqueuing VirtualMachineV2VImport %v", key)
+		ctrl.vmV2VImportQueue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachineV2VImport %v", key)
+		ctrl.vmV2VImportQueue.Forget(key)
+	}
+	return true
+}
+
+func (ctrl *V2VImportController) execute(key string) error {
+	storeObj, exists, err := ctrl.VMV2VImportInformer.GetStore().GetByKey(key)
+	if !exists || err != nil {
+		return err
+	}
+
+	v2vImport, ok := storeObj.(*vmimportv1.VirtualMachineV2VImport)
+	if !ok {
+		return fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	return ctrl.updateVMV2VImport(v2vImport.DeepCopy())
+}
+
+func (ctrl *V2VImportController) handleVMV2VImport(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if v2vImport, ok := obj.(*vmimportv1.VirtualMachineV2VImport); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(v2vImport)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, v2vImport)
+			return
+		}
+
+		log.Log.V(3).Infof("enqueued %q for sync", objName)
+		ctrl.vmV2VImportQueue.Add(objName)
+	}
+}
+
+// handleConverted enqueues the import which created the VM, pod or
+// PersistentVolumeClaim
+func (ctrl *V2VImportController) handleConverted(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	var meta *metav1.ObjectMeta
+	switch converted := obj.(type) {
+	case *kubevirtv1.VirtualMachine:
+		meta = &converted.ObjectMeta
+	case *corev1.Pod:
+		meta = &converted.ObjectMeta
+	case *corev1.PersistentVolumeClaim:
+		meta = &converted.ObjectMeta
+	default:
+		return
+	}
+
+	if importName, ok := meta.Annotations[v2vImportAnnotation]; ok {
+		ctrl.vmV2VImportQueue.Add(fmt.Sprintf("%s/%s", meta.Namespace, importName))
+	}
+}
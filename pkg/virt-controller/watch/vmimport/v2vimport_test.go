@@ -0,0 +1,366 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package vmimport
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+const testConversion = `[   0.0] Setting up the source
+--- begin of the conversion result ---
+10737418240 /var/tmp/v2v/scratch/disk-1/disk.img
+{
+  "name": "web01",
+  "memory": 2147483648,
+  "vcpu": 2,
+  "firmware": {"type": "bios"},
+  "disks": [{"file": "/var/tmp/v2v/scratch/disk-1/disk.img", "format": "raw"}],
+  "nics": [{"mac": "00:50:56:aa:bb:cc", "vnet": "VM Network"}],
+  "guestcaps": {"block-bus": "virtio-blk", "net-bus": "virtio-net"}
+}
+--- end of the conversion result ---
+`
+
+var _ = Describe("V2V import", func() {
+
+	var ctrl *gomock.Controller
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var k8sClient *fake.Clientset
+	var kubevirtClient *kubevirtfake.Clientset
+	var vmV2VImportInformer cache.SharedIndexInformer
+	var vmInformer cache.SharedIndexInformer
+	var podInformer cache.SharedIndexInformer
+	var pvcInformer cache.SharedIndexInformer
+	var recorder *record.FakeRecorder
+	var controller *V2VImportController
+	var updated *vmimportv1.VirtualMachineV2VImport
+	var podLogs map[string]string
+
+	newV2VImport := func() *vmimportv1.VirtualMachineV2VImport {
+		return &vmimportv1.VirtualMachineV2VImport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "import",
+				Namespace: testNamespace,
+			},
+			Spec: vmimportv1.VirtualMachineV2VImportSpec{
+				Source: vmimportv1.V2VSource{
+					VMware: &vmimportv1.VMwareSource{
+						URL:       "vpx://administrator@vcenter.example.com/Datacenter/esxi01?no_verify=1",
+						VMName:    "Web01",
+						SecretRef: "vcenter-credentials",
+					},
+				},
+			},
+		}
+	}
+
+	v2vImportInPhase := func(phase vmimportv1.VirtualMachineV2VImportPhase) *vmimportv1.VirtualMachineV2VImport {
+		v2vImport := newV2VImport()
+		v2vImport.Status = &vmimportv1.VirtualMachineV2VImportStatus{
+			Phase:              phase,
+			VirtualMachineName: &[]string{"web01"}[0],
+		}
+		if phase != vmimportv1.V2VEstimating {
+			v2vImport.Status.ScratchClaimName = "import-v2v-scratch"
+		}
+		if phase == vmimportv1.V2VCopyingDisks {
+			v2vImport.Status.Disks = []vmimportv1.ConvertedDisk{{
+				File:      "/var/tmp/v2v/scratch/disk-1/disk.img",
+				Size:      resource.MustParse("10Gi"),
+				ClaimName: "web01-disk0",
+			}}
+		}
+		return v2vImport
+	}
+
+	addPod := func(name string, phase corev1.PodPhase, message string) {
+		podLogs[name] = message
+		Expect(podInformer.GetStore().Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{v2vImportAnnotation: "import"},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: v2vContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				}},
+			},
+		})).To(Succeed())
+	}
+
+	createdPod := func(name string) *corev1.Pod {
+		pod, err := k8sClient.CoreV1().Pods(testNamespace).Get(name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pod
+	}
+
+	process := func(v2vImport *vmimportv1.VirtualMachineV2VImport) error {
+		Expect(vmV2VImportInformer.GetStore().Add(v2vImport)).To(Succeed())
+		return controller.execute(testNamespace + "/" + v2vImport.Name)
+	}
+
+	setConversionImage := func(image string) {
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{V2VConversionImage: image},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeployed,
+			},
+		})
+		controller.ClusterConfig = config
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+
+		vmV2VImportInformer, _ = testutils.NewFakeInformerFor(&vmimportv1.VirtualMachineV2VImport{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		podInformer, _ = testutils.NewFakeInformerFor(&corev1.Pod{})
+		pvcInformer, _ = testutils.NewFakeInformerFor(&corev1.PersistentVolumeClaim{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = &V2VImportController{
+			Client:              virtClient,
+			VMV2VImportInformer: vmV2VImportInformer,
+			VMInformer:          vmInformer,
+			PodInformer:         podInformer,
+			PVCInformer:         pvcInformer,
+			Recorder:            recorder,
+		}
+		controller.Init()
+		podLogs = map[string]string{}
+		controller.podLogs = func(pod *corev1.Pod) (string, error) {
+			return podLogs[pod.Name], nil
+		}
+		setConversionImage("quay.io/kubevirt/virt-v2v:latest")
+
+		virtClient.EXPECT().VirtualMachine(testNamespace).Return(vmInterface).AnyTimes()
+
+		k8sClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+
+		updated = nil
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		kubevirtClient.Fake.PrependReactor("update", "virtualmachinev2vimports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			updated = action.(testing.UpdateAction).GetObject().(*vmimportv1.VirtualMachineV2VImport)
+			return true, updated, nil
+		})
+		virtClient.EXPECT().VirtualMachineV2VImport(testNamespace).
+			Return(kubevirtClient.VmimportV1alpha1().VirtualMachineV2VImports(testNamespace)).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("without estimate", func() {
+
+		It("should start the estimate with the conversion image", func() {
+			Expect(process(newV2VImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VEstimating))
+			Expect(*updated.Status.VirtualMachineName).To(Equal("web01"))
+
+			pod := createdPod("import-v2v-estimate")
+			Expect(pod.Labels).To(HaveKeyWithValue(v1.AppLabel, v2vPodLabel))
+			Expect(pod.Annotations).To(HaveKeyWithValue(v2vImportAnnotation, "import"))
+			Expect(pod.OwnerReferences[0].Kind).To(Equal("VirtualMachineV2VImport"))
+			Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			container := pod.Spec.Containers[0]
+			Expect(container.Image).To(Equal("quay.io/kubevirt/virt-v2v:latest"))
+			Expect(container.Command[2]).To(ContainSubstring("--password-file /var/tmp/v2v/secret/password Web01"))
+			Expect(container.Command[2]).To(ContainSubstring("--print-estimate"))
+			Expect(pod.Spec.Volumes[0].Secret.SecretName).To(Equal("vcenter-credentials"))
+		})
+
+		It("should keep retrying while no conversion image is configured", func() {
+			setConversionImage("")
+
+			Expect(process(newV2VImport())).ToNot(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VPending))
+			Expect(updated.Status.Message).To(Equal("no virt-v2v conversion image is configured"))
+			testutils.ExpectEvent(recorder, v2vImportErrorEvent)
+		})
+
+		It("should fail without source", func() {
+			v2vImport := newV2VImport()
+			v2vImport.Spec.Source.VMware = nil
+
+			Expect(process(v2vImport)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VFailed))
+			Expect(updated.Status.Message).To(Equal("no source is set"))
+		})
+
+		It("should fail if the VirtualMachine was not created by the import", func() {
+			Expect(vmInformer.GetStore().Add(&v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "web01", Namespace: testNamespace},
+			})).To(Succeed())
+
+			Expect(process(newV2VImport())).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VFailed))
+			Expect(updated.Status.Message).To(Equal("VirtualMachine web01 already exists"))
+		})
+	})
+
+	Context("while estimating", func() {
+
+		It("should create the scratch volume and start the conversion", func() {
+			addPod("import-v2v-estimate", corev1.PodSucceeded, `{ "disks": [ 10737418240 ], "total": 10737418240 }`)
+
+			Expect(process(v2vImportInPhase(vmimportv1.V2VEstimating))).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VConverting))
+			Expect(updated.Status.ScratchClaimName).To(Equal("import-v2v-scratch"))
+			Expect(updated.Status.ScratchSize.String()).To(Equal("11Gi"))
+
+			scratch, err := k8sClient.CoreV1().PersistentVolumeClaims(testNamespace).Get("import-v2v-scratch", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			size := scratch.Spec.Resources.Requests[corev1.ResourceStorage]
+			Expect(size.String()).To(Equal("11Gi"))
+			Expect(scratch.OwnerReferences).To(HaveLen(1))
+
+			pod := createdPod("import-v2v-convert")
+			Expect(pod.Spec.Containers[0].Command[2]).To(ContainSubstring("-on web01"))
+			Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("import-v2v-scratch"))
+		})
+
+		It("should fail if the estimate failed", func() {
+			addPod("import-v2v-estimate", corev1.PodFailed, "virt-v2v: error: could not connect to libvirt")
+
+			Expect(process(v2vImportInPhase(vmimportv1.V2VEstimating))).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VFailed))
+			Expect(updated.Status.Message).To(Equal("failed to estimate the conversion: virt-v2v: error: could not connect to libvirt"))
+			testutils.ExpectEvent(recorder, v2vImportErrorEvent)
+		})
+
+		It("should wait for the estimate", func() {
+			addPod("import-v2v-estimate", corev1.PodRunning, "")
+
+			Expect(process(v2vImportInPhase(vmimportv1.V2VEstimating))).To(Succeed())
+			Expect(updated).To(BeNil())
+		})
+	})
+
+	Context("while converting", func() {
+
+		It("should create the volumes of the disks and copy them", func() {
+			addPod("import-v2v-convert", corev1.PodSucceeded, testConversion)
+
+			Expect(process(v2vImportInPhase(vmimportv1.V2VConverting))).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VCopyingDisks))
+			Expect(updated.Status.Disks).To(HaveLen(1))
+			Expect(updated.Status.Disks[0].ClaimName).To(Equal("web01-disk0"))
+
+			claim, err := k8sClient.CoreV1().PersistentVolumeClaims(testNamespace).Get("web01-disk0", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claim.Annotations).To(HaveKeyWithValue(v2vImportAnnotation, "import"))
+			Expect(claim.OwnerReferences).To(BeEmpty())
+			size := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+			Expect(size.Value()).To(Equal(int64(11327976243)))
+
+			pod := createdPod("import-v2v-copy")
+			Expect(pod.Spec.Containers[0].Command[2]).To(ContainSubstring("cp --sparse=always /var/tmp/v2v/scratch/disk-1/disk.img /var/tmp/v2v/disks/0/disk.img"))
+			Expect(pod.Spec.Containers[0].TerminationMessagePolicy).To(Equal(corev1.TerminationMessageFallbackToLogsOnError))
+		})
+
+		It("should fail if a disk volume was not created by the import", func() {
+			addPod("import-v2v-convert", corev1.PodSucceeded, testConversion)
+			Expect(pvcInformer.GetStore().Add(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "web01-disk0", Namespace: testNamespace},
+			})).To(Succeed())
+
+			Expect(process(v2vImportInPhase(vmimportv1.V2VConverting))).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VFailed))
+			Expect(updated.Status.Message).To(Equal("PersistentVolumeClaim web01-disk0 already exists"))
+		})
+	})
+
+	Context("while copying the disks", func() {
+
+		It("should create the VirtualMachine and clean up", func() {
+			addPod("import-v2v-convert", corev1.PodSucceeded, testConversion)
+			addPod("import-v2v-copy", corev1.PodSucceeded, "")
+			_, err := k8sClient.CoreV1().PersistentVolumeClaims(testNamespace).Create(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "import-v2v-scratch", Namespace: testNamespace},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Name).To(Equal("web01"))
+				Expect(vm.Annotations).To(HaveKeyWithValue(v2vImportAnnotation, "import"))
+				Expect(vm.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("web01-disk0"))
+				return vm, nil
+			})
+
+			Expect(process(v2vImportInPhase(vmimportv1.V2VCopyingDisks))).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VSucceeded))
+			testutils.ExpectEvent(recorder, v2vImportCompleteEvent)
+
+			_, err = k8sClient.CoreV1().PersistentVolumeClaims(testNamespace).Get("import-v2v-scratch", metav1.GetOptions{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if the copy failed", func() {
+			addPod("import-v2v-copy", corev1.PodFailed, "cp: error writing: No space left on device")
+
+			Expect(process(v2vImportInPhase(vmimportv1.V2VCopyingDisks))).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(vmimportv1.V2VFailed))
+			Expect(updated.Status.Message).To(ContainSubstring("No space left on device"))
+		})
+	})
+
+	It("should enqueue the import which created a pod", func() {
+		controller.handleConverted(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "import-v2v-convert",
+				Namespace:   testNamespace,
+				Annotations: map[string]string{v2vImportAnnotation: "import"},
+			},
+		})
+		Expect(controller.vmV2VImportQueue.Len()).To(Equal(1))
+		key, _ := controller.vmV2VImportQueue.Get()
+		Expect(key).To(Equal(testNamespace + "/import"))
+	})
+})
@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+const (
+	// SuccessfulCSICloneReason is added in an event when a PVC was created as
+	// CSI clone of the source PVC of a DataVolume template
+	SuccessfulCSICloneReason = "SuccessfulCSIClone"
+	// FailedCSICloneReason is added in an event when a PVC could not be
+	// created as CSI clone of the source PVC of a DataVolume template
+	FailedCSICloneReason = "FailedCSIClone"
+
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+)
+
+// csiCloneSource returns the PVC the CSI driver can clone the DataVolume spec
+// from, or nil if CDI has to clone it. CSI drivers only clone a bound PVC of
+// the same namespace and StorageClass into a PVC of the same volume mode which
+// is at least as big, and the StorageClass has to be annotated with the
+// csi-clone strategy.
+func csiCloneSource(spec *cdiv1.DataVolumeSpec, namespace string, pvcStore cache.Store, storageClassStore cache.Store) *k8sv1.PersistentVolumeClaim {
+	if spec.Source.PVC == nil || spec.PVC == nil {
+		return nil
+	}
+	if spec.Source.PVC.Namespace != "" && spec.Source.PVC.Namespace != namespace {
+		return nil
+	}
+
+	obj, exists, err := pvcStore.GetByKey(fmt.Sprintf("%s/%s", namespace, spec.Source.PVC.Name))
+	if err != nil || !exists {
+		return nil
+	}
+	source := obj.(*k8sv1.PersistentVolumeClaim)
+	if source.DeletionTimestamp != nil || source.Status.Phase != k8sv1.ClaimBound {
+		return nil
+	}
+
+	storageClassName := storageClassOf(spec.PVC, storageClassStore)
+	if storageClassName == "" || storageClassName != storageClassOf(&source.Spec, storageClassStore) {
+		return nil
+	}
+	obj, exists, err = storageClassStore.GetByKey(storageClassName)
+	if err != nil || !exists {
+		return nil
+	}
+	if obj.(*storagev1.StorageClass).Annotations[virtv1.CloneStrategyAnnotation] != virtv1.CSICloneStrategy {
+		return nil
+	}
+
+	if volumeModeOf(spec.PVC) != volumeModeOf(&source.Spec) {
+		return nil
+	}
+	size, ok := source.Status.Capacity[k8sv1.ResourceStorage]
+	if !ok {
+		size = source.Spec.Resources.Requests[k8sv1.ResourceStorage]
+	}
+	if requested := spec.PVC.Resources.Requests[k8sv1.ResourceStorage]; requested.Cmp(size) < 0 {
+		return nil
+	}
+	return source
+}
+
+// newCSIClonePVC returns a PVC with the spec of the DataVolume template, which
+// the CSI driver provisions as clone of source
+func newCSIClonePVC(name string, namespace string, template *virtv1.DataVolumeTemplateSpec, source *k8sv1.PersistentVolumeClaim) *k8sv1.PersistentVolumeClaim {
+	pvc := &k8sv1.PersistentVolumeClaim{}
+	pvc.ObjectMeta = *template.ObjectMeta.DeepCopy()
+	pvc.ObjectMeta.Name = name
+	pvc.ObjectMeta.Namespace = namespace
+	pvc.ObjectMeta.ResourceVersion = ""
+	pvc.ObjectMeta.UID = ""
+	pvc.Spec = *template.Spec.PVC.DeepCopy()
+	if pvc.Spec.StorageClassName == nil {
+		pvc.Spec.StorageClassName = source.Spec.StorageClassName
+	}
+	pvc.Spec.DataSource = &k8sv1.TypedLocalObjectReference{
+		Kind: "PersistentVolumeClaim",
+		Name: source.Name,
+	}
+
+	annotations := map[string]string{}
+	for k, v := range template.Annotations {
+		annotations[k] = v
+	}
+	annotations[virtv1.CloneStrategyAnnotation] = virtv1.CSICloneStrategy
+	pvc.ObjectMeta.Annotations = annotations
+	return pvc
+}
+
+// authorizeDataVolumeClone checks that the service account of the VMI spec
+// may clone the source PVC of the DataVolume spec into namespace
+func authorizeDataVolumeClone(cloneAuthFunc CloneAuthFunc, namespace string, vmiSpec *virtv1.VirtualMachineInstanceSpec, spec *cdiv1.DataVolumeSpec) error {
+	if spec.Source.PVC == nil {
+		return nil
+	}
+
+	pvcNamespace := spec.Source.PVC.Namespace
+	if pvcNamespace == "" {
+		pvcNamespace = namespace
+	}
+
+	serviceAccount := "default"
+	for _, vol := range vmiSpec.Volumes {
+		if vol.ServiceAccount != nil {
+			serviceAccount = vol.ServiceAccount.ServiceAccountName
+		}
+	}
+
+	allowed, reason, err := cloneAuthFunc(pvcNamespace, spec.Source.PVC.Name, namespace, serviceAccount)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf(reason)
+	}
+	return nil
+}
+
+// storageClassOf returns the StorageClass of a claim, which is the default
+// StorageClass if the claim doesn't name one
+func storageClassOf(spec *k8sv1.PersistentVolumeClaimSpec, storageClassStore cache.Store) string {
+	if spec.StorageClassName != nil {
+		return *spec.StorageClassName
+	}
+	for _, obj := range storageClassStore.List() {
+		storageClass := obj.(*storagev1.StorageClass)
+		if storageClass.Annotations[defaultStorageClassAnnotation] == "true" {
+			return storageClass.Name
+		}
+	}
+	return ""
+}
+
+func volumeModeOf(spec *k8sv1.PersistentVolumeClaimSpec) k8sv1.PersistentVolumeMode {
+	if spec.VolumeMode == nil {
+		return k8sv1.PersistentVolumeFilesystem
+	}
+	return *spec.VolumeMode
+}
@@ -67,6 +67,7 @@ var (
 const StandardLauncherSocketFileName = "launcher-sock"
 const StandardInitLauncherSocketFileName = "launcher-init-sock"
 const StandardLauncherUnresponsiveFileName = "launcher-unresponsive"
+const StandardProfilerSocketFileName = "profiler-sock"
 
 type MigrationOptions struct {
 	Bandwidth               resource.Quantity
@@ -93,6 +94,13 @@ type LauncherClient interface {
 	GetGuestInfo() (*v1.VirtualMachineInstanceGuestAgentInfo, error)
 	GetUsers() (v1.VirtualMachineInstanceGuestOSUserList, error)
 	GetFilesystems() (v1.VirtualMachineInstanceFileSystemList, error)
+	GetNetworkStatus() ([]api.NetworkInterfaceState, error)
+	PlugNetworkInterfaces(vmi *v1.VirtualMachineInstance) error
+	AnnounceNetworkInterfaces(vmi *v1.VirtualMachineInstance) error
+	HibernateVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	ExecQMPCommand(vmi *v1.VirtualMachineInstance, command string) (string, error)
+	FreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	Ping() error
 	Close()
 }
@@ -105,6 +113,8 @@ type VirtLauncherClient struct {
 const (
 	shortTimeout time.Duration = 5 * time.Second
 	longTimeout  time.Duration = 20 * time.Second
+	// hibernateTimeout leaves time to write the whole guest memory to disk
+	hibernateTimeout time.Duration = 5 * time.Minute
 )
 
 func SetLegacyBaseDir(baseDir string) {
@@ -278,6 +288,21 @@ func FindSocketOnHost(vmi *v1.VirtualMachineInstance) (string, error) {
 	return "", fmt.Errorf("No command socket found for vmi %s", vmi.UID)
 }
 
+// FindProfilerSocketOnHost returns the socket virt-launcher serves the
+// profiles of the VMI on
+func FindProfilerSocketOnHost(vmi *v1.VirtualMachineInstance) (string, error) {
+	socketPodDir, err := FindPodDirOnHost(vmi)
+	if err != nil {
+		return "", err
+	}
+	socket := filepath.Join(socketPodDir, StandardProfilerSocketFileName)
+	exists, _ := diskutils.FileExists(socket)
+	if !exists {
+		return "", fmt.Errorf("No profiler socket found for vmi %s, profiling was not enabled when its pod was created", vmi.UID)
+	}
+	return socket, nil
+}
+
 func SocketOnGuest() string {
 	sockFile := StandardLauncherSocketFileName
 	return filepath.Join(LegacySocketsDirectory(), sockFile)
@@ -288,6 +313,10 @@ func UninitializedSocketOnGuest() string {
 	return filepath.Join(LegacySocketsDirectory(), sockFile)
 }
 
+func ProfilerSocketOnGuest() string {
+	return filepath.Join(LegacySocketsDirectory(), StandardProfilerSocketFileName)
+}
+
 func NewClient(socketPath string) (LauncherClient, error) {
 	// dial socket
 	conn, err := grpcutil.DialSocket(socketPath)
@@ -336,6 +365,12 @@ func (c *VirtLauncherClient) Close() {
 func (c *VirtLauncherClient) genericSendVMICmd(cmdName string,
 	cmdFunc func(ctx context.Context, request *cmdv1.VMIRequest, opts ...grpc.CallOption) (*cmdv1.Response, error),
 	vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error {
+	return c.genericSendVMICmdWithTimeout(cmdName, cmdFunc, vmi, options, longTimeout)
+}
+
+func (c *VirtLauncherClient) genericSendVMICmdWithTimeout(cmdName string,
+	cmdFunc func(ctx context.Context, request *cmdv1.VMIRequest, opts ...grpc.CallOption) (*cmdv1.Response, error),
+	vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions, timeout time.Duration) error {
 
 	vmiJson, err := json.Marshal(vmi)
 	if err != nil {
@@ -349,7 +384,7 @@ func (c *VirtLauncherClient) genericSendVMICmd(cmdName string,
 		Options: options,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	response, err := cmdFunc(ctx, request)
 
@@ -364,11 +399,23 @@ func handleError(err error, cmdName string, response *cmdv1.Response) error {
 		msg := fmt.Sprintf("unknown error encountered sending command %s: %s", cmdName, err.Error())
 		return fmt.Errorf(msg)
 	} else if response != nil && response.Success != true {
-		return fmt.Errorf("server error. command %s failed: %q", cmdName, response.Message)
+		return &ServerError{
+			Reason: response.Reason,
+			Msg:    fmt.Sprintf("server error. command %s failed: %q", cmdName, response.Message),
+		}
 	}
 	return nil
 }
 
+// ServerError is returned for commands virt-launcher failed to execute. Reason
+// classifies the failure if virt-launcher knows it.
+type ServerError struct {
+	Reason string
+	Msg    string
+}
+
+func (e *ServerError) Error() string { return e.Msg }
+
 func IsDisconnected(err error) bool {
 	if err == nil {
 		return false
@@ -624,3 +671,84 @@ func (c *VirtLauncherClient) GetFilesystems() (v1.VirtualMachineInstanceFileSyst
 
 	return filesystemList, nil
 }
+
+// GetNetworkStatus returns the state of the VMI network interfaces plugged by virt-launcher
+func (c *VirtLauncherClient) GetNetworkStatus() ([]api.NetworkInterfaceState, error) {
+	states := []api.NetworkInterfaceState{}
+
+	request := &cmdv1.EmptyRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	nsResponse, err := c.v1client.GetNetworkStatus(ctx, request)
+	var response *cmdv1.Response
+	if nsResponse != nil {
+		response = nsResponse.Response
+	}
+
+	if err = handleError(err, "GetNetworkStatus", response); err != nil {
+		return nil, err
+	}
+
+	if nsResponse.GetNetworkStatus() != "" {
+		if err := json.Unmarshal([]byte(nsResponse.GetNetworkStatus()), &states); err != nil {
+			log.Log.Reason(err).Error("error unmarshalling network status response")
+			return nil, err
+		}
+	}
+	return states, nil
+}
+
+// PlugNetworkInterfaces asks virt-launcher to plug the VMI network interfaces into the running domain again
+func (c *VirtLauncherClient) PlugNetworkInterfaces(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("PlugNetworkInterfaces", c.v1client.PlugNetworkInterfaces, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+// AnnounceNetworkInterfaces asks virt-launcher to announce the guest interfaces on the network after a migration
+func (c *VirtLauncherClient) AnnounceNetworkInterfaces(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("AnnounceNetworkInterfaces", c.v1client.AnnounceNetworkInterfaces, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+// HibernateVirtualMachine asks virt-launcher to save the guest memory to the hibernation claim and to shut off the domain
+func (c *VirtLauncherClient) HibernateVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmdWithTimeout("Hibernate", c.v1client.HibernateVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{}, hibernateTimeout)
+}
+
+// FreezeVirtualMachine asks virt-launcher to freeze the guest file systems through the guest agent
+func (c *VirtLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("Freeze", c.v1client.FreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+// UnfreezeVirtualMachine asks virt-launcher to thaw the guest file systems
+func (c *VirtLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+// ExecQMPCommand executes a read-only QMP command against the qemu instance of the VMI and returns its result as JSON
+func (c *VirtLauncherClient) ExecQMPCommand(vmi *v1.VirtualMachineInstance, command string) (string, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return "", err
+	}
+
+	request := &cmdv1.QMPCommandRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Command: command,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	qmpResponse, err := c.v1client.ExecQMPCommand(ctx, request)
+	var response *cmdv1.Response
+	if qmpResponse != nil {
+		response = qmpResponse.Response
+	}
+
+	if err = handleError(err, "ExecQMPCommand", response); err != nil {
+		return "", err
+	}
+	return qmpResponse.GetResult(), nil
+}
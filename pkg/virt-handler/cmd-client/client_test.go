@@ -99,6 +99,28 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(sock).To(Equal(filepath.Join(shareDir, "sockets", "1234_sock")))
 		})
 
+		It("profiler socket from UID", func() {
+			_, err := FindProfilerSocketOnHost(vmi)
+			Expect(err).To(HaveOccurred())
+
+			profilerSocketFile := filepath.Join(filepath.Dir(podSocketFile), StandardProfilerSocketFileName)
+			f, err := os.Create(profilerSocketFile)
+			Expect(err).ToNot(HaveOccurred())
+			f.Close()
+
+			sock, err := FindProfilerSocketOnHost(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sock).To(Equal(profilerSocketFile))
+
+			sock = ProfilerSocketOnGuest()
+			Expect(sock).To(Equal(filepath.Join(shareDir, "sockets", StandardProfilerSocketFileName)))
+
+			// the profiler socket is not a launcher socket
+			sockets, err := ListAllSockets()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sockets).To(ConsistOf(podSocketFile))
+		})
+
 		It("Listing all sockets", func() {
 			// the new socket is already created in the Before function
 
@@ -190,6 +190,78 @@ func (_mr *_MockLauncherClientRecorder) GetFilesystems() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetFilesystems")
 }
 
+func (_m *MockLauncherClient) GetNetworkStatus() ([]api.NetworkInterfaceState, error) {
+	ret := _m.ctrl.Call(_m, "GetNetworkStatus")
+	ret0, _ := ret[0].([]api.NetworkInterfaceState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) GetNetworkStatus() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetNetworkStatus")
+}
+
+func (_m *MockLauncherClient) PlugNetworkInterfaces(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "PlugNetworkInterfaces", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) PlugNetworkInterfaces(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PlugNetworkInterfaces", arg0)
+}
+
+func (_m *MockLauncherClient) AnnounceNetworkInterfaces(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "AnnounceNetworkInterfaces", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) AnnounceNetworkInterfaces(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AnnounceNetworkInterfaces", arg0)
+}
+
+func (_m *MockLauncherClient) HibernateVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "HibernateVirtualMachine", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) HibernateVirtualMachine(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "HibernateVirtualMachine", arg0)
+}
+
+func (_m *MockLauncherClient) ExecQMPCommand(vmi *v1.VirtualMachineInstance, command string) (string, error) {
+	ret := _m.ctrl.Call(_m, "ExecQMPCommand", vmi, command)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) ExecQMPCommand(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExecQMPCommand", arg0, arg1)
+}
+
+func (_m *MockLauncherClient) FreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "FreezeVirtualMachine", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) FreezeVirtualMachine(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FreezeVirtualMachine", arg0)
+}
+
+func (_m *MockLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "UnfreezeVirtualMachine", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) UnfreezeVirtualMachine(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVirtualMachine", arg0)
+}
+
 func (_m *MockLauncherClient) Ping() error {
 	ret := _m.ctrl.Call(_m, "Ping")
 	ret0, _ := ret[0].(error)
@@ -43,10 +43,11 @@ func (_mr *_MockMounterRecorder) ContainerDisksReady(arg0, arg1 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ContainerDisksReady", arg0, arg1)
 }
 
-func (_m *MockMounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
+func (_m *MockMounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) (bool, error) {
 	ret := _m.ctrl.Call(_m, "Mount", vmi, verify)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 func (_mr *_MockMounterRecorder) Mount(arg0, arg1 interface{}) *gomock.Call {
@@ -62,3 +63,13 @@ func (_m *MockMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 func (_mr *_MockMounterRecorder) Unmount(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unmount", arg0)
 }
+
+func (_m *MockMounter) ContainerDiskStatuses(vmi *v1.VirtualMachineInstance) []v1.ContainerDiskStatus {
+	ret := _m.ctrl.Call(_m, "ContainerDiskStatuses", vmi)
+	ret0, _ := ret[0].([]v1.ContainerDiskStatus)
+	return ret0
+}
+
+func (_mr *_MockMounterRecorder) ContainerDiskStatuses(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ContainerDiskStatuses", arg0)
+}
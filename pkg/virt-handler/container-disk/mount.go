@@ -31,12 +31,17 @@ type mounter struct {
 	mountRecordsLock       sync.Mutex
 	suppressWarningTimeout time.Duration
 	pathGetter             containerdisk.SocketPathGetter
+	preparationSlots       chan struct{}
+	preparations           map[types.UID]*vmiPreparation
+	preparationsLock       sync.Mutex
+	prepareDisk            func(vmi *v1.VirtualMachineInstance, volumeIndex int, verify bool) error
 }
 
 type Mounter interface {
 	ContainerDisksReady(vmi *v1.VirtualMachineInstance, notInitializedSince time.Time) (bool, error)
-	Mount(vmi *v1.VirtualMachineInstance, verify bool) error
+	Mount(vmi *v1.VirtualMachineInstance, verify bool) (bool, error)
 	Unmount(vmi *v1.VirtualMachineInstance) error
+	ContainerDiskStatuses(vmi *v1.VirtualMachineInstance) []v1.ContainerDiskStatus
 }
 
 type vmiMountTargetEntry struct {
@@ -48,14 +53,38 @@ type vmiMountTargetRecord struct {
 	MountTargetEntries []vmiMountTargetEntry `json:"mountTargetEntries"`
 }
 
-func NewMounter(isoDetector isolation.PodIsolationDetector, mountStateDir string) Mounter {
-	return &mounter{
+// vmiPreparation tracks the container disks of a VMI which are prepared in the background.
+// The disks and the failed flag are guarded by the preparations lock of the mounter.
+type vmiPreparation struct {
+	disks  []*diskPreparation
+	failed bool
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+type diskPreparation struct {
+	volumeIndex int
+	status      v1.ContainerDiskStatus
+	err         error
+}
+
+// NewMounter creates a mounter which prepares at most maxParallelPreparations container disks of
+// all VMIs of the node at the same time.
+func NewMounter(isoDetector isolation.PodIsolationDetector, mountStateDir string, maxParallelPreparations int) Mounter {
+	if maxParallelPreparations < 1 {
+		maxParallelPreparations = 1
+	}
+	m := &mounter{
 		mountRecords:           make(map[types.UID]*vmiMountTargetRecord),
 		podIsolationDetector:   isoDetector,
 		mountStateDir:          mountStateDir,
 		suppressWarningTimeout: 1 * time.Minute,
 		pathGetter:             containerdisk.NewSocketPathGetter(""),
+		preparationSlots:       make(chan struct{}, maxParallelPreparations),
+		preparations:           make(map[types.UID]*vmiPreparation),
 	}
+	m.prepareDisk = m.prepareContainerDisk
+	return m
 }
 
 func (m *mounter) deleteMountTargetRecord(vmi *v1.VirtualMachineInstance) error {
@@ -177,20 +206,24 @@ func (m *mounter) setMountTargetRecord(vmi *v1.VirtualMachineInstance, record *v
 }
 
 // Mount takes a vmi and mounts all container disks of the VMI, so that they are visible for the qemu process.
-// Additionally qcow2 images are validated if "verify" is true. The validation happens with rlimits set, to avoid DOS.
-func (m *mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
+// Additionally qcow2 images are validated and the disks are compared with their checksums if "verify" is true.
+// The validation happens with rlimits set, to avoid DOS.
+// The disks are prepared in the background in parallel, bounded by the preparation slots of the node. Mount
+// returns false until all disks are prepared, and the error of the first failed disk. A failed preparation is
+// started over on the next call.
+func (m *mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) (bool, error) {
 	record := vmiMountTargetRecord{}
 
 	for i, volume := range vmi.Spec.Volumes {
 		if volume.ContainerDisk != nil {
 			targetFile, err := containerdisk.GetDiskTargetPathFromHostView(vmi, i)
 			if err != nil {
-				return err
+				return false, err
 			}
 
 			sock, err := m.pathGetter(vmi, i)
 			if err != nil {
-				return err
+				return false, err
 			}
 
 			record.MountTargetEntries = append(record.MountTargetEntries, vmiMountTargetEntry{
@@ -200,78 +233,206 @@ func (m *mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
 		}
 	}
 
-	if len(record.MountTargetEntries) > 0 {
-		err := m.setMountTargetRecord(vmi, &record)
-		if err != nil {
-			return err
+	if len(record.MountTargetEntries) == 0 {
+		return true, nil
+	}
+
+	err := m.setMountTargetRecord(vmi, &record)
+	if err != nil {
+		return false, err
+	}
+
+	m.preparationsLock.Lock()
+	defer m.preparationsLock.Unlock()
+
+	preparation, exists := m.preparations[vmi.UID]
+	if !exists {
+		preparation = &vmiPreparation{stop: make(chan struct{})}
+		for i, volume := range vmi.Spec.Volumes {
+			if volume.ContainerDisk != nil {
+				preparation.disks = append(preparation.disks, &diskPreparation{
+					volumeIndex: i,
+					status:      v1.ContainerDiskStatus{Name: volume.Name},
+				})
+			}
+		}
+		m.preparations[vmi.UID] = preparation
+		for _, disk := range preparation.disks {
+			m.startDiskPreparation(vmi, preparation, disk, verify)
+		}
+	} else if preparation.failed {
+		// the failure was reported by the previous call, all goroutines are done
+		preparation.failed = false
+		for _, disk := range preparation.disks {
+			if disk.status.Phase == v1.ContainerDiskFailed {
+				m.startDiskPreparation(vmi, preparation, disk, verify)
+			}
 		}
 	}
 
-	for i, volume := range vmi.Spec.Volumes {
-		if volume.ContainerDisk != nil {
-			targetFile, err := containerdisk.GetDiskTargetPathFromHostView(vmi, i)
-			if err != nil {
-				return err
+	done, ready := true, true
+	for _, disk := range preparation.disks {
+		switch disk.status.Phase {
+		case v1.ContainerDiskReady:
+		case v1.ContainerDiskFailed:
+			ready = false
+			if err == nil {
+				err = disk.err
 			}
+		default:
+			done, ready = false, false
+		}
+	}
+	if done && err != nil {
+		preparation.failed = true
+		return false, err
+	}
+	return ready, nil
+}
 
-			nodeRes := isolation.NodeIsolationResult()
+// startDiskPreparation prepares the container disk in a goroutine. The caller has to hold the preparations lock.
+func (m *mounter) startDiskPreparation(vmi *v1.VirtualMachineInstance, preparation *vmiPreparation, disk *diskPreparation, verify bool) {
+	disk.status.Phase = v1.ContainerDiskPending
+	disk.status.Message = ""
+	disk.err = nil
+	preparation.wg.Add(1)
+	go m.prepare(vmi.DeepCopy(), preparation, disk, verify)
+}
 
-			if isMounted, err := nodeRes.IsMounted(targetFile); err != nil {
-				return fmt.Errorf("failed to determine if %s is already mounted: %v", targetFile, err)
-			} else if !isMounted {
-				sock, err := m.pathGetter(vmi, i)
-				if err != nil {
-					return err
-				}
+// prepare waits for a free preparation slot of the node and mounts and verifies the container disk
+func (m *mounter) prepare(vmi *v1.VirtualMachineInstance, preparation *vmiPreparation, disk *diskPreparation, verify bool) {
+	defer preparation.wg.Done()
 
-				res, err := m.podIsolationDetector.DetectForSocket(vmi, sock)
-				if err != nil {
-					return fmt.Errorf("failed to detect socket for containerDisk %v: %v", volume.Name, err)
-				}
-				mountInfo, err := res.MountInfoRoot()
-				if err != nil {
-					return fmt.Errorf("failed to detect root mount info of containerDisk  %v: %v", volume.Name, err)
-				}
-				rootPath, err := nodeRes.FullPath(mountInfo)
-				if err != nil {
-					return fmt.Errorf("failed to detect root mount point of containerDisk %v on the node: %v", volume.Name, err)
-				}
-				sourceFile, err := containerdisk.GetImage(rootPath, volume.ContainerDisk.Path)
-				if err != nil {
-					return fmt.Errorf("failed to find a sourceFile in containerDisk %v: %v", volume.Name, err)
-				}
-				f, err := os.Create(targetFile)
-				if err != nil {
-					return fmt.Errorf("failed to create mount point target %v: %v", targetFile, err)
-				}
-				f.Close()
+	select {
+	case m.preparationSlots <- struct{}{}:
+	case <-preparation.stop:
+		return
+	}
+	defer func() { <-m.preparationSlots }()
 
-				log.DefaultLogger().Object(vmi).Infof("Bind mounting container disk at %s to %s", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile)
-				// #nosec g204 no risk to TrimPref as argument as it just trims two fixed strings
-				out, err := exec.Command("/usr/bin/virt-chroot", "--mount", "/proc/1/ns/mnt", "mount", "-o", "ro,bind", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile).CombinedOutput()
-				if err != nil {
-					return fmt.Errorf("failed to bindmount containerDisk %v: %v : %v", volume.Name, string(out), err)
-				}
-			}
-			if verify {
-				res, err := m.podIsolationDetector.Detect(vmi)
-				if err != nil {
-					return fmt.Errorf("failed to detect VMI pod: %v", err)
-				}
-				imageInfo, err := isolation.GetImageInfo(containerdisk.GetDiskTargetPathFromLauncherView(i), res)
-				if err != nil {
-					return fmt.Errorf("failed to get image info: %v", err)
-				}
+	m.setPreparationPhase(disk, v1.ContainerDiskPreparing, nil)
+	if err := m.prepareDisk(vmi, disk.volumeIndex, verify); err != nil {
+		m.setPreparationPhase(disk, v1.ContainerDiskFailed, err)
+		return
+	}
+	m.setPreparationPhase(disk, v1.ContainerDiskReady, nil)
+}
 
-				if err := containerdisk.VerifyImage(imageInfo); err != nil {
-					return fmt.Errorf("invalid image in containerDisk %v: %v", volume.Name, err)
-				}
+func (m *mounter) setPreparationPhase(disk *diskPreparation, phase v1.ContainerDiskPhase, err error) {
+	m.preparationsLock.Lock()
+	defer m.preparationsLock.Unlock()
+	disk.status.Phase = phase
+	disk.status.Message = ""
+	disk.err = err
+	if err != nil {
+		disk.status.Message = err.Error()
+	}
+}
+
+// prepareContainerDisk bind mounts the disk of a container disk, unless it is already mounted, and verifies it
+func (m *mounter) prepareContainerDisk(vmi *v1.VirtualMachineInstance, volumeIndex int, verify bool) error {
+	volume := vmi.Spec.Volumes[volumeIndex]
+	targetFile, err := containerdisk.GetDiskTargetPathFromHostView(vmi, volumeIndex)
+	if err != nil {
+		return err
+	}
+
+	nodeRes := isolation.NodeIsolationResult()
+
+	sock, err := m.pathGetter(vmi, volumeIndex)
+	if err != nil {
+		return err
+	}
+
+	res, err := m.podIsolationDetector.DetectForSocket(vmi, sock)
+	if err != nil {
+		return fmt.Errorf("failed to detect socket for containerDisk %v: %v", volume.Name, err)
+	}
+	mountInfo, err := res.MountInfoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect root mount info of containerDisk  %v: %v", volume.Name, err)
+	}
+	rootPath, err := nodeRes.FullPath(mountInfo)
+	if err != nil {
+		return fmt.Errorf("failed to detect root mount point of containerDisk %v on the node: %v", volume.Name, err)
+	}
+	sourceFile, err := containerdisk.GetImage(rootPath, volume.ContainerDisk.Path)
+	if err != nil {
+		return fmt.Errorf("failed to find a sourceFile in containerDisk %v: %v", volume.Name, err)
+	}
+
+	if isMounted, err := nodeRes.IsMounted(targetFile); err != nil {
+		return fmt.Errorf("failed to determine if %s is already mounted: %v", targetFile, err)
+	} else if !isMounted {
+		f, err := os.Create(targetFile)
+		if err != nil {
+			return fmt.Errorf("failed to create mount point target %v: %v", targetFile, err)
+		}
+		f.Close()
+
+		log.DefaultLogger().Object(vmi).Infof("Bind mounting container disk at %s to %s", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile)
+		// #nosec g204 no risk to TrimPref as argument as it just trims two fixed strings
+		out, err := exec.Command("/usr/bin/virt-chroot", "--mount", "/proc/1/ns/mnt", "mount", "-o", "ro,bind", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to bindmount containerDisk %v: %v : %v", volume.Name, string(out), err)
+		}
+	}
+
+	if verify {
+		res, err := m.podIsolationDetector.Detect(vmi)
+		if err != nil {
+			return fmt.Errorf("failed to detect VMI pod: %v", err)
+		}
+		imageInfo, err := isolation.GetImageInfo(containerdisk.GetDiskTargetPathFromLauncherView(volumeIndex), res)
+		if err != nil {
+			return fmt.Errorf("failed to get image info: %v", err)
+		}
+
+		if err := containerdisk.VerifyImage(imageInfo); err != nil {
+			return fmt.Errorf("invalid image in containerDisk %v: %v", volume.Name, err)
+		}
+
+		if volume.ContainerDisk.Checksum != "" {
+			log.DefaultLogger().Object(vmi).Infof("Verifying the checksum of container disk %s", volume.Name)
+			if err := containerdisk.VerifyChecksum(sourceFile, volume.ContainerDisk.Checksum); err != nil {
+				return fmt.Errorf("failed to verify containerDisk %s: %v", volume.Name, err)
 			}
 		}
 	}
 	return nil
 }
 
+// ContainerDiskStatuses returns the preparation progress of the container disks of the VMI, or nil if
+// the disks are not prepared by this virt-handler.
+func (m *mounter) ContainerDiskStatuses(vmi *v1.VirtualMachineInstance) []v1.ContainerDiskStatus {
+	m.preparationsLock.Lock()
+	defer m.preparationsLock.Unlock()
+
+	preparation, exists := m.preparations[vmi.UID]
+	if !exists {
+		return nil
+	}
+	statuses := make([]v1.ContainerDiskStatus, 0, len(preparation.disks))
+	for _, disk := range preparation.disks {
+		statuses = append(statuses, disk.status)
+	}
+	return statuses
+}
+
+// stopPreparation stops the preparation of the container disks of the VMI and waits for the
+// disks which are already being prepared
+func (m *mounter) stopPreparation(vmi *v1.VirtualMachineInstance) {
+	m.preparationsLock.Lock()
+	preparation, exists := m.preparations[vmi.UID]
+	delete(m.preparations, vmi.UID)
+	m.preparationsLock.Unlock()
+
+	if exists {
+		close(preparation.stop)
+		preparation.wg.Wait()
+	}
+}
+
 // Legacy Unmount unmounts all container disks of a given VMI when the hold HostPath method was in use.
 // This exists for backwards compatibility for VMIs running before a KubeVirt update occurs.
 func (m *mounter) legacyUnmount(vmi *v1.VirtualMachineInstance) error {
@@ -317,6 +478,8 @@ func (m *mounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 			return err
 		}
 
+		m.stopPreparation(vmi)
+
 		record, err := m.getMountTargetRecord(vmi)
 		if err != nil {
 			return err
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -54,6 +55,8 @@ var _ = Describe("ContainerDisk", func() {
 			mountStateDir:          tmpDir,
 			suppressWarningTimeout: 1 * time.Minute,
 			pathGetter:             containerdisk.NewSocketPathGetter(""),
+			preparationSlots:       make(chan struct{}, 2),
+			preparations:           make(map[types.UID]*vmiPreparation),
 		}
 	})
 
@@ -170,4 +173,118 @@ var _ = Describe("ContainerDisk", func() {
 			Expect(record).To(BeNil())
 		})
 	})
+
+	Context("preparing containerDisks", func() {
+		var release chan struct{}
+		var lock sync.Mutex
+		var running, maxRunning int
+		var failures map[int]int
+
+		phases := func() []v1.ContainerDiskPhase {
+			var phases []v1.ContainerDiskPhase
+			for _, status := range m.ContainerDiskStatuses(vmi) {
+				phases = append(phases, status.Phase)
+			}
+			return phases
+		}
+
+		BeforeEach(func() {
+			podsDir := filepath.Join(tmpDir, "pods")
+			containerdisk.SetKubeletPodsDirectory(podsDir)
+			Expect(os.MkdirAll(filepath.Join(podsDir, "poduid", "volumes", "kubernetes.io~empty-dir", "container-disks"), 0755)).To(Succeed())
+			vmi.Status.ActivePods = map[types.UID]string{"poduid": "node01"}
+			for _, name := range []string{"test1", "test2"} {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: name,
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{},
+					},
+				})
+			}
+
+			release = make(chan struct{})
+			running, maxRunning = 0, 0
+			failures = map[int]int{}
+			m.pathGetter = func(vmi *v1.VirtualMachineInstance, volumeIndex int) (string, error) {
+				return fmt.Sprintf("disk_%d.sock", volumeIndex), nil
+			}
+			m.prepareDisk = func(vmi *v1.VirtualMachineInstance, volumeIndex int, verify bool) error {
+				lock.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+
+				<-release
+
+				lock.Lock()
+				defer lock.Unlock()
+				running--
+				if failures[volumeIndex] > 0 {
+					failures[volumeIndex]--
+					return fmt.Errorf("failed to bindmount containerDisk %s", vmi.Spec.Volumes[volumeIndex].Name)
+				}
+				return nil
+			}
+		})
+
+		AfterEach(func() {
+			containerdisk.SetKubeletPodsDirectory("/var/lib/kubelet/pods")
+		})
+
+		It("should prepare the disks in parallel bounded by the preparation slots", func() {
+			ready, err := m.Mount(vmi, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ready).To(BeFalse())
+			Eventually(phases).Should(ConsistOf(v1.ContainerDiskPreparing, v1.ContainerDiskPreparing, v1.ContainerDiskPending))
+
+			close(release)
+			Eventually(func() bool {
+				ready, err := m.Mount(vmi, true)
+				Expect(err).ToNot(HaveOccurred())
+				return ready
+			}).Should(BeTrue())
+			Expect(phases()).To(ConsistOf(v1.ContainerDiskReady, v1.ContainerDiskReady, v1.ContainerDiskReady))
+			Expect(maxRunning).To(Equal(2))
+		})
+
+		It("should report a failed disk once all disks are done and retry it on the next mount", func() {
+			failures[1] = 1
+			close(release)
+
+			var err error
+			Eventually(func() error {
+				_, err = m.Mount(vmi, true)
+				return err
+			}).Should(MatchError("failed to bindmount containerDisk test1"))
+			statuses := m.ContainerDiskStatuses(vmi)
+			Expect(statuses).To(HaveLen(3))
+			Expect(statuses[1]).To(Equal(v1.ContainerDiskStatus{
+				Name:    "test1",
+				Phase:   v1.ContainerDiskFailed,
+				Message: "failed to bindmount containerDisk test1",
+			}))
+
+			Eventually(func() bool {
+				ready, err := m.Mount(vmi, true)
+				Expect(err).ToNot(HaveOccurred())
+				return ready
+			}).Should(BeTrue())
+		})
+
+		It("should stop the pending preparations", func() {
+			m.preparationSlots = make(chan struct{}, 1)
+			m.preparationSlots <- struct{}{}
+
+			ready, err := m.Mount(vmi, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ready).To(BeFalse())
+			Expect(phases()).To(ConsistOf(v1.ContainerDiskPending, v1.ContainerDiskPending, v1.ContainerDiskPending))
+
+			m.stopPreparation(vmi)
+			Expect(m.ContainerDiskStatuses(vmi)).To(BeNil())
+			Expect(maxRunning).To(Equal(0))
+		})
+	})
 })
@@ -20,20 +20,33 @@
 package device_manager
 
 import (
+	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
-var permanentDevicePluginPaths = map[string]string{
-	"kvm":       "/dev/kvm",
-	"tun":       "/dev/net/tun",
-	"vhost-net": "/dev/vhost-net",
+// deviceNode is a device node of the host which is exposed as a resource by a GenericDevicePlugin
+type deviceNode struct {
+	path string
+	// preOpen opens the device node once before the resource is advertised,
+	// to load the kernel modules of the device
+	preOpen bool
+}
+
+var permanentDeviceNodes = map[string]deviceNode{
+	"kvm":       {path: "/dev/kvm"},
+	"tun":       {path: "/dev/net/tun", preOpen: true},
+	"vhost-net": {path: "/dev/vhost-net", preOpen: true},
 }
 
 type DeviceController struct {
@@ -51,17 +64,35 @@ type ControlledDevice struct {
 	stopChan     chan struct{}
 }
 
+func newDeviceNodePlugin(name string, node deviceNode, maxDevices int) ControlledDevice {
+	return ControlledDevice{
+		devicePlugin: NewGenericDevicePlugin(name, node.path, maxDevices, node.preOpen),
+		stopChan:     make(chan struct{}),
+	}
+}
+
 func getPermanentHostDevicePlugins(maxDevices int) map[string]ControlledDevice {
 	ret := map[string]ControlledDevice{}
-	for name, path := range permanentDevicePluginPaths {
-		ret[name] = ControlledDevice{
-			devicePlugin: NewGenericDevicePlugin(name, path, maxDevices, (name != "kvm")),
-			stopChan:     make(chan struct{}),
-		}
+	for name, node := range permanentDeviceNodes {
+		ret[name] = newDeviceNodePlugin(name, node, maxDevices)
 	}
 	return ret
 }
 
+// validGenericHostDevice returns an error if a generic host device can't be exposed as a resource
+func validGenericHostDevice(dev v1.GenericHostDevice) error {
+	if _, isPermanent := permanentDeviceNodes[dev.Name]; isPermanent {
+		return fmt.Errorf("the device %s is already exposed by KubeVirt", dev.Name)
+	}
+	if errs := validation.IsDNS1123Label(dev.Name); len(errs) != 0 {
+		return fmt.Errorf("invalid device name %s: %s", dev.Name, strings.Join(errs, ", "))
+	}
+	if filepath.Clean(dev.Path) != dev.Path || !strings.HasPrefix(dev.Path, "/dev/") {
+		return fmt.Errorf("the path %s of the device %s is not a clean path below /dev", dev.Path, dev.Name)
+	}
+	return nil
+}
+
 func NewDeviceController(host string, maxDevices int, clusterConfig *virtconfig.ClusterConfig) *DeviceController {
 	controller := &DeviceController{
 		devicePlugins: getPermanentHostDevicePlugins(maxDevices),
@@ -116,7 +147,7 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() (map[string]Contro
 	if hostDevs := c.virtConfig.GetPermittedHostDevices(); hostDevs != nil {
 		// generate a map of currently started device plugins
 		for resourceName, hostDevDP := range c.devicePlugins {
-			_, isPermanent := permanentDevicePluginPaths[resourceName]
+			_, isPermanent := permanentDeviceNodes[resourceName]
 			if !isPermanent {
 				devicePluginsToStop[resourceName] = hostDevDP
 			}
@@ -167,6 +198,40 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() (map[string]Contro
 				}
 			}
 		}
+		if len(hostDevs.NvmeNamespaces) != 0 {
+			supportedNvmeDeviceMap := make(map[string]string)
+			for _, nvmeDev := range hostDevs.NvmeNamespaces {
+				// do not add a device plugin for this resource if it's being provided via an external device plugin
+				if !nvmeDev.ExternalResourceProvider {
+					supportedNvmeDeviceMap[nvmeDev.PCIVendorSelector] = nvmeDev.ResourceName
+				}
+			}
+			nvmeHostDevices := discoverPermittedHostNvmeNamespaces(supportedNvmeDeviceMap)
+			for pciID, nvmeDevices := range nvmeHostDevices {
+				nvmeResourceName := supportedNvmeDeviceMap[pciID]
+				// add a device plugin only for new devices
+				if _, isRunning := c.devicePlugins[nvmeResourceName]; !isRunning {
+					devicePluginsToRun[nvmeResourceName] = ControlledDevice{
+						devicePlugin: NewNvmeNamespaceDevicePlugin(nvmeDevices, nvmeResourceName),
+						stopChan:     make(chan struct{}),
+					}
+				} else {
+					delete(devicePluginsToStop, nvmeResourceName)
+				}
+			}
+		}
+		for _, genericDev := range hostDevs.GenericHostDevices {
+			if err := validGenericHostDevice(genericDev); err != nil {
+				log.DefaultLogger().Reason(err).Errorf("Ignoring generic host device %s", genericDev.Name)
+				continue
+			}
+			// add a device plugin only for new devices
+			if _, isRunning := c.devicePlugins[genericDev.Name]; !isRunning {
+				devicePluginsToRun[genericDev.Name] = newDeviceNodePlugin(genericDev.Name, deviceNode{path: genericDev.Path, preOpen: genericDev.PreOpen}, c.maxDevices)
+			} else {
+				delete(devicePluginsToStop, genericDev.Name)
+			}
+		}
 	}
 	return devicePluginsToRun, devicePluginsToStop
 }
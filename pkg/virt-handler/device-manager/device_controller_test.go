@@ -9,6 +9,7 @@ import (
 	"time"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
 
@@ -167,4 +168,62 @@ var _ = Describe("Device Controller", func() {
 			}).Should(BeNumerically(">=", 1))
 		})
 	})
+
+	Context("Generic host devices", func() {
+		newDeviceController := func(permittedDevices string) *DeviceController {
+			clusterConfig, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.PermittedHostDevicesKey: permittedDevices},
+			})
+			deviceController := NewDeviceController(host, 10, clusterConfig)
+			return deviceController
+		}
+
+		It("should start a device plugin for a permitted device", func() {
+			deviceController := newDeviceController(`{"genericHostDevices":[{"name":"sev","path":"/dev/sev"}]}`)
+
+			enabledDevicePlugins, disabledDevicePlugins := deviceController.updatePermittedHostDevicePlugins()
+			Expect(disabledDevicePlugins).To(BeEmpty())
+			Expect(enabledDevicePlugins).To(HaveLen(1))
+			Expect(enabledDevicePlugins).To(HaveKey("sev"))
+			Expect(enabledDevicePlugins["sev"].devicePlugin.GetDevicePath()).To(Equal("/dev/sev"))
+		})
+
+		It("should stop the device plugin of a device which is not permitted anymore", func() {
+			deviceController := newDeviceController(`{"genericHostDevices":[]}`)
+			deviceController.devicePlugins["sev"] = ControlledDevice{
+				devicePlugin: NewFakePlugin("sev", "/dev/sev"),
+				stopChan:     make(chan struct{}),
+			}
+
+			enabledDevicePlugins, disabledDevicePlugins := deviceController.updatePermittedHostDevicePlugins()
+			Expect(enabledDevicePlugins).To(BeEmpty())
+			Expect(disabledDevicePlugins).To(HaveLen(1))
+			Expect(disabledDevicePlugins).To(HaveKey("sev"))
+		})
+
+		It("should keep the device plugin of a device which is still permitted", func() {
+			deviceController := newDeviceController(`{"genericHostDevices":[{"name":"sev","path":"/dev/sev"}]}`)
+			deviceController.devicePlugins["sev"] = ControlledDevice{
+				devicePlugin: NewFakePlugin("sev", "/dev/sev"),
+				stopChan:     make(chan struct{}),
+			}
+
+			enabledDevicePlugins, disabledDevicePlugins := deviceController.updatePermittedHostDevicePlugins()
+			Expect(enabledDevicePlugins).To(BeEmpty())
+			Expect(disabledDevicePlugins).To(BeEmpty())
+		})
+
+		table.DescribeTable("should ignore invalid devices", func(permittedDevices string) {
+			deviceController := newDeviceController(permittedDevices)
+
+			enabledDevicePlugins, disabledDevicePlugins := deviceController.updatePermittedHostDevicePlugins()
+			Expect(enabledDevicePlugins).To(BeEmpty())
+			Expect(disabledDevicePlugins).To(BeEmpty())
+		},
+			table.Entry("with the name of a permanent device", `{"genericHostDevices":[{"name":"kvm","path":"/dev/sev"}]}`),
+			table.Entry("with an invalid name", `{"genericHostDevices":[{"name":"SEV/0","path":"/dev/sev"}]}`),
+			table.Entry("with a path outside of /dev", `{"genericHostDevices":[{"name":"sev","path":"/etc/shadow"}]}`),
+			table.Entry("with a path escaping /dev", `{"genericHostDevices":[{"name":"sev","path":"/dev/../etc/shadow"}]}`),
+		)
+	})
 })
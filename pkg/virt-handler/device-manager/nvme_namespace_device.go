@@ -0,0 +1,231 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// nvmeClass is the PCI class of NVMe controllers, mass storage / non-volatile memory / NVMe
+	nvmeClass     = "0x010802"
+	nvmeDriver    = "nvme"
+	vfioPCIDriver = "vfio-pci"
+)
+
+// nvmeSysfs binds the NVMe controllers of permitted namespaces to vfio-pci and resets them.
+// The paths point to the sysfs and the mounts of the host, tests replace them.
+type nvmeSysfs struct {
+	pciDevicesPath   string
+	driversProbePath string
+	hostMountsPath   string
+}
+
+var nvmeHost = &nvmeSysfs{
+	pciDevicesPath:   pciBasePath,
+	driversProbePath: "/sys/bus/pci/drivers_probe",
+	hostMountsPath:   "/proc/1/mounts",
+}
+
+func NewNvmeNamespaceDevicePlugin(pciDevices []*PCIDevice, resourceName string) *PCIDevicePlugin {
+	dpi := NewPCIDevicePlugin(pciDevices, resourceName)
+	dpi.socketPath = SocketPath("nvme-" + strings.Replace(pciDevices[0].pciID, ":", "-", -1))
+	dpi.resetDevices = true
+	return dpi
+}
+
+func discoverPermittedHostNvmeNamespaces(supportedNvmeDeviceMap map[string]string) map[string][]*PCIDevice {
+	return nvmeHost.discover(supportedNvmeDeviceMap)
+}
+
+// ResetNvmeControllers resets the passed through NVMe controllers among the PCI functions, so that
+// the next VMI doesn't find the state the previous one left in the controller
+func ResetNvmeControllers(pciAddresses []string) {
+	for _, address := range pciAddresses {
+		if !nvmeHost.isPassedThroughController(address) {
+			continue
+		}
+		if err := nvmeHost.reset(address); err != nil {
+			log.DefaultLogger().Reason(err).Errorf("failed to reset the NVMe controller %s", address)
+		}
+	}
+}
+
+// discover returns the permitted NVMe controllers by their vendor:device ID. Controllers still bound to
+// the nvme driver are bound to vfio-pci, if they are virtual functions with a single namespace attached,
+// which isn't used by the host.
+func (h *nvmeSysfs) discover(supportedNvmeDeviceMap map[string]string) map[string][]*PCIDevice {
+	initHandler()
+
+	nvmeDevicesMap := make(map[string][]*PCIDevice)
+	entries, err := ioutil.ReadDir(h.pciDevicesPath)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to discover NVMe namespaces")
+		return nvmeDevicesMap
+	}
+	for _, entry := range entries {
+		address := entry.Name()
+		pciID, err := Handler.GetDevicePCIID(h.pciDevicesPath, address)
+		if err != nil {
+			continue
+		}
+		if _, supported := supportedNvmeDeviceMap[pciID]; !supported {
+			continue
+		}
+		logger := log.DefaultLogger().With("address", address)
+		if !h.isNvmeController(address) || !h.isVirtualFunction(address) {
+			logger.Errorf("%s is not the virtual function of an NVMe drive, ignoring it", address)
+			continue
+		}
+
+		driver, _ := Handler.GetDeviceDriver(h.pciDevicesPath, address)
+		switch driver {
+		case vfioPCIDriver:
+		case nvmeDriver:
+			if err := h.bindToVFIO(address); err != nil {
+				logger.Reason(err).Errorf("failed to bind the NVMe controller %s to %s", address, vfioPCIDriver)
+				continue
+			}
+			logger.Infof("bound the NVMe controller %s to %s", address, vfioPCIDriver)
+		default:
+			logger.Errorf("the NVMe controller %s is bound to the unexpected driver %q, ignoring it", address, driver)
+			continue
+		}
+
+		iommuGroup, err := Handler.GetDeviceIOMMUGroup(h.pciDevicesPath, address)
+		if err != nil {
+			continue
+		}
+		nvmeDevicesMap[pciID] = append(nvmeDevicesMap[pciID], &PCIDevice{
+			pciID:      pciID,
+			driver:     vfioPCIDriver,
+			pciAddress: address,
+			iommuGroup: iommuGroup,
+			numaNode:   Handler.GetDeviceNumaNode(h.pciDevicesPath, address),
+		})
+	}
+	return nvmeDevicesMap
+}
+
+func (h *nvmeSysfs) isNvmeController(address string) bool {
+	// #nosec No risk for path injection. Reading static path of PCI data
+	class, err := ioutil.ReadFile(filepath.Join(h.pciDevicesPath, address, "class"))
+	return err == nil && strings.TrimSpace(string(class)) == nvmeClass
+}
+
+func (h *nvmeSysfs) isVirtualFunction(address string) bool {
+	_, err := os.Lstat(filepath.Join(h.pciDevicesPath, address, "physfn"))
+	return err == nil
+}
+
+func (h *nvmeSysfs) isPassedThroughController(address string) bool {
+	driver, err := Handler.GetDeviceDriver(h.pciDevicesPath, address)
+	return err == nil && driver == vfioPCIDriver && h.isNvmeController(address)
+}
+
+// namespaces returns the names of the namespaces attached to a controller bound to the nvme driver
+func (h *nvmeSysfs) namespaces(address string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(h.pciDevicesPath, address, "nvme", "nvme*", "nvme*n*"))
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{}
+	for _, path := range paths {
+		namespaces = append(namespaces, filepath.Base(path))
+	}
+	return namespaces, nil
+}
+
+// namespaceInUse checks if the host mounted the namespace or one of its partitions, or if
+// another block device, like a device mapper or md device, is stacked on top of it
+func (h *nvmeSysfs) namespaceInUse(address string, namespace string) (bool, error) {
+	holders, err := filepath.Glob(filepath.Join(h.pciDevicesPath, address, "nvme", "nvme*", namespace, "holders", "*"))
+	if err != nil {
+		return false, err
+	}
+	if len(holders) > 0 {
+		return true, nil
+	}
+
+	// #nosec No risk for path injection. Reading static path of the host mounts
+	mounts, err := os.Open(h.hostMountsPath)
+	if err != nil {
+		return false, err
+	}
+	defer mounts.Close()
+	scanner := bufio.NewScanner(mounts)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "/dev/"+namespace+" ") || strings.HasPrefix(scanner.Text(), "/dev/"+namespace+"p") {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func (h *nvmeSysfs) bindToVFIO(address string) error {
+	namespaces, err := h.namespaces(address)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) != 1 {
+		return fmt.Errorf("the controller has %d namespaces attached, it needs exactly one to be passed through", len(namespaces))
+	}
+	inUse, err := h.namespaceInUse(address, namespaces[0])
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return fmt.Errorf("the namespace %s is used by the host", namespaces[0])
+	}
+
+	devicePath := filepath.Join(h.pciDevicesPath, address)
+	if err := writeSysfs(filepath.Join(devicePath, "driver_override"), vfioPCIDriver); err != nil {
+		return err
+	}
+	if err := writeSysfs(filepath.Join(devicePath, "driver", "unbind"), address); err != nil {
+		return err
+	}
+	return writeSysfs(h.driversProbePath, address)
+}
+
+// reset triggers a function level reset of the controller
+func (h *nvmeSysfs) reset(address string) error {
+	return writeSysfs(filepath.Join(h.pciDevicesPath, address, "reset"), "1")
+}
+
+func writeSysfs(path string, value string) error {
+	// #nosec No risk for path injection. Writing static paths of PCI devices
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(value); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s to %s: %v", value, path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,160 @@
+package device_manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
+
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+var _ = Describe("NVMe namespace device", func() {
+	const (
+		nvmeID      = "144D:A824"
+		nvmeAddress = "0000:81:00.1"
+		nvmeGroup   = "42"
+	)
+
+	var root string
+	var devicePath string
+	var originalHost *nvmeSysfs
+	var originalHandler DeviceHandler
+
+	readFile := func(path string) string {
+		content, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	writeFile := func(path string, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	setDriver := func(driver string) {
+		Expect(os.MkdirAll(filepath.Join(root, "drivers", driver), 0755)).To(Succeed())
+		writeFile(filepath.Join(root, "drivers", driver, "unbind"), "")
+		os.Remove(filepath.Join(devicePath, "driver"))
+		Expect(os.Symlink(filepath.Join("..", "..", "drivers", driver), filepath.Join(devicePath, "driver"))).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		root, err = ioutil.TempDir("", "nvme")
+		Expect(err).ToNot(HaveOccurred())
+
+		// a virtual function of an NVMe drive, bound to the nvme driver with namespace nvme3n1
+		devicePath = filepath.Join(root, "devices", nvmeAddress)
+		writeFile(filepath.Join(devicePath, "uevent"), "DRIVER=nvme\nPCI_ID="+nvmeID+"\n")
+		writeFile(filepath.Join(devicePath, "class"), nvmeClass+"\n")
+		writeFile(filepath.Join(devicePath, "numa_node"), "1\n")
+		writeFile(filepath.Join(devicePath, "driver_override"), "")
+		writeFile(filepath.Join(devicePath, "reset"), "")
+		Expect(os.MkdirAll(filepath.Join(devicePath, "nvme", "nvme3", "nvme3n1", "holders"), 0755)).To(Succeed())
+		Expect(os.Symlink("../0000:81:00.0", filepath.Join(devicePath, "physfn"))).To(Succeed())
+		Expect(os.Symlink("../../kernel/iommu_groups/"+nvmeGroup, filepath.Join(devicePath, "iommu_group"))).To(Succeed())
+		setDriver(nvmeDriver)
+		writeFile(filepath.Join(root, "drivers_probe"), "")
+		writeFile(filepath.Join(root, "mounts"), "/dev/nvme0n1p1 /boot xfs rw 0 0\n")
+
+		originalHost = nvmeHost
+		nvmeHost = &nvmeSysfs{
+			pciDevicesPath:   filepath.Join(root, "devices"),
+			driversProbePath: filepath.Join(root, "drivers_probe"),
+			hostMountsPath:   filepath.Join(root, "mounts"),
+		}
+		originalHandler = Handler
+		Handler = &DeviceUtilsHandler{}
+	})
+
+	AfterEach(func() {
+		nvmeHost = originalHost
+		Handler = originalHandler
+		os.RemoveAll(root)
+	})
+
+	It("should bind a virtual function with an unused namespace to vfio-pci", func() {
+		devices := discoverPermittedHostNvmeNamespaces(map[string]string{nvmeID: "example.org/nvme"})
+
+		Expect(devices[nvmeID]).To(HaveLen(1))
+		Expect(devices[nvmeID][0].pciAddress).To(Equal(nvmeAddress))
+		Expect(devices[nvmeID][0].iommuGroup).To(Equal(nvmeGroup))
+		Expect(devices[nvmeID][0].numaNode).To(Equal(1))
+		Expect(readFile(filepath.Join(devicePath, "driver_override"))).To(Equal(vfioPCIDriver))
+		Expect(readFile(filepath.Join(root, "drivers", nvmeDriver, "unbind"))).To(Equal(nvmeAddress))
+		Expect(readFile(filepath.Join(root, "drivers_probe"))).To(Equal(nvmeAddress))
+	})
+
+	It("should advertise a controller already bound to vfio-pci", func() {
+		setDriver(vfioPCIDriver)
+
+		devices := discoverPermittedHostNvmeNamespaces(map[string]string{nvmeID: "example.org/nvme"})
+
+		Expect(devices[nvmeID]).To(HaveLen(1))
+		Expect(readFile(filepath.Join(root, "drivers_probe"))).To(BeEmpty())
+	})
+
+	table.DescribeTable("should not bind a controller", func(prepare func()) {
+		prepare()
+
+		devices := discoverPermittedHostNvmeNamespaces(map[string]string{nvmeID: "example.org/nvme"})
+
+		Expect(devices).To(BeEmpty())
+		Expect(readFile(filepath.Join(devicePath, "driver_override"))).To(BeEmpty())
+		Expect(readFile(filepath.Join(root, "drivers", nvmeDriver, "unbind"))).To(BeEmpty())
+	},
+		table.Entry("which is not permitted", func() {
+			writeFile(filepath.Join(devicePath, "uevent"), "PCI_ID=144D:A808\n")
+		}),
+		table.Entry("which is a physical function", func() {
+			Expect(os.Remove(filepath.Join(devicePath, "physfn"))).To(Succeed())
+		}),
+		table.Entry("which is not an NVMe controller", func() {
+			writeFile(filepath.Join(devicePath, "class"), "0x020000\n")
+		}),
+		table.Entry("with several namespaces", func() {
+			Expect(os.MkdirAll(filepath.Join(devicePath, "nvme", "nvme3", "nvme3n2"), 0755)).To(Succeed())
+		}),
+		table.Entry("with a mounted partition", func() {
+			writeFile(filepath.Join(root, "mounts"), "/dev/nvme3n1p1 /data ext4 rw 0 0\n")
+		}),
+		table.Entry("with a stacked block device", func() {
+			writeFile(filepath.Join(devicePath, "nvme", "nvme3", "nvme3n1", "holders", "dm-0"), "")
+		}),
+		table.Entry("bound to another driver", func() {
+			setDriver("uio_pci_generic")
+		}),
+	)
+
+	It("should reset the passed through NVMe controllers only", func() {
+		setDriver(vfioPCIDriver)
+		otherPath := filepath.Join(root, "devices", "0000:03:00.0")
+		writeFile(filepath.Join(otherPath, "class"), "0x030000\n")
+		writeFile(filepath.Join(otherPath, "reset"), "")
+		Expect(os.Symlink(filepath.Join("..", "..", "drivers", vfioPCIDriver), filepath.Join(otherPath, "driver"))).To(Succeed())
+
+		ResetNvmeControllers([]string{nvmeAddress, "0000:03:00.0"})
+
+		Expect(readFile(filepath.Join(devicePath, "reset"))).To(Equal("1"))
+		Expect(readFile(filepath.Join(otherPath, "reset"))).To(BeEmpty())
+	})
+
+	It("should reset the controllers before they are passed to a container", func() {
+		setDriver(vfioPCIDriver)
+		devices := discoverPermittedHostNvmeNamespaces(map[string]string{nvmeID: "example.org/nvme"})
+		dpi := NewNvmeNamespaceDevicePlugin(devices[nvmeID], "example.org/nvme")
+
+		options, err := dpi.GetDevicePluginOptions(context.Background(), &pluginapi.Empty{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options.PreStartRequired).To(BeTrue())
+
+		_, err = dpi.PreStartContainer(context.Background(), &pluginapi.PreStartContainerRequest{DevicesIDs: []string{nvmeGroup}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile(filepath.Join(devicePath, "reset"))).To(Equal("1"))
+	})
+})
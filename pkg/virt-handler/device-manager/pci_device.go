@@ -68,6 +68,8 @@ type PCIDevicePlugin struct {
 	iommuToPCIMap map[string]string
 	initialized   bool
 	lock          *sync.Mutex
+	// resetDevices resets the devices before they are passed to a container
+	resetDevices bool
 }
 
 func NewPCIDevicePlugin(pciDevices []*PCIDevice, resourceName string) *PCIDevicePlugin {
@@ -364,13 +366,25 @@ func (dpi *PCIDevicePlugin) cleanup() error {
 
 func (dpi *PCIDevicePlugin) GetDevicePluginOptions(ctx context.Context, e *pluginapi.Empty) (*pluginapi.DevicePluginOptions, error) {
 	options := &pluginapi.DevicePluginOptions{
-		PreStartRequired: false,
+		PreStartRequired: dpi.resetDevices,
 	}
 	return options, nil
 }
 
 func (dpi *PCIDevicePlugin) PreStartContainer(ctx context.Context, in *pluginapi.PreStartContainerRequest) (*pluginapi.PreStartContainerResponse, error) {
 	res := &pluginapi.PreStartContainerResponse{}
+	if !dpi.resetDevices {
+		return res, nil
+	}
+	for _, devID := range in.DevicesIDs {
+		devPCIAddress, exist := dpi.iommuToPCIMap[devID]
+		if !exist {
+			continue
+		}
+		if err := nvmeHost.reset(devPCIAddress); err != nil {
+			return nil, fmt.Errorf("failed to reset the device %s: %v", devPCIAddress, err)
+		}
+	}
 	return res, nil
 }
 
@@ -19,6 +19,7 @@ import (
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	hotplugdisk "kubevirt.io/kubevirt/pkg/hotplug-disk"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/cgroup"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 
 	"github.com/opencontainers/runc/libcontainer/cgroups/devices"
@@ -41,7 +42,7 @@ var (
 	}
 
 	cgroupsBasePath = func() string {
-		return "/proc/1/root/sys/fs/cgroup/devices/"
+		return cgroup.ControllerPath("/proc/1/root", "devices")
 	}
 
 	statCommand = func(fileName string) ([]byte, error) {
@@ -445,6 +446,11 @@ func (m *volumeMounter) loadEmulator(path string) (*devices.Emulator, error) {
 }
 
 func (m *volumeMounter) updateDevicesList(path string, rule *configs.DeviceRule) error {
+	if cgroup.IsUnified(path) {
+		// The device controller of cgroup v2 is an eBPF program attached by the container runtime,
+		// there are no allow and deny files to update.
+		return fmt.Errorf("updating the device allow-list of %s is not supported on cgroup v2 hosts", path)
+	}
 	// Create the target emulator for comparison later.
 	target, err := m.loadEmulator(path)
 	if err != nil {
@@ -453,6 +453,14 @@ var _ = Describe("HotplugVolume block devices", func() {
 		Expect("b 34:53 rwm").To(Equal(string(content)))
 	})
 
+	It("Should error if the devices cgroup is part of the unified hierarchy", func() {
+		_, err := os.Create(filepath.Join(tempDir, "cgroup.controllers"))
+		Expect(err).ToNot(HaveOccurred())
+		err = m.allowBlockMajorMinor(34, 53, tempDir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not supported on cgroup v2 hosts"))
+	})
+
 	It("Should error if allow/deny cannot be found", func() {
 		allowFile := filepath.Join(tempDir, "devices.allow")
 		denyFile := filepath.Join(tempDir, "devices.deny")
@@ -30,9 +30,11 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -47,9 +49,16 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/cgroup"
+	"kubevirt.io/kubevirt/pkg/util/qos"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 )
 
+// Allow mocking for tests
+var blkioCgroupBasePath = func() string {
+	return cgroup.ControllerPath("/proc/1/root", "blkio")
+}
+
 // PodIsolationDetector helps detecting cgroups, namespaces and PIDs of Pods from outside of them.
 // Different strategies may be applied to do that.
 type PodIsolationDetector interface {
@@ -80,6 +89,11 @@ var mountInfoFunc = func(pid int) string {
 	return fmt.Sprintf("/proc/%d/mountinfo", pid)
 }
 
+// The unit test suite overwrites this function
+var cgroupFileFunc = func(pid int) string {
+	return fmt.Sprintf("/proc/%d/cgroup", pid)
+}
+
 type socketBasedIsolationDetector struct {
 	socketDir  string
 	controller []string
@@ -142,6 +156,10 @@ func prLimit(pid int, limit uintptr, rlimit *unix.Rlimit) error {
 }
 
 func (s *socketBasedIsolationDetector) AdjustResources(vm *v1.VirtualMachineInstance) error {
+	if err := s.adjustBlkioWeight(vm); err != nil {
+		return err
+	}
+
 	// only VFIO attached domains require MEMLOCK adjustment
 	if !util.IsVFIOVMI(vm) {
 		return nil
@@ -189,6 +207,34 @@ func (s *socketBasedIsolationDetector) AdjustResources(vm *v1.VirtualMachineInst
 	return nil
 }
 
+// adjustBlkioWeight applies the blkio weight of the VMI QoS class to the cgroup of the pod
+func (s *socketBasedIsolationDetector) adjustBlkioWeight(vm *v1.VirtualMachineInstance) error {
+	class, ok := qos.Lookup(vm.Spec.QoSClass)
+	if !ok {
+		return nil
+	}
+
+	res, err := s.Detect(vm)
+	if err != nil {
+		return err
+	}
+
+	return setBlkioWeight(filepath.Join(blkioCgroupBasePath(), res.Slice()), class.BlkioWeight)
+}
+
+func setBlkioWeight(cgroupPath string, weight uint16) error {
+	file, value := "blkio.weight", strconv.Itoa(int(weight))
+	if cgroup.IsUnified(cgroupPath) {
+		// the io controller of the unified hierarchy replaces blkio and uses a different range
+		file, value = "io.weight", strconv.FormatUint(cgroup.BlkioToIOWeight(weight), 10)
+	}
+	err := ioutil.WriteFile(filepath.Join(cgroupPath, file), []byte(value), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to set blkio weight of %s: %v", cgroupPath, err)
+	}
+	return nil
+}
+
 // consider reusing getMemoryOverhead()
 // This is not scientific, but neither what libvirtd does is. See details in:
 // https://www.redhat.com/archives/libvirt-users/2019-August/msg00051.html
@@ -452,11 +498,13 @@ func (s *socketBasedIsolationDetector) getPid(socket string) (int, error) {
 }
 
 func (s *socketBasedIsolationDetector) getSlice(pid int) (controller []string, slice string, err error) {
-	cgroups, err := os.Open(fmt.Sprintf("/proc/%d/cgroup", pid))
+	cgroups, err := os.Open(cgroupFileFunc(pid))
 	if err != nil {
 		return
 	}
 	defer util.CloseIOAndCheckErr(cgroups, nil)
+	var unifiedSlice string
+	var legacyHierarchies bool
 	scanner := bufio.NewScanner(cgroups)
 	for scanner.Scan() {
 		cgEntry := strings.SplitN(scanner.Text(), ":", 3)
@@ -465,6 +513,12 @@ func (s *socketBasedIsolationDetector) getSlice(pid int) (controller []string, s
 			err = fmt.Errorf("Could not extract slice from cgroup line: %s", scanner.Text())
 			return
 		}
+		// The unified hierarchy has the ID 0 and no controller list
+		if cgEntry[0] == "0" && cgEntry[1] == "" {
+			unifiedSlice = cgEntry[2]
+			continue
+		}
+		legacyHierarchies = true
 		// Skip not supported cgroup controller
 		if !sliceContains(s.controller, cgEntry[1]) {
 			continue
@@ -486,6 +540,13 @@ func (s *socketBasedIsolationDetector) getSlice(pid int) (controller []string, s
 		return
 	}
 
+	// On cgroup v2 hosts all controllers are part of the unified hierarchy. On
+	// hybrid hosts it only holds the systemd tree and has no controllers.
+	if !legacyHierarchies && unifiedSlice != "" {
+		slice = unifiedSlice
+		controller = s.controller
+	}
+
 	if slice == "" {
 		err = fmt.Errorf("Could not detect slice of whitelisted controller: %v", s.controller)
 		return
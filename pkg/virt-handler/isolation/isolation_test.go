@@ -8,11 +8,14 @@ import (
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/qos"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 )
 
@@ -115,6 +118,89 @@ var _ = Describe("Isolation", func() {
 			Expect(mountInfo.MountPoint).To(Equal("/"))
 		})
 
+		It("Should apply the blkio weight of the QoS class", func() {
+			defer func(f func() string) { blkioCgroupBasePath = f }(blkioCgroupBasePath)
+			cgroupDir, err := ioutil.TempDir("", "cgroup")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(cgroupDir)
+			blkioCgroupBasePath = func() string { return cgroupDir }
+
+			detector := NewSocketBasedIsolationDetector(tmpDir)
+			result, err := detector.Detect(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.MkdirAll(filepath.Join(cgroupDir, result.Slice()), os.ModePerm)).To(Succeed())
+
+			qos.SetClasses([]v1.QoSClassConfiguration{{
+				Name:        v1.QoSClassBronze,
+				BlkioWeight: 100,
+				NetworkRate: resource.MustParse("12500k"),
+				NetworkCeil: resource.MustParse("12500k"),
+			}})
+			defer qos.SetClasses(nil)
+			qosVMI := vm.DeepCopy()
+			qosVMI.Spec.QoSClass = v1.QoSClassBronze
+			Expect(detector.AdjustResources(qosVMI)).To(Succeed())
+
+			weight, err := ioutil.ReadFile(filepath.Join(cgroupDir, result.Slice(), "blkio.weight"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(weight)).To(Equal("100"))
+		})
+
+		It("Should apply the io weight of the QoS class on cgroup v2 hosts", func() {
+			defer func(f func() string) { blkioCgroupBasePath = f }(blkioCgroupBasePath)
+			cgroupDir, err := ioutil.TempDir("", "cgroup")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(cgroupDir)
+			blkioCgroupBasePath = func() string { return cgroupDir }
+
+			detector := NewSocketBasedIsolationDetector(tmpDir)
+			result, err := detector.Detect(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.MkdirAll(filepath.Join(cgroupDir, result.Slice()), os.ModePerm)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(cgroupDir, result.Slice(), "cgroup.controllers"), []byte("io\n"), 0644)).To(Succeed())
+
+			qos.SetClasses([]v1.QoSClassConfiguration{{
+				Name:        v1.QoSClassBronze,
+				BlkioWeight: 100,
+				NetworkRate: resource.MustParse("12500k"),
+				NetworkCeil: resource.MustParse("12500k"),
+			}})
+			defer qos.SetClasses(nil)
+			qosVMI := vm.DeepCopy()
+			qosVMI.Spec.QoSClass = v1.QoSClassBronze
+			Expect(detector.AdjustResources(qosVMI)).To(Succeed())
+
+			weight, err := ioutil.ReadFile(filepath.Join(cgroupDir, result.Slice(), "io.weight"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(weight)).To(Equal("910"))
+		})
+
+		table.DescribeTable("Should detect the slice of the whitelisted controllers", func(hierarchy string, controller []string) {
+			defer func(f func(int) string) { cgroupFileFunc = f }(cgroupFileFunc)
+			cgroupFileFunc = func(pid int) string {
+				return filepath.Join("testdata", "cgroup", hierarchy)
+			}
+
+			result, err := NewSocketBasedIsolationDetector(tmpDir).Whitelist([]string{"devices", "blkio"}).Detect(vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Slice()).To(Equal("/kubepods.slice/kubepods-burstable.slice/crio-4f1a.scope"))
+			Expect(result.(*realIsolationResult).controller).To(Equal(controller))
+		},
+			table.Entry("on cgroup v1 hosts", "legacy", []string{"devices", "blkio"}),
+			table.Entry("on hybrid hosts", "hybrid", []string{"devices", "blkio"}),
+			table.Entry("on cgroup v2 hosts", "unified", []string{"devices", "blkio"}),
+		)
+
+		It("Should not use the unified hierarchy for controllers of hybrid hosts", func() {
+			defer func(f func(int) string) { cgroupFileFunc = f }(cgroupFileFunc)
+			cgroupFileFunc = func(pid int) string {
+				return filepath.Join("testdata", "cgroup", "hybrid")
+			}
+
+			_, err := NewSocketBasedIsolationDetector(tmpDir).Whitelist([]string{"cpuset"}).Detect(vm)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Should detect the full path of the mount on a node", func() {
 			// Restore the overwritten function
 			defer func(f func(int) string) { mountInfoFunc = f }(mountInfoFunc)
@@ -20,13 +20,16 @@
 package rest
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"path"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	"k8s.io/apimachinery/pkg/types"
@@ -35,15 +38,19 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	hypervisorlog "kubevirt.io/kubevirt/pkg/hypervisor-log"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
 type ConsoleHandler struct {
 	podIsolationDetector isolation.PodIsolationDetector
 	serialStopChans      map[types.UID](chan struct{})
 	vncStopChans         map[types.UID](chan struct{})
+	channelStopChans     map[types.UID](chan struct{})
 	serialLock           *sync.Mutex
 	vncLock              *sync.Mutex
+	channelLock          *sync.Mutex
 	vmiInformer          cache.SharedIndexInformer
 }
 
@@ -52,8 +59,10 @@ func NewConsoleHandler(podIsolationDetector isolation.PodIsolationDetector, vmiI
 		podIsolationDetector: podIsolationDetector,
 		serialStopChans:      make(map[types.UID](chan struct{})),
 		vncStopChans:         make(map[types.UID](chan struct{})),
+		channelStopChans:     make(map[types.UID](chan struct{})),
 		serialLock:           &sync.Mutex{},
 		vncLock:              &sync.Mutex{},
+		channelLock:          &sync.Mutex{},
 		vmiInformer:          vmiInformer,
 	}
 }
@@ -100,6 +109,93 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 	t.stream(vmi, request, response, unixSocketPath, stopCh, cleanup)
 }
 
+func (t *ConsoleHandler) ChannelHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	channel := request.PathParameter("channel")
+	index := -1
+	for i, c := range vmi.Spec.Domain.Devices.Channels {
+		if c.Name == channel {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		err := fmt.Errorf("channel %s is not present", channel)
+		log.Log.Object(vmi).Reason(err).Error("Failed finding the channel")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	unixSocketPath, err := t.getUnixSocketPath(vmi, api.ChannelSocketName(index))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed finding unix socket for channel %s", channel)
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	// only one connection per channel, like for the consoles
+	key := types.UID(fmt.Sprintf("%s/%s", vmi.GetUID(), channel))
+	stopCh := newStopChan(key, t.channelLock, t.channelStopChans)
+	cleanup := func() {
+		deleteStopChan(key, stopCh, t.channelLock, t.channelStopChans)
+	}
+	t.stream(vmi, request, response, unixSocketPath, stopCh, cleanup)
+}
+
+// HypervisorLogHandler streams the qemu log and the libvirt lifecycle events
+// of the VMI as JSON lines, until the client disconnects
+func (t *ConsoleHandler) HypervisorLogHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	result, err := t.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the virt-launcher of the VMI")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	qemuLogPath := path.Join(result.MountRoot(), hypervisorlog.QemuLogPath(api.VMINamespaceKeyFunc(vmi)))
+	eventLogPath := path.Join(result.MountRoot(), hypervisorlog.EventLogPath(vmi.GetUID()))
+
+	upgrader := kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer clientSocket.Close()
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go func() {
+		writer.CloseWithError(hypervisorlog.Follow(writer, qemuLogPath, eventLogPath, time.Second, stopCh))
+	}()
+
+	errCh := make(chan error, 2)
+	go func() {
+		_, err := kubecli.CopyTo(clientSocket, reader)
+		errCh <- err
+	}()
+	go func() {
+		// the client only sends to close the connection
+		_, err := kubecli.CopyFrom(ioutil.Discard, clientSocket)
+		errCh <- err
+	}()
+
+	if err := <-errCh; err != nil && err != io.EOF {
+		log.Log.Object(vmi).Reason(err).V(3).Info("Hypervisor log connection closed")
+	}
+}
+
 func newStopChan(uid types.UID, lock *sync.Mutex, stopChans map[types.UID](chan struct{})) chan struct{} {
 	lock.Lock()
 	defer lock.Unlock()
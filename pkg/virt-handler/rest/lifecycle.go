@@ -19,14 +19,19 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/emicklei/go-restful"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 )
 
 type LifecycleHandler struct {
@@ -97,6 +102,68 @@ func (lh *LifecycleHandler) UnpauseHandler(request *restful.Request, response *r
 	response.WriteHeader(http.StatusAccepted)
 }
 
+func (lh *LifecycleHandler) FreezeHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	err = client.FreezeVirtualMachine(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to freeze VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) UnfreezeHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	err = client.UnfreezeVirtualMachine(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to unfreeze VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
 func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *restful.Response) {
 	log.Log.Info("Retreiving guestinfo")
 	vmi, code, err := getVMI(request, lh.vmiInformer)
@@ -188,3 +255,104 @@ func (lh *LifecycleHandler) GetFilesystems(request *restful.Request, response *r
 
 	response.WriteEntity(fsList)
 }
+
+func (lh *LifecycleHandler) QMPCommandHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	command := request.QueryParameter("command")
+	log.Log.Object(vmi).Infof("Executing QMP command %s on %s", command, vmi.Name)
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	result, err := client.ExecQMPCommand(vmi, command)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to execute QMP command %s", command)
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", restful.MIME_JSON)
+	response.WriteHeader(http.StatusOK)
+	response.Write([]byte(result))
+}
+
+func (lh *LifecycleHandler) GetStats(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	domainStats, exists, err := client.GetDomainStats()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get domain stats")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if !exists {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("domain of VMI %s does not exist", vmi.Name))
+		return
+	}
+
+	response.WriteEntity(toVMIStats(domainStats, time.Now()))
+}
+
+// toVMIStats sums up the counters of the domain stats. The memory working set
+// is the memory the guest doesn't report as unused, or the resident memory of
+// qemu if the guest doesn't report it.
+func toVMIStats(domainStats *stats.DomainStats, timestamp time.Time) *v1.VirtualMachineInstanceStats {
+	vmiStats := &v1.VirtualMachineInstanceStats{
+		Timestamp: metav1.NewMicroTime(timestamp),
+		VCPUs:     int64(len(domainStats.Vcpu)),
+	}
+	if domainStats.Cpu != nil && domainStats.Cpu.TimeSet {
+		vmiStats.CPUTimeNanoseconds = int64(domainStats.Cpu.Time)
+	}
+	// libvirt reports the memory in KiB
+	if memory := domainStats.Memory; memory != nil {
+		if memory.AvailableSet && memory.UnusedSet && memory.Available >= memory.Unused {
+			vmiStats.MemoryWorkingSetBytes = int64(memory.Available-memory.Unused) * 1024
+		} else if memory.RSSSet {
+			vmiStats.MemoryWorkingSetBytes = int64(memory.RSS) * 1024
+		}
+	}
+	for _, block := range domainStats.Block {
+		vmiStats.DiskReadBytes += int64(block.RdBytes)
+		vmiStats.DiskWriteBytes += int64(block.WrBytes)
+	}
+	for _, net := range domainStats.Net {
+		vmiStats.NetworkReceiveBytes += int64(net.RxBytes)
+		vmiStats.NetworkTransmitBytes += int64(net.TxBytes)
+	}
+	return vmiStats
+}
@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
+)
+
+type NetworkDryRunHandler struct {
+	clusterConfig *virtconfig.ClusterConfig
+}
+
+func NewNetworkDryRunHandler(clusterConfig *virtconfig.ClusterConfig) *NetworkDryRunHandler {
+	return &NetworkDryRunHandler{
+		clusterConfig: clusterConfig,
+	}
+}
+
+// PlugHandler checks the first phase of plugging the interfaces of the VMI
+// in the request body against the backends of the node, without plugging
+// them, and writes the result of every step
+func (h *NetworkDryRunHandler) PlugHandler(request *restful.Request, response *restful.Response) {
+	if !h.clusterConfig.NetworkDryRunEnabled() {
+		response.WriteError(http.StatusForbidden, fmt.Errorf("the %s feature gate is not enabled", virtconfig.NetworkDryRunGate))
+		return
+	}
+	vmi := &v1.VirtualMachineInstance{}
+	if err := request.ReadEntity(vmi); err != nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("failed to read the VMI: %v", err))
+		return
+	}
+
+	steps := network.DryRunPodNetworkPhase1(vmi)
+	failed := 0
+	for _, step := range steps {
+		if step.Error != "" {
+			failed++
+		}
+	}
+	log.Log.Object(vmi).Infof("Dry run of plugging the interfaces: %d of %d steps would fail", failed, len(steps))
+	response.WriteEntity(steps)
+}
@@ -0,0 +1,87 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/emicklei/go-restful"
+
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/profiler"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+type ProfileHandler struct {
+	clusterConfig *virtconfig.ClusterConfig
+	// vmiInformer holds the VMIs running on the node
+	vmiInformer cache.SharedIndexInformer
+}
+
+func NewProfileHandler(clusterConfig *virtconfig.ClusterConfig, vmiInformer cache.SharedIndexInformer) *ProfileHandler {
+	return &ProfileHandler{
+		clusterConfig: clusterConfig,
+		vmiInformer:   vmiInformer,
+	}
+}
+
+// BundleHandler writes a gzipped tar archive with the profiles of virt-handler
+// and of the virt-launchers of the VMIs running on the node
+func (h *ProfileHandler) BundleHandler(request *restful.Request, response *restful.Response) {
+	if !h.clusterConfig.ProfilingEnabled() {
+		response.WriteError(http.StatusForbidden, fmt.Errorf("the %s feature gate is not enabled", virtconfig.ProfilingGate))
+		return
+	}
+	duration, err := profiler.ParseDuration(request.QueryParameter("seconds"))
+	if err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	bundle := &profiler.Bundle{
+		Sources: []profiler.Source{profiler.LocalSource("virt-handler")},
+	}
+	for _, obj := range h.vmiInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if !vmi.IsRunning() {
+			continue
+		}
+		name := path.Join("virt-launcher", vmi.Namespace, vmi.Name)
+		socket, err := cmdclient.FindProfilerSocketOnHost(vmi)
+		if err != nil {
+			bundle.Errors = append(bundle.Errors, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		bundle.Sources = append(bundle.Sources, profiler.SocketSource(name, socket))
+	}
+
+	log.Log.Infof("Collecting the profiles of virt-handler and %d virt-launchers for %s", len(bundle.Sources)-1, duration)
+	response.AddHeader("Content-Type", "application/gzip")
+	response.WriteHeader(http.StatusOK)
+	if err := bundle.Write(response, duration); err != nil {
+		log.Log.Reason(err).Error("Failed to write the profile bundle")
+	}
+}
@@ -32,12 +32,14 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -55,12 +57,17 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	networkmetrics "kubevirt.io/kubevirt/pkg/monitoring/network/prometheus"
+	startupmetrics "kubevirt.io/kubevirt/pkg/monitoring/startup/prometheus"
 	virtutil "kubevirt.io/kubevirt/pkg/util"
 	clusterutils "kubevirt.io/kubevirt/pkg/util/cluster"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/util/qos"
 	pvcutils "kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	virtcache "kubevirt.io/kubevirt/pkg/virt-handler/cache"
@@ -68,6 +75,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	launchererrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/watchdog"
 )
@@ -80,6 +88,10 @@ type launcherClientInfo struct {
 	ready               bool
 }
 
+// migrationNetworkStateTimeout is how long a migration target waits for the
+// source to report the network state of the VMI
+const migrationNetworkStateTimeout = 30 * time.Second
+
 func NewController(
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
@@ -93,6 +105,7 @@ func NewController(
 	gracefulShutdownInformer cache.SharedIndexInformer,
 	watchdogTimeoutSeconds int,
 	maxDevices int,
+	maxParallelContainerDiskPreparations int,
 	clusterConfig *virtconfig.ClusterConfig,
 	serverTLSConfig *tls.Config,
 	clientTLSConfig *tls.Config,
@@ -113,10 +126,12 @@ func NewController(
 		domainInformer:           domainInformer,
 		gracefulShutdownInformer: gracefulShutdownInformer,
 		heartBeatInterval:        1 * time.Minute,
+		datapathVerifyInterval:   5 * time.Minute,
+		natRulesVerifyInterval:   30 * time.Second,
 		watchdogTimeoutSeconds:   watchdogTimeoutSeconds,
 		migrationProxy:           migrationproxy.NewMigrationProxyManager(serverTLSConfig, clientTLSConfig),
 		podIsolationDetector:     podIsolationDetector,
-		containerDiskMounter:     container_disk.NewMounter(podIsolationDetector, virtPrivateDir+"/container-disk-mount-state"),
+		containerDiskMounter:     container_disk.NewMounter(podIsolationDetector, virtPrivateDir+"/container-disk-mount-state", maxParallelContainerDiskPreparations),
 		hotplugVolumeMounter:     hotplug_volume.NewVolumeMounter(podIsolationDetector, virtPrivateDir+"/hotplug-volume-mount-state"),
 		clusterConfig:            clusterConfig,
 	}
@@ -148,6 +163,9 @@ func NewController(
 	c.launcherClients = make(map[types.UID]*launcherClientInfo)
 	c.phase1NetworkSetupCache = make(map[types.UID]int)
 	c.podInterfaceCache = make(map[string]*network.PodCacheInterface)
+	c.migrationAnnounceCache = make(map[types.UID]types.UID)
+	c.networkAnnounceCache = make(map[types.UID]int)
+	c.datapathLocks = make(map[types.UID]*sync.Mutex)
 
 	c.domainNotifyPipes = make(map[string]string)
 
@@ -171,6 +189,8 @@ type VirtualMachineController struct {
 	launcherClients          map[types.UID]*launcherClientInfo
 	launcherClientLock       sync.Mutex
 	heartBeatInterval        time.Duration
+	datapathVerifyInterval   time.Duration
+	natRulesVerifyInterval   time.Duration
 	watchdogTimeoutSeconds   int
 	deviceManagerController  *device_manager.DeviceController
 	migrationProxy           migrationproxy.ProxyManager
@@ -191,6 +211,34 @@ type VirtualMachineController struct {
 	podInterfaceCache     map[string]*network.PodCacheInterface
 	podInterfaceCacheLock sync.Mutex
 
+	// records the migration whose guest interfaces have been announced
+	// on the target node, keyed by the VMI UID
+	migrationAnnounceCache     map[types.UID]types.UID
+	migrationAnnounceCacheLock sync.Mutex
+
+	// records the pid of the virt-launcher whose interface addresses have
+	// been announced, keyed by the VMI UID
+	networkAnnounceCache     map[types.UID]int
+	networkAnnounceCacheLock sync.Mutex
+
+	// serializes the verifications of the network datapath of a VMI, the
+	// nat rules are restored by two loops which would otherwise append
+	// the same rules twice. Keyed by the VMI UID.
+	datapathLocks     map[types.UID]*sync.Mutex
+	datapathLocksLock sync.Mutex
+
+	// records the side-channel state of the host found by the last
+	// heartbeat, nil until the first heartbeat read it
+	sideChannelState     *sideChannelState
+	sideChannelStateLock sync.Mutex
+
+	// records the capabilities of the host found by the last heartbeat,
+	// passed to the virt-launchers along with every sync. nil until the
+	// first heartbeat probed them, the virt-launchers probe them themselves
+	// meanwhile.
+	hostCapabilities     *cmdv1.HostCapabilities
+	hostCapabilitiesLock sync.Mutex
+
 	domainNotifyPipes map[string]string
 }
 
@@ -206,6 +254,43 @@ type virtLauncherCriticalSecurebootError struct {
 
 func (e *virtLauncherCriticalSecurebootError) Error() string { return e.msg }
 
+type virtLauncherCriticalChecksumError struct {
+	msg string
+}
+
+func (e *virtLauncherCriticalChecksumError) Error() string { return e.msg }
+
+type virtLauncherHibernationError struct {
+	msg string
+}
+
+func (e *virtLauncherHibernationError) Error() string { return e.msg }
+
+type virtLauncherHibernationRestoreError struct {
+	msg string
+}
+
+func (e *virtLauncherHibernationRestoreError) Error() string { return e.msg }
+
+// virtLauncherNetworkSetupError means virt-launcher could not finish plugging the interfaces
+// of the VMI. The VMI keeps running and the sync is retried with backoff.
+type virtLauncherNetworkSetupError struct {
+	reason string
+	msg    string
+}
+
+func (e *virtLauncherNetworkSetupError) Error() string { return e.msg }
+
+// asLauncherNetworkSetupError returns a virtLauncherNetworkSetupError if the
+// command failed in virt-launcher with the reason of a network setup error
+func asLauncherNetworkSetupError(err error) (*virtLauncherNetworkSetupError, bool) {
+	var serverErr *cmdclient.ServerError
+	if goerror.As(err, &serverErr) && network.IsNetworkSetupReason(serverErr.Reason) {
+		return &virtLauncherNetworkSetupError{reason: serverErr.Reason, msg: err.Error()}, true
+	}
+	return nil, false
+}
+
 func handleDomainNotifyPipe(domainPipeStopChan chan struct{}, ln net.Listener, virtShareDir string, vmi *v1.VirtualMachineInstance) {
 
 	fdChan := make(chan net.Conn, 100)
@@ -396,6 +481,18 @@ func (d *VirtualMachineController) clearPodNetworkPhase1(uid types.UID) {
 	delete(d.phase1NetworkSetupCache, uid)
 	d.phase1NetworkSetupCacheLock.Unlock()
 
+	d.migrationAnnounceCacheLock.Lock()
+	delete(d.migrationAnnounceCache, uid)
+	d.migrationAnnounceCacheLock.Unlock()
+
+	d.networkAnnounceCacheLock.Lock()
+	delete(d.networkAnnounceCache, uid)
+	d.networkAnnounceCacheLock.Unlock()
+
+	d.datapathLocksLock.Lock()
+	delete(d.datapathLocks, uid)
+	d.datapathLocksLock.Unlock()
+
 	// Clean Pod interface cache from map and files
 	d.podInterfaceCacheLock.Lock()
 	for key, _ := range d.podInterfaceCache {
@@ -418,6 +515,10 @@ func (d *VirtualMachineController) clearPodNetworkPhase1(uid types.UID) {
 // already taken place or not for a VMI
 func (d *VirtualMachineController) setPodNetworkPhase1(vmi *v1.VirtualMachineInstance) (bool, error) {
 
+	// the QoS class is applied to the tap devices and, after the network
+	// setup, to the cgroup of the pod
+	qos.SetClasses(d.clusterConfig.GetQoSClasses())
+
 	// configure network
 	res, err := d.podIsolationDetector.Detect(vmi)
 	if err != nil {
@@ -436,7 +537,8 @@ func (d *VirtualMachineController) setPodNetworkPhase1(vmi *v1.VirtualMachineIns
 		return false, nil
 	}
 
-	err = res.DoNetNS(func() error { return network.SetupPodNetworkPhase1(vmi, pid) })
+	network.SetBridgeConfiguration(d.clusterConfig.GetBridgeConfiguration())
+	err = network.SetupPodNetworkPhase1(vmi, pid, res.DoNetNS)
 	if err != nil {
 		_, critical := err.(*network.CriticalNetworkError)
 		if critical {
@@ -455,6 +557,114 @@ func (d *VirtualMachineController) setPodNetworkPhase1(vmi *v1.VirtualMachineIns
 	return false, nil
 }
 
+// teardownPodNetworkPhase1 rolls back the changes phase1 made to the network
+// namespace of the pod, if the pod is still there. Once the pod is gone its
+// network namespace is gone with it.
+func (d *VirtualMachineController) teardownPodNetworkPhase1(vmi *v1.VirtualMachineInstance) {
+	if !network.HasPhase1Cache(vmi.UID) {
+		return
+	}
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).V(4).Infof("Not tearing down the pod network, the pod is gone: %v", err)
+		return
+	}
+	if err := network.TeardownPodNetworkPhase1(vmi, res.DoNetNS); err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("Failed to tear down the pod network")
+	}
+}
+
+// updatePortForwards applies changes of the forwarded ports of masquerade
+// interfaces to a running vmi. The network namespace of the pod is only
+// entered if the ports actually changed.
+func (d *VirtualMachineController) updatePortForwards(vmi *v1.VirtualMachineInstance) error {
+	if !network.PortForwardsChanged(vmi) {
+		return nil
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to detect isolation for launcher pod: %v", err)
+	}
+
+	if err := network.UpdatePortForwards(vmi, res.Pid(), res.DoNetNS); err != nil {
+		return fmt.Errorf("failed to update the forwarded ports: %v", err)
+	}
+	d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.PortForwardsUpdated.String(), "VirtualMachineInstance forwarded ports updated.")
+	return nil
+}
+
+// updateStartupTimestamps records the startup milestones observed by virt-handler.
+// Every milestone is recorded only once, so that restarts of the domain or of
+// virt-handler do not skew the startup latency of the vmi.
+func (d *VirtualMachineController) updateStartupTimestamps(vmi *v1.VirtualMachineInstance, oldPhase v1.VirtualMachineInstancePhase, domain *api.Domain, channelConnected bool) {
+	if vmi.ObjectMeta.DeletionTimestamp != nil || vmi.IsFinal() {
+		// A vmi which is going away won't reach any further milestone
+		return
+	}
+	if vmi.Status.StartupTimestamps == nil {
+		if oldPhase == v1.Running {
+			// The vmi was started before its startup milestones were tracked
+			return
+		}
+		vmi.Status.StartupTimestamps = &v1.VirtualMachineInstanceStartupTimestamps{}
+	}
+	timestamps := vmi.Status.StartupTimestamps
+	now := metav1.Now()
+
+	d.phase1NetworkSetupCacheLock.Lock()
+	_, networkConfigured := d.phase1NetworkSetupCache[vmi.UID]
+	d.phase1NetworkSetupCacheLock.Unlock()
+
+	if networkConfigured && timestamps.NetworkConfigured == nil {
+		timestamps.NetworkConfigured = &now
+	}
+	if domain != nil && timestamps.DomainDefined == nil {
+		timestamps.DomainDefined = &now
+	}
+	if domain != nil && domain.Status.Status == api.Running && timestamps.DomainRunning == nil {
+		timestamps.DomainRunning = &now
+	}
+	if channelConnected && timestamps.GuestAgentConnected == nil {
+		timestamps.GuestAgentConnected = &now
+	}
+
+	if *timestamps == (v1.VirtualMachineInstanceStartupTimestamps{}) {
+		vmi.Status.StartupTimestamps = nil
+	}
+}
+
+// updateUsage reports the network counters of a running domain, which virt-controller
+// accumulates into the usage of the owning VirtualMachine. Failures to read the counters
+// are only logged, the next update picks them up again.
+func (d *VirtualMachineController) updateUsage(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if !d.clusterConfig.UsageAccountingEnabled() || domain == nil || domain.Status.Status != api.Running {
+		return
+	}
+	defer d.Queue.AddAfter(controller.VirtualMachineKey(vmi), d.clusterConfig.GetUsageUpdateInterval())
+
+	client, err := d.getLauncherClient(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to get the launcher client to report the usage")
+		return
+	}
+	domainStats, exists, err := client.GetDomainStats()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to get the domain stats to report the usage")
+		return
+	}
+	if !exists {
+		return
+	}
+
+	usage := &v1.VirtualMachineInstanceUsage{}
+	for _, net := range domainStats.Net {
+		usage.NetworkReceiveBytes += int64(net.RxBytes)
+		usage.NetworkTransmitBytes += int64(net.TxBytes)
+	}
+	vmi.Status.Usage = usage
+}
+
 func domainMigrated(domain *api.Domain) bool {
 	if domain != nil && domain.Status.Status == api.Shutoff && domain.Status.Reason == api.ReasonMigrated {
 		return true
@@ -480,11 +690,15 @@ func (d *VirtualMachineController) getPodInterfacefromFileCache(uid types.UID, i
 		log.Log.Reason(err).Errorf("failed to read from cache file: %s", err.Error())
 		return nil, err
 	}
-	err = json.Unmarshal(content, &result)
+	err = network.UnmarshalCacheFile(content, &result)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to unmarshal interface content: %s", err.Error())
 		return nil, err
 	}
+	// the cache is read once per handler lifetime, which is when the primary family is re-evaluated
+	if err := network.RefreshPodInterfaceCache(result, string(uid), ifaceName); err != nil {
+		log.Log.Reason(err).Warningf("failed to re-evaluate the primary IP family of interface %s", ifaceName)
+	}
 	d.podInterfaceCacheLock.Lock()
 	d.podInterfaceCache[ifacepath] = result
 	d.podInterfaceCacheLock.Unlock()
@@ -525,6 +739,11 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 			vmi.Status.GuestOSInfo.KernelVersion = domain.Status.OSInfo.KernelVersion
 			vmi.Status.GuestOSInfo.ID = domain.Status.OSInfo.Id
 		}
+		if domain.Status.FSFreezeStatus.Status == api.FSFrozen {
+			vmi.Status.FSFreezeStatus = v1.FSFrozen
+		} else {
+			vmi.Status.FSFreezeStatus = ""
+		}
 		// This is needed to be backwards compatible with vmi's which have status interfaces
 		// with the name not being set
 		if len(domain.Spec.Devices.Interfaces) == 0 && len(vmi.Status.Interfaces) == 1 && vmi.Status.Interfaces[0].Name == "" {
@@ -688,6 +907,18 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 					}
 					delete(domainInterfaceStatusByMac, interfaceMAC)
 				}
+				if ifaceSpec, exists := existingInterfacesSpecByName[domainInterface.Alias.Name]; exists {
+					setInterfaceDatapath(&newInterface, vmi, &ifaceSpec, &domainInterface)
+					if newInterface.BridgeDevice == "" {
+						newInterface.BridgeTXChecksumOffload = nil
+					} else if newInterface.BridgeTXChecksumOffload == nil {
+						// the decision is recorded by phase1 and doesn't change, so the cache is only read until
+						// it is reported. The status is left unset if it can't be read.
+						if podIface, err := d.getPodInterfacefromFileCache(vmi.UID, domainInterface.Alias.Name); err == nil {
+							newInterface.BridgeTXChecksumOffload = podIface.BridgeTXChecksumOffload
+						}
+					}
+				}
 				newInterfaces = append(newInterfaces, newInterface)
 			}
 
@@ -703,6 +934,9 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 					IPs:           domainInterfaceStatus.IPs,
 					InterfaceName: domainInterfaceStatus.InterfaceName,
 				}
+				if ifaceSpec, exists := existingInterfacesSpecByName[domainInterfaceStatus.Name]; exists {
+					newInterface.Binding = network.BindingName(&ifaceSpec)
+				}
 				newInterfaces = append(newInterfaces, newInterface)
 			}
 			vmi.Status.Interfaces = newInterfaces
@@ -733,6 +967,10 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		}
 	}
 
+	// Hand the network state over to the migration target before it prepares the pod network
+	d.setMigrationSourceNetworkState(vmi)
+	clearMigrationNetworkState(vmi)
+
 	// Update AccessCredential conditions
 	if domain != nil && domain.Spec.Metadata.KubeVirt.AccessCredential != nil {
 
@@ -767,6 +1005,22 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		}
 	}
 
+	// Report the last guest time sync
+	if domain != nil && domain.Spec.Metadata.KubeVirt.TimeSync != nil {
+		timeSync := domain.Spec.Metadata.KubeVirt.TimeSync
+		oldTimeSync := vmi.Status.GuestTimeSync
+		reported := oldTimeSync != nil && oldTimeSync.LastSyncTime.Equal(timeSync.Timestamp)
+		if !reported && !timeSync.Succeeded {
+			d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.GuestTimeSyncFailed.String(), timeSync.Message)
+		}
+		vmi.Status.GuestTimeSync = &v1.VirtualMachineInstanceGuestTimeSync{
+			Synchronized:      timeSync.Succeeded,
+			LastSyncTime:      timeSync.Timestamp,
+			DriftMilliseconds: timeSync.DriftMilliseconds,
+			Message:           timeSync.Message,
+		}
+	}
+
 	// handle migrations differently than normal status updates.
 	//
 	// When a successful migration is detected, we must transfer ownership of the VMI
@@ -823,6 +1077,7 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 			vmi.Status.MigrationState.Completed = true
 			d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Migrated.String(), fmt.Sprintf("The VirtualMachineInstance migrated to node %s.", migrationHost))
 		}
+		clearMigrationNetworkState(vmi)
 
 		if !reflect.DeepEqual(oldStatus, vmi.Status) {
 			_, err = d.clientset.VirtualMachineInstance(vmi.ObjectMeta.Namespace).Update(vmi)
@@ -915,6 +1170,9 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 
 	}
 
+	d.updateStartupTimestamps(vmi, oldStatus.Phase, domain, channelConnected)
+	d.updateUsage(vmi, domain)
+
 	// Update paused condition in case VMI was paused / unpaused
 	if domain != nil && domain.Status.Status == api.Paused && domain.Status.Reason == api.ReasonPausedUser {
 		if !condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused) {
@@ -942,6 +1200,25 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		log.Log.Errorf("virt-launcher does not support the Secure Boot setting. Updating VMI %s status to Failed", vmi.Name)
 		vmi.Status.Phase = v1.Failed
 	}
+	if _, ok := syncError.(*virtLauncherCriticalChecksumError); ok {
+		log.Log.Errorf("virt-handler found a containerDisk not matching its checksum. Updating VMI %s status to Failed", vmi.Name)
+		vmi.Status.Phase = v1.Failed
+	}
+	updateImagesVerifiedCondition(vmi, domain, syncError)
+	if statuses := d.containerDiskMounter.ContainerDiskStatuses(vmi); statuses != nil {
+		vmi.Status.ContainerDiskStatuses = statuses
+	}
+	updateHibernatedCondition(vmi, domain, syncError)
+	if updateEmulatedCondition(vmi, domain) {
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.VirtualMachineInstanceReasonSoftwareEmulation, "The VirtualMachineInstance runs with software emulation, since /dev/kvm is not available.")
+	}
+	if state := d.getSideChannelState(); updateSideChannelIsolatedCondition(vmi, state) {
+		d.recorder.Eventf(vmi, k8sv1.EventTypeWarning, v1.VirtualMachineInstanceReasonSideChannelExposed, "The node does not isolate the VirtualMachineInstance from side-channel attacks: %s", state.exposure)
+	}
+	updateNetworkSetupFailure(vmi, syncError)
+	if updateHibernationRestoreFailure(vmi, syncError) {
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.VirtualMachineInstanceReasonHibernationRestoreFailed, syncError.Error())
+	}
 	condManager.CheckFailure(vmi, syncError, "Synchronizing with the Domain failed.")
 
 	if !reflect.DeepEqual(oldStatus, vmi.Status) {
@@ -949,6 +1226,7 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		if err != nil {
 			return err
 		}
+		startupmetrics.ObserveStartupTimestamps(vmi, oldStatus.StartupTimestamps)
 	}
 
 	if oldStatus.Phase != vmi.Status.Phase {
@@ -965,6 +1243,160 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 	return nil
 }
 
+// updateNetworkSetupFailure reports a failed network setup in the Synchronized condition. Unlike
+// other sync failures the message is kept up to date, since the reason can change between retries.
+func updateNetworkSetupFailure(vmi *v1.VirtualMachineInstance, syncError error) {
+	setupError, ok := syncError.(*virtLauncherNetworkSetupError)
+	if !ok {
+		return
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	message := fmt.Sprintf("%s: %s", setupError.reason, setupError.msg)
+	if existing := condManager.GetCondition(vmi, v1.VirtualMachineInstanceSynchronized); existing != nil &&
+		existing.Reason == v1.VirtualMachineInstanceReasonNetworkSetupFailed && existing.Message == message {
+		return
+	}
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceSynchronized)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceSynchronized,
+		Status:             k8sv1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             v1.VirtualMachineInstanceReasonNetworkSetupFailed,
+		Message:            message,
+	})
+}
+
+// updateHibernationRestoreFailure reports in the Synchronized condition that the saved memory
+// of a hibernated VMI could not be restored. It returns true if the condition was added.
+func updateHibernationRestoreFailure(vmi *v1.VirtualMachineInstance, syncError error) bool {
+	if _, ok := syncError.(*virtLauncherHibernationRestoreError); !ok {
+		return false
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if existing := condManager.GetCondition(vmi, v1.VirtualMachineInstanceSynchronized); existing != nil &&
+		existing.Reason == v1.VirtualMachineInstanceReasonHibernationRestoreFailed {
+		return false
+	}
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceSynchronized)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceSynchronized,
+		Status:             k8sv1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             v1.VirtualMachineInstanceReasonHibernationRestoreFailed,
+		Message:            syncError.Error(),
+	})
+	return true
+}
+
+// updateHibernatedCondition reports whether the memory of a VMI asked to hibernate was saved.
+// virt-controller stops the VMI in both cases.
+func updateHibernatedCondition(vmi *v1.VirtualMachineInstance, domain *api.Domain, syncError error) {
+	if _, requested := vmi.Annotations[v1.HibernationRequestedAnnotation]; !requested {
+		return
+	}
+
+	var condition v1.VirtualMachineInstanceCondition
+	if _, ok := syncError.(*virtLauncherHibernationError); ok {
+		condition = v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstanceHibernated,
+			Status:  k8sv1.ConditionFalse,
+			Reason:  v1.VirtualMachineInstanceReasonHibernationFailed,
+			Message: syncError.Error(),
+		}
+	} else if domain != nil && domain.Status.Status == api.Shutoff && domain.Status.Reason == api.ReasonSaved {
+		condition = v1.VirtualMachineInstanceCondition{
+			Type:   v1.VirtualMachineInstanceHibernated,
+			Status: k8sv1.ConditionTrue,
+		}
+	} else {
+		return
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if existing := condManager.GetCondition(vmi, v1.VirtualMachineInstanceHibernated); existing != nil && existing.Status == condition.Status {
+		return
+	}
+	now := metav1.Now()
+	condition.LastProbeTime = now
+	condition.LastTransitionTime = now
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceHibernated)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, condition)
+}
+
+// updateEmulatedCondition reports whether the domain runs with software emulation
+// instead of KVM. It returns true if the condition was added.
+func updateEmulatedCondition(vmi *v1.VirtualMachineInstance, domain *api.Domain) bool {
+	if domain == nil {
+		return false
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if domain.Spec.Type != "qemu" {
+		// the VMI may have been migrated to a node with KVM
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceEmulated)
+		return false
+	}
+	if condManager.HasCondition(vmi, v1.VirtualMachineInstanceEmulated) {
+		return false
+	}
+	now := metav1.Now()
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceEmulated,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             v1.VirtualMachineInstanceReasonSoftwareEmulation,
+		Message:            "/dev/kvm is not available, the VMI runs with software emulation",
+	})
+	return true
+}
+
+// updateImagesVerifiedCondition reports whether the disks of the VMI match their checksums.
+// virt-handler verifies the containerDisks when it mounts them and virt-controller verifies the DataVolumes
+// before it creates the pod, both before the domain is defined, so an existing domain implies they match.
+func updateImagesVerifiedCondition(vmi *v1.VirtualMachineInstance, domain *api.Domain, syncError error) {
+	hasChecksums := false
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk != nil && volume.ContainerDisk.Checksum != "" ||
+			volume.DataVolume != nil && volume.DataVolume.Checksum != "" {
+			hasChecksums = true
+			break
+		}
+	}
+	if !hasChecksums {
+		return
+	}
+
+	var condition v1.VirtualMachineInstanceCondition
+	if _, ok := syncError.(*virtLauncherCriticalChecksumError); ok {
+		condition = v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstanceImagesVerified,
+			Status:  k8sv1.ConditionFalse,
+			Reason:  v1.VirtualMachineInstanceReasonChecksumMismatch,
+			Message: syncError.Error(),
+		}
+	} else if domain != nil {
+		condition = v1.VirtualMachineInstanceCondition{
+			Type:   v1.VirtualMachineInstanceImagesVerified,
+			Status: k8sv1.ConditionTrue,
+		}
+	} else {
+		return
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if existing := condManager.GetCondition(vmi, v1.VirtualMachineInstanceImagesVerified); existing != nil && existing.Status == condition.Status {
+		return
+	}
+	now := metav1.Now()
+	condition.LastProbeTime = now
+	condition.LastTransitionTime = now
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceImagesVerified)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, condition)
+}
+
 func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.VirtualMachineInstance, hasHotplug bool) (*v1.VirtualMachineInstanceCondition, bool) {
 	liveMigrationCondition := v1.VirtualMachineInstanceCondition{
 		Type:   v1.VirtualMachineInstanceIsMigratable,
@@ -1028,6 +1460,14 @@ func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 
 	go c.heartBeat(c.heartBeatInterval, stopCh)
 
+	if c.clusterConfig.NetworkCacheVersioningEnabled() {
+		network.EnableVersionedCacheFiles()
+		c.migrateNetworkCacheFiles()
+	}
+
+	go wait.Until(c.verifyNetworkDatapaths, c.datapathVerifyInterval, stopCh)
+	go wait.Until(c.restoreNatRules, c.natRulesVerifyInterval, stopCh)
+
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
@@ -1037,6 +1477,197 @@ func (c *VirtualMachineController) Run(threadiness int, stopCh chan struct{}) {
 	log.Log.Info("Stopping virt-handler controller.")
 }
 
+// migrateNetworkCacheFiles converts network cache files of VMIs which were
+// started by a previous KubeVirt version to the versioned format.
+func (c *VirtualMachineController) migrateNetworkCacheFiles() {
+	for _, obj := range c.vmiSourceInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if !vmi.IsRunning() {
+			continue
+		}
+		res, err := c.podIsolationDetector.Detect(vmi)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to detect isolation for launcher pod, skipping network cache migration")
+			continue
+		}
+		if err := network.MigrateCacheFiles(vmi, res.Pid()); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to migrate network cache files")
+		}
+	}
+}
+
+// verifyNetworkDatapaths compares the taps, bridges and nat rules of the
+// running VMIs with the state they were plugged with, repairs what can be
+// repaired in place and reports the drifts. Datapaths are silently broken
+// when something else in the pod or on the node flushes them, e.g. firewalld
+// reloading its rules.
+func (c *VirtualMachineController) verifyNetworkDatapaths() {
+	networkmetrics.ResetDatapathDrifts()
+	c.verifyPluggedNetworks(network.VerifyDatapath, true)
+	c.verifyLauncherNetworks()
+}
+
+// verifyLauncherNetworks asks virt-launcher for the state of the interfaces it
+// plugged into the domain and plugs them again if an attempt failed, e.g. when
+// the DHCP server of an interface did not start.
+func (c *VirtualMachineController) verifyLauncherNetworks() {
+	for _, obj := range c.vmiSourceInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if !vmi.IsRunning() || c.isMigrationSource(vmi) {
+			continue
+		}
+
+		client, err := c.getVerifiedLauncherClient(vmi)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).V(4).Info("failed to connect to virt-launcher, skipping network plug verification")
+			continue
+		}
+
+		drifts, err := replugLauncherNetworks(vmi, client)
+		if setupErr, ok := err.(*virtLauncherNetworkSetupError); ok {
+			log.Log.Object(vmi).Reason(err).Errorf("virt-launcher refused to plug the network interfaces again: %s", setupErr.reason)
+			c.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.NetworkDatapathDrifted.String(), fmt.Sprintf("%s: %s", setupErr.reason, setupErr.msg))
+		} else if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("failed to verify the network interfaces plugged by virt-launcher")
+			c.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.NetworkDatapathDrifted.String(), err.Error())
+		}
+		c.reportDatapathDrifts(vmi, drifts, true)
+	}
+}
+
+// replugLauncherNetworks returns the interfaces which virt-launcher failed to plug and asks it to plug them again
+func replugLauncherNetworks(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) ([]network.DatapathDrift, error) {
+	states, err := client.GetNetworkStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []network.DatapathDrift
+	for _, state := range states {
+		if state.Plugged {
+			continue
+		}
+		drifts = append(drifts, network.DatapathDrift{
+			Interface: state.Name,
+			Component: network.DatapathPlug,
+			Message:   fmt.Sprintf("the interface is not plugged: %s", state.Error),
+		})
+	}
+	if len(drifts) == 0 {
+		return nil, nil
+	}
+
+	err = client.PlugNetworkInterfaces(vmi)
+	for i := range drifts {
+		drifts[i].Repaired = err == nil
+	}
+	if err != nil {
+		if setupErr, ok := asLauncherNetworkSetupError(err); ok {
+			return drifts, setupErr
+		}
+	}
+	return drifts, err
+}
+
+// restoreNatRules re-programs flushed nat rules of masquerade interfaces. It
+// runs more often than verifyNetworkDatapaths, to limit how long VMIs are
+// unreachable after a firewalld reload. It doesn't report unrepaired drifts
+// to the drifts gauge, which is only reset by verifyNetworkDatapaths.
+func (c *VirtualMachineController) restoreNatRules() {
+	c.verifyPluggedNetworks(network.VerifyNatRules, false)
+}
+
+// datapathVerifier verifies the network datapath of a VMI within the network
+// namespace of its virt-launcher, like network.VerifyDatapath
+type datapathVerifier func(vmi *v1.VirtualMachineInstance, pid int, doNetNS func(func() error) error, repair bool) ([]network.DatapathDrift, error)
+
+// datapathLock returns the lock serializing the datapath verifications of a VMI
+func (c *VirtualMachineController) datapathLock(uid types.UID) *sync.Mutex {
+	c.datapathLocksLock.Lock()
+	defer c.datapathLocksLock.Unlock()
+	lock, exists := c.datapathLocks[uid]
+	if !exists {
+		lock = &sync.Mutex{}
+		c.datapathLocks[uid] = lock
+	}
+	return lock
+}
+
+func (c *VirtualMachineController) verifyPluggedNetworks(verify datapathVerifier, reportDrifts bool) {
+	for _, obj := range c.vmiSourceInformer.GetStore().List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if !vmi.IsRunning() || c.isMigrationSource(vmi) {
+			continue
+		}
+		c.verifyPluggedNetwork(vmi, verify, reportDrifts)
+	}
+}
+
+func (c *VirtualMachineController) verifyPluggedNetwork(vmi *v1.VirtualMachineInstance, verify datapathVerifier, reportDrifts bool) {
+	lock := c.datapathLock(vmi.UID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	c.phase1NetworkSetupCacheLock.Lock()
+	cachedPid, configured := c.phase1NetworkSetupCache[vmi.UID]
+	c.phase1NetworkSetupCacheLock.Unlock()
+	if !configured {
+		return
+	}
+
+	res, err := c.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to detect isolation for launcher pod, skipping network datapath verification")
+		return
+	}
+	if res.Pid() != cachedPid {
+		// the network of a new launcher process is not plugged yet
+		return
+	}
+
+	drifts, err := verify(vmi, cachedPid, res.DoNetNS, true)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to verify the network datapath")
+		c.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.NetworkDatapathDrifted.String(), err.Error())
+		return
+	}
+	c.reportDatapathDrifts(vmi, drifts, reportDrifts)
+}
+
+// reportDatapathDrifts records an event per drift, except for the nat rules.
+// A flush removes all rules of an interface at once, their recovery is
+// recorded as a single event per interface. Repairs are always counted,
+// unrepaired drifts only reported to the gauge if reportDrifts is set.
+func (c *VirtualMachineController) reportDatapathDrifts(vmi *v1.VirtualMachineInstance, drifts []network.DatapathDrift, reportDrifts bool) {
+	restoredNatRules := map[string]int{}
+	var interfaces []string
+	for _, drift := range drifts {
+		if drift.Repaired || reportDrifts {
+			networkmetrics.ObserveDatapathDrift(vmi, drift.Interface, string(drift.Component), drift.Repaired)
+		}
+		switch {
+		case drift.Repaired && drift.Component == network.DatapathNat:
+			log.Log.Object(vmi).V(4).Infof("restored the nat rules of interface %s: %s", drift.Interface, drift.Message)
+			if _, exists := restoredNatRules[drift.Interface]; !exists {
+				interfaces = append(interfaces, drift.Interface)
+			}
+			restoredNatRules[drift.Interface]++
+		case drift.Repaired:
+			log.Log.Object(vmi).Infof("repaired the network datapath of interface %s: %s", drift.Interface, drift.Message)
+			c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, v1.NetworkDatapathRepaired.String(), "Repaired interface %s: %s", drift.Interface, drift.Message)
+		default:
+			log.Log.Object(vmi).Warningf("the network datapath of interface %s drifted: %s", drift.Interface, drift.Message)
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, v1.NetworkDatapathDrifted.String(), "Interface %s drifted: %s", drift.Interface, drift.Message)
+		}
+	}
+
+	for _, iface := range interfaces {
+		log.Log.Object(vmi).Infof("restored %d flushed nat chains and rules of interface %s", restoredNatRules[iface], iface)
+		c.recorder.Eventf(vmi, k8sv1.EventTypeNormal, v1.NatRulesRestored.String(),
+			"Restored %d flushed nat chains and rules of interface %s", restoredNatRules[iface], iface)
+	}
+}
+
 func (c *VirtualMachineController) runWorker() {
 	for c.Execute() {
 	}
@@ -1206,6 +1837,10 @@ func (d *VirtualMachineController) migrationTargetExecute(key string,
 			vmiCopy.Status.MigrationState.TargetNodeDomainDetected = true
 			d.setVMIGuestTime(vmi)
 		}
+		if domainExists && vmi.Status.MigrationState != nil && vmi.Status.MigrationState.Completed && !vmi.Status.MigrationState.Failed {
+			d.announceMigratedNetworkInterfaces(vmi)
+			d.announceNetworkInterfaces(vmi)
+		}
 		if !migrations.IsMigrating(vmi) {
 
 			destSrcPortsMap := d.migrationProxy.GetTargetListenerPorts(string(vmi.UID))
@@ -1228,6 +1863,11 @@ func (d *VirtualMachineController) migrationTargetExecute(key string,
 					portsList = append(portsList, k)
 				}
 				portsStrList := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(portsList)), ","), "[]")
+
+				// the source needs the devices of the target before it starts migrating
+				if err := d.setMigrationTargetNetworkState(vmiCopy); err != nil {
+					return err
+				}
 				d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.PreparingTarget.String(), fmt.Sprintf("Migration Target is listening at %s, on ports: %s", d.ipAddress, portsStrList))
 				vmiCopy.Status.MigrationState.TargetNodeAddress = d.ipAddress
 				vmiCopy.Status.MigrationState.TargetDirectMigrationNodePorts = destSrcPortsMap
@@ -1580,8 +2220,14 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 		return err
 	}
 
+	d.teardownPodNetworkPhase1(vmi)
 	d.clearPodNetworkPhase1(vmi.UID)
 
+	// reset the passed through NVMe controllers, before they are assigned to the next VMI
+	if obj, exists, _ := d.domainInformer.GetStore().GetByKey(controller.VirtualMachineKey(vmi)); exists {
+		device_manager.ResetNvmeControllers(hostDevicePCIAddresses(obj.(*api.Domain)))
+	}
+
 	// Watch dog file and command client must be the last things removed here
 	err = d.closeLauncherClient(vmi)
 	if err != nil {
@@ -1597,6 +2243,23 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 	return d.domainInformer.GetStore().Delete(domain)
 }
 
+// hostDevicePCIAddresses returns the addresses of the PCI functions passed through to a domain
+func hostDevicePCIAddresses(domain *api.Domain) []string {
+	addresses := []string{}
+	for _, hostDev := range domain.Spec.Devices.HostDevices {
+		address := hostDev.Source.Address
+		if hostDev.Type != "pci" || address == nil {
+			continue
+		}
+		addresses = append(addresses, fmt.Sprintf("%s:%s:%s.%s",
+			strings.TrimPrefix(address.Domain, "0x"),
+			strings.TrimPrefix(address.Bus, "0x"),
+			strings.TrimPrefix(address.Slot, "0x"),
+			strings.TrimPrefix(address.Function, "0x")))
+	}
+	return addresses
+}
+
 func (d *VirtualMachineController) closeLauncherClient(vmi *v1.VirtualMachineInstance) error {
 
 	// UID is required in order to close socket
@@ -1922,6 +2585,10 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 	// are shared and the VMI has no local disks
 	// Some combinations of disks makes the VMI no suitable for live migration.
 	// A relevant error will be returned in this case.
+	// The hibernation claim is not a VMI volume, but a ReadWriteOnce claim mounted to the pod.
+	if _, hasClaim := vmi.Annotations[v1.HibernationClaimAnnotation]; hasClaim {
+		return true, fmt.Errorf("cannot migrate VMI with a hibernation claim")
+	}
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
 		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil {
@@ -1945,6 +2612,9 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 			if !shared {
 				return true, fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
 			}
+		} else if volSrc.VhostUserBlk != nil {
+			// the storage backend serves the socket to the qemu of a single node
+			return true, fmt.Errorf("cannot migrate VMI with vhost-user-blk disks")
 		} else {
 			blockMigrate = true
 		}
@@ -1965,6 +2635,142 @@ func (d *VirtualMachineController) isMigrationSource(vmi *v1.VirtualMachineInsta
 
 }
 
+// setMigrationSourceNetworkState reports the network state of the bindings
+// on the source node, so that the migration target can take it over.
+func (d *VirtualMachineController) setMigrationSourceNetworkState(vmi *v1.VirtualMachineInstance) {
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil ||
+		migrationState.SourceNode != d.host ||
+		migrationState.Completed ||
+		migrationState.SourceNetworkState != nil ||
+		!network.NeedsMigrationNetworkState(vmi) {
+		return
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to detect isolation for reading the migration network state")
+		return
+	}
+	state, err := network.ReadMigrationNetworkState(vmi, res.Pid())
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to read the migration network state")
+		return
+	}
+	migrationState.SourceNetworkState = state
+}
+
+// clearMigrationNetworkState drops the network state the nodes exchanged for a
+// migration once it completed, it only applies to that migration.
+func clearMigrationNetworkState(vmi *v1.VirtualMachineInstance) {
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil || !migrationState.Completed {
+		return
+	}
+	migrationState.SourceNetworkState = nil
+	migrationState.TargetNetworkState = nil
+}
+
+// setMigrationTargetNetworkState reports the devices of the bindings on the
+// target node, so that the migration source can point the domain at them.
+func (d *VirtualMachineController) setMigrationTargetNetworkState(vmi *v1.VirtualMachineInstance) error {
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil ||
+		migrationState.TargetNetworkState != nil ||
+		!network.NeedsMigrationTargetNetworkState(vmi) {
+		return nil
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to detect isolation for reading the migration network state: %v", err)
+	}
+	state, err := network.ReadMigrationNetworkState(vmi, res.Pid())
+	if err != nil {
+		return fmt.Errorf("failed to read the migration network state: %v", err)
+	}
+	migrationState.TargetNetworkState = state
+	return nil
+}
+
+// announceMigratedNetworkInterfaces announces the guest interfaces once per
+// migration from the target node, since the network behind a macvtap device
+// does not learn the new location of the guest from the pod network.
+func (d *VirtualMachineController) announceMigratedNetworkInterfaces(vmi *v1.VirtualMachineInstance) {
+	if !network.NeedsMigrationTargetNetworkState(vmi) {
+		return
+	}
+
+	migrationUID := vmi.Status.MigrationState.MigrationUID
+	d.migrationAnnounceCacheLock.Lock()
+	announcedUID, announced := d.migrationAnnounceCache[vmi.UID]
+	d.migrationAnnounceCacheLock.Unlock()
+	if announced && announcedUID == migrationUID {
+		return
+	}
+
+	client, err := d.getVerifiedLauncherClient(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to announce the guest interfaces after the migration")
+		return
+	}
+	if err := client.AnnounceNetworkInterfaces(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to announce the guest interfaces after the migration")
+		return
+	}
+
+	d.migrationAnnounceCacheLock.Lock()
+	d.migrationAnnounceCache[vmi.UID] = migrationUID
+	d.migrationAnnounceCacheLock.Unlock()
+}
+
+// announceNetworkInterfaces announces the addresses of the bridge and
+// masquerade interfaces once per virt-launcher, so that the network learns
+// the new location of the VMI after it started, restarted or migrated.
+// Failures are only logged, the network converges on its own eventually.
+func (d *VirtualMachineController) announceNetworkInterfaces(vmi *v1.VirtualMachineInstance) {
+	if !network.NeedsNetworkAnnouncement(vmi) {
+		return
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to detect isolation for launcher pod to announce the interfaces")
+		return
+	}
+	pid := res.Pid()
+
+	d.networkAnnounceCacheLock.Lock()
+	announcedPid, announced := d.networkAnnounceCache[vmi.UID]
+	d.networkAnnounceCacheLock.Unlock()
+	if announced && announcedPid == pid {
+		return
+	}
+
+	if err := network.AnnounceNetworkInterfaces(vmi, pid, res.DoNetNS); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to announce the interfaces")
+		return
+	}
+
+	d.networkAnnounceCacheLock.Lock()
+	d.networkAnnounceCache[vmi.UID] = pid
+	d.networkAnnounceCacheLock.Unlock()
+}
+
+// isMigrationNetworkStateReady tells if the migration target can prepare the
+// pod network. It waits for the state of the source node for a limited time,
+// since a source running an older version never reports it.
+func isMigrationNetworkStateReady(vmi *v1.VirtualMachineInstance, notInitializedSince time.Time) bool {
+	if !network.NeedsMigrationNetworkState(vmi) || vmi.Status.MigrationState.SourceNetworkState != nil {
+		return true
+	}
+	if notInitializedSince.Before(time.Now().Add(-migrationNetworkStateTimeout)) {
+		log.Log.Object(vmi).Warning("migration source did not report its network state, preparing the target without it")
+		return true
+	}
+	return false
+}
+
 func (d *VirtualMachineController) handlePostSyncMigrationProxy(vmi *v1.VirtualMachineInstance) error {
 	// handle starting/stopping target migration proxy
 	migrationTargetSockets := []string{}
@@ -2078,8 +2884,17 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 			}
 
 			// Mount container disks
-			if err := d.containerDiskMounter.Mount(vmi, false); err != nil {
+			if ready, err := d.containerDiskMounter.Mount(vmi, false); err != nil {
 				return err
+			} else if !ready {
+				d.Queue.AddAfter(controller.VirtualMachineKey(vmi), time.Second*1)
+				return nil
+			}
+
+			// wait for the source to report the network state the guest is using
+			if !isMigrationNetworkStateReady(vmi, info.notInitializedSince) {
+				d.Queue.AddAfter(controller.VirtualMachineKey(vmi), time.Second*1)
+				return nil
 			}
 
 			// configure network inside virt-launcher compute container
@@ -2144,8 +2959,14 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 				return nil
 			}
 
-			if err := d.containerDiskMounter.Mount(vmi, true); err != nil {
+			if ready, err := d.containerDiskMounter.Mount(vmi, true); err != nil {
+				if strings.Contains(err.Error(), containerdisk.ChecksumMismatch) {
+					return &virtLauncherCriticalChecksumError{err.Error()}
+				}
 				return err
+			} else if !ready {
+				d.Queue.AddAfter(controller.VirtualMachineKey(vmi), time.Second*1)
+				return nil
 			}
 
 			criticalNetworkError, err := d.setPodNetworkPhase1(vmi)
@@ -2164,9 +2985,16 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 				return fmt.Errorf("failed to adjust resources: %v", err)
 			}
 		} else if vmi.IsRunning() {
+			if shouldHibernate(vmi) {
+				return d.hibernateVMI(vmi, client)
+			}
 			if err := d.hotplugVolumeMounter.Mount(vmi); err != nil {
 				return err
 			}
+			if err := d.updatePortForwards(vmi); err != nil {
+				return err
+			}
+			d.announceNetworkInterfaces(vmi)
 		}
 
 		smbios := d.clusterConfig.GetSMBIOS()
@@ -2181,6 +3009,7 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 				Version:      smbios.Version,
 			},
 			MemBalloonStatsPeriod: period,
+			HostCapabilities:      d.getHostCapabilities(),
 		}
 
 		err = client.SyncVirtualMachine(vmi, options)
@@ -2189,6 +3018,13 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 			if isSecbootError {
 				return &virtLauncherCriticalSecurebootError{fmt.Sprintf("mismatch of Secure Boot setting and bootloaders: %v", err)}
 			}
+			if setupErr, ok := asLauncherNetworkSetupError(err); ok {
+				return setupErr
+			}
+			var serverErr *cmdclient.ServerError
+			if goerror.As(err, &serverErr) && serverErr.Reason == launchererrors.HibernationRestoreFailedReason {
+				return &virtLauncherHibernationRestoreError{msg: err.Error()}
+			}
 			return err
 		}
 		d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Created.String(), "VirtualMachineInstance defined.")
@@ -2203,6 +3039,24 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 	return err
 }
 
+// shouldHibernate returns true if virt-controller asked to hibernate the VMI and it was not tried yet
+func shouldHibernate(vmi *v1.VirtualMachineInstance) bool {
+	if _, requested := vmi.Annotations[v1.HibernationRequestedAnnotation]; !requested {
+		return false
+	}
+	return !controller.NewVirtualMachineInstanceConditionManager().HasCondition(vmi, v1.VirtualMachineInstanceHibernated)
+}
+
+// hibernateVMI saves the memory of the VMI to its hibernation claim and shuts off the domain.
+// The domain is not synced anymore, virt-controller stops the VMI once it reached a final phase.
+func (d *VirtualMachineController) hibernateVMI(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
+	if err := client.HibernateVirtualMachine(vmi); err != nil {
+		return &virtLauncherHibernationError{fmt.Sprintf("failed to hibernate the vmi: %v", err)}
+	}
+	d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Stopped.String(), "The memory of the VirtualMachineInstance was saved.")
+	return nil
+}
+
 func (d *VirtualMachineController) setVmPhaseForStatusReason(domain *api.Domain, vmi *v1.VirtualMachineInstance) error {
 	phase, err := d.calculateVmPhaseForStatusReason(domain, vmi)
 	if err != nil {
@@ -2365,6 +3219,14 @@ func (d *VirtualMachineController) heartBeat(interval time.Duration, stopCh chan
 			if d.clusterConfig.CPUManagerEnabled() {
 				d.updateNodeCpuManagerLabel(cpuManagerPath)
 			}
+			d.updateNodeVhostNetZeroCopyTXLabel(virtutil.VhostNetZeroCopyTXPath)
+			d.updateNodeNestedVirtualizationLabel(virtutil.KVMNestedPaths)
+			d.updateNodeSideChannelLabels(virtutil.CPUVulnerabilitiesDir, virtutil.SMTActivePath, virtutil.L1DFlushPath)
+			if d.clusterConfig.NUMAEnabled() {
+				d.updateNodeNUMAHugepages(hardware.NUMANodesPath)
+			}
+			d.updateHostCapabilities(hardware.NUMANodesPath)
+			d.updateNodeDensityCapacity()
 		}, interval, 1.2, true, stopCh)
 	}
 }
@@ -2399,6 +3261,414 @@ func (d *VirtualMachineController) updateNodeCpuManagerLabel(cpuManagerPath stri
 
 }
 
+// updateNodeVhostNetZeroCopyTXLabel labels the node with whether vhost-net
+// transmits without copying, to schedule the VMIs requesting it
+func (d *VirtualMachineController) updateNodeVhostNetZeroCopyTXLabel(zeroCopyTXPath string) {
+	isEnabled, err := isVhostNetZeroCopyTXEnabled(zeroCopyTXPath)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set a vhost-net zero copy label on host %s", d.host)
+		return
+	}
+
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t"}}}`, v1.VhostNetZeroCopyTX, isEnabled))
+	_, err = d.clientset.CoreV1().Nodes().Patch(d.host, types.StrategicMergePatchType, data)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set a vhost-net zero copy label on host %s", d.host)
+		return
+	}
+	log.DefaultLogger().V(4).Infof("Node has vhost-net zero copy transmission enabled: %t", isEnabled)
+}
+
+// updateNodeNestedVirtualizationLabel labels the node with whether KVM allows
+// nested virtualization, to schedule the VMIs requesting it
+func (d *VirtualMachineController) updateNodeNestedVirtualizationLabel(nestedPaths []string) {
+	isEnabled, err := isNestedVirtualizationEnabled(nestedPaths)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set a nested virtualization label on host %s", d.host)
+		return
+	}
+
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%t"}}}`, v1.NestedVirtualization, isEnabled))
+	_, err = d.clientset.CoreV1().Nodes().Patch(d.host, types.StrategicMergePatchType, data)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set a nested virtualization label on host %s", d.host)
+		return
+	}
+	log.DefaultLogger().V(4).Infof("Node has nested virtualization enabled: %t", isEnabled)
+}
+
+// updateNodeSideChannelLabels labels the node with the mitigation status of
+// the CPU vulnerabilities, SMT and the L1D flushing of KVM, and with whether
+// it isolates the VMIs requesting side-channel isolation
+func (d *VirtualMachineController) updateNodeSideChannelLabels(vulnerabilitiesDir string, smtActivePath string, l1dFlushPath string) {
+	state, err := readSideChannelState(vulnerabilitiesDir, smtActivePath, l1dFlushPath)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the side-channel labels on host %s", d.host)
+		return
+	}
+	d.sideChannelStateLock.Lock()
+	d.sideChannelState = state
+	d.sideChannelStateLock.Unlock()
+
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": state.labels(),
+		},
+	})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the side-channel labels on host %s", d.host)
+		return
+	}
+	_, err = d.clientset.CoreV1().Nodes().Patch(d.host, types.StrategicMergePatchType, data)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to set the side-channel labels on host %s", d.host)
+		return
+	}
+	log.DefaultLogger().V(4).Infof("Node isolates side-channels: %t", state.exposure == "")
+}
+
+func (d *VirtualMachineController) getSideChannelState() *sideChannelState {
+	d.sideChannelStateLock.Lock()
+	defer d.sideChannelStateLock.Unlock()
+	return d.sideChannelState
+}
+
+// updateHostCapabilities probes the capabilities of the host, which are the
+// same for every virt-launcher on it, so that they are handed to the
+// virt-launchers instead of being probed again by each of them
+func (d *VirtualMachineController) updateHostCapabilities(numaNodesPath string) {
+	capabilities, err := readHostCapabilities(numaNodesPath)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to probe the capabilities of host %s", d.host)
+		return
+	}
+	d.hostCapabilitiesLock.Lock()
+	d.hostCapabilities = capabilities
+	d.hostCapabilitiesLock.Unlock()
+}
+
+func (d *VirtualMachineController) getHostCapabilities() *cmdv1.HostCapabilities {
+	d.hostCapabilitiesLock.Lock()
+	defer d.hostCapabilitiesLock.Unlock()
+	return d.hostCapabilities
+}
+
+// updateNodeNUMAHugepages advertises the hugepage pools of the host NUMA
+// nodes as extended resources of the node, for the scheduler to account
+// the hugepages of VMIs mapping their guest NUMA topology to the host
+func (d *VirtualMachineController) updateNodeNUMAHugepages(numaNodesPath string) {
+	capacity, err := numaHugepagesCapacity(numaNodesPath)
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to advertise the NUMA hugepages of host %s", d.host)
+		return
+	}
+	if len(capacity) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"capacity": capacity,
+		},
+	})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to advertise the NUMA hugepages of host %s", d.host)
+		return
+	}
+	_, err = d.clientset.CoreV1().Nodes().Patch(d.host, types.StrategicMergePatchType, data, "status")
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to advertise the NUMA hugepages of host %s", d.host)
+		return
+	}
+	log.DefaultLogger().V(4).Infof("Node has NUMA hugepages %v", capacity)
+}
+
+// updateNodeDensityCapacity advertises the number of VMIs and the overcommitted
+// memory the node can run as extended resources, for the scheduler to enforce
+// the node density configuration
+func (d *VirtualMachineController) updateNodeDensityCapacity() {
+	node, err := d.clientset.CoreV1().Nodes().Get(d.host, metav1.GetOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to advertise the VMI density of host %s", d.host)
+		return
+	}
+
+	capacity := nodeDensityCapacity(node.Annotations, d.clusterConfig.GetMaxVMIsPerNode(), d.clusterConfig.GetMemoryOvercommitBudget())
+	patch := map[k8sv1.ResourceName]interface{}{}
+	for _, name := range []k8sv1.ResourceName{v1.VMIResource, v1.MemoryOvercommitResource} {
+		desired, isDesired := capacity[name]
+		current, isCurrent := node.Status.Capacity[name]
+		if isDesired && (!isCurrent || desired.Cmp(current) != 0) {
+			patch[name] = desired
+		} else if !isDesired && isCurrent {
+			// a null value removes the resource from the node
+			patch[name] = nil
+		}
+	}
+	if len(patch) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"capacity": patch,
+		},
+	})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to advertise the VMI density of host %s", d.host)
+		return
+	}
+	_, err = d.clientset.CoreV1().Nodes().Patch(d.host, types.StrategicMergePatchType, data, "status")
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("failed to advertise the VMI density of host %s", d.host)
+		return
+	}
+	log.DefaultLogger().V(4).Infof("Node has VMI density %v", capacity)
+}
+
+// nodeDensityCapacity returns the extended resources which limit the VMIs of a
+// node. The annotations of the node override the limits of the cluster, invalid
+// annotations are ignored.
+func nodeDensityCapacity(annotations map[string]string, maxVMIs int64, overcommitBudget *resource.Quantity) k8sv1.ResourceList {
+	if value, exists := annotations[v1.MaxVMIsPerNodeAnnotation]; exists {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed >= 0 {
+			maxVMIs = parsed
+		} else {
+			log.DefaultLogger().Errorf("ignoring the invalid %s annotation %q", v1.MaxVMIsPerNodeAnnotation, value)
+		}
+	}
+	if value, exists := annotations[v1.MemoryOvercommitBudgetAnnotation]; exists {
+		if parsed, err := resource.ParseQuantity(value); err == nil && parsed.Sign() >= 0 {
+			overcommitBudget = &parsed
+		} else {
+			log.DefaultLogger().Errorf("ignoring the invalid %s annotation %q", v1.MemoryOvercommitBudgetAnnotation, value)
+		}
+	}
+
+	capacity := k8sv1.ResourceList{}
+	if maxVMIs > 0 {
+		capacity[v1.VMIResource] = *resource.NewQuantity(maxVMIs, resource.DecimalSI)
+	}
+	if overcommitBudget != nil {
+		capacity[v1.MemoryOvercommitResource] = *resource.NewQuantity(overcommitBudget.Value(), resource.BinarySI)
+	}
+	return capacity
+}
+
+// numaHugepagesCapacity returns the size of the hugepage pools of the host
+// NUMA nodes, by their extended resource
+func numaHugepagesCapacity(numaNodesPath string) (k8sv1.ResourceList, error) {
+	nodes, err := hardware.GetNUMANodes(numaNodesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := k8sv1.ResourceList{}
+	for _, node := range nodes {
+		for _, pool := range node.Hugepages {
+			capacity[hardware.NUMAHugepagesResourceName(node.ID, pool.PageSize)] = *resource.NewQuantity(pool.Total*pool.PageSize, resource.BinarySI)
+		}
+	}
+	return capacity, nil
+}
+
+// readHostCapabilities reads the NUMA topology of the host. The free
+// hugepages of the NUMA nodes change all the time and are left out.
+func readHostCapabilities(numaNodesPath string) (*cmdv1.HostCapabilities, error) {
+	nodes, err := hardware.GetNUMANodes(numaNodesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &cmdv1.HostCapabilities{}
+	for _, node := range nodes {
+		numaNode := &cmdv1.NUMANode{Id: uint32(node.ID)}
+		for _, cpu := range node.CPUs {
+			numaNode.Cpus = append(numaNode.Cpus, uint32(cpu))
+		}
+		capabilities.NumaNodes = append(capabilities.NumaNodes, numaNode)
+	}
+	return capabilities, nil
+}
+
+// isVhostNetZeroCopyTXEnabled reads the experimental_zcopytx parameter of the
+// vhost_net kernel module, which is not exposed if the module is not loaded.
+func isVhostNetZeroCopyTXEnabled(zeroCopyTXPath string) (bool, error) {
+	// #nosec No risk for path injection. zeroCopyTXPath is a static value from pkg/util
+	content, err := ioutil.ReadFile(zeroCopyTXPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(content)) == "1", nil
+}
+
+// isNestedVirtualizationEnabled reads the nested parameter of the KVM modules.
+// Depending on the kernel version it is either "Y" or "1" when enabled.
+func isNestedVirtualizationEnabled(nestedPaths []string) (bool, error) {
+	for _, nestedPath := range nestedPaths {
+		// #nosec No risk for path injection. nestedPath is a static value from pkg/util
+		content, err := ioutil.ReadFile(nestedPath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		value := strings.TrimSpace(string(content))
+		if value == "Y" || value == "1" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+const (
+	vulnerabilityNotAffected = "not-affected"
+	vulnerabilityMitigated   = "mitigated"
+	vulnerabilityVulnerable  = "vulnerable"
+	vulnerabilityUnknown     = "unknown"
+)
+
+// sideChannelState is the host state relevant for the mitigation of CPU
+// side-channel attacks
+type sideChannelState struct {
+	// vulnerabilities maps the CPU vulnerabilities known to the kernel to
+	// their description, like "Mitigation: PTE Inversion"
+	vulnerabilities map[string]string
+	smtActive       bool
+	// l1dFlush is the vmentry_l1d_flush parameter of kvm_intel, empty if the
+	// module is not loaded
+	l1dFlush string
+	// exposure tells why the host does not isolate VMIs from side-channel
+	// attacks, empty if it does
+	exposure string
+}
+
+// readSideChannelState reads the CPU vulnerabilities reported by the kernel,
+// whether SMT is active and when KVM flushes the L1 data cache.
+func readSideChannelState(vulnerabilitiesDir string, smtActivePath string, l1dFlushPath string) (*sideChannelState, error) {
+	state := &sideChannelState{vulnerabilities: map[string]string{}}
+
+	entries, err := ioutil.ReadDir(vulnerabilitiesDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		// #nosec No risk for path injection. vulnerabilitiesDir is a static value from pkg/util
+		content, err := ioutil.ReadFile(filepath.Join(vulnerabilitiesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		state.vulnerabilities[entry.Name()] = strings.TrimSpace(string(content))
+	}
+
+	// #nosec No risk for path injection. smtActivePath is a static value from pkg/util
+	content, err := ioutil.ReadFile(smtActivePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	state.smtActive = strings.TrimSpace(string(content)) == "1"
+
+	// #nosec No risk for path injection. l1dFlushPath is a static value from pkg/util
+	content, err = ioutil.ReadFile(l1dFlushPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	state.l1dFlush = strings.TrimSpace(string(content))
+
+	state.exposure = state.findExposure()
+	return state, nil
+}
+
+// vulnerabilityStatus maps the description of a CPU vulnerability by the
+// kernel to a label value.
+func vulnerabilityStatus(description string) string {
+	switch {
+	case description == "Not affected":
+		return vulnerabilityNotAffected
+	case strings.HasPrefix(description, "Mitigation"):
+		return vulnerabilityMitigated
+	case strings.HasPrefix(description, "Vulnerable"):
+		return vulnerabilityVulnerable
+	default:
+		return vulnerabilityUnknown
+	}
+}
+
+// findExposure checks the vulnerabilities in a stable order, so that the
+// reported exposure only changes with the host state.
+func (s *sideChannelState) findExposure() string {
+	names := make([]string, 0, len(s.vulnerabilities))
+	for name := range s.vulnerabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		description := s.vulnerabilities[name]
+		switch vulnerabilityStatus(description) {
+		case vulnerabilityVulnerable:
+			return fmt.Sprintf("the CPU is vulnerable to %s", name)
+		case vulnerabilityUnknown:
+			return fmt.Sprintf("the mitigation status of %s is unknown: %s", name, description)
+		}
+		// the kernel flags mitigations which are incomplete while SMT is active
+		if s.smtActive && strings.Contains(description, "SMT vulnerable") {
+			return fmt.Sprintf("SMT is active and the mitigation of %s requires it to be disabled", name)
+		}
+	}
+	if vulnerabilityStatus(s.vulnerabilities["l1tf"]) == vulnerabilityMitigated && s.l1dFlush != "" && s.l1dFlush != "always" && s.l1dFlush != "not required" {
+		return fmt.Sprintf("KVM does not flush the L1 data cache on every VM entry: vmentry_l1d_flush is %s", s.l1dFlush)
+	}
+	return ""
+}
+
+func (s *sideChannelState) labels() map[string]string {
+	labels := map[string]string{
+		v1.SMTActive:            strconv.FormatBool(s.smtActive),
+		v1.SideChannelIsolation: strconv.FormatBool(s.exposure == ""),
+	}
+	for name, description := range s.vulnerabilities {
+		labels[v1.CPUVulnerabilityLabel+name] = vulnerabilityStatus(description)
+	}
+	if s.l1dFlush != "" {
+		labels[v1.L1DFlush] = strings.Replace(s.l1dFlush, " ", "-", -1)
+	}
+	return labels
+}
+
+// updateSideChannelIsolatedCondition reports whether the node of a VMI
+// requesting side-channel isolation still isolates it, the host state can
+// change after the VMI was scheduled. It returns true if the VMI is exposed
+// for a new reason.
+func updateSideChannelIsolatedCondition(vmi *v1.VirtualMachineInstance, state *sideChannelState) bool {
+	if state == nil || vmi.Spec.Domain.CPU == nil || !vmi.Spec.Domain.CPU.SideChannelIsolation {
+		return false
+	}
+
+	condition := v1.VirtualMachineInstanceCondition{
+		Type:   v1.VirtualMachineInstanceSideChannelIsolated,
+		Status: k8sv1.ConditionTrue,
+	}
+	if state.exposure != "" {
+		condition.Status = k8sv1.ConditionFalse
+		condition.Reason = v1.VirtualMachineInstanceReasonSideChannelExposed
+		condition.Message = state.exposure
+	}
+
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if existing := condManager.GetCondition(vmi, v1.VirtualMachineInstanceSideChannelIsolated); existing != nil && existing.Status == condition.Status && existing.Message == condition.Message {
+		return false
+	}
+	now := metav1.Now()
+	condition.LastProbeTime = now
+	condition.LastTransitionTime = now
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceSideChannelIsolated)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, condition)
+	return condition.Status == k8sv1.ConditionFalse
+}
+
 func (d *VirtualMachineController) setVMIGuestTime(vmi *v1.VirtualMachineInstance) error {
 	// update the vmi guest with the current time
 	client, err := d.getVerifiedLauncherClient(vmi)
@@ -2421,6 +3691,21 @@ func isACPIEnabled(vmi *v1.VirtualMachineInstance, domain *api.Domain) bool {
 		domain.Spec.Features.ACPI != nil
 }
 
+// setInterfaceDatapath reports the binding and the devices backing the
+// interface in the virt-launcher pod, to let tooling verify the datapath.
+func setInterfaceDatapath(ifaceStatus *v1.VirtualMachineInstanceNetworkInterface, vmi *v1.VirtualMachineInstance, ifaceSpec *v1.Interface, domainIface *api.Interface) {
+	ifaceStatus.Binding = network.BindingName(ifaceSpec)
+	ifaceStatus.BridgeDevice = network.BridgeDeviceName(vmi, ifaceSpec)
+	ifaceStatus.TapDevice = ""
+	if domainIface.Target != nil {
+		ifaceStatus.TapDevice = domainIface.Target.Device
+	}
+	ifaceStatus.QueueCount = 0
+	if domainIface.Driver != nil && domainIface.Driver.Queues != nil {
+		ifaceStatus.QueueCount = int32(*domainIface.Driver.Queues)
+	}
+}
+
 func setMissingSRIOVInterfacesNames(interfacesSpecByName map[string]v1.Interface, interfacesStatusByMac map[string]api.InterfaceStatus) {
 	for name, ifaceSpec := range interfacesSpecByName {
 		if ifaceSpec.SRIOV == nil || ifaceSpec.MacAddress == "" {
@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"kubevirt.io/kubevirt/pkg/util"
@@ -39,6 +40,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,11 +63,13 @@ import (
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	networkmetrics "kubevirt.io/kubevirt/pkg/monitoring/network/prometheus"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	virtcache "kubevirt.io/kubevirt/pkg/virt-handler/cache"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	launchererrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/watchdog"
 )
@@ -103,6 +107,9 @@ var _ = Describe("VirtualMachineInstance", func() {
 	var podsDir string
 	var sockFile string
 	var ghostCacheDir string
+	var origVMIInterfaceDir string
+	var origVMIInterfacepath string
+	var origVMISysctlsPath string
 	var vmiTestUUID types.UID
 	var podTestUUID types.UID
 	var stop chan struct{}
@@ -132,6 +139,13 @@ var _ = Describe("VirtualMachineInstance", func() {
 		ghostCacheDir, err = ioutil.TempDir("", "")
 		Expect(err).ToNot(HaveOccurred())
 
+		// keep the network cache of the specs apart from each other and from the host
+		networkInfoDir := filepath.Join(privateDir, "network-info-cache")
+		origVMIInterfaceDir, origVMIInterfacepath, origVMISysctlsPath = util.VMIInterfaceDir, util.VMIInterfacepath, util.VMISysctlsPath
+		util.VMIInterfaceDir = networkInfoDir + "/%s"
+		util.VMIInterfacepath = networkInfoDir + "/%s/%s"
+		util.VMISysctlsPath = networkInfoDir + "/%s/sysctls.json"
+
 		err = virtcache.InitializeGhostRecordCache(ghostCacheDir)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -192,6 +206,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			gracefulShutdownInformer,
 			1,
 			10,
+			4,
 			config,
 			tlsConfig,
 			tlsConfig,
@@ -247,6 +262,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 		os.RemoveAll(podsDir)
 		os.RemoveAll(certDir)
 		os.RemoveAll(ghostCacheDir)
+		util.VMIInterfaceDir, util.VMIInterfacepath, util.VMISysctlsPath = origVMIInterfaceDir, origVMIInterfacepath, origVMISysctlsPath
 	})
 
 	expectEvent := func(substring string, shouldExist bool) {
@@ -623,12 +639,16 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			mockWatchdog.CreateFile(vmi)
 			vmiFeeder.Add(vmi)
-			mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(nil).Times(1)
 			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) {
 				Expect(options.VirtualMachineSMBios.Family).To(Equal(virtconfig.SmbiosConfigDefaultFamily))
 				Expect(options.VirtualMachineSMBios.Product).To(Equal(virtconfig.SmbiosConfigDefaultProduct))
 				Expect(options.VirtualMachineSMBios.Manufacturer).To(Equal(virtconfig.SmbiosConfigDefaultManufacturer))
 			})
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.StartupTimestamps).ToNot(BeNil())
+				Expect(vmi.Status.StartupTimestamps.NetworkConfigured).ToNot(BeNil())
+				Expect(vmi.Status.StartupTimestamps.DomainDefined).To(BeNil())
+			})
 			controller.Execute()
 			Expect(len(controller.phase1NetworkSetupCache)).To(Equal(1))
 		})
@@ -656,7 +676,13 @@ var _ = Describe("VirtualMachineInstance", func() {
 			vmiFeeder.Add(vmi)
 			domainFeeder.Add(domain)
 
-			vmiInterface.EXPECT().Update(updatedVMI)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.StartupTimestamps).ToNot(BeNil())
+				Expect(vmi.Status.StartupTimestamps.DomainDefined).ToNot(BeNil())
+				Expect(vmi.Status.StartupTimestamps.DomainRunning).ToNot(BeNil())
+				updatedVMI.Status.StartupTimestamps = vmi.Status.StartupTimestamps
+				Expect(vmi).To(Equal(updatedVMI))
+			})
 
 			node := &k8sv1.Node{
 				Status: k8sv1.NodeStatus{
@@ -1080,15 +1106,98 @@ var _ = Describe("VirtualMachineInstance", func() {
 			vmi.ObjectMeta.ResourceVersion = "1"
 			vmi.Status.Phase = v1.Scheduled
 			vmi.Status.ActivePods = map[types.UID]string{podTestUUID: ""}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			mockWatchdog.CreateFile(vmi)
+			vmiFeeder.Add(vmi)
+			mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(&network.CriticalNetworkError{Msg: "Critical SetupPodNetworkPhase1 error"}).Times(1)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Phase).To(Equal(v1.Failed))
+			})
+			controller.Execute()
+		})
+
+		It("should move VirtualMachineInstance to Failed if configuring one of several networks fails with critical error", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi.Status.ActivePods = map[types.UID]string{podTestUUID: ""}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+			vmi.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red"}}},
+			}
 
 			mockWatchdog.CreateFile(vmi)
 			vmiFeeder.Add(vmi)
+			// the interfaces are plugged concurrently, so it is up to the
+			// order of the calls which of them fails critically
 			mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(&network.CriticalNetworkError{Msg: "Critical SetupPodNetworkPhase1 error"}).Times(1)
+			mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(fmt.Errorf("SetupPodNetworkPhase1 error")).Times(1)
 
 			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
 				Expect(vmi.Status.Phase).To(Equal(v1.Failed))
 			})
 			controller.Execute()
+			Expect(controller.phase1NetworkSetupCache).To(BeEmpty())
+		})
+
+		It("should keep the VirtualMachineInstance running and retry if the network setup on the virt-launcher fails", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+			vmiFeeder.Add(vmi)
+			setupErr := &cmdclient.ServerError{Reason: network.NetworkSetupReasonDHCP, Msg: "failed to start DHCP server for interface eth0"}
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any()).Return(setupErr)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Phase).To(Equal(v1.Scheduled))
+				cond := findCondition(vmi, v1.VirtualMachineInstanceSynchronized)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+				Expect(cond.Reason).To(Equal(v1.VirtualMachineInstanceReasonNetworkSetupFailed))
+				Expect(cond.Message).To(HavePrefix(network.NetworkSetupReasonDHCP))
+			})
+			controller.Execute()
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+		})
+
+		It("should keep the VirtualMachineInstance from starting if its hibernated memory can't be restored", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+			vmiFeeder.Add(vmi)
+			restoreErr := &cmdclient.ServerError{
+				Reason: launchererrors.HibernationRestoreFailedReason,
+				Msg:    "failed to restore the hibernated memory: unsupported configuration: target device changed",
+			}
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any()).Return(restoreErr)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Phase).To(Equal(v1.Scheduled))
+				cond := findCondition(vmi, v1.VirtualMachineInstanceSynchronized)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+				Expect(cond.Reason).To(Equal(v1.VirtualMachineInstanceReasonHibernationRestoreFailed))
+				Expect(cond.Message).To(Equal(restoreErr.Msg))
+			})
+			controller.Execute()
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+			expectEvent(v1.VirtualMachineInstanceReasonHibernationRestoreFailed, true)
 		})
 
 		It("should remove an error condition if a synchronization run succeeds", func() {
@@ -1115,21 +1224,30 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			mockWatchdog.CreateFile(vmi)
 			vmiFeeder.Add(vmi)
-			mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(nil).Times(1)
 
 			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) {
 				Expect(options.VirtualMachineSMBios.Family).To(Equal(virtconfig.SmbiosConfigDefaultFamily))
 				Expect(options.VirtualMachineSMBios.Product).To(Equal(virtconfig.SmbiosConfigDefaultProduct))
 				Expect(options.VirtualMachineSMBios.Manufacturer).To(Equal(virtconfig.SmbiosConfigDefaultManufacturer))
 			})
-			vmiInterface.EXPECT().Update(updatedVMI)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.StartupTimestamps).ToNot(BeNil())
+				Expect(vmi.Status.StartupTimestamps.NetworkConfigured).ToNot(BeNil())
+				updatedVMI.Status.StartupTimestamps = vmi.Status.StartupTimestamps
+				Expect(vmi).To(Equal(updatedVMI))
+			})
 
 			controller.Execute()
 		})
 
 		Context("reacting to a VMI with a containerDisk", func() {
+			var containerDiskStatuses []v1.ContainerDiskStatus
+
 			BeforeEach(func() {
 				controller.containerDiskMounter = mockContainerDiskMounter
+				mockContainerDiskMounter.EXPECT().ContainerDiskStatuses(gomock.Any()).DoAndReturn(func(vmi *v1.VirtualMachineInstance) []v1.ContainerDiskStatus {
+					return containerDiskStatuses
+				}).AnyTimes()
 			})
 			It("should retry silently if a containerDisk is not yet ready", func() {
 				vmi := NewScheduledVMIWithContainerDisk(vmiTestUUID, podTestUUID, host)
@@ -1171,7 +1289,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 					Expect(notReadySince.Before(time.Now())).To(BeTrue())
 					return true, nil
 				})
-				mockContainerDiskMounter.EXPECT().Mount(gomock.Any(), gomock.Any()).Return(fmt.Errorf("aborting since we only want to reach this point"))
+				mockContainerDiskMounter.EXPECT().Mount(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("aborting since we only want to reach this point"))
 				vmiInterface.EXPECT().Update(gomock.Any()).AnyTimes()
 
 				controller.Execute()
@@ -1179,6 +1297,46 @@ var _ = Describe("VirtualMachineInstance", func() {
 				Expect(mockQueue.Len()).To(Equal(0))
 				Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
 			})
+
+			It("should report the progress while the containerDisks are prepared", func() {
+				vmi := NewScheduledVMIWithContainerDisk(vmiTestUUID, podTestUUID, host)
+				containerDiskStatuses = []v1.ContainerDiskStatus{{Name: "test", Phase: v1.ContainerDiskPreparing}}
+
+				mockWatchdog.CreateFile(vmi)
+				vmiFeeder.Add(vmi)
+				mockContainerDiskMounter.EXPECT().ContainerDisksReady(vmi, gomock.Any()).Return(true, nil)
+				mockContainerDiskMounter.EXPECT().Mount(gomock.Any(), true).Return(false, nil)
+				var updatedVMI *v1.VirtualMachineInstance
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+					updatedVMI = vmi
+				}).AnyTimes()
+
+				controller.Execute()
+				Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+				Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(0))
+				Expect(updatedVMI).ToNot(BeNil())
+				Expect(updatedVMI.Status.ContainerDiskStatuses).To(Equal(containerDiskStatuses))
+			})
+
+			It("should fail the VMI if a containerDisk does not match its checksum", func() {
+				vmi := NewScheduledVMIWithContainerDisk(vmiTestUUID, podTestUUID, host)
+				vmi.Spec.Volumes[0].ContainerDisk.Checksum = "sha256:" + strings.Repeat("0", 64)
+				containerDiskStatuses = []v1.ContainerDiskStatus{{Name: "test", Phase: v1.ContainerDiskFailed, Message: "checksum mismatch"}}
+
+				mockWatchdog.CreateFile(vmi)
+				vmiFeeder.Add(vmi)
+				mockContainerDiskMounter.EXPECT().ContainerDisksReady(vmi, gomock.Any()).Return(true, nil)
+				mockContainerDiskMounter.EXPECT().Mount(gomock.Any(), true).Return(false, fmt.Errorf("failed to verify containerDisk test: checksum mismatch"))
+				var updatedVMI *v1.VirtualMachineInstance
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+					updatedVMI = vmi
+				}).AnyTimes()
+
+				controller.Execute()
+				Expect(updatedVMI).ToNot(BeNil())
+				Expect(updatedVMI.Status.Phase).To(Equal(v1.Failed))
+				Expect(updatedVMI.Status.ContainerDiskStatuses).To(Equal(containerDiskStatuses))
+			})
 		})
 
 		Context("reacting to a VMI with hotplug", func() {
@@ -1243,6 +1401,23 @@ var _ = Describe("VirtualMachineInstance", func() {
 			table.Entry("failed", v1.Failed),
 		)
 
+		table.DescribeTable("should tear down the pod network of a VirtualMachineInstance in the final phase", func(phase v1.VirtualMachineInstancePhase) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = phase
+			Expect(os.MkdirAll(fmt.Sprintf(util.VMIInterfaceDir, vmi.UID), 0755)).To(Succeed())
+			vmiFeeder.Add(vmi)
+			mockHotplugVolumeMounter.EXPECT().UnmountAll(gomock.Any()).Return(nil)
+			mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(nil).Times(1)
+			client.EXPECT().Close()
+			controller.Execute()
+			Expect(mockQueue.NumRequeues("default/testvmi")).To(Equal(0))
+			Expect(fmt.Sprintf(util.VMIInterfaceDir, vmi.UID)).ToNot(BeADirectory())
+		},
+			table.Entry("succeeded", v1.Succeeded),
+			table.Entry("failed", v1.Failed),
+		)
+
 		It("should leave VirtualMachineInstance phase alone if not the current active node", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.ObjectMeta.ResourceVersion = "1"
@@ -1274,7 +1449,6 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			mockWatchdog.CreateFile(vmi)
 			vmiFeeder.Add(vmi)
-			mockIsolationResult.EXPECT().DoNetNS(gomock.Any()).Return(nil).Times(1)
 
 			// something has to be listening to the cmd socket
 			// for the proxy to work.
@@ -1305,6 +1479,40 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		}, 3)
 
+		It("should wait for the migration source to report the network state before preparing the target", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Labels = map[string]string{v1.MigrationTargetNodeNameLabel: host}
+			vmi.Status.NodeName = "othernode"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				TargetNode:   host,
+				SourceNode:   "othernode",
+				MigrationUID: "123",
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi = addActivePods(vmi, podTestUUID, host)
+			controller.getLauncherClinetInfo(vmi).notInitializedSince = time.Now()
+
+			mockWatchdog.CreateFile(vmi)
+			vmiFeeder.Add(vmi)
+
+			os.MkdirAll(cmdclient.SocketDirectoryOnHost(string(podTestUUID)), os.ModePerm)
+			socketFile := cmdclient.SocketFilePathOnHost(string(podTestUUID))
+			os.RemoveAll(socketFile)
+			socket, err := net.Listen("unix", socketFile)
+			Expect(err).NotTo(HaveOccurred())
+			defer socket.Close()
+
+			client.EXPECT().Ping()
+			// no call to SyncMigrationTarget
+			controller.Execute()
+			Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+			Expect(controller.phase1NetworkSetupCache).To(BeEmpty())
+		})
+
 		It("should abort target prep if VMI is deleted", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -1562,6 +1770,68 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			controller.Execute()
 		}, 3)
+		It("should announce macvtap interfaces after completed migration", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Labels = make(map[string]string)
+			vmi.Status.NodeName = "othernode"
+			vmi.Labels[v1.MigrationTargetNodeNameLabel] = host
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMacvtapNetworkInterface("macvtap")}
+			vmi.Spec.Networks = []v1.Network{{Name: "macvtap", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "macvtapnetwork"}}}}
+			pastTime := metav1.NewTime(metav1.Now().Add(time.Duration(-10) * time.Second))
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				TargetNode:               host,
+				TargetNodeAddress:        "127.0.0.1:12345",
+				SourceNode:               "othernode",
+				MigrationUID:             "123",
+				TargetNodeDomainDetected: true,
+				Completed:                true,
+				StartTimestamp:           &pastTime,
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			domainFeeder.Add(domain)
+			vmiFeeder.Add(vmi)
+
+			client.EXPECT().Ping().AnyTimes()
+			client.EXPECT().AnnounceNetworkInterfaces(vmi)
+
+			controller.Execute()
+		}, 3)
+		It("should hibernate the VMI instead of syncing it", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Annotations = map[string]string{
+				v1.HibernationClaimAnnotation:     "testvm-hibernation",
+				v1.HibernationRequestedAnnotation: "true",
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			domainFeeder.Add(domain)
+			vmiFeeder.Add(vmi)
+
+			client.EXPECT().Ping().AnyTimes()
+			client.EXPECT().HibernateVirtualMachine(vmi)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				cond := findCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionFalse))
+				Expect(cond.Reason).To(Equal(v1.VirtualMachineInstanceReasonDisksNotMigratable))
+			})
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, v1.Stopped.String())
+		}, 3)
 	})
 
 	Context("check if migratable", func() {
@@ -1674,6 +1944,14 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(blockMigrate).To(BeTrue())
 			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared PVCs")))
 		})
+		It("should fail migration for VMIs with a hibernation claim", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Annotations = map[string]string{v1.HibernationClaimAnnotation: "testvm-hibernation"}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with a hibernation claim")))
+		})
 		It("should fail migration for non-shared data volume PVCs", func() {
 
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -1882,6 +2160,22 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
 		})
 
+		It("should not be allowed to live-migrate a VMI with vhost-user-blk disks", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						VhostUserBlk: &v1.VhostUserBlkSource{ClaimName: "spdk", Socket: "vhost.0"},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with vhost-user-blk disks")))
+		})
+
 		Context("with network configuration", func() {
 			It("should block migration for bridge binding assigned to the pod network", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -1954,6 +2248,76 @@ var _ = Describe("VirtualMachineInstance", func() {
 		})
 
 	})
+	Context("network datapath verification", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = v1.Running
+			controller.phase1NetworkSetupCache[vmi.UID] = 1
+		})
+
+		datapathDrifts := func() int {
+			families, err := prometheus.DefaultGatherer.Gather()
+			Expect(err).ToNot(HaveOccurred())
+			count := 0
+			for _, family := range families {
+				if family.GetName() == "kubevirt_vmi_network_datapath_drifts" {
+					count += len(family.GetMetric())
+				}
+			}
+			return count
+		}
+
+		It("should serialize the verifications of a VMI", func() {
+			var running int32
+			verify := func(_ *v1.VirtualMachineInstance, pid int, _ func(func() error) error, _ bool) ([]network.DatapathDrift, error) {
+				Expect(pid).To(Equal(1))
+				Expect(atomic.AddInt32(&running, 1)).To(BeEquivalentTo(1))
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil, nil
+			}
+
+			var verifications sync.WaitGroup
+			for i := 0; i < 5; i++ {
+				verifications.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer verifications.Done()
+					controller.verifyPluggedNetwork(vmi, verify, false)
+				}()
+			}
+			verifications.Wait()
+		})
+
+		It("should not verify the datapath before phase1 plugged it", func() {
+			delete(controller.phase1NetworkSetupCache, vmi.UID)
+			controller.verifyPluggedNetwork(vmi, func(_ *v1.VirtualMachineInstance, _ int, _ func(func() error) error, _ bool) ([]network.DatapathDrift, error) {
+				Fail("the datapath of an unplugged VMI must not be verified")
+				return nil, nil
+			}, true)
+		})
+
+		table.DescribeTable("should report unrepaired drifts to the gauge", func(reportDrifts bool, expectedDrifts int) {
+			networkmetrics.ResetDatapathDrifts()
+			controller.verifyPluggedNetwork(vmi, func(_ *v1.VirtualMachineInstance, _ int, _ func(func() error) error, _ bool) ([]network.DatapathDrift, error) {
+				return []network.DatapathDrift{{
+					Interface: "default",
+					Component: network.DatapathNat,
+					Message:   "the nat rules are missing",
+				}}, nil
+			}, reportDrifts)
+
+			Expect(datapathDrifts()).To(Equal(expectedDrifts))
+			expectEvent(v1.NetworkDatapathDrifted.String(), true)
+		},
+			table.Entry("by the full verification", true, 1),
+			table.Entry("not by the restoration of the nat rules", false, 0),
+		)
+	})
+
 	Context("When VirtualMachineInstance is connected to a network", func() {
 
 		It("should only report the pod network in status", func() {
@@ -2216,6 +2580,27 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should report the freeze state of the guest file systems in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Status.FSFreezeStatus = api.FSFreeze{Status: api.FSFrozen}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.FSFreezeStatus).To(Equal(v1.FSFrozen))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
+
 		It("should add new vmi interfaces for new domain interfaces", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -2265,6 +2650,61 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should report the binding and the datapath of the interfaces", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+
+			MAC := "1C:CE:C0:01:BE:E7"
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				{
+					IP:   "1.1.1.1",
+					MAC:  MAC,
+					Name: "default",
+				},
+			}
+
+			offload := false
+			podJson, err := json.Marshal(network.PodCacheInterface{Iface: &vmi.Spec.Domain.Devices.Interfaces[0], BridgeTXChecksumOffload: &offload})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.MkdirAll(fmt.Sprintf(util.VMIInterfaceDir, vmi.UID), 0755)).To(Succeed())
+			defer os.RemoveAll(fmt.Sprintf(util.VMIInterfaceDir, vmi.UID))
+			Expect(ioutil.WriteFile(fmt.Sprintf(util.VMIInterfacepath, vmi.UID, "default"), podJson, 0644)).To(Succeed())
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			queues := uint(4)
+			domain.Spec.Devices.Interfaces = []api.Interface{
+				{
+					MAC:    &api.MAC{MAC: MAC},
+					Alias:  &api.Alias{Name: "default"},
+					Target: &api.InterfaceTarget{Device: "tap0", Managed: "no"},
+					Driver: &api.InterfaceDriver{Name: "vhost", Queues: &queues},
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				interfaces := arg.(*v1.VirtualMachineInstance).Status.Interfaces
+				Expect(interfaces).To(HaveLen(1))
+				Expect(interfaces[0].Binding).To(Equal("bridge"))
+				Expect(interfaces[0].TapDevice).To(Equal("tap0"))
+				Expect(interfaces[0].BridgeDevice).To(Equal("k6t-eth0"))
+				Expect(interfaces[0].QueueCount).To(Equal(int32(4)))
+				Expect(interfaces[0].BridgeTXChecksumOffload).ToNot(BeNil())
+				Expect(*interfaces[0].BridgeTXChecksumOffload).To(BeFalse())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
+
 		It("should update name on status interfaces with no name", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -2464,6 +2904,684 @@ var _ = Describe("VirtualMachineInstance", func() {
 	})
 })
 
+var _ = Describe("vhost-net zero copy transmission", func() {
+	var paramDir string
+
+	BeforeEach(func() {
+		var err error
+		paramDir, err = ioutil.TempDir("", "vhost-net")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(paramDir)
+	})
+
+	table.DescribeTable("should detect the module parameter", func(content string, expected bool) {
+		paramPath := filepath.Join(paramDir, "experimental_zcopytx")
+		Expect(ioutil.WriteFile(paramPath, []byte(content), 0644)).To(Succeed())
+		Expect(isVhostNetZeroCopyTXEnabled(paramPath)).To(Equal(expected))
+	},
+		table.Entry("when enabled", "1\n", true),
+		table.Entry("when disabled", "0\n", false),
+	)
+
+	It("should report disabled when the module is not loaded", func() {
+		Expect(isVhostNetZeroCopyTXEnabled(filepath.Join(paramDir, "missing"))).To(BeFalse())
+	})
+})
+
+var _ = Describe("nested virtualization", func() {
+	var paramDir string
+	var intelPath, amdPath string
+
+	BeforeEach(func() {
+		var err error
+		paramDir, err = ioutil.TempDir("", "kvm")
+		Expect(err).ToNot(HaveOccurred())
+		intelPath = filepath.Join(paramDir, "kvm_intel")
+		amdPath = filepath.Join(paramDir, "kvm_amd")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(paramDir)
+	})
+
+	table.DescribeTable("should detect the module parameter", func(content string, expected bool) {
+		Expect(ioutil.WriteFile(amdPath, []byte(content), 0644)).To(Succeed())
+		Expect(isNestedVirtualizationEnabled([]string{intelPath, amdPath})).To(Equal(expected))
+	},
+		table.Entry("when enabled on newer kernels", "1\n", true),
+		table.Entry("when enabled on older kernels", "Y\n", true),
+		table.Entry("when disabled on newer kernels", "0\n", false),
+		table.Entry("when disabled on older kernels", "N\n", false),
+	)
+
+	It("should report disabled when no KVM module is loaded", func() {
+		Expect(isNestedVirtualizationEnabled([]string{intelPath, amdPath})).To(BeFalse())
+	})
+})
+
+var _ = Describe("side-channel isolation", func() {
+	var hostDir string
+	var vulnerabilitiesDir, smtActivePath, l1dFlushPath string
+
+	writeHostState := func(vulnerabilities map[string]string, smtActive string, l1dFlush string) {
+		Expect(os.MkdirAll(vulnerabilitiesDir, 0755)).To(Succeed())
+		for name, description := range vulnerabilities {
+			Expect(ioutil.WriteFile(filepath.Join(vulnerabilitiesDir, name), []byte(description+"\n"), 0644)).To(Succeed())
+		}
+		Expect(ioutil.WriteFile(smtActivePath, []byte(smtActive+"\n"), 0644)).To(Succeed())
+		if l1dFlush != "" {
+			Expect(ioutil.WriteFile(l1dFlushPath, []byte(l1dFlush+"\n"), 0644)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		hostDir, err = ioutil.TempDir("", "cpu")
+		Expect(err).ToNot(HaveOccurred())
+		vulnerabilitiesDir = filepath.Join(hostDir, "vulnerabilities")
+		smtActivePath = filepath.Join(hostDir, "active")
+		l1dFlushPath = filepath.Join(hostDir, "vmentry_l1d_flush")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(hostDir)
+	})
+
+	It("should label the mitigation status of the host", func() {
+		writeHostState(map[string]string{
+			"l1tf":       "Mitigation: PTE Inversion; VMX: cache flushes, SMT disabled",
+			"meltdown":   "Not affected",
+			"spectre_v1": "Mitigation: usercopy/swapgs barriers and __user pointer sanitization",
+			"srbds":      "Vulnerable: No microcode",
+		}, "0", "always")
+
+		state, err := readSideChannelState(vulnerabilitiesDir, smtActivePath, l1dFlushPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.labels()).To(Equal(map[string]string{
+			v1.CPUVulnerabilityLabel + "l1tf":       "mitigated",
+			v1.CPUVulnerabilityLabel + "meltdown":   "not-affected",
+			v1.CPUVulnerabilityLabel + "spectre_v1": "mitigated",
+			v1.CPUVulnerabilityLabel + "srbds":      "vulnerable",
+			v1.SMTActive:                            "false",
+			v1.L1DFlush:                             "always",
+			v1.SideChannelIsolation:                 "false",
+		}))
+	})
+
+	table.DescribeTable("should find why the host exposes VMIs", func(vulnerabilities map[string]string, smtActive string, l1dFlush string, exposure string) {
+		writeHostState(vulnerabilities, smtActive, l1dFlush)
+
+		state, err := readSideChannelState(vulnerabilitiesDir, smtActivePath, l1dFlushPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.exposure).To(Equal(exposure))
+	},
+		table.Entry("not if all vulnerabilities are mitigated",
+			map[string]string{"l1tf": "Mitigation: PTE Inversion; VMX: cache flushes, SMT vulnerable", "mds": "Not affected"}, "0", "always",
+			""),
+		table.Entry("not on hosts without kvm_intel",
+			map[string]string{"retbleed": "Mitigation: untrained return thunk; SMT disabled"}, "0", "",
+			""),
+		table.Entry("if a vulnerability is not mitigated",
+			map[string]string{"mds": "Vulnerable: Clear CPU buffers attempted, no microcode; SMT disabled"}, "0", "",
+			"the CPU is vulnerable to mds"),
+		table.Entry("if a mitigation requires SMT to be disabled",
+			map[string]string{"mds": "Mitigation: Clear CPU buffers; SMT vulnerable"}, "1", "",
+			"SMT is active and the mitigation of mds requires it to be disabled"),
+		table.Entry("if KVM flushes the L1 data cache conditionally",
+			map[string]string{"l1tf": "Mitigation: PTE Inversion; VMX: conditional cache flushes, SMT disabled"}, "0", "cond",
+			"KVM does not flush the L1 data cache on every VM entry: vmentry_l1d_flush is cond"),
+		table.Entry("if the mitigation status is unknown",
+			map[string]string{"itlb_multihit": "Unknown: Dependent on hypervisor status"}, "0", "",
+			"the mitigation status of itlb_multihit is unknown: Unknown: Dependent on hypervisor status"),
+	)
+
+	It("should report the isolation in the condition of VMIs requesting it", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(updateSideChannelIsolatedCondition(vmi, &sideChannelState{})).To(BeFalse())
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+
+		vmi.Spec.Domain.CPU = &v1.CPU{SideChannelIsolation: true}
+		Expect(updateSideChannelIsolatedCondition(vmi, &sideChannelState{})).To(BeFalse())
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceSideChannelIsolated))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+
+		exposed := &sideChannelState{exposure: "the CPU is vulnerable to mds"}
+		Expect(updateSideChannelIsolatedCondition(vmi, exposed)).To(BeTrue())
+		Expect(updateSideChannelIsolatedCondition(vmi, exposed)).To(BeFalse())
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionFalse))
+		Expect(vmi.Status.Conditions[0].Reason).To(Equal(v1.VirtualMachineInstanceReasonSideChannelExposed))
+		Expect(vmi.Status.Conditions[0].Message).To(Equal("the CPU is vulnerable to mds"))
+	})
+})
+
+var _ = Describe("NUMA hugepages", func() {
+	var nodesPath string
+
+	writeHugepages := func(node string, size string, total string) {
+		poolPath := filepath.Join(nodesPath, node, "hugepages", "hugepages-"+size)
+		Expect(os.MkdirAll(poolPath, 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(nodesPath, node, "cpulist"), []byte("0\n"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(poolPath, "nr_hugepages"), []byte(total), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(poolPath, "free_hugepages"), []byte("0\n"), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		nodesPath, err = ioutil.TempDir("", "numa")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(nodesPath)
+	})
+
+	It("should advertise the hugepage pools of every NUMA node", func() {
+		writeHugepages("node0", "2048kB", "512\n")
+		writeHugepages("node1", "2048kB", "256\n")
+		writeHugepages("node1", "1048576kB", "2\n")
+
+		capacity, err := numaHugepagesCapacity(nodesPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(capacity).To(Equal(k8sv1.ResourceList{
+			"numa.kubevirt.io/node0-hugepages-2Mi": *resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+			"numa.kubevirt.io/node1-hugepages-2Mi": *resource.NewQuantity(512*1024*1024, resource.BinarySI),
+			"numa.kubevirt.io/node1-hugepages-1Gi": *resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+		}))
+	})
+
+	It("should advertise nothing on a host without NUMA support", func() {
+		capacity, err := numaHugepagesCapacity(filepath.Join(nodesPath, "missing"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(capacity).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Host capabilities", func() {
+	var nodesPath string
+
+	BeforeEach(func() {
+		var err error
+		nodesPath, err = ioutil.TempDir("", "numa")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(nodesPath)
+	})
+
+	It("should read the NUMA topology of the host", func() {
+		for node, cpus := range map[string]string{"node0": "0-1\n", "node1": "2,3\n", "node2": "\n"} {
+			Expect(os.MkdirAll(filepath.Join(nodesPath, node), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(nodesPath, node, "cpulist"), []byte(cpus), 0644)).To(Succeed())
+		}
+
+		capabilities, err := readHostCapabilities(nodesPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(capabilities.NumaNodes).To(Equal([]*cmdv1.NUMANode{
+			{Id: 0, Cpus: []uint32{0, 1}},
+			{Id: 1, Cpus: []uint32{2, 3}},
+			{Id: 2},
+		}))
+	})
+
+	It("should report no NUMA nodes on a host without NUMA support", func() {
+		capabilities, err := readHostCapabilities(filepath.Join(nodesPath, "missing"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(capabilities).ToNot(BeNil())
+		Expect(capabilities.NumaNodes).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Node density capacity", func() {
+	budget := resource.MustParse("32Gi")
+
+	It("should advertise nothing without limits", func() {
+		Expect(nodeDensityCapacity(nil, 0, nil)).To(BeEmpty())
+	})
+
+	It("should advertise the limits of the cluster", func() {
+		Expect(nodeDensityCapacity(nil, 50, &budget)).To(Equal(k8sv1.ResourceList{
+			v1.VMIResource:              *resource.NewQuantity(50, resource.DecimalSI),
+			v1.MemoryOvercommitResource: *resource.NewQuantity(32*1024*1024*1024, resource.BinarySI),
+		}))
+	})
+
+	It("should advertise the limits of the node annotations", func() {
+		annotations := map[string]string{
+			v1.MaxVMIsPerNodeAnnotation:         "10",
+			v1.MemoryOvercommitBudgetAnnotation: "0",
+		}
+		Expect(nodeDensityCapacity(annotations, 50, &budget)).To(Equal(k8sv1.ResourceList{
+			v1.VMIResource:              *resource.NewQuantity(10, resource.DecimalSI),
+			v1.MemoryOvercommitResource: *resource.NewQuantity(0, resource.BinarySI),
+		}))
+	})
+
+	It("should ignore invalid node annotations", func() {
+		annotations := map[string]string{
+			v1.MaxVMIsPerNodeAnnotation:         "many",
+			v1.MemoryOvercommitBudgetAnnotation: "-1Gi",
+		}
+		Expect(nodeDensityCapacity(annotations, 50, &budget)).To(Equal(k8sv1.ResourceList{
+			v1.VMIResource:              *resource.NewQuantity(50, resource.DecimalSI),
+			v1.MemoryOvercommitResource: *resource.NewQuantity(32*1024*1024*1024, resource.BinarySI),
+		}))
+	})
+})
+
+var _ = Describe("ImagesVerified condition", func() {
+	const checksum = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+	newVMI := func(checksum string) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Volumes = []v1.Volume{{
+			Name: "disk0",
+			VolumeSource: v1.VolumeSource{
+				ContainerDisk: &v1.ContainerDiskSource{Image: "disk", Checksum: checksum},
+			},
+		}}
+		return vmi
+	}
+
+	It("should not be added if no containerDisk has a checksum", func() {
+		vmi := newVMI("")
+		updateImagesVerifiedCondition(vmi, api.NewMinimalDomain("testvmi"), nil)
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should be true once the domain with a verified DataVolume is defined", func() {
+		vmi := newVMI("")
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "disk1",
+			VolumeSource: v1.VolumeSource{
+				DataVolume: &v1.DataVolumeSource{Name: "imported", Checksum: checksum},
+			},
+		})
+		updateImagesVerifiedCondition(vmi, api.NewMinimalDomain("testvmi"), nil)
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+	})
+
+	It("should not be added before the domain is defined", func() {
+		vmi := newVMI(checksum)
+		updateImagesVerifiedCondition(vmi, nil, nil)
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should be true once the domain is defined", func() {
+		vmi := newVMI(checksum)
+		updateImagesVerifiedCondition(vmi, api.NewMinimalDomain("testvmi"), nil)
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceImagesVerified))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+	})
+
+	It("should be false on a checksum mismatch", func() {
+		vmi := newVMI(checksum)
+		syncErr := &virtLauncherCriticalChecksumError{"failed to verify containerDisk disk0: checksum mismatch"}
+		updateImagesVerifiedCondition(vmi, nil, syncErr)
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionFalse))
+		Expect(vmi.Status.Conditions[0].Reason).To(Equal(v1.VirtualMachineInstanceReasonChecksumMismatch))
+		Expect(vmi.Status.Conditions[0].Message).To(Equal(syncErr.Error()))
+	})
+
+	It("should keep the transition time while the status is unchanged", func() {
+		vmi := newVMI(checksum)
+		updateImagesVerifiedCondition(vmi, api.NewMinimalDomain("testvmi"), nil)
+		transitionTime := metav1.Time{Time: time.Unix(0, 0)}
+		vmi.Status.Conditions[0].LastTransitionTime = transitionTime
+
+		updateImagesVerifiedCondition(vmi, api.NewMinimalDomain("testvmi"), nil)
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].LastTransitionTime).To(Equal(transitionTime))
+	})
+})
+
+var _ = Describe("Hibernated condition", func() {
+	newVMI := func() *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Annotations = map[string]string{v1.HibernationRequestedAnnotation: "true"}
+		return vmi
+	}
+
+	savedDomain := func() *api.Domain {
+		domain := api.NewMinimalDomain("testvmi")
+		domain.Status.Status = api.Shutoff
+		domain.Status.Reason = api.ReasonSaved
+		return domain
+	}
+
+	It("should not be added if the hibernation was not requested", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		updateHibernatedCondition(vmi, savedDomain(), nil)
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should not be added while the domain runs", func() {
+		vmi := newVMI()
+		updateHibernatedCondition(vmi, api.NewMinimalDomain("testvmi"), nil)
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should be true once the domain is saved", func() {
+		vmi := newVMI()
+		updateHibernatedCondition(vmi, savedDomain(), nil)
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceHibernated))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+	})
+
+	It("should be false if saving the domain failed", func() {
+		vmi := newVMI()
+		syncErr := &virtLauncherHibernationError{"failed to hibernate the vmi: no space left on device"}
+		updateHibernatedCondition(vmi, api.NewMinimalDomain("testvmi"), syncErr)
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionFalse))
+		Expect(vmi.Status.Conditions[0].Reason).To(Equal(v1.VirtualMachineInstanceReasonHibernationFailed))
+		Expect(vmi.Status.Conditions[0].Message).To(Equal(syncErr.Error()))
+	})
+
+	It("should not hibernate the VMI twice", func() {
+		vmi := newVMI()
+		Expect(shouldHibernate(vmi)).To(BeTrue())
+		updateHibernatedCondition(vmi, api.NewMinimalDomain("testvmi"), &virtLauncherHibernationError{"failed"})
+		Expect(shouldHibernate(vmi)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Network plug verification", func() {
+	var ctrl *gomock.Controller
+	var client *cmdclient.MockLauncherClient
+	var vmi *v1.VirtualMachineInstance
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		client = cmdclient.NewMockLauncherClient(ctrl)
+		vmi = v1.NewMinimalVMI("testvmi")
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should not plug the interfaces again if all are plugged", func() {
+		client.EXPECT().GetNetworkStatus().Return([]api.NetworkInterfaceState{{Name: "default", Plugged: true, DHCPStarted: true}}, nil)
+		drifts, err := replugLauncherNetworks(vmi, client)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(BeEmpty())
+	})
+
+	It("should plug the interfaces again if one failed", func() {
+		client.EXPECT().GetNetworkStatus().Return([]api.NetworkInterfaceState{
+			{Name: "default", Plugged: true},
+			{Name: "secondary", Error: "failed to start DHCP server"},
+		}, nil)
+		client.EXPECT().PlugNetworkInterfaces(vmi).Return(nil)
+		drifts, err := replugLauncherNetworks(vmi, client)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(HaveLen(1))
+		Expect(drifts[0].Interface).To(Equal("secondary"))
+		Expect(drifts[0].Component).To(Equal(network.DatapathPlug))
+		Expect(drifts[0].Repaired).To(BeTrue())
+	})
+
+	It("should report the drift as not repaired if plugging fails again", func() {
+		client.EXPECT().GetNetworkStatus().Return([]api.NetworkInterfaceState{{Name: "default"}}, nil)
+		client.EXPECT().PlugNetworkInterfaces(vmi).Return(fmt.Errorf("failed"))
+		drifts, err := replugLauncherNetworks(vmi, client)
+		Expect(err).To(HaveOccurred())
+		Expect(drifts).To(HaveLen(1))
+		Expect(drifts[0].Repaired).To(BeFalse())
+	})
+
+	It("should not classify errors without a network setup reason", func() {
+		client.EXPECT().GetNetworkStatus().Return([]api.NetworkInterfaceState{{Name: "default"}}, nil)
+		client.EXPECT().PlugNetworkInterfaces(vmi).Return(&cmdclient.ServerError{Msg: "network setup failed (DHCPFailed): failed"})
+		_, err := replugLauncherNetworks(vmi, client)
+		Expect(err).To(HaveOccurred())
+		_, ok := err.(*virtLauncherNetworkSetupError)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should report the reason if virt-launcher refuses to plug the interfaces again", func() {
+		client.EXPECT().GetNetworkStatus().Return([]api.NetworkInterfaceState{{Name: "default"}}, nil)
+		setupErr := &cmdclient.ServerError{Reason: network.NetworkSetupReasonDomainChanged, Msg: "re-plugging interface default would change its device in the running domain"}
+		client.EXPECT().PlugNetworkInterfaces(vmi).Return(setupErr)
+		drifts, err := replugLauncherNetworks(vmi, client)
+		Expect(err).To(HaveOccurred())
+		launcherErr, ok := err.(*virtLauncherNetworkSetupError)
+		Expect(ok).To(BeTrue())
+		Expect(launcherErr.reason).To(Equal(network.NetworkSetupReasonDomainChanged))
+		Expect(drifts).To(HaveLen(1))
+		Expect(drifts[0].Repaired).To(BeFalse())
+	})
+})
+
+var _ = Describe("Migration network state", func() {
+	newBridgeVMI := func(sourceNode string) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+			SourceNode: sourceNode,
+			TargetNode: "target",
+		}
+		return vmi
+	}
+
+	Context("on the migration source", func() {
+		var ctrl *gomock.Controller
+		var detector *isolation.MockPodIsolationDetector
+		var controller *VirtualMachineController
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			detector = isolation.NewMockPodIsolationDetector(ctrl)
+			controller = &VirtualMachineController{host: "source", podIsolationDetector: detector}
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should be reported", func() {
+			vmi := newBridgeVMI("source")
+			result := isolation.NewMockIsolationResult(ctrl)
+			// no vif is cached in the root of the test process
+			result.EXPECT().Pid().Return(os.Getpid())
+			detector.EXPECT().Detect(vmi).Return(result, nil)
+			controller.setMigrationSourceNetworkState(vmi)
+			Expect(vmi.Status.MigrationState.SourceNetworkState).To(Equal([]v1.MigrationInterfaceNetworkState{{Name: "default"}}))
+		})
+
+		It("should only be read once", func() {
+			vmi := newBridgeVMI("source")
+			reported := []v1.MigrationInterfaceNetworkState{{Name: "default", MAC: "de:ad:00:00:be:af"}}
+			vmi.Status.MigrationState.SourceNetworkState = reported
+			controller.setMigrationSourceNetworkState(vmi)
+			Expect(vmi.Status.MigrationState.SourceNetworkState).To(Equal(reported))
+		})
+
+		It("should not be read by the migration target", func() {
+			vmi := newBridgeVMI("othernode")
+			controller.setMigrationSourceNetworkState(vmi)
+			Expect(vmi.Status.MigrationState.SourceNetworkState).To(BeNil())
+		})
+
+		It("should not be read for VMIs without bridge, masquerade or macvtap interfaces", func() {
+			vmi := newBridgeVMI("source")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}}}
+			controller.setMigrationSourceNetworkState(vmi)
+			Expect(vmi.Status.MigrationState.SourceNetworkState).To(BeNil())
+		})
+
+		It("should not be reported if the pod of the VMI can't be found", func() {
+			vmi := newBridgeVMI("source")
+			detector.EXPECT().Detect(vmi).Return(nil, fmt.Errorf("pod not found"))
+			controller.setMigrationSourceNetworkState(vmi)
+			Expect(vmi.Status.MigrationState.SourceNetworkState).To(BeNil())
+		})
+	})
+
+	Context("on the migration target", func() {
+		It("should be waited for", func() {
+			Expect(isMigrationNetworkStateReady(newBridgeVMI("source"), time.Now())).To(BeFalse())
+		})
+
+		It("should be ready once the source reported it", func() {
+			vmi := newBridgeVMI("source")
+			vmi.Status.MigrationState.SourceNetworkState = []v1.MigrationInterfaceNetworkState{{Name: "default"}}
+			Expect(isMigrationNetworkStateReady(vmi, time.Now())).To(BeTrue())
+		})
+
+		It("should not be waited for if the VMI does not need it", func() {
+			vmi := newBridgeVMI("source")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}}}
+			Expect(isMigrationNetworkStateReady(vmi, time.Now())).To(BeTrue())
+		})
+
+		It("should not be waited for longer than the timeout", func() {
+			notInitializedSince := time.Now().Add(-migrationNetworkStateTimeout - time.Second)
+			Expect(isMigrationNetworkStateReady(newBridgeVMI("source"), notInitializedSince)).To(BeTrue())
+		})
+	})
+
+	newVMI := func(completed bool) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+			Completed:          completed,
+			SourceNetworkState: []v1.MigrationInterfaceNetworkState{{Name: "default", MAC: "de:ad:00:00:be:af"}},
+			TargetNetworkState: []v1.MigrationInterfaceNetworkState{{Name: "default", Device: "macvtap0"}},
+		}
+		return vmi
+	}
+
+	It("should be kept while the migration runs", func() {
+		vmi := newVMI(false)
+		clearMigrationNetworkState(vmi)
+		Expect(vmi.Status.MigrationState.SourceNetworkState).To(HaveLen(1))
+		Expect(vmi.Status.MigrationState.TargetNetworkState).To(HaveLen(1))
+	})
+
+	It("should be cleared once the migration completed", func() {
+		vmi := newVMI(true)
+		clearMigrationNetworkState(vmi)
+		Expect(vmi.Status.MigrationState.SourceNetworkState).To(BeNil())
+		Expect(vmi.Status.MigrationState.TargetNetworkState).To(BeNil())
+	})
+})
+
+var _ = Describe("Emulated condition", func() {
+	emulatedDomain := func() *api.Domain {
+		domain := api.NewMinimalDomain("testvmi")
+		domain.Spec.Type = "qemu"
+		return domain
+	}
+
+	It("should not be added without a domain", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(updateEmulatedCondition(vmi, nil)).To(BeFalse())
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should not be added if the domain uses KVM", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(updateEmulatedCondition(vmi, api.NewMinimalDomain("testvmi"))).To(BeFalse())
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should be added once if the domain is emulated", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(updateEmulatedCondition(vmi, emulatedDomain())).To(BeTrue())
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+		Expect(vmi.Status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceEmulated))
+		Expect(vmi.Status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+		Expect(vmi.Status.Conditions[0].Reason).To(Equal(v1.VirtualMachineInstanceReasonSoftwareEmulation))
+
+		Expect(updateEmulatedCondition(vmi, emulatedDomain())).To(BeFalse())
+		Expect(vmi.Status.Conditions).To(HaveLen(1))
+	})
+
+	It("should be removed once the domain uses KVM", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		updateEmulatedCondition(vmi, emulatedDomain())
+		Expect(updateEmulatedCondition(vmi, api.NewMinimalDomain("testvmi"))).To(BeFalse())
+		Expect(vmi.Status.Conditions).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Startup timestamps", func() {
+	var controller *VirtualMachineController
+	var vmi *v1.VirtualMachineInstance
+
+	BeforeEach(func() {
+		controller = &VirtualMachineController{phase1NetworkSetupCache: make(map[types.UID]int)}
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+		vmi.Status.Phase = v1.Scheduled
+	})
+
+	runningDomain := func() *api.Domain {
+		domain := api.NewMinimalDomain("testvmi")
+		domain.Status.Status = api.Running
+		return domain
+	}
+
+	It("should not be recorded before any milestone was reached", func() {
+		controller.updateStartupTimestamps(vmi, v1.Scheduled, nil, false)
+		Expect(vmi.Status.StartupTimestamps).To(BeNil())
+	})
+
+	It("should record the milestones observed by virt-handler", func() {
+		controller.phase1NetworkSetupCache[vmi.UID] = 1
+		controller.updateStartupTimestamps(vmi, v1.Scheduled, runningDomain(), true)
+		Expect(vmi.Status.StartupTimestamps).ToNot(BeNil())
+		Expect(vmi.Status.StartupTimestamps.NetworkConfigured).ToNot(BeNil())
+		Expect(vmi.Status.StartupTimestamps.DomainDefined).ToNot(BeNil())
+		Expect(vmi.Status.StartupTimestamps.DomainRunning).ToNot(BeNil())
+		Expect(vmi.Status.StartupTimestamps.GuestAgentConnected).ToNot(BeNil())
+	})
+
+	It("should only record the domain as running once it runs", func() {
+		controller.updateStartupTimestamps(vmi, v1.Scheduled, api.NewMinimalDomain("testvmi"), false)
+		Expect(vmi.Status.StartupTimestamps.DomainDefined).ToNot(BeNil())
+		Expect(vmi.Status.StartupTimestamps.DomainRunning).To(BeNil())
+	})
+
+	It("should not overwrite a recorded milestone", func() {
+		recorded := metav1.NewTime(time.Now().Add(-time.Hour))
+		vmi.Status.StartupTimestamps = &v1.VirtualMachineInstanceStartupTimestamps{DomainDefined: &recorded}
+		controller.updateStartupTimestamps(vmi, v1.Scheduled, runningDomain(), false)
+		Expect(*vmi.Status.StartupTimestamps.DomainDefined).To(Equal(recorded))
+		Expect(vmi.Status.StartupTimestamps.DomainRunning).ToNot(BeNil())
+	})
+
+	It("should not be recorded for a vmi started before they were tracked", func() {
+		vmi.Status.Phase = v1.Running
+		controller.updateStartupTimestamps(vmi, v1.Running, runningDomain(), true)
+		Expect(vmi.Status.StartupTimestamps).To(BeNil())
+	})
+
+	It("should not be recorded for a vmi which is being deleted", func() {
+		now := metav1.Now()
+		vmi.DeletionTimestamp = &now
+		controller.updateStartupTimestamps(vmi, v1.Scheduled, runningDomain(), false)
+		Expect(vmi.Status.StartupTimestamps).To(BeNil())
+	})
+
+	It("should not be recorded for a vmi in a final phase", func() {
+		vmi.Status.Phase = v1.Failed
+		controller.updateStartupTimestamps(vmi, v1.Scheduled, api.NewMinimalDomain("testvmi"), false)
+		Expect(vmi.Status.StartupTimestamps).To(BeNil())
+	})
+})
+
 var _ = Describe("DomainNotifyServerRestarts", func() {
 	Context("should establish a notify server pipe", func() {
 		var shareDir string
@@ -2719,6 +3837,15 @@ func addActivePods(vmi *v1.VirtualMachineInstance, podUID types.UID, hostName st
 	return vmi
 }
 
+func findCondition(vmi *v1.VirtualMachineInstance, conditionType v1.VirtualMachineInstanceConditionType) *v1.VirtualMachineInstanceCondition {
+	for i := range vmi.Status.Conditions {
+		if vmi.Status.Conditions[i].Type == conditionType {
+			return &vmi.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 func NewScheduledVMIWithContainerDisk(vmiUID types.UID, podUID types.UID, hostname string) *v1.VirtualMachineInstance {
 	vmi := NewScheduledVMI(vmiUID, podUID, hostname)
 
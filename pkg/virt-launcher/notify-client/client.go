@@ -24,6 +24,7 @@ import (
 	com "kubevirt.io/kubevirt/pkg/handler-launcher-com"
 	"kubevirt.io/kubevirt/pkg/handler-launcher-com/notify/info"
 	notifyv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/notify/v1"
+	hypervisorlog "kubevirt.io/kubevirt/pkg/hypervisor-log"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -199,7 +200,7 @@ func newWatchEventError(err error) watch.Event {
 }
 
 func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEvent, client *Notifier, events chan watch.Event,
-	interfaceStatus []api.InterfaceStatus, osInfo *api.GuestOSInfo) {
+	interfaceStatus []api.InterfaceStatus, osInfo *api.GuestOSInfo, fsFreezeStatus *api.FSFreeze) {
 	d, err := c.LookupDomainByName(util.DomainFromNamespaceName(domain.ObjectMeta.Namespace, domain.ObjectMeta.Name))
 	if err != nil {
 		if !domainerrors.IsNotFound(err) {
@@ -266,6 +267,9 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 		if osInfo != nil {
 			domain.Status.OSInfo = *osInfo
 		}
+		if fsFreezeStatus != nil {
+			domain.Status.FSFreezeStatus = *fsFreezeStatus
+		}
 
 		err := client.SendDomainEvent(watch.Event{Type: watch.Modified, Object: domain})
 		if err != nil {
@@ -326,11 +330,12 @@ func (n *Notifier) StartDomainNotifier(
 	go func() {
 		var interfaceStatuses []api.InterfaceStatus
 		var guestOsInfo *api.GuestOSInfo
+		var fsFreezeStatus *api.FSFreeze
 		for {
 			select {
 			case event := <-eventChan:
 				domainCache = util.NewDomainFromName(event.Domain, vmiUID)
-				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo)
+				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, fsFreezeStatus)
 				log.Log.Infof("Domain name event: %v", domainCache.Spec.Name)
 				if event.AgentEvent != nil {
 					if event.AgentEvent.State == libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_CONNECTED {
@@ -342,20 +347,29 @@ func (n *Notifier) StartDomainNotifier(
 			case agentUpdate := <-agentStore.AgentUpdated:
 				interfaceStatuses = agentUpdate.DomainInfo.Interfaces
 				guestOsInfo = agentUpdate.DomainInfo.OSInfo
+				// the freeze state is only published when it changes, keep it for the
+				// domain events in between
+				if agentUpdate.DomainInfo.FSFreezeStatus != nil {
+					fsFreezeStatus = agentUpdate.DomainInfo.FSFreezeStatus
+				}
 				if interfaceStatuses != nil {
 					interfaceStatuses = agentpoller.MergeAgentStatusesWithDomainData(domainCache.Spec.Devices.Interfaces, interfaceStatuses)
 				}
 
 				eventCallback(domainConn, domainCache, libvirtEvent{}, n, deleteNotificationSent,
-					interfaceStatuses, guestOsInfo)
+					interfaceStatuses, guestOsInfo, fsFreezeStatus)
 			case <-reconnectChan:
 				n.SendDomainEvent(newWatchEventError(fmt.Errorf("Libvirt reconnect, domain %s", domainName)))
 			}
 		}
 	}()
 
+	eventLogPath := hypervisorlog.EventLogPath(vmiUID)
 	domainEventLifecycleCallback := func(c *libvirt.Connect, d *libvirt.Domain, event *libvirt.DomainEventLifecycle) {
 		log.Log.Infof("DomainLifecycle event %d with reason %d received", event.Event, event.Detail)
+		if err := hypervisorlog.WriteEvent(eventLogPath, lifecycleEventLevel(event), event.String(), time.Now()); err != nil {
+			log.Log.Reason(err).Warning("Could not write the lifecycle event to the event log.")
+		}
 		name, err := d.GetName()
 		if err != nil {
 			log.Log.Reason(err).Info("Could not determine name of libvirt domain in event callback.")
@@ -433,6 +447,26 @@ func (n *Notifier) StartDomainNotifier(
 	return nil
 }
 
+// lifecycleEventLevel returns the level of a lifecycle event in the hypervisor
+// log, crashes and failures are errors, suspensions by errors warnings
+func lifecycleEventLevel(event *libvirt.DomainEventLifecycle) string {
+	switch event.Event {
+	case libvirt.DOMAIN_EVENT_CRASHED:
+		return hypervisorlog.LevelError
+	case libvirt.DOMAIN_EVENT_STOPPED:
+		switch libvirt.DomainEventStoppedDetailType(event.Detail) {
+		case libvirt.DOMAIN_EVENT_STOPPED_CRASHED, libvirt.DOMAIN_EVENT_STOPPED_FAILED:
+			return hypervisorlog.LevelError
+		}
+	case libvirt.DOMAIN_EVENT_SUSPENDED:
+		switch libvirt.DomainEventSuspendedDetailType(event.Detail) {
+		case libvirt.DOMAIN_EVENT_SUSPENDED_IOERROR, libvirt.DOMAIN_EVENT_SUSPENDED_WATCHDOG, libvirt.DOMAIN_EVENT_SUSPENDED_API_ERROR:
+			return hypervisorlog.LevelWarning
+		}
+	}
+	return hypervisorlog.LevelInfo
+}
+
 func (n *Notifier) SendK8sEvent(vmi *v1.VirtualMachineInstance, severity string, reason string, message string) error {
 
 	err := n.connect()
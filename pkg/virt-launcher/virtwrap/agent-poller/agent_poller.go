@@ -38,13 +38,14 @@ type AgentCommand string
 // Aliases are also used as keys to the store, it does not matter how the keys are named,
 // only whether it relates to the right data
 const (
-	GET_OSINFO     AgentCommand = "guest-get-osinfo"
-	GET_HOSTNAME   AgentCommand = "guest-get-host-name"
-	GET_INTERFACES AgentCommand = "guest-network-get-interfaces"
-	GET_TIMEZONE   AgentCommand = "guest-get-timezone"
-	GET_USERS      AgentCommand = "guest-get-users"
-	GET_FILESYSTEM AgentCommand = "guest-get-fsinfo"
-	GET_AGENT      AgentCommand = "guest-info"
+	GET_OSINFO          AgentCommand = "guest-get-osinfo"
+	GET_HOSTNAME        AgentCommand = "guest-get-host-name"
+	GET_INTERFACES      AgentCommand = "guest-network-get-interfaces"
+	GET_TIMEZONE        AgentCommand = "guest-get-timezone"
+	GET_USERS           AgentCommand = "guest-get-users"
+	GET_FILESYSTEM      AgentCommand = "guest-get-fsinfo"
+	GET_AGENT           AgentCommand = "guest-info"
+	GET_FSFREEZE_STATUS AgentCommand = "guest-fsfreeze-status"
 
 	pollInitialInterval = 10 * time.Second
 )
@@ -90,6 +91,9 @@ func (s *AsyncAgentStore) Store(key AgentCommand, value interface{}) {
 			domainInfo.OSInfo = &info
 		case GET_INTERFACES:
 			domainInfo.Interfaces = value.([]api.InterfaceStatus)
+		case GET_FSFREEZE_STATUS:
+			status := value.(api.FSFreeze)
+			domainInfo.FSFreezeStatus = &status
 		}
 
 		s.AgentUpdated <- AgentUpdatedEvent{
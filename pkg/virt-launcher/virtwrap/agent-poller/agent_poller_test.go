@@ -83,6 +83,17 @@ var _ = Describe("Qemu agent poller", func() {
 			agentStore.Store(GET_OSINFO, fakeInfo)
 			Expect(agentStore.AgentUpdated).ToNot(Receive())
 		})
+
+		It("should fire an event for a new freeze state", func() {
+			var agentStore = NewAsyncAgentStore()
+			frozen := api.FSFreeze{Status: api.FSFrozen}
+
+			agentStore.Store(GET_FSFREEZE_STATUS, frozen)
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type:       GET_FSFREEZE_STATUS,
+				DomainInfo: api.DomainGuestInfo{FSFreezeStatus: &frozen},
+			})))
+		})
 	})
 
 	Context("PollerWorker", func() {
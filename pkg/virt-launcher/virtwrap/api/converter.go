@@ -32,6 +32,7 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/pborman/uuid"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
@@ -48,6 +49,7 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 )
 
@@ -65,9 +67,18 @@ const (
 	HostDeviceMDEV         HostDeviceType = "mdev"
 )
 const (
-	multiQueueMaxQueues = uint32(256)
+	// seconds qemu waits before reconnecting to the vhost-user-blk socket of a restarted storage backend
+	vhostUserBlkReconnectTimeout = uint(10)
 )
 
+// DiskTagOEMStringPrefix prefixes the SMBIOS OEM strings which publish the
+// tags of the disks to the guest.
+const DiskTagOEMStringPrefix = "io.kubevirt.disk.tag:"
+
+// LowLatencyBusyPollMicroseconds is how long vhost-net busy polls the tap
+// device of low-latency interfaces for new packets before it sleeps.
+const LowLatencyBusyPollMicroseconds = 50
+
 type deviceNamer struct {
 	existingNameMap map[string]string
 	usedDeviceMap   map[string]string
@@ -96,8 +107,15 @@ type ConverterContext struct {
 	VgpuDevices           []string
 	HostDevices           map[string]HostDevicesList
 	EmulatorThreadCpu     *int
+	NUMANodes             []hardware.NUMANode
 	OVMFPath              string
 	MemBalloonStatsPeriod uint
+	// RenderOnly skips probing the host for /dev/kvm and /dev/vhost-net,
+	// for callers which only render the domain and never define it
+	RenderOnly bool
+	// Emulator is the path of the qemu binary, libvirt picks its default
+	// emulator if it is empty
+	Emulator string
 }
 
 // pop next device ID or address from a list
@@ -176,6 +194,7 @@ func Convert_v1_Disk_To_api_Disk(diskDevice *v1.Disk, disk *Disk, prefixMap map[
 		}
 		disk.ReadOnly = toApiReadOnly(diskDevice.Disk.ReadOnly)
 		disk.Serial = diskDevice.Serial
+		disk.WWN = diskDevice.WWN
 	} else if diskDevice.LUN != nil {
 		disk.Device = "lun"
 		disk.Target.Bus = diskDevice.LUN.Bus
@@ -231,6 +250,11 @@ func SetDriverCacheMode(disk *Disk) error {
 	supportDirectIO := true
 	mode := v1.DriverCache(disk.Driver.Cache)
 
+	// the storage backend behind a vhost-user-blk socket does the caching
+	if disk.Type == "vhostuser" {
+		return nil
+	}
+
 	if disk.Source.File != "" {
 		path = disk.Source.File
 	} else if disk.Source.Dev != "" {
@@ -381,6 +405,30 @@ func toApiReadOnly(src bool) *ReadOnly {
 	return nil
 }
 
+// ChannelSocketName returns the name of the unix socket in the private directory
+// of the VMI which backs the channel with the given index. The index is used
+// instead of the channel name, so that the socket path stays short enough for
+// virt-handler to connect to it through /proc.
+func ChannelSocketName(index int) string {
+	return fmt.Sprintf("virt-channel%d", index)
+}
+
+// Convert_v1_Channel_To_api_Channel creates a virtio-serial channel which is
+// backed by a unix socket in the private directory of the VMI
+func Convert_v1_Channel_To_api_Channel(vmi *v1.VirtualMachineInstance, channel v1.Channel, index int) Channel {
+	return Channel{
+		Type: "unix",
+		Source: &ChannelSource{
+			Mode: "bind",
+			Path: fmt.Sprintf("/var/run/kubevirt-private/%s/%s", vmi.ObjectMeta.UID, ChannelSocketName(index)),
+		},
+		Target: &ChannelTarget{
+			Name: channel.Name,
+			Type: "virtio",
+		},
+	}
+}
+
 // Add_Agent_To_api_Channel creates the channel for guest agent communication
 func Add_Agent_To_api_Channel() (channel Channel) {
 	channel.Type = "unix"
@@ -434,6 +482,9 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *Disk, c *ConverterCo
 	if source.ServiceAccount != nil {
 		return Convert_v1_Config_To_api_Disk(source.Name, disk, config.ServiceAccount)
 	}
+	if source.VhostUserBlk != nil {
+		return Convert_v1_VhostUserBlkSource_To_api_Disk(source.Name, source.VhostUserBlk, disk, c)
+	}
 
 	return fmt.Errorf("disk %s references an unsupported source", disk.Alias.Name)
 }
@@ -479,6 +530,12 @@ func Convert_v1_Config_To_api_Disk(volumeName string, disk *Disk, configType con
 	return nil
 }
 
+// DiskEncryptionSecretUUID returns the UUID of the libvirt secret which holds the LUKS passphrase of a volume.
+// It is derived from the VMI UID, so that it is stable across syncs and migrations.
+func DiskEncryptionSecretUUID(vmi *v1.VirtualMachineInstance, volumeName string) string {
+	return uuid.NewSHA1(uuid.Parse(string(vmi.UID)), []byte(volumeName)).String()
+}
+
 func GetFilesystemVolumePath(volumeName string) string {
 	return filepath.Join(string(filepath.Separator), "var", "run", "kubevirt-private", "vmi-disks", volumeName, "disk.img")
 }
@@ -565,6 +622,33 @@ func Convert_v1_HostDisk_To_api_Disk(volumeName string, path string, disk *Disk,
 	return nil
 }
 
+// GetVhostUserBlkSocketPath returns the path of the vhost-user-blk socket in the mounted volume
+func GetVhostUserBlkSocketPath(volumeName string, socket string) string {
+	return filepath.Join(string(filepath.Separator), "var", "run", "kubevirt-private", "vmi-disks", volumeName, socket)
+}
+
+// Convert_v1_VhostUserBlkSource_To_api_Disk connects the disk to the vhost-user-blk socket of a storage backend,
+// qemu reconnects to the socket when the backend restarts
+func Convert_v1_VhostUserBlkSource_To_api_Disk(volumeName string, source *v1.VhostUserBlkSource, disk *Disk, c *ConverterContext) error {
+	if disk.Device != "disk" || disk.Target.Bus != "virtio" {
+		return fmt.Errorf("device %s of a vhost-user-blk volume has to be a virtio disk", disk.Alias.Name)
+	}
+
+	disk.Type = "vhostuser"
+	disk.Snapshot = "no"
+	disk.Driver.Type = "raw"
+	disk.Driver.Cache = ""
+	disk.Driver.IO = ""
+	disk.Source.Type = "unix"
+	disk.Source.Path = GetVhostUserBlkSocketPath(volumeName, source.Socket)
+	reconnectTimeout := vhostUserBlkReconnectTimeout
+	disk.Source.Reconnect = &DiskSourceReconnect{
+		Enabled: "yes",
+		Timeout: &reconnectTimeout,
+	}
+	return nil
+}
+
 func Convert_v1_CloudInitSource_To_api_Disk(source v1.VolumeSource, disk *Disk, c *ConverterContext) error {
 	if disk.Type == "lun" {
 		return fmt.Errorf("device %s is of type lun. Not compatible with a file based disk", disk.Alias.Name)
@@ -604,22 +688,24 @@ func Convert_v1_EmptyDiskSource_To_api_Disk(volumeName string, _ *v1.EmptyDiskSo
 	return nil
 }
 
-func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, _ *v1.ContainerDiskSource, disk *Disk, c *ConverterContext, diskIndex int) error {
+func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, source *v1.ContainerDiskSource, disk *Disk, c *ConverterContext, diskIndex int) error {
 	if disk.Type == "lun" {
 		return fmt.Errorf("device %s is of type lun. Not compatible with a file based disk", disk.Alias.Name)
 	}
 	disk.Type = "file"
-	disk.Driver.Type = "qcow2"
-	disk.Source.File = ephemeraldisk.GetFilePath(volumeName)
+	disk.Driver.Type = ephemeraldisk.GetImageFormat(source.EphemeralImage)
+	disk.Source.File = ephemeraldisk.GetFilePath(volumeName, source.EphemeralImage)
+	// a converted image is not backed by the container disk
+	if !ephemeraldisk.IsOverlay(source.EphemeralImage) {
+		return nil
+	}
 	disk.BackingStore = &BackingStore{
 		Format: &BackingStoreFormat{},
 		Source: &DiskSource{},
 	}
 
-	source := containerdisk.GetDiskTargetPathFromLauncherView(diskIndex)
-
 	disk.BackingStore.Format.Type = c.DiskType[volumeName].Format
-	disk.BackingStore.Source.File = source
+	disk.BackingStore.Source.File = containerdisk.GetDiskTargetPathFromLauncherView(diskIndex)
 	disk.BackingStore.Type = "file"
 
 	return nil
@@ -627,8 +713,12 @@ func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, _ *v1.Contain
 
 func Convert_v1_EphemeralVolumeSource_To_api_Disk(volumeName string, source *v1.EphemeralVolumeSource, disk *Disk, c *ConverterContext) error {
 	disk.Type = "file"
-	disk.Driver.Type = "qcow2"
-	disk.Source.File = ephemeraldisk.GetFilePath(volumeName)
+	disk.Driver.Type = ephemeraldisk.GetImageFormat(source.EphemeralImage)
+	disk.Source.File = ephemeraldisk.GetFilePath(volumeName, source.EphemeralImage)
+	// a converted image is not backed by the claim
+	if !ephemeraldisk.IsOverlay(source.EphemeralImage) {
+		return nil
+	}
 	disk.BackingStore = &BackingStore{
 		Format: &BackingStoreFormat{},
 		Source: &DiskSource{},
@@ -684,12 +774,17 @@ func Convert_v1_Input_To_api_InputDevice(input *v1.Input, inputDevice *Input, _
 		input.Bus = "usb"
 	}
 
-	if input.Type != "tablet" {
+	if input.Type != "tablet" && input.Type != "keyboard" {
 		return fmt.Errorf("input contains unsupported type %s", input.Type)
 	}
 
+	if input.Model != "" && input.Bus != "virtio" {
+		return fmt.Errorf("input model %s requires the virtio bus", input.Model)
+	}
+
 	inputDevice.Bus = input.Bus
 	inputDevice.Type = input.Type
+	inputDevice.Model = input.Model
 	inputDevice.Alias = &Alias{Name: input.Name}
 	return nil
 }
@@ -864,6 +959,67 @@ func popSRIOVPCIAddress(networkName string, addrsMap map[string][]string) (strin
 	return "", addrsMap, fmt.Errorf("no more SR-IOV PCI addresses to allocate")
 }
 
+// setInterfaceDriverTuning applies the ring sizes, the virtqueue layout and
+// the host offloads of the interface to the vhost driver of the domain interface.
+// Zero copy transmission is a setting of the vhost_net kernel module of the
+// node, it only requires the interface to be served by vhost. Without
+// /dev/vhost-net the tuning is applied to the qemu userland driver.
+func setInterfaceDriverTuning(domainIface *Interface, iface *v1.Interface, vhostNetAvailable bool) error {
+	if iface.VhostZeroCopyTX != nil && *iface.VhostZeroCopyTX && !vhostNetAvailable {
+		return fmt.Errorf("zero copy transmission of interface %s requires '/dev/vhost-net'", iface.Name)
+	}
+
+	var host *InterfaceDriverHost
+	if offloads := iface.Offloads; offloads != nil && (iface.Bridge != nil || iface.Masquerade != nil) {
+		host = &InterfaceDriverHost{}
+		if offloads.TSO != nil {
+			host.TSO4 = boolToOnOff(offloads.TSO, true)
+			host.TSO6 = boolToOnOff(offloads.TSO, true)
+		}
+		if offloads.GSO != nil {
+			host.GSO = boolToOnOff(offloads.GSO, true)
+		}
+		if *host == (InterfaceDriverHost{}) {
+			host = nil
+		}
+	}
+	if iface.RxQueueSize == nil && iface.TxQueueSize == nil && iface.PackedRing == nil && iface.VhostZeroCopyTX == nil && host == nil {
+		return nil
+	}
+	if domainIface.Driver == nil {
+		domainIface.Driver = &InterfaceDriver{}
+		if vhostNetAvailable {
+			domainIface.Driver.Name = "vhost"
+		}
+	}
+	if iface.RxQueueSize != nil {
+		size := uint(*iface.RxQueueSize)
+		domainIface.Driver.RxQueueSize = &size
+	}
+	if iface.TxQueueSize != nil {
+		size := uint(*iface.TxQueueSize)
+		domainIface.Driver.TxQueueSize = &size
+	}
+	if iface.PackedRing != nil {
+		domainIface.Driver.Packed = boolToOnOff(iface.PackedRing, false)
+	}
+	domainIface.Driver.Host = host
+	return nil
+}
+
+// setInterfaceBusyPolling makes vhost-net busy poll the tap device of the
+// interface. libvirt has no setting for it, so the poll-us option is set on
+// the netdev libvirt creates for the interface, which is named after its alias.
+func setInterfaceBusyPolling(domain *Domain, iface *v1.Interface) {
+	if domain.Spec.QEMUCmd == nil {
+		domain.Spec.QEMUCmd = &Commandline{}
+	}
+	domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg,
+		Arg{Value: "-set"},
+		Arg{Value: fmt.Sprintf("netdev.host%s%s.poll-us=%d", UserAliasPrefix, iface.Name, LowLatencyBusyPollMicroseconds)},
+	)
+}
+
 func getInterfaceType(iface *v1.Interface) string {
 	if iface.Slirp != nil {
 		// Slirp configuration works only with e1000 or rtl8139
@@ -900,28 +1056,36 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		CPUs:      cpuCount,
 	}
 
-	if _, err := os.Stat("/dev/kvm"); os.IsNotExist(err) {
-		if c.UseEmulation {
-			logger := log.DefaultLogger()
-			logger.Infof("Hardware emulation device '/dev/kvm' not present. Using software emulation.")
-			domain.Spec.Type = "qemu"
-		} else {
-			return fmt.Errorf("hardware emulation device '/dev/kvm' not present")
-		}
-	} else if err != nil {
-		return err
+	if c.Emulator != "" {
+		domain.Spec.Devices.Emulator = c.Emulator
 	}
 
 	virtioNetProhibited := false
-	if _, err := os.Stat("/dev/vhost-net"); os.IsNotExist(err) {
-		if c.UseEmulation {
-			logger := log.DefaultLogger()
-			logger.Infof("In-kernel virtio-net device emulation '/dev/vhost-net' not present. Falling back to QEMU userland emulation.")
-		} else {
-			virtioNetProhibited = true
+	vhostNetAvailable := true
+	if !c.RenderOnly {
+		if _, err := os.Stat("/dev/kvm"); os.IsNotExist(err) {
+			if c.UseEmulation {
+				logger := log.DefaultLogger()
+				logger.Infof("Hardware emulation device '/dev/kvm' not present. Using software emulation.")
+				domain.Spec.Type = "qemu"
+			} else {
+				return fmt.Errorf("hardware emulation device '/dev/kvm' not present")
+			}
+		} else if err != nil {
+			return err
+		}
+
+		if _, err := os.Stat("/dev/vhost-net"); os.IsNotExist(err) {
+			vhostNetAvailable = false
+			if c.UseEmulation {
+				logger := log.DefaultLogger()
+				logger.Infof("In-kernel virtio-net device emulation '/dev/vhost-net' not present. Falling back to QEMU userland emulation.")
+			} else {
+				virtioNetProhibited = true
+			}
+		} else if err != nil {
+			return err
 		}
-	} else if err != nil {
-		return err
 	}
 
 	// Spec metadata
@@ -929,6 +1093,10 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 	newChannel := Add_Agent_To_api_Channel()
 	domain.Spec.Devices.Channels = append(domain.Spec.Devices.Channels, newChannel)
 
+	for i, channel := range vmi.Spec.Domain.Devices.Channels {
+		domain.Spec.Devices.Channels = append(domain.Spec.Devices.Channels, Convert_v1_Channel_To_api_Channel(vmi, channel, i))
+	}
+
 	domain.Spec.Metadata.KubeVirt.UID = vmi.UID
 	gracePeriodSeconds := v1.DefaultGracePeriodSeconds
 	if vmi.Spec.TerminationGracePeriodSeconds != nil {
@@ -983,6 +1151,18 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 			}
 		}
 
+		if bootMenu := vmi.Spec.Domain.Firmware.BootMenu; bootMenu != nil {
+			if bootMenu.Enabled == nil || *bootMenu.Enabled {
+				domain.Spec.OS.BootMenu = &BootMenu{Enabled: "yes"}
+				if bootMenu.Timeout != nil {
+					timeout := uint(*bootMenu.Timeout)
+					domain.Spec.OS.BootMenu.Timeout = &timeout
+				}
+			} else {
+				domain.Spec.OS.BootMenu = &BootMenu{Enabled: "no"}
+			}
+		}
+
 		if len(vmi.Spec.Domain.Firmware.Serial) > 0 {
 			domain.Spec.SysInfo.System = append(domain.Spec.SysInfo.System, Entry{Name: "serial", Value: string(vmi.Spec.Domain.Firmware.Serial)})
 		}
@@ -1046,6 +1226,10 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		}
 	}
 
+	if oemStrings := diskTagOEMStrings(vmi.Spec.Domain.Devices.Disks); len(oemStrings) > 0 {
+		domain.Spec.SysInfo.OEMStrings = &OEMStrings{Entries: oemStrings}
+	}
+
 	if domain.Spec.Memory, err = QuantityToByte(*getVirtualMemory(vmi)); err != nil {
 		return err
 	}
@@ -1059,8 +1243,8 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 			isMemfdRequired = true
 		}
 	}
-	// virtiofs require shared access
-	if util.IsVMIVirtiofsEnabled(vmi) {
+	// virtiofs and vhost-user-blk require shared access
+	if util.IsVMIVirtiofsEnabled(vmi) || util.HasVhostUserBlkVolumes(vmi) {
 		if domain.Spec.MemoryBacking == nil {
 			domain.Spec.MemoryBacking = &MemoryBacking{}
 		}
@@ -1185,7 +1369,18 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 			return err
 		}
 
-		if useIOThreads {
+		if volume.Encryption != nil {
+			newDisk.Encryption = &DiskEncryption{
+				Format: "luks",
+				Secret: &DiskSecret{
+					Type: "passphrase",
+					UUID: DiskEncryptionSecretUUID(vmi, volume.Name),
+				},
+			}
+		}
+
+		// vhost-user-blk disks are served by the storage backend, not by qemu's iothreads
+		if useIOThreads && newDisk.Type != "vhostuser" {
 			ioThreadId := defaultIOThread
 			dedicatedThread := false
 			if disk.DedicatedIOThread != nil {
@@ -1298,10 +1493,14 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 			Model: "none",
 		})
 	} else {
+		model := vmi.Spec.Domain.Devices.USBControllerModel
+		if model == "" {
+			model = "qemu-xhci"
+		}
 		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, Controller{
 			Type:  "usb",
 			Index: "0",
-			Model: "qemu-xhci",
+			Model: model,
 		})
 	}
 
@@ -1357,6 +1556,10 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 			}
 		}
 
+		if vmi.Spec.Domain.CPU.NestedVirtualization && vmi.Spec.Domain.CPU.Model != v1.CPUModeHostPassthrough {
+			appendNestedVirtualizationFeatures(domain)
+		}
+
 		// Adjust guest vcpu config. Currently will handle vCPUs to pCPUs pinning
 		if vmi.IsCPUDedicated() {
 			if err := formatDomainCPUTune(vmi, domain, c); err != nil {
@@ -1370,8 +1573,18 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 					return err
 
 				}
+				if err := checkExclusiveCPUSet(domain, c); err != nil {
+					log.Log.Reason(err).Error("failed to format emulation thread pin")
+					return err
+				}
 				appendDomainEmulatorThreadPin(domain, *c.EmulatorThreadCpu)
 			}
+			if vmi.Spec.Domain.CPU.NUMA != nil && vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough != nil {
+				if err := formatDomainNUMAMapping(vmi, domain, c); err != nil {
+					log.Log.Reason(err).Error("failed to format guest NUMA mapping.")
+					return err
+				}
+			}
 			if useIOThreads {
 				if err := formatDomainIOThreadPin(vmi, domain, c); err != nil {
 					log.Log.Reason(err).Error("failed to format domain iothread pinning.")
@@ -1507,15 +1720,25 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 
 			// if UseEmulation unset and at least one NIC model is virtio,
 			// /dev/vhost-net must be present as we should have asked for it.
-			var virtioNetMQRequested bool
-			if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq != nil {
-				virtioNetMQRequested = *mq
-			}
 			if ifaceType == "virtio" && virtioNetProhibited {
 				return fmt.Errorf("In-kernel virtio-net device emulation '/dev/vhost-net' not present")
-			} else if ifaceType == "virtio" && virtioNetMQRequested {
-				queueCount := uint(CalculateNetworkQueues(vmi))
+			} else if queues := CalculateInterfaceQueues(vmi, &iface); queues > 0 {
+				queueCount := uint(queues)
 				domainIface.Driver = &InterfaceDriver{Name: "vhost", Queues: &queueCount}
+				if iface.RSS != nil {
+					domainIface.Driver.RSS = "on"
+					if iface.RSS.HashReport != nil {
+						domainIface.Driver.RSSHashReport = boolToOnOff(iface.RSS.HashReport, false)
+					}
+				}
+			}
+			if ifaceType == "virtio" {
+				if err := setInterfaceDriverTuning(&domainIface, &iface, vhostNetAvailable); err != nil {
+					return err
+				}
+			}
+			if ifaceType == "virtio" && iface.LatencyProfile == v1.InterfaceLatencyProfileLowLatency && (iface.Bridge != nil || iface.Masquerade != nil) {
+				setInterfaceBusyPolling(domain, &iface)
 			}
 
 			// Add a pciAddress if specified
@@ -1678,17 +1901,34 @@ func calculateRequestedVCPUs(cpuTopology *CPUTopology) uint32 {
 	return cpuTopology.Cores * cpuTopology.Sockets * cpuTopology.Threads
 }
 
+// CalculateNetworkQueues returns the number of queues of the virtio interfaces
+// of a VMI with networkInterfaceMultiqueue: the requested number or the number
+// of vCPUs, capped at the maximum number of queues of a tap device.
 func CalculateNetworkQueues(vmi *v1.VirtualMachineInstance) uint32 {
-	cpuTopology := getCPUTopology(vmi)
-	queueNumber := calculateRequestedVCPUs(cpuTopology)
+	queueNumber := calculateRequestedVCPUs(getCPUTopology(vmi))
+	if queues := vmi.Spec.Domain.Devices.NetworkInterfaceQueues; queues != nil {
+		queueNumber = *queues
+	}
 
-	if queueNumber > multiQueueMaxQueues {
-		log.Log.V(3).Infof("Capped the number of queues to be the current maximum of tap device queues: %d", multiQueueMaxQueues)
-		queueNumber = multiQueueMaxQueues
+	if queueNumber > v1.MaxNetworkInterfaceQueues {
+		log.Log.Object(vmi).Infof("Capped the number of queues of the network interfaces from %d to the maximum of tap device queues: %d", queueNumber, v1.MaxNetworkInterfaceQueues)
+		queueNumber = v1.MaxNetworkInterfaceQueues
 	}
 	return queueNumber
 }
 
+// CalculateInterfaceQueues returns the number of queues of an interface, or 0
+// if it has a single queue. Only virtio interfaces support multiple queues.
+func CalculateInterfaceQueues(vmi *v1.VirtualMachineInstance, iface *v1.Interface) uint32 {
+	if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq == nil || !*mq {
+		return 0
+	}
+	if iface.Slirp != nil || getInterfaceType(iface) != "virtio" {
+		return 0
+	}
+	return CalculateNetworkQueues(vmi)
+}
+
 func formatDomainCPUTune(vmi *v1.VirtualMachineInstance, domain *Domain, c *ConverterContext) error {
 	if len(c.CPUSet) == 0 {
 		return fmt.Errorf("failed for get pods pinned cpus")
@@ -1705,6 +1945,112 @@ func formatDomainCPUTune(vmi *v1.VirtualMachineInstance, domain *Domain, c *Conv
 	return nil
 }
 
+// checkExclusiveCPUSet verifies that the pod cpuset left after reserving the
+// emulator thread pCPU holds exactly the vCPUs. The static policy of the CPU
+// manager allocates exclusive pCPUs matching the pod request; any other policy
+// leaves the pod on the shared pool, where pinning the emulator thread and the
+// IOThreads would not keep them off the pCPUs of the vCPUs.
+func checkExclusiveCPUSet(domain *Domain, c *ConverterContext) error {
+	vcpus := int(calculateRequestedVCPUs(domain.Spec.CPU.Topology))
+	if len(c.CPUSet) != vcpus {
+		return fmt.Errorf("the pod cpuset has %d CPUs besides the emulator thread CPU instead of %d, the CPU manager of the node is not running with the static policy", len(c.CPUSet), vcpus)
+	}
+	return nil
+}
+
+// formatDomainNUMAMapping creates a guest NUMA cell for every host NUMA node
+// of the pinned pCPUs and binds the memory of the cell to that host node, so
+// the hugepages backing a vCPU come from the host node it runs on
+func formatDomainNUMAMapping(vmi *v1.VirtualMachineInstance, domain *Domain, c *ConverterContext) error {
+	if vmi.Spec.Domain.Memory == nil || vmi.Spec.Domain.Memory.Hugepages == nil {
+		return fmt.Errorf("guest NUMA mapping requires hugepages")
+	}
+	pageSize, err := resource.ParseQuantity(vmi.Spec.Domain.Memory.Hugepages.PageSize)
+	if err != nil {
+		return fmt.Errorf("failed to parse the hugepages size: %v", err)
+	}
+
+	cpuNodes := map[int]int{}
+	for _, node := range c.NUMANodes {
+		for _, cpu := range node.CPUs {
+			cpuNodes[cpu] = node.ID
+		}
+	}
+
+	vcpus := int(calculateRequestedVCPUs(domain.Spec.CPU.Topology))
+	if len(c.CPUSet) < vcpus {
+		return fmt.Errorf("%d vCPUs requested but only %d pCPUs pinned", vcpus, len(c.CPUSet))
+	}
+	var hostNodes []int
+	nodeVCPUs := map[int][]string{}
+	for vcpu := 0; vcpu < vcpus; vcpu++ {
+		node, exists := cpuNodes[c.CPUSet[vcpu]]
+		if !exists {
+			return fmt.Errorf("no host NUMA node found for pCPU %d", c.CPUSet[vcpu])
+		}
+		if _, seen := nodeVCPUs[node]; !seen {
+			hostNodes = append(hostNodes, node)
+		}
+		nodeVCPUs[node] = append(nodeVCPUs[node], strconv.Itoa(vcpu))
+	}
+
+	// the hugepages are split between the cells by their number of vCPUs
+	pages := getVirtualMemory(vmi).Value() / pageSize.Value()
+	numa := &NUMA{}
+	numaTune := &NUMATune{Memory: NumaTuneMemory{Mode: "strict"}}
+	var nodeSet []string
+	assignedPages := int64(0)
+	for cell, node := range hostNodes {
+		cellPages := pages * int64(len(nodeVCPUs[node])) / int64(vcpus)
+		if cell == len(hostNodes)-1 {
+			cellPages = pages - assignedPages
+		}
+		if cellPages == 0 {
+			return fmt.Errorf("not enough memory to back %d guest NUMA nodes with %s hugepages", len(hostNodes), pageSize.String())
+		}
+		assignedPages += cellPages
+
+		numa.Cells = append(numa.Cells, NUMACell{
+			ID:     strconv.Itoa(cell),
+			CPUs:   strings.Join(nodeVCPUs[node], ","),
+			Memory: strconv.FormatInt(cellPages*pageSize.Value()/1024, 10),
+			Unit:   "KiB",
+		})
+		numaTune.MemNodes = append(numaTune.MemNodes, MemNode{
+			CellID:  uint32(cell),
+			Mode:    "strict",
+			NodeSet: strconv.Itoa(node),
+		})
+		nodeSet = append(nodeSet, strconv.Itoa(node))
+	}
+	numaTune.Memory.NodeSet = strings.Join(nodeSet, ",")
+
+	domain.Spec.CPU.NUMA = numa
+	domain.Spec.NUMATune = numaTune
+	return nil
+}
+
+// appendNestedVirtualizationFeatures exposes the virtualization extensions
+// of Intel and AMD CPUs. They are optional, since only the ones of the host
+// vendor are available. Features configured explicitly are kept.
+func appendNestedVirtualizationFeatures(domain *Domain) {
+	for _, name := range []string{"vmx", "svm"} {
+		configured := false
+		for _, feature := range domain.Spec.CPU.Features {
+			if feature.Name == name {
+				configured = true
+				break
+			}
+		}
+		if !configured {
+			domain.Spec.CPU.Features = append(domain.Spec.CPU.Features, CPUFeature{
+				Name:   name,
+				Policy: "optional",
+			})
+		}
+	}
+}
+
 func appendDomainEmulatorThreadPin(domain *Domain, allocatedCpu int) {
 	emulatorThread := CPUEmulatorPin{
 		CPUSet: strconv.Itoa(allocatedCpu),
@@ -1724,9 +2070,12 @@ func formatDomainIOThreadPin(vmi *v1.VirtualMachineInstance, domain *Domain, c *
 	vcpus := int(calculateRequestedVCPUs(domain.Spec.CPU.Topology))
 
 	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread {
-		// pin the IOThread on the same pCPU as the emulator thread
+		// pin the IOThreads on the same pCPU as the emulator thread, keeping
+		// them off the pCPUs of the vCPUs
 		cpuset := fmt.Sprintf("%d", *c.EmulatorThreadCpu)
-		appendDomainIOThreadPin(domain, uint(1), cpuset)
+		for thread := 1; thread <= iothreads; thread++ {
+			appendDomainIOThreadPin(domain, uint(thread), cpuset)
+		}
 	} else if iothreads >= vcpus {
 		// pin an IOThread on a CPU
 		for thread := 1; thread <= iothreads; thread++ {
@@ -2088,6 +2437,19 @@ func needsSCSIControler(vmi *v1.VirtualMachineInstance) bool {
 	return !vmi.Spec.Domain.Devices.DisableHotplug
 }
 
+// diskTagOEMStrings maps the tag of every disk to its serial, which is how the
+// guest finds the disk, e.g. in /dev/disk/by-id. Tagged disks without a serial
+// are only published in the devices metadata of the config drive.
+func diskTagOEMStrings(disks []v1.Disk) []string {
+	var oemStrings []string
+	for _, disk := range disks {
+		if disk.Tag != "" && disk.Serial != "" {
+			oemStrings = append(oemStrings, fmt.Sprintf("%s%s=%s", DiskTagOEMStringPrefix, disk.Tag, disk.Serial))
+		}
+	}
+	return oemStrings
+}
+
 func getPrefixFromBus(bus string) string {
 	switch bus {
 	case "virtio":
@@ -34,7 +34,9 @@ import (
 	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 )
 
 var _ = Describe("Converter", func() {
@@ -129,6 +131,76 @@ var _ = Describe("Converter", func() {
 			Expect(xml).To(Equal(convertedDisk))
 		})
 
+		It("should back the qcow2 overlay of a containerDisk by the container disk", func() {
+			disk := &Disk{Driver: &DiskDriver{}}
+			c := &ConverterContext{DiskType: map[string]*containerdisk.DiskInfo{"mydisk": {Format: "raw"}}}
+			Expect(Convert_v1_ContainerDiskSource_To_api_Disk("mydisk", &v1.ContainerDiskSource{}, disk, c, 0)).To(Succeed())
+			Expect(disk.Driver.Type).To(Equal("qcow2"))
+			Expect(disk.Source.File).To(HaveSuffix("/mydisk/disk.qcow2"))
+			Expect(disk.BackingStore).ToNot(BeNil())
+			Expect(disk.BackingStore.Format.Type).To(Equal("raw"))
+		})
+
+		table.DescribeTable("should not back converted containerDisk images", func(image *v1.EphemeralImage, format string) {
+			disk := &Disk{Driver: &DiskDriver{}}
+			c := &ConverterContext{DiskType: map[string]*containerdisk.DiskInfo{"mydisk": {Format: "raw"}}}
+			Expect(Convert_v1_ContainerDiskSource_To_api_Disk("mydisk", &v1.ContainerDiskSource{EphemeralImage: image}, disk, c, 0)).To(Succeed())
+			Expect(disk.Driver.Type).To(Equal(format))
+			Expect(disk.Source.File).To(HaveSuffix("/mydisk/disk." + format))
+			Expect(disk.BackingStore).To(BeNil())
+		},
+			table.Entry("with the raw format", &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw}, "raw"),
+			table.Entry("with compression", &v1.EphemeralImage{Compressed: true}, "qcow2"),
+		)
+
+		It("should not back a converted ephemeral image", func() {
+			disk := &Disk{Driver: &DiskDriver{}}
+			source := &v1.EphemeralVolumeSource{EphemeralImage: &v1.EphemeralImage{Format: v1.EphemeralImageFormatRaw}}
+			Expect(Convert_v1_EphemeralVolumeSource_To_api_Disk("mydisk", source, disk, &ConverterContext{})).To(Succeed())
+			Expect(disk.Driver.Type).To(Equal("raw"))
+			Expect(disk.Source.File).To(HaveSuffix("/mydisk/disk.raw"))
+			Expect(disk.BackingStore).To(BeNil())
+		})
+
+		It("should connect a vhost-user-blk disk to the socket in the volume", func() {
+			kubevirtDisk := &v1.Disk{
+				Name:  "spdk",
+				Cache: v1.CacheNone,
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "virtio"},
+				},
+			}
+			disk := &Disk{}
+			Expect(Convert_v1_Disk_To_api_Disk(kubevirtDisk, disk, map[string]deviceNamer{}, nil)).To(Succeed())
+			source := &v1.VhostUserBlkSource{ClaimName: "spdk-claim", Socket: "vhost.0"}
+			Expect(Convert_v1_VhostUserBlkSource_To_api_Disk("spdk", source, disk, &ConverterContext{})).To(Succeed())
+			Expect(SetDriverCacheMode(disk)).To(Succeed())
+
+			data, err := xml.MarshalIndent(disk, "", "  ")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`<Disk device="disk" snapshot="no" type="vhostuser">
+  <source type="unix" path="/var/run/kubevirt-private/vmi-disks/spdk/vhost.0">
+    <reconnect enabled="yes" timeout="10"></reconnect>
+  </source>
+  <target bus="virtio" dev="vda"></target>
+  <driver name="qemu" type="raw"></driver>
+  <alias name="ua-spdk"></alias>
+</Disk>`))
+		})
+
+		It("should reject vhost-user-blk disks on other buses than virtio", func() {
+			kubevirtDisk := &v1.Disk{
+				Name: "spdk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "sata"},
+				},
+			}
+			disk := &Disk{}
+			Expect(Convert_v1_Disk_To_api_Disk(kubevirtDisk, disk, map[string]deviceNamer{}, nil)).To(Succeed())
+			source := &v1.VhostUserBlkSource{ClaimName: "spdk-claim", Socket: "vhost.0"}
+			Expect(Convert_v1_VhostUserBlkSource_To_api_Disk("spdk", source, disk, &ConverterContext{})).ToNot(Succeed())
+		})
+
 	})
 
 	Context("with v1.VirtualMachineInstance", func() {
@@ -1146,6 +1218,27 @@ var _ = Describe("Converter", func() {
 				Expect(domainSpec.VCPU.CPUs).To(Equal(uint32(12)), "Expect vcpus")
 			})
 
+			table.DescribeTable("should expose the virtualization extensions for nested virtualization", func(model string, expected []CPUFeature) {
+				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Model:                model,
+					NestedVirtualization: true,
+					Features: []v1.CPUFeature{
+						{
+							Name:   "svm",
+							Policy: "disable",
+						},
+					},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+
+				Expect(domainSpec.CPU.Features).To(Equal(expected))
+			},
+				table.Entry("with the default model", "", []CPUFeature{{Name: "svm", Policy: "disable"}, {Name: "vmx", Policy: "optional"}}),
+				table.Entry("with a custom model", "Conroe", []CPUFeature{{Name: "svm", Policy: "disable"}, {Name: "vmx", Policy: "optional"}}),
+				table.Entry("with host-passthrough", v1.CPUModeHostPassthrough, []CPUFeature{{Name: "svm", Policy: "disable"}}),
+			)
+
 			It("should convert CPU cores", func() {
 				v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 				vmi.Spec.Domain.CPU = &v1.CPU{
@@ -1287,6 +1380,67 @@ var _ = Describe("Converter", func() {
 			Expect(*domain.Spec.Devices.Disks[0].Address).To(Equal(test_address))
 		})
 
+		It("should set the disk serial and WWN when specified", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "scsi"
+			vmi.Spec.Domain.Devices.Disks[0].Serial = "D23YZ9W6WA5DJ487"
+			vmi.Spec.Domain.Devices.Disks[0].WWN = "5000c500a1b2c3d4"
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Disks[0].Serial).To(Equal("D23YZ9W6WA5DJ487"))
+			Expect(domain.Spec.Devices.Disks[0].WWN).To(Equal("5000c500a1b2c3d4"))
+			Expect(domain.Spec.Devices.Disks[1].WWN).To(BeEmpty())
+		})
+
+		It("should set the emulator when one is selected", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Emulator).To(BeEmpty())
+
+			c.Emulator = "/usr/libexec/kubevirt/emulators/qemu-6.0/qemu-kvm"
+			domain = vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Emulator).To(Equal("/usr/libexec/kubevirt/emulators/qemu-6.0/qemu-kvm"))
+		})
+
+		It("should add LUKS encryption to disks of encrypted volumes", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.UID = "f4686d2c-6e8d-4335-b8fd-81bee22f4814"
+			vmi.Spec.Volumes[0].Encryption = &v1.VolumeEncryption{
+				SecretRef: &k8sv1.LocalObjectReference{Name: "luks-secret"},
+			}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Disks[0].Encryption).To(Equal(&DiskEncryption{
+				Format: "luks",
+				Secret: &DiskSecret{
+					Type: "passphrase",
+					UUID: DiskEncryptionSecretUUID(vmi, "myvolume"),
+				},
+			}))
+			Expect(domain.Spec.Devices.Disks[1].Encryption).To(BeNil())
+		})
+
+		It("should publish the tags of disks with a serial as OEM strings", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks[0].Tag = "database"
+			vmi.Spec.Domain.Devices.Disks[0].Serial = "D23YZ9W6WA5DJ487"
+			vmi.Spec.Domain.Devices.Disks[1].Tag = "logs"
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.SysInfo.OEMStrings).ToNot(BeNil())
+			Expect(domain.Spec.SysInfo.OEMStrings.Entries).To(ConsistOf(DiskTagOEMStringPrefix + "database=D23YZ9W6WA5DJ487"))
+		})
+
+		It("should not publish OEM strings without tagged disks", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.SysInfo.OEMStrings).To(BeNil())
+		})
+
+		It("should derive distinct disk encryption secret UUIDs per volume", func() {
+			vmi.UID = "f4686d2c-6e8d-4335-b8fd-81bee22f4814"
+			first := DiskEncryptionSecretUUID(vmi, "myvolume")
+			Expect(first).To(Equal(DiskEncryptionSecretUUID(vmi, "myvolume")))
+			Expect(first).ToNot(Equal(DiskEncryptionSecretUUID(vmi, "othervolume")))
+		})
+
 		It("should fail disk config pci address is set with a non virtio bus", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Disks[0].Disk.PciAddress = "0000:81:01.0"
@@ -1352,12 +1506,40 @@ var _ = Describe("Converter", func() {
 			Expect(Convert_v1_VirtualMachine_To_api_Domain(vmi, &Domain{}, c)).ToNot(Succeed(), "Expect error")
 		})
 
-		It("should fail when input device is set to keyboard type", func() {
+		It("should fail when input device is set to mouse type", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Inputs[0].Type = "mouse"
+			Expect(Convert_v1_VirtualMachine_To_api_Domain(vmi, &Domain{}, c)).ToNot(Succeed(), "Expect error")
+		})
+
+		It("should convert a virtio keyboard with a model", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Inputs[0] = v1.Input{Name: "keyboard0", Type: "keyboard", Bus: "virtio", Model: "virtio-non-transitional"}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Inputs[0].Type).To(Equal("keyboard"))
+			Expect(domain.Spec.Devices.Inputs[0].Bus).To(Equal("virtio"))
+			Expect(domain.Spec.Devices.Inputs[0].Model).To(Equal("virtio-non-transitional"))
+		})
+
+		It("should fail when an input device on the usb bus has a model", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
-			vmi.Spec.Domain.Devices.Inputs[0].Type = "keyboard"
+			vmi.Spec.Domain.Devices.Inputs[0].Bus = "usb"
+			vmi.Spec.Domain.Devices.Inputs[0].Model = "virtio"
 			Expect(Convert_v1_VirtualMachine_To_api_Domain(vmi, &Domain{}, c)).ToNot(Succeed(), "Expect error")
 		})
 
+		It("should use the requested usb controller model", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Inputs[0].Bus = "usb"
+			vmi.Spec.Domain.Devices.USBControllerModel = "nec-xhci"
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(Controller{
+				Type:  "usb",
+				Index: "0",
+				Model: "nec-xhci",
+			}))
+		})
+
 		It("should succeed when input device is set to usb bus", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Inputs[0].Bus = "usb"
@@ -1490,6 +1672,34 @@ var _ = Describe("Converter", func() {
 			Expect(domainSpec.Memory.Unit).To(Equal("b"))
 		})
 
+		It("should share the memory with the storage backend of vhost-user-blk disks", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			policy := v1.IOThreadsPolicyShared
+			vmi.Spec.Domain.IOThreadsPolicy = &policy
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "spdk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "virtio"},
+				},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "spdk",
+				VolumeSource: v1.VolumeSource{
+					VhostUserBlk: &v1.VhostUserBlkSource{ClaimName: "spdk-claim", Socket: "vhost.0"},
+				},
+			})
+
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.MemoryBacking.Access.Mode).To(Equal("shared"))
+			Expect(domainSpec.MemoryBacking.Source.Type).To(Equal("memfd"))
+			Expect(domainSpec.CPU.NUMA.Cells).To(HaveLen(1))
+
+			disk := domainSpec.Devices.Disks[len(domainSpec.Devices.Disks)-1]
+			Expect(disk.Type).To(Equal("vhostuser"))
+			Expect(disk.Source.Path).To(Equal("/var/run/kubevirt-private/vmi-disks/spdk/vhost.0"))
+			Expect(disk.Driver.IOThread).To(BeNil())
+		})
+
 		It("should use guest memory instead of requested memory if present", func() {
 			guestMemory := resource.MustParse("123Mi")
 			vmi.Spec.Domain.Memory = &v1.Memory{
@@ -1933,6 +2143,43 @@ var _ = Describe("Converter", func() {
 		)
 	})
 
+	Context("channels", func() {
+
+		It("should add the channels after the guest agent channel", func() {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Devices: v1.Devices{
+							Channels: []v1.Channel{
+								{Name: "org.example.agent.0"},
+								{Name: "org.example.metadata.0"},
+							},
+						},
+					},
+				},
+			}
+			domain := vmiToDomain(&vmi, &ConverterContext{UseEmulation: true})
+			Expect(domain.Spec.Devices.Channels).To(Equal([]Channel{
+				Add_Agent_To_api_Channel(),
+				{
+					Type:   "unix",
+					Source: &ChannelSource{Mode: "bind", Path: "/var/run/kubevirt-private/1234/virt-channel0"},
+					Target: &ChannelTarget{Name: "org.example.agent.0", Type: "virtio"},
+				},
+				{
+					Type:   "unix",
+					Source: &ChannelSource{Mode: "bind", Path: "/var/run/kubevirt-private/1234/virt-channel1"},
+					Target: &ChannelTarget{Name: "org.example.metadata.0", Type: "virtio"},
+				},
+			}))
+		})
+	})
+
 	Context("IOThreads", func() {
 		_false := false
 		_true := true
@@ -2261,6 +2508,110 @@ var _ = Describe("Converter", func() {
 			isExpectedThreadsLayout := reflect.DeepEqual(expectedLayout, domain.Spec.CPUTune.IOThreadPin)
 			Expect(isExpectedThreadsLayout).To(BeTrue())
 		})
+		It("should pin all iothreads on the emulator thread pcpu, if the emulator thread is isolated", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			vmi.Spec.Domain.CPU.IsolateEmulatorThread = true
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			emulatorThreadCpu := 7
+			c := &ConverterContext{CPUSet: []int{5, 6}, EmulatorThreadCpu: &emulatorThreadCpu, UseEmulation: true}
+			domain := vmiToDomain(vmi, c)
+			domain.Spec.IOThreads = &IOThreads{}
+			domain.Spec.IOThreads.IOThreads = uint(3)
+
+			err := formatDomainIOThreadPin(vmi, domain, c)
+			Expect(err).ToNot(HaveOccurred())
+			expectedLayout := []CPUTuneIOThreadPin{
+				CPUTuneIOThreadPin{IOThread: 1, CPUSet: "7"},
+				CPUTuneIOThreadPin{IOThread: 2, CPUSet: "7"},
+				CPUTuneIOThreadPin{IOThread: 3, CPUSet: "7"},
+			}
+			isExpectedThreadsLayout := reflect.DeepEqual(expectedLayout, domain.Spec.CPUTune.IOThreadPin)
+			Expect(isExpectedThreadsLayout).To(BeTrue())
+			Expect(domain.Spec.CPUTune.EmulatorPin).ToNot(BeNil())
+			Expect(domain.Spec.CPUTune.EmulatorPin.CPUSet).To(Equal("7"))
+		})
+		It("should refuse to isolate the emulator thread, if the pod cpuset is not exclusive to the vCPUs", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			vmi.Spec.Domain.CPU.IsolateEmulatorThread = true
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			emulatorThreadCpu := 7
+			c := &ConverterContext{CPUSet: []int{0, 1, 2, 3, 4, 5, 6}, EmulatorThreadCpu: &emulatorThreadCpu, UseEmulation: true}
+			Expect(Convert_v1_VirtualMachine_To_api_Domain(vmi, &Domain{}, c)).ToNot(Succeed())
+		})
+	})
+	Context("guest NUMA mapping passthrough", func() {
+		var vmi *v1.VirtualMachineInstance
+		var numaNodes []hardware.NUMANode
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{
+							DedicatedCPUPlacement: true,
+							NUMA:                  &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}},
+						},
+						Memory: &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}},
+						Resources: v1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{
+								k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+						},
+					},
+				},
+			}
+			numaNodes = []hardware.NUMANode{
+				{ID: 0, CPUs: []int{0, 1, 2, 3}},
+				{ID: 1, CPUs: []int{4, 5, 6, 7}},
+			}
+		})
+
+		It("should create a guest NUMA cell per host NUMA node of the pinned pCPUs", func() {
+			vmi.Spec.Domain.CPU.Cores = 4
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c := &ConverterContext{CPUSet: []int{1, 2, 5, 6}, NUMANodes: numaNodes, UseEmulation: true}
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]NUMACell{
+				{ID: "0", CPUs: "0,1", Memory: "32768", Unit: "KiB"},
+				{ID: "1", CPUs: "2,3", Memory: "32768", Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune).To(Equal(&NUMATune{
+				Memory: NumaTuneMemory{Mode: "strict", NodeSet: "0,1"},
+				MemNodes: []MemNode{
+					{CellID: 0, Mode: "strict", NodeSet: "0"},
+					{CellID: 1, Mode: "strict", NodeSet: "1"},
+				},
+			}))
+		})
+
+		It("should split the hugepages between the cells by their vCPUs", func() {
+			vmi.Spec.Domain.CPU.Cores = 3
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c := &ConverterContext{CPUSet: []int{5, 1, 6}, NUMANodes: numaNodes, UseEmulation: true}
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.CPU.NUMA.Cells).To(Equal([]NUMACell{
+				{ID: "0", CPUs: "0,2", Memory: "43008", Unit: "KiB"},
+				{ID: "1", CPUs: "1", Memory: "22528", Unit: "KiB"},
+			}))
+			Expect(domain.Spec.NUMATune.Memory.NodeSet).To(Equal("1,0"))
+		})
+
+		It("should fail if a pinned pCPU is on no host NUMA node", func() {
+			vmi.Spec.Domain.CPU.Cores = 2
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c := &ConverterContext{CPUSet: []int{1, 9}, NUMANodes: numaNodes, UseEmulation: true}
+			domain := &Domain{}
+
+			err := Convert_v1_VirtualMachine_To_api_Domain(vmi, domain, c)
+			Expect(err).To(MatchError("no host NUMA node found for pCPU 9"))
+		})
 	})
 	Context("virtio-net multi-queue", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -2320,10 +2671,160 @@ var _ = Describe("Converter", func() {
 				Threads: 2,
 			}
 			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
-			expectedNumberQueues := uint(multiQueueMaxQueues)
+			expectedNumberQueues := uint(v1.MaxNetworkInterfaceQueues)
 			Expect(*(domain.Spec.Devices.Interfaces[0].Driver.Queues)).To(Equal(expectedNumberQueues),
 				"should be capped to the maximum number of queues on tap devices")
 		})
+
+		It("should assign the requested number of queues", func() {
+			var expectedQueues uint = 2
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 8}
+			queues := uint32(2)
+			vmi.Spec.Domain.Devices.NetworkInterfaceQueues = &queues
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true})
+			Expect(*(domain.Spec.Devices.Interfaces[0].Driver.Queues)).To(Equal(expectedQueues))
+		})
+
+		It("should only assign queues to the virtio devices", func() {
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+			vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+			virtioIface := v1.DefaultBridgeNetworkInterface()
+			virtioIface.Name = "red"
+			vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, *virtioIface)
+			Expect(CalculateInterfaceQueues(vmi, &vmi.Spec.Domain.Devices.Interfaces[0])).To(BeZero())
+			Expect(CalculateInterfaceQueues(vmi, &vmi.Spec.Domain.Devices.Interfaces[1])).To(Equal(uint32(2)))
+		})
+	})
+
+	Context("interface tuning", func() {
+		var vmi *v1.VirtualMachineInstance
+		var rxQueueSize, txQueueSize uint32 = 1024, 512
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "mynamespace",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		})
+
+		It("should not add a driver if no tuning is requested", func() {
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+		})
+
+		It("should set the ring sizes of virtio interfaces", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].RxQueueSize = &rxQueueSize
+			vmi.Spec.Domain.Devices.Interfaces[0].TxQueueSize = &txQueueSize
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(driver).ToNot(BeNil())
+			Expect(driver.Name).To(Equal("vhost"))
+			Expect(*driver.RxQueueSize).To(Equal(uint(1024)))
+			Expect(*driver.TxQueueSize).To(Equal(uint(512)))
+			Expect(driver.Host).To(BeNil())
+		})
+
+		It("should not set the ring sizes of non virtio interfaces", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+			vmi.Spec.Domain.Devices.Interfaces[0].RxQueueSize = &rxQueueSize
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+		})
+
+		It("should keep the queues when setting the ring sizes", func() {
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = True()
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+			vmi.Spec.Domain.Devices.Interfaces[0].RxQueueSize = &rxQueueSize
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(*driver.Queues).To(Equal(uint(2)))
+			Expect(*driver.RxQueueSize).To(Equal(uint(1024)))
+		})
+
+		It("should enable receive side scaling on multiqueue interfaces", func() {
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = True()
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 4}
+			vmi.Spec.Domain.Devices.Interfaces[0].RSS = &v1.InterfaceRSS{HashReport: True()}
+			Expect(vmiToDomainXML(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})).To(ContainSubstring(`<driver name="vhost" queues="4" rss="on" rss_hash_report="on"></driver>`))
+		})
+
+		It("should leave the hash report to the hypervisor if it is not set", func() {
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = True()
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+			vmi.Spec.Domain.Devices.Interfaces[0].RSS = &v1.InterfaceRSS{}
+			driver := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true}).Spec.Devices.Interfaces[0].Driver
+			Expect(driver.RSS).To(Equal("on"))
+			Expect(driver.RSSHashReport).To(BeEmpty())
+		})
+
+		It("should not enable receive side scaling without multiqueue", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].RSS = &v1.InterfaceRSS{HashReport: True()}
+			Expect(vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true}).Spec.Devices.Interfaces[0].Driver).To(BeNil())
+		})
+
+		It("should render the host offloads", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].Offloads = &v1.InterfaceOffloads{TSO: False(), GSO: True(), GRO: False()}
+			Expect(vmiToDomainXML(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})).To(ContainSubstring(`<driver name="vhost">
+        <host tso4="off" tso6="off" gso="on"></host>
+      </driver>`))
+		})
+
+		It("should not add a driver for offloads which are not negotiated by qemu", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].Offloads = &v1.InterfaceOffloads{GRO: False(), TXChecksum: True()}
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+		})
+
+		It("should busy poll the tap device of low-latency interfaces", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].LatencyProfile = v1.InterfaceLatencyProfileLowLatency
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			Expect(domain.Spec.QEMUCmd).ToNot(BeNil())
+			Expect(domain.Spec.QEMUCmd.QEMUArg).To(Equal([]Arg{
+				{Value: "-set"},
+				{Value: "netdev.hostua-default.poll-us=50"},
+			}))
+		})
+
+		It("should not busy poll the tap device of non virtio low-latency interfaces", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+			vmi.Spec.Domain.Devices.Interfaces[0].LatencyProfile = v1.InterfaceLatencyProfileLowLatency
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			Expect(domain.Spec.QEMUCmd).To(BeNil())
+		})
+
+		It("should render packed virtqueues", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].PackedRing = True()
+			Expect(vmiToDomainXML(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})).To(ContainSubstring(`<driver name="vhost" packed="on"></driver>`))
+		})
+
+		It("should serve interfaces requesting zero copy transmission with vhost", func() {
+			vmi.Spec.Domain.Devices.Interfaces[0].VhostZeroCopyTX = True()
+			domain := vmiToDomain(vmi, &ConverterContext{UseEmulation: true, RenderOnly: true})
+			driver := domain.Spec.Devices.Interfaces[0].Driver
+			Expect(driver).ToNot(BeNil())
+			Expect(driver.Name).To(Equal("vhost"))
+			Expect(driver.Packed).To(BeEmpty())
+		})
+		It("should apply the tuning to the userland driver without /dev/vhost-net", func() {
+			iface := &vmi.Spec.Domain.Devices.Interfaces[0]
+			iface.RxQueueSize = &rxQueueSize
+			domainIface := &Interface{}
+			Expect(setInterfaceDriverTuning(domainIface, iface, false)).To(Succeed())
+			Expect(domainIface.Driver.Name).To(BeEmpty())
+			Expect(*domainIface.Driver.RxQueueSize).To(Equal(uint(1024)))
+		})
+
+		It("should refuse zero copy transmission without /dev/vhost-net", func() {
+			iface := &vmi.Spec.Domain.Devices.Interfaces[0]
+			iface.VhostZeroCopyTX = True()
+			Expect(setInterfaceDriverTuning(&Interface{}, iface, false)).ToNot(Succeed())
+		})
+
 	})
 
 	Context("sriov", func() {
@@ -2478,6 +2979,33 @@ var _ = Describe("Converter", func() {
 				Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/tmp/mynamespace_testvmi"))
 			})
 		})
+
+		Context("when the boot menu is set", func() {
+			It("should not configure a boot menu by default", func() {
+				vmi.Spec.Domain.Firmware = &v1.Firmware{}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.OS.BootMenu).To(BeNil())
+			})
+
+			It("should enable the boot menu with the timeout", func() {
+				timeout := uint32(5000)
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					BootMenu: &v1.BootMenu{Timeout: &timeout},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.OS.BootMenu.Enabled).To(Equal("yes"))
+				Expect(*domainSpec.OS.BootMenu.Timeout).To(Equal(uint(5000)))
+			})
+
+			It("should disable the boot menu", func() {
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					BootMenu: &v1.BootMenu{Enabled: False()},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.OS.BootMenu.Enabled).To(Equal("no"))
+				Expect(domainSpec.OS.BootMenu.Timeout).To(BeNil())
+			})
+		})
 	})
 
 	Context("Legacy GPU resource request", func() {
@@ -731,6 +731,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(DiskAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(DiskEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Alias != nil {
 		in, out := &in.Alias, &out.Alias
 		*out = new(Alias)
@@ -811,6 +816,27 @@ func (in *DiskDriver) DeepCopy() *DiskDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskEncryption) DeepCopyInto(out *DiskEncryption) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(DiskSecret)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryption.
+func (in *DiskEncryption) DeepCopy() *DiskEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSecret) DeepCopyInto(out *DiskSecret) {
 	*out = *in
@@ -835,6 +861,11 @@ func (in *DiskSource) DeepCopyInto(out *DiskSource) {
 		*out = new(DiskSourceHost)
 		**out = **in
 	}
+	if in.Reconnect != nil {
+		in, out := &in.Reconnect, &out.Reconnect
+		*out = new(DiskSourceReconnect)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -864,6 +895,27 @@ func (in *DiskSourceHost) DeepCopy() *DiskSourceHost {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskSourceReconnect) DeepCopyInto(out *DiskSourceReconnect) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskSourceReconnect.
+func (in *DiskSourceReconnect) DeepCopy() *DiskSourceReconnect {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskSourceReconnect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskTarget) DeepCopyInto(out *DiskTarget) {
 	*out = *in
@@ -923,6 +975,11 @@ func (in *DomainGuestInfo) DeepCopyInto(out *DomainGuestInfo) {
 		*out = new(GuestOSInfo)
 		**out = **in
 	}
+	if in.FSFreezeStatus != nil {
+		in, out := &in.FSFreezeStatus, &out.FSFreezeStatus
+		*out = new(FSFreeze)
+		**out = **in
+	}
 	return
 }
 
@@ -1018,6 +1075,11 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(CPUTune)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NUMATune != nil {
+		in, out := &in.NUMATune, &out.NUMATune
+		*out = new(NUMATune)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IOThreads != nil {
 		in, out := &in.IOThreads, &out.IOThreads
 		*out = new(IOThreads)
@@ -1047,6 +1109,7 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 		}
 	}
 	out.OSInfo = in.OSInfo
+	out.FSFreezeStatus = in.FSFreezeStatus
 	return
 }
 
@@ -1254,6 +1317,22 @@ func (in *FeatureSpinlocks) DeepCopy() *FeatureSpinlocks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FSFreeze) DeepCopyInto(out *FSFreeze) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FSFreeze.
+func (in *FSFreeze) DeepCopy() *FSFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(FSFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureState) DeepCopyInto(out *FeatureState) {
 	*out = *in
@@ -1817,6 +1896,21 @@ func (in *InterfaceDriver) DeepCopyInto(out *InterfaceDriver) {
 		*out = new(uint)
 		**out = **in
 	}
+	if in.RxQueueSize != nil {
+		in, out := &in.RxQueueSize, &out.RxQueueSize
+		*out = new(uint)
+		**out = **in
+	}
+	if in.TxQueueSize != nil {
+		in, out := &in.TxQueueSize, &out.TxQueueSize
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(InterfaceDriverHost)
+		**out = **in
+	}
 	return
 }
 
@@ -1830,6 +1924,22 @@ func (in *InterfaceDriver) DeepCopy() *InterfaceDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceDriverHost) DeepCopyInto(out *InterfaceDriverHost) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceDriverHost.
+func (in *InterfaceDriverHost) DeepCopy() *InterfaceDriverHost {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceDriverHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceSource) DeepCopyInto(out *InterfaceSource) {
 	*out = *in
@@ -1906,6 +2016,11 @@ func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
 		*out = new(AccessCredentialMetadata)
 		**out = **in
 	}
+	if in.TimeSync != nil {
+		in, out := &in.TimeSync, &out.TimeSync
+		*out = new(TimeSyncMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2009,6 +2124,22 @@ func (in *MemBalloon) DeepCopy() *MemBalloon {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemNode) DeepCopyInto(out *MemNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemNode.
+func (in *MemNode) DeepCopy() *MemNode {
+	if in == nil {
+		return nil
+	}
+	out := new(MemNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Memory) DeepCopyInto(out *Memory) {
 	*out = *in
@@ -2182,6 +2313,28 @@ func (in *NUMACell) DeepCopy() *NUMACell {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMATune) DeepCopyInto(out *NUMATune) {
+	*out = *in
+	out.Memory = in.Memory
+	if in.MemNodes != nil {
+		in, out := &in.MemNodes, &out.MemNodes
+		*out = make([]MemNode, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMATune.
+func (in *NUMATune) DeepCopy() *NUMATune {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMATune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NVRam) DeepCopyInto(out *NVRam) {
 	*out = *in
@@ -2198,6 +2351,59 @@ func (in *NVRam) DeepCopy() *NVRam {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceState) DeepCopyInto(out *NetworkInterfaceState) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceState.
+func (in *NetworkInterfaceState) DeepCopy() *NetworkInterfaceState {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NumaTuneMemory) DeepCopyInto(out *NumaTuneMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NumaTuneMemory.
+func (in *NumaTuneMemory) DeepCopy() *NumaTuneMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(NumaTuneMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OEMStrings) DeepCopyInto(out *OEMStrings) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OEMStrings.
+func (in *OEMStrings) DeepCopy() *OEMStrings {
+	if in == nil {
+		return nil
+	}
+	out := new(OEMStrings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OS) DeepCopyInto(out *OS) {
 	*out = *in
@@ -2524,6 +2730,11 @@ func (in *SysInfo) DeepCopyInto(out *SysInfo) {
 		*out = make([]Entry, len(*in))
 		copy(*out, *in)
 	}
+	if in.OEMStrings != nil {
+		in, out := &in.OEMStrings, &out.OEMStrings
+		*out = new(OEMStrings)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2537,6 +2748,31 @@ func (in *SysInfo) DeepCopy() *SysInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSyncMetadata) DeepCopyInto(out *TimeSyncMetadata) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.DriftMilliseconds != nil {
+		in, out := &in.DriftMilliseconds, &out.DriftMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSyncMetadata.
+func (in *TimeSyncMetadata) DeepCopy() *TimeSyncMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSyncMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timer) DeepCopyInto(out *Timer) {
 	*out = *in
@@ -3,9 +3,11 @@ package api
 const (
 	resolvConf        = "/etc/resolv.conf"
 	DefaultProtocol   = "TCP"
+	DefaultBridgeName = "k6t-eth0"
+	// DefaultVMCIDR and DefaultVMIpv6CIDR are only used for VMIs whose pod
+	// network was not defaulted by virt-api with the CIDRs of the cluster
 	DefaultVMCIDR     = "10.0.2.0/24"
 	DefaultVMIpv6CIDR = "fd10:0:2::/120"
-	DefaultBridgeName = "k6t-eth0"
 )
 
 func NewDefaulter(arch string) *Defaulter {
@@ -0,0 +1,45 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package api
+
+// ReadOnlyQMPCommands are the QMP commands which may be executed for debugging.
+// They only query the state of qemu and take no arguments.
+var ReadOnlyQMPCommands = []string{
+	"query-balloon",
+	"query-block",
+	"query-blockstats",
+	"query-chardev",
+	"query-cpus-fast",
+	"query-iothreads",
+	"query-kvm",
+	"query-migrate",
+	"query-status",
+	"query-version",
+}
+
+// IsReadOnlyQMPCommand returns whether the QMP command may be executed for debugging
+func IsReadOnlyQMPCommand(command string) bool {
+	for _, allowed := range ReadOnlyQMPCommands {
+		if command == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -93,6 +93,11 @@ const (
 	UserAliasPrefix = "ua-"
 )
 
+const (
+	FSFrozen = "frozen"
+	FSThawed = "thawed"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Domain struct {
 	metav1.TypeMeta
@@ -102,10 +107,11 @@ type Domain struct {
 }
 
 type DomainStatus struct {
-	Status     LifeCycle
-	Reason     StateChangeReason
-	Interfaces []InterfaceStatus
-	OSInfo     GuestOSInfo
+	Status         LifeCycle
+	Reason         StateChangeReason
+	Interfaces     []InterfaceStatus
+	OSInfo         GuestOSInfo
+	FSFreezeStatus FSFreeze
 }
 
 type DomainSysInfo struct {
@@ -133,6 +139,11 @@ type InterfaceStatus struct {
 	InterfaceName string
 }
 
+// FSFreeze reports whether the guest file systems are frozen
+type FSFreeze struct {
+	Status string
+}
+
 type Timezone struct {
 	Zone   string
 	Offset int
@@ -154,8 +165,24 @@ type User struct {
 
 // DomainGuestInfo represent guest agent info for specific domain
 type DomainGuestInfo struct {
-	Interfaces []InterfaceStatus
-	OSInfo     *GuestOSInfo
+	Interfaces     []InterfaceStatus
+	OSInfo         *GuestOSInfo
+	FSFreezeStatus *FSFreeze
+}
+
+// NetworkInterfaceState represents the state of a VMI network interface as seen
+// by virt-launcher after the second phase of the network setup
+type NetworkInterfaceState struct {
+	// Name is the name of the interface in the VMI spec
+	Name string `json:"name"`
+	// PodInterfaceName is the name of the pod interface backing the VMI interface
+	PodInterfaceName string `json:"podInterfaceName,omitempty"`
+	// Plugged is true once the interface was successfully prepared for the domain
+	Plugged bool `json:"plugged"`
+	// DHCPStarted is true once the DHCP server for the interface was started
+	DHCPStarted bool `json:"dhcpStarted"`
+	// Error holds the reason of the last failed attempt to plug the interface
+	Error string `json:"error,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -187,6 +214,7 @@ type DomainSpec struct {
 	CPU           CPU            `xml:"cpu"`
 	VCPU          *VCPU          `xml:"vcpu"`
 	CPUTune       *CPUTune       `xml:"cputune"`
+	NUMATune      *NUMATune      `xml:"numatune,omitempty"`
 	IOThreads     *IOThreads     `xml:"iothreads,omitempty"`
 }
 
@@ -210,6 +238,22 @@ type CPUEmulatorPin struct {
 	CPUSet string `xml:"cpuset,attr"`
 }
 
+type NUMATune struct {
+	Memory   NumaTuneMemory `xml:"memory"`
+	MemNodes []MemNode      `xml:"memnode"`
+}
+
+type NumaTuneMemory struct {
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
+}
+
+type MemNode struct {
+	CellID  uint32 `xml:"cellid,attr"`
+	Mode    string `xml:"mode,attr"`
+	NodeSet string `xml:"nodeset,attr"`
+}
+
 type VCPU struct {
 	Placement string `xml:"placement,attr"`
 	CPUs      uint32 `xml:",chardata"`
@@ -303,6 +347,7 @@ type KubeVirtMetadata struct {
 	GracePeriod      *GracePeriodMetadata      `xml:"graceperiod,omitempty"`
 	Migration        *MigrationMetadata        `xml:"migration,omitempty"`
 	AccessCredential *AccessCredentialMetadata `xml:"accessCredential,omitempty"`
+	TimeSync         *TimeSyncMetadata         `xml:"timeSync,omitempty"`
 }
 
 type AccessCredentialMetadata struct {
@@ -310,6 +355,13 @@ type AccessCredentialMetadata struct {
 	Message   string `xml:"message,omitempty"`
 }
 
+type TimeSyncMetadata struct {
+	Timestamp         *metav1.Time `xml:"timestamp,omitempty"`
+	Succeeded         bool         `xml:"succeeded,omitempty"`
+	DriftMilliseconds *int64       `xml:"driftMilliseconds,omitempty"`
+	Message           string       `xml:"message,omitempty"`
+}
+
 type MigrationMetadata struct {
 	UID            types.UID        `xml:"uid,omitempty"`
 	StartTimestamp *metav1.Time     `xml:"startTimestamp,omitempty"`
@@ -436,6 +488,7 @@ type FilesystemBinaryLock struct {
 type Input struct {
 	Type    string   `xml:"type,attr"`
 	Bus     string   `xml:"bus,attr"`
+	Model   string   `xml:"model,attr,omitempty"`
 	Alias   *Alias   `xml:"alias,omitempty"`
 	Address *Address `xml:"address,emitempty"`
 }
@@ -482,19 +535,21 @@ type ControllerDriver struct {
 // BEGIN Disk -----------------------------
 
 type Disk struct {
-	Device       string        `xml:"device,attr"`
-	Snapshot     string        `xml:"snapshot,attr,omitempty"`
-	Type         string        `xml:"type,attr"`
-	Source       DiskSource    `xml:"source"`
-	Target       DiskTarget    `xml:"target"`
-	Serial       string        `xml:"serial,omitempty"`
-	Driver       *DiskDriver   `xml:"driver,omitempty"`
-	ReadOnly     *ReadOnly     `xml:"readonly,omitempty"`
-	Auth         *DiskAuth     `xml:"auth,omitempty"`
-	Alias        *Alias        `xml:"alias,omitempty"`
-	BackingStore *BackingStore `xml:"backingStore,omitempty"`
-	BootOrder    *BootOrder    `xml:"boot,omitempty"`
-	Address      *Address      `xml:"address,omitempty"`
+	Device       string          `xml:"device,attr"`
+	Snapshot     string          `xml:"snapshot,attr,omitempty"`
+	Type         string          `xml:"type,attr"`
+	Source       DiskSource      `xml:"source"`
+	Target       DiskTarget      `xml:"target"`
+	Serial       string          `xml:"serial,omitempty"`
+	WWN          string          `xml:"wwn,omitempty"`
+	Driver       *DiskDriver     `xml:"driver,omitempty"`
+	ReadOnly     *ReadOnly       `xml:"readonly,omitempty"`
+	Auth         *DiskAuth       `xml:"auth,omitempty"`
+	Encryption   *DiskEncryption `xml:"encryption,omitempty"`
+	Alias        *Alias          `xml:"alias,omitempty"`
+	BackingStore *BackingStore   `xml:"backingStore,omitempty"`
+	BootOrder    *BootOrder      `xml:"boot,omitempty"`
+	Address      *Address        `xml:"address,omitempty"`
 }
 
 type DiskAuth struct {
@@ -508,15 +563,23 @@ type DiskSecret struct {
 	UUID  string `xml:"uuid,attr,omitempty"`
 }
 
+type DiskEncryption struct {
+	Format string      `xml:"format,attr"`
+	Secret *DiskSecret `xml:"secret,omitempty"`
+}
+
 type ReadOnly struct{}
 
 type DiskSource struct {
-	Dev           string          `xml:"dev,attr,omitempty"`
-	File          string          `xml:"file,attr,omitempty"`
-	StartupPolicy string          `xml:"startupPolicy,attr,omitempty"`
-	Protocol      string          `xml:"protocol,attr,omitempty"`
-	Name          string          `xml:"name,attr,omitempty"`
-	Host          *DiskSourceHost `xml:"host,omitempty"`
+	Dev           string               `xml:"dev,attr,omitempty"`
+	File          string               `xml:"file,attr,omitempty"`
+	StartupPolicy string               `xml:"startupPolicy,attr,omitempty"`
+	Protocol      string               `xml:"protocol,attr,omitempty"`
+	Name          string               `xml:"name,attr,omitempty"`
+	Type          string               `xml:"type,attr,omitempty"`
+	Path          string               `xml:"path,attr,omitempty"`
+	Host          *DiskSourceHost      `xml:"host,omitempty"`
+	Reconnect     *DiskSourceReconnect `xml:"reconnect,omitempty"`
 }
 
 type DiskTarget struct {
@@ -540,6 +603,11 @@ type DiskSourceHost struct {
 	Port string `xml:"port,attr,omitempty"`
 }
 
+type DiskSourceReconnect struct {
+	Enabled string `xml:"enabled,attr"`
+	Timeout *uint  `xml:"timeout,attr,omitempty"`
+}
+
 type BackingStore struct {
 	Type   string              `xml:"type,attr,omitempty"`
 	Format *BackingStoreFormat `xml:"format,omitempty"`
@@ -614,8 +682,21 @@ type Interface struct {
 }
 
 type InterfaceDriver struct {
-	Name   string `xml:"name,attr"`
-	Queues *uint  `xml:"queues,attr,omitempty"`
+	Name          string               `xml:"name,attr"`
+	Queues        *uint                `xml:"queues,attr,omitempty"`
+	RxQueueSize   *uint                `xml:"rx_queue_size,attr,omitempty"`
+	TxQueueSize   *uint                `xml:"tx_queue_size,attr,omitempty"`
+	Packed        string               `xml:"packed,attr,omitempty"`
+	RSS           string               `xml:"rss,attr,omitempty"`
+	RSSHashReport string               `xml:"rss_hash_report,attr,omitempty"`
+	Host          *InterfaceDriverHost `xml:"host,omitempty"`
+}
+
+// InterfaceDriverHost toggles the offloads qemu negotiates on the host side tap device
+type InterfaceDriverHost struct {
+	TSO4 string `xml:"tso4,attr,omitempty"`
+	TSO6 string `xml:"tso6,attr,omitempty"`
+	GSO  string `xml:"gso,attr,omitempty"`
 }
 
 type LinkState struct {
@@ -721,8 +802,8 @@ type Boot struct {
 }
 
 type BootMenu struct {
-	Enabled bool  `xml:"enabled,attr"`
-	Timeout *uint `xml:"timeout,attr,omitempty"`
+	Enabled string `xml:"enable,attr"`
+	Timeout *uint  `xml:"timeout,attr,omitempty"`
 }
 
 type Loader struct {
@@ -738,11 +819,16 @@ type BIOS struct {
 }
 
 type SysInfo struct {
-	Type      string  `xml:"type,attr"`
-	System    []Entry `xml:"system>entry"`
-	BIOS      []Entry `xml:"bios>entry"`
-	BaseBoard []Entry `xml:"baseBoard>entry"`
-	Chassis   []Entry `xml:"chassis>entry"`
+	Type       string      `xml:"type,attr"`
+	System     []Entry     `xml:"system>entry"`
+	BIOS       []Entry     `xml:"bios>entry"`
+	BaseBoard  []Entry     `xml:"baseBoard>entry"`
+	Chassis    []Entry     `xml:"chassis>entry"`
+	OEMStrings *OEMStrings `xml:"oemStrings,omitempty"`
+}
+
+type OEMStrings struct {
+	Entries []string `xml:"entry"`
 }
 
 type Entry struct {
@@ -892,6 +978,7 @@ type IOThreads struct {
 type SecretUsage struct {
 	Type   string `xml:"type,attr"`
 	Target string `xml:"target,omitempty"`
+	Volume string `xml:"volume,omitempty"`
 }
 
 type SecretSpec struct {
@@ -899,6 +986,7 @@ type SecretSpec struct {
 	Ephemeral   string      `xml:"ephemeral,attr"`
 	Private     string      `xml:"private,attr"`
 	Description string      `xml:"description,omitempty"`
+	UUID        string      `xml:"uuid,omitempty"`
 	Usage       SecretUsage `xml:"usage,omitempty"`
 }
 
@@ -0,0 +1,96 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// DomainTemplate keeps the domain last converted from a VMI. A VMI is synced
+// over and over while it runs, converting it again is skipped as long as the
+// parts of the VMI and the converter context the domain is built from did not
+// change. It is not safe for concurrent use.
+type DomainTemplate struct {
+	key    string
+	domain *Domain
+}
+
+// templateInput is everything Convert_v1_VirtualMachine_To_api_Domain builds
+// the domain from. The status of the VMI changes all the time, only the
+// volume status is read by the converter.
+type templateInput struct {
+	Name         string
+	Namespace    string
+	UID          types.UID
+	Annotations  map[string]string
+	Spec         *v1.VirtualMachineInstanceSpec
+	VolumeStatus []v1.VolumeStatus
+	Context      ConverterContext
+}
+
+// Convert fills the domain like Convert_v1_VirtualMachine_To_api_Domain, from
+// a copy of the kept domain if it was converted from the same input
+func (t *DomainTemplate) Convert(vmi *v1.VirtualMachineInstance, domain *Domain, c *ConverterContext) error {
+	key, err := templateKey(vmi, c)
+	if err != nil {
+		// converting works without the template, the next sync retries it
+		t.domain = nil
+		return Convert_v1_VirtualMachine_To_api_Domain(vmi, domain, c)
+	}
+	if t.domain != nil && t.key == key {
+		t.domain.DeepCopyInto(domain)
+		return nil
+	}
+
+	if err := Convert_v1_VirtualMachine_To_api_Domain(vmi, domain, c); err != nil {
+		t.domain = nil
+		return err
+	}
+	t.key = key
+	t.domain = domain.DeepCopy()
+	return nil
+}
+
+func templateKey(vmi *v1.VirtualMachineInstance, c *ConverterContext) (string, error) {
+	input := templateInput{
+		Name:         vmi.Name,
+		Namespace:    vmi.Namespace,
+		UID:          vmi.UID,
+		Annotations:  vmi.Annotations,
+		Spec:         &vmi.Spec,
+		VolumeStatus: vmi.Status.VolumeStatus,
+		Context:      *c,
+	}
+	// the VMI is hashed without its status above
+	input.Context.VirtualMachine = nil
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
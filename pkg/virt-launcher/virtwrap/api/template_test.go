@@ -0,0 +1,75 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package api
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("DomainTemplate", func() {
+	var vmi *v1.VirtualMachineInstance
+	var template *DomainTemplate
+
+	convert := func() *Domain {
+		domain := &Domain{}
+		c := &ConverterContext{VirtualMachine: vmi, UseEmulation: true, RenderOnly: true}
+		Expect(template.Convert(vmi, domain, c)).To(Succeed())
+		return domain
+	}
+
+	BeforeEach(func() {
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+			k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+		template = &DomainTemplate{}
+	})
+
+	It("should convert the VMI like the converter", func() {
+		expected := &Domain{}
+		Expect(Convert_v1_VirtualMachine_To_api_Domain(vmi, expected, &ConverterContext{VirtualMachine: vmi, UseEmulation: true, RenderOnly: true})).To(Succeed())
+		Expect(convert()).To(Equal(expected))
+	})
+
+	It("should hand out copies of the kept domain while the VMI does not change", func() {
+		domain := convert()
+		kept := template.domain
+		domain.Spec.Name = "changed"
+
+		vmi.Status.Phase = v1.Running
+		Expect(convert().Spec.Name).To(Equal("default_testvmi"))
+		Expect(template.domain).To(BeIdenticalTo(kept))
+	})
+
+	It("should convert the VMI again once its spec changed", func() {
+		convert()
+		kept := template.domain
+
+		vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory] = resource.MustParse("128Mi")
+		domain := convert()
+		Expect(template.domain).ToNot(BeIdenticalTo(kept))
+		Expect(domain.Spec.Memory.Value).To(Equal(uint64(128 * 1024 * 1024)))
+	})
+})
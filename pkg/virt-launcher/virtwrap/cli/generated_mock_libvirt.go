@@ -167,6 +167,26 @@ func (_mr *_MockConnectionRecorder) GetDomainStats(arg0, arg1 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDomainStats", arg0, arg1)
 }
 
+func (_m *MockConnection) DomainRestore(srcFile string, xml string) error {
+	ret := _m.ctrl.Call(_m, "DomainRestore", srcFile, xml)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) DomainRestore(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainRestore", arg0, arg1)
+}
+
+func (_m *MockConnection) DefineSecret(xml string, value []byte) error {
+	ret := _m.ctrl.Call(_m, "DefineSecret", xml, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockConnectionRecorder) DefineSecret(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DefineSecret", arg0, arg1)
+}
+
 // Mock of Stream interface
 type MockStream struct {
 	ctrl     *gomock.Controller
@@ -450,6 +470,18 @@ func (_mr *_MockVirDomainRecorder) GetJobInfo() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetJobInfo")
 }
 
+func (_m *MockVirDomain) GetTime(flags uint32) (int64, uint, error) {
+	ret := _m.ctrl.Call(_m, "GetTime", flags)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(uint)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockVirDomainRecorder) GetTime(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetTime", arg0)
+}
+
 func (_m *MockVirDomain) SetTime(secs int64, nsecs uint, flags libvirt_go.DomainSetTimeFlags) error {
 	ret := _m.ctrl.Call(_m, "SetTime", secs, nsecs, flags)
 	ret0, _ := ret[0].(error)
@@ -460,6 +492,47 @@ func (_mr *_MockVirDomainRecorder) SetTime(arg0, arg1, arg2 interface{}) *gomock
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetTime", arg0, arg1, arg2)
 }
 
+func (_m *MockVirDomain) Save(destFile string) error {
+	ret := _m.ctrl.Call(_m, "Save", destFile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) Save(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Save", arg0)
+}
+
+func (_m *MockVirDomain) QemuMonitorCommand(command string, flags libvirt_go.DomainQemuMonitorCommandFlags) (string, error) {
+	ret := _m.ctrl.Call(_m, "QemuMonitorCommand", command, flags)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirDomainRecorder) QemuMonitorCommand(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "QemuMonitorCommand", arg0, arg1)
+}
+
+func (_m *MockVirDomain) FSFreeze(mounts []string, flags uint32) error {
+	ret := _m.ctrl.Call(_m, "FSFreeze", mounts, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) FSFreeze(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FSFreeze", arg0, arg1)
+}
+
+func (_m *MockVirDomain) FSThaw(mounts []string, flags uint32) error {
+	ret := _m.ctrl.Call(_m, "FSThaw", mounts, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirDomainRecorder) FSThaw(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FSThaw", arg0, arg1)
+}
+
 func (_m *MockVirDomain) AbortJob() error {
 	ret := _m.ctrl.Call(_m, "AbortJob")
 	ret0, _ := ret[0].(error)
@@ -43,6 +43,9 @@ const ConnectionInterval = 500 * time.Millisecond
 type Connection interface {
 	LookupDomainByName(name string) (VirDomain, error)
 	DomainDefineXML(xml string) (VirDomain, error)
+	// helper method, not found in libvirt
+	// restores a domain saved to srcFile with the given definition and resumes it
+	DomainRestore(srcFile string, xml string) error
 	Close() (int, error)
 	DomainEventLifecycleRegister(callback libvirt.DomainEventLifecycleCallback) error
 	DomainEventDeviceAddedRegister(callback libvirt.DomainEventDeviceAddedCallback) error
@@ -58,6 +61,9 @@ type Connection interface {
 	// 1. avoid to expose to the client code the libvirt-specific return type, see docs in stats/ subpackage
 	// 2. transparently handling the addition of the memory stats, currently (libvirt 4.9) not handled by the bulk stats API
 	GetDomainStats(statsTypes libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*stats.DomainStats, error)
+	// helper method, not found in libvirt
+	// defines a secret and sets its value, without exposing the secret handle to the client code
+	DefineSecret(xml string, value []byte) error
 }
 
 type Stream interface {
@@ -201,6 +207,33 @@ func (l *LibvirtConnection) DomainDefineXML(xml string) (dom VirDomain, err erro
 	return
 }
 
+func (l *LibvirtConnection) DomainRestore(srcFile string, xml string) (err error) {
+	if err = l.reconnectIfNecessary(); err != nil {
+		return
+	}
+
+	err = l.Connect.DomainRestoreFlags(srcFile, xml, libvirt.DOMAIN_SAVE_RUNNING)
+	l.checkConnectionLost(err)
+	return
+}
+
+func (l *LibvirtConnection) DefineSecret(xml string, value []byte) (err error) {
+	if err = l.reconnectIfNecessary(); err != nil {
+		return
+	}
+
+	secret, err := l.Connect.SecretDefineXML(xml, 0)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return
+	}
+	defer secret.Free()
+
+	err = secret.SetValue(value, 0)
+	l.checkConnectionLost(err)
+	return
+}
+
 func (l *LibvirtConnection) ListAllDomains(flags libvirt.ConnectListAllDomainsFlags) ([]VirDomain, error) {
 	if err := l.reconnectIfNecessary(); err != nil {
 		return nil, err
@@ -390,6 +423,7 @@ func (l *LibvirtConnection) checkConnectionLost(err error) {
 type VirDomain interface {
 	GetState() (libvirt.DomainState, int, error)
 	Create() error
+	Save(destFile string) error
 	Suspend() error
 	Resume() error
 	AttachDevice(xml string) error
@@ -407,7 +441,11 @@ type VirDomain interface {
 	MemoryStats(nrStats uint32, flags uint32) ([]libvirt.DomainMemoryStat, error)
 	GetJobStats(flags libvirt.DomainGetJobStatsFlags) (*libvirt.DomainJobInfo, error)
 	GetJobInfo() (*libvirt.DomainJobInfo, error)
+	GetTime(flags uint32) (int64, uint, error)
 	SetTime(secs int64, nsecs uint, flags libvirt.DomainSetTimeFlags) error
+	QemuMonitorCommand(command string, flags libvirt.DomainQemuMonitorCommandFlags) (string, error)
+	FSFreeze(mounts []string, flags uint32) error
+	FSThaw(mounts []string, flags uint32) error
 	AbortJob() error
 	Free() error
 }
@@ -20,6 +20,7 @@
 package cmdserver
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -36,6 +37,7 @@ import (
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
 	launcherErrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 )
 
 type ServerOptions struct {
@@ -87,6 +89,20 @@ func getErrorMessage(err error) string {
 	return err.Error()
 }
 
+// getErrorReason classifies the errors virt-handler handles specially, it is
+// empty for all others
+func getErrorReason(err error) string {
+	var setupErr *network.NetworkSetupError
+	if errors.As(err, &setupErr) {
+		return setupErr.Reason
+	}
+	var restoreErr *launcherErrors.HibernationRestoreError
+	if errors.As(err, &restoreErr) {
+		return launcherErrors.HibernationRestoreFailedReason
+	}
+	return ""
+}
+
 func (l *Launcher) MigrateVirtualMachine(ctx context.Context, request *cmdv1.MigrationRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
@@ -161,6 +177,7 @@ func (l *Launcher) SyncVirtualMachine(ctx context.Context, request *cmdv1.VMIReq
 		log.Log.Object(vmi).Reason(err).Errorf("Failed to sync vmi")
 		response.Success = false
 		response.Message = getErrorMessage(err)
+		response.Reason = getErrorReason(err)
 		return response, nil
 	}
 
@@ -477,3 +494,136 @@ func (l *Launcher) Ping(ctx context.Context, request *cmdv1.EmptyRequest) (*cmdv
 	}
 	return response, nil
 }
+
+// GetNetworkStatus returns the state of the VMI network interfaces plugged by virt-launcher
+func (l *Launcher) GetNetworkStatus(ctx context.Context, request *cmdv1.EmptyRequest) (*cmdv1.NetworkStatusResponse, error) {
+	response := &cmdv1.NetworkStatusResponse{
+		Response: &cmdv1.Response{
+			Success: true,
+		},
+	}
+
+	states := l.domainManager.GetNetworkInterfacesState()
+	if jStates, err := json.Marshal(states); err != nil {
+		log.Log.Reason(err).Errorf("Failed to marshal network status")
+		response.Response.Success = false
+		response.Response.Message = getErrorMessage(err)
+	} else {
+		response.NetworkStatus = string(jStates)
+	}
+
+	return response, nil
+}
+
+// PlugNetworkInterfaces re-plugs the VMI network interfaces into the running domain
+func (l *Launcher) PlugNetworkInterfaces(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.PlugNetworkInterfaces(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to plug network interfaces")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		response.Reason = getErrorReason(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Plugged network interfaces")
+	return response, nil
+}
+
+// AnnounceNetworkInterfaces announces the guest interfaces on the network after a migration
+func (l *Launcher) AnnounceNetworkInterfaces(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.AnnounceNetworkInterfaces(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to announce network interfaces")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Announced network interfaces")
+	return response, nil
+}
+
+// HibernateVirtualMachine saves the guest memory to the hibernation claim and shuts off the domain
+func (l *Launcher) HibernateVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.HibernateVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to hibernate vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Hibernated vmi")
+	return response, nil
+}
+
+// FreezeVirtualMachine freezes the guest file systems through the guest agent
+func (l *Launcher) FreezeVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.FreezeVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to freeze vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Froze vmi")
+	return response, nil
+}
+
+// UnfreezeVirtualMachine thaws the guest file systems
+func (l *Launcher) UnfreezeVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.UnfreezeVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to unfreeze vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Unfroze vmi")
+	return response, nil
+}
+
+// ExecQMPCommand executes a read-only QMP command against the qemu instance of the VMI
+func (l *Launcher) ExecQMPCommand(ctx context.Context, request *cmdv1.QMPCommandRequest) (*cmdv1.QMPCommandResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	qmpResponse := &cmdv1.QMPCommandResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return qmpResponse, nil
+	}
+
+	result, err := l.domainManager.ExecQMPCommand(vmi, request.Command)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to execute QMP command %s", request.Command)
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return qmpResponse, nil
+	}
+
+	qmpResponse.Result = result
+	return qmpResponse, nil
+}
@@ -20,6 +20,7 @@
 package cmdserver
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -36,6 +37,8 @@ import (
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	launcherErrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 )
 
@@ -91,6 +94,19 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should return the reason of a failed hibernation restore", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			restoreErr := &launcherErrors.HibernationRestoreError{Err: fmt.Errorf("unsupported configuration: target device changed")}
+			domainManager.EXPECT().SyncVMI(vmi, useEmulation, &cmdv1.VirtualMachineOptions{}).Return(nil, restoreErr)
+
+			err := client.SyncVirtualMachine(vmi, &cmdv1.VirtualMachineOptions{})
+			Expect(err).To(HaveOccurred())
+			serverErr, ok := err.(*cmdclient.ServerError)
+			Expect(ok).To(BeTrue())
+			Expect(serverErr.Reason).To(Equal(launcherErrors.HibernationRestoreFailedReason))
+			Expect(serverErr.Msg).To(ContainSubstring(restoreErr.Error()))
+		})
+
 		It("should kill a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().KillVMI(vmi)
@@ -120,6 +136,20 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should freeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().FreezeVMI(vmi)
+			err := client.FreezeVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should unfreeze a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().UnfreezeVMI(vmi)
+			err := client.UnfreezeVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should list domains", func() {
 			var list []*api.Domain
 			list = append(list, api.NewMinimalDomain("testvmi1"))
@@ -211,6 +241,82 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred(), "should fetch filesystems without any issue")
 			Expect(fetchedList.Items).To(Equal(fsList), "fetched list should be the same")
 		})
+
+		It("should return the network interfaces state", func() {
+			states := []api.NetworkInterfaceState{
+				{
+					Name:             "default",
+					PodInterfaceName: "eth0",
+					Plugged:          false,
+					Error:            "failed to start DHCP server for interface eth0",
+				},
+			}
+
+			domainManager.EXPECT().GetNetworkInterfacesState().Return(states)
+
+			fetchedStates, err := client.GetNetworkStatus()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetchedStates).To(Equal(states))
+		})
+
+		It("should plug network interfaces", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().PlugNetworkInterfaces(vmi)
+			err := client.PlugNetworkInterfaces(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should return structured network plug errors", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			setupErr := &network.NetworkSetupError{Reason: network.NetworkSetupReasonDomainChanged, Msg: "re-plugging interface default would change its device in the running domain"}
+			domainManager.EXPECT().PlugNetworkInterfaces(vmi).Return(fmt.Errorf("plugging the pod network failed: %w", setupErr))
+			err := client.PlugNetworkInterfaces(vmi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("plugging the pod network failed"))
+			Expect(err.Error()).To(ContainSubstring("would change its device in the running domain"))
+			serverErr, ok := err.(*cmdclient.ServerError)
+			Expect(ok).To(BeTrue())
+			Expect(serverErr.Reason).To(Equal(network.NetworkSetupReasonDomainChanged))
+		})
+
+		It("should not classify other network plug errors", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().PlugNetworkInterfaces(vmi).Return(fmt.Errorf("network setup failed (DHCPFailed): forged"))
+			err := client.PlugNetworkInterfaces(vmi)
+			Expect(err).To(HaveOccurred())
+			serverErr, ok := err.(*cmdclient.ServerError)
+			Expect(ok).To(BeTrue())
+			Expect(serverErr.Reason).To(BeEmpty())
+		})
+
+		It("should announce network interfaces", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().AnnounceNetworkInterfaces(vmi)
+			err := client.AnnounceNetworkInterfaces(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should hibernate a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().HibernateVMI(vmi)
+			err := client.HibernateVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should execute a QMP command", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().ExecQMPCommand(vmi, "query-status").Return(`{"running":true,"status":"running"}`, nil)
+			result, err := client.ExecQMPCommand(vmi, "query-status")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(`{"running":true,"status":"running"}`))
+		})
+
+		It("should report a failed QMP command", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().ExecQMPCommand(vmi, "query-status").Return("", fmt.Errorf("monitor unavailable"))
+			_, err := client.ExecQMPCommand(vmi, "query-status")
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("Version mismatch", func() {
@@ -28,6 +28,20 @@ import (
 
 var MigrationAbortInProgressError = errors.New("Migration abort is in progress")
 
+// HibernationRestoreFailedReason is passed to virt-handler with the failed
+// command if the memory of a hibernated VMI could not be restored
+const HibernationRestoreFailedReason = "HibernationRestoreFailed"
+
+// HibernationRestoreError means the memory saved when the VMI was hibernated
+// could not be restored. The memory is kept and the VMI is not booted.
+type HibernationRestoreError struct {
+	Err error
+}
+
+func (e *HibernationRestoreError) Error() string {
+	return fmt.Sprintf("failed to restore the hibernated memory: %v", e.Err)
+}
+
 func checkError(err error, expectedError libvirt.ErrorNumber) bool {
 	libvirtError, ok := err.(libvirt.Error)
 	if ok {
@@ -199,3 +199,74 @@ func (_m *MockDomainManager) SetGuestTime(_param0 *v1.VirtualMachineInstance) er
 func (_mr *_MockDomainManagerRecorder) SetGuestTime(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetGuestTime", arg0)
 }
+
+func (_m *MockDomainManager) GetNetworkInterfacesState() []api.NetworkInterfaceState {
+	ret := _m.ctrl.Call(_m, "GetNetworkInterfacesState")
+	ret0, _ := ret[0].([]api.NetworkInterfaceState)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) GetNetworkInterfacesState() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetNetworkInterfacesState")
+}
+
+func (_m *MockDomainManager) PlugNetworkInterfaces(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "PlugNetworkInterfaces", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) PlugNetworkInterfaces(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PlugNetworkInterfaces", arg0)
+}
+
+func (_m *MockDomainManager) AnnounceNetworkInterfaces(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "AnnounceNetworkInterfaces", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) AnnounceNetworkInterfaces(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AnnounceNetworkInterfaces", arg0)
+}
+
+func (_m *MockDomainManager) HibernateVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "HibernateVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) HibernateVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "HibernateVMI", arg0)
+}
+
+func (_m *MockDomainManager) ExecQMPCommand(_param0 *v1.VirtualMachineInstance, _param1 string) (string, error) {
+	ret := _m.ctrl.Call(_m, "ExecQMPCommand", _param0, _param1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) ExecQMPCommand(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExecQMPCommand", arg0, arg1)
+}
+
+func (_m *MockDomainManager) FreezeVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "FreezeVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) FreezeVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FreezeVMI", arg0)
+}
+
+func (_m *MockDomainManager) UnfreezeVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "UnfreezeVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) UnfreezeVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVMI", arg0)
+}
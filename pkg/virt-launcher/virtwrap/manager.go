@@ -27,8 +27,10 @@ package virtwrap
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
@@ -60,6 +62,7 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	kutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
@@ -67,6 +70,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	metadataservice "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/metadata-service"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
@@ -78,6 +82,14 @@ const (
 	vgpuEnvPrefix              = "VGPU_PASSTHROUGH_DEVICES"
 	PCI_RESOURCE_PREFIX        = "PCI_RESOURCE"
 	MDEV_RESOURCE_PREFIX       = "MDEV_PCI_RESOURCE"
+	// announce-self makes qemu send RARPs for the guest MACs and asks
+	// virtio guests to send gratuitous ARPs and neighbor advertisements
+	announceSelfCommand = `{"execute":"announce-self","arguments":{"initial":50,"max":550,"rounds":5,"step":50}}`
+	// emulatorBinary is the name of the qemu binary in the directory of an
+	// alternative emulator build, virt-launcher looks for qemu processes by it
+	emulatorBinary = "qemu-kvm"
+	// fsFreezeTimeout is how long the guest file systems stay frozen at most
+	fsFreezeTimeout = 5 * time.Minute
 )
 
 type contextStore struct {
@@ -102,6 +114,13 @@ type DomainManager interface {
 	GetUsers() ([]v1.VirtualMachineInstanceGuestOSUser, error)
 	GetFilesystems() ([]v1.VirtualMachineInstanceFileSystem, error)
 	SetGuestTime(*v1.VirtualMachineInstance) error
+	GetNetworkInterfacesState() []api.NetworkInterfaceState
+	PlugNetworkInterfaces(*v1.VirtualMachineInstance) error
+	AnnounceNetworkInterfaces(*v1.VirtualMachineInstance) error
+	HibernateVMI(*v1.VirtualMachineInstance) error
+	ExecQMPCommand(*v1.VirtualMachineInstance, string) (string, error)
+	FreezeVMI(*v1.VirtualMachineInstance) error
+	UnfreezeVMI(*v1.VirtualMachineInstance) error
 }
 
 type LibvirtDomainManager struct {
@@ -122,6 +141,18 @@ type LibvirtDomainManager struct {
 	cloudInitDataStore     *cloudinit.CloudInitData
 	setGuestTimeContextPtr *contextStore
 	ovmfPath               string
+	metadataService        *metadataservice.MetadataService
+	emulator               string
+	emulatorDirs           []string
+	// the domain last converted by SyncVMI, guarded by domainModifyLock
+	domainTemplate api.DomainTemplate
+	// container disk images don't change while the pod runs, their info is
+	// read once, keyed by the image path
+	imageInfos     map[string]*containerdisk.DiskInfo
+	imageInfosLock sync.Mutex
+	// thaws the guest file systems if they are not thawed in time, set while
+	// they are frozen and guarded by domainModifyLock
+	fsThawTimer *time.Timer
 }
 
 type migrationDisks struct {
@@ -157,7 +188,7 @@ func (s pausedVMIs) contains(uid types.UID) bool {
 	return ok
 }
 
-func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int, agentStore *agentpoller.AsyncAgentStore, ovmfPath string) (DomainManager, error) {
+func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, notifier *eventsclient.Notifier, lessPVCSpaceToleration int, agentStore *agentpoller.AsyncAgentStore, ovmfPath string, emulator string) (DomainManager, error) {
 	manager := LibvirtDomainManager{
 		virConn:                connection,
 		virtShareDir:           virtShareDir,
@@ -168,12 +199,38 @@ func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, not
 		},
 		agentData: agentStore,
 		ovmfPath:  ovmfPath,
+		emulator:  emulator,
+		// builds provided by hook sidecars take precedence over the ones
+		// shipped in the virt-launcher image
+		emulatorDirs: []string{kutil.SidecarEmulatorsDir, kutil.EmulatorsDir},
 	}
 	manager.credManager = accesscredentials.NewManager(connection, &manager.domainModifyLock)
 
 	return &manager, nil
 }
 
+// resolveEmulator returns the path of the qemu build selected for the VMI,
+// or an empty path to let libvirt pick its default emulator.
+func (l *LibvirtDomainManager) resolveEmulator() (string, error) {
+	if l.emulator == "" {
+		return "", nil
+	}
+	for _, dir := range l.emulatorDirs {
+		path := filepath.Join(dir, l.emulator, emulatorBinary)
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			return "", fmt.Errorf("emulator %s is not an executable file", path)
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("emulator %s not found in %s", l.emulator, strings.Join(l.emulatorDirs, ", "))
+}
+
 func (l *LibvirtDomainManager) initializeMigrationMetadata(vmi *v1.VirtualMachineInstance, migrationMode v1.MigrationMode) (bool, error) {
 	l.domainModifyLock.Lock()
 	defer l.domainModifyLock.Unlock()
@@ -489,6 +546,16 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 			params.MigrateDisks = copyDisks
 			params.MigrateDisksSet = true
 		}
+		if network.NeedsMigrationTargetNetworkState(vmi) {
+			destXML, err := migratableDomXML(dom, vmi)
+			if err != nil {
+				log.Log.Object(vmi).Reason(err).Error("Live migration failed. Failed to prepare the domain for the target.")
+				l.setMigrationResult(vmi, true, fmt.Sprintf("%v", err), "")
+				return
+			}
+			params.DestXML = destXML
+			params.DestXMLSet = true
+		}
 		// start live migration tracking
 		migrationErrorChan := make(chan error, 1)
 		defer close(migrationErrorChan)
@@ -504,6 +571,27 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 	}(l, vmi)
 }
 
+// migratableDomXML returns the domain XML which is sent to the migration
+// target, with the macvtap interfaces pointing at the devices of the target pod
+func migratableDomXML(dom cli.VirDomain, vmi *v1.VirtualMachineInstance) (string, error) {
+	xmlstr, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE)
+	if err != nil {
+		return "", err
+	}
+	domSpec := &api.DomainSpec{}
+	if err := xml.Unmarshal([]byte(xmlstr), domSpec); err != nil {
+		return "", fmt.Errorf("failed to parse the migratable domain xml: %v", err)
+	}
+	if err := network.UpdateMigrationDomainInterfaces(vmi, domSpec); err != nil {
+		return "", err
+	}
+	newXML, err := xml.Marshal(domSpec)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the migratable domain xml: %v", err)
+	}
+	return string(newXML), nil
+}
+
 func (l *LibvirtDomainManager) SetGuestTime(vmi *v1.VirtualMachineInstance) error {
 	// Try to set VM time to the current value.  This is typically useful
 	// when clock wasn't running on the VM for some time (e.g. during
@@ -531,10 +619,12 @@ func (l *LibvirtDomainManager) SetGuestTime(vmi *v1.VirtualMachineInstance) erro
 			select {
 			case <-timeout:
 				log.Log.Object(vmi).Error("failed to sync guest time")
+				l.reportGuestTimeSync(vmi, false, nil, "timed out waiting for the guest agent to set the time")
 				return
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
+				drift := getGuestTimeDrift(dom)
 				currTime := time.Now()
 				secs := currTime.Unix()
 				nsecs := uint(currTime.Nanosecond())
@@ -543,6 +633,7 @@ func (l *LibvirtDomainManager) SetGuestTime(vmi *v1.VirtualMachineInstance) erro
 					libvirtError, ok := err.(libvirt.Error)
 					if !ok {
 						log.Log.Object(vmi).Reason(err).Warning("failed to sync guest time")
+						l.reportGuestTimeSync(vmi, false, drift, err.Error())
 						return
 					}
 
@@ -552,15 +643,21 @@ func (l *LibvirtDomainManager) SetGuestTime(vmi *v1.VirtualMachineInstance) erro
 					case libvirt.ERR_OPERATION_UNSUPPORTED:
 						// no need to retry as this opertaion is not supported
 						log.Log.Object(vmi).Reason(err).Warning("failed to set time: not supported")
+						l.reportGuestTimeSync(vmi, false, drift, "setting the guest time is not supported")
 						return
 					case libvirt.ERR_ARGUMENT_UNSUPPORTED:
 						// no need to retry as the agent is not configured
 						log.Log.Object(vmi).Reason(err).Warning("failed to set time: agent not configured")
+						l.reportGuestTimeSync(vmi, false, drift, "the guest agent is not configured")
 						return
 					default:
 						log.Log.Object(vmi).Reason(err).Warning("failed to sync guest time")
 					}
 				} else {
+					if drift != nil {
+						log.Log.Object(vmi).Infof("synced guest time, the guest clock drifted by %dms", *drift)
+					}
+					l.reportGuestTimeSync(vmi, true, drift, "")
 					return
 				}
 			}
@@ -570,6 +667,55 @@ func (l *LibvirtDomainManager) SetGuestTime(vmi *v1.VirtualMachineInstance) erro
 	return nil
 }
 
+// getGuestTimeDrift returns the offset of the guest clock from the clock of
+// the node in milliseconds, or nil if the guest agent can't report the time.
+func getGuestTimeDrift(dom cli.VirDomain) *int64 {
+	secs, nsecs, err := dom.GetTime(0)
+	if err != nil {
+		return nil
+	}
+	guestTime := time.Unix(secs, int64(nsecs))
+	drift := time.Until(guestTime).Milliseconds()
+	return &drift
+}
+
+// reportGuestTimeSync stores the result of the last guest time synchronization
+// in the domain metadata, from where virt-handler reports it in the VMI status.
+func (l *LibvirtDomainManager) reportGuestTimeSync(vmi *v1.VirtualMachineInstance, succeeded bool, drift *int64, message string) {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if !domainerrors.IsNotFound(err) {
+			log.Log.Object(vmi).Reason(err).Error("Getting the domain to report the guest time sync failed.")
+		}
+		return
+	}
+	defer dom.Free()
+
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain spec to report the guest time sync failed.")
+		return
+	}
+
+	now := metav1.Now()
+	domainSpec.Metadata.KubeVirt.TimeSync = &api.TimeSyncMetadata{
+		Timestamp:         &now,
+		Succeeded:         succeeded,
+		DriftMilliseconds: drift,
+		Message:           message,
+	}
+	d, err := l.setDomainSpecWithHooks(vmi, domainSpec)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Storing the guest time sync in the domain metadata failed.")
+		return
+	}
+	defer d.Free()
+}
+
 func (l *LibvirtDomainManager) getGuestTimeContext() context.Context {
 	l.setGuestTimeLock.Lock()
 	defer l.setGuestTimeLock.Unlock()
@@ -849,6 +995,47 @@ var updateHostsFile = func(entry string) (err error) {
 	return nil
 }
 
+// getHostNUMANodes reads the host NUMA nodes if the guest NUMA topology of the VMI is mapped to them,
+// unless virt-handler passed them along with the capabilities of the host
+func getHostNUMANodes(vmi *v1.VirtualMachineInstance, capabilities *cmdv1.HostCapabilities) ([]hardware.NUMANode, error) {
+	if vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.NUMA == nil || vmi.Spec.Domain.CPU.NUMA.GuestMappingPassthrough == nil {
+		return nil, nil
+	}
+	if capabilities == nil {
+		return hardware.GetNUMANodes(hardware.NUMANodesPath)
+	}
+
+	var nodes []hardware.NUMANode
+	for _, numaNode := range capabilities.NumaNodes {
+		node := hardware.NUMANode{ID: int(numaNode.Id)}
+		for _, cpu := range numaNode.Cpus {
+			node.CPUs = append(node.CPUs, int(cpu))
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// getCachedImageInfo returns the info of the container disk image, which is only
+// read on the first call for the image
+func (l *LibvirtDomainManager) getCachedImageInfo(image string) (*containerdisk.DiskInfo, error) {
+	l.imageInfosLock.Lock()
+	defer l.imageInfosLock.Unlock()
+
+	if info, exists := l.imageInfos[image]; exists {
+		return info, nil
+	}
+	info, err := getImageInfo(image)
+	if err != nil {
+		return nil, err
+	}
+	if l.imageInfos == nil {
+		l.imageInfos = map[string]*containerdisk.DiskInfo{}
+	}
+	l.imageInfos[image] = info
+	return info, nil
+}
+
 // Prepares the target pod environment by executing the preStartHook
 func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInstance, useEmulation bool) error {
 
@@ -864,10 +1051,20 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 	// reserve the last cpu for the emulator thread
 	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread {
 		if len(podCPUSet) > 0 {
-			emulatorThreadCpu = &podCPUSet[len(podCPUSet)]
+			emulatorThreadCpu = &podCPUSet[len(podCPUSet)-1]
 			podCPUSet = podCPUSet[:len(podCPUSet)-1]
 		}
 	}
+	numaNodes, err := getHostNUMANodes(vmi, nil)
+	if err != nil {
+		logger.Reason(err).Error("failed to read host NUMA nodes.")
+		return fmt.Errorf("failed to read host NUMA nodes: %v", err)
+	}
+	emulator, err := l.resolveEmulator()
+	if err != nil {
+		logger.Reason(err).Error("failed to resolve the emulator.")
+		return err
+	}
 	// Check if PVC volumes are block volumes
 	isBlockPVCMap := make(map[string]bool)
 	isBlockDVMap := make(map[string]bool)
@@ -886,7 +1083,7 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 			if err != nil {
 				return err
 			}
-			info, err := api.GetImageInfo(image)
+			info, err := l.getCachedImageInfo(image)
 			if err != nil {
 				return err
 			}
@@ -912,7 +1109,9 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 		IsBlockDV:         isBlockDVMap,
 		DiskType:          diskInfo,
 		EmulatorThreadCpu: emulatorThreadCpu,
+		NUMANodes:         numaNodes,
 		OVMFPath:          l.ovmfPath,
+		Emulator:          emulator,
 	}
 	if err := api.Convert_v1_VirtualMachine_To_api_Domain(vmi, domain, c); err != nil {
 		return fmt.Errorf("conversion failed: %v", err)
@@ -922,6 +1121,10 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 	if err != nil {
 		return fmt.Errorf("pre-start pod-setup failed: %v", err)
 	}
+	// the incoming domain opens the encrypted volumes with the same secrets
+	if err := l.defineDiskEncryptionSecrets(dom); err != nil {
+		return fmt.Errorf("defining disk encryption secrets failed: %v", err)
+	}
 
 	err = l.generateCloudInitISO(vmi, nil)
 	if err != nil {
@@ -989,6 +1192,65 @@ func (l *LibvirtDomainManager) generateCloudInitISO(vmi *v1.VirtualMachineInstan
 	return nil
 }
 
+// defineDiskEncryptionSecrets passes the LUKS passphrases of all encrypted disks to libvirt.
+// The secrets are ephemeral and private, so they only live in the memory of libvirt and can't be read back.
+func (l *LibvirtDomainManager) defineDiskEncryptionSecrets(domain *api.Domain) error {
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.Encryption == nil || disk.Encryption.Secret == nil {
+			continue
+		}
+		volumeName := disk.Alias.Name
+
+		passphrase, err := ioutil.ReadFile(config.GetDiskEncryptionKeyPath(volumeName))
+		if err != nil {
+			return fmt.Errorf("failed to read the LUKS passphrase of volume %s: %v", volumeName, err)
+		}
+
+		usage := disk.Source.File
+		if usage == "" {
+			usage = disk.Source.Dev
+		}
+		secret := api.SecretSpec{
+			Ephemeral:   "yes",
+			Private:     "yes",
+			Description: fmt.Sprintf("LUKS passphrase of volume %s", volumeName),
+			UUID:        disk.Encryption.Secret.UUID,
+			Usage: api.SecretUsage{
+				Type:   "volume",
+				Volume: usage,
+			},
+		}
+		secretXML, err := xml.Marshal(secret)
+		if err != nil {
+			return err
+		}
+		if err := l.virConn.DefineSecret(string(secretXML), passphrase); err != nil {
+			return fmt.Errorf("failed to define the LUKS secret of volume %s: %v", volumeName, err)
+		}
+	}
+	return nil
+}
+
+// startMetadataService serves the metadata of the vmi to the guest. virt-handler
+// assigned the metadata address to the bridge of the masquerade interface when
+// it plugged the pod network.
+func (l *LibvirtDomainManager) startMetadataService(vmi *v1.VirtualMachineInstance, cloudInitData *cloudinit.CloudInitData) error {
+	if vmi.Spec.MetadataService == nil || l.metadataService != nil {
+		return nil
+	}
+
+	userData := ""
+	if cloudInitData != nil {
+		userData = cloudInitData.UserData
+	}
+	service := metadataservice.NewMetadataService(vmi, userData, config.ServiceAccountSourceDir)
+	if err := service.Start(); err != nil {
+		return fmt.Errorf("starting the metadata service failed: %v", err)
+	}
+	l.metadataService = service
+	return nil
+}
+
 // All local environment setup that needs to occur before VirtualMachineInstance starts
 // can be done in this function. This includes things like...
 //
@@ -1036,7 +1298,11 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 
 	err = network.SetupNetworkInterfacesPhase2(vmi, domain)
 	if err != nil {
-		return domain, fmt.Errorf("preparing the pod network failed: %v", err)
+		return domain, fmt.Errorf("preparing the pod network failed: %w", err)
+	}
+
+	if err := l.startMetadataService(vmi, cloudInitData); err != nil {
+		return domain, err
 	}
 
 	// create disks images on the cluster lever
@@ -1077,7 +1343,6 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 	if err := config.CreateServiceAccountDisk(vmi); err != nil {
 		return domain, fmt.Errorf("creating service account disk failed: %v", err)
 	}
-
 	// set drivers cache mode
 	for i := range domain.Spec.Devices.Disks {
 		err := api.SetDriverCacheMode(&domain.Spec.Devices.Disks[i])
@@ -1240,6 +1505,10 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 
 	logger := log.Log.Object(vmi)
 
+	if l.metadataService != nil {
+		l.metadataService.SetVMI(vmi)
+	}
+
 	domain := &api.Domain{}
 	var emulatorThreadCpu *int
 	podCPUSet, err := util.GetPodCPUSet()
@@ -1254,6 +1523,16 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 			podCPUSet = podCPUSet[:len(podCPUSet)-1]
 		}
 	}
+	numaNodes, err := getHostNUMANodes(vmi, options.GetHostCapabilities())
+	if err != nil {
+		logger.Reason(err).Error("failed to read host NUMA nodes.")
+		return nil, err
+	}
+	emulator, err := l.resolveEmulator()
+	if err != nil {
+		logger.Reason(err).Error("failed to resolve the emulator.")
+		return nil, err
+	}
 
 	hotplugVolumes := make(map[string]v1.VolumeStatus)
 	permanentVolumes := make(map[string]v1.VolumeStatus)
@@ -1283,7 +1562,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 			if err != nil {
 				return nil, err
 			}
-			info, err := api.GetImageInfo(image)
+			info, err := l.getCachedImageInfo(image)
 			if err != nil {
 				return nil, err
 			}
@@ -1315,7 +1594,9 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		VgpuDevices:       getEnvAddressListByPrefix(vgpuEnvPrefix),
 		HostDevices:       getDevicesForAssignment(vmi.Spec.Domain.Devices),
 		EmulatorThreadCpu: emulatorThreadCpu,
+		NUMANodes:         numaNodes,
 		OVMFPath:          l.ovmfPath,
+		Emulator:          emulator,
 	}
 	if options != nil {
 		if options.VirtualMachineSMBios != nil {
@@ -1328,7 +1609,7 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		return nil, err
 	}
 
-	if err := api.Convert_v1_VirtualMachine_To_api_Domain(vmi, domain, c); err != nil {
+	if err := l.domainTemplate.Convert(vmi, domain, c); err != nil {
 		logger.Error("Conversion failed.")
 		return nil, err
 	}
@@ -1347,6 +1628,10 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 				logger.Reason(err).Error("pre start setup for VirtualMachineInstance failed.")
 				return nil, err
 			}
+			if err := l.defineDiskEncryptionSecrets(domain); err != nil {
+				logger.Reason(err).Error("defining disk encryption secrets failed.")
+				return nil, err
+			}
 			dom, err = l.setDomainSpecWithHooks(vmi, &domain.Spec)
 			if err != nil {
 				return nil, err
@@ -1383,12 +1668,26 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		if err != nil {
 			return nil, err
 		}
-		err = dom.Create()
+		restored, err := l.restoreHibernatedDomain(vmi, dom)
 		if err != nil {
-			logger.Reason(err).Error("Starting the VirtualMachineInstance failed.")
 			return nil, err
 		}
-		logger.Info("Domain started.")
+		if restored {
+			logger.Info("Domain restored from hibernation.")
+			// the guest clock stood still while the domain was hibernated.
+			// This operation is not disruptive.
+			if err := l.SetGuestTime(vmi); err != nil {
+				logger.Reason(err).Warning("Setting the guest time after the restore failed.")
+			}
+		} else {
+			err = dom.Create()
+			if err != nil {
+				logger.Reason(err).Error("Starting the VirtualMachineInstance failed.")
+				return nil, err
+			}
+			logger.Info("Domain started.")
+		}
+		pinVhostThreads(vmi, domain)
 	} else if cli.IsPaused(domState) && !l.paused.contains(vmi.UID) {
 		// TODO: if state change reason indicates a system error, we could try something smarter
 		err := dom.Resume()
@@ -1530,6 +1829,8 @@ func getAttachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	return res
 }
 
+var getImageInfo = api.GetImageInfo
+
 var isHotplugBlockDeviceVolume = isHotplugBlockDeviceVolumeFunc
 
 func isHotplugBlockDeviceVolumeFunc(volumeName string) bool {
@@ -1628,6 +1929,260 @@ func (l *LibvirtDomainManager) PauseVMI(vmi *v1.VirtualMachineInstance) error {
 	return nil
 }
 
+// GetNetworkInterfacesState returns the state of the VMI interfaces plugged by virt-launcher
+func (l *LibvirtDomainManager) GetNetworkInterfacesState() []api.NetworkInterfaceState {
+	return network.GetNetworkInterfacesState()
+}
+
+// PlugNetworkInterfaces re-runs the second phase of the network setup against the
+// existing domain, e.g. to restart a DHCP server which failed to start before.
+// The devices of the running domain are not updated, interfaces whose device
+// would change are refused with a network.NetworkSetupReasonDomainChanged error.
+func (l *LibvirtDomainManager) PlugNetworkInterfaces(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during network plug.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain spec failed during network plug.")
+		return err
+	}
+
+	domain := &api.Domain{Spec: *domainSpec}
+	if err := network.ReplugPodNetworkPhase2(vmi, domain); err != nil {
+		return fmt.Errorf("plugging the pod network failed: %w", err)
+	}
+	return nil
+}
+
+// AnnounceNetworkInterfaces announces the guest interfaces on the network, so that
+// switches learn the new location of the guest after a migration.
+func (l *LibvirtDomainManager) AnnounceNetworkInterfaces(vmi *v1.VirtualMachineInstance) error {
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain failed during network announcement.")
+		return err
+	}
+	defer dom.Free()
+
+	if _, err := dom.QemuMonitorCommand(announceSelfCommand, libvirt.DOMAIN_QEMU_MONITOR_COMMAND_DEFAULT); err != nil {
+		return fmt.Errorf("announcing the guest interfaces failed: %v", err)
+	}
+	return nil
+}
+
+// ExecQMPCommand executes a read-only QMP command without arguments on the qemu monitor
+// of the domain and returns the result of the command as JSON.
+func (l *LibvirtDomainManager) ExecQMPCommand(vmi *v1.VirtualMachineInstance, command string) (string, error) {
+	if !api.IsReadOnlyQMPCommand(command) {
+		return "", fmt.Errorf("QMP command %q is not allowed", command)
+	}
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return "", fmt.Errorf("Domain not found.")
+		}
+		log.Log.Object(vmi).Reason(err).Error("Getting the domain failed during QMP command execution.")
+		return "", err
+	}
+	defer dom.Free()
+
+	// the command is built here, so that no arguments can be passed to it
+	output, err := dom.QemuMonitorCommand(fmt.Sprintf(`{"execute":"%s"}`, command), libvirt.DOMAIN_QEMU_MONITOR_COMMAND_DEFAULT)
+	if err != nil {
+		return "", fmt.Errorf("executing QMP command %s failed: %v", command, err)
+	}
+
+	reply := struct {
+		Return json.RawMessage `json:"return"`
+	}{}
+	if err := json.Unmarshal([]byte(output), &reply); err != nil {
+		return "", fmt.Errorf("failed to parse the reply of QMP command %s: %v", command, err)
+	}
+	return string(reply.Return), nil
+}
+
+// FreezeVMI freezes the guest file systems through the guest agent, so that
+// the volumes can be snapshotted consistently. They are thawed by UnfreezeVMI,
+// or after fsFreezeTimeout at the latest, so that a lost thaw request doesn't
+// block the guest.
+func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	if l.fsThawTimer != nil {
+		logger.V(3).Info("Guest file systems are already frozen.")
+		return nil
+	}
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during freeze.")
+		return err
+	}
+	defer dom.Free()
+
+	if err := dom.FSFreeze(nil, 0); err != nil {
+		return fmt.Errorf("freezing the guest file systems failed: %v", err)
+	}
+	logger.Info("Froze the guest file systems.")
+	l.setFSFreezeStatus(api.FSFrozen)
+
+	l.fsThawTimer = time.AfterFunc(fsFreezeTimeout, func() {
+		logger.Warningf("Guest file systems were not thawed within %v, thawing them.", fsFreezeTimeout)
+		if err := l.UnfreezeVMI(vmi); err != nil {
+			logger.Reason(err).Error("Thawing the guest file systems failed.")
+		}
+	})
+	return nil
+}
+
+// UnfreezeVMI thaws the guest file systems frozen by FreezeVMI.
+func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	if l.fsThawTimer == nil {
+		logger.V(3).Info("Guest file systems are not frozen.")
+		return nil
+	}
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			l.fsThawTimer.Stop()
+			l.fsThawTimer = nil
+			l.setFSFreezeStatus(api.FSThawed)
+			return nil
+		}
+		logger.Reason(err).Error("Getting the domain failed during thaw.")
+		return err
+	}
+	defer dom.Free()
+
+	if err := dom.FSThaw(nil, 0); err != nil {
+		return fmt.Errorf("thawing the guest file systems failed: %v", err)
+	}
+	logger.Info("Thawed the guest file systems.")
+
+	l.fsThawTimer.Stop()
+	l.fsThawTimer = nil
+	l.setFSFreezeStatus(api.FSThawed)
+	return nil
+}
+
+// setFSFreezeStatus publishes the freeze state of the guest file systems with
+// the guest agent data, from where it is reported in the domain status
+func (l *LibvirtDomainManager) setFSFreezeStatus(status string) {
+	if l.agentData != nil {
+		l.agentData.Store(agentpoller.GET_FSFREEZE_STATUS, api.FSFreeze{Status: status})
+	}
+}
+
+// HibernateVMI saves the guest memory to the hibernation claim and shuts off the domain.
+// The memory is restored the next time the VMI starts.
+func (l *LibvirtDomainManager) HibernateVMI(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during hibernation.")
+		return err
+	}
+	defer dom.Free()
+
+	domState, reason, err := dom.GetState()
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain state failed.")
+		return err
+	}
+	if util.ConvState(domState) == api.Shutoff && util.ConvReason(domState, reason) == api.ReasonSaved {
+		logger.Info("Domain is already hibernated.")
+		return nil
+	}
+	if domState != libvirt.DOMAIN_RUNNING && domState != libvirt.DOMAIN_PAUSED {
+		return fmt.Errorf("domain can't be hibernated in state %s", util.ConvState(domState))
+	}
+
+	if err := dom.Save(config.GetHibernationImagePath()); err != nil {
+		logger.Reason(err).Error("Saving the domain failed.")
+		// don't restore a partially written memory on the next start
+		if err := os.Remove(config.GetHibernationImagePath()); err != nil && !os.IsNotExist(err) {
+			logger.Reason(err).Error("Removing the partially saved memory failed.")
+		}
+		return err
+	}
+	l.paused.remove(vmi.UID)
+	logger.Info("Domain hibernated.")
+	return nil
+}
+
+// restoreHibernatedDomain resumes a VMI from the memory saved when it was hibernated. It returns
+// false if there is no saved memory and the domain has to be booted instead.
+func (l *LibvirtDomainManager) restoreHibernatedDomain(vmi *v1.VirtualMachineInstance, dom cli.VirDomain) (bool, error) {
+	logger := log.Log.Object(vmi)
+
+	imagePath := config.GetHibernationImagePath()
+	if _, err := os.Stat(imagePath); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	xml, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE)
+	if err != nil {
+		return false, err
+	}
+	if err := l.virConn.DomainRestore(imagePath, xml); err != nil {
+		// the saved memory can't be restored if e.g. the devices of the VMI changed
+		// since it was hibernated. Booting the guest would discard it, keep it and
+		// the VMI from starting instead.
+		logger.Reason(err).Error("Restoring the hibernated domain failed.")
+		return false, &domainerrors.HibernationRestoreError{Err: err}
+	}
+
+	// the memory is restored only once, the next start boots the VMI
+	if err := os.Remove(imagePath); err != nil {
+		logger.Reason(err).Errorf("Removing the restored memory %s failed.", imagePath)
+	}
+	return true, nil
+}
+
 func (l *LibvirtDomainManager) UnpauseVMI(vmi *v1.VirtualMachineInstance) error {
 	l.domainModifyLock.Lock()
 	defer l.domainModifyLock.Unlock()
@@ -1881,6 +2436,7 @@ func (l *LibvirtDomainManager) GetDomainStats() ([]*stats.DomainStats, error) {
 
 func (l *LibvirtDomainManager) buildDevicesMetadata(vmi *v1.VirtualMachineInstance, dom cli.VirDomain) ([]cloudinit.DeviceData, error) {
 	taggedInterfaces := make(map[string]v1.Interface)
+	taggedDisks := make(map[string]v1.Disk)
 	var devicesMetadata []cloudinit.DeviceData
 
 	// Get all tagged interfaces for lookup
@@ -1890,6 +2446,13 @@ func (l *LibvirtDomainManager) buildDevicesMetadata(vmi *v1.VirtualMachineInstan
 		}
 	}
 
+	// Get all tagged disks for lookup
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Tag != "" {
+			taggedDisks[disk.Name] = disk
+		}
+	}
+
 	devices, err := getAllDomainDevices(dom)
 	if err != nil {
 		return nil, err
@@ -1897,26 +2460,48 @@ func (l *LibvirtDomainManager) buildDevicesMetadata(vmi *v1.VirtualMachineInstan
 	interfaces := devices.Interfaces
 	for _, nic := range interfaces {
 		if data, exist := taggedInterfaces[nic.Alias.Name]; exist {
-			address := nic.Address
 			var mac string
 			if nic.MAC != nil {
 				mac = nic.MAC.MAC
 			}
-			pciAddrStr := fmt.Sprintf("%s:%s:%s:%s", address.Domain[2:], address.Bus[2:], address.Slot[2:], address.Function[2:])
 			deviceData := cloudinit.DeviceData{
 				Type:    cloudinit.NICMetadataType,
 				Bus:     nic.Address.Type,
-				Address: pciAddrStr,
+				Address: formatDeviceAddress(nic.Address),
 				MAC:     mac,
 				Tags:    []string{data.Tag},
 			}
 			devicesMetadata = append(devicesMetadata, deviceData)
 		}
 	}
+	for _, disk := range devices.Disks {
+		if disk.Alias == nil || disk.Address == nil {
+			continue
+		}
+		if data, exist := taggedDisks[disk.Alias.Name]; exist {
+			deviceData := cloudinit.DeviceData{
+				Type:    cloudinit.DiskMetadataType,
+				Bus:     disk.Address.Type,
+				Address: formatDeviceAddress(disk.Address),
+				Serial:  disk.Serial,
+				Tags:    []string{data.Tag},
+			}
+			devicesMetadata = append(devicesMetadata, deviceData)
+		}
+	}
 	return devicesMetadata, nil
 
 }
 
+// formatDeviceAddress formats PCI addresses as domain:bus:slot:function
+// without the hex prefixes, and drive addresses as controller:bus:target:unit
+func formatDeviceAddress(address *api.Address) string {
+	if address.Type == "drive" {
+		return fmt.Sprintf("%s:%s:%s:%s", address.Controller, address.Bus, address.Target, address.Unit)
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", address.Domain[2:], address.Bus[2:], address.Slot[2:], address.Function[2:])
+}
+
 // GetGuestInfo queries the agent store and return the aggregated data from Guest agent
 func (l *LibvirtDomainManager) GetGuestInfo() (v1.VirtualMachineInstanceGuestAgentInfo, error) {
 	sysInfo := l.agentData.GetSysInfo()
@@ -25,7 +25,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,10 +46,14 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
+	"kubevirt.io/kubevirt/pkg/config"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 )
@@ -126,11 +132,96 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xml), nil)
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
 		})
+		It("should restore a hibernated VirtualMachineInstance and sync the guest time", func() {
+			timeSync := make(chan *api.TimeSyncMetadata, 1)
+			defer close(timeSync)
+
+			hibernationDir, err := ioutil.TempDir("", "hibernation")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(hibernationDir)
+			defer func(dir string) { config.HibernationSourceDir = dir }(config.HibernationSourceDir)
+			config.HibernationSourceDir = hibernationDir
+			Expect(ioutil.WriteFile(config.GetHibernationImagePath(), []byte("memory"), 0600)).To(Succeed())
+
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free().AnyTimes()
+			StubOutNetworkForTest()
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
+			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
+
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainXml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+			metadataXml, err := xml.MarshalIndent(domainSpec.Metadata.KubeVirt, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+			mockConn.EXPECT().DomainDefineXML(string(domainXml)).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTOFF, int(libvirt.DOMAIN_SHUTOFF_SAVED), nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE).Return(string(domainXml), nil)
+			mockConn.EXPECT().DomainRestore(config.GetHibernationImagePath(), string(domainXml)).Return(nil)
+			// no call to create
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).AnyTimes().Return(string(domainXml), nil)
+
+			// the guest clock is an hour behind
+			mockDomain.EXPECT().GetTime(uint32(0)).Return(time.Now().Add(-time.Hour).Unix(), uint(0), nil)
+			mockDomain.EXPECT().SetTime(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockDomain.EXPECT().
+				GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).
+				Return(string(metadataXml), nil)
+			mockConn.EXPECT().DomainDefineXML(gomock.Any()).Do(func(domXml string) {
+				newSpec := &api.DomainSpec{}
+				Expect(xml.Unmarshal([]byte(domXml), newSpec)).To(Succeed())
+				timeSync <- newSpec.Metadata.KubeVirt.TimeSync
+			}).Return(mockDomain, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newspec).ToNot(BeNil())
+
+			var reported *api.TimeSyncMetadata
+			Eventually(timeSync, 20*time.Second).Should(Receive(&reported), "the guest time sync wasn't reported")
+			Expect(reported.Succeeded).To(BeTrue())
+			_, err = os.Stat(config.GetHibernationImagePath())
+			Expect(os.IsNotExist(err)).To(BeTrue(), "the memory is only restored once")
+		})
+		It("should keep the hibernated memory and not boot the VirtualMachineInstance if the restore fails", func() {
+			hibernationDir, err := ioutil.TempDir("", "hibernation")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(hibernationDir)
+			defer func(dir string) { config.HibernationSourceDir = dir }(config.HibernationSourceDir)
+			config.HibernationSourceDir = hibernationDir
+			Expect(ioutil.WriteFile(config.GetHibernationImagePath(), []byte("memory"), 0600)).To(Succeed())
+
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free().AnyTimes()
+			StubOutNetworkForTest()
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
+
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainXml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+			mockConn.EXPECT().DomainDefineXML(string(domainXml)).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTOFF, int(libvirt.DOMAIN_SHUTOFF_SAVED), nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DOMAIN_XML_MIGRATABLE).Return(string(domainXml), nil)
+			mockConn.EXPECT().DomainRestore(config.GetHibernationImagePath(), string(domainXml)).
+				Return(libvirt.Error{Code: libvirt.ERR_CONFIG_UNSUPPORTED})
+			// no call to create
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).AnyTimes().Return(string(domainXml), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+
+			_, err = manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).To(HaveOccurred())
+			_, ok := err.(*domainerrors.HibernationRestoreError)
+			Expect(ok).To(BeTrue())
+			Expect(config.GetHibernationImagePath()).To(BeAnExistingFile())
+		})
 		It("should define and start a new VirtualMachineInstance with userData", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
@@ -149,7 +240,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xml), nil)
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -171,7 +262,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xml), nil)
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -186,7 +277,7 @@ var _ = Describe("Manager", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -205,7 +296,7 @@ var _ = Describe("Manager", func() {
 				mockDomain.EXPECT().Create().Return(nil)
 				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xml), nil)
 				mockDomain.EXPECT().Free()
-				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 				newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 				Expect(err).To(BeNil())
 				Expect(newspec).ToNot(BeNil())
@@ -226,7 +317,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
 			mockDomain.EXPECT().Resume().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -241,7 +332,7 @@ var _ = Describe("Manager", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
 			mockDomain.EXPECT().Suspend().Return(nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 
 			err = manager.PauseVMI(vmi)
 			Expect(err).To(BeNil())
@@ -264,7 +355,7 @@ var _ = Describe("Manager", func() {
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
 			mockDomain.EXPECT().Suspend().Return(nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 
 			err := manager.PauseVMI(vmi)
 			Expect(err).To(BeNil())
@@ -276,39 +367,151 @@ var _ = Describe("Manager", func() {
 
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			// no call to suspend
 
 			err := manager.PauseVMI(vmi)
 			Expect(err).To(BeNil())
 		})
-		It("should unpause a VirtualMachineInstance", func() {
-			isSetTimeCalled := make(chan bool, 1)
-			defer close(isSetTimeCalled)
+		It("should freeze and thaw the guest file systems of a VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free().Times(2)
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil).Times(2)
+			mockDomain.EXPECT().FSFreeze(nil, uint32(0)).Return(nil)
+			mockDomain.EXPECT().FSThaw(nil, uint32(0)).Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+
+			Expect(manager.FreezeVMI(vmi)).To(Succeed())
+			// freezing again doesn't touch the frozen domain
+			Expect(manager.FreezeVMI(vmi)).To(Succeed())
+			Expect(manager.UnfreezeVMI(vmi)).To(Succeed())
+			// thawing again doesn't touch the thawed domain
+			Expect(manager.UnfreezeVMI(vmi)).To(Succeed())
+		})
+		It("should hibernate a VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
 
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().Save(config.GetHibernationImagePath()).Return(nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+
+			err := manager.HibernateVMI(vmi)
+			Expect(err).To(BeNil())
+		})
+		It("should not try to hibernate a hibernated VirtualMachineInstance", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
 			vmi := newVMI(testNamespace, testVmName)
 
-			mockConn.EXPECT().LookupDomainByName(testDomainName).MaxTimes(2).Return(mockDomain, nil)
-			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
-			mockDomain.EXPECT().Resume().Return(nil)
-			mockDomain.EXPECT().SetTime(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Do(func(interface{}, interface{}, interface{}) {
-				isSetTimeCalled <- true
-			})
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTOFF, int(libvirt.DOMAIN_SHUTOFF_SAVED), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+			// no call to save
+
+			err := manager.HibernateVMI(vmi)
+			Expect(err).To(BeNil())
+		})
+		It("should execute a read-only QMP command", func() {
+			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			vmi := newVMI(testNamespace, testVmName)
 
-			err := manager.UnpauseVMI(vmi)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().QemuMonitorCommand(`{"execute":"query-status"}`, libvirt.DOMAIN_QEMU_MONITOR_COMMAND_DEFAULT).
+				Return(`{"return":{"running":true,"singlestep":false,"status":"running"},"id":"libvirt-42"}`, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+
+			result, err := manager.ExecQMPCommand(vmi, "query-status")
 			Expect(err).To(BeNil())
-			Eventually(func() bool {
-				select {
-				case isCalled := <-isSetTimeCalled:
-					return isCalled
-				default:
-				}
-				return false
-			}, 20*time.Second, 1).Should(BeTrue(), "SetTime wasn't called")
+			Expect(result).To(Equal(`{"running":true,"singlestep":false,"status":"running"}`))
+		})
+		It("should not execute a QMP command which is not read-only", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+			// no call to the monitor
+
+			_, err := manager.ExecQMPCommand(vmi, "system_powerdown")
+			Expect(err).To(HaveOccurred())
+		})
+		It("should unpause a VirtualMachineInstance and report the guest time sync", func() {
+			timeSync := make(chan *api.TimeSyncMetadata, 1)
+			defer close(timeSync)
+
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free().AnyTimes()
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainXml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+			metadataXml, err := xml.MarshalIndent(domainSpec.Metadata.KubeVirt, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+			mockDomain.EXPECT().Resume().Return(nil)
+			// the guest clock is an hour behind
+			mockDomain.EXPECT().GetTime(uint32(0)).Return(time.Now().Add(-time.Hour).Unix(), uint(0), nil)
+			mockDomain.EXPECT().SetTime(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domainXml), nil)
+			mockDomain.EXPECT().
+				GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).
+				Return(string(metadataXml), nil)
+			mockConn.EXPECT().DomainDefineXML(gomock.Any()).Do(func(domXml string) {
+				newSpec := &api.DomainSpec{}
+				Expect(xml.Unmarshal([]byte(domXml), newSpec)).To(Succeed())
+				timeSync <- newSpec.Metadata.KubeVirt.TimeSync
+			}).Return(mockDomain, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+
+			err = manager.UnpauseVMI(vmi)
+			Expect(err).To(BeNil())
+
+			var reported *api.TimeSyncMetadata
+			Eventually(timeSync, 20*time.Second).Should(Receive(&reported), "the guest time sync wasn't reported")
+			Expect(reported.Succeeded).To(BeTrue())
+			Expect(reported.Timestamp).ToNot(BeNil())
+			Expect(reported.DriftMilliseconds).ToNot(BeNil())
+			Expect(*reported.DriftMilliseconds).To(BeNumerically("~", -time.Hour.Milliseconds(), 5000))
+		})
+		It("should report that the guest time could not be synced", func() {
+			timeSync := make(chan *api.TimeSyncMetadata, 1)
+			defer close(timeSync)
+
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free().AnyTimes()
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+			domainXml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+			metadataXml, err := xml.MarshalIndent(domainSpec.Metadata.KubeVirt, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
+			mockDomain.EXPECT().GetTime(uint32(0)).Return(int64(0), uint(0), libvirt.Error{Code: libvirt.ERR_ARGUMENT_UNSUPPORTED})
+			mockDomain.EXPECT().SetTime(gomock.Any(), gomock.Any(), gomock.Any()).Return(libvirt.Error{Code: libvirt.ERR_ARGUMENT_UNSUPPORTED})
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domainXml), nil)
+			mockDomain.EXPECT().
+				GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).
+				Return(string(metadataXml), nil)
+			mockConn.EXPECT().DomainDefineXML(gomock.Any()).Do(func(domXml string) {
+				newSpec := &api.DomainSpec{}
+				Expect(xml.Unmarshal([]byte(domXml), newSpec)).To(Succeed())
+				timeSync <- newSpec.Metadata.KubeVirt.TimeSync
+			}).Return(mockDomain, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+
+			Expect(manager.SetGuestTime(vmi)).To(Succeed())
+
+			var reported *api.TimeSyncMetadata
+			Eventually(timeSync, 20*time.Second).Should(Receive(&reported), "the guest time sync wasn't reported")
+			Expect(reported.Succeeded).To(BeFalse())
+			Expect(reported.DriftMilliseconds).To(BeNil())
+			Expect(reported.Message).To(Equal("the guest agent is not configured"))
 		})
 		It("should not try to unpause a running VirtualMachineInstance", func() {
 			// Make sure that we always free the domain after use
@@ -317,7 +520,7 @@ var _ = Describe("Manager", func() {
 
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			// no call to unpause
 			err := manager.UnpauseVMI(vmi)
 			Expect(err).To(BeNil())
@@ -450,7 +653,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().AttachDevice(strings.ToLower(string(attachBytes)))
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xmlDomain2), nil)
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -562,7 +765,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().DetachDevice(strings.ToLower(string(detachBytes)))
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xmlDomain), nil)
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -643,7 +846,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xmlDomain), nil)
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -747,7 +950,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().Create().Return(nil)
 			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xmlDomain2), nil)
 			mockDomain.EXPECT().Free()
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
 			Expect(err).To(BeNil())
 			Expect(newspec).ToNot(BeNil())
@@ -778,7 +981,7 @@ var _ = Describe("Manager", func() {
 				Expect(strings.Contains(xml, "<markedForGracefulShutdown>true</markedForGracefulShutdown>")).To(BeTrue())
 				return mockDomain, nil
 			})
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 
 			manager.MarkGracefulShutdownVMI(vmi)
 		})
@@ -968,7 +1171,7 @@ var _ = Describe("Manager", func() {
 			mockDomain.EXPECT().AbortJob().MaxTimes(1)
 			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_MIGRATABLE)).AnyTimes().Return(string(xml), nil)
 			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DOMAIN_XML_INACTIVE)).AnyTimes().Return(string(xml), nil)
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			manager.CancelVMIMigration(vmi)
 
 		})
@@ -1002,7 +1205,7 @@ var _ = Describe("Manager", func() {
 				AnyTimes().
 				Return(string(metadataXml), nil)
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			err = manager.CancelVMIMigration(vmi)
 			Expect(err).To(BeNil())
 		})
@@ -1029,10 +1232,48 @@ var _ = Describe("Manager", func() {
 				TargetPod:    "fakepod",
 			}
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			err := manager.PrepareMigrationTarget(vmi, true)
 			Expect(err).To(BeNil())
 		})
+		It("should define the LUKS secrets of encrypted volumes on the target", func() {
+			updateHostsFile = func(entry string) error {
+				return nil
+			}
+			StubOutNetworkForTest()
+			keyDir, err := ioutil.TempDir("", "disk-encryption")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(keyDir)
+			defer func(dir string) { config.DiskEncryptionSourceDir = dir }(config.DiskEncryptionSourceDir)
+			config.DiskEncryptionSourceDir = keyDir
+			Expect(os.MkdirAll(filepath.Join(keyDir, "encrypted"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(keyDir, "encrypted", config.DiskEncryptionSecretKey), []byte("passphrase"), 0600)).To(Succeed())
+			defer func() { isBlockDeviceVolume = isBlockDeviceVolumeFunc }()
+			isBlockDeviceVolume = func(volumeName string) (bool, error) {
+				return true, nil
+			}
+
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "encrypted"}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "encrypted",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "encrypted"},
+				},
+				Encryption: &v1.VolumeEncryption{SecretRef: &k8sv1.LocalObjectReference{Name: "luks"}},
+			}}
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: "111222333",
+				TargetPod:    "fakepod",
+			}
+			mockConn.EXPECT().DefineSecret(gomock.Any(), []byte("passphrase")).DoAndReturn(func(secretXML string, value []byte) error {
+				Expect(secretXML).To(ContainSubstring(api.DiskEncryptionSecretUUID(vmi, "encrypted")))
+				return nil
+			})
+
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
+			Expect(manager.PrepareMigrationTarget(vmi, true)).To(Succeed())
+		})
 		It("should verify that migration failure is set in the monitor thread", func() {
 			isMigrationFailedSet := make(chan bool, 1)
 
@@ -1058,7 +1299,7 @@ var _ = Describe("Manager", func() {
 			domainSpec := expectIsolationDetectionForVMI(vmi)
 			domainSpec.Metadata.KubeVirt.Migration = &api.MigrationMetadata{}
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 
 			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
 			mockDomain.EXPECT().GetState().AnyTimes().Return(libvirt.DOMAIN_RUNNING, 1, nil)
@@ -1115,7 +1356,7 @@ var _ = Describe("Manager", func() {
 				UID: vmi.Status.MigrationState.MigrationUID,
 			}
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 
@@ -1226,7 +1467,7 @@ var _ = Describe("Manager", func() {
 				mockDomain.EXPECT().Free()
 				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 				mockDomain.EXPECT().UndefineFlags(libvirt.DOMAIN_UNDEFINE_NVRAM).Return(nil)
-				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 				err := manager.DeleteVMI(newVMI(testNamespace, testVmName))
 				Expect(err).To(BeNil())
 			},
@@ -1240,7 +1481,7 @@ var _ = Describe("Manager", func() {
 				mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
 				mockDomain.EXPECT().GetState().Return(state, 1, nil)
 				mockDomain.EXPECT().DestroyFlags(libvirt.DOMAIN_DESTROY_GRACEFUL).Return(nil)
-				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+				manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 				err := manager.KillVMI(newVMI(testNamespace, testVmName))
 				Expect(err).To(BeNil())
 			},
@@ -1297,7 +1538,7 @@ var _ = Describe("Manager", func() {
 				AnyTimes().
 				Return("<kubevirt></kubevirt>", nil)
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			doms, err := manager.ListAllDomains()
 
 			Expect(len(doms)).To(Equal(1))
@@ -1326,7 +1567,7 @@ var _ = Describe("Manager", func() {
 				&stats.DomainStats{},
 			}, nil)
 
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 			domStats, err := manager.GetDomainStats()
 
 			Expect(err).To(BeNil())
@@ -1336,7 +1577,7 @@ var _ = Describe("Manager", func() {
 
 	Context("on failed GetDomainSpecWithRuntimeInfo", func() {
 		It("should fall back to returning domain spec without runtime info", func() {
-			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF")
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0, nil, "/usr/share/OVMF", "")
 
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
 
@@ -1583,6 +1824,143 @@ var _ = Describe("getDetachedDisks", func() {
 	)
 })
 
+var _ = Describe("resolveEmulator", func() {
+	var imageDir, sidecarDir string
+	var manager *LibvirtDomainManager
+
+	addEmulator := func(dir, name string, mode os.FileMode) {
+		Expect(os.MkdirAll(filepath.Join(dir, name), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, name, emulatorBinary), []byte{}, mode)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		imageDir, err = ioutil.TempDir("", "emulators")
+		Expect(err).ToNot(HaveOccurred())
+		sidecarDir, err = ioutil.TempDir("", "sidecar-emulators")
+		Expect(err).ToNot(HaveOccurred())
+		manager = &LibvirtDomainManager{emulatorDirs: []string{sidecarDir, imageDir}}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(imageDir)
+		os.RemoveAll(sidecarDir)
+	})
+
+	It("should leave the emulator to libvirt if none is selected", func() {
+		Expect(manager.resolveEmulator()).To(BeEmpty())
+	})
+
+	It("should prefer the emulators provided by hook sidecars", func() {
+		addEmulator(imageDir, "qemu-6.0", 0755)
+		manager.emulator = "qemu-6.0"
+		Expect(manager.resolveEmulator()).To(Equal(filepath.Join(imageDir, "qemu-6.0", emulatorBinary)))
+
+		addEmulator(sidecarDir, "qemu-6.0", 0755)
+		Expect(manager.resolveEmulator()).To(Equal(filepath.Join(sidecarDir, "qemu-6.0", emulatorBinary)))
+	})
+
+	It("should fail if the emulator is missing or not executable", func() {
+		manager.emulator = "qemu-6.0"
+		_, err := manager.resolveEmulator()
+		Expect(err).To(MatchError(ContainSubstring("emulator qemu-6.0 not found")))
+
+		addEmulator(imageDir, "qemu-6.0", 0644)
+		_, err = manager.resolveEmulator()
+		Expect(err).To(MatchError(ContainSubstring("is not an executable file")))
+	})
+})
+
+var _ = Describe("host capabilities", func() {
+	It("should take the host NUMA nodes from the capabilities passed by virt-handler", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.CPU = &v1.CPU{NUMA: &v1.NUMA{GuestMappingPassthrough: &v1.NUMAGuestMappingPassthrough{}}}
+		capabilities := &cmdv1.HostCapabilities{NumaNodes: []*cmdv1.NUMANode{
+			{Id: 0, Cpus: []uint32{0, 1}},
+			{Id: 1, Cpus: []uint32{2, 3}},
+		}}
+
+		Expect(getHostNUMANodes(vmi, capabilities)).To(Equal([]hardware.NUMANode{
+			{ID: 0, CPUs: []int{0, 1}},
+			{ID: 1, CPUs: []int{2, 3}},
+		}))
+	})
+
+	It("should not need the host NUMA nodes without guest NUMA mapping", func() {
+		Expect(getHostNUMANodes(v1.NewMinimalVMI("testvmi"), nil)).To(BeEmpty())
+	})
+
+	It("should read the info of a container disk image only once", func() {
+		calls := 0
+		getImageInfo = func(image string) (*containerdisk.DiskInfo, error) {
+			calls++
+			return &containerdisk.DiskInfo{Format: "qcow2"}, nil
+		}
+		defer func() { getImageInfo = api.GetImageInfo }()
+
+		manager := &LibvirtDomainManager{}
+		for i := 0; i < 2; i++ {
+			info, err := manager.getCachedImageInfo("/var/run/kubevirt-ephemeral-disks/disk-data/disk/disk.img")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Format).To(Equal("qcow2"))
+		}
+		Expect(calls).To(Equal(1))
+	})
+})
+
+var _ = Describe("vhost-net threads", func() {
+	var tmpDir string
+
+	addTask := func(pid, tid int, comm string) {
+		taskDir := filepath.Join(procDir, strconv.Itoa(pid), "task", strconv.Itoa(tid))
+		Expect(os.MkdirAll(taskDir, 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(taskDir, "comm"), []byte(comm+"\n"), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "vhost")
+		Expect(err).ToNot(HaveOccurred())
+		qemuPidDir = filepath.Join(tmpDir, "qemu")
+		procDir = filepath.Join(tmpDir, "proc")
+		Expect(os.MkdirAll(qemuPidDir, 0755)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+		qemuPidDir = "/var/run/libvirt/qemu"
+		procDir = "/proc"
+	})
+
+	It("should read the pid of the qemu process from its pid file", func() {
+		Expect(ioutil.WriteFile(filepath.Join(qemuPidDir, "default_testvmi.pid"), []byte("42\n"), 0644)).To(Succeed())
+		Expect(qemuPid("default_testvmi")).To(Equal(42))
+	})
+
+	It("should find the vhost-net threads of the qemu process", func() {
+		addTask(42, 42, "qemu-kvm")
+		addTask(42, 43, "vhost-42")
+		addTask(42, 44, "CPU 0/KVM")
+		addTask(42, 45, "vhost-42")
+		Expect(vhostThreads(42)).To(Equal([]int{43, 45}))
+	})
+
+	It("should find no vhost-net threads if they are kernel threads", func() {
+		addTask(42, 42, "qemu-kvm")
+		Expect(vhostThreads(42)).To(BeEmpty())
+	})
+
+	It("should collect the pCPUs of all vCPUs", func() {
+		cpuTune := &api.CPUTune{VCPUPin: []api.CPUTuneVCPUPin{
+			{VCPU: 0, CPUSet: "5"},
+			{VCPU: 1, CPUSet: "2"},
+			{VCPU: 2, CPUSet: "3-4"},
+		}}
+		Expect(vcpuPinCPUs(cpuTune)).To(Equal([]int{2, 3, 4, 5}))
+		Expect(vcpuPinCPUs(nil)).To(BeEmpty())
+	})
+})
+
 func newVMI(namespace, name string) *v1.VirtualMachineInstance {
 	vmi := v1.NewMinimalVMIWithNS(namespace, name)
 	v1.SetObjectDefaults_VirtualMachineInstance(vmi)
@@ -0,0 +1,182 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package metadataservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/net/dns"
+)
+
+const (
+	// Address is the link-local address the metadata service listens on, the
+	// same as on most clouds
+	Address = "169.254.169.254"
+	port    = "80"
+
+	// FlavorHeader has to be set to Flavor on every request, which protects
+	// the service from requests forged by applications in the guest
+	FlavorHeader = "Metadata-Flavor"
+	Flavor       = "KubeVirt"
+
+	metadataPath       = "/latest/meta-data"
+	userDataPath       = "/latest/user-data"
+	serviceAccountPath = "/latest/service-account/"
+)
+
+// the files of the service account which are served
+var serviceAccountFiles = map[string]bool{
+	"token":     true,
+	"namespace": true,
+	"ca.crt":    true,
+}
+
+// Metadata is the document served on /latest/meta-data
+type Metadata struct {
+	InstanceID    string            `json:"instance-id"`
+	UID           string            `json:"uid"`
+	Name          string            `json:"name"`
+	Namespace     string            `json:"namespace"`
+	LocalHostname string            `json:"local-hostname"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+// MetadataService serves the metadata of a VMI to its guest
+type MetadataService struct {
+	lock              sync.Mutex
+	metadata          Metadata
+	userData          string
+	serviceAccountDir string
+}
+
+// NewMetadataService creates a service for the vmi. The service account files
+// are read from serviceAccountDir on every request, as the token is rotated.
+func NewMetadataService(vmi *v1.VirtualMachineInstance, userData string, serviceAccountDir string) *MetadataService {
+	s := &MetadataService{
+		userData:          userData,
+		serviceAccountDir: serviceAccountDir,
+	}
+	s.SetVMI(vmi)
+	return s
+}
+
+// SetVMI updates the metadata, to serve the current labels of the vmi
+func (s *MetadataService) SetVMI(vmi *v1.VirtualMachineInstance) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.metadata = Metadata{
+		InstanceID:    fmt.Sprintf("%s.%s", vmi.Name, vmi.Namespace),
+		UID:           string(vmi.UID),
+		Name:          vmi.Name,
+		Namespace:     vmi.Namespace,
+		LocalHostname: dns.SanitizeHostname(vmi),
+		Labels:        vmi.Labels,
+	}
+}
+
+// Start serves the metadata on the metadata address for the lifetime of
+// virt-launcher. The address has to be assigned to an interface of the pod.
+func (s *MetadataService) Start() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(Address, port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on the metadata address: %v", err)
+	}
+
+	server := &http.Server{
+		Handler:      s,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Log.Reason(err).Error("the metadata service stopped")
+		}
+	}()
+	log.Log.Infof("serving the metadata on %s", listener.Addr())
+	return nil
+}
+
+func (s *MetadataService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get(FlavorHeader) != Flavor {
+		http.Error(w, fmt.Sprintf("missing header %s: %s", FlavorHeader, Flavor), http.StatusForbidden)
+		return
+	}
+	w.Header().Set(FlavorHeader, Flavor)
+
+	switch {
+	case r.URL.Path == metadataPath || r.URL.Path == metadataPath+"/":
+		s.serveMetadata(w)
+	case r.URL.Path == userDataPath:
+		s.serveUserData(w)
+	case strings.HasPrefix(r.URL.Path, serviceAccountPath) && serviceAccountFiles[strings.TrimPrefix(r.URL.Path, serviceAccountPath)]:
+		s.serveServiceAccountFile(w, strings.TrimPrefix(r.URL.Path, serviceAccountPath))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *MetadataService) serveMetadata(w http.ResponseWriter) {
+	s.lock.Lock()
+	body, err := json.Marshal(s.metadata)
+	s.lock.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func (s *MetadataService) serveUserData(w http.ResponseWriter) {
+	if s.userData == "" {
+		http.Error(w, "the vmi has no user-data", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(s.userData))
+}
+
+func (s *MetadataService) serveServiceAccountFile(w http.ResponseWriter, name string) {
+	body, err := ioutil.ReadFile(filepath.Join(s.serviceAccountDir, name))
+	if os.IsNotExist(err) {
+		http.Error(w, "the vmi has no service account", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(body)
+}
@@ -0,0 +1,136 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package metadataservice
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("MetadataService", func() {
+	var serviceAccountDir string
+	var vmi *v1.VirtualMachineInstance
+	var service *MetadataService
+
+	get := func(path string, flavor string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		if flavor != "" {
+			request.Header.Set(FlavorHeader, flavor)
+		}
+		recorder := httptest.NewRecorder()
+		service.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		var err error
+		serviceAccountDir, err = ioutil.TempDir("", "serviceaccount")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(serviceAccountDir, "token"), []byte("secret-token"), 0600)).To(Succeed())
+
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+		vmi.Labels = map[string]string{"app": "db"}
+		service = NewMetadataService(vmi, "#cloud-config\n", serviceAccountDir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(serviceAccountDir)
+	})
+
+	It("should serve the metadata of the vmi", func() {
+		response := get("/latest/meta-data", Flavor)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Header().Get(FlavorHeader)).To(Equal(Flavor))
+
+		metadata := Metadata{}
+		Expect(json.Unmarshal(response.Body.Bytes(), &metadata)).To(Succeed())
+		Expect(metadata).To(Equal(Metadata{
+			InstanceID:    "testvmi.default",
+			UID:           "1234",
+			Name:          "testvmi",
+			Namespace:     "default",
+			LocalHostname: "testvmi",
+			Labels:        map[string]string{"app": "db"},
+		}))
+	})
+
+	It("should serve the current labels of the vmi", func() {
+		updated := vmi.DeepCopy()
+		updated.Labels["tier"] = "backend"
+		service.SetVMI(updated)
+
+		metadata := Metadata{}
+		Expect(json.Unmarshal(get("/latest/meta-data/", Flavor).Body.Bytes(), &metadata)).To(Succeed())
+		Expect(metadata.Labels).To(HaveKeyWithValue("tier", "backend"))
+	})
+
+	It("should serve the user-data and the service account token", func() {
+		response := get("/latest/user-data", Flavor)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Body.String()).To(Equal("#cloud-config\n"))
+
+		response = get("/latest/service-account/token", Flavor)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Body.String()).To(Equal("secret-token"))
+	})
+
+	It("should read the token on every request", func() {
+		Expect(ioutil.WriteFile(filepath.Join(serviceAccountDir, "token"), []byte("rotated-token"), 0600)).To(Succeed())
+		Expect(get("/latest/service-account/token", Flavor).Body.String()).To(Equal("rotated-token"))
+	})
+
+	It("should reject requests without the metadata flavor", func() {
+		Expect(get("/latest/service-account/token", "").Code).To(Equal(http.StatusForbidden))
+		Expect(get("/latest/service-account/token", "Google").Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should reject other methods than GET", func() {
+		request := httptest.NewRequest(http.MethodPost, "/latest/meta-data", nil)
+		request.Header.Set(FlavorHeader, Flavor)
+		recorder := httptest.NewRecorder()
+		service.ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	table.DescribeTable("should not find", func(path string) {
+		Expect(get(path, Flavor).Code).To(Equal(http.StatusNotFound))
+	},
+		table.Entry("unknown paths", "/latest/dynamic"),
+		table.Entry("missing service account files", "/latest/service-account/ca.crt"),
+		table.Entry("other files of the service account directory", "/latest/service-account/../token"),
+		table.Entry("nested service account paths", "/latest/service-account/token/x"),
+	)
+
+	It("should not find the user-data of a vmi without user-data", func() {
+		service = NewMetadataService(vmi, "", serviceAccountDir)
+		Expect(get("/latest/user-data", Flavor).Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,237 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	etherTypeARP  = 0x0806
+	etherTypeIPv6 = 0x86dd
+
+	arpRequest             = 1
+	icmpv6NeighborAdvert   = 136
+	icmpv6OverrideFlag     = 0x20
+	ndOptTargetLinkAddress = 2
+	ndHopLimit             = 255
+
+	// frames shorter than the ethernet minimum are padded by the sender
+	minEthernetFrameLength = 60
+)
+
+var (
+	ethernetBroadcast = net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	ipv6AllNodes      = net.ParseIP("ff02::1")
+	ipv6AllNodesMAC   = net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x01}
+)
+
+// NeedsNetworkAnnouncement tells if the VMI has bindings whose addresses are
+// announced on the pod network after the VMI started or migrated.
+func NeedsNetworkAnnouncement(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Bridge != nil || iface.Masquerade != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// AnnounceNetworkInterfaces sends gratuitous ARPs and unsolicited neighbor
+// advertisements for the bridge and masquerade interfaces of the VMI, so that
+// switches and peers learn where the VMI runs right after it started or
+// migrated, instead of waiting for their caches to expire. Bridge interfaces
+// announce the MAC and IPs of the guest on the bridge, masquerade interfaces
+// the ones of the pod interface which the guest is reached through.
+// doNetNS has to execute the passed function in the network namespace of the
+// virt-launcher pod.
+func AnnounceNetworkInterfaces(vmi *v1.VirtualMachineInstance, pid int, doNetNS func(func() error) error) error {
+	initHandler()
+
+	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		iface := &vmi.Spec.Domain.Devices.Interfaces[i]
+		if iface.Bridge == nil && iface.Masquerade == nil {
+			continue
+		}
+		if _, exists := networks[iface.Name]; !exists {
+			return fmt.Errorf("failed to find a network %s", iface.Name)
+		}
+		podInterfaceName := getPodInterfaceName(networks, cniNetworks, iface.Name)
+
+		vif := &VIF{}
+		exists, err := readFromCachedFile(strconv.Itoa(pid), iface.Name, vifCacheFile, vif)
+		if err != nil {
+			return fmt.Errorf("failed to read the cached vif of interface %s: %v", iface.Name, err)
+		}
+		// the interface is not plugged yet
+		if !exists {
+			continue
+		}
+
+		err = doNetNS(func() error {
+			device, mac, ips, err := announcedAddresses(iface, vif, podInterfaceName)
+			if err != nil {
+				return err
+			}
+			frames := announcementFrames(mac, ips)
+			if len(frames) == 0 {
+				return nil
+			}
+			return Handler.SendEthernetFrames(device, frames)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to announce interface %s: %v", iface.Name, err)
+		}
+		log.Log.Object(vmi).V(4).Infof("announced the addresses of interface %s", iface.Name)
+	}
+	return nil
+}
+
+// announcedAddresses returns the device to announce the interface on, and
+// the MAC and IPs which are announced
+func announcedAddresses(iface *v1.Interface, vif *VIF, podInterfaceName string) (string, net.HardwareAddr, []net.IP, error) {
+	if iface.Bridge != nil {
+		var ips []net.IP
+		if vif.IP.IPNet != nil {
+			ips = append(ips, vif.IP.IP)
+		}
+		if vif.IPv6.IPNet != nil {
+			ips = append(ips, vif.IPv6.IP)
+		}
+		return fmt.Sprintf("k6t-%s", podInterfaceName), vif.MAC, ips, nil
+	}
+
+	link, err := Handler.LinkByName(podInterfaceName)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	addrs, err := Handler.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if addr.IPNet != nil && addr.IP.IsGlobalUnicast() {
+			ips = append(ips, addr.IP)
+		}
+	}
+	return podInterfaceName, link.Attrs().HardwareAddr, ips, nil
+}
+
+func announcementFrames(mac net.HardwareAddr, ips []net.IP) [][]byte {
+	if len(mac) == 0 {
+		return nil
+	}
+	var frames [][]byte
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			frames = append(frames, gratuitousARPFrame(mac, ip))
+		} else {
+			frames = append(frames, unsolicitedNAFrame(mac, ip))
+		}
+	}
+	return frames
+}
+
+func ethernetHeader(dst net.HardwareAddr, src net.HardwareAddr, etherType uint16) []byte {
+	header := make([]byte, 14)
+	copy(header[0:6], dst)
+	copy(header[6:12], src)
+	binary.BigEndian.PutUint16(header[12:14], etherType)
+	return header
+}
+
+// gratuitousARPFrame builds a broadcast ARP request for the IP, which asks
+// for the IP itself and carries the MAC as the sender hardware address
+func gratuitousARPFrame(mac net.HardwareAddr, ip net.IP) []byte {
+	arp := make([]byte, 28)
+	binary.BigEndian.PutUint16(arp[0:2], 1) // ethernet
+	binary.BigEndian.PutUint16(arp[2:4], 0x0800)
+	arp[4] = 6
+	arp[5] = 4
+	binary.BigEndian.PutUint16(arp[6:8], arpRequest)
+	copy(arp[8:14], mac)
+	copy(arp[14:18], ip.To4())
+	copy(arp[24:28], ip.To4())
+
+	frame := append(ethernetHeader(ethernetBroadcast, mac, etherTypeARP), arp...)
+	if len(frame) < minEthernetFrameLength {
+		frame = append(frame, make([]byte, minEthernetFrameLength-len(frame))...)
+	}
+	return frame
+}
+
+// unsolicitedNAFrame builds a neighbor advertisement for the IP to all
+// nodes, which overrides the cached link layer address of the IP with the MAC
+func unsolicitedNAFrame(mac net.HardwareAddr, ip net.IP) []byte {
+	icmp := make([]byte, 32)
+	icmp[0] = icmpv6NeighborAdvert
+	icmp[4] = icmpv6OverrideFlag
+	copy(icmp[8:24], ip.To16())
+	icmp[24] = ndOptTargetLinkAddress
+	icmp[25] = 1 // in units of 8 bytes
+	copy(icmp[26:32], mac)
+	binary.BigEndian.PutUint16(icmp[2:4], icmpv6Checksum(ip, ipv6AllNodes, icmp))
+
+	ipv6 := make([]byte, 40)
+	ipv6[0] = 6 << 4
+	binary.BigEndian.PutUint16(ipv6[4:6], uint16(len(icmp)))
+	ipv6[6] = 58 // ICMPv6
+	ipv6[7] = ndHopLimit
+	copy(ipv6[8:24], ip.To16())
+	copy(ipv6[24:40], ipv6AllNodes.To16())
+
+	frame := ethernetHeader(ipv6AllNodesMAC, mac, etherTypeIPv6)
+	frame = append(frame, ipv6...)
+	return append(frame, icmp...)
+}
+
+// icmpv6Checksum computes the checksum of the ICMPv6 message, including the
+// IPv6 pseudo header
+func icmpv6Checksum(src net.IP, dst net.IP, message []byte) uint16 {
+	pseudoHeader := make([]byte, 40)
+	copy(pseudoHeader[0:16], src.To16())
+	copy(pseudoHeader[16:32], dst.To16())
+	binary.BigEndian.PutUint32(pseudoHeader[32:36], uint32(len(message)))
+	pseudoHeader[39] = 58
+
+	var sum uint32
+	for _, data := range [][]byte{pseudoHeader, message} {
+		for i := 0; i+1 < len(data); i += 2 {
+			sum += uint32(binary.BigEndian.Uint16(data[i : i+2]))
+		}
+		if len(data)%2 == 1 {
+			sum += uint32(data[len(data)-1]) << 8
+		}
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
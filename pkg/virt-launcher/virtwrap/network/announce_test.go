@@ -0,0 +1,137 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+)
+
+var _ = Describe("Network announcement", func() {
+	var tmpDir string
+	var ctrl *gomock.Controller
+	var mockNetwork *MockNetworkHandler
+	const pid = 1234
+
+	mac, _ := net.ParseMAC("de:ad:00:00:be:af")
+
+	doNetNS := func(f func() error) error {
+		return f()
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "announcetest")
+		Expect(err).ToNot(HaveOccurred())
+		setVifCacheFile(tmpDir + "/vif-cache-%s-%s.json")
+
+		ctrl = gomock.NewController(GinkgoT())
+		mockNetwork = NewMockNetworkHandler(ctrl)
+		Handler = mockNetwork
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should build a gratuitous ARP request", func() {
+		frame := gratuitousARPFrame(mac, net.ParseIP("10.244.0.8"))
+		Expect(frame).To(HaveLen(60))
+		Expect(frame[0:14]).To(Equal([]byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xde, 0xad, 0x00, 0x00, 0xbe, 0xaf,
+			0x08, 0x06,
+		}))
+		Expect(frame[14:42]).To(Equal([]byte{
+			0x00, 0x01, 0x08, 0x00, 6, 4, 0x00, 0x01,
+			0xde, 0xad, 0x00, 0x00, 0xbe, 0xaf, 10, 244, 0, 8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 10, 244, 0, 8,
+		}))
+	})
+
+	It("should build an unsolicited neighbor advertisement", func() {
+		ip := net.ParseIP("fd10:244::8")
+		frame := unsolicitedNAFrame(mac, ip)
+		Expect(frame).To(HaveLen(86))
+		Expect(frame[0:14]).To(Equal([]byte{
+			0x33, 0x33, 0x00, 0x00, 0x00, 0x01,
+			0xde, 0xad, 0x00, 0x00, 0xbe, 0xaf,
+			0x86, 0xdd,
+		}))
+
+		ipv6 := frame[14:54]
+		Expect(ipv6[6]).To(Equal(byte(58)))
+		Expect(ipv6[7]).To(Equal(byte(255)))
+		Expect(net.IP(ipv6[8:24]).Equal(ip)).To(BeTrue())
+		Expect(net.IP(ipv6[24:40]).Equal(net.ParseIP("ff02::1"))).To(BeTrue())
+
+		icmp := frame[54:]
+		Expect(icmp[0]).To(Equal(byte(136)))
+		Expect(icmp[4]).To(Equal(byte(0x20)))
+		Expect(net.IP(icmp[8:24]).Equal(ip)).To(BeTrue())
+		Expect(icmp[24:32]).To(Equal([]byte{2, 1, 0xde, 0xad, 0x00, 0x00, 0xbe, 0xaf}))
+		// a message with a valid checksum sums up to zero
+		Expect(icmpv6Checksum(ip, net.ParseIP("ff02::1"), icmp)).To(BeZero())
+	})
+
+	It("should announce the guest addresses of bridge interfaces on the bridge", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		ip, _ := netlink.ParseAddr("10.244.0.8/24")
+		ipv6, _ := netlink.ParseAddr("fd10:244::8/64")
+		bridge := &BridgePodInterface{vif: &VIF{Name: "eth0", MAC: mac, IP: *ip, IPv6: *ipv6}}
+		Expect(bridge.setCachedVIF(strconv.Itoa(pid), "default")).To(Succeed())
+
+		mockNetwork.EXPECT().SendEthernetFrames("k6t-eth0", [][]byte{
+			gratuitousARPFrame(mac, ip.IP),
+			unsolicitedNAFrame(mac, ipv6.IP),
+		}).Return(nil)
+		Expect(AnnounceNetworkInterfaces(vmi, pid, doNetNS)).To(Succeed())
+	})
+
+	It("should announce the global addresses of the pod interface of masquerade interfaces", func() {
+		vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+		ip, _ := netlink.ParseAddr("10.0.2.2/24")
+		masquerade := &MasqueradePodInterface{vif: &VIF{Name: "eth0", MAC: mac, IP: *ip}}
+		Expect(masquerade.setCachedVIF(strconv.Itoa(pid), "default")).To(Succeed())
+
+		podMAC, _ := net.ParseMAC("0a:58:0a:f4:00:08")
+		link := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth0", HardwareAddr: podMAC}}
+		podIP, _ := netlink.ParseAddr("10.244.0.8/24")
+		linkLocal, _ := netlink.ParseAddr("fe80::858:aff:fef4:8/64")
+
+		mockNetwork.EXPECT().LinkByName("eth0").Return(link, nil)
+		mockNetwork.EXPECT().AddrList(link, netlink.FAMILY_ALL).Return([]netlink.Addr{*podIP, *linkLocal}, nil)
+		mockNetwork.EXPECT().SendEthernetFrames("eth0", [][]byte{gratuitousARPFrame(podMAC, podIP.IP)}).Return(nil)
+		Expect(AnnounceNetworkInterfaces(vmi, pid, doNetNS)).To(Succeed())
+	})
+
+	It("should not announce interfaces which are not plugged yet", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		Expect(AnnounceNetworkInterfaces(vmi, pid, doNetNS)).To(Succeed())
+	})
+})
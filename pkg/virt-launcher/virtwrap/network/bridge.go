@@ -0,0 +1,90 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// the kernel expects the ageing time of a bridge in hundredths of a second
+const bridgeAgeingTimeUnitsPerSecond = 100
+
+var bridgeConfig struct {
+	lock   sync.Mutex
+	config *v1.BridgeConfiguration
+}
+
+// SetBridgeConfiguration sets the cluster wide parameters of the bridges
+// which are created from now on. virt-handler updates them before it plugs
+// the interfaces of a VMI, bridges which already exist are left as they are.
+func SetBridgeConfiguration(config *v1.BridgeConfiguration) {
+	bridgeConfig.lock.Lock()
+	defer bridgeConfig.lock.Unlock()
+	bridgeConfig.config = config.DeepCopy()
+}
+
+func getBridgeConfiguration() *v1.BridgeConfiguration {
+	bridgeConfig.lock.Lock()
+	defer bridgeConfig.lock.Unlock()
+	if bridgeConfig.config == nil {
+		return &v1.BridgeConfiguration{}
+	}
+	return bridgeConfig.config.DeepCopy()
+}
+
+// newBridge returns the bridge to create in the pod, with the cluster wide
+// parameters which netlink can set on creation
+func newBridge(attrs netlink.LinkAttrs, config *v1.BridgeConfiguration) *netlink.Bridge {
+	bridge := &netlink.Bridge{
+		LinkAttrs:         attrs,
+		MulticastSnooping: config.MulticastSnooping,
+		VlanFiltering:     config.VLANFiltering,
+	}
+	if config.AgeingTimeSeconds != nil {
+		ageingTime := *config.AgeingTimeSeconds * bridgeAgeingTimeUnitsPerSecond
+		bridge.AgeingTime = &ageingTime
+	}
+	return bridge
+}
+
+// addBridge creates the bridge and applies the cluster wide parameters to it
+func addBridge(attrs netlink.LinkAttrs) (*netlink.Bridge, error) {
+	config := getBridgeConfiguration()
+	bridge := newBridge(attrs, config)
+	if err := Handler.LinkAdd(bridge); err != nil {
+		return nil, err
+	}
+	if config.STP != nil && *config.STP {
+		if err := Handler.LinkSetBridgeSTP(bridge, true); err != nil {
+			return nil, fmt.Errorf("failed to enable STP on bridge %s: %v", attrs.Name, err)
+		}
+	}
+	if config.MulticastQuerier != nil && *config.MulticastQuerier {
+		if err := Handler.LinkSetBridgeMulticastQuerier(bridge, true); err != nil {
+			return nil, fmt.Errorf("failed to enable the multicast querier on bridge %s: %v", attrs.Name, err)
+		}
+	}
+	return bridge, nil
+}
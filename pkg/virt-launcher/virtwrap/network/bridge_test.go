@@ -0,0 +1,105 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Bridge configuration", func() {
+	var ctrl *gomock.Controller
+	var mockNetwork *MockNetworkHandler
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockNetwork = NewMockNetworkHandler(ctrl)
+		Handler = mockNetwork
+	})
+
+	AfterEach(func() {
+		SetBridgeConfiguration(nil)
+		ctrl.Finish()
+	})
+
+	It("should keep the kernel defaults without a configuration", func() {
+		mockNetwork.EXPECT().LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "k6t-eth0"}}).Return(nil)
+
+		bridge, err := addBridge(netlink.LinkAttrs{Name: "k6t-eth0"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bridge.Name).To(Equal("k6t-eth0"))
+	})
+
+	It("should create the bridge with the configured parameters", func() {
+		ageingTimeSeconds := uint32(30)
+		SetBridgeConfiguration(&v1.BridgeConfiguration{
+			STP:               pointer.BoolPtr(true),
+			AgeingTimeSeconds: &ageingTimeSeconds,
+			MulticastSnooping: pointer.BoolPtr(false),
+			VLANFiltering:     pointer.BoolPtr(true),
+		})
+		ageingTime := uint32(3000)
+		expected := &netlink.Bridge{
+			LinkAttrs:         netlink.LinkAttrs{Name: "k6t-eth0", MTU: 1450},
+			AgeingTime:        &ageingTime,
+			MulticastSnooping: pointer.BoolPtr(false),
+			VlanFiltering:     pointer.BoolPtr(true),
+		}
+		mockNetwork.EXPECT().LinkAdd(expected).Return(nil)
+		mockNetwork.EXPECT().LinkSetBridgeSTP(expected, true).Return(nil)
+
+		_, err := addBridge(netlink.LinkAttrs{Name: "k6t-eth0", MTU: 1450})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not touch STP if it is disabled", func() {
+		SetBridgeConfiguration(&v1.BridgeConfiguration{STP: pointer.BoolPtr(false)})
+		mockNetwork.EXPECT().LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "k6t-eth0"}}).Return(nil)
+
+		_, err := addBridge(netlink.LinkAttrs{Name: "k6t-eth0"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should enable the multicast querier", func() {
+		SetBridgeConfiguration(&v1.BridgeConfiguration{MulticastQuerier: pointer.BoolPtr(true)})
+		expected := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "k6t-eth0"}}
+		mockNetwork.EXPECT().LinkAdd(expected).Return(nil)
+		mockNetwork.EXPECT().LinkSetBridgeMulticastQuerier(expected, true).Return(nil)
+
+		_, err := addBridge(netlink.LinkAttrs{Name: "k6t-eth0"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fail if STP can not be enabled", func() {
+		SetBridgeConfiguration(&v1.BridgeConfiguration{STP: pointer.BoolPtr(true)})
+		mockNetwork.EXPECT().LinkAdd(gomock.Any()).Return(nil)
+		mockNetwork.EXPECT().LinkSetBridgeSTP(gomock.Any(), true).Return(fmt.Errorf("not supported"))
+
+		_, err := addBridge(netlink.LinkAttrs{Name: "k6t-eth0"})
+		Expect(err).To(MatchError("failed to enable STP on bridge k6t-eth0: not supported"))
+	})
+})
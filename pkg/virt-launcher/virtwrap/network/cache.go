@@ -0,0 +1,168 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/dhcp"
+)
+
+// Network cache files are shared between virt-handler and virt-launcher and
+// outlive a KubeVirt upgrade for VMIs which keep running, so the components
+// of two releases read each other's files during an upgrade.
+//
+// Readers understand both the legacy format, in which the cached object is
+// stored as-is (version 0), and a versioned envelope. The writers are switched
+// to the envelope in two steps: the NetworkCacheVersioning feature gate makes
+// virt-handler and virt-launcher write the envelope and virt-handler convert
+// the files of running VMIs on startup. It may only be enabled once no
+// virt-launcher which reads the legacy format only is left, and it becomes
+// the default in the following release.
+const (
+	legacyCacheFileVersion  = 0
+	currentCacheFileVersion = 1
+)
+
+type versionedCacheFile struct {
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// cacheFileWriteVersion is the schema version cache files are written with.
+var cacheFileWriteVersion = legacyCacheFileVersion
+
+// EnableVersionedCacheFiles makes the current process write cache files in
+// the versioned envelope. It has to be called before any network is set up.
+func EnableVersionedCacheFiles() {
+	cacheFileWriteVersion = currentCacheFileVersion
+}
+
+func marshalCacheFile(obj interface{}) ([]byte, error) {
+	if cacheFileWriteVersion == legacyCacheFileVersion {
+		return json.MarshalIndent(obj, "", "  ")
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(&versionedCacheFile{Version: cacheFileWriteVersion, Data: data}, "", "  ")
+}
+
+// unmarshalCacheFile decodes both versioned and legacy cache files into obj
+// and returns the schema version the file was written with.
+func unmarshalCacheFile(buf []byte, obj interface{}) (int, error) {
+	var envelope versionedCacheFile
+	if err := json.Unmarshal(buf, &envelope); err == nil && envelope.Version != legacyCacheFileVersion && envelope.Data != nil {
+		if envelope.Version > currentCacheFileVersion {
+			return envelope.Version, fmt.Errorf("unsupported cache file version %d", envelope.Version)
+		}
+		return envelope.Version, json.Unmarshal(envelope.Data, obj)
+	}
+	return legacyCacheFileVersion, json.Unmarshal(buf, obj)
+}
+
+// UnmarshalCacheFile decodes a network cache file written by any supported
+// version of virt-handler or virt-launcher.
+func UnmarshalCacheFile(buf []byte, obj interface{}) error {
+	_, err := unmarshalCacheFile(buf, obj)
+	return err
+}
+
+// migrateCacheFile rewrites the cache file at path in the version cache files
+// are written with. Missing files and files which are already up to date are
+// left untouched.
+func migrateCacheFile(path string, obj interface{}) error {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	version, err := unmarshalCacheFile(buf, obj)
+	if err != nil {
+		return fmt.Errorf("error unmarshaling cache file %s: %v", path, err)
+	}
+	if version == cacheFileWriteVersion {
+		return nil
+	}
+
+	buf, err = marshalCacheFile(obj)
+	if err != nil {
+		return fmt.Errorf("error marshaling cache file %s: %v", path, err)
+	}
+	if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+		return fmt.Errorf("error writing cache file %s: %v", path, err)
+	}
+	log.Log.V(4).Infof("migrated network cache file %s from version %d to %d", path, version, cacheFileWriteVersion)
+	return nil
+}
+
+// MigrateCacheFiles converts the network cache files of a running VMI to the
+// version cache files are written with. It is executed by virt-handler on
+// startup, so that VMIs started before an upgrade keep working with the new
+// components.
+func MigrateCacheFiles(vmi *v1.VirtualMachineInstance, pid int) error {
+	pidStr := fmt.Sprintf("%d", pid)
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if err := migrateCacheFile(getInterfaceCacheFile(util.VMIInterfacepath, string(vmi.UID), iface.Name), &PodCacheInterface{}); err != nil {
+			return err
+		}
+		if err := migrateCacheFile(getInterfaceCacheFile(interfaceCacheFile, pidStr, iface.Name), &api.Interface{}); err != nil {
+			return err
+		}
+		if err := migrateCacheFile(getVifFilePath(pidStr, iface.Name), &VIF{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dhcpLeaseCache persists the lease handed out by the DHCP server of an
+// interface, so that the server honors it after a restart of virt-launcher.
+type dhcpLeaseCache struct {
+	pid  string
+	name string
+}
+
+func newDHCPLeaseCache(pid, name string) dhcp.LeaseStore {
+	return &dhcpLeaseCache{pid: pid, name: name}
+}
+
+func (c *dhcpLeaseCache) Load() (*dhcp.Lease, error) {
+	var lease *dhcp.Lease
+	if _, err := readFromCachedFile(c.pid, c.name, dhcpLeaseCacheFile, &lease); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+func (c *dhcpLeaseCache) Store(lease *dhcp.Lease) error {
+	return writeToCachedFile(lease, dhcpLeaseCacheFile, c.pid, c.name)
+}
@@ -0,0 +1,195 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/dhcp"
+)
+
+var _ = Describe("Network cache files", func() {
+	var tmpDir string
+	var origVMIInterfacepath string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "cachetest")
+		Expect(err).ToNot(HaveOccurred())
+		setInterfaceCacheFile(tmpDir + "/interface-cache-%s-%s.json")
+		setVifCacheFile(tmpDir + "/vif-cache-%s-%s.json")
+		dhcpLeaseCacheFile = tmpDir + "/dhcp-lease-%s-%s.json"
+		origVMIInterfacepath = util.VMIInterfacepath
+		util.VMIInterfacepath = tmpDir + "/pod-cache-%s-%s.json"
+	})
+
+	AfterEach(func() {
+		util.VMIInterfacepath = origVMIInterfacepath
+		cacheFileWriteVersion = legacyCacheFileVersion
+		os.RemoveAll(tmpDir)
+	})
+
+	writeLegacyFile := func(path string, obj interface{}) {
+		buf, err := json.Marshal(obj)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ioutil.WriteFile(path, buf, 0644)).To(Succeed())
+	}
+
+	It("should write files in the legacy format by default", func() {
+		buf, err := marshalCacheFile(&api.Interface{Type: "ethernet"})
+		Expect(err).ToNot(HaveOccurred())
+
+		var iface api.Interface
+		Expect(json.Unmarshal(buf, &iface)).To(Succeed())
+		Expect(iface.Type).To(Equal("ethernet"))
+	})
+
+	It("should write files with the current version once versioned files are enabled", func() {
+		EnableVersionedCacheFiles()
+		buf, err := marshalCacheFile(&api.Interface{Type: "ethernet"})
+		Expect(err).ToNot(HaveOccurred())
+
+		var envelope versionedCacheFile
+		Expect(json.Unmarshal(buf, &envelope)).To(Succeed())
+		Expect(envelope.Version).To(Equal(currentCacheFileVersion))
+
+		var iface api.Interface
+		Expect(UnmarshalCacheFile(buf, &iface)).To(Succeed())
+		Expect(iface.Type).To(Equal("ethernet"))
+	})
+
+	It("should decode legacy files without a version header", func() {
+		buf, err := json.Marshal(&api.Interface{Type: "ethernet"})
+		Expect(err).ToNot(HaveOccurred())
+
+		var iface api.Interface
+		version, err := unmarshalCacheFile(buf, &iface)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal(legacyCacheFileVersion))
+		Expect(iface.Type).To(Equal("ethernet"))
+	})
+
+	It("should refuse files written by a newer version", func() {
+		buf := []byte(fmt.Sprintf(`{"version": %d, "data": {}}`, currentCacheFileVersion+1))
+
+		var iface api.Interface
+		_, err := unmarshalCacheFile(buf, &iface)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should decode files with a version header", func() {
+		buf := []byte(fmt.Sprintf(`{"version": %d, "data": {"Name": "eth0", "Mtu": 1410}}`, currentCacheFileVersion))
+
+		vif := &VIF{}
+		version, err := unmarshalCacheFile(buf, vif)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal(currentCacheFileVersion))
+		Expect(vif.Name).To(Equal("eth0"))
+		Expect(vif.Mtu).To(Equal(uint16(1410)))
+	})
+
+	Context("migration of a running VMI", func() {
+		var vmi *v1.VirtualMachineInstance
+		var podCachePath, ifaceCachePath, vifCachePath string
+
+		BeforeEach(func() {
+			vmi = newVMIMasqueradeInterface("testnamespace", "testVmName")
+			vmi.UID = "123"
+			ifaceName := vmi.Spec.Domain.Devices.Interfaces[0].Name
+
+			podCachePath = fmt.Sprintf(util.VMIInterfacepath, vmi.UID, ifaceName)
+			ifaceCachePath = fmt.Sprintf(interfaceCacheFile, "42", ifaceName)
+			vifCachePath = getVifFilePath("42", ifaceName)
+			writeLegacyFile(podCachePath, &PodCacheInterface{PodIP: "10.0.0.1"})
+			writeLegacyFile(ifaceCachePath, &api.Interface{Type: "ethernet"})
+			writeLegacyFile(vifCachePath, &VIF{Name: "eth0", Mtu: 1410})
+		})
+
+		readVersion := func(path string) int {
+			buf, err := ioutil.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			version, err := unmarshalCacheFile(buf, &struct{}{})
+			Expect(err).ToNot(HaveOccurred())
+			return version
+		}
+
+		It("should convert legacy files once versioned files are enabled", func() {
+			EnableVersionedCacheFiles()
+			Expect(MigrateCacheFiles(vmi, 42)).To(Succeed())
+
+			for _, path := range []string{podCachePath, ifaceCachePath, vifCachePath} {
+				Expect(readVersion(path)).To(Equal(currentCacheFileVersion), path)
+			}
+
+			vif := &VIF{}
+			buf, err := ioutil.ReadFile(vifCachePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(UnmarshalCacheFile(buf, vif)).To(Succeed())
+			Expect(vif.Name).To(Equal("eth0"))
+			Expect(vif.Mtu).To(Equal(uint16(1410)))
+		})
+
+		It("should leave legacy files untouched while versioned files are disabled", func() {
+			Expect(MigrateCacheFiles(vmi, 42)).To(Succeed())
+
+			for _, path := range []string{podCachePath, ifaceCachePath, vifCachePath} {
+				Expect(readVersion(path)).To(Equal(legacyCacheFileVersion), path)
+			}
+		})
+	})
+
+	It("should ignore missing cache files", func() {
+		EnableVersionedCacheFiles()
+		vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+		Expect(MigrateCacheFiles(vmi, 42)).To(Succeed())
+	})
+
+	It("should persist DHCP leases", func() {
+		leases := newDHCPLeaseCache("self", "eth0")
+		lease, err := leases.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lease).To(BeNil())
+
+		expiry := time.Now().Add(time.Hour).Round(time.Second)
+		Expect(leases.Store(&dhcp.Lease{IP: net.ParseIP("10.0.2.2"), ClientID: []byte{1, 2}, Expiry: expiry})).To(Succeed())
+
+		lease, err = newDHCPLeaseCache("self", "eth0").Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lease.IP.Equal(net.ParseIP("10.0.2.2"))).To(BeTrue())
+		Expect(lease.ClientID).To(Equal([]byte{1, 2}))
+		Expect(lease.Expiry.Equal(expiry)).To(BeTrue())
+
+		Expect(leases.Store(nil)).To(Succeed())
+		lease, err = leases.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lease).To(BeNil())
+	})
+})
@@ -23,20 +23,22 @@ package network
 
 import (
 	"crypto/rand"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"syscall"
 
 	"github.com/coreos/go-iptables/iptables"
 	"github.com/opencontainers/selinux/go-selinux"
 	lmf "github.com/subgraph/libmacouflage"
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
 
+	"kubevirt.io/kubevirt/pkg/util/qos"
 	"kubevirt.io/kubevirt/pkg/util/sysctl"
 
 	netutils "k8s.io/utils/net"
@@ -47,6 +49,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/dhcp"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/dhcpv6"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network/tftp"
 )
 
 const (
@@ -74,6 +77,39 @@ type CriticalNetworkError struct {
 
 func (e *CriticalNetworkError) Error() string { return e.Msg }
 
+const (
+	NetworkSetupReasonCacheMissing    = "CacheMissing"
+	NetworkSetupReasonCacheLoadFailed = "CacheLoadFailed"
+	NetworkSetupReasonDomainConfig    = "DomainConfigFailed"
+	NetworkSetupReasonDHCP            = "DHCPFailed"
+	NetworkSetupReasonDomainChanged   = "DomainInterfaceChanged"
+)
+
+// NetworkSetupError is returned by the second phase of plugging an interface.
+// Unlike a CriticalNetworkError it does not fail the VMI, the sync is retried.
+// virt-launcher passes its Reason to virt-handler with the failed command.
+type NetworkSetupError struct {
+	Reason string
+	Msg    string
+}
+
+func (e *NetworkSetupError) Error() string {
+	return fmt.Sprintf("network setup failed (%s): %s", e.Reason, e.Msg)
+}
+
+// IsNetworkSetupReason tells whether reason is the Reason of a NetworkSetupError
+func IsNetworkSetupReason(reason string) bool {
+	switch reason {
+	case NetworkSetupReasonCacheMissing,
+		NetworkSetupReasonCacheLoadFailed,
+		NetworkSetupReasonDomainConfig,
+		NetworkSetupReasonDHCP,
+		NetworkSetupReasonDomainChanged:
+		return true
+	}
+	return false
+}
+
 func (vif VIF) String() string {
 	return fmt.Sprintf(
 		"VIF: { Name: %s, IP: %s, Mask: %s, IPv6: %s, MAC: %s, Gateway: %s, MTU: %d, IPAMDisabled: %t, TapDevice: %s}",
@@ -105,20 +141,40 @@ type NetworkHandler interface {
 	GenerateRandomMac() (net.HardwareAddr, error)
 	GetMacDetails(iface string) (net.HardwareAddr, error)
 	LinkSetMaster(link netlink.Link, master *netlink.Bridge) error
+	LinkSetHardwareAddr(link netlink.Link, hwaddr net.HardwareAddr) error
+	NeighSet(neigh *netlink.Neigh) error
 	StartDHCP(nic *VIF, serverAddr net.IP, bridgeInterfaceName string, dhcpOptions *v1.DHCPOptions) error
+	StartTFTPProxy(serverAddr net.IP, upstream string) error
 	HasNatIptables(proto iptables.Protocol) bool
 	IsIpv6Enabled(interfaceName string) (bool, error)
 	IsIpv4Primary() (bool, error)
-	ConfigureIpv6Forwarding() error
+	GetSysctl(name string) (int, error)
+	SetSysctl(name string, value int) error
 	IptablesNewChain(proto iptables.Protocol, table, chain string) error
 	IptablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error
+	IptablesDeleteRule(proto iptables.Protocol, table, chain string, rulespec ...string) error
+	IptablesChainExists(proto iptables.Protocol, table, chain string) (bool, error)
+	IptablesRuleExists(proto iptables.Protocol, table, chain string, rulespec ...string) (bool, error)
+	NftablesNewTable(proto iptables.Protocol, table string) error
 	NftablesNewChain(proto iptables.Protocol, table, chain string) error
+	NftablesNewBaseChain(proto iptables.Protocol, table, chain, chainType, hook string, priority int) error
 	NftablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error
+	NftablesFlushChain(proto iptables.Protocol, table, chain string) error
+	NftablesFlushTable(proto iptables.Protocol, table string) error
+	NftablesChainExists(proto iptables.Protocol, table, chain string) bool
 	NftablesLoad(fnName string) error
+	NftablesCheck(proto iptables.Protocol) error
 	GetNFTIPString(proto iptables.Protocol) string
 	CreateTapDevice(tapName string, queueNumber uint32, launcherPID int, mtu int) error
 	BindTapDeviceToBridge(tapName string, bridgeName string) error
+	ConfigureTapQoS(tapName string, class qos.Class) error
+	ConfigureTapOffloads(tapName string, offloads *v1.InterfaceOffloads) error
+	MirrorTapTraffic(tapName string, targetName string, direction v1.MirrorDirection) error
 	DisableTXOffloadChecksum(ifaceName string) error
+	DisableGRO(ifaceName string) error
+	SendEthernetFrames(ifaceName string, frames [][]byte) error
+	LinkSetBridgeSTP(link netlink.Link, enabled bool) error
+	LinkSetBridgeMulticastQuerier(link netlink.Link, enabled bool) error
 }
 
 type NetworkUtilsHandler struct{}
@@ -165,6 +221,12 @@ func (h *NetworkUtilsHandler) AddrAdd(link netlink.Link, addr *netlink.Addr) err
 func (h *NetworkUtilsHandler) LinkSetMaster(link netlink.Link, master *netlink.Bridge) error {
 	return netlink.LinkSetMaster(link, master)
 }
+func (h *NetworkUtilsHandler) LinkSetHardwareAddr(link netlink.Link, hwaddr net.HardwareAddr) error {
+	return netlink.LinkSetHardwareAddr(link, hwaddr)
+}
+func (h *NetworkUtilsHandler) NeighSet(neigh *netlink.Neigh) error {
+	return netlink.NeighSet(neigh)
+}
 func (h *NetworkUtilsHandler) HasNatIptables(proto iptables.Protocol) bool {
 	iptablesObject, err := iptables.NewWithProtocol(proto)
 	if err != nil {
@@ -181,9 +243,12 @@ func (h *NetworkUtilsHandler) HasNatIptables(proto iptables.Protocol) bool {
 	return true
 }
 
-func (h *NetworkUtilsHandler) ConfigureIpv6Forwarding() error {
-	err := sysctl.New().SetSysctl(sysctl.NetIPv6Forwarding, 1)
-	return err
+func (h *NetworkUtilsHandler) GetSysctl(name string) (int, error) {
+	return sysctl.New().GetSysctl(name)
+}
+
+func (h *NetworkUtilsHandler) SetSysctl(name string, value int) error {
+	return sysctl.New().SetSysctl(name, value)
 }
 
 func (h *NetworkUtilsHandler) IsIpv6Enabled(interfaceName string) (bool, error) {
@@ -228,6 +293,42 @@ func (h *NetworkUtilsHandler) IptablesAppendRule(proto iptables.Protocol, table,
 	return iptablesObject.Append(table, chain, rulespec...)
 }
 
+func (h *NetworkUtilsHandler) IptablesDeleteRule(proto iptables.Protocol, table, chain string, rulespec ...string) error {
+	iptablesObject, err := iptables.NewWithProtocol(proto)
+	if err != nil {
+		return err
+	}
+
+	return iptablesObject.Delete(table, chain, rulespec...)
+}
+
+func (h *NetworkUtilsHandler) IptablesChainExists(proto iptables.Protocol, table, chain string) (bool, error) {
+	iptablesObject, err := iptables.NewWithProtocol(proto)
+	if err != nil {
+		return false, err
+	}
+
+	chains, err := iptablesObject.ListChains(table)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range chains {
+		if c == chain {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (h *NetworkUtilsHandler) IptablesRuleExists(proto iptables.Protocol, table, chain string, rulespec ...string) (bool, error) {
+	iptablesObject, err := iptables.NewWithProtocol(proto)
+	if err != nil {
+		return false, err
+	}
+
+	return iptablesObject.Exists(table, chain, rulespec...)
+}
+
 func (h *NetworkUtilsHandler) NftablesNewChain(proto iptables.Protocol, table, chain string) error {
 	// #nosec g204 no risk to use GetNFTIPString as  argument as it returns either "ipv6" or "ip" strings
 	output, err := exec.Command("nft", "add", "chain", Handler.GetNFTIPString(proto), table, chain).CombinedOutput()
@@ -238,6 +339,29 @@ func (h *NetworkUtilsHandler) NftablesNewChain(proto iptables.Protocol, table, c
 	return nil
 }
 
+func (h *NetworkUtilsHandler) NftablesNewTable(proto iptables.Protocol, table string) error {
+	// #nosec g204 no risk to use GetNFTIPString as  argument as it returns either "ipv6" or "ip" strings
+	output, err := exec.Command("nft", "add", "table", Handler.GetNFTIPString(proto), table).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to add nft table %s error %s", table, string(output))
+	}
+
+	return nil
+}
+
+// NftablesNewBaseChain adds a chain of the given type attached to the given netfilter hook
+func (h *NetworkUtilsHandler) NftablesNewBaseChain(proto iptables.Protocol, table, chain, chainType, hook string, priority int) error {
+	cmd := []string{"add", "chain", Handler.GetNFTIPString(proto), table, chain,
+		"{", "type", chainType, "hook", hook, "priority", strconv.Itoa(priority), ";", "}"}
+	// #nosec No risk for attacket injection. CMD variables are predefined strings
+	output, err := exec.Command("nft", cmd...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to add nft chain %s error %s", chain, string(output))
+	}
+
+	return nil
+}
+
 func (h *NetworkUtilsHandler) NftablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error {
 	cmd := append([]string{"add", "rule", Handler.GetNFTIPString(proto), table, chain}, rulespec...)
 	// #nosec No risk for attacket injection. CMD variables are predefined strings
@@ -249,6 +373,34 @@ func (h *NetworkUtilsHandler) NftablesAppendRule(proto iptables.Protocol, table,
 	return nil
 }
 
+func (h *NetworkUtilsHandler) NftablesFlushChain(proto iptables.Protocol, table, chain string) error {
+	// #nosec g204 no risk to use GetNFTIPString as  argument as it returns either "ipv6" or "ip" strings
+	output, err := exec.Command("nft", "flush", "chain", Handler.GetNFTIPString(proto), table, chain).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to flush nft chain %s error %s", chain, string(output))
+	}
+
+	return nil
+}
+
+// NftablesFlushTable removes the rules of all chains of the table, the chains are kept
+func (h *NetworkUtilsHandler) NftablesFlushTable(proto iptables.Protocol, table string) error {
+	// #nosec g204 no risk to use GetNFTIPString as  argument as it returns either "ipv6" or "ip" strings
+	output, err := exec.Command("nft", "flush", "table", Handler.GetNFTIPString(proto), table).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to flush nft table %s error %s", table, string(output))
+	}
+
+	return nil
+}
+
+// NftablesChainExists tells if the chain can be listed, nft failing for chains and tables which don't exist
+func (h *NetworkUtilsHandler) NftablesChainExists(proto iptables.Protocol, table, chain string) bool {
+	// #nosec g204 no risk to use GetNFTIPString as  argument as it returns either "ipv6" or "ip" strings
+	err := exec.Command("nft", "list", "chain", Handler.GetNFTIPString(proto), table, chain).Run()
+	return err == nil
+}
+
 func (h *NetworkUtilsHandler) GetNFTIPString(proto iptables.Protocol) string {
 	if proto == iptables.ProtocolIPv6 {
 		return "ip6"
@@ -266,6 +418,22 @@ func (h *NetworkUtilsHandler) NftablesLoad(fnName string) error {
 
 	return nil
 }
+
+// NftablesCheck checks if the table of the masquerade binding and a nat chain
+// in it could be added, without adding them
+func (h *NetworkUtilsHandler) NftablesCheck(proto iptables.Protocol) error {
+	family := Handler.GetNFTIPString(proto)
+	cmd := fmt.Sprintf("add table %[1]s %[2]s; add chain %[1]s %[2]s postrouting { type nat hook postrouting priority 100 ; }",
+		family, kubevirtNftTable)
+	// #nosec g204 no risk to use Sprintf as argument as it only uses static strings
+	output, err := exec.Command("nft", "-c", cmd).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to check nft table %s error %s", kubevirtNftTable, string(output))
+	}
+
+	return nil
+}
+
 func (h *NetworkUtilsHandler) GetHostAndGwAddressesFromCIDR(s string) (string, string, error) {
 	ip, ipnet, err := net.ParseCIDR(s)
 	if err != nil {
@@ -362,6 +530,7 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *VIF, serverAddr net.IP, bridgeInter
 			searchDomains,
 			nic.Mtu,
 			dhcpOptions,
+			newDHCPLeaseCache("self", nic.Name),
 		); err != nil {
 			log.Log.Errorf("failed to run DHCP: %v", err)
 			panic(err)
@@ -383,6 +552,19 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *VIF, serverAddr net.IP, bridgeInter
 	return nil
 }
 
+// StartTFTPProxy relays the TFTP requests the guest sends to serverAddr to the
+// upstream TFTP server
+func (h *NetworkUtilsHandler) StartTFTPProxy(serverAddr net.IP, upstream string) error {
+	proxy := tftp.NewProxy(&net.UDPAddr{IP: serverAddr, Port: tftp.Port}, upstream)
+	// the guest can still boot from other devices, don't take the vm down
+	go func() {
+		if err := proxy.ListenAndServe(); err != nil {
+			log.Log.Reason(err).Error("failed to run the TFTP proxy")
+		}
+	}()
+	return nil
+}
+
 // Generate a random mac for interface
 // Avoid MAC address starting with reserved value 0xFE (https://github.com/kubevirt/kubevirt/issues/1494)
 func (h *NetworkUtilsHandler) GenerateRandomMac() (net.HardwareAddr, error) {
@@ -512,6 +694,121 @@ func (h *NetworkUtilsHandler) BindTapDeviceToBridge(tapName string, bridgeName s
 	return nil
 }
 
+// ConfigureTapQoS shapes the traffic sent to the guest through the tap device
+// with a single HTB class, which all packets fall into by default.
+func (h *NetworkUtilsHandler) ConfigureTapQoS(tapName string, class qos.Class) error {
+	tap, err := netlink.LinkByName(tapName)
+	if err != nil {
+		return fmt.Errorf("could not find tap device %s; %v", tapName, err)
+	}
+
+	qdisc := netlink.NewHtb(netlink.QdiscAttrs{
+		LinkIndex: tap.Attrs().Index,
+		Handle:    netlink.MakeHandle(1, 0),
+		Parent:    netlink.HANDLE_ROOT,
+	})
+	qdisc.Defcls = 1
+	if err := netlink.QdiscReplace(qdisc); err != nil {
+		return fmt.Errorf("failed to add htb qdisc to tap device %s; %v", tapName, err)
+	}
+
+	htbClass := netlink.NewHtbClass(netlink.ClassAttrs{
+		LinkIndex: tap.Attrs().Index,
+		Parent:    qdisc.Handle,
+		Handle:    netlink.MakeHandle(1, 1),
+	}, netlink.HtbClassAttrs{
+		// netlink expects bits per second
+		Rate: class.NetworkRate * 8,
+		Ceil: class.NetworkCeil * 8,
+		Prio: class.NetworkPrio,
+	})
+	if err := netlink.ClassReplace(htbClass); err != nil {
+		return fmt.Errorf("failed to add htb class to tap device %s; %v", tapName, err)
+	}
+
+	log.Log.Infof("Successfully configured QoS on tap device: %s", tapName)
+	return nil
+}
+
+func (h *NetworkUtilsHandler) ConfigureTapOffloads(tapName string, offloads *v1.InterfaceOffloads) error {
+	features := []struct {
+		name    string
+		enabled *bool
+		getCmd  uint32
+		setCmd  uint32
+	}{
+		{"tso", offloads.TSO, dhcp.ETHTOOL_GTSO, dhcp.ETHTOOL_STSO},
+		{"gso", offloads.GSO, dhcp.ETHTOOL_GGSO, dhcp.ETHTOOL_SGSO},
+		{"gro", offloads.GRO, dhcp.ETHTOOL_GGRO, dhcp.ETHTOOL_SGRO},
+	}
+	for _, feature := range features {
+		if feature.enabled == nil {
+			continue
+		}
+		if err := dhcp.EthtoolSetFeature(tapName, feature.getCmd, feature.setCmd, *feature.enabled); err != nil {
+			return fmt.Errorf("failed to set %s offload of tap device %s to %t; %v", feature.name, tapName, *feature.enabled, err)
+		}
+	}
+
+	log.Log.Infof("Successfully configured offloads on tap device: %s", tapName)
+	return nil
+}
+
+// MirrorTapTraffic copies the traffic of the tap device to the target
+// interface with mirred actions attached to a clsact qdisc, which leaves the
+// root qdisc of the tap device to the QoS shaping. The traffic sent by the
+// guest enters the tap device, the traffic received by the guest leaves it.
+func (h *NetworkUtilsHandler) MirrorTapTraffic(tapName string, targetName string, direction v1.MirrorDirection) error {
+	tap, err := netlink.LinkByName(tapName)
+	if err != nil {
+		return fmt.Errorf("could not find tap device %s; %v", tapName, err)
+	}
+	target, err := netlink.LinkByName(targetName)
+	if err != nil {
+		return fmt.Errorf("could not find mirror target interface %s; %v", targetName, err)
+	}
+
+	qdisc := &netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: tap.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+		QdiscType: "clsact",
+	}
+	if err := netlink.QdiscReplace(qdisc); err != nil {
+		return fmt.Errorf("failed to add clsact qdisc to tap device %s; %v", tapName, err)
+	}
+
+	var parents []uint32
+	if direction != v1.MirrorDirectionRX {
+		parents = append(parents, netlink.HANDLE_MIN_INGRESS)
+	}
+	if direction != v1.MirrorDirectionTX {
+		parents = append(parents, netlink.HANDLE_MIN_EGRESS)
+	}
+	for _, parent := range parents {
+		mirror := netlink.NewMirredAction(target.Attrs().Index)
+		mirror.MirredAction = netlink.TCA_EGRESS_MIRROR
+		mirror.Action = netlink.TC_ACT_PIPE
+		filter := &netlink.MatchAll{
+			FilterAttrs: netlink.FilterAttrs{
+				LinkIndex: tap.Attrs().Index,
+				Parent:    parent,
+				Priority:  1,
+				Protocol:  syscall.ETH_P_ALL,
+			},
+			Actions: []netlink.Action{mirror},
+		}
+		if err := netlink.FilterReplace(filter); err != nil {
+			return fmt.Errorf("failed to mirror tap device %s to %s; %v", tapName, targetName, err)
+		}
+	}
+
+	log.Log.Infof("Successfully mirrored tap device %s to %s", tapName, targetName)
+	return nil
+}
+
 func (h *NetworkUtilsHandler) DisableTXOffloadChecksum(ifaceName string) error {
 	if err := dhcp.EthtoolTXOff(ifaceName); err != nil {
 		log.Log.Reason(err).Errorf("Failed to set tx offload for interface %s off", ifaceName)
@@ -521,9 +818,86 @@ func (h *NetworkUtilsHandler) DisableTXOffloadChecksum(ifaceName string) error {
 	return nil
 }
 
+func (h *NetworkUtilsHandler) DisableGRO(ifaceName string) error {
+	if err := dhcp.EthtoolSetFeature(ifaceName, dhcp.ETHTOOL_GGRO, dhcp.ETHTOOL_SGRO, false); err != nil {
+		log.Log.Reason(err).Errorf("Failed to set gro for interface %s off", ifaceName)
+		return err
+	}
+
+	return nil
+}
+
+// SendEthernetFrames transmits complete ethernet frames, including their
+// link layer header, on the given interface
+func (h *NetworkUtilsHandler) SendEthernetFrames(ifaceName string, frames [][]byte) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		return err
+	}
+
+	// a protocol of zero makes the socket send-only
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open a packet socket: %v", err)
+	}
+	defer syscall.Close(fd)
+
+	for _, frame := range frames {
+		addr := &syscall.SockaddrLinklayer{
+			// the ethertype of the frame, in network byte order
+			Protocol: nl.NativeEndian().Uint16(frame[12:14]),
+			Ifindex:  link.Attrs().Index,
+		}
+		if err := syscall.Sendto(fd, frame, 0, addr); err != nil {
+			return fmt.Errorf("failed to send a frame on interface %s: %v", ifaceName, err)
+		}
+	}
+	return nil
+}
+
+// LinkSetBridgeSTP turns the spanning tree protocol of the bridge on or off
+func (h *NetworkUtilsHandler) LinkSetBridgeSTP(link netlink.Link, enabled bool) error {
+	state := uint32(0)
+	if enabled {
+		state = 1
+	}
+	return setBridgeAttribute(link, nl.IFLA_BR_STP_STATE, nl.Uint32Attr(state))
+}
+
+// LinkSetBridgeMulticastQuerier turns the IGMP and MLD querier of the bridge
+// on or off
+func (h *NetworkUtilsHandler) LinkSetBridgeMulticastQuerier(link netlink.Link, enabled bool) error {
+	state := uint8(0)
+	if enabled {
+		state = 1
+	}
+	return setBridgeAttribute(link, nl.IFLA_BR_MCAST_QUERIER, nl.Uint8Attr(state))
+}
+
+// setBridgeAttribute changes a single attribute of an existing bridge. The
+// netlink library only sets a few bridge attributes, and only on creation,
+// so the request is built here.
+func setBridgeAttribute(link netlink.Link, attrType int, value []byte) error {
+	req := nl.NewNetlinkRequest(syscall.RTM_NEWLINK, syscall.NLM_F_ACK)
+	msg := nl.NewIfInfomsg(syscall.AF_UNSPEC)
+	msg.Index = int32(link.Attrs().Index)
+	req.AddData(msg)
+
+	linkInfo := nl.NewRtAttr(syscall.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated("bridge"))
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+	data.AddRtAttr(attrType, value)
+	req.AddData(linkInfo)
+
+	_, err := req.Execute(syscall.NETLINK_ROUTE, 0)
+	return err
+}
+
 // Allow mocking for tests
 var SetupPodNetworkPhase1 = SetupNetworkInterfacesPhase1
 var SetupPodNetworkPhase2 = SetupNetworkInterfacesPhase2
+var ReplugPodNetworkPhase2 = ReplugNetworkInterfacesPhase2
+var TeardownPodNetworkPhase1 = TeardownNetworkInterfacesPhase1
 var DHCPServer = dhcp.SingleClientDHCPServer
 var DHCPv6Server = dhcpv6.SingleClientDHCPv6Server
 
@@ -534,7 +908,7 @@ func initHandler() {
 }
 
 func writeToCachedFile(inter interface{}, fileName, pid, name string) error {
-	buf, err := json.MarshalIndent(&inter, "", "  ")
+	buf, err := marshalCacheFile(inter)
 	if err != nil {
 		return fmt.Errorf("error marshaling cached object: %v", err)
 	}
@@ -556,7 +930,7 @@ func readFromCachedFile(pid, name, fileName string, inter interface{}) (bool, er
 		return false, err
 	}
 
-	err = json.Unmarshal(buf, &inter)
+	_, err = unmarshalCacheFile(buf, inter)
 	if err != nil {
 		return false, fmt.Errorf("error unmarshaling cached object: %v", err)
 	}
@@ -0,0 +1,295 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+// DatapathComponent is a part of the datapath of an interface in the pod
+type DatapathComponent string
+
+const (
+	DatapathTap    DatapathComponent = "tap"
+	DatapathBridge DatapathComponent = "bridge"
+	DatapathNat    DatapathComponent = "nat"
+	// DatapathPlug is the state virt-launcher reports for an interface it
+	// plugged into the domain
+	DatapathPlug DatapathComponent = "plug"
+)
+
+// DatapathDrift is a deviation of the datapath of an interface from the state
+// it was configured with when the interface was plugged.
+type DatapathDrift struct {
+	Interface string
+	Component DatapathComponent
+	Message   string
+	// Repaired tells if the deviation was corrected
+	Repaired bool
+}
+
+// datapathVerifier collects the drifts of a single interface
+type datapathVerifier struct {
+	iface      string
+	tapName    string
+	bridgeName string
+	repair     bool
+	drifts     []DatapathDrift
+}
+
+func (v *datapathVerifier) drift(component DatapathComponent, repair func() error, format string, args ...interface{}) error {
+	drift := DatapathDrift{Interface: v.iface, Component: component, Message: fmt.Sprintf(format, args...)}
+	if v.repair && repair != nil {
+		if err := repair(); err != nil {
+			return fmt.Errorf("failed to repair %s of interface %s: %v", drift.Message, v.iface, err)
+		}
+		drift.Repaired = true
+	}
+	v.drifts = append(v.drifts, drift)
+	return nil
+}
+
+// VerifyDatapath compares the tap devices, the bridges and the nat rules of the
+// bridge and masquerade interfaces of a running VMI with the state they were
+// plugged with. With repair, links which are down or detached from their bridge
+// are brought back, and missing nat chains and rules are recreated. Missing
+// links are only reported, the domain would have to be restarted to recreate
+// them. doNetNS has to execute the passed function in the network namespace of
+// the virt-launcher pod.
+func VerifyDatapath(vmi *v1.VirtualMachineInstance, pid int, doNetNS func(func() error) error, repair bool) ([]DatapathDrift, error) {
+	return verifyDatapath(vmi, pid, doNetNS, repair, false)
+}
+
+// VerifyNatRules is VerifyDatapath limited to the nat rules of the masquerade
+// interfaces. The network namespace of the pod is not entered for VMIs without
+// masquerade interfaces, which makes it cheap enough to detect flushes of the
+// nat table, e.g. by firewalld reloads, shortly after they happened.
+func VerifyNatRules(vmi *v1.VirtualMachineInstance, pid int, doNetNS func(func() error) error, repair bool) ([]DatapathDrift, error) {
+	return verifyDatapath(vmi, pid, doNetNS, repair, true)
+}
+
+func verifyDatapath(vmi *v1.VirtualMachineInstance, pid int, doNetNS func(func() error) error, repair bool, natOnly bool) ([]DatapathDrift, error) {
+	initHandler()
+
+	var drifts []DatapathDrift
+	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		iface := &vmi.Spec.Domain.Devices.Interfaces[i]
+		if iface.Masquerade == nil && (natOnly || iface.Bridge == nil) {
+			continue
+		}
+		if _, exists := networks[iface.Name]; !exists {
+			return nil, fmt.Errorf("failed to find a network %s", iface.Name)
+		}
+		podInterfaceName := getPodInterfaceName(networks, cniNetworks, iface.Name)
+
+		verifier := &datapathVerifier{
+			iface:      iface.Name,
+			tapName:    generateTapDeviceName(podInterfaceName),
+			bridgeName: fmt.Sprintf("k6t-%s", podInterfaceName),
+			repair:     repair,
+		}
+
+		var driver *MasqueradePodInterface
+		var err error
+		if iface.Masquerade != nil {
+			driver, err = loadMasqueradeDatapath(vmi, iface, podInterfaceName, pid)
+		} else {
+			bridge := &BridgePodInterface{vif: &VIF{Name: podInterfaceName}}
+			_, err = bridge.loadCachedVIF(strconv.Itoa(pid), iface.Name)
+		}
+		if os.IsNotExist(err) {
+			// the interface is not plugged yet
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to load the cached vif of interface %s: %v", iface.Name, err)
+		}
+
+		err = doNetNS(func() error {
+			if !natOnly {
+				if err := verifier.verifyLinks(); err != nil {
+					return err
+				}
+			}
+			if driver == nil {
+				return nil
+			}
+			protocols := []iptables.Protocol{iptables.ProtocolIPv4}
+			if driver.vif.IPv6.IPNet != nil {
+				protocols = append(protocols, iptables.ProtocolIPv6)
+			}
+			for _, proto := range protocols {
+				if err := verifier.verifyNatRules(driver, proto); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify the datapath of interface %s: %v", iface.Name, err)
+		}
+		drifts = append(drifts, verifier.drifts...)
+	}
+	return drifts, nil
+}
+
+// loadMasqueradeDatapath restores the masquerade binding of a plugged interface
+// from the cache, with the ports which were forwarded the last time
+func loadMasqueradeDatapath(vmi *v1.VirtualMachineInstance, iface *v1.Interface, podInterfaceName string, pid int) (*MasqueradePodInterface, error) {
+	driver := &MasqueradePodInterface{
+		vmi:                 vmi,
+		iface:               iface,
+		vif:                 &VIF{Name: podInterfaceName},
+		podInterfaceName:    podInterfaceName,
+		bridgeInterfaceName: fmt.Sprintf("k6t-%s", podInterfaceName),
+	}
+	if _, err := driver.loadCachedVIF(strconv.Itoa(pid), iface.Name); err != nil {
+		return nil, err
+	}
+	driver.gatewayAddr = &netlink.Addr{IPNet: &net.IPNet{IP: driver.vif.Gateway}}
+	driver.gatewayIpv6Addr = &netlink.Addr{IPNet: &net.IPNet{IP: driver.vif.GatewayIpv6}}
+
+	cache := &PodCacheInterface{}
+	exists, err := readFromCachedFile(string(vmi.UID), iface.Name, util.VMIInterfacepath, cache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the cached pod interface %s: %v", iface.Name, err)
+	}
+	if exists && cache.Iface != nil {
+		driver.iface = cache.Iface
+	}
+	return driver, nil
+}
+
+func (v *datapathVerifier) link(component DatapathComponent, name string) (netlink.Link, error) {
+	link, err := Handler.LinkByName(name)
+	if _, notFound := err.(netlink.LinkNotFoundError); notFound {
+		return nil, v.drift(component, nil, "%s %s is missing", component, name)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if link.Attrs().Flags&net.FlagUp == 0 {
+		err := v.drift(component, func() error { return Handler.LinkSetUp(link) }, "%s %s is down", component, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return link, nil
+}
+
+func (v *datapathVerifier) verifyLinks() error {
+	bridge, err := v.link(DatapathBridge, v.bridgeName)
+	if err != nil {
+		return err
+	}
+	tap, err := v.link(DatapathTap, v.tapName)
+	if err != nil || tap == nil || bridge == nil {
+		return err
+	}
+
+	if tap.Attrs().MasterIndex != bridge.Attrs().Index {
+		return v.drift(DatapathTap, func() error { return Handler.BindTapDeviceToBridge(v.tapName, v.bridgeName) },
+			"tap %s is not attached to bridge %s", v.tapName, v.bridgeName)
+	}
+	return nil
+}
+
+func (v *datapathVerifier) verifyNatRules(driver *MasqueradePodInterface, proto iptables.Protocol) error {
+	if Handler.HasNatIptables(proto) {
+		return v.verifyIptablesNatRules(driver, proto)
+	}
+	return v.verifyNftablesNatRules(driver, proto)
+}
+
+func (v *datapathVerifier) verifyIptablesNatRules(driver *MasqueradePodInterface, proto iptables.Protocol) error {
+	missingChains := map[string]bool{}
+	for _, chain := range []string{"KUBEVIRT_PREINBOUND", "KUBEVIRT_POSTINBOUND"} {
+		exists, err := Handler.IptablesChainExists(proto, "nat", chain)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		missingChains[chain] = !v.repair
+		chain := chain
+		err = v.drift(DatapathNat, func() error { return Handler.IptablesNewChain(proto, "nat", chain) },
+			"%s chain %s is missing", protocolName(proto), chain)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range driver.iptablesNatRules(proto) {
+		// the rules of a chain which is still missing are covered by its drift
+		if missingChains[rule.chain] {
+			continue
+		}
+		exists, err := Handler.IptablesRuleExists(proto, "nat", rule.chain, rule.spec...)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		rule := rule
+		err = v.drift(DatapathNat, func() error { return Handler.IptablesAppendRule(proto, "nat", rule.chain, rule.spec...) },
+			"%s rule %v of chain %s is missing", protocolName(proto), rule.spec, rule.chain)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyNftablesNatRules only looks for the chains of the interface. nft prints
+// rules differently from how they were added, and the chains can't be deleted
+// while rules jump to them, so they are only missing if the whole KubeVirt
+// table was deleted, which is repaired by recreating it.
+func (v *datapathVerifier) verifyNftablesNatRules(driver *MasqueradePodInterface, proto iptables.Protocol) error {
+	for _, chain := range []string{"KUBEVIRT_PREINBOUND", "KUBEVIRT_POSTINBOUND"} {
+		if Handler.NftablesChainExists(proto, kubevirtNftTable, chain) {
+			continue
+		}
+		return v.drift(DatapathNat, func() error {
+			if err := Handler.NftablesNewTable(proto, kubevirtNftTable); err != nil {
+				return err
+			}
+			return driver.createNatRulesUsingNftables(proto)
+		}, "%s chain %s is missing", protocolName(proto), chain)
+	}
+	return nil
+}
+
+func protocolName(proto iptables.Protocol) string {
+	if proto == iptables.ProtocolIPv6 {
+		return "ipv6"
+	}
+	return "ipv4"
+}
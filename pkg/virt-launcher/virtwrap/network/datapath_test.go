@@ -0,0 +1,240 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+var _ = Describe("Datapath verification", func() {
+	var tmpDir string
+	var origInterfacePath string
+	var ctrl *gomock.Controller
+	var mockNetwork *MockNetworkHandler
+	var vmi *v1.VirtualMachineInstance
+	var bridge *netlink.Bridge
+	var tap *netlink.Tuntap
+	const pid = 1234
+	proto := iptables.ProtocolIPv4
+
+	doNetNS := func(f func() error) error {
+		return f()
+	}
+
+	expectLinks := func() {
+		mockNetwork.EXPECT().LinkByName("k6t-eth0").Return(bridge, nil)
+		mockNetwork.EXPECT().LinkByName("tap0").Return(tap, nil)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "datapathtest")
+		Expect(err).ToNot(HaveOccurred())
+		setVifCacheFile(tmpDir + "/vif-cache-%s-%s.json")
+		origInterfacePath = util.VMIInterfacepath
+		util.VMIInterfacepath = tmpDir + "/pod-interface-%s-%s.json"
+
+		ctrl = gomock.NewController(GinkgoT())
+		mockNetwork = NewMockNetworkHandler(ctrl)
+		Handler = mockNetwork
+
+		vmi = newVMIMasqueradeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80, Protocol: "TCP"}}
+		cache := PodCacheInterface{Iface: vmi.Spec.Domain.Devices.Interfaces[0].DeepCopy(), PodIP: "10.244.0.8", PodIPs: []string{"10.244.0.8"}}
+		Expect(writeToCachedFile(cache, util.VMIInterfacepath, string(vmi.UID), "default")).To(Succeed())
+
+		ip, _ := netlink.ParseAddr("10.0.2.2/24")
+		masquerade := &MasqueradePodInterface{vif: &VIF{Name: "eth0", IP: *ip, Gateway: net.ParseIP("10.0.2.1")}}
+		Expect(masquerade.setCachedVIF(strconv.Itoa(pid), "default")).To(Succeed())
+
+		bridge = &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "k6t-eth0", Index: 3, Flags: net.FlagUp}}
+		tap = &netlink.Tuntap{LinkAttrs: netlink.LinkAttrs{Name: "tap0", Index: 4, MasterIndex: 3, Flags: net.FlagUp}}
+	})
+
+	AfterEach(func() {
+		util.VMIInterfacepath = origInterfacePath
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should not report drifts if the datapath is intact", func() {
+		expectLinks()
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", gomock.Any()).Return(true, nil).Times(2)
+		mockNetwork.EXPECT().IptablesRuleExists(proto, "nat", gomock.Any(), gomock.Any()).Return(true, nil).Times(6)
+
+		drifts, err := VerifyDatapath(vmi, pid, doNetNS, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(BeEmpty())
+		ctrl.Finish()
+	})
+
+	It("should skip interfaces which are not plugged yet", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Name = "other"
+		vmi.Spec.Networks[0].Name = "other"
+
+		drifts, err := VerifyDatapath(vmi, pid, doNetNS, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(BeEmpty())
+	})
+
+	It("should bring up and reattach a tap device", func() {
+		tap.Attrs().Flags = 0
+		tap.Attrs().MasterIndex = 0
+		expectLinks()
+		mockNetwork.EXPECT().LinkSetUp(tap).Return(nil)
+		mockNetwork.EXPECT().BindTapDeviceToBridge("tap0", "k6t-eth0").Return(nil)
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", gomock.Any()).Return(true, nil).Times(2)
+		mockNetwork.EXPECT().IptablesRuleExists(proto, "nat", gomock.Any(), gomock.Any()).Return(true, nil).Times(6)
+
+		drifts, err := VerifyDatapath(vmi, pid, doNetNS, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(ConsistOf(
+			DatapathDrift{Interface: "default", Component: DatapathTap, Message: "tap tap0 is down", Repaired: true},
+			DatapathDrift{Interface: "default", Component: DatapathTap, Message: "tap tap0 is not attached to bridge k6t-eth0", Repaired: true},
+		))
+		ctrl.Finish()
+	})
+
+	It("should only report a missing bridge", func() {
+		mockNetwork.EXPECT().LinkByName("k6t-eth0").Return(nil, netlink.LinkNotFoundError{})
+		mockNetwork.EXPECT().LinkByName("tap0").Return(tap, nil)
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", gomock.Any()).Return(true, nil).Times(2)
+		mockNetwork.EXPECT().IptablesRuleExists(proto, "nat", gomock.Any(), gomock.Any()).Return(true, nil).Times(6)
+
+		drifts, err := VerifyDatapath(vmi, pid, doNetNS, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(ConsistOf(
+			DatapathDrift{Interface: "default", Component: DatapathBridge, Message: "bridge k6t-eth0 is missing"},
+		))
+		ctrl.Finish()
+	})
+
+	It("should recreate missing iptables chains and rules", func() {
+		expectLinks()
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", "KUBEVIRT_PREINBOUND").Return(false, nil)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(true, nil)
+		mockNetwork.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND").Return(nil)
+		mockNetwork.EXPECT().IptablesRuleExists(proto, "nat", "KUBEVIRT_PREINBOUND",
+			"-p", "tcp", "--dport", "80", "-j", "DNAT", "--to-destination", "10.0.2.2").Return(false, nil)
+		mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+			"-p", "tcp", "--dport", "80", "-j", "DNAT", "--to-destination", "10.0.2.2").Return(nil)
+		mockNetwork.EXPECT().IptablesRuleExists(proto, "nat", gomock.Any(), gomock.Any()).Return(true, nil).Times(5)
+
+		drifts, err := VerifyDatapath(vmi, pid, doNetNS, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(HaveLen(2))
+		for _, drift := range drifts {
+			Expect(drift.Component).To(Equal(DatapathNat))
+			Expect(drift.Repaired).To(BeTrue())
+		}
+		ctrl.Finish()
+	})
+
+	It("should not check the rules of a missing chain without repair", func() {
+		expectLinks()
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", "KUBEVIRT_PREINBOUND").Return(false, nil)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(true, nil)
+		mockNetwork.EXPECT().IptablesRuleExists(proto, "nat", gomock.Any(), gomock.Any()).Return(true, nil).Times(5)
+
+		drifts, err := VerifyDatapath(vmi, pid, doNetNS, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(ConsistOf(
+			DatapathDrift{Interface: "default", Component: DatapathNat, Message: "ipv4 chain KUBEVIRT_PREINBOUND is missing"},
+		))
+		ctrl.Finish()
+	})
+
+	It("should recreate the KubeVirt table if the nftables chains are missing", func() {
+		expectLinks()
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(false)
+		mockNetwork.EXPECT().GetNFTIPString(proto).Return("ip").AnyTimes()
+		mockNetwork.EXPECT().NftablesChainExists(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND").Return(false)
+		mockNetwork.EXPECT().NftablesNewTable(proto, kubevirtNftTable).Return(nil)
+		mockNetwork.EXPECT().NftablesFlushTable(proto, kubevirtNftTable).Return(nil)
+		expectNftablesNatHooks(mockNetwork, proto)
+		mockNetwork.EXPECT().NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND").Return(nil)
+		mockNetwork.EXPECT().NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND").Return(nil)
+		mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, gomock.Any(), gomock.Any()).Return(nil).Times(6)
+
+		drifts, err := VerifyDatapath(vmi, pid, doNetNS, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(ConsistOf(
+			DatapathDrift{Interface: "default", Component: DatapathNat, Message: "ipv4 chain KUBEVIRT_PREINBOUND is missing", Repaired: true},
+		))
+		ctrl.Finish()
+	})
+
+	It("should restore flushed iptables chains without looking at the links", func() {
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesChainExists(proto, "nat", gomock.Any()).Return(false, nil).Times(2)
+		mockNetwork.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND").Return(nil)
+		mockNetwork.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(nil)
+		mockNetwork.EXPECT().IptablesRuleExists(proto, "nat", gomock.Any(), gomock.Any()).Return(false, nil).Times(6)
+		mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", gomock.Any(), gomock.Any()).Return(nil).Times(6)
+
+		drifts, err := VerifyNatRules(vmi, pid, doNetNS, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(HaveLen(8))
+		for _, drift := range drifts {
+			Expect(drift.Component).To(Equal(DatapathNat))
+			Expect(drift.Repaired).To(BeTrue())
+		}
+		ctrl.Finish()
+	})
+
+	It("should not enter the network namespace to verify the nat rules of bridge interfaces", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Masquerade = nil
+		vmi.Spec.Domain.Devices.Interfaces[0].Bridge = &v1.InterfaceBridge{}
+
+		drifts, err := VerifyNatRules(vmi, pid, func(func() error) error {
+			Fail("the network namespace should not be entered")
+			return nil
+		}, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drifts).To(BeEmpty())
+	})
+
+	It("should fail if a drift could not be repaired", func() {
+		tap.Attrs().Flags = 0
+		expectLinks()
+		mockNetwork.EXPECT().LinkSetUp(tap).Return(fmt.Errorf("no such device"))
+
+		_, err := VerifyDatapath(vmi, pid, doNetNS, true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("tap tap0 is down"))
+	})
+})
@@ -58,7 +58,8 @@ func SingleClientDHCPServer(
 	routes *[]netlink.Route,
 	searchDomains []string,
 	mtu uint16,
-	customDHCPOptions *v1.DHCPOptions) error {
+	customDHCPOptions *v1.DHCPOptions,
+	leases LeaseStore) error {
 
 	log.Log.Info("Starting SingleClientDHCPServer")
 
@@ -78,7 +79,15 @@ func SingleClientDHCPServer(
 		serverIP:      serverIP.To4(),
 		leaseDuration: infiniteLease,
 		options:       options,
+		leases:        leases,
 	}
+	if customDHCPOptions != nil && customDHCPOptions.NextServer != "" {
+		handler.nextServer = net.ParseIP(customDHCPOptions.NextServer).To4()
+		if handler.nextServer == nil {
+			return fmt.Errorf("next server is not an IPv4 address: %s", customDHCPOptions.NextServer)
+		}
+	}
+	handler.loadLease()
 
 	l, err := NewUDP4FilterListener(serverIface, ":67")
 	if err != nil {
@@ -107,11 +116,15 @@ func prepareDHCPOptions(
 
 	dhcpOptions := dhcp.Options{
 		dhcp.OptionSubnetMask:       []byte(clientMask),
-		dhcp.OptionRouter:           []byte(routerIP),
 		dhcp.OptionDomainNameServer: bytes.Join(dnsIPs, nil),
 		dhcp.OptionInterfaceMTU:     mtuArray,
 	}
 
+	// static leases of bridged guests may come without a gateway
+	if len(routerIP) > 0 {
+		dhcpOptions[dhcp.OptionRouter] = []byte(routerIP)
+	}
+
 	netRoutes := formClasslessRoutes(routes)
 
 	if netRoutes != nil {
@@ -175,10 +188,67 @@ func prepareDHCPOptions(
 
 type DHCPHandler struct {
 	serverIP      net.IP
+	nextServer    net.IP
 	clientIP      net.IP
 	clientMAC     net.HardwareAddr
 	leaseDuration time.Duration
 	options       dhcp.Options
+	leases        LeaseStore
+	lease         *Lease
+}
+
+// loadLease picks up the lease a previous instance of the server handed out,
+// unless it expired or was for another address.
+func (h *DHCPHandler) loadLease() {
+	if h.leases == nil {
+		return
+	}
+	lease, err := h.leases.Load()
+	if err != nil {
+		log.Log.Reason(err).Warning("failed to load the persisted DHCP lease, starting a new one")
+		return
+	}
+	if !lease.isValidFor(h.clientIP, time.Now()) {
+		return
+	}
+	log.Log.Infof("Honoring the DHCP lease of %s until %s", lease.IP, lease.Expiry)
+	h.lease = lease
+}
+
+// leaseTime returns how long the lease of the client lasts, which is what is
+// left of its current lease if it has one.
+func (h *DHCPHandler) leaseTime(clientID []byte) time.Duration {
+	now := time.Now()
+	if h.lease.isValidFor(h.clientIP, now) && h.lease.isHeldBy(clientID) {
+		return h.lease.Expiry.Sub(now)
+	}
+	return h.leaseDuration
+}
+
+// commitLease records the lease acknowledged to the client and returns its duration.
+func (h *DHCPHandler) commitLease(clientID []byte) time.Duration {
+	now := time.Now()
+	if h.lease.isValidFor(h.clientIP, now) && h.lease.isHeldBy(clientID) {
+		return h.lease.Expiry.Sub(now)
+	}
+
+	h.lease = &Lease{
+		IP:       h.clientIP,
+		ClientID: clientID,
+		Expiry:   now.Add(h.leaseDuration),
+	}
+	h.storeLease()
+	return h.leaseDuration
+}
+
+func (h *DHCPHandler) storeLease() {
+	if h.leases == nil {
+		return
+	}
+	// the client is served regardless, it only loses its lease if the server restarts
+	if err := h.leases.Store(h.lease); err != nil {
+		log.Log.Reason(err).Warning("failed to persist the DHCP lease")
+	}
 }
 
 func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, options dhcp.Options) (d dhcp.Packet) {
@@ -192,13 +262,19 @@ func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, options
 
 	case dhcp.Discover:
 		log.Log.V(4).Info("The request has message type DISCOVER")
-		return dhcp.ReplyPacket(p, dhcp.Offer, h.serverIP, h.clientIP, h.leaseDuration,
-			h.options.SelectOrderOrAll(nil))
+		return h.reply(p, dhcp.Offer, h.leaseTime(options[dhcp.OptionClientIdentifier]))
 
 	case dhcp.Request:
 		log.Log.V(4).Info("The request has message type REQUEST")
-		return dhcp.ReplyPacket(p, dhcp.ACK, h.serverIP, h.clientIP, h.leaseDuration,
-			h.options.SelectOrderOrAll(nil))
+		return h.reply(p, dhcp.ACK, h.commitLease(options[dhcp.OptionClientIdentifier]))
+
+	case dhcp.Release:
+		log.Log.V(4).Info("The request has message type RELEASE")
+		if h.lease != nil && h.lease.isHeldBy(options[dhcp.OptionClientIdentifier]) {
+			h.lease = nil
+			h.storeLease()
+		}
+		return nil
 
 	default:
 		log.Log.V(4).Info("The request has unhandled message type")
@@ -207,6 +283,16 @@ func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, options
 	}
 }
 
+// reply answers the request of the client with its address and the options,
+// pointing it to the boot server if there is one
+func (h *DHCPHandler) reply(p dhcp.Packet, msgType dhcp.MessageType, leaseTime time.Duration) dhcp.Packet {
+	reply := dhcp.ReplyPacket(p, msgType, h.serverIP, h.clientIP, leaseTime, h.options.SelectOrderOrAll(nil))
+	if h.nextServer != nil {
+		reply.SetSIAddr(h.nextServer)
+	}
+	return reply
+}
+
 func sortRoutes(routes []netlink.Route) []netlink.Route {
 	// Default route must come last, otherwise it may not get applied
 	// because there is no route to its gateway yet
@@ -20,7 +20,9 @@
 package dhcp
 
 import (
+	"encoding/binary"
 	"net"
+	"time"
 
 	"github.com/krolaw/dhcp4"
 	. "github.com/onsi/ginkgo"
@@ -215,6 +217,13 @@ var _ = Describe("DHCP", func() {
 			Expect(options[dhcp4.OptionDomainName]).To(Equal([]byte("14wg5xngig6vzfqjww4kocnky3c9dqjpwkewzlwpf.com")))
 		})
 
+		It("should omit the router option without a gateway", func() {
+			ip := net.ParseIP("192.168.2.1")
+			options, err := prepareDHCPOptions(ip.DefaultMask(), nil, nil, nil, nil, 1500, "myhost", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options).ToNot(HaveKey(dhcp4.OptionRouter))
+		})
+
 		It("should contain custom options", func() {
 			searchDomains := []string{
 				"pix3ob5ymm5jbsjessf0o4e84uvij588rz23iz0o.com",
@@ -245,4 +254,127 @@ var _ = Describe("DHCP", func() {
 			Expect(options[240]).To(Equal([]byte("private.options.kubevirt.io")))
 		})
 	})
+
+	Context("next server", func() {
+		clientMAC, _ := net.ParseMAC("de:ad:00:00:be:af")
+
+		serve := func(nextServer net.IP, msgType dhcp4.MessageType) dhcp4.Packet {
+			handler := &DHCPHandler{
+				clientIP:      net.ParseIP("10.0.2.2"),
+				clientMAC:     clientMAC,
+				serverIP:      net.ParseIP("10.0.2.1").To4(),
+				nextServer:    nextServer,
+				leaseDuration: time.Hour,
+				options:       dhcp4.Options{},
+			}
+			request := dhcp4.RequestPacket(msgType, clientMAC, nil, []byte{1, 2, 3, 4}, false, nil)
+			return handler.ServeDHCP(request, msgType, request.ParseOptions())
+		}
+
+		It("should point offers and acknowledgements to the next server", func() {
+			nextServer := net.ParseIP("192.168.1.10").To4()
+			Expect(serve(nextServer, dhcp4.Discover).SIAddr().Equal(nextServer)).To(BeTrue())
+			Expect(serve(nextServer, dhcp4.Request).SIAddr().Equal(nextServer)).To(BeTrue())
+		})
+
+		It("should leave the next server unset by default", func() {
+			Expect(serve(nil, dhcp4.Request).SIAddr().Equal(net.IPv4zero)).To(BeTrue())
+		})
+	})
+
+	Context("lease persistence", func() {
+		clientMAC, _ := net.ParseMAC("de:ad:00:00:be:af")
+		clientIP := net.ParseIP("10.0.2.2")
+		clientID := []byte{1, 0xde, 0xad, 0, 0, 0xbe, 0xaf}
+		var store *memoryLeaseStore
+
+		newHandler := func() *DHCPHandler {
+			handler := &DHCPHandler{
+				clientIP:      clientIP,
+				clientMAC:     clientMAC,
+				serverIP:      net.ParseIP("10.0.2.1").To4(),
+				leaseDuration: time.Hour,
+				options:       dhcp4.Options{},
+				leases:        store,
+			}
+			handler.loadLease()
+			return handler
+		}
+
+		serve := func(handler *DHCPHandler, msgType dhcp4.MessageType) dhcp4.Packet {
+			request := dhcp4.RequestPacket(msgType, clientMAC, nil, []byte{1, 2, 3, 4}, false,
+				[]dhcp4.Option{{Code: dhcp4.OptionClientIdentifier, Value: clientID}})
+			return handler.ServeDHCP(request, msgType, request.ParseOptions())
+		}
+
+		leaseTime := func(reply dhcp4.Packet) time.Duration {
+			seconds := binary.BigEndian.Uint32(reply.ParseOptions()[dhcp4.OptionIPAddressLeaseTime])
+			return time.Duration(seconds) * time.Second
+		}
+
+		BeforeEach(func() {
+			store = &memoryLeaseStore{}
+		})
+
+		It("should persist the acknowledged lease", func() {
+			reply := serve(newHandler(), dhcp4.Request)
+			Expect(leaseTime(reply)).To(Equal(time.Hour))
+			Expect(store.lease).ToNot(BeNil())
+			Expect(store.lease.IP.Equal(clientIP)).To(BeTrue())
+			Expect(store.lease.ClientID).To(Equal(clientID))
+		})
+
+		It("should keep honoring the persisted lease after a restart", func() {
+			expiry := time.Now().Add(10 * time.Minute)
+			store.lease = &Lease{IP: clientIP, ClientID: clientID, Expiry: expiry}
+
+			handler := newHandler()
+			Expect(leaseTime(serve(handler, dhcp4.Discover))).To(BeNumerically("<=", 10*time.Minute))
+			Expect(leaseTime(serve(handler, dhcp4.Request))).To(BeNumerically("<=", 10*time.Minute))
+			Expect(store.lease.Expiry).To(Equal(expiry))
+		})
+
+		It("should start a new lease if the persisted one expired", func() {
+			store.lease = &Lease{IP: clientIP, ClientID: clientID, Expiry: time.Now().Add(-time.Minute)}
+
+			Expect(leaseTime(serve(newHandler(), dhcp4.Request))).To(Equal(time.Hour))
+			Expect(store.lease.Expiry).To(BeTemporally(">", time.Now()))
+		})
+
+		It("should start a new lease if the persisted one is for another address", func() {
+			store.lease = &Lease{IP: net.ParseIP("10.0.2.3"), ClientID: clientID, Expiry: time.Now().Add(10 * time.Minute)}
+
+			Expect(leaseTime(serve(newHandler(), dhcp4.Request))).To(Equal(time.Hour))
+			Expect(store.lease.IP.Equal(clientIP)).To(BeTrue())
+		})
+
+		It("should forget the lease once it is released", func() {
+			handler := newHandler()
+			serve(handler, dhcp4.Request)
+			Expect(serve(handler, dhcp4.Release)).To(BeNil())
+			Expect(store.lease).To(BeNil())
+		})
+
+		It("should keep the lease if another client releases it", func() {
+			handler := newHandler()
+			serve(handler, dhcp4.Request)
+			request := dhcp4.RequestPacket(dhcp4.Release, clientMAC, nil, []byte{1, 2, 3, 4}, false,
+				[]dhcp4.Option{{Code: dhcp4.OptionClientIdentifier, Value: []byte{9, 9}}})
+			Expect(handler.ServeDHCP(request, dhcp4.Release, request.ParseOptions())).To(BeNil())
+			Expect(store.lease).ToNot(BeNil())
+		})
+	})
 })
+
+type memoryLeaseStore struct {
+	lease *Lease
+}
+
+func (s *memoryLeaseStore) Load() (*Lease, error) {
+	return s.lease, nil
+}
+
+func (s *memoryLeaseStore) Store(lease *Lease) error {
+	s.lease = lease
+	return nil
+}
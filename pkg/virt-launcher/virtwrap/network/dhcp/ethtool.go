@@ -12,6 +12,12 @@ const (
 	SIOCETHTOOL     = 0x8946     // linux/sockios.h
 	ETHTOOL_GTXCSUM = 0x00000016 // linux/ethtool.h
 	ETHTOOL_STXCSUM = 0x00000017 // linux/ethtool.h
+	ETHTOOL_GTSO    = 0x0000001e // linux/ethtool.h
+	ETHTOOL_STSO    = 0x0000001f // linux/ethtool.h
+	ETHTOOL_GGSO    = 0x00000023 // linux/ethtool.h
+	ETHTOOL_SGSO    = 0x00000024 // linux/ethtool.h
+	ETHTOOL_GGRO    = 0x0000002b // linux/ethtool.h
+	ETHTOOL_SGRO    = 0x0000002c // linux/ethtool.h
 	IFNAMSIZ        = 16         // linux/if.h
 )
 
@@ -37,6 +43,12 @@ func ioctlEthtool(fd int, argp uintptr) error {
 
 // Disable TX checksum offload on specified interface
 func EthtoolTXOff(name string) error {
+	return EthtoolSetFeature(name, ETHTOOL_GTXCSUM, ETHTOOL_STXCSUM, false)
+}
+
+// EthtoolSetFeature toggles the feature read by getCmd and written by setCmd
+// on the specified interface
+func EthtoolSetFeature(name string, getCmd uint32, setCmd uint32, enabled bool) error {
 	if len(name)+1 > IFNAMSIZ {
 		return fmt.Errorf("name too long")
 	}
@@ -48,17 +60,21 @@ func EthtoolTXOff(name string) error {
 	defer syscall.Close(socket)
 
 	// Request current value
-	value := EthtoolValue{Cmd: ETHTOOL_GTXCSUM}
+	value := EthtoolValue{Cmd: getCmd}
 	request := IFReqData{Data: uintptr(unsafe.Pointer(&value))} // #nosec Used for a RawSyscall
 	copy(request.Name[:], name)
 
 	if err := ioctlEthtool(socket, uintptr(unsafe.Pointer(&request))); err != nil { // #nosec Used for a RawSyscall
 		return err
 	}
-	if value.Data == 0 { // if already off, don't try to change
+	var wanted uint32
+	if enabled {
+		wanted = 1
+	}
+	if value.Data == wanted { // if already set, don't try to change
 		return nil
 	}
 
-	value = EthtoolValue{ETHTOOL_STXCSUM, 0}
+	value = EthtoolValue{setCmd, wanted}
 	return ioctlEthtool(socket, uintptr(unsafe.Pointer(&request))) // #nosec Used for a RawSyscall
 }
@@ -0,0 +1,49 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package dhcp
+
+import (
+	"bytes"
+	"net"
+	"time"
+)
+
+// Lease is the lease handed out to the client. It is persisted, so that a
+// restarted server keeps honoring it instead of starting a new one.
+type Lease struct {
+	IP       net.IP    `json:"ip"`
+	ClientID []byte    `json:"clientID,omitempty"`
+	Expiry   time.Time `json:"expiry"`
+}
+
+// LeaseStore persists the lease of the client. Store is passed nil once the
+// client released its lease.
+type LeaseStore interface {
+	Load() (*Lease, error)
+	Store(lease *Lease) error
+}
+
+func (l *Lease) isValidFor(ip net.IP, now time.Time) bool {
+	return l != nil && l.IP.Equal(ip) && now.Before(l.Expiry)
+}
+
+func (l *Lease) isHeldBy(clientID []byte) bool {
+	return bytes.Equal(l.ClientID, clientID)
+}
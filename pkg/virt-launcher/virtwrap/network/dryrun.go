@@ -0,0 +1,108 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coreos/go-iptables/iptables"
+	"golang.org/x/sys/unix"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// PlugStep is the result of checking a step of the first phase of plugging
+// an interface in a dry run
+type PlugStep struct {
+	Interface string `json:"interface"`
+	Step      string `json:"step"`
+	// Error tells why the step would fail, it is empty if it would succeed
+	Error string `json:"error,omitempty"`
+}
+
+var (
+	tunDevice            = "/dev/net/tun"
+	vhostNetDevice       = "/dev/vhost-net"
+	ipv6ForwardingSysctl = "/proc/sys/net/ipv6/conf/all/forwarding"
+)
+
+// DryRunPodNetworkPhase1 checks for every interface of the VMI whether the
+// backends the first phase of plugging it relies on are available: the tap
+// and vhost-net devices, the nat tables of iptables or nftables and the IPv6
+// forwarding sysctl. Nothing is changed, the steps are checked in the current
+// network namespace with the binaries the real setup uses.
+func DryRunPodNetworkPhase1(vmi *v1.VirtualMachineInstance) []PlugStep {
+	initHandler()
+
+	networks, _ := getNetworksAndCniNetworks(vmi)
+	var steps []PlugStep
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		check := func(step string, err error) {
+			result := PlugStep{Interface: iface.Name, Step: step}
+			if err != nil {
+				result.Error = err.Error()
+			}
+			steps = append(steps, result)
+		}
+
+		_, err := getNetworkInterfaceFactory(networks, iface.Name)
+		check("network", err)
+		if err != nil {
+			continue
+		}
+
+		if iface.Bridge == nil && iface.Masquerade == nil {
+			// slirp, SR-IOV and macvtap interfaces are not plugged with a tap device
+			continue
+		}
+		check("tap device", checkDevice(tunDevice))
+		if iface.Model == "" || iface.Model == "virtio" {
+			check("vhost-net", checkDevice(vhostNetDevice))
+		}
+		if iface.Masquerade != nil {
+			check("ipv4 nat", checkNat(iptables.ProtocolIPv4))
+			if _, err := os.Stat(ipv6ForwardingSysctl); err == nil {
+				check("ipv6 forwarding", unix.Access(ipv6ForwardingSysctl, unix.W_OK))
+				check("ipv6 nat", checkNat(iptables.ProtocolIPv6))
+			}
+		}
+	}
+	return steps
+}
+
+func checkDevice(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return unix.Access(path, unix.R_OK|unix.W_OK)
+}
+
+// checkNat follows the masquerade binding, which falls back to a table of its
+// own in nftables if iptables has no nat table
+func checkNat(proto iptables.Protocol) error {
+	if Handler.HasNatIptables(proto) {
+		return nil
+	}
+	if err := Handler.NftablesCheck(proto); err != nil {
+		return fmt.Errorf("iptables has no nat table and nftables can't add one: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Plug dry run", func() {
+	var tmpDir string
+	var ctrl *gomock.Controller
+	var mockNetwork *MockNetworkHandler
+	var vmi *v1.VirtualMachineInstance
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "dryrun")
+		Expect(err).ToNot(HaveOccurred())
+		tunDevice = filepath.Join(tmpDir, "tun")
+		vhostNetDevice = filepath.Join(tmpDir, "vhost-net")
+		ipv6ForwardingSysctl = filepath.Join(tmpDir, "forwarding")
+		for _, path := range []string{tunDevice, vhostNetDevice} {
+			Expect(ioutil.WriteFile(path, []byte{}, 0600)).To(Succeed())
+		}
+
+		ctrl = gomock.NewController(GinkgoT())
+		mockNetwork = NewMockNetworkHandler(ctrl)
+		Handler = mockNetwork
+
+		vmi = newVMI("testnamespace", "testVmName")
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+		tunDevice = "/dev/net/tun"
+		vhostNetDevice = "/dev/vhost-net"
+		ipv6ForwardingSysctl = "/proc/sys/net/ipv6/conf/all/forwarding"
+	})
+
+	It("should check the devices of bridge interfaces", func() {
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		Expect(os.Remove(vhostNetDevice)).To(Succeed())
+
+		steps := DryRunPodNetworkPhase1(vmi)
+		Expect(steps).To(HaveLen(3))
+		Expect(steps[0]).To(Equal(PlugStep{Interface: "default", Step: "network"}))
+		Expect(steps[1]).To(Equal(PlugStep{Interface: "default", Step: "tap device"}))
+		Expect(steps[2].Step).To(Equal("vhost-net"))
+		Expect(steps[2].Error).To(ContainSubstring("no such file or directory"))
+	})
+
+	It("should not check vhost-net for non virtio interfaces", func() {
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+
+		Expect(DryRunPodNetworkPhase1(vmi)).To(Equal([]PlugStep{
+			{Interface: "default", Step: "network"},
+			{Interface: "default", Step: "tap device"},
+		}))
+	})
+
+	It("should fall back to nftables for the nat of masquerade interfaces", func() {
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+		Expect(ioutil.WriteFile(ipv6ForwardingSysctl, []byte("0\n"), 0600)).To(Succeed())
+
+		mockNetwork.EXPECT().HasNatIptables(iptables.ProtocolIPv4).Return(false)
+		mockNetwork.EXPECT().NftablesCheck(iptables.ProtocolIPv4).Return(nil)
+		mockNetwork.EXPECT().HasNatIptables(iptables.ProtocolIPv6).Return(false)
+		mockNetwork.EXPECT().NftablesCheck(iptables.ProtocolIPv6).Return(fmt.Errorf("no nft"))
+
+		steps := DryRunPodNetworkPhase1(vmi)
+		Expect(steps).To(HaveLen(6))
+		Expect(steps[3]).To(Equal(PlugStep{Interface: "default", Step: "ipv4 nat"}))
+		Expect(steps[4]).To(Equal(PlugStep{Interface: "default", Step: "ipv6 forwarding"}))
+		Expect(steps[5]).To(Equal(PlugStep{Interface: "default", Step: "ipv6 nat", Error: "iptables has no nat table and nftables can't add one: no nft"}))
+	})
+
+	It("should skip IPv6 on nodes without it", func() {
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+		mockNetwork.EXPECT().HasNatIptables(iptables.ProtocolIPv4).Return(true)
+
+		steps := DryRunPodNetworkPhase1(vmi)
+		Expect(steps).To(HaveLen(4))
+		Expect(steps[3]).To(Equal(PlugStep{Interface: "default", Step: "ipv4 nat"}))
+	})
+
+	It("should report interfaces without network", func() {
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		vmi.Spec.Networks = nil
+
+		Expect(DryRunPodNetworkPhase1(vmi)).To(Equal([]PlugStep{
+			{Interface: "default", Step: "network", Error: "failed to find a network default"},
+		}))
+	})
+})
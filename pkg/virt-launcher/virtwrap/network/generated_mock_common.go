@@ -11,6 +11,7 @@ import (
 	netlink "github.com/vishvananda/netlink"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	qos "kubevirt.io/kubevirt/pkg/util/qos"
 )
 
 // Mock of NetworkHandler interface
@@ -193,6 +194,26 @@ func (_mr *_MockNetworkHandlerRecorder) LinkSetMaster(arg0, arg1 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "LinkSetMaster", arg0, arg1)
 }
 
+func (_m *MockNetworkHandler) LinkSetHardwareAddr(link netlink.Link, hwaddr net.HardwareAddr) error {
+	ret := _m.ctrl.Call(_m, "LinkSetHardwareAddr", link, hwaddr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) LinkSetHardwareAddr(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LinkSetHardwareAddr", arg0, arg1)
+}
+
+func (_m *MockNetworkHandler) NeighSet(neigh *netlink.Neigh) error {
+	ret := _m.ctrl.Call(_m, "NeighSet", neigh)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NeighSet(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NeighSet", arg0)
+}
+
 func (_m *MockNetworkHandler) StartDHCP(nic *VIF, serverAddr net.IP, bridgeInterfaceName string, dhcpOptions *v1.DHCPOptions) error {
 	ret := _m.ctrl.Call(_m, "StartDHCP", nic, serverAddr, bridgeInterfaceName, dhcpOptions)
 	ret0, _ := ret[0].(error)
@@ -203,6 +224,16 @@ func (_mr *_MockNetworkHandlerRecorder) StartDHCP(arg0, arg1, arg2, arg3 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartDHCP", arg0, arg1, arg2, arg3)
 }
 
+func (_m *MockNetworkHandler) StartTFTPProxy(serverAddr net.IP, upstream string) error {
+	ret := _m.ctrl.Call(_m, "StartTFTPProxy", serverAddr, upstream)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) StartTFTPProxy(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartTFTPProxy", arg0, arg1)
+}
+
 func (_m *MockNetworkHandler) HasNatIptables(proto iptables.Protocol) bool {
 	ret := _m.ctrl.Call(_m, "HasNatIptables", proto)
 	ret0, _ := ret[0].(bool)
@@ -235,14 +266,25 @@ func (_mr *_MockNetworkHandlerRecorder) IsIpv4Primary() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsIpv4Primary")
 }
 
-func (_m *MockNetworkHandler) ConfigureIpv6Forwarding() error {
-	ret := _m.ctrl.Call(_m, "ConfigureIpv6Forwarding")
+func (_m *MockNetworkHandler) GetSysctl(name string) (int, error) {
+	ret := _m.ctrl.Call(_m, "GetSysctl", name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockNetworkHandlerRecorder) GetSysctl(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSysctl", arg0)
+}
+
+func (_m *MockNetworkHandler) SetSysctl(name string, value int) error {
+	ret := _m.ctrl.Call(_m, "SetSysctl", name, value)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockNetworkHandlerRecorder) ConfigureIpv6Forwarding() *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConfigureIpv6Forwarding")
+func (_mr *_MockNetworkHandlerRecorder) SetSysctl(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetSysctl", arg0, arg1)
 }
 
 func (_m *MockNetworkHandler) IptablesNewChain(proto iptables.Protocol, table string, chain string) error {
@@ -270,6 +312,58 @@ func (_mr *_MockNetworkHandlerRecorder) IptablesAppendRule(arg0, arg1, arg2 inte
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "IptablesAppendRule", _s...)
 }
 
+func (_m *MockNetworkHandler) IptablesDeleteRule(proto iptables.Protocol, table string, chain string, rulespec ...string) error {
+	_s := []interface{}{proto, table, chain}
+	for _, _x := range rulespec {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "IptablesDeleteRule", _s...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) IptablesDeleteRule(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IptablesDeleteRule", _s...)
+}
+
+func (_m *MockNetworkHandler) IptablesChainExists(proto iptables.Protocol, table string, chain string) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IptablesChainExists", proto, table, chain)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockNetworkHandlerRecorder) IptablesChainExists(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IptablesChainExists", arg0, arg1, arg2)
+}
+
+func (_m *MockNetworkHandler) IptablesRuleExists(proto iptables.Protocol, table string, chain string, rulespec ...string) (bool, error) {
+	_s := []interface{}{proto, table, chain}
+	for _, _x := range rulespec {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "IptablesRuleExists", _s...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockNetworkHandlerRecorder) IptablesRuleExists(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IptablesRuleExists", _s...)
+}
+
+func (_m *MockNetworkHandler) NftablesNewTable(proto iptables.Protocol, table string) error {
+	ret := _m.ctrl.Call(_m, "NftablesNewTable", proto, table)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NftablesNewTable(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesNewTable", arg0, arg1)
+}
+
 func (_m *MockNetworkHandler) NftablesNewChain(proto iptables.Protocol, table string, chain string) error {
 	ret := _m.ctrl.Call(_m, "NftablesNewChain", proto, table, chain)
 	ret0, _ := ret[0].(error)
@@ -280,6 +374,16 @@ func (_mr *_MockNetworkHandlerRecorder) NftablesNewChain(arg0, arg1, arg2 interf
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesNewChain", arg0, arg1, arg2)
 }
 
+func (_m *MockNetworkHandler) NftablesNewBaseChain(proto iptables.Protocol, table string, chain string, chainType string, hook string, priority int) error {
+	ret := _m.ctrl.Call(_m, "NftablesNewBaseChain", proto, table, chain, chainType, hook, priority)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NftablesNewBaseChain(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesNewBaseChain", arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 func (_m *MockNetworkHandler) NftablesAppendRule(proto iptables.Protocol, table string, chain string, rulespec ...string) error {
 	_s := []interface{}{proto, table, chain}
 	for _, _x := range rulespec {
@@ -295,6 +399,36 @@ func (_mr *_MockNetworkHandlerRecorder) NftablesAppendRule(arg0, arg1, arg2 inte
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesAppendRule", _s...)
 }
 
+func (_m *MockNetworkHandler) NftablesFlushChain(proto iptables.Protocol, table string, chain string) error {
+	ret := _m.ctrl.Call(_m, "NftablesFlushChain", proto, table, chain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NftablesFlushChain(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesFlushChain", arg0, arg1, arg2)
+}
+
+func (_m *MockNetworkHandler) NftablesFlushTable(proto iptables.Protocol, table string) error {
+	ret := _m.ctrl.Call(_m, "NftablesFlushTable", proto, table)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NftablesFlushTable(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesFlushTable", arg0, arg1)
+}
+
+func (_m *MockNetworkHandler) NftablesChainExists(proto iptables.Protocol, table string, chain string) bool {
+	ret := _m.ctrl.Call(_m, "NftablesChainExists", proto, table, chain)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NftablesChainExists(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesChainExists", arg0, arg1, arg2)
+}
+
 func (_m *MockNetworkHandler) NftablesLoad(fnName string) error {
 	ret := _m.ctrl.Call(_m, "NftablesLoad", fnName)
 	ret0, _ := ret[0].(error)
@@ -305,6 +439,16 @@ func (_mr *_MockNetworkHandlerRecorder) NftablesLoad(arg0 interface{}) *gomock.C
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesLoad", arg0)
 }
 
+func (_m *MockNetworkHandler) NftablesCheck(proto iptables.Protocol) error {
+	ret := _m.ctrl.Call(_m, "NftablesCheck", proto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) NftablesCheck(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NftablesCheck", arg0)
+}
+
 func (_m *MockNetworkHandler) GetNFTIPString(proto iptables.Protocol) string {
 	ret := _m.ctrl.Call(_m, "GetNFTIPString", proto)
 	ret0, _ := ret[0].(string)
@@ -335,6 +479,36 @@ func (_mr *_MockNetworkHandlerRecorder) BindTapDeviceToBridge(arg0, arg1 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "BindTapDeviceToBridge", arg0, arg1)
 }
 
+func (_m *MockNetworkHandler) ConfigureTapQoS(tapName string, class qos.Class) error {
+	ret := _m.ctrl.Call(_m, "ConfigureTapQoS", tapName, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) ConfigureTapQoS(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConfigureTapQoS", arg0, arg1)
+}
+
+func (_m *MockNetworkHandler) ConfigureTapOffloads(tapName string, offloads *v1.InterfaceOffloads) error {
+	ret := _m.ctrl.Call(_m, "ConfigureTapOffloads", tapName, offloads)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) ConfigureTapOffloads(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConfigureTapOffloads", arg0, arg1)
+}
+
+func (_m *MockNetworkHandler) MirrorTapTraffic(tapName string, targetName string, direction v1.MirrorDirection) error {
+	ret := _m.ctrl.Call(_m, "MirrorTapTraffic", tapName, targetName, direction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) MirrorTapTraffic(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MirrorTapTraffic", arg0, arg1, arg2)
+}
+
 func (_m *MockNetworkHandler) DisableTXOffloadChecksum(ifaceName string) error {
 	ret := _m.ctrl.Call(_m, "DisableTXOffloadChecksum", ifaceName)
 	ret0, _ := ret[0].(error)
@@ -344,3 +518,43 @@ func (_m *MockNetworkHandler) DisableTXOffloadChecksum(ifaceName string) error {
 func (_mr *_MockNetworkHandlerRecorder) DisableTXOffloadChecksum(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DisableTXOffloadChecksum", arg0)
 }
+
+func (_m *MockNetworkHandler) DisableGRO(ifaceName string) error {
+	ret := _m.ctrl.Call(_m, "DisableGRO", ifaceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) DisableGRO(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DisableGRO", arg0)
+}
+
+func (_m *MockNetworkHandler) SendEthernetFrames(ifaceName string, frames [][]byte) error {
+	ret := _m.ctrl.Call(_m, "SendEthernetFrames", ifaceName, frames)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) SendEthernetFrames(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SendEthernetFrames", arg0, arg1)
+}
+
+func (_m *MockNetworkHandler) LinkSetBridgeSTP(link netlink.Link, enabled bool) error {
+	ret := _m.ctrl.Call(_m, "LinkSetBridgeSTP", link, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) LinkSetBridgeSTP(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LinkSetBridgeSTP", arg0, arg1)
+}
+
+func (_m *MockNetworkHandler) LinkSetBridgeMulticastQuerier(link netlink.Link, enabled bool) error {
+	ret := _m.ctrl.Call(_m, "LinkSetBridgeMulticastQuerier", link, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) LinkSetBridgeMulticastQuerier(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LinkSetBridgeMulticastQuerier", arg0, arg1)
+}
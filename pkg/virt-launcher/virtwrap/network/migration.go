@@ -0,0 +1,325 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// NeedsMigrationNetworkState tells if the VMI has bindings whose state the
+// migration target has to take over from the source.
+func NeedsMigrationNetworkState(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Bridge != nil || iface.Masquerade != nil || iface.Macvtap != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsMigrationTargetNetworkState tells if the VMI has bindings whose
+// devices differ between the pods, so that the migration source has to
+// take the state of the target over into the migrated domain.
+func NeedsMigrationTargetNetworkState(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Macvtap != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadMigrationNetworkState collects the state of the bridge, masquerade and
+// macvtap bindings from the caches of the launcher with the given pid.
+// Interfaces without a cached VIF are reported with their name and, for
+// masquerade, their nat configuration only.
+func ReadMigrationNetworkState(vmi *v1.VirtualMachineInstance, pid int) ([]v1.MigrationInterfaceNetworkState, error) {
+	states := []v1.MigrationInterfaceNetworkState{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Bridge == nil && iface.Masquerade == nil && iface.Macvtap == nil {
+			continue
+		}
+		state := v1.MigrationInterfaceNetworkState{Name: iface.Name}
+
+		vif := &VIF{}
+		exists, err := readFromCachedFile(strconv.Itoa(pid), iface.Name, vifCacheFile, vif)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cached vif of interface %s: %v", iface.Name, err)
+		}
+		if exists {
+			if len(vif.MAC) > 0 {
+				state.MAC = vif.MAC.String()
+			}
+			if vif.IP.IPNet != nil {
+				state.IP = vif.IP.IPNet.String()
+			}
+			if vif.IPv6.IPNet != nil {
+				state.IPv6 = vif.IPv6.IPNet.String()
+			}
+		}
+
+		if iface.Masquerade != nil {
+			var pluggedVIF *VIF
+			if exists {
+				pluggedVIF = vif
+			}
+			state.Masquerade, err = readMigrationMasqueradeState(vmi, &iface, pluggedVIF)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if iface.Macvtap != nil {
+			domainIface := &api.Interface{}
+			exists, err := readFromCachedFile(strconv.Itoa(pid), iface.Name, interfaceCacheFile, domainIface)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the cached interface %s: %v", iface.Name, err)
+			}
+			if exists && domainIface.Target != nil {
+				state.Device = domainIface.Target.Device
+			}
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// readMigrationMasqueradeState returns the nat configuration the masquerade
+// interface was plugged with. Forwarded ports changed in the spec since then
+// are not applied yet, so the configuration is taken from the pod interface
+// cache rather than from the spec.
+func readMigrationMasqueradeState(vmi *v1.VirtualMachineInstance, iface *v1.Interface, vif *VIF) (*v1.MigrationMasqueradeNetworkState, error) {
+	plugged := iface
+	cache := &PodCacheInterface{}
+	exists, err := readFromCachedFile(string(vmi.UID), iface.Name, util.VMIInterfacepath, cache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the cached pod interface %s: %v", iface.Name, err)
+	}
+	if exists && cache.Iface != nil {
+		plugged = cache.Iface
+	}
+
+	state := &v1.MigrationMasqueradeNetworkState{Ports: plugged.Ports}
+	if plugged.Masquerade != nil {
+		state.Hairpin = plugged.Masquerade.Hairpin
+		state.ClampMSS = plugged.Masquerade.ClampMSS != nil && *plugged.Masquerade.ClampMSS
+	}
+
+	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
+	if _, exists := networks[iface.Name]; !exists || vif == nil || vif.IP.IPNet == nil {
+		return state, nil
+	}
+	podInterfaceName := getPodInterfaceName(networks, cniNetworks, iface.Name)
+	driver := &MasqueradePodInterface{
+		vmi:                 vmi,
+		iface:               plugged,
+		vif:                 vif,
+		podInterfaceName:    podInterfaceName,
+		bridgeInterfaceName: fmt.Sprintf("k6t-%s", podInterfaceName),
+		gatewayAddr:         &netlink.Addr{IPNet: &net.IPNet{IP: vif.Gateway}},
+		gatewayIpv6Addr:     &netlink.Addr{IPNet: &net.IPNet{IP: vif.GatewayIpv6}},
+	}
+	state.NatRules = driver.natRuleSet()
+	return state, nil
+}
+
+// natRuleSet returns the nat rules of the interface of all its IP families in
+// iptables notation, whichever backend they were created with
+func (p *MasqueradePodInterface) natRuleSet() []string {
+	protocols := []iptables.Protocol{iptables.ProtocolIPv4}
+	if p.vif.IPv6.IPNet != nil {
+		protocols = append(protocols, iptables.ProtocolIPv6)
+	}
+
+	var rules []string
+	for _, proto := range protocols {
+		for _, rule := range p.iptablesNatRules(proto) {
+			rules = append(rules, strings.Join(append([]string{"-A", rule.chain}, rule.spec...), " "))
+		}
+	}
+	return rules
+}
+
+// migrationMasqueradeInterface returns the interface with the nat
+// configuration of the migration source, which the target applies until the
+// migration completed. Changes of the forwarded ports pending on the source
+// are applied on the target afterwards, like on any other node.
+func migrationMasqueradeInterface(iface *v1.Interface, state *v1.MigrationMasqueradeNetworkState) *v1.Interface {
+	plugged := iface.DeepCopy()
+	plugged.Ports = state.Ports
+	if plugged.Masquerade == nil {
+		plugged.Masquerade = &v1.InterfaceMasquerade{}
+	}
+	plugged.Masquerade.Hairpin = state.Hairpin
+	plugged.Masquerade.ClampMSS = nil
+	if state.ClampMSS {
+		clampMSS := true
+		plugged.Masquerade.ClampMSS = &clampMSS
+	}
+	return plugged
+}
+
+// sourceNetworkState returns the state the source node reported for the
+// interface, if the VMI is being migrated
+func sourceNetworkState(vmi *v1.VirtualMachineInstance, ifaceName string) *v1.MigrationInterfaceNetworkState {
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil || migrationState.Completed {
+		return nil
+	}
+	for i, state := range migrationState.SourceNetworkState {
+		if state.Name == ifaceName {
+			return &migrationState.SourceNetworkState[i]
+		}
+	}
+	return nil
+}
+
+// targetNetworkState returns the state the target node reported for the
+// interface, if the VMI is being migrated
+func targetNetworkState(vmi *v1.VirtualMachineInstance, ifaceName string) *v1.MigrationInterfaceNetworkState {
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil || migrationState.Completed {
+		return nil
+	}
+	for i, state := range migrationState.TargetNetworkState {
+		if state.Name == ifaceName {
+			return &migrationState.TargetNetworkState[i]
+		}
+	}
+	return nil
+}
+
+// bindingInterface returns the interface the binding is plugged with, which
+// differs from the spec while a masquerade interface takes the nat
+// configuration of the migration source over
+func bindingInterface(driver BindMechanism, iface *v1.Interface) *v1.Interface {
+	if masquerade, ok := driver.(*MasqueradePodInterface); ok && masquerade.iface != nil {
+		return masquerade.iface
+	}
+	return iface
+}
+
+func bindingVIF(driver BindMechanism) *VIF {
+	switch binding := driver.(type) {
+	case *BridgePodInterface:
+		return binding.vif
+	case *MasqueradePodInterface:
+		return binding.vif
+	case *MacvtapPodInterface:
+		return binding.vif
+	}
+	return nil
+}
+
+// UpdateMigrationDomainInterfaces points the macvtap interfaces of the domain
+// which is sent to the migration target at the devices of the target pod
+func UpdateMigrationDomainInterfaces(vmi *v1.VirtualMachineInstance, domainSpec *api.DomainSpec) error {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Macvtap == nil {
+			continue
+		}
+		state := targetNetworkState(vmi, iface.Name)
+		if state == nil || state.Device == "" {
+			return fmt.Errorf("the migration target did not report the macvtap device of interface %s", iface.Name)
+		}
+		for i, domainIface := range domainSpec.Devices.Interfaces {
+			if domainIface.Alias == nil || domainIface.Alias.Name != iface.Name {
+				continue
+			}
+			if domainIface.Target == nil {
+				domainSpec.Devices.Interfaces[i].Target = &api.InterfaceTarget{Managed: "no"}
+			}
+			domainSpec.Devices.Interfaces[i].Target.Device = state.Device
+		}
+	}
+	return nil
+}
+
+// applyMigrationNetworkState makes the binding keep the MAC address the guest
+// used on the source node, unless the spec already requests one, and makes a
+// masquerade binding create the nat rules of the source node
+func applyMigrationNetworkState(vmi *v1.VirtualMachineInstance, iface *v1.Interface, driver BindMechanism) error {
+	state := sourceNetworkState(vmi, iface.Name)
+	if state == nil {
+		return nil
+	}
+
+	if masquerade, ok := driver.(*MasqueradePodInterface); ok && state.Masquerade != nil {
+		masquerade.iface = migrationMasqueradeInterface(iface, state.Masquerade)
+	}
+
+	vif := bindingVIF(driver)
+	if vif == nil || state.MAC == "" || iface.MacAddress != "" {
+		return nil
+	}
+
+	mac, err := net.ParseMAC(state.MAC)
+	if err != nil {
+		return fmt.Errorf("failed to parse the MAC address %s of interface %s on the migration source: %v", state.MAC, iface.Name, err)
+	}
+	vif.MAC = mac
+	return nil
+}
+
+// verifyMigrationNetworkState warns if the addresses discovered on the
+// target differ from the ones the guest used on the source node. The IP
+// addresses are not taken over: masquerade derives the same ones from the VMI
+// network CIDR, while bridge hands the IP of the target pod to the guest.
+func verifyMigrationNetworkState(vmi *v1.VirtualMachineInstance, iface *v1.Interface, driver BindMechanism) {
+	state := sourceNetworkState(vmi, iface.Name)
+	vif := bindingVIF(driver)
+	if state == nil || vif == nil {
+		return
+	}
+
+	if ip := ipNetString(vif.IP.IPNet); state.IP != "" && ip != state.IP {
+		log.Log.Object(vmi).Warningf("interface %s has IP %s on the migration target instead of %s, the guest has to renew its lease", iface.Name, ip, state.IP)
+	}
+	if ip := ipNetString(vif.IPv6.IPNet); state.IPv6 != "" && ip != state.IPv6 {
+		log.Log.Object(vmi).Warningf("interface %s has IPv6 %s on the migration target instead of %s, the guest has to renew its lease", iface.Name, ip, state.IPv6)
+	}
+
+	masquerade, ok := driver.(*MasqueradePodInterface)
+	if !ok || state.Masquerade == nil || len(state.Masquerade.NatRules) == 0 {
+		return
+	}
+	if rules := masquerade.natRuleSet(); !reflect.DeepEqual(rules, state.Masquerade.NatRules) {
+		log.Log.Object(vmi).Warningf("interface %s gets the nat rules %q on the migration target instead of %q", iface.Name, rules, state.Masquerade.NatRules)
+	}
+}
+
+func ipNetString(ipNet *net.IPNet) string {
+	if ipNet == nil {
+		return ""
+	}
+	return ipNet.String()
+}
@@ -0,0 +1,251 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var _ = Describe("Migration network state", func() {
+	var tmpDir string
+	var origVMIInterfacepath string
+	const pid = 1234
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "migrationtest")
+		Expect(err).ToNot(HaveOccurred())
+		setVifCacheFile(tmpDir + "/vif-cache-%s-%s.json")
+		setInterfaceCacheFile(tmpDir + "/interface-cache-%s-%s.json")
+		origVMIInterfacepath = util.VMIInterfacepath
+		util.VMIInterfacepath = tmpDir + "/pod-cache-%s-%s.json"
+	})
+
+	AfterEach(func() {
+		util.VMIInterfacepath = origVMIInterfacepath
+		os.RemoveAll(tmpDir)
+	})
+
+	newMigratingVMI := func(state ...v1.MigrationInterfaceNetworkState) *v1.VirtualMachineInstance {
+		vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+			SourceNetworkState: state,
+		}
+		return vmi
+	}
+
+	It("should read the state of the cached VIFs", func() {
+		vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+		mac, _ := net.ParseMAC("de:ad:00:00:be:af")
+		ip, _ := netlink.ParseAddr("10.0.2.2/24")
+		ipv6, _ := netlink.ParseAddr("fd10:0:2::2/120")
+		masquerade := &MasqueradePodInterface{vif: &VIF{
+			Name:        "eth0",
+			MAC:         mac,
+			IP:          *ip,
+			IPv6:        *ipv6,
+			Gateway:     net.ParseIP("10.0.2.1"),
+			GatewayIpv6: net.ParseIP("fd10:0:2::1"),
+		}}
+		Expect(masquerade.setCachedVIF(strconv.Itoa(pid), "default")).To(Succeed())
+
+		state, err := ReadMigrationNetworkState(vmi, pid)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state).To(Equal([]v1.MigrationInterfaceNetworkState{{
+			Name: "default",
+			MAC:  "de:ad:00:00:be:af",
+			IP:   "10.0.2.2/24",
+			IPv6: "fd10:0:2::2/120",
+			Masquerade: &v1.MigrationMasqueradeNetworkState{
+				NatRules: []string{
+					"-A POSTROUTING -s 10.0.2.2 -j MASQUERADE",
+					"-A PREROUTING -i eth0 -j KUBEVIRT_PREINBOUND",
+					"-A POSTROUTING -o k6t-eth0 -j KUBEVIRT_POSTINBOUND",
+					"-A KUBEVIRT_PREINBOUND -j DNAT --to-destination 10.0.2.2",
+					"-A POSTROUTING -s fd10:0:2::2 -j MASQUERADE",
+					"-A PREROUTING -i eth0 -j KUBEVIRT_PREINBOUND",
+					"-A POSTROUTING -o k6t-eth0 -j KUBEVIRT_POSTINBOUND",
+					"-A KUBEVIRT_PREINBOUND -j DNAT --to-destination fd10:0:2::2",
+				},
+			},
+		}}))
+	})
+
+	It("should report the nat configuration the masquerade interface was plugged with", func() {
+		vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+		plugged := vmi.Spec.Domain.Devices.Interfaces[0].DeepCopy()
+		plugged.Ports = []v1.Port{{Port: 80, Protocol: "TCP"}}
+		plugged.Masquerade.Hairpin = v1.MasqueradeHairpinNone
+		// the spec changed since the interface was plugged
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Port: 80, Protocol: "TCP"}, {Port: 443, Protocol: "TCP"}}
+		Expect(writeToCachedFile(&PodCacheInterface{Iface: plugged}, util.VMIInterfacepath, string(vmi.UID), "default")).To(Succeed())
+		ip, _ := netlink.ParseAddr("10.0.2.2/24")
+		masquerade := &MasqueradePodInterface{vif: &VIF{Name: "eth0", IP: *ip, Gateway: net.ParseIP("10.0.2.1")}}
+		Expect(masquerade.setCachedVIF(strconv.Itoa(pid), "default")).To(Succeed())
+
+		state, err := ReadMigrationNetworkState(vmi, pid)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state).To(HaveLen(1))
+		Expect(state[0].Masquerade).To(Equal(&v1.MigrationMasqueradeNetworkState{
+			Ports:   []v1.Port{{Port: 80, Protocol: "TCP"}},
+			Hairpin: v1.MasqueradeHairpinNone,
+			NatRules: []string{
+				"-A POSTROUTING -s 10.0.2.2 -j MASQUERADE",
+				"-A PREROUTING -i eth0 -j KUBEVIRT_PREINBOUND",
+				"-A POSTROUTING -o k6t-eth0 -j KUBEVIRT_POSTINBOUND",
+				"-A KUBEVIRT_PREINBOUND -p tcp --dport 80 -j DNAT --to-destination 10.0.2.2",
+			},
+		}))
+	})
+
+	It("should report interfaces without a cached VIF by name", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+
+		state, err := ReadMigrationNetworkState(vmi, pid)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state).To(Equal([]v1.MigrationInterfaceNetworkState{{Name: "default"}}))
+	})
+
+	It("should read the device of macvtap interfaces", func() {
+		vmi := newVMIMacvtapInterface("testnamespace", "testVmName", "macvtap0")
+		mac, _ := net.ParseMAC("de:ad:00:00:be:af")
+		macvtap := &MacvtapPodInterface{
+			vif:       &VIF{Name: "net1", MAC: mac},
+			virtIface: &api.Interface{Target: &api.InterfaceTarget{Device: "net1", Managed: "no"}},
+		}
+		Expect(macvtap.setCachedVIF(strconv.Itoa(pid), "macvtap0")).To(Succeed())
+		Expect(macvtap.setCachedInterface(strconv.Itoa(pid), "macvtap0")).To(Succeed())
+
+		Expect(NeedsMigrationTargetNetworkState(vmi)).To(BeTrue())
+		state, err := ReadMigrationNetworkState(vmi, pid)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state).To(Equal([]v1.MigrationInterfaceNetworkState{{
+			Name:   "macvtap0",
+			MAC:    "de:ad:00:00:be:af",
+			Device: "net1",
+		}}))
+	})
+
+	It("should point macvtap interfaces at the devices of the target pod", func() {
+		vmi := newVMIMacvtapInterface("testnamespace", "testVmName", "macvtap0")
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+			TargetNetworkState: []v1.MigrationInterfaceNetworkState{{Name: "macvtap0", Device: "net2"}},
+		}
+		domainSpec := &api.DomainSpec{Devices: api.Devices{Interfaces: []api.Interface{{
+			Alias:  &api.Alias{Name: "macvtap0"},
+			Target: &api.InterfaceTarget{Device: "net1", Managed: "no"},
+		}}}}
+
+		Expect(UpdateMigrationDomainInterfaces(vmi, domainSpec)).To(Succeed())
+		Expect(domainSpec.Devices.Interfaces[0].Target).To(Equal(&api.InterfaceTarget{Device: "net2", Managed: "no"}))
+	})
+
+	It("should fail to migrate macvtap interfaces without the device of the target pod", func() {
+		vmi := newVMIMacvtapInterface("testnamespace", "testVmName", "macvtap0")
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+
+		Expect(UpdateMigrationDomainInterfaces(vmi, &api.DomainSpec{})).ToNot(Succeed())
+	})
+
+	It("should not need any state without bridge, masquerade or macvtap bindings", func() {
+		vmi := newVMISlirpInterface("testnamespace", "testVmName")
+		Expect(NeedsMigrationNetworkState(vmi)).To(BeFalse())
+
+		state, err := ReadMigrationNetworkState(vmi, pid)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state).To(BeEmpty())
+	})
+
+	It("should keep the MAC address of the migration source", func() {
+		vmi := newMigratingVMI(v1.MigrationInterfaceNetworkState{Name: "default", MAC: "de:ad:00:00:be:af"})
+
+		driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], "eth0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(driver.(*MasqueradePodInterface).vif.MAC.String()).To(Equal("de:ad:00:00:be:af"))
+	})
+
+	It("should prefer the MAC address of the spec", func() {
+		vmi := newMigratingVMI(v1.MigrationInterfaceNetworkState{Name: "default", MAC: "de:ad:00:00:be:af"})
+		vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de:ad:00:00:be:00"
+
+		driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], "eth0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(driver.(*MasqueradePodInterface).vif.MAC.String()).To(Equal("de:ad:00:00:be:00"))
+	})
+
+	It("should ignore the state of completed migrations", func() {
+		vmi := newMigratingVMI(v1.MigrationInterfaceNetworkState{Name: "default", MAC: "de:ad:00:00:be:af"})
+		vmi.Status.MigrationState.Completed = true
+
+		driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], "eth0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(driver.(*MasqueradePodInterface).vif.MAC).To(BeEmpty())
+	})
+
+	It("should create the nat rules of the migration source", func() {
+		clampMSS := true
+		vmi := newMigratingVMI(v1.MigrationInterfaceNetworkState{
+			Name: "default",
+			Masquerade: &v1.MigrationMasqueradeNetworkState{
+				Ports:    []v1.Port{{Port: 8080, Protocol: "TCP"}},
+				Hairpin:  v1.MasqueradeHairpinFull,
+				ClampMSS: true,
+			},
+		})
+		iface := &vmi.Spec.Domain.Devices.Interfaces[0]
+		iface.Ports = []v1.Port{{Port: 80, Protocol: "TCP"}}
+
+		driver, err := getPhase1Binding(vmi, iface, &vmi.Spec.Networks[0], "eth0")
+		Expect(err).ToNot(HaveOccurred())
+		plugged := bindingInterface(driver, iface)
+		Expect(plugged.Ports).To(Equal([]v1.Port{{Port: 8080, Protocol: "TCP"}}))
+		Expect(plugged.Masquerade).To(Equal(&v1.InterfaceMasquerade{Hairpin: v1.MasqueradeHairpinFull, ClampMSS: &clampMSS}))
+		// the spec is applied once the migration completed
+		Expect(iface.Ports).To(Equal([]v1.Port{{Port: 80, Protocol: "TCP"}}))
+		Expect(iface.Masquerade).To(Equal(&v1.InterfaceMasquerade{}))
+	})
+
+	It("should plug the nat rules of the spec without the state of the source", func() {
+		vmi := newMigratingVMI(v1.MigrationInterfaceNetworkState{Name: "default", MAC: "de:ad:00:00:be:af"})
+		iface := &vmi.Spec.Domain.Devices.Interfaces[0]
+
+		driver, err := getPhase1Binding(vmi, iface, &vmi.Spec.Networks[0], "eth0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bindingInterface(driver, iface)).To(Equal(iface))
+	})
+
+	It("should fail on a malformed MAC address of the source", func() {
+		vmi := newMigratingVMI(v1.MigrationInterfaceNetworkState{Name: "default", MAC: "mac"})
+
+		_, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], "eth0")
+		Expect(err).To(HaveOccurred())
+	})
+})
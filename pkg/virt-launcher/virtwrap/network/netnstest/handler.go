@@ -0,0 +1,442 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package netnstest provides a network.NetworkHandler which executes all
+// operations against a real, private network namespace. It allows binding
+// logic to be verified against kernel behavior instead of mocks, while
+// leaving the network configuration of the host untouched.
+//
+// Creating network namespaces requires CAP_SYS_ADMIN and CAP_NET_ADMIN,
+// tests using this package should skip when Supported returns false.
+package netnstest
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+	"sync"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
+)
+
+// DHCPServerConfig records the arguments of a StartDHCP call. No DHCP server
+// is actually started, since it would never return.
+type DHCPServerConfig struct {
+	Nic                 network.VIF
+	ServerAddr          net.IP
+	BridgeInterfaceName string
+	DHCPOptions         *v1.DHCPOptions
+}
+
+type Handler struct {
+	// Embedded for the entry points which do not touch the kernel
+	network.NetworkUtilsHandler
+
+	// IPv4Primary is returned by IsIpv4Primary, it defaults to true.
+	IPv4Primary bool
+
+	ns netns.NsHandle
+	nl *netlink.Handle
+
+	lock        sync.Mutex
+	dhcpServers []DHCPServerConfig
+}
+
+var _ network.NetworkHandler = &Handler{}
+
+// New creates a network namespace and returns a handler operating inside of
+// it. The caller must call Close to release the namespace.
+func New() (*Handler, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current network namespace: %v", err)
+	}
+	defer origin.Close()
+
+	ns, err := netns.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a network namespace: %v", err)
+	}
+	if err := netns.Set(origin); err != nil {
+		ns.Close()
+		return nil, fmt.Errorf("failed to restore the original network namespace: %v", err)
+	}
+
+	nl, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		ns.Close()
+		return nil, fmt.Errorf("failed to open a netlink handle: %v", err)
+	}
+
+	h := &Handler{IPv4Primary: true, ns: ns, nl: nl}
+	if err := h.bringUpLoopback(); err != nil {
+		h.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+// Supported reports whether network namespaces can be created by the
+// current process.
+func Supported() bool {
+	h, err := New()
+	if err != nil {
+		return false
+	}
+	h.Close()
+	return true
+}
+
+// Close releases the network namespace together with all links created in it.
+func (h *Handler) Close() error {
+	h.nl.Delete()
+	return h.ns.Close()
+}
+
+// Do executes f with the calling thread switched into the network namespace.
+func (h *Handler) Do(f func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get the current network namespace: %v", err)
+	}
+	defer origin.Close()
+
+	if err := netns.Set(h.ns); err != nil {
+		return fmt.Errorf("failed to enter the network namespace: %v", err)
+	}
+	defer netns.Set(origin)
+
+	return f()
+}
+
+// AddPodInterface simulates a pod interface configured by a CNI plugin: one
+// end of a veth pair with the given address and a default route through
+// gateway. Leave cidr empty to simulate a network with IPAM disabled.
+func (h *Handler) AddPodInterface(name string, mtu int, cidr string, gateway string) (netlink.Link, error) {
+	peerName := fmt.Sprintf("%.11s-cni", name)
+	link := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: name, MTU: mtu}, PeerName: peerName}
+	if err := h.nl.LinkAdd(link); err != nil {
+		return nil, fmt.Errorf("failed to create pod interface %s: %v", name, err)
+	}
+	for _, linkName := range []string{peerName, name} {
+		l, err := h.nl.LinkByName(linkName)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.nl.LinkSetUp(l); err != nil {
+			return nil, err
+		}
+	}
+	podLink, err := h.nl.LinkByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if cidr == "" {
+		return podLink, nil
+	}
+
+	addr, err := netlink.ParseAddr(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.nl.AddrAdd(podLink, addr); err != nil {
+		return nil, fmt.Errorf("failed to set address %s on %s: %v", cidr, name, err)
+	}
+	if gateway != "" {
+		route := &netlink.Route{LinkIndex: podLink.Attrs().Index, Gw: net.ParseIP(gateway)}
+		if err := h.nl.RouteAdd(route); err != nil {
+			return nil, fmt.Errorf("failed to add default route via %s: %v", gateway, err)
+		}
+	}
+	return podLink, nil
+}
+
+// DHCPServers returns the configuration of all DHCP servers requested so far.
+func (h *Handler) DHCPServers() []DHCPServerConfig {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return append([]DHCPServerConfig{}, h.dhcpServers...)
+}
+
+func (h *Handler) bringUpLoopback() error {
+	lo, err := h.nl.LinkByName("lo")
+	if err != nil {
+		return err
+	}
+	return h.nl.LinkSetUp(lo)
+}
+
+func (h *Handler) LinkByName(name string) (netlink.Link, error) {
+	return h.nl.LinkByName(name)
+}
+
+func (h *Handler) AddrList(link netlink.Link, family int) ([]netlink.Addr, error) {
+	return h.nl.AddrList(link, family)
+}
+
+func (h *Handler) RouteList(link netlink.Link, family int) ([]netlink.Route, error) {
+	return h.nl.RouteList(link, family)
+}
+
+func (h *Handler) AddrDel(link netlink.Link, addr *netlink.Addr) error {
+	return h.nl.AddrDel(link, addr)
+}
+
+func (h *Handler) AddrAdd(link netlink.Link, addr *netlink.Addr) error {
+	return h.nl.AddrAdd(link, addr)
+}
+
+func (h *Handler) LinkSetDown(link netlink.Link) error {
+	return h.nl.LinkSetDown(link)
+}
+
+func (h *Handler) LinkSetUp(link netlink.Link) error {
+	return h.nl.LinkSetUp(link)
+}
+
+func (h *Handler) LinkAdd(link netlink.Link) error {
+	return h.nl.LinkAdd(link)
+}
+
+func (h *Handler) LinkSetLearningOff(link netlink.Link) error {
+	return h.nl.LinkSetLearning(link, false)
+}
+
+func (h *Handler) LinkSetMaster(link netlink.Link, master *netlink.Bridge) error {
+	return h.nl.LinkSetMaster(link, master)
+}
+
+func (h *Handler) LinkSetHardwareAddr(link netlink.Link, hwaddr net.HardwareAddr) error {
+	return h.nl.LinkSetHardwareAddr(link, hwaddr)
+}
+
+func (h *Handler) SetRandomMac(iface string) (mac net.HardwareAddr, err error) {
+	err = h.Do(func() error {
+		mac, err = h.NetworkUtilsHandler.SetRandomMac(iface)
+		return err
+	})
+	return mac, err
+}
+
+func (h *Handler) GetMacDetails(iface string) (net.HardwareAddr, error) {
+	link, err := h.nl.LinkByName(iface)
+	if err != nil {
+		return nil, err
+	}
+	return link.Attrs().HardwareAddr, nil
+}
+
+func (h *Handler) StartDHCP(nic *network.VIF, serverAddr net.IP, bridgeInterfaceName string, dhcpOptions *v1.DHCPOptions) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.dhcpServers = append(h.dhcpServers, DHCPServerConfig{
+		Nic:                 *nic,
+		ServerAddr:          serverAddr,
+		BridgeInterfaceName: bridgeInterfaceName,
+		DHCPOptions:         dhcpOptions,
+	})
+	return nil
+}
+
+func (h *Handler) HasNatIptables(proto iptables.Protocol) (hasNat bool) {
+	h.Do(func() error {
+		hasNat = h.NetworkUtilsHandler.HasNatIptables(proto)
+		return nil
+	})
+	return hasNat
+}
+
+func (h *Handler) IsIpv6Enabled(interfaceName string) (bool, error) {
+	link, err := h.nl.LinkByName(interfaceName)
+	if err != nil {
+		return false, err
+	}
+	addrList, err := h.nl.AddrList(link, netlink.FAMILY_V6)
+	if err != nil {
+		return false, err
+	}
+	for _, addr := range addrList {
+		if addr.IP.IsGlobalUnicast() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (h *Handler) IsIpv4Primary() (bool, error) {
+	return h.IPv4Primary, nil
+}
+
+func (h *Handler) GetSysctl(name string) (int, error) {
+	var value int
+	err := h.Do(func() (err error) {
+		value, err = h.NetworkUtilsHandler.GetSysctl(name)
+		return err
+	})
+	return value, err
+}
+
+func (h *Handler) SetSysctl(name string, value int) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.SetSysctl(name, value)
+	})
+}
+
+func (h *Handler) IptablesNewChain(proto iptables.Protocol, table, chain string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.IptablesNewChain(proto, table, chain)
+	})
+}
+
+func (h *Handler) IptablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.IptablesAppendRule(proto, table, chain, rulespec...)
+	})
+}
+
+func (h *Handler) IptablesDeleteRule(proto iptables.Protocol, table, chain string, rulespec ...string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.IptablesDeleteRule(proto, table, chain, rulespec...)
+	})
+}
+
+func (h *Handler) NftablesNewTable(proto iptables.Protocol, table string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.NftablesNewTable(proto, table)
+	})
+}
+
+func (h *Handler) NftablesNewChain(proto iptables.Protocol, table, chain string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.NftablesNewChain(proto, table, chain)
+	})
+}
+
+func (h *Handler) NftablesNewBaseChain(proto iptables.Protocol, table, chain, chainType, hook string, priority int) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.NftablesNewBaseChain(proto, table, chain, chainType, hook, priority)
+	})
+}
+
+func (h *Handler) NftablesAppendRule(proto iptables.Protocol, table, chain string, rulespec ...string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.NftablesAppendRule(proto, table, chain, rulespec...)
+	})
+}
+
+func (h *Handler) NftablesFlushChain(proto iptables.Protocol, table, chain string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.NftablesFlushChain(proto, table, chain)
+	})
+}
+
+func (h *Handler) NftablesFlushTable(proto iptables.Protocol, table string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.NftablesFlushTable(proto, table)
+	})
+}
+
+func (h *Handler) NftablesLoad(fnName string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.NftablesLoad(fnName)
+	})
+}
+
+// CreateTapDevice creates the tap device directly in the namespace instead of
+// going through virt-chroot, which is not available in unit tests.
+func (h *Handler) CreateTapDevice(tapName string, queueNumber uint32, launcherPID int, mtu int) error {
+	tap := &netlink.Tuntap{
+		LinkAttrs: netlink.LinkAttrs{Name: tapName, MTU: mtu},
+		Mode:      netlink.TUNTAP_MODE_TAP,
+		Flags:     netlink.TUNTAP_DEFAULTS,
+	}
+	if queueNumber > 0 {
+		tap.Flags |= netlink.TUNTAP_MULTI_QUEUE_DEFAULTS
+		tap.Queues = int(queueNumber)
+	}
+	err := h.Do(func() error {
+		if err := netlink.LinkAdd(tap); err != nil {
+			return fmt.Errorf("error creating tap device named %s; %v", tapName, err)
+		}
+		for _, fd := range tap.Fds {
+			fd.Close()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return h.nl.LinkSetMTU(tap, mtu)
+}
+
+func (h *Handler) BindTapDeviceToBridge(tapName string, bridgeName string) error {
+	tap, err := h.nl.LinkByName(tapName)
+	if err != nil {
+		return fmt.Errorf("could not find tap device %s; %v", tapName, err)
+	}
+	bridge, err := h.nl.LinkByName(bridgeName)
+	if err != nil {
+		return fmt.Errorf("could not find bridge %s; %v", bridgeName, err)
+	}
+	if err := h.nl.LinkSetMaster(tap, bridge); err != nil {
+		return fmt.Errorf("failed to bind tap device %s to bridge %s; %v", tapName, bridgeName, err)
+	}
+	return h.nl.LinkSetUp(tap)
+}
+
+func (h *Handler) MirrorTapTraffic(tapName string, targetName string, direction v1.MirrorDirection) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.MirrorTapTraffic(tapName, targetName, direction)
+	})
+}
+
+func (h *Handler) DisableTXOffloadChecksum(ifaceName string) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.DisableTXOffloadChecksum(ifaceName)
+	})
+}
+
+func (h *Handler) SendEthernetFrames(ifaceName string, frames [][]byte) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.SendEthernetFrames(ifaceName, frames)
+	})
+}
+
+func (h *Handler) LinkSetBridgeSTP(link netlink.Link, enabled bool) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.LinkSetBridgeSTP(link, enabled)
+	})
+}
+
+func (h *Handler) LinkSetBridgeMulticastQuerier(link netlink.Link, enabled bool) error {
+	return h.Do(func() error {
+		return h.NetworkUtilsHandler.LinkSetBridgeMulticastQuerier(link, enabled)
+	})
+}
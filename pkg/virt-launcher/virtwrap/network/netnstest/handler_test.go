@@ -0,0 +1,105 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package netnstest
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
+)
+
+var _ = Describe("Network namespace backed handler", func() {
+	var handler *Handler
+
+	BeforeEach(func() {
+		handler = nil
+		if !Supported() {
+			Skip("creating network namespaces requires privileges")
+		}
+		var err error
+		handler, err = New()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if handler != nil {
+			Expect(handler.Close()).To(Succeed())
+		}
+	})
+
+	It("should not leak links into the current namespace", func() {
+		_, err := handler.AddPodInterface("eth0-nstest", 1400, "", "")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = handler.LinkByName("eth0-nstest")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = netlink.LinkByName("eth0-nstest")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should simulate a pod interface configured by CNI", func() {
+		link, err := handler.AddPodInterface("eth0", 1400, "10.35.0.6/24", "10.35.0.1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(link.Attrs().MTU).To(Equal(1400))
+
+		addrs, err := handler.AddrList(link, netlink.FAMILY_V4)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(addrs).To(HaveLen(1))
+		Expect(addrs[0].IP.String()).To(Equal("10.35.0.6"))
+
+		routes, err := handler.RouteList(link, netlink.FAMILY_V4)
+		Expect(err).ToNot(HaveOccurred())
+		var gateways []string
+		for _, route := range routes {
+			if route.Gw != nil {
+				gateways = append(gateways, route.Gw.String())
+			}
+		}
+		Expect(gateways).To(ConsistOf("10.35.0.1"))
+	})
+
+	It("should create a tap device and bind it to a bridge", func() {
+		bridge := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "k6t-eth0"}}
+		Expect(handler.LinkAdd(bridge)).To(Succeed())
+		Expect(handler.CreateTapDevice("tap0", 0, 0, 1400)).To(Succeed())
+		Expect(handler.BindTapDeviceToBridge("tap0", "k6t-eth0")).To(Succeed())
+
+		bridgeLink, err := handler.LinkByName("k6t-eth0")
+		Expect(err).ToNot(HaveOccurred())
+		tap, err := handler.LinkByName("tap0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tap.Attrs().MTU).To(Equal(1400))
+		Expect(tap.Attrs().MasterIndex).To(Equal(bridgeLink.Attrs().Index))
+	})
+
+	It("should record DHCP server requests instead of serving", func() {
+		nic := &network.VIF{Name: "eth0", Mtu: 1400}
+		Expect(handler.StartDHCP(nic, net.ParseIP("169.254.75.10"), "k6t-eth0", nil)).To(Succeed())
+
+		servers := handler.DHCPServers()
+		Expect(servers).To(HaveLen(1))
+		Expect(servers[0].Nic.Name).To(Equal("eth0"))
+		Expect(servers[0].BridgeInterfaceName).To(Equal("k6t-eth0"))
+	})
+})
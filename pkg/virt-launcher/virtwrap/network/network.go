@@ -28,6 +28,13 @@ package network
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"sync"
+
+	"github.com/coreos/go-iptables/iptables"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/util"
@@ -39,14 +46,17 @@ const podInterface = "eth0"
 var interfaceCacheFile = "/proc/%s/root/var/run/kubevirt-private/interface-cache-%s.json"
 var qemuArgCacheFile = "/proc/%s/root/var/run/kubevirt-private/qemu-arg-%s.json"
 var vifCacheFile = "/proc/%s/root/var/run/kubevirt-private/vif-cache-%s.json"
+var dhcpLeaseCacheFile = "/proc/%s/root/var/run/kubevirt-private/dhcp-lease-%s.json"
 var NetworkInterfaceFactory = getNetworkClass
 
-var podInterfaceName = podInterface
-
 type PodCacheInterface struct {
 	Iface  *v1.Interface `json:"iface,omitempty"`
 	PodIP  string        `json:"podIP,omitempty"`
 	PodIPs []string      `json:"podIPs,omitempty"`
+	// PrimaryIPFamily is the family PodIPs were ordered by, it is kept for debugging
+	PrimaryIPFamily k8sv1.IPFamily `json:"primaryIPFamily,omitempty"`
+	// BridgeTXChecksumOffload records whether TX checksum offload was kept on the bridge of the interface
+	BridgeTXChecksumOffload *bool `json:"bridgeTXChecksumOffload,omitempty"`
 }
 
 type plugFunction func(vif NetworkInterface, vmi *v1.VirtualMachineInstance, iface *v1.Interface, network *v1.Network, domain *api.Domain, podInterfaceName string) error
@@ -106,25 +116,145 @@ func getPodInterfaceName(networks map[string]*v1.Network, cniNetworks map[string
 	}
 }
 
-func SetupNetworkInterfacesPhase1(vmi *v1.VirtualMachineInstance, pid int) error {
+// BindingName returns the name of the binding method of the interface, as
+// reported in the VMI status.
+func BindingName(iface *v1.Interface) string {
+	switch {
+	case iface.Bridge != nil:
+		return "bridge"
+	case iface.Masquerade != nil:
+		return "masquerade"
+	case iface.Slirp != nil:
+		return "slirp"
+	case iface.SRIOV != nil:
+		return "sriov"
+	case iface.Macvtap != nil:
+		return "macvtap"
+	}
+	return ""
+}
+
+// BridgeDeviceName returns the name of the bridge connecting the tap device
+// of the interface to the pod network, or an empty string if the binding of
+// the interface does not use one.
+func BridgeDeviceName(vmi *v1.VirtualMachineInstance, iface *v1.Interface) string {
+	if iface.Bridge == nil && iface.Masquerade == nil {
+		return ""
+	}
+	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
+	if _, exists := networks[iface.Name]; !exists {
+		return ""
+	}
+	return fmt.Sprintf("k6t-%s", getPodInterfaceName(networks, cniNetworks, iface.Name))
+}
+
+// maxParallelPhase1Plugs bounds the number of interfaces of a single VMI
+// which are plugged concurrently by virt-handler.
+const maxParallelPhase1Plugs = 4
+
+// SetupNetworkInterfacesPhase1 plugs all interfaces of the VMI in parallel.
+// doNetNS has to execute the passed function in the network namespace of the
+// virt-launcher pod. It is called once per interface and from different
+// goroutines, since the network namespace is a property of the OS thread.
+func SetupNetworkInterfacesPhase1(vmi *v1.VirtualMachineInstance, pid int, doNetNS func(func() error) error) error {
 	// Create a dir with VMI UID under network-info-dir to store network files
 	err := os.MkdirAll(fmt.Sprintf(util.VMIInterfaceDir, vmi.ObjectMeta.UID), 0755)
 	if err != nil {
 		return err
 	}
+	initHandler()
+
 	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
-	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
-		networkInterfaceFactory, err := getNetworkInterfaceFactory(networks, iface.Name)
+	ifaces := vmi.Spec.Domain.Devices.Interfaces
+	vifs := make([]NetworkInterface, len(ifaces))
+	for i, iface := range ifaces {
+		vifs[i], err = getNetworkInterfaceFactory(networks, iface.Name)
 		if err != nil {
 			return err
 		}
-		podInterfaceName = getPodInterfaceName(networks, cniNetworks, iface.Name)
-		err = NetworkInterface.PlugPhase1(networkInterfaceFactory, vmi, &iface, networks[iface.Name], podInterfaceName, pid)
-		if err != nil {
-			return err
+	}
+
+	errs := make([]error, len(ifaces))
+	sem := make(chan struct{}, maxParallelPhase1Plugs)
+	var wg sync.WaitGroup
+	for i := range ifaces {
+		iface := ifaces[i]
+		podInterfaceName := getPodInterfaceName(networks, cniNetworks, iface.Name)
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = doNetNS(func() error {
+				return vifs[i].PlugPhase1(vmi, &iface, networks[iface.Name], podInterfaceName, pid)
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	return aggregatePhase1Errors(ifaces, errs)
+}
+
+// aggregatePhase1Errors merges the errors of all interfaces into a single
+// one. The result is a CriticalNetworkError if any of the interfaces failed
+// critically.
+func aggregatePhase1Errors(ifaces []v1.Interface, errs []error) error {
+	var failed []error
+	var lastErr error
+	critical := false
+	for i, err := range errs {
+		if err == nil {
+			continue
 		}
+		if _, ok := err.(*CriticalNetworkError); ok {
+			critical = true
+		}
+		failed = append(failed, fmt.Errorf("failed to plug interface %s: %v", ifaces[i].Name, err))
+		lastErr = err
 	}
-	return nil
+
+	// Keep a single error as-is, so that callers can inspect it
+	if len(failed) <= 1 {
+		return lastErr
+	}
+
+	aggregate := utilerrors.NewAggregate(failed)
+	if critical {
+		return &CriticalNetworkError{Msg: aggregate.Error()}
+	}
+	return aggregate
+}
+
+// HasPhase1Cache tells whether phase1 ran for the VMI on this node
+func HasPhase1Cache(uid types.UID) bool {
+	_, err := os.Stat(fmt.Sprintf(util.VMIInterfaceDir, uid))
+	return err == nil
+}
+
+// TeardownNetworkInterfacesPhase1 undoes the changes of phase1 to the network
+// namespace of the pod which aren't tied to the interfaces: the KubeVirt
+// nftables tables are flushed and the sysctls are restored. doNetNS has to
+// execute the passed function in the network namespace of the virt-launcher
+// pod.
+func TeardownNetworkInterfacesPhase1(vmi *v1.VirtualMachineInstance, doNetNS func(func() error) error) error {
+	initHandler()
+
+	err := doNetNS(func() error {
+		for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+			if !Handler.NftablesChainExists(proto, kubevirtNftTable, "postrouting") {
+				continue
+			}
+			if err := Handler.NftablesFlushTable(proto, kubevirtNftTable); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return RestorePodSysctls(vmi, doNetNS)
 }
 
 func SetupNetworkInterfacesPhase2(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
@@ -134,12 +264,68 @@ func SetupNetworkInterfacesPhase2(vmi *v1.VirtualMachineInstance, domain *api.Do
 		if err != nil {
 			return err
 		}
-		podInterfaceName = getPodInterfaceName(networks, cniNetworks, iface.Name)
+		podInterfaceName := getPodInterfaceName(networks, cniNetworks, iface.Name)
 		err = NetworkInterface.PlugPhase2(vif, vmi, &iface, networks[iface.Name], domain, podInterfaceName)
+		interfaceStates.setPlugResult(iface.Name, podInterfaceName, err)
 		if err != nil {
 			return err
 		}
 	}
+	publishInterfaceOEMStrings(vmi, domain)
+	return nil
+}
+
+// ReplugNetworkInterfacesPhase2 re-runs the second phase for the interfaces
+// which are not plugged yet, e.g. to restart their DHCP servers. The devices of
+// the running domain are not updated, so re-plugging is refused with a
+// NetworkSetupReasonDomainChanged error before anything is started if it
+// would change the definition of an interface. Slirp interfaces live in qemu
+// and are skipped.
+func ReplugNetworkInterfacesPhase2(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	replugged := vmi.DeepCopy()
+	replugged.Spec.Domain.Devices.Interfaces = nil
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Slirp == nil && !interfaceStates.isPlugged(iface.Name) {
+			replugged.Spec.Domain.Devices.Interfaces = append(replugged.Spec.Domain.Devices.Interfaces, iface)
+		}
+	}
+
+	networks, cniNetworks := getNetworksAndCniNetworks(replugged)
+	decorated := domain.DeepCopy()
+	for i := range replugged.Spec.Domain.Devices.Interfaces {
+		iface := &replugged.Spec.Domain.Devices.Interfaces[i]
+		if iface.SRIOV != nil {
+			continue
+		}
+		podInterfaceName := getPodInterfaceName(networks, cniNetworks, iface.Name)
+		_, err := decorateDomainInterface(replugged, iface, networks[iface.Name], decorated, podInterfaceName)
+		if err == nil && !sameDomainInterface(domainInterface(domain, iface.Name), domainInterface(decorated, iface.Name)) {
+			err = &NetworkSetupError{NetworkSetupReasonDomainChanged, fmt.Sprintf("re-plugging interface %s would change its device in the running domain", iface.Name)}
+		}
+		if err != nil {
+			interfaceStates.setPlugResult(iface.Name, podInterfaceName, err)
+			return err
+		}
+	}
+
+	return SetupPodNetworkPhase2(replugged, domain.DeepCopy())
+}
+
+func sameDomainInterface(running, decorated *api.Interface) bool {
+	if running == nil || decorated == nil {
+		return running == decorated
+	}
+	return reflect.DeepEqual(running.MAC, decorated.MAC) &&
+		reflect.DeepEqual(running.MTU, decorated.MTU) &&
+		reflect.DeepEqual(running.Target, decorated.Target)
+}
+
+func domainInterface(domain *api.Domain, name string) *api.Interface {
+	for i, iface := range domain.Spec.Devices.Interfaces {
+		if iface.Alias != nil && iface.Alias.Name == name {
+			return &domain.Spec.Devices.Interfaces[i]
+		}
+	}
 	return nil
 }
 
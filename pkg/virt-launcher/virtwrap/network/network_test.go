@@ -20,15 +20,24 @@
 package network
 
 import (
+	"fmt"
 	"os"
+	"sync"
 
+	"github.com/coreos/go-iptables/iptables"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/sysctl"
 )
 
+func runInCurrentNetNS(f func() error) error {
+	return f()
+}
+
 var _ = Describe("Network", func() {
 	var mockNetworkInterface *MockNetworkInterface
 	var ctrl *gomock.Controller
@@ -53,12 +62,12 @@ var _ = Describe("Network", func() {
 			defaultNet := v1.DefaultPodNetwork()
 
 			mockNetworkInterface.EXPECT().PlugPhase1(vm, iface, defaultNet, podInterface, pid)
-			err := SetupNetworkInterfacesPhase1(vm, pid)
+			err := SetupNetworkInterfacesPhase1(vm, pid, runInCurrentNetNS)
 			Expect(err).To(BeNil())
 		})
 		It("should accept empty network list", func() {
 			vmi := newVMI("testnamespace", "testVmName")
-			err := SetupNetworkInterfacesPhase1(vmi, pid)
+			err := SetupNetworkInterfacesPhase1(vmi, pid, runInCurrentNetNS)
 			Expect(err).To(BeNil())
 		})
 		It("should configure networking with multus", func() {
@@ -77,7 +86,7 @@ var _ = Describe("Network", func() {
 			vm.Spec.Networks = []v1.Network{*cniNet}
 
 			mockNetworkInterface.EXPECT().PlugPhase1(vm, iface, cniNet, multusInterfaceName, pid)
-			err := SetupNetworkInterfacesPhase1(vm, pid)
+			err := SetupNetworkInterfacesPhase1(vm, pid, runInCurrentNetNS)
 			Expect(err).To(BeNil())
 		})
 		It("should configure networking with multus and a default multus network", func() {
@@ -134,8 +143,105 @@ var _ = Describe("Network", func() {
 			mockNetworkInterface.EXPECT().PlugPhase1(vm, &vm.Spec.Domain.Devices.Interfaces[0], additionalCNINet1, "net1", pid)
 			mockNetworkInterface.EXPECT().PlugPhase1(vm, &vm.Spec.Domain.Devices.Interfaces[1], cniNet, "eth0", pid)
 			mockNetworkInterface.EXPECT().PlugPhase1(vm, &vm.Spec.Domain.Devices.Interfaces[2], additionalCNINet2, "net2", pid)
-			err := SetupNetworkInterfacesPhase1(vm, pid)
+			err := SetupNetworkInterfacesPhase1(vm, pid, runInCurrentNetNS)
 			Expect(err).To(BeNil())
 		})
+		Context("with multiple interfaces", func() {
+			var vm *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				NetworkInterfaceFactory = func(network *v1.Network) (NetworkInterface, error) {
+					return mockNetworkInterface, nil
+				}
+				vm = newVMIBridgeInterface("testnamespace", "testVmName")
+				vm.Spec.Domain.Devices.Interfaces = nil
+				vm.Spec.Networks = nil
+				for i := 0; i < maxParallelPhase1Plugs+2; i++ {
+					name := fmt.Sprintf("additional%d", i)
+					vm.Spec.Domain.Devices.Interfaces = append(vm.Spec.Domain.Devices.Interfaces, v1.Interface{
+						Name:                   name,
+						InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+					})
+					vm.Spec.Networks = append(vm.Spec.Networks, v1.Network{
+						Name:          name,
+						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: name}},
+					})
+				}
+			})
+
+			It("should plug every interface in the pod network namespace", func() {
+				mockNetworkInterface.EXPECT().PlugPhase1(vm, gomock.Any(), gomock.Any(), gomock.Any(), pid).Return(nil).Times(len(vm.Spec.Domain.Devices.Interfaces))
+
+				var lock sync.Mutex
+				nsCalls := 0
+				doNetNS := func(f func() error) error {
+					lock.Lock()
+					nsCalls++
+					lock.Unlock()
+					return f()
+				}
+				err := SetupNetworkInterfacesPhase1(vm, pid, doNetNS)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nsCalls).To(Equal(len(vm.Spec.Domain.Devices.Interfaces)))
+			})
+
+			It("should aggregate errors and keep plugging the remaining interfaces", func() {
+				mockNetworkInterface.EXPECT().PlugPhase1(vm, gomock.Any(), gomock.Any(), "net1", pid).Return(fmt.Errorf("net1 failed"))
+				mockNetworkInterface.EXPECT().PlugPhase1(vm, gomock.Any(), gomock.Any(), "net2", pid).Return(&CriticalNetworkError{Msg: "net2 failed"})
+				mockNetworkInterface.EXPECT().PlugPhase1(vm, gomock.Any(), gomock.Any(), gomock.Any(), pid).Return(nil).Times(len(vm.Spec.Domain.Devices.Interfaces) - 2)
+
+				err := SetupNetworkInterfacesPhase1(vm, pid, runInCurrentNetNS)
+				Expect(err).To(HaveOccurred())
+				_, critical := err.(*CriticalNetworkError)
+				Expect(critical).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("net1 failed"))
+				Expect(err.Error()).To(ContainSubstring("net2 failed"))
+			})
+		})
+	})
+
+	Context("interface datapath", func() {
+		It("should name the bridge after the pod interface", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+				Name:          "secondary",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "secondary"}},
+			})
+			secondary := v1.Interface{Name: "secondary", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}}
+
+			Expect(BindingName(&vmi.Spec.Domain.Devices.Interfaces[0])).To(Equal("bridge"))
+			Expect(BridgeDeviceName(vmi, &vmi.Spec.Domain.Devices.Interfaces[0])).To(Equal("k6t-eth0"))
+			Expect(BindingName(&secondary)).To(Equal("masquerade"))
+			Expect(BridgeDeviceName(vmi, &secondary)).To(Equal("k6t-net1"))
+		})
+
+		It("should not report a bridge for bindings without one", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			iface := v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}}
+
+			Expect(BindingName(&iface)).To(Equal("sriov"))
+			Expect(BridgeDeviceName(vmi, &iface)).To(BeEmpty())
+		})
+	})
+
+	Context("teardown", func() {
+		It("should flush the KubeVirt nftables tables which exist and restore the sysctls", func() {
+			mockNetwork := NewMockNetworkHandler(ctrl)
+			Handler = mockNetwork
+			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+			vmi.UID = "test-teardown"
+			defer os.RemoveAll(fmt.Sprintf(util.VMIInterfaceDir, vmi.UID))
+			Expect(HasPhase1Cache(vmi.UID)).To(BeFalse())
+			Expect(writeSavedSysctls(vmi.UID, map[string]int{sysctl.NetIPv6Forwarding: 0})).To(Succeed())
+			Expect(HasPhase1Cache(vmi.UID)).To(BeTrue())
+
+			mockNetwork.EXPECT().NftablesChainExists(iptables.ProtocolIPv4, kubevirtNftTable, "postrouting").Return(true)
+			mockNetwork.EXPECT().NftablesFlushTable(iptables.ProtocolIPv4, kubevirtNftTable).Return(nil)
+			mockNetwork.EXPECT().NftablesChainExists(iptables.ProtocolIPv6, kubevirtNftTable, "postrouting").Return(false)
+			mockNetwork.EXPECT().SetSysctl(sysctl.NetIPv6Forwarding, 0).Return(nil)
+
+			Expect(TeardownNetworkInterfacesPhase1(vmi, runInCurrentNetNS)).To(Succeed())
+			ctrl.Finish()
+		})
 	})
 })
@@ -22,17 +22,20 @@
 package network
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
-	"os"
+	"reflect"
 	"strconv"
 	"strings"
+	"syscall"
 
+	k8sv1 "k8s.io/api/core/v1"
 	netutils "k8s.io/utils/net"
 
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/qos"
+	"kubevirt.io/kubevirt/pkg/util/sysctl"
 
 	"github.com/coreos/go-iptables/iptables"
 	"github.com/vishvananda/netlink"
@@ -41,6 +44,7 @@ import (
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	metadataservice "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/metadata-service"
 )
 
 var bridgeFakeIP = "169.254.75.1%d/32"
@@ -94,13 +98,17 @@ func setPodInterfaceCache(iface *v1.Interface, podInterfaceName string, uid stri
 
 	switch {
 	case ipv4 != "" && ipv6 != "":
-		cache.PodIPs, err = sortIPsBasedOnPrimaryIP(ipv4, ipv6)
+		// the primary family is only determined once per plug, the cache keeps the outcome
+		cache.PrimaryIPFamily, err = primaryIPFamily()
 		if err != nil {
 			return err
 		}
+		cache.PodIPs = sortIPsBasedOnPrimaryIP(ipv4, ipv6, cache.PrimaryIPFamily)
 	case ipv4 != "":
+		cache.PrimaryIPFamily = k8sv1.IPv4Protocol
 		cache.PodIPs = []string{ipv4}
 	case ipv6 != "":
+		cache.PrimaryIPFamily = k8sv1.IPv6Protocol
 		cache.PodIPs = []string{ipv6}
 	default:
 		return nil
@@ -116,6 +124,47 @@ func setPodInterfaceCache(iface *v1.Interface, podInterfaceName string, uid stri
 	return nil
 }
 
+// RefreshPodInterfaceCache re-evaluates the primary IP family of the node for a dual-stack pod
+// interface read back from the cache, and reorders and rewrites its IPs if the family changed
+// since the interface was plugged.
+func RefreshPodInterfaceCache(cache *PodCacheInterface, uid string, ifaceName string) error {
+	if len(cache.PodIPs) != 2 {
+		return nil
+	}
+	initHandler()
+
+	family, err := primaryIPFamily()
+	if err != nil {
+		return err
+	}
+	if family == cache.PrimaryIPFamily {
+		return nil
+	}
+
+	ipv4, ipv6 := cache.PodIPs[0], cache.PodIPs[1]
+	if netutils.IsIPv6String(ipv4) {
+		ipv4, ipv6 = ipv6, ipv4
+	}
+	log.Log.Infof("primary IP family of interface %s changed from %q to %q", ifaceName, cache.PrimaryIPFamily, family)
+	cache.PrimaryIPFamily = family
+	cache.PodIPs = sortIPsBasedOnPrimaryIP(ipv4, ipv6, family)
+	cache.PodIP = cache.PodIPs[0]
+	return writeToCachedFile(cache, util.VMIInterfacepath, uid, ifaceName)
+}
+
+// primaryIPFamily returns the primary IP family of the node, which may differ between the nodes
+// of a dual-stack cluster.
+func primaryIPFamily() (k8sv1.IPFamily, error) {
+	ipv4Primary, err := Handler.IsIpv4Primary()
+	if err != nil {
+		return "", err
+	}
+	if ipv4Primary {
+		return k8sv1.IPv4Protocol, nil
+	}
+	return k8sv1.IPv6Protocol, nil
+}
+
 func readIPAddressesFromLink(podInterfaceName string) (string, string, error) {
 	link, err := Handler.LinkByName(podInterfaceName)
 	if err != nil {
@@ -149,19 +198,13 @@ func readIPAddressesFromLink(podInterfaceName string) (string, string, error) {
 	return ipv4, ipv6, nil
 }
 
-// sortIPsBasedOnPrimaryIP returns a sorted slice of IP/s based on the detected cluster primary IP.
+// sortIPsBasedOnPrimaryIP returns a sorted slice of IP/s based on the detected primary IP family.
 // The operation clones the Pod status IP list order logic.
-func sortIPsBasedOnPrimaryIP(ipv4, ipv6 string) ([]string, error) {
-	ipv4Primary, err := Handler.IsIpv4Primary()
-	if err != nil {
-		return nil, err
+func sortIPsBasedOnPrimaryIP(ipv4, ipv6 string, family k8sv1.IPFamily) []string {
+	if family == k8sv1.IPv6Protocol {
+		return []string{ipv6, ipv4}
 	}
-
-	if ipv4Primary {
-		return []string{ipv4, ipv6}, nil
-	}
-
-	return []string{ipv6, ipv4}, nil
+	return []string{ipv4, ipv6}
 }
 
 func (l *PodInterface) PlugPhase1(vmi *v1.VirtualMachineInstance, iface *v1.Interface, network *v1.Network, podInterfaceName string, pid int) error {
@@ -185,7 +228,7 @@ func (l *PodInterface) PlugPhase1(vmi *v1.VirtualMachineInstance, iface *v1.Inte
 
 	// ignore the driver.loadCachedInterface for slirp and set the Pod interface cache
 	if !isExist || iface.Slirp != nil {
-		err := setPodInterfaceCache(iface, podInterfaceName, string(vmi.ObjectMeta.UID))
+		err := setPodInterfaceCache(bindingInterface(driver, iface), podInterfaceName, string(vmi.ObjectMeta.UID))
 		if err != nil {
 			return err
 		}
@@ -195,17 +238,20 @@ func (l *PodInterface) PlugPhase1(vmi *v1.VirtualMachineInstance, iface *v1.Inte
 		if err != nil {
 			return err
 		}
+		verifyMigrationNetworkState(vmi, iface, driver)
 
-		queueNumber := uint32(0)
-		isMultiqueue := (vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue != nil) && (*vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue)
-		if isMultiqueue {
-			queueNumber = api.CalculateNetworkQueues(vmi)
-		}
+		// the tap device has to be created with the queues qemu opens it with
+		queueNumber := api.CalculateInterfaceQueues(vmi, iface)
 		if err := driver.preparePodNetworkInterfaces(queueNumber, pid); err != nil {
 			log.Log.Reason(err).Error("failed to prepare pod networking")
 			return createCriticalNetworkError(err)
 		}
 
+		if err := configureSysctls(vmi.UID, bindingSysctls(iface, fmt.Sprintf("k6t-%s", podInterfaceName))); err != nil {
+			log.Log.Reason(err).Error("failed to configure the sysctls of the binding")
+			return createCriticalNetworkError(err)
+		}
+
 		err = driver.setCachedInterface(pidStr, iface.Name)
 		if err != nil {
 			log.Log.Reason(err).Error("failed to save interface configuration")
@@ -226,19 +272,14 @@ func createCriticalNetworkError(err error) *CriticalNetworkError {
 	return &CriticalNetworkError{fmt.Sprintf("Critical network error: %v", err)}
 }
 
-func ensureDHCP(vmi *v1.VirtualMachineInstance, driver BindMechanism, podInterfaceName string) error {
-	dhcpStartedFile := fmt.Sprintf("/var/run/kubevirt-private/dhcp_started-%s", podInterfaceName)
-	_, err := os.Stat(dhcpStartedFile)
-	if os.IsNotExist(err) {
-		if err := driver.startDHCP(vmi); err != nil {
-			return fmt.Errorf("failed to start DHCP server for interface %s", podInterfaceName)
-		}
-		newFile, err := os.Create(dhcpStartedFile)
-		if err != nil {
-			return fmt.Errorf("failed to create dhcp started file %s: %s", dhcpStartedFile, err)
-		}
-		newFile.Close()
+func ensureDHCP(vmi *v1.VirtualMachineInstance, driver BindMechanism, ifaceName string, podInterfaceName string) error {
+	if interfaceStates.isDHCPStarted(ifaceName) {
+		return nil
+	}
+	if err := driver.startDHCP(vmi); err != nil {
+		return fmt.Errorf("failed to start DHCP server for interface %s: %v", podInterfaceName, err)
 	}
+	interfaceStates.setDHCPStarted(ifaceName, podInterfaceName)
 	return nil
 }
 
@@ -251,41 +292,58 @@ func (l *PodInterface) PlugPhase2(vmi *v1.VirtualMachineInstance, iface *v1.Inte
 		return nil
 	}
 
-	driver, err := getPhase2Binding(vmi, iface, network, domain, podInterfaceName)
+	driver, err := decorateDomainInterface(vmi, iface, network, domain, podInterfaceName)
 	if err != nil {
 		return err
 	}
 
+	err = ensureDHCP(vmi, driver, iface.Name, podInterfaceName)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to ensure dhcp service running for %s", podInterfaceName)
+		return &NetworkSetupError{NetworkSetupReasonDHCP, err.Error()}
+	}
+
+	return nil
+}
+
+// decorateDomainInterface configures the domain interface from the cached
+// configuration of the first phase.
+func decorateDomainInterface(vmi *v1.VirtualMachineInstance, iface *v1.Interface, network *v1.Network, domain *api.Domain, podInterfaceName string) (BindMechanism, error) {
+	driver, err := getPhase2Binding(vmi, iface, network, domain, podInterfaceName)
+	if err != nil {
+		return nil, err
+	}
+
 	pid := "self"
 
+	// Errors below are returned to the caller rather than crashing
+	// virt-launcher. virt-handler reports them in the VMI Synchronized
+	// condition and retries the sync with a rate-limited backoff.
 	isExist, err := driver.loadCachedInterface(pid, iface.Name)
 	if err != nil {
-		log.Log.Reason(err).Critical("failed to load cached interface configuration")
+		log.Log.Reason(err).Error("failed to load cached interface configuration")
+		return nil, &NetworkSetupError{NetworkSetupReasonCacheLoadFailed, fmt.Sprintf("failed to load cached interface configuration for %s: %v", iface.Name, err)}
 	}
 	if !isExist {
-		log.Log.Reason(err).Critical("cached interface configuration doesn't exist")
+		return nil, &NetworkSetupError{NetworkSetupReasonCacheMissing, fmt.Sprintf("cached interface configuration for %s doesn't exist", iface.Name)}
 	}
 
 	isExist, err = driver.loadCachedVIF(pid, iface.Name)
 	if err != nil {
-		log.Log.Reason(err).Critical("failed to load cached vif configuration")
+		log.Log.Reason(err).Error("failed to load cached vif configuration")
+		return nil, &NetworkSetupError{NetworkSetupReasonCacheLoadFailed, fmt.Sprintf("failed to load cached vif configuration for %s: %v", iface.Name, err)}
 	}
 	if !isExist {
-		log.Log.Reason(err).Critical("cached vif configuration doesn't exist")
+		return nil, &NetworkSetupError{NetworkSetupReasonCacheMissing, fmt.Sprintf("cached vif configuration for %s doesn't exist", iface.Name)}
 	}
 
 	err = driver.decorateConfig()
 	if err != nil {
-		log.Log.Reason(err).Critical("failed to create libvirt configuration")
-	}
-
-	err = ensureDHCP(vmi, driver, podInterfaceName)
-	if err != nil {
-		log.Log.Reason(err).Criticalf("failed to ensure dhcp service running for %s: %s", podInterfaceName, err)
-		panic(err)
+		log.Log.Reason(err).Error("failed to create libvirt configuration")
+		return nil, &NetworkSetupError{NetworkSetupReasonDomainConfig, fmt.Sprintf("failed to create libvirt configuration for %s: %v", iface.Name, err)}
 	}
 
-	return nil
+	return driver, nil
 }
 
 // The only difference between bindings for two phases is that the first phase
@@ -293,7 +351,14 @@ func (l *PodInterface) PlugPhase2(vmi *v1.VirtualMachineInstance, iface *v1.Inte
 // it. This means that any functions called under phase1 code path should not
 // use the domain set on the binding.
 func getPhase1Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, network *v1.Network, podInterfaceName string) (BindMechanism, error) {
-	return getPhase2Binding(vmi, iface, network, nil, podInterfaceName)
+	driver, err := getPhase2Binding(vmi, iface, network, nil, podInterfaceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyMigrationNetworkState(vmi, iface, driver); err != nil {
+		return nil, err
+	}
+	return driver, nil
 }
 
 func getPhase2Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, network *v1.Network, domain *api.Domain, podInterfaceName string) (BindMechanism, error) {
@@ -322,14 +387,19 @@ func getPhase2Binding(vmi *v1.VirtualMachineInstance, iface *v1.Interface, netwo
 	if iface.Masquerade != nil {
 		vif := &VIF{Name: podInterfaceName}
 		populateMacAddress(vif, iface)
+		vmNetworkCIDR := network.Pod.VMNetworkCIDR
+		if vmNetworkCIDR == "" {
+			// allocated by virt-controller from the masquerade subnet pool of the cluster
+			vmNetworkCIDR = vmi.Status.VMNetworkCIDR
+		}
 		return &MasqueradePodInterface{iface: iface,
 			virtIface:           &api.Interface{},
 			vmi:                 vmi,
 			vif:                 vif,
 			domain:              domain,
 			podInterfaceName:    podInterfaceName,
-			vmNetworkCIDR:       network.Pod.VMNetworkCIDR,
-			vmIpv6NetworkCIDR:   "", // TODO add ipv6 cidr to PodNetwork schema
+			vmNetworkCIDR:       vmNetworkCIDR,
+			vmIpv6NetworkCIDR:   network.Pod.VMIPv6NetworkCIDR,
 			bridgeInterfaceName: fmt.Sprintf("k6t-%s", podInterfaceName)}, nil
 	}
 	if iface.Slirp != nil {
@@ -377,6 +447,9 @@ func (b *BridgePodInterface) discoverPodNetworkInterface() error {
 	}
 	if len(addrList) == 0 {
 		b.vif.IPAMDisabled = true
+		if err := b.setGuestAddress(); err != nil {
+			return err
+		}
 	} else {
 		b.vif.IP = addrList[0]
 		b.vif.IPAMDisabled = false
@@ -418,8 +491,79 @@ func (b *BridgePodInterface) getFakeBridgeIP() (string, error) {
 	return "", fmt.Errorf("Failed to generate bridge fake address for interface %s", b.iface.Name)
 }
 
-func (b *BridgePodInterface) startDHCP(vmi *v1.VirtualMachineInstance) error {
+// setGuestAddress stores the address declared for the guest in the VIF,
+// since the pod network does not provide one
+func (b *BridgePodInterface) setGuestAddress() error {
+	guestAddress := b.iface.Bridge.GuestAddress
+	if guestAddress == nil {
+		return nil
+	}
+
+	addr, err := netlink.ParseAddr(guestAddress.IP)
+	if err != nil {
+		return fmt.Errorf("failed to parse the guest address %s: %v", guestAddress.IP, err)
+	}
+	b.vif.IP = *addr
+	if guestAddress.Gateway != "" {
+		b.vif.Gateway = net.ParseIP(guestAddress.Gateway).To4()
+	}
+	return nil
+}
+
+// seedGuestAddress adds static forwarding and neighbor entries for the guest,
+// so that the bridge neither floods frames to it nor has to resolve its address
+func (b *BridgePodInterface) seedGuestAddress() error {
+	tap, err := Handler.LinkByName(b.vif.TapDevice)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to get a link for interface: %s", b.vif.TapDevice)
+		return err
+	}
+	bridge, err := Handler.LinkByName(b.bridgeInterfaceName)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to get a link for interface: %s", b.bridgeInterfaceName)
+		return err
+	}
+
+	fdbEntry := &netlink.Neigh{
+		LinkIndex:    tap.Attrs().Index,
+		Family:       syscall.AF_BRIDGE,
+		Flags:        netlink.NTF_MASTER,
+		State:        netlink.NUD_NOARP,
+		HardwareAddr: b.vif.MAC,
+	}
+	if err := Handler.NeighSet(fdbEntry); err != nil {
+		log.Log.Reason(err).Errorf("failed to add the forwarding entry for %s to bridge %s", b.vif.MAC, b.bridgeInterfaceName)
+		return err
+	}
+
+	family := netlink.FAMILY_V4
+	if b.vif.IP.IP.To4() == nil {
+		family = netlink.FAMILY_V6
+	}
+	neighEntry := &netlink.Neigh{
+		LinkIndex:    bridge.Attrs().Index,
+		Family:       family,
+		State:        netlink.NUD_PERMANENT,
+		IP:           b.vif.IP.IP,
+		HardwareAddr: b.vif.MAC,
+	}
+	if err := Handler.NeighSet(neighEntry); err != nil {
+		log.Log.Reason(err).Errorf("failed to add the neighbor entry for %s to bridge %s", b.vif.IP.IP, b.bridgeInterfaceName)
+		return err
+	}
+	return nil
+}
+
+func (b *BridgePodInterface) servesDHCP() bool {
 	if !b.vif.IPAMDisabled {
+		return true
+	}
+	guestAddress := b.iface.Bridge.GuestAddress
+	return guestAddress != nil && guestAddress.ServeDHCP
+}
+
+func (b *BridgePodInterface) startDHCP(vmi *v1.VirtualMachineInstance) error {
+	if b.servesDHCP() {
 		addr, err := b.getFakeBridgeIP()
 		if err != nil {
 			return err
@@ -454,13 +598,29 @@ func (b *BridgePodInterface) preparePodNetworkInterfaces(queueNumber uint32, lau
 		return err
 	}
 
-	tapDeviceName := generateTapDeviceName(podInterfaceName)
+	tapDeviceName := generateTapDeviceName(b.podInterfaceName)
 	err := createAndBindTapToBridge(b.vif, tapDeviceName, b.bridgeInterfaceName, queueNumber, launcherPID, int(b.vif.Mtu))
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to create tap device named %s", tapDeviceName)
 		return err
 	}
 
+	if err := configureTapQoS(b.vmi, tapDeviceName); err != nil {
+		return err
+	}
+
+	if err := configureTapOffloads(b.iface, tapDeviceName); err != nil {
+		return err
+	}
+
+	if err := configurePodInterfaceLatency(b.iface, b.podInterfaceName); err != nil {
+		return err
+	}
+
+	if err := configureTapMirror(b.vmi, b.iface, tapDeviceName); err != nil {
+		return err
+	}
+
 	if !b.vif.IPAMDisabled {
 		// Remove IP from POD interface
 		err := Handler.AddrDel(b.podNicLink, &b.vif.IP)
@@ -476,6 +636,12 @@ func (b *BridgePodInterface) preparePodNetworkInterfaces(queueNumber uint32, lau
 		return err
 	}
 
+	if b.vif.IPAMDisabled && b.iface.Bridge.GuestAddress != nil {
+		if err := b.seedGuestAddress(); err != nil {
+			return err
+		}
+	}
+
 	b.virtIface.MTU = &api.MTU{Size: strconv.Itoa(b.podNicLink.Attrs().MTU)}
 	b.virtIface.MAC = &api.MAC{MAC: b.vif.MAC.String()}
 	b.virtIface.Target = &api.InterfaceTarget{
@@ -525,7 +691,7 @@ func (b *BridgePodInterface) loadCachedVIF(pid, name string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	err = json.Unmarshal(buf, &b.vif)
+	_, err = unmarshalCacheFile(buf, &b.vif)
 	if err != nil {
 		return false, err
 	}
@@ -534,7 +700,7 @@ func (b *BridgePodInterface) loadCachedVIF(pid, name string) (bool, error) {
 }
 
 func (b *BridgePodInterface) setCachedVIF(pid, name string) error {
-	buf, err := json.MarshalIndent(&b.vif, "", "  ")
+	buf, err := marshalCacheFile(b.vif)
 	if err != nil {
 		return fmt.Errorf("error marshaling vif object: %v", err)
 	}
@@ -560,12 +726,7 @@ func (b *BridgePodInterface) setInterfaceRoutes() error {
 
 func (b *BridgePodInterface) createBridge() error {
 	// Create a bridge
-	bridge := &netlink.Bridge{
-		LinkAttrs: netlink.LinkAttrs{
-			Name: b.bridgeInterfaceName,
-		},
-	}
-	err := Handler.LinkAdd(bridge)
+	bridge, err := addBridge(netlink.LinkAttrs{Name: b.bridgeInterfaceName})
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to create a bridge")
 		return err
@@ -599,8 +760,7 @@ func (b *BridgePodInterface) createBridge() error {
 		return err
 	}
 
-	if err = Handler.DisableTXOffloadChecksum(b.bridgeInterfaceName); err != nil {
-		log.Log.Reason(err).Error("failed to disable TX offload checksum on bridge interface")
+	if err = configureBridgeTXChecksumOffload(b.vmi, b.iface, b.bridgeInterfaceName, b.servesDHCP()); err != nil {
 		return err
 	}
 
@@ -709,7 +869,21 @@ func configureVifV6Addresses(p *MasqueradePodInterface, err error) error {
 }
 
 func (p *MasqueradePodInterface) startDHCP(vmi *v1.VirtualMachineInstance) error {
-	return Handler.StartDHCP(p.vif, p.vif.Gateway, p.bridgeInterfaceName, p.iface.DHCPOptions)
+	dhcpOptions := p.iface.DHCPOptions
+	if dhcpOptions != nil && dhcpOptions.TFTPProxy {
+		upstream := dhcpOptions.NextServer
+		if upstream == "" {
+			upstream = dhcpOptions.TFTPServerName
+		}
+		if err := Handler.StartTFTPProxy(p.vif.Gateway, upstream); err != nil {
+			return fmt.Errorf("failed to start the TFTP proxy: %v", err)
+		}
+		// the guest boots from the proxy on its gateway
+		dhcpOptions = dhcpOptions.DeepCopy()
+		dhcpOptions.NextServer = p.vif.Gateway.String()
+		dhcpOptions.TFTPServerName = p.vif.Gateway.String()
+	}
+	return Handler.StartDHCP(p.vif, p.vif.Gateway, p.bridgeInterfaceName, dhcpOptions)
 }
 
 func (p *MasqueradePodInterface) preparePodNetworkInterfaces(queueNumber uint32, launcherPID int) error {
@@ -727,7 +901,7 @@ func (p *MasqueradePodInterface) preparePodNetworkInterfaces(queueNumber uint32,
 		return err
 	}
 
-	if p.iface.MacAddress == "" {
+	if len(p.vif.MAC) == 0 {
 		p.vif.MAC, err = Handler.GenerateRandomMac()
 		if err != nil {
 			log.Log.Reason(err).Errorf("failed to generate random mac address")
@@ -745,14 +919,30 @@ func (p *MasqueradePodInterface) preparePodNetworkInterfaces(queueNumber uint32,
 		return err
 	}
 
-	tapDeviceName := generateTapDeviceName(podInterfaceName)
+	tapDeviceName := generateTapDeviceName(p.podInterfaceName)
 	err = createAndBindTapToBridge(p.vif, tapDeviceName, p.bridgeInterfaceName, queueNumber, launcherPID, int(p.vif.Mtu))
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to create tap device named %s", tapDeviceName)
 		return err
 	}
 
-	if Handler.HasNatIptables(iptables.ProtocolIPv4) || Handler.NftablesLoad("ipv4-nat") == nil {
+	if err := configureTapQoS(p.vmi, tapDeviceName); err != nil {
+		return err
+	}
+
+	if err := configureTapOffloads(p.iface, tapDeviceName); err != nil {
+		return err
+	}
+
+	if err := configurePodInterfaceLatency(p.iface, p.podInterfaceName); err != nil {
+		return err
+	}
+
+	if err := configureTapMirror(p.vmi, p.iface, tapDeviceName); err != nil {
+		return err
+	}
+
+	if Handler.HasNatIptables(iptables.ProtocolIPv4) || Handler.NftablesNewTable(iptables.ProtocolIPv4, kubevirtNftTable) == nil {
 		err = p.createNatRules(iptables.ProtocolIPv4)
 		if err != nil {
 			log.Log.Reason(err).Errorf("failed to create ipv4 nat rules for vm error: %v", err)
@@ -768,8 +958,10 @@ func (p *MasqueradePodInterface) preparePodNetworkInterfaces(queueNumber uint32,
 		return err
 	}
 	if ipv6Enabled {
-		if Handler.HasNatIptables(iptables.ProtocolIPv6) || Handler.NftablesLoad("ipv6-nat") == nil {
-			err = Handler.ConfigureIpv6Forwarding()
+		if Handler.HasNatIptables(iptables.ProtocolIPv6) || Handler.NftablesNewTable(iptables.ProtocolIPv6, kubevirtNftTable) == nil {
+			// the kernel only forwards IPv6 if it is enabled for the whole
+			// network namespace, it can't be enabled per interface
+			err = configureSysctls(p.vmi.UID, []sysctlSetting{{name: sysctl.NetIPv6Forwarding, value: 1}})
 			if err != nil {
 				log.Log.Reason(err).Errorf("failed to configure ipv6 forwarding")
 				return err
@@ -834,7 +1026,7 @@ func (p *MasqueradePodInterface) loadCachedVIF(pid, name string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	err = json.Unmarshal(buf, &p.vif)
+	_, err = unmarshalCacheFile(buf, &p.vif)
 	if err != nil {
 		return false, err
 	}
@@ -844,7 +1036,7 @@ func (p *MasqueradePodInterface) loadCachedVIF(pid, name string) (bool, error) {
 }
 
 func (p *MasqueradePodInterface) setCachedVIF(pid, name string) error {
-	buf, err := json.MarshalIndent(&p.vif, "", "  ")
+	buf, err := marshalCacheFile(p.vif)
 	if err != nil {
 		return fmt.Errorf("error marshaling vif object: %v", err)
 	}
@@ -861,13 +1053,10 @@ func (p *MasqueradePodInterface) createBridge() error {
 	}
 
 	// Create a bridge
-	bridge := &netlink.Bridge{
-		LinkAttrs: netlink.LinkAttrs{
-			Name: p.bridgeInterfaceName,
-			MTU:  int(p.vif.Mtu),
-		},
-	}
-	err = Handler.LinkAdd(bridge)
+	bridge, err := addBridge(netlink.LinkAttrs{
+		Name: p.bridgeInterfaceName,
+		MTU:  int(p.vif.Mtu),
+	})
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to create a bridge")
 		return err
@@ -890,6 +1079,19 @@ func (p *MasqueradePodInterface) createBridge() error {
 		return err
 	}
 
+	// the metadata service listens on the link-local address, which the guest
+	// reaches through its default gateway
+	if p.vmi.Spec.MetadataService != nil {
+		metadataServiceAddr, err := Handler.ParseAddr(metadataservice.Address + "/32")
+		if err != nil {
+			return err
+		}
+		if err := Handler.AddrAdd(bridge, metadataServiceAddr); err != nil {
+			log.Log.Reason(err).Errorf("failed to set the metadata service IP on the bridge")
+			return err
+		}
+	}
+
 	ipv6Enabled, err := Handler.IsIpv6Enabled(p.podInterfaceName)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to verify whether ipv6 is configured on %s", p.podInterfaceName)
@@ -902,8 +1104,7 @@ func (p *MasqueradePodInterface) createBridge() error {
 		}
 	}
 
-	if err = Handler.DisableTXOffloadChecksum(p.bridgeInterfaceName); err != nil {
-		log.Log.Reason(err).Error("failed to disable TX offload checksum on bridge interface")
+	if err = configureBridgeTXChecksumOffload(p.vmi, p.iface, p.bridgeInterfaceName, true); err != nil {
 		return err
 	}
 
@@ -912,9 +1113,43 @@ func (p *MasqueradePodInterface) createBridge() error {
 
 func (p *MasqueradePodInterface) createNatRules(protocol iptables.Protocol) error {
 	if Handler.HasNatIptables(protocol) {
-		return p.createNatRulesUsingIptables(protocol)
+		if err := p.createNatRulesUsingIptables(protocol); err != nil {
+			return err
+		}
+		return p.createMSSClampRuleUsingIptables(protocol)
+	}
+	if err := p.createNatRulesUsingNftables(protocol); err != nil {
+		return err
+	}
+	return p.createMSSClampRuleUsingNftables(protocol)
+}
+
+func (p *MasqueradePodInterface) clampMSS() bool {
+	return p.iface.Masquerade != nil && p.iface.Masquerade.ClampMSS != nil && *p.iface.Masquerade.ClampMSS
+}
+
+// createMSSClampRuleUsingIptables rewrites the MSS option of the forwarded TCP SYN packets
+// to fit the path MTU, in both directions
+func (p *MasqueradePodInterface) createMSSClampRuleUsingIptables(protocol iptables.Protocol) error {
+	if !p.clampMSS() {
+		return nil
 	}
-	return p.createNatRulesUsingNftables(protocol)
+	return Handler.IptablesAppendRule(protocol, "mangle", "FORWARD",
+		"-p", "tcp", "--tcp-flags", "SYN,RST", "SYN", "-j", "TCPMSS", "--clamp-mss-to-pmtu")
+}
+
+// createMSSClampRuleUsingNftables is the nftables counterpart of createMSSClampRuleUsingIptables,
+// the rule lives in a forward chain of the KubeVirt table
+func (p *MasqueradePodInterface) createMSSClampRuleUsingNftables(proto iptables.Protocol) error {
+	if !p.clampMSS() {
+		return nil
+	}
+	// the mangle priority, as iptables uses
+	if err := Handler.NftablesNewBaseChain(proto, kubevirtNftTable, "forward", "filter", "forward", -150); err != nil {
+		return err
+	}
+	return Handler.NftablesAppendRule(proto, kubevirtNftTable, "forward",
+		"tcp", "flags", "&", "(syn|rst)", "==", "syn", "counter", "tcp", "option", "maxseg", "size", "set", "rt", "mtu")
 }
 
 func (p *MasqueradePodInterface) createNatRulesUsingIptables(protocol iptables.Protocol) error {
@@ -928,75 +1163,134 @@ func (p *MasqueradePodInterface) createNatRulesUsingIptables(protocol iptables.P
 		return err
 	}
 
-	err = Handler.IptablesAppendRule(protocol, "nat", "POSTROUTING", "-s", p.getVifIpByProtocol(protocol), "-j", "MASQUERADE")
-	if err != nil {
-		return err
+	for _, rule := range p.iptablesNatRules(protocol) {
+		err = Handler.IptablesAppendRule(protocol, "nat", rule.chain, rule.spec...)
+		if err != nil {
+			return err
+		}
 	}
 
-	err = Handler.IptablesAppendRule(protocol, "nat", "PREROUTING", "-i", p.podInterfaceName, "-j", "KUBEVIRT_PREINBOUND")
-	if err != nil {
-		return err
+	return nil
+}
+
+// iptablesNatRules returns all rules of the nat table of the interface: the masquerading of the
+// traffic of the VM, the jumps to the KUBEVIRT chains and the port forwarding rules
+func (p *MasqueradePodInterface) iptablesNatRules(protocol iptables.Protocol) []natRule {
+	rules := []natRule{
+		{"POSTROUTING", []string{"-s", p.getVifIpByProtocol(protocol), "-j", "MASQUERADE"}},
+		{"PREROUTING", []string{"-i", p.podInterfaceName, "-j", "KUBEVIRT_PREINBOUND"}},
+		{"POSTROUTING", []string{"-o", p.bridgeInterfaceName, "-j", "KUBEVIRT_POSTINBOUND"}},
 	}
+	return append(rules, p.iptablesPortForwardRules(protocol)...)
+}
 
-	err = Handler.IptablesAppendRule(protocol, "nat", "POSTROUTING", "-o", p.bridgeInterfaceName, "-j", "KUBEVIRT_POSTINBOUND")
-	if err != nil {
-		return err
+// natRule is a rule of a chain of the nat table
+type natRule struct {
+	chain string
+	spec  []string
+}
+
+func (r natRule) equal(other natRule) bool {
+	return r.chain == other.chain && reflect.DeepEqual(r.spec, other.spec)
+}
+
+func containsNatRule(rules []natRule, rule natRule) bool {
+	for _, r := range rules {
+		if r.equal(rule) {
+			return true
+		}
 	}
+	return false
+}
+
+// iptablesPortForwardRules returns the rules forwarding the ports of the interface to the VM
+func (p *MasqueradePodInterface) iptablesPortForwardRules(protocol iptables.Protocol) []natRule {
+	hairpin := p.hairpinMode()
 
 	if len(p.iface.Ports) == 0 {
-		err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
+		rules := []natRule{{"KUBEVIRT_PREINBOUND", []string{
 			"-j",
 			"DNAT",
-			"--to-destination", p.getVifIpByProtocol(protocol))
+			"--to-destination", p.getVifIpByProtocol(protocol)}}}
+		if hairpin != v1.MasqueradeHairpinFull {
+			return rules
+		}
 
-		return err
+		// connections from the VM to its own pod or service IP come back from a local address,
+		// the VM must answer them through the gateway for the replies to be translated back
+		return append(rules, natRule{"KUBEVIRT_POSTINBOUND", append(p.iptablesHairpinMatch(protocol, true),
+			"-j",
+			"SNAT",
+			"--to-source", p.getGatewayByProtocol(protocol))})
 	}
 
+	var rules []natRule
 	for _, port := range p.iface.Ports {
+		// VMIs created before the mutating webhook defaulted the protocol don't have one
 		if port.Protocol == "" {
 			port.Protocol = "tcp"
 		}
-
-		err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_POSTINBOUND",
-			"-p",
-			strings.ToLower(port.Protocol),
-			"--dport",
-			strconv.Itoa(int(port.Port)),
-			"--source", getLoopbackAdrress(protocol),
-			"-j",
-			"SNAT",
-			"--to-source", p.getGatewayByProtocol(protocol))
-		if err != nil {
-			return err
+		match := []string{"-p", strings.ToLower(port.Protocol), "--dport", strconv.Itoa(int(port.Port))}
+
+		if hairpin != v1.MasqueradeHairpinNone {
+			// a packet from a local address, and on IPv6 in particular from ::1, must not reach the
+			// bridge with its original source, otherwise it is dropped and the VM cannot answer it
+			spec := append(append([]string{}, match...), p.iptablesHairpinMatch(protocol, true)...)
+			rules = append(rules, natRule{"KUBEVIRT_POSTINBOUND", append(spec,
+				"-j",
+				"SNAT",
+				"--to-source", p.getGatewayByProtocol(protocol))})
 		}
 
-		err = Handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
-			"-p",
-			strings.ToLower(port.Protocol),
-			"--dport",
-			strconv.Itoa(int(port.Port)),
+		rules = append(rules, natRule{"KUBEVIRT_PREINBOUND", append(append([]string{}, match...),
 			"-j",
 			"DNAT",
-			"--to-destination", p.getVifIpByProtocol(protocol))
-		if err != nil {
-			return err
+			"--to-destination", p.getVifIpByProtocol(protocol))})
+
+		if hairpin != v1.MasqueradeHairpinNone {
+			spec := append(append([]string{}, match...), p.iptablesHairpinMatch(protocol, false)...)
+			rules = append(rules, natRule{"OUTPUT", append(spec,
+				"-j",
+				"DNAT",
+				"--to-destination", p.getVifIpByProtocol(protocol))})
 		}
+	}
 
-		err = Handler.IptablesAppendRule(protocol, "nat", "OUTPUT",
-			"-p",
-			strings.ToLower(port.Protocol),
-			"--dport",
-			strconv.Itoa(int(port.Port)),
-			"--destination", getLoopbackAdrress(protocol),
-			"-j",
-			"DNAT",
-			"--to-destination", p.getVifIpByProtocol(protocol))
-		if err != nil {
-			return err
+	return rules
+}
+
+func (p *MasqueradePodInterface) hairpinMode() v1.MasqueradeHairpinMode {
+	if p.iface.Masquerade == nil || p.iface.Masquerade.Hairpin == "" {
+		return v1.MasqueradeHairpinLoopback
+	}
+	return p.iface.Masquerade.Hairpin
+}
+
+// iptablesHairpinMatch matches the source or the destination of connections originating in
+// the pod itself: the loopback address, or any local address of the pod in full hairpin mode
+func (p *MasqueradePodInterface) iptablesHairpinMatch(proto iptables.Protocol, source bool) []string {
+	if p.hairpinMode() == v1.MasqueradeHairpinFull {
+		if source {
+			return []string{"-m", "addrtype", "--src-type", "LOCAL"}
 		}
+		return []string{"-m", "addrtype", "--dst-type", "LOCAL"}
 	}
+	if source {
+		return []string{"--source", getLoopbackAdrress(proto)}
+	}
+	return []string{"--destination", getLoopbackAdrress(proto)}
+}
 
-	return nil
+// nftablesHairpinMatch is the nftables counterpart of iptablesHairpinMatch
+func (p *MasqueradePodInterface) nftablesHairpinMatch(proto iptables.Protocol, source bool) []string {
+	addr := "daddr"
+	if source {
+		addr = "saddr"
+	}
+	if p.hairpinMode() == v1.MasqueradeHairpinFull {
+		return []string{"fib", addr, "type", "local"}
+	}
+	return []string{Handler.GetNFTIPString(proto), addr, getLoopbackAdrress(proto)}
 }
 
 func (p *MasqueradePodInterface) getGatewayByProtocol(proto iptables.Protocol) string {
@@ -1023,74 +1317,155 @@ func getLoopbackAdrress(proto iptables.Protocol) string {
 	}
 }
 
+// kubevirtNftTable holds all nftables rules of the masquerade binding. Unlike
+// the nat table, which other agents in the pod manage as well, it is only
+// touched by KubeVirt, and flushing it removes the rules of the binding and
+// nothing else.
+const kubevirtNftTable = "kubevirt"
+
+// nftablesNatHooks are the nat base chains of kubevirtNftTable, named after
+// their hooks, with the priorities of the nat table of iptables
+var nftablesNatHooks = []struct {
+	hook     string
+	priority int
+}{
+	{"prerouting", -100},
+	{"output", -100},
+	{"postrouting", 100},
+}
+
+// createNatRulesUsingNftables builds the rules of the interface in
+// kubevirtNftTable, which has to exist. Rules left behind in the table by a
+// previous attempt are flushed first.
 func (p *MasqueradePodInterface) createNatRulesUsingNftables(proto iptables.Protocol) error {
-	err := Handler.NftablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND")
+	err := Handler.NftablesFlushTable(proto, kubevirtNftTable)
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND")
+	for _, chain := range nftablesNatHooks {
+		err = Handler.NftablesNewBaseChain(proto, kubevirtNftTable, chain.hook, "nat", chain.hook, chain.priority)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = Handler.NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesAppendRule(proto, "nat", "postrouting", Handler.GetNFTIPString(proto), "saddr", p.getVifIpByProtocol(proto), "counter", "masquerade")
+	err = Handler.NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesAppendRule(proto, "nat", "prerouting", "iifname", p.podInterfaceName, "counter", "jump", "KUBEVIRT_PREINBOUND")
+	err = Handler.NftablesAppendRule(proto, kubevirtNftTable, "postrouting", Handler.GetNFTIPString(proto), "saddr", p.getVifIpByProtocol(proto), "counter", "masquerade")
 	if err != nil {
 		return err
 	}
 
-	err = Handler.NftablesAppendRule(proto, "nat", "postrouting", "oifname", p.bridgeInterfaceName, "counter", "jump", "KUBEVIRT_POSTINBOUND")
+	err = Handler.NftablesAppendRule(proto, kubevirtNftTable, "prerouting", "iifname", p.podInterfaceName, "counter", "jump", "KUBEVIRT_PREINBOUND")
 	if err != nil {
 		return err
 	}
 
+	err = Handler.NftablesAppendRule(proto, kubevirtNftTable, "postrouting", "oifname", p.bridgeInterfaceName, "counter", "jump", "KUBEVIRT_POSTINBOUND")
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range p.nftablesPortForwardRules(proto) {
+		err = Handler.NftablesAppendRule(proto, kubevirtNftTable, rule.chain, rule.spec...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nftablesPortForwardRules is the nftables counterpart of iptablesPortForwardRules
+func (p *MasqueradePodInterface) nftablesPortForwardRules(proto iptables.Protocol) []natRule {
+	hairpin := p.hairpinMode()
+
 	if len(p.iface.Ports) == 0 {
-		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
-			"counter", "dnat", "to", p.getVifIpByProtocol(proto))
+		rules := []natRule{{"KUBEVIRT_PREINBOUND", []string{
+			"counter", "dnat", "to", p.getVifIpByProtocol(proto)}}}
+		if hairpin != v1.MasqueradeHairpinFull {
+			return rules
+		}
 
-		return err
+		return append(rules, natRule{"KUBEVIRT_POSTINBOUND", append(p.nftablesHairpinMatch(proto, true),
+			"counter", "snat", "to", p.getGatewayByProtocol(proto))})
 	}
 
+	var rules []natRule
 	for _, port := range p.iface.Ports {
+		// VMIs created before the mutating webhook defaulted the protocol don't have one
 		if port.Protocol == "" {
 			port.Protocol = "tcp"
 		}
+		match := []string{strings.ToLower(port.Protocol), "dport", strconv.Itoa(int(port.Port))}
 
-		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
-			strings.ToLower(port.Protocol),
-			"dport",
-			strconv.Itoa(int(port.Port)),
-			Handler.GetNFTIPString(proto), "saddr", getLoopbackAdrress(proto),
-			"counter", "snat", "to", p.getGatewayByProtocol(proto))
-		if err != nil {
-			return err
+		if hairpin != v1.MasqueradeHairpinNone {
+			spec := append(append([]string{}, match...), p.nftablesHairpinMatch(proto, true)...)
+			rules = append(rules, natRule{"KUBEVIRT_POSTINBOUND", append(spec,
+				"counter", "snat", "to", p.getGatewayByProtocol(proto))})
 		}
 
-		err = Handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
-			strings.ToLower(port.Protocol),
-			"dport",
-			strconv.Itoa(int(port.Port)),
-			"counter", "dnat", "to", p.getVifIpByProtocol(proto))
-		if err != nil {
-			return err
+		rules = append(rules, natRule{"KUBEVIRT_PREINBOUND", append(append([]string{}, match...),
+			"counter", "dnat", "to", p.getVifIpByProtocol(proto))})
+
+		if hairpin != v1.MasqueradeHairpinNone {
+			spec := append(p.nftablesHairpinMatch(proto, false), match...)
+			rules = append(rules, natRule{"output", append(spec,
+				"counter", "dnat", "to", p.getVifIpByProtocol(proto))})
 		}
+	}
 
-		err = Handler.NftablesAppendRule(proto, "nat", "output",
-			Handler.GetNFTIPString(proto), "daddr", getLoopbackAdrress(proto),
-			strings.ToLower(port.Protocol),
-			"dport",
-			strconv.Itoa(int(port.Port)),
-			"counter", "dnat", "to", p.getVifIpByProtocol(proto))
-		if err != nil {
-			return err
+	return rules
+}
+
+// updateNatRules replaces the port forwarding rules created for the previous spec of the
+// interface by the ones of its current spec. The bridge and the tap device are left untouched.
+func (p *MasqueradePodInterface) updateNatRules(proto iptables.Protocol, previous *v1.Interface) error {
+	old := *p
+	old.iface = previous
+
+	if Handler.HasNatIptables(proto) {
+		oldRules, newRules := old.iptablesPortForwardRules(proto), p.iptablesPortForwardRules(proto)
+		for _, rule := range oldRules {
+			if containsNatRule(newRules, rule) {
+				continue
+			}
+			if err := Handler.IptablesDeleteRule(proto, "nat", rule.chain, rule.spec...); err != nil {
+				return err
+			}
 		}
+		for _, rule := range newRules {
+			if containsNatRule(oldRules, rule) {
+				continue
+			}
+			if err := Handler.IptablesAppendRule(proto, "nat", rule.chain, rule.spec...); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
+	// nftables rules can only be deleted by their handle, the chains holding
+	// nothing but the port forwarding rules are rebuilt instead
+	for _, chain := range []string{"KUBEVIRT_PREINBOUND", "KUBEVIRT_POSTINBOUND", "output"} {
+		if err := Handler.NftablesFlushChain(proto, kubevirtNftTable, chain); err != nil {
+			return err
+		}
+	}
+	for _, rule := range p.nftablesPortForwardRules(proto) {
+		if err := Handler.NftablesAppendRule(proto, kubevirtNftTable, rule.chain, rule.spec...); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1189,6 +1564,15 @@ func (m *MacvtapPodInterface) discoverPodNetworkInterface() error {
 }
 
 func (m *MacvtapPodInterface) preparePodNetworkInterfaces(queueNumber uint32, launcherPID int) error {
+	// macvtap only delivers frames addressed to the MAC of the device, which
+	// differs from the guest MAC on a migration target
+	if m.podNicLink.Attrs().HardwareAddr.String() != m.vif.MAC.String() {
+		if err := Handler.LinkSetHardwareAddr(m.podNicLink, m.vif.MAC); err != nil {
+			log.Log.Reason(err).Errorf("failed to set MAC %s on macvtap device %s", m.vif.MAC, m.podInterfaceName)
+			return err
+		}
+	}
+
 	m.virtIface.MAC = &api.MAC{MAC: m.vif.MAC.String()}
 	m.virtIface.MTU = &api.MTU{Size: strconv.Itoa(m.podNicLink.Attrs().MTU)}
 	m.virtIface.Target = &api.InterfaceTarget{
@@ -1237,7 +1621,7 @@ func (m *MacvtapPodInterface) loadCachedVIF(pid, name string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	err = json.Unmarshal(buf, &m.vif)
+	_, err = unmarshalCacheFile(buf, &m.vif)
 	if err != nil {
 		return false, err
 	}
@@ -1245,7 +1629,7 @@ func (m *MacvtapPodInterface) loadCachedVIF(pid, name string) (bool, error) {
 }
 
 func (m *MacvtapPodInterface) setCachedVIF(pid, name string) error {
-	buf, err := json.MarshalIndent(&m.vif, "", "  ")
+	buf, err := marshalCacheFile(m.vif)
 	if err != nil {
 		return fmt.Errorf("error marshaling vif object: %v", err)
 	}
@@ -1266,6 +1650,111 @@ func createAndBindTapToBridge(virtualInterface *VIF, deviceName string, bridgeIf
 	return Handler.BindTapDeviceToBridge(deviceName, bridgeIfaceName)
 }
 
+// configureTapQoS shapes the tap device according to the QoS class of the VMI, if any
+func configureTapQoS(vmi *v1.VirtualMachineInstance, tapDeviceName string) error {
+	class, ok := qos.Lookup(vmi.Spec.QoSClass)
+	if !ok {
+		return nil
+	}
+	if err := Handler.ConfigureTapQoS(tapDeviceName, class); err != nil {
+		log.Log.Reason(err).Errorf("failed to configure QoS class %s on tap device %s", vmi.Spec.QoSClass, tapDeviceName)
+		return err
+	}
+	return nil
+}
+
+// bridgeTXChecksumOffload decides whether TX checksum offload is kept on the
+// bridge of the interface. The replies of the DHCP server leave the bridge
+// with partial checksums when it is on, which some guest DHCP clients drop,
+// so unless the interface asks otherwise it is only kept when the guest is
+// not served by the DHCP server.
+func bridgeTXChecksumOffload(iface *v1.Interface, dhcpServed bool) bool {
+	if iface.Offloads != nil && iface.Offloads.TXChecksum != nil {
+		return *iface.Offloads.TXChecksum
+	}
+	return !dhcpServed
+}
+
+func configureBridgeTXChecksumOffload(vmi *v1.VirtualMachineInstance, iface *v1.Interface, bridgeInterfaceName string, dhcpServed bool) error {
+	enabled := bridgeTXChecksumOffload(iface, dhcpServed)
+	if !enabled {
+		if err := Handler.DisableTXOffloadChecksum(bridgeInterfaceName); err != nil {
+			log.Log.Reason(err).Error("failed to disable TX offload checksum on bridge interface")
+			return err
+		}
+	}
+
+	cache := &PodCacheInterface{}
+	if _, err := readFromCachedFile(string(vmi.UID), iface.Name, util.VMIInterfacepath, cache); err != nil {
+		return err
+	}
+	cache.BridgeTXChecksumOffload = &enabled
+	return writeToCachedFile(cache, util.VMIInterfacepath, string(vmi.UID), iface.Name)
+}
+
+// configureTapOffloads toggles the offloads of the tap device requested on the interface, if any
+func configureTapOffloads(iface *v1.Interface, tapDeviceName string) error {
+	offloads := tapOffloads(iface)
+	if offloads == nil {
+		return nil
+	}
+	if err := Handler.ConfigureTapOffloads(tapDeviceName, offloads); err != nil {
+		log.Log.Reason(err).Errorf("failed to configure offloads on tap device %s", tapDeviceName)
+		return err
+	}
+	return nil
+}
+
+// tapOffloads returns the offloads of the tap device of the interface. GRO
+// delays the delivery of packets to coalesce them, so it is disabled on
+// low-latency interfaces, unless the interface explicitly enables it.
+func tapOffloads(iface *v1.Interface) *v1.InterfaceOffloads {
+	if iface.LatencyProfile != v1.InterfaceLatencyProfileLowLatency {
+		return iface.Offloads
+	}
+	offloads := &v1.InterfaceOffloads{}
+	if iface.Offloads != nil {
+		offloads = iface.Offloads.DeepCopy()
+	}
+	if offloads.GRO == nil {
+		disabled := false
+		offloads.GRO = &disabled
+	}
+	return offloads
+}
+
+// configurePodInterfaceLatency disables GRO on the pod interface of
+// low-latency interfaces, the packets received by the guest pass it before
+// they reach the tap device.
+func configurePodInterfaceLatency(iface *v1.Interface, podInterfaceName string) error {
+	if iface.LatencyProfile != v1.InterfaceLatencyProfileLowLatency {
+		return nil
+	}
+	if err := Handler.DisableGRO(podInterfaceName); err != nil {
+		log.Log.Reason(err).Errorf("failed to disable GRO on interface %s", podInterfaceName)
+		return err
+	}
+	return nil
+}
+
+// configureTapMirror copies the traffic of the tap device to the pod interface
+// of the monitoring network requested on the interface, if any
+func configureTapMirror(vmi *v1.VirtualMachineInstance, iface *v1.Interface, tapDeviceName string) error {
+	if iface.Mirror == nil {
+		return nil
+	}
+	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
+	if _, exists := networks[iface.Mirror.Network]; !exists {
+		return fmt.Errorf("failed to find the mirror network %s", iface.Mirror.Network)
+	}
+	targetName := getPodInterfaceName(networks, cniNetworks, iface.Mirror.Network)
+	if err := Handler.MirrorTapTraffic(tapDeviceName, targetName, iface.Mirror.Direction); err != nil {
+		log.Log.Reason(err).Errorf("failed to mirror tap device %s to %s", tapDeviceName, targetName)
+		return err
+	}
+	return nil
+}
+
 func generateTapDeviceName(podInterfaceName string) string {
 	return "tap" + podInterfaceName[3:]
 }
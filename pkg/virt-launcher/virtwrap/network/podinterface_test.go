@@ -20,15 +20,17 @@
 package network
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
 	"runtime"
+	"syscall"
 
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/qos"
+	"kubevirt.io/kubevirt/pkg/util/sysctl"
 
 	"github.com/coreos/go-iptables/iptables"
 
@@ -36,6 +38,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/vishvananda/netlink"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
@@ -87,6 +92,7 @@ var _ = Describe("Pod Network", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockNetwork = NewMockNetworkHandler(ctrl)
 		Handler = mockNetwork
+		interfaceStates = newInterfaceStateCache()
 		testMac := "12:34:56:78:9A:BC"
 		updateTestMac := "AF:B3:1F:78:2A:CA"
 		mtu = 1410
@@ -200,6 +206,11 @@ var _ = Describe("Pod Network", func() {
 		mockNetwork.EXPECT().CreateTapDevice(tapDeviceName, queueNumber, pid, mtu).Return(nil)
 		mockNetwork.EXPECT().BindTapDeviceToBridge(tapDeviceName, "k6t-eth0").Return(nil)
 		mockNetwork.EXPECT().DisableTXOffloadChecksum(bridgeTest.Name).Return(nil)
+		mockNetwork.EXPECT().GetSysctl("net/ipv4/conf/k6t-eth0/arp_ignore").Return(0, nil)
+		mockNetwork.EXPECT().SetSysctl("net/ipv4/conf/k6t-eth0/arp_ignore", 1).Return(nil)
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetBridgeNfCallIptables).Return(1, nil)
+		mockNetwork.EXPECT().SetSysctl(sysctl.NetBridgeNfCallIptables, 0).Return(nil)
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetBridgeNfCallIp6tables).Return(-1, os.ErrNotExist)
 
 		// For masquerade tests
 		mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
@@ -223,7 +234,8 @@ var _ = Describe("Pod Network", func() {
 		mockNetwork.EXPECT().CreateTapDevice(tapDeviceName, queueNumber, pid, mtu).Return(nil)
 		mockNetwork.EXPECT().DisableTXOffloadChecksum(bridgeTest.Name).Return(nil)
 		// Global nat rules using iptables
-		mockNetwork.EXPECT().ConfigureIpv6Forwarding().Return(nil)
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetIPv6Forwarding).Return(0, nil)
+		mockNetwork.EXPECT().SetSysctl(sysctl.NetIPv6Forwarding, 1).Return(nil)
 		mockNetwork.EXPECT().GetNFTIPString(iptables.ProtocolIPv4).Return("ip").AnyTimes()
 		mockNetwork.EXPECT().GetNFTIPString(iptables.ProtocolIPv6).Return("ip6").AnyTimes()
 		for _, proto := range ipProtocols() {
@@ -253,29 +265,27 @@ var _ = Describe("Pod Network", func() {
 				"--to-destination",
 				GetMasqueradeVmIp(proto)).Return(nil)
 			//Global net rules using nftable
-			ipVersionNum := "4"
-			if proto == iptables.ProtocolIPv6 {
-				ipVersionNum = "6"
-			}
-			mockNetwork.EXPECT().NftablesLoad(fmt.Sprintf("ipv%s-nat", ipVersionNum)).Return(nil)
-			mockNetwork.EXPECT().NftablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND").Return(nil)
-			mockNetwork.EXPECT().NftablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(nil)
-			mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "postrouting", GetNFTIPString(proto), "saddr", GetMasqueradeVmIp(proto), "counter", "masquerade").Return(nil)
-			mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "prerouting", "iifname", "eth0", "counter", "jump", "KUBEVIRT_PREINBOUND").Return(nil)
-			mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "postrouting", "oifname", "k6t-eth0", "counter", "jump", "KUBEVIRT_POSTINBOUND").Return(nil)
-			mockNetwork.EXPECT().NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND", "counter", "dnat", "to", GetMasqueradeVmIp(proto)).Return(nil)
+			mockNetwork.EXPECT().NftablesNewTable(proto, kubevirtNftTable).Return(nil)
+			mockNetwork.EXPECT().NftablesFlushTable(proto, kubevirtNftTable).Return(nil)
+			expectNftablesNatHooks(mockNetwork, proto)
+			mockNetwork.EXPECT().NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND").Return(nil)
+			mockNetwork.EXPECT().NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "postrouting", GetNFTIPString(proto), "saddr", GetMasqueradeVmIp(proto), "counter", "masquerade").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "prerouting", "iifname", "eth0", "counter", "jump", "KUBEVIRT_PREINBOUND").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "postrouting", "oifname", "k6t-eth0", "counter", "jump", "KUBEVIRT_POSTINBOUND").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND", "counter", "dnat", "to", GetMasqueradeVmIp(proto)).Return(nil)
 
 		}
 		mockNetwork.EXPECT().CreateTapDevice(tapDeviceName, queueNumber, pid, mtu).Return(nil)
 		mockNetwork.EXPECT().BindTapDeviceToBridge(tapDeviceName, "k6t-eth0").Return(nil)
 
-		err := SetupPodNetworkPhase1(vm, pid)
+		err := SetupPodNetworkPhase1(vm, pid, runInCurrentNetNS)
 		Expect(err).To(BeNil())
 
 		// Calling SetupPodNetworkPhase1 a second time should result in
 		// no mockNetwork function calls, as confirmed by mock object
 		// limited number of calls expected for each mocked entry point.
-		err = SetupPodNetworkPhase1(vm, pid)
+		err = SetupPodNetworkPhase1(vm, pid, runInCurrentNetNS)
 		Expect(err).To(BeNil())
 	}
 
@@ -301,6 +311,98 @@ var _ = Describe("Pod Network", func() {
 			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
 			TestPodInterfaceIPBinding(vm, domain)
 		})
+		It("should shape the tap device according to the QoS class", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			vm.Spec.QoSClass = v1.QoSClassSilver
+			qos.SetClasses([]v1.QoSClassConfiguration{{
+				Name:            v1.QoSClassSilver,
+				BlkioWeight:     500,
+				NetworkRate:     resource.MustParse("62500k"),
+				NetworkCeil:     resource.MustParse("125M"),
+				NetworkPriority: 1,
+			}})
+			defer qos.SetClasses(nil)
+
+			class := qos.Class{BlkioWeight: 500, NetworkRate: 62500000, NetworkCeil: 125000000, NetworkPrio: 1}
+			mockNetwork.EXPECT().ConfigureTapQoS(tapDeviceName, class).Return(nil)
+
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			TestPodInterfaceIPBinding(vm, domain)
+		})
+		It("should create a multiqueue tap device for multiqueue virtio interfaces", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			multiQueue := true
+			vm.Spec.Domain.Devices.NetworkInterfaceMultiQueue = &multiQueue
+			vm.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+
+			mockNetwork.EXPECT().CreateTapDevice(tapDeviceName, uint32(2), pid, mtu).Return(nil)
+
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			TestPodInterfaceIPBinding(vm, domain)
+		})
+		It("should create a single queue tap device for non virtio interfaces of multiqueue VMIs", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			multiQueue := true
+			vm.Spec.Domain.Devices.NetworkInterfaceMultiQueue = &multiQueue
+			vm.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+			vm.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			TestPodInterfaceIPBinding(vm, domain)
+		})
+		It("should toggle the offloads of the tap device", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			off := false
+			offloads := &v1.InterfaceOffloads{TSO: &off, GRO: &off}
+			vm.Spec.Domain.Devices.Interfaces[0].Offloads = offloads
+
+			mockNetwork.EXPECT().ConfigureTapOffloads(tapDeviceName, offloads).Return(nil)
+
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			TestPodInterfaceIPBinding(vm, domain)
+		})
+		It("should disable GRO on the tap device and the pod interface of low-latency interfaces", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			off := false
+			vm.Spec.Domain.Devices.Interfaces[0].LatencyProfile = v1.InterfaceLatencyProfileLowLatency
+
+			mockNetwork.EXPECT().ConfigureTapOffloads(tapDeviceName, &v1.InterfaceOffloads{GRO: &off}).Return(nil)
+			mockNetwork.EXPECT().DisableGRO(podInterface).Return(nil)
+
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			TestPodInterfaceIPBinding(vm, domain)
+		})
+		It("should mirror the tap device to the monitoring network", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			vm.Spec.Networks = append(vm.Spec.Networks, v1.Network{
+				Name:          "monitor",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "monitor-net"}},
+			})
+			vm.Spec.Domain.Devices.Interfaces[0].Mirror = &v1.InterfaceMirror{Network: "monitor", Direction: v1.MirrorDirectionRX}
+
+			mockNetwork.EXPECT().MirrorTapTraffic(tapDeviceName, "net1", v1.MirrorDirectionRX).Return(nil)
+
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			TestPodInterfaceIPBinding(vm, domain)
+		})
 		It("phase1 should return a CriticalNetworkError if pod networking fails to setup", func() {
 
 			domain := NewDomainWithBridgeInterface()
@@ -329,7 +431,7 @@ var _ = Describe("Pod Network", func() {
 			mockNetwork.EXPECT().DisableTXOffloadChecksum(bridgeTest.Name).Return(nil)
 			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
 
-			err := SetupPodNetworkPhase1(vm, pid)
+			err := SetupPodNetworkPhase1(vm, pid, runInCurrentNetNS)
 			Expect(err).To(HaveOccurred(), "SetupPodNetworkPhase1 should return an error")
 
 			_, ok := err.(*CriticalNetworkError)
@@ -349,7 +451,7 @@ var _ = Describe("Pod Network", func() {
 			mockNetwork.EXPECT().GetMacDetails(podInterface).Return(fakeMac, nil)
 			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
 
-			err := SetupPodNetworkPhase1(vm, pid)
+			err := SetupPodNetworkPhase1(vm, pid, runInCurrentNetNS)
 			Expect(err).To(HaveOccurred())
 		})
 		Context("func filterPodNetworkRoutes()", func() {
@@ -372,15 +474,124 @@ var _ = Describe("Pod Network", func() {
 				Expect(filterPodNetworkRoutes(staticRouteList, testNic)).To(Equal(expectedRouteList))
 			})
 		})
-		It("phase2 should panic if DHCP startup fails", func() {
-			testDhcpPanic := func() {
-				domain := NewDomainWithBridgeInterface()
-				vm := newVMIBridgeInterface("testnamespace", "testVmName")
-				api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
-				mockNetwork.EXPECT().StartDHCP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to open file"))
-				SetupPodNetworkPhase2(vm, domain)
+		It("phase2 should return an error if DHCP startup fails", func() {
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+
+			iface := &vm.Spec.Domain.Devices.Interfaces[0]
+			driver, err := getPhase1Binding(vm, iface, &vm.Spec.Networks[0], podInterface)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(driver.setCachedInterface("self", iface.Name)).To(Succeed())
+			Expect(driver.setCachedVIF("self", iface.Name)).To(Succeed())
+
+			mockNetwork.EXPECT().StartDHCP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to open file"))
+			var testDhcpErr error
+			testDhcp := func() {
+				testDhcpErr = SetupPodNetworkPhase2(vm, domain)
 			}
-			Expect(testDhcpPanic).To(Panic())
+			Expect(testDhcp).ToNot(Panic())
+			Expect(testDhcpErr).To(HaveOccurred())
+			Expect(testDhcpErr.Error()).To(ContainSubstring("failed to start DHCP server"))
+			setupErr, ok := testDhcpErr.(*NetworkSetupError)
+			Expect(ok).To(BeTrue())
+			Expect(setupErr.Reason).To(Equal(NetworkSetupReasonDHCP))
+		})
+		It("phase2 should report the interface state and start DHCP only once", func() {
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+
+			iface := &vm.Spec.Domain.Devices.Interfaces[0]
+			driver, err := getPhase1Binding(vm, iface, &vm.Spec.Networks[0], podInterface)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(driver.setCachedInterface("self", iface.Name)).To(Succeed())
+			Expect(driver.setCachedVIF("self", iface.Name)).To(Succeed())
+
+			mockNetwork.EXPECT().StartDHCP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to open file"))
+			Expect(SetupPodNetworkPhase2(vm, domain)).ToNot(Succeed())
+			states := GetNetworkInterfacesState()
+			Expect(states).To(HaveLen(1))
+			Expect(states[0].Name).To(Equal(iface.Name))
+			Expect(states[0].PodInterfaceName).To(Equal(podInterface))
+			Expect(states[0].Plugged).To(BeFalse())
+			Expect(states[0].DHCPStarted).To(BeFalse())
+			Expect(states[0].Error).To(ContainSubstring("failed to start DHCP server"))
+
+			mockNetwork.EXPECT().StartDHCP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			Expect(SetupPodNetworkPhase2(vm, domain)).To(Succeed())
+			Expect(SetupPodNetworkPhase2(vm, domain)).To(Succeed())
+			states = GetNetworkInterfacesState()
+			Expect(states).To(HaveLen(1))
+			Expect(states[0].Plugged).To(BeTrue())
+			Expect(states[0].DHCPStarted).To(BeTrue())
+			Expect(states[0].Error).To(BeEmpty())
+		})
+		It("re-plug should start DHCP for the unplugged interfaces of the running domain", func() {
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+
+			iface := &vm.Spec.Domain.Devices.Interfaces[0]
+			driver, err := getPhase1Binding(vm, iface, &vm.Spec.Networks[0], podInterface)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(driver.setCachedInterface("self", iface.Name)).To(Succeed())
+			Expect(driver.setCachedVIF("self", iface.Name)).To(Succeed())
+
+			mockNetwork.EXPECT().StartDHCP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to open file"))
+			Expect(SetupPodNetworkPhase2(vm, domain)).ToNot(Succeed())
+
+			mockNetwork.EXPECT().StartDHCP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			Expect(ReplugNetworkInterfacesPhase2(vm, domain)).To(Succeed())
+			Expect(ReplugNetworkInterfacesPhase2(vm, domain)).To(Succeed())
+			states := GetNetworkInterfacesState()
+			Expect(states).To(HaveLen(1))
+			Expect(states[0].Plugged).To(BeTrue())
+			Expect(states[0].DHCPStarted).To(BeTrue())
+		})
+		It("re-plug should refuse changing the device of the running domain", func() {
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+
+			iface := &vm.Spec.Domain.Devices.Interfaces[0]
+			driver, err := getPhase1Binding(vm, iface, &vm.Spec.Networks[0], podInterface)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(driver.setCachedInterface("self", iface.Name)).To(Succeed())
+			Expect(driver.setCachedVIF("self", iface.Name)).To(Succeed())
+
+			mockNetwork.EXPECT().StartDHCP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to open file"))
+			Expect(SetupPodNetworkPhase2(vm, domain)).ToNot(Succeed())
+			domain.Spec.Devices.Interfaces[0].MAC = &api.MAC{MAC: "de:ad:00:00:be:af"}
+
+			err = ReplugNetworkInterfacesPhase2(vm, domain)
+			Expect(err).To(HaveOccurred())
+			setupErr, ok := err.(*NetworkSetupError)
+			Expect(ok).To(BeTrue())
+			Expect(setupErr.Reason).To(Equal(NetworkSetupReasonDomainChanged))
+			Expect(domain.Spec.Devices.Interfaces[0].MAC.MAC).To(Equal("de:ad:00:00:be:af"))
+			states := GetNetworkInterfacesState()
+			Expect(states).To(HaveLen(1))
+			Expect(states[0].Plugged).To(BeFalse())
+			Expect(states[0].Error).To(ContainSubstring("would change its device in the running domain"))
+		})
+		It("phase2 should return an error if cached interface configuration is missing", func() {
+			domain := NewDomainWithBridgeInterface()
+			vm := newVMIBridgeInterface("testnamespace", "testVmName")
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+
+			err := SetupPodNetworkPhase2(vm, domain)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cached interface configuration"))
+			var setupErr *NetworkSetupError
+			Expect(errors.As(fmt.Errorf("plugging the pod network failed: %w", err), &setupErr)).To(BeTrue())
+			Expect(setupErr.Reason).To(Equal(NetworkSetupReasonCacheMissing))
+			Expect(IsNetworkSetupReason(setupErr.Reason)).To(BeTrue())
+		})
+		It("should only accept the reasons of network setup errors", func() {
+			Expect(IsNetworkSetupReason(NetworkSetupReasonDHCP)).To(BeTrue())
+			Expect(IsNetworkSetupReason("")).To(BeFalse())
+			Expect(IsNetworkSetupReason("Unknown")).To(BeFalse())
 		})
 		Context("getPhase1Binding", func() {
 			Context("for Bridge", func() {
@@ -394,6 +605,27 @@ var _ = Describe("Pod Network", func() {
 					Expect(bridge.vif.MAC.String()).To(Equal("de:ad:00:00:be:af"))
 				})
 			})
+			Context("for Masquerade", func() {
+				It("should use the subnet allocated from the masquerade subnet pool", func() {
+					vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+					vmi.Status.VMNetworkCIDR = "10.10.3.0/24"
+					driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], podInterface)
+					Expect(err).ToNot(HaveOccurred())
+					masquerade, ok := driver.(*MasqueradePodInterface)
+					Expect(ok).To(BeTrue())
+					Expect(masquerade.vmNetworkCIDR).To(Equal("10.10.3.0/24"))
+				})
+				It("should prefer the CIDR of the pod network", func() {
+					vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+					vmi.Spec.Networks[0].Pod.VMNetworkCIDR = "192.168.0.0/24"
+					vmi.Status.VMNetworkCIDR = "10.10.3.0/24"
+					driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], podInterface)
+					Expect(err).ToNot(HaveOccurred())
+					masquerade, ok := driver.(*MasqueradePodInterface)
+					Expect(ok).To(BeTrue())
+					Expect(masquerade.vmNetworkCIDR).To(Equal("192.168.0.0/24"))
+				})
+			})
 		})
 		Context("SRIOV Plug", func() {
 			It("Does not crash", func() {
@@ -492,20 +724,20 @@ var _ = Describe("Pod Network", func() {
 				for _, proto := range ipProtocols() {
 					mockNetwork.EXPECT().HasNatIptables(proto).Return(false).Times(2)
 
-					mockNetwork.EXPECT().NftablesAppendRule(proto, "nat",
+					mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable,
 						"KUBEVIRT_POSTINBOUND",
 						"tcp",
 						"dport",
 						"80",
 						GetNFTIPString(proto), "saddr", getLoopbackAdrress(proto),
 						"counter", "snat", "to", GetMasqueradeGwIp(proto)).Return(nil).AnyTimes()
-					mockNetwork.EXPECT().NftablesAppendRule(proto, "nat",
+					mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable,
 						"KUBEVIRT_PREINBOUND",
 						"tcp",
 						"dport",
 						"80",
 						"counter", "dnat", "to", GetMasqueradeVmIp(proto)).Return(nil).AnyTimes()
-					mockNetwork.EXPECT().NftablesAppendRule(proto, "nat",
+					mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable,
 						"output",
 						GetNFTIPString(proto), "daddr", getLoopbackAdrress(proto),
 						"tcp",
@@ -587,6 +819,7 @@ var _ = Describe("Pod Network", func() {
 				vmi := newVMIMacvtapInterface("testnamespace", "default", ifaceName)
 
 				api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+				dummy.HardwareAddr = fakeMac
 
 				driver, err := getPhase2Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, ifaceName)
 				mockNetwork.EXPECT().GetMacDetails(ifaceName).Return(fakeMac, nil)
@@ -598,6 +831,198 @@ var _ = Describe("Pod Network", func() {
 				Expect(domain.Spec.Devices.Interfaces[0].MAC).To(Equal(&api.MAC{MAC: fakeMac.String()}), "should have the expected MAC address")
 				Expect(domain.Spec.Devices.Interfaces[0].MTU).To(Equal(&api.MTU{Size: "1410"}), "should have the expected MTU")
 			})
+			It("Should set the guest MAC of the migration source on the macvtap device", func() {
+				ifaceName := "macvtap0"
+				domain := NewDomainWithMacvtapInterface(ifaceName)
+				vmi := newVMIMacvtapInterface("testnamespace", "default", ifaceName)
+				vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+					SourceNetworkState: []v1.MigrationInterfaceNetworkState{{Name: ifaceName, MAC: updateFakeMac.String()}},
+				}
+
+				api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+				dummy.HardwareAddr = fakeMac
+
+				driver, err := getPhase1Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], ifaceName)
+				Expect(err).ToNot(HaveOccurred())
+				driver.(*MacvtapPodInterface).domain = domain
+				mockNetwork.EXPECT().LinkByName(ifaceName).Return(dummy, nil)
+				mockNetwork.EXPECT().LinkSetHardwareAddr(dummy, updateFakeMac).Return(nil)
+				TestRunPlug(driver)
+				Expect(domain.Spec.Devices.Interfaces[0].MAC).To(Equal(&api.MAC{MAC: updateFakeMac.String()}))
+			})
+		})
+	})
+
+	Context("Masquerade hairpin on IPv6", func() {
+		proto := iptables.ProtocolIPv6
+		var iface *v1.Interface
+		var driver *MasqueradePodInterface
+
+		BeforeEach(func() {
+			iface = v1.DefaultMasqueradeNetworkInterface()
+			iface.Ports = []v1.Port{{Name: "http", Port: 80, Protocol: "TCP"}}
+			driver = &MasqueradePodInterface{
+				iface:               iface,
+				vif:                 masqueradeTestNic,
+				podInterfaceName:    "eth0",
+				bridgeInterfaceName: "k6t-eth0",
+				gatewayAddr:         masqueradeGwAddr,
+				gatewayIpv6Addr:     masqueradeIpv6GwAddr,
+			}
+			mockNetwork.EXPECT().GetNFTIPString(proto).Return("ip6").AnyTimes()
+		})
+
+		expectIptablesBaseRules := func() {
+			mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+			mockNetwork.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND").Return(nil)
+			mockNetwork.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "POSTROUTING", "-s", masqueradeVmIpv6, "-j", "MASQUERADE").Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "PREROUTING", "-i", "eth0", "-j", "KUBEVIRT_PREINBOUND").Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "POSTROUTING", "-o", "k6t-eth0", "-j", "KUBEVIRT_POSTINBOUND").Return(nil)
+		}
+
+		expectNftablesBaseRules := func() {
+			mockNetwork.EXPECT().HasNatIptables(proto).Return(false)
+			mockNetwork.EXPECT().NftablesFlushTable(proto, kubevirtNftTable).Return(nil)
+			expectNftablesNatHooks(mockNetwork, proto)
+			mockNetwork.EXPECT().NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND").Return(nil)
+			mockNetwork.EXPECT().NftablesNewChain(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "postrouting", "ip6", "saddr", masqueradeVmIpv6, "counter", "masquerade").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "prerouting", "iifname", "eth0", "counter", "jump", "KUBEVIRT_PREINBOUND").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "postrouting", "oifname", "k6t-eth0", "counter", "jump", "KUBEVIRT_POSTINBOUND").Return(nil)
+		}
+
+		It("should forward connections to ::1 by default using iptables", func() {
+			expectIptablesBaseRules()
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+				"-p", "tcp", "--dport", "80", "--source", "::1", "-j", "SNAT", "--to-source", masqueradeGwIpv6).Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+				"-p", "tcp", "--dport", "80", "-j", "DNAT", "--to-destination", masqueradeVmIpv6).Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "OUTPUT",
+				"-p", "tcp", "--dport", "80", "--destination", "::1", "-j", "DNAT", "--to-destination", masqueradeVmIpv6).Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+
+		It("should leave connections from the pod alone without hairpin using iptables", func() {
+			iface.Masquerade.Hairpin = v1.MasqueradeHairpinNone
+			expectIptablesBaseRules()
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+				"-p", "tcp", "--dport", "80", "-j", "DNAT", "--to-destination", masqueradeVmIpv6).Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+
+		It("should forward connections to any local address with full hairpin using iptables", func() {
+			iface.Masquerade.Hairpin = v1.MasqueradeHairpinFull
+			expectIptablesBaseRules()
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+				"-p", "tcp", "--dport", "80", "-m", "addrtype", "--src-type", "LOCAL", "-j", "SNAT", "--to-source", masqueradeGwIpv6).Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+				"-p", "tcp", "--dport", "80", "-j", "DNAT", "--to-destination", masqueradeVmIpv6).Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "OUTPUT",
+				"-p", "tcp", "--dport", "80", "-m", "addrtype", "--dst-type", "LOCAL", "-j", "DNAT", "--to-destination", masqueradeVmIpv6).Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+
+		It("should hairpin the VM to its own service IP when all ports are forwarded using iptables", func() {
+			iface.Ports = nil
+			iface.Masquerade.Hairpin = v1.MasqueradeHairpinFull
+			expectIptablesBaseRules()
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+				"-j", "DNAT", "--to-destination", masqueradeVmIpv6).Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+				"-m", "addrtype", "--src-type", "LOCAL", "-j", "SNAT", "--to-source", masqueradeGwIpv6).Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+
+		It("should clamp the TCP MSS to the path MTU when requested using iptables", func() {
+			iface.Masquerade.Hairpin = v1.MasqueradeHairpinNone
+			clampMSS := true
+			iface.Masquerade.ClampMSS = &clampMSS
+			expectIptablesBaseRules()
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+				"-p", "tcp", "--dport", "80", "-j", "DNAT", "--to-destination", masqueradeVmIpv6).Return(nil)
+			mockNetwork.EXPECT().IptablesAppendRule(proto, "mangle", "FORWARD",
+				"-p", "tcp", "--tcp-flags", "SYN,RST", "SYN", "-j", "TCPMSS", "--clamp-mss-to-pmtu").Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+
+		It("should clamp the TCP MSS to the path MTU when requested using nftables", func() {
+			iface.Masquerade.Hairpin = v1.MasqueradeHairpinNone
+			clampMSS := true
+			iface.Masquerade.ClampMSS = &clampMSS
+			expectNftablesBaseRules()
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND",
+				"tcp", "dport", "80", "counter", "dnat", "to", masqueradeVmIpv6).Return(nil)
+			mockNetwork.EXPECT().NftablesNewBaseChain(proto, kubevirtNftTable, "forward", "filter", "forward", -150).Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "forward",
+				"tcp", "flags", "&", "(syn|rst)", "==", "syn", "counter", "tcp", "option", "maxseg", "size", "set", "rt", "mtu").Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+
+		It("should leave connections from the pod alone without hairpin using nftables", func() {
+			iface.Masquerade.Hairpin = v1.MasqueradeHairpinNone
+			expectNftablesBaseRules()
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND",
+				"tcp", "dport", "80", "counter", "dnat", "to", masqueradeVmIpv6).Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+
+		It("should forward connections to any local address with full hairpin using nftables", func() {
+			iface.Masquerade.Hairpin = v1.MasqueradeHairpinFull
+			expectNftablesBaseRules()
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND",
+				"tcp", "dport", "80", "fib", "saddr", "type", "local", "counter", "snat", "to", masqueradeGwIpv6).Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND",
+				"tcp", "dport", "80", "counter", "dnat", "to", masqueradeVmIpv6).Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "output",
+				"fib", "daddr", "type", "local", "tcp", "dport", "80", "counter", "dnat", "to", masqueradeVmIpv6).Return(nil)
+
+			Expect(driver.createNatRules(proto)).To(Succeed())
+			ctrl.Finish()
+		})
+	})
+
+	Context("Masquerade bridge with the metadata service", func() {
+		It("should add the metadata service address to the bridge", func() {
+			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+			vmi.Spec.MetadataService = &v1.MetadataService{}
+			driver := &MasqueradePodInterface{
+				vmi:                 vmi,
+				iface:               &vmi.Spec.Domain.Devices.Interfaces[0],
+				vif:                 masqueradeTestNic,
+				podInterfaceName:    podInterface,
+				bridgeInterfaceName: api.DefaultBridgeName,
+				gatewayAddr:         masqueradeGwAddr,
+				gatewayIpv6Addr:     masqueradeIpv6GwAddr,
+			}
+			metadataServiceAddr, _ := netlink.ParseAddr("169.254.169.254/32")
+
+			mockNetwork.EXPECT().LinkByName(masqueradeDummyName).Return(masqueradeDummy, nil)
+			mockNetwork.EXPECT().LinkAdd(masqueradeBridgeTest).Return(nil)
+			mockNetwork.EXPECT().LinkSetMaster(masqueradeDummy, masqueradeBridgeTest).Return(nil)
+			mockNetwork.EXPECT().LinkSetUp(masqueradeBridgeTest).Return(nil)
+			mockNetwork.EXPECT().AddrAdd(masqueradeBridgeTest, masqueradeGwAddr).Return(nil)
+			mockNetwork.EXPECT().ParseAddr("169.254.169.254/32").Return(metadataServiceAddr, nil)
+			mockNetwork.EXPECT().AddrAdd(masqueradeBridgeTest, metadataServiceAddr).Return(nil)
+			mockNetwork.EXPECT().IsIpv6Enabled(podInterface).Return(false, nil)
+			mockNetwork.EXPECT().DisableTXOffloadChecksum(api.DefaultBridgeName).Return(nil)
+
+			Expect(driver.createBridge()).To(Succeed())
+			ctrl.Finish()
 		})
 	})
 
@@ -619,6 +1044,33 @@ var _ = Describe("Pod Network", func() {
 			err = masq.startDHCP(vmi)
 			Expect(err).ToNot(HaveOccurred())
 		})
+		It("should relay TFTP on the gateway and point the guest to it", func() {
+			domain := NewDomainWithBridgeInterface()
+			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = &v1.DHCPOptions{
+				BootFileName: "pxelinux.0",
+				NextServer:   "192.168.1.10",
+				TFTPProxy:    true,
+			}
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			driver, err := getPhase2Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, podInterface)
+			Expect(err).ToNot(HaveOccurred())
+			masq, ok := driver.(*MasqueradePodInterface)
+			Expect(ok).To(BeTrue())
+
+			masq.vif.Gateway = masqueradeGwAddr.IP.To4()
+			masq.vif.GatewayIpv6 = masqueradeIpv6GwAddr.IP.To16()
+			mockNetwork.EXPECT().StartTFTPProxy(masq.vif.Gateway, "192.168.1.10").Return(nil)
+			mockNetwork.EXPECT().StartDHCP(masq.vif, gomock.Any(), masq.bridgeInterfaceName, &v1.DHCPOptions{
+				BootFileName:   "pxelinux.0",
+				TFTPServerName: masqueradeGwIp,
+				NextServer:     masqueradeGwIp,
+				TFTPProxy:      true,
+			}).Return(nil)
+
+			Expect(masq.startDHCP(vmi)).To(Succeed())
+			Expect(vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions.NextServer).To(Equal("192.168.1.10"))
+		})
 		It("should fail when DHCP server failed", func() {
 			domain := NewDomainWithBridgeInterface()
 			vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
@@ -689,6 +1141,81 @@ var _ = Describe("Pod Network", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+	Context("Bridge without IPAM and a guest address", func() {
+		var bridge *BridgePodInterface
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			domain := NewDomainWithBridgeInterface()
+			vmi = newVMIBridgeInterface("testnamespace", "testVmName")
+			api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de:ad:00:00:be:af"
+			vmi.Spec.Domain.Devices.Interfaces[0].Bridge.GuestAddress = &v1.InterfaceBridgeGuestAddress{
+				IP:        "192.168.1.10/24",
+				Gateway:   "192.168.1.1",
+				ServeDHCP: true,
+			}
+			driver, err := getPhase2Binding(vmi, &vmi.Spec.Domain.Devices.Interfaces[0], &vmi.Spec.Networks[0], domain, podInterface)
+			Expect(err).ToNot(HaveOccurred())
+			var ok bool
+			bridge, ok = driver.(*BridgePodInterface)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should take the VIF address from the guest address", func() {
+			mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
+			mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_V4).Return([]netlink.Addr{}, nil)
+
+			Expect(bridge.discoverPodNetworkInterface()).To(Succeed())
+			Expect(bridge.vif.IPAMDisabled).To(BeTrue())
+			Expect(bridge.vif.IP.String()).To(Equal("192.168.1.10/24"))
+			Expect(bridge.vif.Gateway).To(Equal(net.ParseIP("192.168.1.1").To4()))
+			Expect(bridge.vif.MAC.String()).To(Equal("de:ad:00:00:be:af"))
+		})
+
+		It("should seed the forwarding and neighbor tables of the bridge", func() {
+			tap := &netlink.Tuntap{LinkAttrs: netlink.LinkAttrs{Name: tapDeviceName, Index: 3}}
+			bridgeLink := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: api.DefaultBridgeName, Index: 2}}
+			bridge.vif.IPAMDisabled = true
+			bridge.vif.TapDevice = tapDeviceName
+			Expect(bridge.setGuestAddress()).To(Succeed())
+
+			mockNetwork.EXPECT().LinkByName(tapDeviceName).Return(tap, nil)
+			mockNetwork.EXPECT().LinkByName(api.DefaultBridgeName).Return(bridgeLink, nil)
+			mockNetwork.EXPECT().NeighSet(&netlink.Neigh{
+				LinkIndex:    3,
+				Family:       syscall.AF_BRIDGE,
+				Flags:        netlink.NTF_MASTER,
+				State:        netlink.NUD_NOARP,
+				HardwareAddr: bridge.vif.MAC,
+			}).Return(nil)
+			mockNetwork.EXPECT().NeighSet(&netlink.Neigh{
+				LinkIndex:    2,
+				Family:       netlink.FAMILY_V4,
+				State:        netlink.NUD_PERMANENT,
+				IP:           bridge.vif.IP.IP,
+				HardwareAddr: bridge.vif.MAC,
+			}).Return(nil)
+
+			Expect(bridge.seedGuestAddress()).To(Succeed())
+		})
+
+		It("should serve the guest address over DHCP", func() {
+			bridge.vif.IPAMDisabled = true
+			err := fmt.Errorf("failed to start DHCP server")
+			mockNetwork.EXPECT().StartDHCP(bridge.vif, gomock.Any(), api.DefaultBridgeName, nil).Return(err)
+
+			Expect(bridge.startDHCP(vmi)).To(MatchError(err))
+		})
+
+		It("should not serve DHCP unless requested", func() {
+			bridge.vif.IPAMDisabled = true
+			bridge.iface.Bridge.GuestAddress.ServeDHCP = false
+
+			Expect(bridge.startDHCP(vmi)).To(Succeed())
+		})
+	})
+
 	Context("Slirp startDHCP", func() {
 		It("should succeed when DHCP server started", func() {
 			domain := NewDomainWithSlirpInterface()
@@ -811,9 +1338,143 @@ var _ = Describe("Pod Network", func() {
 		data, err := ioutil.ReadFile(fmt.Sprintf(util.VMIInterfacepath, uid, iface.Name))
 		Expect(err).ToNot(HaveOccurred())
 		var podData *PodCacheInterface
-		err = json.Unmarshal(data, &podData)
+		err = UnmarshalCacheFile(data, &podData)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(podData.PodIP).To(Equal("1.2.3.4"))
+		Expect(podData.PrimaryIPFamily).To(Equal(k8sv1.IPv4Protocol))
+	})
+
+	Context("bridge TX checksum offload", func() {
+		uid := "test-txcsum"
+		off, on := false, true
+
+		BeforeEach(func() {
+			Expect(os.MkdirAll(fmt.Sprintf(util.VMIInterfaceDir, uid), 0755)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(fmt.Sprintf(util.VMIInterfaceDir, uid))
+		})
+
+		It("should only be disabled if the guest is served by the DHCP server", func() {
+			iface := &v1.Interface{Name: "default"}
+			Expect(bridgeTXChecksumOffload(iface, true)).To(BeFalse())
+			Expect(bridgeTXChecksumOffload(iface, false)).To(BeTrue())
+		})
+
+		It("should follow the interface if it asks for it", func() {
+			Expect(bridgeTXChecksumOffload(&v1.Interface{Name: "default", Offloads: &v1.InterfaceOffloads{TXChecksum: &on}}, true)).To(BeTrue())
+			Expect(bridgeTXChecksumOffload(&v1.Interface{Name: "default", Offloads: &v1.InterfaceOffloads{TXChecksum: &off}}, false)).To(BeFalse())
+		})
+
+		It("should disable the offload and record it in the pod interface cache", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			vmi.UID = types.UID(uid)
+			iface := &vmi.Spec.Domain.Devices.Interfaces[0]
+			Expect(writeToCachedFile(&PodCacheInterface{Iface: iface, PodIP: "1.2.3.4"}, util.VMIInterfacepath, uid, iface.Name)).To(Succeed())
+
+			mockNetwork.EXPECT().DisableTXOffloadChecksum(api.DefaultBridgeName).Return(nil)
+			Expect(configureBridgeTXChecksumOffload(vmi, iface, api.DefaultBridgeName, true)).To(Succeed())
+
+			cache := &PodCacheInterface{}
+			exists, err := readFromCachedFile(uid, iface.Name, util.VMIInterfacepath, cache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(cache.PodIP).To(Equal("1.2.3.4"))
+			Expect(cache.BridgeTXChecksumOffload).To(Equal(&off))
+		})
+
+		It("should keep the offload if the guest is not served by the DHCP server", func() {
+			vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+			vmi.UID = types.UID(uid)
+			iface := &vmi.Spec.Domain.Devices.Interfaces[0]
+
+			Expect(configureBridgeTXChecksumOffload(vmi, iface, api.DefaultBridgeName, false)).To(Succeed())
+
+			cache := &PodCacheInterface{}
+			_, err := readFromCachedFile(uid, iface.Name, util.VMIInterfacepath, cache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cache.BridgeTXChecksumOffload).To(Equal(&on))
+		})
+	})
+
+	Context("tap offloads", func() {
+		off, on := false, true
+
+		It("should keep the offloads of interfaces without latency profile", func() {
+			offloads := &v1.InterfaceOffloads{TSO: &off}
+			Expect(tapOffloads(&v1.Interface{Name: "default"})).To(BeNil())
+			Expect(tapOffloads(&v1.Interface{Name: "default", Offloads: offloads})).To(BeIdenticalTo(offloads))
+		})
+
+		It("should disable GRO on low-latency interfaces", func() {
+			iface := &v1.Interface{Name: "default", LatencyProfile: v1.InterfaceLatencyProfileLowLatency, Offloads: &v1.InterfaceOffloads{TSO: &off}}
+			Expect(tapOffloads(iface)).To(Equal(&v1.InterfaceOffloads{TSO: &off, GRO: &off}))
+			Expect(iface.Offloads.GRO).To(BeNil())
+		})
+
+		It("should keep GRO on low-latency interfaces enabling it", func() {
+			iface := &v1.Interface{Name: "default", LatencyProfile: v1.InterfaceLatencyProfileLowLatency, Offloads: &v1.InterfaceOffloads{GRO: &on}}
+			Expect(tapOffloads(iface)).To(Equal(&v1.InterfaceOffloads{GRO: &on}))
+		})
+	})
+
+	Context("primary IP family", func() {
+		uid := "test-family"
+		iface := &v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}}
+
+		readCache := func() *PodCacheInterface {
+			data, err := ioutil.ReadFile(fmt.Sprintf(util.VMIInterfacepath, uid, iface.Name))
+			Expect(err).ToNot(HaveOccurred())
+			var podData *PodCacheInterface
+			Expect(UnmarshalCacheFile(data, &podData)).To(Succeed())
+			return podData
+		}
+
+		BeforeEach(func() {
+			Expect(os.MkdirAll(fmt.Sprintf(util.VMIInterfaceDir, uid), 0755)).To(Succeed())
+			addrList := []netlink.Addr{
+				{IPNet: &net.IPNet{IP: net.IPv4(1, 2, 3, 4)}},
+				{IPNet: &net.IPNet{IP: net.ParseIP("fd10:244::8")}},
+			}
+			mockNetwork.EXPECT().LinkByName(podInterface).Return(dummy, nil)
+			mockNetwork.EXPECT().AddrList(dummy, netlink.FAMILY_ALL).Return(addrList, nil)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(fmt.Sprintf(util.VMIInterfaceDir, uid))
+		})
+
+		It("should order dual-stack pod IPs by the primary family and record it", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(false, nil).Times(1)
+			Expect(setPodInterfaceCache(iface, podInterface, uid)).To(Succeed())
+
+			podData := readCache()
+			Expect(podData.PrimaryIPFamily).To(Equal(k8sv1.IPv6Protocol))
+			Expect(podData.PodIPs).To(Equal([]string{"fd10:244::8", "1.2.3.4"}))
+			Expect(podData.PodIP).To(Equal("fd10:244::8"))
+		})
+
+		It("should reorder the cached pod IPs if the primary family changed", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(1)
+			Expect(setPodInterfaceCache(iface, podInterface, uid)).To(Succeed())
+
+			mockNetwork.EXPECT().IsIpv4Primary().Return(false, nil).Times(1)
+			podData := readCache()
+			Expect(RefreshPodInterfaceCache(podData, uid, iface.Name)).To(Succeed())
+			Expect(podData.PodIPs).To(Equal([]string{"fd10:244::8", "1.2.3.4"}))
+			Expect(readCache()).To(Equal(podData))
+		})
+
+		It("should keep the cached pod IPs if the primary family did not change", func() {
+			mockNetwork.EXPECT().IsIpv4Primary().Return(true, nil).Times(2)
+			Expect(setPodInterfaceCache(iface, podInterface, uid)).To(Succeed())
+
+			podData := readCache()
+			Expect(RefreshPodInterfaceCache(podData, uid, iface.Name)).To(Succeed())
+			Expect(podData.PrimaryIPFamily).To(Equal(k8sv1.IPv4Protocol))
+			Expect(podData.PodIPs).To(Equal([]string{"1.2.3.4", "fd10:244::8"}))
+		})
 	})
 })
 
@@ -909,3 +1570,9 @@ func NewDomainWithMacvtapInterface(macvtapName string) *api.Domain {
 	}}
 	return domain
 }
+
+func expectNftablesNatHooks(mockNetwork *MockNetworkHandler, proto iptables.Protocol) {
+	mockNetwork.EXPECT().NftablesNewBaseChain(proto, kubevirtNftTable, "prerouting", "nat", "prerouting", -100).Return(nil)
+	mockNetwork.EXPECT().NftablesNewBaseChain(proto, kubevirtNftTable, "output", "nat", "output", -100).Return(nil)
+	mockNetwork.EXPECT().NftablesNewBaseChain(proto, kubevirtNftTable, "postrouting", "nat", "postrouting", 100).Return(nil)
+}
@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+// portForwardUpdate is a masquerade interface whose forwarded ports changed
+// since it was plugged.
+type portForwardUpdate struct {
+	iface *v1.Interface
+	cache *PodCacheInterface
+}
+
+func pendingPortForwardUpdates(vmi *v1.VirtualMachineInstance) ([]portForwardUpdate, error) {
+	var updates []portForwardUpdate
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		iface := &vmi.Spec.Domain.Devices.Interfaces[i]
+		if iface.Masquerade == nil {
+			continue
+		}
+
+		cache := &PodCacheInterface{}
+		exists, err := readFromCachedFile(string(vmi.UID), iface.Name, util.VMIInterfacepath, cache)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cached pod interface %s: %v", iface.Name, err)
+		}
+		// the interface is not plugged yet
+		if !exists || cache.Iface == nil {
+			continue
+		}
+		if !portsEqual(cache.Iface.Ports, iface.Ports) {
+			updates = append(updates, portForwardUpdate{iface: iface, cache: cache})
+		}
+	}
+	return updates, nil
+}
+
+func portsEqual(a, b []v1.Port) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// PortForwardsChanged tells if the forwarded ports of a masquerade interface of
+// the VMI differ from the ones which were applied when it was plugged. It only
+// reads the pod interface cache of virt-handler, without entering the network
+// namespace of the pod.
+func PortForwardsChanged(vmi *v1.VirtualMachineInstance) bool {
+	updates, err := pendingPortForwardUpdates(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to compare the forwarded ports")
+		return false
+	}
+	return len(updates) > 0
+}
+
+// UpdatePortForwards applies the changes of the forwarded ports of the
+// masquerade interfaces of a running VMI. Only the nat rules of the changed
+// ports are touched, the bridge and the tap device are left as they are.
+// doNetNS has to execute the passed function in the network namespace of the
+// virt-launcher pod.
+func UpdatePortForwards(vmi *v1.VirtualMachineInstance, pid int, doNetNS func(func() error) error) error {
+	updates, err := pendingPortForwardUpdates(vmi)
+	if err != nil {
+		return err
+	}
+	initHandler()
+
+	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
+	for _, update := range updates {
+		if _, exists := networks[update.iface.Name]; !exists {
+			return fmt.Errorf("failed to find a network %s", update.iface.Name)
+		}
+		podInterfaceName := getPodInterfaceName(networks, cniNetworks, update.iface.Name)
+
+		driver := &MasqueradePodInterface{
+			vmi:                 vmi,
+			iface:               update.iface,
+			vif:                 &VIF{Name: podInterfaceName},
+			podInterfaceName:    podInterfaceName,
+			bridgeInterfaceName: fmt.Sprintf("k6t-%s", podInterfaceName),
+		}
+		exists, err := driver.loadCachedVIF(strconv.Itoa(pid), update.iface.Name)
+		if err != nil || !exists {
+			return fmt.Errorf("failed to load the cached vif of interface %s: %v", update.iface.Name, err)
+		}
+		driver.gatewayAddr = &netlink.Addr{IPNet: &net.IPNet{IP: driver.vif.Gateway}}
+		driver.gatewayIpv6Addr = &netlink.Addr{IPNet: &net.IPNet{IP: driver.vif.GatewayIpv6}}
+
+		protocols := []iptables.Protocol{iptables.ProtocolIPv4}
+		if driver.vif.IPv6.IPNet != nil {
+			protocols = append(protocols, iptables.ProtocolIPv6)
+		}
+
+		err = doNetNS(func() error {
+			for _, proto := range protocols {
+				if err := driver.updateNatRules(proto, update.cache.Iface); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update the forwarded ports of interface %s: %v", update.iface.Name, err)
+		}
+
+		update.cache.Iface = update.iface
+		err = writeToCachedFile(update.cache, util.VMIInterfacepath, string(vmi.UID), update.iface.Name)
+		if err != nil {
+			return err
+		}
+		log.Log.Object(vmi).Infof("updated the forwarded ports of interface %s", update.iface.Name)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+var _ = Describe("Port forwards update", func() {
+	var tmpDir string
+	var origInterfacePath string
+	var ctrl *gomock.Controller
+	var mockNetwork *MockNetworkHandler
+	var vmi *v1.VirtualMachineInstance
+	const pid = 1234
+	proto := iptables.ProtocolIPv4
+
+	doNetNS := func(f func() error) error {
+		return f()
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "portforwardtest")
+		Expect(err).ToNot(HaveOccurred())
+		setVifCacheFile(tmpDir + "/vif-cache-%s-%s.json")
+		origInterfacePath = util.VMIInterfacepath
+		util.VMIInterfacepath = tmpDir + "/pod-interface-%s-%s.json"
+
+		ctrl = gomock.NewController(GinkgoT())
+		mockNetwork = NewMockNetworkHandler(ctrl)
+		Handler = mockNetwork
+
+		vmi = newVMIMasqueradeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80}}
+		cache := PodCacheInterface{Iface: vmi.Spec.Domain.Devices.Interfaces[0].DeepCopy(), PodIP: "10.244.0.8", PodIPs: []string{"10.244.0.8"}}
+		Expect(writeToCachedFile(cache, util.VMIInterfacepath, string(vmi.UID), "default")).To(Succeed())
+
+		ip, _ := netlink.ParseAddr("10.0.2.2/24")
+		masquerade := &MasqueradePodInterface{vif: &VIF{Name: "eth0", IP: *ip, Gateway: net.ParseIP("10.0.2.1")}}
+		Expect(masquerade.setCachedVIF(strconv.Itoa(pid), "default")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		util.VMIInterfacepath = origInterfacePath
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should not report changes if the ports are the ones plugged", func() {
+		Expect(PortForwardsChanged(vmi)).To(BeFalse())
+		Expect(UpdatePortForwards(vmi, pid, doNetNS)).To(Succeed())
+		ctrl.Finish()
+	})
+
+	It("should not report changes of interfaces which are not plugged yet", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Name = "other"
+		vmi.Spec.Networks[0].Name = "other"
+		Expect(PortForwardsChanged(vmi)).To(BeFalse())
+	})
+
+	It("should only replace the rules of the changed ports using iptables", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80, Protocol: "TCP"}, {Name: "https", Port: 443, Protocol: "TCP"}}
+		Expect(PortForwardsChanged(vmi)).To(BeTrue())
+
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+			"-p", "tcp", "--dport", "443", "--source", "127.0.0.1", "-j", "SNAT", "--to-source", "10.0.2.1").Return(nil)
+		mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+			"-p", "tcp", "--dport", "443", "-j", "DNAT", "--to-destination", "10.0.2.2").Return(nil)
+		mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "OUTPUT",
+			"-p", "tcp", "--dport", "443", "--destination", "127.0.0.1", "-j", "DNAT", "--to-destination", "10.0.2.2").Return(nil)
+
+		Expect(UpdatePortForwards(vmi, pid, doNetNS)).To(Succeed())
+		ctrl.Finish()
+		Expect(PortForwardsChanged(vmi)).To(BeFalse())
+	})
+
+	It("should forward all ports once the last port is removed using iptables", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = nil
+
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesDeleteRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
+			"-p", "tcp", "--dport", "80", "--source", "127.0.0.1", "-j", "SNAT", "--to-source", "10.0.2.1").Return(nil)
+		mockNetwork.EXPECT().IptablesDeleteRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+			"-p", "tcp", "--dport", "80", "-j", "DNAT", "--to-destination", "10.0.2.2").Return(nil)
+		mockNetwork.EXPECT().IptablesDeleteRule(proto, "nat", "OUTPUT",
+			"-p", "tcp", "--dport", "80", "--destination", "127.0.0.1", "-j", "DNAT", "--to-destination", "10.0.2.2").Return(nil)
+		mockNetwork.EXPECT().IptablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
+			"-j", "DNAT", "--to-destination", "10.0.2.2").Return(nil)
+
+		Expect(UpdatePortForwards(vmi, pid, doNetNS)).To(Succeed())
+		ctrl.Finish()
+	})
+
+	It("should rebuild the port forwarding chains using nftables", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "dns", Port: 53, Protocol: "UDP"}}
+
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(false)
+		mockNetwork.EXPECT().GetNFTIPString(proto).Return("ip").AnyTimes()
+		mockNetwork.EXPECT().NftablesFlushChain(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND").Return(nil)
+		mockNetwork.EXPECT().NftablesFlushChain(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND").Return(nil)
+		mockNetwork.EXPECT().NftablesFlushChain(proto, kubevirtNftTable, "output").Return(nil)
+		mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND",
+			"udp", "dport", "53", "ip", "saddr", "127.0.0.1", "counter", "snat", "to", "10.0.2.1").Return(nil)
+		mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND",
+			"udp", "dport", "53", "counter", "dnat", "to", "10.0.2.2").Return(nil)
+		mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "output",
+			"ip", "daddr", "127.0.0.1", "udp", "dport", "53", "counter", "dnat", "to", "10.0.2.2").Return(nil)
+
+		Expect(UpdatePortForwards(vmi, pid, doNetNS)).To(Succeed())
+		ctrl.Finish()
+	})
+
+	It("should default the protocol of ports to TCP", func() {
+		// the cached port was plugged without a protocol
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = []v1.Port{{Name: "http", Port: 80}, {Name: "https", Port: 443}}
+
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(false)
+		mockNetwork.EXPECT().GetNFTIPString(proto).Return("ip").AnyTimes()
+		mockNetwork.EXPECT().NftablesFlushChain(proto, kubevirtNftTable, gomock.Any()).Return(nil).Times(3)
+		for _, port := range []string{"80", "443"} {
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_POSTINBOUND",
+				"tcp", "dport", port, "ip", "saddr", "127.0.0.1", "counter", "snat", "to", "10.0.2.1").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "KUBEVIRT_PREINBOUND",
+				"tcp", "dport", port, "counter", "dnat", "to", "10.0.2.2").Return(nil)
+			mockNetwork.EXPECT().NftablesAppendRule(proto, kubevirtNftTable, "output",
+				"ip", "daddr", "127.0.0.1", "tcp", "dport", port, "counter", "dnat", "to", "10.0.2.2").Return(nil)
+		}
+
+		Expect(UpdatePortForwards(vmi, pid, doNetNS)).To(Succeed())
+		ctrl.Finish()
+	})
+
+	It("should keep the cached ports if the rules could not be updated", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Ports = nil
+
+		mockNetwork.EXPECT().HasNatIptables(proto).Return(true)
+		mockNetwork.EXPECT().IptablesDeleteRule(proto, "nat", "KUBEVIRT_POSTINBOUND", gomock.Any()).Return(os.ErrPermission)
+
+		Expect(UpdatePortForwards(vmi, pid, doNetNS)).ToNot(Succeed())
+		Expect(PortForwardsChanged(vmi)).To(BeTrue())
+	})
+})
@@ -0,0 +1,77 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// RenderDomainInterfaces applies the phase2 decorations of every VMI
+// interface to a domain converted from the VMI spec, without touching the
+// host. Values which are only known once the pod network exists, like the
+// MTU or a MAC address not requested in the spec, are left unset.
+func RenderDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
+	networks, cniNetworks := getNetworksAndCniNetworks(vmi)
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		iface := &vmi.Spec.Domain.Devices.Interfaces[i]
+		if iface.SRIOV != nil {
+			continue
+		}
+		network, ok := networks[iface.Name]
+		if !ok {
+			return fmt.Errorf("failed to find a network %s", iface.Name)
+		}
+		podInterfaceName := getPodInterfaceName(networks, cniNetworks, iface.Name)
+		driver, err := getPhase2Binding(vmi, iface, network, domain, podInterfaceName)
+		if err != nil {
+			return err
+		}
+
+		switch binding := driver.(type) {
+		case *BridgePodInterface:
+			binding.virtIface.Target = &api.InterfaceTarget{Device: generateTapDeviceName(podInterfaceName), Managed: "no"}
+		case *MasqueradePodInterface:
+			binding.virtIface.Target = &api.InterfaceTarget{Device: generateTapDeviceName(podInterfaceName), Managed: "no"}
+		case *MacvtapPodInterface:
+			binding.virtIface.Target = &api.InterfaceTarget{Device: podInterfaceName, Managed: "no"}
+		}
+
+		if err := driver.decorateConfig(); err != nil {
+			return err
+		}
+		dropUnknownMAC(domain, iface.Name)
+	}
+	publishInterfaceOEMStrings(vmi, domain)
+	return nil
+}
+
+// dropUnknownMAC removes the empty MAC element decorateConfig leaves behind
+// when the MAC address is only assigned by the pod network.
+func dropUnknownMAC(domain *api.Domain, ifaceName string) {
+	for i, iface := range domain.Spec.Devices.Interfaces {
+		if iface.Alias != nil && iface.Alias.Name == ifaceName && iface.MAC != nil && iface.MAC.MAC == "" {
+			domain.Spec.Devices.Interfaces[i].MAC = nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var _ = Describe("Domain interface rendering", func() {
+
+	It("should point bridge interfaces to the tap device without a MAC", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		domain := NewDomainWithBridgeInterface()
+
+		Expect(RenderDomainInterfaces(vmi, domain)).To(Succeed())
+		Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+		Expect(domain.Spec.Devices.Interfaces[0].Target).To(Equal(&api.InterfaceTarget{Device: "tap0", Managed: "no"}))
+		Expect(domain.Spec.Devices.Interfaces[0].MAC).To(BeNil())
+		Expect(domain.Spec.Devices.Interfaces[0].MTU).To(BeNil())
+	})
+
+	It("should keep the MAC address requested in the spec", func() {
+		vmi := newVMIMasqueradeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de:ad:00:00:be:af"
+		domain := NewDomainWithBridgeInterface()
+
+		Expect(RenderDomainInterfaces(vmi, domain)).To(Succeed())
+		Expect(domain.Spec.Devices.Interfaces[0].Target).To(Equal(&api.InterfaceTarget{Device: "tap0", Managed: "no"}))
+		Expect(domain.Spec.Devices.Interfaces[0].MAC).To(Equal(&api.MAC{MAC: "de:ad:00:00:be:af"}))
+	})
+
+	It("should use the pod interface as macvtap target", func() {
+		vmi := newVMIMacvtapInterface("testnamespace", "testVmName", "default")
+		domain := NewDomainWithMacvtapInterface("default")
+
+		Expect(RenderDomainInterfaces(vmi, domain)).To(Succeed())
+		Expect(domain.Spec.Devices.Interfaces[0].Target).To(Equal(&api.InterfaceTarget{Device: "eth0", Managed: "no"}))
+	})
+
+	It("should move slirp interfaces to the qemu command line", func() {
+		vmi := newVMISlirpInterface("testnamespace", "testVmName")
+		domain := NewDomainWithSlirpInterface()
+
+		Expect(RenderDomainInterfaces(vmi, domain)).To(Succeed())
+		Expect(domain.Spec.Devices.Interfaces).To(BeEmpty())
+		Expect(domain.Spec.QEMUCmd.QEMUArg).To(ContainElement(api.Arg{Value: "-device"}))
+	})
+})
@@ -0,0 +1,96 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	// InterfaceRoleOEMStringPrefix prefixes the SMBIOS OEM strings which publish
+	// the roles of the interfaces to the guest.
+	InterfaceRoleOEMStringPrefix = "io.kubevirt.interface.role:"
+	// InterfaceTagOEMStringPrefix prefixes the SMBIOS OEM strings which publish
+	// the tags of the interfaces to the guest.
+	InterfaceTagOEMStringPrefix = "io.kubevirt.interface.tag:"
+)
+
+// publishInterfaceOEMStrings adds an SMBIOS OEM string per role and tag of
+// every interface, mapping them to the MAC address of the interface. It runs
+// once the bindings decorated the domain, since the MAC address of an
+// interface may only be known from the pod network. The OEM strings of the
+// disks are kept.
+func publishInterfaceOEMStrings(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	domainMACs := map[string]string{}
+	for _, iface := range domain.Spec.Devices.Interfaces {
+		if iface.Alias != nil && iface.MAC != nil {
+			domainMACs[iface.Alias.Name] = iface.MAC.MAC
+		}
+	}
+
+	var oemStrings []string
+	if domain.Spec.SysInfo != nil && domain.Spec.SysInfo.OEMStrings != nil {
+		for _, entry := range domain.Spec.SysInfo.OEMStrings.Entries {
+			if !strings.HasPrefix(entry, InterfaceRoleOEMStringPrefix) && !strings.HasPrefix(entry, InterfaceTagOEMStringPrefix) {
+				oemStrings = append(oemStrings, entry)
+			}
+		}
+	}
+
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if len(iface.Roles) == 0 && iface.Tag == "" {
+			continue
+		}
+		mac := iface.MacAddress
+		if domainMAC := domainMACs[iface.Name]; domainMAC != "" {
+			mac = domainMAC
+		}
+		if mac == "" {
+			log.Log.Object(vmi).Warningf("not publishing the roles and the tag of interface %s, its MAC address is unknown", iface.Name)
+			continue
+		}
+		if hwAddr, err := net.ParseMAC(mac); err == nil {
+			mac = hwAddr.String()
+		}
+		for _, role := range iface.Roles {
+			oemStrings = append(oemStrings, fmt.Sprintf("%s%s=%s", InterfaceRoleOEMStringPrefix, role, mac))
+		}
+		if iface.Tag != "" {
+			oemStrings = append(oemStrings, fmt.Sprintf("%s%s=%s", InterfaceTagOEMStringPrefix, iface.Tag, mac))
+		}
+	}
+
+	if len(oemStrings) == 0 {
+		if domain.Spec.SysInfo != nil {
+			domain.Spec.SysInfo.OEMStrings = nil
+		}
+		return
+	}
+	if domain.Spec.SysInfo == nil {
+		domain.Spec.SysInfo = &api.SysInfo{Type: "smbios"}
+	}
+	domain.Spec.SysInfo.OEMStrings = &api.OEMStrings{Entries: oemStrings}
+}
@@ -0,0 +1,92 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var _ = Describe("Interface OEM strings", func() {
+
+	It("should publish the roles with the MAC address assigned to the domain", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].Roles = []v1.InterfaceRole{v1.InterfaceRoleManagement, v1.InterfaceRoleWorkload}
+		domain := NewDomainWithBridgeInterface()
+		domain.Spec.Devices.Interfaces[0].MAC = &api.MAC{MAC: "DE:AD:00:00:BE:AF"}
+
+		publishInterfaceOEMStrings(vmi, domain)
+		Expect(domain.Spec.SysInfo.OEMStrings.Entries).To(Equal([]string{
+			"io.kubevirt.interface.role:management=de:ad:00:00:be:af",
+			"io.kubevirt.interface.role:workload=de:ad:00:00:be:af",
+		}))
+	})
+
+	It("should fall back to the MAC address of the spec for interfaces missing in the domain", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].InterfaceBindingMethod = v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}
+		vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "de:ad:00:00:be:ef"
+		vmi.Spec.Domain.Devices.Interfaces[0].Roles = []v1.InterfaceRole{v1.InterfaceRoleStorage}
+		domain := &api.Domain{}
+
+		publishInterfaceOEMStrings(vmi, domain)
+		Expect(domain.Spec.SysInfo.Type).To(Equal("smbios"))
+		Expect(domain.Spec.SysInfo.OEMStrings.Entries).To(Equal([]string{"io.kubevirt.interface.role:storage=de:ad:00:00:be:ef"}))
+	})
+
+	It("should skip interfaces with an unknown MAC address", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].Roles = []v1.InterfaceRole{v1.InterfaceRoleStorage}
+		domain := NewDomainWithBridgeInterface()
+
+		publishInterfaceOEMStrings(vmi, domain)
+		Expect(domain.Spec.SysInfo).To(BeNil())
+	})
+
+	It("should publish the tag with the MAC address assigned to the domain", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].Tag = "frontend"
+		domain := NewDomainWithBridgeInterface()
+		domain.Spec.Devices.Interfaces[0].MAC = &api.MAC{MAC: "de:ad:00:00:be:af"}
+
+		publishInterfaceOEMStrings(vmi, domain)
+		Expect(domain.Spec.SysInfo.OEMStrings.Entries).To(Equal([]string{"io.kubevirt.interface.tag:frontend=de:ad:00:00:be:af"}))
+	})
+
+	It("should keep the OEM strings of the disks and replace the ones of the interfaces", func() {
+		vmi := newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.Spec.Domain.Devices.Interfaces[0].Roles = []v1.InterfaceRole{v1.InterfaceRoleWorkload}
+		domain := NewDomainWithBridgeInterface()
+		domain.Spec.Devices.Interfaces[0].MAC = &api.MAC{MAC: "de:ad:00:00:be:af"}
+		domain.Spec.SysInfo = &api.SysInfo{OEMStrings: &api.OEMStrings{Entries: []string{
+			"io.kubevirt.disk.tag:database=DB01",
+			"io.kubevirt.interface.role:workload=de:ad:00:00:be:af",
+		}}}
+
+		publishInterfaceOEMStrings(vmi, domain)
+		Expect(domain.Spec.SysInfo.OEMStrings.Entries).To(Equal([]string{
+			"io.kubevirt.disk.tag:database=DB01",
+			"io.kubevirt.interface.role:workload=de:ad:00:00:be:af",
+		}))
+	})
+})
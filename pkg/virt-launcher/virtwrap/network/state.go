@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"sort"
+	"sync"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// interfaceStateCache keeps track of the phase2 state of every VMI interface
+// in virt-launcher. It replaces marker files on the pod filesystem, which
+// outlive the DHCP servers they describe when virt-launcher restarts.
+type interfaceStateCache struct {
+	lock   sync.Mutex
+	states map[string]*api.NetworkInterfaceState
+}
+
+var interfaceStates = newInterfaceStateCache()
+
+func newInterfaceStateCache() *interfaceStateCache {
+	return &interfaceStateCache{states: map[string]*api.NetworkInterfaceState{}}
+}
+
+// get returns the state of the interface, creating it when missing. The
+// caller must hold the lock.
+func (c *interfaceStateCache) get(ifaceName string, podInterfaceName string) *api.NetworkInterfaceState {
+	state, exists := c.states[ifaceName]
+	if !exists {
+		state = &api.NetworkInterfaceState{Name: ifaceName}
+		c.states[ifaceName] = state
+	}
+	state.PodInterfaceName = podInterfaceName
+	return state
+}
+
+func (c *interfaceStateCache) isDHCPStarted(ifaceName string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	state, exists := c.states[ifaceName]
+	return exists && state.DHCPStarted
+}
+
+func (c *interfaceStateCache) isPlugged(ifaceName string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	state, exists := c.states[ifaceName]
+	return exists && state.Plugged
+}
+
+func (c *interfaceStateCache) setDHCPStarted(ifaceName string, podInterfaceName string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.get(ifaceName, podInterfaceName).DHCPStarted = true
+}
+
+func (c *interfaceStateCache) setPlugResult(ifaceName string, podInterfaceName string, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	state := c.get(ifaceName, podInterfaceName)
+	state.Plugged = err == nil
+	state.Error = ""
+	if err != nil {
+		state.Error = err.Error()
+	}
+}
+
+func (c *interfaceStateCache) list() []api.NetworkInterfaceState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	states := make([]api.NetworkInterfaceState, 0, len(c.states))
+	for _, state := range c.states {
+		states = append(states, *state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// GetNetworkInterfacesState returns the phase2 state of all interfaces which
+// virt-launcher attempted to plug, sorted by interface name.
+func GetNetworkInterfacesState() []api.NetworkInterfaceState {
+	return interfaceStates.list()
+}
@@ -0,0 +1,163 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/sysctl"
+)
+
+// sysctlSetting is a sysctl a binding relies on. Optional sysctls are
+// skipped if the kernel doesn't provide them in the network namespace of the
+// pod, e.g. the bridge netfilter ones, which older kernels only have in the
+// namespace of the node.
+type sysctlSetting struct {
+	name     string
+	value    int
+	optional bool
+}
+
+// the interfaces of a VMI are plugged in parallel and may share sysctls
+var sysctlLock sync.Mutex
+
+// bindingSysctls returns the sysctls the binding of the interface needs on
+// its bridge
+func bindingSysctls(iface *v1.Interface, bridgeInterfaceName string) []sysctlSetting {
+	if iface.Bridge == nil && iface.Masquerade == nil {
+		return nil
+	}
+	// only answer ARP requests for the addresses of the bridge itself, not
+	// for the other addresses of the pod
+	settings := []sysctlSetting{
+		{name: fmt.Sprintf(sysctl.NetIPv4ArpIgnore, bridgeInterfaceName), value: 1},
+	}
+	if iface.Bridge != nil {
+		// the traffic between the guest and the pod interface is bridged,
+		// the iptables rules of the pod must not apply to it
+		settings = append(settings,
+			sysctlSetting{name: sysctl.NetBridgeNfCallIptables, value: 0, optional: true},
+			sysctlSetting{name: sysctl.NetBridgeNfCallIp6tables, value: 0, optional: true},
+		)
+	}
+	return settings
+}
+
+// configureSysctls applies the sysctls in the network namespace of the pod.
+// The value a sysctl had before it was first changed for the VMI is saved in
+// its network cache, so that RestorePodSysctls can roll the namespace back.
+func configureSysctls(uid types.UID, settings []sysctlSetting) error {
+	sysctlLock.Lock()
+	defer sysctlLock.Unlock()
+
+	saved, err := readSavedSysctls(uid)
+	if err != nil {
+		return err
+	}
+	for _, setting := range settings {
+		value, err := Handler.GetSysctl(setting.name)
+		if err != nil {
+			if setting.optional && os.IsNotExist(err) {
+				log.Log.V(4).Infof("Skipping sysctl %s, it is not available in the network namespace", setting.name)
+				continue
+			}
+			return fmt.Errorf("failed to read sysctl %s: %v", setting.name, err)
+		}
+		if value == setting.value {
+			continue
+		}
+		if _, exists := saved[setting.name]; !exists {
+			saved[setting.name] = value
+			// save before changing, a failed plug is retried with the value
+			// it left behind
+			if err := writeSavedSysctls(uid, saved); err != nil {
+				return err
+			}
+		}
+		if err := Handler.SetSysctl(setting.name, setting.value); err != nil {
+			return fmt.Errorf("failed to set sysctl %s to %d: %v", setting.name, setting.value, err)
+		}
+	}
+	return nil
+}
+
+// RestorePodSysctls sets the sysctls changed by the plugs of the interfaces of
+// the VMI back to the values they had before. doNetNS has to execute the
+// passed function in the network namespace of the virt-launcher pod.
+func RestorePodSysctls(vmi *v1.VirtualMachineInstance, doNetNS func(func() error) error) error {
+	sysctlLock.Lock()
+	defer sysctlLock.Unlock()
+	initHandler()
+
+	saved, err := readSavedSysctls(vmi.UID)
+	if err != nil || len(saved) == 0 {
+		return err
+	}
+	err = doNetNS(func() error {
+		for name, value := range saved {
+			if err := Handler.SetSysctl(name, value); err != nil {
+				return fmt.Errorf("failed to restore sysctl %s to %d: %v", name, value, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return os.Remove(fmt.Sprintf(util.VMISysctlsPath, vmi.UID))
+}
+
+func readSavedSysctls(uid types.UID) (map[string]int, error) {
+	saved := map[string]int{}
+	buf, err := ioutil.ReadFile(fmt.Sprintf(util.VMISysctlsPath, uid))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return saved, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(buf, &saved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the saved sysctls: %v", err)
+	}
+	return saved, nil
+}
+
+func writeSavedSysctls(uid types.UID, saved map[string]int) error {
+	buf, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(fmt.Sprintf(util.VMIInterfaceDir, uid), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(fmt.Sprintf(util.VMISysctlsPath, uid), buf, 0644); err != nil {
+		return fmt.Errorf("failed to save the sysctls: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/sysctl"
+)
+
+var _ = Describe("Sysctls", func() {
+	const uid = types.UID("test-sysctls")
+	const arpIgnore = "net/ipv4/conf/k6t-eth0/arp_ignore"
+	var mockNetwork *MockNetworkHandler
+	var vmi *v1.VirtualMachineInstance
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockNetwork = NewMockNetworkHandler(ctrl)
+		Handler = mockNetwork
+
+		vmi = newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.UID = uid
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(fmt.Sprintf(util.VMIInterfaceDir, uid))
+	})
+
+	It("should configure the bridge binding and skip the sysctls the namespace does not have", func() {
+		mockNetwork.EXPECT().GetSysctl(arpIgnore).Return(0, nil)
+		mockNetwork.EXPECT().SetSysctl(arpIgnore, 1).Return(nil)
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetBridgeNfCallIptables).Return(-1, os.ErrNotExist)
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetBridgeNfCallIp6tables).Return(-1, os.ErrNotExist)
+
+		Expect(configureSysctls(uid, bindingSysctls(&vmi.Spec.Domain.Devices.Interfaces[0], "k6t-eth0"))).To(Succeed())
+		Expect(readSavedSysctls(uid)).To(Equal(map[string]int{arpIgnore: 0}))
+	})
+
+	It("should only configure arp_ignore for masquerade and nothing for slirp", func() {
+		masquerade := v1.DefaultMasqueradeNetworkInterface()
+		Expect(bindingSysctls(masquerade, "k6t-eth0")).To(Equal([]sysctlSetting{{name: arpIgnore, value: 1}}))
+		Expect(bindingSysctls(v1.DefaultSlirpNetworkInterface(), "k6t-eth0")).To(BeEmpty())
+	})
+
+	It("should fail on missing sysctls which are not optional", func() {
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetIPv6Forwarding).Return(-1, os.ErrNotExist)
+
+		err := configureSysctls(uid, []sysctlSetting{{name: sysctl.NetIPv6Forwarding, value: 1}})
+		Expect(err).To(HaveOccurred())
+		Expect(fmt.Sprintf(util.VMISysctlsPath, uid)).ToNot(BeAnExistingFile())
+	})
+
+	It("should keep the original value when a sysctl is configured again", func() {
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetIPv6Forwarding).Return(0, nil)
+		mockNetwork.EXPECT().SetSysctl(sysctl.NetIPv6Forwarding, 1).Return(nil)
+		Expect(configureSysctls(uid, []sysctlSetting{{name: sysctl.NetIPv6Forwarding, value: 1}})).To(Succeed())
+
+		mockNetwork.EXPECT().GetSysctl(sysctl.NetIPv6Forwarding).Return(1, nil)
+		Expect(configureSysctls(uid, []sysctlSetting{{name: sysctl.NetIPv6Forwarding, value: 1}})).To(Succeed())
+		Expect(readSavedSysctls(uid)).To(Equal(map[string]int{sysctl.NetIPv6Forwarding: 0}))
+	})
+
+	It("should not save sysctls which already have the value", func() {
+		mockNetwork.EXPECT().GetSysctl(arpIgnore).Return(1, nil)
+
+		Expect(configureSysctls(uid, []sysctlSetting{{name: arpIgnore, value: 1}})).To(Succeed())
+		Expect(fmt.Sprintf(util.VMISysctlsPath, uid)).ToNot(BeAnExistingFile())
+	})
+
+	It("should restore the saved sysctls and forget them", func() {
+		Expect(writeSavedSysctls(uid, map[string]int{sysctl.NetIPv6Forwarding: 0, arpIgnore: 0})).To(Succeed())
+		mockNetwork.EXPECT().SetSysctl(sysctl.NetIPv6Forwarding, 0).Return(nil)
+		mockNetwork.EXPECT().SetSysctl(arpIgnore, 0).Return(nil)
+
+		Expect(RestorePodSysctls(vmi, runInCurrentNetNS)).To(Succeed())
+		Expect(fmt.Sprintf(util.VMISysctlsPath, uid)).ToNot(BeAnExistingFile())
+	})
+
+	It("should not enter the network namespace without saved sysctls", func() {
+		Expect(RestorePodSysctls(vmi, func(func() error) error {
+			return fmt.Errorf("should not be called")
+		})).To(Succeed())
+	})
+})
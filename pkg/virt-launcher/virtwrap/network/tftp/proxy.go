@@ -0,0 +1,180 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package tftp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// Port is the well known port of TFTP servers
+	Port = 69
+
+	opcodeRRQ   = 1
+	opcodeError = 5
+
+	errorIllegalOperation = 4
+
+	// the largest block size clients may negotiate is 65464, plus the header
+	maxPacketSize = 65468
+)
+
+// Proxy relays the read requests of a guest to an upstream TFTP server. A TFTP
+// transfer continues on ports which both ends pick for it, which doesn't pass
+// the masquerade nat without a conntrack helper. The proxy therefore terminates
+// every transfer on a port of its own, and relays its packets to the port the
+// upstream server answered from.
+type Proxy struct {
+	listenAddr *net.UDPAddr
+	upstream   string
+	// Timeout ends a transfer when neither end sent a packet for that long
+	Timeout time.Duration
+}
+
+// NewProxy creates a proxy listening on listenAddr, which relays to the TFTP
+// server at upstream, a host or a host:port.
+func NewProxy(listenAddr *net.UDPAddr, upstream string) *Proxy {
+	if _, _, err := net.SplitHostPort(upstream); err != nil {
+		upstream = net.JoinHostPort(upstream, strconv.Itoa(Port))
+	}
+	return &Proxy{
+		listenAddr: listenAddr,
+		upstream:   upstream,
+		Timeout:    10 * time.Second,
+	}
+}
+
+// ListenAndServe relays the transfers until listening fails
+func (p *Proxy) ListenAndServe() error {
+	conn, err := net.ListenUDP("udp4", p.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", p.listenAddr, err)
+	}
+	defer conn.Close()
+	return p.Serve(conn)
+}
+
+// Serve relays the transfers requested on conn until reading from it fails
+func (p *Proxy) Serve(conn *net.UDPConn) error {
+	log.Log.Infof("relaying TFTP requests on %s to %s", conn.LocalAddr(), p.upstream)
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, client, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			return err
+		}
+		if n < 2 {
+			continue
+		}
+		if binary.BigEndian.Uint16(buf) != opcodeRRQ {
+			conn.WriteToUDP(errorPacket(errorIllegalOperation, "only read requests are supported"), client)
+			continue
+		}
+		request := append([]byte(nil), buf[:n]...)
+		go func() {
+			if err := p.relay(conn.LocalAddr().(*net.UDPAddr).IP, client, request); err != nil {
+				log.Log.Reason(err).Warningf("failed to relay the TFTP transfer of %s", client)
+			}
+		}()
+	}
+}
+
+// relay forwards the request of the client to the upstream server, and the
+// packets of the transfer between them until it went quiet
+func (p *Proxy) relay(localIP net.IP, client *net.UDPAddr, request []byte) error {
+	upstreamAddr, err := net.ResolveUDPAddr("udp4", p.upstream)
+	if err != nil {
+		return err
+	}
+	clientConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: localIP})
+	if err != nil {
+		return err
+	}
+	defer clientConn.Close()
+	upstreamConn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return err
+	}
+	defer upstreamConn.Close()
+
+	if _, err := upstreamConn.WriteToUDP(request, upstreamAddr); err != nil {
+		return err
+	}
+
+	// the server answers from the port it picked for the transfer
+	buf := make([]byte, maxPacketSize)
+	var server *net.UDPAddr
+	for server == nil {
+		upstreamConn.SetReadDeadline(time.Now().Add(p.Timeout))
+		n, from, err := upstreamConn.ReadFromUDP(buf)
+		if err != nil {
+			return fmt.Errorf("no answer from %s: %v", upstreamAddr, err)
+		}
+		if !from.IP.Equal(upstreamAddr.IP) {
+			continue
+		}
+		server = from
+		if _, err := clientConn.WriteToUDP(buf[:n], client); err != nil {
+			return err
+		}
+	}
+
+	done := make(chan error, 2)
+	go func() { done <- p.forward(upstreamConn, server, clientConn, client) }()
+	go func() { done <- p.forward(clientConn, client, upstreamConn, server) }()
+	err = <-done
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		// the transfer is over
+		return nil
+	}
+	return err
+}
+
+// forward copies the packets which src receives from peer to dst on dstConn
+func (p *Proxy) forward(srcConn *net.UDPConn, peer *net.UDPAddr, dstConn *net.UDPConn, dst *net.UDPAddr) error {
+	buf := make([]byte, maxPacketSize)
+	for {
+		srcConn.SetReadDeadline(time.Now().Add(p.Timeout))
+		n, from, err := srcConn.ReadFromUDP(buf)
+		if err != nil {
+			return err
+		}
+		if !from.IP.Equal(peer.IP) || from.Port != peer.Port {
+			continue
+		}
+		if _, err := dstConn.WriteToUDP(buf[:n], dst); err != nil {
+			return err
+		}
+	}
+}
+
+func errorPacket(code uint16, message string) []byte {
+	packet := make([]byte, 4, 4+len(message)+1)
+	binary.BigEndian.PutUint16(packet, opcodeError)
+	binary.BigEndian.PutUint16(packet[2:], code)
+	packet = append(packet, message...)
+	return append(packet, 0)
+}
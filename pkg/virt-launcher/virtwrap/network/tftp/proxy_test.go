@@ -0,0 +1,115 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package tftp
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TFTP proxy", func() {
+	localhost := net.ParseIP("127.0.0.1")
+	data := append([]byte{0, 3, 0, 1}, "kernel"...)
+	ack := []byte{0, 4, 0, 1}
+
+	var upstream *net.UDPConn
+	var proxyConn *net.UDPConn
+	var client *net.UDPConn
+	var acks chan []byte
+
+	// serveUpstream answers the first read request from a port of the transfer
+	// with a single block, and passes on the acknowledgement of the client
+	serveUpstream := func() {
+		defer GinkgoRecover()
+		buf := make([]byte, maxPacketSize)
+		_, from, err := upstream.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		transfer, err := net.ListenUDP("udp4", &net.UDPAddr{IP: localhost})
+		Expect(err).ToNot(HaveOccurred())
+		defer transfer.Close()
+		_, err = transfer.WriteToUDP(data, from)
+		Expect(err).ToNot(HaveOccurred())
+		transfer.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := transfer.ReadFromUDP(buf)
+		if err == nil {
+			acks <- append([]byte(nil), buf[:n]...)
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		upstream, err = net.ListenUDP("udp4", &net.UDPAddr{IP: localhost})
+		Expect(err).ToNot(HaveOccurred())
+		proxyConn, err = net.ListenUDP("udp4", &net.UDPAddr{IP: localhost})
+		Expect(err).ToNot(HaveOccurred())
+		client, err = net.ListenUDP("udp4", &net.UDPAddr{IP: localhost})
+		Expect(err).ToNot(HaveOccurred())
+		acks = make(chan []byte, 1)
+
+		proxy := NewProxy(nil, upstream.LocalAddr().String())
+		proxy.Timeout = time.Second
+		go proxy.Serve(proxyConn)
+	})
+
+	AfterEach(func() {
+		upstream.Close()
+		proxyConn.Close()
+		client.Close()
+	})
+
+	It("should relay a transfer between the client and the upstream server", func() {
+		go serveUpstream()
+
+		request := append([]byte{0, 1}, "pxelinux.0\x00octet\x00"...)
+		_, err := client.WriteToUDP(request, proxyConn.LocalAddr().(*net.UDPAddr))
+		Expect(err).ToNot(HaveOccurred())
+
+		buf := make([]byte, maxPacketSize)
+		client.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, from, err := client.ReadFromUDP(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf[:n]).To(Equal(data))
+		Expect(from.Port).ToNot(Equal(proxyConn.LocalAddr().(*net.UDPAddr).Port), "the transfer continues on a port of its own")
+
+		_, err = client.WriteToUDP(ack, from)
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(acks, 5*time.Second).Should(Receive(Equal(ack)))
+	})
+
+	It("should reject write requests", func() {
+		request := append([]byte{0, 2}, "pxelinux.0\x00octet\x00"...)
+		_, err := client.WriteToUDP(request, proxyConn.LocalAddr().(*net.UDPAddr))
+		Expect(err).ToNot(HaveOccurred())
+
+		buf := make([]byte, maxPacketSize)
+		client.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := client.ReadFromUDP(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(binary.BigEndian.Uint16(buf)).To(Equal(uint16(opcodeError)))
+		Expect(binary.BigEndian.Uint16(buf[2:])).To(Equal(uint16(errorIllegalOperation)))
+		Expect(n).To(BeNumerically(">", 4))
+	})
+})
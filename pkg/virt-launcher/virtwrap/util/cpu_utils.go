@@ -25,12 +25,17 @@ import (
 	"os"
 
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/cgroup"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 )
 
 func GetPodCPUSet() ([]int, error) {
 	var cpuset string
-	file, err := os.Open(hardware.CPUSET_PATH)
+	cpusetPath := hardware.CPUSET_PATH
+	if cgroup.IsUnified(cgroup.MountPoint) {
+		cpusetPath = hardware.CPUSET_UNIFIED_PATH
+	}
+	file, err := os.Open(cpusetPath)
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +21,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	hypervisorlog "kubevirt.io/kubevirt/pkg/hypervisor-log"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
@@ -263,7 +264,7 @@ func StartVirtlog(stopChan chan struct{}, domainName string) {
 			}
 
 			go func() {
-				logfile := fmt.Sprintf("/var/log/libvirt/qemu/%s.log", domainName)
+				logfile := hypervisorlog.QemuLogPath(domainName)
 
 				// It can take a few seconds to the log file to be created
 				for {
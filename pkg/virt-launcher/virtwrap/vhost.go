@@ -0,0 +1,159 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var (
+	// qemuPidDir is where libvirt writes the pid file of the qemu process of a domain
+	qemuPidDir = "/var/run/libvirt/qemu"
+	procDir    = "/proc"
+)
+
+// pinVhostThreads pins the vhost-net worker threads of the qemu process of
+// VMIs with dedicated CPUs and low-latency interfaces to the pCPUs of the
+// vCPUs, so that busy polling the tap devices only burns the CPUs of the VMI.
+//
+// Before Linux 6.4 the workers are kernel threads which are not visible in the
+// pod. They are already confined to the cpuset of the pod, so nothing is pinned
+// then. Pinning is best effort, a VMI with unpinned workers still works.
+func pinVhostThreads(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if !vmi.IsCPUDedicated() || !hasLowLatencyInterface(vmi) {
+		return
+	}
+	logger := log.Log.Object(vmi)
+
+	cpus, err := vcpuPinCPUs(domain.Spec.CPUTune)
+	if err != nil {
+		logger.Reason(err).Error("failed to read the pCPUs of the vCPUs, not pinning the vhost-net threads")
+		return
+	}
+	if len(cpus) == 0 {
+		return
+	}
+
+	pid, err := qemuPid(domain.Spec.Name)
+	if err != nil {
+		logger.Reason(err).Error("failed to find the qemu process, not pinning the vhost-net threads")
+		return
+	}
+	tids, err := vhostThreads(pid)
+	if err != nil {
+		logger.Reason(err).Error("failed to find the vhost-net threads")
+		return
+	}
+	if len(tids) == 0 {
+		logger.V(3).Info("no vhost-net threads in the pod, they are confined by its cpuset")
+		return
+	}
+
+	set := unix.CPUSet{}
+	for _, cpu := range cpus {
+		set.Set(cpu)
+	}
+	for _, tid := range tids {
+		if err := unix.SchedSetaffinity(tid, &set); err != nil {
+			logger.Reason(err).Errorf("failed to pin the vhost-net thread %d", tid)
+			continue
+		}
+	}
+	logger.Infof("Pinned the vhost-net threads %v to the pCPUs %v", tids, cpus)
+}
+
+func hasLowLatencyInterface(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.LatencyProfile == v1.InterfaceLatencyProfileLowLatency {
+			return true
+		}
+	}
+	return false
+}
+
+// vcpuPinCPUs returns the sorted union of the pCPUs the vCPUs are pinned to
+func vcpuPinCPUs(cpuTune *api.CPUTune) ([]int, error) {
+	if cpuTune == nil {
+		return nil, nil
+	}
+	union := map[int]bool{}
+	for _, pin := range cpuTune.VCPUPin {
+		cpus, err := hardware.ParseCPUSetLine(pin.CPUSet)
+		if err != nil {
+			return nil, err
+		}
+		for _, cpu := range cpus {
+			union[cpu] = true
+		}
+	}
+	cpus := make([]int, 0, len(union))
+	for cpu := range union {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
+
+func qemuPid(domainName string) (int, error) {
+	content, err := ioutil.ReadFile(filepath.Join(qemuPidDir, domainName+".pid"))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
+
+// vhostThreads returns the ids of the threads of the qemu process which run
+// the vhost-net workers. Since Linux 6.4 they are named vhost-<pid>.
+func vhostThreads(pid int) ([]int, error) {
+	taskDir := filepath.Join(procDir, strconv.Itoa(pid), "task")
+	tasks, err := ioutil.ReadDir(taskDir)
+	if err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("vhost-%d", pid)
+	var tids []int
+	for _, task := range tasks {
+		tid, err := strconv.Atoi(task.Name())
+		if err != nil {
+			continue
+		}
+		comm, err := ioutil.ReadFile(filepath.Join(taskDir, task.Name(), "comm"))
+		if err != nil {
+			// the thread exited in the meantime
+			continue
+		}
+		if strings.TrimSpace(string(comm)) == name {
+			tids = append(tids, tid)
+		}
+	}
+	return tids, nil
+}
@@ -33,6 +33,8 @@ import (
 
 	virtv1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	templatev1 "kubevirt.io/client-go/apis/template/v1alpha1"
+	vmimportv1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
 )
 
 const (
@@ -45,6 +47,8 @@ var (
 	VIRTUALMACHINEINSTANCEPRESET     = "virtualmachineinstancepresets." + virtv1.VirtualMachineInstancePresetGroupVersionKind.Group
 	VIRTUALMACHINEINSTANCEREPLICASET = "virtualmachineinstancereplicasets." + virtv1.VirtualMachineInstanceReplicaSetGroupVersionKind.Group
 	VIRTUALMACHINEINSTANCEMIGRATION  = "virtualmachineinstancemigrations." + virtv1.VirtualMachineInstanceMigrationGroupVersionKind.Group
+	VIRTUALMACHINENOTIFICATIONHOOK   = "virtualmachinenotificationhooks." + virtv1.VirtualMachineNotificationHookGroupVersionKind.Group
+	FENCINGREQUEST                   = "fencingrequests." + virtv1.FencingRequestGroupVersionKind.Group
 	KUBEVIRT                         = "kubevirts." + virtv1.KubeVirtGroupVersionKind.Group
 	VIRTUALMACHINESNAPSHOT           = "virtualmachinesnapshots." + snapshotv1.SchemeGroupVersion.Group
 	VIRTUALMACHINESNAPSHOTCONTENT    = "virtualmachinesnapshotcontents." + snapshotv1.SchemeGroupVersion.Group
@@ -138,6 +142,7 @@ func NewVirtualMachineCrd() (*extv1beta1.CustomResourceDefinition, error) {
 		},
 		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
 			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+			{Name: "Status", Description: "Human Readable Status", Type: "string", JSONPath: ".status.printableStatus"},
 			{Name: "Volume", Description: "Primary Volume", Type: "string", JSONPath: ".spec.volumes[0].name"},
 			{Name: "Created", Type: "boolean", JSONPath: ".status.created", Priority: 1},
 		},
@@ -254,6 +259,69 @@ func NewVirtualMachineInstanceMigrationCrd() (*extv1beta1.CustomResourceDefiniti
 	return crd, nil
 }
 
+func NewVirtualMachineNotificationHookCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINENOTIFICATIONHOOK
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineNotificationHookGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Namespaced",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinenotificationhooks",
+			Singular:   "virtualmachinenotificationhook",
+			Kind:       virtv1.VirtualMachineNotificationHookGroupVersionKind.Kind,
+			ShortNames: []string{"vmnotificationhook", "vmnotificationhooks"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "URL", Type: "string", JSONPath: ".spec.url"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewFencingRequestCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = FENCINGREQUEST
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.FencingRequestGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Namespaced",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "fencingrequests",
+			Singular:   "fencingrequest",
+			Kind:       virtv1.FencingRequestGroupVersionKind.Kind,
+			ShortNames: []string{"fr", "frs"},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "Node", Type: "string", JSONPath: ".spec.nodeName"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+		Subresources: &extv1beta1.CustomResourceSubresources{
+			Status: &extv1beta1.CustomResourceSubresourceStatus{},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
 // Used by manifest generation
 // If you change something here, you probably need to change the CSV manifest too,
 // see /manifests/release/kubevirt.VERSION.csv.yaml.in
@@ -418,6 +486,196 @@ func NewVirtualMachineRestoreCrd() (*extv1beta1.CustomResourceDefinition, error)
 	return crd, nil
 }
 
+func NewVirtualMachineSnapshotScheduleCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachinesnapshotschedules." + snapshotv1.SchemeGroupVersion.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   snapshotv1.SchemeGroupVersion.Group,
+		Version: snapshotv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    snapshotv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinesnapshotschedules",
+			Singular:   "virtualmachinesnapshotschedule",
+			Kind:       "VirtualMachineSnapshotSchedule",
+			ShortNames: []string{"vmsnapshotschedule", "vmsnapshotschedules"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "SourceKind", Type: "string", JSONPath: ".spec.source.kind"},
+			{Name: "SourceName", Type: "string", JSONPath: ".spec.source.name"},
+			{Name: "Schedule", Type: "string", JSONPath: ".spec.schedule"},
+			{Name: "LastSchedule", Type: "date", JSONPath: ".status.lastScheduleTime"},
+			{Name: "NextSchedule", Type: "date", JSONPath: ".status.nextScheduleTime"},
+			{Name: "Error", Type: "string", JSONPath: ".status.error.message"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineOVFImportCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachineovfimports." + vmimportv1.SchemeGroupVersion.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   vmimportv1.SchemeGroupVersion.Group,
+		Version: vmimportv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    vmimportv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineovfimports",
+			Singular:   "virtualmachineovfimport",
+			Kind:       "VirtualMachineOVFImport",
+			ShortNames: []string{"vmovfimport", "vmovfimports"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "VirtualMachine", Type: "string", JSONPath: ".status.virtualMachineName"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Progress", Type: "string", JSONPath: ".status.progress"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineV2VImportCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachinev2vimports." + vmimportv1.SchemeGroupVersion.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   vmimportv1.SchemeGroupVersion.Group,
+		Version: vmimportv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    vmimportv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinev2vimports",
+			Singular:   "virtualmachinev2vimport",
+			Kind:       "VirtualMachineV2VImport",
+			ShortNames: []string{"vmv2vimport", "vmv2vimports"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "VirtualMachine", Type: "string", JSONPath: ".status.virtualMachineName"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Progress", Type: "string", JSONPath: ".status.progress"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineClusterImportCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachineclusterimports." + vmimportv1.SchemeGroupVersion.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   vmimportv1.SchemeGroupVersion.Group,
+		Version: vmimportv1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    vmimportv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineclusterimports",
+			Singular:   "virtualmachineclusterimport",
+			Kind:       "VirtualMachineClusterImport",
+			ShortNames: []string{"vmclusterimport", "vmclusterimports"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "Source", Type: "string", JSONPath: ".spec.source.name"},
+			{Name: "VirtualMachine", Type: "string", JSONPath: ".status.virtualMachineName"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineTemplateCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachinetemplates." + templatev1.SchemeGroupVersion.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:   templatev1.SchemeGroupVersion.Group,
+		Version: templatev1.SchemeGroupVersion.Version,
+		Versions: []extv1beta1.CustomResourceDefinitionVersion{
+			{
+				Name:    templatev1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinetemplates",
+			Singular:   "virtualmachinetemplate",
+			Kind:       "VirtualMachineTemplate",
+			ShortNames: []string{"vmtemplate", "vmtemplates"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "Display Name", Type: "string", JSONPath: ".spec.displayName"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+	}
+
+	if err := patchValidation(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
 func NewServiceMonitorCR(namespace string, monitorNamespace string, insecureSkipVerify bool) *promv1.ServiceMonitor {
 	return &promv1.ServiceMonitor{
 		TypeMeta: metav1.TypeMeta{
@@ -20,6 +20,8 @@ var _ = Describe("CRDs", func() {
 		table.Entry("for VMIPRESET", NewPresetCrd),
 		table.Entry("for VMIRS", NewReplicaSetCrd),
 		table.Entry("for VMIM", NewVirtualMachineInstanceMigrationCrd),
+		table.Entry("for VMNOTIFICATIONHOOK", NewVirtualMachineNotificationHookCrd),
+		table.Entry("for FENCINGREQUEST", NewFencingRequestCrd),
 		table.Entry("for KV", NewKubeVirtCrd),
 		table.Entry("for VMSNAPSHOT", NewVirtualMachineSnapshotCrd),
 		table.Entry("for VMSNAPSHOTCONTENT", NewVirtualMachineSnapshotContentCrd),
@@ -223,6 +223,43 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"fencingrequest": `openAPIV3Schema:
+  description: FencingRequest asks an external fencing agent to power off or isolate a node, whose virt-handler is unresponsive, before the VirtualMachineInstances of the node are restarted on other nodes
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      properties:
+        nodeName:
+          description: NodeName is the name of the node to fence
+          type: string
+      required:
+      - nodeName
+      type: object
+    status:
+      description: FencingRequestStatus is set by the fencing agent which handles the request
+      properties:
+        fencedTimestamp:
+          description: FencedTimestamp is the time the node was fenced at
+          format: date-time
+          type: string
+        message:
+          description: Message is a human readable detail of the phase
+          type: string
+        phase:
+          description: Phase is the state of the fencing of the node. The VMIs of the node are only restarted once it is Fenced.
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"kubevirt": `openAPIV3Schema:
   description: KubeVirt represents the object deploying all KubeVirt resources
@@ -252,6 +289,88 @@ var CRDsValidation map[string]string = map[string]string{
         configuration:
           description: holds kubevirt configurations. same as the virt-configMap
           properties:
+            admissionPolicies:
+              description: AdmissionPolicies reject the creation of VMIs which match them
+              items:
+                description: VMIAdmissionPolicy rejects the creation of VMIs which match all of its rules
+                properties:
+                  message:
+                    description: Message is returned to the user when a VMI is rejected
+                    type: string
+                  name:
+                    description: Name identifies the policy in the messages of rejected VMIs
+                    type: string
+                  namespaces:
+                    description: Namespaces the policy applies to, all namespaces if empty
+                    items:
+                      type: string
+                    type: array
+                  rules:
+                    description: Rules which all have to match a VMI to reject it
+                    items:
+                      description: VMIAdmissionRule matches the values a JSONPath expression selects from a VMI
+                      properties:
+                        operator:
+                          description: Operator is one of In, NotIn, Exists, DoesNotExist, Gt and Lt. In, NotIn, Gt and Lt match if any selected value matches.
+                          type: string
+                        path:
+                          description: Path is a JSONPath expression evaluated against the VMI, e.g. {.spec.domain.cpu.cores}
+                          type: string
+                        values:
+                          description: Values the selected values are compared to, a single quantity like 4 or 8Gi for Gt and Lt
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - operator
+                      - path
+                      type: object
+                    type: array
+                required:
+                - name
+                - rules
+                type: object
+              type: array
+            allowedImportURLs:
+              description: AllowedImportURLs are the URL prefixes VMs may be imported from. OVF descriptors and the files they reference are only fetched from URLs starting with one of them.
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+            console:
+              description: ConsoleConfiguration holds options of the serial console and VNC proxy
+              properties:
+                idleTimeoutSeconds:
+                  description: IdleTimeoutSeconds closes sessions without traffic in either direction for this long, 0 disables the timeout
+                  format: int64
+                  type: integer
+                maxSessions:
+                  description: MaxSessions limits the number of sessions proxied concurrently by every virt-api instance, 0 means unlimited
+                  format: int32
+                  type: integer
+                requireAccessReason:
+                  description: RequireAccessReason rejects sessions to VMIs which do not carry the kubevirt.io/console-access-reason annotation
+                  type: boolean
+              type: object
+            cpuBaselines:
+              description: CPUBaselines are named CPU models computed from the CPU models and features all nodes of a node pool support. VMIs referencing a baseline as CPU model are migratable between the nodes of its pool.
+              items:
+                description: CPUBaseline selects the node pool a CPU baseline is computed for
+                properties:
+                  name:
+                    description: Name of the baseline, which VMIs reference as CPU model. It must not be the name of a CPU model known to libvirt.
+                    type: string
+                  nodeSelector:
+                    additionalProperties:
+                      type: string
+                    description: NodeSelector selects the nodes of the pool by their labels
+                    type: object
+                required:
+                - name
+                - nodeSelector
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
             cpuModel:
               type: string
             cpuRequest:
@@ -265,10 +384,24 @@ var CRDsValidation map[string]string = map[string]string{
               properties:
                 cpuAllocationRatio:
                   type: integer
+                emulationPolicy:
+                  description: EmulationPolicy controls which VMIs may run with software emulation, one of Never, OptIn or Always. Defaults to Always if useEmulation is set and to Never otherwise.
+                  type: string
                 featureGates:
                   items:
                     type: string
                   type: array
+                logVerbosity:
+                  description: LogVerbosity sets log verbosity level of the various
+                    components
+                  properties:
+                    virtController:
+                      type: integer
+                    virtHandler:
+                      type: integer
+                    virtLauncher:
+                      type: integer
+                  type: object
                 memoryOvercommit:
                   type: integer
                 nodeSelectors:
@@ -277,13 +410,95 @@ var CRDsValidation map[string]string = map[string]string{
                   type: object
                 pvcTolerateLessSpaceUpToPercent:
                   type: integer
+                scopedFeatureGates:
+                  description: ScopedFeatureGates enable feature gates for the workloads of the selected namespaces only.
+                  items:
+                    description: ScopedFeatureGate enables a feature gate for the workloads of the namespaces it selects. The webhooks reject workloads using the feature in other namespaces.
+                    properties:
+                      name:
+                        description: Name of the feature gate, e.g. Macvtap
+                        type: string
+                      namespaceSelector:
+                        description: NamespaceSelector selects the namespaces the feature gate is enabled in by their labels
+                        properties:
+                          matchExpressions:
+                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                            items:
+                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                              properties:
+                                key:
+                                  description: key is the label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                  type: string
+                                values:
+                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                          matchLabels:
+                            additionalProperties:
+                              type: string
+                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                            type: object
+                        type: object
+                      namespaces:
+                        description: Namespaces the feature gate is enabled in
+                        items:
+                          type: string
+                        type: array
+                    required:
+                    - name
+                    type: object
+                  type: array
                 useEmulation:
                   type: boolean
               type: object
+            diskTransferImage:
+              description: DiskTransferImage is the image with curl which uploads the disks of VMs imported from peer clusters, it has to be pullable in the peer cluster
+              type: string
+            disruptionBudgetPolicy:
+              description: DisruptionBudgetPolicy controls the PodDisruptionBudgets protecting the VMIs which are live-migrated on evictions, one of Always, DuringMigration or Never. Defaults to Always.
+              type: string
             emulatedMachines:
               items:
                 type: string
               type: array
+            idlePolicy:
+              description: IdlePolicy holds the activity thresholds below which VirtualMachines of the namespaces opted in are idle, and the action taken on idle VirtualMachines
+              properties:
+                action:
+                  description: Action taken on idle VirtualMachines, one of Pause or Hibernate. VirtualMachines without hibernation configured are paused. Defaults to Pause.
+                  type: string
+                cpuThreshold:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: CPUThreshold is the CPU time per second the guest uses on all its vCPUs, e.g. 50m, below which it is idle
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                networkThreshold:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: NetworkThreshold is the number of bytes per second the guest receives and transmits on all its interfaces, below which it is idle
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                sampleIntervalSeconds:
+                  description: SampleIntervalSeconds is the time between two reads of the stats of a running VirtualMachine
+                  format: int64
+                  type: integer
+                windowSeconds:
+                  description: WindowSeconds is how long a VirtualMachine has to stay below all thresholds to be idle
+                  format: int64
+                  type: integer
+              type: object
             imagePullPolicy:
               description: PullPolicy describes a policy for if/when to pull a container image
               type: string
@@ -325,18 +540,92 @@ var CRDsValidation map[string]string = map[string]string{
             network:
               description: NetworkConfiguration holds network options
               properties:
+                bridge:
+                  description: Bridge tunes the bridges which connect bridge and masquerade interfaces to the pod network
+                  properties:
+                    ageingTimeSeconds:
+                      description: AgeingTimeSeconds is how long the bridges keep a learned MAC address in their forwarding database
+                      format: int32
+                      type: integer
+                    multicastQuerier:
+                      description: MulticastQuerier runs an IGMP and MLD querier on the bridges, so that snooping keeps forwarding multicast groups when the pod network has no querier
+                      type: boolean
+                    multicastSnooping:
+                      description: MulticastSnooping enables IGMP and MLD snooping on the bridges
+                      type: boolean
+                    stp:
+                      description: STP enables the spanning tree protocol on the bridges
+                      type: boolean
+                    vlanFiltering:
+                      description: VLANFiltering enables VLAN filtering on the bridges
+                      type: boolean
+                  type: object
                 defaultNetworkInterface:
                   type: string
+                defaultVMIPv6NetworkCIDR:
+                  description: DefaultVMIPv6NetworkCIDR is the vmIPv6NetworkCIDR of pod networks with a masquerade interface which do not set one. Defaults to fd10:0:2::/120
+                  type: string
+                defaultVMNetworkCIDR:
+                  description: DefaultVMNetworkCIDR is the vmNetworkCIDR of pod networks with a masquerade interface which do not set one. It is not applied when a masquerade subnet pool is configured. Defaults to 10.0.2.0/24
+                  type: string
+                masqueradeSubnetPool:
+                  description: MasqueradeSubnetPool is the pool which the internal subnets of masquerade interfaces are allocated from, when the pod network does not set a vmNetworkCIDR
+                  properties:
+                    cidr:
+                      description: CIDR is the IPv4 range of the pool
+                      type: string
+                    prefixLength:
+                      description: PrefixLength is the prefix length of the subnets allocated from the pool, between the prefix length of the pool and 30. Defaults to 24
+                      format: int32
+                      type: integer
+                  required:
+                  - cidr
+                  type: object
                 permitBridgeInterfaceOnPodNetwork:
                   type: boolean
                 permitSlirpInterface:
                   type: boolean
               type: object
+            nodeDensity:
+              description: NodeDensity limits the number of VMIs and the memory overcommitment of every node
+              properties:
+                maxVMIsPerNode:
+                  description: MaxVMIsPerNode is the number of VMIs a node can run at the same time. It is overridden for a node by its kubevirt.io/max-vmis annotation.
+                  format: int64
+                  type: integer
+                memoryOvercommitBudget:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: MemoryOvercommitBudget is the guest memory a node can give to its VMIs beyond the memory requested by their virt-launcher pods. It is overridden for a node by its kubevirt.io/memory-overcommit-budget annotation.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+              type: object
             ovmfPath:
               type: string
             permittedHostDevices:
               description: PermittedHostDevices holds inforamtion about devices allowed for passthrough
               properties:
+                genericHostDevices:
+                  description: GenericHostDevices are device nodes of the hosts which VMIs can request as devices.kubevirt.io/<name> resources, like /dev/sev or /dev/vfio/vfio
+                  items:
+                    description: GenericHostDevice represents a device node of the host which is exposed as a resource
+                    properties:
+                      name:
+                        description: Name of the device, the resource is devices.kubevirt.io/<name>
+                        type: string
+                      path:
+                        description: Path of the device node on the host
+                        type: string
+                      preOpen:
+                        description: PreOpen opens the device node once before the resource is advertised, to load the kernel modules of the device
+                        type: boolean
+                    required:
+                    - name
+                    - path
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 mediatedDevices:
                   items:
                     description: MediatedHostDevice represents a host mediated device allowed for passthrough
@@ -353,6 +642,26 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                   x-kubernetes-list-type: atomic
+                nvmeNamespaces:
+                  description: NvmeNamespaces are NVMe namespaces which VMIs can request as host devices, each namespace is passed through with the NVMe controller it is attached to
+                  items:
+                    description: NvmeNamespaceHostDevice represents NVMe namespaces allowed for passthrough. VFIO passes through PCI functions, a namespace is therefore passed through with the NVMe controller it is attached to, which has to be a virtual function of an SR-IOV capable NVMe drive with this namespace as its only namespace.
+                    properties:
+                      externalResourceProvider:
+                        description: ExternalResourceProvider indicates that the namespaces are advertised by another device plugin
+                        type: boolean
+                      pciVendorSelector:
+                        description: PCIVendorSelector selects the NVMe controllers by their vendor:device ID
+                        type: string
+                      resourceName:
+                        description: ResourceName is the name of the resource the namespaces are advertised as
+                        type: string
+                    required:
+                    - pciVendorSelector
+                    - resourceName
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 pciHostDevices:
                   items:
                     description: PciHostDevice represents a host PCI device allowed for passthrough
@@ -370,6 +679,47 @@ var CRDsValidation map[string]string = map[string]string{
                   type: array
                   x-kubernetes-list-type: atomic
               type: object
+            qosClasses:
+              description: QoSClasses are the tiers of disk and network service VMIs select with their qosClass. Setting them replaces the default gold, silver and bronze classes.
+              items:
+                description: QoSClassConfiguration holds the node level settings a VMI QoS class is mapped to
+                properties:
+                  blkioWeight:
+                    description: BlkioWeight is the relative blkio weight of the virt-launcher pod cgroup, between 10 and 1000
+                    format: int32
+                    type: integer
+                  name:
+                    description: Name of the class, which VMIs select with their qosClass
+                    type: string
+                  networkCeil:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: NetworkCeil is the maximum egress rate of the tap devices in bytes per second, at least the NetworkRate
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  networkPriority:
+                    description: NetworkPriority is the HTB priority of the tap devices, lower values are served first
+                    format: int32
+                    type: integer
+                  networkRate:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: NetworkRate is the guaranteed egress rate of the tap devices in bytes per second
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                required:
+                - blkioWeight
+                - name
+                - networkCeil
+                - networkRate
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            replicationImage:
+              description: ReplicationImage is the image with rsync and kubectl which copies the disks of replicated VMs to the disaster recovery cluster, unless their storage class has a CSI replication class
+              type: string
             selinuxLauncherType:
               type: string
             smbios:
@@ -389,6 +739,23 @@ var CRDsValidation map[string]string = map[string]string{
               items:
                 type: string
               type: array
+            usageAccounting:
+              description: UsageAccountingConfiguration holds options of the per-VM resource usage accounting
+              properties:
+                billingPeriod:
+                  description: BillingPeriod after which the usage of VirtualMachines is reset, one of Daily, Weekly or Monthly. Periods start at midnight UTC, weeks on Monday.
+                  type: string
+                updateIntervalSeconds:
+                  description: UpdateIntervalSeconds is the time between two accumulations of the usage of a running VirtualMachine
+                  format: int64
+                  type: integer
+              type: object
+            v2vConversionImage:
+              description: V2VConversionImage is the image running virt-v2v for the conversion of VMs imported from other hypervisors
+              type: string
+            virtioWinImage:
+              description: VirtioWinImage is the container disk image with the virtio-win drivers, which is attached to VMIs asking for the virtio-win driver disk
+              type: string
           type: object
         customizeComponents:
           properties:
@@ -1214,6 +1581,34 @@ var CRDsValidation map[string]string = map[string]string{
             - type
             type: object
           type: array
+        cpuBaselines:
+          items:
+            description: CPUBaselineStatus reports the CPU model and features computed for a CPU baseline
+            properties:
+              features:
+                description: Features are the CPU features all nodes of the pool have
+                items:
+                  type: string
+                type: array
+                x-kubernetes-list-type: atomic
+              message:
+                description: Message reports why no model could be computed
+                type: string
+              model:
+                description: Model is the newest CPU model all nodes of the pool support
+                type: string
+              name:
+                description: Name of the baseline
+                type: string
+              nodes:
+                description: Nodes is the number of nodes in the pool
+                format: int32
+                type: integer
+            required:
+            - name
+            - nodes
+            type: object
+          type: array
         observedDeploymentConfig:
           type: string
         observedDeploymentID:
@@ -1479,6 +1874,47 @@ var CRDsValidation map[string]string = map[string]string{
             - spec
             type: object
           type: array
+        hibernation:
+          description: Hibernation allows to hibernate the VirtualMachine. The memory of the guest is saved to a PersistentVolumeClaim managed by KubeVirt and restored on the next start.
+          properties:
+            storageClassName:
+              description: StorageClassName of the PersistentVolumeClaim, the default storage class is used if empty
+              type: string
+          type: object
+        powerSchedule:
+          description: PowerSchedule starts and stops the VirtualMachine at the times of cron schedules, e.g. to stop development VMs at night.
+          properties:
+            skipIfInUse:
+              description: SkipIfInUse skips a scheduled stop while the VirtualMachine is in use, that is users are logged in to the guest or the VirtualMachineInstance is migrating
+              type: boolean
+            start:
+              description: Start is the schedule in cron format the VirtualMachine is started at, e.g. "0 8 * * 1-5"
+              type: string
+            stop:
+              description: Stop is the schedule in cron format the VirtualMachine is stopped at, e.g. "0 20 * * *"
+              type: string
+            timeZone:
+              description: TimeZone the schedules are evaluated in, a name of the IANA time zone database like "Europe/Berlin". Defaults to UTC.
+              type: string
+          type: object
+        replication:
+          description: Replication copies the disks of the VirtualMachine asynchronously to a disaster recovery cluster, which keeps a stopped copy of the VirtualMachine to start if this cluster fails.
+          properties:
+            interval:
+              description: Interval between the copies of the volumes replicated with rsync, one hour if not set
+              type: string
+            namespace:
+              description: Namespace of the copy in the disaster recovery cluster, defaults to the namespace key of the pairing Secret
+              type: string
+            pairingSecretRef:
+              description: PairingSecretRef is the name of the Secret granting access to the disaster recovery cluster, with the server and token and the optional ca.crt and namespace keys
+              type: string
+            storageClassName:
+              description: StorageClassName of the PersistentVolumeClaims created in the disaster recovery cluster for the volumes replicated with rsync, the default storage class is used if empty
+              type: string
+          required:
+          - pairingSecretRef
+          type: object
         runStrategy:
           description: Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running
           type: string
@@ -1942,6 +2378,9 @@ var CRDsValidation map[string]string = map[string]string{
                 domain:
                   description: Specification of the desired behavior of the VirtualMachineInstance on the host.
                   properties:
+                    allowEmulation:
+                      description: AllowEmulation overrides whether the VMI may fall back to software emulation on nodes without /dev/kvm, within the emulation policy of the cluster.
+                      type: boolean
                     chassis:
                       description: Chassis specifies the chassis info passed to the domain.
                       properties:
@@ -2052,6 +2491,19 @@ var CRDsValidation map[string]string = map[string]string{
                         model:
                           description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                           type: string
+                        nestedVirtualization:
+                          description: NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.
+                          type: boolean
+                        numa:
+                          description: NUMA allows specifying settings for the guest NUMA topology
+                          properties:
+                            guestMappingPassthrough:
+                              description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes. Requires DedicatedCPUPlacement and Hugepages.
+                              type: object
+                          type: object
+                        sideChannelIsolation:
+                          description: SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and flushes the L1 data cache on every VM entry where L1TF applies.
+                          type: boolean
                         sockets:
                           description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                           format: int32
@@ -2067,6 +2519,9 @@ var CRDsValidation map[string]string = map[string]string{
                         autoattachGraphicsDevice:
                           description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
                           type: boolean
+                        autoattachInputDevice:
+                          description: Whether to attach a tablet when the graphics device is attached and no inputs are specified. The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.
+                          type: boolean
                         autoattachMemBalloon:
                           description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                           type: boolean
@@ -2076,9 +2531,24 @@ var CRDsValidation map[string]string = map[string]string{
                         autoattachSerialConsole:
                           description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                           type: boolean
+                        autoattachVirtioWinDriverDisk:
+                          description: Whether to attach the virtio-win driver disk as a CD-ROM, which holds the virtio drivers Windows needs to install on virtio disks and to use virtio network interfaces. The image of the disk is configured in the KubeVirt CR. Defaults to false.
+                          type: boolean
                         blockMultiQueue:
                           description: Whether or not to enable virtio multi-queue for block devices
                           type: boolean
+                        channels:
+                          description: Channels are additional virtio-serial channels for custom communication between the host and the guest.
+                          items:
+                            properties:
+                              name:
+                                description: Name is the name of the virtio-serial port, like org.example.agent.0. The guest finds the channel at /dev/virtio-ports/<name>.
+                                type: string
+                            required:
+                            - name
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         disableHotplug:
                           description: DisableHotplug disabled the ability to hotplug disks.
                           type: boolean
@@ -2151,7 +2621,10 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Serial provides the ability to specify a serial number for the disk device.
                                 type: string
                               tag:
-                                description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                                description: If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
+                                type: string
+                              wwn:
+                                description: WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.
                                 type: string
                             required:
                             - name
@@ -2210,11 +2683,14 @@ var CRDsValidation map[string]string = map[string]string{
                               bus:
                                 description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
                                 type: string
+                              model:
+                                description: 'Model indicates the model of a virtio input device. Supported values: virtio, virtio-transitional, virtio-non-transitional.'
+                                type: string
                               name:
                                 description: Name is the device name
                                 type: string
                               type:
-                                description: 'Type indicated the type of input device. Supported values: tablet.'
+                                description: 'Type indicated the type of input device. Supported values: tablet, keyboard.'
                                 type: string
                             required:
                             - name
@@ -2229,6 +2705,22 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                 type: integer
                               bridge:
+                                properties:
+                                  guestAddress:
+                                    description: GuestAddress declares the address the guest uses when the pod network does not provide one. The bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.
+                                    properties:
+                                      gateway:
+                                        description: Gateway offered to the guest in the static DHCP lease.
+                                        type: string
+                                      ip:
+                                        description: IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.
+                                        type: string
+                                      serveDHCP:
+                                        description: ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.
+                                        type: boolean
+                                    required:
+                                    - ip
+                                    type: object
                                 type: object
                               dhcpOptions:
                                 description: If specified the network interface will pass additional DHCP options to the VMI
@@ -2236,6 +2728,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   bootFileName:
                                     description: If specified will pass option 67 to interface's DHCP server
                                     type: string
+                                  nextServer:
+                                    description: If specified will set the next server address (siaddr) of the replies of interface's DHCP server, the address of the boot server. Must be an IPv4 address.
+                                    type: string
                                   ntpServers:
                                     description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                                     items:
@@ -2257,16 +2752,41 @@ var CRDsValidation map[string]string = map[string]string{
                                       - value
                                       type: object
                                     type: array
+                                  tftpProxy:
+                                    description: If set, virt-launcher relays the TFTP requests of the guest, which it sends to the gateway of a masquerade interface, to the next server or the TFTP server. The DHCP server passes the gateway as next server and TFTP server then.
+                                    type: boolean
                                   tftpServerName:
                                     description: If specified will pass option 66 to interface's DHCP server
                                     type: string
                                 type: object
+                              latencyProfile:
+                                description: Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls the tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net threads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs. Only supported on virtio bridge and masquerade interfaces.
+                                type: string
                               macAddress:
                                 description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                 type: string
                               macvtap:
                                 type: object
                               masquerade:
+                                properties:
+                                  clampMSS:
+                                    description: ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU, so that they do not stall when the MTU of the pod is lower than the one the guest assumes. Defaults to false.
+                                    type: boolean
+                                  hairpin:
+                                    description: Hairpin selects which connections originating in the pod itself are forwarded to the VM. "loopback" forwards connections to the loopback address on the forwarded ports, "none" leaves all of them to processes in the pod and "full" forwards connections to any local address of the pod, including the VM connecting to its own service IP. Defaults to loopback.
+                                    type: string
+                                type: object
+                              mirror:
+                                description: Copy the traffic of the interface to a monitoring network. Only supported on bridge and masquerade interfaces.
+                                properties:
+                                  direction:
+                                    description: 'Traffic to copy, as seen from the guest: rx, tx or both. Defaults to both.'
+                                    type: string
+                                  network:
+                                    description: Name of the Multus network which receives the copied traffic. It must not be the network of an interface.
+                                    type: string
+                                required:
+                                - network
                                 type: object
                               model:
                                 description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
@@ -2274,6 +2794,25 @@ var CRDsValidation map[string]string = map[string]string{
                               name:
                                 description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                                 type: string
+                              offloads:
+                                description: Offloads of the host side tap device of the interface. Only supported on bridge and masquerade interfaces.
+                                properties:
+                                  gro:
+                                    description: Generic receive offload.
+                                    type: boolean
+                                  gso:
+                                    description: Generic segmentation offload.
+                                    type: boolean
+                                  tso:
+                                    description: TCP segmentation offload.
+                                    type: boolean
+                                  txChecksum:
+                                    description: TX checksum offload of the pod side bridge serving the interface. When not set, it is only disabled if the DHCP server of KubeVirt answers the guest on the bridge, since some guest DHCP clients drop its replies otherwise.
+                                    type: boolean
+                                type: object
+                              packedRing:
+                                description: Use packed virtqueues instead of split virtqueues for the interface, which reduces the latency of the guest network. Only supported on virtio interfaces.
+                                type: boolean
                               pciAddress:
                                 description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                                 type: string
@@ -2296,13 +2835,36 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
+                              roles:
+                                description: 'Roles the interface fulfills in the guest: management, storage or workload. They are published to the guest as SMBIOS OEM strings of the form io.kubevirt.interface.role:<role>=<MAC address>.'
+                                items:
+                                  type: string
+                                type: array
+                              rss:
+                                description: Receive side scaling of the interface. The device steers the received packets to the queues by their hash, as configured by the guest, which spreads the load of the guest network over the vCPUs. Only supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.
+                                properties:
+                                  hashReport:
+                                    description: Report the hash of the received packets to the guest, which saves the guest from calculating it again, e.g. for receive flow steering.
+                                    type: boolean
+                                type: object
+                              rxQueueSize:
+                                description: Size of the virtio RX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                                format: int32
+                                type: integer
                               slirp:
                                 type: object
                               sriov:
                                 type: object
                               tag:
-                                description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+                                description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive. The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>
                                 type: string
+                              txQueueSize:
+                                description: Size of the virtio TX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                                format: int32
+                                type: integer
+                              vhostZeroCopyTX:
+                                description: Request zero copy transmission by vhost-net for the interface. The VMI is only scheduled on nodes where the vhost_net kernel module has zero copy transmission enabled. Only supported on virtio interfaces which are not bound with slirp.
+                                type: boolean
                             required:
                             - name
                             type: object
@@ -2310,9 +2872,16 @@ var CRDsValidation map[string]string = map[string]string{
                         networkInterfaceMultiqueue:
                           description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                           type: boolean
+                        networkInterfaceQueues:
+                          description: Number of queues of the virtio interfaces with networkInterfaceMultiqueue. Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.
+                          format: int32
+                          type: integer
                         rng:
                           description: Whether to have random number generator from host
                           type: object
+                        usbControllerModel:
+                          description: 'Model of the USB controller, which is attached when an input device is on the usb bus. Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.'
+                          type: string
                         watchdog:
                           description: Watchdog describes a watchdog device which can be added to the vmi.
                           properties:
@@ -2477,6 +3046,17 @@ var CRDsValidation map[string]string = map[string]string{
                     firmware:
                       description: Firmware.
                       properties:
+                        bootMenu:
+                          description: Settings to control the interactive boot menu of the firmware.
+                          properties:
+                            enabled:
+                              description: If set, the firmware offers a boot menu. Defaults to true
+                              type: boolean
+                            timeout:
+                              description: Time in milliseconds the boot menu waits for a selection before booting the default entry. Must be at most 65535.
+                              format: int32
+                              type: integer
+                          type: object
                         bootloader:
                           description: Settings to control the bootloader that is used.
                           properties:
@@ -2495,6 +3075,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   type: boolean
                               type: object
                           type: object
+                        identityPolicy:
+                          description: IdentityPolicy controls how the UUID and the serial are generated when they are not set. Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores. RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that clones get new ones, while restores keep them. Defaults to Keep.
+                          type: string
                         serial:
                           description: The system-serial-number in SMBIOS
                           type: string
@@ -2563,6 +3146,9 @@ var CRDsValidation map[string]string = map[string]string{
                 evictionStrategy:
                   description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.
                   type: string
+                guestOS:
+                  description: 'GuestOS declares the operating system of the guest, e.g. "windows2k19" or "rhel8". The disk bus, the interface model, the clock and the inputs which are neither set on the vmi nor by a preset are defaulted to what suits the guest OS.'
+                  type: string
                 hostname:
                   description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                   type: string
@@ -2642,6 +3228,9 @@ var CRDsValidation map[string]string = map[string]string{
                       format: int32
                       type: integer
                   type: object
+                metadataService:
+                  description: MetadataService serves the metadata and the user-data of the vmi and the token of its service account to the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.
+                  type: object
                 networks:
                   description: List of networks that can be attached to a vm's virtual interface.
                   items:
@@ -2654,7 +3243,7 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Select the default network and add it to the multus-cni.io/default-network annotation.
                             type: boolean
                           networkName:
-                            description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                            description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.'
                             type: string
                         required:
                         - networkName
@@ -2665,6 +3254,9 @@ var CRDsValidation map[string]string = map[string]string{
                       pod:
                         description: Represents the stock pod network interface.
                         properties:
+                          vmIPv6NetworkCIDR:
+                            description: IPv6 CIDR for the vm network of masquerade interfaces. Default fd10:0:2::/120 if not specified.
+                            type: string
                           vmNetworkCIDR:
                             description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
                             type: string
@@ -2681,6 +3273,9 @@ var CRDsValidation map[string]string = map[string]string{
                 priorityClassName:
                   description: If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.
                   type: string
+                qosClass:
+                  description: QoSClass selects the tier of disk and network service the vmi gets on the node. Valid values are the QoS classes of the KubeVirt configuration, "gold", "silver" and "bronze" by default. No QoS is applied if not set. Unrelated to the pod QoS class reported in the status.
+                  type: string
                 readinessProbe:
                   description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
                   properties:
@@ -2871,6 +3466,22 @@ var CRDsValidation map[string]string = map[string]string{
                       containerDisk:
                         description: 'ContainerDisk references a docker image, embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                         properties:
+                          checksum:
+                            description: Checksum is the expected digest of the disk in the image, in the form sha256:<hex>. The disk is verified before the VMI is started, which fails on a mismatch.
+                            type: string
+                          ephemeralImage:
+                            description: EphemeralImage configures the writable image the VMI runs on.
+                            properties:
+                              compressed:
+                                description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                                type: boolean
+                              format:
+                                description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                                type: string
+                              preallocation:
+                                description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                                type: string
+                            type: object
                           image:
                             description: Image is the name of the image with the embedded disk.
                             type: string
@@ -2889,6 +3500,9 @@ var CRDsValidation map[string]string = map[string]string{
                       dataVolume:
                         description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
                         properties:
+                          checksum:
+                            description: Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.
+                            type: string
                           name:
                             description: Name represents the name of the DataVolume in the same namespace
                             type: string
@@ -2962,9 +3576,35 @@ var CRDsValidation map[string]string = map[string]string{
                         required:
                         - capacity
                         type: object
+                      encryption:
+                        description: Encryption layers LUKS encryption on top of the volume, which is handled by qemu. Only PersistentVolumeClaim and DataVolume volumes can be encrypted.
+                        properties:
+                          secretRef:
+                            description: SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the "key" key. Keys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                type: string
+                            type: object
+                        required:
+                        - secretRef
+                        type: object
                       ephemeral:
                         description: Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
                         properties:
+                          ephemeralImage:
+                            description: EphemeralImage configures the writable image the VMI runs on.
+                            properties:
+                              compressed:
+                                description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                                type: boolean
+                              format:
+                                description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                                type: string
+                              preallocation:
+                                description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                                type: string
+                            type: object
                           persistentVolumeClaim:
                             description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                             properties:
@@ -3036,6 +3676,19 @@ var CRDsValidation map[string]string = map[string]string{
                             description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                             type: string
                         type: object
+                      vhostUserBlk:
+                        description: VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.
+                        properties:
+                          claimName:
+                            description: ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume contains the socket, like the volumes of an SPDK CSI driver
+                            type: string
+                          socket:
+                            description: Socket is the path of the vhost-user-blk socket in the volume
+                            type: string
+                        required:
+                        - claimName
+                        - socket
+                        type: object
                     required:
                     - name
                     type: object
@@ -3079,9 +3732,133 @@ var CRDsValidation map[string]string = map[string]string{
         created:
           description: Created indicates if the virtual machine is created in the cluster
           type: boolean
+        fsFreezeStatus:
+          description: FSFreezeStatus is "frozen" while the guest file systems of the VirtualMachineInstance are frozen, and empty otherwise
+          type: string
+        hibernation:
+          description: Hibernation reports the PersistentVolumeClaim holding the memory of the guest and whether the VirtualMachine is hibernated. It is only set if hibernation is configured.
+          properties:
+            claimName:
+              description: ClaimName is the name of the PersistentVolumeClaim holding the memory of the guest
+              type: string
+            hibernated:
+              description: Hibernated indicates that the memory of the guest is saved and will be restored on the next start
+              type: boolean
+            hibernationTime:
+              description: HibernationTime is the time the memory of the guest was saved
+              format: date-time
+              nullable: true
+              type: string
+            size:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Size is the capacity of the PersistentVolumeClaim, or its requested size while it is not bound
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+          required:
+          - claimName
+          type: object
+        idleStatus:
+          description: IdleStatus reports the activity of the VirtualMachine and whether it was suspended for being idle. It is only set for running VirtualMachines the idle policy applies to.
+          properties:
+            action:
+              description: Action is the action taken on the idle VirtualMachine, empty until it is taken
+              type: string
+            idle:
+              description: Idle is true if the VirtualMachine had no activity for the window of the idle policy
+              type: boolean
+            lastActivityTime:
+              description: LastActivityTime is the last time the guest was above a threshold of the idle policy, or its console was in use
+              format: date-time
+              nullable: true
+              type: string
+          type: object
+        lastSnapshotTime:
+          description: LastSnapshotTime is the creation time of the last VirtualMachineSnapshot of the VM which became ready to use
+          format: date-time
+          nullable: true
+          type: string
+        powerScheduleStatus:
+          description: PowerScheduleStatus reports the last and next runs of the power schedule. It is only set if a power schedule is configured.
+          properties:
+            lastStartTime:
+              description: LastStartTime is the time of the last scheduled start
+              format: date-time
+              nullable: true
+              type: string
+            lastStopTime:
+              description: LastStopTime is the time of the last scheduled stop
+              format: date-time
+              nullable: true
+              type: string
+            message:
+              description: Message reports an invalid schedule or a skipped stop
+              type: string
+            nextStartTime:
+              description: NextStartTime is the time of the next scheduled start
+              format: date-time
+              nullable: true
+              type: string
+            nextStopTime:
+              description: NextStopTime is the time of the next scheduled stop
+              format: date-time
+              nullable: true
+              type: string
+          type: object
+        printableStatus:
+          description: PrintableStatus is a human readable, high-level summary of the state of the virtual machine, including the reason why it is not running if its virtual machine instance can't be started
+          type: string
         ready:
           description: Ready indicates if the virtual machine is running and ready
           type: boolean
+        replicationStatus:
+          description: ReplicationStatus reports the replication of the disks to the disaster recovery cluster. It is only set if replication is configured.
+          properties:
+            lastSyncTime:
+              description: LastSyncTime is the time all volumes were last replicated, the state the copy in the disaster recovery cluster is in
+              format: date-time
+              nullable: true
+              type: string
+            message:
+              description: Message explains the phase
+              type: string
+            phase:
+              description: Phase of the replication, the least advanced phase of the volumes
+              type: string
+            volumes:
+              description: Volumes reports the replication of the volumes backed by PersistentVolumeClaims
+              items:
+                description: VolumeReplicationStatus reports the replication of a volume of a VirtualMachine.
+                properties:
+                  claimName:
+                    description: ClaimName is the name of the PersistentVolumeClaim, the copy in the disaster recovery cluster has the same name
+                    type: string
+                  lastSyncTime:
+                    description: LastSyncTime is the time the volume was last replicated
+                    format: date-time
+                    nullable: true
+                    type: string
+                  message:
+                    description: Message explains the phase
+                    type: string
+                  method:
+                    description: Method the volume is replicated with
+                    type: string
+                  name:
+                    description: Name of the volume
+                    type: string
+                  phase:
+                    description: Phase of the replication of the volume
+                    type: string
+                required:
+                - claimName
+                - method
+                - name
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+          type: object
         snapshotInProgress:
           description: SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing
           type: string
@@ -3104,6 +3881,135 @@ var CRDsValidation map[string]string = map[string]string{
             - action
             type: object
           type: array
+        usage:
+          description: Usage accumulates the resources consumed by the VirtualMachine in the current and the previous billing period, for chargeback. It is only maintained when usage accounting is enabled.
+          properties:
+            current:
+              description: Current is the usage accumulated in the running billing period
+              properties:
+                end:
+                  description: End is the end of the billing period
+                  format: date-time
+                  nullable: true
+                  type: string
+                memoryMebibyteSeconds:
+                  description: MemoryMebibyteSeconds is the guest memory in MiB of the VirtualMachineInstance, summed up over every second it was running
+                  format: int64
+                  type: integer
+                networkReceiveBytes:
+                  description: NetworkReceiveBytes is the number of bytes received by the VirtualMachineInstance
+                  format: int64
+                  type: integer
+                networkTransmitBytes:
+                  description: NetworkTransmitBytes is the number of bytes transmitted by the VirtualMachineInstance
+                  format: int64
+                  type: integer
+                start:
+                  description: Start is the beginning of the billing period
+                  format: date-time
+                  nullable: true
+                  type: string
+                storageBytes:
+                  description: StorageBytes is the capacity of the PersistentVolumeClaims and DataVolumes of the VirtualMachine at the last accumulation
+                  format: int64
+                  type: integer
+                vcpuSeconds:
+                  description: VCPUSeconds is the number of vCPUs allocated to the VirtualMachineInstance, summed up over every second it was running
+                  format: int64
+                  type: integer
+              required:
+              - end
+              - memoryMebibyteSeconds
+              - networkReceiveBytes
+              - networkTransmitBytes
+              - start
+              - storageBytes
+              - vcpuSeconds
+              type: object
+            instance:
+              description: Instance is the state of the VirtualMachineInstance at the last accumulation. It is meant to be used by KubeVirt core components only.
+              properties:
+                memoryMebibytes:
+                  description: MemoryMebibytes is the guest memory of the VirtualMachineInstance in MiB
+                  format: int64
+                  type: integer
+                networkReceiveBytes:
+                  description: NetworkReceiveBytes is the receive counter reported for the VirtualMachineInstance
+                  format: int64
+                  type: integer
+                networkTransmitBytes:
+                  description: NetworkTransmitBytes is the transmit counter reported for the VirtualMachineInstance
+                  format: int64
+                  type: integer
+                running:
+                  description: Running indicates if the VirtualMachineInstance was running
+                  type: boolean
+                uid:
+                  description: UID of the VirtualMachineInstance
+                  type: string
+                vcpus:
+                  description: VCPUs is the number of vCPUs allocated to the VirtualMachineInstance
+                  format: int64
+                  type: integer
+              required:
+              - memoryMebibytes
+              - networkReceiveBytes
+              - networkTransmitBytes
+              - running
+              - uid
+              - vcpus
+              type: object
+            lastUpdateTime:
+              description: LastUpdateTime is the time the usage was last accumulated
+              format: date-time
+              nullable: true
+              type: string
+            previous:
+              description: Previous is the usage of the last completed billing period
+              properties:
+                end:
+                  description: End is the end of the billing period
+                  format: date-time
+                  nullable: true
+                  type: string
+                memoryMebibyteSeconds:
+                  description: MemoryMebibyteSeconds is the guest memory in MiB of the VirtualMachineInstance, summed up over every second it was running
+                  format: int64
+                  type: integer
+                networkReceiveBytes:
+                  description: NetworkReceiveBytes is the number of bytes received by the VirtualMachineInstance
+                  format: int64
+                  type: integer
+                networkTransmitBytes:
+                  description: NetworkTransmitBytes is the number of bytes transmitted by the VirtualMachineInstance
+                  format: int64
+                  type: integer
+                start:
+                  description: Start is the beginning of the billing period
+                  format: date-time
+                  nullable: true
+                  type: string
+                storageBytes:
+                  description: StorageBytes is the capacity of the PersistentVolumeClaims and DataVolumes of the VirtualMachine at the last accumulation
+                  format: int64
+                  type: integer
+                vcpuSeconds:
+                  description: VCPUSeconds is the number of vCPUs allocated to the VirtualMachineInstance, summed up over every second it was running
+                  format: int64
+                  type: integer
+              required:
+              - end
+              - memoryMebibyteSeconds
+              - networkReceiveBytes
+              - networkTransmitBytes
+              - start
+              - storageBytes
+              - vcpuSeconds
+              type: object
+          required:
+          - current
+          - lastUpdateTime
+          type: object
         volumeRequests:
           description: VolumeRequests indicates a list of volumes add or remove from the VMI template and hotplug on an active running VMI.
           items:
@@ -3179,7 +4085,10 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Serial provides the ability to specify a serial number for the disk device.
                         type: string
                       tag:
-                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
+                        type: string
+                      wwn:
+                        description: WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.
                         type: string
                     required:
                     - name
@@ -3193,6 +4102,9 @@ var CRDsValidation map[string]string = map[string]string{
                       dataVolume:
                         description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
                         properties:
+                          checksum:
+                            description: Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.
+                            type: string
                           name:
                             description: Name represents the name of the DataVolume in the same namespace
                             type: string
@@ -3252,8 +4164,8 @@ var CRDsValidation map[string]string = map[string]string{
   - spec
   type: object
 `,
-	"virtualmachineinstance": `openAPIV3Schema:
-  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
+	"virtualmachineclusterimport": `openAPIV3Schema:
+  description: VirtualMachineClusterImport defines the migration of a stopped VM from a peer cluster running KubeVirt, its spec is copied and its disks are uploaded to DataVolumes of this cluster
   properties:
     apiVersion:
       description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
@@ -3264,33 +4176,123 @@ var CRDsValidation map[string]string = map[string]string{
     metadata:
       type: object
     spec:
-      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
+      description: VirtualMachineClusterImportSpec is the spec for a VirtualMachineClusterImport resource
       properties:
-        accessCredentials:
-          description: Specifies a set of public keys to inject into the vm guest
+        running:
+          description: Running of the created VirtualMachine, defaults to false unless the import stopped the source VM
+          type: boolean
+        source:
+          description: ClusterImportSource is the VM in the peer cluster
+          properties:
+            name:
+              description: Name of the VM in the peer cluster
+              type: string
+            namespace:
+              description: Namespace of the VM in the peer cluster, defaults to the namespace key of the pairing Secret
+              type: string
+            pairingSecretRef:
+              description: PairingSecretRef names a Secret with the server, token and ca.crt used to access the peer cluster
+              type: string
+          required:
+          - name
+          - pairingSecretRef
+          type: object
+        stopSource:
+          description: 'StopSource stops the source VM if it is running, otherwise the import waits until it is stopped. The VM is moved: the created VM is started once the disks were transferred, unless running is set'
+          type: boolean
+        storageClassName:
+          description: StorageClassName of the DataVolumes the disks are uploaded to
+          type: string
+        uploadProxyCertConfigMap:
+          description: UploadProxyCertConfigMap names a ConfigMap with the ca.pem of the upload proxy, the system CAs of the transfer pods are used otherwise
+          type: string
+        uploadProxyURL:
+          description: UploadProxyURL of CDI in this cluster, which has to be reachable from the peer cluster. Defaults to the URL in the CDIConfig
+          type: string
+        virtualMachineName:
+          description: Name of the created VirtualMachine, defaults to the name of the source VM
+          type: string
+      required:
+      - source
+      type: object
+    status:
+      description: VirtualMachineClusterImportStatus is the status for a VirtualMachineClusterImport resource
+      properties:
+        disks:
           items:
-            description: AccessCredential represents a credential source that can be used to authorize remote access to the vm guest Only one of its members may be specified.
+            description: TransferredDisk is a volume of the source VM uploaded from the peer cluster
             properties:
-              sshPublicKey:
-                description: SSHPublicKey represents the source and method of applying a ssh public key into a guest virtual machine.
-                properties:
-                  propagationMethod:
-                    description: PropagationMethod represents how the public key is injected into the vm guest.
-                    properties:
-                      configDrive:
-                        description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
-                        type: object
-                      qemuGuestAgent:
-                        description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
-                        properties:
-                          users:
-                            description: Users represents a list of guest users that should have the ssh public keys added to their authorized_keys file.
-                            items:
-                              type: string
-                            type: array
-                            x-kubernetes-list-type: set
-                        required:
-                        - users
+              dataVolumeName:
+                description: DataVolumeName of the DataVolume the disk is uploaded to
+                type: string
+              phase:
+                type: string
+              sourceClaimName:
+                description: SourceClaimName of the PersistentVolumeClaim in the peer cluster
+                type: string
+              volumeName:
+                description: VolumeName in the spec of the VM
+                type: string
+            required:
+            - dataVolumeName
+            - sourceClaimName
+            - volumeName
+            type: object
+          type: array
+        message:
+          type: string
+        phase:
+          description: VirtualMachineClusterImportPhase is the phase of a VirtualMachineClusterImport
+          type: string
+        sourceStopped:
+          description: SourceStopped tells that the import stopped the running source VM
+          type: boolean
+        virtualMachineName:
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineinstance": `openAPIV3Schema:
+  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
+      properties:
+        accessCredentials:
+          description: Specifies a set of public keys to inject into the vm guest
+          items:
+            description: AccessCredential represents a credential source that can be used to authorize remote access to the vm guest Only one of its members may be specified.
+            properties:
+              sshPublicKey:
+                description: SSHPublicKey represents the source and method of applying a ssh public key into a guest virtual machine.
+                properties:
+                  propagationMethod:
+                    description: PropagationMethod represents how the public key is injected into the vm guest.
+                    properties:
+                      configDrive:
+                        description: ConfigDrivePropagation means that the ssh public keys are injected into the VM using metadata using the configDrive cloud-init provider
+                        type: object
+                      qemuGuestAgent:
+                        description: QemuGuestAgentAccessCredentailPropagation means ssh public keys are dynamically injected into the vm at runtime via the qemu guest agent. This feature requires the qemu guest agent to be running within the guest.
+                        properties:
+                          users:
+                            description: Users represents a list of guest users that should have the ssh public keys added to their authorized_keys file.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: set
+                        required:
+                        - users
                         type: object
                     type: object
                   source:
@@ -3713,6 +4715,9 @@ var CRDsValidation map[string]string = map[string]string{
         domain:
           description: Specification of the desired behavior of the VirtualMachineInstance on the host.
           properties:
+            allowEmulation:
+              description: AllowEmulation overrides whether the VMI may fall back to software emulation on nodes without /dev/kvm, within the emulation policy of the cluster.
+              type: boolean
             chassis:
               description: Chassis specifies the chassis info passed to the domain.
               properties:
@@ -3823,6 +4828,19 @@ var CRDsValidation map[string]string = map[string]string{
                 model:
                   description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                   type: string
+                nestedVirtualization:
+                  description: NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.
+                  type: boolean
+                numa:
+                  description: NUMA allows specifying settings for the guest NUMA topology
+                  properties:
+                    guestMappingPassthrough:
+                      description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes. Requires DedicatedCPUPlacement and Hugepages.
+                      type: object
+                  type: object
+                sideChannelIsolation:
+                  description: SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and flushes the L1 data cache on every VM entry where L1TF applies.
+                  type: boolean
                 sockets:
                   description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                   format: int32
@@ -3838,6 +4856,9 @@ var CRDsValidation map[string]string = map[string]string{
                 autoattachGraphicsDevice:
                   description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
                   type: boolean
+                autoattachInputDevice:
+                  description: Whether to attach a tablet when the graphics device is attached and no inputs are specified. The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.
+                  type: boolean
                 autoattachMemBalloon:
                   description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                   type: boolean
@@ -3847,9 +4868,24 @@ var CRDsValidation map[string]string = map[string]string{
                 autoattachSerialConsole:
                   description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                   type: boolean
+                autoattachVirtioWinDriverDisk:
+                  description: Whether to attach the virtio-win driver disk as a CD-ROM, which holds the virtio drivers Windows needs to install on virtio disks and to use virtio network interfaces. The image of the disk is configured in the KubeVirt CR. Defaults to false.
+                  type: boolean
                 blockMultiQueue:
                   description: Whether or not to enable virtio multi-queue for block devices
                   type: boolean
+                channels:
+                  description: Channels are additional virtio-serial channels for custom communication between the host and the guest.
+                  items:
+                    properties:
+                      name:
+                        description: Name is the name of the virtio-serial port, like org.example.agent.0. The guest finds the channel at /dev/virtio-ports/<name>.
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 disableHotplug:
                   description: DisableHotplug disabled the ability to hotplug disks.
                   type: boolean
@@ -3922,7 +4958,10 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Serial provides the ability to specify a serial number for the disk device.
                         type: string
                       tag:
-                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
+                        type: string
+                      wwn:
+                        description: WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.
                         type: string
                     required:
                     - name
@@ -3981,11 +5020,14 @@ var CRDsValidation map[string]string = map[string]string{
                       bus:
                         description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
                         type: string
+                      model:
+                        description: 'Model indicates the model of a virtio input device. Supported values: virtio, virtio-transitional, virtio-non-transitional.'
+                        type: string
                       name:
                         description: Name is the device name
                         type: string
                       type:
-                        description: 'Type indicated the type of input device. Supported values: tablet.'
+                        description: 'Type indicated the type of input device. Supported values: tablet, keyboard.'
                         type: string
                     required:
                     - name
@@ -4000,6 +5042,22 @@ var CRDsValidation map[string]string = map[string]string{
                         description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                         type: integer
                       bridge:
+                        properties:
+                          guestAddress:
+                            description: GuestAddress declares the address the guest uses when the pod network does not provide one. The bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.
+                            properties:
+                              gateway:
+                                description: Gateway offered to the guest in the static DHCP lease.
+                                type: string
+                              ip:
+                                description: IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.
+                                type: string
+                              serveDHCP:
+                                description: ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.
+                                type: boolean
+                            required:
+                            - ip
+                            type: object
                         type: object
                       dhcpOptions:
                         description: If specified the network interface will pass additional DHCP options to the VMI
@@ -4007,6 +5065,9 @@ var CRDsValidation map[string]string = map[string]string{
                           bootFileName:
                             description: If specified will pass option 67 to interface's DHCP server
                             type: string
+                          nextServer:
+                            description: If specified will set the next server address (siaddr) of the replies of interface's DHCP server, the address of the boot server. Must be an IPv4 address.
+                            type: string
                           ntpServers:
                             description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                             items:
@@ -4028,16 +5089,41 @@ var CRDsValidation map[string]string = map[string]string{
                               - value
                               type: object
                             type: array
+                          tftpProxy:
+                            description: If set, virt-launcher relays the TFTP requests of the guest, which it sends to the gateway of a masquerade interface, to the next server or the TFTP server. The DHCP server passes the gateway as next server and TFTP server then.
+                            type: boolean
                           tftpServerName:
                             description: If specified will pass option 66 to interface's DHCP server
                             type: string
                         type: object
+                      latencyProfile:
+                        description: Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls the tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net threads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs. Only supported on virtio bridge and masquerade interfaces.
+                        type: string
                       macAddress:
                         description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                         type: string
                       macvtap:
                         type: object
                       masquerade:
+                        properties:
+                          clampMSS:
+                            description: ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU, so that they do not stall when the MTU of the pod is lower than the one the guest assumes. Defaults to false.
+                            type: boolean
+                          hairpin:
+                            description: Hairpin selects which connections originating in the pod itself are forwarded to the VM. "loopback" forwards connections to the loopback address on the forwarded ports, "none" leaves all of them to processes in the pod and "full" forwards connections to any local address of the pod, including the VM connecting to its own service IP. Defaults to loopback.
+                            type: string
+                        type: object
+                      mirror:
+                        description: Copy the traffic of the interface to a monitoring network. Only supported on bridge and masquerade interfaces.
+                        properties:
+                          direction:
+                            description: 'Traffic to copy, as seen from the guest: rx, tx or both. Defaults to both.'
+                            type: string
+                          network:
+                            description: Name of the Multus network which receives the copied traffic. It must not be the network of an interface.
+                            type: string
+                        required:
+                        - network
                         type: object
                       model:
                         description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
@@ -4045,6 +5131,25 @@ var CRDsValidation map[string]string = map[string]string{
                       name:
                         description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                         type: string
+                      offloads:
+                        description: Offloads of the host side tap device of the interface. Only supported on bridge and masquerade interfaces.
+                        properties:
+                          gro:
+                            description: Generic receive offload.
+                            type: boolean
+                          gso:
+                            description: Generic segmentation offload.
+                            type: boolean
+                          tso:
+                            description: TCP segmentation offload.
+                            type: boolean
+                          txChecksum:
+                            description: TX checksum offload of the pod side bridge serving the interface. When not set, it is only disabled if the DHCP server of KubeVirt answers the guest on the bridge, since some guest DHCP clients drop its replies otherwise.
+                            type: boolean
+                        type: object
+                      packedRing:
+                        description: Use packed virtqueues instead of split virtqueues for the interface, which reduces the latency of the guest network. Only supported on virtio interfaces.
+                        type: boolean
                       pciAddress:
                         description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                         type: string
@@ -4067,13 +5172,36 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      roles:
+                        description: 'Roles the interface fulfills in the guest: management, storage or workload. They are published to the guest as SMBIOS OEM strings of the form io.kubevirt.interface.role:<role>=<MAC address>.'
+                        items:
+                          type: string
+                        type: array
+                      rss:
+                        description: Receive side scaling of the interface. The device steers the received packets to the queues by their hash, as configured by the guest, which spreads the load of the guest network over the vCPUs. Only supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.
+                        properties:
+                          hashReport:
+                            description: Report the hash of the received packets to the guest, which saves the guest from calculating it again, e.g. for receive flow steering.
+                            type: boolean
+                        type: object
+                      rxQueueSize:
+                        description: Size of the virtio RX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                        format: int32
+                        type: integer
                       slirp:
                         type: object
                       sriov:
                         type: object
                       tag:
-                        description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+                        description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive. The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>
                         type: string
+                      txQueueSize:
+                        description: Size of the virtio TX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                        format: int32
+                        type: integer
+                      vhostZeroCopyTX:
+                        description: Request zero copy transmission by vhost-net for the interface. The VMI is only scheduled on nodes where the vhost_net kernel module has zero copy transmission enabled. Only supported on virtio interfaces which are not bound with slirp.
+                        type: boolean
                     required:
                     - name
                     type: object
@@ -4081,9 +5209,16 @@ var CRDsValidation map[string]string = map[string]string{
                 networkInterfaceMultiqueue:
                   description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                   type: boolean
+                networkInterfaceQueues:
+                  description: Number of queues of the virtio interfaces with networkInterfaceMultiqueue. Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.
+                  format: int32
+                  type: integer
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                usbControllerModel:
+                  description: 'Model of the USB controller, which is attached when an input device is on the usb bus. Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.'
+                  type: string
                 watchdog:
                   description: Watchdog describes a watchdog device which can be added to the vmi.
                   properties:
@@ -4248,6 +5383,17 @@ var CRDsValidation map[string]string = map[string]string{
             firmware:
               description: Firmware.
               properties:
+                bootMenu:
+                  description: Settings to control the interactive boot menu of the firmware.
+                  properties:
+                    enabled:
+                      description: If set, the firmware offers a boot menu. Defaults to true
+                      type: boolean
+                    timeout:
+                      description: Time in milliseconds the boot menu waits for a selection before booting the default entry. Must be at most 65535.
+                      format: int32
+                      type: integer
+                  type: object
                 bootloader:
                   description: Settings to control the bootloader that is used.
                   properties:
@@ -4266,6 +5412,9 @@ var CRDsValidation map[string]string = map[string]string{
                           type: boolean
                       type: object
                   type: object
+                identityPolicy:
+                  description: IdentityPolicy controls how the UUID and the serial are generated when they are not set. Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores. RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that clones get new ones, while restores keep them. Defaults to Keep.
+                  type: string
                 serial:
                   description: The system-serial-number in SMBIOS
                   type: string
@@ -4334,6 +5483,9 @@ var CRDsValidation map[string]string = map[string]string{
         evictionStrategy:
           description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.
           type: string
+        guestOS:
+          description: 'GuestOS declares the operating system of the guest, e.g. "windows2k19" or "rhel8". The disk bus, the interface model, the clock and the inputs which are neither set on the vmi nor by a preset are defaulted to what suits the guest OS.'
+          type: string
         hostname:
           description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
           type: string
@@ -4413,6 +5565,9 @@ var CRDsValidation map[string]string = map[string]string{
               format: int32
               type: integer
           type: object
+        metadataService:
+          description: MetadataService serves the metadata and the user-data of the vmi and the token of its service account to the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.
+          type: object
         networks:
           description: List of networks that can be attached to a vm's virtual interface.
           items:
@@ -4425,7 +5580,7 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Select the default network and add it to the multus-cni.io/default-network annotation.
                     type: boolean
                   networkName:
-                    description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                    description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.'
                     type: string
                 required:
                 - networkName
@@ -4436,6 +5591,9 @@ var CRDsValidation map[string]string = map[string]string{
               pod:
                 description: Represents the stock pod network interface.
                 properties:
+                  vmIPv6NetworkCIDR:
+                    description: IPv6 CIDR for the vm network of masquerade interfaces. Default fd10:0:2::/120 if not specified.
+                    type: string
                   vmNetworkCIDR:
                     description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
                     type: string
@@ -4452,6 +5610,9 @@ var CRDsValidation map[string]string = map[string]string{
         priorityClassName:
           description: If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.
           type: string
+        qosClass:
+          description: QoSClass selects the tier of disk and network service the vmi gets on the node. Valid values are the QoS classes of the KubeVirt configuration, "gold", "silver" and "bronze" by default. No QoS is applied if not set. Unrelated to the pod QoS class reported in the status.
+          type: string
         readinessProbe:
           description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
           properties:
@@ -4642,6 +5803,22 @@ var CRDsValidation map[string]string = map[string]string{
               containerDisk:
                 description: 'ContainerDisk references a docker image, embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                 properties:
+                  checksum:
+                    description: Checksum is the expected digest of the disk in the image, in the form sha256:<hex>. The disk is verified before the VMI is started, which fails on a mismatch.
+                    type: string
+                  ephemeralImage:
+                    description: EphemeralImage configures the writable image the VMI runs on.
+                    properties:
+                      compressed:
+                        description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                        type: boolean
+                      format:
+                        description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                        type: string
+                      preallocation:
+                        description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                        type: string
+                    type: object
                   image:
                     description: Image is the name of the image with the embedded disk.
                     type: string
@@ -4660,6 +5837,9 @@ var CRDsValidation map[string]string = map[string]string{
               dataVolume:
                 description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
                 properties:
+                  checksum:
+                    description: Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.
+                    type: string
                   name:
                     description: Name represents the name of the DataVolume in the same namespace
                     type: string
@@ -4733,9 +5913,35 @@ var CRDsValidation map[string]string = map[string]string{
                 required:
                 - capacity
                 type: object
+              encryption:
+                description: Encryption layers LUKS encryption on top of the volume, which is handled by qemu. Only PersistentVolumeClaim and DataVolume volumes can be encrypted.
+                properties:
+                  secretRef:
+                    description: SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the "key" key. Keys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.
+                    properties:
+                      name:
+                        description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                        type: string
+                    type: object
+                required:
+                - secretRef
+                type: object
               ephemeral:
                 description: Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
                 properties:
+                  ephemeralImage:
+                    description: EphemeralImage configures the writable image the VMI runs on.
+                    properties:
+                      compressed:
+                        description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                        type: boolean
+                      format:
+                        description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                        type: string
+                      preallocation:
+                        description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                        type: string
+                    type: object
                   persistentVolumeClaim:
                     description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                     properties:
@@ -4807,6 +6013,19 @@ var CRDsValidation map[string]string = map[string]string{
                     description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                     type: string
                 type: object
+              vhostUserBlk:
+                description: VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.
+                properties:
+                  claimName:
+                    description: ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume contains the socket, like the volumes of an SPDK CSI driver
+                    type: string
+                  socket:
+                    description: Socket is the path of the vhost-user-blk socket in the volume
+                    type: string
+                required:
+                - claimName
+                - socket
+                type: object
             required:
             - name
             type: object
@@ -4847,9 +6066,31 @@ var CRDsValidation map[string]string = map[string]string{
             - type
             type: object
           type: array
+        containerDiskStatuses:
+          description: ContainerDiskStatuses reports the progress of the preparation of the containerDisks on the node, before the VirtualMachineInstance is started or migrated to the node.
+          items:
+            description: ContainerDiskStatus reports the preparation of a containerDisk, which is mounted into the virt-launcher pod and verified by virt-handler.
+            properties:
+              message:
+                description: Message describes why the preparation failed
+                type: string
+              name:
+                description: Name is the name of the volume
+                type: string
+              phase:
+                description: Phase is the phase of the preparation
+                type: string
+            required:
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         evacuationNodeName:
           description: EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want to evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.
           type: string
+        fsFreezeStatus:
+          description: FSFreezeStatus is "frozen" while the guest file systems are frozen through the guest agent, and empty otherwise.
+          type: string
         guestOSInfo:
           description: Guest OS Information
           properties:
@@ -4878,6 +6119,27 @@ var CRDsValidation map[string]string = map[string]string{
               description: Version ID of the Guest OS
               type: string
           type: object
+        guestTimeSync:
+          description: GuestTimeSync reports the last synchronization of the guest clock, which is set through the guest agent after the VirtualMachineInstance was unpaused, migrated or resumed from hibernation.
+          properties:
+            driftMilliseconds:
+              description: DriftMilliseconds is the offset of the guest clock from the clock of the node before it was set. It is positive if the guest clock was ahead, and only reported if the guest agent returned the guest time.
+              format: int64
+              type: integer
+            lastSyncTime:
+              description: LastSyncTime is the time of the last attempt to set the guest clock
+              format: date-time
+              nullable: true
+              type: string
+            message:
+              description: Message describes why the guest clock could not be set
+              type: string
+            synchronized:
+              description: Synchronized indicates whether the guest clock was set
+              type: boolean
+          required:
+          - synchronized
+          type: object
         interfaces:
           description: Interfaces represent the details of available network interfaces.
           items:
@@ -4930,6 +6192,64 @@ var CRDsValidation map[string]string = map[string]string{
             mode:
               description: Lets us know if the vmi is currently running pre or post copy migration
               type: string
+            sourceNetworkState:
+              description: The network state of the interfaces on the source node, which the target node applies before the migration so that the guest keeps its MAC addresses. It is cleared once the migration completed
+              items:
+                description: MigrationInterfaceNetworkState is the state of a pod network binding which has to be preserved across a migration.
+                properties:
+                  device:
+                    description: The device backing the interface in the virt-launcher pod, for macvtap bindings
+                    type: string
+                  ip:
+                    description: The IPv4 address of the guest in CIDR notation
+                    type: string
+                  ipv6:
+                    description: The IPv6 address of the guest in CIDR notation
+                    type: string
+                  mac:
+                    description: The MAC address the guest uses on the interface
+                    type: string
+                  masquerade:
+                    description: The nat configuration of the interface, for masquerade bindings
+                    properties:
+                      clampMSS:
+                        description: Whether the maximum segment size of the TCP connections of the guest is clamped to the path MTU
+                        type: boolean
+                      hairpin:
+                        description: The hairpin mode of the port forwarding rules
+                        type: string
+                      natRules:
+                        description: The nat rules of the interface in iptables notation
+                        items:
+                          type: string
+                        type: array
+                      ports:
+                        description: The ports forwarded to the guest
+                        items:
+                          description: Port repesents a port to expose from the virtual machine. Default protocol TCP. The port field is mandatory
+                          properties:
+                            name:
+                              description: If specified, this must be an IANA_SVC_NAME and unique within the pod. Each named port in a pod must have a unique name. Name for the port that can be referred to by services.
+                              type: string
+                            port:
+                              description: Number of port to expose for the virtual machine. This must be a valid port number, 0 < x < 65536.
+                              format: int32
+                              type: integer
+                            protocol:
+                              description: Protocol for port. Must be UDP or TCP. Defaults to "TCP".
+                              type: string
+                          required:
+                          - port
+                          type: object
+                        type: array
+                    type: object
+                  name:
+                    description: Name of the interface
+                    type: string
+                required:
+                - name
+                type: object
+              type: array
             sourceNode:
               description: The source node that the VMI originated on
               type: string
@@ -4943,41 +6263,143 @@ var CRDsValidation map[string]string = map[string]string{
                 type: integer
               description: The list of ports opened for live migration on the destination node
               type: object
-            targetNode:
-              description: The target node that the VMI is moving to
-              type: string
-            targetNodeAddress:
-              description: The address of the target node to use for the migration
-              type: string
-            targetNodeDomainDetected:
-              description: The Target Node has seen the Domain Start Event
-              type: boolean
-            targetPod:
-              description: The target pod that the VMI is moving to
-              type: string
-          type: object
-        nodeName:
-          description: NodeName is the name where the VirtualMachineInstance is currently running.
-          type: string
-        phase:
-          description: Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.
-          type: string
-        qosClass:
-          description: 'The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements See PodQOSClass type for available QOS classes More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md'
-          type: string
-        reason:
-          description: A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'
-          type: string
-        volumeStatus:
-          description: VolumeStatus contains the statuses of all the volumes
-          items:
-            description: VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
-            properties:
-              hotplugVolume:
-                description: If the volume is hotplug, this will contain the hotplug status.
+            targetNetworkState:
+              description: The network state of the interfaces on the target node, which the source node applies to the migrated domain so that it uses the devices of the target pod. It is cleared once the migration completed
+              items:
+                description: MigrationInterfaceNetworkState is the state of a pod network binding which has to be preserved across a migration.
                 properties:
-                  attachPodName:
-                    description: AttachPodName is the name of the pod used to attach the volume to the node.
+                  device:
+                    description: The device backing the interface in the virt-launcher pod, for macvtap bindings
+                    type: string
+                  ip:
+                    description: The IPv4 address of the guest in CIDR notation
+                    type: string
+                  ipv6:
+                    description: The IPv6 address of the guest in CIDR notation
+                    type: string
+                  mac:
+                    description: The MAC address the guest uses on the interface
+                    type: string
+                  masquerade:
+                    description: The nat configuration of the interface, for masquerade bindings
+                    properties:
+                      clampMSS:
+                        description: Whether the maximum segment size of the TCP connections of the guest is clamped to the path MTU
+                        type: boolean
+                      hairpin:
+                        description: The hairpin mode of the port forwarding rules
+                        type: string
+                      natRules:
+                        description: The nat rules of the interface in iptables notation
+                        items:
+                          type: string
+                        type: array
+                      ports:
+                        description: The ports forwarded to the guest
+                        items:
+                          description: Port repesents a port to expose from the virtual machine. Default protocol TCP. The port field is mandatory
+                          properties:
+                            name:
+                              description: If specified, this must be an IANA_SVC_NAME and unique within the pod. Each named port in a pod must have a unique name. Name for the port that can be referred to by services.
+                              type: string
+                            port:
+                              description: Number of port to expose for the virtual machine. This must be a valid port number, 0 < x < 65536.
+                              format: int32
+                              type: integer
+                            protocol:
+                              description: Protocol for port. Must be UDP or TCP. Defaults to "TCP".
+                              type: string
+                          required:
+                          - port
+                          type: object
+                        type: array
+                    type: object
+                  name:
+                    description: Name of the interface
+                    type: string
+                required:
+                - name
+                type: object
+              type: array
+            targetNode:
+              description: The target node that the VMI is moving to
+              type: string
+            targetNodeAddress:
+              description: The address of the target node to use for the migration
+              type: string
+            targetNodeDomainDetected:
+              description: The Target Node has seen the Domain Start Event
+              type: boolean
+            targetPod:
+              description: The target pod that the VMI is moving to
+              type: string
+          type: object
+        nodeName:
+          description: NodeName is the name where the VirtualMachineInstance is currently running.
+          type: string
+        phase:
+          description: Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.
+          type: string
+        qosClass:
+          description: 'The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements See PodQOSClass type for available QOS classes More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md'
+          type: string
+        reason:
+          description: A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'
+          type: string
+        startupTimestamps:
+          description: StartupTimestamps records when the VirtualMachineInstance reached the milestones of its startup
+          properties:
+            domainDefined:
+              description: DomainDefined is the time the domain was first reported by virt-launcher
+              format: date-time
+              nullable: true
+              type: string
+            domainRunning:
+              description: DomainRunning is the time the domain was first reported as running
+              format: date-time
+              nullable: true
+              type: string
+            guestAgentConnected:
+              description: GuestAgentConnected is the time the guest agent first connected
+              format: date-time
+              nullable: true
+              type: string
+            networkConfigured:
+              description: NetworkConfigured is the time virt-handler finished to configure the pod networking
+              format: date-time
+              nullable: true
+              type: string
+            podScheduled:
+              description: PodScheduled is the time the virt-launcher pod was scheduled to a node
+              format: date-time
+              nullable: true
+              type: string
+          type: object
+        usage:
+          description: Usage holds the resource usage counters of the VirtualMachineInstance, which are reported by virt-handler when usage accounting is enabled.
+          properties:
+            networkReceiveBytes:
+              description: NetworkReceiveBytes is the number of bytes received on all interfaces
+              format: int64
+              type: integer
+            networkTransmitBytes:
+              description: NetworkTransmitBytes is the number of bytes transmitted on all interfaces
+              format: int64
+              type: integer
+          required:
+          - networkReceiveBytes
+          - networkTransmitBytes
+          type: object
+        volumeStatus:
+          description: VolumeStatus contains the statuses of all the volumes
+          items:
+            description: VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
+            properties:
+              hotplugVolume:
+                description: If the volume is hotplug, this will contain the hotplug status.
+                properties:
+                  attachPodName:
+                    description: AttachPodName is the name of the pod used to attach the volume to the node.
                     type: string
                   attachPodUID:
                     description: AttachPodUID is the UID of the pod used to attach the volume to the node.
@@ -5004,6 +6426,9 @@ var CRDsValidation map[string]string = map[string]string{
             type: object
           type: array
           x-kubernetes-list-type: atomic
+        vmNetworkCIDR:
+          description: VMNetworkCIDR is the internal subnet of the masquerade interface allocated from the masquerade subnet pool of the cluster. It is meant to be used by KubeVirt core components only and can't be set or modified by users.
+          type: string
       type: object
   required:
   - spec
@@ -5077,6 +6502,9 @@ var CRDsValidation map[string]string = map[string]string{
         domain:
           description: Domain is the same object type as contained in VirtualMachineInstanceSpec
           properties:
+            allowEmulation:
+              description: AllowEmulation overrides whether the VMI may fall back to software emulation on nodes without /dev/kvm, within the emulation policy of the cluster.
+              type: boolean
             chassis:
               description: Chassis specifies the chassis info passed to the domain.
               properties:
@@ -5187,6 +6615,19 @@ var CRDsValidation map[string]string = map[string]string{
                 model:
                   description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                   type: string
+                nestedVirtualization:
+                  description: NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.
+                  type: boolean
+                numa:
+                  description: NUMA allows specifying settings for the guest NUMA topology
+                  properties:
+                    guestMappingPassthrough:
+                      description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes. Requires DedicatedCPUPlacement and Hugepages.
+                      type: object
+                  type: object
+                sideChannelIsolation:
+                  description: SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and flushes the L1 data cache on every VM entry where L1TF applies.
+                  type: boolean
                 sockets:
                   description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                   format: int32
@@ -5202,6 +6643,9 @@ var CRDsValidation map[string]string = map[string]string{
                 autoattachGraphicsDevice:
                   description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
                   type: boolean
+                autoattachInputDevice:
+                  description: Whether to attach a tablet when the graphics device is attached and no inputs are specified. The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.
+                  type: boolean
                 autoattachMemBalloon:
                   description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                   type: boolean
@@ -5211,9 +6655,24 @@ var CRDsValidation map[string]string = map[string]string{
                 autoattachSerialConsole:
                   description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                   type: boolean
+                autoattachVirtioWinDriverDisk:
+                  description: Whether to attach the virtio-win driver disk as a CD-ROM, which holds the virtio drivers Windows needs to install on virtio disks and to use virtio network interfaces. The image of the disk is configured in the KubeVirt CR. Defaults to false.
+                  type: boolean
                 blockMultiQueue:
                   description: Whether or not to enable virtio multi-queue for block devices
                   type: boolean
+                channels:
+                  description: Channels are additional virtio-serial channels for custom communication between the host and the guest.
+                  items:
+                    properties:
+                      name:
+                        description: Name is the name of the virtio-serial port, like org.example.agent.0. The guest finds the channel at /dev/virtio-ports/<name>.
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 disableHotplug:
                   description: DisableHotplug disabled the ability to hotplug disks.
                   type: boolean
@@ -5286,7 +6745,10 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Serial provides the ability to specify a serial number for the disk device.
                         type: string
                       tag:
-                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                        description: If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
+                        type: string
+                      wwn:
+                        description: WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.
                         type: string
                     required:
                     - name
@@ -5345,11 +6807,14 @@ var CRDsValidation map[string]string = map[string]string{
                       bus:
                         description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
                         type: string
+                      model:
+                        description: 'Model indicates the model of a virtio input device. Supported values: virtio, virtio-transitional, virtio-non-transitional.'
+                        type: string
                       name:
                         description: Name is the device name
                         type: string
                       type:
-                        description: 'Type indicated the type of input device. Supported values: tablet.'
+                        description: 'Type indicated the type of input device. Supported values: tablet, keyboard.'
                         type: string
                     required:
                     - name
@@ -5364,6 +6829,22 @@ var CRDsValidation map[string]string = map[string]string{
                         description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                         type: integer
                       bridge:
+                        properties:
+                          guestAddress:
+                            description: GuestAddress declares the address the guest uses when the pod network does not provide one. The bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.
+                            properties:
+                              gateway:
+                                description: Gateway offered to the guest in the static DHCP lease.
+                                type: string
+                              ip:
+                                description: IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.
+                                type: string
+                              serveDHCP:
+                                description: ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.
+                                type: boolean
+                            required:
+                            - ip
+                            type: object
                         type: object
                       dhcpOptions:
                         description: If specified the network interface will pass additional DHCP options to the VMI
@@ -5371,6 +6852,9 @@ var CRDsValidation map[string]string = map[string]string{
                           bootFileName:
                             description: If specified will pass option 67 to interface's DHCP server
                             type: string
+                          nextServer:
+                            description: If specified will set the next server address (siaddr) of the replies of interface's DHCP server, the address of the boot server. Must be an IPv4 address.
+                            type: string
                           ntpServers:
                             description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                             items:
@@ -5392,16 +6876,41 @@ var CRDsValidation map[string]string = map[string]string{
                               - value
                               type: object
                             type: array
+                          tftpProxy:
+                            description: If set, virt-launcher relays the TFTP requests of the guest, which it sends to the gateway of a masquerade interface, to the next server or the TFTP server. The DHCP server passes the gateway as next server and TFTP server then.
+                            type: boolean
                           tftpServerName:
                             description: If specified will pass option 66 to interface's DHCP server
                             type: string
                         type: object
+                      latencyProfile:
+                        description: Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls the tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net threads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs. Only supported on virtio bridge and masquerade interfaces.
+                        type: string
                       macAddress:
                         description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                         type: string
                       macvtap:
                         type: object
                       masquerade:
+                        properties:
+                          clampMSS:
+                            description: ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU, so that they do not stall when the MTU of the pod is lower than the one the guest assumes. Defaults to false.
+                            type: boolean
+                          hairpin:
+                            description: Hairpin selects which connections originating in the pod itself are forwarded to the VM. "loopback" forwards connections to the loopback address on the forwarded ports, "none" leaves all of them to processes in the pod and "full" forwards connections to any local address of the pod, including the VM connecting to its own service IP. Defaults to loopback.
+                            type: string
+                        type: object
+                      mirror:
+                        description: Copy the traffic of the interface to a monitoring network. Only supported on bridge and masquerade interfaces.
+                        properties:
+                          direction:
+                            description: 'Traffic to copy, as seen from the guest: rx, tx or both. Defaults to both.'
+                            type: string
+                          network:
+                            description: Name of the Multus network which receives the copied traffic. It must not be the network of an interface.
+                            type: string
+                        required:
+                        - network
                         type: object
                       model:
                         description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
@@ -5409,6 +6918,25 @@ var CRDsValidation map[string]string = map[string]string{
                       name:
                         description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                         type: string
+                      offloads:
+                        description: Offloads of the host side tap device of the interface. Only supported on bridge and masquerade interfaces.
+                        properties:
+                          gro:
+                            description: Generic receive offload.
+                            type: boolean
+                          gso:
+                            description: Generic segmentation offload.
+                            type: boolean
+                          tso:
+                            description: TCP segmentation offload.
+                            type: boolean
+                          txChecksum:
+                            description: TX checksum offload of the pod side bridge serving the interface. When not set, it is only disabled if the DHCP server of KubeVirt answers the guest on the bridge, since some guest DHCP clients drop its replies otherwise.
+                            type: boolean
+                        type: object
+                      packedRing:
+                        description: Use packed virtqueues instead of split virtqueues for the interface, which reduces the latency of the guest network. Only supported on virtio interfaces.
+                        type: boolean
                       pciAddress:
                         description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                         type: string
@@ -5431,13 +6959,36 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      roles:
+                        description: 'Roles the interface fulfills in the guest: management, storage or workload. They are published to the guest as SMBIOS OEM strings of the form io.kubevirt.interface.role:<role>=<MAC address>.'
+                        items:
+                          type: string
+                        type: array
+                      rss:
+                        description: Receive side scaling of the interface. The device steers the received packets to the queues by their hash, as configured by the guest, which spreads the load of the guest network over the vCPUs. Only supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.
+                        properties:
+                          hashReport:
+                            description: Report the hash of the received packets to the guest, which saves the guest from calculating it again, e.g. for receive flow steering.
+                            type: boolean
+                        type: object
+                      rxQueueSize:
+                        description: Size of the virtio RX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                        format: int32
+                        type: integer
                       slirp:
                         type: object
                       sriov:
                         type: object
                       tag:
-                        description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+                        description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive. The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>
                         type: string
+                      txQueueSize:
+                        description: Size of the virtio TX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                        format: int32
+                        type: integer
+                      vhostZeroCopyTX:
+                        description: Request zero copy transmission by vhost-net for the interface. The VMI is only scheduled on nodes where the vhost_net kernel module has zero copy transmission enabled. Only supported on virtio interfaces which are not bound with slirp.
+                        type: boolean
                     required:
                     - name
                     type: object
@@ -5445,9 +6996,16 @@ var CRDsValidation map[string]string = map[string]string{
                 networkInterfaceMultiqueue:
                   description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                   type: boolean
+                networkInterfaceQueues:
+                  description: Number of queues of the virtio interfaces with networkInterfaceMultiqueue. Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.
+                  format: int32
+                  type: integer
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                usbControllerModel:
+                  description: 'Model of the USB controller, which is attached when an input device is on the usb bus. Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.'
+                  type: string
                 watchdog:
                   description: Watchdog describes a watchdog device which can be added to the vmi.
                   properties:
@@ -5612,6 +7170,17 @@ var CRDsValidation map[string]string = map[string]string{
             firmware:
               description: Firmware.
               properties:
+                bootMenu:
+                  description: Settings to control the interactive boot menu of the firmware.
+                  properties:
+                    enabled:
+                      description: If set, the firmware offers a boot menu. Defaults to true
+                      type: boolean
+                    timeout:
+                      description: Time in milliseconds the boot menu waits for a selection before booting the default entry. Must be at most 65535.
+                      format: int32
+                      type: integer
+                  type: object
                 bootloader:
                   description: Settings to control the bootloader that is used.
                   properties:
@@ -5630,6 +7199,9 @@ var CRDsValidation map[string]string = map[string]string{
                           type: boolean
                       type: object
                   type: object
+                identityPolicy:
+                  description: IdentityPolicy controls how the UUID and the serial are generated when they are not set. Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores. RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that clones get new ones, while restores keep them. Defaults to Keep.
+                  type: string
                 serial:
                   description: The system-serial-number in SMBIOS
                   type: string
@@ -5715,35 +7287,261 @@ var CRDsValidation map[string]string = map[string]string{
                       type: string
                     type: array
                 required:
-                - key
-                - operator
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+      required:
+      - selector
+      type: object
+  type: object
+`,
+	"virtualmachineinstancereplicaset": `openAPIV3Schema:
+  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
+      properties:
+        dataVolumeTemplates:
+          description: dataVolumeTemplates is a list of dataVolumes which are created for every VirtualMachineInstance of the replica set. The dataVolume volumes of the template referencing them are pointed to the copy of their replica, which is deleted along with the VirtualMachineInstance.
+          items:
+            nullable: true
+            properties:
+              apiVersion:
+                description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+                type: string
+              kind:
+                description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+                type: string
+              metadata:
+                nullable: true
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+              spec:
+                description: DataVolumeSpec contains the DataVolume specification.
+                properties:
+                  contentType:
+                    description: 'DataVolumeContentType options: "kubevirt", "archive"'
+                    enum:
+                    - kubevirt
+                    - archive
+                    type: string
+                  pvc:
+                    description: PVC is the PVC specification
+                    properties:
+                      accessModes:
+                        description: 'AccessModes contains the desired access modes the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1'
+                        items:
+                          type: string
+                        type: array
+                      dataSource:
+                        description: This field requires the VolumeSnapshotDataSource alpha feature gate to be enabled and currently VolumeSnapshot is the only supported data source. If the provisioner can support VolumeSnapshot data source, it will create a new volume and data will be restored to the volume at the same time. If the provisioner does not support VolumeSnapshot data source, volume will not be created and the failure will be reported as an event. In the future, we plan to support more data source types and the behavior of the provisioner may change.
+                        properties:
+                          apiGroup:
+                            description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+                            type: string
+                          kind:
+                            description: Kind is the type of resource being referenced
+                            type: string
+                          name:
+                            description: Name is the name of resource being referenced
+                            type: string
+                        required:
+                        - kind
+                        - name
+                        type: object
+                      resources:
+                        description: 'Resources represents the minimum resources the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources'
+                        properties:
+                          limits:
+                            additionalProperties:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            description: 'Limits describes the maximum amount of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                            type: object
+                          requests:
+                            additionalProperties:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            description: 'Requests describes the minimum amount of compute resources required. If Requests is omitted for a container, it defaults to Limits if that is explicitly specified, otherwise to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                            type: object
+                        type: object
+                      selector:
+                        description: A label query over volumes to consider for binding.
+                        properties:
+                          matchExpressions:
+                            description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                            items:
+                              description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                              properties:
+                                key:
+                                  description: key is the label key that the selector applies to.
+                                  type: string
+                                operator:
+                                  description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                                  type: string
+                                values:
+                                  description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                          matchLabels:
+                            additionalProperties:
+                              type: string
+                            description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                            type: object
+                        type: object
+                      storageClassName:
+                        description: 'Name of the StorageClass required by the claim. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1'
+                        type: string
+                      volumeMode:
+                        description: volumeMode defines what type of volume is required by the claim. Value of Filesystem is implied when not included in claim spec. This is a beta feature.
+                        type: string
+                      volumeName:
+                        description: VolumeName is the binding reference to the PersistentVolume backing this claim.
+                        type: string
+                    type: object
+                  source:
+                    description: Source is the src of the data for the requested DataVolume
+                    properties:
+                      blank:
+                        description: DataVolumeBlankImage provides the parameters to create a new raw blank image for the PVC
+                        type: object
+                      http:
+                        description: DataVolumeSourceHTTP can be either an http or https endpoint, with an optional basic auth user name and password, and an optional configmap containing additional CAs
+                        properties:
+                          certConfigMap:
+                            description: CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+                            type: string
+                          secretRef:
+                            description: SecretRef A Secret reference, the secret should contain accessKeyId (user name) base64 encoded, and secretKey (password) also base64 encoded
+                            type: string
+                          url:
+                            description: URL is the URL of the http(s) endpoint
+                            type: string
+                        required:
+                        - url
+                        type: object
+                      imageio:
+                        description: DataVolumeSourceImageIO provides the parameters to create a Data Volume from an imageio source
+                        properties:
+                          certConfigMap:
+                            description: CertConfigMap provides a reference to the CA cert
+                            type: string
+                          diskId:
+                            description: DiskID provides id of a disk to be imported
+                            type: string
+                          secretRef:
+                            description: SecretRef provides the secret reference needed to access the ovirt-engine
+                            type: string
+                          url:
+                            description: URL is the URL of the ovirt-engine
+                            type: string
+                        required:
+                        - diskId
+                        - url
+                        type: object
+                      pvc:
+                        description: DataVolumeSourcePVC provides the parameters to create a Data Volume from an existing PVC
+                        properties:
+                          name:
+                            description: The name of the source PVC
+                            type: string
+                          namespace:
+                            description: The namespace of the source PVC
+                            type: string
+                        required:
+                        - name
+                        - namespace
+                        type: object
+                      registry:
+                        description: DataVolumeSourceRegistry provides the parameters to create a Data Volume from an registry source
+                        properties:
+                          certConfigMap:
+                            description: CertConfigMap provides a reference to the Registry certs
+                            type: string
+                          secretRef:
+                            description: SecretRef provides the secret reference needed to access the Registry source
+                            type: string
+                          url:
+                            description: URL is the url of the Docker registry source
+                            type: string
+                        required:
+                        - url
+                        type: object
+                      s3:
+                        description: DataVolumeSourceS3 provides the parameters to create a Data Volume from an S3 source
+                        properties:
+                          secretRef:
+                            description: SecretRef provides the secret reference needed to access the S3 source
+                            type: string
+                          url:
+                            description: URL is the url of the S3 source
+                            type: string
+                        required:
+                        - url
+                        type: object
+                      upload:
+                        description: DataVolumeSourceUpload provides the parameters to create a Data Volume by uploading the source
+                        type: object
+                      vddk:
+                        description: DataVolumeSourceVDDK provides the parameters to create a Data Volume from a Vmware source
+                        properties:
+                          backingFile:
+                            description: BackingFile is the path to the virtual hard disk to migrate from vCenter/ESXi
+                            type: string
+                          secretRef:
+                            description: SecretRef provides a reference to a secret containing the username and password needed to access the vCenter or ESXi host
+                            type: string
+                          thumbprint:
+                            description: Thumbprint is the certificate thumbprint of the vCenter or ESXi host
+                            type: string
+                          url:
+                            description: URL is the URL of the vCenter or ESXi host with the VM to migrate
+                            type: string
+                          uuid:
+                            description: UUID is the UUID of the virtual machine that the backing file is attached to in vCenter/ESXi
+                            type: string
+                        type: object
+                    type: object
+                required:
+                - pvc
+                - source
                 type: object
-              type: array
-            matchLabels:
-              additionalProperties:
-                type: string
-              description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
-              type: object
-          type: object
-      required:
-      - selector
-      type: object
-  type: object
-`,
-	"virtualmachineinstancereplicaset": `openAPIV3Schema:
-  description: VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.
-  properties:
-    apiVersion:
-      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
-      type: string
-    kind:
-      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
-      type: string
-    metadata:
-      type: object
-    spec:
-      description: VirtualMachineInstance Spec contains the VirtualMachineInstance specification.
-      properties:
+              status:
+                description: DataVolumeTemplateDummyStatus is here simply for backwards compatibility with a previous API.
+                nullable: true
+                type: object
+            required:
+            - spec
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         paused:
           description: Indicates that the replica set is paused.
           type: boolean
@@ -6238,6 +8036,9 @@ var CRDsValidation map[string]string = map[string]string{
                 domain:
                   description: Specification of the desired behavior of the VirtualMachineInstance on the host.
                   properties:
+                    allowEmulation:
+                      description: AllowEmulation overrides whether the VMI may fall back to software emulation on nodes without /dev/kvm, within the emulation policy of the cluster.
+                      type: boolean
                     chassis:
                       description: Chassis specifies the chassis info passed to the domain.
                       properties:
@@ -6348,6 +8149,19 @@ var CRDsValidation map[string]string = map[string]string{
                         model:
                           description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                           type: string
+                        nestedVirtualization:
+                          description: NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.
+                          type: boolean
+                        numa:
+                          description: NUMA allows specifying settings for the guest NUMA topology
+                          properties:
+                            guestMappingPassthrough:
+                              description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes. Requires DedicatedCPUPlacement and Hugepages.
+                              type: object
+                          type: object
+                        sideChannelIsolation:
+                          description: SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and flushes the L1 data cache on every VM entry where L1TF applies.
+                          type: boolean
                         sockets:
                           description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                           format: int32
@@ -6363,6 +8177,9 @@ var CRDsValidation map[string]string = map[string]string{
                         autoattachGraphicsDevice:
                           description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
                           type: boolean
+                        autoattachInputDevice:
+                          description: Whether to attach a tablet when the graphics device is attached and no inputs are specified. The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.
+                          type: boolean
                         autoattachMemBalloon:
                           description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                           type: boolean
@@ -6372,9 +8189,24 @@ var CRDsValidation map[string]string = map[string]string{
                         autoattachSerialConsole:
                           description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                           type: boolean
+                        autoattachVirtioWinDriverDisk:
+                          description: Whether to attach the virtio-win driver disk as a CD-ROM, which holds the virtio drivers Windows needs to install on virtio disks and to use virtio network interfaces. The image of the disk is configured in the KubeVirt CR. Defaults to false.
+                          type: boolean
                         blockMultiQueue:
                           description: Whether or not to enable virtio multi-queue for block devices
                           type: boolean
+                        channels:
+                          description: Channels are additional virtio-serial channels for custom communication between the host and the guest.
+                          items:
+                            properties:
+                              name:
+                                description: Name is the name of the virtio-serial port, like org.example.agent.0. The guest finds the channel at /dev/virtio-ports/<name>.
+                                type: string
+                            required:
+                            - name
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         disableHotplug:
                           description: DisableHotplug disabled the ability to hotplug disks.
                           type: boolean
@@ -6447,7 +8279,10 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Serial provides the ability to specify a serial number for the disk device.
                                 type: string
                               tag:
-                                description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                                description: If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
+                                type: string
+                              wwn:
+                                description: WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.
                                 type: string
                             required:
                             - name
@@ -6506,11 +8341,14 @@ var CRDsValidation map[string]string = map[string]string{
                               bus:
                                 description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
                                 type: string
+                              model:
+                                description: 'Model indicates the model of a virtio input device. Supported values: virtio, virtio-transitional, virtio-non-transitional.'
+                                type: string
                               name:
                                 description: Name is the device name
                                 type: string
                               type:
-                                description: 'Type indicated the type of input device. Supported values: tablet.'
+                                description: 'Type indicated the type of input device. Supported values: tablet, keyboard.'
                                 type: string
                             required:
                             - name
@@ -6525,6 +8363,22 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                 type: integer
                               bridge:
+                                properties:
+                                  guestAddress:
+                                    description: GuestAddress declares the address the guest uses when the pod network does not provide one. The bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.
+                                    properties:
+                                      gateway:
+                                        description: Gateway offered to the guest in the static DHCP lease.
+                                        type: string
+                                      ip:
+                                        description: IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.
+                                        type: string
+                                      serveDHCP:
+                                        description: ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.
+                                        type: boolean
+                                    required:
+                                    - ip
+                                    type: object
                                 type: object
                               dhcpOptions:
                                 description: If specified the network interface will pass additional DHCP options to the VMI
@@ -6532,6 +8386,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   bootFileName:
                                     description: If specified will pass option 67 to interface's DHCP server
                                     type: string
+                                  nextServer:
+                                    description: If specified will set the next server address (siaddr) of the replies of interface's DHCP server, the address of the boot server. Must be an IPv4 address.
+                                    type: string
                                   ntpServers:
                                     description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                                     items:
@@ -6553,16 +8410,41 @@ var CRDsValidation map[string]string = map[string]string{
                                       - value
                                       type: object
                                     type: array
+                                  tftpProxy:
+                                    description: If set, virt-launcher relays the TFTP requests of the guest, which it sends to the gateway of a masquerade interface, to the next server or the TFTP server. The DHCP server passes the gateway as next server and TFTP server then.
+                                    type: boolean
                                   tftpServerName:
                                     description: If specified will pass option 66 to interface's DHCP server
                                     type: string
                                 type: object
+                              latencyProfile:
+                                description: Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls the tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net threads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs. Only supported on virtio bridge and masquerade interfaces.
+                                type: string
                               macAddress:
                                 description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                 type: string
                               macvtap:
                                 type: object
                               masquerade:
+                                properties:
+                                  clampMSS:
+                                    description: ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU, so that they do not stall when the MTU of the pod is lower than the one the guest assumes. Defaults to false.
+                                    type: boolean
+                                  hairpin:
+                                    description: Hairpin selects which connections originating in the pod itself are forwarded to the VM. "loopback" forwards connections to the loopback address on the forwarded ports, "none" leaves all of them to processes in the pod and "full" forwards connections to any local address of the pod, including the VM connecting to its own service IP. Defaults to loopback.
+                                    type: string
+                                type: object
+                              mirror:
+                                description: Copy the traffic of the interface to a monitoring network. Only supported on bridge and masquerade interfaces.
+                                properties:
+                                  direction:
+                                    description: 'Traffic to copy, as seen from the guest: rx, tx or both. Defaults to both.'
+                                    type: string
+                                  network:
+                                    description: Name of the Multus network which receives the copied traffic. It must not be the network of an interface.
+                                    type: string
+                                required:
+                                - network
                                 type: object
                               model:
                                 description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
@@ -6570,6 +8452,25 @@ var CRDsValidation map[string]string = map[string]string{
                               name:
                                 description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                                 type: string
+                              offloads:
+                                description: Offloads of the host side tap device of the interface. Only supported on bridge and masquerade interfaces.
+                                properties:
+                                  gro:
+                                    description: Generic receive offload.
+                                    type: boolean
+                                  gso:
+                                    description: Generic segmentation offload.
+                                    type: boolean
+                                  tso:
+                                    description: TCP segmentation offload.
+                                    type: boolean
+                                  txChecksum:
+                                    description: TX checksum offload of the pod side bridge serving the interface. When not set, it is only disabled if the DHCP server of KubeVirt answers the guest on the bridge, since some guest DHCP clients drop its replies otherwise.
+                                    type: boolean
+                                type: object
+                              packedRing:
+                                description: Use packed virtqueues instead of split virtqueues for the interface, which reduces the latency of the guest network. Only supported on virtio interfaces.
+                                type: boolean
                               pciAddress:
                                 description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                                 type: string
@@ -6592,13 +8493,36 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
+                              roles:
+                                description: 'Roles the interface fulfills in the guest: management, storage or workload. They are published to the guest as SMBIOS OEM strings of the form io.kubevirt.interface.role:<role>=<MAC address>.'
+                                items:
+                                  type: string
+                                type: array
+                              rss:
+                                description: Receive side scaling of the interface. The device steers the received packets to the queues by their hash, as configured by the guest, which spreads the load of the guest network over the vCPUs. Only supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.
+                                properties:
+                                  hashReport:
+                                    description: Report the hash of the received packets to the guest, which saves the guest from calculating it again, e.g. for receive flow steering.
+                                    type: boolean
+                                type: object
+                              rxQueueSize:
+                                description: Size of the virtio RX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                                format: int32
+                                type: integer
                               slirp:
                                 type: object
                               sriov:
                                 type: object
                               tag:
-                                description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+                                description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive. The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>
                                 type: string
+                              txQueueSize:
+                                description: Size of the virtio TX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                                format: int32
+                                type: integer
+                              vhostZeroCopyTX:
+                                description: Request zero copy transmission by vhost-net for the interface. The VMI is only scheduled on nodes where the vhost_net kernel module has zero copy transmission enabled. Only supported on virtio interfaces which are not bound with slirp.
+                                type: boolean
                             required:
                             - name
                             type: object
@@ -6606,9 +8530,16 @@ var CRDsValidation map[string]string = map[string]string{
                         networkInterfaceMultiqueue:
                           description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                           type: boolean
+                        networkInterfaceQueues:
+                          description: Number of queues of the virtio interfaces with networkInterfaceMultiqueue. Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.
+                          format: int32
+                          type: integer
                         rng:
                           description: Whether to have random number generator from host
                           type: object
+                        usbControllerModel:
+                          description: 'Model of the USB controller, which is attached when an input device is on the usb bus. Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.'
+                          type: string
                         watchdog:
                           description: Watchdog describes a watchdog device which can be added to the vmi.
                           properties:
@@ -6773,6 +8704,17 @@ var CRDsValidation map[string]string = map[string]string{
                     firmware:
                       description: Firmware.
                       properties:
+                        bootMenu:
+                          description: Settings to control the interactive boot menu of the firmware.
+                          properties:
+                            enabled:
+                              description: If set, the firmware offers a boot menu. Defaults to true
+                              type: boolean
+                            timeout:
+                              description: Time in milliseconds the boot menu waits for a selection before booting the default entry. Must be at most 65535.
+                              format: int32
+                              type: integer
+                          type: object
                         bootloader:
                           description: Settings to control the bootloader that is used.
                           properties:
@@ -6791,6 +8733,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   type: boolean
                               type: object
                           type: object
+                        identityPolicy:
+                          description: IdentityPolicy controls how the UUID and the serial are generated when they are not set. Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores. RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that clones get new ones, while restores keep them. Defaults to Keep.
+                          type: string
                         serial:
                           description: The system-serial-number in SMBIOS
                           type: string
@@ -6859,6 +8804,9 @@ var CRDsValidation map[string]string = map[string]string{
                 evictionStrategy:
                   description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.
                   type: string
+                guestOS:
+                  description: 'GuestOS declares the operating system of the guest, e.g. "windows2k19" or "rhel8". The disk bus, the interface model, the clock and the inputs which are neither set on the vmi nor by a preset are defaulted to what suits the guest OS.'
+                  type: string
                 hostname:
                   description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                   type: string
@@ -6938,6 +8886,9 @@ var CRDsValidation map[string]string = map[string]string{
                       format: int32
                       type: integer
                   type: object
+                metadataService:
+                  description: MetadataService serves the metadata and the user-data of the vmi and the token of its service account to the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.
+                  type: object
                 networks:
                   description: List of networks that can be attached to a vm's virtual interface.
                   items:
@@ -6950,7 +8901,7 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Select the default network and add it to the multus-cni.io/default-network annotation.
                             type: boolean
                           networkName:
-                            description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                            description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.'
                             type: string
                         required:
                         - networkName
@@ -6961,6 +8912,9 @@ var CRDsValidation map[string]string = map[string]string{
                       pod:
                         description: Represents the stock pod network interface.
                         properties:
+                          vmIPv6NetworkCIDR:
+                            description: IPv6 CIDR for the vm network of masquerade interfaces. Default fd10:0:2::/120 if not specified.
+                            type: string
                           vmNetworkCIDR:
                             description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
                             type: string
@@ -6977,6 +8931,9 @@ var CRDsValidation map[string]string = map[string]string{
                 priorityClassName:
                   description: If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.
                   type: string
+                qosClass:
+                  description: QoSClass selects the tier of disk and network service the vmi gets on the node. Valid values are the QoS classes of the KubeVirt configuration, "gold", "silver" and "bronze" by default. No QoS is applied if not set. Unrelated to the pod QoS class reported in the status.
+                  type: string
                 readinessProbe:
                   description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
                   properties:
@@ -7167,6 +9124,22 @@ var CRDsValidation map[string]string = map[string]string{
                       containerDisk:
                         description: 'ContainerDisk references a docker image, embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                         properties:
+                          checksum:
+                            description: Checksum is the expected digest of the disk in the image, in the form sha256:<hex>. The disk is verified before the VMI is started, which fails on a mismatch.
+                            type: string
+                          ephemeralImage:
+                            description: EphemeralImage configures the writable image the VMI runs on.
+                            properties:
+                              compressed:
+                                description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                                type: boolean
+                              format:
+                                description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                                type: string
+                              preallocation:
+                                description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                                type: string
+                            type: object
                           image:
                             description: Image is the name of the image with the embedded disk.
                             type: string
@@ -7185,6 +9158,9 @@ var CRDsValidation map[string]string = map[string]string{
                       dataVolume:
                         description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
                         properties:
+                          checksum:
+                            description: Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.
+                            type: string
                           name:
                             description: Name represents the name of the DataVolume in the same namespace
                             type: string
@@ -7258,9 +9234,35 @@ var CRDsValidation map[string]string = map[string]string{
                         required:
                         - capacity
                         type: object
+                      encryption:
+                        description: Encryption layers LUKS encryption on top of the volume, which is handled by qemu. Only PersistentVolumeClaim and DataVolume volumes can be encrypted.
+                        properties:
+                          secretRef:
+                            description: SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the "key" key. Keys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                type: string
+                            type: object
+                        required:
+                        - secretRef
+                        type: object
                       ephemeral:
                         description: Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
                         properties:
+                          ephemeralImage:
+                            description: EphemeralImage configures the writable image the VMI runs on.
+                            properties:
+                              compressed:
+                                description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                                type: boolean
+                              format:
+                                description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                                type: string
+                              preallocation:
+                                description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                                type: string
+                            type: object
                           persistentVolumeClaim:
                             description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                             properties:
@@ -7332,6 +9334,19 @@ var CRDsValidation map[string]string = map[string]string{
                             description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                             type: string
                         type: object
+                      vhostUserBlk:
+                        description: VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.
+                        properties:
+                          claimName:
+                            description: ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume contains the socket, like the volumes of an SPDK CSI driver
+                            type: string
+                          socket:
+                            description: Socket is the path of the vhost-user-blk socket in the volume
+                            type: string
+                        required:
+                        - claimName
+                        - socket
+                        type: object
                     required:
                     - name
                     type: object
@@ -7341,48 +9356,378 @@ var CRDsValidation map[string]string = map[string]string{
               type: object
           type: object
       required:
-      - selector
-      - template
+      - selector
+      - template
+      type: object
+    status:
+      description: Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.
+      nullable: true
+      properties:
+        conditions:
+          items:
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        labelSelector:
+          description: Canonical form of the label selector for HPA which consumes it through the scale subresource.
+          type: string
+        readyReplicas:
+          description: The number of ready replicas for this replica set.
+          format: int32
+          type: integer
+        replicas:
+          description: Total number of non-terminated pods targeted by this deployment (their labels match the selector).
+          format: int32
+          type: integer
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinenotificationhook": `openAPIV3Schema:
+  description: VirtualMachineNotificationHook registers an endpoint which is notified about lifecycle events of the VirtualMachineInstances in its namespace
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      properties:
+        events:
+          description: Events which are sent to the URL. All events are sent if empty.
+          items:
+            description: VirtualMachineNotificationEvent is a lifecycle event of a VirtualMachineInstance
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        maxRetries:
+          description: MaxRetries is the number of times the delivery of an event is retried after it failed. Defaults to 5.
+          format: int32
+          type: integer
+        selector:
+          description: Selector is a label query over the VMIs whose events are sent. The events of all VMIs in the namespace are sent if empty.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+              items:
+                description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                    items:
+                      type: string
+                    type: array
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+        signingSecretRef:
+          description: SigningSecretRef references a secret in the namespace of the hook. The value of its "key" entry signs the events with HMAC-SHA256.
+          properties:
+            name:
+              description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+              type: string
+          type: object
+        url:
+          description: URL the events are sent to with a POST request. Only http and https URLs are supported.
+          type: string
+      required:
+      - url
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineovfimport": `openAPIV3Schema:
+  description: VirtualMachineOVFImport defines the operation of importing a virtual appliance described by an OVF descriptor as a VM
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineOVFImportSpec is the spec for a VirtualMachineOVFImport resource
+      properties:
+        networkMappings:
+          description: NetworkMappings attach the interfaces on the networks of the descriptor to networks of the VM, interfaces on networks which are not mapped are attached to the pod network
+          items:
+            description: NetworkMapping maps a network of the descriptor to a network of the VM
+            properties:
+              multus:
+                description: Represents the multus cni network.
+                properties:
+                  default:
+                    description: Select the default network and add it to the multus-cni.io/default-network annotation.
+                    type: boolean
+                  networkName:
+                    description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.'
+                    type: string
+                required:
+                - networkName
+                type: object
+              name:
+                description: Name of the network in the descriptor
+                type: string
+              pod:
+                description: Represents the stock pod network interface.
+                properties:
+                  vmIPv6NetworkCIDR:
+                    description: IPv6 CIDR for the vm network of masquerade interfaces. Default fd10:0:2::/120 if not specified.
+                    type: string
+                  vmNetworkCIDR:
+                    description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
+                    type: string
+                type: object
+            required:
+            - name
+            type: object
+          type: array
+        running:
+          description: Running of the created VirtualMachine, defaults to false
+          type: boolean
+        source:
+          description: OVFSource is the location of the OVF descriptor, the disks it references are imported from locations relative to it
+          properties:
+            certConfigMap:
+              description: CertConfigMap names a ConfigMap with the ca.pem of the server
+              type: string
+            secretRef:
+              description: SecretRef names a Secret with the accessKeyId and secretKey used for basic authentication
+              type: string
+            url:
+              description: URL of the descriptor, served over http or https
+              type: string
+          required:
+          - url
+          type: object
+        storageClassName:
+          description: StorageClassName of the PersistentVolumeClaims the disks are imported to
+          type: string
+        virtualMachineName:
+          description: Name of the created VirtualMachine, defaults to the name of the virtual system
+          type: string
+      required:
+      - source
       type: object
     status:
-      description: Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.
-      nullable: true
+      description: VirtualMachineOVFImportStatus is the status for a VirtualMachineOVFImport resource
       properties:
-        conditions:
+        disks:
           items:
+            description: DiskImportStatus is the status of the import of one disk of the appliance
             properties:
-              lastProbeTime:
-                format: date-time
-                nullable: true
+              dataVolumeName:
                 type: string
-              lastTransitionTime:
-                format: date-time
-                nullable: true
+              diskId:
+                description: DiskID of the disk in the descriptor
                 type: string
-              message:
+              file:
+                description: File of the disk in the OVA archive, which is extracted to the blank DataVolume
                 type: string
-              reason:
+              phase:
                 type: string
-              status:
+              progress:
                 type: string
-              type:
+            required:
+            - dataVolumeName
+            - diskId
+            type: object
+          type: array
+        message:
+          type: string
+        phase:
+          description: VirtualMachineOVFImportPhase is the phase of a VirtualMachineOVFImport
+          type: string
+        progress:
+          description: Progress of the import of all disks
+          type: string
+        virtualMachineName:
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinev2vimport": `openAPIV3Schema:
+  description: VirtualMachineV2VImport defines the cold migration of a VM of another hypervisor, whose disks are converted by virt-v2v
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineV2VImportSpec is the spec for a VirtualMachineV2VImport resource
+      properties:
+        networkMappings:
+          description: NetworkMappings attach the interfaces on the networks of the source VM to networks of the VM, interfaces on networks which are not mapped are attached to the pod network
+          items:
+            description: NetworkMapping maps a network of the descriptor to a network of the VM
+            properties:
+              multus:
+                description: Represents the multus cni network.
+                properties:
+                  default:
+                    description: Select the default network and add it to the multus-cni.io/default-network annotation.
+                    type: boolean
+                  networkName:
+                    description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.'
+                    type: string
+                required:
+                - networkName
+                type: object
+              name:
+                description: Name of the network in the descriptor
                 type: string
+              pod:
+                description: Represents the stock pod network interface.
+                properties:
+                  vmIPv6NetworkCIDR:
+                    description: IPv6 CIDR for the vm network of masquerade interfaces. Default fd10:0:2::/120 if not specified.
+                    type: string
+                  vmNetworkCIDR:
+                    description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
+                    type: string
+                type: object
             required:
-            - status
-            - type
+            - name
             type: object
           type: array
-        labelSelector:
-          description: Canonical form of the label selector for HPA which consumes it through the scale subresource.
+        running:
+          description: Running of the created VirtualMachine, defaults to false
+          type: boolean
+        source:
+          description: V2VSource is the VM to convert, exactly one source has to be set
+          properties:
+            ova:
+              description: OVASource is an OVA archive stored on a PersistentVolumeClaim, as exported by oVirt or vSphere
+              properties:
+                claimName:
+                  description: ClaimName of the PersistentVolumeClaim holding the archive
+                  type: string
+                path:
+                  description: Path of the archive on the PersistentVolumeClaim
+                  type: string
+              required:
+              - claimName
+              - path
+              type: object
+            vmware:
+              description: VMwareSource is a VM managed by vCenter or an ESXi host, it has to be shut down
+              properties:
+                secretRef:
+                  description: SecretRef names a Secret with the password of the user of the URL under the password key
+                  type: string
+                url:
+                  description: URL of the libvirt connection to the hypervisor, like vpx://vcenter.example.com/Datacenter/esxi.example.com?no_verify=1
+                  type: string
+                vmName:
+                  description: VMName is the name of the VM in the inventory
+                  type: string
+              required:
+              - secretRef
+              - url
+              - vmName
+              type: object
+          type: object
+        storageClassName:
+          description: StorageClassName of the PersistentVolumeClaims the disks are converted to
+          type: string
+        virtualMachineName:
+          description: Name of the created VirtualMachine, defaults to the name of the source VM
+          type: string
+      required:
+      - source
+      type: object
+    status:
+      description: VirtualMachineV2VImportStatus is the status for a VirtualMachineV2VImport resource
+      properties:
+        disks:
+          items:
+            description: ConvertedDisk is a disk converted by virt-v2v
+            properties:
+              claimName:
+                description: ClaimName of the PersistentVolumeClaim the disk is copied to
+                type: string
+              file:
+                description: File of the disk on the scratch volume
+                type: string
+              size:
+                anyOf:
+                - type: integer
+                - type: string
+                description: Size of the disk
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
+            required:
+            - claimName
+            - file
+            - size
+            type: object
+          type: array
+        message:
+          type: string
+        phase:
+          description: VirtualMachineV2VImportPhase is the phase of a VirtualMachineV2VImport
+          type: string
+        progress:
+          description: Progress of the conversion, as reported by virt-v2v
+          type: string
+        scratchClaimName:
+          description: ScratchClaimName is the PersistentVolumeClaim virt-v2v converts the VM to
+          type: string
+        scratchSize:
+          anyOf:
+          - type: integer
+          - type: string
+          description: ScratchSize estimated by virt-v2v
+          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+          x-kubernetes-int-or-string: true
+        virtualMachineName:
           type: string
-        readyReplicas:
-          description: The number of ready replicas for this replica set.
-          format: int32
-          type: integer
-        replicas:
-          description: Total number of non-terminated pods targeted by this deployment (their labels match the selector).
-          format: int32
-          type: integer
       type: object
   required:
   - spec
@@ -7502,6 +9847,9 @@ var CRDsValidation map[string]string = map[string]string{
         deletionPolicy:
           description: DeletionPolicy defines that to do with VirtualMachineSnapshot when VirtualMachineSnapshot is deleted
           type: string
+        quiesce:
+          description: Quiesce freezes the guest file systems through the guest agent while the volumes of a running VM are snapshotted
+          type: boolean
         source:
           description: TypedLocalObjectReference contains enough information to let you locate the typed referenced object inside the same namespace.
           properties:
@@ -7831,6 +10179,47 @@ var CRDsValidation map[string]string = map[string]string{
                         - spec
                         type: object
                       type: array
+                    hibernation:
+                      description: Hibernation allows to hibernate the VirtualMachine. The memory of the guest is saved to a PersistentVolumeClaim managed by KubeVirt and restored on the next start.
+                      properties:
+                        storageClassName:
+                          description: StorageClassName of the PersistentVolumeClaim, the default storage class is used if empty
+                          type: string
+                      type: object
+                    powerSchedule:
+                      description: PowerSchedule starts and stops the VirtualMachine at the times of cron schedules, e.g. to stop development VMs at night.
+                      properties:
+                        skipIfInUse:
+                          description: SkipIfInUse skips a scheduled stop while the VirtualMachine is in use, that is users are logged in to the guest or the VirtualMachineInstance is migrating
+                          type: boolean
+                        start:
+                          description: Start is the schedule in cron format the VirtualMachine is started at, e.g. "0 8 * * 1-5"
+                          type: string
+                        stop:
+                          description: Stop is the schedule in cron format the VirtualMachine is stopped at, e.g. "0 20 * * *"
+                          type: string
+                        timeZone:
+                          description: TimeZone the schedules are evaluated in, a name of the IANA time zone database like "Europe/Berlin". Defaults to UTC.
+                          type: string
+                      type: object
+                    replication:
+                      description: Replication copies the disks of the VirtualMachine asynchronously to a disaster recovery cluster, which keeps a stopped copy of the VirtualMachine to start if this cluster fails.
+                      properties:
+                        interval:
+                          description: Interval between the copies of the volumes replicated with rsync, one hour if not set
+                          type: string
+                        namespace:
+                          description: Namespace of the copy in the disaster recovery cluster, defaults to the namespace key of the pairing Secret
+                          type: string
+                        pairingSecretRef:
+                          description: PairingSecretRef is the name of the Secret granting access to the disaster recovery cluster, with the server and token and the optional ca.crt and namespace keys
+                          type: string
+                        storageClassName:
+                          description: StorageClassName of the PersistentVolumeClaims created in the disaster recovery cluster for the volumes replicated with rsync, the default storage class is used if empty
+                          type: string
+                      required:
+                      - pairingSecretRef
+                      type: object
                     runStrategy:
                       description: Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running
                       type: string
@@ -8294,6 +10683,9 @@ var CRDsValidation map[string]string = map[string]string{
                             domain:
                               description: Specification of the desired behavior of the VirtualMachineInstance on the host.
                               properties:
+                                allowEmulation:
+                                  description: AllowEmulation overrides whether the VMI may fall back to software emulation on nodes without /dev/kvm, within the emulation policy of the cluster.
+                                  type: boolean
                                 chassis:
                                   description: Chassis specifies the chassis info passed to the domain.
                                   properties:
@@ -8404,6 +10796,19 @@ var CRDsValidation map[string]string = map[string]string{
                                     model:
                                       description: Model specifies the CPU model inside the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map. It is possible to specify special cases like "host-passthrough" to get the same CPU as the node and "host-model" to get CPU closest to the node one. Defaults to host-model.
                                       type: string
+                                    nestedVirtualization:
+                                      description: NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.
+                                      type: boolean
+                                    numa:
+                                      description: NUMA allows specifying settings for the guest NUMA topology
+                                      properties:
+                                        guestMappingPassthrough:
+                                          description: GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes. Requires DedicatedCPUPlacement and Hugepages.
+                                          type: object
+                                      type: object
+                                    sideChannelIsolation:
+                                      description: SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and flushes the L1 data cache on every VM entry where L1TF applies.
+                                      type: boolean
                                     sockets:
                                       description: Sockets specifies the number of sockets inside the vmi. Must be a value greater or equal 1.
                                       format: int32
@@ -8419,6 +10824,9 @@ var CRDsValidation map[string]string = map[string]string{
                                     autoattachGraphicsDevice:
                                       description: Whether to attach the default graphics device or not. VNC will not be available if set to false. Defaults to true.
                                       type: boolean
+                                    autoattachInputDevice:
+                                      description: Whether to attach a tablet when the graphics device is attached and no inputs are specified. The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.
+                                      type: boolean
                                     autoattachMemBalloon:
                                       description: Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.
                                       type: boolean
@@ -8428,9 +10836,24 @@ var CRDsValidation map[string]string = map[string]string{
                                     autoattachSerialConsole:
                                       description: Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.
                                       type: boolean
+                                    autoattachVirtioWinDriverDisk:
+                                      description: Whether to attach the virtio-win driver disk as a CD-ROM, which holds the virtio drivers Windows needs to install on virtio disks and to use virtio network interfaces. The image of the disk is configured in the KubeVirt CR. Defaults to false.
+                                      type: boolean
                                     blockMultiQueue:
                                       description: Whether or not to enable virtio multi-queue for block devices
                                       type: boolean
+                                    channels:
+                                      description: Channels are additional virtio-serial channels for custom communication between the host and the guest.
+                                      items:
+                                        properties:
+                                          name:
+                                            description: Name is the name of the virtio-serial port, like org.example.agent.0. The guest finds the channel at /dev/virtio-ports/<name>.
+                                            type: string
+                                        required:
+                                        - name
+                                        type: object
+                                      type: array
+                                      x-kubernetes-list-type: atomic
                                     disableHotplug:
                                       description: DisableHotplug disabled the ability to hotplug disks.
                                       type: boolean
@@ -8503,7 +10926,10 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: Serial provides the ability to specify a serial number for the disk device.
                                             type: string
                                           tag:
-                                            description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                                            description: If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
+                                            type: string
+                                          wwn:
+                                            description: WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.
                                             type: string
                                         required:
                                         - name
@@ -8562,11 +10988,14 @@ var CRDsValidation map[string]string = map[string]string{
                                           bus:
                                             description: 'Bus indicates the bus of input device to emulate. Supported values: virtio, usb.'
                                             type: string
+                                          model:
+                                            description: 'Model indicates the model of a virtio input device. Supported values: virtio, virtio-transitional, virtio-non-transitional.'
+                                            type: string
                                           name:
                                             description: Name is the device name
                                             type: string
                                           type:
-                                            description: 'Type indicated the type of input device. Supported values: tablet.'
+                                            description: 'Type indicated the type of input device. Supported values: tablet, keyboard.'
                                             type: string
                                         required:
                                         - name
@@ -8581,6 +11010,22 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: BootOrder is an integer value > 0, used to determine ordering of boot devices. Lower values take precedence. Each interface or disk that has a boot order must have a unique value. Interfaces without a boot order are not tried.
                                             type: integer
                                           bridge:
+                                            properties:
+                                              guestAddress:
+                                                description: GuestAddress declares the address the guest uses when the pod network does not provide one. The bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.
+                                                properties:
+                                                  gateway:
+                                                    description: Gateway offered to the guest in the static DHCP lease.
+                                                    type: string
+                                                  ip:
+                                                    description: IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.
+                                                    type: string
+                                                  serveDHCP:
+                                                    description: ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.
+                                                    type: boolean
+                                                required:
+                                                - ip
+                                                type: object
                                             type: object
                                           dhcpOptions:
                                             description: If specified the network interface will pass additional DHCP options to the VMI
@@ -8588,6 +11033,9 @@ var CRDsValidation map[string]string = map[string]string{
                                               bootFileName:
                                                 description: If specified will pass option 67 to interface's DHCP server
                                                 type: string
+                                              nextServer:
+                                                description: If specified will set the next server address (siaddr) of the replies of interface's DHCP server, the address of the boot server. Must be an IPv4 address.
+                                                type: string
                                               ntpServers:
                                                 description: If specified will pass the configured NTP server to the VM via DHCP option 042.
                                                 items:
@@ -8609,16 +11057,41 @@ var CRDsValidation map[string]string = map[string]string{
                                                   - value
                                                   type: object
                                                 type: array
+                                              tftpProxy:
+                                                description: If set, virt-launcher relays the TFTP requests of the guest, which it sends to the gateway of a masquerade interface, to the next server or the TFTP server. The DHCP server passes the gateway as next server and TFTP server then.
+                                                type: boolean
                                               tftpServerName:
                                                 description: If specified will pass option 66 to interface's DHCP server
                                                 type: string
                                             type: object
+                                          latencyProfile:
+                                            description: Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls the tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net threads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs. Only supported on virtio bridge and masquerade interfaces.
+                                            type: string
                                           macAddress:
                                             description: 'Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
                                             type: string
                                           macvtap:
                                             type: object
                                           masquerade:
+                                            properties:
+                                              clampMSS:
+                                                description: ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU, so that they do not stall when the MTU of the pod is lower than the one the guest assumes. Defaults to false.
+                                                type: boolean
+                                              hairpin:
+                                                description: Hairpin selects which connections originating in the pod itself are forwarded to the VM. "loopback" forwards connections to the loopback address on the forwarded ports, "none" leaves all of them to processes in the pod and "full" forwards connections to any local address of the pod, including the VM connecting to its own service IP. Defaults to loopback.
+                                                type: string
+                                            type: object
+                                          mirror:
+                                            description: Copy the traffic of the interface to a monitoring network. Only supported on bridge and masquerade interfaces.
+                                            properties:
+                                              direction:
+                                                description: 'Traffic to copy, as seen from the guest: rx, tx or both. Defaults to both.'
+                                                type: string
+                                              network:
+                                                description: Name of the Multus network which receives the copied traffic. It must not be the network of an interface.
+                                                type: string
+                                            required:
+                                            - network
                                             type: object
                                           model:
                                             description: 'Interface model. One of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio. Defaults to virtio. TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51'
@@ -8626,6 +11099,25 @@ var CRDsValidation map[string]string = map[string]string{
                                           name:
                                             description: Logical name of the interface as well as a reference to the associated networks. Must match the Name of a Network.
                                             type: string
+                                          offloads:
+                                            description: Offloads of the host side tap device of the interface. Only supported on bridge and masquerade interfaces.
+                                            properties:
+                                              gro:
+                                                description: Generic receive offload.
+                                                type: boolean
+                                              gso:
+                                                description: Generic segmentation offload.
+                                                type: boolean
+                                              tso:
+                                                description: TCP segmentation offload.
+                                                type: boolean
+                                              txChecksum:
+                                                description: TX checksum offload of the pod side bridge serving the interface. When not set, it is only disabled if the DHCP server of KubeVirt answers the guest on the bridge, since some guest DHCP clients drop its replies otherwise.
+                                                type: boolean
+                                            type: object
+                                          packedRing:
+                                            description: Use packed virtqueues instead of split virtqueues for the interface, which reduces the latency of the guest network. Only supported on virtio interfaces.
+                                            type: boolean
                                           pciAddress:
                                             description: 'If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10'
                                             type: string
@@ -8648,13 +11140,36 @@ var CRDsValidation map[string]string = map[string]string{
                                               - port
                                               type: object
                                             type: array
+                                          roles:
+                                            description: 'Roles the interface fulfills in the guest: management, storage or workload. They are published to the guest as SMBIOS OEM strings of the form io.kubevirt.interface.role:<role>=<MAC address>.'
+                                            items:
+                                              type: string
+                                            type: array
+                                          rss:
+                                            description: Receive side scaling of the interface. The device steers the received packets to the queues by their hash, as configured by the guest, which spreads the load of the guest network over the vCPUs. Only supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.
+                                            properties:
+                                              hashReport:
+                                                description: Report the hash of the received packets to the guest, which saves the guest from calculating it again, e.g. for receive flow steering.
+                                                type: boolean
+                                            type: object
+                                          rxQueueSize:
+                                            description: Size of the virtio RX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                                            format: int32
+                                            type: integer
                                           slirp:
                                             type: object
                                           sriov:
                                             type: object
                                           tag:
-                                            description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+                                            description: If specified, the virtual network interface address and its tag will be provided to the guest via config drive. The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>
                                             type: string
+                                          txQueueSize:
+                                            description: Size of the virtio TX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+                                            format: int32
+                                            type: integer
+                                          vhostZeroCopyTX:
+                                            description: Request zero copy transmission by vhost-net for the interface. The VMI is only scheduled on nodes where the vhost_net kernel module has zero copy transmission enabled. Only supported on virtio interfaces which are not bound with slirp.
+                                            type: boolean
                                         required:
                                         - name
                                         type: object
@@ -8662,9 +11177,16 @@ var CRDsValidation map[string]string = map[string]string{
                                     networkInterfaceMultiqueue:
                                       description: If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
                                       type: boolean
+                                    networkInterfaceQueues:
+                                      description: Number of queues of the virtio interfaces with networkInterfaceMultiqueue. Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.
+                                      format: int32
+                                      type: integer
                                     rng:
                                       description: Whether to have random number generator from host
                                       type: object
+                                    usbControllerModel:
+                                      description: 'Model of the USB controller, which is attached when an input device is on the usb bus. Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.'
+                                      type: string
                                     watchdog:
                                       description: Watchdog describes a watchdog device which can be added to the vmi.
                                       properties:
@@ -8829,6 +11351,17 @@ var CRDsValidation map[string]string = map[string]string{
                                 firmware:
                                   description: Firmware.
                                   properties:
+                                    bootMenu:
+                                      description: Settings to control the interactive boot menu of the firmware.
+                                      properties:
+                                        enabled:
+                                          description: If set, the firmware offers a boot menu. Defaults to true
+                                          type: boolean
+                                        timeout:
+                                          description: Time in milliseconds the boot menu waits for a selection before booting the default entry. Must be at most 65535.
+                                          format: int32
+                                          type: integer
+                                      type: object
                                     bootloader:
                                       description: Settings to control the bootloader that is used.
                                       properties:
@@ -8847,6 +11380,9 @@ var CRDsValidation map[string]string = map[string]string{
                                               type: boolean
                                           type: object
                                       type: object
+                                    identityPolicy:
+                                      description: IdentityPolicy controls how the UUID and the serial are generated when they are not set. Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores. RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that clones get new ones, while restores keep them. Defaults to Keep.
+                                      type: string
                                     serial:
                                       description: The system-serial-number in SMBIOS
                                       type: string
@@ -8915,6 +11451,9 @@ var CRDsValidation map[string]string = map[string]string{
                             evictionStrategy:
                               description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.
                               type: string
+                            guestOS:
+                              description: 'GuestOS declares the operating system of the guest, e.g. "windows2k19" or "rhel8". The disk bus, the interface model, the clock and the inputs which are neither set on the vmi nor by a preset are defaulted to what suits the guest OS.'
+                              type: string
                             hostname:
                               description: Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
                               type: string
@@ -8994,6 +11533,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   format: int32
                                   type: integer
                               type: object
+                            metadataService:
+                              description: MetadataService serves the metadata and the user-data of the vmi and the token of its service account to the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.
+                              type: object
                             networks:
                               description: List of networks that can be attached to a vm's virtual interface.
                               items:
@@ -9006,7 +11548,7 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: Select the default network and add it to the multus-cni.io/default-network annotation.
                                         type: boolean
                                       networkName:
-                                        description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.'
+                                        description: 'References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.'
                                         type: string
                                     required:
                                     - networkName
@@ -9017,6 +11559,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   pod:
                                     description: Represents the stock pod network interface.
                                     properties:
+                                      vmIPv6NetworkCIDR:
+                                        description: IPv6 CIDR for the vm network of masquerade interfaces. Default fd10:0:2::/120 if not specified.
+                                        type: string
                                       vmNetworkCIDR:
                                         description: CIDR for vm network. Default 10.0.2.0/24 if not specified.
                                         type: string
@@ -9033,6 +11578,9 @@ var CRDsValidation map[string]string = map[string]string{
                             priorityClassName:
                               description: If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.
                               type: string
+                            qosClass:
+                              description: QoSClass selects the tier of disk and network service the vmi gets on the node. Valid values are the QoS classes of the KubeVirt configuration, "gold", "silver" and "bronze" by default. No QoS is applied if not set. Unrelated to the pod QoS class reported in the status.
+                              type: string
                             readinessProbe:
                               description: 'Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
                               properties:
@@ -9223,6 +11771,22 @@ var CRDsValidation map[string]string = map[string]string{
                                   containerDisk:
                                     description: 'ContainerDisk references a docker image, embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
                                     properties:
+                                      checksum:
+                                        description: Checksum is the expected digest of the disk in the image, in the form sha256:<hex>. The disk is verified before the VMI is started, which fails on a mismatch.
+                                        type: string
+                                      ephemeralImage:
+                                        description: EphemeralImage configures the writable image the VMI runs on.
+                                        properties:
+                                          compressed:
+                                            description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                                            type: boolean
+                                          format:
+                                            description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                                            type: string
+                                          preallocation:
+                                            description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                                            type: string
+                                        type: object
                                       image:
                                         description: Image is the name of the image with the embedded disk.
                                         type: string
@@ -9241,6 +11805,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   dataVolume:
                                     description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
                                     properties:
+                                      checksum:
+                                        description: Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.
+                                        type: string
                                       name:
                                         description: Name represents the name of the DataVolume in the same namespace
                                         type: string
@@ -9314,9 +11881,35 @@ var CRDsValidation map[string]string = map[string]string{
                                     required:
                                     - capacity
                                     type: object
+                                  encryption:
+                                    description: Encryption layers LUKS encryption on top of the volume, which is handled by qemu. Only PersistentVolumeClaim and DataVolume volumes can be encrypted.
+                                    properties:
+                                      secretRef:
+                                        description: SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the "key" key. Keys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.
+                                        properties:
+                                          name:
+                                            description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?'
+                                            type: string
+                                        type: object
+                                    required:
+                                    - secretRef
+                                    type: object
                                   ephemeral:
                                     description: Ephemeral is a special volume source that "wraps" specified source and provides copy-on-write image on top of it.
                                     properties:
+                                      ephemeralImage:
+                                        description: EphemeralImage configures the writable image the VMI runs on.
+                                        properties:
+                                          compressed:
+                                            description: Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.
+                                            type: boolean
+                                          format:
+                                            description: Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.
+                                            type: string
+                                          preallocation:
+                                            description: Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.
+                                            type: string
+                                        type: object
                                       persistentVolumeClaim:
                                         description: 'PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace. Directly attached to the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
                                         properties:
@@ -9388,6 +11981,19 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: 'Name of the service account in the pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
                                         type: string
                                     type: object
+                                  vhostUserBlk:
+                                    description: VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.
+                                    properties:
+                                      claimName:
+                                        description: ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume contains the socket, like the volumes of an SPDK CSI driver
+                                        type: string
+                                      socket:
+                                        description: Socket is the path of the vhost-user-blk socket in the volume
+                                        type: string
+                                    required:
+                                    - claimName
+                                    - socket
+                                    type: object
                                 required:
                                 - name
                                 type: object
@@ -9431,9 +12037,133 @@ var CRDsValidation map[string]string = map[string]string{
                     created:
                       description: Created indicates if the virtual machine is created in the cluster
                       type: boolean
+                    fsFreezeStatus:
+                      description: FSFreezeStatus is "frozen" while the guest file systems of the VirtualMachineInstance are frozen, and empty otherwise
+                      type: string
+                    hibernation:
+                      description: Hibernation reports the PersistentVolumeClaim holding the memory of the guest and whether the VirtualMachine is hibernated. It is only set if hibernation is configured.
+                      properties:
+                        claimName:
+                          description: ClaimName is the name of the PersistentVolumeClaim holding the memory of the guest
+                          type: string
+                        hibernated:
+                          description: Hibernated indicates that the memory of the guest is saved and will be restored on the next start
+                          type: boolean
+                        hibernationTime:
+                          description: HibernationTime is the time the memory of the guest was saved
+                          format: date-time
+                          nullable: true
+                          type: string
+                        size:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Size is the capacity of the PersistentVolumeClaim, or its requested size while it is not bound
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                      required:
+                      - claimName
+                      type: object
+                    idleStatus:
+                      description: IdleStatus reports the activity of the VirtualMachine and whether it was suspended for being idle. It is only set for running VirtualMachines the idle policy applies to.
+                      properties:
+                        action:
+                          description: Action is the action taken on the idle VirtualMachine, empty until it is taken
+                          type: string
+                        idle:
+                          description: Idle is true if the VirtualMachine had no activity for the window of the idle policy
+                          type: boolean
+                        lastActivityTime:
+                          description: LastActivityTime is the last time the guest was above a threshold of the idle policy, or its console was in use
+                          format: date-time
+                          nullable: true
+                          type: string
+                      type: object
+                    lastSnapshotTime:
+                      description: LastSnapshotTime is the creation time of the last VirtualMachineSnapshot of the VM which became ready to use
+                      format: date-time
+                      nullable: true
+                      type: string
+                    powerScheduleStatus:
+                      description: PowerScheduleStatus reports the last and next runs of the power schedule. It is only set if a power schedule is configured.
+                      properties:
+                        lastStartTime:
+                          description: LastStartTime is the time of the last scheduled start
+                          format: date-time
+                          nullable: true
+                          type: string
+                        lastStopTime:
+                          description: LastStopTime is the time of the last scheduled stop
+                          format: date-time
+                          nullable: true
+                          type: string
+                        message:
+                          description: Message reports an invalid schedule or a skipped stop
+                          type: string
+                        nextStartTime:
+                          description: NextStartTime is the time of the next scheduled start
+                          format: date-time
+                          nullable: true
+                          type: string
+                        nextStopTime:
+                          description: NextStopTime is the time of the next scheduled stop
+                          format: date-time
+                          nullable: true
+                          type: string
+                      type: object
+                    printableStatus:
+                      description: PrintableStatus is a human readable, high-level summary of the state of the virtual machine, including the reason why it is not running if its virtual machine instance can't be started
+                      type: string
                     ready:
                       description: Ready indicates if the virtual machine is running and ready
                       type: boolean
+                    replicationStatus:
+                      description: ReplicationStatus reports the replication of the disks to the disaster recovery cluster. It is only set if replication is configured.
+                      properties:
+                        lastSyncTime:
+                          description: LastSyncTime is the time all volumes were last replicated, the state the copy in the disaster recovery cluster is in
+                          format: date-time
+                          nullable: true
+                          type: string
+                        message:
+                          description: Message explains the phase
+                          type: string
+                        phase:
+                          description: Phase of the replication, the least advanced phase of the volumes
+                          type: string
+                        volumes:
+                          description: Volumes reports the replication of the volumes backed by PersistentVolumeClaims
+                          items:
+                            description: VolumeReplicationStatus reports the replication of a volume of a VirtualMachine.
+                            properties:
+                              claimName:
+                                description: ClaimName is the name of the PersistentVolumeClaim, the copy in the disaster recovery cluster has the same name
+                                type: string
+                              lastSyncTime:
+                                description: LastSyncTime is the time the volume was last replicated
+                                format: date-time
+                                nullable: true
+                                type: string
+                              message:
+                                description: Message explains the phase
+                                type: string
+                              method:
+                                description: Method the volume is replicated with
+                                type: string
+                              name:
+                                description: Name of the volume
+                                type: string
+                              phase:
+                                description: Phase of the replication of the volume
+                                type: string
+                            required:
+                            - claimName
+                            - method
+                            - name
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                      type: object
                     snapshotInProgress:
                       description: SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing
                       type: string
@@ -9456,6 +12186,135 @@ var CRDsValidation map[string]string = map[string]string{
                         - action
                         type: object
                       type: array
+                    usage:
+                      description: Usage accumulates the resources consumed by the VirtualMachine in the current and the previous billing period, for chargeback. It is only maintained when usage accounting is enabled.
+                      properties:
+                        current:
+                          description: Current is the usage accumulated in the running billing period
+                          properties:
+                            end:
+                              description: End is the end of the billing period
+                              format: date-time
+                              nullable: true
+                              type: string
+                            memoryMebibyteSeconds:
+                              description: MemoryMebibyteSeconds is the guest memory in MiB of the VirtualMachineInstance, summed up over every second it was running
+                              format: int64
+                              type: integer
+                            networkReceiveBytes:
+                              description: NetworkReceiveBytes is the number of bytes received by the VirtualMachineInstance
+                              format: int64
+                              type: integer
+                            networkTransmitBytes:
+                              description: NetworkTransmitBytes is the number of bytes transmitted by the VirtualMachineInstance
+                              format: int64
+                              type: integer
+                            start:
+                              description: Start is the beginning of the billing period
+                              format: date-time
+                              nullable: true
+                              type: string
+                            storageBytes:
+                              description: StorageBytes is the capacity of the PersistentVolumeClaims and DataVolumes of the VirtualMachine at the last accumulation
+                              format: int64
+                              type: integer
+                            vcpuSeconds:
+                              description: VCPUSeconds is the number of vCPUs allocated to the VirtualMachineInstance, summed up over every second it was running
+                              format: int64
+                              type: integer
+                          required:
+                          - end
+                          - memoryMebibyteSeconds
+                          - networkReceiveBytes
+                          - networkTransmitBytes
+                          - start
+                          - storageBytes
+                          - vcpuSeconds
+                          type: object
+                        instance:
+                          description: Instance is the state of the VirtualMachineInstance at the last accumulation. It is meant to be used by KubeVirt core components only.
+                          properties:
+                            memoryMebibytes:
+                              description: MemoryMebibytes is the guest memory of the VirtualMachineInstance in MiB
+                              format: int64
+                              type: integer
+                            networkReceiveBytes:
+                              description: NetworkReceiveBytes is the receive counter reported for the VirtualMachineInstance
+                              format: int64
+                              type: integer
+                            networkTransmitBytes:
+                              description: NetworkTransmitBytes is the transmit counter reported for the VirtualMachineInstance
+                              format: int64
+                              type: integer
+                            running:
+                              description: Running indicates if the VirtualMachineInstance was running
+                              type: boolean
+                            uid:
+                              description: UID of the VirtualMachineInstance
+                              type: string
+                            vcpus:
+                              description: VCPUs is the number of vCPUs allocated to the VirtualMachineInstance
+                              format: int64
+                              type: integer
+                          required:
+                          - memoryMebibytes
+                          - networkReceiveBytes
+                          - networkTransmitBytes
+                          - running
+                          - uid
+                          - vcpus
+                          type: object
+                        lastUpdateTime:
+                          description: LastUpdateTime is the time the usage was last accumulated
+                          format: date-time
+                          nullable: true
+                          type: string
+                        previous:
+                          description: Previous is the usage of the last completed billing period
+                          properties:
+                            end:
+                              description: End is the end of the billing period
+                              format: date-time
+                              nullable: true
+                              type: string
+                            memoryMebibyteSeconds:
+                              description: MemoryMebibyteSeconds is the guest memory in MiB of the VirtualMachineInstance, summed up over every second it was running
+                              format: int64
+                              type: integer
+                            networkReceiveBytes:
+                              description: NetworkReceiveBytes is the number of bytes received by the VirtualMachineInstance
+                              format: int64
+                              type: integer
+                            networkTransmitBytes:
+                              description: NetworkTransmitBytes is the number of bytes transmitted by the VirtualMachineInstance
+                              format: int64
+                              type: integer
+                            start:
+                              description: Start is the beginning of the billing period
+                              format: date-time
+                              nullable: true
+                              type: string
+                            storageBytes:
+                              description: StorageBytes is the capacity of the PersistentVolumeClaims and DataVolumes of the VirtualMachine at the last accumulation
+                              format: int64
+                              type: integer
+                            vcpuSeconds:
+                              description: VCPUSeconds is the number of vCPUs allocated to the VirtualMachineInstance, summed up over every second it was running
+                              format: int64
+                              type: integer
+                          required:
+                          - end
+                          - memoryMebibyteSeconds
+                          - networkReceiveBytes
+                          - networkTransmitBytes
+                          - start
+                          - storageBytes
+                          - vcpuSeconds
+                          type: object
+                      required:
+                      - current
+                      - lastUpdateTime
+                      type: object
                     volumeRequests:
                       description: VolumeRequests indicates a list of volumes add or remove from the VMI template and hotplug on an active running VMI.
                       items:
@@ -9531,7 +12390,10 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: Serial provides the ability to specify a serial number for the disk device.
                                     type: string
                                   tag:
-                                    description: If specified, disk address and its tag will be provided to the guest via config drive metadata
+                                    description: If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
+                                    type: string
+                                  wwn:
+                                    description: WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.
                                     type: string
                                 required:
                                 - name
@@ -9545,6 +12407,9 @@ var CRDsValidation map[string]string = map[string]string{
                                   dataVolume:
                                     description: DataVolume represents the dynamic creation a PVC for this volume as well as the process of populating that PVC with a disk image.
                                     properties:
+                                      checksum:
+                                        description: Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.
+                                        type: string
                                       name:
                                         description: Name represents the name of the DataVolume in the same namespace
                                         type: string
@@ -9762,5 +12627,134 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinesnapshotschedule": `openAPIV3Schema:
+  description: VirtualMachineSnapshotSchedule defines a schedule for snapshotting a VM periodically
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource
+      properties:
+        deletionPolicy:
+          description: DeletionPolicy of the VirtualMachineSnapshots created by the schedule
+          type: string
+        quiesce:
+          description: Quiesce snapshots running VMs with their guest file systems frozen through the guest agent, otherwise only stopped VMs are snapshotted
+          type: boolean
+        retention:
+          description: Retention is the number of ready snapshots to keep, older ones are deleted. All snapshots are kept if not set.
+          format: int32
+          type: integer
+        schedule:
+          description: Schedule in cron format, evaluated in UTC, e.g. "0 2 * * *" or "@daily"
+          type: string
+        source:
+          description: TypedLocalObjectReference contains enough information to let you locate the typed referenced object inside the same namespace.
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced. If APIGroup is not specified, the specified Kind must be in the core API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+      required:
+      - schedule
+      - source
+      type: object
+    status:
+      description: VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource
+      properties:
+        error:
+          description: Error is the last error encountered during the snapshot/restore
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        lastScheduleTime:
+          format: date-time
+          nullable: true
+          type: string
+        lastSnapshotName:
+          type: string
+        nextScheduleTime:
+          format: date-time
+          nullable: true
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinetemplate": `openAPIV3Schema:
+  description: VirtualMachineTemplate is a curated VirtualMachine offering, the VirtualMachine it describes is created by processing the template with values for its parameters
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineTemplateSpec is the spec for a VirtualMachineTemplate resource
+      properties:
+        description:
+          type: string
+        displayName:
+          description: DisplayName of the offering in catalogs
+          type: string
+        parameters:
+          items:
+            description: TemplateParameter is a value the VirtualMachine of a template is parameterized with
+            properties:
+              description:
+                type: string
+              displayName:
+                type: string
+              name:
+                description: Name referenced as ${NAME} in the VirtualMachine
+                type: string
+              required:
+                description: Required parameters need a value or a default
+                type: boolean
+              type:
+                description: Type of the parameter, defaults to String
+                type: string
+              value:
+                description: Value used when processing the template without a value for the parameter
+                type: string
+            required:
+            - name
+            type: object
+          type: array
+        virtualMachine:
+          description: VirtualMachine is the manifest of the VirtualMachine, ${NAME} references in its string values are replaced with the value of the parameter NAME
+          type: object
+          x-kubernetes-embedded-resource: true
+          x-kubernetes-preserve-unknown-fields: true
+      required:
+      - virtualMachine
+      type: object
+  required:
+  - spec
+  type: object
 `,
 }
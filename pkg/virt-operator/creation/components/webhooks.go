@@ -215,7 +215,9 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *v1beta1.
 	migrationCreatePath := MigrationCreateValidatePath
 	migrationUpdatePath := MigrationUpdateValidatePath
 	vmSnapshotValidatePath := VMSnapshotValidatePath
+	vmSnapshotScheduleValidatePath := VMSnapshotScheduleValidatePath
 	vmRestoreValidatePath := VMRestoreValidatePath
+	vmNotificationHookValidatePath := VMNotificationHookValidatePath
 	launcherEvictionValidatePath := LauncherEvictionValidatePath
 	statusValidatePath := StatusValidatePath
 	failurePolicy := v1beta1.Fail
@@ -441,6 +443,29 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *v1beta1.
 					},
 				},
 			},
+			{
+				Name:          "virtualmachinesnapshotschedule-validator.snapshot.kubevirt.io",
+				FailurePolicy: &failurePolicy,
+				SideEffects:   &sideEffectNone,
+				Rules: []v1beta1.RuleWithOperations{{
+					Operations: []v1beta1.OperationType{
+						v1beta1.Create,
+						v1beta1.Update,
+					},
+					Rule: v1beta1.Rule{
+						APIGroups:   []string{snapshotv1.SchemeGroupVersion.Group},
+						APIVersions: []string{snapshotv1.SchemeGroupVersion.Version},
+						Resources:   []string{"virtualmachinesnapshotschedules"},
+					},
+				}},
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service: &v1beta1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &vmSnapshotScheduleValidatePath,
+					},
+				},
+			},
 			{
 				Name:          "virtualmachinerestore-validator.snapshot.kubevirt.io",
 				SideEffects:   &sideEffectNone,
@@ -464,6 +489,29 @@ func NewVirtAPIValidatingWebhookConfiguration(installNamespace string) *v1beta1.
 					},
 				},
 			},
+			{
+				Name:          "virtualmachinenotificationhook-validator.kubevirt.io",
+				FailurePolicy: &failurePolicy,
+				SideEffects:   &sideEffectNone,
+				Rules: []v1beta1.RuleWithOperations{{
+					Operations: []v1beta1.OperationType{
+						v1beta1.Create,
+						v1beta1.Update,
+					},
+					Rule: v1beta1.Rule{
+						APIGroups:   []string{virtv1.GroupName},
+						APIVersions: virtv1.ApiSupportedWebhookVersions,
+						Resources:   []string{"virtualmachinenotificationhooks"},
+					},
+				}},
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service: &v1beta1.ServiceReference{
+						Namespace: installNamespace,
+						Name:      VirtApiServiceName,
+						Path:      &vmNotificationHookValidatePath,
+					},
+				},
+			},
 			{
 				Name:          "kubevirt-crd-status-validator.kubevirt.io",
 				FailurePolicy: &failurePolicy,
@@ -535,8 +583,12 @@ const KubeVirtOperatorValidatingWebhookName = "virt-operator-validator"
 
 const VMSnapshotValidatePath = "/virtualmachinesnapshots-validate"
 
+const VMSnapshotScheduleValidatePath = "/virtualmachinesnapshotschedules-validate"
+
 const VMRestoreValidatePath = "/virtualmachinerestores-validate"
 
+const VMNotificationHookValidatePath = "/virtualmachinenotificationhooks-validate"
+
 const StatusValidatePath = "/status-validate"
 
 const LauncherEvictionValidatePath = "/launcher-eviction-validate"
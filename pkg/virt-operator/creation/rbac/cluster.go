@@ -32,6 +32,10 @@ func GetAllCluster(namespace string) []interface{} {
 		newAdminClusterRole(),
 		newEditClusterRole(),
 		newViewClusterRole(),
+		newVMPowerClusterRole(),
+		newVMConsoleClusterRole(),
+		newVMMigrateClusterRole(),
+		newVMVolumesClusterRole(),
 	}
 }
 
@@ -122,7 +126,14 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachineinstances/console",
+					"virtualmachineinstances/channel",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/domainxml",
+					"virtualmachineinstances/qmp",
+					"virtualmachineinstances/hypervisorlog",
+					"virtualmachineinstances/stats",
+					"virtualmachines/domainxml",
+					"virtualmachines/validate-start",
 				},
 				Verbs: []string{
 					"get",
@@ -135,6 +146,8 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachineinstances/pause",
 					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
 				},
@@ -150,7 +163,24 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachines/start",
 					"virtualmachines/stop",
+					"virtualmachines/hibernate",
 					"virtualmachines/restart",
+					"virtualmachinetemplates/process",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinebulkoperations",
+				},
+				ResourceNames: []string{
+					"start",
+					"stop",
 				},
 				Verbs: []string{
 					"update",
@@ -166,6 +196,7 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinenotificationhooks",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -179,6 +210,31 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
+			{
+				APIGroups: []string{
+					"vmimport.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineovfimports",
+					"virtualmachinev2vimports",
+					"virtualmachineclusterimports",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
+			{
+				APIGroups: []string{
+					"template.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinetemplates",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -208,7 +264,12 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachineinstances/console",
+					"virtualmachineinstances/channel",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/domainxml",
+					"virtualmachineinstances/stats",
+					"virtualmachines/domainxml",
+					"virtualmachines/validate-start",
 				},
 				Verbs: []string{
 					"get",
@@ -221,6 +282,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachineinstances/pause",
 					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/unfreeze",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
 				},
@@ -236,7 +299,24 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				Resources: []string{
 					"virtualmachines/start",
 					"virtualmachines/stop",
+					"virtualmachines/hibernate",
 					"virtualmachines/restart",
+					"virtualmachinetemplates/process",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinebulkoperations",
+				},
+				ResourceNames: []string{
+					"start",
+					"stop",
 				},
 				Verbs: []string{
 					"update",
@@ -257,6 +337,17 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinenotificationhooks",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"snapshot.kubevirt.io",
@@ -265,6 +356,31 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"vmimport.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineovfimports",
+					"virtualmachinev2vimports",
+					"virtualmachineclusterimports",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"template.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinetemplates",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
@@ -298,6 +414,7 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinenotificationhooks",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
@@ -311,6 +428,31 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"vmimport.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineovfimports",
+					"virtualmachinev2vimports",
+					"virtualmachineclusterimports",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"template.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinetemplates",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
@@ -319,3 +461,149 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 		},
 	}
 }
+
+// newVMOperationClusterRole returns a role granting a single kind of operation
+// on VMs, which can be bound on its own for policies like "restart, but no
+// console access". The roles aggregate into the admin and edit roles.
+func newVMOperationClusterRole(name string, rules ...rbacv1.PolicyRule) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+				"rbac.authorization.k8s.io/aggregate-to-admin": "true",
+				"rbac.authorization.k8s.io/aggregate-to-edit":  "true",
+			},
+		},
+		Rules: rules,
+	}
+}
+
+func newVMPowerClusterRole() *rbacv1.ClusterRole {
+	return newVMOperationClusterRole("kubevirt.io:vm-power",
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachines/start",
+				"virtualmachines/stop",
+				"virtualmachines/hibernate",
+				"virtualmachines/restart",
+				"virtualmachineinstances/pause",
+				"virtualmachineinstances/unpause",
+			},
+			Verbs: []string{
+				"update",
+			},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachinebulkoperations",
+			},
+			ResourceNames: []string{
+				"start",
+				"stop",
+			},
+			Verbs: []string{
+				"update",
+			},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachines/validate-start",
+			},
+			Verbs: []string{
+				"get",
+			},
+		},
+	)
+}
+
+func newVMConsoleClusterRole() *rbacv1.ClusterRole {
+	return newVMOperationClusterRole("kubevirt.io:vm-console",
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachineinstances/console",
+				"virtualmachineinstances/channel",
+				"virtualmachineinstances/vnc",
+			},
+			Verbs: []string{
+				"get",
+			},
+		},
+	)
+}
+
+func newVMMigrateClusterRole() *rbacv1.ClusterRole {
+	return newVMOperationClusterRole("kubevirt.io:vm-migrate",
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachines/migrate",
+			},
+			Verbs: []string{
+				"update",
+			},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachinebulkoperations",
+			},
+			ResourceNames: []string{
+				"migrate",
+			},
+			Verbs: []string{
+				"update",
+			},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachineinstancemigrations",
+			},
+			Verbs: []string{
+				"get", "create", "list", "watch",
+			},
+		},
+	)
+}
+
+func newVMVolumesClusterRole() *rbacv1.ClusterRole {
+	return newVMOperationClusterRole("kubevirt.io:vm-volumes",
+		rbacv1.PolicyRule{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachines/addvolume",
+				"virtualmachines/removevolume",
+				"virtualmachineinstances/addvolume",
+				"virtualmachineinstances/removevolume",
+			},
+			Verbs: []string{
+				"update",
+			},
+		},
+	)
+}
@@ -109,6 +109,17 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"update",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"pods/log",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -131,6 +142,28 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch", "create", "update", "delete", "patch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"secrets",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"namespaces",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"snapshot.kubevirt.io",
@@ -142,6 +175,17 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"*",
 				},
 			},
+			{
+				APIGroups: []string{
+					"vmimport.kubevirt.io",
+				},
+				Resources: []string{
+					"*",
+				},
+				Verbs: []string{
+					"*",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -159,7 +203,15 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachineinstances/addvolume",
+					"virtualmachineinstances/freeze",
+					"virtualmachineinstances/pause",
 					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/stats",
+					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/userlist",
+					"virtualmachines/hibernate",
+					"virtualmachines/start",
+					"virtualmachines/stop",
 				},
 				Verbs: []string{
 					"get",
@@ -177,6 +229,17 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"*",
 				},
 			},
+			{
+				APIGroups: []string{
+					"upload.cdi.kubevirt.io",
+				},
+				Resources: []string{
+					"uploadtokenrequests",
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
 			{
 				APIGroups: []string{
 					"k8s.cni.cncf.io",
@@ -254,6 +317,19 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"replication.storage.openshift.io",
+				},
+				Resources: []string{
+					"volumereplications",
+				},
+				Verbs: []string{
+					"get",
+					"create",
+					"delete",
+				},
+			},
 		},
 	}
 }
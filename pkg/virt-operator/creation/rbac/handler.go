@@ -101,6 +101,17 @@ func newHandlerClusterRole() *rbacv1.ClusterRole {
 					"patch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"nodes/status",
+				},
+				Verbs: []string{
+					"patch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -208,7 +208,10 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 		components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 		components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 		components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-		components.NewVirtualMachineRestoreCrd,
+		components.NewVirtualMachineRestoreCrd, components.NewVirtualMachineOVFImportCrd,
+		components.NewVirtualMachineV2VImportCrd, components.NewVirtualMachineTemplateCrd,
+		components.NewVirtualMachineSnapshotScheduleCrd, components.NewVirtualMachineNotificationHookCrd,
+		components.NewVirtualMachineClusterImportCrd, components.NewFencingRequestCrd,
 	}
 	for _, f := range functions {
 		crd, err := f()
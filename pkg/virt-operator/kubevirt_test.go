@@ -142,9 +142,9 @@ var _ = Describe("KubeVirt Operator", func() {
 	var totalDeletions int
 	var resourceChanges map[string]map[string]int
 
-	resourceCount := 53
-	patchCount := 34
-	updateCount := 20
+	resourceCount := 64
+	patchCount := 41
+	updateCount := 24
 
 	deleteFromCache := true
 	addToCache := true
@@ -843,6 +843,13 @@ var _ = Describe("KubeVirt Operator", func() {
 			components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 			components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
 			components.NewVirtualMachineRestoreCrd,
+			components.NewVirtualMachineOVFImportCrd,
+			components.NewVirtualMachineV2VImportCrd,
+			components.NewVirtualMachineTemplateCrd,
+			components.NewVirtualMachineSnapshotScheduleCrd,
+			components.NewVirtualMachineNotificationHookCrd,
+			components.NewVirtualMachineClusterImportCrd,
+			components.NewFencingRequestCrd,
 		}
 		for _, f := range functions {
 			crd, err := f()
@@ -1921,11 +1928,11 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(totalAdds).To(Equal(resourceCount - expectedUncreatedResources + expectedTemporaryResources))
 
 			Expect(len(controller.stores.ServiceAccountCache.List())).To(Equal(3))
-			Expect(len(controller.stores.ClusterRoleCache.List())).To(Equal(7))
+			Expect(len(controller.stores.ClusterRoleCache.List())).To(Equal(11))
 			Expect(len(controller.stores.ClusterRoleBindingCache.List())).To(Equal(5))
 			Expect(len(controller.stores.RoleCache.List())).To(Equal(3))
 			Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(3))
-			Expect(len(controller.stores.CrdCache.List())).To(Equal(8))
+			Expect(len(controller.stores.CrdCache.List())).To(Equal(15))
 			Expect(len(controller.stores.ServiceCache.List())).To(Equal(3))
 			Expect(len(controller.stores.DeploymentCache.List())).To(Equal(1))
 			Expect(len(controller.stores.DaemonSetCache.List())).To(Equal(0))
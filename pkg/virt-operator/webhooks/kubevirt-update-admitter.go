@@ -27,11 +27,13 @@ import (
 	"k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	validating_webhooks "kubevirt.io/kubevirt/pkg/util/webhooks/validating-webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 )
 
 // KubeVirtUpdateAdmitter validates KubeVirt updates
@@ -57,6 +59,20 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1be
 		return resp
 	}
 
+	if causes := webhooks.ValidateVMIAdmissionPolicies(k8sfield.NewPath("spec", "configuration", "admissionPolicies"), newKV.Spec.Configuration.AdmissionPolicies); len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	if causes := webhooks.ValidateQoSClasses(k8sfield.NewPath("spec", "configuration", "qosClasses"), newKV.Spec.Configuration.QoSClasses); len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	if developerConfig := newKV.Spec.Configuration.DeveloperConfiguration; developerConfig != nil {
+		if causes := webhooks.ValidateScopedFeatureGates(k8sfield.NewPath("spec", "configuration", "developerConfiguration", "scopedFeatureGates"), developerConfig.ScopedFeatureGates); len(causes) > 0 {
+			return webhookutils.ToAdmissionResponse(causes)
+		}
+	}
+
 	if reflect.DeepEqual(newKV.Spec.Workloads, oldKV.Spec.Workloads) {
 		return validating_webhooks.NewPassingAdmissionResponse()
 	}
@@ -0,0 +1,314 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package create
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_CREATE = "create"
+	COMMAND_VM     = "vm"
+
+	BINDING_MASQUERADE = "masquerade"
+	BINDING_BRIDGE     = "bridge"
+	BINDING_SLIRP      = "slirp"
+
+	// image prefixes which select the volume source of the root disk
+	pvcImagePrefix = "pvc:"
+
+	rootDiskName  = "rootdisk"
+	cloudInitName = "cloudinitdisk"
+)
+
+var nameSanitizer = regexp.MustCompile("[^a-z0-9-]+")
+
+type createVM struct {
+	clientConfig clientcmd.ClientConfig
+
+	name          string
+	image         string
+	diskSize      string
+	cores         uint32
+	memory        string
+	binding       string
+	cloudInitUser string
+	sshKey        string
+	running       bool
+}
+
+// NewCreateCommand returns the create command, which renders manifests
+// from flags instead of creating objects on the cluster.
+func NewCreateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   COMMAND_CREATE,
+		Short: "Create a manifest for the specified Kind.",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newCreateVMCommand(clientConfig))
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func newCreateVMCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := createVM{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:   COMMAND_VM,
+		Short: "Create a VirtualMachine manifest.",
+		Long: `Create a VirtualMachine manifest and print it to stdout.
+The root disk is derived from --image: "pvc:<name>" uses an existing PVC, an http(s) URL is imported into a DataVolume and anything else is used as a containerDisk image.`,
+		Example: usage(),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd)
+		},
+	}
+	cmd.Flags().StringVar(&c.name, "name", "", "Name of the VM. Derived from --image if omitted.")
+	cmd.Flags().StringVar(&c.image, "image", "", "Root disk of the VM: a containerDisk image, an http(s) URL or pvc:<name>.")
+	cmd.Flags().StringVar(&c.diskSize, "disk-size", "10Gi", "Size of the DataVolume an http(s) image is imported into.")
+	cmd.Flags().Uint32Var(&c.cores, "cores", 1, "Number of CPU cores of the VM.")
+	cmd.Flags().StringVar(&c.memory, "memory", "1Gi", "Amount of memory of the VM.")
+	cmd.Flags().StringVar(&c.binding, "network-binding", BINDING_MASQUERADE, "Binding of the pod network interface: masquerade, bridge or slirp.")
+	cmd.Flags().StringVar(&c.cloudInitUser, "cloud-init-user", "", "Name of the default user which cloud-init sets up in the guest.")
+	cmd.Flags().StringVar(&c.sshKey, "ssh-key", "", "Public SSH key which cloud-init authorizes for the default user.")
+	cmd.Flags().BoolVar(&c.running, "running", false, "Start the VM as soon as it is created.")
+	output.AddFlag(cmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Create a manifest for a Fedora VM with 2 cores and 2Gi of memory:\n"
+	usage += "  {{ProgramName}} create vm --image quay.io/kubevirt/fedora-cloud-container-disk-demo --cores 2 --memory 2Gi --cloud-init-user fedora\n\n"
+	usage += "  # Create a manifest for a VM booting from an existing PVC and apply it:\n"
+	usage += "  {{ProgramName}} create vm --name myvm --image pvc:mydisk | kubectl apply -f -"
+	return usage
+}
+
+func (c *createVM) run(cmd *cobra.Command) error {
+	vm, err := c.newVirtualMachine()
+	if err != nil {
+		return err
+	}
+
+	namespace, overridden, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	if overridden {
+		vm.Namespace = namespace
+	}
+
+	// the manifest is printed as YAML unless JSON is requested
+	return output.Print(cmd, vm, func(out io.Writer) error {
+		data, err := yaml.Marshal(vm)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the VM manifest: %v", err)
+		}
+		_, err = out.Write(data)
+		return err
+	})
+}
+
+func (c *createVM) newVirtualMachine() (*v1.VirtualMachine, error) {
+	if c.image == "" {
+		return nil, fmt.Errorf("--image is required")
+	}
+	if c.cores == 0 {
+		return nil, fmt.Errorf("--cores has to be greater than zero")
+	}
+	memory, err := resource.ParseQuantity(c.memory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --memory %q: %v", c.memory, err)
+	}
+
+	name := c.name
+	if name == "" {
+		name = nameFromImage(c.image)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("cannot derive a name from %q, please provide --name", c.image)
+	}
+
+	iface, err := c.newInterface()
+	if err != nil {
+		return nil, err
+	}
+
+	vm := &v1.VirtualMachine{
+		TypeMeta: k8smetav1.TypeMeta{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       "VirtualMachine",
+		},
+		ObjectMeta: k8smetav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1.VirtualMachineSpec{
+			Running: &c.running,
+			Template: &v1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Labels: map[string]string{"kubevirt.io/vm": name},
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						CPU: &v1.CPU{Cores: c.cores},
+						Resources: v1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: memory},
+						},
+						Devices: v1.Devices{
+							Disks: []v1.Disk{{
+								Name:       rootDiskName,
+								DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+							}},
+							Interfaces: []v1.Interface{*iface},
+						},
+					},
+					Networks: []v1.Network{*v1.DefaultPodNetwork()},
+				},
+			},
+		},
+	}
+
+	if err := c.addRootDisk(vm); err != nil {
+		return nil, err
+	}
+	if userData := c.cloudInitUserData(); userData != "" {
+		spec := &vm.Spec.Template.Spec
+		spec.Domain.Devices.Disks = append(spec.Domain.Devices.Disks, v1.Disk{
+			Name:       cloudInitName,
+			DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+		})
+		spec.Volumes = append(spec.Volumes, v1.Volume{
+			Name: cloudInitName,
+			VolumeSource: v1.VolumeSource{
+				CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: userData},
+			},
+		})
+	}
+
+	return vm, nil
+}
+
+func (c *createVM) newInterface() (*v1.Interface, error) {
+	switch c.binding {
+	case BINDING_MASQUERADE:
+		return v1.DefaultMasqueradeNetworkInterface(), nil
+	case BINDING_BRIDGE:
+		return v1.DefaultBridgeNetworkInterface(), nil
+	case BINDING_SLIRP:
+		return v1.DefaultSlirpNetworkInterface(), nil
+	}
+	return nil, fmt.Errorf("unsupported --network-binding %q, use one of %s, %s or %s", c.binding, BINDING_MASQUERADE, BINDING_BRIDGE, BINDING_SLIRP)
+}
+
+// addRootDisk picks the volume source of the root disk from the format of the image
+func (c *createVM) addRootDisk(vm *v1.VirtualMachine) error {
+	spec := &vm.Spec.Template.Spec
+	switch {
+	case strings.HasPrefix(c.image, pvcImagePrefix):
+		claimName := strings.TrimPrefix(c.image, pvcImagePrefix)
+		if claimName == "" {
+			return fmt.Errorf("missing PVC name in --image %q", c.image)
+		}
+		spec.Volumes = append(spec.Volumes, v1.Volume{
+			Name: rootDiskName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		})
+	case strings.HasPrefix(c.image, "http://") || strings.HasPrefix(c.image, "https://"):
+		size, err := resource.ParseQuantity(c.diskSize)
+		if err != nil {
+			return fmt.Errorf("invalid --disk-size %q: %v", c.diskSize, err)
+		}
+		dvName := vm.Name + "-" + rootDiskName
+		vm.Spec.DataVolumeTemplates = append(vm.Spec.DataVolumeTemplates, v1.DataVolumeTemplateSpec{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: dvName},
+			Spec: cdiv1.DataVolumeSpec{
+				Source: cdiv1.DataVolumeSource{
+					HTTP: &cdiv1.DataVolumeSourceHTTP{URL: c.image},
+				},
+				PVC: &k8sv1.PersistentVolumeClaimSpec{
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+					Resources: k8sv1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: size},
+					},
+				},
+			},
+		})
+		spec.Volumes = append(spec.Volumes, v1.Volume{
+			Name: rootDiskName,
+			VolumeSource: v1.VolumeSource{
+				DataVolume: &v1.DataVolumeSource{Name: dvName},
+			},
+		})
+	default:
+		spec.Volumes = append(spec.Volumes, v1.Volume{
+			Name: rootDiskName,
+			VolumeSource: v1.VolumeSource{
+				ContainerDisk: &v1.ContainerDiskSource{Image: c.image},
+			},
+		})
+	}
+	return nil
+}
+
+func (c *createVM) cloudInitUserData() string {
+	if c.cloudInitUser == "" && c.sshKey == "" {
+		return ""
+	}
+	userData := "#cloud-config\n"
+	if c.cloudInitUser != "" {
+		userData += fmt.Sprintf("user: %s\n", c.cloudInitUser)
+	}
+	if c.sshKey != "" {
+		userData += fmt.Sprintf("ssh_authorized_keys:\n  - %s\n", c.sshKey)
+	}
+	return userData
+}
+
+// nameFromImage derives a DNS compatible VM name from the last path element
+// of the image, without registry, tag, digest or file extensions.
+func nameFromImage(image string) string {
+	image = strings.TrimPrefix(image, pvcImagePrefix)
+	image = strings.SplitN(image, "?", 2)[0]
+	name := path.Base(image)
+	name = strings.SplitN(name, "@", 2)[0]
+	name = strings.SplitN(name, ":", 2)[0]
+	name = strings.SplitN(name, ".", 2)[0]
+	name = nameSanitizer.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(name, "-")
+}
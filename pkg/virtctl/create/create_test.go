@@ -0,0 +1,106 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virtctl/create"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Create VM", func() {
+
+	runCreate := func(args ...string) (*v1.VirtualMachine, error) {
+		cmd := tests.NewVirtctlCommand(append([]string{create.COMMAND_CREATE, create.COMMAND_VM}, args...)...)
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		if err := cmd.Execute(); err != nil {
+			return nil, err
+		}
+		vm := &v1.VirtualMachine{}
+		Expect(yaml.Unmarshal(out.Bytes(), vm)).To(Succeed())
+		return vm, nil
+	}
+
+	It("should create a VM with a containerDisk from the defaults", func() {
+		vm, err := runCreate("--image", "quay.io/kubevirt/fedora-cloud-container-disk-demo:v0.36.0")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(vm.Kind).To(Equal("VirtualMachine"))
+		Expect(vm.Name).To(Equal("fedora-cloud-container-disk-demo"))
+		Expect(vm.Namespace).To(BeEmpty())
+		Expect(*vm.Spec.Running).To(BeFalse())
+
+		spec := vm.Spec.Template.Spec
+		Expect(spec.Domain.CPU.Cores).To(Equal(uint32(1)))
+		Expect(spec.Domain.Resources.Requests[k8sv1.ResourceMemory]).To(Equal(resource.MustParse("1Gi")))
+		Expect(spec.Domain.Devices.Interfaces).To(Equal([]v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}))
+		Expect(spec.Networks).To(Equal([]v1.Network{*v1.DefaultPodNetwork()}))
+		Expect(spec.Volumes).To(HaveLen(1))
+		Expect(spec.Volumes[0].ContainerDisk.Image).To(Equal("quay.io/kubevirt/fedora-cloud-container-disk-demo:v0.36.0"))
+	})
+
+	It("should apply the flags", func() {
+		vm, err := runCreate("--namespace", "testns",
+			"--name", "myvm", "--image", "pvc:mydisk", "--cores", "4", "--memory", "4Gi",
+			"--network-binding", "bridge", "--cloud-init-user", "fedora", "--ssh-key", "ssh-rsa AAAA", "--running")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(vm.Name).To(Equal("myvm"))
+		Expect(vm.Namespace).To(Equal("testns"))
+		Expect(*vm.Spec.Running).To(BeTrue())
+
+		spec := vm.Spec.Template.Spec
+		Expect(spec.Domain.CPU.Cores).To(Equal(uint32(4)))
+		Expect(spec.Domain.Resources.Requests[k8sv1.ResourceMemory]).To(Equal(resource.MustParse("4Gi")))
+		Expect(spec.Domain.Devices.Interfaces).To(Equal([]v1.Interface{*v1.DefaultBridgeNetworkInterface()}))
+		Expect(spec.Domain.Devices.Disks).To(HaveLen(2))
+		Expect(spec.Volumes).To(HaveLen(2))
+		Expect(spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("mydisk"))
+		Expect(spec.Volumes[1].CloudInitNoCloud.UserData).To(Equal("#cloud-config\nuser: fedora\nssh_authorized_keys:\n  - ssh-rsa AAAA\n"))
+	})
+
+	It("should import http images into a DataVolume", func() {
+		vm, err := runCreate("--image", "https://example.com/images/Fedora-Cloud.qcow2", "--disk-size", "20Gi")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(vm.Name).To(Equal("fedora-cloud"))
+		Expect(vm.Spec.DataVolumeTemplates).To(HaveLen(1))
+		dv := vm.Spec.DataVolumeTemplates[0]
+		Expect(dv.Name).To(Equal("fedora-cloud-rootdisk"))
+		Expect(dv.Spec.Source.HTTP.URL).To(Equal("https://example.com/images/Fedora-Cloud.qcow2"))
+		Expect(dv.Spec.PVC.Resources.Requests[k8sv1.ResourceStorage]).To(Equal(resource.MustParse("20Gi")))
+		Expect(vm.Spec.Template.Spec.Volumes[0].DataVolume.Name).To(Equal("fedora-cloud-rootdisk"))
+	})
+
+	It("should print the manifest as JSON on request", func() {
+		cmd := tests.NewVirtctlCommand(create.COMMAND_CREATE, create.COMMAND_VM, "--image", "cirros", "-o", "json")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		Expect(cmd.Execute()).To(Succeed())
+
+		vm := &v1.VirtualMachine{}
+		Expect(json.Unmarshal(out.Bytes(), vm)).To(Succeed())
+		Expect(vm.Name).To(Equal("cirros"))
+	})
+
+	table.DescribeTable("should reject invalid flags", func(errMsg string, args ...string) {
+		_, err := runCreate(args...)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errMsg))
+	},
+		table.Entry("without an image", "--image is required"),
+		table.Entry("with zero cores", "--cores has to be greater than zero", "--image", "cirros", "--cores", "0"),
+		table.Entry("with invalid memory", "invalid --memory", "--image", "cirros", "--memory", "lots"),
+		table.Entry("with an unknown binding", "unsupported --network-binding", "--image", "cirros", "--network-binding", "sriov"),
+		table.Entry("with an empty PVC name", "missing PVC name", "--name", "myvm", "--image", "pvc:"),
+	)
+})
@@ -2,6 +2,7 @@ package expose
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -13,6 +14,7 @@ import (
 	v12 "kubevirt.io/client-go/api/v1"
 
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -71,6 +73,7 @@ virtualmachineinstance (vmi), virtualmachine (vm), virtualmachineinstancereplica
 	cmd.Flags().StringVar(&strServiceType, "type", "ClusterIP", "Type for this service: ClusterIP, NodePort, or LoadBalancer.")
 	cmd.Flags().StringVar(&portName, "port-name", "", "Name of the port. Optional.")
 	cmd.Flags().StringVar(&strIPFamily, "ip-family", "IPv4", "IP family over which the service will be exposed. Valid values are 'IPv4' or 'IPv6'.")
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -220,12 +223,16 @@ func (o *Command) RunE(cmd *cobra.Command, args []string) error {
 	}
 
 	// try to create the service on the cluster
-	_, err = virtClient.CoreV1().Services(namespace).Create(service)
+	created, err := virtClient.CoreV1().Services(namespace).Create(service)
 	if err != nil {
 		return fmt.Errorf("service creation failed: %v", err)
 	}
-	fmt.Printf("Service %s successfully exposed for %s %s\n", serviceName, vmType, vmName)
-	return nil
+	// the created service holds what the cluster allocated, like the cluster IP and the node ports
+	created.APIVersion, created.Kind = "v1", "Service"
+	return output.Print(cmd, created, func(out io.Writer) error {
+		_, err := fmt.Fprintf(out, "Service %s successfully exposed for %s %s\n", serviceName, vmType, vmName)
+		return err
+	})
 }
 
 func convertIPFamily(strIPFamily string) (v1.IPFamily, error) {
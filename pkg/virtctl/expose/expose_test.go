@@ -1,6 +1,8 @@
 package expose_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 
 	"github.com/golang/mock/gomock"
@@ -141,6 +143,27 @@ var _ = Describe("Expose", func() {
 				Expect(cmd()).To(Succeed())
 			})
 		})
+		Context("With an output format", func() {
+			It("should print the created service", func() {
+				kubeclient.Fake.PrependReactor("create", "services", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					service := action.(testing.CreateAction).GetObject().(*k8sv1.Service).DeepCopy()
+					service.Spec.ClusterIP = "10.0.0.1"
+					return true, service, nil
+				})
+				cmd := tests.NewVirtctlCommand(expose.COMMAND_EXPOSE, "vmi", vmName, "--name", "my-service",
+					"--port", "9999", "-o", "json")
+				out := &bytes.Buffer{}
+				cmd.SetOut(out)
+				Expect(cmd.Execute()).To(Succeed())
+
+				service := &k8sv1.Service{}
+				Expect(json.Unmarshal(out.Bytes(), service)).To(Succeed())
+				Expect(service.Kind).To(Equal("Service"))
+				Expect(service.Name).To(Equal("my-service"))
+				Expect(service.Namespace).To(Equal(k8smetav1.NamespaceDefault))
+				Expect(service.Spec.ClusterIP).To(Equal("10.0.0.1"))
+			})
+		})
 		Context("With missing service name", func() {
 			It("should fail", func() {
 				err := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vmi", vmName, "--port", "9999")
@@ -22,6 +22,7 @@ package imageupload
 import (
 	"crypto/tls"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -47,6 +48,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	uploadcdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/upload/v1alpha1"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -87,6 +89,9 @@ var (
 	blockVolume       bool
 	noCreate          bool
 	createPVC         bool
+
+	// progress is where the steps of the upload and the progress bar are written to
+	progress io.Writer = os.Stdout
 )
 
 // HTTPClientCreator is a function that creates http clients
@@ -138,6 +143,7 @@ func NewImageUploadCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.MarkFlagRequired("image-path")
 	cmd.Flags().BoolVar(&noCreate, "no-create", false, "Don't attempt to create a new DataVolume/PVC.")
 	cmd.Flags().UintVar(&uploadPodWaitSecs, "wait-secs", 300, "Seconds to wait for upload pod to start.")
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -206,10 +212,26 @@ func parseArgs(args []string) error {
 	return nil
 }
 
+// uploadResult is the result of an upload. The size, the storage class and the
+// modes are only set when the DataVolume or PVC was created by the upload.
+type uploadResult struct {
+	Kind           string `json:"kind"`
+	Namespace      string `json:"namespace"`
+	Name           string `json:"name"`
+	Created        bool   `json:"created"`
+	Size           string `json:"size,omitempty"`
+	StorageClass   string `json:"storageClass,omitempty"`
+	AccessMode     string `json:"accessMode,omitempty"`
+	VolumeMode     string `json:"volumeMode,omitempty"`
+	ImagePath      string `json:"imagePath"`
+	UploadProxyURL string `json:"uploadProxyURL"`
+}
+
 func (c *command) run(cmd *cobra.Command, args []string) error {
 	if err := parseArgs(args); err != nil {
 		return err
 	}
+	progress = output.Progress(cmd)
 	// #nosec G304 No risk for path injection as this funtion exectues with
 	// the same previliges as those of virtctl user who supplies imagePath
 	file, err := os.Open(imagePath)
@@ -228,6 +250,7 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot obtain KubeVirt client: %v", err)
 	}
 
+	var result uploadResult
 	pvc, err := getAndValidateUploadPVC(virtClient, namespace, name, noCreate)
 	if err != nil {
 		if !(k8serrors.IsNotFound(err) && !noCreate) {
@@ -239,6 +262,10 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 		}
 
 		var obj metav1.Object
+		volumeMode := v1.PersistentVolumeFilesystem
+		if blockVolume {
+			volumeMode = v1.PersistentVolumeBlock
+		}
 
 		if createPVC {
 			obj, err = createUploadPVC(virtClient, namespace, name, size, storageClass, accessMode, blockVolume)
@@ -252,14 +279,25 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		fmt.Printf("%s %s/%s created\n", reflect.TypeOf(obj).Elem().Name(), obj.GetNamespace(), obj.GetName())
+		result = uploadResult{
+			Kind:         reflect.TypeOf(obj).Elem().Name(),
+			Namespace:    obj.GetNamespace(),
+			Name:         obj.GetName(),
+			Created:      true,
+			Size:         size,
+			StorageClass: storageClass,
+			AccessMode:   accessMode,
+			VolumeMode:   string(volumeMode),
+		}
+		fmt.Fprintf(progress, "%s %s/%s created\n", result.Kind, result.Namespace, result.Name)
 	} else {
 		pvc, err = ensurePVCSupportsUpload(virtClient, pvc)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Using existing PVC %s/%s\n", namespace, pvc.Name)
+		result = uploadResult{Kind: "PersistentVolumeClaim", Namespace: namespace, Name: pvc.Name}
+		fmt.Fprintf(progress, "Using existing PVC %s/%s\n", namespace, pvc.Name)
 	}
 
 	err = waitUploadServerReady(virtClient, namespace, name, uploadReadyWaitInterval, time.Duration(uploadPodWaitSecs)*time.Second)
@@ -286,7 +324,7 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 		uploadProxyURL = fmt.Sprintf("https://%s", uploadProxyURL)
 	}
 
-	fmt.Printf("Uploading data to %s\n", uploadProxyURL)
+	fmt.Fprintf(progress, "Uploading data to %s\n", uploadProxyURL)
 
 	token, err := getUploadToken(virtClient.CdiClient(), namespace, name)
 	if err != nil {
@@ -298,15 +336,19 @@ func (c *command) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Println("Uploading data completed successfully, waiting for processing to complete, you can hit ctrl-c without interrupting the progress")
+	fmt.Fprintln(progress, "Uploading data completed successfully, waiting for processing to complete, you can hit ctrl-c without interrupting the progress")
 	err = UploadProcessingCompleteFunc(virtClient, namespace, name, processingWaitInterval, processingWaitTotal)
 	if err != nil {
-		fmt.Printf("Timed out waiting for post upload processing to complete, please check upload pod status for progress\n")
-	} else {
-		fmt.Printf("Uploading %s completed successfully\n", imagePath)
+		fmt.Fprintf(progress, "Timed out waiting for post upload processing to complete, please check upload pod status for progress\n")
+		return err
 	}
 
-	return err
+	result.ImagePath = imagePath
+	result.UploadProxyURL = uploadProxyURL
+	return output.Print(cmd, result, func(out io.Writer) error {
+		_, err := fmt.Fprintf(out, "Uploading %s completed successfully\n", imagePath)
+		return err
+	})
 }
 
 func getHTTPClient(insecure bool) *http.Client {
@@ -373,6 +415,7 @@ func uploadData(uploadProxyURL, token string, file *os.File, insecure bool) erro
 	}
 
 	bar := pb.New64(fi.Size()).SetUnits(pb.U_BYTES)
+	bar.Output = progress
 	reader := bar.NewProxyReader(file)
 
 	client := httpClientCreatorFunc(insecure)
@@ -382,13 +425,13 @@ func uploadData(uploadProxyURL, token string, file *os.File, insecure bool) erro
 	req.Header.Add("Content-Type", "application/octet-stream")
 	req.ContentLength = fi.Size()
 
-	fmt.Println()
+	fmt.Fprintln(progress)
 	bar.Start()
 
 	resp, err := client.Do(req)
 
 	bar.Finish()
-	fmt.Println()
+	fmt.Fprintln(progress)
 
 	if err != nil {
 		return err
@@ -442,12 +485,12 @@ func waitUploadServerReady(client kubernetes.Interface, namespace, name string,
 		done, _ := strconv.ParseBool(podReady)
 
 		if !done && !loggedStatus {
-			fmt.Printf("Waiting for PVC %s upload pod to be ready...\n", name)
+			fmt.Fprintf(progress, "Waiting for PVC %s upload pod to be ready...\n", name)
 			loggedStatus = true
 		}
 
 		if done && loggedStatus {
-			fmt.Printf("Pod now ready\n")
+			fmt.Fprintf(progress, "Pod now ready\n")
 		}
 
 		return done, nil
@@ -467,7 +510,7 @@ func waitUploadProcessingComplete(client kubernetes.Interface, namespace, name s
 		podPhase := pvc.Annotations[PodPhaseAnnotation]
 
 		if podPhase == string(v1.PodSucceeded) {
-			fmt.Printf("Processing completed successfully\n")
+			fmt.Fprintf(progress, "Processing completed successfully\n")
 		}
 
 		return podPhase == string(v1.PodSucceeded), nil
@@ -1,6 +1,7 @@
 package imageupload_test
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -382,6 +383,29 @@ var _ = Describe("ImageUpload", func() {
 			validateDataVolume()
 		})
 
+		It("DV does not exist with JSON output", func() {
+			testInit(http.StatusOK)
+			cmd := tests.NewVirtctlCommand(commandName, "dv", targetName, "--size", pvcSize,
+				"--uploadproxy-url", server.URL, "--insecure", "--image-path", imagePath, "-o", "json")
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(stderr)
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(dvCreateCalled).To(BeTrue())
+			Expect(stderr.String()).To(ContainSubstring("Uploading data to " + server.URL))
+			Expect(stdout.String()).To(MatchJSON(fmt.Sprintf(`{
+				"kind": "DataVolume",
+				"namespace": %q,
+				"name": %q,
+				"created": true,
+				"size": %q,
+				"accessMode": "ReadWriteOnce",
+				"volumeMode": "Filesystem",
+				"imagePath": %q,
+				"uploadProxyURL": %q
+			}`, targetNamespace, targetName, pvcSize, imagePath, server.URL)))
+		})
+
 		It("DV does not exist and --no-create", func() {
 			testInit(http.StatusOK)
 			cmd := tests.NewRepeatableVirtctlCommand(commandName, "dv", targetName, "--pvc-size", pvcSize,
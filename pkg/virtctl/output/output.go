@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagName = "output"
+
+	JSON = "json"
+	YAML = "yaml"
+)
+
+// Operation is the result of a command which asks the server to apply an
+// operation to a resource, like starting a VM
+type Operation struct {
+	Operation string `json:"operation"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// format is the value of the output flag, it only accepts the known formats
+// so that a typo fails before the command does anything
+type format string
+
+func (f *format) String() string {
+	return string(*f)
+}
+
+func (f *format) Set(value string) error {
+	switch value {
+	case JSON, YAML:
+		*f = format(value)
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, expected %s or %s", value, JSON, YAML)
+	}
+}
+
+func (f *format) Type() string {
+	return "string"
+}
+
+// AddFlag adds the output flag to a command, which prints the result of the
+// command as JSON or YAML instead of text
+func AddFlag(cmd *cobra.Command) {
+	cmd.Flags().VarP(new(format), FlagName, "o", fmt.Sprintf("Print the result as %s or %s instead of text.", JSON, YAML))
+}
+
+// Format returns the format requested with the output flag, or an empty
+// string for text
+func Format(cmd *cobra.Command) string {
+	flag := cmd.Flags().Lookup(FlagName)
+	if flag == nil {
+		return ""
+	}
+	return flag.Value.String()
+}
+
+// Requested tells whether the result is printed as JSON or YAML
+func Requested(cmd *cobra.Command) bool {
+	return Format(cmd) != ""
+}
+
+// Progress returns where a command writes the messages telling what it is
+// doing. They go to stderr once a format is requested, stdout then only holds
+// the result.
+func Progress(cmd *cobra.Command) io.Writer {
+	if Requested(cmd) {
+		return cmd.ErrOrStderr()
+	}
+	return cmd.OutOrStdout()
+}
+
+// Print writes the result to stdout in the requested format. Without one,
+// text writes the result as text instead.
+func Print(cmd *cobra.Command, result interface{}, text func(out io.Writer) error) error {
+	out := cmd.OutOrStdout()
+	switch Format(cmd) {
+	case JSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Cannot marshal the result: %v", err)
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case YAML:
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("Cannot marshal the result: %v", err)
+		}
+		_, err = out.Write(data)
+		return err
+	default:
+		return text(out)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package output_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
+)
+
+var _ = Describe("Output", func() {
+	var cmd *cobra.Command
+	var stdout, stderr *bytes.Buffer
+
+	result := output.Operation{Operation: "start", Kind: "VirtualMachine", Namespace: "default", Name: "myvm"}
+	text := func(out io.Writer) error {
+		_, err := fmt.Fprintln(out, "VM myvm was scheduled to start")
+		return err
+	}
+
+	BeforeEach(func() {
+		stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+		cmd = &cobra.Command{Use: "start", RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintln(output.Progress(cmd), "starting")
+			return output.Print(cmd, result, text)
+		}}
+		cmd.SetOut(stdout)
+		cmd.SetErr(stderr)
+		output.AddFlag(cmd)
+	})
+
+	table.DescribeTable("should print the result", func(args []string, expected, progress string) {
+		cmd.SetArgs(args)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(stdout.String()).To(Equal(expected))
+		Expect(stderr.String()).To(Equal(progress))
+	},
+		table.Entry("as text by default", nil,
+			"starting\nVM myvm was scheduled to start\n", ""),
+		table.Entry("as JSON", []string{"-o", "json"},
+			"{\n  \"operation\": \"start\",\n  \"kind\": \"VirtualMachine\",\n  \"namespace\": \"default\",\n  \"name\": \"myvm\"\n}\n", "starting\n"),
+		table.Entry("as YAML", []string{"--output=yaml"},
+			"kind: VirtualMachine\nname: myvm\nnamespace: default\noperation: start\n", "starting\n"),
+	)
+
+	It("should reject an unknown format before running the command", func() {
+		cmd.SetArgs([]string{"-o", "xml"})
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown output format "xml"`))
+		Expect(stdout.String()).ToNot(ContainSubstring("starting"))
+	})
+
+	It("should print text for commands without the flag", func() {
+		Expect(output.Format(&cobra.Command{})).To(BeEmpty())
+	})
+})
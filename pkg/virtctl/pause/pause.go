@@ -21,6 +21,7 @@ package pause
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -32,6 +33,7 @@ import (
 	kubevirtV1 "kubevirt.io/client-go/api/v1"
 
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -61,6 +63,7 @@ Second argument is the name of the resource.`,
 			return c.Run(cmd, args)
 		},
 	}
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -82,6 +85,7 @@ Second argument is the name of the resource.`,
 			return c.Run(cmd, args)
 		},
 	}
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -100,6 +104,11 @@ type VirtCommand struct {
 func (vc *VirtCommand) Run(cmd *cobra.Command, args []string) error {
 	resourceType := strings.ToLower(args[0])
 	resourceName := args[1]
+	switch resourceType {
+	case ARG_VM_LONG, ARG_VM_SHORT, ARG_VMI_LONG, ARG_VMI_SHORT:
+	default:
+		return fmt.Errorf("unsupported resource type: %s", args[0])
+	}
 	namespace, _, err := vc.clientConfig.Namespace()
 	if err != nil {
 		return err
@@ -110,6 +119,8 @@ func (vc *VirtCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
 	}
 
+	// a VM is paused through its VMI, which has the same name
+	vmiName := resourceName
 	switch vc.command {
 	case COMMAND_PAUSE:
 		switch resourceType {
@@ -118,7 +129,7 @@ func (vc *VirtCommand) Run(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return fmt.Errorf("Error getting VirtualMachine %s: %v", resourceName, err)
 			}
-			vmiName := vm.Name
+			vmiName = vm.Name
 			err = virtClient.VirtualMachineInstance(namespace).Pause(vmiName)
 			if err != nil {
 				if errors.IsNotFound(err) {
@@ -134,13 +145,11 @@ func (vc *VirtCommand) Run(cmd *cobra.Command, args []string) error {
 				}
 				return fmt.Errorf("Error pausing VirutalMachineInstance %s: %v", vmiName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", vmiName, vc.command)
 		case ARG_VMI_LONG, ARG_VMI_SHORT:
 			err = virtClient.VirtualMachineInstance(namespace).Pause(resourceName)
 			if err != nil {
 				return fmt.Errorf("Error pausing VirtualMachineInstance %s: %v", resourceName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", resourceName, vc.command)
 		}
 	case COMMAND_UNPAUSE:
 		switch resourceType {
@@ -149,19 +158,22 @@ func (vc *VirtCommand) Run(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return fmt.Errorf("Error getting VirtualMachine %s: %v", resourceName, err)
 			}
-			vmiName := vm.Name
+			vmiName = vm.Name
 			err = virtClient.VirtualMachineInstance(namespace).Unpause(vmiName)
 			if err != nil {
 				return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %v", vmiName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", vmiName, vc.command)
 		case ARG_VMI_LONG, ARG_VMI_SHORT:
 			err = virtClient.VirtualMachineInstance(namespace).Unpause(resourceName)
 			if err != nil {
 				return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %v", resourceName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", resourceName, vc.command)
 		}
 	}
-	return nil
+
+	result := output.Operation{Operation: vc.command, Kind: "VirtualMachineInstance", Namespace: namespace, Name: vmiName}
+	return output.Print(cmd, result, func(out io.Writer) error {
+		_, err := fmt.Fprintf(out, "VMI %s was scheduled to %s\n", vmiName, vc.command)
+		return err
+	})
 }
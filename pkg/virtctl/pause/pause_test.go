@@ -1,6 +1,9 @@
 package pause_test
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -90,6 +93,27 @@ var _ = Describe("Pausing", func() {
 		Expect(cmd.Execute()).To(BeNil())
 	})
 
+	It("should print the paused VMI of a VM as YAML", func() {
+		vm := kubecli.NewMinimalVM(vmName)
+
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+
+		vmInterface.EXPECT().Get(vm.Name, &k8smetav1.GetOptions{}).Return(vm, nil).Times(1)
+		vmiInterface.EXPECT().Pause(vm.Name).Return(nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(pause.COMMAND_PAUSE, "vm", vmName, "-o", "yaml")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(Equal(fmt.Sprintf("kind: VirtualMachineInstance\nname: %s\nnamespace: default\noperation: pause\n", vmName)))
+	})
+
+	It("should reject an unknown resource type", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(pause.COMMAND_PAUSE, "pod", vmName)
+		Expect(cmd()).To(MatchError("unsupported resource type: pod"))
+	})
+
 	AfterEach(func() {
 		ctrl.Finish()
 	})
@@ -0,0 +1,135 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package profile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/profiler"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_PROFILE = "profile"
+
+type profile struct {
+	clientConfig clientcmd.ClientConfig
+
+	duration time.Duration
+	file     string
+}
+
+// NewProfileCommand returns the profile command, which collects the profiles
+// of the KubeVirt components on a node.
+func NewProfileCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := profile{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:   "profile (NODE)",
+		Short: "Collect the pprof profiles and the traces of virt-handler and of the virt-launchers on a node.",
+		Long: `Collect the CPU, heap, allocation and goroutine profiles and the execution traces of virt-handler and of the virt-launchers on a node into a gzipped tar archive.
+The Profiling feature gate has to be enabled. Only the virt-launchers of VMIs started while it was enabled can be profiled, the others are listed in errors.txt.`,
+		Example: usage(),
+		Args:    templates.ExactArgs(COMMAND_PROFILE, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args)
+		},
+	}
+	cmd.Flags().DurationVar(&c.duration, "duration", profiler.DefaultDuration, fmt.Sprintf("Time the CPU profiles and the traces are recorded for, at most %s.", profiler.MaxDuration))
+	cmd.Flags().StringVar(&c.file, "file", "", "File to write the profiles to, <node>-profile.tar.gz by default.")
+	output.AddFlag(cmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Collect the profiles of the node 'node01' into node01-profile.tar.gz:\n"
+	usage += "  {{ProgramName}} profile node01\n\n"
+	usage += "  # Record the CPU profiles and the traces for 45 seconds:\n"
+	usage += "  {{ProgramName}} profile node01 --duration=45s --file=/tmp/node01.tar.gz"
+	return usage
+}
+
+func (c *profile) run(cmd *cobra.Command, args []string) error {
+	node := args[0]
+	if c.duration < time.Second || c.duration > profiler.MaxDuration {
+		return fmt.Errorf("the duration has to be between 1s and %s", profiler.MaxDuration)
+	}
+	file := c.file
+	if file == "" {
+		file = node + "-profile.tar.gz"
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	// the profiles are a subresource of the KubeVirt deployment
+	kvs, err := virtClient.KubeVirt(k8smetav1.NamespaceAll).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("Error listing KubeVirt deployments: %v", err)
+	}
+	if len(kvs.Items) != 1 {
+		return fmt.Errorf("Expected to find one KubeVirt deployment, found %d", len(kvs.Items))
+	}
+	kv := kvs.Items[0]
+
+	fmt.Fprintf(output.Progress(cmd), "Collecting the profiles of node %s for %s\n", node, c.duration)
+	bundle, err := virtClient.KubeVirt(kv.Namespace).Profile(kv.Name, node, c.duration)
+	if err != nil {
+		return fmt.Errorf("Error collecting the profiles of node %s: %v", node, err)
+	}
+	defer bundle.Close()
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	size, err := io.Copy(f, bundle)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("Error writing the profiles of node %s: %v", node, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	result := profileResult{Node: node, Duration: c.duration.String(), File: file, Bytes: size}
+	return output.Print(cmd, result, func(out io.Writer) error {
+		_, err := fmt.Fprintf(out, "Wrote the profiles to %s\n", file)
+		return err
+	})
+}
+
+// profileResult tells where the profiles of a node were written to
+type profileResult struct {
+	Node     string `json:"node"`
+	Duration string `json:"duration"`
+	File     string `json:"file"`
+	Bytes    int64  `json:"bytes"`
+}
@@ -0,0 +1,103 @@
+package profile_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/profiler"
+	"kubevirt.io/kubevirt/pkg/virtctl/profile"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Profile", func() {
+
+	var kvInterface *kubecli.MockKubeVirtInterface
+	var ctrl *gomock.Controller
+	var dir string
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kvInterface = kubecli.NewMockKubeVirtInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().KubeVirt(gomock.Any()).Return(kvInterface).AnyTimes()
+
+		var err error
+		dir, err = ioutil.TempDir("", "profile")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		os.RemoveAll(dir)
+	})
+
+	expectKubeVirts := func(kvs ...v1.KubeVirt) {
+		kvInterface.EXPECT().List(gomock.Any()).Return(&v1.KubeVirtList{Items: kvs}, nil)
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := tests.NewVirtctlCommand(append([]string{profile.COMMAND_PROFILE}, args...)...)
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	It("should write the profiles of the node to the file", func() {
+		expectKubeVirts(v1.KubeVirt{ObjectMeta: k8smetav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"}})
+		kvInterface.EXPECT().Profile("kubevirt", "node01", 5*time.Second).Return(ioutil.NopCloser(strings.NewReader("bundle")), nil)
+
+		file := filepath.Join(dir, "node01.tar.gz")
+		out, err := run("node01", "--duration", "5s", "--file", file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("Wrote the profiles to " + file))
+
+		bundle, err := ioutil.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(bundle)).To(Equal("bundle"))
+	})
+
+	It("should only print the result to stdout with an output format", func() {
+		expectKubeVirts(v1.KubeVirt{ObjectMeta: k8smetav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"}})
+		kvInterface.EXPECT().Profile("kubevirt", "node01", profiler.DefaultDuration).Return(ioutil.NopCloser(strings.NewReader("bundle")), nil)
+
+		file := filepath.Join(dir, "node01.tar.gz")
+		out, err := run("node01", "--file", file, "-o", "json")
+		Expect(err).ToNot(HaveOccurred())
+
+		result := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(out), &result)).To(Succeed())
+		Expect(result).To(Equal(map[string]interface{}{
+			"node":     "node01",
+			"duration": profiler.DefaultDuration.String(),
+			"file":     file,
+			"bytes":    float64(len("bundle")),
+		}))
+	})
+
+	It("should fail without a KubeVirt deployment", func() {
+		expectKubeVirts()
+
+		_, err := run("node01", "--file", filepath.Join(dir, "node01.tar.gz"))
+		Expect(err).To(MatchError("Expected to find one KubeVirt deployment, found 0"))
+	})
+
+	It("should reject durations the API server would time out", func() {
+		_, err := run("node01", "--duration", "2m")
+		Expect(err).To(MatchError(ContainSubstring("the duration has to be between 1s and 50s")))
+	})
+})
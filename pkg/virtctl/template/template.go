@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package template
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_PROCESS = "process"
+
+type processTemplate struct {
+	clientConfig clientcmd.ClientConfig
+
+	params []string
+	create bool
+}
+
+// NewProcessCommand returns the process command, which substitutes parameter
+// values into a VirtualMachineTemplate.
+func NewProcessCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := processTemplate{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:   "process (TEMPLATE)",
+		Short: "Process a VirtualMachineTemplate into a VirtualMachine.",
+		Long: `Substitute the parameter values into a VirtualMachineTemplate and print the resulting VirtualMachine to stdout.
+Parameters which are not given fall back to their default value. With --create the VirtualMachine is created instead.`,
+		Example: usage(),
+		Args:    templates.ExactArgs(COMMAND_PROCESS, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args[0])
+		},
+	}
+	cmd.Flags().StringArrayVarP(&c.params, "param", "p", nil, "Value of a template parameter in the form KEY=VALUE. Can be given multiple times.")
+	cmd.Flags().BoolVar(&c.create, "create", false, "Create the processed VirtualMachine instead of printing it.")
+	output.AddFlag(cmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Print the VirtualMachine of the template 'fedora-small' with the name 'myvm':\n"
+	usage += "  {{ProgramName}} process fedora-small -p NAME=myvm\n\n"
+	usage += "  # Create the VirtualMachine with more memory:\n"
+	usage += "  {{ProgramName}} process fedora-small -p NAME=myvm -p MEMORY=4Gi --create"
+	return usage
+}
+
+func (c *processTemplate) run(cmd *cobra.Command, name string) error {
+	parameters, err := parseParameters(c.params)
+	if err != nil {
+		return err
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	vm, err := virtClient.VirtualMachineTemplate(namespace).Process(name, &templatev1alpha1.ProcessOptions{Parameters: parameters})
+	if err != nil {
+		return fmt.Errorf("Error processing VirtualMachineTemplate %s: %v", name, err)
+	}
+
+	if c.create {
+		created, err := virtClient.VirtualMachine(namespace).Create(vm)
+		if err != nil {
+			return fmt.Errorf("Error creating VirtualMachine %s: %v", vm.Name, err)
+		}
+		return output.Print(cmd, created, func(out io.Writer) error {
+			_, err := fmt.Fprintf(out, "VM %s was created\n", created.Name)
+			return err
+		})
+	}
+
+	// the manifest is printed as YAML unless JSON is requested
+	return output.Print(cmd, vm, func(out io.Writer) error {
+		data, err := yaml.Marshal(vm)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the VM manifest: %v", err)
+		}
+		_, err = out.Write(data)
+		return err
+	})
+}
+
+func parseParameters(params []string) (map[string]string, error) {
+	parameters := map[string]string{}
+	for _, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected KEY=VALUE", param)
+		}
+		if _, exists := parameters[kv[0]]; exists {
+			return nil, fmt.Errorf("parameter %s is given twice", kv[0])
+		}
+		parameters[kv[0]] = kv[1]
+	}
+	return parameters, nil
+}
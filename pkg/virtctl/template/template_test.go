@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package template_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/template"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Process VirtualMachineTemplate", func() {
+
+	const templateName = "fedora-small"
+	var templateInterface *kubecli.MockVirtualMachineTemplateInterface
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		templateInterface = kubecli.NewMockVirtualMachineTemplateInterface(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	expectProcess := func(parameters map[string]string) *v1.VirtualMachine {
+		vm := kubecli.NewMinimalVM("myvm")
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineTemplate(k8smetav1.NamespaceDefault).Return(templateInterface).Times(1)
+		templateInterface.EXPECT().Process(templateName, &templatev1alpha1.ProcessOptions{Parameters: parameters}).Return(vm, nil).Times(1)
+		return vm
+	}
+
+	It("should fail without a template name", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(template.COMMAND_PROCESS)
+		Expect(cmd()).NotTo(Succeed())
+	})
+
+	table.DescribeTable("should reject invalid parameters", func(param string) {
+		cmd := tests.NewRepeatableVirtctlCommand(template.COMMAND_PROCESS, templateName, "-p", param)
+		Expect(cmd()).NotTo(Succeed())
+	},
+		table.Entry("without a value", "NAME"),
+		table.Entry("without a key", "=myvm"),
+	)
+
+	It("should reject parameters given twice", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(template.COMMAND_PROCESS, templateName, "-p", "NAME=a", "-p", "NAME=b")
+		Expect(cmd()).NotTo(Succeed())
+	})
+
+	It("should print the processed VirtualMachine", func() {
+		expected := expectProcess(map[string]string{"NAME": "myvm", "MEMORY": "4Gi"})
+
+		cmd := tests.NewVirtctlCommand(template.COMMAND_PROCESS, templateName, "-p", "NAME=myvm", "--param", "MEMORY=4Gi")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		Expect(cmd.Execute()).To(Succeed())
+
+		vm := &v1.VirtualMachine{}
+		Expect(yaml.Unmarshal(out.Bytes(), vm)).To(Succeed())
+		Expect(vm.Name).To(Equal(expected.Name))
+	})
+
+	It("should create the processed VirtualMachine with --create", func() {
+		vm := expectProcess(map[string]string{"NAME": "myvm"})
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().Create(vm).Return(vm, nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(template.COMMAND_PROCESS, templateName, "-p", "NAME=myvm", "--create")
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	It("should print the created VirtualMachine as JSON", func() {
+		vm := expectProcess(map[string]string{"NAME": "myvm"})
+		created := vm.DeepCopy()
+		created.UID = "1234"
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().Create(vm).Return(created, nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(template.COMMAND_PROCESS, templateName, "-p", "NAME=myvm", "--create", "-o", "json")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		Expect(cmd.Execute()).To(Succeed())
+
+		printed := &v1.VirtualMachine{}
+		Expect(json.Unmarshal(out.Bytes(), printed)).To(Succeed())
+		Expect(printed.UID).To(Equal(created.UID))
+	})
+})
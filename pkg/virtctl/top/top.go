@@ -0,0 +1,233 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package top
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_TOP = "top"
+	COMMAND_VMI = "vmi"
+)
+
+type topVMI struct {
+	clientConfig clientcmd.ClientConfig
+
+	interval time.Duration
+}
+
+// NewTopCommand returns the top command, which displays the resource usage
+// of VirtualMachineInstances.
+func NewTopCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   COMMAND_TOP,
+		Short: "Display the resource usage of virtual machine instances.",
+	}
+	cmd.AddCommand(newTopVMICommand(clientConfig))
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func newTopVMICommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	c := topVMI{clientConfig: clientConfig}
+	cmd := &cobra.Command{
+		Use:   "vmi [VMI]",
+		Short: "Display the CPU, memory, disk and network usage of virtual machine instances.",
+		Long: `Display the CPU, memory, disk and network usage of a virtual machine instance, or of all running ones in the namespace.
+The usage counters are read twice, the rates are the difference over the interval between the reads.
+CPU% is relative to one host CPU, a guest using two vCPUs fully shows 200%.`,
+		Example: usage(),
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, args)
+		},
+	}
+	cmd.Flags().DurationVar(&c.interval, "interval", time.Second, "Time between the two reads of the usage counters.")
+	output.AddFlag(cmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Show the resource usage of all running VMIs in the namespace:\n"
+	usage += "  {{ProgramName}} top vmi\n\n"
+	usage += "  # Show the resource usage of the VMI 'myvmi' over five seconds:\n"
+	usage += "  {{ProgramName}} top vmi myvmi --interval=5s"
+	return usage
+}
+
+func (c *topVMI) run(cmd *cobra.Command, args []string) error {
+	if c.interval <= 0 {
+		return fmt.Errorf("the interval has to be positive")
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+	vmiInterface := virtClient.VirtualMachineInstance(namespace)
+
+	var names []string
+	if len(args) == 1 {
+		names = []string{args[0]}
+	} else {
+		vmis, err := vmiInterface.List(&k8smetav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Error listing VirtualMachineInstances: %v", err)
+		}
+		for _, vmi := range vmis.Items {
+			if vmi.Status.Phase == v1.Running {
+				names = append(names, vmi.Name)
+			}
+		}
+		sort.Strings(names)
+	}
+
+	// a VMI which was listed may stop before its stats are read, only a VMI
+	// which was asked for by name fails the command
+	strict := len(args) == 1
+	first := map[string]*v1.VirtualMachineInstanceStats{}
+	for _, name := range names {
+		stats, err := vmiInterface.Stats(name)
+		if err != nil {
+			if strict {
+				return fmt.Errorf("Error getting the stats of VirtualMachineInstance %s: %v", name, err)
+			}
+			continue
+		}
+		first[name] = stats
+	}
+
+	if len(first) > 0 {
+		time.Sleep(c.interval)
+	}
+
+	usages := []vmiUsage{}
+	for _, name := range names {
+		if first[name] == nil {
+			continue
+		}
+		stats, err := vmiInterface.Stats(name)
+		if err != nil {
+			if strict {
+				return fmt.Errorf("Error getting the stats of VirtualMachineInstance %s: %v", name, err)
+			}
+			continue
+		}
+		usages = append(usages, newVMIUsage(name, first[name], stats))
+	}
+
+	report := usageReport{Namespace: namespace, Interval: c.interval.String(), VMIs: usages}
+	return output.Print(cmd, report, func(out io.Writer) error {
+		return printUsages(out, usages)
+	})
+}
+
+// usageReport is the result of the command, the rates are in bytes per second
+type usageReport struct {
+	Namespace string     `json:"namespace"`
+	Interval  string     `json:"interval"`
+	VMIs      []vmiUsage `json:"vmis"`
+}
+
+type vmiUsage struct {
+	Name                  string  `json:"name"`
+	CPUPercent            float64 `json:"cpuPercent"`
+	MemoryWorkingSetBytes int64   `json:"memoryWorkingSetBytes"`
+	DiskReadRate          float64 `json:"diskReadRate"`
+	DiskWriteRate         float64 `json:"diskWriteRate"`
+	NetworkReceiveRate    float64 `json:"networkReceiveRate"`
+	NetworkTransmitRate   float64 `json:"networkTransmitRate"`
+}
+
+// newVMIUsage computes the rates between two reads of the counters of a VMI
+func newVMIUsage(name string, previous, current *v1.VirtualMachineInstanceStats) vmiUsage {
+	u := vmiUsage{
+		Name:                  name,
+		MemoryWorkingSetBytes: current.MemoryWorkingSetBytes,
+	}
+	elapsed := current.Timestamp.Sub(previous.Timestamp.Time)
+	if elapsed <= 0 {
+		return u
+	}
+	rate := func(previous, current int64) float64 {
+		if current < previous {
+			// the counters were reset, e.g. by a restart of the guest
+			return 0
+		}
+		return float64(current-previous) / elapsed.Seconds()
+	}
+	u.CPUPercent = rate(previous.CPUTimeNanoseconds, current.CPUTimeNanoseconds) / float64(time.Second) * 100
+	u.DiskReadRate = rate(previous.DiskReadBytes, current.DiskReadBytes)
+	u.DiskWriteRate = rate(previous.DiskWriteBytes, current.DiskWriteBytes)
+	u.NetworkReceiveRate = rate(previous.NetworkReceiveBytes, current.NetworkReceiveBytes)
+	u.NetworkTransmitRate = rate(previous.NetworkTransmitBytes, current.NetworkTransmitBytes)
+	return u
+}
+
+func printUsages(out io.Writer, usages []vmiUsage) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCPU%\tMEMORY\tDISK READ/s\tDISK WRITE/s\tNET RX/s\tNET TX/s")
+	for _, u := range usages {
+		fmt.Fprintf(w, "%s\t%.1f%%\t%s\t%s\t%s\t%s\t%s\n",
+			u.Name,
+			u.CPUPercent,
+			formatBytes(float64(u.MemoryWorkingSetBytes)),
+			formatBytes(u.DiskReadRate),
+			formatBytes(u.DiskWriteRate),
+			formatBytes(u.NetworkReceiveRate),
+			formatBytes(u.NetworkTransmitRate),
+		)
+	}
+	return w.Flush()
+}
+
+// formatBytes prints an amount of bytes with a binary unit
+func formatBytes(bytes float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	unit := 0
+	for bytes >= 1024 && unit < len(units)-1 {
+		bytes /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f%s", bytes, units[unit])
+	}
+	return fmt.Sprintf("%.1f%s", bytes, units[unit])
+}
@@ -0,0 +1,147 @@
+package top_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/top"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Top", func() {
+
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	newStats := func(elapsed time.Duration, cpu, memory, diskRead, diskWrite, rx, tx int64) *v1.VirtualMachineInstanceStats {
+		return &v1.VirtualMachineInstanceStats{
+			Timestamp:             k8smetav1.NewMicroTime(start.Add(elapsed)),
+			VCPUs:                 2,
+			CPUTimeNanoseconds:    cpu,
+			MemoryWorkingSetBytes: memory,
+			DiskReadBytes:         diskRead,
+			DiskWriteBytes:        diskWrite,
+			NetworkReceiveBytes:   rx,
+			NetworkTransmitBytes:  tx,
+		}
+	}
+
+	expectStats := func(name string, first, second *v1.VirtualMachineInstanceStats) {
+		gomock.InOrder(
+			vmiInterface.EXPECT().Stats(name).Return(first, nil),
+			vmiInterface.EXPECT().Stats(name).Return(second, nil),
+		)
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := tests.NewVirtctlCommand(append([]string{top.COMMAND_TOP, top.COMMAND_VMI, "--interval", "1ms"}, args...)...)
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	It("should show the rates of a VMI between the two reads", func() {
+		expectStats("testvmi",
+			newStats(0, 0, 512*1024*1024, 0, 0, 0, 0),
+			newStats(2*time.Second, int64(3*time.Second), 1536*1024*1024, 2048, 4*1024*1024, 1000, 0),
+		)
+
+		out, err := run("testvmi")
+		Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(strings.Fields(lines[0])).To(Equal([]string{"NAME", "CPU%", "MEMORY", "DISK", "READ/s", "DISK", "WRITE/s", "NET", "RX/s", "NET", "TX/s"}))
+		Expect(strings.Fields(lines[1])).To(Equal([]string{"testvmi", "150.0%", "1.5GiB", "1.0KiB", "2.0MiB", "500B", "0B"}))
+	})
+
+	It("should show all running VMIs of the namespace", func() {
+		running := v1.NewMinimalVMI("running")
+		running.Status.Phase = v1.Running
+		other := v1.NewMinimalVMI("another")
+		other.Status.Phase = v1.Running
+		scheduled := v1.NewMinimalVMI("scheduled")
+		scheduled.Status.Phase = v1.Scheduled
+		vmiInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceList{
+			Items: []v1.VirtualMachineInstance{*running, *scheduled, *other},
+		}, nil)
+
+		expectStats("another", newStats(0, 0, 0, 0, 0, 0, 0), newStats(time.Second, 0, 0, 0, 0, 0, 0))
+		// a VMI which stopped after it was listed is left out
+		vmiInterface.EXPECT().Stats("running").Return(nil, fmt.Errorf("VMI is not running"))
+
+		out, err := run()
+		Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[1]).To(HavePrefix("another "))
+	})
+
+	It("should not report a negative rate when the counters were reset", func() {
+		expectStats("testvmi",
+			newStats(0, int64(time.Hour), 0, 4096, 0, 0, 0),
+			newStats(time.Second, int64(time.Second), 0, 0, 0, 0, 0),
+		)
+
+		out, err := run("testvmi")
+		Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		Expect(strings.Fields(lines[1])).To(Equal([]string{"testvmi", "0.0%", "0B", "0B", "0B", "0B", "0B"}))
+	})
+
+	It("should print the usage with the interval as JSON", func() {
+		expectStats("testvmi",
+			newStats(0, 0, 1024, 0, 0, 0, 0),
+			newStats(2*time.Second, int64(time.Second), 2048, 0, 0, 4096, 0),
+		)
+
+		out, err := run("testvmi", "-o", "json")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(MatchJSON(`{
+			"namespace": "default",
+			"interval": "1ms",
+			"vmis": [{
+				"name": "testvmi",
+				"cpuPercent": 50,
+				"memoryWorkingSetBytes": 2048,
+				"diskReadRate": 0,
+				"diskWriteRate": 0,
+				"networkReceiveRate": 2048,
+				"networkTransmitRate": 0
+			}]
+		}`))
+	})
+
+	It("should fail when the stats of the named VMI can't be read", func() {
+		vmiInterface.EXPECT().Stats("testvmi").Return(nil, fmt.Errorf("VMI is not running"))
+
+		_, err := run("testvmi")
+		Expect(err).To(MatchError(ContainSubstring("VMI is not running")))
+	})
+})
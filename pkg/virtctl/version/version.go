@@ -2,12 +2,14 @@ package version
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/version"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -25,6 +27,7 @@ func VersionCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&clientOnly, "client", "c", clientOnly, "Client version only (no server required).")
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -39,22 +42,39 @@ type Version struct {
 	clientConfig clientcmd.ClientConfig
 }
 
+// versions is the result of the version command, the server version is
+// missing with --client
+type versions struct {
+	ClientVersion version.Info  `json:"clientVersion"`
+	ServerVersion *version.Info `json:"serverVersion,omitempty"`
+}
+
 func (v *Version) Run(cmd *cobra.Command, args []string) error {
-	fmt.Printf("Client Version: %s\n", fmt.Sprintf("%#v", version.Get()))
+	result := versions{ClientVersion: version.Get()}
 
+	// the client version is printed even if the server can't be reached
+	var serverErr error
 	if !clientOnly {
-		virCli, err := kubecli.GetKubevirtClientFromClientConfig(v.clientConfig)
-		if err != nil {
-			return err
-		}
+		result.ServerVersion, serverErr = v.serverVersion()
+	}
 
-		serverInfo, err := virCli.ServerVersion().Get()
-		if err != nil {
-			return err
+	err := output.Print(cmd, result, func(out io.Writer) error {
+		fmt.Fprintf(out, "Client Version: %s\n", fmt.Sprintf("%#v", result.ClientVersion))
+		if result.ServerVersion != nil {
+			fmt.Fprintf(out, "Server Version: %s\n", fmt.Sprintf("%#v", *result.ServerVersion))
 		}
-
-		fmt.Printf("Server Version: %s\n", fmt.Sprintf("%#v", *serverInfo))
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	return serverErr
+}
 
-	return nil
+func (v *Version) serverVersion() (*version.Info, error) {
+	virCli, err := kubecli.GetKubevirtClientFromClientConfig(v.clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	return virCli.ServerVersion().Get()
 }
@@ -22,6 +22,7 @@ package vm
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -30,51 +31,77 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
 const (
 	COMMAND_START       = "start"
 	COMMAND_STOP        = "stop"
+	COMMAND_HIBERNATE   = "hibernate"
 	COMMAND_RESTART     = "restart"
 	COMMAND_MIGRATE     = "migrate"
 	COMMAND_RENAME      = "rename"
 	COMMAND_GUESTOSINFO = "guestosinfo"
 	COMMAND_USERLIST    = "userlist"
 	COMMAND_FSLIST      = "fslist"
+	COMMAND_QMP         = "qmp"
 )
 
 var (
-	forceRestart bool
-	gracePeriod  int = -1
+	forceRestart   bool
+	gracePeriod    int = -1
+	selector       string
+	batchSize      int64
+	maxConcurrency int64
 )
 
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "start (VM)",
+		Use:     "start (VM | --selector SELECTOR)",
 		Short:   "Start a virtual machine.",
 		Example: usage(COMMAND_START),
-		Args:    templates.ExactArgs("start", 1),
+		Args:    vmOrSelectorArgs("start"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_START, clientConfig: clientConfig}
 			return c.Run(cmd, args)
 		},
 	}
+	addBulkFlags(cmd)
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func NewStopCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "stop (VM)",
+		Use:     "stop (VM | --selector SELECTOR)",
 		Short:   "Stop a virtual machine.",
 		Example: usage(COMMAND_STOP),
-		Args:    templates.ExactArgs("stop", 1),
+		Args:    vmOrSelectorArgs("stop"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_STOP, clientConfig: clientConfig}
 			return c.Run(cmd, args)
 		},
 	}
+	addBulkFlags(cmd)
+	output.AddFlag(cmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewHibernateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "hibernate (VM)",
+		Short:   "Hibernate a virtual machine, saving its memory to disk. It resumes on the next start.",
+		Example: usage(COMMAND_HIBERNATE),
+		Args:    templates.ExactArgs("hibernate", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_HIBERNATE, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -92,21 +119,24 @@ func NewRestartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	}
 	cmd.Flags().BoolVar(&forceRestart, "force", forceRestart, "--force=false: Only used when grace-period=0. If true, immediately remove VMI pod from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
 	cmd.Flags().IntVar(&gracePeriod, "grace-period", gracePeriod, "--grace-period=-1: Period of time in seconds given to the VMI to terminate gracefully. Can only be set to 0 when --force is true (force deletion). Currently only setting 0 is supported.")
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func NewMigrateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "migrate (VM)",
+		Use:     "migrate (VM | --selector SELECTOR)",
 		Short:   "Migrate a virtual machine.",
 		Example: usage(COMMAND_MIGRATE),
-		Args:    templates.ExactArgs("migrate", 1),
+		Args:    vmOrSelectorArgs("migrate"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_MIGRATE, clientConfig: clientConfig}
 			return c.Run(cmd, args)
 		},
 	}
+	addBulkFlags(cmd)
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -122,6 +152,7 @@ func NewRenameCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 			return c.Run(cmd, args)
 		},
 	}
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -137,6 +168,7 @@ func NewGuestOsInfoCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 			return c.Run(cmd, args)
 		},
 	}
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -152,6 +184,7 @@ func NewUserListCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 			return c.Run(cmd, args)
 		},
 	}
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -167,10 +200,44 @@ func NewFSListCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 			return c.Run(cmd, args)
 		},
 	}
+	output.AddFlag(cmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewQMPCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "qmp (VMI) (COMMAND)",
+		Short:   "Execute a read-only QMP command on the qemu monitor of a virtual machine instance.",
+		Example: usage(COMMAND_QMP),
+		Args:    templates.ExactArgs("qmp", 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_QMP, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
+// addBulkFlags adds the flags applying the command to all VMs matching a label selector
+func addBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Apply the command to all VMs of the namespace matching the label selector instead of a single VM, e.g. -l app=web.")
+	cmd.Flags().Int64Var(&batchSize, "batch-size", 0, "Number of matching VMs listed and processed at once by the server, 50 by default. Only used with --selector.")
+	cmd.Flags().Int64Var(&maxConcurrency, "max-concurrency", 0, "Number of VMs of a batch processed in parallel by the server, 10 by default. Only used with --selector.")
+}
+
+// vmOrSelectorArgs expects the name of a VM, unless the command is applied to a label selector
+func vmOrSelectorArgs(nameOfCommand string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if selector != "" {
+			return templates.ExactArgs(nameOfCommand, 0)(cmd, args)
+		}
+		return templates.ExactArgs(nameOfCommand, 1)(cmd, args)
+	}
+}
+
 type Command struct {
 	clientConfig clientcmd.ClientConfig
 	command      string
@@ -182,16 +249,23 @@ func usage(cmd string) string {
 		usage += fmt.Sprintf("	{{ProgramName}} %s myvm notmyvm", cmd)
 		return usage
 	}
+	if cmd == COMMAND_QMP {
+		usage := "  # Query the block devices of a virtual machine instance called 'myvm':\n"
+		usage += fmt.Sprintf("  {{ProgramName}} %s myvm query-block", cmd)
+		return usage
+	}
 
 	usage := fmt.Sprintf("  # %s a virtual machine called 'myvm':\n", strings.Title(cmd))
 	usage += fmt.Sprintf("  {{ProgramName}} %s myvm", cmd)
+	if cmd == COMMAND_START || cmd == COMMAND_STOP || cmd == COMMAND_MIGRATE {
+		usage += fmt.Sprintf("\n\n  # %s all virtual machines labeled with app=web, 5 at a time:\n", strings.Title(cmd))
+		usage += fmt.Sprintf("  {{ProgramName}} %s -l app=web --max-concurrency=5", cmd)
+	}
 	return usage
 }
 
 func (o *Command) Run(cmd *cobra.Command, args []string) error {
 
-	vmiName := args[0]
-
 	namespace, _, err := o.clientConfig.Namespace()
 	if err != nil {
 		return err
@@ -202,6 +276,12 @@ func (o *Command) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
 	}
 
+	if selector != "" {
+		return o.runBulk(cmd, virtClient, namespace)
+	}
+
+	vmiName := args[0]
+
 	switch o.command {
 	case COMMAND_START:
 		err = virtClient.VirtualMachine(namespace).Start(vmiName)
@@ -213,6 +293,11 @@ func (o *Command) Run(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("Error stopping VirtualMachine %v", err)
 		}
+	case COMMAND_HIBERNATE:
+		err = virtClient.VirtualMachine(namespace).Hibernate(vmiName)
+		if err != nil {
+			return fmt.Errorf("Error hibernating VirtualMachine %v", err)
+		}
 	case COMMAND_RESTART:
 		if gracePeriod != -1 && forceRestart == false {
 			return fmt.Errorf("Can not set gracePeriod without --force=true")
@@ -248,41 +333,97 @@ func (o *Command) Run(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("Error getting guestosinfo of VirtualMachine %s, %v", vmiName, err)
 		}
 
-		data, err := json.MarshalIndent(guestosinfo, "", "  ")
-		if err != nil {
-			return fmt.Errorf("Cannot marshal guestosinfo %v", err)
-		}
-
-		fmt.Printf("%s\n", string(data))
-		return nil
+		return output.Print(cmd, guestosinfo, printIndented(guestosinfo, "guestosinfo"))
 	case COMMAND_USERLIST:
 		userlist, err := virtClient.VirtualMachineInstance(namespace).UserList(vmiName)
 		if err != nil {
 			return fmt.Errorf("Error listing users of VirtualMachine %s, %v", vmiName, err)
 		}
 
-		data, err := json.MarshalIndent(userlist, "", "  ")
-		if err != nil {
-			return fmt.Errorf("Cannot marshal userlist %v", err)
-		}
-
-		fmt.Printf("%s\n", string(data))
-		return nil
+		return output.Print(cmd, userlist, printIndented(userlist, "userlist"))
 	case COMMAND_FSLIST:
 		fslist, err := virtClient.VirtualMachineInstance(namespace).FilesystemList(vmiName)
 		if err != nil {
 			return fmt.Errorf("Error listing filesystems of VirtualMachine %s, %v", vmiName, err)
 		}
 
-		data, err := json.MarshalIndent(fslist, "", "  ")
+		return output.Print(cmd, fslist, printIndented(fslist, "filesystem list"))
+	case COMMAND_QMP:
+		result, err := virtClient.VirtualMachineInstance(namespace).QMPCommand(vmiName, args[1])
 		if err != nil {
-			return fmt.Errorf("Cannot marshal filesystem list %v", err)
+			return fmt.Errorf("Error executing QMP command %s on VirtualMachineInstance %s, %v", args[1], vmiName, err)
 		}
 
-		fmt.Printf("%s\n", string(data))
-		return nil
+		return output.Print(cmd, json.RawMessage(result), printIndented(json.RawMessage(result), "the result of QMP command "+args[1]))
 	}
 
-	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
+	operation := output.Operation{Operation: o.command, Kind: "VirtualMachine", Namespace: namespace, Name: vmiName}
+	var result interface{} = operation
+	if o.command == COMMAND_RENAME {
+		result = renameResult{Operation: operation, NewName: args[1]}
+	}
+	return output.Print(cmd, result, func(out io.Writer) error {
+		_, err := fmt.Fprintf(out, "VM %s was scheduled to %s\n", vmiName, o.command)
+		return err
+	})
+}
+
+// renameResult adds the new name of the VM to the result of a rename
+type renameResult struct {
+	output.Operation
+	NewName string `json:"newName"`
+}
+
+// bulkResult is the result of applying a command to all VMs matching a selector
+type bulkResult struct {
+	Namespace string `json:"namespace"`
+	Selector  string `json:"selector"`
+	*v1.VirtualMachineBulkOperationResult
+}
+
+// printIndented prints what the guest agent or the qemu monitor returned as
+// indented JSON, which is also its text output
+func printIndented(result interface{}, what string) func(out io.Writer) error {
+	return func(out io.Writer) error {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Cannot marshal %s %v", what, err)
+		}
+		_, err = fmt.Fprintf(out, "%s\n", string(data))
+		return err
+	}
+}
+
+// runBulk applies the command to all VMs matching the selector and prints what it was applied to
+func (o *Command) runBulk(cmd *cobra.Command, virtClient kubecli.KubevirtClient, namespace string) error {
+	result, err := virtClient.VirtualMachine(namespace).Bulk(v1.VirtualMachineBulkOperation(o.command), &v1.VirtualMachineBulkOperationOptions{
+		LabelSelector:  selector,
+		BatchSize:      batchSize,
+		MaxConcurrency: maxConcurrency,
+	})
+	if err != nil {
+		return fmt.Errorf("Error applying %s to the VirtualMachines matching %s: %v", o.command, selector, err)
+	}
+
+	err = output.Print(cmd, bulkResult{Namespace: namespace, Selector: selector, VirtualMachineBulkOperationResult: result}, func(out io.Writer) error {
+		for _, name := range result.Succeeded {
+			fmt.Fprintf(out, "VM %s was scheduled to %s\n", name, o.command)
+		}
+		for _, skipped := range result.Skipped {
+			fmt.Fprintf(out, "VM %s was skipped: %s\n", skipped.Name, skipped.Message)
+		}
+		for _, failed := range result.Failed {
+			fmt.Fprintf(out, "VM %s failed: %s\n", failed.Name, failed.Message)
+		}
+		_, err := fmt.Fprintf(out, "%d VMs matched, %d scheduled, %d skipped, %d failed\n", result.Matched, len(result.Succeeded), len(result.Skipped), len(result.Failed))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("Error applying %s to %d VirtualMachines", o.command, len(result.Failed))
+	}
 	return nil
 }
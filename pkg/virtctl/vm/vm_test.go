@@ -1,6 +1,10 @@
 package vm_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -92,6 +96,14 @@ var _ = Describe("VirtualMachine", func() {
 			Expect(cmd.Execute()).To(BeNil())
 		})
 
+		It("should hibernate the VM", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Hibernate(vmName).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("hibernate", vmName)
+			Expect(cmd.Execute()).To(BeNil())
+		})
+
 		It("with spec:running:false when it's false already ", func() {
 			vm := kubecli.NewMinimalVM(vmName)
 			vm.Spec.Running = &notRunning
@@ -207,6 +219,26 @@ var _ = Describe("VirtualMachine", func() {
 			cmd := tests.NewVirtctlCommand("rename", vm.Name, vm.Name+"new")
 			Expect(cmd.Execute()).To(BeNil())
 		})
+
+		It("should print the result of the rename as JSON", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Rename(vmName, &v1.RenameOptions{NewName: "newvm"}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("rename", vmName, "newvm", "-o", "json")
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			Expect(cmd.Execute()).To(Succeed())
+
+			result := map[string]string{}
+			Expect(json.Unmarshal(out.Bytes(), &result)).To(Succeed())
+			Expect(result).To(Equal(map[string]string{
+				"operation": "rename",
+				"kind":      "VirtualMachine",
+				"namespace": k8smetav1.NamespaceDefault,
+				"name":      vmName,
+				"newName":   "newvm",
+			}))
+		})
 	})
 
 	Context("guest agent", func() {
@@ -272,6 +304,103 @@ var _ = Describe("VirtualMachine", func() {
 			cmd := tests.NewVirtctlCommand("userlist", vm.Name)
 			Expect(cmd.Execute()).To(BeNil())
 		})
+
+		It("should return the result of a QMP command", func() {
+			vmi := v1.NewMinimalVMI(vmName)
+
+			kubecli.MockKubevirtClientInstance.
+				EXPECT().
+				VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(vmiInterface).
+				Times(1)
+
+			vmiInterface.EXPECT().QMPCommand(vmi.Name, "query-status").Return(`{"running":true,"status":"running"}`, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("qmp", vmi.Name, "query-status")
+			Expect(cmd.Execute()).To(BeNil())
+		})
+
+		It("should print the result of a QMP command as YAML", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().QMPCommand(vmName, "query-status").Return(`{"running":true,"status":"running"}`, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("qmp", vmName, "query-status", "-o", "yaml")
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(out.String()).To(Equal("running: true\nstatus: running\n"))
+		})
+
+		It("should fail on a QMP command which is not allowed", func() {
+			vmi := v1.NewMinimalVMI(vmName)
+
+			kubecli.MockKubevirtClientInstance.
+				EXPECT().
+				VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(vmiInterface).
+				Times(1)
+
+			vmiInterface.EXPECT().QMPCommand(vmi.Name, "quit").Return("", fmt.Errorf(`QMP command "quit" is not allowed`)).Times(1)
+
+			cmd := tests.NewVirtctlCommand("qmp", vmi.Name, "quit")
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+	})
+
+	Context("with a label selector", func() {
+		It("should migrate all matching VMs", func() {
+			options := &v1.VirtualMachineBulkOperationOptions{LabelSelector: "app=web", MaxConcurrency: 5}
+			result := &v1.VirtualMachineBulkOperationResult{
+				Operation: v1.BulkMigrateOperation,
+				Matched:   2,
+				Succeeded: []string{"web1"},
+				Skipped:   []v1.VirtualMachineBulkOperationMessage{{Name: "web2", Message: "VM is not running"}},
+			}
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Bulk(v1.BulkMigrateOperation, options).Return(result, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate", "-l", "app=web", "--max-concurrency=5")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should fail if the operation failed for a VM", func() {
+			options := &v1.VirtualMachineBulkOperationOptions{LabelSelector: "app=web"}
+			result := &v1.VirtualMachineBulkOperationResult{
+				Operation: v1.BulkStopOperation,
+				Matched:   1,
+				Failed:    []v1.VirtualMachineBulkOperationMessage{{Name: "web1", Message: "Internal error occurred"}},
+			}
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Bulk(v1.BulkStopOperation, options).Return(result, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("stop", "--selector", "app=web")
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+
+		It("should print the bulk result with the namespace and the selector as YAML", func() {
+			options := &v1.VirtualMachineBulkOperationOptions{LabelSelector: "app=web"}
+			result := &v1.VirtualMachineBulkOperationResult{
+				Operation: v1.BulkStartOperation,
+				Matched:   1,
+				Succeeded: []string{"web1"},
+			}
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Bulk(v1.BulkStartOperation, options).Return(result, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("start", "-l", "app=web", "-o", "yaml")
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(out.String()).To(Equal("matched: 1\nnamespace: default\noperation: start\nselector: app=web\nsucceeded:\n- web1\n"))
+		})
+
+		It("should not accept a VM name", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("start", vmName, "-l", "app=web")
+			Expect(cmd()).ToNot(Succeed())
+		})
 	})
 
 	AfterEach(func() {
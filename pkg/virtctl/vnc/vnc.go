@@ -36,6 +36,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/output"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
 
@@ -76,6 +77,7 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd.Flags().BoolVar(&proxyOnly, "proxy-only", proxyOnly, "--proxy-only=false: Setting this true will run only the virtctl vnc proxy and show the localhost port where VNC viewers can connect")
 	cmd.Flags().IntVar(&customPort, "port", customPort,
 		"--port=0: Assigning a port value to this will try to run the proxy on the given port if the port is accessible; If unassigned, the proxy will run on a random port")
+	output.AddFlag(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -84,6 +86,13 @@ type VNC struct {
 	clientConfig clientcmd.ClientConfig
 }
 
+// proxyResult tells where VNC viewers connect to the proxy of a VMI
+type proxyResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Port      int    `json:"port"`
+}
+
 func (o *VNC) Run(cmd *cobra.Command, args []string) error {
 	namespace, _, err := o.clientConfig.Namespace()
 	if err != nil {
@@ -175,15 +184,27 @@ func (o *VNC) Run(cmd *cobra.Command, args []string) error {
 
 	port := ln.Addr().(*net.TCPAddr).Port
 
-	if proxyOnly {
-		defer close(doneChan)
-		optionString, err := json.Marshal(struct {
-			Port int `json:"port"`
-		}{port})
+	// the port is printed for the viewers started by the user, or on request
+	if proxyOnly || output.Requested(cmd) {
+		result := proxyResult{Namespace: namespace, Name: vmi, Port: port}
+		err := output.Print(cmd, result, func(out io.Writer) error {
+			optionString, err := json.Marshal(struct {
+				Port int `json:"port"`
+			}{port})
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(out, string(optionString))
+			return err
+		})
 		if err != nil {
+			close(doneChan)
 			return fmt.Errorf("Error encountered: %s", err.Error())
 		}
-		fmt.Fprintln(cmd.OutOrStdout(), string(optionString))
+	}
+
+	if proxyOnly {
+		defer close(doneChan)
 	} else {
 		// execute VNC Viewer
 		go checkAndRunVNCViewer(doneChan, viewResChan, port)
@@ -0,0 +1,245 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package vmtemplate processes VirtualMachineTemplates, substituting the values
+// of their parameters into the VirtualMachines they describe.
+package vmtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	templatev1 "kubevirt.io/client-go/apis/template/v1alpha1"
+)
+
+var (
+	// parameters are referenced like ${NAME}
+	referencePattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+	parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// registry[:port]/repository[:tag][@digest], as accepted for containerDisks
+	imagePattern = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(:[0-9]+)?(/[a-z0-9]+([._-][a-z0-9]+)*)*(:[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+
+	knownTypes = map[templatev1.TemplateParameterType]bool{
+		"":                         true,
+		templatev1.ParameterString: true,
+		templatev1.ParameterName:   true,
+		templatev1.ParameterMemory: true,
+		templatev1.ParameterImage:  true,
+		templatev1.ParameterSSHKey: true,
+	}
+)
+
+// Process returns the VirtualMachine of the template with the given values
+// substituted for its parameters. Parameters without a value take their
+// default, the VirtualMachine is created in the namespace of the template.
+func Process(template *templatev1.VirtualMachineTemplate, values map[string]string) (*v1.VirtualMachine, error) {
+	resolved, err := resolveParameters(template.Spec.Parameters, values)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rawVirtualMachine(template)
+	if err != nil {
+		return nil, err
+	}
+	var manifest interface{}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid VirtualMachine in template %s: %v", template.Name, err)
+	}
+	manifest, err = substitute(manifest, resolved)
+	if err != nil {
+		return nil, err
+	}
+	raw, err = json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := &v1.VirtualMachine{}
+	if err := json.Unmarshal(raw, vm); err != nil {
+		return nil, fmt.Errorf("the processed template is not a valid VirtualMachine: %v", err)
+	}
+	if err := checkKind(vm); err != nil {
+		return nil, err
+	}
+	vm.Namespace = template.Namespace
+	return vm, nil
+}
+
+// ValidateParameters checks that the parameters of a template have unique,
+// referencable names, known types and valid defaults.
+func ValidateParameters(parameters []templatev1.TemplateParameter) error {
+	names := map[string]bool{}
+	for _, parameter := range parameters {
+		if !parameterNamePattern.MatchString(parameter.Name) {
+			return fmt.Errorf("invalid parameter name %q", parameter.Name)
+		}
+		if names[parameter.Name] {
+			return fmt.Errorf("parameter %s is defined twice", parameter.Name)
+		}
+		names[parameter.Name] = true
+		if !knownTypes[parameter.Type] {
+			return fmt.Errorf("parameter %s has unknown type %s", parameter.Name, parameter.Type)
+		}
+		if parameter.Value != "" {
+			if err := validateValue(parameter, parameter.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resolveParameters(parameters []templatev1.TemplateParameter, values map[string]string) (map[string]string, error) {
+	if err := ValidateParameters(parameters); err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, parameter := range parameters {
+		known[parameter.Name] = true
+	}
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown parameters: %s", strings.Join(unknown, ", "))
+	}
+
+	resolved := map[string]string{}
+	var missing []string
+	for _, parameter := range parameters {
+		value, exists := values[parameter.Name]
+		if !exists || value == "" {
+			value = parameter.Value
+		}
+		if value == "" {
+			if parameter.Required {
+				missing = append(missing, parameter.Name)
+			}
+			resolved[parameter.Name] = ""
+			continue
+		}
+		if err := validateValue(parameter, value); err != nil {
+			return nil, err
+		}
+		resolved[parameter.Name] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("required parameters have no value: %s", strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+func validateValue(parameter templatev1.TemplateParameter, value string) error {
+	switch parameter.Type {
+	case templatev1.ParameterName:
+		if errs := validation.IsDNS1123Label(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q of parameter %s: %s", value, parameter.Name, strings.Join(errs, ", "))
+		}
+	case templatev1.ParameterMemory:
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q of parameter %s: %v", value, parameter.Name, err)
+		}
+		if quantity.Sign() <= 0 {
+			return fmt.Errorf("invalid value %q of parameter %s: the memory has to be positive", value, parameter.Name)
+		}
+	case templatev1.ParameterImage:
+		if !imagePattern.MatchString(value) {
+			return fmt.Errorf("invalid value %q of parameter %s: not an image reference", value, parameter.Name)
+		}
+	case templatev1.ParameterSSHKey:
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(value)); err != nil {
+			return fmt.Errorf("invalid value of parameter %s: %v", parameter.Name, err)
+		}
+	}
+	return nil
+}
+
+func rawVirtualMachine(template *templatev1.VirtualMachineTemplate) ([]byte, error) {
+	if len(template.Spec.VirtualMachine.Raw) > 0 {
+		return template.Spec.VirtualMachine.Raw, nil
+	}
+	if template.Spec.VirtualMachine.Object != nil {
+		return json.Marshal(template.Spec.VirtualMachine.Object)
+	}
+	return nil, fmt.Errorf("template %s has no VirtualMachine", template.Name)
+}
+
+// substitute replaces the parameter references in all string values of the
+// manifest, keys are left untouched.
+func substitute(node interface{}, values map[string]string) (interface{}, error) {
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			substituted, err := substitute(value, values)
+			if err != nil {
+				return nil, err
+			}
+			typed[key] = substituted
+		}
+	case []interface{}:
+		for i, value := range typed {
+			substituted, err := substitute(value, values)
+			if err != nil {
+				return nil, err
+			}
+			typed[i] = substituted
+		}
+	case string:
+		var err error
+		substituted := referencePattern.ReplaceAllStringFunc(typed, func(reference string) string {
+			name := reference[2 : len(reference)-1]
+			value, exists := values[name]
+			if !exists && err == nil {
+				err = fmt.Errorf("the VirtualMachine references the undefined parameter %s", name)
+			}
+			return value
+		})
+		return substituted, err
+	}
+	return node, nil
+}
+
+func checkKind(vm *v1.VirtualMachine) error {
+	gv, err := schema.ParseGroupVersion(vm.APIVersion)
+	if err != nil {
+		return err
+	}
+	if gv.Group != v1.GroupName || vm.Kind != v1.VirtualMachineGroupVersionKind.Kind {
+		return fmt.Errorf("the template describes a %s of %s instead of a VirtualMachine", vm.Kind, vm.APIVersion)
+	}
+	return nil
+}
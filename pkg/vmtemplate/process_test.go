@@ -0,0 +1,143 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmtemplate
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templatev1 "kubevirt.io/client-go/apis/template/v1alpha1"
+)
+
+const (
+	sshKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIEh0kNyGVgPJpWTRZPvxrmCf3n1uxn8Vm4x/eFjBkwBT user@example.com"
+
+	virtualMachine = `{
+  "apiVersion": "kubevirt.io/v1alpha3",
+  "kind": "VirtualMachine",
+  "metadata": {
+    "name": "${NAME}",
+    "labels": {"app": "${NAME}"}
+  },
+  "spec": {
+    "running": false,
+    "template": {
+      "spec": {
+        "domain": {
+          "devices": {},
+          "resources": {"requests": {"memory": "${MEMORY}"}}
+        },
+        "volumes": [
+          {"name": "rootdisk", "containerDisk": {"image": "${IMAGE}"}},
+          {"name": "cloudinitdisk", "cloudInitNoCloud": {"userData": "#cloud-config\nssh_authorized_keys:\n- ${SSH_KEY}\n"}}
+        ]
+      }
+    }
+  }
+}`
+)
+
+func newTemplate(vm string) *templatev1.VirtualMachineTemplate {
+	return &templatev1.VirtualMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "fedora", Namespace: "catalog"},
+		Spec: templatev1.VirtualMachineTemplateSpec{
+			Parameters: []templatev1.TemplateParameter{
+				{Name: "NAME", Type: templatev1.ParameterName, Required: true},
+				{Name: "MEMORY", Type: templatev1.ParameterMemory, Value: "1Gi"},
+				{Name: "IMAGE", Type: templatev1.ParameterImage, Value: "quay.io/kubevirt/fedora-cloud-container-disk-demo:latest"},
+				{Name: "SSH_KEY", Type: templatev1.ParameterSSHKey},
+			},
+			VirtualMachine: runtime.RawExtension{Raw: []byte(vm)},
+		},
+	}
+}
+
+var _ = Describe("VirtualMachineTemplate", func() {
+
+	It("should substitute the values and defaults of the parameters", func() {
+		vm, err := Process(newTemplate(virtualMachine), map[string]string{
+			"NAME":    "fedora01",
+			"MEMORY":  "2Gi",
+			"SSH_KEY": sshKey,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vm.Name).To(Equal("fedora01"))
+		Expect(vm.Namespace).To(Equal("catalog"))
+		Expect(vm.Labels).To(HaveKeyWithValue("app", "fedora01"))
+
+		spec := vm.Spec.Template.Spec
+		memory := spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+		Expect(memory.String()).To(Equal("2Gi"))
+		Expect(spec.Volumes[0].ContainerDisk.Image).To(Equal("quay.io/kubevirt/fedora-cloud-container-disk-demo:latest"))
+		Expect(spec.Volumes[1].CloudInitNoCloud.UserData).To(ContainSubstring("- " + sshKey + "\n"))
+	})
+
+	It("should substitute optional parameters without value with an empty string", func() {
+		vm, err := Process(newTemplate(virtualMachine), map[string]string{"NAME": "fedora01"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vm.Spec.Template.Spec.Volumes[1].CloudInitNoCloud.UserData).To(HaveSuffix("- \n"))
+	})
+
+	table.DescribeTable("should reject", func(values map[string]string, message string) {
+		_, err := Process(newTemplate(virtualMachine), values)
+		Expect(err).To(MatchError(ContainSubstring(message)))
+	},
+		table.Entry("missing required parameters", map[string]string{}, "required parameters have no value: NAME"),
+		table.Entry("unknown parameters", map[string]string{"NAME": "fedora01", "CPU": "2"}, "unknown parameters: CPU"),
+		table.Entry("invalid names", map[string]string{"NAME": "Fedora_01"}, "invalid value \"Fedora_01\" of parameter NAME"),
+		table.Entry("invalid memory", map[string]string{"NAME": "fedora01", "MEMORY": "lots"}, "invalid value \"lots\" of parameter MEMORY"),
+		table.Entry("negative memory", map[string]string{"NAME": "fedora01", "MEMORY": "-1Gi"}, "the memory has to be positive"),
+		table.Entry("invalid images", map[string]string{"NAME": "fedora01", "IMAGE": "quay.io/Fedora latest"}, "not an image reference"),
+		table.Entry("invalid ssh keys", map[string]string{"NAME": "fedora01", "SSH_KEY": "ssh-rsa garbage"}, "invalid value of parameter SSH_KEY"),
+	)
+
+	It("should accept images with a registry port and a digest", func() {
+		_, err := Process(newTemplate(virtualMachine), map[string]string{
+			"NAME":  "fedora01",
+			"IMAGE": "registry.example.com:5000/vms/fedora@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should reject references to undefined parameters", func() {
+		_, err := Process(newTemplate(`{"apiVersion": "kubevirt.io/v1alpha3", "kind": "VirtualMachine", "metadata": {"name": "${NAME}-${SUFFIX}"}}`),
+			map[string]string{"NAME": "fedora01"})
+		Expect(err).To(MatchError("the VirtualMachine references the undefined parameter SUFFIX"))
+	})
+
+	It("should reject templates of other kinds", func() {
+		_, err := Process(newTemplate(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "${NAME}"}}`),
+			map[string]string{"NAME": "fedora01"})
+		Expect(err).To(MatchError(ContainSubstring("instead of a VirtualMachine")))
+	})
+
+	table.DescribeTable("should reject parameter definitions", func(parameters []templatev1.TemplateParameter, message string) {
+		Expect(ValidateParameters(parameters)).To(MatchError(ContainSubstring(message)))
+	},
+		table.Entry("with invalid names", []templatev1.TemplateParameter{{Name: "vm-name"}}, "invalid parameter name"),
+		table.Entry("defined twice", []templatev1.TemplateParameter{{Name: "NAME"}, {Name: "NAME"}}, "defined twice"),
+		table.Entry("with unknown types", []templatev1.TemplateParameter{{Name: "CORES", Type: "Integer"}}, "unknown type Integer"),
+		table.Entry("with invalid defaults", []templatev1.TemplateParameter{{Name: "MEMORY", Type: templatev1.ParameterMemory, Value: "1 GB"}}, "invalid value \"1 GB\""),
+	)
+})
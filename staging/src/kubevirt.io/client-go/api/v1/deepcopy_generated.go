@@ -132,6 +132,32 @@ func (in *BIOS) DeepCopy() *BIOS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootMenu) DeepCopyInto(out *BootMenu) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootMenu.
+func (in *BootMenu) DeepCopy() *BootMenu {
+	if in == nil {
+		return nil
+	}
+	out := new(BootMenu)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bootloader) DeepCopyInto(out *Bootloader) {
 	*out = *in
@@ -158,6 +184,47 @@ func (in *Bootloader) DeepCopy() *Bootloader {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BridgeConfiguration) DeepCopyInto(out *BridgeConfiguration) {
+	*out = *in
+	if in.STP != nil {
+		in, out := &in.STP, &out.STP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AgeingTimeSeconds != nil {
+		in, out := &in.AgeingTimeSeconds, &out.AgeingTimeSeconds
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MulticastSnooping != nil {
+		in, out := &in.MulticastSnooping, &out.MulticastSnooping
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MulticastQuerier != nil {
+		in, out := &in.MulticastQuerier, &out.MulticastQuerier
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VLANFiltering != nil {
+		in, out := &in.VLANFiltering, &out.VLANFiltering
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BridgeConfiguration.
+func (in *BridgeConfiguration) DeepCopy() *BridgeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BridgeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDRomTarget) DeepCopyInto(out *CDRomTarget) {
 	*out = *in
@@ -187,6 +254,11 @@ func (in *CPU) DeepCopyInto(out *CPU) {
 		*out = make([]CPUFeature, len(*in))
 		copy(*out, *in)
 	}
+	if in.NUMA != nil {
+		in, out := &in.NUMA, &out.NUMA
+		*out = new(NUMA)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -200,6 +272,50 @@ func (in *CPU) DeepCopy() *CPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUBaseline) DeepCopyInto(out *CPUBaseline) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUBaseline.
+func (in *CPUBaseline) DeepCopy() *CPUBaseline {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUBaseline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUBaselineStatus) DeepCopyInto(out *CPUBaselineStatus) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUBaselineStatus.
+func (in *CPUBaselineStatus) DeepCopy() *CPUBaselineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUBaselineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPUFeature) DeepCopyInto(out *CPUFeature) {
 	*out = *in
@@ -216,6 +332,22 @@ func (in *CPUFeature) DeepCopy() *CPUFeature {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Channel) DeepCopyInto(out *Channel) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Channel.
+func (in *Channel) DeepCopy() *Channel {
+	if in == nil {
+		return nil
+	}
+	out := new(Channel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Chassis) DeepCopyInto(out *Chassis) {
 	*out = *in
@@ -412,9 +544,40 @@ func (in *ConfigMapVolumeSource) DeepCopy() *ConfigMapVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsoleConfiguration) DeepCopyInto(out *ConsoleConfiguration) {
+	*out = *in
+	if in.MaxSessions != nil {
+		in, out := &in.MaxSessions, &out.MaxSessions
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleConfiguration.
+func (in *ConsoleConfiguration) DeepCopy() *ConsoleConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsoleConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerDiskSource) DeepCopyInto(out *ContainerDiskSource) {
 	*out = *in
+	if in.EphemeralImage != nil {
+		in, out := &in.EphemeralImage, &out.EphemeralImage
+		*out = new(EphemeralImage)
+		**out = **in
+	}
 	return
 }
 
@@ -428,6 +591,22 @@ func (in *ContainerDiskSource) DeepCopy() *ContainerDiskSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskStatus) DeepCopyInto(out *ContainerDiskStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskStatus.
+func (in *ContainerDiskStatus) DeepCopy() *ContainerDiskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomizeComponents) DeepCopyInto(out *CustomizeComponents) {
 	*out = *in
@@ -578,6 +757,18 @@ func (in *DeveloperConfiguration) DeepCopyInto(out *DeveloperConfiguration) {
 			(*out)[key] = val
 		}
 	}
+	if in.LogVerbosity != nil {
+		in, out := &in.LogVerbosity, &out.LogVerbosity
+		*out = new(LogVerbosity)
+		**out = **in
+	}
+	if in.ScopedFeatureGates != nil {
+		in, out := &in.ScopedFeatureGates, &out.ScopedFeatureGates
+		*out = make([]ScopedFeatureGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -628,6 +819,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AutoattachInputDevice != nil {
+		in, out := &in.AutoattachInputDevice, &out.AutoattachInputDevice
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AutoattachSerialConsole != nil {
 		in, out := &in.AutoattachSerialConsole, &out.AutoattachSerialConsole
 		*out = new(bool)
@@ -638,6 +834,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AutoattachVirtioWinDriverDisk != nil {
+		in, out := &in.AutoattachVirtioWinDriverDisk, &out.AutoattachVirtioWinDriverDisk
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Rng != nil {
 		in, out := &in.Rng, &out.Rng
 		*out = new(Rng)
@@ -653,6 +854,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NetworkInterfaceQueues != nil {
+		in, out := &in.NetworkInterfaceQueues, &out.NetworkInterfaceQueues
+		*out = new(uint32)
+		**out = **in
+	}
 	if in.GPUs != nil {
 		in, out := &in.GPUs, &out.GPUs
 		*out = make([]GPU, len(*in))
@@ -670,6 +876,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = make([]HostDevice, len(*in))
 		copy(*out, *in)
 	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]Channel, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -803,6 +1014,11 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(Chassis)
 		**out = **in
 	}
+	if in.AllowEmulation != nil {
+		in, out := &in.AllowEmulation, &out.AllowEmulation
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -877,6 +1093,22 @@ func (in *EmptyDiskSource) DeepCopy() *EmptyDiskSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralImage) DeepCopyInto(out *EphemeralImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralImage.
+func (in *EphemeralImage) DeepCopy() *EphemeralImage {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralVolumeSource) DeepCopyInto(out *EphemeralVolumeSource) {
 	*out = *in
@@ -885,6 +1117,11 @@ func (in *EphemeralVolumeSource) DeepCopyInto(out *EphemeralVolumeSource) {
 		*out = new(corev1.PersistentVolumeClaimVolumeSource)
 		**out = **in
 	}
+	if in.EphemeralImage != nil {
+		in, out := &in.EphemeralImage, &out.EphemeralImage
+		*out = new(EphemeralImage)
+		**out = **in
+	}
 	return
 }
 
@@ -1126,6 +1363,103 @@ func (in *Features) DeepCopy() *Features {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FencingRequest) DeepCopyInto(out *FencingRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FencingRequest.
+func (in *FencingRequest) DeepCopy() *FencingRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(FencingRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FencingRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FencingRequestList) DeepCopyInto(out *FencingRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FencingRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FencingRequestList.
+func (in *FencingRequestList) DeepCopy() *FencingRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(FencingRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FencingRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FencingRequestSpec) DeepCopyInto(out *FencingRequestSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FencingRequestSpec.
+func (in *FencingRequestSpec) DeepCopy() *FencingRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FencingRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FencingRequestStatus) DeepCopyInto(out *FencingRequestStatus) {
+	*out = *in
+	if in.FencedTimestamp != nil {
+		in, out := &in.FencedTimestamp, &out.FencedTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FencingRequestStatus.
+func (in *FencingRequestStatus) DeepCopy() *FencingRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FencingRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filesystem) DeepCopyInto(out *Filesystem) {
 	*out = *in
@@ -1171,6 +1505,11 @@ func (in *Firmware) DeepCopyInto(out *Firmware) {
 		*out = new(Bootloader)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BootMenu != nil {
+		in, out := &in.BootMenu, &out.BootMenu
+		*out = new(BootMenu)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1216,6 +1555,22 @@ func (in *GPU) DeepCopy() *GPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericHostDevice) DeepCopyInto(out *GenericHostDevice) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericHostDevice.
+func (in *GenericHostDevice) DeepCopy() *GenericHostDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericHostDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPETTimer) DeepCopyInto(out *HPETTimer) {
 	*out = *in
@@ -1397,10 +1752,46 @@ func (in *I6300ESBWatchdog) DeepCopy() *I6300ESBWatchdog {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Input) DeepCopyInto(out *Input) {
+func (in *IdlePolicyConfiguration) DeepCopyInto(out *IdlePolicyConfiguration) {
 	*out = *in
-	return
-}
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SampleIntervalSeconds != nil {
+		in, out := &in.SampleIntervalSeconds, &out.SampleIntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CPUThreshold != nil {
+		in, out := &in.CPUThreshold, &out.CPUThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.NetworkThreshold != nil {
+		in, out := &in.NetworkThreshold, &out.NetworkThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdlePolicyConfiguration.
+func (in *IdlePolicyConfiguration) DeepCopy() *IdlePolicyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(IdlePolicyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Input) DeepCopyInto(out *Input) {
+	*out = *in
+	return
+}
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Input.
 func (in *Input) DeepCopy() *Input {
@@ -1431,6 +1822,46 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(DHCPOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]InterfaceRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.RxQueueSize != nil {
+		in, out := &in.RxQueueSize, &out.RxQueueSize
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.TxQueueSize != nil {
+		in, out := &in.TxQueueSize, &out.TxQueueSize
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Offloads != nil {
+		in, out := &in.Offloads, &out.Offloads
+		*out = new(InterfaceOffloads)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PackedRing != nil {
+		in, out := &in.PackedRing, &out.PackedRing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VhostZeroCopyTX != nil {
+		in, out := &in.VhostZeroCopyTX, &out.VhostZeroCopyTX
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(InterfaceMirror)
+		**out = **in
+	}
+	if in.RSS != nil {
+		in, out := &in.RSS, &out.RSS
+		*out = new(InterfaceRSS)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1450,7 +1881,7 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 	if in.Bridge != nil {
 		in, out := &in.Bridge, &out.Bridge
 		*out = new(InterfaceBridge)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Slirp != nil {
 		in, out := &in.Slirp, &out.Slirp
@@ -1460,7 +1891,7 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 	if in.Masquerade != nil {
 		in, out := &in.Masquerade, &out.Masquerade
 		*out = new(InterfaceMasquerade)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.SRIOV != nil {
 		in, out := &in.SRIOV, &out.SRIOV
@@ -1488,6 +1919,11 @@ func (in *InterfaceBindingMethod) DeepCopy() *InterfaceBindingMethod {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceBridge) DeepCopyInto(out *InterfaceBridge) {
 	*out = *in
+	if in.GuestAddress != nil {
+		in, out := &in.GuestAddress, &out.GuestAddress
+		*out = new(InterfaceBridgeGuestAddress)
+		**out = **in
+	}
 	return
 }
 
@@ -1501,6 +1937,22 @@ func (in *InterfaceBridge) DeepCopy() *InterfaceBridge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBridgeGuestAddress) DeepCopyInto(out *InterfaceBridgeGuestAddress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBridgeGuestAddress.
+func (in *InterfaceBridgeGuestAddress) DeepCopy() *InterfaceBridgeGuestAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBridgeGuestAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceMacvtap) DeepCopyInto(out *InterfaceMacvtap) {
 	*out = *in
@@ -1520,6 +1972,11 @@ func (in *InterfaceMacvtap) DeepCopy() *InterfaceMacvtap {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceMasquerade) DeepCopyInto(out *InterfaceMasquerade) {
 	*out = *in
+	if in.ClampMSS != nil {
+		in, out := &in.ClampMSS, &out.ClampMSS
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1533,6 +1990,79 @@ func (in *InterfaceMasquerade) DeepCopy() *InterfaceMasquerade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceMirror) DeepCopyInto(out *InterfaceMirror) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceMirror.
+func (in *InterfaceMirror) DeepCopy() *InterfaceMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceOffloads) DeepCopyInto(out *InterfaceOffloads) {
+	*out = *in
+	if in.TSO != nil {
+		in, out := &in.TSO, &out.TSO
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GSO != nil {
+		in, out := &in.GSO, &out.GSO
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GRO != nil {
+		in, out := &in.GRO, &out.GRO
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TXChecksum != nil {
+		in, out := &in.TXChecksum, &out.TXChecksum
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceOffloads.
+func (in *InterfaceOffloads) DeepCopy() *InterfaceOffloads {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceOffloads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceRSS) DeepCopyInto(out *InterfaceRSS) {
+	*out = *in
+	if in.HashReport != nil {
+		in, out := &in.HashReport, &out.HashReport
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceRSS.
+func (in *InterfaceRSS) DeepCopy() *InterfaceRSS {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceRSS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceSRIOV) DeepCopyInto(out *InterfaceSRIOV) {
 	*out = *in
@@ -1701,6 +2231,52 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(PermittedHostDevices)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConsoleConfiguration != nil {
+		in, out := &in.ConsoleConfiguration, &out.ConsoleConfiguration
+		*out = new(ConsoleConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageAccountingConfiguration != nil {
+		in, out := &in.UsageAccountingConfiguration, &out.UsageAccountingConfiguration
+		*out = new(UsageAccountingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionPolicies != nil {
+		in, out := &in.AdmissionPolicies, &out.AdmissionPolicies
+		*out = make([]VMIAdmissionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeDensity != nil {
+		in, out := &in.NodeDensity, &out.NodeDensity
+		*out = new(NodeDensityConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdlePolicy != nil {
+		in, out := &in.IdlePolicy, &out.IdlePolicy
+		*out = new(IdlePolicyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPUBaselines != nil {
+		in, out := &in.CPUBaselines, &out.CPUBaselines
+		*out = make([]CPUBaseline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedImportURLs != nil {
+		in, out := &in.AllowedImportURLs, &out.AllowedImportURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QoSClasses != nil {
+		in, out := &in.QoSClasses, &out.QoSClasses
+		*out = make([]QoSClassConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1714,6 +2290,64 @@ func (in *KubeVirtConfiguration) DeepCopy() *KubeVirtConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtConfigurationImpact) DeepCopyInto(out *KubeVirtConfigurationImpact) {
+	*out = *in
+	if in.VirtualMachineInstances != nil {
+		in, out := &in.VirtualMachineInstances, &out.VirtualMachineInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VirtualMachines != nil {
+		in, out := &in.VirtualMachines, &out.VirtualMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtConfigurationImpact.
+func (in *KubeVirtConfigurationImpact) DeepCopy() *KubeVirtConfigurationImpact {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtConfigurationImpact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtConfigurationValidation) DeepCopyInto(out *KubeVirtConfigurationValidation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Impacts != nil {
+		in, out := &in.Impacts, &out.Impacts
+		*out = make([]KubeVirtConfigurationImpact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtConfigurationValidation.
+func (in *KubeVirtConfigurationValidation) DeepCopy() *KubeVirtConfigurationValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtConfigurationValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeVirtConfigurationValidation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirtList) DeepCopyInto(out *KubeVirtList) {
 	*out = *in
@@ -1817,6 +2451,13 @@ func (in *KubeVirtStatus) DeepCopyInto(out *KubeVirtStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CPUBaselines != nil {
+		in, out := &in.CPUBaselines, &out.CPUBaselines
+		*out = make([]CPUBaselineStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1830,6 +2471,22 @@ func (in *KubeVirtStatus) DeepCopy() *KubeVirtStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogVerbosity) DeepCopyInto(out *LogVerbosity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogVerbosity.
+func (in *LogVerbosity) DeepCopy() *LogVerbosity {
+	if in == nil {
+		return nil
+	}
+	out := new(LogVerbosity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LunTarget) DeepCopyInto(out *LunTarget) {
 	*out = *in
@@ -1862,6 +2519,27 @@ func (in *Machine) DeepCopy() *Machine {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasqueradeSubnetPool) DeepCopyInto(out *MasqueradeSubnetPool) {
+	*out = *in
+	if in.PrefixLength != nil {
+		in, out := &in.PrefixLength, &out.PrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasqueradeSubnetPool.
+func (in *MasqueradeSubnetPool) DeepCopy() *MasqueradeSubnetPool {
+	if in == nil {
+		return nil
+	}
+	out := new(MasqueradeSubnetPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MediatedHostDevice) DeepCopyInto(out *MediatedHostDevice) {
 	*out = *in
@@ -1904,6 +2582,22 @@ func (in *Memory) DeepCopy() *Memory {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataService) DeepCopyInto(out *MetadataService) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataService.
+func (in *MetadataService) DeepCopy() *MetadataService {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 	*out = *in
@@ -1966,51 +2660,145 @@ func (in *MigrationConfiguration) DeepCopy() *MigrationConfiguration {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
+func (in *MigrationInterfaceNetworkState) DeepCopyInto(out *MigrationInterfaceNetworkState) {
 	*out = *in
+	if in.Masquerade != nil {
+		in, out := &in.Masquerade, &out.Masquerade
+		*out = new(MigrationMasqueradeNetworkState)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultusNetwork.
-func (in *MultusNetwork) DeepCopy() *MultusNetwork {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationInterfaceNetworkState.
+func (in *MigrationInterfaceNetworkState) DeepCopy() *MigrationInterfaceNetworkState {
 	if in == nil {
 		return nil
 	}
-	out := new(MultusNetwork)
+	out := new(MigrationInterfaceNetworkState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Network) DeepCopyInto(out *Network) {
+func (in *MigrationMasqueradeNetworkState) DeepCopyInto(out *MigrationMasqueradeNetworkState) {
 	*out = *in
-	in.NetworkSource.DeepCopyInto(&out.NetworkSource)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]Port, len(*in))
+		copy(*out, *in)
+	}
+	if in.NatRules != nil {
+		in, out := &in.NatRules, &out.NatRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
-func (in *Network) DeepCopy() *Network {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationMasqueradeNetworkState.
+func (in *MigrationMasqueradeNetworkState) DeepCopy() *MigrationMasqueradeNetworkState {
 	if in == nil {
 		return nil
 	}
-	out := new(Network)
+	out := new(MigrationMasqueradeNetworkState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
+func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
 	*out = *in
-	if in.PermitSlirpInterface != nil {
-		in, out := &in.PermitSlirpInterface, &out.PermitSlirpInterface
-		*out = new(bool)
-		**out = **in
-	}
-	if in.PermitBridgeInterfaceOnPodNetwork != nil {
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultusNetwork.
+func (in *MultusNetwork) DeepCopy() *MultusNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(MultusNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMA) DeepCopyInto(out *NUMA) {
+	*out = *in
+	if in.GuestMappingPassthrough != nil {
+		in, out := &in.GuestMappingPassthrough, &out.GuestMappingPassthrough
+		*out = new(NUMAGuestMappingPassthrough)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMA.
+func (in *NUMA) DeepCopy() *NUMA {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NUMAGuestMappingPassthrough) DeepCopyInto(out *NUMAGuestMappingPassthrough) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NUMAGuestMappingPassthrough.
+func (in *NUMAGuestMappingPassthrough) DeepCopy() *NUMAGuestMappingPassthrough {
+	if in == nil {
+		return nil
+	}
+	out := new(NUMAGuestMappingPassthrough)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Network) DeepCopyInto(out *Network) {
+	*out = *in
+	in.NetworkSource.DeepCopyInto(&out.NetworkSource)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
+func (in *Network) DeepCopy() *Network {
+	if in == nil {
+		return nil
+	}
+	out := new(Network)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
+	*out = *in
+	if in.PermitSlirpInterface != nil {
+		in, out := &in.PermitSlirpInterface, &out.PermitSlirpInterface
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PermitBridgeInterfaceOnPodNetwork != nil {
 		in, out := &in.PermitBridgeInterfaceOnPodNetwork, &out.PermitBridgeInterfaceOnPodNetwork
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Bridge != nil {
+		in, out := &in.Bridge, &out.Bridge
+		*out = new(BridgeConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MasqueradeSubnetPool != nil {
+		in, out := &in.MasqueradeSubnetPool, &out.MasqueradeSubnetPool
+		*out = new(MasqueradeSubnetPool)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2050,6 +2838,27 @@ func (in *NetworkSource) DeepCopy() *NetworkSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDensityConfiguration) DeepCopyInto(out *NodeDensityConfiguration) {
+	*out = *in
+	if in.MemoryOvercommitBudget != nil {
+		in, out := &in.MemoryOvercommitBudget, &out.MemoryOvercommitBudget
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDensityConfiguration.
+func (in *NodeDensityConfiguration) DeepCopy() *NodeDensityConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDensityConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
 	*out = *in
@@ -2085,6 +2894,22 @@ func (in *NodePlacement) DeepCopy() *NodePlacement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NvmeNamespaceHostDevice) DeepCopyInto(out *NvmeNamespaceHostDevice) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NvmeNamespaceHostDevice.
+func (in *NvmeNamespaceHostDevice) DeepCopy() *NvmeNamespaceHostDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(NvmeNamespaceHostDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PITTimer) DeepCopyInto(out *PITTimer) {
 	*out = *in
@@ -2135,6 +2960,16 @@ func (in *PermittedHostDevices) DeepCopyInto(out *PermittedHostDevices) {
 		*out = make([]MediatedHostDevice, len(*in))
 		copy(*out, *in)
 	}
+	if in.GenericHostDevices != nil {
+		in, out := &in.GenericHostDevices, &out.GenericHostDevices
+		*out = make([]GenericHostDevice, len(*in))
+		copy(*out, *in)
+	}
+	if in.NvmeNamespaces != nil {
+		in, out := &in.NvmeNamespaces, &out.NvmeNamespaces
+		*out = make([]NvmeNamespaceHostDevice, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2234,6 +3069,24 @@ func (in *QemuGuestAgentUserPasswordAccessCredentialPropagation) DeepCopy() *Qem
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSClassConfiguration) DeepCopyInto(out *QoSClassConfiguration) {
+	*out = *in
+	out.NetworkRate = in.NetworkRate.DeepCopy()
+	out.NetworkCeil = in.NetworkCeil.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSClassConfiguration.
+func (in *QoSClassConfiguration) DeepCopy() *QoSClassConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSClassConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RTCTimer) DeepCopyInto(out *RTCTimer) {
 	*out = *in
@@ -2442,6 +3295,32 @@ func (in *SSHPublicKeyAccessCredentialSource) DeepCopy() *SSHPublicKeyAccessCred
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScopedFeatureGate) DeepCopyInto(out *ScopedFeatureGate) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopedFeatureGate.
+func (in *ScopedFeatureGate) DeepCopy() *ScopedFeatureGate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScopedFeatureGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretVolumeSource) DeepCopyInto(out *SecretVolumeSource) {
 	*out = *in
@@ -2520,6 +3399,27 @@ func (in *Timer) DeepCopy() *Timer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageAccountingConfiguration) DeepCopyInto(out *UsageAccountingConfiguration) {
+	*out = *in
+	if in.UpdateIntervalSeconds != nil {
+		in, out := &in.UpdateIntervalSeconds, &out.UpdateIntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageAccountingConfiguration.
+func (in *UsageAccountingConfiguration) DeepCopy() *UsageAccountingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageAccountingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserPasswordAccessCredential) DeepCopyInto(out *UserPasswordAccessCredential) {
 	*out = *in
@@ -2580,6 +3480,55 @@ func (in *UserPasswordAccessCredentialSource) DeepCopy() *UserPasswordAccessCred
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMIAdmissionPolicy) DeepCopyInto(out *VMIAdmissionPolicy) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]VMIAdmissionRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMIAdmissionPolicy.
+func (in *VMIAdmissionPolicy) DeepCopy() *VMIAdmissionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VMIAdmissionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMIAdmissionRule) DeepCopyInto(out *VMIAdmissionRule) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMIAdmissionRule.
+func (in *VMIAdmissionRule) DeepCopy() *VMIAdmissionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VMIAdmissionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMISelector) DeepCopyInto(out *VMISelector) {
 	*out = *in
@@ -2596,6 +3545,22 @@ func (in *VMISelector) DeepCopy() *VMISelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VhostUserBlkSource) DeepCopyInto(out *VhostUserBlkSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VhostUserBlkSource.
+func (in *VhostUserBlkSource) DeepCopy() *VhostUserBlkSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VhostUserBlkSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachine) DeepCopyInto(out *VirtualMachine) {
 	*out = *in
@@ -2625,45 +3590,72 @@ func (in *VirtualMachine) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineCondition) DeepCopyInto(out *VirtualMachineCondition) {
+func (in *VirtualMachineBulkOperationMessage) DeepCopyInto(out *VirtualMachineBulkOperationMessage) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCondition.
-func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBulkOperationMessage.
+func (in *VirtualMachineBulkOperationMessage) DeepCopy() *VirtualMachineBulkOperationMessage {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineCondition)
+	out := new(VirtualMachineBulkOperationMessage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstance) DeepCopyInto(out *VirtualMachineInstance) {
+func (in *VirtualMachineBulkOperationOptions) DeepCopyInto(out *VirtualMachineBulkOperationOptions) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstance.
-func (in *VirtualMachineInstance) DeepCopy() *VirtualMachineInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBulkOperationOptions.
+func (in *VirtualMachineBulkOperationOptions) DeepCopy() *VirtualMachineBulkOperationOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineInstance)
+	out := new(VirtualMachineBulkOperationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBulkOperationResult) DeepCopyInto(out *VirtualMachineBulkOperationResult) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Succeeded != nil {
+		in, out := &in.Succeeded, &out.Succeeded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make([]VirtualMachineBulkOperationMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = make([]VirtualMachineBulkOperationMessage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBulkOperationResult.
+func (in *VirtualMachineBulkOperationResult) DeepCopy() *VirtualMachineBulkOperationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBulkOperationResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineInstance) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineBulkOperationResult) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2671,64 +3663,176 @@ func (in *VirtualMachineInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstanceCondition) DeepCopyInto(out *VirtualMachineInstanceCondition) {
+func (in *VirtualMachineCondition) DeepCopyInto(out *VirtualMachineCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceCondition.
-func (in *VirtualMachineInstanceCondition) DeepCopy() *VirtualMachineInstanceCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCondition.
+func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineInstanceCondition)
+	out := new(VirtualMachineCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstanceFileSystem) DeepCopyInto(out *VirtualMachineInstanceFileSystem) {
+func (in *VirtualMachineHibernation) DeepCopyInto(out *VirtualMachineHibernation) {
 	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystem.
-func (in *VirtualMachineInstanceFileSystem) DeepCopy() *VirtualMachineInstanceFileSystem {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineHibernation.
+func (in *VirtualMachineHibernation) DeepCopy() *VirtualMachineHibernation {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineInstanceFileSystem)
+	out := new(VirtualMachineHibernation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstanceFileSystemInfo) DeepCopyInto(out *VirtualMachineInstanceFileSystemInfo) {
+func (in *VirtualMachineHibernationStatus) DeepCopyInto(out *VirtualMachineHibernationStatus) {
 	*out = *in
-	if in.Filesystems != nil {
-		in, out := &in.Filesystems, &out.Filesystems
-		*out = make([]VirtualMachineInstanceFileSystem, len(*in))
-		copy(*out, *in)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.HibernationTime != nil {
+		in, out := &in.HibernationTime, &out.HibernationTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystemInfo.
-func (in *VirtualMachineInstanceFileSystemInfo) DeepCopy() *VirtualMachineInstanceFileSystemInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineHibernationStatus.
+func (in *VirtualMachineHibernationStatus) DeepCopy() *VirtualMachineHibernationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineInstanceFileSystemInfo)
+	out := new(VirtualMachineHibernationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstanceFileSystemList) DeepCopyInto(out *VirtualMachineInstanceFileSystemList) {
+func (in *VirtualMachineIdleStatus) DeepCopyInto(out *VirtualMachineIdleStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineIdleStatus.
+func (in *VirtualMachineIdleStatus) DeepCopy() *VirtualMachineIdleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineIdleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstance) DeepCopyInto(out *VirtualMachineInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstance.
+func (in *VirtualMachineInstance) DeepCopy() *VirtualMachineInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceCondition) DeepCopyInto(out *VirtualMachineInstanceCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceCondition.
+func (in *VirtualMachineInstanceCondition) DeepCopy() *VirtualMachineInstanceCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceFileSystem) DeepCopyInto(out *VirtualMachineInstanceFileSystem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystem.
+func (in *VirtualMachineInstanceFileSystem) DeepCopy() *VirtualMachineInstanceFileSystem {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceFileSystem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceFileSystemInfo) DeepCopyInto(out *VirtualMachineInstanceFileSystemInfo) {
+	*out = *in
+	if in.Filesystems != nil {
+		in, out := &in.Filesystems, &out.Filesystems
+		*out = make([]VirtualMachineInstanceFileSystem, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystemInfo.
+func (in *VirtualMachineInstanceFileSystemInfo) DeepCopy() *VirtualMachineInstanceFileSystemInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceFileSystemInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceFileSystemList) DeepCopyInto(out *VirtualMachineInstanceFileSystemList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
@@ -2851,6 +3955,31 @@ func (in *VirtualMachineInstanceGuestOSUserList) DeepCopyObject() runtime.Object
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceGuestTimeSync) DeepCopyInto(out *VirtualMachineInstanceGuestTimeSync) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DriftMilliseconds != nil {
+		in, out := &in.DriftMilliseconds, &out.DriftMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestTimeSync.
+func (in *VirtualMachineInstanceGuestTimeSync) DeepCopy() *VirtualMachineInstanceGuestTimeSync {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceGuestTimeSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceList) DeepCopyInto(out *VirtualMachineInstanceList) {
 	*out = *in
@@ -2997,6 +4126,16 @@ func (in *VirtualMachineInstanceMigrationState) DeepCopyInto(out *VirtualMachine
 			(*out)[key] = val
 		}
 	}
+	if in.SourceNetworkState != nil {
+		in, out := &in.SourceNetworkState, &out.SourceNetworkState
+		*out = make([]MigrationInterfaceNetworkState, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetNetworkState != nil {
+		in, out := &in.TargetNetworkState, &out.TargetNetworkState
+		*out = make([]MigrationInterfaceNetworkState, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3041,6 +4180,11 @@ func (in *VirtualMachineInstanceNetworkInterface) DeepCopyInto(out *VirtualMachi
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BridgeTXChecksumOffload != nil {
+		in, out := &in.BridgeTXChecksumOffload, &out.BridgeTXChecksumOffload
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -3233,6 +4377,13 @@ func (in *VirtualMachineInstanceReplicaSetSpec) DeepCopyInto(out *VirtualMachine
 		*out = new(VirtualMachineInstanceTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DataVolumeTemplates != nil {
+		in, out := &in.DataVolumeTemplates, &out.DataVolumeTemplates
+		*out = make([]DataVolumeTemplateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -3338,6 +4489,11 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MetadataService != nil {
+		in, out := &in.MetadataService, &out.MetadataService
+		*out = new(MetadataService)
+		**out = **in
+	}
 	return
 }
 
@@ -3351,6 +4507,68 @@ func (in *VirtualMachineInstanceSpec) DeepCopy() *VirtualMachineInstanceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceStartupTimestamps) DeepCopyInto(out *VirtualMachineInstanceStartupTimestamps) {
+	*out = *in
+	if in.PodScheduled != nil {
+		in, out := &in.PodScheduled, &out.PodScheduled
+		*out = (*in).DeepCopy()
+	}
+	if in.NetworkConfigured != nil {
+		in, out := &in.NetworkConfigured, &out.NetworkConfigured
+		*out = (*in).DeepCopy()
+	}
+	if in.DomainDefined != nil {
+		in, out := &in.DomainDefined, &out.DomainDefined
+		*out = (*in).DeepCopy()
+	}
+	if in.DomainRunning != nil {
+		in, out := &in.DomainRunning, &out.DomainRunning
+		*out = (*in).DeepCopy()
+	}
+	if in.GuestAgentConnected != nil {
+		in, out := &in.GuestAgentConnected, &out.GuestAgentConnected
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceStartupTimestamps.
+func (in *VirtualMachineInstanceStartupTimestamps) DeepCopy() *VirtualMachineInstanceStartupTimestamps {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceStartupTimestamps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceStats) DeepCopyInto(out *VirtualMachineInstanceStats) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceStats.
+func (in *VirtualMachineInstanceStats) DeepCopy() *VirtualMachineInstanceStats {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineInstanceStats) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstanceStatus) {
 	*out = *in
@@ -3393,6 +4611,26 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StartupTimestamps != nil {
+		in, out := &in.StartupTimestamps, &out.StartupTimestamps
+		*out = new(VirtualMachineInstanceStartupTimestamps)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(VirtualMachineInstanceUsage)
+		**out = **in
+	}
+	if in.GuestTimeSync != nil {
+		in, out := &in.GuestTimeSync, &out.GuestTimeSync
+		*out = new(VirtualMachineInstanceGuestTimeSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerDiskStatuses != nil {
+		in, out := &in.ContainerDiskStatuses, &out.ContainerDiskStatuses
+		*out = make([]ContainerDiskStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3424,6 +4662,22 @@ func (in *VirtualMachineInstanceTemplateSpec) DeepCopy() *VirtualMachineInstance
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceUsage) DeepCopyInto(out *VirtualMachineInstanceUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceUsage.
+func (in *VirtualMachineInstanceUsage) DeepCopy() *VirtualMachineInstanceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineList) DeepCopyInto(out *VirtualMachineList) {
 	*out = *in
@@ -3457,6 +4711,203 @@ func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNotificationHook) DeepCopyInto(out *VirtualMachineNotificationHook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNotificationHook.
+func (in *VirtualMachineNotificationHook) DeepCopy() *VirtualMachineNotificationHook {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNotificationHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineNotificationHook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNotificationHookList) DeepCopyInto(out *VirtualMachineNotificationHookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineNotificationHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNotificationHookList.
+func (in *VirtualMachineNotificationHookList) DeepCopy() *VirtualMachineNotificationHookList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNotificationHookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineNotificationHookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNotificationHookSpec) DeepCopyInto(out *VirtualMachineNotificationHookSpec) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]VirtualMachineNotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SigningSecretRef != nil {
+		in, out := &in.SigningSecretRef, &out.SigningSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNotificationHookSpec.
+func (in *VirtualMachineNotificationHookSpec) DeepCopy() *VirtualMachineNotificationHookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNotificationHookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePowerSchedule) DeepCopyInto(out *VirtualMachinePowerSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerSchedule.
+func (in *VirtualMachinePowerSchedule) DeepCopy() *VirtualMachinePowerSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePowerSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePowerScheduleStatus) DeepCopyInto(out *VirtualMachinePowerScheduleStatus) {
+	*out = *in
+	if in.LastStartTime != nil {
+		in, out := &in.LastStartTime, &out.LastStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStartTime != nil {
+		in, out := &in.NextStartTime, &out.NextStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastStopTime != nil {
+		in, out := &in.LastStopTime, &out.LastStopTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStopTime != nil {
+		in, out := &in.NextStopTime, &out.NextStopTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleStatus.
+func (in *VirtualMachinePowerScheduleStatus) DeepCopy() *VirtualMachinePowerScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePowerScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReplication) DeepCopyInto(out *VirtualMachineReplication) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplication.
+func (in *VirtualMachineReplication) DeepCopy() *VirtualMachineReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReplicationStatus) DeepCopyInto(out *VirtualMachineReplicationStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeReplicationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicationStatus.
+func (in *VirtualMachineReplicationStatus) DeepCopy() *VirtualMachineReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -3482,6 +4933,21 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(VirtualMachineHibernation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(VirtualMachineReplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerSchedule != nil {
+		in, out := &in.PowerSchedule, &out.PowerSchedule
+		*out = new(VirtualMachinePowerSchedule)
+		**out = **in
+	}
 	return
 }
 
@@ -3495,6 +4961,52 @@ func (in *VirtualMachineSpec) DeepCopy() *VirtualMachineSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineStartBlocker) DeepCopyInto(out *VirtualMachineStartBlocker) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineStartBlocker.
+func (in *VirtualMachineStartBlocker) DeepCopy() *VirtualMachineStartBlocker {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineStartBlocker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineStartValidation) DeepCopyInto(out *VirtualMachineStartValidation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Blockers != nil {
+		in, out := &in.Blockers, &out.Blockers
+		*out = make([]VirtualMachineStartBlocker, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineStartValidation.
+func (in *VirtualMachineStartValidation) DeepCopy() *VirtualMachineStartValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineStartValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineStartValidation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineStateChangeRequest) DeepCopyInto(out *VirtualMachineStateChangeRequest) {
 	*out = *in
@@ -3531,6 +5043,10 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.LastSnapshotTime != nil {
+		in, out := &in.LastSnapshotTime, &out.LastSnapshotTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]VirtualMachineCondition, len(*in))
@@ -3557,6 +5073,31 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = make([]VolumeSnapshotStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(VirtualMachineUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(VirtualMachineHibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicationStatus != nil {
+		in, out := &in.ReplicationStatus, &out.ReplicationStatus
+		*out = new(VirtualMachineReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerScheduleStatus != nil {
+		in, out := &in.PowerScheduleStatus, &out.PowerScheduleStatus
+		*out = new(VirtualMachinePowerScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleStatus != nil {
+		in, out := &in.IdleStatus, &out.IdleStatus
+		*out = new(VirtualMachineIdleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3570,6 +5111,68 @@ func (in *VirtualMachineStatus) DeepCopy() *VirtualMachineStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineUsage) DeepCopyInto(out *VirtualMachineUsage) {
+	*out = *in
+	in.Current.DeepCopyInto(&out.Current)
+	if in.Previous != nil {
+		in, out := &in.Previous, &out.Previous
+		*out = new(VirtualMachineUsagePeriod)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(VirtualMachineUsageInstance)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineUsage.
+func (in *VirtualMachineUsage) DeepCopy() *VirtualMachineUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineUsageInstance) DeepCopyInto(out *VirtualMachineUsageInstance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineUsageInstance.
+func (in *VirtualMachineUsageInstance) DeepCopy() *VirtualMachineUsageInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineUsageInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineUsagePeriod) DeepCopyInto(out *VirtualMachineUsagePeriod) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineUsagePeriod.
+func (in *VirtualMachineUsagePeriod) DeepCopy() *VirtualMachineUsagePeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineUsagePeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineVolumeRequest) DeepCopyInto(out *VirtualMachineVolumeRequest) {
 	*out = *in
@@ -3600,6 +5203,11 @@ func (in *VirtualMachineVolumeRequest) DeepCopy() *VirtualMachineVolumeRequest {
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
 	in.VolumeSource.DeepCopyInto(&out.VolumeSource)
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(VolumeEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3613,6 +5221,47 @@ func (in *Volume) DeepCopy() *Volume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeEncryption) DeepCopyInto(out *VolumeEncryption) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeEncryption.
+func (in *VolumeEncryption) DeepCopy() *VolumeEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeReplicationStatus) DeepCopyInto(out *VolumeReplicationStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationStatus.
+func (in *VolumeReplicationStatus) DeepCopy() *VolumeReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
 	*out = *in
@@ -3655,7 +5304,7 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 	if in.ContainerDisk != nil {
 		in, out := &in.ContainerDisk, &out.ContainerDisk
 		*out = new(ContainerDiskSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Ephemeral != nil {
 		in, out := &in.Ephemeral, &out.Ephemeral
@@ -3692,6 +5341,11 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(ServiceAccountVolumeSource)
 		**out = **in
 	}
+	if in.VhostUserBlk != nil {
+		in, out := &in.VhostUserBlk, &out.VhostUserBlk
+		*out = new(VhostUserBlkSource)
+		**out = **in
+	}
 	return
 }
 
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -293,10 +294,15 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.AddVolumeOptions":                                           schema_kubevirtio_client_go_api_v1_AddVolumeOptions(ref),
 		"kubevirt.io/client-go/api/v1.AuthorizedKeysFile":                                         schema_kubevirtio_client_go_api_v1_AuthorizedKeysFile(ref),
 		"kubevirt.io/client-go/api/v1.BIOS":                                                       schema_kubevirtio_client_go_api_v1_BIOS(ref),
+		"kubevirt.io/client-go/api/v1.BootMenu":                                                   schema_kubevirtio_client_go_api_v1_BootMenu(ref),
 		"kubevirt.io/client-go/api/v1.Bootloader":                                                 schema_kubevirtio_client_go_api_v1_Bootloader(ref),
+		"kubevirt.io/client-go/api/v1.BridgeConfiguration":                                        schema_kubevirtio_client_go_api_v1_BridgeConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.CDRomTarget":                                                schema_kubevirtio_client_go_api_v1_CDRomTarget(ref),
 		"kubevirt.io/client-go/api/v1.CPU":                                                        schema_kubevirtio_client_go_api_v1_CPU(ref),
+		"kubevirt.io/client-go/api/v1.CPUBaseline":                                                schema_kubevirtio_client_go_api_v1_CPUBaseline(ref),
+		"kubevirt.io/client-go/api/v1.CPUBaselineStatus":                                          schema_kubevirtio_client_go_api_v1_CPUBaselineStatus(ref),
 		"kubevirt.io/client-go/api/v1.CPUFeature":                                                 schema_kubevirtio_client_go_api_v1_CPUFeature(ref),
+		"kubevirt.io/client-go/api/v1.Channel":                                                    schema_kubevirtio_client_go_api_v1_Channel(ref),
 		"kubevirt.io/client-go/api/v1.Chassis":                                                    schema_kubevirtio_client_go_api_v1_Chassis(ref),
 		"kubevirt.io/client-go/api/v1.Clock":                                                      schema_kubevirtio_client_go_api_v1_Clock(ref),
 		"kubevirt.io/client-go/api/v1.ClockOffset":                                                schema_kubevirtio_client_go_api_v1_ClockOffset(ref),
@@ -306,7 +312,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.ComponentConfig":                                            schema_kubevirtio_client_go_api_v1_ComponentConfig(ref),
 		"kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation":         schema_kubevirtio_client_go_api_v1_ConfigDriveSSHPublicKeyAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.ConfigMapVolumeSource":                                      schema_kubevirtio_client_go_api_v1_ConfigMapVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.ConsoleConfiguration":                                       schema_kubevirtio_client_go_api_v1_ConsoleConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.ContainerDiskSource":                                        schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref),
+		"kubevirt.io/client-go/api/v1.ContainerDiskStatus":                                        schema_kubevirtio_client_go_api_v1_ContainerDiskStatus(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponents":                                        schema_kubevirtio_client_go_api_v1_CustomizeComponents(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponentsPatch":                                   schema_kubevirtio_client_go_api_v1_CustomizeComponentsPatch(ref),
 		"kubevirt.io/client-go/api/v1.DHCPOptions":                                                schema_kubevirtio_client_go_api_v1_DHCPOptions(ref),
@@ -323,6 +331,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource":                                    schema_kubevirtio_client_go_api_v1_DownwardAPIVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.EFI":                                                        schema_kubevirtio_client_go_api_v1_EFI(ref),
 		"kubevirt.io/client-go/api/v1.EmptyDiskSource":                                            schema_kubevirtio_client_go_api_v1_EmptyDiskSource(ref),
+		"kubevirt.io/client-go/api/v1.EphemeralImage":                                             schema_kubevirtio_client_go_api_v1_EphemeralImage(ref),
 		"kubevirt.io/client-go/api/v1.EphemeralVolumeSource":                                      schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.FeatureAPIC":                                                schema_kubevirtio_client_go_api_v1_FeatureAPIC(ref),
 		"kubevirt.io/client-go/api/v1.FeatureHyperv":                                              schema_kubevirtio_client_go_api_v1_FeatureHyperv(ref),
@@ -331,11 +340,16 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.FeatureState":                                               schema_kubevirtio_client_go_api_v1_FeatureState(ref),
 		"kubevirt.io/client-go/api/v1.FeatureVendorID":                                            schema_kubevirtio_client_go_api_v1_FeatureVendorID(ref),
 		"kubevirt.io/client-go/api/v1.Features":                                                   schema_kubevirtio_client_go_api_v1_Features(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequest":                                             schema_kubevirtio_client_go_api_v1_FencingRequest(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequestList":                                         schema_kubevirtio_client_go_api_v1_FencingRequestList(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequestSpec":                                         schema_kubevirtio_client_go_api_v1_FencingRequestSpec(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequestStatus":                                       schema_kubevirtio_client_go_api_v1_FencingRequestStatus(ref),
 		"kubevirt.io/client-go/api/v1.Filesystem":                                                 schema_kubevirtio_client_go_api_v1_Filesystem(ref),
 		"kubevirt.io/client-go/api/v1.FilesystemVirtiofs":                                         schema_kubevirtio_client_go_api_v1_FilesystemVirtiofs(ref),
 		"kubevirt.io/client-go/api/v1.Firmware":                                                   schema_kubevirtio_client_go_api_v1_Firmware(ref),
 		"kubevirt.io/client-go/api/v1.FloppyTarget":                                               schema_kubevirtio_client_go_api_v1_FloppyTarget(ref),
 		"kubevirt.io/client-go/api/v1.GPU":                                                        schema_kubevirtio_client_go_api_v1_GPU(ref),
+		"kubevirt.io/client-go/api/v1.GenericHostDevice":                                          schema_kubevirtio_client_go_api_v1_GenericHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.HPETTimer":                                                  schema_kubevirtio_client_go_api_v1_HPETTimer(ref),
 		"kubevirt.io/client-go/api/v1.HostDevice":                                                 schema_kubevirtio_client_go_api_v1_HostDevice(ref),
 		"kubevirt.io/client-go/api/v1.HostDisk":                                                   schema_kubevirtio_client_go_api_v1_HostDisk(ref),
@@ -344,12 +358,17 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.Hugepages":                                                  schema_kubevirtio_client_go_api_v1_Hugepages(ref),
 		"kubevirt.io/client-go/api/v1.HypervTimer":                                                schema_kubevirtio_client_go_api_v1_HypervTimer(ref),
 		"kubevirt.io/client-go/api/v1.I6300ESBWatchdog":                                           schema_kubevirtio_client_go_api_v1_I6300ESBWatchdog(ref),
+		"kubevirt.io/client-go/api/v1.IdlePolicyConfiguration":                                    schema_kubevirtio_client_go_api_v1_IdlePolicyConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.Input":                                                      schema_kubevirtio_client_go_api_v1_Input(ref),
 		"kubevirt.io/client-go/api/v1.Interface":                                                  schema_kubevirtio_client_go_api_v1_Interface(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceBindingMethod":                                     schema_kubevirtio_client_go_api_v1_InterfaceBindingMethod(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceBridge":                                            schema_kubevirtio_client_go_api_v1_InterfaceBridge(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceBridgeGuestAddress":                                schema_kubevirtio_client_go_api_v1_InterfaceBridgeGuestAddress(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceMacvtap":                                           schema_kubevirtio_client_go_api_v1_InterfaceMacvtap(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceMasquerade":                                        schema_kubevirtio_client_go_api_v1_InterfaceMasquerade(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceMirror":                                            schema_kubevirtio_client_go_api_v1_InterfaceMirror(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceOffloads":                                          schema_kubevirtio_client_go_api_v1_InterfaceOffloads(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceRSS":                                               schema_kubevirtio_client_go_api_v1_InterfaceRSS(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceSRIOV":                                             schema_kubevirtio_client_go_api_v1_InterfaceSRIOV(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceSlirp":                                             schema_kubevirtio_client_go_api_v1_InterfaceSlirp(ref),
 		"kubevirt.io/client-go/api/v1.KVMTimer":                                                   schema_kubevirtio_client_go_api_v1_KVMTimer(ref),
@@ -357,20 +376,31 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.KubeVirtCertificateRotateStrategy":                          schema_kubevirtio_client_go_api_v1_KubeVirtCertificateRotateStrategy(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtCondition":                                          schema_kubevirtio_client_go_api_v1_KubeVirtCondition(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtConfiguration":                                      schema_kubevirtio_client_go_api_v1_KubeVirtConfiguration(ref),
+		"kubevirt.io/client-go/api/v1.KubeVirtConfigurationImpact":                                schema_kubevirtio_client_go_api_v1_KubeVirtConfigurationImpact(ref),
+		"kubevirt.io/client-go/api/v1.KubeVirtConfigurationValidation":                            schema_kubevirtio_client_go_api_v1_KubeVirtConfigurationValidation(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtList":                                               schema_kubevirtio_client_go_api_v1_KubeVirtList(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtSelfSignConfiguration":                              schema_kubevirtio_client_go_api_v1_KubeVirtSelfSignConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtSpec":                                               schema_kubevirtio_client_go_api_v1_KubeVirtSpec(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtStatus":                                             schema_kubevirtio_client_go_api_v1_KubeVirtStatus(ref),
+		"kubevirt.io/client-go/api/v1.LogVerbosity":                                               schema_kubevirtio_client_go_api_v1_LogVerbosity(ref),
 		"kubevirt.io/client-go/api/v1.LunTarget":                                                  schema_kubevirtio_client_go_api_v1_LunTarget(ref),
 		"kubevirt.io/client-go/api/v1.Machine":                                                    schema_kubevirtio_client_go_api_v1_Machine(ref),
+		"kubevirt.io/client-go/api/v1.MasqueradeSubnetPool":                                       schema_kubevirtio_client_go_api_v1_MasqueradeSubnetPool(ref),
 		"kubevirt.io/client-go/api/v1.MediatedHostDevice":                                         schema_kubevirtio_client_go_api_v1_MediatedHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.Memory":                                                     schema_kubevirtio_client_go_api_v1_Memory(ref),
+		"kubevirt.io/client-go/api/v1.MetadataService":                                            schema_kubevirtio_client_go_api_v1_MetadataService(ref),
 		"kubevirt.io/client-go/api/v1.MigrationConfiguration":                                     schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref),
+		"kubevirt.io/client-go/api/v1.MigrationInterfaceNetworkState":                             schema_kubevirtio_client_go_api_v1_MigrationInterfaceNetworkState(ref),
+		"kubevirt.io/client-go/api/v1.MigrationMasqueradeNetworkState":                            schema_kubevirtio_client_go_api_v1_MigrationMasqueradeNetworkState(ref),
 		"kubevirt.io/client-go/api/v1.MultusNetwork":                                              schema_kubevirtio_client_go_api_v1_MultusNetwork(ref),
+		"kubevirt.io/client-go/api/v1.NUMA":                                                       schema_kubevirtio_client_go_api_v1_NUMA(ref),
+		"kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough":                                schema_kubevirtio_client_go_api_v1_NUMAGuestMappingPassthrough(ref),
 		"kubevirt.io/client-go/api/v1.Network":                                                    schema_kubevirtio_client_go_api_v1_Network(ref),
 		"kubevirt.io/client-go/api/v1.NetworkConfiguration":                                       schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.NetworkSource":                                              schema_kubevirtio_client_go_api_v1_NetworkSource(ref),
+		"kubevirt.io/client-go/api/v1.NodeDensityConfiguration":                                   schema_kubevirtio_client_go_api_v1_NodeDensityConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.NodePlacement":                                              schema_kubevirtio_client_go_api_v1_NodePlacement(ref),
+		"kubevirt.io/client-go/api/v1.NvmeNamespaceHostDevice":                                    schema_kubevirtio_client_go_api_v1_NvmeNamespaceHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.PITTimer":                                                   schema_kubevirtio_client_go_api_v1_PITTimer(ref),
 		"kubevirt.io/client-go/api/v1.PciHostDevice":                                              schema_kubevirtio_client_go_api_v1_PciHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.PermittedHostDevices":                                       schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref),
@@ -379,6 +409,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.Probe":                                                      schema_kubevirtio_client_go_api_v1_Probe(ref),
 		"kubevirt.io/client-go/api/v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation":      schema_kubevirtio_client_go_api_v1_QemuGuestAgentSSHPublicKeyAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.QemuGuestAgentUserPasswordAccessCredentialPropagation":      schema_kubevirtio_client_go_api_v1_QemuGuestAgentUserPasswordAccessCredentialPropagation(ref),
+		"kubevirt.io/client-go/api/v1.QoSClassConfiguration":                                      schema_kubevirtio_client_go_api_v1_QoSClassConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.RTCTimer":                                                   schema_kubevirtio_client_go_api_v1_RTCTimer(ref),
 		"kubevirt.io/client-go/api/v1.RemoveVolumeOptions":                                        schema_kubevirtio_client_go_api_v1_RemoveVolumeOptions(ref),
 		"kubevirt.io/client-go/api/v1.ResourceRequirements":                                       schema_kubevirtio_client_go_api_v1_ResourceRequirements(ref),
@@ -388,14 +419,25 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredential":                               schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialPropagationMethod":              schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialPropagationMethod(ref),
 		"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialSource":                         schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialSource(ref),
+		"kubevirt.io/client-go/api/v1.ScopedFeatureGate":                                          schema_kubevirtio_client_go_api_v1_ScopedFeatureGate(ref),
 		"kubevirt.io/client-go/api/v1.SecretVolumeSource":                                         schema_kubevirtio_client_go_api_v1_SecretVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource":                                 schema_kubevirtio_client_go_api_v1_ServiceAccountVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.Timer":                                                      schema_kubevirtio_client_go_api_v1_Timer(ref),
+		"kubevirt.io/client-go/api/v1.UsageAccountingConfiguration":                               schema_kubevirtio_client_go_api_v1_UsageAccountingConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredential":                               schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialPropagationMethod":              schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialPropagationMethod(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialSource":                         schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialSource(ref),
+		"kubevirt.io/client-go/api/v1.VMIAdmissionPolicy":                                         schema_kubevirtio_client_go_api_v1_VMIAdmissionPolicy(ref),
+		"kubevirt.io/client-go/api/v1.VMIAdmissionRule":                                           schema_kubevirtio_client_go_api_v1_VMIAdmissionRule(ref),
+		"kubevirt.io/client-go/api/v1.VhostUserBlkSource":                                         schema_kubevirtio_client_go_api_v1_VhostUserBlkSource(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachine":                                             schema_kubevirtio_client_go_api_v1_VirtualMachine(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineBulkOperationMessage":                         schema_kubevirtio_client_go_api_v1_VirtualMachineBulkOperationMessage(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineBulkOperationOptions":                         schema_kubevirtio_client_go_api_v1_VirtualMachineBulkOperationOptions(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineBulkOperationResult":                          schema_kubevirtio_client_go_api_v1_VirtualMachineBulkOperationResult(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineCondition":                                    schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineHibernation":                                  schema_kubevirtio_client_go_api_v1_VirtualMachineHibernation(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineHibernationStatus":                            schema_kubevirtio_client_go_api_v1_VirtualMachineHibernationStatus(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineIdleStatus":                                   schema_kubevirtio_client_go_api_v1_VirtualMachineIdleStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstance":                                     schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition":                            schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem":                           schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystem(ref),
@@ -405,6 +447,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo":                          schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSInfo(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser":                          schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUser(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUserList":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUserList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestTimeSync":                        schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestTimeSync(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceList":                                 schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceList(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration":                            schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigration(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition":                   schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationCondition(ref),
@@ -422,14 +465,31 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetSpec":                       schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetSpec(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetStatus":                     schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec":                                 schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceStartupTimestamps":                    schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStartupTimestamps(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceStats":                                schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStats(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus":                               schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec":                         schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceTemplateSpec(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceUsage":                                schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceUsage(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineList":                                         schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineNotificationHook":                             schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHook(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookList":                         schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookSpec":                         schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookSpec(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachinePowerSchedule":                                schema_kubevirtio_client_go_api_v1_VirtualMachinePowerSchedule(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachinePowerScheduleStatus":                          schema_kubevirtio_client_go_api_v1_VirtualMachinePowerScheduleStatus(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineReplication":                                  schema_kubevirtio_client_go_api_v1_VirtualMachineReplication(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineReplicationStatus":                            schema_kubevirtio_client_go_api_v1_VirtualMachineReplicationStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineSpec":                                         schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineStartBlocker":                                 schema_kubevirtio_client_go_api_v1_VirtualMachineStartBlocker(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineStartValidation":                              schema_kubevirtio_client_go_api_v1_VirtualMachineStartValidation(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest":                           schema_kubevirtio_client_go_api_v1_VirtualMachineStateChangeRequest(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineStatus":                                       schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineUsage":                                        schema_kubevirtio_client_go_api_v1_VirtualMachineUsage(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineUsageInstance":                                schema_kubevirtio_client_go_api_v1_VirtualMachineUsageInstance(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineUsagePeriod":                                  schema_kubevirtio_client_go_api_v1_VirtualMachineUsagePeriod(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest":                                schema_kubevirtio_client_go_api_v1_VirtualMachineVolumeRequest(ref),
 		"kubevirt.io/client-go/api/v1.Volume":                                                     schema_kubevirtio_client_go_api_v1_Volume(ref),
+		"kubevirt.io/client-go/api/v1.VolumeEncryption":                                           schema_kubevirtio_client_go_api_v1_VolumeEncryption(ref),
+		"kubevirt.io/client-go/api/v1.VolumeReplicationStatus":                                    schema_kubevirtio_client_go_api_v1_VolumeReplicationStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSnapshotStatus":                                       schema_kubevirtio_client_go_api_v1_VolumeSnapshotStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSource":                                               schema_kubevirtio_client_go_api_v1_VolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.VolumeStatus":                                               schema_kubevirtio_client_go_api_v1_VolumeStatus(ref),
@@ -13627,6 +13687,33 @@ func schema_kubevirtio_client_go_api_v1_BIOS(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_BootMenu(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootMenu lets the user pick a boot entry on the serial or graphical console while the VMI boots.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If set, the firmware offers a boot menu. Defaults to true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time in milliseconds the boot menu waits for a selection before booting the default entry. Must be at most 65535.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Bootloader(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13654,6 +13741,54 @@ func schema_kubevirtio_client_go_api_v1_Bootloader(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_BridgeConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BridgeConfiguration holds the parameters of the bridges created in the virt-launcher pods. Unset fields keep the defaults of the kernel.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"stp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "STP enables the spanning tree protocol on the bridges",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ageingTimeSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AgeingTimeSeconds is how long the bridges keep a learned MAC address in their forwarding database",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"multicastSnooping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MulticastSnooping enables IGMP and MLD snooping on the bridges",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"multicastQuerier": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MulticastQuerier runs an IGMP and MLD querier on the bridges, so that snooping keeps forwarding multicast groups when the pod network has no querier",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"vlanFiltering": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VLANFiltering enables VLAN filtering on the bridges",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_CDRomTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13749,11 +13884,128 @@ func schema_kubevirtio_client_go_api_v1_CPU(ref common.ReferenceCallback) common
 							Format:      "",
 						},
 					},
+					"nestedVirtualization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"numa": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NUMA allows specifying settings for the guest NUMA topology",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NUMA"),
+						},
+					},
+					"sideChannelIsolation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and flushes the L1 data cache on every VM entry where L1TF applies.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CPUFeature"},
+			"kubevirt.io/client-go/api/v1.CPUFeature", "kubevirt.io/client-go/api/v1.NUMA"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_CPUBaseline(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CPUBaseline selects the node pool a CPU baseline is computed for",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the baseline, which VMIs reference as CPU model. It must not be the name of a CPU model known to libvirt.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector selects the nodes of the pool by their labels",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "nodeSelector"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_CPUBaselineStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CPUBaselineStatus reports the CPU model and features computed for a CPU baseline",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the baseline",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"model": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model is the newest CPU model all nodes of the pool support",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"features": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Features are the CPU features all nodes of the pool have",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes is the number of nodes in the pool",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message reports why no model could be computed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "nodes"},
+			},
+		},
 	}
 }
 
@@ -13785,6 +14037,26 @@ func schema_kubevirtio_client_go_api_v1_CPUFeature(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_Channel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the virtio-serial port, like org.example.agent.0. The guest finds the channel at /dev/virtio-ports/<name>.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Chassis(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14084,6 +14356,40 @@ func schema_kubevirtio_client_go_api_v1_ConfigMapVolumeSource(ref common.Referen
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_ConsoleConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConsoleConfiguration holds options of the serial console and VNC proxy",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxSessions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSessions limits the number of sessions proxied concurrently by every virt-api instance, 0 means unlimited",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"idleTimeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdleTimeoutSeconds closes sessions without traffic in either direction for this long, 0 disables the timeout",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"requireAccessReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequireAccessReason rejects sessions to VMIs which do not carry the kubevirt.io/console-access-reason annotation",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14119,10 +14425,60 @@ func schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref common.Reference
 							Format:      "",
 						},
 					},
+					"checksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checksum is the expected digest of the disk in the image, in the form sha256:<hex>. The disk is verified before the VMI is started, which fails on a mismatch.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ephemeralImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EphemeralImage configures the writable image the VMI runs on.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.EphemeralImage"),
+						},
+					},
 				},
 				Required: []string{"image"},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.EphemeralImage"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_ContainerDiskStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ContainerDiskStatus reports the preparation of a containerDisk, which is mounted into the virt-launcher pod and verified by virt-handler.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase of the preparation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes why the preparation failed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
 
@@ -14214,6 +14570,20 @@ func schema_kubevirtio_client_go_api_v1_DHCPOptions(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"nextServer": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will set the next server address (siaddr) of the replies of interface's DHCP server, the address of the boot server. Must be an IPv4 address.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tftpProxy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If set, virt-launcher relays the TFTP requests of the guest, which it sends to the gateway of a masquerade interface, to the next server or the TFTP server. The DHCP server passes the gateway as next server and TFTP server then.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"ntpServers": {
 						SchemaProps: spec.SchemaProps{
 							Description: "If specified will pass the configured NTP server to the VM via DHCP option 042.",
@@ -14290,6 +14660,13 @@ func schema_kubevirtio_client_go_api_v1_DataVolumeSource(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"checksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -14411,9 +14788,36 @@ func schema_kubevirtio_client_go_api_v1_DeveloperConfiguration(ref common.Refere
 							Format: "int32",
 						},
 					},
-				},
-			},
-		},
+					"logVerbosity": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.LogVerbosity"),
+						},
+					},
+					"emulationPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EmulationPolicy controls which VMIs may run with software emulation, one of Never, OptIn or Always. Defaults to Always if useEmulation is set and to Never otherwise.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scopedFeatureGates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScopedFeatureGates enable feature gates for the workloads of the selected namespaces only.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.ScopedFeatureGate"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.LogVerbosity", "kubevirt.io/client-go/api/v1.ScopedFeatureGate"},
 	}
 }
 
@@ -14475,6 +14879,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"usbControllerModel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model of the USB controller, which is attached when an input device is on the usb bus. Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"autoattachPodInterface": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to attach a pod network interface. Defaults to true.",
@@ -14489,6 +14900,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"autoattachInputDevice": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to attach a tablet when the graphics device is attached and no inputs are specified. The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"autoattachSerialConsole": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.",
@@ -14503,6 +14921,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"autoattachVirtioWinDriverDisk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to attach the virtio-win driver disk as a CD-ROM, which holds the virtio drivers Windows needs to install on virtio disks and to use virtio network interfaces. The image of the disk is configured in the KubeVirt CR. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"rng": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to have random number generator from host",
@@ -14523,6 +14948,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"networkInterfaceQueues": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of queues of the virtio interfaces with networkInterfaceMultiqueue. Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"gpus": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -14577,11 +15009,29 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"channels": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Channels are additional virtio-serial channels for custom communication between the host and the guest.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.Channel"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.Watchdog"},
+			"kubevirt.io/client-go/api/v1.Channel", "kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.Watchdog"},
 	}
 }
 
@@ -14636,6 +15086,13 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
+					"wwn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"dedicatedIOThread": {
 						SchemaProps: spec.SchemaProps{
 							Description: "dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.",
@@ -14659,7 +15116,7 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 					},
 					"tag": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, disk address and its tag will be provided to the guest via config drive metadata",
+							Description: "If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -14812,6 +15269,13 @@ func schema_kubevirtio_client_go_api_v1_DomainSpec(ref common.ReferenceCallback)
 							Ref:         ref("kubevirt.io/client-go/api/v1.Chassis"),
 						},
 					},
+					"allowEmulation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowEmulation overrides whether the VMI may fall back to software emulation on nodes without /dev/kvm, within the emulation policy of the cluster.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"devices"},
 			},
@@ -14898,6 +15362,40 @@ func schema_kubevirtio_client_go_api_v1_EmptyDiskSource(ref common.ReferenceCall
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_EphemeralImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EphemeralImage configures the writable image which is created from a containerDisk or ephemeral volume when the VMI starts.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"preallocation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"compressed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14910,11 +15408,17 @@ func schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref common.Referen
 							Ref:         ref("k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"),
 						},
 					},
+					"ephemeralImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EphemeralImage configures the writable image the VMI runs on.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.EphemeralImage"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.EphemeralImage"},
 	}
 }
 
@@ -15178,6 +15682,153 @@ func schema_kubevirtio_client_go_api_v1_Features(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_FencingRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FencingRequest asks an external fencing agent to power off or isolate a node, whose virt-handler is unresponsive, before the VirtualMachineInstances of the node are restarted on other nodes",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.FencingRequestSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.FencingRequestStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.FencingRequestSpec", "kubevirt.io/client-go/api/v1.FencingRequestStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FencingRequestList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FencingRequestList is a list of FencingRequests",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.FencingRequest"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.FencingRequest"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FencingRequestSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeName is the name of the node to fence",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"nodeName"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FencingRequestStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FencingRequestStatus is set by the fencing agent which handles the request",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the state of the fencing of the node. The VMIs of the node are only restarted once it is Fenced.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable detail of the phase",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fencedTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FencedTimestamp is the time the node was fenced at",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Filesystem(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15242,11 +15893,24 @@ func schema_kubevirtio_client_go_api_v1_Firmware(ref common.ReferenceCallback) c
 							Format:      "",
 						},
 					},
+					"bootMenu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Settings to control the interactive boot menu of the firmware.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.BootMenu"),
+						},
+					},
+					"identityPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdentityPolicy controls how the UUID and the serial are generated when they are not set. Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores. RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that clones get new ones, while restores keep them. Defaults to Keep.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.Bootloader"},
+			"kubevirt.io/client-go/api/v1.BootMenu", "kubevirt.io/client-go/api/v1.Bootloader"},
 	}
 }
 
@@ -15302,44 +15966,79 @@ func schema_kubevirtio_client_go_api_v1_GPU(ref common.ReferenceCallback) common
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_HPETTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_GenericHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "GenericHostDevice represents a device node of the host which is exposed as a resource",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"tickPolicy": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of \"delay\", \"catchup\", \"merge\", \"discard\".",
+							Description: "Name of the device, the resource is devices.kubevirt.io/<name>",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"present": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.",
+							Description: "Path of the device node on the host",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"preOpen": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreOpen opens the device node once before the resource is advertised, to load the kernel modules of the device",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"name", "path"},
 			},
 		},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_HostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_HPETTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"tickPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
+							Description: "TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of \"delay\", \"catchup\", \"merge\", \"discard\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"present": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_HostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
 					"deviceName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "DeviceName is the resource name of the host device exposed by a device plugin",
@@ -15510,6 +16209,54 @@ func schema_kubevirtio_client_go_api_v1_I6300ESBWatchdog(ref common.ReferenceCal
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_IdlePolicyConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IdlePolicyConfiguration holds the activity thresholds below which running VirtualMachines are idle, and the action taken on VirtualMachines which were idle for a whole window",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action taken on idle VirtualMachines, one of Pause or Hibernate. VirtualMachines without hibernation configured are paused. Defaults to Pause.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"windowSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WindowSeconds is how long a VirtualMachine has to stay below all thresholds to be idle",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"sampleIntervalSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SampleIntervalSeconds is the time between two reads of the stats of a running VirtualMachine",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cpuThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUThreshold is the CPU time per second the guest uses on all its vCPUs, e.g. 50m, below which it is idle",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"networkThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkThreshold is the number of bytes per second the guest receives and transmits on all its interfaces, below which it is idle",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Input(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15525,7 +16272,7 @@ func schema_kubevirtio_client_go_api_v1_Input(ref common.ReferenceCallback) comm
 					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type indicated the type of input device. Supported values: tablet.",
+							Description: "Type indicated the type of input device. Supported values: tablet, keyboard.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -15537,6 +16284,13 @@ func schema_kubevirtio_client_go_api_v1_Input(ref common.ReferenceCallback) comm
 							Format:      "",
 						},
 					},
+					"model": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model indicates the model of a virtio input device. Supported values: virtio, virtio-transitional, virtio-non-transitional.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"type", "name"},
 			},
@@ -15631,17 +16385,84 @@ func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback)
 					},
 					"tag": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, the virtual network interface address and its tag will be provided to the guest via config drive",
+							Description: "If specified, the virtual network interface address and its tag will be provided to the guest via config drive. The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"roles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Roles the interface fulfills in the guest: management, storage or workload. They are published to the guest as SMBIOS OEM strings of the form io.kubevirt.interface.role:<role>=<MAC address>.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"rxQueueSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size of the virtio RX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"txQueueSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size of the virtio TX queue ring of the interface. Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"offloads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Offloads of the host side tap device of the interface. Only supported on bridge and masquerade interfaces.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceOffloads"),
+						},
+					},
+					"packedRing": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Use packed virtqueues instead of split virtqueues for the interface, which reduces the latency of the guest network. Only supported on virtio interfaces.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"vhostZeroCopyTX": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Request zero copy transmission by vhost-net for the interface. The VMI is only scheduled on nodes where the vhost_net kernel module has zero copy transmission enabled. Only supported on virtio interfaces which are not bound with slirp.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"mirror": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Copy the traffic of the interface to a monitoring network. Only supported on bridge and masquerade interfaces.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceMirror"),
+						},
+					},
+					"latencyProfile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls the tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net threads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs. Only supported on virtio bridge and masquerade interfaces.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"rss": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Receive side scaling of the interface. The device steers the received packets to the queues by their hash, as configured by the guest, which spreads the load of the guest network over the vCPUs. Only supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceRSS"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DHCPOptions", "kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.Port"},
+			"kubevirt.io/client-go/api/v1.DHCPOptions", "kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceMirror", "kubevirt.io/client-go/api/v1.InterfaceOffloads", "kubevirt.io/client-go/api/v1.InterfaceRSS", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.Port"},
 	}
 }
 
@@ -15690,6 +16511,51 @@ func schema_kubevirtio_client_go_api_v1_InterfaceBridge(ref common.ReferenceCall
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"guestAddress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAddress declares the address the guest uses when the pod network does not provide one. The bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceBridgeGuestAddress"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.InterfaceBridgeGuestAddress"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceBridgeGuestAddress(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceBridgeGuestAddress is the static address of a guest connected to a bridge without IPAM.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ip": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"gateway": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Gateway offered to the guest in the static DHCP lease.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serveDHCP": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"ip"},
 			},
 		},
 	}
@@ -15710,6 +16576,111 @@ func schema_kubevirtio_client_go_api_v1_InterfaceMasquerade(ref common.Reference
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hairpin": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hairpin selects which connections originating in the pod itself are forwarded to the VM. \"loopback\" forwards connections to the loopback address on the forwarded ports, \"none\" leaves all of them to processes in the pod and \"full\" forwards connections to any local address of the pod, including the VM connecting to its own service IP. Defaults to loopback.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clampMSS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU, so that they do not stall when the MTU of the pod is lower than the one the guest assumes. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceMirror(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceMirror copies the traffic of the tap device of an interface to the pod interface of a monitoring network.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"network": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the Multus network which receives the copied traffic. It must not be the network of an interface.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"direction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Traffic to copy, as seen from the guest: rx, tx or both. Defaults to both.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"network"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceOffloads(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceOffloads toggles the offloads of the host side tap device of an interface. Offloads which are not set keep the defaults of the hypervisor.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tso": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TCP segmentation offload.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"gso": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generic segmentation offload.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"gro": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generic receive offload.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"txChecksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TX checksum offload of the pod side bridge serving the interface. When not set, it is only disabled if the DHCP server of KubeVirt answers the guest on the bridge, since some guest DHCP clients drop its replies otherwise.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceRSS(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceRSS enables the receive side scaling of a multiqueue virtio interface.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"hashReport": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Report the hash of the received packets to the guest, which saves the guest from calculating it again, e.g. for receive flow steering.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -15967,36 +16938,252 @@ func schema_kubevirtio_client_go_api_v1_KubeVirtConfiguration(ref common.Referen
 							Ref: ref("kubevirt.io/client-go/api/v1.PermittedHostDevices"),
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/client-go/api/v1.DeveloperConfiguration", "kubevirt.io/client-go/api/v1.MigrationConfiguration", "kubevirt.io/client-go/api/v1.NetworkConfiguration", "kubevirt.io/client-go/api/v1.PermittedHostDevices", "kubevirt.io/client-go/api/v1.SMBiosConfiguration"},
-	}
-}
-
-func schema_kubevirtio_client_go_api_v1_KubeVirtList(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "KubeVirtList is a list of KubeVirts",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"kind": {
+					"console": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Ref: ref("kubevirt.io/client-go/api/v1.ConsoleConfiguration"),
 						},
 					},
-					"apiVersion": {
+					"v2vConversionImage": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Description: "V2VConversionImage is the image running virt-v2v for the conversion of VMs imported from other hypervisors",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"usageAccounting": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.UsageAccountingConfiguration"),
+						},
+					},
+					"virtioWinImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtioWinImage is the container disk image with the virtio-win drivers, which is attached to VMIs asking for the virtio-win driver disk",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"admissionPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdmissionPolicies reject the creation of VMIs which match them",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VMIAdmissionPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"nodeDensity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeDensity limits the number of VMIs and the memory overcommitment of every node",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NodeDensityConfiguration"),
+						},
+					},
+					"diskTransferImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DiskTransferImage is the image with curl which uploads the disks of VMs imported from peer clusters, it has to be pullable in the peer cluster",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replicationImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicationImage is the image with rsync and kubectl which copies the disks of replicated VMs to the disaster recovery cluster, unless their storage class has a CSI replication class",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"idlePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdlePolicy holds the activity thresholds below which VirtualMachines of the namespaces opted in are idle, and the action taken on idle VirtualMachines",
+							Ref:         ref("kubevirt.io/client-go/api/v1.IdlePolicyConfiguration"),
+						},
+					},
+					"disruptionBudgetPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisruptionBudgetPolicy controls the PodDisruptionBudgets protecting the VMIs which are live-migrated on evictions, one of Always, DuringMigration or Never. Defaults to Always.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cpuBaselines": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUBaselines are named CPU models computed from the CPU models and features all nodes of a node pool support. VMIs referencing a baseline as CPU model are migratable between the nodes of its pool.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.CPUBaseline"),
+									},
+								},
+							},
+						},
+					},
+					"allowedImportURLs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowedImportURLs are the URL prefixes VMs may be imported from. OVF descriptors and the files they reference are only fetched from URLs starting with one of them.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"qosClasses": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "QoSClasses are the tiers of disk and network service VMIs select with their qosClass. Setting them replaces the default gold, silver and bronze classes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.QoSClassConfiguration"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/client-go/api/v1.CPUBaseline", "kubevirt.io/client-go/api/v1.ConsoleConfiguration", "kubevirt.io/client-go/api/v1.DeveloperConfiguration", "kubevirt.io/client-go/api/v1.IdlePolicyConfiguration", "kubevirt.io/client-go/api/v1.MigrationConfiguration", "kubevirt.io/client-go/api/v1.NetworkConfiguration", "kubevirt.io/client-go/api/v1.NodeDensityConfiguration", "kubevirt.io/client-go/api/v1.PermittedHostDevices", "kubevirt.io/client-go/api/v1.QoSClassConfiguration", "kubevirt.io/client-go/api/v1.SMBiosConfiguration", "kubevirt.io/client-go/api/v1.UsageAccountingConfiguration", "kubevirt.io/client-go/api/v1.VMIAdmissionPolicy"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_KubeVirtConfigurationImpact(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "KubeVirtConfigurationImpact is a single way in which a change of the KubeVirt configuration affects workloads",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes the impact and how many workloads it affects",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"virtualMachineInstances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualMachineInstances lists the affected VirtualMachineInstances as namespace/name",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"virtualMachines": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualMachines lists the affected VirtualMachines as namespace/name",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"message"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_KubeVirtConfigurationValidation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "KubeVirtConfigurationValidation reports the workloads which a change of the KubeVirt configuration affects",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"impacts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Impacts lists how the change affects the workloads, empty if it doesn't affect any",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.KubeVirtConfigurationImpact"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.KubeVirtConfigurationImpact"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_KubeVirtList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "KubeVirtList is a list of KubeVirts",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 						},
@@ -16228,11 +17415,54 @@ func schema_kubevirtio_client_go_api_v1_KubeVirtStatus(ref common.ReferenceCallb
 							Format: "",
 						},
 					},
+					"cpuBaselines": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.CPUBaselineStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.KubeVirtCondition"},
+			"kubevirt.io/client-go/api/v1.CPUBaselineStatus", "kubevirt.io/client-go/api/v1.KubeVirtCondition"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_LogVerbosity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogVerbosity sets log verbosity level of the various components",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"virtController": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"virtHandler": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"virtLauncher": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -16282,6 +17512,34 @@ func schema_kubevirtio_client_go_api_v1_Machine(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MasqueradeSubnetPool(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MasqueradeSubnetPool is an IPv4 range which is split into subnets of the same size. Every VMI with a masquerade interface gets a subnet which is not used by any other VMI.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cidr": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CIDR is the IPv4 range of the pool",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"prefixLength": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrefixLength is the prefix length of the subnets allocated from the pool, between the prefix length of the pool and 30. Defaults to 24",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"cidr"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MediatedHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16341,17 +17599,28 @@ func schema_kubevirtio_client_go_api_v1_Memory(ref common.ReferenceCallback) com
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_MetadataService(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MigrationConfiguration holds migration options",
+				Description: "MetadataService enables the metadata service of the guest, which is served by virt-launcher.",
 				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"nodeDrainTaintKey": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MigrationConfiguration holds migration options",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeDrainTaintKey": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
 					"parallelOutboundMigrationsPerNode": {
@@ -16409,6 +17678,119 @@ func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.Refere
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MigrationInterfaceNetworkState(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MigrationInterfaceNetworkState is the state of a pod network binding which has to be preserved across a migration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the interface",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mac": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The MAC address the guest uses on the interface",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ip": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The IPv4 address of the guest in CIDR notation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ipv6": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The IPv6 address of the guest in CIDR notation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"device": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The device backing the interface in the virt-launcher pod, for macvtap bindings",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"masquerade": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The nat configuration of the interface, for masquerade bindings",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MigrationMasqueradeNetworkState"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.MigrationMasqueradeNetworkState"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MigrationMasqueradeNetworkState(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MigrationMasqueradeNetworkState is the nat configuration a masquerade binding was set up with on a node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ports": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The ports forwarded to the guest",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.Port"),
+									},
+								},
+							},
+						},
+					},
+					"hairpin": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The hairpin mode of the port forwarding rules",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clampMSS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the maximum segment size of the TCP connections of the guest is clamped to the path MTU",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"natRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The nat rules of the interface in iptables notation",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.Port"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16418,7 +17800,7 @@ func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallba
 				Properties: map[string]spec.Schema{
 					"networkName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.",
+							Description: "References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -16437,6 +17819,38 @@ func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NUMA(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NUMA allows specifying settings for the guest NUMA topology.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"guestMappingPassthrough": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes. Requires DedicatedCPUPlacement and Hugepages.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_NUMAGuestMappingPassthrough(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NUMAGuestMappingPassthrough requests a guest NUMA topology mirroring the host NUMA nodes of the pinned pCPUs.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Network(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16495,9 +17909,37 @@ func schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref common.Referenc
 							Format: "",
 						},
 					},
+					"bridge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bridge tunes the bridges which connect bridge and masquerade interfaces to the pod network",
+							Ref:         ref("kubevirt.io/client-go/api/v1.BridgeConfiguration"),
+						},
+					},
+					"masqueradeSubnetPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MasqueradeSubnetPool is the pool which the internal subnets of masquerade interfaces are allocated from, when the pod network does not set a vmNetworkCIDR",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MasqueradeSubnetPool"),
+						},
+					},
+					"defaultVMNetworkCIDR": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultVMNetworkCIDR is the vmNetworkCIDR of pod networks with a masquerade interface which do not set one. It is not applied when a masquerade subnet pool is configured. Defaults to 10.0.2.0/24",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"defaultVMIPv6NetworkCIDR": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultVMIPv6NetworkCIDR is the vmIPv6NetworkCIDR of pod networks with a masquerade interface which do not set one. Defaults to fd10:0:2::/120",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.BridgeConfiguration", "kubevirt.io/client-go/api/v1.MasqueradeSubnetPool"},
 	}
 }
 
@@ -16526,6 +17968,34 @@ func schema_kubevirtio_client_go_api_v1_NetworkSource(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NodeDensityConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeDensityConfiguration limits the VMIs a node runs with extended resources, which virt-handler advertises on the node and virt-launcher pods request",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxVMIsPerNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxVMIsPerNode is the number of VMIs a node can run at the same time. It is overridden for a node by its kubevirt.io/max-vmis annotation.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryOvercommitBudget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryOvercommitBudget is the guest memory a node can give to its VMIs beyond the memory requested by their virt-launcher pods. It is overridden for a node by its kubevirt.io/memory-overcommit-budget annotation.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_NodePlacement(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16575,6 +18045,41 @@ func schema_kubevirtio_client_go_api_v1_NodePlacement(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NvmeNamespaceHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NvmeNamespaceHostDevice represents NVMe namespaces allowed for passthrough. VFIO passes through PCI functions, a namespace is therefore passed through with the NVMe controller it is attached to, which has to be a virtual function of an SR-IOV capable NVMe drive with this namespace as its only namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pciVendorSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PCIVendorSelector selects the NVMe controllers by their vendor:device ID",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceName is the name of the resource the namespaces are advertised as",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"externalResourceProvider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalResourceProvider indicates that the namespaces are advertised by another device plugin",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"pciVendorSelector", "resourceName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_PITTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16674,11 +18179,47 @@ func schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref common.Referenc
 							},
 						},
 					},
+					"genericHostDevices": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "GenericHostDevices are device nodes of the hosts which VMIs can request as devices.kubevirt.io/<name> resources, like /dev/sev or /dev/vfio/vfio",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.GenericHostDevice"),
+									},
+								},
+							},
+						},
+					},
+					"nvmeNamespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "NvmeNamespaces are NVMe namespaces which VMIs can request as host devices, each namespace is passed through with the NVMe controller it is attached to",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.NvmeNamespaceHostDevice"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice"},
+			"kubevirt.io/client-go/api/v1.GenericHostDevice", "kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.NvmeNamespaceHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice"},
 	}
 }
 
@@ -16696,6 +18237,13 @@ func schema_kubevirtio_client_go_api_v1_PodNetwork(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"vmIPv6NetworkCIDR": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPv6 CIDR for the vm network of masquerade interfaces. Default fd10:0:2::/120 if not specified.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -16841,36 +18389,85 @@ func schema_kubevirtio_client_go_api_v1_QemuGuestAgentUserPasswordAccessCredenti
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_RTCTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_QoSClassConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "QoSClassConfiguration holds the node level settings a VMI QoS class is mapped to",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"tickPolicy": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of \"delay\", \"catchup\".",
+							Description: "Name of the class, which VMIs select with their qosClass",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"present": {
+					"blkioWeight": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "BlkioWeight is the relative blkio weight of the virt-launcher pod cgroup, between 10 and 1000",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"track": {
+					"networkRate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Track the guest or the wall clock.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NetworkRate is the guaranteed egress rate of the tap devices in bytes per second",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 						},
 					},
-				},
-			},
-		},
+					"networkCeil": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkCeil is the maximum egress rate of the tap devices in bytes per second, at least the NetworkRate",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"networkPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkPriority is the HTB priority of the tap devices, lower values are served first",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name", "blkioWeight", "networkRate", "networkCeil"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_RTCTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tickPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TickPolicy determines what happens when QEMU misses a deadline for injecting a tick to the guest. One of \"delay\", \"catchup\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"present": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled set to false makes sure that the machine type or a preset can't add the timer. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"track": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Track the guest or the wall clock.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -17107,6 +18704,49 @@ func schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialSource(ref c
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_ScopedFeatureGate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScopedFeatureGate enables a feature gate for the workloads of the namespaces it selects. The webhooks reject workloads using the feature in other namespaces.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the feature gate, e.g. Macvtap",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces the feature gate is enabled in",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector selects the namespaces the feature gate is enabled in by their labels",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_SecretVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17206,6 +18846,33 @@ func schema_kubevirtio_client_go_api_v1_Timer(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_UsageAccountingConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UsageAccountingConfiguration holds options of the per-VM resource usage accounting",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"billingPeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BillingPeriod after which the usage of VirtualMachines is reset, one of Daily, Weekly or Monthly. Periods start at midnight UTC, weeks on Monday.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"updateIntervalSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdateIntervalSeconds is the time between two accumulations of the usage of a running VirtualMachine",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredential(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17276,108 +18943,138 @@ func schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialSource(ref c
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachine(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VMIAdmissionPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachine handles the VirtualMachines that are not running or are in a stopped state The VirtualMachine contains the template to create the VirtualMachineInstance. It also mirrors the running state of the created VirtualMachineInstance in its status.",
+				Description: "VMIAdmissionPolicy rejects the creation of VMIs which match all of its rules",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"apiVersion": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Description: "Name identifies the policy in the messages of rejected VMIs",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"namespaces": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "Namespaces the policy applies to, all namespaces if empty",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"spec": {
+					"rules": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Spec contains the specification of VirtualMachineInstance created",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineSpec"),
+							Description: "Rules which all have to match a VMI to reject it",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VMIAdmissionRule"),
+									},
+								},
+							},
 						},
 					},
-					"status": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Status holds the current state of the controller and brief information about its associated VirtualMachineInstance",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineStatus"),
+							Description: "Message is returned to the user when a VMI is rejected",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"spec"},
+				Required: []string{"name", "rules"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineSpec", "kubevirt.io/client-go/api/v1.VirtualMachineStatus"},
+			"kubevirt.io/client-go/api/v1.VMIAdmissionRule"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VMIAdmissionRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineCondition represents the state of VirtualMachine",
+				Description: "VMIAdmissionRule matches the values a JSONPath expression selects from a VMI",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"type": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"status": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Path is a JSONPath expression evaluated against the VMI, e.g. {.spec.domain.cpu.cores}",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"lastProbeTime": {
+					"operator": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "Operator is one of In, NotIn, Exists, DoesNotExist, Gt and Lt. In, NotIn, Gt and Lt match if any selected value matches.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"lastTransitionTime": {
+					"values": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "Values the selected values are compared to, a single quantity like 4 or 8Gi for Gt and Lt",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
 						},
 					},
-					"reason": {
+				},
+				Required: []string{"path", "operator"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VhostUserBlkSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VhostUserBlkSource references the vhost-user-blk socket of a storage backend like SPDK, which qemu connects the disk to instead of opening a disk image.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume contains the socket, like the volumes of an SPDK CSI driver",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"message": {
+					"socket": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Socket is the path of the vhost-user-blk socket in the volume",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"type", "status"},
+				Required: []string{"claimName", "socket"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachine(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.",
+				Description: "VirtualMachine handles the VirtualMachines that are not running or are in a stopped state The VirtualMachine contains the template to create the VirtualMachineInstance. It also mirrors the running state of the created VirtualMachineInstance in its status.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -17401,14 +19098,14 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref common.Refere
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"),
+							Description: "Spec contains the specification of VirtualMachineInstance created",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"),
+							Description: "Status holds the current state of the controller and brief information about its associated VirtualMachineInstance",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineStatus"),
 						},
 					},
 				},
@@ -17416,136 +19113,92 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref common.Refere
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineSpec", "kubevirt.io/client-go/api/v1.VirtualMachineStatus"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineBulkOperationMessage(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineBulkOperationMessage tells why a bulk operation was not applied to a VirtualMachine",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"type": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"status": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"lastProbeTime": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
-						},
-					},
-					"lastTransitionTime": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
-						},
-					},
-					"reason": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Name is the name of the VirtualMachine",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"message": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Message tells why the operation was not applied",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"type", "status"},
+				Required: []string{"name", "message"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystem(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineBulkOperationOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceFileSystem represents guest os disk",
+				Description: "VirtualMachineBulkOperationOptions selects the VirtualMachines a bulk operation is applied to and how many of them are processed at once.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"diskName": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"mountPoint": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"fileSystemType": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"usedBytes": {
+					"labelSelector": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "LabelSelector selects the VirtualMachines of the namespace the operation is applied to",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"totalBytes": {
+					"batchSize": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "BatchSize is the number of VirtualMachines listed and processed at once. Defaults to 50, at most 500.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-				},
-				Required: []string{"diskName", "mountPoint", "fileSystemType", "usedBytes", "totalBytes"},
-			},
-		},
-	}
-}
-
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceFileSystemInfo represents information regarding single guest os filesystem",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"disks": {
+					"maxConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"),
-									},
-								},
-							},
+							Description: "MaxConcurrency is the number of VirtualMachines of a batch processed in parallel. Defaults to 10, at most 50.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
 				},
-				Required: []string{"disks"},
+				Required: []string{"labelSelector"},
 			},
 		},
-		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineBulkOperationResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceFileSystemList comprises the list of all filesystems on guest machine",
+				Description: "VirtualMachineBulkOperationResult summarizes the outcome of a bulk operation",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -17562,212 +19215,221 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemList(ref
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"operation": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Description: "Operation is the operation which was applied",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"items": {
+					"matched": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Matched is the number of VirtualMachines matching the label selector",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"succeeded": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Succeeded lists the VirtualMachines the operation was applied to",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"),
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"skipped": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Skipped lists the VirtualMachines the operation does not apply to in their current state, like running VirtualMachines for a start",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineBulkOperationMessage"),
+									},
+								},
+							},
+						},
+					},
+					"failed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Failed lists the VirtualMachines the operation failed for",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineBulkOperationMessage"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"items"},
+				Required: []string{"operation", "matched"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"},
+			"kubevirt.io/client-go/api/v1.VirtualMachineBulkOperationMessage"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestAgentInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent",
+				Description: "VirtualMachineCondition represents the state of VirtualMachine",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"apiVersion": {
-						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"guestAgentVersion": {
+					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "GAVersion is a version of currently installed guest agent",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"hostname": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Hostname represents FQDN of a guest",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"os": {
+					"lastProbeTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "OS contains the guest operating system information",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo"),
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"timezone": {
+					"lastTransitionTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Timezone is guest os current timezone",
-							Type:        []string{"string"},
-							Format:      "",
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"userList": {
+					"reason": {
 						SchemaProps: spec.SchemaProps{
-							Description: "UserList is a list of active guest OS users",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"),
-									},
-								},
-							},
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"fsInfo": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "FSInfo is a guest os filesystem information containing the disk mapping and disk mounts with usage",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemInfo"),
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
 				},
+				Required: []string{"type", "status"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineHibernation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineHibernation configures the PersistentVolumeClaim holding the memory of a hibernated VirtualMachine.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Name of the Guest OS",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"kernelRelease": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Guest OS Kernel Release",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"version": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Guest OS Version",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"prettyName": {
+					"storageClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Guest OS Pretty Name",
+							Description: "StorageClassName of the PersistentVolumeClaim, the default storage class is used if empty",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"versionId": {
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineHibernationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineHibernationStatus reports the hibernation state of a VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version ID of the Guest OS",
+							Description: "ClaimName is the name of the PersistentVolumeClaim holding the memory of the guest",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"kernelVersion": {
+					"size": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kernel version of the Guest OS",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Size is the capacity of the PersistentVolumeClaim, or its requested size while it is not bound",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 						},
 					},
-					"machine": {
+					"hibernated": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Machine type of the Guest OS",
-							Type:        []string{"string"},
+							Description: "Hibernated indicates that the memory of the guest is saved and will be restored on the next start",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"id": {
+					"hibernationTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Guest OS Id",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "HibernationTime is the time the memory of the guest was saved",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
+				Required: []string{"claimName"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUser(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineIdleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineGuestOSUser is the single user of the guest os",
+				Description: "VirtualMachineIdleStatus reports the activity of a VirtualMachine watched by the idle policy.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"userName": {
+					"lastActivityTime": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "LastActivityTime is the last time the guest was above a threshold of the idle policy, or its console was in use",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"domain": {
+					"idle": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Idle is true if the VirtualMachine had no activity for the window of the idle policy",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"loginTime": {
+					"action": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"number"},
-							Format: "double",
+							Description: "Action is the action taken on the idle VirtualMachine, empty until it is taken",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"userName"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUserList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceGuestOSUserList comprises the list of all active users on guest machine",
+				Description: "VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -17786,176 +19448,156 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUserList(re
 					},
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"items": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"),
-									},
-								},
-							},
+							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"),
 						},
 					},
 				},
-				Required: []string{"items"},
+				Required: []string{"spec"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceList is a list of VirtualMachines",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"apiVersion": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"metadata": {
+					"lastProbeTime": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"items": {
+					"lastTransitionTime": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstance"),
-									},
-								},
-							},
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
 				},
-				Required: []string{"items"},
+				Required: []string{"type", "status"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstance"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystem(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceMigration represents the object tracking a VMI's migration to another host in the cluster",
+				Description: "VirtualMachineInstanceFileSystem represents guest os disk",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"diskName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"apiVersion": {
+					"mountPoint": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"metadata": {
+					"fileSystemType": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"spec": {
+					"usedBytes": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationSpec"),
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"status": {
+					"totalBytes": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationStatus"),
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
 				},
-				Required: []string{"spec"},
+				Required: []string{"diskName", "mountPoint", "fileSystemType", "usedBytes", "totalBytes"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationStatus"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineInstanceFileSystemInfo represents information regarding single guest os filesystem",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"type": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"status": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"lastProbeTime": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
-						},
-					},
-					"lastTransitionTime": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
-						},
-					},
-					"reason": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"message": {
+					"disks": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"type", "status"},
+				Required: []string{"disks"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceMigrationList is a list of VirtualMachineMigrations",
+				Description: "VirtualMachineInstanceFileSystemList comprises the list of all filesystems on guest machine",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -17983,7 +19625,7 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationList(ref
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration"),
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"),
 									},
 								},
 							},
@@ -17994,135 +19636,143 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationList(ref
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestAgentInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"vmiName": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationState(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"startTimestamp": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The time the migration action began",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"endTimestamp": {
+					"guestAgentVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The time the migration action ended",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "GAVersion is a version of currently installed guest agent",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"targetNodeDomainDetected": {
+					"hostname": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The Target Node has seen the Domain Start Event",
-							Type:        []string{"boolean"},
+							Description: "Hostname represents FQDN of a guest",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"targetNodeAddress": {
+					"os": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The address of the target node to use for the migration",
+							Description: "OS contains the guest operating system information",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo"),
+						},
+					},
+					"timezone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timezone is guest os current timezone",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"targetDirectMigrationNodePorts": {
+					"userList": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The list of ports opened for live migration on the destination node",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "UserList is a list of active guest OS users",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"integer"},
-										Format: "int32",
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"),
 									},
 								},
 							},
 						},
 					},
-					"targetNode": {
+					"fsInfo": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The target node that the VMI is moving to",
+							Description: "FSInfo is a guest os filesystem information containing the disk mapping and disk mounts with usage",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemInfo"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the Guest OS",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"targetPod": {
+					"kernelRelease": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The target pod that the VMI is moving to",
+							Description: "Guest OS Kernel Release",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"sourceNode": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The source node that the VMI originated on",
+							Description: "Guest OS Version",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"completed": {
+					"prettyName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates the migration completed",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"failed": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Indicates that the migration failed",
-							Type:        []string{"boolean"},
+							Description: "Guest OS Pretty Name",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"abortRequested": {
+					"versionId": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates that the migration has been requested to abort",
-							Type:        []string{"boolean"},
+							Description: "Version ID of the Guest OS",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"abortStatus": {
+					"kernelVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates the final status of the live migration abortion",
+							Description: "Kernel version of the Guest OS",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"migrationUid": {
+					"machine": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The VirtualMachineInstanceMigration object associated with this migration",
+							Description: "Machine type of the Guest OS",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"mode": {
+					"id": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Lets us know if the vmi is currently running pre or post copy migration",
+							Description: "Guest OS Id",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -18130,142 +19780,136 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationState(ref
 				},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUser(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.",
+				Description: "VirtualMachineGuestOSUser is the single user of the guest os",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"phase": {
+					"userName": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"conditions": {
+					"domain": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition"),
-									},
-								},
-							},
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"loginTime": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"number"},
+							Format: "double",
 						},
 					},
 				},
+				Required: []string{"userName"},
 			},
 		},
-		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceNetworkInterface(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUserList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineInstanceGuestOSUserList comprises the list of all active users on guest machine",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"ipAddress": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "IP address of a Virtual Machine interface. It is always the first item of IPs",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"mac": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Hardware address of a Virtual Machine interface",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"name": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of the interface, corresponds to name of the network assigned to the interface",
-							Type:        []string{"string"},
-							Format:      "",
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 						},
 					},
-					"ipAddresses": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of all IP addresses of a Virtual Machine interface",
-							Type:        []string{"array"},
+							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"),
 									},
 								},
 							},
 						},
 					},
-					"interfaceName": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The interface name inside the Virtual Machine",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
 				},
+				Required: []string{"items"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePreset(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestTimeSync(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineInstanceGuestTimeSync reports the last synchronization of the guest clock with the clock of the node.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"synchronized": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
+							Description: "Synchronized indicates whether the guest clock was set",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"lastSyncTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "LastSyncTime is the time of the last attempt to set the guest clock",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"metadata": {
+					"driftMilliseconds": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "DriftMilliseconds is the offset of the guest clock from the clock of the node before it was set. It is positive if the guest clock was ahead, and only reported if the guest agent returned the guest time.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"spec": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstancePresetSpec"),
+							Description: "Message describes why the guest clock could not be set",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"synchronized"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstancePresetSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePresetList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstancePresetList is a list of VirtualMachinePresets",
+				Description: "VirtualMachineInstanceList is a list of VirtualMachines",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -18293,7 +19937,7 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePresetList(ref com
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstancePreset"),
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstance"),
 									},
 								},
 							},
@@ -18304,42 +19948,15 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePresetList(ref com
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstancePreset"},
-	}
-}
-
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePresetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"selector": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Selector is a label query over a set of VMIs. Required.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
-						},
-					},
-					"domain": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Domain is the same object type as contained in VirtualMachineInstanceSpec",
-							Ref:         ref("kubevirt.io/client-go/api/v1.DomainSpec"),
-						},
-					},
-				},
-				Required: []string{"selector"},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/client-go/api/v1.DomainSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstance"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSet(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.",
+				Description: "VirtualMachineInstanceMigration represents the object tracking a VMI's migration to another host in the cluster",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -18363,14 +19980,12 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSet(ref com
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetSpec"),
+							Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationSpec"),
 						},
 					},
 					"status": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetStatus"),
+							Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationStatus"),
 						},
 					},
 				},
@@ -18378,11 +19993,11 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSet(ref com
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationStatus"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -18431,11 +20046,11 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetConditio
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VMIList is a list of VMIs",
+				Description: "VirtualMachineInstanceMigrationList is a list of VirtualMachineMigrations",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -18463,7 +20078,7 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetList(ref
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSet"),
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration"),
 									},
 								},
 							},
@@ -18474,399 +20089,315 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetList(ref
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSet"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"replicas": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Number of desired pods. This is a pointer to distinguish between explicit zero and not specified. Defaults to 1.",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"selector": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Label selector for pods. Existing ReplicaSets whose pods are selected by this will be the ones affected by this deployment.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
-						},
-					},
-					"template": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Template describes the pods that will be created.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"),
-						},
-					},
-					"paused": {
+					"vmiName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates that the replica set is paused.",
-							Type:        []string{"boolean"},
+							Description: "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"selector", "template"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationState(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"replicas": {
+					"startTimestamp": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Total number of non-terminated pods targeted by this deployment (their labels match the selector).",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "The time the migration action began",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"readyReplicas": {
+					"endTimestamp": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The number of ready replicas for this replica set.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "The time the migration action ended",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"conditions": {
+					"targetNodeDomainDetected": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "The Target Node has seen the Domain Start Event",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"targetNodeAddress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The address of the target node to use for the migration",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetDirectMigrationNodePorts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The list of ports opened for live migration on the destination node",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetCondition"),
+										Type:   []string{"integer"},
+										Format: "int32",
 									},
 								},
 							},
 						},
 					},
-					"labelSelector": {
+					"targetNode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Canonical form of the label selector for HPA which consumes it through the scale subresource.",
+							Description: "The target node that the VMI is moving to",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetCondition"},
-	}
-}
-
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceSpec is a description of a VirtualMachineInstance.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"priorityClassName": {
+					"targetPod": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.",
+							Description: "The target pod that the VMI is moving to",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"domain": {
+					"sourceNode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specification of the desired behavior of the VirtualMachineInstance on the host.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.DomainSpec"),
+							Description: "The source node that the VMI originated on",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"nodeSelector": {
+					"completed": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeSelector is a selector which must be true for the vmi to fit on a node. Selector which must match a node's labels for the vmi to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
-									},
-								},
-							},
+							Description: "Indicates the migration completed",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"affinity": {
+					"failed": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If affinity is specifies, obey all the affinity rules",
-							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+							Description: "Indicates that the migration failed",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"schedulerName": {
+					"abortRequested": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, the VMI will be dispatched by specified scheduler. If not specified, the VMI will be dispatched by default scheduler.",
-							Type:        []string{"string"},
+							Description: "Indicates that the migration has been requested to abort",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"tolerations": {
+					"abortStatus": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If toleration is specified, obey all the toleration rules.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("k8s.io/api/core/v1.Toleration"),
-									},
-								},
-							},
+							Description: "Indicates the final status of the live migration abortion",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"evictionStrategy": {
+					"migrationUid": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EvictionStrategy can be set to \"LiveMigrate\" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.",
+							Description: "The VirtualMachineInstanceMigration object associated with this migration",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"terminationGracePeriodSeconds": {
+					"mode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "Lets us know if the vmi is currently running pre or post copy migration",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"volumes": {
+					"sourceNetworkState": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of volumes that can be mounted by disks belonging to the vmi.",
+							Description: "The network state of the interfaces on the source node, which the target node applies before the migration so that the guest keeps its MAC addresses. It is cleared once the migration completed",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.Volume"),
+										Ref: ref("kubevirt.io/client-go/api/v1.MigrationInterfaceNetworkState"),
 									},
 								},
 							},
 						},
 					},
-					"livenessProbe": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes",
-							Ref:         ref("kubevirt.io/client-go/api/v1.Probe"),
-						},
-					},
-					"readinessProbe": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes",
-							Ref:         ref("kubevirt.io/client-go/api/v1.Probe"),
-						},
-					},
-					"hostname": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"subdomain": {
-						SchemaProps: spec.SchemaProps{
-							Description: "If specified, the fully qualified vmi hostname will be \"<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>\". If not specified, the vmi will not have a domainname at all. The DNS entry will resolve to the vmi, no matter if the vmi itself can pick up a hostname.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"networks": {
+					"targetNetworkState": {
 						SchemaProps: spec.SchemaProps{
-							Description: "List of networks that can be attached to a vm's virtual interface.",
+							Description: "The network state of the interfaces on the target node, which the source node applies to the migrated domain so that it uses the devices of the target pod. It is cleared once the migration completed",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.Network"),
+										Ref: ref("kubevirt.io/client-go/api/v1.MigrationInterfaceNetworkState"),
 									},
 								},
 							},
 						},
 					},
-					"dnsPolicy": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Set DNS policy for the pod. Defaults to \"ClusterFirst\". Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'. DNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy. To have DNS options set along with hostNetwork, you have to specify DNS policy explicitly to 'ClusterFirstWithHostNet'.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"dnsConfig": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/api/v1.MigrationInterfaceNetworkState"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.",
-							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"accessCredentials": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "atomic",
-							},
-						},
+					"conditions": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies a set of public keys to inject into the vm guest",
-							Type:        []string{"array"},
+							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.AccessCredential"),
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"domain"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.Toleration", "kubevirt.io/client-go/api/v1.AccessCredential", "kubevirt.io/client-go/api/v1.DomainSpec", "kubevirt.io/client-go/api/v1.Network", "kubevirt.io/client-go/api/v1.Probe", "kubevirt.io/client-go/api/v1.Volume"},
+			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceNetworkInterface(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual state of a system.",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"nodeName": {
+					"ipAddress": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeName is the name where the VirtualMachineInstance is currently running.",
+							Description: "IP address of a Virtual Machine interface. It is always the first item of IPs",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"reason": {
+					"mac": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'",
+							Description: "Hardware address of a Virtual Machine interface",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"conditions": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Conditions are specific points in VirtualMachineInstance's pod runtime.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition"),
-									},
-								},
-							},
-						},
-					},
-					"phase": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
+							Description: "Name of the interface, corresponds to name of the network assigned to the interface",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"interfaces": {
+					"ipAddresses": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Interfaces represent the details of available network interfaces.",
+							Description: "List of all IP addresses of a Virtual Machine interface",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface"),
+										Type:   []string{"string"},
+										Format: "",
 									},
 								},
 							},
 						},
 					},
-					"guestOSInfo": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Guest OS Information",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo"),
-						},
-					},
-					"migrationState": {
+					"interfaceName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Represents the status of a live migration",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState"),
+							Description: "The interface name inside the Virtual Machine",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"migrationMethod": {
+					"binding": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Represents the method using which the vmi can be migrated: live migration or block migration",
+							Description: "Binding method connecting the interface to the pod network: bridge, masquerade, slirp, sriov or macvtap",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"qosClass": {
+					"tapDevice": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements See PodQOSClass type for available QOS classes More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md",
+							Description: "Name of the tap device backing the interface in the virt-launcher pod",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"evacuationNodeName": {
+					"bridgeDevice": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want to evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.",
+							Description: "Name of the bridge connecting the tap device to the pod network",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"activePods": {
+					"queueCount": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ActivePods is a mapping of pod UID to node name. It is possible for multiple pods to be running for a single VMI during migration.",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
-									},
-								},
-							},
+							Description: "Effective number of queues of a multiqueue interface, after capping it at the maximum of the tap device",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"volumeStatus": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "atomic",
-							},
-						},
+					"bridgeTXChecksumOffload": {
 						SchemaProps: spec.SchemaProps{
-							Description: "VolumeStatus contains the statuses of all the volumes",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VolumeStatus"),
-									},
-								},
-							},
+							Description: "Whether TX checksum offload is enabled on the bridge device, unset for interfaces without one",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
 			},
 		},
-		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VolumeStatus"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceTemplateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePreset(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
@@ -18875,22 +20406,192 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceTemplateSpec(ref c
 					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"),
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstancePresetSpec"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstancePresetSpec"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePresetList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineList is a list of virtualmachines",
+				Description: "VirtualMachineInstancePresetList is a list of VirtualMachinePresets",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstancePreset"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstancePreset"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstancePresetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is a label query over a set of VMIs. Required.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"domain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Domain is the same object type as contained in VirtualMachineInstanceSpec",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DomainSpec"),
+						},
+					},
+				},
+				Required: []string{"selector"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/client-go/api/v1.DomainSpec"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSet(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstance is *the* VirtualMachineInstance Definition. It represents a virtual machine in the runtime environment of kubernetes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is the high level overview of how the VirtualMachineInstance is doing. It contains information available to controllers and users.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"lastProbeTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastTransitionTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"type", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VMIList is a list of VMIs",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -18909,76 +20610,1203 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref common.ReferenceC
 					},
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSet"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSet"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of desired pods. This is a pointer to distinguish between explicit zero and not specified. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Label selector for pods. Existing ReplicaSets whose pods are selected by this will be the ones affected by this deployment.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template describes the pods that will be created.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"),
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that the replica set is paused.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"dataVolumeTemplates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "dataVolumeTemplates is a list of dataVolumes which are created for every VirtualMachineInstance of the replica set. The dataVolume volumes of the template referencing them are pointed to the copy of their replica, which is deleted along with the VirtualMachineInstance.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"selector", "template"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total number of non-terminated pods targeted by this deployment (their labels match the selector).",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"readyReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of ready replicas for this replica set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetCondition"),
+									},
+								},
+							},
+						},
+					},
+					"labelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Canonical form of the label selector for HPA which consumes it through the scale subresource.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetCondition"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceSpec is a description of a VirtualMachineInstance.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"domain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specification of the desired behavior of the VirtualMachineInstance on the host.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DomainSpec"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector is a selector which must be true for the vmi to fit on a node. Selector which must match a node's labels for the vmi to be scheduled on that node. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If affinity is specifies, obey all the affinity rules",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"schedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, the VMI will be dispatched by specified scheduler. If not specified, the VMI will be dispatched by default scheduler.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If toleration is specified, obey all the toleration rules.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"evictionStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvictionStrategy can be set to \"LiveMigrate\" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of volumes that can be mounted by disks belonging to the vmi.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.Volume"),
+									},
+								},
+							},
+						},
+					},
+					"livenessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes",
+							Ref:         ref("kubevirt.io/client-go/api/v1.Probe"),
+						},
+					},
+					"readinessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Periodic probe of VirtualMachineInstance service readiness. VirtualmachineInstances will be removed from service endpoints if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes",
+							Ref:         ref("kubevirt.io/client-go/api/v1.Probe"),
+						},
+					},
+					"hostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subdomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified, the fully qualified vmi hostname will be \"<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>\". If not specified, the vmi will not have a domainname at all. The DNS entry will resolve to the vmi, no matter if the vmi itself can pick up a hostname.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"networks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of networks that can be attached to a vm's virtual interface.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.Network"),
+									},
+								},
+							},
+						},
+					},
+					"dnsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set DNS policy for the pod. Defaults to \"ClusterFirst\". Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'. DNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy. To have DNS options set along with hostNetwork, you have to specify DNS policy explicitly to 'ClusterFirstWithHostNet'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the DNS parameters of a pod. Parameters specified here will be merged to the generated DNS configuration based on DNSPolicy.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+						},
+					},
+					"accessCredentials": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies a set of public keys to inject into the vm guest",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.AccessCredential"),
+									},
+								},
+							},
+						},
+					},
+					"qosClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QoSClass selects the tier of disk and network service the vmi gets on the node. Valid values are the QoS classes of the KubeVirt configuration, \"gold\", \"silver\" and \"bronze\" by default. No QoS is applied if not set. Unrelated to the pod QoS class reported in the status.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadataService": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MetadataService serves the metadata and the user-data of the vmi and the token of its service account to the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MetadataService"),
+						},
+					},
+					"guestOS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestOS declares the operating system of the guest, e.g. \"windows2k19\" or \"rhel8\". The disk bus, the interface model, the clock and the inputs which are neither set on the vmi nor by a preset are defaulted to what suits the guest OS.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"domain"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.Toleration", "kubevirt.io/client-go/api/v1.AccessCredential", "kubevirt.io/client-go/api/v1.DomainSpec", "kubevirt.io/client-go/api/v1.MetadataService", "kubevirt.io/client-go/api/v1.Network", "kubevirt.io/client-go/api/v1.Probe", "kubevirt.io/client-go/api/v1.Volume"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStartupTimestamps(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceStartupTimestamps records when the milestones of the VirtualMachineInstance startup were reached. Together with the creation timestamp they allow to see where the startup time is spent.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"podScheduled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodScheduled is the time the virt-launcher pod was scheduled to a node",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"networkConfigured": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkConfigured is the time virt-handler finished to configure the pod networking",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"domainDefined": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DomainDefined is the time the domain was first reported by virt-launcher",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"domainRunning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DomainRunning is the time the domain was first reported as running",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"guestAgentConnected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAgentConnected is the time the guest agent first connected",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceStats holds the resource usage counters of a running VirtualMachineInstance. The counters grow from the start of the VirtualMachineInstance, rates are the difference of two samples.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timestamp is the time the counters were read",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"),
+						},
+					},
+					"vcpus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VCPUs is the number of vCPUs of the guest",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cpuTimeNanoseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPUTimeNanoseconds is the CPU time the guest used",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryWorkingSetBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryWorkingSetBytes is the memory used by the guest, or the resident memory of qemu if the guest doesn't report its memory usage",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"diskReadBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DiskReadBytes is the number of bytes read from all disks",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"diskWriteBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DiskWriteBytes is the number of bytes written to all disks",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"networkReceiveBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkReceiveBytes is the number of bytes received on all interfaces",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"networkTransmitBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkTransmitBytes is the number of bytes transmitted on all interfaces",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"timestamp", "vcpus", "cpuTimeNanoseconds", "memoryWorkingSetBytes", "diskReadBytes", "diskWriteBytes", "networkReceiveBytes", "networkTransmitBytes"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual state of a system.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeName is the name where the VirtualMachineInstance is currently running.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are specific points in VirtualMachineInstance's pod runtime.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition"),
+									},
+								},
+							},
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"interfaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interfaces represent the details of available network interfaces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface"),
+									},
+								},
+							},
+						},
+					},
+					"guestOSInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Guest OS Information",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo"),
+						},
+					},
+					"migrationState": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Represents the status of a live migration",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState"),
+						},
+					},
+					"migrationMethod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Represents the method using which the vmi can be migrated: live migration or block migration",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"qosClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements See PodQOSClass type for available QOS classes More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"evacuationNodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want to evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"activePods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActivePods is a mapping of pod UID to node name. It is possible for multiple pods to be running for a single VMI during migration.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"volumeStatus": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeStatus contains the statuses of all the volumes",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VolumeStatus"),
+									},
+								},
+							},
+						},
+					},
+					"startupTimestamps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartupTimestamps records when the VirtualMachineInstance reached the milestones of its startup",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceStartupTimestamps"),
+						},
+					},
+					"vmNetworkCIDR": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VMNetworkCIDR is the internal subnet of the masquerade interface allocated from the masquerade subnet pool of the cluster. It is meant to be used by KubeVirt core components only and can't be set or modified by users.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Usage holds the resource usage counters of the VirtualMachineInstance, which are reported by virt-handler when usage accounting is enabled.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceUsage"),
+						},
+					},
+					"guestTimeSync": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestTimeSync reports the last synchronization of the guest clock, which is set through the guest agent after the VirtualMachineInstance was unpaused, migrated or resumed from hibernation.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestTimeSync"),
+						},
+					},
+					"fsFreezeStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FSFreezeStatus is \"frozen\" while the guest file systems are frozen through the guest agent, and empty otherwise.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"containerDiskStatuses": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerDiskStatuses reports the progress of the preparation of the containerDisks on the node, before the VirtualMachineInstance is started or migrated to the node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.ContainerDiskStatus"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.ContainerDiskStatus", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestTimeSync", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceStartupTimestamps", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceUsage", "kubevirt.io/client-go/api/v1.VolumeStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceTemplateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualMachineInstance Spec contains the VirtualMachineInstance specification.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceUsage holds the resource usage counters of a VirtualMachineInstance. The counters start at zero whenever a domain is started, also on migration targets.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"networkReceiveBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkReceiveBytes is the number of bytes received on all interfaces",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"networkTransmitBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkTransmitBytes is the number of bytes transmitted on all interfaces",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"networkReceiveBytes", "networkTransmitBytes"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineList is a list of virtualmachines",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachine"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachine"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineNotificationHook registers an endpoint which is notified about lifecycle events of the VirtualMachineInstances in its namespace",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookSpec"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookSpec"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineNotificationHookList is a list of VirtualMachineNotificationHooks",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineNotificationHook"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineNotificationHook"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL the events are sent to with a POST request. Only http and https URLs are supported.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"events": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Events which are sent to the URL. All events are sent if empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is a label query over the VMIs whose events are sent. The events of all VMIs in the namespace are sent if empty.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"signingSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SigningSecretRef references a secret in the namespace of the hook. The value of its \"key\" entry signs the events with HMAC-SHA256.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRetries is the number of times the delivery of an event is retried after it failed. Defaults to 5.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachinePowerSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachinePowerSchedule starts and stops a VirtualMachine at the times of cron schedules.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is the schedule in cron format the VirtualMachine is started at, e.g. \"0 8 * * 1-5\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"stop": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stop is the schedule in cron format the VirtualMachine is stopped at, e.g. \"0 20 * * *\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone the schedules are evaluated in, a name of the IANA time zone database like \"Europe/Berlin\". Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"skipIfInUse": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipIfInUse skips a scheduled stop while the VirtualMachine is in use, that is users are logged in to the guest or the VirtualMachineInstance is migrating",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachinePowerScheduleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachinePowerScheduleStatus reports the runs of the power schedule of a VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastStartTime is the time of the last scheduled start",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextStartTime is the time of the next scheduled start",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastStopTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastStopTime is the time of the last scheduled stop",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextStopTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextStopTime is the time of the next scheduled stop",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message reports an invalid schedule or a skipped stop",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineReplication(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineReplication configures the disaster recovery cluster the disks of a VirtualMachine are replicated to.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pairingSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PairingSecretRef is the name of the Secret granting access to the disaster recovery cluster, with the server and token and the optional ca.crt and namespace keys",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the copy in the disaster recovery cluster, defaults to the namespace key of the pairing Secret",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interval between the copies of the volumes replicated with rsync, one hour if not set",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClassName of the PersistentVolumeClaims created in the disaster recovery cluster for the volumes replicated with rsync, the default storage class is used if empty",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"pairingSecretRef"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineReplicationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineReplicationStatus reports the replication of a VirtualMachine to the disaster recovery cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the replication, the least advanced phase of the volumes",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the time all volumes were last replicated, the state the copy in the disaster recovery cluster is in",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"volumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes reports the replication of the volumes backed by PersistentVolumeClaims",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VolumeReplicationStatus"),
+									},
+								},
+							},
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains the phase",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/api/v1.VolumeReplicationStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSpec describes how the proper VirtualMachine should look like",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"running": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Running controls whether the associatied VirtualMachineInstance is created or not Mutually exclusive with RunStrategy",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"runStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template is the direct specification of VirtualMachineInstance",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"),
+						},
+					},
+					"dataVolumeTemplates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference. DataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec"),
+									},
+								},
+							},
+						},
+					},
+					"hibernation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hibernation allows to hibernate the VirtualMachine. The memory of the guest is saved to a PersistentVolumeClaim managed by KubeVirt and restored on the next start.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineHibernation"),
+						},
+					},
+					"replication": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replication copies the disks of the VirtualMachine asynchronously to a disaster recovery cluster, which keeps a stopped copy of the VirtualMachine to start if this cluster fails.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineReplication"),
+						},
+					},
+					"powerSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PowerSchedule starts and stops the VirtualMachine at the times of cron schedules, e.g. to stop development VMs at night.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachinePowerSchedule"),
+						},
+					},
+				},
+				Required: []string{"template"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineHibernation", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachinePowerSchedule", "kubevirt.io/client-go/api/v1.VirtualMachineReplication"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineStartBlocker(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineStartBlocker is a single reason why a VirtualMachine can't be started",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"check": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Check is the check which failed",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"items": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachine"),
-									},
-								},
-							},
+							Description: "Message describes what blocks the start",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"items"},
+				Required: []string{"check", "message"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachine"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineStartValidation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineSpec describes how the proper VirtualMachine should look like",
+				Description: "VirtualMachineStartValidation reports what would block the start of a VirtualMachine",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"running": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Running controls whether the associatied VirtualMachineInstance is created or not Mutually exclusive with RunStrategy",
-							Type:        []string{"boolean"},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"runStrategy": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"template": {
+					"startable": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Template is the direct specification of VirtualMachineInstance",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"),
+							Description: "Startable is true if none of the checks blocks the start",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"dataVolumeTemplates": {
+					"blockers": {
 						SchemaProps: spec.SchemaProps{
-							Description: "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference. DataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+							Description: "Blockers lists everything which would block the start",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec"),
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineStartBlocker"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"template"},
+				Required: []string{"startable"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
+			"kubevirt.io/client-go/api/v1.VirtualMachineStartBlocker"},
 	}
 }
 
@@ -19038,6 +21866,19 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"lastSnapshotTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSnapshotTime is the creation time of the last VirtualMachineSnapshot of the VM which became ready to use",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"fsFreezeStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FSFreezeStatus is \"frozen\" while the guest file systems of the VirtualMachineInstance are frozen, and empty otherwise",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"created": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Created indicates if the virtual machine is created in the cluster",
@@ -19052,6 +21893,13 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"printableStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrintableStatus is a human readable, high-level summary of the state of the virtual machine, including the reason why it is not running if its virtual machine instance can't be started",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"conditions": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
@@ -19109,11 +21957,200 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref common.Referenc
 							},
 						},
 					},
+					"usage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Usage accumulates the resources consumed by the VirtualMachine in the current and the previous billing period, for chargeback. It is only maintained when usage accounting is enabled.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineUsage"),
+						},
+					},
+					"hibernation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hibernation reports the PersistentVolumeClaim holding the memory of the guest and whether the VirtualMachine is hibernated. It is only set if hibernation is configured.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineHibernationStatus"),
+						},
+					},
+					"replicationStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicationStatus reports the replication of the disks to the disaster recovery cluster. It is only set if replication is configured.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineReplicationStatus"),
+						},
+					},
+					"powerScheduleStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PowerScheduleStatus reports the last and next runs of the power schedule. It is only set if a power schedule is configured.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachinePowerScheduleStatus"),
+						},
+					},
+					"idleStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdleStatus reports the activity of the VirtualMachine and whether it was suspended for being idle. It is only set for running VirtualMachines the idle policy applies to.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineIdleStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/api/v1.VirtualMachineCondition", "kubevirt.io/client-go/api/v1.VirtualMachineHibernationStatus", "kubevirt.io/client-go/api/v1.VirtualMachineIdleStatus", "kubevirt.io/client-go/api/v1.VirtualMachinePowerScheduleStatus", "kubevirt.io/client-go/api/v1.VirtualMachineReplicationStatus", "kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest", "kubevirt.io/client-go/api/v1.VirtualMachineUsage", "kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest", "kubevirt.io/client-go/api/v1.VolumeSnapshotStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineUsage holds the resources consumed by a VirtualMachine per billing period.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"current": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Current is the usage accumulated in the running billing period",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineUsagePeriod"),
+						},
+					},
+					"previous": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Previous is the usage of the last completed billing period",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineUsagePeriod"),
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime is the time the usage was last accumulated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"instance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Instance is the state of the VirtualMachineInstance at the last accumulation. It is meant to be used by KubeVirt core components only.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineUsageInstance"),
+						},
+					},
+				},
+				Required: []string{"current", "lastUpdateTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/api/v1.VirtualMachineUsageInstance", "kubevirt.io/client-go/api/v1.VirtualMachineUsagePeriod"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineUsageInstance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineUsageInstance is the state of a VirtualMachineInstance at the last usage accumulation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UID of the VirtualMachineInstance",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"running": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Running indicates if the VirtualMachineInstance was running",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"vcpus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VCPUs is the number of vCPUs allocated to the VirtualMachineInstance",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryMebibytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryMebibytes is the guest memory of the VirtualMachineInstance in MiB",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"networkReceiveBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkReceiveBytes is the receive counter reported for the VirtualMachineInstance",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"networkTransmitBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkTransmitBytes is the transmit counter reported for the VirtualMachineInstance",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"uid", "running", "vcpus", "memoryMebibytes", "networkReceiveBytes", "networkTransmitBytes"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineUsagePeriod(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineUsagePeriod holds the resources consumed by a VirtualMachine in a billing period.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is the beginning of the billing period",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"end": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End is the end of the billing period",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"vcpuSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VCPUSeconds is the number of vCPUs allocated to the VirtualMachineInstance, summed up over every second it was running",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryMebibyteSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryMebibyteSeconds is the guest memory in MiB of the VirtualMachineInstance, summed up over every second it was running",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"storageBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageBytes is the capacity of the PersistentVolumeClaims and DataVolumes of the VirtualMachine at the last accumulation",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"networkReceiveBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkReceiveBytes is the number of bytes received by the VirtualMachineInstance",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"networkTransmitBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NetworkTransmitBytes is the number of bytes transmitted by the VirtualMachineInstance",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
+				Required: []string{"start", "end", "vcpuSeconds", "memoryMebibyteSeconds", "storageBytes", "networkReceiveBytes", "networkTransmitBytes"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineCondition", "kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest", "kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest", "kubevirt.io/client-go/api/v1.VolumeSnapshotStatus"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -19229,12 +22266,103 @@ func schema_kubevirtio_client_go_api_v1_Volume(ref common.ReferenceCallback) com
 							Ref:         ref("kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"),
 						},
 					},
+					"vhostUserBlk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VhostUserBlkSource"),
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Encryption layers LUKS encryption on top of the volume, which is handled by qemu. Only PersistentVolumeClaim and DataVolume volumes can be encrypted.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VolumeEncryption"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.VhostUserBlkSource", "kubevirt.io/client-go/api/v1.VolumeEncryption"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VolumeEncryption(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeEncryption specifies how a volume is encrypted.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the \"key\" key. Keys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+				},
+				Required: []string{"secretRef"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VolumeReplicationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeReplicationStatus reports the replication of a volume of a VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PersistentVolumeClaim, the copy in the disaster recovery cluster has the same name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method the volume is replicated with",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the replication of the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the time the volume was last replicated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains the phase",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "claimName", "method"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -19351,11 +22479,17 @@ func schema_kubevirtio_client_go_api_v1_VolumeSource(ref common.ReferenceCallbac
 							Ref:         ref("kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"),
 						},
 					},
+					"vhostUserBlk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VhostUserBlkSource"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.VhostUserBlkSource"},
 	}
 }
 
@@ -75,6 +75,8 @@ var (
 	VirtualMachineInstancePresetGroupVersionKind     = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineInstancePreset"}
 	VirtualMachineGroupVersionKind                   = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachine"}
 	VirtualMachineInstanceMigrationGroupVersionKind  = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineInstanceMigration"}
+	VirtualMachineNotificationHookGroupVersionKind   = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineNotificationHook"}
+	FencingRequestGroupVersionKind                   = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "FencingRequest"}
 	KubeVirtGroupVersionKind                         = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "KubeVirt"}
 )
 
@@ -106,6 +108,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 			&VirtualMachineInstanceMigrationList{},
 			&VirtualMachine{},
 			&VirtualMachineList{},
+			&VirtualMachineNotificationHook{},
+			&VirtualMachineNotificationHookList{},
+			&FencingRequest{},
+			&FencingRequestList{},
 			&KubeVirt{},
 			&KubeVirtList{},
 		)
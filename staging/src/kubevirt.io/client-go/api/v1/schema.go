@@ -197,6 +197,10 @@ type DomainSpec struct {
 	// Chassis specifies the chassis info passed to the domain.
 	// +optional
 	Chassis *Chassis `json:"chassis,omitempty"`
+	// AllowEmulation overrides whether the VMI may fall back to software emulation
+	// on nodes without /dev/kvm, within the emulation policy of the cluster.
+	// +optional
+	AllowEmulation *bool `json:"allowEmulation,omitempty"`
 }
 
 // Chassis specifies the chassis info passed to the domain.
@@ -244,6 +248,21 @@ type EFI struct {
 	SecureBoot *bool `json:"secureBoot,omitempty"`
 }
 
+// BootMenu lets the user pick a boot entry on the serial or graphical
+// console while the VMI boots.
+//
+// +k8s:openapi-gen=true
+type BootMenu struct {
+	// If set, the firmware offers a boot menu.
+	// Defaults to true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Time in milliseconds the boot menu waits for a selection before booting
+	// the default entry. Must be at most 65535.
+	// +optional
+	Timeout *uint32 `json:"timeout,omitempty"`
+}
+
 //
 // +k8s:openapi-gen=true
 type ResourceRequirements struct {
@@ -292,6 +311,35 @@ type CPU struct {
 	// the emulator thread on it.
 	// +optional
 	IsolateEmulatorThread bool `json:"isolateEmulatorThread,omitempty"`
+	// NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest
+	// and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.
+	// +optional
+	NestedVirtualization bool `json:"nestedVirtualization,omitempty"`
+	// NUMA allows specifying settings for the guest NUMA topology
+	// +optional
+	NUMA *NUMA `json:"numa,omitempty"`
+	// SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not
+	// vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and
+	// flushes the L1 data cache on every VM entry where L1TF applies.
+	// +optional
+	SideChannelIsolation bool `json:"sideChannelIsolation,omitempty"`
+}
+
+// NUMAGuestMappingPassthrough requests a guest NUMA topology mirroring the host NUMA nodes of the pinned pCPUs.
+//
+// +k8s:openapi-gen=true
+type NUMAGuestMappingPassthrough struct {
+}
+
+// NUMA allows specifying settings for the guest NUMA topology.
+//
+// +k8s:openapi-gen=true
+type NUMA struct {
+	// GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod.
+	// The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.
+	// Requires DedicatedCPUPlacement and Hugepages.
+	// +optional
+	GuestMappingPassthrough *NUMAGuestMappingPassthrough `json:"guestMappingPassthrough,omitempty"`
 }
 
 // CPUFeature allows specifying a CPU feature.
@@ -351,8 +399,29 @@ type Firmware struct {
 	Bootloader *Bootloader `json:"bootloader,omitempty"`
 	// The system-serial-number in SMBIOS
 	Serial string `json:"serial,omitempty"`
+	// Settings to control the interactive boot menu of the firmware.
+	// +optional
+	BootMenu *BootMenu `json:"bootMenu,omitempty"`
+	// IdentityPolicy controls how the UUID and the serial are generated when they are not set.
+	// Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores.
+	// RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that
+	// clones get new ones, while restores keep them. Defaults to Keep.
+	// +optional
+	IdentityPolicy FirmwareIdentityPolicy `json:"identityPolicy,omitempty"`
 }
 
+// FirmwareIdentityPolicy controls how the UUID and the serial of a vmi are generated.
+//
+// +k8s:openapi-gen=true
+type FirmwareIdentityPolicy string
+
+const (
+	// FirmwareIdentityPolicyKeep keeps the UUID of the VirtualMachine on clones and restores
+	FirmwareIdentityPolicyKeep FirmwareIdentityPolicy = "Keep"
+	// FirmwareIdentityPolicyRegenerateOnClone generates a new UUID and serial for clones
+	FirmwareIdentityPolicyRegenerateOnClone FirmwareIdentityPolicy = "RegenerateOnClone"
+)
+
 //
 // +k8s:openapi-gen=true
 type Devices struct {
@@ -366,11 +435,19 @@ type Devices struct {
 	Interfaces []Interface `json:"interfaces,omitempty"`
 	// Inputs describe input devices
 	Inputs []Input `json:"inputs,omitempty"`
+	// Model of the USB controller, which is attached when an input device is on the usb bus.
+	// Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.
+	// +optional
+	USBControllerModel string `json:"usbControllerModel,omitempty"`
 	// Whether to attach a pod network interface. Defaults to true.
 	AutoattachPodInterface *bool `json:"autoattachPodInterface,omitempty"`
 	// Whether to attach the default graphics device or not.
 	// VNC will not be available if set to false. Defaults to true.
 	AutoattachGraphicsDevice *bool `json:"autoattachGraphicsDevice,omitempty"`
+	// Whether to attach a tablet when the graphics device is attached and no inputs are specified.
+	// The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.
+	// +optional
+	AutoattachInputDevice *bool `json:"autoattachInputDevice,omitempty"`
 	// Whether to attach the default serial console or not.
 	// Serial console access will not be available if set to false. Defaults to true.
 	AutoattachSerialConsole *bool `json:"autoattachSerialConsole,omitempty"`
@@ -379,6 +456,12 @@ type Devices struct {
 	// Defaults to true.
 	// +optional
 	AutoattachMemBalloon *bool `json:"autoattachMemBalloon,omitempty"`
+	// Whether to attach the virtio-win driver disk as a CD-ROM, which holds the
+	// virtio drivers Windows needs to install on virtio disks and to use virtio
+	// network interfaces. The image of the disk is configured in the KubeVirt CR.
+	// Defaults to false.
+	// +optional
+	AutoattachVirtioWinDriverDisk *bool `json:"autoattachVirtioWinDriverDisk,omitempty"`
 	// Whether to have random number generator from host
 	// +optional
 	Rng *Rng `json:"rng,omitempty"`
@@ -388,6 +471,10 @@ type Devices struct {
 	// If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
 	// +optional
 	NetworkInterfaceMultiQueue *bool `json:"networkInterfaceMultiqueue,omitempty"`
+	// Number of queues of the virtio interfaces with networkInterfaceMultiqueue.
+	// Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.
+	// +optional
+	NetworkInterfaceQueues *uint32 `json:"networkInterfaceQueues,omitempty"`
 	//Whether to attach a GPU device to the vmi.
 	// +optional
 	// +listType=atomic
@@ -400,8 +487,20 @@ type Devices struct {
 	// +optional
 	// +listType=atomic
 	HostDevices []HostDevice `json:"hostDevices,omitempty"`
+	// Channels are additional virtio-serial channels for custom communication between the host and the guest.
+	// +optional
+	// +listType=atomic
+	Channels []Channel `json:"channels,omitempty"`
 }
 
+// VirtioWinDriverDiskName is the name of the disk and the volume which are
+// attached for AutoattachVirtioWinDriverDisk
+const VirtioWinDriverDiskName = "virtio-win"
+
+// MaxNetworkInterfaceQueues is the maximum number of queues of a tap device
+// (MAX_TAP_QUEUES of the kernel), which limits the queues of virtio interfaces
+const MaxNetworkInterfaceQueues = 256
+
 //
 // +k8s:openapi-gen=true
 type Input struct {
@@ -409,10 +508,14 @@ type Input struct {
 	// Supported values: virtio, usb.
 	Bus string `json:"bus,omitempty"`
 	// Type indicated the type of input device.
-	// Supported values: tablet.
+	// Supported values: tablet, keyboard.
 	Type string `json:"type"`
 	// Name is the device name
 	Name string `json:"name"`
+	// Model indicates the model of a virtio input device.
+	// Supported values: virtio, virtio-transitional, virtio-non-transitional.
+	// +optional
+	Model string `json:"model,omitempty"`
 }
 
 //
@@ -444,6 +547,14 @@ type HostDevice struct {
 	DeviceName string `json:"deviceName"`
 }
 
+//
+// +k8s:openapi-gen=true
+type Channel struct {
+	// Name is the name of the virtio-serial port, like org.example.agent.0.
+	// The guest finds the channel at /dev/virtio-ports/<name>.
+	Name string `json:"name"`
+}
+
 //
 // +k8s:openapi-gen=true
 type Disk struct {
@@ -461,6 +572,10 @@ type Disk struct {
 	// Serial provides the ability to specify a serial number for the disk device.
 	// +optional
 	Serial string `json:"serial,omitempty"`
+	// WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits.
+	// Only supported for disks on the sata and scsi bus.
+	// +optional
+	WWN string `json:"wwn,omitempty"`
 	// dedicatedIOThread indicates this disk should have an exclusive IO Thread.
 	// Enabling this implies useIOThreads = true.
 	// Defaults to false.
@@ -473,7 +588,8 @@ type Disk struct {
 	// Supported values are: native, default, threads.
 	// +optional
 	IO DriverIO `json:"io,omitempty"`
-	// If specified, disk address and its tag will be provided to the guest via config drive metadata
+	// If specified, disk address and its tag will be provided to the guest via config drive metadata.
+	// Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>
 	// +optional
 	Tag string `json:"tag,omitempty"`
 }
@@ -570,6 +686,19 @@ type Volume struct {
 	// VolumeSource represents the location and type of the mounted volume.
 	// Defaults to Disk, if no type is specified.
 	VolumeSource `json:",inline"`
+	// Encryption layers LUKS encryption on top of the volume, which is handled by qemu.
+	// Only PersistentVolumeClaim and DataVolume volumes can be encrypted.
+	// +optional
+	Encryption *VolumeEncryption `json:"encryption,omitempty"`
+}
+
+// VolumeEncryption specifies how a volume is encrypted.
+//
+// +k8s:openapi-gen=true
+type VolumeEncryption struct {
+	// SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the "key" key.
+	// Keys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.
+	SecretRef *v1.LocalObjectReference `json:"secretRef"`
 }
 
 // Represents the source of a volume to mount.
@@ -626,6 +755,22 @@ type VolumeSource struct {
 	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
 	// +optional
 	ServiceAccount *ServiceAccountVolumeSource `json:"serviceAccount,omitempty"`
+	// VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk.
+	// The VhostUserBlk feature gate has to be enabled.
+	// +optional
+	VhostUserBlk *VhostUserBlkSource `json:"vhostUserBlk,omitempty"`
+}
+
+// VhostUserBlkSource references the vhost-user-blk socket of a storage backend like SPDK,
+// which qemu connects the disk to instead of opening a disk image.
+//
+// +k8s:openapi-gen=true
+type VhostUserBlkSource struct {
+	// ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume
+	// contains the socket, like the volumes of an SPDK CSI driver
+	ClaimName string `json:"claimName"`
+	// Socket is the path of the vhost-user-blk socket in the volume
+	Socket string `json:"socket"`
 }
 
 // HotplugVolumeSource Represents the source of a volume to mount which are capable
@@ -650,6 +795,10 @@ type HotplugVolumeSource struct {
 type DataVolumeSource struct {
 	// Name represents the name of the DataVolume in the same namespace
 	Name string `json:"name"`
+	// Checksum is the expected digest of the imported disk, in the form sha256:<hex>.
+	// The disk is verified once after the import, VMIs using it are not started before it matched.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
 }
 
 //
@@ -660,6 +809,9 @@ type EphemeralVolumeSource struct {
 	// More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
 	// +optional
 	PersistentVolumeClaim *v1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// EphemeralImage configures the writable image the VMI runs on.
+	// +optional
+	EphemeralImage *EphemeralImage `json:"ephemeralImage,omitempty"`
 }
 
 // EmptyDisk represents a temporary disk which shares the vmis lifecycle.
@@ -687,8 +839,62 @@ type ContainerDiskSource struct {
 	// More info: https://kubernetes.io/docs/concepts/containers/images#updating-images
 	// +optional
 	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Checksum is the expected digest of the disk in the image, in the form sha256:<hex>.
+	// The disk is verified before the VMI is started, which fails on a mismatch.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+	// EphemeralImage configures the writable image the VMI runs on.
+	// +optional
+	EphemeralImage *EphemeralImage `json:"ephemeralImage,omitempty"`
+}
+
+// EphemeralImage configures the writable image which is created from a
+// containerDisk or ephemeral volume when the VMI starts.
+//
+// +k8s:openapi-gen=true
+type EphemeralImage struct {
+	// Format of the writable image. qcow2 creates a copy-on-write overlay backed by
+	// the source image, raw converts the source image into a raw image.
+	// Defaults to qcow2.
+	// +optional
+	Format EphemeralImageFormat `json:"format,omitempty"`
+	// Preallocation mode of a raw image.
+	// One of off, falloc or full.
+	// Defaults to off.
+	// +optional
+	Preallocation EphemeralImagePreallocation `json:"preallocation,omitempty"`
+	// Compressed converts the source image into a compressed qcow2 image instead
+	// of creating an overlay. Only supported for the qcow2 format.
+	// +optional
+	Compressed bool `json:"compressed,omitempty"`
 }
 
+// EphemeralImageFormat is the format of the writable image of a containerDisk or ephemeral volume.
+//
+// +k8s:openapi-gen=true
+type EphemeralImageFormat string
+
+const (
+	// EphemeralImageFormatQcow2 creates a qcow2 image
+	EphemeralImageFormatQcow2 EphemeralImageFormat = "qcow2"
+	// EphemeralImageFormatRaw creates a raw image
+	EphemeralImageFormatRaw EphemeralImageFormat = "raw"
+)
+
+// EphemeralImagePreallocation is the preallocation mode of the writable image of a containerDisk or ephemeral volume.
+//
+// +k8s:openapi-gen=true
+type EphemeralImagePreallocation string
+
+const (
+	// EphemeralImagePreallocationOff allocates the image sparsely
+	EphemeralImagePreallocationOff EphemeralImagePreallocation = "off"
+	// EphemeralImagePreallocationFalloc reserves the space of the image with fallocate
+	EphemeralImagePreallocationFalloc EphemeralImagePreallocation = "falloc"
+	// EphemeralImagePreallocationFull writes zeroes to the whole image
+	EphemeralImagePreallocationFull EphemeralImagePreallocation = "full"
+)
+
 // Exactly one of its members must be set.
 //
 // +k8s:openapi-gen=true
@@ -1071,11 +1277,138 @@ type Interface struct {
 	// If specified the network interface will pass additional DHCP options to the VMI
 	// +optional
 	DHCPOptions *DHCPOptions `json:"dhcpOptions,omitempty"`
-	// If specified, the virtual network interface address and its tag will be provided to the guest via config drive
+	// If specified, the virtual network interface address and its tag will be provided to the guest via config drive.
+	// The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>
 	// +optional
 	Tag string `json:"tag,omitempty"`
+	// Roles the interface fulfills in the guest: management, storage or workload.
+	// They are published to the guest as SMBIOS OEM strings of the form
+	// io.kubevirt.interface.role:<role>=<MAC address>.
+	// +optional
+	Roles []InterfaceRole `json:"roles,omitempty"`
+	// Size of the virtio RX queue ring of the interface.
+	// Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+	// +optional
+	RxQueueSize *uint32 `json:"rxQueueSize,omitempty"`
+	// Size of the virtio TX queue ring of the interface.
+	// Must be a power of 2 between 256 and 1024. Only supported on virtio interfaces.
+	// +optional
+	TxQueueSize *uint32 `json:"txQueueSize,omitempty"`
+	// Offloads of the host side tap device of the interface.
+	// Only supported on bridge and masquerade interfaces.
+	// +optional
+	Offloads *InterfaceOffloads `json:"offloads,omitempty"`
+	// Use packed virtqueues instead of split virtqueues for the interface,
+	// which reduces the latency of the guest network.
+	// Only supported on virtio interfaces.
+	// +optional
+	PackedRing *bool `json:"packedRing,omitempty"`
+	// Request zero copy transmission by vhost-net for the interface.
+	// The VMI is only scheduled on nodes where the vhost_net kernel module
+	// has zero copy transmission enabled.
+	// Only supported on virtio interfaces which are not bound with slirp.
+	// +optional
+	VhostZeroCopyTX *bool `json:"vhostZeroCopyTX,omitempty"`
+	// Copy the traffic of the interface to a monitoring network.
+	// Only supported on bridge and masquerade interfaces.
+	// +optional
+	Mirror *InterfaceMirror `json:"mirror,omitempty"`
+	// Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls
+	// the tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net
+	// threads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs.
+	// Only supported on virtio bridge and masquerade interfaces.
+	// +optional
+	LatencyProfile InterfaceLatencyProfile `json:"latencyProfile,omitempty"`
+	// Receive side scaling of the interface. The device steers the received packets
+	// to the queues by their hash, as configured by the guest, which spreads the load
+	// of the guest network over the vCPUs.
+	// Only supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.
+	// +optional
+	RSS *InterfaceRSS `json:"rss,omitempty"`
+}
+
+// InterfaceRSS enables the receive side scaling of a multiqueue virtio interface.
+//
+// +k8s:openapi-gen=true
+type InterfaceRSS struct {
+	// Report the hash of the received packets to the guest, which saves the guest
+	// from calculating it again, e.g. for receive flow steering.
+	// +optional
+	HashReport *bool `json:"hashReport,omitempty"`
 }
 
+// InterfaceMirror copies the traffic of the tap device of an interface to the
+// pod interface of a monitoring network.
+//
+// +k8s:openapi-gen=true
+type InterfaceMirror struct {
+	// Name of the Multus network which receives the copied traffic.
+	// It must not be the network of an interface.
+	Network string `json:"network"`
+	// Traffic to copy, as seen from the guest: rx, tx or both.
+	// Defaults to both.
+	// +optional
+	Direction MirrorDirection `json:"direction,omitempty"`
+}
+
+// MirrorDirection selects the traffic of an interface which is mirrored.
+//
+// +k8s:openapi-gen=true
+type MirrorDirection string
+
+const (
+	// MirrorDirectionRX mirrors the traffic received by the guest
+	MirrorDirectionRX MirrorDirection = "rx"
+	// MirrorDirectionTX mirrors the traffic sent by the guest
+	MirrorDirectionTX MirrorDirection = "tx"
+	// MirrorDirectionBoth mirrors the traffic in both directions
+	MirrorDirectionBoth MirrorDirection = "both"
+)
+
+// InterfaceLatencyProfile tunes the host datapath of an interface.
+type InterfaceLatencyProfile string
+
+const (
+	// InterfaceLatencyProfileLowLatency trades host CPU time for a lower latency of the interface
+	InterfaceLatencyProfileLowLatency InterfaceLatencyProfile = "low-latency"
+)
+
+// InterfaceOffloads toggles the offloads of the host side tap device of an interface.
+// Offloads which are not set keep the defaults of the hypervisor.
+//
+// +k8s:openapi-gen=true
+type InterfaceOffloads struct {
+	// TCP segmentation offload.
+	// +optional
+	TSO *bool `json:"tso,omitempty"`
+	// Generic segmentation offload.
+	// +optional
+	GSO *bool `json:"gso,omitempty"`
+	// Generic receive offload.
+	// +optional
+	GRO *bool `json:"gro,omitempty"`
+	// TX checksum offload of the pod side bridge serving the interface.
+	// When not set, it is only disabled if the DHCP server of KubeVirt answers
+	// the guest on the bridge, since some guest DHCP clients drop its replies
+	// otherwise.
+	// +optional
+	TXChecksum *bool `json:"txChecksum,omitempty"`
+}
+
+// InterfaceRole tags an interface with the purpose it serves in the guest.
+//
+// +k8s:openapi-gen=true
+type InterfaceRole string
+
+const (
+	// InterfaceRoleManagement marks the interface used to manage the guest
+	InterfaceRoleManagement InterfaceRole = "management"
+	// InterfaceRoleStorage marks the interface used to reach storage
+	InterfaceRoleStorage InterfaceRole = "storage"
+	// InterfaceRoleWorkload marks the interface serving the workload of the guest
+	InterfaceRoleWorkload InterfaceRole = "workload"
+)
+
 // Extra DHCP options to use in the interface.
 //
 // +k8s:openapi-gen=true
@@ -1086,6 +1419,15 @@ type DHCPOptions struct {
 	// If specified will pass option 66 to interface's DHCP server
 	// +optional
 	TFTPServerName string `json:"tftpServerName,omitempty"`
+	// If specified will set the next server address (siaddr) of the replies of
+	// interface's DHCP server, the address of the boot server. Must be an IPv4 address.
+	// +optional
+	NextServer string `json:"nextServer,omitempty"`
+	// If set, virt-launcher relays the TFTP requests of the guest, which it sends to
+	// the gateway of a masquerade interface, to the next server or the TFTP server.
+	// The DHCP server passes the gateway as next server and TFTP server then.
+	// +optional
+	TFTPProxy bool `json:"tftpProxy,omitempty"`
 	// If specified will pass the configured NTP server to the VM via DHCP option 042.
 	// +optional
 	NTPServers []string `json:"ntpServers,omitempty"`
@@ -1120,7 +1462,26 @@ type InterfaceBindingMethod struct {
 
 //
 // +k8s:openapi-gen=true
-type InterfaceBridge struct{}
+type InterfaceBridge struct {
+	// GuestAddress declares the address the guest uses when the pod network does not provide one.
+	// The bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.
+	// +optional
+	GuestAddress *InterfaceBridgeGuestAddress `json:"guestAddress,omitempty"`
+}
+
+// InterfaceBridgeGuestAddress is the static address of a guest connected to a bridge without IPAM.
+//
+// +k8s:openapi-gen=true
+type InterfaceBridgeGuestAddress struct {
+	// IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.
+	IP string `json:"ip"`
+	// Gateway offered to the guest in the static DHCP lease.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+	// ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.
+	// +optional
+	ServeDHCP bool `json:"serveDHCP,omitempty"`
+}
 
 //
 // +k8s:openapi-gen=true
@@ -1128,7 +1489,33 @@ type InterfaceSlirp struct{}
 
 //
 // +k8s:openapi-gen=true
-type InterfaceMasquerade struct{}
+type InterfaceMasquerade struct {
+	// Hairpin selects which connections originating in the pod itself are forwarded to the VM.
+	// "loopback" forwards connections to the loopback address on the forwarded ports, "none" leaves
+	// all of them to processes in the pod and "full" forwards connections to any local address of
+	// the pod, including the VM connecting to its own service IP. Defaults to loopback.
+	// +optional
+	Hairpin MasqueradeHairpinMode `json:"hairpin,omitempty"`
+	// ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU,
+	// so that they do not stall when the MTU of the pod is lower than the one the guest assumes.
+	// Defaults to false.
+	// +optional
+	ClampMSS *bool `json:"clampMSS,omitempty"`
+}
+
+// MasqueradeHairpinMode selects how connections from the pod itself reach a VM behind masquerade.
+//
+// +k8s:openapi-gen=true
+type MasqueradeHairpinMode string
+
+const (
+	// MasqueradeHairpinLoopback forwards connections to the loopback address of the pod
+	MasqueradeHairpinLoopback MasqueradeHairpinMode = "loopback"
+	// MasqueradeHairpinNone does not forward any connections originating in the pod
+	MasqueradeHairpinNone MasqueradeHairpinMode = "none"
+	// MasqueradeHairpinFull forwards connections to any local address of the pod
+	MasqueradeHairpinFull MasqueradeHairpinMode = "full"
+)
 
 //
 // +k8s:openapi-gen=true
@@ -1316,6 +1703,10 @@ type PodNetwork struct {
 	// CIDR for vm network.
 	// Default 10.0.2.0/24 if not specified.
 	VMNetworkCIDR string `json:"vmNetworkCIDR,omitempty"`
+	// IPv6 CIDR for the vm network of masquerade interfaces.
+	// Default fd10:0:2::/120 if not specified.
+	// +optional
+	VMIPv6NetworkCIDR string `json:"vmIPv6NetworkCIDR,omitempty"`
 }
 
 // Rng represents the random device passed from host
@@ -1330,7 +1721,9 @@ type Rng struct {
 type MultusNetwork struct {
 	// References to a NetworkAttachmentDefinition CRD object. Format:
 	// <networkName>, <namespace>/<networkName>. If namespace is not
-	// specified, VMI namespace is assumed.
+	// specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition
+	// of another namespace requires the ServiceAccount of the VMI to be granted
+	// the use verb on it in that namespace, for example through a RoleBinding.
 	NetworkName string `json:"networkName"`
 
 	// Select the default network and add it to the
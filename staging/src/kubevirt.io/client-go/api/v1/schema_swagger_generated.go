@@ -81,6 +81,7 @@ func (DomainSpec) SwaggerDoc() map[string]string {
 		"devices":         "Devices allows adding disks, network interfaces, and others",
 		"ioThreadsPolicy": "Controls whether or not disks will share IOThreads.\nOmitting IOThreadsPolicy disables use of IOThreads.\nOne of: shared, auto\n+optional",
 		"chassis":         "Chassis specifies the chassis info passed to the domain.\n+optional",
+		"allowEmulation":  "AllowEmulation overrides whether the VMI may fall back to software emulation\non nodes without /dev/kvm, within the emulation policy of the cluster.\n+optional",
 	}
 }
 
@@ -112,6 +113,14 @@ func (EFI) SwaggerDoc() map[string]string {
 	}
 }
 
+func (BootMenu) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "BootMenu lets the user pick a boot entry on the serial or graphical\nconsole while the VMI boots.\n\n+k8s:openapi-gen=true",
+		"enabled": "If set, the firmware offers a boot menu.\nDefaults to true\n+optional",
+		"timeout": "Time in milliseconds the boot menu waits for a selection before booting\nthe default entry. Must be at most 65535.\n+optional",
+	}
+}
+
 func (ResourceRequirements) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                        "+k8s:openapi-gen=true",
@@ -131,6 +140,22 @@ func (CPU) SwaggerDoc() map[string]string {
 		"features":              "Features specifies the CPU features list inside the VMI.\n+optional",
 		"dedicatedCpuPlacement": "DedicatedCPUPlacement requests the scheduler to place the VirtualMachineInstance on a node\nwith enough dedicated pCPUs and pin the vCPUs to it.\n+optional",
 		"isolateEmulatorThread": "IsolateEmulatorThread requests one more dedicated pCPU to be allocated for the VMI to place\nthe emulator thread on it.\n+optional",
+		"nestedVirtualization":  "NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest\nand requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.\n+optional",
+		"numa":                  "NUMA allows specifying settings for the guest NUMA topology\n+optional",
+		"sideChannelIsolation":  "SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not\nvulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and\nflushes the L1 data cache on every VM entry where L1TF applies.\n+optional",
+	}
+}
+
+func (NUMAGuestMappingPassthrough) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "NUMAGuestMappingPassthrough requests a guest NUMA topology mirroring the host NUMA nodes of the pinned pCPUs.\n\n+k8s:openapi-gen=true",
+	}
+}
+
+func (NUMA) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "NUMA allows specifying settings for the guest NUMA topology.\n\n+k8s:openapi-gen=true",
+		"guestMappingPassthrough": "GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod.\nThe created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes.\nRequires DedicatedCPUPlacement and Hugepages.\n+optional",
 	}
 }
 
@@ -166,40 +191,48 @@ func (Machine) SwaggerDoc() map[string]string {
 
 func (Firmware) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":           "+k8s:openapi-gen=true",
-		"uuid":       "UUID reported by the vmi bios.\nDefaults to a random generated uid.",
-		"bootloader": "Settings to control the bootloader that is used.\n+optional",
-		"serial":     "The system-serial-number in SMBIOS",
+		"":               "+k8s:openapi-gen=true",
+		"uuid":           "UUID reported by the vmi bios.\nDefaults to a random generated uid.",
+		"bootloader":     "Settings to control the bootloader that is used.\n+optional",
+		"serial":         "The system-serial-number in SMBIOS",
+		"bootMenu":       "Settings to control the interactive boot menu of the firmware.\n+optional",
+		"identityPolicy": "IdentityPolicy controls how the UUID and the serial are generated when they are not set.\nKeep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores.\nRegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that\nclones get new ones, while restores keep them. Defaults to Keep.\n+optional",
 	}
 }
 
 func (Devices) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                           "+k8s:openapi-gen=true",
-		"disableHotplug":             "DisableHotplug disabled the ability to hotplug disks.",
-		"disks":                      "Disks describes disks, cdroms, floppy and luns which are connected to the vmi.",
-		"watchdog":                   "Watchdog describes a watchdog device which can be added to the vmi.",
-		"interfaces":                 "Interfaces describe network interfaces which are added to the vmi.",
-		"inputs":                     "Inputs describe input devices",
-		"autoattachPodInterface":     "Whether to attach a pod network interface. Defaults to true.",
-		"autoattachGraphicsDevice":   "Whether to attach the default graphics device or not.\nVNC will not be available if set to false. Defaults to true.",
-		"autoattachSerialConsole":    "Whether to attach the default serial console or not.\nSerial console access will not be available if set to false. Defaults to true.",
-		"autoattachMemBalloon":       "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
-		"rng":                        "Whether to have random number generator from host\n+optional",
-		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices\n+optional",
-		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
-		"gpus":                       "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
-		"filesystems":                "Filesystems describes filesystem which is connected to the vmi.\n+optional\n+listType=atomic",
-		"hostDevices":                "Whether to attach a host device to the vmi.\n+optional\n+listType=atomic",
+		"":                              "+k8s:openapi-gen=true",
+		"disableHotplug":                "DisableHotplug disabled the ability to hotplug disks.",
+		"disks":                         "Disks describes disks, cdroms, floppy and luns which are connected to the vmi.",
+		"watchdog":                      "Watchdog describes a watchdog device which can be added to the vmi.",
+		"interfaces":                    "Interfaces describe network interfaces which are added to the vmi.",
+		"inputs":                        "Inputs describe input devices",
+		"usbControllerModel":            "Model of the USB controller, which is attached when an input device is on the usb bus.\nSupported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.\n+optional",
+		"autoattachPodInterface":        "Whether to attach a pod network interface. Defaults to true.",
+		"autoattachGraphicsDevice":      "Whether to attach the default graphics device or not.\nVNC will not be available if set to false. Defaults to true.",
+		"autoattachInputDevice":         "Whether to attach a tablet when the graphics device is attached and no inputs are specified.\nThe tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.\n+optional",
+		"autoattachSerialConsole":       "Whether to attach the default serial console or not.\nSerial console access will not be available if set to false. Defaults to true.",
+		"autoattachMemBalloon":          "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
+		"autoattachVirtioWinDriverDisk": "Whether to attach the virtio-win driver disk as a CD-ROM, which holds the\nvirtio drivers Windows needs to install on virtio disks and to use virtio\nnetwork interfaces. The image of the disk is configured in the KubeVirt CR.\nDefaults to false.\n+optional",
+		"rng":                           "Whether to have random number generator from host\n+optional",
+		"blockMultiQueue":               "Whether or not to enable virtio multi-queue for block devices\n+optional",
+		"networkInterfaceMultiqueue":    "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
+		"networkInterfaceQueues":        "Number of queues of the virtio interfaces with networkInterfaceMultiqueue.\nDefaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.\n+optional",
+		"gpus":                          "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
+		"filesystems":                   "Filesystems describes filesystem which is connected to the vmi.\n+optional\n+listType=atomic",
+		"hostDevices":                   "Whether to attach a host device to the vmi.\n+optional\n+listType=atomic",
+		"channels":                      "Channels are additional virtio-serial channels for custom communication between the host and the guest.\n+optional\n+listType=atomic",
 	}
 }
 
 func (Input) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":     "+k8s:openapi-gen=true",
-		"bus":  "Bus indicates the bus of input device to emulate.\nSupported values: virtio, usb.",
-		"type": "Type indicated the type of input device.\nSupported values: tablet.",
-		"name": "Name is the device name",
+		"":      "+k8s:openapi-gen=true",
+		"bus":   "Bus indicates the bus of input device to emulate.\nSupported values: virtio, usb.",
+		"type":  "Type indicated the type of input device.\nSupported values: tablet, keyboard.",
+		"name":  "Name is the device name",
+		"model": "Model indicates the model of a virtio input device.\nSupported values: virtio, virtio-transitional, virtio-non-transitional.\n+optional",
 	}
 }
 
@@ -231,16 +264,24 @@ func (HostDevice) SwaggerDoc() map[string]string {
 	}
 }
 
+func (Channel) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "+k8s:openapi-gen=true",
+		"name": "Name is the name of the virtio-serial port, like org.example.agent.0.\nThe guest finds the channel at /dev/virtio-ports/<name>.",
+	}
+}
+
 func (Disk) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                  "+k8s:openapi-gen=true",
 		"name":              "Name is the device name",
 		"bootOrder":         "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach disk or interface that has a boot order must have a unique value.\nDisks without a boot order are not tried if a disk with a boot order exists.\n+optional",
 		"serial":            "Serial provides the ability to specify a serial number for the disk device.\n+optional",
+		"wwn":               "WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits.\nOnly supported for disks on the sata and scsi bus.\n+optional",
 		"dedicatedIOThread": "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
 		"cache":             "Cache specifies which kvm disk cache mode should be used.\n+optional",
 		"io":                "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
-		"tag":               "If specified, disk address and its tag will be provided to the guest via config drive metadata\n+optional",
+		"tag":               "If specified, disk address and its tag will be provided to the guest via config drive metadata.\nDisks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>\n+optional",
 	}
 }
 
@@ -290,8 +331,16 @@ func (CDRomTarget) SwaggerDoc() map[string]string {
 
 func (Volume) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":     "Volume represents a named volume in a vmi.\n\n+k8s:openapi-gen=true",
-		"name": "Volume's name.\nMust be a DNS_LABEL and unique within the vmi.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		"":           "Volume represents a named volume in a vmi.\n\n+k8s:openapi-gen=true",
+		"name":       "Volume's name.\nMust be a DNS_LABEL and unique within the vmi.\nMore info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		"encryption": "Encryption layers LUKS encryption on top of the volume, which is handled by qemu.\nOnly PersistentVolumeClaim and DataVolume volumes can be encrypted.\n+optional",
+	}
+}
+
+func (VolumeEncryption) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VolumeEncryption specifies how a volume is encrypted.\n\n+k8s:openapi-gen=true",
+		"secretRef": "SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the \"key\" key.\nKeys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.",
 	}
 }
 
@@ -310,6 +359,15 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"secret":                "SecretVolumeSource represents a reference to a secret data in the same namespace.\nMore info: https://kubernetes.io/docs/concepts/configuration/secret/\n+optional",
 		"downwardAPI":           "DownwardAPI represents downward API about the pod that should populate this volume\n+optional",
 		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nThere can only be one volume of this type!\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/\n+optional",
+		"vhostUserBlk":          "VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk.\nThe VhostUserBlk feature gate has to be enabled.\n+optional",
+	}
+}
+
+func (VhostUserBlkSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VhostUserBlkSource references the vhost-user-blk socket of a storage backend like SPDK,\nwhich qemu connects the disk to instead of opening a disk image.\n\n+k8s:openapi-gen=true",
+		"claimName": "ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume\ncontains the socket, like the volumes of an SPDK CSI driver",
+		"socket":    "Socket is the path of the vhost-user-blk socket in the volume",
 	}
 }
 
@@ -323,8 +381,9 @@ func (HotplugVolumeSource) SwaggerDoc() map[string]string {
 
 func (DataVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":     "+k8s:openapi-gen=true",
-		"name": "Name represents the name of the DataVolume in the same namespace",
+		"":         "+k8s:openapi-gen=true",
+		"name":     "Name represents the name of the DataVolume in the same namespace",
+		"checksum": "Checksum is the expected digest of the imported disk, in the form sha256:<hex>.\nThe disk is verified once after the import, VMIs using it are not started before it matched.\n+optional",
 	}
 }
 
@@ -332,6 +391,7 @@ func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                      "+k8s:openapi-gen=true",
 		"persistentVolumeClaim": "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nDirectly attached to the vmi via qemu.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n+optional",
+		"ephemeralImage":        "EphemeralImage configures the writable image the VMI runs on.\n+optional",
 	}
 }
 
@@ -349,6 +409,17 @@ func (ContainerDiskSource) SwaggerDoc() map[string]string {
 		"imagePullSecret": "ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.",
 		"path":            "Path defines the path to disk file in the container",
 		"imagePullPolicy": "Image pull policy.\nOne of Always, Never, IfNotPresent.\nDefaults to Always if :latest tag is specified, or IfNotPresent otherwise.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/containers/images#updating-images\n+optional",
+		"checksum":        "Checksum is the expected digest of the disk in the image, in the form sha256:<hex>.\nThe disk is verified before the VMI is started, which fails on a mismatch.\n+optional",
+		"ephemeralImage":  "EphemeralImage configures the writable image the VMI runs on.\n+optional",
+	}
+}
+
+func (EphemeralImage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "EphemeralImage configures the writable image which is created from a\ncontainerDisk or ephemeral volume when the VMI starts.\n\n+k8s:openapi-gen=true",
+		"format":        "Format of the writable image. qcow2 creates a copy-on-write overlay backed by\nthe source image, raw converts the source image into a raw image.\nDefaults to qcow2.\n+optional",
+		"preallocation": "Preallocation mode of a raw image.\nOne of off, falloc or full.\nDefaults to off.\n+optional",
+		"compressed":    "Compressed converts the source image into a compressed qcow2 image instead\nof creating an overlay. Only supported for the qcow2 format.\n+optional",
 	}
 }
 
@@ -516,15 +587,49 @@ func (I6300ESBWatchdog) SwaggerDoc() map[string]string {
 
 func (Interface) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":            "+k8s:openapi-gen=true",
-		"name":        "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
-		"model":       "Interface model.\nOne of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio.\nDefaults to virtio.",
-		"ports":       "List of ports to be forwarded to the virtual machine.",
-		"macAddress":  "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
-		"bootOrder":   "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
-		"pciAddress":  "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
-		"dhcpOptions": "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
-		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
+		"":                "+k8s:openapi-gen=true",
+		"name":            "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
+		"model":           "Interface model.\nOne of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio.\nDefaults to virtio.",
+		"ports":           "List of ports to be forwarded to the virtual machine.",
+		"macAddress":      "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
+		"bootOrder":       "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
+		"pciAddress":      "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
+		"dhcpOptions":     "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
+		"tag":             "If specified, the virtual network interface address and its tag will be provided to the guest via config drive.\nThe tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>\n+optional",
+		"roles":           "Roles the interface fulfills in the guest: management, storage or workload.\nThey are published to the guest as SMBIOS OEM strings of the form\nio.kubevirt.interface.role:<role>=<MAC address>.\n+optional",
+		"rxQueueSize":     "Size of the virtio RX queue ring of the interface.\nMust be a power of 2 between 256 and 1024. Only supported on virtio interfaces.\n+optional",
+		"txQueueSize":     "Size of the virtio TX queue ring of the interface.\nMust be a power of 2 between 256 and 1024. Only supported on virtio interfaces.\n+optional",
+		"offloads":        "Offloads of the host side tap device of the interface.\nOnly supported on bridge and masquerade interfaces.\n+optional",
+		"packedRing":      "Use packed virtqueues instead of split virtqueues for the interface,\nwhich reduces the latency of the guest network.\nOnly supported on virtio interfaces.\n+optional",
+		"vhostZeroCopyTX": "Request zero copy transmission by vhost-net for the interface.\nThe VMI is only scheduled on nodes where the vhost_net kernel module\nhas zero copy transmission enabled.\nOnly supported on virtio interfaces which are not bound with slirp.\n+optional",
+		"mirror":          "Copy the traffic of the interface to a monitoring network.\nOnly supported on bridge and masquerade interfaces.\n+optional",
+		"latencyProfile":  "Latency profile of the host datapath of the interface. With low-latency, vhost-net busy polls\nthe tap device, GRO is disabled on the tap device and the pod interface, and the vhost-net\nthreads are pinned to the pCPUs of the vCPUs of VMIs with dedicated CPUs.\nOnly supported on virtio bridge and masquerade interfaces.\n+optional",
+		"rss":             "Receive side scaling of the interface. The device steers the received packets\nto the queues by their hash, as configured by the guest, which spreads the load\nof the guest network over the vCPUs.\nOnly supported on virtio interfaces of VMIs with networkInterfaceMultiqueue.\n+optional",
+	}
+}
+
+func (InterfaceRSS) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "InterfaceRSS enables the receive side scaling of a multiqueue virtio interface.\n\n+k8s:openapi-gen=true",
+		"hashReport": "Report the hash of the received packets to the guest, which saves the guest\nfrom calculating it again, e.g. for receive flow steering.\n+optional",
+	}
+}
+
+func (InterfaceMirror) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "InterfaceMirror copies the traffic of the tap device of an interface to the\npod interface of a monitoring network.\n\n+k8s:openapi-gen=true",
+		"network":   "Name of the Multus network which receives the copied traffic.\nIt must not be the network of an interface.",
+		"direction": "Traffic to copy, as seen from the guest: rx, tx or both.\nDefaults to both.\n+optional",
+	}
+}
+
+func (InterfaceOffloads) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "InterfaceOffloads toggles the offloads of the host side tap device of an interface.\nOffloads which are not set keep the defaults of the hypervisor.\n\n+k8s:openapi-gen=true",
+		"tso":        "TCP segmentation offload.\n+optional",
+		"gso":        "Generic segmentation offload.\n+optional",
+		"gro":        "Generic receive offload.\n+optional",
+		"txChecksum": "TX checksum offload of the pod side bridge serving the interface.\nWhen not set, it is only disabled if the DHCP server of KubeVirt answers\nthe guest on the bridge, since some guest DHCP clients drop its replies\notherwise.\n+optional",
 	}
 }
 
@@ -533,6 +638,8 @@ func (DHCPOptions) SwaggerDoc() map[string]string {
 		"":               "Extra DHCP options to use in the interface.\n\n+k8s:openapi-gen=true",
 		"bootFileName":   "If specified will pass option 67 to interface's DHCP server\n+optional",
 		"tftpServerName": "If specified will pass option 66 to interface's DHCP server\n+optional",
+		"nextServer":     "If specified will set the next server address (siaddr) of the replies of\ninterface's DHCP server, the address of the boot server. Must be an IPv4 address.\n+optional",
+		"tftpProxy":      "If set, virt-launcher relays the TFTP requests of the guest, which it sends to\nthe gateway of a masquerade interface, to the next server or the TFTP server.\nThe DHCP server passes the gateway as next server and TFTP server then.\n+optional",
 		"ntpServers":     "If specified will pass the configured NTP server to the VM via DHCP option 042.\n+optional",
 		"privateOptions": "If specified will pass extra DHCP options for private use, range: 224-254\n+optional",
 	}
@@ -554,7 +661,17 @@ func (InterfaceBindingMethod) SwaggerDoc() map[string]string {
 
 func (InterfaceBridge) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "+k8s:openapi-gen=true",
+		"":             "+k8s:openapi-gen=true",
+		"guestAddress": "GuestAddress declares the address the guest uses when the pod network does not provide one.\nThe bridge forwarding and neighbor tables are seeded with it and the MAC address of the interface.\n+optional",
+	}
+}
+
+func (InterfaceBridgeGuestAddress) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "InterfaceBridgeGuestAddress is the static address of a guest connected to a bridge without IPAM.\n\n+k8s:openapi-gen=true",
+		"ip":        "IP address of the guest in CIDR notation, e.g. 192.168.1.10/24.",
+		"gateway":   "Gateway offered to the guest in the static DHCP lease.\n+optional",
+		"serveDHCP": "ServeDHCP hands out the IP address to the guest in a static DHCP lease. Requires an IPv4 address.\n+optional",
 	}
 }
 
@@ -566,7 +683,9 @@ func (InterfaceSlirp) SwaggerDoc() map[string]string {
 
 func (InterfaceMasquerade) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "+k8s:openapi-gen=true",
+		"":         "+k8s:openapi-gen=true",
+		"hairpin":  "Hairpin selects which connections originating in the pod itself are forwarded to the VM.\n\"loopback\" forwards connections to the loopback address on the forwarded ports, \"none\" leaves\nall of them to processes in the pod and \"full\" forwards connections to any local address of\nthe pod, including the VM connecting to its own service IP. Defaults to loopback.\n+optional",
+		"clampMSS": "ClampMSS clamps the maximum segment size of the TCP connections of the VM to the path MTU,\nso that they do not stall when the MTU of the pod is lower than the one the guest assumes.\nDefaults to false.\n+optional",
 	}
 }
 
@@ -692,8 +811,9 @@ func (NetworkSource) SwaggerDoc() map[string]string {
 
 func (PodNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "Represents the stock pod network interface.\n\n+k8s:openapi-gen=true",
-		"vmNetworkCIDR": "CIDR for vm network.\nDefault 10.0.2.0/24 if not specified.",
+		"":                  "Represents the stock pod network interface.\n\n+k8s:openapi-gen=true",
+		"vmNetworkCIDR":     "CIDR for vm network.\nDefault 10.0.2.0/24 if not specified.",
+		"vmIPv6NetworkCIDR": "IPv6 CIDR for the vm network of masquerade interfaces.\nDefault fd10:0:2::/120 if not specified.\n+optional",
 	}
 }
 
@@ -706,7 +826,7 @@ func (Rng) SwaggerDoc() map[string]string {
 func (MultusNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "Represents the multus cni network.\n\n+k8s:openapi-gen=true",
-		"networkName": "References to a NetworkAttachmentDefinition CRD object. Format:\n<networkName>, <namespace>/<networkName>. If namespace is not\nspecified, VMI namespace is assumed.",
+		"networkName": "References to a NetworkAttachmentDefinition CRD object. Format:\n<networkName>, <namespace>/<networkName>. If namespace is not\nspecified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition\nof another namespace requires the ServiceAccount of the VMI to be granted\nthe use verb on it in that namespace, for example through a RoleBinding.",
 		"default":     "Select the default network and add it to the\nmultus-cni.io/default-network annotation.",
 	}
 }
@@ -77,6 +77,18 @@ type VirtualMachineInstanceList struct {
 // +k8s:openapi-gen=true
 type EvictionStrategy string
 
+// QoSClass is a tier of service which virt-handler maps to blkio weights and
+// tc classes on the node.
+//
+// +k8s:openapi-gen=true
+type QoSClass string
+
+// GuestOS is the operating system of the guest, which the devices, the clock
+// and the inputs of a vmi are defaulted for.
+//
+// +k8s:openapi-gen=true
+type GuestOS string
+
 // VirtualMachineInstanceSpec is a description of a VirtualMachineInstance.
 //
 // +k8s:openapi-gen=true
@@ -154,8 +166,28 @@ type VirtualMachineInstanceSpec struct {
 	// +listType=atomic
 	// +optional
 	AccessCredentials []AccessCredential `json:"accessCredentials,omitempty"`
+	// QoSClass selects the tier of disk and network service the vmi gets on the node.
+	// Valid values are the QoS classes of the KubeVirt configuration, "gold", "silver" and "bronze"
+	// by default. No QoS is applied if not set.
+	// Unrelated to the pod QoS class reported in the status.
+	// +optional
+	QoSClass QoSClass `json:"qosClass,omitempty"`
+	// MetadataService serves the metadata and the user-data of the vmi and the token of its service account
+	// to the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.
+	// +optional
+	MetadataService *MetadataService `json:"metadataService,omitempty"`
+	// GuestOS declares the operating system of the guest, e.g. "windows2k19" or "rhel8".
+	// The disk bus, the interface model, the clock and the inputs which are neither set on
+	// the vmi nor by a preset are defaulted to what suits the guest OS.
+	// +optional
+	GuestOS GuestOS `json:"guestOS,omitempty"`
 }
 
+// MetadataService enables the metadata service of the guest, which is served by virt-launcher.
+//
+// +k8s:openapi-gen=true
+type MetadataService struct{}
+
 // VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual
 // state of a system.
 //
@@ -197,6 +229,116 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	// +listType=atomic
 	VolumeStatus []VolumeStatus `json:"volumeStatus,omitempty"`
+
+	// StartupTimestamps records when the VirtualMachineInstance reached the milestones of its startup
+	// +optional
+	StartupTimestamps *VirtualMachineInstanceStartupTimestamps `json:"startupTimestamps,omitempty"`
+
+	// VMNetworkCIDR is the internal subnet of the masquerade interface allocated from the masquerade subnet pool of the cluster.
+	// It is meant to be used by KubeVirt core components only and can't be set or modified by users.
+	// +optional
+	VMNetworkCIDR string `json:"vmNetworkCIDR,omitempty"`
+
+	// Usage holds the resource usage counters of the VirtualMachineInstance, which are reported by virt-handler
+	// when usage accounting is enabled.
+	// +optional
+	Usage *VirtualMachineInstanceUsage `json:"usage,omitempty"`
+
+	// GuestTimeSync reports the last synchronization of the guest clock, which is set through the guest agent
+	// after the VirtualMachineInstance was unpaused, migrated or resumed from hibernation.
+	// +optional
+	GuestTimeSync *VirtualMachineInstanceGuestTimeSync `json:"guestTimeSync,omitempty"`
+
+	// FSFreezeStatus is "frozen" while the guest file systems are frozen through the guest agent,
+	// and empty otherwise.
+	// +optional
+	FSFreezeStatus string `json:"fsFreezeStatus,omitempty"`
+
+	// ContainerDiskStatuses reports the progress of the preparation of the containerDisks on the node,
+	// before the VirtualMachineInstance is started or migrated to the node.
+	// +optional
+	// +listType=atomic
+	ContainerDiskStatuses []ContainerDiskStatus `json:"containerDiskStatuses,omitempty"`
+}
+
+// FSFrozen is the FSFreezeStatus of VirtualMachineInstances and VirtualMachines whose guest file systems are frozen
+const FSFrozen = "frozen"
+
+// VirtualMachineInstanceGuestTimeSync reports the last synchronization of the guest clock with the clock of the node.
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestTimeSync struct {
+	// Synchronized indicates whether the guest clock was set
+	Synchronized bool `json:"synchronized"`
+	// LastSyncTime is the time of the last attempt to set the guest clock
+	// +optional
+	// +nullable
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// DriftMilliseconds is the offset of the guest clock from the clock of the node before it was set.
+	// It is positive if the guest clock was ahead, and only reported if the guest agent returned the guest time.
+	// +optional
+	DriftMilliseconds *int64 `json:"driftMilliseconds,omitempty"`
+	// Message describes why the guest clock could not be set
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ContainerDiskStatus reports the preparation of a containerDisk, which is mounted into the virt-launcher pod
+// and verified by virt-handler.
+// +k8s:openapi-gen=true
+type ContainerDiskStatus struct {
+	// Name is the name of the volume
+	Name string `json:"name"`
+	// Phase is the phase of the preparation
+	Phase ContainerDiskPhase `json:"phase,omitempty"`
+	// Message describes why the preparation failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ContainerDiskPhase is the phase of the preparation of a containerDisk.
+// +k8s:openapi-gen=true
+type ContainerDiskPhase string
+
+const (
+	// ContainerDiskPending means the containerDisk waits for a free preparation slot of the node
+	ContainerDiskPending ContainerDiskPhase = "Pending"
+	// ContainerDiskPreparing means virt-handler mounts and verifies the containerDisk
+	ContainerDiskPreparing ContainerDiskPhase = "Preparing"
+	// ContainerDiskReady means the containerDisk is mounted and verified
+	ContainerDiskReady ContainerDiskPhase = "Ready"
+	// ContainerDiskFailed means the preparation failed, it is retried unless the disk does not match its checksum
+	ContainerDiskFailed ContainerDiskPhase = "Failed"
+)
+
+// VirtualMachineInstanceUsage holds the resource usage counters of a VirtualMachineInstance.
+// The counters start at zero whenever a domain is started, also on migration targets.
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceUsage struct {
+	// NetworkReceiveBytes is the number of bytes received on all interfaces
+	NetworkReceiveBytes int64 `json:"networkReceiveBytes"`
+	// NetworkTransmitBytes is the number of bytes transmitted on all interfaces
+	NetworkTransmitBytes int64 `json:"networkTransmitBytes"`
+}
+
+// VirtualMachineInstanceStartupTimestamps records when the milestones of the VirtualMachineInstance startup were reached.
+// Together with the creation timestamp they allow to see where the startup time is spent.
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceStartupTimestamps struct {
+	// PodScheduled is the time the virt-launcher pod was scheduled to a node
+	// +optional
+	PodScheduled *metav1.Time `json:"podScheduled,omitempty"`
+	// NetworkConfigured is the time virt-handler finished to configure the pod networking
+	// +optional
+	NetworkConfigured *metav1.Time `json:"networkConfigured,omitempty"`
+	// DomainDefined is the time the domain was first reported by virt-launcher
+	// +optional
+	DomainDefined *metav1.Time `json:"domainDefined,omitempty"`
+	// DomainRunning is the time the domain was first reported as running
+	// +optional
+	DomainRunning *metav1.Time `json:"domainRunning,omitempty"`
+	// GuestAgentConnected is the time the guest agent first connected
+	// +optional
+	GuestAgentConnected *metav1.Time `json:"guestAgentConnected,omitempty"`
 }
 
 // VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
@@ -317,6 +459,11 @@ const (
 	// If there happens any error while trying to synchronize the VirtualMachineInstance with the Domain,
 	// this is reported as false.
 	VirtualMachineInstanceSynchronized VirtualMachineInstanceConditionType = "Synchronized"
+	// Reason means that virt-launcher could not finish plugging the interfaces of the VMI, the sync is retried
+	VirtualMachineInstanceReasonNetworkSetupFailed = "NetworkSetupFailed"
+	// Reason means that virt-launcher could not restore the saved memory of a hibernated VMI. The memory
+	// is kept and the VMI does not start, the sync is retried
+	VirtualMachineInstanceReasonHibernationRestoreFailed = "HibernationRestoreFailed"
 
 	// If the VMI was paused by the user, this is reported as true.
 	VirtualMachineInstancePaused VirtualMachineInstanceConditionType = "Paused"
@@ -338,11 +485,40 @@ const (
 	VirtualMachineInstanceReasonInterfaceNotMigratable = "InterfaceNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's network interfaces collection
 	VirtualMachineInstanceReasonHotplugNotMigratable = "HotplugNotLiveMigratable"
+
+	// Reflects whether the NetworkAttachmentDefinitions referenced by the multus networks of the VMI exist and are valid
+	VirtualMachineInstanceNetworkAttachmentsReady VirtualMachineInstanceConditionType = "NetworkAttachmentsReady"
+	// Reason means that a NetworkAttachmentDefinition referenced by the VMI does not exist
+	VirtualMachineInstanceReasonNetworkAttachmentNotFound = "NetworkAttachmentDefinitionNotFound"
+	// Reason means that a NetworkAttachmentDefinition referenced by the VMI has an invalid CNI configuration
+	VirtualMachineInstanceReasonNetworkAttachmentInvalid = "InvalidNetworkAttachmentDefinition"
+
+	// Reflects whether the disks of the VMI match the checksums they are expected to have
+	VirtualMachineInstanceImagesVerified VirtualMachineInstanceConditionType = "ImagesVerified"
+	// Reason means that the digest of a disk differs from its expected checksum
+	VirtualMachineInstanceReasonChecksumMismatch = "ChecksumMismatch"
+
+	// Reflects whether the memory of the VMI was saved to its hibernation claim
+	VirtualMachineInstanceHibernated VirtualMachineInstanceConditionType = "Hibernated"
+	// Reason means that the memory of the VMI could not be saved
+	VirtualMachineInstanceReasonHibernationFailed = "HibernationFailed"
+
+	// Reflects whether the VMI runs with software emulation instead of KVM
+	VirtualMachineInstanceEmulated VirtualMachineInstanceConditionType = "Emulated"
+	// Reason means that /dev/kvm was not available and the VMI fell back to software emulation
+	VirtualMachineInstanceReasonSoftwareEmulation = "SoftwareEmulation"
+
+	// Reflects whether the node of a VMI requesting side-channel isolation mitigates the CPU vulnerabilities
+	VirtualMachineInstanceSideChannelIsolated VirtualMachineInstanceConditionType = "SideChannelIsolated"
+	// Reason means that the node of the VMI lost its side-channel isolation after the VMI was scheduled
+	VirtualMachineInstanceReasonSideChannelExposed = "SideChannelExposed"
 )
 
 const (
 	// PodTerminatingReason indicates on the PodReady condition on the VMI if the underlying pod is terminating
 	PodTerminatingReason = "PodTerminating"
+	// PausedReason indicates on the PodReady condition on the VMI if the VMI is paused
+	PausedReason = "Paused"
 )
 
 // +k8s:openapi-gen=true
@@ -422,6 +598,16 @@ type VirtualMachineInstanceNetworkInterface struct {
 	IPs []string `json:"ipAddresses,omitempty"`
 	// The interface name inside the Virtual Machine
 	InterfaceName string `json:"interfaceName,omitempty"`
+	// Binding method connecting the interface to the pod network: bridge, masquerade, slirp, sriov or macvtap
+	Binding string `json:"binding,omitempty"`
+	// Name of the tap device backing the interface in the virt-launcher pod
+	TapDevice string `json:"tapDevice,omitempty"`
+	// Name of the bridge connecting the tap device to the pod network
+	BridgeDevice string `json:"bridgeDevice,omitempty"`
+	// Effective number of queues of a multiqueue interface, after capping it at the maximum of the tap device
+	QueueCount int32 `json:"queueCount,omitempty"`
+	// Whether TX checksum offload is enabled on the bridge device, unset for interfaces without one
+	BridgeTXChecksumOffload *bool `json:"bridgeTXChecksumOffload,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -476,6 +662,53 @@ type VirtualMachineInstanceMigrationState struct {
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
 	// Lets us know if the vmi is currently running pre or post copy migration
 	Mode MigrationMode `json:"mode,omitempty"`
+	// The network state of the interfaces on the source node, which the target node applies before
+	// the migration so that the guest keeps its MAC addresses. It is cleared once the migration completed
+	SourceNetworkState []MigrationInterfaceNetworkState `json:"sourceNetworkState,omitempty"`
+	// The network state of the interfaces on the target node, which the source node applies to
+	// the migrated domain so that it uses the devices of the target pod. It is cleared once the migration completed
+	TargetNetworkState []MigrationInterfaceNetworkState `json:"targetNetworkState,omitempty"`
+}
+
+// MigrationInterfaceNetworkState is the state of a pod network binding which has to be preserved across a migration.
+//
+// +k8s:openapi-gen=true
+type MigrationInterfaceNetworkState struct {
+	// Name of the interface
+	Name string `json:"name"`
+	// The MAC address the guest uses on the interface
+	// +optional
+	MAC string `json:"mac,omitempty"`
+	// The IPv4 address of the guest in CIDR notation
+	// +optional
+	IP string `json:"ip,omitempty"`
+	// The IPv6 address of the guest in CIDR notation
+	// +optional
+	IPv6 string `json:"ipv6,omitempty"`
+	// The device backing the interface in the virt-launcher pod, for macvtap bindings
+	// +optional
+	Device string `json:"device,omitempty"`
+	// The nat configuration of the interface, for masquerade bindings
+	// +optional
+	Masquerade *MigrationMasqueradeNetworkState `json:"masquerade,omitempty"`
+}
+
+// MigrationMasqueradeNetworkState is the nat configuration a masquerade binding was set up with on a node.
+//
+// +k8s:openapi-gen=true
+type MigrationMasqueradeNetworkState struct {
+	// The ports forwarded to the guest
+	// +optional
+	Ports []Port `json:"ports,omitempty"`
+	// The hairpin mode of the port forwarding rules
+	// +optional
+	Hairpin MasqueradeHairpinMode `json:"hairpin,omitempty"`
+	// Whether the maximum segment size of the TCP connections of the guest is clamped to the path MTU
+	// +optional
+	ClampMSS bool `json:"clampMSS,omitempty"`
+	// The nat rules of the interface in iptables notation
+	// +optional
+	NatRules []string `json:"natRules,omitempty"`
 }
 
 //
@@ -617,15 +850,88 @@ const (
 	VirtualMachineInstanceFinalizer          string = "foregroundDeleteVirtualMachine"
 	VirtualMachineInstanceMigrationFinalizer string = "kubevirt.io/migrationJobFinalize"
 	CPUManager                               string = "cpumanager"
+	// This label declares whether the vhost_net kernel module of a node has
+	// zero copy transmission enabled. Used on Node.
+	VhostNetZeroCopyTX string = "kubevirt.io/vhost-net-zerocopy-tx"
+	// This label declares whether the KVM module of a node allows nested
+	// virtualization. Used on Node.
+	NestedVirtualization string = "kubevirt.io/nested-virtualization"
+	// This label prefix declares the mitigation status of a CPU vulnerability
+	// reported by the kernel of a node, e.g.
+	// cpu-vulnerability.node.kubevirt.io/l1tf=mitigated. Used on Node.
+	CPUVulnerabilityLabel string = "cpu-vulnerability.node.kubevirt.io/"
+	// This label declares whether simultaneous multithreading is active on
+	// a node. Used on Node.
+	SMTActive string = "kubevirt.io/smt-active"
+	// This label declares when KVM flushes the L1 data cache on VM entry
+	// to mitigate L1TF. Used on Node.
+	L1DFlush string = "kubevirt.io/l1d-flush"
+	// This label declares whether a node mitigates all CPU vulnerabilities
+	// for the VMIs requesting side-channel isolation. Used on Node.
+	SideChannelIsolation string = "kubevirt.io/side-channel-isolation"
 	// This annotation is used to inject ignition data
 	// Used on VirtualMachineInstance.
 	IgnitionAnnotation           string = "kubevirt.io/ignitiondata"
 	PlacePCIDevicesOnRootComplex string = "kubevirt.io/placePCIDevicesOnRootComplex"
+	// This annotation holds the reason for accessing the console or VNC of a
+	// VirtualMachineInstance, it is recorded in the audit events of the sessions.
+	// Used on VirtualMachineInstance.
+	ConsoleAccessReasonAnnotation string = "kubevirt.io/console-access-reason"
+	// This annotation raises the log verbosity of the virt-launcher of a
+	// single VirtualMachineInstance above the one configured for the cluster.
+	// Used on VirtualMachineInstance.
+	LauncherLogVerbosityAnnotation string = "kubevirt.io/launcher-log-verbosity"
+	// This annotation holds the name of the PersistentVolumeClaim the memory
+	// of a hibernated VirtualMachineInstance is saved to. It is set by
+	// virt-controller for VirtualMachines with hibernation configured.
+	// Used on VirtualMachineInstance.
+	HibernationClaimAnnotation string = "kubevirt.io/hibernation-claim"
+	// This annotation asks virt-handler to save the memory of the
+	// VirtualMachineInstance to its hibernation claim and to stop it.
+	// Used on VirtualMachineInstance.
+	HibernationRequestedAnnotation string = "kubevirt.io/hibernation-requested"
+	// This label opts the VirtualMachines of a namespace in to the idle policy
+	// with "enabled", and a single VirtualMachine out of it with "disabled".
+	// Used on Namespace and VirtualMachine.
+	IdlePolicyLabel string = "kubevirt.io/idle-policy"
+	// This annotation holds the last time a console or VNC session of the
+	// VirtualMachineInstance was active, it is refreshed by virt-api while
+	// a session is open. Used on VirtualMachineInstance.
+	ConsoleActivityAnnotation string = "kubevirt.io/console-activity"
+	// This annotation overrides the maximum number of VMIs of the node
+	// density configuration for a single node. Used on Node.
+	MaxVMIsPerNodeAnnotation string = "kubevirt.io/max-vmis"
+	// This annotation overrides the memory overcommit budget of the node
+	// density configuration for a single node. Used on Node.
+	MemoryOvercommitBudgetAnnotation string = "kubevirt.io/memory-overcommit-budget"
+	// This annotation selects an alternative qemu build, shipped in the
+	// virt-launcher image or provided by a hook sidecar, as emulator of the
+	// VirtualMachineInstance. It requires the EmulatorSelection feature gate.
+	// Used on VirtualMachineInstance.
+	EmulatorAnnotation string = "kubevirt.io/emulator"
+	// This annotation tells how the PVCs of a StorageClass are cloned. With
+	// "csi-clone", DataVolume templates cloning a PVC of the same namespace
+	// and StorageClass are provisioned with the CSI driver by a PVC with the
+	// source PVC as dataSource, instead of a DataVolume cloned by CDI.
+	// Used on StorageClass.
+	CloneStrategyAnnotation string = "kubevirt.io/clone-strategy"
+	// CSICloneStrategy is the CloneStrategyAnnotation value of
+	// StorageClasses whose CSI driver can clone volumes
+	CSICloneStrategy string = "csi-clone"
 
 	VirtualMachineLabel        = AppLabel + "/vm"
 	MemfdMemoryBackend  string = "kubevirt.io/memfd"
 )
 
+const (
+	// VMIResource is the extended resource for the number of VMIs a node can
+	// run, every virt-launcher pod requests one if a limit is configured
+	VMIResource k8sv1.ResourceName = "kubevirt.io/vmi"
+	// MemoryOvercommitResource is the extended resource for the overcommitted
+	// guest memory a node can give to its VMIs, in bytes
+	MemoryOvercommitResource k8sv1.ResourceName = "kubevirt.io/memory-overcommit"
+)
+
 func NewVMI(name string, uid types.UID) *VirtualMachineInstance {
 	return &VirtualMachineInstance{
 		Spec: VirtualMachineInstanceSpec{},
@@ -661,6 +967,14 @@ const (
 	Resumed                      SyncEvent = "Resumed"
 	AccessCredentialsSyncFailed  SyncEvent = "AccessCredentialsSyncFailed"
 	AccessCredentialsSyncSuccess SyncEvent = "AccessCredentialsSyncSuccess"
+	PortForwardsUpdated          SyncEvent = "PortForwardsUpdated"
+	ConsoleSessionStarted        SyncEvent = "ConsoleSessionStarted"
+	ConsoleSessionEnded          SyncEvent = "ConsoleSessionEnded"
+	ConsoleSessionRejected       SyncEvent = "ConsoleSessionRejected"
+	GuestTimeSyncFailed          SyncEvent = "GuestTimeSyncFailed"
+	NetworkDatapathDrifted       SyncEvent = "NetworkDatapathDrifted"
+	NetworkDatapathRepaired      SyncEvent = "NetworkDatapathRepaired"
+	NatRulesRestored             SyncEvent = "NatRulesRestored"
 )
 
 func (s SyncEvent) String() string {
@@ -783,6 +1097,14 @@ type VirtualMachineInstanceReplicaSetSpec struct {
 	// Indicates that the replica set is paused.
 	// +optional
 	Paused bool `json:"paused,omitempty" protobuf:"varint,7,opt,name=paused"`
+
+	// dataVolumeTemplates is a list of dataVolumes which are created for every
+	// VirtualMachineInstance of the replica set. The dataVolume volumes of the
+	// template referencing them are pointed to the copy of their replica,
+	// which is deleted along with the VirtualMachineInstance.
+	// +listType=atomic
+	// +optional
+	DataVolumeTemplates []DataVolumeTemplateSpec `json:"dataVolumeTemplates,omitempty"`
 }
 
 //
@@ -974,6 +1296,130 @@ func NewVirtualMachinePreset(name string, selector metav1.LabelSelector) *Virtua
 	}
 }
 
+// VirtualMachineNotificationHook registers an endpoint which is notified
+// about lifecycle events of the VirtualMachineInstances in its namespace
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineNotificationHook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VirtualMachineNotificationHookSpec `json:"spec" valid:"required"`
+}
+
+// VirtualMachineNotificationHookList is a list of VirtualMachineNotificationHooks
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineNotificationHookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineNotificationHook `json:"items"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VirtualMachineNotificationHookSpec struct {
+	// URL the events are sent to with a POST request. Only http and https
+	// URLs are supported.
+	URL string `json:"url"`
+	// Events which are sent to the URL. All events are sent if empty.
+	// +optional
+	// +listType=set
+	Events []VirtualMachineNotificationEvent `json:"events,omitempty"`
+	// Selector is a label query over the VMIs whose events are sent. The
+	// events of all VMIs in the namespace are sent if empty.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// SigningSecretRef references a secret in the namespace of the hook. The
+	// value of its "key" entry signs the events with HMAC-SHA256.
+	// +optional
+	SigningSecretRef *k8sv1.LocalObjectReference `json:"signingSecretRef,omitempty"`
+	// MaxRetries is the number of times the delivery of an event is retried
+	// after it failed. Defaults to 5.
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// VirtualMachineNotificationEvent is a lifecycle event of a VirtualMachineInstance
+//
+// +k8s:openapi-gen=true
+type VirtualMachineNotificationEvent string
+
+// These are the events VirtualMachineNotificationHooks are notified about
+const (
+	// The VMI started running
+	NotificationEventStarted VirtualMachineNotificationEvent = "Started"
+	// The VMI was live migrated to another node
+	NotificationEventMigrated VirtualMachineNotificationEvent = "Migrated"
+	// The VMI stopped after its domain was shut down
+	NotificationEventStopped VirtualMachineNotificationEvent = "Stopped"
+	// The VMI failed
+	NotificationEventCrashed VirtualMachineNotificationEvent = "Crashed"
+)
+
+// FencingRequest asks an external fencing agent to power off or isolate a
+// node, whose virt-handler is unresponsive, before the VirtualMachineInstances
+// of the node are restarted on other nodes
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type FencingRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              FencingRequestSpec `json:"spec" valid:"required"`
+	// +optional
+	Status FencingRequestStatus `json:"status,omitempty"`
+}
+
+// FencingRequestList is a list of FencingRequests
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type FencingRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FencingRequest `json:"items"`
+}
+
+//
+// +k8s:openapi-gen=true
+type FencingRequestSpec struct {
+	// NodeName is the name of the node to fence
+	NodeName string `json:"nodeName"`
+}
+
+// FencingRequestStatus is set by the fencing agent which handles the request
+//
+// +k8s:openapi-gen=true
+type FencingRequestStatus struct {
+	// Phase is the state of the fencing of the node. The VMIs of the node
+	// are only restarted once it is Fenced.
+	// +optional
+	Phase FencingRequestPhase `json:"phase,omitempty"`
+	// Message is a human readable detail of the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+	// FencedTimestamp is the time the node was fenced at
+	// +optional
+	FencedTimestamp *metav1.Time `json:"fencedTimestamp,omitempty"`
+}
+
+// FencingRequestPhase is the state of the fencing of a node
+//
+// +k8s:openapi-gen=true
+type FencingRequestPhase string
+
+// These are the phases of a FencingRequest
+const (
+	// No fencing agent handled the request yet
+	FencingRequestPending FencingRequestPhase = "Pending"
+	// The node is powered off or isolated from the cluster
+	FencingRequestFenced FencingRequestPhase = "Fenced"
+	// The fencing agent failed to fence the node, it may retry
+	FencingRequestFailed FencingRequestPhase = "Failed"
+)
+
 // VirtualMachine handles the VirtualMachines that are not running
 // or are in a stopped state
 // The VirtualMachine contains the template to create the
@@ -1060,6 +1506,186 @@ type VirtualMachineSpec struct {
 	// dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.
 	// DataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.
 	DataVolumeTemplates []DataVolumeTemplateSpec `json:"dataVolumeTemplates,omitempty"`
+
+	// Hibernation allows to hibernate the VirtualMachine. The memory of the guest is saved to a
+	// PersistentVolumeClaim managed by KubeVirt and restored on the next start.
+	// +optional
+	Hibernation *VirtualMachineHibernation `json:"hibernation,omitempty"`
+
+	// Replication copies the disks of the VirtualMachine asynchronously to a disaster recovery cluster,
+	// which keeps a stopped copy of the VirtualMachine to start if this cluster fails.
+	// +optional
+	Replication *VirtualMachineReplication `json:"replication,omitempty"`
+
+	// PowerSchedule starts and stops the VirtualMachine at the times of cron schedules, e.g. to stop
+	// development VMs at night.
+	// +optional
+	PowerSchedule *VirtualMachinePowerSchedule `json:"powerSchedule,omitempty"`
+}
+
+// VirtualMachineHibernation configures the PersistentVolumeClaim holding the memory of a hibernated VirtualMachine.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineHibernation struct {
+	// StorageClassName of the PersistentVolumeClaim, the default storage class is used if empty
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// VirtualMachineReplication configures the disaster recovery cluster the disks of a VirtualMachine are replicated to.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineReplication struct {
+	// PairingSecretRef is the name of the Secret granting access to the disaster recovery cluster, with the
+	// server and token and the optional ca.crt and namespace keys
+	PairingSecretRef string `json:"pairingSecretRef"`
+	// Namespace of the copy in the disaster recovery cluster, defaults to the namespace key of the pairing Secret
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Interval between the copies of the volumes replicated with rsync, one hour if not set
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// StorageClassName of the PersistentVolumeClaims created in the disaster recovery cluster for the volumes
+	// replicated with rsync, the default storage class is used if empty
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// VirtualMachinePowerSchedule starts and stops a VirtualMachine at the times of cron schedules.
+//
+// +k8s:openapi-gen=true
+type VirtualMachinePowerSchedule struct {
+	// Start is the schedule in cron format the VirtualMachine is started at, e.g. "0 8 * * 1-5"
+	// +optional
+	Start string `json:"start,omitempty"`
+	// Stop is the schedule in cron format the VirtualMachine is stopped at, e.g. "0 20 * * *"
+	// +optional
+	Stop string `json:"stop,omitempty"`
+	// TimeZone the schedules are evaluated in, a name of the IANA time zone database like "Europe/Berlin".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// SkipIfInUse skips a scheduled stop while the VirtualMachine is in use, that is users are logged in to
+	// the guest or the VirtualMachineInstance is migrating
+	// +optional
+	SkipIfInUse bool `json:"skipIfInUse,omitempty"`
+}
+
+// VirtualMachinePowerScheduleStatus reports the runs of the power schedule of a VirtualMachine.
+//
+// +k8s:openapi-gen=true
+type VirtualMachinePowerScheduleStatus struct {
+	// LastStartTime is the time of the last scheduled start
+	// +optional
+	// +nullable
+	LastStartTime *metav1.Time `json:"lastStartTime,omitempty"`
+	// NextStartTime is the time of the next scheduled start
+	// +optional
+	// +nullable
+	NextStartTime *metav1.Time `json:"nextStartTime,omitempty"`
+	// LastStopTime is the time of the last scheduled stop
+	// +optional
+	// +nullable
+	LastStopTime *metav1.Time `json:"lastStopTime,omitempty"`
+	// NextStopTime is the time of the next scheduled stop
+	// +optional
+	// +nullable
+	NextStopTime *metav1.Time `json:"nextStopTime,omitempty"`
+	// Message reports an invalid schedule or a skipped stop
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VirtualMachineIdleStatus reports the activity of a VirtualMachine watched by the idle policy.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineIdleStatus struct {
+	// LastActivityTime is the last time the guest was above a threshold of the idle policy, or its
+	// console was in use
+	// +optional
+	// +nullable
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+	// Idle is true if the VirtualMachine had no activity for the window of the idle policy
+	// +optional
+	Idle bool `json:"idle,omitempty"`
+	// Action is the action taken on the idle VirtualMachine, empty until it is taken
+	// +optional
+	Action IdleAction `json:"action,omitempty"`
+}
+
+// VolumeReplicationMethod is the way the disk of a volume is replicated
+//
+// +k8s:openapi-gen=true
+type VolumeReplicationMethod string
+
+const (
+	// VolumeReplicationCSI hands the replication to the replication extension of the CSI driver, it is used
+	// for the PersistentVolumeClaims of storage classes with a volume replication class
+	VolumeReplicationCSI VolumeReplicationMethod = "CSI"
+	// VolumeReplicationRsync copies the disk periodically with rsync, the fallback for filesystem volumes
+	VolumeReplicationRsync VolumeReplicationMethod = "Rsync"
+)
+
+// VirtualMachineReplicationPhase is the state of the replication of a VirtualMachine or one of its volumes
+//
+// +k8s:openapi-gen=true
+type VirtualMachineReplicationPhase string
+
+const (
+	// ReplicationPending means the replication didn't start yet
+	ReplicationPending VirtualMachineReplicationPhase = "Pending"
+	// ReplicationReplicating means a copy is in progress
+	ReplicationReplicating VirtualMachineReplicationPhase = "Replicating"
+	// ReplicationReplicated means the copy in the disaster recovery cluster is complete as of the last sync time
+	ReplicationReplicated VirtualMachineReplicationPhase = "Replicated"
+	// ReplicationFailedOver means the copy was started in the disaster recovery cluster, the replication is
+	// suspended to keep its disks intact
+	ReplicationFailedOver VirtualMachineReplicationPhase = "FailedOver"
+	// ReplicationFailed means the last copy failed, it is retried
+	ReplicationFailed VirtualMachineReplicationPhase = "Failed"
+)
+
+// VirtualMachineReplicationStatus reports the replication of a VirtualMachine to the disaster recovery cluster.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineReplicationStatus struct {
+	// Phase of the replication, the least advanced phase of the volumes
+	// +optional
+	Phase VirtualMachineReplicationPhase `json:"phase,omitempty"`
+	// LastSyncTime is the time all volumes were last replicated, the state the copy in the disaster recovery
+	// cluster is in
+	// +optional
+	// +nullable
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Volumes reports the replication of the volumes backed by PersistentVolumeClaims
+	// +optional
+	// +listType=atomic
+	Volumes []VolumeReplicationStatus `json:"volumes,omitempty"`
+	// Message explains the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VolumeReplicationStatus reports the replication of a volume of a VirtualMachine.
+//
+// +k8s:openapi-gen=true
+type VolumeReplicationStatus struct {
+	// Name of the volume
+	Name string `json:"name"`
+	// ClaimName is the name of the PersistentVolumeClaim, the copy in the disaster recovery cluster has the same name
+	ClaimName string `json:"claimName"`
+	// Method the volume is replicated with
+	Method VolumeReplicationMethod `json:"method"`
+	// Phase of the replication of the volume
+	// +optional
+	Phase VirtualMachineReplicationPhase `json:"phase,omitempty"`
+	// LastSyncTime is the time the volume was last replicated
+	// +optional
+	// +nullable
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Message explains the phase
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // StateChangeRequestType represents the existing state change requests that are possible
@@ -1069,9 +1695,10 @@ type StateChangeRequestAction string
 
 // These are the currently defined state change requests
 const (
-	StartRequest  StateChangeRequestAction = "Start"
-	StopRequest   StateChangeRequestAction = "Stop"
-	RenameRequest                          = "Rename"
+	StartRequest     StateChangeRequestAction = "Start"
+	StopRequest      StateChangeRequestAction = "Stop"
+	HibernateRequest StateChangeRequestAction = "Hibernate"
+	RenameRequest                             = "Rename"
 )
 
 // VirtualMachineStatus represents the status returned by the
@@ -1081,10 +1708,23 @@ const (
 type VirtualMachineStatus struct {
 	// SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing
 	SnapshotInProgress *string `json:"snapshotInProgress,omitempty"`
+	// LastSnapshotTime is the creation time of the last VirtualMachineSnapshot of the VM
+	// which became ready to use
+	// +optional
+	// +nullable
+	LastSnapshotTime *metav1.Time `json:"lastSnapshotTime,omitempty"`
+	// FSFreezeStatus is "frozen" while the guest file systems of the VirtualMachineInstance are frozen,
+	// and empty otherwise
+	// +optional
+	FSFreezeStatus string `json:"fsFreezeStatus,omitempty"`
 	// Created indicates if the virtual machine is created in the cluster
 	Created bool `json:"created,omitempty"`
 	// Ready indicates if the virtual machine is running and ready
 	Ready bool `json:"ready,omitempty"`
+	// PrintableStatus is a human readable, high-level summary of the state of the virtual machine,
+	// including the reason why it is not running if its virtual machine instance can't be started
+	// +optional
+	PrintableStatus VirtualMachinePrintableStatus `json:"printableStatus,omitempty"`
 	// Hold the state information of the VirtualMachine and its VirtualMachineInstance
 	Conditions []VirtualMachineCondition `json:"conditions,omitempty" optional:"true"`
 	// StateChangeRequests indicates a list of actions that should be taken on a VMI
@@ -1098,6 +1738,142 @@ type VirtualMachineStatus struct {
 	// VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is
 	// supported by each volume.
 	VolumeSnapshotStatuses []VolumeSnapshotStatus `json:"volumeSnapshotStatuses,omitempty" optional:"true"`
+
+	// Usage accumulates the resources consumed by the VirtualMachine in the current and the previous
+	// billing period, for chargeback. It is only maintained when usage accounting is enabled.
+	// +optional
+	Usage *VirtualMachineUsage `json:"usage,omitempty"`
+
+	// Hibernation reports the PersistentVolumeClaim holding the memory of the guest and whether
+	// the VirtualMachine is hibernated. It is only set if hibernation is configured.
+	// +optional
+	Hibernation *VirtualMachineHibernationStatus `json:"hibernation,omitempty"`
+
+	// ReplicationStatus reports the replication of the disks to the disaster recovery cluster. It is only
+	// set if replication is configured.
+	// +optional
+	ReplicationStatus *VirtualMachineReplicationStatus `json:"replicationStatus,omitempty"`
+
+	// PowerScheduleStatus reports the last and next runs of the power schedule. It is only set if a power
+	// schedule is configured.
+	// +optional
+	PowerScheduleStatus *VirtualMachinePowerScheduleStatus `json:"powerScheduleStatus,omitempty"`
+
+	// IdleStatus reports the activity of the VirtualMachine and whether it was suspended for being idle.
+	// It is only set for running VirtualMachines the idle policy applies to.
+	// +optional
+	IdleStatus *VirtualMachineIdleStatus `json:"idleStatus,omitempty"`
+}
+
+// VirtualMachinePrintableStatus is a human readable, high-level summary of the state of a VirtualMachine
+//
+// +k8s:openapi-gen=true
+type VirtualMachinePrintableStatus string
+
+// A VirtualMachine is in exactly one of these states, the first one which applies wins
+const (
+	// VirtualMachineStatusTerminating indicates that the virtual machine is being deleted
+	VirtualMachineStatusTerminating VirtualMachinePrintableStatus = "Terminating"
+	// VirtualMachineStatusStopping indicates that the virtual machine instance is being stopped
+	VirtualMachineStatusStopping VirtualMachinePrintableStatus = "Stopping"
+	// VirtualMachineStatusDataVolumeError indicates that a DataVolume of the virtual machine failed
+	VirtualMachineStatusDataVolumeError VirtualMachinePrintableStatus = "DataVolumeError"
+	// VirtualMachineStatusProvisioning indicates that the DataVolumes of the virtual machine are being populated
+	VirtualMachineStatusProvisioning VirtualMachinePrintableStatus = "Provisioning"
+	// VirtualMachineStatusPvcNotFound indicates that a PersistentVolumeClaim of the virtual machine does not exist
+	VirtualMachineStatusPvcNotFound VirtualMachinePrintableStatus = "ErrorPvcNotFound"
+	// VirtualMachineStatusNetworkAttachmentError indicates that a NetworkAttachmentDefinition of the virtual machine
+	// is missing or invalid
+	VirtualMachineStatusNetworkAttachmentError VirtualMachinePrintableStatus = "ErrorNetworkAttachment"
+	// VirtualMachineStatusUnschedulable indicates that the pod of the virtual machine instance can't be scheduled
+	VirtualMachineStatusUnschedulable VirtualMachinePrintableStatus = "ErrorUnschedulable"
+	// VirtualMachineStatusErrImagePull indicates that an image of the virtual machine instance could not be pulled
+	VirtualMachineStatusErrImagePull VirtualMachinePrintableStatus = "ErrImagePull"
+	// VirtualMachineStatusImagePullBackOff indicates that pulling an image of the virtual machine instance
+	// failed repeatedly and is backing off
+	VirtualMachineStatusImagePullBackOff VirtualMachinePrintableStatus = "ImagePullBackOff"
+	// VirtualMachineStatusStopped indicates that the virtual machine has no running virtual machine instance
+	VirtualMachineStatusStopped VirtualMachinePrintableStatus = "Stopped"
+	// VirtualMachineStatusStarting indicates that the virtual machine instance is being prepared for running
+	VirtualMachineStatusStarting VirtualMachinePrintableStatus = "Starting"
+	// VirtualMachineStatusMigrating indicates that the virtual machine instance is being migrated to another node
+	VirtualMachineStatusMigrating VirtualMachinePrintableStatus = "Migrating"
+	// VirtualMachineStatusPaused indicates that the virtual machine instance is paused
+	VirtualMachineStatusPaused VirtualMachinePrintableStatus = "Paused"
+	// VirtualMachineStatusRunning indicates that the virtual machine instance is running
+	VirtualMachineStatusRunning VirtualMachinePrintableStatus = "Running"
+	// VirtualMachineStatusUnknown indicates that the state of the virtual machine could not be determined
+	VirtualMachineStatusUnknown VirtualMachinePrintableStatus = "Unknown"
+)
+
+// VirtualMachineHibernationStatus reports the hibernation state of a VirtualMachine.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineHibernationStatus struct {
+	// ClaimName is the name of the PersistentVolumeClaim holding the memory of the guest
+	ClaimName string `json:"claimName"`
+	// Size is the capacity of the PersistentVolumeClaim, or its requested size while it is not bound
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Hibernated indicates that the memory of the guest is saved and will be restored on the next start
+	// +optional
+	Hibernated bool `json:"hibernated,omitempty"`
+	// HibernationTime is the time the memory of the guest was saved
+	// +optional
+	// +nullable
+	HibernationTime *metav1.Time `json:"hibernationTime,omitempty"`
+}
+
+// VirtualMachineUsage holds the resources consumed by a VirtualMachine per billing period.
+// +k8s:openapi-gen=true
+type VirtualMachineUsage struct {
+	// Current is the usage accumulated in the running billing period
+	Current VirtualMachineUsagePeriod `json:"current"`
+	// Previous is the usage of the last completed billing period
+	// +optional
+	Previous *VirtualMachineUsagePeriod `json:"previous,omitempty"`
+	// LastUpdateTime is the time the usage was last accumulated
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// Instance is the state of the VirtualMachineInstance at the last accumulation.
+	// It is meant to be used by KubeVirt core components only.
+	// +optional
+	Instance *VirtualMachineUsageInstance `json:"instance,omitempty"`
+}
+
+// VirtualMachineUsagePeriod holds the resources consumed by a VirtualMachine in a billing period.
+// +k8s:openapi-gen=true
+type VirtualMachineUsagePeriod struct {
+	// Start is the beginning of the billing period
+	Start metav1.Time `json:"start"`
+	// End is the end of the billing period
+	End metav1.Time `json:"end"`
+	// VCPUSeconds is the number of vCPUs allocated to the VirtualMachineInstance, summed up over every second it was running
+	VCPUSeconds int64 `json:"vcpuSeconds"`
+	// MemoryMebibyteSeconds is the guest memory in MiB of the VirtualMachineInstance, summed up over every second it was running
+	MemoryMebibyteSeconds int64 `json:"memoryMebibyteSeconds"`
+	// StorageBytes is the capacity of the PersistentVolumeClaims and DataVolumes of the VirtualMachine at the last accumulation
+	StorageBytes int64 `json:"storageBytes"`
+	// NetworkReceiveBytes is the number of bytes received by the VirtualMachineInstance
+	NetworkReceiveBytes int64 `json:"networkReceiveBytes"`
+	// NetworkTransmitBytes is the number of bytes transmitted by the VirtualMachineInstance
+	NetworkTransmitBytes int64 `json:"networkTransmitBytes"`
+}
+
+// VirtualMachineUsageInstance is the state of a VirtualMachineInstance at the last usage accumulation.
+// +k8s:openapi-gen=true
+type VirtualMachineUsageInstance struct {
+	// UID of the VirtualMachineInstance
+	UID types.UID `json:"uid"`
+	// Running indicates if the VirtualMachineInstance was running
+	Running bool `json:"running"`
+	// VCPUs is the number of vCPUs allocated to the VirtualMachineInstance
+	VCPUs int64 `json:"vcpus"`
+	// MemoryMebibytes is the guest memory of the VirtualMachineInstance in MiB
+	MemoryMebibytes int64 `json:"memoryMebibytes"`
+	// NetworkReceiveBytes is the receive counter reported for the VirtualMachineInstance
+	NetworkReceiveBytes int64 `json:"networkReceiveBytes"`
+	// NetworkTransmitBytes is the transmit counter reported for the VirtualMachineInstance
+	NetworkTransmitBytes int64 `json:"networkTransmitBytes"`
 }
 
 // +k8s:openapi-gen=true
@@ -1163,6 +1939,14 @@ const (
 
 	// This condition indicates that the VM was renamed
 	RenameConditionType VirtualMachineConditionType = "RenameOperation"
+
+	// VirtualMachineDataVolumesReady is added in a virtual machine with DataVolumeTemplates and
+	// indicates whether all its DataVolumes are populated.
+	VirtualMachineDataVolumesReady VirtualMachineConditionType = "DataVolumesReady"
+	// Reason means that a DataVolume of the virtual machine is not created or populated yet
+	VirtualMachineReasonDataVolumeNotPopulated = "DataVolumeNotPopulated"
+	// Reason means that a DataVolume of the virtual machine failed to be populated
+	VirtualMachineReasonDataVolumeFailed = "DataVolumeFailed"
 )
 
 //
@@ -1388,6 +2172,7 @@ type KubeVirtStatus struct {
 	ObservedKubeVirtVersion  string              `json:"observedKubeVirtVersion,omitempty" optional:"true"`
 	ObservedDeploymentConfig string              `json:"observedDeploymentConfig,omitempty" optional:"true"`
 	ObservedDeploymentID     string              `json:"observedDeploymentID,omitempty" optional:"true"`
+	CPUBaselines             []CPUBaselineStatus `json:"cpuBaselines,omitempty" optional:"true"`
 }
 
 // KubeVirtPhase is a label for the phase of a KubeVirt deployment at the current time.
@@ -1447,6 +2232,26 @@ const (
 	EvictionStrategyLiveMigrate EvictionStrategy = "LiveMigrate"
 )
 
+const (
+	QoSClassGold   QoSClass = "gold"
+	QoSClassSilver QoSClass = "silver"
+	QoSClassBronze QoSClass = "bronze"
+)
+
+const (
+	GuestOSWindows10   GuestOS = "windows10"
+	GuestOSWindows2k12 GuestOS = "windows2k12"
+	GuestOSWindows2k16 GuestOS = "windows2k16"
+	GuestOSWindows2k19 GuestOS = "windows2k19"
+	GuestOSRHEL7       GuestOS = "rhel7"
+	GuestOSRHEL8       GuestOS = "rhel8"
+	GuestOSRHEL9       GuestOS = "rhel9"
+	GuestOSCentOS7     GuestOS = "centos7"
+	GuestOSCentOS8     GuestOS = "centos8"
+	GuestOSFedora      GuestOS = "fedora"
+	GuestOSUbuntu      GuestOS = "ubuntu"
+)
+
 // RestartOptions may be provided when deleting an API object.
 //
 // +k8s:openapi-gen=true
@@ -1554,6 +2359,146 @@ type RemoveVolumeOptions struct {
 	Name string `json:"name"`
 }
 
+// VirtualMachineStartValidation reports what would block the start of a VirtualMachine
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineStartValidation struct {
+	metav1.TypeMeta `json:",inline"`
+	// Startable is true if none of the checks blocks the start
+	Startable bool `json:"startable"`
+	// Blockers lists everything which would block the start
+	Blockers []VirtualMachineStartBlocker `json:"blockers,omitempty"`
+}
+
+// VirtualMachineStartBlocker is a single reason why a VirtualMachine can't be started
+// +k8s:openapi-gen=true
+type VirtualMachineStartBlocker struct {
+	// Check is the check which failed
+	Check VirtualMachineStartCheck `json:"check"`
+	// Message describes what blocks the start
+	Message string `json:"message"`
+}
+
+// VirtualMachineStartCheck is a check run before the start of a VirtualMachine
+type VirtualMachineStartCheck string
+
+const (
+	// StartCheckState checks that the VirtualMachine can be asked to start
+	StartCheckState VirtualMachineStartCheck = "State"
+	// StartCheckNode checks that a schedulable node matches the node selector of the VMI
+	StartCheckNode VirtualMachineStartCheck = "Node"
+	// StartCheckStorage checks that the volumes of the VMI are available
+	StartCheckStorage VirtualMachineStartCheck = "Storage"
+	// StartCheckNetwork checks that the networks and interface bindings of the VMI can be used
+	StartCheckNetwork VirtualMachineStartCheck = "Network"
+	// StartCheckDevices checks that a node provides the device plugin resources of the VMI
+	StartCheckDevices VirtualMachineStartCheck = "Devices"
+)
+
+// KubeVirtConfigurationValidation reports the workloads which a change of the KubeVirt configuration affects
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type KubeVirtConfigurationValidation struct {
+	metav1.TypeMeta `json:",inline"`
+	// Impacts lists how the change affects the workloads, empty if it doesn't affect any
+	Impacts []KubeVirtConfigurationImpact `json:"impacts,omitempty"`
+}
+
+// KubeVirtConfigurationImpact is a single way in which a change of the KubeVirt configuration affects workloads
+// +k8s:openapi-gen=true
+type KubeVirtConfigurationImpact struct {
+	// Message describes the impact and how many workloads it affects
+	Message string `json:"message"`
+	// VirtualMachineInstances lists the affected VirtualMachineInstances as namespace/name
+	VirtualMachineInstances []string `json:"virtualMachineInstances,omitempty"`
+	// VirtualMachines lists the affected VirtualMachines as namespace/name
+	VirtualMachines []string `json:"virtualMachines,omitempty"`
+}
+
+// VirtualMachineInstanceStats holds the resource usage counters of a running VirtualMachineInstance.
+// The counters grow from the start of the VirtualMachineInstance, rates are the difference of two samples.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceStats struct {
+	metav1.TypeMeta `json:",inline"`
+	// Timestamp is the time the counters were read
+	Timestamp metav1.MicroTime `json:"timestamp"`
+	// VCPUs is the number of vCPUs of the guest
+	VCPUs int64 `json:"vcpus"`
+	// CPUTimeNanoseconds is the CPU time the guest used
+	CPUTimeNanoseconds int64 `json:"cpuTimeNanoseconds"`
+	// MemoryWorkingSetBytes is the memory used by the guest, or the resident memory
+	// of qemu if the guest doesn't report its memory usage
+	MemoryWorkingSetBytes int64 `json:"memoryWorkingSetBytes"`
+	// DiskReadBytes is the number of bytes read from all disks
+	DiskReadBytes int64 `json:"diskReadBytes"`
+	// DiskWriteBytes is the number of bytes written to all disks
+	DiskWriteBytes int64 `json:"diskWriteBytes"`
+	// NetworkReceiveBytes is the number of bytes received on all interfaces
+	NetworkReceiveBytes int64 `json:"networkReceiveBytes"`
+	// NetworkTransmitBytes is the number of bytes transmitted on all interfaces
+	NetworkTransmitBytes int64 `json:"networkTransmitBytes"`
+}
+
+// VirtualMachineBulkOperation is a lifecycle operation which can be applied to all VirtualMachines matching a label selector
+type VirtualMachineBulkOperation string
+
+const (
+	// BulkStartOperation starts the VirtualMachines
+	BulkStartOperation VirtualMachineBulkOperation = "start"
+	// BulkStopOperation stops the VirtualMachines
+	BulkStopOperation VirtualMachineBulkOperation = "stop"
+	// BulkMigrateOperation migrates the VirtualMachines to other nodes
+	BulkMigrateOperation VirtualMachineBulkOperation = "migrate"
+)
+
+// VirtualMachineBulkOperationOptions selects the VirtualMachines a bulk operation is applied to
+// and how many of them are processed at once.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineBulkOperationOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// LabelSelector selects the VirtualMachines of the namespace the operation is applied to
+	LabelSelector string `json:"labelSelector"`
+	// BatchSize is the number of VirtualMachines listed and processed at once. Defaults to 50, at most 500.
+	// +optional
+	BatchSize int64 `json:"batchSize,omitempty"`
+	// MaxConcurrency is the number of VirtualMachines of a batch processed in parallel. Defaults to 10, at most 50.
+	// +optional
+	MaxConcurrency int64 `json:"maxConcurrency,omitempty"`
+}
+
+// VirtualMachineBulkOperationResult summarizes the outcome of a bulk operation
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineBulkOperationResult struct {
+	metav1.TypeMeta `json:",inline"`
+	// Operation is the operation which was applied
+	Operation VirtualMachineBulkOperation `json:"operation"`
+	// Matched is the number of VirtualMachines matching the label selector
+	Matched int64 `json:"matched"`
+	// Succeeded lists the VirtualMachines the operation was applied to
+	Succeeded []string `json:"succeeded,omitempty"`
+	// Skipped lists the VirtualMachines the operation does not apply to in their current state,
+	// like running VirtualMachines for a start
+	Skipped []VirtualMachineBulkOperationMessage `json:"skipped,omitempty"`
+	// Failed lists the VirtualMachines the operation failed for
+	Failed []VirtualMachineBulkOperationMessage `json:"failed,omitempty"`
+}
+
+// VirtualMachineBulkOperationMessage tells why a bulk operation was not applied to a VirtualMachine
+// +k8s:openapi-gen=true
+type VirtualMachineBulkOperationMessage struct {
+	// Name is the name of the VirtualMachine
+	Name string `json:"name"`
+	// Message tells why the operation was not applied
+	Message string `json:"message"`
+}
+
 // KubeVirtConfiguration holds all kubevirt configurations
 // +k8s:openapi-gen=true
 type KubeVirtConfiguration struct {
@@ -1571,8 +2516,108 @@ type KubeVirtConfiguration struct {
 	SupportedGuestAgentVersions []string                `json:"supportedGuestAgentVersions,omitempty"`
 	MemBalloonStatsPeriod       *uint32                 `json:"memBalloonStatsPeriod,omitempty"`
 	PermittedHostDevices        *PermittedHostDevices   `json:"permittedHostDevices,omitempty"`
+	ConsoleConfiguration        *ConsoleConfiguration   `json:"console,omitempty"`
+	// V2VConversionImage is the image running virt-v2v for the conversion of
+	// VMs imported from other hypervisors
+	V2VConversionImage           string                        `json:"v2vConversionImage,omitempty"`
+	UsageAccountingConfiguration *UsageAccountingConfiguration `json:"usageAccounting,omitempty"`
+	// VirtioWinImage is the container disk image with the virtio-win drivers,
+	// which is attached to VMIs asking for the virtio-win driver disk
+	VirtioWinImage string `json:"virtioWinImage,omitempty"`
+	// AdmissionPolicies reject the creation of VMIs which match them
+	AdmissionPolicies []VMIAdmissionPolicy `json:"admissionPolicies,omitempty"`
+	// NodeDensity limits the number of VMIs and the memory overcommitment of every node
+	NodeDensity *NodeDensityConfiguration `json:"nodeDensity,omitempty"`
+	// DiskTransferImage is the image with curl which uploads the disks of VMs
+	// imported from peer clusters, it has to be pullable in the peer cluster
+	DiskTransferImage string `json:"diskTransferImage,omitempty"`
+	// ReplicationImage is the image with rsync and kubectl which copies the
+	// disks of replicated VMs to the disaster recovery cluster, unless their
+	// storage class has a CSI replication class
+	ReplicationImage string `json:"replicationImage,omitempty"`
+	// IdlePolicy holds the activity thresholds below which VirtualMachines of the namespaces opted in are
+	// idle, and the action taken on idle VirtualMachines
+	IdlePolicy *IdlePolicyConfiguration `json:"idlePolicy,omitempty"`
+	// DisruptionBudgetPolicy controls the PodDisruptionBudgets protecting the VMIs which are live-migrated
+	// on evictions, one of Always, DuringMigration or Never. Defaults to Always.
+	DisruptionBudgetPolicy DisruptionBudgetPolicy `json:"disruptionBudgetPolicy,omitempty"`
+	// CPUBaselines are named CPU models computed from the CPU models and features all nodes of a node pool
+	// support. VMIs referencing a baseline as CPU model are migratable between the nodes of its pool.
+	// +listType=atomic
+	CPUBaselines []CPUBaseline `json:"cpuBaselines,omitempty"`
+	// AllowedImportURLs are the URL prefixes VMs may be imported from. OVF descriptors and the files
+	// they reference are only fetched from URLs starting with one of them.
+	// +listType=atomic
+	AllowedImportURLs []string `json:"allowedImportURLs,omitempty"`
+	// QoSClasses are the tiers of disk and network service VMIs select with their qosClass.
+	// Setting them replaces the default gold, silver and bronze classes.
+	// +listType=atomic
+	QoSClasses []QoSClassConfiguration `json:"qosClasses,omitempty"`
 }
 
+// QoSClassConfiguration holds the node level settings a VMI QoS class is mapped to
+//
+// +k8s:openapi-gen=true
+type QoSClassConfiguration struct {
+	// Name of the class, which VMIs select with their qosClass
+	Name QoSClass `json:"name"`
+	// BlkioWeight is the relative blkio weight of the virt-launcher pod cgroup, between 10 and 1000
+	BlkioWeight uint32 `json:"blkioWeight"`
+	// NetworkRate is the guaranteed egress rate of the tap devices in bytes per second
+	NetworkRate resource.Quantity `json:"networkRate"`
+	// NetworkCeil is the maximum egress rate of the tap devices in bytes per second, at least the NetworkRate
+	NetworkCeil resource.Quantity `json:"networkCeil"`
+	// NetworkPriority is the HTB priority of the tap devices, lower values are served first
+	// +optional
+	NetworkPriority uint32 `json:"networkPriority,omitempty"`
+}
+
+// CPUBaseline selects the node pool a CPU baseline is computed for
+//
+// +k8s:openapi-gen=true
+type CPUBaseline struct {
+	// Name of the baseline, which VMIs reference as CPU model. It must not be the name of a CPU model
+	// known to libvirt.
+	Name string `json:"name"`
+	// NodeSelector selects the nodes of the pool by their labels
+	NodeSelector map[string]string `json:"nodeSelector"`
+}
+
+// CPUBaselineStatus reports the CPU model and features computed for a CPU baseline
+//
+// +k8s:openapi-gen=true
+type CPUBaselineStatus struct {
+	// Name of the baseline
+	Name string `json:"name"`
+	// Model is the newest CPU model all nodes of the pool support
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Features are the CPU features all nodes of the pool have
+	// +optional
+	// +listType=atomic
+	Features []string `json:"features,omitempty"`
+	// Nodes is the number of nodes in the pool
+	Nodes int32 `json:"nodes"`
+	// Message reports why no model could be computed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DisruptionBudgetPolicy controls when virt-controller protects the VMIs
+// which are live-migrated on evictions with a PodDisruptionBudget
+type DisruptionBudgetPolicy string
+
+const (
+	// DisruptionBudgetPolicyAlways keeps a PodDisruptionBudget for the VMIs, which
+	// allows no eviction while they are migrated
+	DisruptionBudgetPolicyAlways DisruptionBudgetPolicy = "Always"
+	// DisruptionBudgetPolicyDuringMigration only creates a PodDisruptionBudget for the
+	// VMIs while they are migrated and removes it afterwards
+	DisruptionBudgetPolicyDuringMigration DisruptionBudgetPolicy = "DuringMigration"
+	// DisruptionBudgetPolicyNever creates no PodDisruptionBudgets for the VMIs
+	DisruptionBudgetPolicyNever DisruptionBudgetPolicy = "Never"
+)
+
 //
 // +k8s:openapi-gen=true
 type SMBiosConfiguration struct {
@@ -1606,6 +2651,45 @@ type DeveloperConfiguration struct {
 	NodeSelectors          map[string]string `json:"nodeSelectors,omitempty"`
 	UseEmulation           bool              `json:"useEmulation,omitempty"`
 	CPUAllocationRatio     int               `json:"cpuAllocationRatio,omitempty"`
+	LogVerbosity           *LogVerbosity     `json:"logVerbosity,omitempty"`
+	// EmulationPolicy controls which VMIs may run with software emulation, one of Never, OptIn or Always.
+	// Defaults to Always if useEmulation is set and to Never otherwise.
+	EmulationPolicy EmulationPolicy `json:"emulationPolicy,omitempty"`
+	// ScopedFeatureGates enable feature gates for the workloads of the selected namespaces only.
+	ScopedFeatureGates []ScopedFeatureGate `json:"scopedFeatureGates,omitempty"`
+}
+
+// EmulationPolicy controls which VMIs may run with software emulation
+// on nodes without /dev/kvm
+type EmulationPolicy string
+
+const (
+	// EmulationPolicyNever requires KVM for all VMIs
+	EmulationPolicyNever EmulationPolicy = "Never"
+	// EmulationPolicyOptIn allows software emulation for VMIs setting spec.domain.allowEmulation
+	EmulationPolicyOptIn EmulationPolicy = "OptIn"
+	// EmulationPolicyAlways allows software emulation for VMIs not opting out with spec.domain.allowEmulation
+	EmulationPolicyAlways EmulationPolicy = "Always"
+)
+
+// ScopedFeatureGate enables a feature gate for the workloads of the namespaces
+// it selects. The webhooks reject workloads using the feature in other namespaces.
+// +k8s:openapi-gen=true
+type ScopedFeatureGate struct {
+	// Name of the feature gate, e.g. Macvtap
+	Name string `json:"name"`
+	// Namespaces the feature gate is enabled in
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces the feature gate is enabled in by their labels
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// LogVerbosity sets log verbosity level of the various components
+// +k8s:openapi-gen=true
+type LogVerbosity struct {
+	VirtController uint `json:"virtController,omitempty"`
+	VirtHandler    uint `json:"virtHandler,omitempty"`
+	VirtLauncher   uint `json:"virtLauncher,omitempty"`
 }
 
 // PermittedHostDevices holds inforamtion about devices allowed for passthrough
@@ -1615,6 +2699,14 @@ type PermittedHostDevices struct {
 	PciHostDevices []PciHostDevice `json:"pciHostDevices,omitempty"`
 	// +listType=atomic
 	MediatedDevices []MediatedHostDevice `json:"mediatedDevices,omitempty"`
+	// GenericHostDevices are device nodes of the hosts which VMIs can request as
+	// devices.kubevirt.io/<name> resources, like /dev/sev or /dev/vfio/vfio
+	// +listType=atomic
+	GenericHostDevices []GenericHostDevice `json:"genericHostDevices,omitempty"`
+	// NvmeNamespaces are NVMe namespaces which VMIs can request as host devices,
+	// each namespace is passed through with the NVMe controller it is attached to
+	// +listType=atomic
+	NvmeNamespaces []NvmeNamespaceHostDevice `json:"nvmeNamespaces,omitempty"`
 }
 
 // PciHostDevice represents a host PCI device allowed for passthrough
@@ -1633,10 +2725,186 @@ type MediatedHostDevice struct {
 	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
 }
 
+// NvmeNamespaceHostDevice represents NVMe namespaces allowed for passthrough. VFIO passes
+// through PCI functions, a namespace is therefore passed through with the NVMe controller
+// it is attached to, which has to be a virtual function of an SR-IOV capable NVMe drive
+// with this namespace as its only namespace.
+// +k8s:openapi-gen=true
+type NvmeNamespaceHostDevice struct {
+	// PCIVendorSelector selects the NVMe controllers by their vendor:device ID
+	PCIVendorSelector string `json:"pciVendorSelector"`
+	// ResourceName is the name of the resource the namespaces are advertised as
+	ResourceName string `json:"resourceName"`
+	// ExternalResourceProvider indicates that the namespaces are advertised by another device plugin
+	// +optional
+	ExternalResourceProvider bool `json:"externalResourceProvider,omitempty"`
+}
+
+// GenericHostDevice represents a device node of the host which is exposed as a resource
+// +k8s:openapi-gen=true
+type GenericHostDevice struct {
+	// Name of the device, the resource is devices.kubevirt.io/<name>
+	Name string `json:"name"`
+	// Path of the device node on the host
+	Path string `json:"path"`
+	// PreOpen opens the device node once before the resource is advertised,
+	// to load the kernel modules of the device
+	// +optional
+	PreOpen bool `json:"preOpen,omitempty"`
+}
+
 // NetworkConfiguration holds network options
 // +k8s:openapi-gen=true
 type NetworkConfiguration struct {
 	NetworkInterface                  string `json:"defaultNetworkInterface,omitempty"`
 	PermitSlirpInterface              *bool  `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool  `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
+	// Bridge tunes the bridges which connect bridge and masquerade interfaces to the pod network
+	Bridge *BridgeConfiguration `json:"bridge,omitempty"`
+	// MasqueradeSubnetPool is the pool which the internal subnets of masquerade interfaces are allocated from,
+	// when the pod network does not set a vmNetworkCIDR
+	MasqueradeSubnetPool *MasqueradeSubnetPool `json:"masqueradeSubnetPool,omitempty"`
+	// DefaultVMNetworkCIDR is the vmNetworkCIDR of pod networks with a masquerade interface which do not set one.
+	// It is not applied when a masquerade subnet pool is configured. Defaults to 10.0.2.0/24
+	DefaultVMNetworkCIDR string `json:"defaultVMNetworkCIDR,omitempty"`
+	// DefaultVMIPv6NetworkCIDR is the vmIPv6NetworkCIDR of pod networks with a masquerade interface which do not set one.
+	// Defaults to fd10:0:2::/120
+	DefaultVMIPv6NetworkCIDR string `json:"defaultVMIPv6NetworkCIDR,omitempty"`
+}
+
+// MasqueradeSubnetPool is an IPv4 range which is split into subnets of the same size.
+// Every VMI with a masquerade interface gets a subnet which is not used by any other VMI.
+// +k8s:openapi-gen=true
+type MasqueradeSubnetPool struct {
+	// CIDR is the IPv4 range of the pool
+	CIDR string `json:"cidr"`
+	// PrefixLength is the prefix length of the subnets allocated from the pool, between the prefix length of the pool and 30. Defaults to 24
+	PrefixLength *int32 `json:"prefixLength,omitempty"`
+}
+
+// BridgeConfiguration holds the parameters of the bridges created in the virt-launcher pods.
+// Unset fields keep the defaults of the kernel.
+// +k8s:openapi-gen=true
+type BridgeConfiguration struct {
+	// STP enables the spanning tree protocol on the bridges
+	STP *bool `json:"stp,omitempty"`
+	// AgeingTimeSeconds is how long the bridges keep a learned MAC address in their forwarding database
+	AgeingTimeSeconds *uint32 `json:"ageingTimeSeconds,omitempty"`
+	// MulticastSnooping enables IGMP and MLD snooping on the bridges
+	MulticastSnooping *bool `json:"multicastSnooping,omitempty"`
+	// MulticastQuerier runs an IGMP and MLD querier on the bridges, so that snooping keeps forwarding multicast groups when the pod network has no querier
+	MulticastQuerier *bool `json:"multicastQuerier,omitempty"`
+	// VLANFiltering enables VLAN filtering on the bridges
+	VLANFiltering *bool `json:"vlanFiltering,omitempty"`
+}
+
+// UsageAccountingConfiguration holds options of the per-VM resource usage accounting
+// +k8s:openapi-gen=true
+type UsageAccountingConfiguration struct {
+	// BillingPeriod after which the usage of VirtualMachines is reset, one of Daily, Weekly or Monthly.
+	// Periods start at midnight UTC, weeks on Monday.
+	BillingPeriod BillingPeriod `json:"billingPeriod,omitempty"`
+	// UpdateIntervalSeconds is the time between two accumulations of the usage of a running VirtualMachine
+	UpdateIntervalSeconds *int64 `json:"updateIntervalSeconds,omitempty"`
+}
+
+// NodeDensityConfiguration limits the VMIs a node runs with extended resources,
+// which virt-handler advertises on the node and virt-launcher pods request
+// +k8s:openapi-gen=true
+type NodeDensityConfiguration struct {
+	// MaxVMIsPerNode is the number of VMIs a node can run at the same time.
+	// It is overridden for a node by its kubevirt.io/max-vmis annotation.
+	// +optional
+	MaxVMIsPerNode int64 `json:"maxVMIsPerNode,omitempty"`
+	// MemoryOvercommitBudget is the guest memory a node can give to its VMIs beyond the memory
+	// requested by their virt-launcher pods. It is overridden for a node by its
+	// kubevirt.io/memory-overcommit-budget annotation.
+	// +optional
+	MemoryOvercommitBudget *resource.Quantity `json:"memoryOvercommitBudget,omitempty"`
+}
+
+// BillingPeriod is the length of a usage accounting period
+type BillingPeriod string
+
+const (
+	BillingPeriodDaily   BillingPeriod = "Daily"
+	BillingPeriodWeekly  BillingPeriod = "Weekly"
+	BillingPeriodMonthly BillingPeriod = "Monthly"
+)
+
+// IdlePolicyConfiguration holds the activity thresholds below which running VirtualMachines are idle,
+// and the action taken on VirtualMachines which were idle for a whole window
+// +k8s:openapi-gen=true
+type IdlePolicyConfiguration struct {
+	// Action taken on idle VirtualMachines, one of Pause or Hibernate. VirtualMachines without
+	// hibernation configured are paused. Defaults to Pause.
+	Action IdleAction `json:"action,omitempty"`
+	// WindowSeconds is how long a VirtualMachine has to stay below all thresholds to be idle
+	WindowSeconds *int64 `json:"windowSeconds,omitempty"`
+	// SampleIntervalSeconds is the time between two reads of the stats of a running VirtualMachine
+	SampleIntervalSeconds *int64 `json:"sampleIntervalSeconds,omitempty"`
+	// CPUThreshold is the CPU time per second the guest uses on all its vCPUs, e.g. 50m, below which it
+	// is idle
+	CPUThreshold *resource.Quantity `json:"cpuThreshold,omitempty"`
+	// NetworkThreshold is the number of bytes per second the guest receives and transmits on all its
+	// interfaces, below which it is idle
+	NetworkThreshold *resource.Quantity `json:"networkThreshold,omitempty"`
+}
+
+// IdleAction is the action taken on an idle VirtualMachine
+type IdleAction string
+
+const (
+	// IdleActionPause pauses the VirtualMachineInstance of an idle VirtualMachine
+	IdleActionPause IdleAction = "Pause"
+	// IdleActionHibernate hibernates an idle VirtualMachine
+	IdleActionHibernate IdleAction = "Hibernate"
+)
+
+// VMIAdmissionPolicy rejects the creation of VMIs which match all of its rules
+// +k8s:openapi-gen=true
+type VMIAdmissionPolicy struct {
+	// Name identifies the policy in the messages of rejected VMIs
+	Name string `json:"name"`
+	// Namespaces the policy applies to, all namespaces if empty
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Rules which all have to match a VMI to reject it
+	Rules []VMIAdmissionRule `json:"rules"`
+	// Message is returned to the user when a VMI is rejected
+	Message string `json:"message,omitempty"`
+}
+
+// VMIAdmissionRule matches the values a JSONPath expression selects from a VMI
+// +k8s:openapi-gen=true
+type VMIAdmissionRule struct {
+	// Path is a JSONPath expression evaluated against the VMI, e.g. {.spec.domain.cpu.cores}
+	Path string `json:"path"`
+	// Operator is one of In, NotIn, Exists, DoesNotExist, Gt and Lt.
+	// In, NotIn, Gt and Lt match if any selected value matches.
+	Operator VMIAdmissionRuleOperator `json:"operator"`
+	// Values the selected values are compared to, a single quantity like 4 or 8Gi for Gt and Lt
+	Values []string `json:"values,omitempty"`
+}
+
+// VMIAdmissionRuleOperator compares the values selected by an admission rule
+type VMIAdmissionRuleOperator string
+
+const (
+	VMIAdmissionRuleOpIn           VMIAdmissionRuleOperator = "In"
+	VMIAdmissionRuleOpNotIn        VMIAdmissionRuleOperator = "NotIn"
+	VMIAdmissionRuleOpExists       VMIAdmissionRuleOperator = "Exists"
+	VMIAdmissionRuleOpDoesNotExist VMIAdmissionRuleOperator = "DoesNotExist"
+	VMIAdmissionRuleOpGt           VMIAdmissionRuleOperator = "Gt"
+	VMIAdmissionRuleOpLt           VMIAdmissionRuleOperator = "Lt"
+)
+
+// ConsoleConfiguration holds options of the serial console and VNC proxy
+// +k8s:openapi-gen=true
+type ConsoleConfiguration struct {
+	// MaxSessions limits the number of sessions proxied concurrently by every virt-api instance, 0 means unlimited
+	MaxSessions *uint32 `json:"maxSessions,omitempty"`
+	// IdleTimeoutSeconds closes sessions without traffic in either direction for this long, 0 disables the timeout
+	IdleTimeoutSeconds *int64 `json:"idleTimeoutSeconds,omitempty"`
+	// RequireAccessReason rejects sessions to VMIs which do not carry the kubevirt.io/console-access-reason annotation
+	RequireAccessReason bool `json:"requireAccessReason,omitempty"`
 }
@@ -36,24 +36,77 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 		"dnsPolicy":                     "Set DNS policy for the pod.\nDefaults to \"ClusterFirst\".\nValid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.\nDNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy.\nTo have DNS options set along with hostNetwork, you have to specify DNS policy\nexplicitly to 'ClusterFirstWithHostNet'.\n+optional",
 		"dnsConfig":                     "Specifies the DNS parameters of a pod.\nParameters specified here will be merged to the generated DNS\nconfiguration based on DNSPolicy.\n+optional",
 		"accessCredentials":             "Specifies a set of public keys to inject into the vm guest\n+listType=atomic\n+optional",
+		"qosClass":                      "QoSClass selects the tier of disk and network service the vmi gets on the node.\nValid values are the QoS classes of the KubeVirt configuration, \"gold\", \"silver\" and \"bronze\"\nby default. No QoS is applied if not set.\nUnrelated to the pod QoS class reported in the status.\n+optional",
+		"metadataService":               "MetadataService serves the metadata and the user-data of the vmi and the token of its service account\nto the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.\n+optional",
+		"guestOS":                       "GuestOS declares the operating system of the guest, e.g. \"windows2k19\" or \"rhel8\".\nThe disk bus, the interface model, the clock and the inputs which are neither set on\nthe vmi nor by a preset are defaulted to what suits the guest OS.\n+optional",
+	}
+}
+
+func (MetadataService) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "MetadataService enables the metadata service of the guest, which is served by virt-launcher.\n\n+k8s:openapi-gen=true",
 	}
 }
 
 func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                   "VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual\nstate of a system.\n\n+k8s:openapi-gen=true",
-		"nodeName":           "NodeName is the name where the VirtualMachineInstance is currently running.",
-		"reason":             "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'\n+optional",
-		"conditions":         "Conditions are specific points in VirtualMachineInstance's pod runtime.",
-		"phase":              "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
-		"interfaces":         "Interfaces represent the details of available network interfaces.",
-		"guestOSInfo":        "Guest OS Information",
-		"migrationState":     "Represents the status of a live migration",
-		"migrationMethod":    "Represents the method using which the vmi can be migrated: live migration or block migration",
-		"qosClass":           "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md\n+optional",
-		"evacuationNodeName": "EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want\nto evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.\n+optional",
-		"activePods":         "ActivePods is a mapping of pod UID to node name.\nIt is possible for multiple pods to be running for a single VMI during migration.",
-		"volumeStatus":       "VolumeStatus contains the statuses of all the volumes\n+optional\n+listType=atomic",
+		"":                      "VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual\nstate of a system.\n\n+k8s:openapi-gen=true",
+		"nodeName":              "NodeName is the name where the VirtualMachineInstance is currently running.",
+		"reason":                "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'\n+optional",
+		"conditions":            "Conditions are specific points in VirtualMachineInstance's pod runtime.",
+		"phase":                 "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
+		"interfaces":            "Interfaces represent the details of available network interfaces.",
+		"guestOSInfo":           "Guest OS Information",
+		"migrationState":        "Represents the status of a live migration",
+		"migrationMethod":       "Represents the method using which the vmi can be migrated: live migration or block migration",
+		"qosClass":              "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md\n+optional",
+		"evacuationNodeName":    "EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want\nto evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.\n+optional",
+		"activePods":            "ActivePods is a mapping of pod UID to node name.\nIt is possible for multiple pods to be running for a single VMI during migration.",
+		"volumeStatus":          "VolumeStatus contains the statuses of all the volumes\n+optional\n+listType=atomic",
+		"startupTimestamps":     "StartupTimestamps records when the VirtualMachineInstance reached the milestones of its startup\n+optional",
+		"vmNetworkCIDR":         "VMNetworkCIDR is the internal subnet of the masquerade interface allocated from the masquerade subnet pool of the cluster.\nIt is meant to be used by KubeVirt core components only and can't be set or modified by users.\n+optional",
+		"usage":                 "Usage holds the resource usage counters of the VirtualMachineInstance, which are reported by virt-handler\nwhen usage accounting is enabled.\n+optional",
+		"guestTimeSync":         "GuestTimeSync reports the last synchronization of the guest clock, which is set through the guest agent\nafter the VirtualMachineInstance was unpaused, migrated or resumed from hibernation.\n+optional",
+		"fsFreezeStatus":        "FSFreezeStatus is \"frozen\" while the guest file systems are frozen through the guest agent,\nand empty otherwise.\n+optional",
+		"containerDiskStatuses": "ContainerDiskStatuses reports the progress of the preparation of the containerDisks on the node,\nbefore the VirtualMachineInstance is started or migrated to the node.\n+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineInstanceGuestTimeSync) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "VirtualMachineInstanceGuestTimeSync reports the last synchronization of the guest clock with the clock of the node.\n+k8s:openapi-gen=true",
+		"synchronized":      "Synchronized indicates whether the guest clock was set",
+		"lastSyncTime":      "LastSyncTime is the time of the last attempt to set the guest clock\n+optional\n+nullable",
+		"driftMilliseconds": "DriftMilliseconds is the offset of the guest clock from the clock of the node before it was set.\nIt is positive if the guest clock was ahead, and only reported if the guest agent returned the guest time.\n+optional",
+		"message":           "Message describes why the guest clock could not be set\n+optional",
+	}
+}
+
+func (ContainerDiskStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "ContainerDiskStatus reports the preparation of a containerDisk, which is mounted into the virt-launcher pod\nand verified by virt-handler.\n+k8s:openapi-gen=true",
+		"name":    "Name is the name of the volume",
+		"phase":   "Phase is the phase of the preparation",
+		"message": "Message describes why the preparation failed\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceUsage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "VirtualMachineInstanceUsage holds the resource usage counters of a VirtualMachineInstance.\nThe counters start at zero whenever a domain is started, also on migration targets.\n+k8s:openapi-gen=true",
+		"networkReceiveBytes":  "NetworkReceiveBytes is the number of bytes received on all interfaces",
+		"networkTransmitBytes": "NetworkTransmitBytes is the number of bytes transmitted on all interfaces",
+	}
+}
+
+func (VirtualMachineInstanceStartupTimestamps) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "VirtualMachineInstanceStartupTimestamps records when the milestones of the VirtualMachineInstance startup were reached.\nTogether with the creation timestamp they allow to see where the startup time is spent.\n+k8s:openapi-gen=true",
+		"podScheduled":        "PodScheduled is the time the virt-launcher pod was scheduled to a node\n+optional",
+		"networkConfigured":   "NetworkConfigured is the time virt-handler finished to configure the pod networking\n+optional",
+		"domainDefined":       "DomainDefined is the time the domain was first reported by virt-launcher\n+optional",
+		"domainRunning":       "DomainRunning is the time the domain was first reported as running\n+optional",
+		"guestAgentConnected": "GuestAgentConnected is the time the guest agent first connected\n+optional",
 	}
 }
 
@@ -95,12 +148,17 @@ func (VirtualMachineInstanceMigrationCondition) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceNetworkInterface) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "+k8s:openapi-gen=true",
-		"ipAddress":     "IP address of a Virtual Machine interface. It is always the first item of\nIPs",
-		"mac":           "Hardware address of a Virtual Machine interface",
-		"name":          "Name of the interface, corresponds to name of the network assigned to the interface",
-		"ipAddresses":   "List of all IP addresses of a Virtual Machine interface",
-		"interfaceName": "The interface name inside the Virtual Machine",
+		"":                        "+k8s:openapi-gen=true",
+		"ipAddress":               "IP address of a Virtual Machine interface. It is always the first item of\nIPs",
+		"mac":                     "Hardware address of a Virtual Machine interface",
+		"name":                    "Name of the interface, corresponds to name of the network assigned to the interface",
+		"ipAddresses":             "List of all IP addresses of a Virtual Machine interface",
+		"interfaceName":           "The interface name inside the Virtual Machine",
+		"binding":                 "Binding method connecting the interface to the pod network: bridge, masquerade, slirp, sriov or macvtap",
+		"tapDevice":               "Name of the tap device backing the interface in the virt-launcher pod",
+		"bridgeDevice":            "Name of the bridge connecting the tap device to the pod network",
+		"queueCount":              "Effective number of queues of a multiqueue interface, after capping it at the maximum of the tap device",
+		"bridgeTXChecksumOffload": "Whether TX checksum offload is enabled on the bridge device, unset for interfaces without one",
 	}
 }
 
@@ -135,6 +193,30 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"abortStatus":                    "Indicates the final status of the live migration abortion",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
 		"mode":                           "Lets us know if the vmi is currently running pre or post copy migration",
+		"sourceNetworkState":             "The network state of the interfaces on the source node, which the target node applies before\nthe migration so that the guest keeps its MAC addresses. It is cleared once the migration completed",
+		"targetNetworkState":             "The network state of the interfaces on the target node, which the source node applies to\nthe migrated domain so that it uses the devices of the target pod. It is cleared once the migration completed",
+	}
+}
+
+func (MigrationInterfaceNetworkState) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "MigrationInterfaceNetworkState is the state of a pod network binding which has to be preserved across a migration.\n\n+k8s:openapi-gen=true",
+		"name":       "Name of the interface",
+		"mac":        "The MAC address the guest uses on the interface\n+optional",
+		"ip":         "The IPv4 address of the guest in CIDR notation\n+optional",
+		"ipv6":       "The IPv6 address of the guest in CIDR notation\n+optional",
+		"device":     "The device backing the interface in the virt-launcher pod, for macvtap bindings\n+optional",
+		"masquerade": "The nat configuration of the interface, for masquerade bindings\n+optional",
+	}
+}
+
+func (MigrationMasqueradeNetworkState) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "MigrationMasqueradeNetworkState is the nat configuration a masquerade binding was set up with on a node.\n\n+k8s:openapi-gen=true",
+		"ports":    "The ports forwarded to the guest\n+optional",
+		"hairpin":  "The hairpin mode of the port forwarding rules\n+optional",
+		"clampMSS": "Whether the maximum segment size of the TCP connections of the guest is clamped to the path MTU\n+optional",
+		"natRules": "The nat rules of the interface in iptables notation\n+optional",
 	}
 }
 
@@ -160,11 +242,12 @@ func (VirtualMachineInstanceReplicaSetList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceReplicaSetSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "+k8s:openapi-gen=true",
-		"replicas": "Number of desired pods. This is a pointer to distinguish between explicit\nzero and not specified. Defaults to 1.\n+optional",
-		"selector": "Label selector for pods. Existing ReplicaSets whose pods are\nselected by this will be the ones affected by this deployment.",
-		"template": "Template describes the pods that will be created.",
-		"paused":   "Indicates that the replica set is paused.\n+optional",
+		"":                    "+k8s:openapi-gen=true",
+		"replicas":            "Number of desired pods. This is a pointer to distinguish between explicit\nzero and not specified. Defaults to 1.\n+optional",
+		"selector":            "Label selector for pods. Existing ReplicaSets whose pods are\nselected by this will be the ones affected by this deployment.",
+		"template":            "Template describes the pods that will be created.",
+		"paused":              "Indicates that the replica set is paused.\n+optional",
+		"dataVolumeTemplates": "dataVolumeTemplates is a list of dataVolumes which are created for every\nVirtualMachineInstance of the replica set. The dataVolume volumes of the\ntemplate referencing them are pointed to the copy of their replica,\nwhich is deleted along with the VirtualMachineInstance.\n+listType=atomic\n+optional",
 	}
 }
 
@@ -253,6 +336,58 @@ func (VirtualMachineInstancePresetSpec) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineNotificationHook) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineNotificationHook registers an endpoint which is notified\nabout lifecycle events of the VirtualMachineInstances in its namespace\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (VirtualMachineNotificationHookList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineNotificationHookList is a list of VirtualMachineNotificationHooks\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (VirtualMachineNotificationHookSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "+k8s:openapi-gen=true",
+		"url":              "URL the events are sent to with a POST request. Only http and https\nURLs are supported.",
+		"events":           "Events which are sent to the URL. All events are sent if empty.\n+optional\n+listType=set",
+		"selector":         "Selector is a label query over the VMIs whose events are sent. The\nevents of all VMIs in the namespace are sent if empty.\n+optional",
+		"signingSecretRef": "SigningSecretRef references a secret in the namespace of the hook. The\nvalue of its \"key\" entry signs the events with HMAC-SHA256.\n+optional",
+		"maxRetries":       "MaxRetries is the number of times the delivery of an event is retried\nafter it failed. Defaults to 5.\n+optional",
+	}
+}
+
+func (FencingRequest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "FencingRequest asks an external fencing agent to power off or isolate a\nnode, whose virt-handler is unresponsive, before the VirtualMachineInstances\nof the node are restarted on other nodes\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"status": "+optional",
+	}
+}
+
+func (FencingRequestList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "FencingRequestList is a list of FencingRequests\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (FencingRequestSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "+k8s:openapi-gen=true",
+		"nodeName": "NodeName is the name of the node to fence",
+	}
+}
+
+func (FencingRequestStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "FencingRequestStatus is set by the fencing agent which handles the request\n\n+k8s:openapi-gen=true",
+		"phase":           "Phase is the state of the fencing of the node. The VMIs of the node\nare only restarted once it is Fenced.\n+optional",
+		"message":         "Message is a human readable detail of the phase\n+optional",
+		"fencedTimestamp": "FencedTimestamp is the time the node was fenced at\n+optional",
+	}
+}
+
 func (VirtualMachine) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "VirtualMachine handles the VirtualMachines that are not running\nor are in a stopped state\nThe VirtualMachine contains the template to create the\nVirtualMachineInstance. It also mirrors the running state of the created\nVirtualMachineInstance in its status.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
@@ -274,6 +409,78 @@ func (VirtualMachineSpec) SwaggerDoc() map[string]string {
 		"runStrategy":         "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
 		"template":            "Template is the direct specification of VirtualMachineInstance",
 		"dataVolumeTemplates": "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+		"hibernation":         "Hibernation allows to hibernate the VirtualMachine. The memory of the guest is saved to a\nPersistentVolumeClaim managed by KubeVirt and restored on the next start.\n+optional",
+		"replication":         "Replication copies the disks of the VirtualMachine asynchronously to a disaster recovery cluster,\nwhich keeps a stopped copy of the VirtualMachine to start if this cluster fails.\n+optional",
+		"powerSchedule":       "PowerSchedule starts and stops the VirtualMachine at the times of cron schedules, e.g. to stop\ndevelopment VMs at night.\n+optional",
+	}
+}
+
+func (VirtualMachineHibernation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "VirtualMachineHibernation configures the PersistentVolumeClaim holding the memory of a hibernated VirtualMachine.\n\n+k8s:openapi-gen=true",
+		"storageClassName": "StorageClassName of the PersistentVolumeClaim, the default storage class is used if empty\n+optional",
+	}
+}
+
+func (VirtualMachineReplication) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "VirtualMachineReplication configures the disaster recovery cluster the disks of a VirtualMachine are replicated to.\n\n+k8s:openapi-gen=true",
+		"pairingSecretRef": "PairingSecretRef is the name of the Secret granting access to the disaster recovery cluster, with the\nserver and token and the optional ca.crt and namespace keys",
+		"namespace":        "Namespace of the copy in the disaster recovery cluster, defaults to the namespace key of the pairing Secret\n+optional",
+		"interval":         "Interval between the copies of the volumes replicated with rsync, one hour if not set\n+optional",
+		"storageClassName": "StorageClassName of the PersistentVolumeClaims created in the disaster recovery cluster for the volumes\nreplicated with rsync, the default storage class is used if empty\n+optional",
+	}
+}
+
+func (VirtualMachinePowerSchedule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachinePowerSchedule starts and stops a VirtualMachine at the times of cron schedules.\n\n+k8s:openapi-gen=true",
+		"start":       "Start is the schedule in cron format the VirtualMachine is started at, e.g. \"0 8 * * 1-5\"\n+optional",
+		"stop":        "Stop is the schedule in cron format the VirtualMachine is stopped at, e.g. \"0 20 * * *\"\n+optional",
+		"timeZone":    "TimeZone the schedules are evaluated in, a name of the IANA time zone database like \"Europe/Berlin\".\nDefaults to UTC.\n+optional",
+		"skipIfInUse": "SkipIfInUse skips a scheduled stop while the VirtualMachine is in use, that is users are logged in to\nthe guest or the VirtualMachineInstance is migrating\n+optional",
+	}
+}
+
+func (VirtualMachinePowerScheduleStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "VirtualMachinePowerScheduleStatus reports the runs of the power schedule of a VirtualMachine.\n\n+k8s:openapi-gen=true",
+		"lastStartTime": "LastStartTime is the time of the last scheduled start\n+optional\n+nullable",
+		"nextStartTime": "NextStartTime is the time of the next scheduled start\n+optional\n+nullable",
+		"lastStopTime":  "LastStopTime is the time of the last scheduled stop\n+optional\n+nullable",
+		"nextStopTime":  "NextStopTime is the time of the next scheduled stop\n+optional\n+nullable",
+		"message":       "Message reports an invalid schedule or a skipped stop\n+optional",
+	}
+}
+
+func (VirtualMachineIdleStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "VirtualMachineIdleStatus reports the activity of a VirtualMachine watched by the idle policy.\n\n+k8s:openapi-gen=true",
+		"lastActivityTime": "LastActivityTime is the last time the guest was above a threshold of the idle policy, or its\nconsole was in use\n+optional\n+nullable",
+		"idle":             "Idle is true if the VirtualMachine had no activity for the window of the idle policy\n+optional",
+		"action":           "Action is the action taken on the idle VirtualMachine, empty until it is taken\n+optional",
+	}
+}
+
+func (VirtualMachineReplicationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "VirtualMachineReplicationStatus reports the replication of a VirtualMachine to the disaster recovery cluster.\n\n+k8s:openapi-gen=true",
+		"phase":        "Phase of the replication, the least advanced phase of the volumes\n+optional",
+		"lastSyncTime": "LastSyncTime is the time all volumes were last replicated, the state the copy in the disaster recovery\ncluster is in\n+optional\n+nullable",
+		"volumes":      "Volumes reports the replication of the volumes backed by PersistentVolumeClaims\n+optional\n+listType=atomic",
+		"message":      "Message explains the phase\n+optional",
+	}
+}
+
+func (VolumeReplicationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "VolumeReplicationStatus reports the replication of a volume of a VirtualMachine.\n\n+k8s:openapi-gen=true",
+		"name":         "Name of the volume",
+		"claimName":    "ClaimName is the name of the PersistentVolumeClaim, the copy in the disaster recovery cluster has the same name",
+		"method":       "Method the volume is replicated with",
+		"phase":        "Phase of the replication of the volume\n+optional",
+		"lastSyncTime": "LastSyncTime is the time the volume was last replicated\n+optional\n+nullable",
+		"message":      "Message explains the phase\n+optional",
 	}
 }
 
@@ -281,12 +488,65 @@ func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                       "VirtualMachineStatus represents the status returned by the\ncontroller to describe how the VirtualMachine is doing\n\n+k8s:openapi-gen=true",
 		"snapshotInProgress":     "SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing",
+		"lastSnapshotTime":       "LastSnapshotTime is the creation time of the last VirtualMachineSnapshot of the VM\nwhich became ready to use\n+optional\n+nullable",
+		"fsFreezeStatus":         "FSFreezeStatus is \"frozen\" while the guest file systems of the VirtualMachineInstance are frozen,\nand empty otherwise\n+optional",
 		"created":                "Created indicates if the virtual machine is created in the cluster",
 		"ready":                  "Ready indicates if the virtual machine is running and ready",
+		"printableStatus":        "PrintableStatus is a human readable, high-level summary of the state of the virtual machine,\nincluding the reason why it is not running if its virtual machine instance can't be started\n+optional",
 		"conditions":             "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
 		"stateChangeRequests":    "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
 		"volumeRequests":         "VolumeRequests indicates a list of volumes add or remove from the VMI template and\nhotplug on an active running VMI.\n+listType=atomic",
 		"volumeSnapshotStatuses": "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
+		"usage":                  "Usage accumulates the resources consumed by the VirtualMachine in the current and the previous\nbilling period, for chargeback. It is only maintained when usage accounting is enabled.\n+optional",
+		"hibernation":            "Hibernation reports the PersistentVolumeClaim holding the memory of the guest and whether\nthe VirtualMachine is hibernated. It is only set if hibernation is configured.\n+optional",
+		"replicationStatus":      "ReplicationStatus reports the replication of the disks to the disaster recovery cluster. It is only\nset if replication is configured.\n+optional",
+		"powerScheduleStatus":    "PowerScheduleStatus reports the last and next runs of the power schedule. It is only set if a power\nschedule is configured.\n+optional",
+		"idleStatus":             "IdleStatus reports the activity of the VirtualMachine and whether it was suspended for being idle.\nIt is only set for running VirtualMachines the idle policy applies to.\n+optional",
+	}
+}
+
+func (VirtualMachineHibernationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineHibernationStatus reports the hibernation state of a VirtualMachine.\n\n+k8s:openapi-gen=true",
+		"claimName":       "ClaimName is the name of the PersistentVolumeClaim holding the memory of the guest",
+		"size":            "Size is the capacity of the PersistentVolumeClaim, or its requested size while it is not bound\n+optional",
+		"hibernated":      "Hibernated indicates that the memory of the guest is saved and will be restored on the next start\n+optional",
+		"hibernationTime": "HibernationTime is the time the memory of the guest was saved\n+optional\n+nullable",
+	}
+}
+
+func (VirtualMachineUsage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineUsage holds the resources consumed by a VirtualMachine per billing period.\n+k8s:openapi-gen=true",
+		"current":        "Current is the usage accumulated in the running billing period",
+		"previous":       "Previous is the usage of the last completed billing period\n+optional",
+		"lastUpdateTime": "LastUpdateTime is the time the usage was last accumulated",
+		"instance":       "Instance is the state of the VirtualMachineInstance at the last accumulation.\nIt is meant to be used by KubeVirt core components only.\n+optional",
+	}
+}
+
+func (VirtualMachineUsagePeriod) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VirtualMachineUsagePeriod holds the resources consumed by a VirtualMachine in a billing period.\n+k8s:openapi-gen=true",
+		"start":                 "Start is the beginning of the billing period",
+		"end":                   "End is the end of the billing period",
+		"vcpuSeconds":           "VCPUSeconds is the number of vCPUs allocated to the VirtualMachineInstance, summed up over every second it was running",
+		"memoryMebibyteSeconds": "MemoryMebibyteSeconds is the guest memory in MiB of the VirtualMachineInstance, summed up over every second it was running",
+		"storageBytes":          "StorageBytes is the capacity of the PersistentVolumeClaims and DataVolumes of the VirtualMachine at the last accumulation",
+		"networkReceiveBytes":   "NetworkReceiveBytes is the number of bytes received by the VirtualMachineInstance",
+		"networkTransmitBytes":  "NetworkTransmitBytes is the number of bytes transmitted by the VirtualMachineInstance",
+	}
+}
+
+func (VirtualMachineUsageInstance) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "VirtualMachineUsageInstance is the state of a VirtualMachineInstance at the last usage accumulation.\n+k8s:openapi-gen=true",
+		"uid":                  "UID of the VirtualMachineInstance",
+		"running":              "Running indicates if the VirtualMachineInstance was running",
+		"vcpus":                "VCPUs is the number of vCPUs allocated to the VirtualMachineInstance",
+		"memoryMebibytes":      "MemoryMebibytes is the guest memory of the VirtualMachineInstance in MiB",
+		"networkReceiveBytes":  "NetworkReceiveBytes is the receive counter reported for the VirtualMachineInstance",
+		"networkTransmitBytes": "NetworkTransmitBytes is the transmit counter reported for the VirtualMachineInstance",
 	}
 }
 
@@ -482,9 +742,124 @@ func (RemoveVolumeOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineStartValidation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineStartValidation reports what would block the start of a VirtualMachine\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"startable": "Startable is true if none of the checks blocks the start",
+		"blockers":  "Blockers lists everything which would block the start",
+	}
+}
+
+func (VirtualMachineStartBlocker) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "VirtualMachineStartBlocker is a single reason why a VirtualMachine can't be started\n+k8s:openapi-gen=true",
+		"check":   "Check is the check which failed",
+		"message": "Message describes what blocks the start",
+	}
+}
+
+func (KubeVirtConfigurationValidation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "KubeVirtConfigurationValidation reports the workloads which a change of the KubeVirt configuration affects\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"impacts": "Impacts lists how the change affects the workloads, empty if it doesn't affect any",
+	}
+}
+
+func (KubeVirtConfigurationImpact) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "KubeVirtConfigurationImpact is a single way in which a change of the KubeVirt configuration affects workloads\n+k8s:openapi-gen=true",
+		"message":                 "Message describes the impact and how many workloads it affects",
+		"virtualMachineInstances": "VirtualMachineInstances lists the affected VirtualMachineInstances as namespace/name",
+		"virtualMachines":         "VirtualMachines lists the affected VirtualMachines as namespace/name",
+	}
+}
+
+func (VirtualMachineInstanceStats) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VirtualMachineInstanceStats holds the resource usage counters of a running VirtualMachineInstance.\nThe counters grow from the start of the VirtualMachineInstance, rates are the difference of two samples.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"timestamp":             "Timestamp is the time the counters were read",
+		"vcpus":                 "VCPUs is the number of vCPUs of the guest",
+		"cpuTimeNanoseconds":    "CPUTimeNanoseconds is the CPU time the guest used",
+		"memoryWorkingSetBytes": "MemoryWorkingSetBytes is the memory used by the guest, or the resident memory\nof qemu if the guest doesn't report its memory usage",
+		"diskReadBytes":         "DiskReadBytes is the number of bytes read from all disks",
+		"diskWriteBytes":        "DiskWriteBytes is the number of bytes written to all disks",
+		"networkReceiveBytes":   "NetworkReceiveBytes is the number of bytes received on all interfaces",
+		"networkTransmitBytes":  "NetworkTransmitBytes is the number of bytes transmitted on all interfaces",
+	}
+}
+
+func (VirtualMachineBulkOperationOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineBulkOperationOptions selects the VirtualMachines a bulk operation is applied to\nand how many of them are processed at once.\n\n+k8s:openapi-gen=true",
+		"labelSelector":  "LabelSelector selects the VirtualMachines of the namespace the operation is applied to",
+		"batchSize":      "BatchSize is the number of VirtualMachines listed and processed at once. Defaults to 50, at most 500.\n+optional",
+		"maxConcurrency": "MaxConcurrency is the number of VirtualMachines of a batch processed in parallel. Defaults to 10, at most 50.\n+optional",
+	}
+}
+
+func (VirtualMachineBulkOperationResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineBulkOperationResult summarizes the outcome of a bulk operation\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"operation": "Operation is the operation which was applied",
+		"matched":   "Matched is the number of VirtualMachines matching the label selector",
+		"succeeded": "Succeeded lists the VirtualMachines the operation was applied to",
+		"skipped":   "Skipped lists the VirtualMachines the operation does not apply to in their current state,\nlike running VirtualMachines for a start",
+		"failed":    "Failed lists the VirtualMachines the operation failed for",
+	}
+}
+
+func (VirtualMachineBulkOperationMessage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "VirtualMachineBulkOperationMessage tells why a bulk operation was not applied to a VirtualMachine\n+k8s:openapi-gen=true",
+		"name":    "Name is the name of the VirtualMachine",
+		"message": "Message tells why the operation was not applied",
+	}
+}
+
 func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "KubeVirtConfiguration holds all kubevirt configurations\n+k8s:openapi-gen=true",
+		"":                       "KubeVirtConfiguration holds all kubevirt configurations\n+k8s:openapi-gen=true",
+		"v2vConversionImage":     "V2VConversionImage is the image running virt-v2v for the conversion of\nVMs imported from other hypervisors",
+		"virtioWinImage":         "VirtioWinImage is the container disk image with the virtio-win drivers,\nwhich is attached to VMIs asking for the virtio-win driver disk",
+		"admissionPolicies":      "AdmissionPolicies reject the creation of VMIs which match them",
+		"nodeDensity":            "NodeDensity limits the number of VMIs and the memory overcommitment of every node",
+		"diskTransferImage":      "DiskTransferImage is the image with curl which uploads the disks of VMs\nimported from peer clusters, it has to be pullable in the peer cluster",
+		"replicationImage":       "ReplicationImage is the image with rsync and kubectl which copies the\ndisks of replicated VMs to the disaster recovery cluster, unless their\nstorage class has a CSI replication class",
+		"idlePolicy":             "IdlePolicy holds the activity thresholds below which VirtualMachines of the namespaces opted in are\nidle, and the action taken on idle VirtualMachines",
+		"disruptionBudgetPolicy": "DisruptionBudgetPolicy controls the PodDisruptionBudgets protecting the VMIs which are live-migrated\non evictions, one of Always, DuringMigration or Never. Defaults to Always.",
+		"cpuBaselines":           "CPUBaselines are named CPU models computed from the CPU models and features all nodes of a node pool\nsupport. VMIs referencing a baseline as CPU model are migratable between the nodes of its pool.\n+listType=atomic",
+		"allowedImportURLs":      "AllowedImportURLs are the URL prefixes VMs may be imported from. OVF descriptors and the files\nthey reference are only fetched from URLs starting with one of them.\n+listType=atomic",
+		"qosClasses":             "QoSClasses are the tiers of disk and network service VMIs select with their qosClass.\nSetting them replaces the default gold, silver and bronze classes.\n+listType=atomic",
+	}
+}
+
+func (QoSClassConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "QoSClassConfiguration holds the node level settings a VMI QoS class is mapped to\n\n+k8s:openapi-gen=true",
+		"name":            "Name of the class, which VMIs select with their qosClass",
+		"blkioWeight":     "BlkioWeight is the relative blkio weight of the virt-launcher pod cgroup, between 10 and 1000",
+		"networkRate":     "NetworkRate is the guaranteed egress rate of the tap devices in bytes per second",
+		"networkCeil":     "NetworkCeil is the maximum egress rate of the tap devices in bytes per second, at least the NetworkRate",
+		"networkPriority": "NetworkPriority is the HTB priority of the tap devices, lower values are served first\n+optional",
+	}
+}
+
+func (CPUBaseline) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "CPUBaseline selects the node pool a CPU baseline is computed for\n\n+k8s:openapi-gen=true",
+		"name":         "Name of the baseline, which VMIs reference as CPU model. It must not be the name of a CPU model\nknown to libvirt.",
+		"nodeSelector": "NodeSelector selects the nodes of the pool by their labels",
+	}
+}
+
+func (CPUBaselineStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "CPUBaselineStatus reports the CPU model and features computed for a CPU baseline\n\n+k8s:openapi-gen=true",
+		"name":     "Name of the baseline",
+		"model":    "Model is the newest CPU model all nodes of the pool support\n+optional",
+		"features": "Features are the CPU features all nodes of the pool have\n+optional\n+listType=atomic",
+		"nodes":    "Nodes is the number of nodes in the pool",
+		"message":  "Message reports why no model could be computed\n+optional",
 	}
 }
 
@@ -502,15 +877,34 @@ func (MigrationConfiguration) SwaggerDoc() map[string]string {
 
 func (DeveloperConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "DeveloperConfiguration holds developer options\n+k8s:openapi-gen=true",
+		"":                   "DeveloperConfiguration holds developer options\n+k8s:openapi-gen=true",
+		"emulationPolicy":    "EmulationPolicy controls which VMIs may run with software emulation, one of Never, OptIn or Always.\nDefaults to Always if useEmulation is set and to Never otherwise.",
+		"scopedFeatureGates": "ScopedFeatureGates enable feature gates for the workloads of the selected namespaces only.",
+	}
+}
+
+func (ScopedFeatureGate) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "ScopedFeatureGate enables a feature gate for the workloads of the namespaces\nit selects. The webhooks reject workloads using the feature in other namespaces.\n+k8s:openapi-gen=true",
+		"name":              "Name of the feature gate, e.g. Macvtap",
+		"namespaces":        "Namespaces the feature gate is enabled in",
+		"namespaceSelector": "NamespaceSelector selects the namespaces the feature gate is enabled in by their labels",
+	}
+}
+
+func (LogVerbosity) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "LogVerbosity sets log verbosity level of the various components\n+k8s:openapi-gen=true",
 	}
 }
 
 func (PermittedHostDevices) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                "PermittedHostDevices holds inforamtion about devices allowed for passthrough\n+k8s:openapi-gen=true",
-		"pciHostDevices":  "+listType=atomic",
-		"mediatedDevices": "+listType=atomic",
+		"":                   "PermittedHostDevices holds inforamtion about devices allowed for passthrough\n+k8s:openapi-gen=true",
+		"pciHostDevices":     "+listType=atomic",
+		"mediatedDevices":    "+listType=atomic",
+		"genericHostDevices": "GenericHostDevices are device nodes of the hosts which VMIs can request as\ndevices.kubevirt.io/<name> resources, like /dev/sev or /dev/vfio/vfio\n+listType=atomic",
+		"nvmeNamespaces":     "NvmeNamespaces are NVMe namespaces which VMIs can request as host devices,\neach namespace is passed through with the NVMe controller it is attached to\n+listType=atomic",
 	}
 }
 
@@ -526,8 +920,104 @@ func (MediatedHostDevice) SwaggerDoc() map[string]string {
 	}
 }
 
+func (GenericHostDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "GenericHostDevice represents a device node of the host which is exposed as a resource\n+k8s:openapi-gen=true",
+		"name":    "Name of the device, the resource is devices.kubevirt.io/<name>",
+		"path":    "Path of the device node on the host",
+		"preOpen": "PreOpen opens the device node once before the resource is advertised,\nto load the kernel modules of the device\n+optional",
+	}
+}
+
+func (NvmeNamespaceHostDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                         "NvmeNamespaceHostDevice represents NVMe namespaces allowed for passthrough. VFIO passes\nthrough PCI functions, a namespace is therefore passed through with the NVMe controller\nit is attached to, which has to be a virtual function of an SR-IOV capable NVMe drive\nwith this namespace as its only namespace.\n+k8s:openapi-gen=true",
+		"pciVendorSelector":        "PCIVendorSelector selects the NVMe controllers by their vendor:device ID",
+		"resourceName":             "ResourceName is the name of the resource the namespaces are advertised as",
+		"externalResourceProvider": "ExternalResourceProvider indicates that the namespaces are advertised by another device plugin\n+optional",
+	}
+}
+
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"":                         "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"bridge":                   "Bridge tunes the bridges which connect bridge and masquerade interfaces to the pod network",
+		"masqueradeSubnetPool":     "MasqueradeSubnetPool is the pool which the internal subnets of masquerade interfaces are allocated from,\nwhen the pod network does not set a vmNetworkCIDR",
+		"defaultVMNetworkCIDR":     "DefaultVMNetworkCIDR is the vmNetworkCIDR of pod networks with a masquerade interface which do not set one.\nIt is not applied when a masquerade subnet pool is configured. Defaults to 10.0.2.0/24",
+		"defaultVMIPv6NetworkCIDR": "DefaultVMIPv6NetworkCIDR is the vmIPv6NetworkCIDR of pod networks with a masquerade interface which do not set one.\nDefaults to fd10:0:2::/120",
+	}
+}
+
+func (MasqueradeSubnetPool) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "MasqueradeSubnetPool is an IPv4 range which is split into subnets of the same size.\nEvery VMI with a masquerade interface gets a subnet which is not used by any other VMI.\n+k8s:openapi-gen=true",
+		"cidr":         "CIDR is the IPv4 range of the pool",
+		"prefixLength": "PrefixLength is the prefix length of the subnets allocated from the pool, between the prefix length of the pool and 30. Defaults to 24",
+	}
+}
+
+func (BridgeConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "BridgeConfiguration holds the parameters of the bridges created in the virt-launcher pods.\nUnset fields keep the defaults of the kernel.\n+k8s:openapi-gen=true",
+		"stp":               "STP enables the spanning tree protocol on the bridges",
+		"ageingTimeSeconds": "AgeingTimeSeconds is how long the bridges keep a learned MAC address in their forwarding database",
+		"multicastSnooping": "MulticastSnooping enables IGMP and MLD snooping on the bridges",
+		"multicastQuerier":  "MulticastQuerier runs an IGMP and MLD querier on the bridges, so that snooping keeps forwarding multicast groups when the pod network has no querier",
+		"vlanFiltering":     "VLANFiltering enables VLAN filtering on the bridges",
+	}
+}
+
+func (UsageAccountingConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "UsageAccountingConfiguration holds options of the per-VM resource usage accounting\n+k8s:openapi-gen=true",
+		"billingPeriod":         "BillingPeriod after which the usage of VirtualMachines is reset, one of Daily, Weekly or Monthly.\nPeriods start at midnight UTC, weeks on Monday.",
+		"updateIntervalSeconds": "UpdateIntervalSeconds is the time between two accumulations of the usage of a running VirtualMachine",
+	}
+}
+
+func (NodeDensityConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "NodeDensityConfiguration limits the VMIs a node runs with extended resources,\nwhich virt-handler advertises on the node and virt-launcher pods request\n+k8s:openapi-gen=true",
+		"maxVMIsPerNode":         "MaxVMIsPerNode is the number of VMIs a node can run at the same time.\nIt is overridden for a node by its kubevirt.io/max-vmis annotation.\n+optional",
+		"memoryOvercommitBudget": "MemoryOvercommitBudget is the guest memory a node can give to its VMIs beyond the memory\nrequested by their virt-launcher pods. It is overridden for a node by its\nkubevirt.io/memory-overcommit-budget annotation.\n+optional",
+	}
+}
+
+func (IdlePolicyConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "IdlePolicyConfiguration holds the activity thresholds below which running VirtualMachines are idle,\nand the action taken on VirtualMachines which were idle for a whole window\n+k8s:openapi-gen=true",
+		"action":                "Action taken on idle VirtualMachines, one of Pause or Hibernate. VirtualMachines without\nhibernation configured are paused. Defaults to Pause.",
+		"windowSeconds":         "WindowSeconds is how long a VirtualMachine has to stay below all thresholds to be idle",
+		"sampleIntervalSeconds": "SampleIntervalSeconds is the time between two reads of the stats of a running VirtualMachine",
+		"cpuThreshold":          "CPUThreshold is the CPU time per second the guest uses on all its vCPUs, e.g. 50m, below which it\nis idle",
+		"networkThreshold":      "NetworkThreshold is the number of bytes per second the guest receives and transmits on all its\ninterfaces, below which it is idle",
+	}
+}
+
+func (VMIAdmissionPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "VMIAdmissionPolicy rejects the creation of VMIs which match all of its rules\n+k8s:openapi-gen=true",
+		"name":       "Name identifies the policy in the messages of rejected VMIs",
+		"namespaces": "Namespaces the policy applies to, all namespaces if empty",
+		"rules":      "Rules which all have to match a VMI to reject it",
+		"message":    "Message is returned to the user when a VMI is rejected",
+	}
+}
+
+func (VMIAdmissionRule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VMIAdmissionRule matches the values a JSONPath expression selects from a VMI\n+k8s:openapi-gen=true",
+		"path":     "Path is a JSONPath expression evaluated against the VMI, e.g. {.spec.domain.cpu.cores}",
+		"operator": "Operator is one of In, NotIn, Exists, DoesNotExist, Gt and Lt.\nIn, NotIn, Gt and Lt match if any selected value matches.",
+		"values":   "Values the selected values are compared to, a single quantity like 4 or 8Gi for Gt and Lt",
+	}
+}
+
+func (ConsoleConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "ConsoleConfiguration holds options of the serial console and VNC proxy\n+k8s:openapi-gen=true",
+		"maxSessions":         "MaxSessions limits the number of sessions proxied concurrently by every virt-api instance, 0 means unlimited",
+		"idleTimeoutSeconds":  "IdleTimeoutSeconds closes sessions without traffic in either direction for this long, 0 disables the timeout",
+		"requireAccessReason": "RequireAccessReason rejects sessions to VMIs which do not carry the kubevirt.io/console-access-reason annotation",
 	}
 }
@@ -431,6 +431,132 @@ func (in *VirtualMachineSnapshotList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotSchedule) DeepCopyInto(out *VirtualMachineSnapshotSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(VirtualMachineSnapshotScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotSchedule.
+func (in *VirtualMachineSnapshotSchedule) DeepCopy() *VirtualMachineSnapshotSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSnapshotSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotScheduleList) DeepCopyInto(out *VirtualMachineSnapshotScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineSnapshotSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotScheduleList.
+func (in *VirtualMachineSnapshotScheduleList) DeepCopy() *VirtualMachineSnapshotScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSnapshotScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotScheduleSpec) DeepCopyInto(out *VirtualMachineSnapshotScheduleSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotScheduleSpec.
+func (in *VirtualMachineSnapshotScheduleSpec) DeepCopy() *VirtualMachineSnapshotScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotScheduleStatus) DeepCopyInto(out *VirtualMachineSnapshotScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSnapshotName != nil {
+		in, out := &in.LastSnapshotName, &out.LastSnapshotName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(Error)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotScheduleStatus.
+func (in *VirtualMachineSnapshotScheduleStatus) DeepCopy() *VirtualMachineSnapshotScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSnapshotSpec) DeepCopyInto(out *VirtualMachineSnapshotSpec) {
 	*out = *in
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -288,7 +289,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.Bootloader":                                            schema_kubevirtio_client_go_api_v1_Bootloader(ref),
 		"kubevirt.io/client-go/api/v1.CDRomTarget":                                           schema_kubevirtio_client_go_api_v1_CDRomTarget(ref),
 		"kubevirt.io/client-go/api/v1.CPU":                                                   schema_kubevirtio_client_go_api_v1_CPU(ref),
+		"kubevirt.io/client-go/api/v1.CPUBaseline":                                           schema_kubevirtio_client_go_api_v1_CPUBaseline(ref),
+		"kubevirt.io/client-go/api/v1.CPUBaselineStatus":                                     schema_kubevirtio_client_go_api_v1_CPUBaselineStatus(ref),
 		"kubevirt.io/client-go/api/v1.CPUFeature":                                            schema_kubevirtio_client_go_api_v1_CPUFeature(ref),
+		"kubevirt.io/client-go/api/v1.Channel":                                               schema_kubevirtio_client_go_api_v1_Channel(ref),
 		"kubevirt.io/client-go/api/v1.Chassis":                                               schema_kubevirtio_client_go_api_v1_Chassis(ref),
 		"kubevirt.io/client-go/api/v1.Clock":                                                 schema_kubevirtio_client_go_api_v1_Clock(ref),
 		"kubevirt.io/client-go/api/v1.ClockOffset":                                           schema_kubevirtio_client_go_api_v1_ClockOffset(ref),
@@ -299,6 +303,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation":    schema_kubevirtio_client_go_api_v1_ConfigDriveSSHPublicKeyAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.ConfigMapVolumeSource":                                 schema_kubevirtio_client_go_api_v1_ConfigMapVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.ContainerDiskSource":                                   schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref),
+		"kubevirt.io/client-go/api/v1.ContainerDiskStatus":                                   schema_kubevirtio_client_go_api_v1_ContainerDiskStatus(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponents":                                   schema_kubevirtio_client_go_api_v1_CustomizeComponents(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponentsPatch":                              schema_kubevirtio_client_go_api_v1_CustomizeComponentsPatch(ref),
 		"kubevirt.io/client-go/api/v1.DHCPOptions":                                           schema_kubevirtio_client_go_api_v1_DHCPOptions(ref),
@@ -315,6 +320,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource":                               schema_kubevirtio_client_go_api_v1_DownwardAPIVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.EFI":                                                   schema_kubevirtio_client_go_api_v1_EFI(ref),
 		"kubevirt.io/client-go/api/v1.EmptyDiskSource":                                       schema_kubevirtio_client_go_api_v1_EmptyDiskSource(ref),
+		"kubevirt.io/client-go/api/v1.EphemeralImage":                                        schema_kubevirtio_client_go_api_v1_EphemeralImage(ref),
 		"kubevirt.io/client-go/api/v1.EphemeralVolumeSource":                                 schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.FeatureAPIC":                                           schema_kubevirtio_client_go_api_v1_FeatureAPIC(ref),
 		"kubevirt.io/client-go/api/v1.FeatureHyperv":                                         schema_kubevirtio_client_go_api_v1_FeatureHyperv(ref),
@@ -323,11 +329,16 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.FeatureState":                                          schema_kubevirtio_client_go_api_v1_FeatureState(ref),
 		"kubevirt.io/client-go/api/v1.FeatureVendorID":                                       schema_kubevirtio_client_go_api_v1_FeatureVendorID(ref),
 		"kubevirt.io/client-go/api/v1.Features":                                              schema_kubevirtio_client_go_api_v1_Features(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequest":                                        schema_kubevirtio_client_go_api_v1_FencingRequest(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequestList":                                    schema_kubevirtio_client_go_api_v1_FencingRequestList(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequestSpec":                                    schema_kubevirtio_client_go_api_v1_FencingRequestSpec(ref),
+		"kubevirt.io/client-go/api/v1.FencingRequestStatus":                                  schema_kubevirtio_client_go_api_v1_FencingRequestStatus(ref),
 		"kubevirt.io/client-go/api/v1.Filesystem":                                            schema_kubevirtio_client_go_api_v1_Filesystem(ref),
 		"kubevirt.io/client-go/api/v1.FilesystemVirtiofs":                                    schema_kubevirtio_client_go_api_v1_FilesystemVirtiofs(ref),
 		"kubevirt.io/client-go/api/v1.Firmware":                                              schema_kubevirtio_client_go_api_v1_Firmware(ref),
 		"kubevirt.io/client-go/api/v1.FloppyTarget":                                          schema_kubevirtio_client_go_api_v1_FloppyTarget(ref),
 		"kubevirt.io/client-go/api/v1.GPU":                                                   schema_kubevirtio_client_go_api_v1_GPU(ref),
+		"kubevirt.io/client-go/api/v1.GenericHostDevice":                                     schema_kubevirtio_client_go_api_v1_GenericHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.HPETTimer":                                             schema_kubevirtio_client_go_api_v1_HPETTimer(ref),
 		"kubevirt.io/client-go/api/v1.HostDevice":                                            schema_kubevirtio_client_go_api_v1_HostDevice(ref),
 		"kubevirt.io/client-go/api/v1.HostDisk":                                              schema_kubevirtio_client_go_api_v1_HostDisk(ref),
@@ -357,12 +368,16 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.Machine":                                               schema_kubevirtio_client_go_api_v1_Machine(ref),
 		"kubevirt.io/client-go/api/v1.MediatedHostDevice":                                    schema_kubevirtio_client_go_api_v1_MediatedHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.Memory":                                                schema_kubevirtio_client_go_api_v1_Memory(ref),
+		"kubevirt.io/client-go/api/v1.MetadataService":                                       schema_kubevirtio_client_go_api_v1_MetadataService(ref),
 		"kubevirt.io/client-go/api/v1.MigrationConfiguration":                                schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.MultusNetwork":                                         schema_kubevirtio_client_go_api_v1_MultusNetwork(ref),
+		"kubevirt.io/client-go/api/v1.NUMA":                                                  schema_kubevirtio_client_go_api_v1_NUMA(ref),
+		"kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough":                           schema_kubevirtio_client_go_api_v1_NUMAGuestMappingPassthrough(ref),
 		"kubevirt.io/client-go/api/v1.Network":                                               schema_kubevirtio_client_go_api_v1_Network(ref),
 		"kubevirt.io/client-go/api/v1.NetworkConfiguration":                                  schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.NetworkSource":                                         schema_kubevirtio_client_go_api_v1_NetworkSource(ref),
 		"kubevirt.io/client-go/api/v1.NodePlacement":                                         schema_kubevirtio_client_go_api_v1_NodePlacement(ref),
+		"kubevirt.io/client-go/api/v1.NvmeNamespaceHostDevice":                               schema_kubevirtio_client_go_api_v1_NvmeNamespaceHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.PITTimer":                                              schema_kubevirtio_client_go_api_v1_PITTimer(ref),
 		"kubevirt.io/client-go/api/v1.PciHostDevice":                                         schema_kubevirtio_client_go_api_v1_PciHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.PermittedHostDevices":                                  schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref),
@@ -386,8 +401,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredential":                          schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialPropagationMethod":         schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialPropagationMethod(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialSource":                    schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialSource(ref),
+		"kubevirt.io/client-go/api/v1.VhostUserBlkSource":                                    schema_kubevirtio_client_go_api_v1_VhostUserBlkSource(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachine":                                        schema_kubevirtio_client_go_api_v1_VirtualMachine(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineCondition":                               schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineHibernation":                             schema_kubevirtio_client_go_api_v1_VirtualMachineHibernation(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineHibernationStatus":                       schema_kubevirtio_client_go_api_v1_VirtualMachineHibernationStatus(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineIdleStatus":                              schema_kubevirtio_client_go_api_v1_VirtualMachineIdleStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstance":                                schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition":                       schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystem(ref),
@@ -397,6 +416,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo":                     schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSInfo(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUser":                     schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUser(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSUserList":                 schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUserList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestTimeSync":                   schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestTimeSync(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceList":                            schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceList(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration":                       schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigration(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition":              schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationCondition(ref),
@@ -417,11 +437,20 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus":                          schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec":                    schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceTemplateSpec(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineList":                                    schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineNotificationHook":                        schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHook(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookList":                    schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookSpec":                    schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookSpec(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachinePowerSchedule":                           schema_kubevirtio_client_go_api_v1_VirtualMachinePowerSchedule(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachinePowerScheduleStatus":                     schema_kubevirtio_client_go_api_v1_VirtualMachinePowerScheduleStatus(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineReplication":                             schema_kubevirtio_client_go_api_v1_VirtualMachineReplication(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineReplicationStatus":                       schema_kubevirtio_client_go_api_v1_VirtualMachineReplicationStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineSpec":                                    schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest":                      schema_kubevirtio_client_go_api_v1_VirtualMachineStateChangeRequest(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineStatus":                                  schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest":                           schema_kubevirtio_client_go_api_v1_VirtualMachineVolumeRequest(ref),
 		"kubevirt.io/client-go/api/v1.Volume":                                                schema_kubevirtio_client_go_api_v1_Volume(ref),
+		"kubevirt.io/client-go/api/v1.VolumeEncryption":                                      schema_kubevirtio_client_go_api_v1_VolumeEncryption(ref),
+		"kubevirt.io/client-go/api/v1.VolumeReplicationStatus":                               schema_kubevirtio_client_go_api_v1_VolumeReplicationStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSnapshotStatus":                                  schema_kubevirtio_client_go_api_v1_VolumeSnapshotStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSource":                                          schema_kubevirtio_client_go_api_v1_VolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.VolumeStatus":                                          schema_kubevirtio_client_go_api_v1_VolumeStatus(ref),
@@ -441,6 +470,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotContentSpec":     schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotContentSpec(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotContentStatus":   schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotContentStatus(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotList":            schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotList(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSchedule":        schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSchedule(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleList":    schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleList(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleSpec":    schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleSpec(ref),
+		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleStatus":  schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleStatus(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSpec":            schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSpec(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotStatus":          schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotStatus(ref),
 		"kubevirt.io/client-go/apis/snapshot/v1alpha1.VolumeBackup":                          schema_client_go_apis_snapshot_v1alpha1_VolumeBackup(ref),
@@ -13604,11 +13637,128 @@ func schema_kubevirtio_client_go_api_v1_CPU(ref common.ReferenceCallback) common
 							Format:      "",
 						},
 					},
+					"nestedVirtualization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NestedVirtualization exposes the virtualization extensions of the host CPU (vmx or svm) to the guest and requests the scheduler to place the VirtualMachineInstance on a node with nested KVM enabled.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"numa": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NUMA allows specifying settings for the guest NUMA topology",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NUMA"),
+						},
+					},
+					"sideChannelIsolation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SideChannelIsolation requests the scheduler to place the VirtualMachineInstance on a node which is not vulnerable to the CPU side-channel attacks reported by its kernel, has SMT disabled where it is a risk and flushes the L1 data cache on every VM entry where L1TF applies.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CPUFeature"},
+			"kubevirt.io/client-go/api/v1.CPUFeature", "kubevirt.io/client-go/api/v1.NUMA"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_CPUBaseline(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CPUBaseline selects the node pool a CPU baseline is computed for",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the baseline, which VMIs reference as CPU model. It must not be the name of a CPU model known to libvirt.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector selects the nodes of the pool by their labels",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "nodeSelector"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_CPUBaselineStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CPUBaselineStatus reports the CPU model and features computed for a CPU baseline",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the baseline",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"model": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model is the newest CPU model all nodes of the pool support",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"features": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Features are the CPU features all nodes of the pool have",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes is the number of nodes in the pool",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message reports why no model could be computed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "nodes"},
+			},
+		},
 	}
 }
 
@@ -13640,6 +13790,26 @@ func schema_kubevirtio_client_go_api_v1_CPUFeature(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_Channel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the virtio-serial port, like org.example.agent.0. The guest finds the channel at /dev/virtio-ports/<name>.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Chassis(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -13974,10 +14144,60 @@ func schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref common.Reference
 							Format:      "",
 						},
 					},
+					"checksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checksum is the expected digest of the disk in the image, in the form sha256:<hex>. The disk is verified before the VMI is started, which fails on a mismatch.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ephemeralImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EphemeralImage configures the writable image the VMI runs on.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.EphemeralImage"),
+						},
+					},
 				},
 				Required: []string{"image"},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.EphemeralImage"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_ContainerDiskStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ContainerDiskStatus reports the preparation of a containerDisk, which is mounted into the virt-launcher pod and verified by virt-handler.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase of the preparation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes why the preparation failed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
 
@@ -14069,6 +14289,20 @@ func schema_kubevirtio_client_go_api_v1_DHCPOptions(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"nextServer": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If specified will set the next server address (siaddr) of the replies of interface's DHCP server, the address of the boot server. Must be an IPv4 address.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tftpProxy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If set, virt-launcher relays the TFTP requests of the guest, which it sends to the gateway of a masquerade interface, to the next server or the TFTP server. The DHCP server passes the gateway as next server and TFTP server then.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"ntpServers": {
 						SchemaProps: spec.SchemaProps{
 							Description: "If specified will pass the configured NTP server to the VM via DHCP option 042.",
@@ -14145,6 +14379,13 @@ func schema_kubevirtio_client_go_api_v1_DataVolumeSource(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"checksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checksum is the expected digest of the imported disk, in the form sha256:<hex>. The disk is verified once after the import, VMIs using it are not started before it matched.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -14330,6 +14571,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"usbControllerModel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model of the USB controller, which is attached when an input device is on the usb bus. Supported values: qemu-xhci, nec-xhci. Defaults to qemu-xhci.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"autoattachPodInterface": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to attach a pod network interface. Defaults to true.",
@@ -14344,6 +14592,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"autoattachInputDevice": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to attach a tablet when the graphics device is attached and no inputs are specified. The tablet keeps the cursor of VNC clients in line with the one of the guest. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"autoattachSerialConsole": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to attach the default serial console or not. Serial console access will not be available if set to false. Defaults to true.",
@@ -14358,6 +14613,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"autoattachVirtioWinDriverDisk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to attach the virtio-win driver disk as a CD-ROM, which holds the virtio drivers Windows needs to install on virtio disks and to use virtio network interfaces. The image of the disk is configured in the KubeVirt CR. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"rng": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to have random number generator from host",
@@ -14378,6 +14640,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"networkInterfaceQueues": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of queues of the virtio interfaces with networkInterfaceMultiqueue. Defaults to the number of vCPUs, capped at 256, the maximum number of queues of a tap device.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"gpus": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -14432,11 +14701,29 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"channels": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Channels are additional virtio-serial channels for custom communication between the host and the guest.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.Channel"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.Watchdog"},
+			"kubevirt.io/client-go/api/v1.Channel", "kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.Watchdog"},
 	}
 }
 
@@ -14491,6 +14778,13 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
+					"wwn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WWN provides the ability to specify the World Wide Name of the disk device, as 16 hexadecimal digits. Only supported for disks on the sata and scsi bus.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"dedicatedIOThread": {
 						SchemaProps: spec.SchemaProps{
 							Description: "dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.",
@@ -14514,7 +14808,7 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 					},
 					"tag": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, disk address and its tag will be provided to the guest via config drive metadata",
+							Description: "If specified, disk address and its tag will be provided to the guest via config drive metadata. Disks with a serial additionally get an SMBIOS OEM string io.kubevirt.disk.tag:<tag>=<serial>",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -14667,6 +14961,13 @@ func schema_kubevirtio_client_go_api_v1_DomainSpec(ref common.ReferenceCallback)
 							Ref:         ref("kubevirt.io/client-go/api/v1.Chassis"),
 						},
 					},
+					"allowEmulation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowEmulation overrides whether the VMI may fall back to software emulation on nodes without /dev/kvm, within the emulation policy of the cluster.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"devices"},
 			},
@@ -14753,6 +15054,40 @@ func schema_kubevirtio_client_go_api_v1_EmptyDiskSource(ref common.ReferenceCall
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_EphemeralImage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EphemeralImage configures the writable image which is created from a containerDisk or ephemeral volume when the VMI starts.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format of the writable image. qcow2 creates a copy-on-write overlay backed by the source image, raw converts the source image into a raw image. Defaults to qcow2.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"preallocation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Preallocation mode of a raw image. One of off, falloc or full. Defaults to off.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"compressed": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Compressed converts the source image into a compressed qcow2 image instead of creating an overlay. Only supported for the qcow2 format.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -14765,11 +15100,17 @@ func schema_kubevirtio_client_go_api_v1_EphemeralVolumeSource(ref common.Referen
 							Ref:         ref("k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"),
 						},
 					},
+					"ephemeralImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EphemeralImage configures the writable image the VMI runs on.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.EphemeralImage"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.EphemeralImage"},
 	}
 }
 
@@ -15033,24 +15374,171 @@ func schema_kubevirtio_client_go_api_v1_Features(ref common.ReferenceCallback) c
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_Filesystem(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_FencingRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "FencingRequest asks an external fencing agent to power off or isolate a node, whose virt-handler is unresponsive, before the VirtualMachineInstances of the node are restarted on other nodes",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name is the device name",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"virtiofs": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Virtiofs is supported",
-							Ref:         ref("kubevirt.io/client-go/api/v1.FilesystemVirtiofs"),
-						},
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.FencingRequestSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.FencingRequestStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.FencingRequestSpec", "kubevirt.io/client-go/api/v1.FencingRequestStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FencingRequestList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FencingRequestList is a list of FencingRequests",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.FencingRequest"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.FencingRequest"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FencingRequestSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeName is the name of the node to fence",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"nodeName"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_FencingRequestStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FencingRequestStatus is set by the fencing agent which handles the request",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the state of the fencing of the node. The VMIs of the node are only restarted once it is Fenced.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable detail of the phase",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fencedTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FencedTimestamp is the time the node was fenced at",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_Filesystem(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the device name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"virtiofs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Virtiofs is supported",
+							Ref:         ref("kubevirt.io/client-go/api/v1.FilesystemVirtiofs"),
+						},
 					},
 				},
 				Required: []string{"name", "virtiofs"},
@@ -15097,6 +15585,13 @@ func schema_kubevirtio_client_go_api_v1_Firmware(ref common.ReferenceCallback) c
 							Format:      "",
 						},
 					},
+					"identityPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdentityPolicy controls how the UUID and the serial are generated when they are not set. Keep derives the UUID from the name of the VirtualMachine, so that it survives clones and restores. RegenerateOnClone derives the UUID and the serial from the uid of the VirtualMachine, so that clones get new ones, while restores keep them. Defaults to Keep.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -15157,6 +15652,41 @@ func schema_kubevirtio_client_go_api_v1_GPU(ref common.ReferenceCallback) common
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_GenericHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GenericHostDevice represents a device node of the host which is exposed as a resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the device, the resource is devices.kubevirt.io/<name>",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path of the device node on the host",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"preOpen": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreOpen opens the device node once before the resource is advertised, to load the kernel modules of the device",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "path"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_HPETTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -15380,7 +15910,7 @@ func schema_kubevirtio_client_go_api_v1_Input(ref common.ReferenceCallback) comm
 					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type indicated the type of input device. Supported values: tablet.",
+							Description: "Type indicated the type of input device. Supported values: tablet, keyboard.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -15392,6 +15922,13 @@ func schema_kubevirtio_client_go_api_v1_Input(ref common.ReferenceCallback) comm
 							Format:      "",
 						},
 					},
+					"model": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model indicates the model of a virtio input device. Supported values: virtio, virtio-transitional, virtio-non-transitional.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"type", "name"},
 			},
@@ -15486,7 +16023,7 @@ func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback)
 					},
 					"tag": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, the virtual network interface address and its tag will be provided to the guest via config drive",
+							Description: "If specified, the virtual network interface address and its tag will be provided to the guest via config drive. The tag is also published as SMBIOS OEM string io.kubevirt.interface.tag:<tag>=<MAC address>",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -16083,11 +16620,23 @@ func schema_kubevirtio_client_go_api_v1_KubeVirtStatus(ref common.ReferenceCallb
 							Format: "",
 						},
 					},
+					"cpuBaselines": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.CPUBaselineStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.KubeVirtCondition"},
+			"kubevirt.io/client-go/api/v1.CPUBaselineStatus", "kubevirt.io/client-go/api/v1.KubeVirtCondition"},
 	}
 }
 
@@ -16196,6 +16745,17 @@ func schema_kubevirtio_client_go_api_v1_Memory(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MetadataService(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MetadataService enables the metadata service of the guest, which is served by virt-launcher.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16273,7 +16833,7 @@ func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallba
 				Properties: map[string]spec.Schema{
 					"networkName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed.",
+							Description: "References to a NetworkAttachmentDefinition CRD object. Format: <networkName>, <namespace>/<networkName>. If namespace is not specified, VMI namespace is assumed. Referencing a NetworkAttachmentDefinition of another namespace requires the ServiceAccount of the VMI to be granted the use verb on it in that namespace, for example through a RoleBinding.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -16292,6 +16852,38 @@ func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NUMA(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NUMA allows specifying settings for the guest NUMA topology.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"guestMappingPassthrough": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestMappingPassthrough will create an efficient guest topology based on host CPUs exclusively assigned to a pod. The created topology ensures that memory and CPUs on the virtual numa nodes never cross boundaries of host numa nodes. Requires DedicatedCPUPlacement and Hugepages.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_NUMAGuestMappingPassthrough(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NUMAGuestMappingPassthrough requests a guest NUMA topology mirroring the host NUMA nodes of the pinned pCPUs.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Network(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16430,6 +17022,41 @@ func schema_kubevirtio_client_go_api_v1_NodePlacement(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NvmeNamespaceHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NvmeNamespaceHostDevice represents NVMe namespaces allowed for passthrough. VFIO passes through PCI functions, a namespace is therefore passed through with the NVMe controller it is attached to, which has to be a virtual function of an SR-IOV capable NVMe drive with this namespace as its only namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pciVendorSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PCIVendorSelector selects the NVMe controllers by their vendor:device ID",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceName is the name of the resource the namespaces are advertised as",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"externalResourceProvider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalResourceProvider indicates that the namespaces are advertised by another device plugin",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"pciVendorSelector", "resourceName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_PITTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -16529,18 +17156,54 @@ func schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref common.Referenc
 							},
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice"},
-	}
-}
-
-func schema_kubevirtio_client_go_api_v1_PodNetwork(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
+					"genericHostDevices": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "GenericHostDevices are device nodes of the hosts which VMIs can request as devices.kubevirt.io/<name> resources, like /dev/sev or /dev/vfio/vfio",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.GenericHostDevice"),
+									},
+								},
+							},
+						},
+					},
+					"nvmeNamespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "NvmeNamespaces are NVMe namespaces which VMIs can request as host devices, each namespace is passed through with the NVMe controller it is attached to",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.NvmeNamespaceHostDevice"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.GenericHostDevice", "kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.NvmeNamespaceHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_PodNetwork(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
 				Description: "Represents the stock pod network interface.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
@@ -17131,6 +17794,34 @@ func schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialSource(ref c
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VhostUserBlkSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VhostUserBlkSource references the vhost-user-blk socket of a storage backend like SPDK, which qemu connects the disk to instead of opening a disk image.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of a PersistentVolumeClaim in the same namespace, whose volume contains the socket, like the volumes of an SPDK CSI driver",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"socket": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Socket is the path of the vhost-user-blk socket in the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"claimName", "socket"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachine(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17228,6 +17919,103 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref common.Refer
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineHibernation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineHibernation configures the PersistentVolumeClaim holding the memory of a hibernated VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClassName of the PersistentVolumeClaim, the default storage class is used if empty",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineHibernationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineHibernationStatus reports the hibernation state of a VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PersistentVolumeClaim holding the memory of the guest",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"size": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Size is the capacity of the PersistentVolumeClaim, or its requested size while it is not bound",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"hibernated": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hibernated indicates that the memory of the guest is saved and will be restored on the next start",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"hibernationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HibernationTime is the time the memory of the guest was saved",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"claimName"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineIdleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineIdleStatus reports the activity of a VirtualMachine watched by the idle policy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastActivityTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastActivityTime is the last time the guest was above a threshold of the idle policy, or its console was in use",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"idle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Idle is true if the VirtualMachine had no activity for the window of the idle policy",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action is the action taken on the idle VirtualMachine, empty until it is taken",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -17665,6 +18453,49 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestOSUserList(re
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestTimeSync(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceGuestTimeSync reports the last synchronization of the guest clock with the clock of the node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"synchronized": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Synchronized indicates whether the guest clock was set",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the time of the last attempt to set the guest clock",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"driftMilliseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DriftMilliseconds is the offset of the guest clock from the clock of the node before it was set. It is positive if the guest clock was ahead, and only reported if the guest agent returned the guest time.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes why the guest clock could not be set",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"synchronized"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -18365,12 +19196,30 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetSpec(ref
 							Format:      "",
 						},
 					},
+					"dataVolumeTemplates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "dataVolumeTemplates is a list of dataVolumes which are created for every VirtualMachineInstance of the replica set. The dataVolume volumes of the template referencing them are pointed to the copy of their replica, which is deleted along with the VirtualMachineInstance.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"selector", "template"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
 	}
 }
 
@@ -18579,12 +19428,25 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.Re
 							},
 						},
 					},
+					"metadataService": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MetadataService serves the metadata and the user-data of the vmi and the token of its service account to the guest on the link-local address 169.254.169.254. Requires a masquerade interface on the pod network.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MetadataService"),
+						},
+					},
+					"guestOS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestOS declares the operating system of the guest, e.g. \"windows2k19\" or \"rhel8\". The disk bus, the interface model, the clock and the inputs which are neither set on the vmi nor by a preset are defaulted to what suits the guest OS.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"domain"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.Toleration", "kubevirt.io/client-go/api/v1.AccessCredential", "kubevirt.io/client-go/api/v1.DomainSpec", "kubevirt.io/client-go/api/v1.Network", "kubevirt.io/client-go/api/v1.Probe", "kubevirt.io/client-go/api/v1.Volume"},
+			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.Toleration", "kubevirt.io/client-go/api/v1.AccessCredential", "kubevirt.io/client-go/api/v1.DomainSpec", "kubevirt.io/client-go/api/v1.MetadataService", "kubevirt.io/client-go/api/v1.Network", "kubevirt.io/client-go/api/v1.Probe", "kubevirt.io/client-go/api/v1.Volume"},
 	}
 }
 
@@ -18708,11 +19570,35 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.
 							},
 						},
 					},
+					"guestTimeSync": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestTimeSync reports the last synchronization of the guest clock, which is set through the guest agent after the VirtualMachineInstance was unpaused, migrated or resumed from hibernation.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestTimeSync"),
+						},
+					},
+					"containerDiskStatuses": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerDiskStatuses reports the progress of the preparation of the containerDisks on the node, before the VirtualMachineInstance is started or migrated to the node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.ContainerDiskStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VolumeStatus"},
+			"kubevirt.io/client-go/api/v1.ContainerDiskStatus", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestTimeSync", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VolumeStatus"},
 	}
 }
 
@@ -18788,71 +19674,419 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref common.ReferenceC
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "VirtualMachineSpec describes how the proper VirtualMachine should look like",
+				Description: "VirtualMachineNotificationHook registers an endpoint which is notified about lifecycle events of the VirtualMachineInstances in its namespace",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"running": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Running controls whether the associatied VirtualMachineInstance is created or not Mutually exclusive with RunStrategy",
-							Type:        []string{"boolean"},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"runStrategy": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"template": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Template is the direct specification of VirtualMachineInstance",
-							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"),
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"dataVolumeTemplates": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference. DataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec"),
-									},
-								},
-							},
+							Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookSpec"),
 						},
 					},
 				},
-				Required: []string{"template"},
+				Required: []string{"spec"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/api/v1.VirtualMachineNotificationHookSpec"},
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_VirtualMachineStateChangeRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "VirtualMachineNotificationHookList is a list of VirtualMachineNotificationHooks",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"action": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates the type of action that is requested. e.g. Start or Stop",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"data": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Provides additional data in order to perform the Action",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineNotificationHook"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/api/v1.VirtualMachineNotificationHook"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineNotificationHookSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL the events are sent to with a POST request. Only http and https URLs are supported.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"events": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Events which are sent to the URL. All events are sent if empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is a label query over the VMIs whose events are sent. The events of all VMIs in the namespace are sent if empty.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"signingSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SigningSecretRef references a secret in the namespace of the hook. The value of its \"key\" entry signs the events with HMAC-SHA256.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRetries is the number of times the delivery of an event is retried after it failed. Defaults to 5.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachinePowerSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachinePowerSchedule starts and stops a VirtualMachine at the times of cron schedules.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is the schedule in cron format the VirtualMachine is started at, e.g. \"0 8 * * 1-5\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"stop": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stop is the schedule in cron format the VirtualMachine is stopped at, e.g. \"0 20 * * *\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone the schedules are evaluated in, a name of the IANA time zone database like \"Europe/Berlin\". Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"skipIfInUse": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipIfInUse skips a scheduled stop while the VirtualMachine is in use, that is users are logged in to the guest or the VirtualMachineInstance is migrating",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachinePowerScheduleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachinePowerScheduleStatus reports the runs of the power schedule of a VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastStartTime is the time of the last scheduled start",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextStartTime is the time of the next scheduled start",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastStopTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastStopTime is the time of the last scheduled stop",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextStopTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextStopTime is the time of the next scheduled stop",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message reports an invalid schedule or a skipped stop",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineReplication(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineReplication configures the disaster recovery cluster the disks of a VirtualMachine are replicated to.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pairingSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PairingSecretRef is the name of the Secret granting access to the disaster recovery cluster, with the server and token and the optional ca.crt and namespace keys",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the copy in the disaster recovery cluster, defaults to the namespace key of the pairing Secret",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interval between the copies of the volumes replicated with rsync, one hour if not set",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClassName of the PersistentVolumeClaims created in the disaster recovery cluster for the volumes replicated with rsync, the default storage class is used if empty",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"pairingSecretRef"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineReplicationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineReplicationStatus reports the replication of a VirtualMachine to the disaster recovery cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the replication, the least advanced phase of the volumes",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the time all volumes were last replicated, the state the copy in the disaster recovery cluster is in",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"volumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes reports the replication of the volumes backed by PersistentVolumeClaims",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VolumeReplicationStatus"),
+									},
+								},
+							},
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains the phase",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/api/v1.VolumeReplicationStatus"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSpec describes how the proper VirtualMachine should look like",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"running": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Running controls whether the associatied VirtualMachineInstance is created or not Mutually exclusive with RunStrategy",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"runStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Running state indicates the requested running state of the VirtualMachineInstance mutually exclusive with Running",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template is the direct specification of VirtualMachineInstance",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"),
+						},
+					},
+					"dataVolumeTemplates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference. DataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec"),
+									},
+								},
+							},
+						},
+					},
+					"hibernation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hibernation allows to hibernate the VirtualMachine. The memory of the guest is saved to a PersistentVolumeClaim managed by KubeVirt and restored on the next start.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineHibernation"),
+						},
+					},
+					"replication": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replication copies the disks of the VirtualMachine asynchronously to a disaster recovery cluster, which keeps a stopped copy of the VirtualMachine to start if this cluster fails.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineReplication"),
+						},
+					},
+					"powerSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PowerSchedule starts and stops the VirtualMachine at the times of cron schedules, e.g. to stop development VMs at night.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachinePowerSchedule"),
+						},
+					},
+				},
+				Required: []string{"template"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineHibernation", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachinePowerSchedule", "kubevirt.io/client-go/api/v1.VirtualMachineReplication"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineStateChangeRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates the type of action that is requested. e.g. Start or Stop",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"data": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Provides additional data in order to perform the Action",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -18907,6 +20141,13 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"printableStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrintableStatus is a human readable, high-level summary of the state of the virtual machine, including the reason why it is not running if its virtual machine instance can't be started",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"conditions": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
@@ -18964,11 +20205,35 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref common.Referenc
 							},
 						},
 					},
+					"hibernation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hibernation reports the PersistentVolumeClaim holding the memory of the guest and whether the VirtualMachine is hibernated. It is only set if hibernation is configured.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineHibernationStatus"),
+						},
+					},
+					"replicationStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicationStatus reports the replication of the disks to the disaster recovery cluster. It is only set if replication is configured.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineReplicationStatus"),
+						},
+					},
+					"powerScheduleStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PowerScheduleStatus reports the last and next runs of the power schedule. It is only set if a power schedule is configured.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachinePowerScheduleStatus"),
+						},
+					},
+					"idleStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IdleStatus reports the activity of the VirtualMachine and whether it was suspended for being idle. It is only set for running VirtualMachines the idle policy applies to.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineIdleStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineCondition", "kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest", "kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest", "kubevirt.io/client-go/api/v1.VolumeSnapshotStatus"},
+			"kubevirt.io/client-go/api/v1.VirtualMachineCondition", "kubevirt.io/client-go/api/v1.VirtualMachineHibernationStatus", "kubevirt.io/client-go/api/v1.VirtualMachineIdleStatus", "kubevirt.io/client-go/api/v1.VirtualMachinePowerScheduleStatus", "kubevirt.io/client-go/api/v1.VirtualMachineReplicationStatus", "kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest", "kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest", "kubevirt.io/client-go/api/v1.VolumeSnapshotStatus"},
 	}
 }
 
@@ -19084,12 +20349,103 @@ func schema_kubevirtio_client_go_api_v1_Volume(ref common.ReferenceCallback) com
 							Ref:         ref("kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"),
 						},
 					},
+					"vhostUserBlk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VhostUserBlkSource"),
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Encryption layers LUKS encryption on top of the volume, which is handled by qemu. Only PersistentVolumeClaim and DataVolume volumes can be encrypted.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VolumeEncryption"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.VhostUserBlkSource", "kubevirt.io/client-go/api/v1.VolumeEncryption"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VolumeEncryption(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeEncryption specifies how a volume is encrypted.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef references a Secret in the same namespace which holds the LUKS passphrase under the \"key\" key. Keys managed by a KMS can be used by syncing them into a Secret, for example with the Secrets Store CSI driver.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+				},
+				Required: []string{"secretRef"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VolumeReplicationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeReplicationStatus reports the replication of a volume of a VirtualMachine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PersistentVolumeClaim, the copy in the disaster recovery cluster has the same name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method the volume is replicated with",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase of the replication of the volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the time the volume was last replicated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains the phase",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "claimName", "method"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -19206,11 +20562,17 @@ func schema_kubevirtio_client_go_api_v1_VolumeSource(ref common.ReferenceCallbac
 							Ref:         ref("kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"),
 						},
 					},
+					"vhostUserBlk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VhostUserBlk attaches the vhost-user-blk socket of a storage backend like SPDK as a disk. The VhostUserBlk feature gate has to be enabled.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VhostUserBlkSource"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource"},
+			"k8s.io/api/core/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.VhostUserBlkSource"},
 	}
 }
 
@@ -19892,6 +21254,183 @@ func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotList(ref comm
 	}
 }
 
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotSchedule defines a schedule for snapshotting a VM periodically",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleSpec", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotScheduleStatus"},
+	}
+}
+
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSchedule"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "kubevirt.io/client-go/apis/snapshot/v1alpha1.VirtualMachineSnapshotSchedule"},
+	}
+}
+
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/api/core/v1.TypedLocalObjectReference"),
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule in cron format, evaluated in UTC, e.g. \"0 2 * * *\" or \"@daily\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retention is the number of ready snapshots to keep, older ones are deleted. All snapshots are kept if not set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionPolicy of the VirtualMachineSnapshots created by the schedule",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"quiesce": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quiesce snapshots running VMs with their guest file systems frozen through the guest agent, otherwise only stopped VMs are snapshotted",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"source", "schedule"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.TypedLocalObjectReference"},
+	}
+}
+
+func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotScheduleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"lastSnapshotName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/apis/snapshot/v1alpha1.Error"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/apis/snapshot/v1alpha1.Error"},
+	}
+}
+
 func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -19910,6 +21449,13 @@ func schema_client_go_apis_snapshot_v1alpha1_VirtualMachineSnapshotSpec(ref comm
 							Format: "",
 						},
 					},
+					"quiesce": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quiesce freezes the guest file systems through the guest agent while the volumes of a running VM are snapshotted",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"source"},
 			},
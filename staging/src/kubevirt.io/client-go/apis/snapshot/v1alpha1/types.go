@@ -60,6 +60,11 @@ type VirtualMachineSnapshotSpec struct {
 
 	// +optional
 	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Quiesce freezes the guest file systems through the guest agent while
+	// the volumes of a running VM are snapshotted
+	// +optional
+	Quiesce bool `json:"quiesce,omitempty"`
 }
 
 // VirtualMachineSnapshotStatus is the status for a VirtualMachineSnapshot resource
@@ -285,3 +290,64 @@ type VirtualMachineRestoreList struct {
 
 	Items []VirtualMachineRestore `json:"items"`
 }
+
+// VirtualMachineSnapshotSchedule defines a schedule for snapshotting a VM periodically
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineSnapshotScheduleSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineSnapshotScheduleStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleSpec struct {
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// Schedule in cron format, evaluated in UTC, e.g. "0 2 * * *" or "@daily"
+	Schedule string `json:"schedule"`
+
+	// Retention is the number of ready snapshots to keep, older ones are deleted.
+	// All snapshots are kept if not set.
+	// +optional
+	Retention *int32 `json:"retention,omitempty"`
+
+	// DeletionPolicy of the VirtualMachineSnapshots created by the schedule
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Quiesce snapshots running VMs with their guest file systems frozen
+	// through the guest agent, otherwise only stopped VMs are snapshotted
+	// +optional
+	Quiesce bool `json:"quiesce,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleStatus struct {
+	// +optional
+	// +nullable
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// +optional
+	// +nullable
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// +optional
+	LastSnapshotName *string `json:"lastSnapshotName,omitempty"`
+
+	// +optional
+	Error *Error `json:"error,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineSnapshotSchedule `json:"items"`
+}
@@ -13,6 +13,7 @@ func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":               "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
 		"deletionPolicy": "+optional",
+		"quiesce":        "Quiesce freezes the guest file systems through the guest agent while\nthe volumes of a running VM are snapshotted\n+optional",
 	}
 }
 
@@ -148,3 +149,36 @@ func (VirtualMachineRestoreList) SwaggerDoc() map[string]string {
 		"": "VirtualMachineRestoreList is a list of VirtualMachineRestore resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
 	}
 }
+
+func (VirtualMachineSnapshotSchedule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineSnapshotSchedule defines a schedule for snapshotting a VM periodically\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource",
+		"schedule":       "Schedule in cron format, evaluated in UTC, e.g. \"0 2 * * *\" or \"@daily\"",
+		"retention":      "Retention is the number of ready snapshots to keep, older ones are deleted.\nAll snapshots are kept if not set.\n+optional",
+		"deletionPolicy": "DeletionPolicy of the VirtualMachineSnapshots created by the schedule\n+optional",
+		"quiesce":        "Quiesce snapshots running VMs with their guest file systems frozen\nthrough the guest agent, otherwise only stopped VMs are snapshotted\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource",
+		"lastScheduleTime": "+optional\n+nullable",
+		"nextScheduleTime": "+optional\n+nullable",
+		"lastSnapshotName": "+optional",
+		"error":            "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// VirtualMachineTemplate is a curated VirtualMachine offering, the VirtualMachine
+// it describes is created by processing the template with values for its parameters
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineTemplateSpec `json:"spec"`
+}
+
+// VirtualMachineTemplateSpec is the spec for a VirtualMachineTemplate resource
+type VirtualMachineTemplateSpec struct {
+	// DisplayName of the offering in catalogs
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+
+	// VirtualMachine is the manifest of the VirtualMachine, ${NAME} references in
+	// its string values are replaced with the value of the parameter NAME
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	VirtualMachine runtime.RawExtension `json:"virtualMachine"`
+}
+
+// TemplateParameterType determines which values a parameter accepts
+type TemplateParameterType string
+
+const (
+	// ParameterString accepts any value
+	ParameterString TemplateParameterType = "String"
+
+	// ParameterName accepts DNS-1123 labels, like names of VirtualMachines
+	ParameterName TemplateParameterType = "Name"
+
+	// ParameterMemory accepts quantities, like 2Gi
+	ParameterMemory TemplateParameterType = "Memory"
+
+	// ParameterImage accepts container image references
+	ParameterImage TemplateParameterType = "Image"
+
+	// ParameterSSHKey accepts public SSH keys in the authorized_keys format
+	ParameterSSHKey TemplateParameterType = "SSHKey"
+)
+
+// TemplateParameter is a value the VirtualMachine of a template is parameterized with
+type TemplateParameter struct {
+	// Name referenced as ${NAME} in the VirtualMachine
+	Name string `json:"name"`
+
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Type of the parameter, defaults to String
+	// +optional
+	Type TemplateParameterType `json:"type,omitempty"`
+
+	// Value used when processing the template without a value for the parameter
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Required parameters need a value or a default
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// VirtualMachineTemplateList is a list of VirtualMachineTemplate resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineTemplate `json:"items"`
+}
+
+// ProcessOptions are the values of the parameters a template is processed with
+type ProcessOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
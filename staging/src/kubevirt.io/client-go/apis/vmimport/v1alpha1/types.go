@@ -0,0 +1,417 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// VirtualMachineOVFImport defines the operation of importing a virtual
+// appliance described by an OVF descriptor as a VM
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineOVFImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineOVFImportSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineOVFImportStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineOVFImportSpec is the spec for a VirtualMachineOVFImport resource
+type VirtualMachineOVFImportSpec struct {
+	Source OVFSource `json:"source"`
+
+	// Name of the created VirtualMachine, defaults to the name of the virtual system
+	// +optional
+	VirtualMachineName *string `json:"virtualMachineName,omitempty"`
+
+	// StorageClassName of the PersistentVolumeClaims the disks are imported to
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// NetworkMappings attach the interfaces on the networks of the descriptor
+	// to networks of the VM, interfaces on networks which are not mapped are
+	// attached to the pod network
+	// +optional
+	NetworkMappings []NetworkMapping `json:"networkMappings,omitempty"`
+
+	// Running of the created VirtualMachine, defaults to false
+	// +optional
+	Running *bool `json:"running,omitempty"`
+}
+
+// OVFSource is the location of the OVF descriptor, the disks it references are
+// imported from locations relative to it
+type OVFSource struct {
+	// URL of the descriptor, served over http or https
+	URL string `json:"url"`
+
+	// SecretRef names a Secret with the accessKeyId and secretKey used for
+	// basic authentication
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// CertConfigMap names a ConfigMap with the ca.pem of the server
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// NetworkMapping maps a network of the descriptor to a network of the VM
+type NetworkMapping struct {
+	// Name of the network in the descriptor
+	Name string `json:"name"`
+
+	v1.NetworkSource `json:",inline"`
+}
+
+// VirtualMachineOVFImportPhase is the phase of a VirtualMachineOVFImport
+type VirtualMachineOVFImportPhase string
+
+const (
+	// ImportPending means the descriptor was not processed yet
+	ImportPending VirtualMachineOVFImportPhase = "Pending"
+
+	// ImportingDisks means the VM was created and its disks are imported
+	ImportingDisks VirtualMachineOVFImportPhase = "ImportingDisks"
+
+	// ImportSucceeded means all disks of the VM are imported
+	ImportSucceeded VirtualMachineOVFImportPhase = "Succeeded"
+
+	// ImportFailed means the appliance can't be imported
+	ImportFailed VirtualMachineOVFImportPhase = "Failed"
+)
+
+// VirtualMachineOVFImportStatus is the status for a VirtualMachineOVFImport resource
+type VirtualMachineOVFImportStatus struct {
+	// +optional
+	Phase VirtualMachineOVFImportPhase `json:"phase,omitempty"`
+
+	// +optional
+	VirtualMachineName *string `json:"virtualMachineName,omitempty"`
+
+	// Progress of the import of all disks
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// +optional
+	Disks []DiskImportStatus `json:"disks,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DiskImportStatus is the status of the import of one disk of the appliance
+type DiskImportStatus struct {
+	// DiskID of the disk in the descriptor
+	DiskID string `json:"diskId"`
+
+	DataVolumeName string `json:"dataVolumeName"`
+
+	// File of the disk in the OVA archive, which is extracted to the blank
+	// DataVolume
+	// +optional
+	File string `json:"file,omitempty"`
+
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// +optional
+	Progress string `json:"progress,omitempty"`
+}
+
+// VirtualMachineOVFImportList is a list of VirtualMachineOVFImport resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineOVFImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineOVFImport `json:"items"`
+}
+
+// VirtualMachineV2VImport defines the cold migration of a VM of another
+// hypervisor, whose disks are converted by virt-v2v
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineV2VImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineV2VImportSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineV2VImportStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineV2VImportSpec is the spec for a VirtualMachineV2VImport resource
+type VirtualMachineV2VImportSpec struct {
+	Source V2VSource `json:"source"`
+
+	// Name of the created VirtualMachine, defaults to the name of the source VM
+	// +optional
+	VirtualMachineName *string `json:"virtualMachineName,omitempty"`
+
+	// StorageClassName of the PersistentVolumeClaims the disks are converted to
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// NetworkMappings attach the interfaces on the networks of the source VM
+	// to networks of the VM, interfaces on networks which are not mapped are
+	// attached to the pod network
+	// +optional
+	NetworkMappings []NetworkMapping `json:"networkMappings,omitempty"`
+
+	// Running of the created VirtualMachine, defaults to false
+	// +optional
+	Running *bool `json:"running,omitempty"`
+}
+
+// V2VSource is the VM to convert, exactly one source has to be set
+type V2VSource struct {
+	// +optional
+	VMware *VMwareSource `json:"vmware,omitempty"`
+
+	// +optional
+	OVA *OVASource `json:"ova,omitempty"`
+}
+
+// VMwareSource is a VM managed by vCenter or an ESXi host, it has to be shut down
+type VMwareSource struct {
+	// URL of the libvirt connection to the hypervisor, like
+	// vpx://vcenter.example.com/Datacenter/esxi.example.com?no_verify=1
+	URL string `json:"url"`
+
+	// VMName is the name of the VM in the inventory
+	VMName string `json:"vmName"`
+
+	// SecretRef names a Secret with the password of the user of the URL
+	// under the password key
+	SecretRef string `json:"secretRef"`
+}
+
+// OVASource is an OVA archive stored on a PersistentVolumeClaim, as exported by
+// oVirt or vSphere
+type OVASource struct {
+	// ClaimName of the PersistentVolumeClaim holding the archive
+	ClaimName string `json:"claimName"`
+
+	// Path of the archive on the PersistentVolumeClaim
+	Path string `json:"path"`
+}
+
+// VirtualMachineV2VImportPhase is the phase of a VirtualMachineV2VImport
+type VirtualMachineV2VImportPhase string
+
+const (
+	// V2VPending means the conversion was not started yet
+	V2VPending VirtualMachineV2VImportPhase = "Pending"
+
+	// V2VEstimating means virt-v2v estimates the space the converted disks need
+	V2VEstimating VirtualMachineV2VImportPhase = "Estimating"
+
+	// V2VConverting means virt-v2v converts the VM to a scratch volume
+	V2VConverting VirtualMachineV2VImportPhase = "Converting"
+
+	// V2VCopyingDisks means the converted disks are copied to their own volumes
+	V2VCopyingDisks VirtualMachineV2VImportPhase = "CopyingDisks"
+
+	// V2VSucceeded means the VM was created
+	V2VSucceeded VirtualMachineV2VImportPhase = "Succeeded"
+
+	// V2VFailed means the VM can't be converted
+	V2VFailed VirtualMachineV2VImportPhase = "Failed"
+)
+
+// VirtualMachineV2VImportStatus is the status for a VirtualMachineV2VImport resource
+type VirtualMachineV2VImportStatus struct {
+	// +optional
+	Phase VirtualMachineV2VImportPhase `json:"phase,omitempty"`
+
+	// +optional
+	VirtualMachineName *string `json:"virtualMachineName,omitempty"`
+
+	// Progress of the conversion, as reported by virt-v2v
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// ScratchClaimName is the PersistentVolumeClaim virt-v2v converts the VM to
+	// +optional
+	ScratchClaimName string `json:"scratchClaimName,omitempty"`
+
+	// ScratchSize estimated by virt-v2v
+	// +optional
+	ScratchSize *resource.Quantity `json:"scratchSize,omitempty"`
+
+	// +optional
+	Disks []ConvertedDisk `json:"disks,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ConvertedDisk is a disk converted by virt-v2v
+type ConvertedDisk struct {
+	// File of the disk on the scratch volume
+	File string `json:"file"`
+
+	// Size of the disk
+	Size resource.Quantity `json:"size"`
+
+	// ClaimName of the PersistentVolumeClaim the disk is copied to
+	ClaimName string `json:"claimName"`
+}
+
+// VirtualMachineV2VImportList is a list of VirtualMachineV2VImport resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineV2VImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineV2VImport `json:"items"`
+}
+
+// VirtualMachineClusterImport defines the migration of a stopped VM from a
+// peer cluster running KubeVirt, its spec is copied and its disks are
+// uploaded to DataVolumes of this cluster
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineClusterImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineClusterImportSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineClusterImportStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineClusterImportSpec is the spec for a VirtualMachineClusterImport resource
+type VirtualMachineClusterImportSpec struct {
+	Source ClusterImportSource `json:"source"`
+
+	// Name of the created VirtualMachine, defaults to the name of the source VM
+	// +optional
+	VirtualMachineName *string `json:"virtualMachineName,omitempty"`
+
+	// StorageClassName of the DataVolumes the disks are uploaded to
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// StopSource stops the source VM if it is running, otherwise the import
+	// waits until it is stopped. The VM is moved: the created VM is started
+	// once the disks were transferred, unless running is set
+	// +optional
+	StopSource bool `json:"stopSource,omitempty"`
+
+	// UploadProxyURL of CDI in this cluster, which has to be reachable from
+	// the peer cluster. Defaults to the URL in the CDIConfig
+	// +optional
+	UploadProxyURL string `json:"uploadProxyURL,omitempty"`
+
+	// UploadProxyCertConfigMap names a ConfigMap with the ca.pem of the
+	// upload proxy, the system CAs of the transfer pods are used otherwise
+	// +optional
+	UploadProxyCertConfigMap string `json:"uploadProxyCertConfigMap,omitempty"`
+
+	// Running of the created VirtualMachine, defaults to false unless the
+	// import stopped the source VM
+	// +optional
+	Running *bool `json:"running,omitempty"`
+}
+
+// ClusterImportSource is the VM in the peer cluster
+type ClusterImportSource struct {
+	// PairingSecretRef names a Secret with the server, token and ca.crt used
+	// to access the peer cluster
+	PairingSecretRef string `json:"pairingSecretRef"`
+
+	// Namespace of the VM in the peer cluster, defaults to the namespace key
+	// of the pairing Secret
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the VM in the peer cluster
+	Name string `json:"name"`
+}
+
+// VirtualMachineClusterImportPhase is the phase of a VirtualMachineClusterImport
+type VirtualMachineClusterImportPhase string
+
+const (
+	// ClusterImportPending means the source VM was not read yet or is still running
+	ClusterImportPending VirtualMachineClusterImportPhase = "Pending"
+
+	// ClusterImportTransferringDisks means the disks are uploaded from the peer cluster
+	ClusterImportTransferringDisks VirtualMachineClusterImportPhase = "TransferringDisks"
+
+	// ClusterImportSucceeded means the VM was created
+	ClusterImportSucceeded VirtualMachineClusterImportPhase = "Succeeded"
+
+	// ClusterImportFailed means the VM can't be imported
+	ClusterImportFailed VirtualMachineClusterImportPhase = "Failed"
+)
+
+// VirtualMachineClusterImportStatus is the status for a VirtualMachineClusterImport resource
+type VirtualMachineClusterImportStatus struct {
+	// +optional
+	Phase VirtualMachineClusterImportPhase `json:"phase,omitempty"`
+
+	// +optional
+	VirtualMachineName *string `json:"virtualMachineName,omitempty"`
+
+	// +optional
+	Disks []TransferredDisk `json:"disks,omitempty"`
+
+	// SourceStopped tells that the import stopped the running source VM
+	// +optional
+	SourceStopped bool `json:"sourceStopped,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TransferredDisk is a volume of the source VM uploaded from the peer cluster
+type TransferredDisk struct {
+	// VolumeName in the spec of the VM
+	VolumeName string `json:"volumeName"`
+
+	// SourceClaimName of the PersistentVolumeClaim in the peer cluster
+	SourceClaimName string `json:"sourceClaimName"`
+
+	// DataVolumeName of the DataVolume the disk is uploaded to
+	DataVolumeName string `json:"dataVolumeName"`
+
+	// +optional
+	Phase string `json:"phase,omitempty"`
+}
+
+// VirtualMachineClusterImportList is a list of VirtualMachineClusterImport resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineClusterImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineClusterImport `json:"items"`
+}
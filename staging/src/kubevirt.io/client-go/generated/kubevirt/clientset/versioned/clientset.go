@@ -26,11 +26,15 @@ import (
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 
 	snapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
+	templatev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/template/v1alpha1"
+	vmimportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/vmimport/v1alpha1"
 )
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	SnapshotV1alpha1() snapshotv1alpha1.SnapshotV1alpha1Interface
+	TemplateV1alpha1() templatev1alpha1.TemplateV1alpha1Interface
+	VmimportV1alpha1() vmimportv1alpha1.VmimportV1alpha1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -38,6 +42,8 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	snapshotV1alpha1 *snapshotv1alpha1.SnapshotV1alpha1Client
+	templateV1alpha1 *templatev1alpha1.TemplateV1alpha1Client
+	vmimportV1alpha1 *vmimportv1alpha1.VmimportV1alpha1Client
 }
 
 // SnapshotV1alpha1 retrieves the SnapshotV1alpha1Client
@@ -45,6 +51,16 @@ func (c *Clientset) SnapshotV1alpha1() snapshotv1alpha1.SnapshotV1alpha1Interfac
 	return c.snapshotV1alpha1
 }
 
+// TemplateV1alpha1 retrieves the TemplateV1alpha1Client
+func (c *Clientset) TemplateV1alpha1() templatev1alpha1.TemplateV1alpha1Interface {
+	return c.templateV1alpha1
+}
+
+// VmimportV1alpha1 retrieves the VmimportV1alpha1Client
+func (c *Clientset) VmimportV1alpha1() vmimportv1alpha1.VmimportV1alpha1Interface {
+	return c.vmimportV1alpha1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -70,6 +86,14 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.templateV1alpha1, err = templatev1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.vmimportV1alpha1, err = vmimportv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -83,6 +107,8 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.snapshotV1alpha1 = snapshotv1alpha1.NewForConfigOrDie(c)
+	cs.templateV1alpha1 = templatev1alpha1.NewForConfigOrDie(c)
+	cs.vmimportV1alpha1 = vmimportv1alpha1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -92,6 +118,8 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.snapshotV1alpha1 = snapshotv1alpha1.New(c)
+	cs.templateV1alpha1 = templatev1alpha1.New(c)
+	cs.vmimportV1alpha1 = vmimportv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -28,6 +28,10 @@ import (
 	clientset "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
 	snapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	fakesnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1/fake"
+	templatev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/template/v1alpha1"
+	faketemplatev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/template/v1alpha1/fake"
+	vmimportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/vmimport/v1alpha1"
+	fakevmimportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/vmimport/v1alpha1/fake"
 )
 
 // NewSimpleClientset returns a clientset that will respond with the provided objects.
@@ -81,3 +85,13 @@ var _ clientset.Interface = &Clientset{}
 func (c *Clientset) SnapshotV1alpha1() snapshotv1alpha1.SnapshotV1alpha1Interface {
 	return &fakesnapshotv1alpha1.FakeSnapshotV1alpha1{Fake: &c.Fake}
 }
+
+// TemplateV1alpha1 retrieves the TemplateV1alpha1Client
+func (c *Clientset) TemplateV1alpha1() templatev1alpha1.TemplateV1alpha1Interface {
+	return &faketemplatev1alpha1.FakeTemplateV1alpha1{Fake: &c.Fake}
+}
+
+// VmimportV1alpha1 retrieves the VmimportV1alpha1Client
+func (c *Clientset) VmimportV1alpha1() vmimportv1alpha1.VmimportV1alpha1Interface {
+	return &fakevmimportv1alpha1.FakeVmimportV1alpha1{Fake: &c.Fake}
+}
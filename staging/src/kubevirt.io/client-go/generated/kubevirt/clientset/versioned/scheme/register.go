@@ -26,6 +26,8 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
 	snapshotv1alpha1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
+	vmimportv1alpha1 "kubevirt.io/client-go/apis/vmimport/v1alpha1"
 )
 
 var Scheme = runtime.NewScheme()
@@ -33,6 +35,8 @@ var Codecs = serializer.NewCodecFactory(Scheme)
 var ParameterCodec = runtime.NewParameterCodec(Scheme)
 var localSchemeBuilder = runtime.SchemeBuilder{
 	snapshotv1alpha1.AddToScheme,
+	templatev1alpha1.AddToScheme,
+	vmimportv1alpha1.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
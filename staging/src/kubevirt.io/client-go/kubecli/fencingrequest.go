@@ -0,0 +1,114 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) FencingRequest(namespace string) FencingRequestInterface {
+	return &fencingRequests{k.restClient, namespace, "fencingrequests"}
+}
+
+type fencingRequests struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+func (v *fencingRequests) Get(name string, options *k8smetav1.GetOptions) (request *v1.FencingRequest, err error) {
+	request = &v1.FencingRequest{}
+	err = v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(request)
+	request.SetGroupVersionKind(v1.FencingRequestGroupVersionKind)
+	return
+}
+
+func (v *fencingRequests) List(options *k8smetav1.ListOptions) (requestList *v1.FencingRequestList, err error) {
+	requestList = &v1.FencingRequestList{}
+	err = v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(requestList)
+	for i := range requestList.Items {
+		requestList.Items[i].SetGroupVersionKind(v1.FencingRequestGroupVersionKind)
+	}
+	return
+}
+
+func (v *fencingRequests) Create(request *v1.FencingRequest) (result *v1.FencingRequest, err error) {
+	result = &v1.FencingRequest{}
+	err = v.restClient.Post().
+		Namespace(v.namespace).
+		Resource(v.resource).
+		Body(request).
+		Do().
+		Into(result)
+	result.SetGroupVersionKind(v1.FencingRequestGroupVersionKind)
+	return
+}
+
+func (v *fencingRequests) Update(request *v1.FencingRequest) (result *v1.FencingRequest, err error) {
+	result = &v1.FencingRequest{}
+	err = v.restClient.Put().
+		Name(request.ObjectMeta.Name).
+		Namespace(v.namespace).
+		Resource(v.resource).
+		Body(request).
+		Do().
+		Into(result)
+	result.SetGroupVersionKind(v1.FencingRequestGroupVersionKind)
+	return
+}
+
+func (v *fencingRequests) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	return v.restClient.Delete().
+		Namespace(v.namespace).
+		Resource(v.resource).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+func (v *fencingRequests) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FencingRequest, err error) {
+	result = &v1.FencingRequest{}
+	err = v.restClient.Patch(pt).
+		Namespace(v.namespace).
+		Resource(v.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	result.SetGroupVersionKind(v1.FencingRequestGroupVersionKind)
+	return
+}
@@ -0,0 +1,135 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Kubevirt FencingRequest Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/kubevirt/fencingrequests"
+	requestPath := basePath + "/testnode"
+
+	newRequest := func() *v1.FencingRequest {
+		return &v1.FencingRequest{
+			TypeMeta:   k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "FencingRequest"},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "testnode", Namespace: "kubevirt"},
+			Spec:       v1.FencingRequestSpec{NodeName: "testnode"},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a FencingRequest", func() {
+		request := newRequest()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", requestPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, request),
+		))
+		fetchedRequest, err := client.FencingRequest("kubevirt").Get("testnode", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedRequest).To(Equal(request))
+	})
+
+	It("should detect non existent FencingRequests", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", requestPath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testnode")),
+		))
+		_, err := client.FencingRequest("kubevirt").Get("testnode", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue(), "Expected an IsNotFound error to have occurred")
+	})
+
+	It("should fetch a FencingRequest list", func() {
+		request := newRequest()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.FencingRequestList{Items: []v1.FencingRequest{*request}}),
+		))
+		fetchedRequestList, err := client.FencingRequest("kubevirt").List(&k8smetav1.ListOptions{})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(fetchedRequestList.Items).To(HaveLen(1))
+		Expect(fetchedRequestList.Items[0]).To(Equal(*request))
+	})
+
+	It("should create a FencingRequest", func() {
+		request := newRequest()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, request),
+		))
+		createdRequest, err := client.FencingRequest("kubevirt").Create(request)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdRequest).To(Equal(request))
+	})
+
+	It("should update a FencingRequest", func() {
+		request := newRequest()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", requestPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, request),
+		))
+		updatedRequest, err := client.FencingRequest("kubevirt").Update(request)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedRequest).To(Equal(request))
+	})
+
+	It("should delete a FencingRequest", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", requestPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.FencingRequest("kubevirt").Delete("testnode", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
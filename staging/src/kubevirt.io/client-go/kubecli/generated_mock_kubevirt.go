@@ -4,6 +4,9 @@
 package kubecli
 
 import (
+	io "io"
+	time "time"
+
 	gomock "github.com/golang/mock/gomock"
 	v1 "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	v10 "k8s.io/api/autoscaling/v1"
@@ -52,10 +55,12 @@ import (
 	rest "k8s.io/client-go/rest"
 
 	v114 "kubevirt.io/client-go/api/v1"
+	v1alpha18 "kubevirt.io/client-go/apis/template/v1alpha1"
 	versioned "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned"
 	versioned0 "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned"
 	versioned1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
 	v1alpha16 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
+	v1alpha17 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/vmimport/v1alpha1"
 	versioned2 "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	versioned3 "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
 )
@@ -141,6 +146,26 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineInstancePreset(arg0 interf
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineInstancePreset", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineNotificationHook(namespace string) VirtualMachineNotificationHookInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineNotificationHook", namespace)
+	ret0, _ := ret[0].(VirtualMachineNotificationHookInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineNotificationHook(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineNotificationHook", arg0)
+}
+
+func (_m *MockKubevirtClient) FencingRequest(namespace string) FencingRequestInterface {
+	ret := _m.ctrl.Call(_m, "FencingRequest", namespace)
+	ret0, _ := ret[0].(FencingRequestInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) FencingRequest(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FencingRequest", arg0)
+}
+
 func (_m *MockKubevirtClient) VirtualMachineSnapshot(namespace string) v1alpha16.VirtualMachineSnapshotInterface {
 	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshot", namespace)
 	ret0, _ := ret[0].(v1alpha16.VirtualMachineSnapshotInterface)
@@ -161,6 +186,16 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshotContent(arg0 inter
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshotContent", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineSnapshotSchedule(namespace string) v1alpha16.VirtualMachineSnapshotScheduleInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshotSchedule", namespace)
+	ret0, _ := ret[0].(v1alpha16.VirtualMachineSnapshotScheduleInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshotSchedule(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshotSchedule", arg0)
+}
+
 func (_m *MockKubevirtClient) VirtualMachineRestore(namespace string) v1alpha16.VirtualMachineRestoreInterface {
 	ret := _m.ctrl.Call(_m, "VirtualMachineRestore", namespace)
 	ret0, _ := ret[0].(v1alpha16.VirtualMachineRestoreInterface)
@@ -171,6 +206,46 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineOVFImport(namespace string) v1alpha17.VirtualMachineOVFImportInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineOVFImport", namespace)
+	ret0, _ := ret[0].(v1alpha17.VirtualMachineOVFImportInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineOVFImport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineOVFImport", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineV2VImport(namespace string) v1alpha17.VirtualMachineV2VImportInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineV2VImport", namespace)
+	ret0, _ := ret[0].(v1alpha17.VirtualMachineV2VImportInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineV2VImport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineV2VImport", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineClusterImport(namespace string) v1alpha17.VirtualMachineClusterImportInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineClusterImport", namespace)
+	ret0, _ := ret[0].(v1alpha17.VirtualMachineClusterImportInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineClusterImport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineClusterImport", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineTemplate(namespace string) VirtualMachineTemplateInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineTemplate", namespace)
+	ret0, _ := ret[0].(VirtualMachineTemplateInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineTemplate(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineTemplate", arg0)
+}
+
 func (_m *MockKubevirtClient) ServerVersion() *ServerVersion {
 	ret := _m.ctrl.Call(_m, "ServerVersion")
 	ret0, _ := ret[0].(*ServerVersion)
@@ -815,6 +890,28 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VNC(arg0 interface{}) *
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VNC", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) Channel(name string, channel string) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "Channel", name, channel)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Channel(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Channel", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) HypervisorLog(name string) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "HypervisorLog", name)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) HypervisorLog(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "HypervisorLog", arg0)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) Pause(name string) error {
 	ret := _m.ctrl.Call(_m, "Pause", name)
 	ret0, _ := ret[0].(error)
@@ -835,6 +932,26 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unpause(arg0 interface{
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unpause", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) Freeze(name string) error {
+	ret := _m.ctrl.Call(_m, "Freeze", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Freeze(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Freeze", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Unfreeze(name string) error {
+	ret := _m.ctrl.Call(_m, "Unfreeze", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unfreeze(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unfreeze", arg0)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) GuestOsInfo(name string) (v114.VirtualMachineInstanceGuestAgentInfo, error) {
 	ret := _m.ctrl.Call(_m, "GuestOsInfo", name)
 	ret0, _ := ret[0].(v114.VirtualMachineInstanceGuestAgentInfo)
@@ -888,6 +1005,39 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveVolume(arg0, arg1
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) DomainXML(name string) (string, error) {
+	ret := _m.ctrl.Call(_m, "DomainXML", name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) DomainXML(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainXML", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) QMPCommand(name string, command string) (string, error) {
+	ret := _m.ctrl.Call(_m, "QMPCommand", name, command)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) QMPCommand(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "QMPCommand", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Stats(name string) (*v114.VirtualMachineInstanceStats, error) {
+	ret := _m.ctrl.Call(_m, "Stats", name)
+	ret0, _ := ret[0].(*v114.VirtualMachineInstanceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Stats(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Stats", arg0)
+}
+
 // Mock of ReplicaSetInterface interface
 type MockReplicaSetInterface struct {
 	ctrl     *gomock.Controller
@@ -1114,6 +1264,188 @@ func (_mr *_MockVirtualMachineInstancePresetInterfaceRecorder) Patch(arg0, arg1,
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
 }
 
+// Mock of VirtualMachineNotificationHookInterface interface
+type MockVirtualMachineNotificationHookInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineNotificationHookInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineNotificationHookInterface (not exported)
+type _MockVirtualMachineNotificationHookInterfaceRecorder struct {
+	mock *MockVirtualMachineNotificationHookInterface
+}
+
+func NewMockVirtualMachineNotificationHookInterface(ctrl *gomock.Controller) *MockVirtualMachineNotificationHookInterface {
+	mock := &MockVirtualMachineNotificationHookInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineNotificationHookInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineNotificationHookInterface) EXPECT() *_MockVirtualMachineNotificationHookInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineNotificationHookInterface) Get(name string, options *v11.GetOptions) (*v114.VirtualMachineNotificationHook, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v114.VirtualMachineNotificationHook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineNotificationHookInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineNotificationHookInterface) List(opts *v11.ListOptions) (*v114.VirtualMachineNotificationHookList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v114.VirtualMachineNotificationHookList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineNotificationHookInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineNotificationHookInterface) Create(_param0 *v114.VirtualMachineNotificationHook) (*v114.VirtualMachineNotificationHook, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v114.VirtualMachineNotificationHook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineNotificationHookInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineNotificationHookInterface) Update(_param0 *v114.VirtualMachineNotificationHook) (*v114.VirtualMachineNotificationHook, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v114.VirtualMachineNotificationHook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineNotificationHookInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineNotificationHookInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineNotificationHookInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineNotificationHookInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v114.VirtualMachineNotificationHook, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v114.VirtualMachineNotificationHook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineNotificationHookInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+// Mock of FencingRequestInterface interface
+type MockFencingRequestInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockFencingRequestInterfaceRecorder
+}
+
+// Recorder for MockFencingRequestInterface (not exported)
+type _MockFencingRequestInterfaceRecorder struct {
+	mock *MockFencingRequestInterface
+}
+
+func NewMockFencingRequestInterface(ctrl *gomock.Controller) *MockFencingRequestInterface {
+	mock := &MockFencingRequestInterface{ctrl: ctrl}
+	mock.recorder = &_MockFencingRequestInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockFencingRequestInterface) EXPECT() *_MockFencingRequestInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockFencingRequestInterface) Get(name string, options *v11.GetOptions) (*v114.FencingRequest, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v114.FencingRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFencingRequestInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockFencingRequestInterface) List(opts *v11.ListOptions) (*v114.FencingRequestList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v114.FencingRequestList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFencingRequestInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockFencingRequestInterface) Create(_param0 *v114.FencingRequest) (*v114.FencingRequest, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v114.FencingRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFencingRequestInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockFencingRequestInterface) Update(_param0 *v114.FencingRequest) (*v114.FencingRequest, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v114.FencingRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFencingRequestInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockFencingRequestInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockFencingRequestInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockFencingRequestInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v114.FencingRequest, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v114.FencingRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFencingRequestInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
 // Mock of VirtualMachineInterface interface
 type MockVirtualMachineInterface struct {
 	ctrl     *gomock.Controller
@@ -1267,6 +1599,16 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) Stop(arg0 interface{}) *gomock.
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Stop", arg0)
 }
 
+func (_m *MockVirtualMachineInterface) Hibernate(name string) error {
+	ret := _m.ctrl.Call(_m, "Hibernate", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) Hibernate(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Hibernate", arg0)
+}
+
 func (_m *MockVirtualMachineInterface) Migrate(name string) error {
 	ret := _m.ctrl.Call(_m, "Migrate", name)
 	ret0, _ := ret[0].(error)
@@ -1307,6 +1649,39 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveVolume(arg0, arg1 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInterface) DomainXML(name string) (string, error) {
+	ret := _m.ctrl.Call(_m, "DomainXML", name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) DomainXML(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainXML", arg0)
+}
+
+func (_m *MockVirtualMachineInterface) ValidateStart(name string) (*v114.VirtualMachineStartValidation, error) {
+	ret := _m.ctrl.Call(_m, "ValidateStart", name)
+	ret0, _ := ret[0].(*v114.VirtualMachineStartValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) ValidateStart(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ValidateStart", arg0)
+}
+
+func (_m *MockVirtualMachineInterface) Bulk(operation v114.VirtualMachineBulkOperation, options *v114.VirtualMachineBulkOperationOptions) (*v114.VirtualMachineBulkOperationResult, error) {
+	ret := _m.ctrl.Call(_m, "Bulk", operation, options)
+	ret0, _ := ret[0].(*v114.VirtualMachineBulkOperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) Bulk(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Bulk", arg0, arg1)
+}
+
 // Mock of VirtualMachineInstanceMigrationInterface interface
 type MockVirtualMachineInstanceMigrationInterface struct {
 	ctrl     *gomock.Controller
@@ -1532,3 +1907,127 @@ func (_m *MockKubeVirtInterface) PatchStatus(name string, pt types.PatchType, da
 func (_mr *_MockKubeVirtInterfaceRecorder) PatchStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PatchStatus", arg0, arg1, arg2)
 }
+
+func (_m *MockKubeVirtInterface) ValidateConfiguration(name string, kubevirt *v114.KubeVirt) (*v114.KubeVirtConfigurationValidation, error) {
+	ret := _m.ctrl.Call(_m, "ValidateConfiguration", name, kubevirt)
+	ret0, _ := ret[0].(*v114.KubeVirtConfigurationValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockKubeVirtInterfaceRecorder) ValidateConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ValidateConfiguration", arg0, arg1)
+}
+
+func (_m *MockKubeVirtInterface) Profile(name string, node string, duration time.Duration) (io.ReadCloser, error) {
+	ret := _m.ctrl.Call(_m, "Profile", name, node, duration)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockKubeVirtInterfaceRecorder) Profile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Profile", arg0, arg1, arg2)
+}
+
+// Mock of VirtualMachineTemplateInterface interface
+type MockVirtualMachineTemplateInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineTemplateInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineTemplateInterface (not exported)
+type _MockVirtualMachineTemplateInterfaceRecorder struct {
+	mock *MockVirtualMachineTemplateInterface
+}
+
+func NewMockVirtualMachineTemplateInterface(ctrl *gomock.Controller) *MockVirtualMachineTemplateInterface {
+	mock := &MockVirtualMachineTemplateInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineTemplateInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineTemplateInterface) EXPECT() *_MockVirtualMachineTemplateInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineTemplateInterface) Get(name string, options *v11.GetOptions) (*v1alpha18.VirtualMachineTemplate, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v1alpha18.VirtualMachineTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineTemplateInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineTemplateInterface) List(opts *v11.ListOptions) (*v1alpha18.VirtualMachineTemplateList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v1alpha18.VirtualMachineTemplateList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineTemplateInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineTemplateInterface) Create(_param0 *v1alpha18.VirtualMachineTemplate) (*v1alpha18.VirtualMachineTemplate, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v1alpha18.VirtualMachineTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineTemplateInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineTemplateInterface) Update(_param0 *v1alpha18.VirtualMachineTemplate) (*v1alpha18.VirtualMachineTemplate, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v1alpha18.VirtualMachineTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineTemplateInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineTemplateInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineTemplateInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineTemplateInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v1alpha18.VirtualMachineTemplate, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v1alpha18.VirtualMachineTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineTemplateInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+func (_m *MockVirtualMachineTemplateInterface) Process(name string, options *v1alpha18.ProcessOptions) (*v114.VirtualMachine, error) {
+	ret := _m.ctrl.Call(_m, "Process", name, options)
+	ret0, _ := ret[0].(*v114.VirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineTemplateInterfaceRecorder) Process(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Process", arg0, arg1)
+}
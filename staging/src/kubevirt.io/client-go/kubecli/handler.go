@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -20,11 +21,18 @@ import (
 const (
 	consoleTemplateURI        = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/console"
 	vncTemplateURI            = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/vnc"
+	channelTemplateURI        = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/channel/%s"
+	hypervisorLogTemplateURI  = "wss://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/hypervisorlog"
 	pauseTemplateURI          = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/pause"
 	unpauseTemplateURI        = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/unpause"
+	freezeTemplateURI         = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/freeze"
+	unfreezeTemplateURI       = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/unfreeze"
 	guestInfoTemplateURI      = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/guestosinfo"
 	userListTemplateURI       = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/userlist"
 	filesystemListTemplateURI = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/filesystemlist"
+	qmpTemplateURI            = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/qmp?command=%s"
+	statsTemplateURI          = "https://%s:%v/v1/namespaces/%s/virtualmachineinstances/%s/stats"
+	profileTemplateURI        = "https://%s:%v/v1/debug/profile?seconds=%d"
 )
 
 func NewVirtHandlerClient(client KubevirtClient) VirtHandlerClient {
@@ -45,14 +53,21 @@ type VirtHandlerConn interface {
 	ConnectionDetails() (ip string, port int, err error)
 	ConsoleURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	VNCURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	ChannelURI(vmi *virtv1.VirtualMachineInstance, channel string) (string, error)
+	HypervisorLogURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	PauseURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	UnpauseURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	FreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	UnfreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	Pod() (pod *v1.Pod, err error)
 	Put(url string, tlsConfig *tls.Config) error
 	Get(url string, tlsConfig *tls.Config) (string, error)
 	GuestInfoURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	UserListURI(vmi *virtv1.VirtualMachineInstance) (string, error)
 	FilesystemListURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	QMPCommandURI(vmi *virtv1.VirtualMachineInstance, command string) (string, error)
+	StatsURI(vmi *virtv1.VirtualMachineInstance) (string, error)
+	ProfileURI(seconds int) (string, error)
 }
 
 type virtHandler struct {
@@ -158,6 +173,24 @@ func (v *virtHandlerConn) VNCURI(vmi *virtv1.VirtualMachineInstance) (string, er
 	return fmt.Sprintf(vncTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
 }
 
+func (v *virtHandlerConn) ChannelURI(vmi *virtv1.VirtualMachineInstance, channel string) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(channelTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, url.PathEscape(channel)), nil
+}
+
+func (v *virtHandlerConn) HypervisorLogURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(hypervisorLogTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
 func (v *virtHandlerConn) PauseURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
 	ip, port, err := v.ConnectionDetails()
 	if err != nil {
@@ -176,6 +209,24 @@ func (v *virtHandlerConn) UnpauseURI(vmi *virtv1.VirtualMachineInstance) (string
 	return fmt.Sprintf(unpauseTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
 }
 
+func (v *virtHandlerConn) FreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(freezeTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) UnfreezeURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(unfreezeTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
 func (v *virtHandlerConn) Pod() (pod *v1.Pod, err error) {
 	if v.err != nil {
 		err = v.err
@@ -276,3 +327,27 @@ func (v *virtHandlerConn) FilesystemListURI(vmi *virtv1.VirtualMachineInstance)
 	}
 	return fmt.Sprintf(filesystemListTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
 }
+
+func (v *virtHandlerConn) QMPCommandURI(vmi *virtv1.VirtualMachineInstance, command string) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(qmpTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name, url.QueryEscape(command)), nil
+}
+
+func (v *virtHandlerConn) StatsURI(vmi *virtv1.VirtualMachineInstance) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(statsTemplateURI, formatIpForUri(ip), port, vmi.ObjectMeta.Namespace, vmi.ObjectMeta.Name), nil
+}
+
+func (v *virtHandlerConn) ProfileURI(seconds int) (string, error) {
+	ip, port, err := v.ConnectionDetails()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(profileTemplateURI, formatIpForUri(ip), port, seconds), nil
+}
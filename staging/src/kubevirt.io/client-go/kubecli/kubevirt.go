@@ -27,6 +27,7 @@ package kubecli
 
 import (
 	"io"
+	"time"
 
 	secv1 "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	autov1 "k8s.io/api/autoscaling/v1"
@@ -38,10 +39,12 @@ import (
 	"k8s.io/client-go/rest"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
 	cdiclient "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned"
 	k8ssnapshotclient "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned"
 	generatedclient "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
 	vmsnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
+	vmimportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/vmimport/v1alpha1"
 	networkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	promclient "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
 )
@@ -53,9 +56,16 @@ type KubevirtClient interface {
 	VirtualMachine(namespace string) VirtualMachineInterface
 	KubeVirt(namespace string) KubeVirtInterface
 	VirtualMachineInstancePreset(namespace string) VirtualMachineInstancePresetInterface
+	VirtualMachineNotificationHook(namespace string) VirtualMachineNotificationHookInterface
+	FencingRequest(namespace string) FencingRequestInterface
 	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
+	VirtualMachineSnapshotSchedule(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotScheduleInterface
 	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachineOVFImport(namespace string) vmimportv1alpha1.VirtualMachineOVFImportInterface
+	VirtualMachineV2VImport(namespace string) vmimportv1alpha1.VirtualMachineV2VImportInterface
+	VirtualMachineClusterImport(namespace string) vmimportv1alpha1.VirtualMachineClusterImportInterface
+	VirtualMachineTemplate(namespace string) VirtualMachineTemplateInterface
 	ServerVersion() *ServerVersion
 	RestClient() *rest.RESTClient
 	GeneratedKubeVirtClient() generatedclient.Interface
@@ -130,10 +140,26 @@ func (k kubevirt) VirtualMachineSnapshotContent(namespace string) vmsnapshotv1al
 	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineSnapshotContents(namespace)
 }
 
+func (k kubevirt) VirtualMachineSnapshotSchedule(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotScheduleInterface {
+	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(namespace)
+}
+
 func (k kubevirt) VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface {
 	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineRestores(namespace)
 }
 
+func (k kubevirt) VirtualMachineOVFImport(namespace string) vmimportv1alpha1.VirtualMachineOVFImportInterface {
+	return k.generatedKubeVirtClient.VmimportV1alpha1().VirtualMachineOVFImports(namespace)
+}
+
+func (k kubevirt) VirtualMachineV2VImport(namespace string) vmimportv1alpha1.VirtualMachineV2VImportInterface {
+	return k.generatedKubeVirtClient.VmimportV1alpha1().VirtualMachineV2VImports(namespace)
+}
+
+func (k kubevirt) VirtualMachineClusterImport(namespace string) vmimportv1alpha1.VirtualMachineClusterImportInterface {
+	return k.generatedKubeVirtClient.VmimportV1alpha1().VirtualMachineClusterImports(namespace)
+}
+
 func (k kubevirt) KubernetesSnapshotClient() k8ssnapshotclient.Interface {
 	return k.snapshotClient
 }
@@ -156,13 +182,20 @@ type VirtualMachineInstanceInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstance, err error)
 	SerialConsole(name string, options *SerialConsoleOptions) (StreamInterface, error)
 	VNC(name string) (StreamInterface, error)
+	Channel(name string, channel string) (StreamInterface, error)
+	HypervisorLog(name string) (StreamInterface, error)
 	Pause(name string) error
 	Unpause(name string) error
+	Freeze(name string) error
+	Unfreeze(name string) error
 	GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
 	UserList(name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
 	FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemList, error)
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	DomainXML(name string) (string, error)
+	QMPCommand(name string, command string) (string, error)
+	Stats(name string) (*v1.VirtualMachineInstanceStats, error)
 }
 
 type ReplicaSetInterface interface {
@@ -187,6 +220,24 @@ type VirtualMachineInstancePresetInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstancePreset, err error)
 }
 
+type VirtualMachineNotificationHookInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineNotificationHook, error)
+	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineNotificationHookList, error)
+	Create(*v1.VirtualMachineNotificationHook) (*v1.VirtualMachineNotificationHook, error)
+	Update(*v1.VirtualMachineNotificationHook) (*v1.VirtualMachineNotificationHook, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineNotificationHook, err error)
+}
+
+type FencingRequestInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.FencingRequest, error)
+	List(opts *k8smetav1.ListOptions) (*v1.FencingRequestList, error)
+	Create(*v1.FencingRequest) (*v1.FencingRequest, error)
+	Update(*v1.FencingRequest) (*v1.FencingRequest, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FencingRequest, err error)
+}
+
 // VirtualMachineInterface provides convenience methods to work with
 // virtual machines inside the cluster
 type VirtualMachineInterface interface {
@@ -202,10 +253,14 @@ type VirtualMachineInterface interface {
 	ForceRestart(name string, graceperiod int) error
 	Start(name string) error
 	Stop(name string) error
+	Hibernate(name string) error
 	Migrate(name string) error
 	Rename(name string, options *v1.RenameOptions) error
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	DomainXML(name string) (string, error)
+	ValidateStart(name string) (*v1.VirtualMachineStartValidation, error)
+	Bulk(operation v1.VirtualMachineBulkOperation, options *v1.VirtualMachineBulkOperationOptions) (*v1.VirtualMachineBulkOperationResult, error)
 }
 
 type VirtualMachineInstanceMigrationInterface interface {
@@ -228,4 +283,18 @@ type KubeVirtInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.KubeVirt, err error)
 	UpdateStatus(*v1.KubeVirt) (*v1.KubeVirt, error)
 	PatchStatus(name string, pt types.PatchType, data []byte) (result *v1.KubeVirt, err error)
+	ValidateConfiguration(name string, kubevirt *v1.KubeVirt) (*v1.KubeVirtConfigurationValidation, error)
+	Profile(name string, node string, duration time.Duration) (io.ReadCloser, error)
+}
+
+// VirtualMachineTemplateInterface provides convenience methods to work with
+// VirtualMachineTemplates and to process them into VirtualMachines
+type VirtualMachineTemplateInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*templatev1alpha1.VirtualMachineTemplate, error)
+	List(opts *k8smetav1.ListOptions) (*templatev1alpha1.VirtualMachineTemplateList, error)
+	Create(*templatev1alpha1.VirtualMachineTemplate) (*templatev1alpha1.VirtualMachineTemplate, error)
+	Update(*templatev1alpha1.VirtualMachineTemplate) (*templatev1alpha1.VirtualMachineTemplate, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *templatev1alpha1.VirtualMachineTemplate, err error)
+	Process(name string, options *templatev1alpha1.ProcessOptions) (*v1.VirtualMachine, error)
 }
@@ -20,6 +20,12 @@
 package kubecli
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -28,6 +34,8 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 )
 
+const kvSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/kubevirts/%s/%s"
+
 func (k *kubevirt) KubeVirt(namespace string) KubeVirtInterface {
 	return &kv{
 		restClient: k.restClient,
@@ -158,3 +166,34 @@ func (v *kv) UpdateStatus(vmi *v1.KubeVirt) (result *v1.KubeVirt, err error) {
 	result.SetGroupVersionKind(v1.KubeVirtGroupVersionKind)
 	return
 }
+
+// ValidateConfiguration reports the workloads depending on the settings which
+// the configuration of the given KubeVirt removes, without applying it
+func (v *kv) ValidateConfiguration(name string, kubevirt *v1.KubeVirt) (*v1.KubeVirtConfigurationValidation, error) {
+	validation := &v1.KubeVirtConfigurationValidation{}
+	uri := fmt.Sprintf(kvSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "validate-configuration")
+	body, err := json.Marshal(kubevirt)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot Marshal to json: %s", err)
+	}
+	raw, err := v.restClient.Put().RequestURI(uri).Body(body).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, validation); err != nil {
+		return nil, err
+	}
+	return validation, nil
+}
+
+// Profile collects the profiles of virt-handler and of the virt-launchers on
+// the node for the duration, and returns them as a gzipped tar archive
+func (v *kv) Profile(name string, node string, duration time.Duration) (io.ReadCloser, error) {
+	uri := fmt.Sprintf(kvSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "profile")
+	return v.restClient.Get().
+		RequestURI(uri).
+		Param("node", node).
+		Param("seconds", strconv.Itoa(int(duration.Seconds()))).
+		SetHeader("Accept", "application/gzip").
+		Stream()
+}
@@ -20,7 +20,10 @@
 package kubecli
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -30,6 +33,8 @@ import (
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
 )
 
 var _ = Describe("Kubevirt Client", func() {
@@ -139,6 +144,43 @@ var _ = Describe("Kubevirt Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should validate a KubeVirt configuration", func() {
+		kubevirt := NewMinimalKubeVirt("testkubevirt")
+		validation := &v1.KubeVirtConfigurationValidation{
+			Impacts: []v1.KubeVirtConfigurationImpact{
+				{Message: "GPU feature gate is not enabled in kubevirt-config: 1 VMI depends on the current setting", VirtualMachineInstances: []string{"default/testvmi"}},
+			},
+		}
+		body, err := json.Marshal(kubevirt)
+		Expect(err).ToNot(HaveOccurred())
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/kubevirts/testkubevirt/validate-configuration"),
+			ghttp.VerifyBody(body),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, validation),
+		))
+		result, err := client.KubeVirt(k8sv1.NamespaceDefault).ValidateConfiguration("testkubevirt", kubevirt)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(validation))
+	})
+
+	It("should stream the profiles of a node", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/kubevirts/testkubevirt/profile", "node=node01&seconds=10"),
+			ghttp.VerifyHeaderKV("Accept", "application/gzip"),
+			ghttp.RespondWith(http.StatusOK, "bundle"),
+		))
+		stream, err := client.KubeVirt(k8sv1.NamespaceDefault).Profile("testkubevirt", "node01", 10*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		defer stream.Close()
+
+		bundle, err := ioutil.ReadAll(stream)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(bundle)).To(Equal("bundle"))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
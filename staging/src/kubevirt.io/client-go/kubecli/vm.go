@@ -31,7 +31,10 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 )
 
-const vmSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachines/%s/%s"
+const (
+	vmSubresourceURL   = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachines/%s/%s"
+	vmBulkOperationURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachinebulkoperations/%s"
+)
 
 func (k *kubevirt) VirtualMachine(namespace string) VirtualMachineInterface {
 	return &vm{
@@ -189,6 +192,11 @@ func (v *vm) Stop(name string) error {
 	return v.restClient.Put().RequestURI(uri).Do().Error()
 }
 
+func (v *vm) Hibernate(name string) error {
+	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "hibernate")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
 func (v *vm) Migrate(name string) error {
 	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "migrate")
 	return v.restClient.Put().RequestURI(uri).Do().Error()
@@ -229,3 +237,45 @@ func (v *vm) RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptio
 
 	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do().Error()
 }
+
+// DomainXML returns the libvirt domain XML the VM would be started with
+func (v *vm) DomainXML(name string) (string, error) {
+	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "domainxml")
+	domainXML, err := v.restClient.Get().RequestURI(uri).Do().Raw()
+	return string(domainXML), err
+}
+
+// ValidateStart runs the checks a start of the VM has to pass and returns what would block it
+func (v *vm) ValidateStart(name string) (*v1.VirtualMachineStartValidation, error) {
+	validation := &v1.VirtualMachineStartValidation{}
+	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "validate-start")
+	raw, err := v.restClient.Get().RequestURI(uri).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, validation); err != nil {
+		return nil, err
+	}
+	return validation, nil
+}
+
+// Bulk applies the operation to all VMs of the namespace matching the label selector of the options
+// and returns what it was applied to
+func (v *vm) Bulk(operation v1.VirtualMachineBulkOperation, options *v1.VirtualMachineBulkOperationOptions) (*v1.VirtualMachineBulkOperationResult, error) {
+	uri := fmt.Sprintf(vmBulkOperationURL, v1.ApiStorageVersion, v.namespace, operation)
+
+	JSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1.VirtualMachineBulkOperationResult{}
+	raw, err := v.restClient.Put().RequestURI(uri).SetHeader("Content-Type", "application/json").Body(JSON).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -176,6 +176,17 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should hibernate a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/hibernate"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).Hibernate("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	It("should migrate a VirtualMachine", func() {
 		server.AppendHandlers(ghttp.CombineHandlers(
 			ghttp.VerifyRequest("PUT", subVMIPath+"/migrate"),
@@ -201,6 +212,67 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should fetch the domain XML a VM would be started with", func() {
+		domainXML := "<domain type=\"kvm\"><name>default_testvm</name></domain>"
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", subVMIPath+"/domainxml"),
+				ghttp.RespondWith(http.StatusOK, domainXML, http.Header{"Content-Type": []string{"application/xml"}}),
+			),
+		)
+
+		fetchedXML, err := client.VirtualMachine(k8sv1.NamespaceDefault).DomainXML("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedXML).To(Equal(domainXML))
+	})
+
+	It("should fetch what would block the start of a VM", func() {
+		validation := &virtv1.VirtualMachineStartValidation{
+			Blockers: []virtv1.VirtualMachineStartBlocker{
+				{Check: virtv1.StartCheckStorage, Message: "PersistentVolumeClaim disk of volume rootdisk does not exist"},
+			},
+		}
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", subVMIPath+"/validate-start"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, validation),
+			),
+		)
+
+		fetchedValidation, err := client.VirtualMachine(k8sv1.NamespaceDefault).ValidateStart("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedValidation).To(Equal(validation))
+	})
+
+	It("should apply an operation to all VMs matching a label selector", func() {
+		options := &virtv1.VirtualMachineBulkOperationOptions{LabelSelector: "app=web", MaxConcurrency: 5}
+		result := &virtv1.VirtualMachineBulkOperationResult{
+			Operation: virtv1.BulkStartOperation,
+			Matched:   2,
+			Succeeded: []string{"web1"},
+			Skipped: []virtv1.VirtualMachineBulkOperationMessage{
+				{Name: "web2", Message: "VM is already running"},
+			},
+		}
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", fmt.Sprintf("/apis/subresources.kubevirt.io/%s/namespaces/default/virtualmachinebulkoperations/start", virtv1.SubresourceStorageGroupVersion.Version)),
+				ghttp.VerifyJSONRepresenting(options),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, result),
+			),
+		)
+
+		fetchedResult, err := client.VirtualMachine(k8sv1.NamespaceDefault).Bulk(virtv1.BulkStartOperation, options)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedResult).To(Equal(result))
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -179,6 +179,16 @@ func (v *vmis) VNC(name string) (StreamInterface, error) {
 	return v.asyncSubresourceHelper(name, "vnc")
 }
 
+// Channel connects to the unix socket behind the virtio-serial channel of the VMI with the given name
+func (v *vmis) Channel(name string, channel string) (StreamInterface, error) {
+	return v.asyncSubresourceHelper(name, "channel/"+channel)
+}
+
+// HypervisorLog streams the qemu log and the libvirt lifecycle events of the VMI with the given name as JSON lines
+func (v *vmis) HypervisorLog(name string) (StreamInterface, error) {
+	return v.asyncSubresourceHelper(name, "hypervisorlog")
+}
+
 type connectionStruct struct {
 	con StreamInterface
 	err error
@@ -316,6 +326,16 @@ func (v *vmis) Unpause(name string) error {
 	return v.restClient.Put().RequestURI(uri).Do().Error()
 }
 
+func (v *vmis) Freeze(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "freeze")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
+func (v *vmis) Unfreeze(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "unfreeze")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
 func (v *vmis) Get(name string, options *k8smetav1.GetOptions) (vmi *v1.VirtualMachineInstance, err error) {
 	vmi = &v1.VirtualMachineInstance{}
 	err = v.restClient.Get().
@@ -495,3 +515,31 @@ func (v *vmis) RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOpt
 
 	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do().Error()
 }
+
+// DomainXML returns the libvirt domain XML the VMI is rendered to
+func (v *vmis) DomainXML(name string) (string, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "domainxml")
+	domainXML, err := v.restClient.Get().RequestURI(uri).Do().Raw()
+	return string(domainXML), err
+}
+
+// QMPCommand executes a read-only QMP command on the qemu monitor of the VMI and returns its JSON result
+func (v *vmis) QMPCommand(name string, command string) (string, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "qmp")
+	result, err := v.restClient.Get().RequestURI(uri).Param("command", command).Do().Raw()
+	return string(result), err
+}
+
+// Stats returns the resource usage counters of the VMI
+func (v *vmis) Stats(name string) (*v1.VirtualMachineInstanceStats, error) {
+	stats := &v1.VirtualMachineInstanceStats{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "stats")
+	raw, err := v.restClient.Get().RequestURI(uri).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -292,6 +292,44 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(fetchedInfo).To(Equal(fileSystemList), "fetched info should be the same as passed in")
 	})
 
+	It("should fetch the domain XML of a VirtualMachineInstance via subresource", func() {
+		domainXML := "<domain type=\"kvm\"><name>default_testvm</name></domain>"
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/domainxml"),
+			ghttp.RespondWith(http.StatusOK, domainXML, http.Header{"Content-Type": []string{"application/xml"}}),
+		))
+		fetchedXML, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).DomainXML("testvm")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedXML).To(Equal(domainXML))
+	})
+
+	It("should execute a QMP command on a VirtualMachineInstance via subresource", func() {
+		result := `{"running":true,"singlestep":false,"status":"running"}`
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/qmp", "command=query-status"),
+			ghttp.RespondWith(http.StatusOK, result, http.Header{"Content-Type": []string{"application/json"}}),
+		))
+		fetchedResult, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).QMPCommand("testvm", "query-status")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedResult).To(Equal(result))
+	})
+
+	It("should fetch the stats of a VirtualMachineInstance via subresource", func() {
+		stats := v1.VirtualMachineInstanceStats{VCPUs: 2, CPUTimeNanoseconds: 1500000000, NetworkReceiveBytes: 1024}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/stats"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, stats),
+		))
+		fetchedStats, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Stats("testvm")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedStats.VCPUs).To(Equal(int64(2)))
+		Expect(fetchedStats.CPUTimeNanoseconds).To(Equal(int64(1500000000)))
+		Expect(fetchedStats.NetworkReceiveBytes).To(Equal(int64(1024)))
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -0,0 +1,114 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) VirtualMachineNotificationHook(namespace string) VirtualMachineNotificationHookInterface {
+	return &vmNotificationHooks{k.restClient, namespace, "virtualmachinenotificationhooks"}
+}
+
+type vmNotificationHooks struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+func (v *vmNotificationHooks) Get(name string, options *k8smetav1.GetOptions) (hook *v1.VirtualMachineNotificationHook, err error) {
+	hook = &v1.VirtualMachineNotificationHook{}
+	err = v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(hook)
+	hook.SetGroupVersionKind(v1.VirtualMachineNotificationHookGroupVersionKind)
+	return
+}
+
+func (v *vmNotificationHooks) List(options *k8smetav1.ListOptions) (hookList *v1.VirtualMachineNotificationHookList, err error) {
+	hookList = &v1.VirtualMachineNotificationHookList{}
+	err = v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(hookList)
+	for i := range hookList.Items {
+		hookList.Items[i].SetGroupVersionKind(v1.VirtualMachineNotificationHookGroupVersionKind)
+	}
+	return
+}
+
+func (v *vmNotificationHooks) Create(hook *v1.VirtualMachineNotificationHook) (result *v1.VirtualMachineNotificationHook, err error) {
+	result = &v1.VirtualMachineNotificationHook{}
+	err = v.restClient.Post().
+		Namespace(v.namespace).
+		Resource(v.resource).
+		Body(hook).
+		Do().
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineNotificationHookGroupVersionKind)
+	return
+}
+
+func (v *vmNotificationHooks) Update(hook *v1.VirtualMachineNotificationHook) (result *v1.VirtualMachineNotificationHook, err error) {
+	result = &v1.VirtualMachineNotificationHook{}
+	err = v.restClient.Put().
+		Name(hook.ObjectMeta.Name).
+		Namespace(v.namespace).
+		Resource(v.resource).
+		Body(hook).
+		Do().
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineNotificationHookGroupVersionKind)
+	return
+}
+
+func (v *vmNotificationHooks) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	return v.restClient.Delete().
+		Namespace(v.namespace).
+		Resource(v.resource).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+func (v *vmNotificationHooks) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineNotificationHook, err error) {
+	result = &v1.VirtualMachineNotificationHook{}
+	err = v.restClient.Patch(pt).
+		Namespace(v.namespace).
+		Resource(v.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineNotificationHookGroupVersionKind)
+	return
+}
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Kubevirt VirtualMachineNotificationHook Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachinenotificationhooks"
+	hookPath := basePath + "/testhook"
+
+	newHook := func() *v1.VirtualMachineNotificationHook {
+		return &v1.VirtualMachineNotificationHook{
+			TypeMeta:   k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineNotificationHook"},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "testhook", Namespace: k8sv1.NamespaceDefault},
+			Spec: v1.VirtualMachineNotificationHookSpec{
+				URL:    "https://cmdb.example.com/kubevirt",
+				Events: []v1.VirtualMachineNotificationEvent{v1.NotificationEventStarted},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a VirtualMachineNotificationHook", func() {
+		hook := newHook()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", hookPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, hook),
+		))
+		fetchedHook, err := client.VirtualMachineNotificationHook(k8sv1.NamespaceDefault).Get("testhook", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedHook).To(Equal(hook))
+	})
+
+	It("should detect non existent VirtualMachineNotificationHooks", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", hookPath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testhook")),
+		))
+		_, err := client.VirtualMachineNotificationHook(k8sv1.NamespaceDefault).Get("testhook", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue(), "Expected an IsNotFound error to have occurred")
+	})
+
+	It("should fetch a VirtualMachineNotificationHook list", func() {
+		hook := newHook()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.VirtualMachineNotificationHookList{Items: []v1.VirtualMachineNotificationHook{*hook}}),
+		))
+		fetchedHookList, err := client.VirtualMachineNotificationHook(k8sv1.NamespaceDefault).List(&k8smetav1.ListOptions{})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(fetchedHookList.Items).To(HaveLen(1))
+		Expect(fetchedHookList.Items[0]).To(Equal(*hook))
+	})
+
+	It("should create a VirtualMachineNotificationHook", func() {
+		hook := newHook()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, hook),
+		))
+		createdHook, err := client.VirtualMachineNotificationHook(k8sv1.NamespaceDefault).Create(hook)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdHook).To(Equal(hook))
+	})
+
+	It("should update a VirtualMachineNotificationHook", func() {
+		hook := newHook()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", hookPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, hook),
+		))
+		updatedHook, err := client.VirtualMachineNotificationHook(k8sv1.NamespaceDefault).Update(hook)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedHook).To(Equal(hook))
+	})
+
+	It("should delete a VirtualMachineNotificationHook", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", hookPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineNotificationHook(k8sv1.NamespaceDefault).Delete("testhook", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
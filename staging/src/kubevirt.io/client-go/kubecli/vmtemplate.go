@@ -0,0 +1,89 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	templatev1alpha1 "kubevirt.io/client-go/apis/template/v1alpha1"
+	generatedtemplatev1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/template/v1alpha1"
+)
+
+const vmTemplateSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachinetemplates/%s/%s"
+
+func (k *kubevirt) VirtualMachineTemplate(namespace string) VirtualMachineTemplateInterface {
+	return &vmTemplate{
+		restClient: k.restClient,
+		templates:  k.generatedKubeVirtClient.TemplateV1alpha1().VirtualMachineTemplates(namespace),
+		namespace:  namespace,
+	}
+}
+
+type vmTemplate struct {
+	restClient *rest.RESTClient
+	templates  generatedtemplatev1alpha1.VirtualMachineTemplateInterface
+	namespace  string
+}
+
+func (t *vmTemplate) Get(name string, options *k8smetav1.GetOptions) (*templatev1alpha1.VirtualMachineTemplate, error) {
+	return t.templates.Get(name, *options)
+}
+
+func (t *vmTemplate) List(options *k8smetav1.ListOptions) (*templatev1alpha1.VirtualMachineTemplateList, error) {
+	return t.templates.List(*options)
+}
+
+func (t *vmTemplate) Create(template *templatev1alpha1.VirtualMachineTemplate) (*templatev1alpha1.VirtualMachineTemplate, error) {
+	return t.templates.Create(template)
+}
+
+func (t *vmTemplate) Update(template *templatev1alpha1.VirtualMachineTemplate) (*templatev1alpha1.VirtualMachineTemplate, error) {
+	return t.templates.Update(template)
+}
+
+func (t *vmTemplate) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	return t.templates.Delete(name, options)
+}
+
+func (t *vmTemplate) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*templatev1alpha1.VirtualMachineTemplate, error) {
+	return t.templates.Patch(name, pt, data, subresources...)
+}
+
+// Process returns the VirtualMachine of the template with the parameters
+// substituted, the VirtualMachine is not created
+func (t *vmTemplate) Process(name string, options *templatev1alpha1.ProcessOptions) (*v1.VirtualMachine, error) {
+	uri := fmt.Sprintf(vmTemplateSubresourceURL, v1.ApiStorageVersion, t.namespace, name, "process")
+
+	body, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot Marshal to json: %s", err)
+	}
+
+	vm := &v1.VirtualMachine{}
+	err = t.restClient.Put().RequestURI(uri).Body(body).Do().Into(vm)
+	vm.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+	return vm, err
+}
@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	templatev1 "kubevirt.io/client-go/apis/template/v1alpha1"
+)
+
+var _ = Describe("Kubevirt VirtualMachineTemplate Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	templatePath := "/apis/template.kubevirt.io/v1alpha1/namespaces/default/virtualmachinetemplates/fedora"
+	subTemplatePath := fmt.Sprintf("/apis/subresources.kubevirt.io/%s/namespaces/default/virtualmachinetemplates/fedora", virtv1.SubresourceStorageGroupVersion.Version)
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a VirtualMachineTemplate", func() {
+		template := &templatev1.VirtualMachineTemplate{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "fedora", Namespace: k8sv1.NamespaceDefault},
+			Spec: templatev1.VirtualMachineTemplateSpec{
+				DisplayName: "Fedora",
+				Parameters:  []templatev1.TemplateParameter{{Name: "NAME", Type: templatev1.ParameterName}},
+			},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", templatePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, template),
+		))
+		fetched, err := client.VirtualMachineTemplate(k8sv1.NamespaceDefault).Get("fedora", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetched.Spec.DisplayName).To(Equal("Fedora"))
+		Expect(fetched.Spec.Parameters).To(Equal(template.Spec.Parameters))
+	})
+
+	It("should process a VirtualMachineTemplate", func() {
+		vm := NewMinimalVM("fedora01")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subTemplatePath+"/process"),
+			func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				Expect(err).ToNot(HaveOccurred())
+				options := &templatev1.ProcessOptions{}
+				Expect(json.Unmarshal(body, options)).To(Succeed())
+				Expect(options.Parameters).To(HaveKeyWithValue("NAME", "fedora01"))
+			},
+			ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+		))
+		processed, err := client.VirtualMachineTemplate(k8sv1.NamespaceDefault).Process("fedora", &templatev1.ProcessOptions{
+			Parameters: map[string]string{"NAME": "fedora01"},
+		})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(processed.Name).To(Equal("fedora01"))
+		Expect(processed.Kind).To(Equal("VirtualMachine"))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})